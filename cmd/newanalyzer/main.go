@@ -0,0 +1,260 @@
+// Copyright 2022 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// The newanalyzer command creates the skeleton of a new analysis pass.
+//
+// Usage:
+//
+//	newanalyzer [-dir directory] [-pkg importpath] name description
+//
+// It creates a package with the given name in the directory (by
+// default, ./name) containing:
+//
+//	doc.go                  the package documentation and the Doc constant
+//	name.go                 an Analyzer whose Run function reports calls to println
+//	name_test.go            a test that runs the Analyzer with analysistest
+//	testdata/src/a/a.go     a test package with a "// want" comment
+//	cmd/name/main.go        a command that runs the Analyzer with singlechecker
+//
+// The description is a phrase such as "report calls to println", used
+// as the first line of the Analyzer's documentation.
+//
+// The -pkg flag gives the import path of the new package. By default
+// it is derived from the go.mod file of the enclosing module.
+//
+// The newanalyzer command never overwrites an existing file.
+package main
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"fmt"
+	"go/format"
+	"go/token"
+	"io/ioutil"
+	"log"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"text/template"
+
+	"golang.org/x/mod/modfile"
+)
+
+func usage() {
+	fmt.Fprintf(os.Stderr, "usage: newanalyzer [-dir directory] [-pkg importpath] name description\n")
+	flag.PrintDefaults()
+	os.Exit(2)
+}
+
+func main() {
+	log.SetFlags(0)
+	log.SetPrefix("newanalyzer: ")
+
+	dir := flag.String("dir", "", "directory of the new package (default ./name)")
+	pkg := flag.String("pkg", "", "import path of the new package (default derived from go.mod)")
+	flag.Usage = usage
+	flag.Parse()
+	if flag.NArg() != 2 {
+		usage()
+	}
+	name, description := flag.Arg(0), flag.Arg(1)
+
+	if *dir == "" {
+		*dir = name
+	}
+	if *pkg == "" {
+		var err error
+		*pkg, err = importPath(*dir)
+		if err != nil {
+			log.Fatalf("%v; use -pkg to specify the import path", err)
+		}
+	}
+	if err := generate(*dir, *pkg, name, description); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// importPath returns the import path of the package in dir, which
+// need not exist, by finding the go.mod file of the enclosing module.
+func importPath(dir string) (string, error) {
+	abs, err := filepath.Abs(dir)
+	if err != nil {
+		return "", err
+	}
+	for d := abs; ; d = filepath.Dir(d) {
+		data, err := ioutil.ReadFile(filepath.Join(d, "go.mod"))
+		if err == nil {
+			modPath := modfile.ModulePath(data)
+			if modPath == "" {
+				return "", fmt.Errorf("no module path in %s", filepath.Join(d, "go.mod"))
+			}
+			rel, err := filepath.Rel(d, abs)
+			if err != nil {
+				return "", err
+			}
+			return path.Join(modPath, filepath.ToSlash(rel)), nil
+		}
+		if !os.IsNotExist(err) {
+			return "", err
+		}
+		if filepath.Dir(d) == d {
+			return "", fmt.Errorf("no go.mod file in %s or any parent directory", abs)
+		}
+	}
+}
+
+// A file is a file created by newanalyzer.
+type file struct {
+	name string // slash-separated name relative to the package directory
+	text *template.Template
+}
+
+// data is the data to which the templates are applied.
+type data struct {
+	Name        string // package name
+	Pkg         string // import path
+	Description string // first line of the Doc
+}
+
+// generate writes the files of a new analysis pass with the given
+// package name and import path to dir.
+func generate(dir, pkg, name, description string) error {
+	if !token.IsIdentifier(name) || token.IsKeyword(name) || strings.ToLower(name) != name {
+		return fmt.Errorf("invalid analyzer name %q: must be a lower-case Go identifier", name)
+	}
+	if base := path.Base(pkg); base != name {
+		return fmt.Errorf("import path %s does not end in %s", pkg, name)
+	}
+	description = strings.TrimSpace(description)
+	if description == "" || strings.ContainsAny(description, "\n`") {
+		return errors.New("description must be a single non-empty line without backquotes")
+	}
+	d := data{
+		Name:        name,
+		Pkg:         pkg,
+		Description: strings.TrimSuffix(description, "."),
+	}
+
+	// Render all files before writing any, and refuse to
+	// overwrite existing ones.
+	contents := make(map[string][]byte)
+	for _, f := range files(name) {
+		var buf bytes.Buffer
+		if err := f.text.Execute(&buf, d); err != nil {
+			return fmt.Errorf("executing template for %s: %v", f.name, err)
+		}
+		content := buf.Bytes()
+		if strings.HasSuffix(f.name, ".go") {
+			src, err := format.Source(content)
+			if err != nil {
+				return fmt.Errorf("formatting %s: %v", f.name, err)
+			}
+			content = src
+		}
+		filename := filepath.Join(dir, filepath.FromSlash(f.name))
+		if _, err := os.Stat(filename); err == nil {
+			return fmt.Errorf("%s already exists", filename)
+		}
+		contents[filename] = content
+	}
+	for filename, content := range contents {
+		if err := os.MkdirAll(filepath.Dir(filename), 0777); err != nil {
+			return err
+		}
+		if err := ioutil.WriteFile(filename, content, 0666); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// files returns the files of a new analysis pass with the given name.
+func files(name string) []file {
+	return []file{
+		{"doc.go", docTemplate},
+		{name + ".go", analyzerTemplate},
+		{name + "_test.go", testTemplate},
+		{"testdata/src/a/a.go", testdataTemplate},
+		{"cmd/" + name + "/main.go", mainTemplate},
+	}
+}
+
+var docTemplate = template.Must(template.New("doc").Parse(`// Package {{.Name}} defines an Analyzer to {{.Description}}.
+package {{.Name}}
+
+const Doc = ` + "`" + `{{.Description}}
+
+TODO: describe the problem reported by the {{.Name}} analyzer,
+with an example of code that it reports.` + "`" + `
+`))
+
+var analyzerTemplate = template.Must(template.New("analyzer").Parse(`package {{.Name}}
+
+import (
+	"go/ast"
+
+	"github.com/iansmith/golang-x-tools/go/analysis"
+	"github.com/iansmith/golang-x-tools/go/analysis/passes/inspect"
+	"github.com/iansmith/golang-x-tools/go/ast/inspector"
+)
+
+var Analyzer = &analysis.Analyzer{
+	Name:     "{{.Name}}",
+	Doc:      Doc,
+	Requires: []*analysis.Analyzer{inspect.Analyzer},
+	Run:      run,
+}
+
+func run(pass *analysis.Pass) (interface{}, error) {
+	inspect := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+
+	// TODO: replace this example, which reports calls to println.
+	nodeFilter := []ast.Node{
+		(*ast.CallExpr)(nil),
+	}
+	inspect.Preorder(nodeFilter, func(n ast.Node) {
+		call := n.(*ast.CallExpr)
+		if id, ok := call.Fun.(*ast.Ident); ok && id.Name == "println" {
+			pass.ReportRangef(call, "call of println")
+		}
+	})
+	return nil, nil
+}
+`))
+
+var testTemplate = template.Must(template.New("test").Parse(`package {{.Name}}_test
+
+import (
+	"testing"
+
+	"github.com/iansmith/golang-x-tools/go/analysis/analysistest"
+	"{{.Pkg}}"
+)
+
+func Test(t *testing.T) {
+	testdata := analysistest.TestData()
+	analysistest.Run(t, testdata, {{.Name}}.Analyzer, "a")
+}
+`))
+
+var testdataTemplate = template.Must(template.New("testdata").Parse(`package a
+
+func f() {
+	println("hello") // want "call of println"
+}
+`))
+
+var mainTemplate = template.Must(template.New("main").Parse(`// The {{.Name}} command runs the {{.Name}} analyzer.
+package main
+
+import (
+	"{{.Pkg}}"
+	"github.com/iansmith/golang-x-tools/go/analysis/singlechecker"
+)
+
+func main() { singlechecker.Main({{.Name}}.Analyzer) }
+`))
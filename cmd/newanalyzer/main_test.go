@@ -0,0 +1,88 @@
+// Copyright 2022 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"go/parser"
+	"go/token"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestGenerate(t *testing.T) {
+	root := t.TempDir()
+	if err := ioutil.WriteFile(filepath.Join(root, "go.mod"), []byte("module example.com/m\n"), 0666); err != nil {
+		t.Fatal(err)
+	}
+	dir := filepath.Join(root, "passes", "nopanic")
+	pkg, err := importPath(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "example.com/m/passes/nopanic"; pkg != want {
+		t.Errorf("importPath(%s) = %s, want %s", dir, pkg, want)
+	}
+	if err := generate(dir, pkg, "nopanic", "report calls to panic."); err != nil {
+		t.Fatal(err)
+	}
+
+	fset := token.NewFileSet()
+	for _, f := range files("nopanic") {
+		filename := filepath.Join(dir, filepath.FromSlash(f.name))
+		file, err := parser.ParseFile(fset, filename, nil, parser.ParseComments)
+		if err != nil {
+			t.Errorf("generated file does not parse: %v", err)
+			continue
+		}
+		for _, imp := range file.Imports {
+			if path, _ := strconv.Unquote(imp.Path.Value); strings.HasPrefix(path, "example.com/") && path != pkg {
+				t.Errorf("%s imports %s, want %s", f.name, path, pkg)
+			}
+		}
+	}
+
+	doc, err := ioutil.ReadFile(filepath.Join(dir, "doc.go"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{
+		"// Package nopanic defines an Analyzer to report calls to panic.\n",
+		"const Doc = `report calls to panic\n",
+	} {
+		if !strings.Contains(string(doc), want) {
+			t.Errorf("doc.go does not contain %q:\n%s", want, doc)
+		}
+	}
+
+	// A second run must not overwrite the files.
+	if err := generate(dir, pkg, "nopanic", "report calls to panic"); err == nil || !strings.Contains(err.Error(), "already exists") {
+		t.Errorf("second generate returned %v, want already exists error", err)
+	}
+}
+
+func TestGenerateErrors(t *testing.T) {
+	for _, test := range []struct {
+		pkg, name, description, want string
+	}{
+		{"example.com/Bad", "Bad", "report things", "invalid analyzer name"},
+		{"example.com/type", "type", "report things", "invalid analyzer name"},
+		{"example.com/other", "good", "report things", "does not end in good"},
+		{"example.com/good", "good", "  ", "description must be"},
+		{"example.com/good", "good", "report `x`", "description must be"},
+	} {
+		dir := filepath.Join(t.TempDir(), test.name)
+		err := generate(dir, test.pkg, test.name, test.description)
+		if err == nil || !strings.Contains(err.Error(), test.want) {
+			t.Errorf("generate(%q, %q, %q) = %v, want error containing %q", test.pkg, test.name, test.description, err, test.want)
+		}
+		if _, err := os.Stat(dir); !os.IsNotExist(err) {
+			t.Errorf("generate(%q, %q, %q) created %s", test.pkg, test.name, test.description, dir)
+		}
+	}
+}
@@ -0,0 +1,342 @@
+// Copyright 2022 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package convertrange defines an Analyzer that reports integer
+// conversions that may overflow the range of the destination type.
+package convertrange
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+
+	"github.com/iansmith/golang-x-tools/go/analysis"
+	"github.com/iansmith/golang-x-tools/go/analysis/passes/inspect"
+	"github.com/iansmith/golang-x-tools/go/analysis/passes/internal/analysisutil"
+	"github.com/iansmith/golang-x-tools/go/ast/inspector"
+	"github.com/iansmith/golang-x-tools/go/types/typeutil"
+	"github.com/iansmith/golang-x-tools/internal/typeparams"
+)
+
+const Doc = `check for integer conversions that may overflow
+
+A conversion between integer types silently truncates or changes the
+sign of values outside the range of the destination type. This is a
+frequent source of bugs when the value comes from outside the program:
+
+	n := binary.BigEndian.Uint64(hdr)
+	buf := make([]byte, int(n)) // n may exceed math.MaxInt64
+
+This checker reports conversions to a narrower integer type of values
+that are derived from lengths, slice indexes, or input decoded by the
+encoding/binary and strconv packages, unless the value is compared with
+another value elsewhere in the function, which is taken to be a bounds
+check. The types int, uint, and uintptr are
+assumed to have 64 bits, so for example the conversion int32(len(b)) is
+reported because it may overflow on 64-bit platforms.
+
+The -all flag causes every non-constant conversion that may overflow to
+be reported, whatever the origin of the value.`
+
+var Analyzer = &analysis.Analyzer{
+	Name:     "convertrange",
+	Doc:      Doc,
+	Requires: []*analysis.Analyzer{inspect.Analyzer},
+	Run:      run,
+}
+
+// flags
+var all = false
+
+func init() {
+	Analyzer.Flags.BoolVar(&all, "all", all, "report all conversions that may overflow, not just those of lengths and external input")
+}
+
+// An origin describes where an integer value comes from.
+type origin int
+
+const (
+	unknown origin = iota
+	length         // result of len or cap, or a count of bytes read
+	index          // index of a range loop
+	decoded        // decoded by encoding/binary
+	parsed         // parsed by strconv
+)
+
+func (o origin) String() string {
+	switch o {
+	case length:
+		return "length"
+	case index:
+		return "index"
+	case decoded:
+		return "decoded value"
+	case parsed:
+		return "parsed value"
+	}
+	return "value"
+}
+
+func run(pass *analysis.Pass) (interface{}, error) {
+	inspect := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+
+	nodeFilter := []ast.Node{
+		(*ast.FuncDecl)(nil),
+		(*ast.FuncLit)(nil),
+	}
+	inspect.Preorder(nodeFilter, func(n ast.Node) {
+		var body *ast.BlockStmt
+		switch n := n.(type) {
+		case *ast.FuncDecl:
+			body = n.Body
+		case *ast.FuncLit:
+			body = n.Body
+		}
+		if body != nil {
+			checkFunc(pass, body)
+		}
+	})
+	return nil, nil
+}
+
+// checkFunc checks the conversions within a function body, except
+// those in nested function literals, which are checked separately.
+func checkFunc(pass *analysis.Pass, body *ast.BlockStmt) {
+	origins := make(map[types.Object]origin)
+	guarded := make(map[types.Object]bool)
+
+	// Record the origins of variables, and the variables that are
+	// compared with another value, in source order.
+	ast.Inspect(body, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.AssignStmt:
+			recordAssign(pass, origins, n.Lhs, n.Rhs)
+		case *ast.ValueSpec:
+			lhs := make([]ast.Expr, len(n.Names))
+			for i, name := range n.Names {
+				lhs[i] = name
+			}
+			recordAssign(pass, origins, lhs, n.Values)
+		case *ast.RangeStmt:
+			switch typeparams.CoreType(pass.TypesInfo.TypeOf(n.X)).(type) {
+			case *types.Slice, *types.Array, *types.Pointer, *types.Basic:
+				if id, ok := n.Key.(*ast.Ident); ok {
+					if obj := pass.TypesInfo.ObjectOf(id); obj != nil {
+						origins[obj] = index
+					}
+				}
+			}
+		case *ast.BinaryExpr:
+			switch n.Op {
+			case token.LSS, token.LEQ, token.GTR, token.GEQ:
+				for _, x := range []ast.Expr{n.X, n.Y} {
+					if obj := rootVar(pass.TypesInfo, x); obj != nil {
+						guarded[obj] = true
+					}
+				}
+			}
+		}
+		return true
+	})
+
+	ast.Inspect(body, func(n ast.Node) bool {
+		if _, ok := n.(*ast.FuncLit); ok {
+			return false // checked separately
+		}
+		call, ok := n.(*ast.CallExpr)
+		if !ok || len(call.Args) != 1 {
+			return true
+		}
+		tv, ok := pass.TypesInfo.Types[call.Fun]
+		if !ok || !tv.IsType() {
+			return true
+		}
+		arg := call.Args[0]
+		argTV, ok := pass.TypesInfo.Types[arg]
+		if !ok || argTV.Value != nil {
+			return true // constant conversions are checked by the compiler
+		}
+		dst, src := intType(tv.Type), intType(argTV.Type)
+		if dst == nil || src == nil {
+			return true
+		}
+		o := exprOrigin(pass, origins, arg)
+		if obj := rootVar(pass.TypesInfo, arg); obj != nil && guarded[obj] {
+			return true
+		}
+		if o == unknown && !all {
+			return true
+		}
+		if fits(src, o, dst) {
+			return true
+		}
+		what := analysisutil.Format(pass.Fset, arg)
+		if o != unknown {
+			what = o.String() + " " + what
+		}
+		pass.ReportRangef(call, "conversion of %s from %s to %s may overflow", what, argTV.Type, tv.Type)
+		return true
+	})
+}
+
+// recordAssign records the origins of the variables assigned by lhs = rhs.
+func recordAssign(pass *analysis.Pass, origins map[types.Object]origin, lhs, rhs []ast.Expr) {
+	record := func(x ast.Expr, o origin) {
+		id, ok := x.(*ast.Ident)
+		if !ok || o == unknown {
+			return
+		}
+		if obj := pass.TypesInfo.ObjectOf(id); obj != nil && origins[obj] == unknown {
+			origins[obj] = o
+		}
+	}
+	if len(lhs) == len(rhs) {
+		for i := range lhs {
+			record(lhs[i], exprOrigin(pass, origins, rhs[i]))
+		}
+		return
+	}
+	if len(rhs) == 1 && len(lhs) > 0 {
+		// v, err := f(...)
+		if call, ok := analysisutil.Unparen(rhs[0]).(*ast.CallExpr); ok {
+			record(lhs[0], callOrigin(pass, call))
+		}
+	}
+}
+
+// exprOrigin returns the origin of the value of the integer
+// expression e, or unknown.
+func exprOrigin(pass *analysis.Pass, origins map[types.Object]origin, e ast.Expr) origin {
+	switch e := analysisutil.Unparen(e).(type) {
+	case *ast.Ident:
+		if obj := pass.TypesInfo.Uses[e]; obj != nil {
+			return origins[obj]
+		}
+	case *ast.BinaryExpr:
+		switch e.Op {
+		case token.ADD, token.SUB, token.MUL, token.SHL, token.OR, token.XOR:
+			if o := exprOrigin(pass, origins, e.X); o != unknown {
+				return o
+			}
+			return exprOrigin(pass, origins, e.Y)
+		}
+	case *ast.CallExpr:
+		if tv, ok := pass.TypesInfo.Types[e.Fun]; ok && tv.IsType() && len(e.Args) == 1 {
+			return exprOrigin(pass, origins, e.Args[0])
+		}
+		return callOrigin(pass, e)
+	}
+	return unknown
+}
+
+// callOrigin returns the origin of the (first) result of call.
+func callOrigin(pass *analysis.Pass, call *ast.CallExpr) origin {
+	switch fn := typeutil.Callee(pass.TypesInfo, call).(type) {
+	case *types.Builtin:
+		switch fn.Name() {
+		case "len", "cap":
+			return length
+		}
+	case *types.Func:
+		if fn.Pkg() == nil {
+			return unknown
+		}
+		switch fn.Pkg().Path() {
+		case "encoding/binary":
+			switch fn.Name() {
+			case "Uint16", "Uint32", "Uint64", "Uvarint", "Varint", "ReadUvarint", "ReadVarint":
+				return decoded
+			}
+		case "strconv":
+			switch fn.Name() {
+			case "Atoi":
+				return parsed
+			case "ParseInt", "ParseUint":
+				// A constant bitSize less than 64 bounds the result.
+				if len(call.Args) == 3 {
+					if tv := pass.TypesInfo.Types[call.Args[2]]; tv.Value != nil && tv.Value.String() != "0" && tv.Value.String() != "64" {
+						return unknown
+					}
+				}
+				return parsed
+			}
+		case "io":
+			switch fn.Name() {
+			case "Read", "ReadAtLeast", "ReadFull", "Copy", "CopyN", "CopyBuffer":
+				return length
+			}
+		}
+	}
+	return unknown
+}
+
+// rootVar returns the variable denoted by e, a possibly
+// converted or parenthesized identifier, or nil.
+func rootVar(info *types.Info, e ast.Expr) types.Object {
+	for {
+		switch x := analysisutil.Unparen(e).(type) {
+		case *ast.Ident:
+			if v, ok := info.Uses[x].(*types.Var); ok {
+				return v
+			}
+			return nil
+		case *ast.CallExpr:
+			if tv, ok := info.Types[x.Fun]; ok && tv.IsType() && len(x.Args) == 1 {
+				e = x.Args[0]
+				continue
+			}
+		}
+		return nil
+	}
+}
+
+// An intInfo describes the range of an integer type.
+type intInfo struct {
+	bits     int
+	unsigned bool
+}
+
+// intType returns the range of the integer type t, or nil if t is not
+// an integer type. The platform-dependent types are assumed to have
+// 64 bits.
+func intType(t types.Type) *intInfo {
+	if typeparams.IsTypeParam(t) {
+		return nil
+	}
+	b, ok := t.Underlying().(*types.Basic)
+	if !ok || b.Info()&types.IsInteger == 0 {
+		return nil
+	}
+	unsigned := b.Info()&types.IsUnsigned != 0
+	switch b.Kind() {
+	case types.Int8, types.Uint8:
+		return &intInfo{8, unsigned}
+	case types.Int16, types.Uint16:
+		return &intInfo{16, unsigned}
+	case types.Int32, types.Uint32:
+		return &intInfo{32, unsigned}
+	case types.Int, types.Uint, types.Uintptr, types.Int64, types.Uint64:
+		return &intInfo{64, unsigned}
+	}
+	return nil
+}
+
+// fits reports whether every value of src with the given origin is
+// representable in dst.
+func fits(src *intInfo, o origin, dst *intInfo) bool {
+	bits, unsigned := src.bits, src.unsigned
+	if (o == length || o == index) && !unsigned {
+		// Lengths and indexes are never negative.
+		bits, unsigned = bits-1, true
+	}
+	switch {
+	case unsigned && dst.unsigned:
+		return dst.bits >= bits
+	case unsigned && !dst.unsigned:
+		return dst.bits > bits
+	case !unsigned && !dst.unsigned:
+		return dst.bits >= bits
+	}
+	return false // signed to unsigned
+}
@@ -0,0 +1,24 @@
+// Copyright 2022 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package convertrange_test
+
+import (
+	"testing"
+
+	"github.com/iansmith/golang-x-tools/go/analysis/analysistest"
+	"github.com/iansmith/golang-x-tools/go/analysis/passes/convertrange"
+)
+
+func Test(t *testing.T) {
+	testdata := analysistest.TestData()
+	analysistest.Run(t, testdata, convertrange.Analyzer, "a")
+}
+
+func TestAll(t *testing.T) {
+	testdata := analysistest.TestData()
+	convertrange.Analyzer.Flags.Set("all", "true")
+	defer convertrange.Analyzer.Flags.Set("all", "false")
+	analysistest.Run(t, testdata, convertrange.Analyzer, "b")
+}
@@ -0,0 +1,54 @@
+package a
+
+import (
+	"encoding/binary"
+	"io"
+	"strconv"
+)
+
+func lengths(b []byte, s string) {
+	_ = int32(len(b))  // want `conversion of length len\(b\) from int to int32 may overflow`
+	_ = uint32(len(s)) // want `conversion of length len\(s\) from int to uint32 may overflow`
+	_ = int64(len(b))
+	_ = uint64(len(b))
+	_ = uint(cap(b))
+	n := len(b) + 1
+	_ = uint16(n) // want `conversion of length n from int to uint16 may overflow`
+	for i := range b {
+		_ = int8(i) // want `conversion of index i from int to int8 may overflow`
+		_ = uint64(i)
+	}
+}
+
+func decode(hdr []byte, r io.ByteReader) {
+	n := binary.BigEndian.Uint64(hdr)
+	_ = make([]byte, int(n)) // want `conversion of decoded value n from uint64 to int may overflow`
+	_ = uint64(n)
+	_ = int32(binary.LittleEndian.Uint32(hdr)) // want `conversion of decoded value binary.LittleEndian.Uint32\(hdr\) from uint32 to int32 may overflow`
+	_ = int64(binary.LittleEndian.Uint32(hdr))
+	v, err := binary.ReadUvarint(r)
+	if err != nil {
+		return
+	}
+	_ = int(v) // want `conversion of decoded value v from uint64 to int may overflow`
+
+	m := binary.BigEndian.Uint64(hdr)
+	if m > 1<<20 {
+		return
+	}
+	_ = int(m) // bounds checked
+}
+
+func parse(s string) {
+	i, _ := strconv.Atoi(s)
+	_ = int32(i) // want `conversion of parsed value i from int to int32 may overflow`
+	u, _ := strconv.ParseUint(s, 10, 64)
+	_ = int(u) // want `conversion of parsed value u from uint64 to int may overflow`
+	w, _ := strconv.ParseInt(s, 10, 32)
+	_ = int32(w)
+	var x int64 = 1
+	_ = int32(x) // origin unknown
+	_ = func() {
+		_ = int16(len(s)) // want `conversion of length len\(s\) from int to int16 may overflow`
+	}
+}
@@ -0,0 +1,19 @@
+package b
+
+func _(x int64, y uint, z int8) {
+	_ = int32(x) // want `conversion of x from int64 to int32 may overflow`
+	_ = int(y)   // want `conversion of y from uint to int may overflow`
+	_ = uint8(z) // want `conversion of z from int8 to uint8 may overflow`
+	_ = int64(z)
+	_ = int32(1 << 20)
+}
+
+func _(x int64) {
+	if x < 100 {
+		_ = int8(x)
+	}
+}
+
+func _[T ~int64](v T) {
+	_ = int32(v)
+}
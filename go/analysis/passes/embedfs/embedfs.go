@@ -0,0 +1,484 @@
+// Copyright 2022 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package embedfs defines an Analyzer that checks //go:embed directives
+// against the variables they apply to and the files they embed.
+package embedfs
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/iansmith/golang-x-tools/go/analysis"
+)
+
+const Doc = `check //go:embed directives against the file tree
+
+The embedfs analyzer reports //go:embed directives that the go command
+would reject when building the package, including:
+
+  - directives that do not immediately precede a package-level
+    variable declaration, or that appear in a file that does not
+    import the embed package;
+  - variables whose type is not string, []byte, or embed.FS, or that
+    have an initializer, and string or []byte variables that would
+    receive more than one file;
+  - patterns that are malformed, that match no files, or that match
+    only files the go command will not embed, such as files in another
+    module, irregular files, or directories containing nothing but
+    hidden files.
+
+Directives in files excluded from the build by build constraints are
+checked too, so that a pattern that is broken only on another platform
+is still reported. Conversely, a pattern that embeds Go source files
+the build excludes from the package is reported, as that usually means
+the pattern is broader than intended.`
+
+var Analyzer = &analysis.Analyzer{
+	Name:             "embedfs",
+	Doc:              Doc,
+	Run:              run,
+	RunDespiteErrors: true,
+}
+
+func run(pass *analysis.Pass) (interface{}, error) {
+	ignored := make(map[string]bool)
+	for _, name := range pass.IgnoredFiles {
+		if abs, err := filepath.Abs(name); err == nil {
+			ignored[abs] = true
+		}
+	}
+	for _, f := range pass.Files {
+		checkFile(pass, f, ignored, true)
+	}
+	for _, name := range pass.IgnoredFiles {
+		if !strings.HasSuffix(name, ".go") {
+			continue
+		}
+		f, err := parser.ParseFile(pass.Fset, name, nil, parser.ParseComments)
+		if err != nil {
+			// Not valid Go source code - not our job to diagnose, so ignore.
+			continue
+		}
+		checkFile(pass, f, ignored, false)
+	}
+	return nil, nil
+}
+
+// A directive is a //go:embed comment.
+type directive struct {
+	comment *ast.Comment
+	args    string    // text following "//go:embed"
+	pos     token.Pos // position of args
+}
+
+// checkFile checks the //go:embed directives of f. Type information
+// is available only if typed is set; otherwise f is a file excluded
+// from the build, and only the patterns are checked.
+func checkFile(pass *analysis.Pass, f *ast.File, ignored map[string]bool, typed bool) {
+	directives := make(map[*ast.Comment]*directive)
+	var order []*directive
+	for _, g := range f.Comments {
+		for _, c := range g.List {
+			if d := parseDirective(c); d != nil {
+				directives[c] = d
+				order = append(order, d)
+			}
+		}
+	}
+	if len(order) == 0 {
+		return
+	}
+
+	if !importsEmbed(f) {
+		d := order[0]
+		pass.ReportRangef(d.comment, `//go:embed directive requires import "embed"`)
+	}
+
+	// Match directives to the variable declarations that follow them.
+	dir, err := filepath.Abs(filepath.Dir(pass.Fset.File(f.Pos()).Name()))
+	if err != nil {
+		return
+	}
+	placed := make(map[*directive]bool)
+	ast.Inspect(f, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.DeclStmt:
+			if decl, ok := n.Decl.(*ast.GenDecl); ok {
+				checkDecl(pass, decl, directives, placed, dir, ignored, typed, true)
+			}
+			return false
+		case *ast.GenDecl:
+			checkDecl(pass, n, directives, placed, dir, ignored, typed, false)
+			return false
+		}
+		return true
+	})
+
+	for _, d := range order {
+		if !placed[d] {
+			pass.ReportRangef(d.comment, "misplaced //go:embed directive: must precede a var declaration")
+		}
+	}
+}
+
+// checkDecl checks the directives attached to the specs of a
+// declaration, marking them as placed.
+func checkDecl(pass *analysis.Pass, decl *ast.GenDecl, directives map[*ast.Comment]*directive, placed map[*directive]bool, dir string, ignored map[string]bool, typed, local bool) {
+	if decl.Tok != token.VAR {
+		return
+	}
+	for i, spec := range decl.Specs {
+		vs := spec.(*ast.ValueSpec)
+		doc := vs.Doc
+		if i == 0 && !decl.Lparen.IsValid() {
+			doc = decl.Doc
+		}
+		if doc == nil {
+			continue
+		}
+		var list []*directive
+		for _, c := range doc.List {
+			if d := directives[c]; d != nil {
+				placed[d] = true
+				list = append(list, d)
+			}
+		}
+		if len(list) == 0 {
+			continue
+		}
+		checkSpec(pass, vs, list, dir, ignored, typed, local)
+	}
+}
+
+// embedKind describes the type of a variable initialized by //go:embed.
+type embedKind int
+
+const (
+	embedUnknown embedKind = iota
+	embedBad
+	embedString
+	embedBytes
+	embedFS
+)
+
+// checkSpec checks the directives applied to a variable specification.
+func checkSpec(pass *analysis.Pass, vs *ast.ValueSpec, list []*directive, dir string, ignored map[string]bool, typed, local bool) {
+	first := list[0].comment
+	switch {
+	case local:
+		pass.ReportRangef(first, "//go:embed cannot apply to var inside func")
+		return
+	case len(vs.Names) > 1:
+		pass.ReportRangef(first, "//go:embed cannot apply to multiple vars")
+		return
+	case len(vs.Values) > 0:
+		pass.ReportRangef(first, "//go:embed cannot apply to var with initializer")
+		return
+	}
+
+	kind := embedUnknown
+	if typed {
+		if obj, ok := pass.TypesInfo.Defs[vs.Names[0]].(*types.Var); ok {
+			kind = kindOf(obj.Type())
+			if kind == embedBad {
+				pass.ReportRangef(vs.Type, "//go:embed cannot apply to var of type %s; want string, []byte, or embed.FS", obj.Type())
+				return
+			}
+		}
+	}
+
+	var pats []pattern
+	for _, d := range list {
+		ps, err := parsePatterns(d.args, d.pos)
+		if err != nil {
+			pass.ReportRangef(d.comment, "%v", err)
+			return
+		}
+		if len(ps) == 0 {
+			pass.ReportRangef(d.comment, "usage: //go:embed pattern...")
+			return
+		}
+		pats = append(pats, ps...)
+	}
+
+	seen := make(map[string]bool)
+	var files []string
+	for _, p := range pats {
+		list, ok := resolve(pass, p, dir, kind)
+		if !ok {
+			return
+		}
+		for _, file := range list {
+			if seen[file] {
+				continue
+			}
+			seen[file] = true
+			files = append(files, file)
+			if ignored[file] {
+				rel, _ := filepath.Rel(dir, file)
+				pass.Reportf(p.pos, "pattern %s embeds %s, which is excluded from the build by build constraints", p.text, filepath.ToSlash(rel))
+			}
+		}
+	}
+	if (kind == embedString || kind == embedBytes) && len(files) > 1 {
+		pass.ReportRangef(first, "//go:embed cannot embed multiple files into var of type %s", pass.TypesInfo.Defs[vs.Names[0]].Type())
+	}
+}
+
+// kindOf reports how a variable of type t is initialized by //go:embed.
+func kindOf(t types.Type) embedKind {
+	if named, ok := t.(*types.Named); ok {
+		obj := named.Obj()
+		if obj.Pkg() != nil && obj.Pkg().Path() == "embed" && obj.Name() == "FS" {
+			return embedFS
+		}
+	}
+	switch u := t.Underlying().(type) {
+	case *types.Basic:
+		if u.Info()&types.IsString != 0 {
+			return embedString
+		}
+	case *types.Slice:
+		if elem, ok := u.Elem().Underlying().(*types.Basic); ok && elem.Kind() == types.Byte {
+			return embedBytes
+		}
+	}
+	return embedBad
+}
+
+// A pattern is an argument of a //go:embed directive.
+type pattern struct {
+	text string
+	pos  token.Pos
+}
+
+// resolve reports the errors that the go command would report for
+// pattern p, relative to directory dir, and returns the absolute names
+// of the regular files it embeds. It reports whether p is valid.
+func resolve(pass *analysis.Pass, p pattern, dir string, kind embedKind) ([]string, bool) {
+	glob := p.text
+	all := strings.HasPrefix(glob, "all:")
+	if all {
+		glob = glob[len("all:"):]
+	}
+	if _, err := path.Match(glob, ""); err != nil || !validPattern(glob) {
+		pass.Reportf(p.pos, "pattern %s: invalid pattern syntax", p.text)
+		return nil, false
+	}
+	matches, err := filepath.Glob(filepath.Join(dir, filepath.FromSlash(glob)))
+	if err != nil {
+		pass.Reportf(p.pos, "pattern %s: %v", p.text, err)
+		return nil, false
+	}
+
+	var files []string
+	for _, match := range matches {
+		rel := filepath.ToSlash(match[len(dir)+1:])
+		info, err := os.Lstat(match)
+		if err != nil {
+			pass.Reportf(p.pos, "pattern %s: %v", p.text, err)
+			return nil, false
+		}
+		what := "file"
+		if info.IsDir() {
+			what = "directory"
+		}
+		if msg := checkPath(dir, match, rel, what); msg != "" {
+			pass.Reportf(p.pos, "pattern %s: %s", p.text, msg)
+			return nil, false
+		}
+		switch {
+		case info.Mode().IsRegular():
+			files = append(files, match)
+		case info.IsDir():
+			if kind == embedString || kind == embedBytes {
+				pass.Reportf(p.pos, "pattern %s: cannot embed directory %s into a value of type string or []byte", p.text, rel)
+				return nil, false
+			}
+			list, err := walkDir(match, all)
+			if err != nil {
+				pass.Reportf(p.pos, "pattern %s: %v", p.text, err)
+				return nil, false
+			}
+			if len(list) == 0 {
+				pass.Reportf(p.pos, "pattern %s: cannot embed directory %s: contains no embeddable files", p.text, rel)
+				return nil, false
+			}
+			files = append(files, list...)
+		default:
+			pass.Reportf(p.pos, "pattern %s: cannot embed irregular file %s", p.text, rel)
+			return nil, false
+		}
+	}
+	if len(files) == 0 {
+		pass.Reportf(p.pos, "pattern %s: no matching files found", p.text)
+		return nil, false
+	}
+	return files, true
+}
+
+// checkPath returns a description of the reason the go command would
+// refuse to embed file, whose name relative to dir is rel, or "".
+func checkPath(dir, file, rel, what string) string {
+	for d := file; len(d) > len(dir)+1; d = filepath.Dir(d) {
+		if _, err := os.Stat(filepath.Join(d, "go.mod")); err == nil {
+			return fmt.Sprintf("cannot embed %s %s: in different module", what, rel)
+		}
+		if elem := filepath.Base(d); isBadName(elem) {
+			if d == file {
+				return fmt.Sprintf("cannot embed %s %s: invalid name %s", what, rel, elem)
+			}
+			return fmt.Sprintf("cannot embed %s %s: in invalid directory %s", what, rel, elem)
+		}
+	}
+	return ""
+}
+
+// walkDir returns the files the go command would embed for the
+// directory root: the regular files beneath it, excluding those with
+// invalid names, those in other modules, and, unless all is set,
+// those whose names begin with '.' or '_'.
+func walkDir(root string, all bool) ([]string, error) {
+	var files []string
+	err := filepath.Walk(root, func(file string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		name := info.Name()
+		if file != root && (isBadName(name) || ((name[0] == '.' || name[0] == '_') && !all)) {
+			if info.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if info.IsDir() {
+			if _, err := os.Stat(filepath.Join(file, "go.mod")); err == nil && file != root {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if info.Mode().IsRegular() {
+			files = append(files, file)
+		}
+		return nil
+	})
+	return files, err
+}
+
+// validPattern reports whether pattern is a valid //go:embed pattern,
+// as in cmd/go.
+func validPattern(pattern string) bool {
+	return pattern != "." && fs.ValidPath(pattern)
+}
+
+// isBadName reports whether name is the base name of a file that
+// the go command refuses to embed, as in cmd/go.
+func isBadName(name string) bool {
+	switch name {
+	case "", ".bzr", ".hg", ".git", ".svn":
+		return true
+	}
+	return false
+}
+
+// parseDirective returns the //go:embed directive in comment c, or nil.
+func parseDirective(c *ast.Comment) *directive {
+	const prefix = "//go:embed"
+	if !strings.HasPrefix(c.Text, prefix) {
+		return nil
+	}
+	args := c.Text[len(prefix):]
+	if args != "" && args[0] != ' ' && args[0] != '\t' {
+		return nil // e.g. //go:embedded
+	}
+	return &directive{
+		comment: c,
+		args:    args,
+		pos:     c.Slash + token.Pos(len(prefix)),
+	}
+}
+
+// parsePatterns parses the arguments of a //go:embed directive, which
+// starts at pos. It accepts unquoted space-separated patterns as well
+// as double-quoted and back-quoted Go strings, as in go/build.
+func parsePatterns(args string, pos token.Pos) ([]pattern, error) {
+	var list []pattern
+	offset := 0
+	for {
+		trim := strings.TrimLeftFunc(args[offset:], unicode.IsSpace)
+		offset = len(args) - len(trim)
+		if trim == "" {
+			break
+		}
+		var text string
+		start := offset
+		switch trim[0] {
+		default:
+			i := strings.IndexFunc(trim, unicode.IsSpace)
+			if i < 0 {
+				i = len(trim)
+			}
+			text = trim[:i]
+			offset += i
+
+		case '`':
+			i := strings.Index(trim[1:], "`")
+			if i < 0 {
+				return nil, fmt.Errorf("invalid quoted string in //go:embed: %s", trim)
+			}
+			text = trim[1 : 1+i]
+			offset += 1 + i + 1
+
+		case '"':
+			i := 1
+			for ; i < len(trim); i++ {
+				if trim[i] == '\\' {
+					i++
+					continue
+				}
+				if trim[i] == '"' {
+					break
+				}
+			}
+			if i >= len(trim) {
+				return nil, fmt.Errorf("invalid quoted string in //go:embed: %s", trim)
+			}
+			q, err := strconv.Unquote(trim[:i+1])
+			if err != nil {
+				return nil, fmt.Errorf("invalid quoted string in //go:embed: %s", trim[:i+1])
+			}
+			text = q
+			offset += i + 1
+		}
+		if rest := args[offset:]; rest != "" {
+			if r, _ := utf8.DecodeRuneInString(rest); !unicode.IsSpace(r) {
+				return nil, fmt.Errorf("invalid quoted string in //go:embed: %s", trim)
+			}
+		}
+		list = append(list, pattern{text, pos + token.Pos(start)})
+	}
+	return list, nil
+}
+
+// importsEmbed reports whether f imports the embed package.
+func importsEmbed(f *ast.File) bool {
+	for _, imp := range f.Imports {
+		if imp.Path.Value == `"embed"` {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,27 @@
+// Copyright 2022 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package embedfs_test
+
+import (
+	"testing"
+
+	"github.com/iansmith/golang-x-tools/go/analysis"
+	"github.com/iansmith/golang-x-tools/go/analysis/analysistest"
+	"github.com/iansmith/golang-x-tools/go/analysis/passes/embedfs"
+)
+
+func Test(t *testing.T) {
+	analyzer := *embedfs.Analyzer
+	analyzer.Run = func(pass *analysis.Pass) (interface{}, error) {
+		defer func() {
+			// The embedfs pass checks the directives in IgnoredFiles.
+			// Add them to OtherFiles so that the test harness checks
+			// for expected diagnostics in those too.
+			pass.OtherFiles = append(pass.OtherFiles, pass.IgnoredFiles...)
+		}()
+		return embedfs.Analyzer.Run(pass)
+	}
+	analysistest.Run(t, analysistest.TestData(), &analyzer, "a")
+}
@@ -0,0 +1,109 @@
+// Copyright 2022 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package a
+
+import "embed"
+
+//go:embed hello.txt
+var hello string
+
+//go:embed hello.txt
+var helloBytes []byte
+
+type text string
+
+//go:embed hello.txt
+var helloText text
+
+//go:embed dir
+var dir embed.FS
+
+//go:embed hello.txt dir/*.txt "dir/sub/y.txt"
+var files embed.FS
+
+//go:embed all:hidden
+var allHidden embed.FS
+
+// want +2 `pattern nonexistent.txt: no matching files found`
+//
+//go:embed nonexistent.txt
+var missing string
+
+// want +2 "pattern \\*.md: no matching files found"
+//
+//go:embed hello.txt `*.md`
+var missingGlob embed.FS
+
+// want +2 "pattern hidden: cannot embed directory hidden: contains no embeddable files"
+//
+//go:embed hidden
+var hidden embed.FS
+
+// want +2 "pattern mod/data.txt: cannot embed file mod/data.txt: in different module"
+//
+//go:embed mod/data.txt
+var other string
+
+// want +2 "pattern ../a/hello.txt: invalid pattern syntax"
+//
+//go:embed ../a/hello.txt
+var parent string
+
+// want +2 "invalid quoted string in //go:embed"
+//
+//go:embed "hello.txt
+var unterminated string
+
+// want +2 "pattern dir: cannot embed directory dir into a value of type string or \\[\\]byte"
+//
+//go:embed dir
+var dirString string
+
+// want +2 "//go:embed cannot embed multiple files into var of type \\[\\]byte"
+//
+//go:embed hello.txt dir/x.txt
+var twoFiles []byte
+
+//go:embed hello.txt
+var wrong int // want "//go:embed cannot apply to var of type int; want string, \\[\\]byte, or embed.FS"
+
+//go:embed hello.txt
+var wrongSlice []string // want "//go:embed cannot apply to var of type \\[\\]string"
+
+// want +2 "//go:embed cannot apply to multiple vars"
+//
+//go:embed hello.txt
+var x, y string
+
+// want +2 "//go:embed cannot apply to var with initializer"
+//
+//go:embed hello.txt
+var initialized = "hello"
+
+// want +2 "misplaced //go:embed directive: must precede a var declaration"
+//
+//go:embed hello.txt
+type T struct{}
+
+var (
+	//go:embed hello.txt
+	grouped string
+
+	// want +1 "pattern missing.txt: no matching files found"
+	//go:embed missing.txt
+	groupedMissing string
+)
+
+func f() {
+	// want +1 "//go:embed cannot apply to var inside func"
+	//go:embed hello.txt
+	var local string
+	_ = local
+}
+
+// want +2 "pattern \\*.go embeds gen.go, which is excluded from the build by build constraints"
+//
+//go:embed *.go
+var sources embed.FS
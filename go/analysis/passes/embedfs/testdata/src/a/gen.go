@@ -0,0 +1,18 @@
+// Copyright 2022 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build ignore
+// +build ignore
+
+package main
+
+import _ "embed"
+
+//go:embed hello.txt
+var hello string
+
+// want +2 "pattern hello.md: no matching files found"
+//
+//go:embed hello.md
+var missing string
@@ -0,0 +1,10 @@
+// Copyright 2022 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package a
+
+// want +2 `//go:embed directive requires import "embed"`
+//
+//go:embed hello.txt
+var noImport string
@@ -0,0 +1,388 @@
+// Copyright 2022 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package httpclient defines an Analyzer that reports HTTP requests
+// made without a timeout or context in long-running programs.
+package httpclient
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"go/types"
+	"strconv"
+	"strings"
+
+	"github.com/iansmith/golang-x-tools/go/analysis"
+	"github.com/iansmith/golang-x-tools/go/analysis/passes/inspect"
+	"github.com/iansmith/golang-x-tools/go/analysis/passes/internal/analysisutil"
+	"github.com/iansmith/golang-x-tools/go/ast/inspector"
+	"github.com/iansmith/golang-x-tools/go/types/typeutil"
+)
+
+const Doc = `check for HTTP requests that may hang forever
+
+An http.Client whose Timeout field is not set, including the
+http.DefaultClient used by the functions http.Get, http.Head, http.Post,
+and http.PostForm, waits indefinitely for an unresponsive server. In a
+long-running program such as a server, requests that never complete
+accumulate until the program runs out of resources:
+
+	resp, err := http.Get(url) // may never return
+
+This checker reports http.Client composite literals that do not set a
+Timeout, and calls of the package-level request functions. It suggests
+fixes that set a Timeout or, where a context.Context is in scope, make
+the request with that context:
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+
+By default only commands and packages that serve HTTP are checked; use
+the -all flag to check every package.`
+
+var Analyzer = &analysis.Analyzer{
+	Name:     "httpclient",
+	Doc:      Doc,
+	Requires: []*analysis.Analyzer{inspect.Analyzer},
+	Run:      run,
+}
+
+// flags
+var all = false
+
+func init() {
+	Analyzer.Flags.BoolVar(&all, "all", all, "check all packages, not just commands and HTTP servers")
+}
+
+// defaultTimeout is the timeout set by suggested fixes.
+const defaultTimeout = "30 * time.Second"
+
+// requestFuncs maps each net/http function that uses http.DefaultClient
+// to its HTTP method, or "" if it has no context-aware equivalent.
+var requestFuncs = map[string]string{
+	"Get":      "MethodGet",
+	"Head":     "MethodHead",
+	"Post":     "MethodPost",
+	"PostForm": "",
+}
+
+// serverObjects are the net/http objects whose use marks a package as
+// an HTTP server.
+var serverObjects = map[string]bool{
+	"Handle":            true,
+	"HandleFunc":        true,
+	"ListenAndServe":    true,
+	"ListenAndServeTLS": true,
+	"ResponseWriter":    true,
+	"Serve":             true,
+	"ServeTLS":          true,
+	"Server":            true,
+}
+
+func run(pass *analysis.Pass) (interface{}, error) {
+	inspect := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+
+	// Fast path: if the package doesn't import net/http,
+	// skip the traversal.
+	if !analysisutil.Imports(pass.Pkg, "net/http") {
+		return nil, nil
+	}
+	if !all && !longRunning(pass) {
+		return nil, nil
+	}
+
+	nodeFilter := []ast.Node{
+		(*ast.CompositeLit)(nil),
+		(*ast.CallExpr)(nil),
+	}
+	inspect.WithStack(nodeFilter, func(n ast.Node, push bool, stack []ast.Node) bool {
+		if !push {
+			return true
+		}
+		file := stack[0].(*ast.File)
+		if strings.HasSuffix(pass.Fset.File(file.Pos()).Name(), "_test.go") {
+			return false
+		}
+		switch n := n.(type) {
+		case *ast.CompositeLit:
+			checkClientLit(pass, file, n)
+		case *ast.CallExpr:
+			checkRequestCall(pass, file, n, stack)
+		}
+		return true
+	})
+	return nil, nil
+}
+
+// longRunning reports whether the package is a command or an HTTP server.
+func longRunning(pass *analysis.Pass) bool {
+	if pass.Pkg.Name() == "main" {
+		return true
+	}
+	for _, obj := range pass.TypesInfo.Uses {
+		if obj.Pkg() != nil && obj.Pkg().Path() == "net/http" && obj.Parent() == obj.Pkg().Scope() && serverObjects[obj.Name()] {
+			return true
+		}
+	}
+	return false
+}
+
+// checkClientLit reports an http.Client literal without a Timeout.
+func checkClientLit(pass *analysis.Pass, file *ast.File, lit *ast.CompositeLit) {
+	if !isNamedType(pass.TypesInfo.TypeOf(lit), "net/http", "Client") {
+		return
+	}
+	for _, elt := range lit.Elts {
+		kv, ok := elt.(*ast.KeyValueExpr)
+		if !ok {
+			return // unkeyed literals are reported by the composites checker
+		}
+		if id, ok := kv.Key.(*ast.Ident); ok && id.Name == "Timeout" {
+			return
+		}
+	}
+
+	diag := analysis.Diagnostic{
+		Pos:     lit.Pos(),
+		End:     lit.End(),
+		Message: "http.Client has no Timeout; its requests may hang forever",
+	}
+	if timeName, importEdits, ok := timePackage(pass, file); ok {
+		field := "Timeout: " + strings.Replace(defaultTimeout, "time", timeName, 1)
+		var edit analysis.TextEdit
+		if len(lit.Elts) == 0 {
+			edit = analysis.TextEdit{Pos: lit.Lbrace + 1, End: lit.Rbrace, NewText: []byte(field)}
+		} else {
+			last := lit.Elts[len(lit.Elts)-1]
+			sep := ", "
+			if line(pass.Fset, last.End()) != line(pass.Fset, lit.Rbrace) {
+				sep = ",\n" + indent(pass.Fset, last.Pos())
+			}
+			edit = analysis.TextEdit{Pos: last.End(), End: last.End(), NewText: []byte(sep + field)}
+		}
+		diag.SuggestedFixes = []analysis.SuggestedFix{{
+			Message:   "Set a Timeout of " + defaultTimeout,
+			TextEdits: append([]analysis.TextEdit{edit}, importEdits...),
+		}}
+	}
+	pass.Report(diag)
+}
+
+// checkRequestCall reports a call of a package-level net/http function
+// that makes a request using http.DefaultClient.
+func checkRequestCall(pass *analysis.Pass, file *ast.File, call *ast.CallExpr, stack []ast.Node) {
+	fn, ok := typeutil.Callee(pass.TypesInfo, call).(*types.Func)
+	if !ok || fn.Pkg() == nil || fn.Pkg().Path() != "net/http" || fn.Type().(*types.Signature).Recv() != nil {
+		return
+	}
+	method, ok := requestFuncs[fn.Name()]
+	if !ok {
+		return
+	}
+	sel, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok {
+		return // dot import
+	}
+	httpName := analysisutil.Format(pass.Fset, sel.X)
+
+	diag := analysis.Diagnostic{
+		Pos:     call.Pos(),
+		End:     call.End(),
+		Message: fmt.Sprintf("http.%s uses http.DefaultClient, which has no timeout", fn.Name()),
+	}
+	if method != "" {
+		if fix, ok := contextFix(pass, call, stack, httpName, method); ok {
+			diag.SuggestedFixes = append(diag.SuggestedFixes, fix)
+		}
+	}
+	if timeName, importEdits, ok := timePackage(pass, file); ok {
+		client := fmt.Sprintf("(&%s.Client{Timeout: %s}).%s", httpName, strings.Replace(defaultTimeout, "time", timeName, 1), fn.Name())
+		diag.SuggestedFixes = append(diag.SuggestedFixes, analysis.SuggestedFix{
+			Message: "Use a client with a timeout of " + defaultTimeout,
+			TextEdits: append([]analysis.TextEdit{{
+				Pos:     sel.Pos(),
+				End:     sel.End(),
+				NewText: []byte(client),
+			}}, importEdits...),
+		})
+	}
+	pass.Report(diag)
+}
+
+// contextFix returns a fix that rewrites a statement of the form
+//
+//	resp, err := http.Get(url)
+//
+// to make the request with the context.Context of the enclosing function.
+func contextFix(pass *analysis.Pass, call *ast.CallExpr, stack []ast.Node, httpName, method string) (analysis.SuggestedFix, bool) {
+	if len(stack) < 3 {
+		return analysis.SuggestedFix{}, false
+	}
+	assign, ok := stack[len(stack)-2].(*ast.AssignStmt)
+	if !ok || assign.Tok != token.DEFINE || len(assign.Lhs) != 2 || len(assign.Rhs) != 1 {
+		return analysis.SuggestedFix{}, false
+	}
+	switch stack[len(stack)-3].(type) {
+	case *ast.BlockStmt, *ast.CaseClause, *ast.CommClause:
+	default:
+		return analysis.SuggestedFix{}, false // e.g. if statement init
+	}
+	// Both variables must be newly declared, since the rewritten
+	// statements declare them separately.
+	var names []string
+	for _, lhs := range assign.Lhs {
+		id, ok := lhs.(*ast.Ident)
+		if !ok || pass.TypesInfo.Defs[id] == nil {
+			return analysis.SuggestedFix{}, false
+		}
+		names = append(names, id.Name)
+	}
+	scope := pass.Pkg.Scope().Innermost(assign.Pos())
+	if scope == nil {
+		return analysis.SuggestedFix{}, false
+	}
+	if _, obj := scope.LookupParent("req", assign.Pos()); obj != nil {
+		return analysis.SuggestedFix{}, false
+	}
+	ctx := contextParam(pass, stack)
+	if ctx == "" {
+		return analysis.SuggestedFix{}, false
+	}
+
+	var args []string
+	for _, arg := range call.Args {
+		args = append(args, analysisutil.Format(pass.Fset, arg))
+	}
+	var body, do string
+	switch method {
+	case "MethodPost":
+		if len(args) != 3 {
+			return analysis.SuggestedFix{}, false
+		}
+		body = args[2]
+		do = fmt.Sprintf("req.Header.Set(\"Content-Type\", %s)\n", args[1])
+	default:
+		if len(args) != 1 {
+			return analysis.SuggestedFix{}, false
+		}
+		body = "nil"
+	}
+	resp, err := names[0], names[1]
+	do += fmt.Sprintf("%s, %s = %s.DefaultClient.Do(req)", resp, err, httpName)
+
+	in := indent(pass.Fset, assign.Pos())
+	var b strings.Builder
+	fmt.Fprintf(&b, "var %s *%s.Response\n", resp, httpName)
+	fmt.Fprintf(&b, "%sreq, %s := %s.NewRequestWithContext(%s, %s.%s, %s, %s)\n", in, err, httpName, ctx, httpName, method, args[0], body)
+	fmt.Fprintf(&b, "%sif %s == nil {\n", in, err)
+	for _, line := range strings.Split(do, "\n") {
+		fmt.Fprintf(&b, "%s\t%s\n", in, line)
+	}
+	fmt.Fprintf(&b, "%s}", in)
+	return analysis.SuggestedFix{
+		Message: "Make the request with " + ctx,
+		TextEdits: []analysis.TextEdit{{
+			Pos:     assign.Pos(),
+			End:     assign.End(),
+			NewText: []byte(b.String()),
+		}},
+	}, true
+}
+
+// contextParam returns the name of a context.Context parameter of the
+// innermost function enclosing the top of the stack, or "" if none.
+func contextParam(pass *analysis.Pass, stack []ast.Node) string {
+	for i := len(stack) - 1; i >= 0; i-- {
+		var ftype *ast.FuncType
+		switch n := stack[i].(type) {
+		case *ast.FuncDecl:
+			ftype = n.Type
+		case *ast.FuncLit:
+			ftype = n.Type
+		default:
+			continue
+		}
+		for _, field := range ftype.Params.List {
+			if !isNamedType(pass.TypesInfo.TypeOf(field.Type), "context", "Context") {
+				continue
+			}
+			for _, name := range field.Names {
+				if name.Name != "_" {
+					return name.Name
+				}
+			}
+		}
+		return ""
+	}
+	return ""
+}
+
+// timePackage returns the name by which file refers to the time
+// package, along with the edits needed to import it if it does not.
+func timePackage(pass *analysis.Pass, file *ast.File) (string, []analysis.TextEdit, bool) {
+	var httpSpec *ast.ImportSpec
+	for _, spec := range file.Imports {
+		path, _ := strconv.Unquote(spec.Path.Value)
+		switch path {
+		case "time":
+			if spec.Name == nil {
+				return "time", nil, true
+			}
+			if spec.Name.Name == "_" || spec.Name.Name == "." {
+				return "", nil, false
+			}
+			return spec.Name.Name, nil, true
+		case "net/http":
+			httpSpec = spec
+		}
+	}
+	if httpSpec == nil {
+		return "", nil, false
+	}
+	// Add the import after net/http.
+	for _, decl := range file.Decls {
+		gen, ok := decl.(*ast.GenDecl)
+		if !ok || gen.Tok != token.IMPORT {
+			continue
+		}
+		for _, spec := range gen.Specs {
+			if spec != httpSpec {
+				continue
+			}
+			if gen.Lparen.IsValid() {
+				edit := analysis.TextEdit{Pos: spec.End(), End: spec.End(), NewText: []byte("\n\t\"time\"")}
+				return "time", []analysis.TextEdit{edit}, true
+			}
+			// Turn "import "net/http"" into a parenthesized declaration.
+			text := httpSpec.Path.Value
+			if httpSpec.Name != nil {
+				text = httpSpec.Name.Name + " " + text
+			}
+			edit := analysis.TextEdit{Pos: gen.Pos(), End: gen.End(), NewText: []byte("import (\n\t" + text + "\n\t\"time\"\n)")}
+			return "time", []analysis.TextEdit{edit}, true
+		}
+	}
+	return "", nil, false
+}
+
+// line returns the line number of pos.
+func line(fset *token.FileSet, pos token.Pos) int {
+	return fset.Position(pos).Line
+}
+
+// indent returns the indentation of the line containing pos, assuming
+// the file is gofmt-formatted and pos is the first token on its line.
+func indent(fset *token.FileSet, pos token.Pos) string {
+	return strings.Repeat("\t", fset.Position(pos).Column-1)
+}
+
+// isNamedType reports whether t is the named type path.name.
+func isNamedType(t types.Type, path, name string) bool {
+	n, ok := t.(*types.Named)
+	if !ok {
+		return false
+	}
+	obj := n.Obj()
+	return obj.Name() == name && obj.Pkg() != nil && obj.Pkg().Path() == path
+}
@@ -0,0 +1,46 @@
+package a
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"time"
+)
+
+func handler(w http.ResponseWriter, r *http.Request) {}
+
+var client = &http.Client{} // want "http.Client has no Timeout"
+
+var keyed = http.Client{Transport: http.DefaultTransport} // want "http.Client has no Timeout"
+
+var multiline = &http.Client{ // want "http.Client has no Timeout"
+	Transport: http.DefaultTransport,
+}
+
+var ok = &http.Client{Timeout: time.Minute}
+
+func fetch(ctx context.Context, url string) error {
+	resp, err := http.Get(url) // want "http.Get uses http.DefaultClient, which has no timeout"
+	if err != nil {
+		return err
+	}
+	return resp.Body.Close()
+}
+
+func post(ctx context.Context, url string) error {
+	resp, err := http.Post(url, "text/plain", strings.NewReader("hi")) // want "http.Post uses http.DefaultClient, which has no timeout"
+	if err != nil {
+		return err
+	}
+	return resp.Body.Close()
+}
+
+func noContext(url string) {
+	http.Head(url) // want "http.Head uses http.DefaultClient, which has no timeout"
+}
+
+func form(ctx context.Context, url string) {
+	if _, err := http.PostForm(url, nil); err != nil { // want "http.PostForm uses http.DefaultClient, which has no timeout"
+		panic(err)
+	}
+}
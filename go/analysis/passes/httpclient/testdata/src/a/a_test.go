@@ -0,0 +1,5 @@
+package a
+
+import "net/http"
+
+var testClient = &http.Client{}
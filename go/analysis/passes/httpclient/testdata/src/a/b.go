@@ -0,0 +1,7 @@
+package a
+
+import "net/http"
+
+func newClient() *http.Client {
+	return &http.Client{} // want "http.Client has no Timeout"
+}
@@ -0,0 +1,9 @@
+package lib
+
+import "net/http"
+
+var client = &http.Client{}
+
+func fetch(url string) {
+	http.Get(url)
+}
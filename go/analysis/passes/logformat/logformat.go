@@ -0,0 +1,351 @@
+// Copyright 2022 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package logformat defines an Analyzer that checks the key-value
+// arguments of calls to structured logging functions.
+package logformat
+
+import (
+	"fmt"
+	"go/ast"
+	"go/types"
+	"sort"
+	"strings"
+
+	"github.com/iansmith/golang-x-tools/go/analysis"
+	"github.com/iansmith/golang-x-tools/go/analysis/passes/inspect"
+	"github.com/iansmith/golang-x-tools/go/analysis/passes/internal/analysisutil"
+	"github.com/iansmith/golang-x-tools/go/ast/inspector"
+	"github.com/iansmith/golang-x-tools/go/types/typeutil"
+	"github.com/iansmith/golang-x-tools/internal/aliases"
+)
+
+func init() {
+	Analyzer.Flags.Var(isKV, "funcs", "comma-separated list of additional key-value logging functions")
+}
+
+var Analyzer = &analysis.Analyzer{
+	Name:      "logformat",
+	Doc:       Doc,
+	Requires:  []*analysis.Analyzer{inspect.Analyzer},
+	Run:       run,
+	FactTypes: []analysis.Fact{new(isWrapper)},
+}
+
+const Doc = `check key-value arguments of structured logging calls
+
+Structured loggers such as log/slog, zap's SugaredLogger, and logr accept
+a final list of alternating keys and values:
+
+	logger.Infow("request failed", "url", url, "attempt", n)
+
+This checker reports calls in which that list has a key with no value,
+a key that is not a string, or an error in a key position, which
+usually means that its key was forgotten:
+
+	logger.Errorw("request failed", err) // error used as a key
+
+Arguments of types that stand for a complete key-value pair, such as
+slog.Attr and zap.Field, are allowed anywhere in the list.
+
+The check applies to known functions as well as any detected wrappers
+of known functions, that is, functions that pass their own final
+...interface{} parameter on to a known function.
+
+The -funcs flag specifies a comma-separated list of names of additional
+functions or methods whose final variadic parameter is a key-value list.
+Each name must denote a specific function using one of the following
+forms:
+
+	dir/pkg.Function
+	dir/pkg.Type.Method
+	(*dir/pkg.Type).Method
+`
+
+// isWrapper is a fact indicating that a function passes its final
+// variadic parameter to a key-value logging function.
+type isWrapper struct{}
+
+func (f *isWrapper) AFact() {}
+
+func (f *isWrapper) String() string { return "kvWrapper" }
+
+func run(pass *analysis.Pass) (interface{}, error) {
+	findWrappers(pass)
+
+	inspect := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+	nodeFilter := []ast.Node{
+		(*ast.CallExpr)(nil),
+	}
+	inspect.Preorder(nodeFilter, func(n ast.Node) {
+		call := n.(*ast.CallExpr)
+		if fn := kvFunc(pass, call); fn != nil && !call.Ellipsis.IsValid() {
+			checkCall(pass, call, fn)
+		}
+	})
+	return nil, nil
+}
+
+// kvFunc returns the function called by call if it is a known key-value
+// logging function or a wrapper of one, or nil otherwise.
+func kvFunc(pass *analysis.Pass, call *ast.CallExpr) *types.Func {
+	fn, ok := typeutil.Callee(pass.TypesInfo, call).(*types.Func)
+	if !ok {
+		return nil
+	}
+	if sig := fn.Type().(*types.Signature); !sig.Variadic() {
+		return nil
+	}
+	if isKV[fn.FullName()] || pass.ImportObjectFact(fn, new(isWrapper)) {
+		return fn
+	}
+	return nil
+}
+
+// findWrappers exports an isWrapper fact for each function of the
+// package that forwards its final ...interface{} parameter to a known
+// key-value function or to another wrapper.
+func findWrappers(pass *analysis.Pass) {
+	type candidate struct {
+		fn   *types.Func
+		body *ast.BlockStmt
+		args *types.Var
+	}
+	var candidates []candidate
+	for _, file := range pass.Files {
+		for _, decl := range file.Decls {
+			fdecl, ok := decl.(*ast.FuncDecl)
+			if !ok || fdecl.Body == nil {
+				continue
+			}
+			fn, ok := pass.TypesInfo.Defs[fdecl.Name].(*types.Func)
+			if !ok {
+				continue
+			}
+			sig := fn.Type().(*types.Signature)
+			if !sig.Variadic() {
+				continue
+			}
+			args := sig.Params().At(sig.Params().Len() - 1)
+			if iface, ok := args.Type().(*types.Slice).Elem().Underlying().(*types.Interface); !ok || !iface.Empty() {
+				continue
+			}
+			candidates = append(candidates, candidate{fn, fdecl.Body, args})
+		}
+	}
+
+	// Iterate to a fixed point, since wrappers may call each other.
+	for changed := true; changed; {
+		changed = false
+		for _, c := range candidates {
+			if pass.ImportObjectFact(c.fn, new(isWrapper)) {
+				continue
+			}
+			forwards := false
+			ast.Inspect(c.body, func(n ast.Node) bool {
+				call, ok := n.(*ast.CallExpr)
+				if !ok || forwards || !call.Ellipsis.IsValid() || len(call.Args) == 0 {
+					return !forwards
+				}
+				last, ok := call.Args[len(call.Args)-1].(*ast.Ident)
+				if ok && pass.TypesInfo.Uses[last] == c.args && kvFunc(pass, call) != nil {
+					forwards = true
+				}
+				return !forwards
+			})
+			if forwards {
+				pass.ExportObjectFact(c.fn, new(isWrapper))
+				changed = true
+			}
+		}
+	}
+}
+
+// checkCall checks the key-value arguments of a call to fn.
+func checkCall(pass *analysis.Pass, call *ast.CallExpr, fn *types.Func) {
+	sig := fn.Type().(*types.Signature)
+	first := sig.Params().Len() - 1
+	if len(call.Args) <= first {
+		return
+	}
+	kvs := call.Args[first:]
+	for i := 0; i < len(kvs); i++ {
+		key := kvs[i]
+		t := pass.TypesInfo.TypeOf(key)
+		if t == nil || isPair(t) {
+			continue
+		}
+		if isError(t) {
+			diag := analysis.Diagnostic{
+				Pos:     key.Pos(),
+				End:     key.End(),
+				Message: fmt.Sprintf("error %s used as a key in call to %s", analysisutil.Format(pass.Fset, key), fn.FullName()),
+			}
+			if (len(kvs)-i)%2 == 1 {
+				// Supplying a key makes the rest of the list well formed.
+				diag.SuggestedFixes = []analysis.SuggestedFix{{
+					Message: `Add key "error"`,
+					TextEdits: []analysis.TextEdit{{
+						Pos:     key.Pos(),
+						End:     key.Pos(),
+						NewText: []byte(`"error", `),
+					}},
+				}}
+			}
+			pass.Report(diag)
+			return
+		}
+		if !maybeString(t) {
+			pass.ReportRangef(key, "key %s in call to %s is not a string", analysisutil.Format(pass.Fset, key), fn.FullName())
+			return
+		}
+		if i+1 == len(kvs) {
+			pass.ReportRangef(key, "missing value for key %s in call to %s", analysisutil.Format(pass.Fset, key), fn.FullName())
+			return
+		}
+		i++ // skip the value
+	}
+}
+
+var errorType = types.Universe.Lookup("error").Type().Underlying().(*types.Interface)
+
+// isError reports whether t is a concrete type or non-empty
+// interface that implements error.
+func isError(t types.Type) bool {
+	if iface, ok := t.Underlying().(*types.Interface); ok && iface.Empty() {
+		return false
+	}
+	return types.Implements(t, errorType)
+}
+
+// maybeString reports whether a value of type t may be a string.
+func maybeString(t types.Type) bool {
+	switch u := t.Underlying().(type) {
+	case *types.Basic:
+		return u.Info()&types.IsString != 0
+	case *types.Interface:
+		return true
+	}
+	return false
+}
+
+// isPair reports whether t is a type that stands for a complete
+// key-value pair. Aliases such as zap.Field are followed to the type
+// they denote.
+func isPair(t types.Type) bool {
+	named, ok := aliases.Unalias(t).(*types.Named)
+	if !ok || named.Obj().Pkg() == nil {
+		return false
+	}
+	return pairTypes[named.Obj().Pkg().Path()+"."+named.Obj().Name()]
+}
+
+// pairTypes records the types that stand for complete key-value pairs.
+var pairTypes = map[string]bool{
+	"log/slog.Attr":                 true,
+	"golang.org/x/exp/slog.Attr":    true,
+	"go.uber.org/zap/zapcore.Field": true,
+}
+
+// isKV records the key-value logging functions.
+//
+// Keys are values returned by (*types.Func).FullName.
+//
+// The -funcs flag adds to this set.
+var isKV = stringSet{
+	"(*go.uber.org/zap.SugaredLogger).DPanicw": true,
+	"(*go.uber.org/zap.SugaredLogger).Debugw":  true,
+	"(*go.uber.org/zap.SugaredLogger).Errorw":  true,
+	"(*go.uber.org/zap.SugaredLogger).Fatalw":  true,
+	"(*go.uber.org/zap.SugaredLogger).Infow":   true,
+	"(*go.uber.org/zap.SugaredLogger).Panicw":  true,
+	"(*go.uber.org/zap.SugaredLogger).Warnw":   true,
+	"(*go.uber.org/zap.SugaredLogger).With":    true,
+
+	"(github.com/go-logr/logr.Logger).Error":      true,
+	"(github.com/go-logr/logr.Logger).Info":       true,
+	"(github.com/go-logr/logr.Logger).WithValues": true,
+
+	"(*log/slog.Logger).Debug":        true,
+	"(*log/slog.Logger).DebugContext": true,
+	"(*log/slog.Logger).Error":        true,
+	"(*log/slog.Logger).ErrorContext": true,
+	"(*log/slog.Logger).Info":         true,
+	"(*log/slog.Logger).InfoContext":  true,
+	"(*log/slog.Logger).Log":          true,
+	"(*log/slog.Logger).Warn":         true,
+	"(*log/slog.Logger).WarnContext":  true,
+	"(*log/slog.Logger).With":         true,
+	"(*log/slog.Record).Add":          true,
+	"log/slog.Debug":                  true,
+	"log/slog.DebugContext":           true,
+	"log/slog.Error":                  true,
+	"log/slog.ErrorContext":           true,
+	"log/slog.Group":                  true,
+	"log/slog.Info":                   true,
+	"log/slog.InfoContext":            true,
+	"log/slog.Log":                    true,
+	"log/slog.Warn":                   true,
+	"log/slog.WarnContext":            true,
+	"log/slog.With":                   true,
+
+	"(*golang.org/x/exp/slog.Logger).Debug":        true,
+	"(*golang.org/x/exp/slog.Logger).DebugContext": true,
+	"(*golang.org/x/exp/slog.Logger).Error":        true,
+	"(*golang.org/x/exp/slog.Logger).ErrorContext": true,
+	"(*golang.org/x/exp/slog.Logger).Info":         true,
+	"(*golang.org/x/exp/slog.Logger).InfoContext":  true,
+	"(*golang.org/x/exp/slog.Logger).Log":          true,
+	"(*golang.org/x/exp/slog.Logger).Warn":         true,
+	"(*golang.org/x/exp/slog.Logger).WarnContext":  true,
+	"(*golang.org/x/exp/slog.Logger).With":         true,
+	"(*golang.org/x/exp/slog.Record).Add":          true,
+	"golang.org/x/exp/slog.Debug":                  true,
+	"golang.org/x/exp/slog.DebugContext":           true,
+	"golang.org/x/exp/slog.Error":                  true,
+	"golang.org/x/exp/slog.ErrorContext":           true,
+	"golang.org/x/exp/slog.Group":                  true,
+	"golang.org/x/exp/slog.Info":                   true,
+	"golang.org/x/exp/slog.InfoContext":            true,
+	"golang.org/x/exp/slog.Log":                    true,
+	"golang.org/x/exp/slog.Warn":                   true,
+	"golang.org/x/exp/slog.WarnContext":            true,
+	"golang.org/x/exp/slog.With":                   true,
+}
+
+// stringSet is a set-of-function-names-valued flag.
+// Names of the form dir/pkg.Type.Method are recorded
+// as (dir/pkg.Type).Method, to match (*types.Func).FullName.
+type stringSet map[string]bool
+
+func (ss stringSet) String() string {
+	var list []string
+	for name := range ss {
+		list = append(list, name)
+	}
+	sort.Strings(list)
+	return strings.Join(list, ",")
+}
+
+func (ss stringSet) Set(flag string) error {
+	for _, name := range strings.Split(flag, ",") {
+		if len(name) == 0 {
+			return fmt.Errorf("empty string")
+		}
+		if !strings.HasPrefix(name, "(") {
+			slash := strings.LastIndex(name, "/")
+			parts := strings.Split(name[slash+1:], ".")
+			switch len(parts) {
+			case 2: // dir/pkg.Function
+			case 3: // dir/pkg.Type.Method
+				dot := strings.LastIndex(name, ".")
+				name = "(" + name[:dot] + ")" + name[dot:]
+			default:
+				return fmt.Errorf("invalid function name %q", name)
+			}
+		}
+		ss[name] = true
+	}
+	return nil
+}
@@ -0,0 +1,18 @@
+// Copyright 2022 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package logformat_test
+
+import (
+	"testing"
+
+	"github.com/iansmith/golang-x-tools/go/analysis/analysistest"
+	"github.com/iansmith/golang-x-tools/go/analysis/passes/logformat"
+)
+
+func Test(t *testing.T) {
+	testdata := analysistest.TestData()
+	logformat.Analyzer.Flags.Set("funcs", "(*custom.Log).Put,custom.Emit")
+	analysistest.RunWithSuggestedFixes(t, testdata, logformat.Analyzer, "a", "b", "custom")
+}
@@ -0,0 +1,55 @@
+package a
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/go-logr/logr"
+	"go.uber.org/zap"
+	"golang.org/x/exp/slog"
+)
+
+type key string
+
+func _(sugar *zap.SugaredLogger, log logr.Logger, l *slog.Logger, err error, n int, k key, any interface{}) {
+	sugar.Infow("ok")
+	sugar.Infow("ok", "n", n, "err", err)
+	sugar.Infow("ok", zap.String("a", "b"), "n", n)
+	sugar.Infow("ok", "n", n, k, n)
+	sugar.Infow("ok", any, n)
+	sugar.Infow("bad", "n")                   // want `missing value for key "n" in call to \(\*go.uber.org/zap.SugaredLogger\).Infow`
+	sugar.Infow("bad", "n", n, "m")           // want `missing value for key "m" in call to`
+	sugar.Errorw("bad", err)                  // want `error err used as a key in call to \(\*go.uber.org/zap.SugaredLogger\).Errorw`
+	sugar.Errorw("bad", errors.New("x"), "n") // want `error errors.New\("x"\) used as a key`
+	sugar.Infow("bad", n, n)                  // want `key n in call to \(\*go.uber.org/zap.SugaredLogger\).Infow is not a string`
+	sugar.With("a", 1, 2)                     // want `key 2 in call to \(\*go.uber.org/zap.SugaredLogger\).With is not a string`
+	sugar.Infof("%d", n)
+	sugar.Infow("ok", []interface{}{"n"}...)
+	_ = fmt.Sprint(err)
+
+	log.Info("ok", "n", n)
+	log.Error(err, "ok", "n", n)
+	log.Error(err, "bad", "n") // want `missing value for key "n" in call to \(github.com/go-logr/logr.Logger\).Error`
+	log.WithValues(err)        // want `error err used as a key in call to \(github.com/go-logr/logr.Logger\).WithValues`
+
+	slog.Info("ok", slog.Int("n", n), "m", n)
+	slog.Info("bad", slog.Int("n", n), "m") // want `missing value for key "m" in call to golang.org/x/exp/slog.Info`
+	l.Warn("bad", 1.5, n)                   // want `key 1.5 in call to \(\*golang.org/x/exp/slog.Logger\).Warn is not a string`
+}
+
+var sugar *zap.SugaredLogger
+
+// logKV forwards its key-value list to zap.
+func logKV(msg string, kvs ...interface{}) { // want logKV:"kvWrapper"
+	sugar.Infow(msg, kvs...)
+}
+
+// LogKV forwards its key-value list to logKV.
+func LogKV(kvs ...interface{}) { // want LogKV:"kvWrapper"
+	logKV("msg", kvs...)
+}
+
+func _() {
+	logKV("bad", "n") // want `missing value for key "n" in call to a.logKV`
+	LogKV("n", 1)
+}
@@ -0,0 +1,7 @@
+package b
+
+import "a"
+
+func _(err error) {
+	a.LogKV(err) // want `error err used as a key in call to a.LogKV`
+}
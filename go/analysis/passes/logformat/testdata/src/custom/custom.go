@@ -0,0 +1,12 @@
+package custom
+
+type Log struct{}
+
+func (l *Log) Put(msg string, kvs ...interface{}) {}
+
+func Emit(kvs ...interface{}) {}
+
+func _(l *Log) {
+	l.Put("bad", "n") // want `missing value for key "n" in call to \(\*custom.Log\).Put`
+	Emit("n", 1, "m") // want `missing value for key "m" in call to custom.Emit`
+}
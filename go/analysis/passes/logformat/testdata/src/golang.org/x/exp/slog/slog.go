@@ -0,0 +1,11 @@
+package slog
+
+type Attr struct{ Key string }
+
+func Int(key string, v int) Attr { return Attr{Key: key} }
+
+func Info(msg string, args ...interface{}) {}
+
+type Logger struct{}
+
+func (l *Logger) Warn(msg string, args ...interface{}) {}
@@ -0,0 +1,277 @@
+// Copyright 2022 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package mainexit defines an Analyzer that reports calls to os.Exit
+// and log.Fatal that prevent deferred calls from running.
+package mainexit
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/types"
+	"strconv"
+	"strings"
+
+	"github.com/iansmith/golang-x-tools/go/analysis"
+	"github.com/iansmith/golang-x-tools/go/analysis/passes/inspect"
+	"github.com/iansmith/golang-x-tools/go/analysis/passes/internal/analysisutil"
+	"github.com/iansmith/golang-x-tools/go/ast/inspector"
+	"github.com/iansmith/golang-x-tools/go/types/typeutil"
+)
+
+const Doc = `check for os.Exit and log.Fatal calls that skip deferred calls
+
+The functions os.Exit and log.Fatal (and its variants) terminate the
+program immediately; calls deferred by the current function are not
+run. Cleanup placed in a defer, such as flushing a buffered writer or
+removing a temporary file, is silently skipped:
+
+	func main() {
+		f, err := os.Create(name)
+		...
+		defer f.Close()
+		if err := write(f); err != nil {
+			log.Fatal(err) // f is never closed
+		}
+	}
+
+This checker reports such calls when a defer statement precedes them in
+the same function. In function main, it suggests moving the body into a
+function run that returns an error, so that deferred calls run before
+main exits:
+
+	func main() {
+		if err := run(); err != nil {
+			log.Fatal(err)
+		}
+	}`
+
+var Analyzer = &analysis.Analyzer{
+	Name:     "mainexit",
+	Doc:      Doc,
+	Requires: []*analysis.Analyzer{inspect.Analyzer},
+	Run:      run,
+}
+
+// exitFuncs are the functions that terminate the program, keyed by
+// (*types.Func).FullName.
+var exitFuncs = map[string]bool{
+	"os.Exit":                    true,
+	"log.Fatal":                  true,
+	"log.Fatalf":                 true,
+	"log.Fatalln":                true,
+	"(*log.Logger).Fatal":        true,
+	"(*log.Logger).Fatalf":       true,
+	"(*log.Logger).Fatalln":      true,
+	"syscall.Exit":               true,
+	"golang.org/x/sys/unix.Exit": true,
+}
+
+func run(pass *analysis.Pass) (interface{}, error) {
+	inspect := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+
+	nodeFilter := []ast.Node{
+		(*ast.FuncDecl)(nil),
+		(*ast.FuncLit)(nil),
+	}
+	inspect.WithStack(nodeFilter, func(n ast.Node, push bool, stack []ast.Node) bool {
+		if !push {
+			return true
+		}
+		var body *ast.BlockStmt
+		var decl *ast.FuncDecl
+		switch n := n.(type) {
+		case *ast.FuncDecl:
+			body, decl = n.Body, n
+		case *ast.FuncLit:
+			body = n.Body
+		}
+		if body != nil {
+			checkFunc(pass, stack[0].(*ast.File), decl, body)
+		}
+		return true
+	})
+	return nil, nil
+}
+
+// An exit is a call that terminates the program.
+type exit struct {
+	call *ast.CallExpr
+	fn   *types.Func
+	stmt bool // call is an expression statement
+}
+
+// checkFunc reports calls that terminate the program while a deferred
+// call of the function body is pending. Nested function literals are
+// checked separately.
+func checkFunc(pass *analysis.Pass, file *ast.File, decl *ast.FuncDecl, body *ast.BlockStmt) {
+	var defers []*ast.DeferStmt
+	var exits []exit
+	var returns []*ast.ReturnStmt
+	ast.Inspect(body, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.FuncLit:
+			return false
+		case *ast.DeferStmt:
+			defers = append(defers, n)
+		case *ast.ReturnStmt:
+			returns = append(returns, n)
+		case *ast.ExprStmt:
+			if call, ok := n.X.(*ast.CallExpr); ok {
+				if fn := exitFunc(pass.TypesInfo, call); fn != nil {
+					exits = append(exits, exit{call, fn, true})
+					return false
+				}
+			}
+		case *ast.CallExpr:
+			if fn := exitFunc(pass.TypesInfo, n); fn != nil {
+				exits = append(exits, exit{n, fn, false})
+			}
+		}
+		return true
+	})
+	if len(defers) == 0 || len(exits) == 0 {
+		return
+	}
+
+	isMain := decl != nil && decl.Recv == nil && decl.Name.Name == "main" && pass.Pkg.Name() == "main"
+	fixed := false
+	for _, e := range exits {
+		// Find the last defer statement that precedes the call.
+		var pending *ast.DeferStmt
+		for _, d := range defers {
+			if d.Pos() < e.call.Pos() {
+				pending = d
+			}
+		}
+		if pending == nil {
+			continue
+		}
+		diag := analysis.Diagnostic{
+			Pos: e.call.Pos(),
+			End: e.call.End(),
+			Message: fmt.Sprintf("%s exits without running deferred call %s at line %d",
+				name(e.fn), analysisutil.Format(pass.Fset, pending.Call.Fun), pass.Fset.Position(pending.Pos()).Line),
+		}
+		if isMain && !fixed {
+			// Offer the restructuring once per function.
+			fixed = true
+			if fix, ok := runFix(pass, file, decl, exits, returns); ok {
+				diag.SuggestedFixes = []analysis.SuggestedFix{fix}
+			}
+		}
+		pass.Report(diag)
+	}
+}
+
+// runFix returns a fix that moves the body of main into a new function
+// run that returns an error, replacing each call to log.Fatal by a
+// return statement. It fails if any call cannot be replaced.
+func runFix(pass *analysis.Pass, file *ast.File, main *ast.FuncDecl, exits []exit, returns []*ast.ReturnStmt) (analysis.SuggestedFix, bool) {
+	if pass.Pkg.Scope().Lookup("run") != nil {
+		return analysis.SuggestedFix{}, false
+	}
+	logName, fmtName := importName(file, "log"), importName(file, "fmt")
+	if logName == "" {
+		return analysis.SuggestedFix{}, false
+	}
+	errorType := types.Universe.Lookup("error").Type()
+
+	var edits []analysis.TextEdit
+	for _, e := range exits {
+		if !e.stmt || e.fn.Pkg().Path() != "log" || e.fn.Type().(*types.Signature).Recv() != nil || e.call.Ellipsis.IsValid() {
+			return analysis.SuggestedFix{}, false
+		}
+		var args []string
+		for _, arg := range e.call.Args {
+			args = append(args, analysisutil.Format(pass.Fset, arg))
+		}
+		var result string
+		switch {
+		case e.fn.Name() == "Fatalf" && fmtName != "":
+			result = fmtName + ".Errorf(" + strings.Join(args, ", ") + ")"
+		case len(args) == 1 && types.Identical(pass.TypesInfo.TypeOf(e.call.Args[0]), errorType):
+			result = args[0]
+		case len(args) == 1 && fmtName != "":
+			result = fmtName + `.Errorf("%v", ` + args[0] + ")"
+		default:
+			return analysis.SuggestedFix{}, false
+		}
+		edits = append(edits, analysis.TextEdit{
+			Pos:     e.call.Pos(),
+			End:     e.call.End(),
+			NewText: []byte("return " + result),
+		})
+	}
+	for _, ret := range returns {
+		edits = append(edits, analysis.TextEdit{
+			Pos:     ret.Pos(),
+			End:     ret.End(),
+			NewText: []byte("return nil"),
+		})
+	}
+
+	var header bytes.Buffer
+	fmt.Fprintf(&header, "func main() {\n\tif err := run(); err != nil {\n\t\t%s.Fatal(err)\n\t}\n}\n\n", logName)
+	header.WriteString("// run does the work of main, returning an error instead of exiting\n// so that deferred calls are run.\n")
+	header.WriteString("func run() error {")
+	edits = append(edits, analysis.TextEdit{
+		Pos:     main.Pos(),
+		End:     main.Body.Lbrace + 1,
+		NewText: header.Bytes(),
+	})
+	body := main.Body.List
+	if len(body) == 0 || !isReturn(body[len(body)-1]) {
+		edits = append(edits, analysis.TextEdit{
+			Pos:     main.Body.Rbrace,
+			End:     main.Body.Rbrace,
+			NewText: []byte("\treturn nil\n"),
+		})
+	}
+	return analysis.SuggestedFix{
+		Message:   "Move body of main into a function returning an error",
+		TextEdits: edits,
+	}, true
+}
+
+// exitFunc returns the program-terminating function called by call, or nil.
+func exitFunc(info *types.Info, call *ast.CallExpr) *types.Func {
+	fn, ok := typeutil.Callee(info, call).(*types.Func)
+	if ok && exitFuncs[fn.FullName()] {
+		return fn
+	}
+	return nil
+}
+
+// name returns the name of fn qualified by its package or receiver type.
+func name(fn *types.Func) string {
+	if fn.Type().(*types.Signature).Recv() != nil {
+		return fn.FullName()
+	}
+	return fn.Pkg().Name() + "." + fn.Name()
+}
+
+// importName returns the name by which file refers to the package
+// with the given path, or "" if it is not imported by name.
+func importName(file *ast.File, path string) string {
+	for _, spec := range file.Imports {
+		if p, _ := strconv.Unquote(spec.Path.Value); p != path {
+			continue
+		}
+		if spec.Name == nil {
+			return path
+		}
+		if spec.Name.Name == "_" || spec.Name.Name == "." {
+			return ""
+		}
+		return spec.Name.Name
+	}
+	return ""
+}
+
+func isReturn(stmt ast.Stmt) bool {
+	_, ok := stmt.(*ast.ReturnStmt)
+	return ok
+}
@@ -0,0 +1,33 @@
+package a
+
+import (
+	"log"
+	"os"
+)
+
+func noDefer() {
+	os.Exit(1)
+}
+
+func deferAfter() {
+	log.Fatal("x")
+	defer println()
+}
+
+func withDefer(f *os.File, l *log.Logger) {
+	defer f.Close()
+	if f == nil {
+		os.Exit(2) // want `os.Exit exits without running deferred call f.Close at line 18`
+	}
+	l.Fatalf("%v", f) // want `\(\*log.Logger\).Fatalf exits without running deferred call f.Close at line 18`
+	func() {
+		log.Fatal() // not in the deferring function
+	}()
+}
+
+func closure() {
+	_ = func() {
+		defer println()
+		log.Fatalln() // want `log.Fatalln exits without running deferred call println at line 30`
+	}
+}
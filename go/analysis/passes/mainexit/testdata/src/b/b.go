@@ -0,0 +1,30 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"os"
+)
+
+func main() {
+	f, err := os.Create("out")
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer os.Remove(f.Name())
+	if len(os.Args) > 1 {
+		return
+	}
+	if err := write(f); err != nil {
+		log.Fatalf("writing %s: %v", f.Name(), err) // want `log.Fatalf exits without running deferred call os.Remove at line 15`
+	}
+	if f == nil {
+		log.Fatal("no file") // want `log.Fatal exits without running deferred call os.Remove`
+	}
+}
+
+func write(f *os.File) error {
+	_ = fmt.Sprint()
+	return errors.New("nope")
+}
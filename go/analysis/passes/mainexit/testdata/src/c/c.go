@@ -0,0 +1,11 @@
+package main
+
+import (
+	"log"
+	"os"
+)
+
+func main() {
+	defer log.Println("done")
+	os.Exit(3) // want `os.Exit exits without running deferred call log.Println at line 9`
+}
@@ -0,0 +1,14 @@
+// Copyright 2022 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// The structlayout command applies the structlayout analysis to the
+// specified packages of Go source code.
+package main
+
+import (
+	"github.com/iansmith/golang-x-tools/go/analysis/passes/structlayout"
+	"github.com/iansmith/golang-x-tools/go/analysis/singlechecker"
+)
+
+func main() { singlechecker.Main(structlayout.Analyzer) }
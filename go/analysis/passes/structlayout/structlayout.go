@@ -0,0 +1,365 @@
+// Copyright 2022 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package structlayout defines an Analyzer that reports named struct
+// types whose fields could be reordered to waste less memory on padding,
+// and offers to reorder them when doing so is known to be safe.
+package structlayout
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/token"
+	"go/types"
+	"strings"
+
+	"github.com/iansmith/golang-x-tools/go/analysis"
+	"github.com/iansmith/golang-x-tools/go/analysis/passes/inspect"
+	"github.com/iansmith/golang-x-tools/go/analysis/passes/internal/analysisutil"
+	"github.com/iansmith/golang-x-tools/go/ast/inspector"
+	"github.com/iansmith/golang-x-tools/go/types/typeutil"
+	"github.com/iansmith/golang-x-tools/internal/typeparams"
+)
+
+const Doc = `report structs whose field order wastes memory on padding
+
+The structlayout analyzer reports named struct types whose size could be
+reduced by reordering their fields, along with the number of bytes each
+value would save.
+
+Unlike fieldalignment, which always suggests the optimal order, this
+analyzer offers a fix only when reordering cannot change the meaning of
+the program as far as can be determined from the analyzed package. The
+fix is withheld, and the reason given in the diagnostic, if:
+
+  - the type is initialized by an unkeyed composite literal;
+  - the type is exported and has only exported fields, so unkeyed
+    literals may appear in other packages;
+  - the layout of the type is observed through unsafe.Offsetof, a
+    conversion to unsafe.Pointer, or a function of the reflect package
+    or of a package that encodes the fields of a value in declaration
+    order, such as encoding/binary, encoding/json or text/template;
+  - the package uses cgo;
+  - the struct declaration contains comments not attached to a field.`
+
+var Analyzer = &analysis.Analyzer{
+	Name:     "structlayout",
+	Doc:      Doc,
+	Requires: []*analysis.Analyzer{inspect.Analyzer},
+	Run:      run,
+}
+
+// layoutPackages are the packages whose functions may depend on the
+// order of the fields of a struct passed to them: reflect, and the
+// packages that encode or print the fields of a value in declaration
+// order.
+var layoutPackages = map[string]bool{
+	"encoding/asn1":   true,
+	"encoding/binary": true,
+	"encoding/csv":    true,
+	"encoding/gob":    true,
+	"encoding/json":   true,
+	"encoding/xml":    true,
+	"html/template":   true,
+	"reflect":         true,
+	"text/template":   true,
+}
+
+// candidate is a struct type declaration that could be smaller.
+type candidate struct {
+	str    *ast.StructType
+	header *ast.CommentGroup // comment after the opening brace
+	size   int64
+	optsz  int64
+	order  []int
+	hazard string // reason not to reorder, or ""
+}
+
+func run(pass *analysis.Pass) (interface{}, error) {
+	inspect := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+	sizes := analysisutil.NewGCSizes(pass.TypesSizes)
+
+	// Find the struct types whose field order could be improved.
+	candidates := make(map[*types.TypeName]*candidate)
+	var ordered []*types.TypeName
+	inspect.Preorder([]ast.Node{(*ast.TypeSpec)(nil)}, func(n ast.Node) {
+		spec := n.(*ast.TypeSpec)
+		str, ok := spec.Type.(*ast.StructType)
+		if !ok || spec.Assign.IsValid() || typeparams.ForTypeSpec(spec) != nil {
+			return
+		}
+		obj, ok := pass.TypesInfo.Defs[spec.Name].(*types.TypeName)
+		if !ok {
+			return
+		}
+		typ, ok := obj.Type().Underlying().(*types.Struct)
+		if !ok {
+			return
+		}
+		optimal, order := analysisutil.OptimalOrder(typ, sizes)
+		size, optsz := sizes.Sizeof(typ), sizes.Sizeof(optimal)
+		if size <= optsz {
+			return
+		}
+		c := &candidate{
+			str:   str,
+			size:  size,
+			optsz: optsz,
+			order: order,
+		}
+		header, free := freeComments(pass, str)
+		c.header = header
+		if obj.Exported() && allExported(typ) {
+			c.hazard = "exported fields may be initialized by unkeyed literals in other packages"
+		} else if free {
+			c.hazard = "struct contains comments not attached to a field"
+		}
+		candidates[obj] = c
+		ordered = append(ordered, obj)
+	})
+	if len(candidates) == 0 {
+		return nil, nil
+	}
+
+	// Look for uses that depend on the current field order.
+	cgo := usesCgo(pass)
+	mark := func(t types.Type, format string, args ...interface{}) {
+		if c := candidates[typeName(t)]; c != nil && c.hazard == "" {
+			c.hazard = fmt.Sprintf(format, args...)
+		}
+	}
+	nodeFilter := []ast.Node{
+		(*ast.CompositeLit)(nil),
+		(*ast.CallExpr)(nil),
+	}
+	inspect.Preorder(nodeFilter, func(n ast.Node) {
+		switch n := n.(type) {
+		case *ast.CompositeLit:
+			if len(n.Elts) == 0 {
+				return
+			}
+			if _, ok := n.Elts[0].(*ast.KeyValueExpr); ok {
+				return
+			}
+			mark(pass.TypesInfo.TypeOf(n), "unkeyed composite literal at %v", pass.Fset.Position(n.Pos()))
+
+		case *ast.CallExpr:
+			if tv, ok := pass.TypesInfo.Types[n.Fun]; ok && tv.IsType() {
+				if isUnsafePointer(tv.Type) && len(n.Args) == 1 {
+					mark(pass.TypesInfo.TypeOf(n.Args[0]), "converted to unsafe.Pointer at %v", pass.Fset.Position(n.Pos()))
+				}
+				return
+			}
+			switch fn := typeutil.Callee(pass.TypesInfo, n).(type) {
+			case *types.Builtin:
+				if fn.Pkg() == types.Unsafe && fn.Name() == "Offsetof" && len(n.Args) == 1 {
+					if sel, ok := pass.TypesInfo.Selections[selector(n.Args[0])]; ok {
+						mark(sel.Recv(), "field offset observed by unsafe.Offsetof at %v", pass.Fset.Position(n.Pos()))
+					}
+				}
+			case *types.Func:
+				if fn.Pkg() == nil || !layoutPackages[fn.Pkg().Path()] {
+					return
+				}
+				for _, arg := range n.Args {
+					t := pass.TypesInfo.TypeOf(arg)
+					switch u := t.(type) {
+					case *types.Slice:
+						t = u.Elem()
+					case *types.Array:
+						t = u.Elem()
+					}
+					mark(t, "passed to %s.%s at %v", fn.Pkg().Name(), fn.Name(), pass.Fset.Position(n.Pos()))
+				}
+			}
+		}
+	})
+
+	for _, obj := range ordered {
+		c := candidates[obj]
+		if c.hazard == "" && cgo {
+			c.hazard = "package uses cgo"
+		}
+		report(pass, obj, c)
+	}
+	return nil, nil
+}
+
+func report(pass *analysis.Pass, obj *types.TypeName, c *candidate) {
+	message := fmt.Sprintf("struct %s of size %d could be %d, saving %d bytes per value", obj.Name(), c.size, c.optsz, c.size-c.optsz)
+	diag := analysis.Diagnostic{
+		Pos: c.str.Pos(),
+		End: c.str.Pos() + token.Pos(len("struct")),
+	}
+	if c.hazard != "" {
+		diag.Message = message + " (not reordered: " + c.hazard + ")"
+		pass.Report(diag)
+		return
+	}
+	newText, err := reorder(pass.Fset, c.str, c.header, c.order)
+	if err != nil {
+		diag.Message = message
+		pass.Report(diag)
+		return
+	}
+	diag.Message = message
+	diag.SuggestedFixes = []analysis.SuggestedFix{{
+		Message: "Reorder fields",
+		TextEdits: []analysis.TextEdit{{
+			Pos:     c.str.Pos(),
+			End:     c.str.End(),
+			NewText: newText,
+		}},
+	}}
+	pass.Report(diag)
+}
+
+// reorder returns the source of str with its fields in the given order,
+// preserving their doc and line comments and the header comment that
+// follows the opening brace. Fields declared together, as in "a, b int",
+// are split into separate declarations.
+func reorder(fset *token.FileSet, str *ast.StructType, header *ast.CommentGroup, order []int) ([]byte, error) {
+	var decls []string
+	for _, f := range str.Fields.List {
+		typ := analysisutil.Format(fset, f.Type)
+		var tag string
+		if f.Tag != nil {
+			tag = " " + f.Tag.Value
+		}
+		if len(f.Names) == 0 {
+			decls = append(decls, commentText(f.Doc)+typ+tag+lineComment(f.Comment))
+			continue
+		}
+		for i, name := range f.Names {
+			var doc, comment string
+			if i == 0 {
+				doc = commentText(f.Doc)
+			}
+			if i == len(f.Names)-1 {
+				comment = lineComment(f.Comment)
+			}
+			decls = append(decls, doc+name.Name+" "+typ+tag+comment)
+		}
+	}
+	if len(decls) != len(order) {
+		return nil, fmt.Errorf("struct has %d fields, want %d", len(decls), len(order))
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString("package p\n\ntype _ struct {")
+	buf.WriteString(lineComment(header))
+	buf.WriteByte('\n')
+	for _, index := range order {
+		buf.WriteString(decls[index])
+		buf.WriteByte('\n')
+	}
+	buf.WriteString("}\n")
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		return nil, err
+	}
+	src = src[bytes.Index(src, []byte("struct {")):]
+	return bytes.TrimSuffix(src, []byte("\n")), nil
+}
+
+// commentText returns the text of a doc comment, followed by a newline.
+func commentText(g *ast.CommentGroup) string {
+	if g == nil {
+		return ""
+	}
+	var b strings.Builder
+	for _, c := range g.List {
+		b.WriteString(c.Text)
+		b.WriteByte('\n')
+	}
+	return b.String()
+}
+
+// lineComment returns the text of a line comment, preceded by a space.
+func lineComment(g *ast.CommentGroup) string {
+	if g == nil {
+		return ""
+	}
+	var b strings.Builder
+	for _, c := range g.List {
+		b.WriteByte(' ')
+		b.WriteString(c.Text)
+	}
+	return b.String()
+}
+
+// freeComments returns the comment that follows the opening brace of
+// str on the same line, if any, and reports whether the braces of str
+// enclose any other comment that is neither the doc nor the line comment
+// of one of its fields. Such comments would be lost by reordering.
+func freeComments(pass *analysis.Pass, str *ast.StructType) (header *ast.CommentGroup, free bool) {
+	attached := make(map[*ast.CommentGroup]bool)
+	for _, f := range str.Fields.List {
+		attached[f.Doc] = true
+		attached[f.Comment] = true
+	}
+	for _, file := range pass.Files {
+		if file.Pos() > str.Pos() || str.End() > file.End() {
+			continue
+		}
+		for _, g := range file.Comments {
+			if g.Pos() < str.Fields.Opening || str.Fields.Closing < g.End() || attached[g] {
+				continue
+			}
+			if header == nil && pass.Fset.Position(g.Pos()).Line == pass.Fset.Position(str.Fields.Opening).Line {
+				header = g
+			} else {
+				free = true
+			}
+		}
+	}
+	return header, free
+}
+
+// allExported reports whether every field of str is exported.
+func allExported(str *types.Struct) bool {
+	for i := 0; i < str.NumFields(); i++ {
+		if !str.Field(i).Exported() {
+			return false
+		}
+	}
+	return true
+}
+
+// usesCgo reports whether the package being analyzed uses cgo.
+func usesCgo(pass *analysis.Pass) bool {
+	for _, f := range pass.Files {
+		for _, imp := range f.Imports {
+			if imp.Path.Value == `"C"` {
+				return true
+			}
+		}
+	}
+	return analysisutil.Imports(pass.Pkg, "runtime/cgo")
+}
+
+// typeName returns the type name of t, or of the element type of t if
+// it is a pointer, or nil if t is not a named type.
+func typeName(t types.Type) *types.TypeName {
+	if ptr, ok := t.(*types.Pointer); ok {
+		t = ptr.Elem()
+	}
+	if named, ok := t.(*types.Named); ok {
+		return named.Obj()
+	}
+	return nil
+}
+
+func isUnsafePointer(t types.Type) bool {
+	basic, ok := t.(*types.Basic)
+	return ok && basic.Kind() == types.UnsafePointer
+}
+
+// selector returns e as a selector expression, or nil.
+func selector(e ast.Expr) *ast.SelectorExpr {
+	sel, _ := analysisutil.Unparen(e).(*ast.SelectorExpr)
+	return sel
+}
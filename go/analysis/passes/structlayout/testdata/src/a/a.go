@@ -0,0 +1,105 @@
+package a
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"io"
+	"reflect"
+	"unsafe"
+)
+
+type good struct {
+	y int32
+	x byte
+	z byte
+}
+
+type bad struct { // want "struct bad of size 12 could be 8, saving 4 bytes per value"
+	x byte
+	y int32
+	z byte
+}
+
+type comments struct { // want "struct comments of size 24 could be 16, saving 8 bytes per value"
+	// a is first.
+	a bool
+	b int64 // b is second.
+	c bool
+}
+
+type multi struct { // want "struct multi of size 24 could be 16, saving 8 bytes per value"
+	a, b bool
+	c    int64 `json:"c"`
+	d    bool
+}
+
+type Exported struct { // want `struct Exported of size 12 could be 8, saving 4 bytes per value \(not reordered: exported fields may be initialized by unkeyed literals in other packages\)`
+	X byte
+	Y int32
+	Z byte
+}
+
+type ExportedHidden struct { // want "struct ExportedHidden of size 12 could be 8, saving 4 bytes per value"
+	X byte
+	Y int32
+	z byte
+}
+
+type unkeyed struct { // want `struct unkeyed of size 12 could be 8, saving 4 bytes per value \(not reordered: unkeyed composite literal at .*a.go:\d+:\d+\)`
+	x byte
+	y int32
+	z byte
+}
+
+var _ = unkeyed{1, 2, 3}
+
+var _ = []*unkeyed{{x: 1}}
+
+type offset struct { // want `\(not reordered: field offset observed by unsafe.Offsetof at .*\)`
+	x byte
+	y int32
+	z byte
+}
+
+var _ = unsafe.Offsetof(offset{}.y)
+
+type pointer struct { // want `\(not reordered: converted to unsafe.Pointer at .*\)`
+	x byte
+	y int32
+	z byte
+}
+
+func _(p *pointer) unsafe.Pointer { return unsafe.Pointer(p) }
+
+type reflected struct { // want `\(not reordered: passed to reflect.ValueOf at .*\)`
+	x byte
+	y int32
+	z byte
+}
+
+func _(v reflected) { reflect.ValueOf(v).Field(0) }
+
+type encoded struct { // want `\(not reordered: passed to binary.Write at .*\)`
+	x byte
+	y int32
+	z byte
+}
+
+func _(w io.Writer, v []encoded) { binary.Write(w, binary.LittleEndian, v) }
+
+type marshaled struct { // want `\(not reordered: passed to json.Marshal at .*\)`
+	x byte
+	y int32
+	z byte
+}
+
+func _(s marshaled) ([]byte, error) { return json.Marshal(&s) }
+
+type floating struct { // want `\(not reordered: struct contains comments not attached to a field\)`
+	x byte
+
+	// A free-floating comment.
+
+	y int32
+	z byte
+}
@@ -0,0 +1,11 @@
+// Copyright 2022 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+package main
+
+import (
+	"github.com/iansmith/golang-x-tools/go/analysis/passes/testifycheck"
+	"github.com/iansmith/golang-x-tools/go/analysis/singlechecker"
+)
+
+func main() { singlechecker.Main(testifycheck.Analyzer) }
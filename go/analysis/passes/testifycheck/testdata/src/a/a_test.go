@@ -0,0 +1,60 @@
+// Copyright 2022 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package a
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func compute() int { return 42 }
+
+func TestOrder(t *testing.T) {
+	got, want := compute(), 42
+	assert.Equal(t, 42, got)
+	assert.Equal(t, want, got)
+	assert.Equal(t, got, 42)         // want "expected and actual arguments of assert.Equal appear to be swapped"
+	assert.Equal(t, got, want)       // want "expected and actual arguments of assert.Equal appear to be swapped"
+	assert.NotEqualf(t, got, 1, "x") // want "expected and actual arguments of assert.NotEqualf appear to be swapped"
+	require.EqualValues(t, got, nil) // want "expected and actual arguments of require.EqualValues appear to be swapped"
+	assert.Equal(t, compute(), 42)   // want "expected and actual arguments of assert.Equal appear to be swapped"
+	assert.Len(t, []int{1}, 1)
+	assert.Len(t, 1, got) // want "expected and actual arguments of assert.Len appear to be swapped"
+
+	a := assert.New(t)
+	a.Equal(want, got)
+	a.Equal(got, want) // want `expected and actual arguments of \(\*github.com/stretchr/testify/assert.Assertions\).Equal appear to be swapped`
+}
+
+func TestNil(t *testing.T) {
+	err := errors.New("oops")
+	var p *int
+	assert.Nil(t, p)
+	assert.Nil(t, err)             // want "use NoError instead of Nil to check an error"
+	require.NotNil(t, err)         // want "use Error instead of NotNil to check an error"
+	assert.Nilf(t, err, "x %d", 1) // want "use NoErrorf instead of Nilf to check an error"
+	assert.New(t).Nil(err)         // want "use NoError instead of Nil to check an error"
+	assert.NoError(t, err)
+}
+
+func TestGoroutine(t *testing.T) {
+	require.Equal(t, 1, compute())
+	done := make(chan bool)
+	go func() {
+		defer close(done)
+		require.Equal(t, 1, compute()) // want "call to require.Equal from a non-test goroutine; it stops the test by calling t.FailNow"
+		assert.Equal(t, 1, compute())
+		r := require.New(t)
+		r.Equal(1, compute()) // want `call to \(\*github.com/stretchr/testify/require.Assertions\).Equal from a non-test goroutine`
+		f := func() {
+			require.True(t, true) // want "call to require.True from a non-test goroutine"
+		}
+		f()
+	}()
+	<-done
+}
@@ -0,0 +1,51 @@
+// Package assert is a fake of the package of that name in github.com/stretchr/testify.
+package assert
+
+type TestingT interface {
+	Errorf(format string, args ...interface{})
+}
+
+type Assertions struct{ t TestingT }
+
+func New(t TestingT) *Assertions { return &Assertions{t} }
+
+func Equal(t TestingT, expected, actual interface{}, msgAndArgs ...interface{}) bool { return true }
+func Equalf(t TestingT, expected, actual interface{}, msg string, args ...interface{}) bool {
+	return true
+}
+func (a *Assertions) Equal(expected, actual interface{}, msgAndArgs ...interface{}) bool { return true }
+func EqualValues(t TestingT, expected, actual interface{}, msgAndArgs ...interface{}) bool {
+	return true
+}
+func EqualValuesf(t TestingT, expected, actual interface{}, msg string, args ...interface{}) bool {
+	return true
+}
+func (a *Assertions) EqualValues(expected, actual interface{}, msgAndArgs ...interface{}) bool {
+	return true
+}
+func NotEqual(t TestingT, expected, actual interface{}, msgAndArgs ...interface{}) bool { return true }
+func NotEqualf(t TestingT, expected, actual interface{}, msg string, args ...interface{}) bool {
+	return true
+}
+func (a *Assertions) NotEqual(expected, actual interface{}, msgAndArgs ...interface{}) bool {
+	return true
+}
+func InDelta(t TestingT, expected, actual interface{}, msgAndArgs ...interface{}) bool { return true }
+func InDeltaf(t TestingT, expected, actual interface{}, msg string, args ...interface{}) bool {
+	return true
+}
+func (a *Assertions) InDelta(expected, actual interface{}, msgAndArgs ...interface{}) bool {
+	return true
+}
+func EqualError(t TestingT, theError error, errString string, msgAndArgs ...interface{}) bool {
+	return true
+}
+func Len(t TestingT, object interface{}, length int, msgAndArgs ...interface{}) bool { return true }
+func Nil(t TestingT, object interface{}, msgAndArgs ...interface{}) bool             { return true }
+func Nilf(t TestingT, object interface{}, msg string, args ...interface{}) bool      { return true }
+func NotNil(t TestingT, object interface{}, msgAndArgs ...interface{}) bool          { return true }
+func NoError(t TestingT, err error, msgAndArgs ...interface{}) bool                  { return true }
+func NoErrorf(t TestingT, err error, msg string, args ...interface{}) bool           { return true }
+func Error(t TestingT, err error, msgAndArgs ...interface{}) bool                    { return true }
+func True(t TestingT, value bool, msgAndArgs ...interface{}) bool                    { return true }
+func (a *Assertions) Nil(object interface{}, msgAndArgs ...interface{}) bool         { return true }
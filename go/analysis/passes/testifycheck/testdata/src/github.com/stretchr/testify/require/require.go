@@ -0,0 +1,33 @@
+// Package require is a fake of the package of that name in github.com/stretchr/testify.
+package require
+
+type TestingT interface {
+	Errorf(format string, args ...interface{})
+}
+
+type Assertions struct{ t TestingT }
+
+func New(t TestingT) *Assertions { return &Assertions{t} }
+
+func Equal(t TestingT, expected, actual interface{}, msgAndArgs ...interface{})              {}
+func Equalf(t TestingT, expected, actual interface{}, msg string, args ...interface{})       {}
+func (a *Assertions) Equal(expected, actual interface{}, msgAndArgs ...interface{})          {}
+func EqualValues(t TestingT, expected, actual interface{}, msgAndArgs ...interface{})        {}
+func EqualValuesf(t TestingT, expected, actual interface{}, msg string, args ...interface{}) {}
+func (a *Assertions) EqualValues(expected, actual interface{}, msgAndArgs ...interface{})    {}
+func NotEqual(t TestingT, expected, actual interface{}, msgAndArgs ...interface{})           {}
+func NotEqualf(t TestingT, expected, actual interface{}, msg string, args ...interface{})    {}
+func (a *Assertions) NotEqual(expected, actual interface{}, msgAndArgs ...interface{})       {}
+func InDelta(t TestingT, expected, actual interface{}, msgAndArgs ...interface{})            {}
+func InDeltaf(t TestingT, expected, actual interface{}, msg string, args ...interface{})     {}
+func (a *Assertions) InDelta(expected, actual interface{}, msgAndArgs ...interface{})        {}
+func EqualError(t TestingT, theError error, errString string, msgAndArgs ...interface{})     {}
+func Len(t TestingT, object interface{}, length int, msgAndArgs ...interface{})              {}
+func Nil(t TestingT, object interface{}, msgAndArgs ...interface{})                          {}
+func Nilf(t TestingT, object interface{}, msg string, args ...interface{})                   {}
+func NotNil(t TestingT, object interface{}, msgAndArgs ...interface{})                       {}
+func NoError(t TestingT, err error, msgAndArgs ...interface{})                               {}
+func NoErrorf(t TestingT, err error, msg string, args ...interface{})                        {}
+func Error(t TestingT, err error, msgAndArgs ...interface{})                                 {}
+func True(t TestingT, value bool, msgAndArgs ...interface{})                                 {}
+func (a *Assertions) Nil(object interface{}, msgAndArgs ...interface{})                      {}
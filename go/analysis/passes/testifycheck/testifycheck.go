@@ -0,0 +1,325 @@
+// Copyright 2022 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package testifycheck defines an Analyzer that checks for common
+// mistakes in the use of assertion libraries such as testify.
+package testifycheck
+
+import (
+	"go/ast"
+	"go/types"
+	"strconv"
+
+	"github.com/iansmith/golang-x-tools/go/analysis"
+	"github.com/iansmith/golang-x-tools/go/analysis/passes/inspect"
+	"github.com/iansmith/golang-x-tools/go/analysis/passes/internal/analysisutil"
+	"github.com/iansmith/golang-x-tools/go/ast/inspector"
+	"github.com/iansmith/golang-x-tools/go/types/typeutil"
+)
+
+const Doc = `check for mistakes in the use of assertion libraries
+
+The testifycheck analyzer reports three kinds of mistakes in calls to
+the assertion functions of github.com/stretchr/testify:
+
+Swapped arguments. The expected value comes before the actual one, and
+failure messages label them accordingly. An argument order that puts a
+constant in the actual position, or a variable named got or actual in
+the expected position, is reported:
+
+	assert.Equal(t, got, 42) // should be assert.Equal(t, 42, got)
+
+Nil checks of errors. assert.Nil(t, err) works, but assert.NoError(t, err)
+reports the error's message on failure rather than its internal structure.
+
+Fatal assertions in goroutines. The functions of the require package
+call t.FailNow, which must be called only from the goroutine running the
+test. Within a goroutine started by the test, the equivalent function of
+the assert package should be used instead.`
+
+var Analyzer = &analysis.Analyzer{
+	Name:     "testifycheck",
+	Doc:      Doc,
+	Requires: []*analysis.Analyzer{inspect.Analyzer},
+	Run:      run,
+}
+
+// A library describes the assertion functions of a testing library.
+// Package-level assertion functions take the test as their first
+// argument; methods do not.
+type library struct {
+	// pkgs maps the import path of each package of assertion functions
+	// to the path of the package that provides the same functions
+	// without stopping the test, or to "" if the package's functions
+	// do not stop the test.
+	pkgs map[string]string
+
+	// funcs describes the arguments of assertion functions by name.
+	// Methods of the same name of types declared in pkgs are described
+	// by the same entry.
+	funcs map[string]assertion
+
+	// helpers are the names of the functions of pkgs that are not
+	// assertions, such as constructors.
+	helpers map[string]bool
+}
+
+// An assertion describes the arguments of an assertion function.
+// Argument indexes do not count the test argument.
+type assertion struct {
+	expected, actual int // indexes of the expected and actual values, or -1
+
+	// For functions that check that their first argument is nil or
+	// not nil, errFunc is the function that should be used instead
+	// if the argument is an error.
+	errFunc string
+}
+
+// libraries is the vocabulary of assertion libraries known to the
+// analyzer. To support another library, add an entry here.
+var libraries = []*library{
+	{
+		pkgs: map[string]string{
+			"github.com/stretchr/testify/assert":  "",
+			"github.com/stretchr/testify/require": "github.com/stretchr/testify/assert",
+		},
+		funcs: formatted(map[string]assertion{
+			"Equal":          {expected: 0, actual: 1},
+			"EqualError":     {expected: 1, actual: 0},
+			"EqualValues":    {expected: 0, actual: 1},
+			"Exactly":        {expected: 0, actual: 1},
+			"InDelta":        {expected: 0, actual: 1},
+			"InEpsilon":      {expected: 0, actual: 1},
+			"Len":            {expected: 1, actual: 0},
+			"NotEqual":       {expected: 0, actual: 1},
+			"NotEqualValues": {expected: 0, actual: 1},
+			"NotSame":        {expected: 0, actual: 1},
+			"Same":           {expected: 0, actual: 1},
+			"Nil":            {expected: -1, actual: -1, errFunc: "NoError"},
+			"NotNil":         {expected: -1, actual: -1, errFunc: "Error"},
+		}),
+		helpers: map[string]bool{"New": true},
+	},
+}
+
+// formatted adds to funcs the variant of each function that
+// takes a format string, named with the suffix "f".
+func formatted(funcs map[string]assertion) map[string]assertion {
+	all := make(map[string]assertion, 2*len(funcs))
+	for name, a := range funcs {
+		all[name] = a
+		if a.errFunc != "" {
+			a.errFunc += "f"
+		}
+		all[name+"f"] = a
+	}
+	return all
+}
+
+// namesActual and namesExpected are the names that suggest
+// a variable holds an actual or expected value.
+var (
+	namesActual   = map[string]bool{"actual": true, "got": true}
+	namesExpected = map[string]bool{"expected": true, "want": true}
+)
+
+func run(pass *analysis.Pass) (interface{}, error) {
+	inspect := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+
+	nodeFilter := []ast.Node{
+		(*ast.CallExpr)(nil),
+	}
+	inspect.WithStack(nodeFilter, func(n ast.Node, push bool, stack []ast.Node) bool {
+		if !push {
+			return true
+		}
+		call := n.(*ast.CallExpr)
+		fn, ok := typeutil.Callee(pass.TypesInfo, call).(*types.Func)
+		if !ok || fn.Pkg() == nil {
+			return true
+		}
+		for _, lib := range libraries {
+			nonfatal, ok := lib.pkgs[fn.Pkg().Path()]
+			if !ok {
+				continue
+			}
+			if lib.helpers[fn.Name()] {
+				continue
+			}
+			if nonfatal != "" && inGoroutine(stack) {
+				checkFatal(pass, stack[0].(*ast.File), call, fn, nonfatal)
+			}
+			a, ok := lib.funcs[fn.Name()]
+			if !ok {
+				continue
+			}
+			args := call.Args
+			if fn.Type().(*types.Signature).Recv() == nil {
+				if len(args) == 0 {
+					continue
+				}
+				args = args[1:] // skip the test
+			}
+			checkOrder(pass, call, fn, a, args)
+			checkNil(pass, call, fn, a, args)
+		}
+		return true
+	})
+	return nil, nil
+}
+
+// checkOrder reports a call whose expected and actual arguments
+// appear to be swapped, offering to swap them if that does not change
+// the order of any side effects.
+func checkOrder(pass *analysis.Pass, call *ast.CallExpr, fn *types.Func, a assertion, args []ast.Expr) {
+	if a.expected < 0 || a.actual < 0 || a.expected >= len(args) || a.actual >= len(args) {
+		return
+	}
+	expected, actual := args[a.expected], args[a.actual]
+	swapped := isConstant(pass.TypesInfo, actual) && !isConstant(pass.TypesInfo, expected) ||
+		hasName(expected, namesActual) || hasName(actual, namesExpected)
+	if !swapped {
+		return
+	}
+	diag := analysis.Diagnostic{
+		Pos:     call.Pos(),
+		End:     call.End(),
+		Message: "expected and actual arguments of " + name(fn) + " appear to be swapped",
+	}
+	if !analysisutil.HasSideEffects(pass.TypesInfo, expected) && !analysisutil.HasSideEffects(pass.TypesInfo, actual) {
+		diag.SuggestedFixes = []analysis.SuggestedFix{{
+			Message: "Swap expected and actual arguments",
+			TextEdits: []analysis.TextEdit{
+				{
+					Pos:     expected.Pos(),
+					End:     expected.End(),
+					NewText: []byte(analysisutil.Format(pass.Fset, actual)),
+				},
+				{
+					Pos:     actual.Pos(),
+					End:     actual.End(),
+					NewText: []byte(analysisutil.Format(pass.Fset, expected)),
+				},
+			},
+		}}
+	}
+	pass.Report(diag)
+}
+
+// checkNil reports a nil check of an error, offering to use the
+// library's function for errors instead.
+func checkNil(pass *analysis.Pass, call *ast.CallExpr, fn *types.Func, a assertion, args []ast.Expr) {
+	if a.errFunc == "" || len(args) == 0 || !isError(pass.TypesInfo.TypeOf(args[0])) {
+		return
+	}
+	diag := analysis.Diagnostic{
+		Pos:     call.Pos(),
+		End:     call.End(),
+		Message: "use " + a.errFunc + " instead of " + fn.Name() + " to check an error",
+	}
+	if sel, ok := call.Fun.(*ast.SelectorExpr); ok {
+		diag.SuggestedFixes = []analysis.SuggestedFix{{
+			Message: "Replace " + fn.Name() + " with " + a.errFunc,
+			TextEdits: []analysis.TextEdit{{
+				Pos:     sel.Sel.Pos(),
+				End:     sel.Sel.End(),
+				NewText: []byte(a.errFunc),
+			}},
+		}}
+	}
+	pass.Report(diag)
+}
+
+// checkFatal reports a call to an assertion function that stops the
+// test from within a goroutine, offering to call the function of the
+// same name in the package nonfatal if file imports it.
+func checkFatal(pass *analysis.Pass, file *ast.File, call *ast.CallExpr, fn *types.Func, nonfatal string) {
+	diag := analysis.Diagnostic{
+		Pos:     call.Pos(),
+		End:     call.End(),
+		Message: "call to " + name(fn) + " from a non-test goroutine; it stops the test by calling t.FailNow",
+	}
+	if sel, ok := call.Fun.(*ast.SelectorExpr); ok {
+		if id, ok := sel.X.(*ast.Ident); ok && isPkgName(pass.TypesInfo, id) {
+			if pkgName := importName(pass.TypesInfo, file, nonfatal); pkgName != "" {
+				diag.SuggestedFixes = []analysis.SuggestedFix{{
+					Message: "Use " + pkgName + "." + fn.Name(),
+					TextEdits: []analysis.TextEdit{{
+						Pos:     id.Pos(),
+						End:     id.End(),
+						NewText: []byte(pkgName),
+					}},
+				}}
+			}
+		}
+	}
+	pass.Report(diag)
+}
+
+// inGoroutine reports whether the innermost node of stack is within
+// a function literal called by a go statement.
+func inGoroutine(stack []ast.Node) bool {
+	for i := len(stack) - 1; i >= 2; i-- {
+		lit, ok := stack[i].(*ast.FuncLit)
+		if !ok {
+			continue
+		}
+		if call, ok := stack[i-1].(*ast.CallExpr); ok && call.Fun == lit {
+			if _, ok := stack[i-2].(*ast.GoStmt); ok {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// isConstant reports whether e is a constant or nil.
+func isConstant(info *types.Info, e ast.Expr) bool {
+	tv, ok := info.Types[e]
+	return ok && (tv.Value != nil || tv.IsNil())
+}
+
+// hasName reports whether e is an identifier whose name is in names.
+func hasName(e ast.Expr, names map[string]bool) bool {
+	id, ok := analysisutil.Unparen(e).(*ast.Ident)
+	return ok && names[id.Name]
+}
+
+var errorType = types.Universe.Lookup("error").Type().Underlying().(*types.Interface)
+
+// isError reports whether t is an interface type that implements error.
+func isError(t types.Type) bool {
+	return t != nil && types.IsInterface(t) && types.Implements(t, errorType)
+}
+
+// name returns the name of fn qualified by its package or receiver type.
+func name(fn *types.Func) string {
+	if recv := fn.Type().(*types.Signature).Recv(); recv != nil {
+		return fn.FullName()
+	}
+	return fn.Pkg().Name() + "." + fn.Name()
+}
+
+func isPkgName(info *types.Info, id *ast.Ident) bool {
+	_, ok := info.Uses[id].(*types.PkgName)
+	return ok
+}
+
+// importName returns the name by which file refers to the package
+// with the given path, or "" if it does not import it by name.
+func importName(info *types.Info, file *ast.File, path string) string {
+	for _, spec := range file.Imports {
+		if p, _ := strconv.Unquote(spec.Path.Value); p != path {
+			continue
+		}
+		obj := info.Implicits[spec]
+		if spec.Name != nil {
+			obj = info.Defs[spec.Name]
+		}
+		if pkgName, ok := obj.(*types.PkgName); ok && pkgName.Name() != "_" && pkgName.Name() != "." {
+			return pkgName.Name()
+		}
+	}
+	return ""
+}
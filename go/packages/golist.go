@@ -96,6 +96,44 @@ type golistState struct {
 
 	// vendorDirs caches the (non)existence of vendor directories.
 	vendorDirs map[string]bool
+
+	// cgoErrors holds the errors that go list printed to stderr for the
+	// packages whose cgo processing failed, keyed by import path.
+	cgoErrorsMu sync.Mutex
+	cgoErrors   map[string]string
+}
+
+// addCgoErrors records the errors of stderr, printed by go list in sections
+// headed by "# <import path>" lines, for the packages whose cgo processing
+// failed.
+func (state *golistState) addCgoErrors(stderr string) {
+	state.cgoErrorsMu.Lock()
+	defer state.cgoErrorsMu.Unlock()
+	var pkg string
+	for _, line := range strings.Split(stderr, "\n") {
+		if strings.HasPrefix(line, "# ") {
+			pkg = strings.TrimSpace(line[len("# "):])
+			continue
+		}
+		if pkg == "" || strings.TrimSpace(line) == "" {
+			continue
+		}
+		if state.cgoErrors == nil {
+			state.cgoErrors = make(map[string]string)
+		}
+		if state.cgoErrors[pkg] != "" {
+			line = "\n" + line
+		}
+		state.cgoErrors[pkg] += line
+	}
+}
+
+// cgoError returns the errors of the cgo processing of the package
+// importPath, or "" if there are none.
+func (state *golistState) cgoError(importPath string) string {
+	state.cgoErrorsMu.Lock()
+	defer state.cgoErrorsMu.Unlock()
+	return state.cgoErrors[importPath]
 }
 
 // getEnv returns Go environment variables. Only specific variables are
@@ -590,8 +628,13 @@ func (state *golistState) createDriverResponse(words ...string) (*driverResponse
 			} else {
 				// golang/go#38990: go list silently fails to do cgo processing
 				pkg.CompiledGoFiles = nil
+				msg := "go list failed to return CompiledGoFiles. This may indicate failure to perform cgo processing; try building at the command line. See https://golang.org/issue/38990."
+				// The errors of the cgo processing, if any, are only in stderr.
+				if cgoMsg := state.cgoError(p.ImportPath); cgoMsg != "" {
+					msg += "\n" + cgoMsg
+				}
 				pkg.Errors = append(pkg.Errors, Error{
-					Msg:  "go list failed to return CompiledGoFiles. This may indicate failure to perform cgo processing; try building at the command line. See https://golang.org/issue/38990.",
+					Msg:  msg,
 					Kind: ListError,
 				})
 			}
@@ -986,6 +1029,7 @@ func (state *golistState) invokeGo(verb string, args ...string) (*bytes.Buffer,
 		if len(stderr.String()) > 0 && strings.HasPrefix(stderr.String(), "# ") {
 			msg := msg[len("# "):]
 			if strings.HasPrefix(strings.TrimLeftFunc(msg, isPkgPathRune), "\n") {
+				state.addCgoErrors(stderr.String())
 				return stdout, nil
 			}
 			// Treat pkg-config errors as a special case (golang.org/issue/36770).
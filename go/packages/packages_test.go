@@ -1851,6 +1851,51 @@ const A = 4
 	}
 }
 
+func TestCgoPreambleError(t *testing.T) { testAllOrModulesParallel(t, testCgoPreambleError) }
+func testCgoPreambleError(t *testing.T, exporter packagestest.Exporter) {
+	testenv.NeedsGo1Point(t, 15)
+	testenv.NeedsTool(t, "cgo")
+	exported := packagestest.Export(t, exporter, []packagestest.Module{{
+		Name: "golang.org/fake",
+		Files: map[string]interface{}{
+			"a/a.go": `package a
+
+// int add(int a, int b) { return a + b; }
+// int bad(void) { return undeclared; }
+import "C"
+
+func Add() int { return int(C.add(1, 2)) }
+`,
+		}}})
+	defer exported.Cleanup()
+
+	// Explicitly enable cgo.
+	exported.Config.Env = append(exported.Config.Env, "CGO_ENABLED=1")
+	exported.Config.Mode = packages.NeedName | packages.NeedFiles | packages.NeedCompiledGoFiles |
+		packages.LoadMode(packagesinternal.TypecheckCgo)
+	initial, err := packages.Load(exported.Config, "golang.org/fake/a")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(initial) != 1 {
+		t.Fatalf("Expected 1 package, got %v", initial)
+	}
+
+	// The error of the C compiler, with its position, must be reported
+	// along with the generic error.
+	var msgs []string
+	for _, e := range initial[0].Errors {
+		msgs = append(msgs, e.Msg)
+	}
+	got := strings.Join(msgs, "\n")
+	if !strings.Contains(got, "a.go:4:") || !strings.Contains(got, "undeclared") {
+		t.Errorf("errors: got %q, want the error of undeclared at a.go:4", got)
+	}
+	if !strings.Contains(got, "golang.org/issue/38990") {
+		t.Errorf("errors: got %q, want the guidance of golang.org/issue/38990", got)
+	}
+}
+
 func TestLoadImportsC(t *testing.T) {
 	// This test checks that when a package depends on the
 	// test variant of "syscall", "unsafe", or "runtime/cgo", that dependency
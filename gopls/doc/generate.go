@@ -36,6 +36,7 @@ import (
 	"github.com/iansmith/golang-x-tools/internal/lsp/command/commandmeta"
 	"github.com/iansmith/golang-x-tools/internal/lsp/mod"
 	"github.com/iansmith/golang-x-tools/internal/lsp/source"
+	"github.com/iansmith/golang-x-tools/internal/lsp/work"
 )
 
 func main() {
@@ -63,6 +64,9 @@ func doMain(baseDir string, write bool) (bool, error) {
 	if ok, err := rewriteFile(filepath.Join(baseDir, "gopls/doc/analyzers.md"), api, write, rewriteAnalyzers); !ok || err != nil {
 		return ok, err
 	}
+	if ok, err := rewriteFile(filepath.Join(baseDir, "gopls/doc/inlayHints.md"), api, write, rewriteInlayHints); !ok || err != nil {
+		return ok, err
+	}
 
 	return true, nil
 }
@@ -102,6 +106,7 @@ func loadAPI() (*source.APIJSON, error) {
 	} {
 		api.Analyzers = append(api.Analyzers, loadAnalyzers(m)...)
 	}
+	api.Hints = loadHints(source.AllInlayHints)
 	for _, category := range []reflect.Value{
 		reflect.ValueOf(defaults.UserOptions),
 	} {
@@ -117,8 +122,8 @@ func loadAPI() (*source.APIJSON, error) {
 		catName := strings.TrimSuffix(category.Type().Name(), "Options")
 		api.Options[catName] = opts
 
-		// Hardcode the expected values for the analyses and code lenses
-		// settings, since their keys are not enums.
+		// Hardcode the expected values for the analyses, code lenses,
+		// and inlay hints settings, since their keys are not enums.
 		for _, opt := range opts {
 			switch opt.Name {
 			case "analyses":
@@ -146,6 +151,14 @@ func loadAPI() (*source.APIJSON, error) {
 						Default: def,
 					})
 				}
+			case "hints":
+				for _, h := range api.Hints {
+					opt.EnumKeys.Keys = append(opt.EnumKeys.Keys, source.EnumKey{
+						Name:    fmt.Sprintf("%q", h.Name),
+						Doc:     h.Doc,
+						Default: strconv.FormatBool(h.Default),
+					})
+				}
 			}
 		}
 	}
@@ -455,6 +468,12 @@ func loadLenses(commands []*source.CommandJSON) []*source.LensJSON {
 		}
 		all[k] = struct{}{}
 	}
+	for k := range work.LensFuncs() {
+		if _, ok := all[k]; ok {
+			panic(fmt.Sprintf("duplicate lens %q", string(k)))
+		}
+		all[k] = struct{}{}
+	}
 
 	var lenses []*source.LensJSON
 
@@ -488,6 +507,23 @@ func loadAnalyzers(m map[string]*source.Analyzer) []*source.AnalyzerJSON {
 	return json
 }
 
+func loadHints(m map[string]*source.Hint) []*source.HintJSON {
+	var sorted []string
+	for _, h := range m {
+		sorted = append(sorted, h.Name)
+	}
+	sort.Strings(sorted)
+	var json []*source.HintJSON
+	for _, name := range sorted {
+		h := m[name]
+		json = append(json, &source.HintJSON{
+			Name: h.Name,
+			Doc:  h.Doc,
+		})
+	}
+	return json
+}
+
 func lowerFirst(x string) string {
 	if x == "" {
 		return x
@@ -644,7 +680,7 @@ func collectGroups(opts []*source.OptionJSON) []optionsGroup {
 }
 
 func hardcodedEnumKeys(name string) bool {
-	return name == "analyses" || name == "codelenses"
+	return name == "analyses" || name == "codelenses" || name == "hints"
 }
 
 func writeBullet(w io.Writer, title string, level int) {
@@ -699,6 +735,21 @@ func rewriteAnalyzers(doc []byte, api *source.APIJSON) ([]byte, error) {
 	return replaceSection(doc, "Analyzers", section.Bytes())
 }
 
+func rewriteInlayHints(doc []byte, api *source.APIJSON) ([]byte, error) {
+	section := bytes.NewBuffer(nil)
+	for _, hint := range api.Hints {
+		fmt.Fprintf(section, "## **%v**\n\n", hint.Name)
+		fmt.Fprintf(section, "%s\n\n", hint.Doc)
+		switch hint.Default {
+		case true:
+			fmt.Fprintf(section, "**Enabled by default.**\n\n")
+		case false:
+			fmt.Fprintf(section, "**Disabled by default. Enable it by setting `\"hints\": {\"%s\": true}`.**\n\n", hint.Name)
+		}
+	}
+	return replaceSection(doc, "Hints", section.Bytes())
+}
+
 func replaceSection(doc []byte, sectionName string, replacement []byte) ([]byte, error) {
 	re := regexp.MustCompile(fmt.Sprintf(`(?s)<!-- BEGIN %v.* -->\n(.*?)<!-- END %v.* -->`, sectionName, sectionName))
 	idx := re.FindSubmatchIndex(doc)
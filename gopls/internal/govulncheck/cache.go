@@ -45,6 +45,11 @@ import (
 type FSCache struct {
 	mu      sync.Mutex
 	rootDir string
+
+	// ttl, if non-zero, is how long a cached index is used without asking
+	// the database whether it changed. If negative, a cached index is
+	// always used.
+	ttl time.Duration
 }
 
 // Assert that *FSCache implements client.Cache.
@@ -57,6 +62,14 @@ func DefaultCache() *FSCache {
 	return &FSCache{rootDir: defaultCacheRoot}
 }
 
+// WithTTL returns a cache in the same directory as c whose indexes are
+// used for ttl after they are retrieved, rather than for the period that
+// the client chooses. If ttl is negative, they are always used, so that a
+// database whose index is cached is not contacted again.
+func (c *FSCache) WithTTL(ttl time.Duration) *FSCache {
+	return &FSCache{rootDir: c.rootDir, ttl: ttl}
+}
+
 type cachedIndex struct {
 	Retrieved time.Time
 	Index     client.DBIndex
@@ -77,6 +90,15 @@ func (c *FSCache) ReadIndex(dbName string) (client.DBIndex, time.Time, error) {
 	if err := json.Unmarshal(b, &index); err != nil {
 		return nil, time.Time{}, err
 	}
+	if c.ttl != 0 {
+		// The client decides whether the index is fresh from the time it
+		// was retrieved, so report a fresh index as just retrieved, and a
+		// stale one as missing.
+		if c.ttl > 0 && time.Since(index.Retrieved) >= c.ttl {
+			return nil, time.Time{}, nil
+		}
+		return index.Index, time.Now(), nil
+	}
 	return index.Index, index.Retrieved, nil
 }
 
@@ -82,6 +82,38 @@ func TestCache(t *testing.T) {
 	}
 }
 
+func TestCacheTTL(t *testing.T) {
+	cache := &FSCache{rootDir: t.TempDir()}
+	dbName := "vulndb.golang.org"
+	index := client.DBIndex{"a.vuln.example.com": time.Time{}.Add(time.Hour)}
+	retrieved := time.Now().Add(-3 * time.Hour)
+	if err := cache.WriteIndex(dbName, index, retrieved); err != nil {
+		t.Fatalf("WriteIndex failed to write index: %v", err)
+	}
+
+	for _, test := range []struct {
+		ttl       time.Duration
+		wantIndex bool
+		wantFresh bool // reported as retrieved now
+	}{
+		{0, true, false},
+		{time.Hour, false, false},
+		{4 * time.Hour, true, true},
+		{-1, true, true},
+	} {
+		idx, got, err := cache.WithTTL(test.ttl).ReadIndex(dbName)
+		if err != nil {
+			t.Fatalf("ReadIndex with TTL %v failed: %v", test.ttl, err)
+		}
+		if (idx != nil) != test.wantIndex {
+			t.Errorf("ReadIndex with TTL %v returned index %v, want index: %v", test.ttl, idx, test.wantIndex)
+		}
+		if fresh := time.Since(got) < time.Minute; test.wantIndex && fresh != test.wantFresh {
+			t.Errorf("ReadIndex with TTL %v returned retrieved %s, want fresh: %v", test.ttl, got, test.wantFresh)
+		}
+	}
+}
+
 func TestConcurrency(t *testing.T) {
 	tmpDir := t.TempDir()
 
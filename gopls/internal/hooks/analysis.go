@@ -11,6 +11,7 @@ import (
 	"github.com/iansmith/golang-x-tools/internal/lsp/protocol"
 	"github.com/iansmith/golang-x-tools/internal/lsp/source"
 	"honnef.co/go/tools/analysis/lint"
+	"honnef.co/go/tools/config"
 	"honnef.co/go/tools/quickfix"
 	"honnef.co/go/tools/simple"
 	"honnef.co/go/tools/staticcheck"
@@ -59,4 +60,17 @@ func updateAnalyzers(options *source.Options) {
 	})
 	add(stylecheck.Analyzers, nil)
 	add(quickfix.Analyzers, nil)
+
+	// The staticcheckConfig setting applies over the staticcheck.conf files,
+	// as a staticcheck.conf file does over those of its parent directories.
+	options.StaticcheckConfigAnalyzer = config.Analyzer
+	options.StaticcheckConfigure = func(result interface{}, settings map[string][]string) interface{} {
+		cfg := result.(*config.Config).Merge(config.Config{
+			Checks:                  settings["checks"],
+			Initialisms:             settings["initialisms"],
+			DotImportWhitelist:      settings["dot_import_whitelist"],
+			HTTPStatusCodeWhitelist: settings["http_status_code_whitelist"],
+		})
+		return &cfg
+	}
 }
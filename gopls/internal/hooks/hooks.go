@@ -22,13 +22,15 @@ func Options(options *source.Options) {
 		options.ComputeEdits = ComputeEdits
 	}
 	options.URLRegexp = xurls.Relaxed()
-	options.GofumptFormat = func(ctx context.Context, langVersion, modulePath string, src []byte) ([]byte, error) {
+	options.GofumptFormat = func(ctx context.Context, opts source.GofumptOptions, src []byte) ([]byte, error) {
 		return format.Source(src, format.Options{
-			LangVersion: langVersion,
-			ModulePath:  modulePath,
+			LangVersion: opts.LangVersion,
+			ModulePath:  opts.ModulePath,
+			ExtraRules:  opts.ExtraRules,
 		})
 	}
 	updateAnalyzers(options)
 
 	options.Govulncheck = vulncheck.Govulncheck
+	options.ModuleVulns = vulncheck.ModuleVulns
 }
@@ -185,6 +185,36 @@ require golang.org/x/hello v1.3.3
 			})
 		})
 	}
+	t.Run("Upgrade all dependencies", func(t *testing.T) {
+		WithOptions(ProxyFiles(proxyWithLatest)).Run(t, shouldUpdateDep, func(t *testing.T, env *Env) {
+			env.OpenFile("go.mod")
+			for _, l := range env.CodeLens("go.mod") {
+				if l.Command.Title == "Upgrade all dependencies" {
+					t.Fatalf("found %q code lens before checking for upgrades", l.Command.Title)
+				}
+			}
+			env.ExecuteCodeLensCommand("go.mod", command.CheckUpgrades)
+			env.Await(env.DiagnosticAtRegexpWithMessage("go.mod", `require`, "can be upgraded"))
+			var lens *protocol.CodeLens
+			for _, l := range env.CodeLens("go.mod") {
+				if l.Command.Title == "Upgrade all dependencies" {
+					l := l
+					lens = &l
+				}
+			}
+			if lens == nil {
+				t.Fatal(`found no "Upgrade all dependencies" code lens after checking for upgrades`)
+			}
+			env.ExecuteCommand(&protocol.ExecuteCommandParams{
+				Command:   lens.Command.Command,
+				Arguments: lens.Command.Arguments,
+			}, nil)
+			env.Await(env.DoneWithChangeWatchedFiles())
+			if got := env.Editor.BufferText("go.mod"); got != wantGoMod {
+				t.Fatalf("go.mod upgrade failed:\n%s", tests.Diff(t, wantGoMod, got))
+			}
+		})
+	})
 }
 
 func TestUnusedDependenciesCodelens(t *testing.T) {
@@ -269,19 +299,18 @@ func Foo() {
 }
 `
 	Run(t, workspace, func(t *testing.T, env *Env) {
-		// Open the file. We have a nonexistant symbol that will break cgo processing.
+		// Open the file. We have a nonexistant symbol that will break cgo
+		// processing, which is reported where the symbol is used.
 		env.OpenFile("cgo.go")
-		env.Await(env.DiagnosticAtRegexpWithMessage("cgo.go", ``, "go list failed to return CompiledGoFiles"))
+		env.Await(env.DiagnosticAtRegexpWithMessage("cgo.go", `C\.fortytwo`, "C.fortytwo"))
 
-		// Fix the C function name. We haven't regenerated cgo, so nothing should be fixed.
+		// Fix the C function name. Changes to the preamble regenerate cgo,
+		// fixing the diagnostic.
 		env.RegexpReplace("cgo.go", `int fortythree`, "int fortytwo")
 		env.SaveBuffer("cgo.go")
-		env.Await(OnceMet(
-			env.DoneWithSave(),
-			env.DiagnosticAtRegexpWithMessage("cgo.go", ``, "go list failed to return CompiledGoFiles"),
-		))
+		env.Await(EmptyDiagnostics("cgo.go"))
 
-		// Regenerate cgo, fixing the diagnostic.
+		// Regenerating cgo explicitly keeps it fixed.
 		env.ExecuteCodeLensCommand("cgo.go", command.RegenerateCgo)
 		env.Await(EmptyDiagnostics("cgo.go"))
 	})
@@ -353,3 +382,125 @@ func main() {
 		)
 	})
 }
+
+func TestRunTestsCodeLens(t *testing.T) {
+	testenv.NeedsGo1Point(t, 18) // uses fuzz targets
+
+	const mod = `
+-- go.mod --
+module mod.com
+
+go 1.18
+-- a/a_test.go --
+package a
+
+import "testing"
+
+func TestPass(t *testing.T) {}
+
+func TestFail(t *testing.T) {
+	t.Run("sub", func(t *testing.T) {
+		t.Error("bad result")
+	})
+}
+
+func FuzzParse(f *testing.F) {
+	f.Add("x")
+	f.Fuzz(func(t *testing.T, s string) {})
+}
+
+func BenchmarkNothing(b *testing.B) {}
+`
+	WithOptions(
+		EditorConfig{
+			CodeLenses: map[string]bool{
+				"test": true,
+			}},
+	).Run(t, mod, func(t *testing.T, env *Env) {
+		env.OpenFile("a/a_test.go")
+		var n uint64
+		for _, l := range env.CodeLens("a/a_test.go") {
+			if l.Command.Command != command.RunTests.ID() || l.Command.Title == "run file benchmarks" {
+				continue
+			}
+			env.ExecuteCommand(&protocol.ExecuteCommandParams{
+				Command:   l.Command.Command,
+				Arguments: l.Command.Arguments,
+			}, nil)
+			n++
+		}
+		if n != 4 {
+			t.Fatalf("ran %d code lenses, want 4", n)
+		}
+		env.Await(
+			OnceMet(
+				CompletedWork("Running go test", n, false),
+				env.DiagnosticAtRegexpWithMessage("a/a_test.go", "TestPass", "TestPass passed"),
+				env.DiagnosticAtRegexpWithMessage("a/a_test.go", "TestFail", "bad result"),
+				env.DiagnosticAtRegexpWithMessage("a/a_test.go", "FuzzParse", "FuzzParse passed"),
+				env.DiagnosticAtRegexpWithMessage("a/a_test.go", "BenchmarkNothing", "BenchmarkNothing passed"),
+				LogMatching(protocol.Log, "--- FAIL: TestFail", 1, true),
+			),
+		)
+
+		// The results are cleared by the next change.
+		env.RegexpReplace("a/a_test.go", "bad result", "still bad")
+		env.Await(env.NoDiagnosticAtRegexp("a/a_test.go", "TestPass"))
+	})
+}
+
+func TestRunTestsWithCoverage(t *testing.T) {
+	const mod = `
+-- go.mod --
+module mod.com
+
+go 1.12
+-- a/a.go --
+package a
+
+func Abs(x int) int {
+	if x < 0 {
+		return -x
+	}
+	return x
+}
+-- a/a_test.go --
+package a
+
+import "testing"
+
+func TestAbs(t *testing.T) {
+	if got := Abs(1); got != 1 {
+		t.Errorf("Abs(1) = %d", got)
+	}
+}
+`
+	Run(t, mod, func(t *testing.T, env *Env) {
+		env.OpenFile("a/a_test.go")
+		cmd, err := command.NewRunTestsWithCoverageCommand("", command.RunTestsArgs{
+			URI: env.Sandbox.Workdir.URI("a/a_test.go"),
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		env.ExecuteCommand(&protocol.ExecuteCommandParams{
+			Command:   cmd.Command,
+			Arguments: cmd.Arguments,
+		}, nil)
+		d := &protocol.PublishDiagnosticsParams{}
+		env.Await(
+			OnceMet(
+				CompletedWork("Running go test with coverage", 1, false),
+				ShownMessage("coverage: 66.7% of statements"),
+				env.DiagnosticAtRegexpWithMessage("a/a.go", `{\n\t\treturn -x`, "not covered by tests"),
+				ReadDiagnostics("a/a.go", d),
+			),
+		)
+		if len(d.Diagnostics) != 1 {
+			t.Fatalf("got %d diagnostics in a/a.go, want 1: %v", len(d.Diagnostics), d.Diagnostics)
+		}
+		if got := d.Diagnostics[0].Tags; len(got) != 1 || got[0] != protocol.Unnecessary {
+			t.Errorf("got tags %v for the uncovered block, want [Unnecessary]", got)
+		}
+	})
+}
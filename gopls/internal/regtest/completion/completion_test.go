@@ -549,6 +549,162 @@ func main() {
 	})
 }
 
+func TestUnimportedSymbolCompletion(t *testing.T) {
+	testenv.NeedsGo1Point(t, 14)
+
+	const proxy = `
+-- example.com@v1.2.3/go.mod --
+module example.com
+
+go 1.12
+-- example.com@v1.2.3/blah/blah.go --
+package blah
+
+const Hello = "Hello"
+
+const BlahGreeting = "Hello, Blah"
+`
+	const mod = `
+-- go.mod --
+module mod.com
+
+go 1.14
+
+require example.com v1.2.3
+-- main.go --
+package main
+
+func main() {
+	_ = ParseDur
+	_ = BlahGree
+}
+-- main2.go --
+package main
+
+import "example.com/blah"
+
+func _() {
+	_ = blah.Hello
+}
+`
+	WithOptions(
+		ProxyFiles(proxy),
+		EditorConfig{
+			Settings: map[string]interface{}{
+				"experimentalUnimportedSymbolsBudget": "5s",
+			},
+		},
+	).Run(t, mod, func(t *testing.T, env *Env) {
+		// Make sure the dependency is in the module cache, and then remove
+		// its import.
+		env.RunGoCommand("mod", "download", "example.com")
+		env.RemoveWorkspaceFile("main2.go")
+		env.RunGoCommand("mod", "tidy")
+		env.Await(env.DoneWithChangeWatchedFiles())
+
+		env.OpenFile("main.go")
+		env.Await(env.DoneWithOpen())
+
+		// The symbols of the module cache are found.
+		pos := env.RegexpSearch("main.go", "BlahGree()")
+		completions := env.Completion("main.go", pos)
+		found := false
+		for _, item := range completions.Items {
+			if item.Label == "blah.BlahGreeting" {
+				found = true
+			}
+		}
+		if !found {
+			t.Errorf("completion of BlahGree: got %v, want blah.BlahGreeting", completions.Items)
+		}
+
+		// Accepting a symbol of the standard library imports its package.
+		pos = env.RegexpSearch("main.go", "ParseDur()")
+		completions = env.Completion("main.go", pos)
+		var item *protocol.CompletionItem
+		for i := range completions.Items {
+			if completions.Items[i].Label == "time.ParseDuration" {
+				item = &completions.Items[i]
+			}
+		}
+		if item == nil {
+			t.Fatalf("completion of ParseDur: got %v, want time.ParseDuration", completions.Items)
+		}
+		env.AcceptCompletion("main.go", pos, *item)
+		env.Await(env.DoneWithChange())
+		got := env.Editor.BufferText("main.go")
+		want := "package main\n\nimport \"time\"\n\nfunc main() {\n\t_ = time.ParseDuration\n\t_ = BlahGree\n}\n"
+		if got != want {
+			t.Errorf("unimported symbol completion: got %q, want %q", got, want)
+		}
+	})
+}
+
+func TestUnimportedSymbolCompletionTypePosition(t *testing.T) {
+	testenv.NeedsGo1Point(t, 14)
+
+	const proxy = `
+-- example.com@v1.2.3/go.mod --
+module example.com
+
+go 1.12
+-- example.com@v1.2.3/blah/blah.go --
+package blah
+
+const BlahGreeting = "Hello, Blah"
+
+var BlahGreeter = func() {}
+`
+	const mod = `
+-- go.mod --
+module mod.com
+
+go 1.14
+
+require example.com v1.2.3
+-- main.go --
+package main
+
+func _(x BlahGree) {
+	var _ int = BlahGree
+}
+-- main2.go --
+package main
+
+import "example.com/blah"
+
+var _ = blah.BlahGreeting
+`
+	WithOptions(
+		ProxyFiles(proxy),
+		EditorConfig{
+			Settings: map[string]interface{}{
+				"experimentalUnimportedSymbolsBudget": "5s",
+			},
+		},
+	).Run(t, mod, func(t *testing.T, env *Env) {
+		env.RunGoCommand("mod", "download", "example.com")
+		env.RemoveWorkspaceFile("main2.go")
+		env.RunGoCommand("mod", "tidy")
+		env.Await(env.DoneWithChangeWatchedFiles())
+
+		env.OpenFile("main.go")
+		env.Await(env.DoneWithOpen())
+
+		// Neither a type position nor a value of a known type is offered
+		// the untyped symbols of unimported packages.
+		for _, pat := range []string{"x BlahGree()", "int = BlahGree()"} {
+			pos := env.RegexpSearch("main.go", pat)
+			completions := env.Completion("main.go", pos)
+			for _, item := range completions.Items {
+				if strings.HasPrefix(item.Label, "blah.") {
+					t.Errorf("completion of %q: got unwanted %s", pat, item.Label)
+				}
+			}
+		}
+	})
+}
+
 func TestDefinition(t *testing.T) {
 	stuff := `
 -- go.mod --
@@ -646,3 +802,103 @@ use ./dir/foobar/
 		}
 	})
 }
+
+func TestCompletionRanking(t *testing.T) {
+	const files = `
+-- go.mod --
+module mod.com
+
+go 1.12
+-- a/a.go --
+package a
+
+func Alpha() {}
+func Alpen() {}
+
+func _() {
+	Al
+}
+-- b/b.go --
+package b
+
+import "mod.com/a"
+
+func _() {
+	a.Alpha()
+	a.Alpha()
+	a.Alpha()
+}
+`
+	for _, ranking := range []bool{false, true} {
+		t.Run(fmt.Sprint(ranking), func(t *testing.T) {
+			WithOptions(
+				EditorConfig{
+					Settings: map[string]interface{}{
+						"experimentalCompletionRanking": ranking,
+					},
+				},
+			).Run(t, files, func(t *testing.T, env *Env) {
+				env.OpenFile("a/a.go")
+				env.Await(env.DoneWithOpen())
+				completions := env.Completion("a/a.go", env.RegexpSearch("a/a.go", `\tAl()`))
+				if len(completions.Items) < 2 {
+					t.Fatalf("got %d completion items, want at least 2", len(completions.Items))
+				}
+				// Alpha ranks first only if the ranking counts its uses in
+				// the workspace.
+				want := "Alpen"
+				if ranking {
+					want = "Alpha"
+				}
+				item := completions.Items[0]
+				if item.Label != want {
+					t.Errorf("first completion = %s, want %s", item.Label, want)
+				}
+				if got := item.Command != nil; got != ranking {
+					t.Errorf("completion has a command: %v, want %v", got, ranking)
+				}
+				if ranking && item.Command != nil && item.Command.Command != "gopls.accept_completion" {
+					t.Errorf("completion command = %s, want gopls.accept_completion", item.Command.Command)
+				}
+			})
+		})
+	}
+}
+
+func TestNewerStdSymbol(t *testing.T) {
+	testenv.NeedsGo1Point(t, 18) // strings.Cut was added in Go 1.18
+
+	const files = `
+-- go.mod --
+module mod.com
+
+go 1.17
+-- main.go --
+package main
+
+import "strings"
+
+func _() {
+	strings.Cu
+	strings.Cut("a=b", "=")
+}
+`
+	Run(t, files, func(t *testing.T, env *Env) {
+		env.OpenFile("main.go")
+		completions := env.Completion("main.go", env.RegexpSearch("main.go", `strings\.Cu()\n`))
+		var detail string
+		for _, item := range completions.Items {
+			if item.Label == "Cut" {
+				detail = item.Detail
+			}
+		}
+		if want := "(requires go1.18)"; !strings.HasSuffix(detail, want) {
+			t.Errorf("detail of the completion of strings.Cut = %q, want suffix %q", detail, want)
+		}
+
+		content, _ := env.Hover("main.go", env.RegexpSearch("main.go", `strings\.(Cut)\(`))
+		if want := "Added in go1.18"; !strings.Contains(content.Value, want) {
+			t.Errorf("hover of strings.Cut = %q, want it to contain %q", content.Value, want)
+		}
+	})
+}
@@ -264,6 +264,113 @@ for k := range foo {
 	keys = append(keys, k)
 }
 
+}
+`,
+		},
+		{
+			name: "slice_for",
+			before: `
+package foo
+
+func _() {
+	var foo []int
+	foo.for
+}
+`,
+			after: `
+package foo
+
+func _() {
+	var foo []int
+	for i := range foo {
+	$0
+}
+}
+`,
+		},
+		{
+			name: "map_for",
+			before: `
+package foo
+
+func _() {
+	var foo map[string]int
+	foo.for
+}
+`,
+			after: `
+package foo
+
+func _() {
+	var foo map[string]int
+	for k := range foo {
+	$0
+}
+}
+`,
+		},
+		{
+			name: "chan_range",
+			before: `
+package foo
+
+func _() {
+	var foo chan int
+	foo.range
+}
+`,
+			after: `
+package foo
+
+func _() {
+	var foo chan int
+	for e := range foo {
+	$0
+}
+}
+`,
+		},
+		{
+			name: "bool_if",
+			before: `
+package foo
+
+func _() {
+	var ok bool
+	ok.if
+}
+`,
+			after: `
+package foo
+
+func _() {
+	var ok bool
+	if ok {
+	$0
+}
+}
+`,
+		},
+		{
+			name: "error_wrap",
+			before: `
+package foo
+
+func foo() (int, error) {
+	var err error
+	err.wrap
+}
+`,
+			after: `
+package foo
+
+import "fmt"
+
+func foo() (int, error) {
+	var err error
+	if err != nil {
+	return 0, fmt.Errorf("$0: %w", err)
+}
 }
 `,
 		},
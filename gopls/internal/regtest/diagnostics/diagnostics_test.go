@@ -2266,3 +2266,85 @@ func F[T any](_ T) {
 		)
 	})
 }
+
+func TestDiagnosticPlatforms(t *testing.T) {
+	const files = `
+-- go.mod --
+module mod.com
+
+go 1.12
+-- main.go --
+package main
+
+func main() {
+	_ = sep
+}
+-- sep_other.go --
+//go:build !windows
+// +build !windows
+
+package main
+
+const sep = "/"
+`
+	WithOptions(
+		EditorConfig{
+			Settings: map[string]interface{}{
+				"diagnosticPlatforms": []interface{}{"windows/amd64", "windows/arm64"},
+			},
+		},
+	).Run(t, files, func(t *testing.T, env *Env) {
+		env.OpenFile("main.go")
+		env.Await(
+			env.DiagnosticAtRegexpWithMessage("main.go", "sep", "(windows/amd64, windows/arm64)"),
+		)
+		// Once sep is declared on windows too, the error goes away.
+		env.CreateBuffer("sep_windows.go", "package main\n\nconst sep = `\\`\n")
+		env.Await(
+			EmptyDiagnostics("main.go"),
+		)
+	})
+}
+
+func TestDiagnosticSeverities(t *testing.T) {
+	const files = `
+-- go.mod --
+module mod.com
+
+go 1.12
+-- main.go --
+package main
+
+import "fmt"
+
+func main() {
+	fmt.Printf("%d", "x")
+	var x int
+	x = x
+}
+`
+	WithOptions(
+		EditorConfig{
+			Settings: map[string]interface{}{
+				"diagnosticSeverities": map[string]interface{}{"printf": "error", "assign": "hint"},
+			},
+		},
+	).Run(t, files, func(t *testing.T, env *Env) {
+		env.OpenFile("main.go")
+		var d protocol.PublishDiagnosticsParams
+		env.Await(
+			OnceMet(
+				env.DiagnosticAtRegexpWithMessage("main.go", `fmt.Printf`, "wrong type"),
+				env.DiagnosticAtRegexpWithMessage("main.go", `x = x`, "self-assignment"),
+				ReadDiagnostics("main.go", &d),
+			),
+		)
+		got := make(map[string]protocol.DiagnosticSeverity)
+		for _, diag := range d.Diagnostics {
+			got[diag.Source] = diag.Severity
+		}
+		if got["printf"] != protocol.SeverityError || got["assign"] != protocol.SeverityHint {
+			t.Errorf("severities of printf and assign = %v, %v, want %v, %v", got["printf"], got["assign"], protocol.SeverityError, protocol.SeverityHint)
+		}
+	})
+}
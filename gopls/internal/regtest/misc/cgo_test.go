@@ -0,0 +1,97 @@
+// Copyright 2022 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package misc
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/iansmith/golang-x-tools/internal/lsp/protocol"
+	. "github.com/iansmith/golang-x-tools/internal/lsp/regtest"
+	"github.com/iansmith/golang-x-tools/internal/testenv"
+)
+
+const cgoFiles = `
+-- go.mod --
+module mod.com
+
+go 1.12
+-- a.go --
+package a
+
+/*
+typedef int number;
+
+int add(number a, number b) {
+	return a + b;
+}
+*/
+import "C"
+
+func Add() C.number {
+	return C.add(1, 2)
+}
+`
+
+func TestCgoNavigation(t *testing.T) {
+	testenv.NeedsTool(t, "cgo")
+	testenv.NeedsGo1Point(t, 15)
+
+	Run(t, cgoFiles, func(t *testing.T, env *Env) {
+		env.OpenFile("a.go")
+		use := env.RegexpSearch("a.go", `add\(1`)
+
+		// Definitions are in the preamble, not in the files generated by cgo.
+		file, pos := env.GoToDefinition("a.go", use)
+		if want := env.RegexpSearch("a.go", `add\(number`); file != "a.go" || pos != want {
+			t.Errorf("definition of C.add: got %s:%v, want a.go:%v", file, pos, want)
+		}
+		file, pos = env.GoToDefinition("a.go", env.RegexpSearch("a.go", `number {`))
+		if want := env.RegexpSearch("a.go", `number;`); file != "a.go" || pos != want {
+			t.Errorf("definition of C.number: got %s:%v, want a.go:%v", file, pos, want)
+		}
+
+		refs := env.References("a.go", use)
+		if len(refs) != 2 {
+			t.Fatalf("references of C.add: got %v, want 2 in a.go", refs)
+		}
+		for _, ref := range refs {
+			if got := env.Sandbox.Workdir.URIToPath(ref.URI); got != "a.go" {
+				t.Errorf("reference of C.add in %s, want a.go", got)
+			}
+		}
+
+		hover, _ := env.Hover("a.go", use)
+		if !strings.Contains(hover.Value, "func C.add(p0 C.int, p1 C.int) (r1 C.int)") {
+			t.Errorf("hover of C.add: got %q, want the signature using C names", hover.Value)
+		}
+	})
+}
+
+func TestCgoPreambleDiagnostics(t *testing.T) {
+	testenv.NeedsTool(t, "cgo")
+	testenv.NeedsGo1Point(t, 15)
+
+	Run(t, cgoFiles, func(t *testing.T, env *Env) {
+		env.OpenFile("a.go")
+		env.Await(env.DoneWithOpen())
+		env.RegexpReplace("a.go", `a \+ b;`, "a + undeclared;")
+		var d protocol.PublishDiagnosticsParams
+		env.Await(
+			OnceMet(
+				env.DoneWithChange(),
+				env.DiagnosticAtRegexpWithMessage("a.go", "undeclared;", "undeclared"),
+				ReadDiagnostics("a.go", &d),
+			),
+		)
+		for _, diag := range d.Diagnostics {
+			if strings.Contains(diag.Message, "golang.org/issue/38990") {
+				t.Errorf("got the generic cgo error %q, want the error of the C compiler", diag.Message)
+			}
+		}
+		env.RegexpReplace("a.go", "undeclared;", "b;")
+		env.Await(EmptyDiagnostics("a.go"))
+	})
+}
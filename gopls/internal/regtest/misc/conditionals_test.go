@@ -0,0 +1,218 @@
+// Copyright 2022 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package misc
+
+import (
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/iansmith/golang-x-tools/internal/lsp/protocol"
+	. "github.com/iansmith/golang-x-tools/internal/lsp/regtest"
+	"github.com/iansmith/golang-x-tools/internal/lsp/source"
+	"github.com/iansmith/golang-x-tools/internal/lsp/tests"
+)
+
+func TestRewriteConditionals(t *testing.T) {
+	for _, test := range []struct {
+		name, fix, src, re, want string
+	}{
+		{
+			name: "if to switch",
+			fix:  source.IfToSwitch,
+			src: `func f(x int) string {
+	if y := x * 2; x == 1 || 2 == x {
+		// One or two.
+		return "small"
+	} else if x == y+3 { return "odd" } else {
+		return "large"
+	}
+}`,
+			re: `else if`,
+			want: `func f(x int) string {
+	switch y := x * 2; x {
+	case 1, 2:
+		// One or two.
+		return "small"
+	case y + 3:
+		return "odd"
+	default:
+		return "large"
+	}
+}`,
+		},
+		{
+			name: "switch to if",
+			fix:  source.SwitchToIf,
+			src: `func f(x int, ok bool) string {
+	switch ok {
+	case x > 10, x == 3:
+		return "large"
+	default:
+		return "other"
+	case x < 0 || x > 5:
+		return "out"
+	}
+}`,
+			re: `switch`,
+			want: `func f(x int, ok bool) string {
+	if ok == (x > 10) || ok == (x == 3) {
+		return "large"
+	} else if ok == (x < 0 || x > 5) {
+		return "out"
+	} else {
+		return "other"
+	}
+}`,
+		},
+		{
+			name: "tagless switch to if",
+			fix:  source.SwitchToIf,
+			src: `func f(x int, ok bool) string {
+	switch {
+	case x < 0, !ok:
+		return "bad"
+	case x > 10:
+	}
+	return ""
+}`,
+			re: `switch`,
+			want: `func f(x int, ok bool) string {
+	if x < 0 || !ok {
+		return "bad"
+	} else if x > 10 {
+	}
+	return ""
+}`,
+		},
+		{
+			name: "invert if",
+			fix:  source.InvertIf,
+			src: `func f(x int, y float64, ok bool) string {
+	if x < 1 && y < 1 || !ok {
+		return "a"
+	} else {
+		return "b"
+	}
+}`,
+			re: `if x`,
+			want: `func f(x int, y float64, ok bool) string {
+	if (x >= 1 || !(y < 1)) && ok {
+		return "b"
+	} else {
+		return "a"
+	}
+}`,
+		},
+		{
+			name: "merge ifs",
+			fix:  source.MergeIfs,
+			src: `func f(x int, ok bool) string {
+	if x < 0 || x > 10 {
+		if ok {
+			return "out"
+		}
+	}
+	return ""
+}`,
+			re: `if x`,
+			want: `func f(x int, ok bool) string {
+	if (x < 0 || x > 10) && ok {
+		return "out"
+	}
+	return ""
+}`,
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			files := "-- go.mod --\nmodule mod.com\n\ngo 1.12\n-- a.go --\npackage a\n\n" + test.src + "\n"
+			Run(t, files, func(t *testing.T, env *Env) {
+				env.OpenFile("a.go")
+				if err := applyFix(env, test.fix, "a.go", test.re); err != nil {
+					t.Fatal(err)
+				}
+				want := "package a\n\n" + test.want + "\n"
+				if got := env.Editor.BufferText("a.go"); got != want {
+					t.Errorf("a.go after the rewrite:\n%s", tests.Diff(t, want, got))
+				}
+			})
+		})
+	}
+}
+
+func TestRewriteConditionalsBlockers(t *testing.T) {
+	const files = `
+-- go.mod --
+module mod.com
+
+go 1.12
+-- a.go --
+package a
+
+func f(xs []int) {
+	for _, x := range xs {
+		if x == 1 {
+			break
+		} else if x == 2 {
+			continue
+		}
+		switch x {
+		case 1:
+			fallthrough
+		case 2:
+		}
+	}
+}
+`
+	Run(t, files, func(t *testing.T, env *Env) {
+		env.OpenFile("a.go")
+		if err := applyFix(env, source.IfToSwitch, "a.go", `if x == 1`); err == nil || !strings.Contains(err.Error(), "break") {
+			t.Errorf("converting an if statement with a break statement to a switch statement returned %v, want a break error", err)
+		}
+		if err := applyFix(env, source.SwitchToIf, "a.go", `switch`); err == nil || !strings.Contains(err.Error(), "fallthrough") {
+			t.Errorf("converting a switch statement with a fallthrough statement to if statements returned %v, want a fallthrough error", err)
+		}
+	})
+}
+
+func TestRewriteConditionalsActions(t *testing.T) {
+	const files = `
+-- go.mod --
+module mod.com
+
+go 1.12
+-- a.go --
+package a
+
+func f(x int) int {
+	if x == 1 {
+		return 1
+	} else if x == 2 {
+		return 2
+	} else {
+		return 0
+	}
+}
+`
+	Run(t, files, func(t *testing.T, env *Env) {
+		env.OpenFile("a.go")
+		pos := env.RegexpSearch("a.go", `else if`).ToProtocolPosition()
+		actions, err := env.Editor.CodeAction(env.Ctx, "a.go", &protocol.Range{Start: pos, End: pos}, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var got []string
+		for _, a := range actions {
+			if a.Kind == protocol.RefactorRewrite {
+				got = append(got, a.Title)
+			}
+		}
+		sort.Strings(got)
+		want := []string{"Convert to switch statement", "Invert if condition"}
+		if strings.Join(got, "\n") != strings.Join(want, "\n") {
+			t.Errorf("rewrite actions of an else if statement = %q, want %q", got, want)
+		}
+	})
+}
@@ -0,0 +1,216 @@
+// Copyright 2022 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package misc
+
+import (
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/iansmith/golang-x-tools/internal/lsp/protocol"
+	. "github.com/iansmith/golang-x-tools/internal/lsp/regtest"
+	"github.com/iansmith/golang-x-tools/internal/lsp/tests"
+)
+
+const serverFiles = `
+-- go.mod --
+module mod.com
+
+go 1.12
+-- a/a.go --
+package a
+
+import "time"
+
+// Server serves requests.
+type Server struct {
+	Addr    string
+	timeout time.Duration
+	retries int
+}
+
+func (srv *Server) Retries() int { return srv.retries }
+`
+
+// generateStructCode runs the source.generate code action titled title
+// for the struct whose declaration is selected from the start of the
+// first match of start to the end of the first match of end in path, and
+// returns the titles of the source.generate code actions.
+func generateStructCode(t *testing.T, env *Env, path, start, end, title string) []string {
+	t.Helper()
+	rng := protocol.Range{
+		Start: env.RegexpSearch(path, start).ToProtocolPosition(),
+		End:   env.RegexpSearch(path, end).ToProtocolPosition(),
+	}
+	rng.End.Character += uint32(len(end))
+	actions, err := env.Editor.CodeAction(env.Ctx, path, &rng, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var titles []string
+	for _, action := range actions {
+		if action.Kind != protocol.SourceGenerate {
+			continue
+		}
+		titles = append(titles, action.Title)
+		if action.Title == title {
+			env.ExecuteCommand(&protocol.ExecuteCommandParams{
+				Command:   action.Command.Command,
+				Arguments: action.Command.Arguments,
+			}, nil)
+		}
+	}
+	sort.Strings(titles)
+	return titles
+}
+
+func TestGenerateConstructor(t *testing.T) {
+	Run(t, serverFiles, func(t *testing.T, env *Env) {
+		env.OpenFile("a/a.go")
+		titles := generateStructCode(t, env, "a/a.go", "Server struct", "Server", "Generate a constructor for Server")
+		want := []string{
+			"Generate a constructor for Server",
+			"Generate a constructor with options for Server",
+			"Generate getters for Server",
+			"Generate setters for Server",
+		}
+		if strings.Join(titles, "\n") != strings.Join(want, "\n") {
+			t.Errorf("got code actions %q, want %q", titles, want)
+		}
+		const wantSrc = `package a
+
+import "time"
+
+// Server serves requests.
+type Server struct {
+	Addr    string
+	timeout time.Duration
+	retries int
+}
+
+// NewServer returns a new Server.
+func NewServer(addr string, timeout time.Duration, retries int) *Server {
+	return &Server{
+		Addr:    addr,
+		timeout: timeout,
+		retries: retries,
+	}
+}
+
+func (srv *Server) Retries() int { return srv.retries }
+`
+		if got := env.Editor.BufferText("a/a.go"); got != wantSrc {
+			t.Errorf("a/a.go after generating the constructor:\n%s", tests.Diff(t, wantSrc, got))
+		}
+
+		// The constructor is declared.
+		titles = generateStructCode(t, env, "a/a.go", "Server struct", "Server", "")
+		if len(titles) != 2 || !strings.Contains(titles[0], "getters") {
+			t.Errorf("got code actions %q, want only getters and setters", titles)
+		}
+	})
+}
+
+func TestGenerateOptions(t *testing.T) {
+	Run(t, serverFiles, func(t *testing.T, env *Env) {
+		env.OpenFile("a/a.go")
+		generateStructCode(t, env, "a/a.go", "Addr", "Addr", "Generate a constructor with options for Server")
+		const want = `package a
+
+import "time"
+
+// Server serves requests.
+type Server struct {
+	Addr    string
+	timeout time.Duration
+	retries int
+}
+
+// NewServer returns a new Server.
+func NewServer(addr string, opts ...ServerOption) *Server {
+	srv := &Server{
+		Addr: addr,
+	}
+	for _, opt := range opts {
+		opt(srv)
+	}
+	return srv
+}
+
+// ServerOption sets an optional field of a Server.
+type ServerOption func(*Server)
+
+// WithTimeout sets the timeout of a Server.
+func WithTimeout(timeout time.Duration) ServerOption {
+	return func(srv *Server) {
+		srv.timeout = timeout
+	}
+}
+
+// WithRetries sets the retries of a Server.
+func WithRetries(retries int) ServerOption {
+	return func(srv *Server) {
+		srv.retries = retries
+	}
+}
+
+func (srv *Server) Retries() int { return srv.retries }
+`
+		if got := env.Editor.BufferText("a/a.go"); got != want {
+			t.Errorf("a/a.go after generating the constructor:\n%s", tests.Diff(t, want, got))
+		}
+	})
+}
+
+func TestGenerateAccessors(t *testing.T) {
+	const files = `
+-- go.mod --
+module mod.com
+
+go 1.18
+-- a/a.go --
+package a
+
+type cache[K comparable, V any] struct {
+	items map[K]V
+	size  int
+}
+`
+	Run(t, files, func(t *testing.T, env *Env) {
+		env.OpenFile("a/a.go")
+		generateStructCode(t, env, "a/a.go", "cache", "cache", "Generate getters for cache")
+		generateStructCode(t, env, "a/a.go", "size  int", "size  int", "Generate setters for cache")
+		const want = `package a
+
+type cache[K comparable, V any] struct {
+	items map[K]V
+	size  int
+}
+
+// SetItems sets the items of the cache.
+func (c *cache[K, V]) SetItems(items map[K]V) {
+	c.items = items
+}
+
+// SetSize sets the size of the cache.
+func (c *cache[K, V]) SetSize(size int) {
+	c.size = size
+}
+
+// Items returns the items of the cache.
+func (c *cache[K, V]) Items() map[K]V {
+	return c.items
+}
+
+// Size returns the size of the cache.
+func (c *cache[K, V]) Size() int {
+	return c.size
+}
+`
+		if got := env.Editor.BufferText("a/a.go"); got != want {
+			t.Errorf("a/a.go after generating the accessors:\n%s", tests.Diff(t, want, got))
+		}
+	})
+}
@@ -289,3 +289,104 @@ func TestGoToCrashingDefinition_Issue49223(t *testing.T) {
 		env.Editor.Server.Definition(env.Ctx, params)
 	})
 }
+
+func TestGoToLinknameDefinition(t *testing.T) {
+	const files = `
+-- go.mod --
+module mod.com
+
+go 1.12
+-- a/a.go --
+package a
+
+type T struct{}
+
+func (*T) m() int { return 1 }
+
+func hidden() int { return 2 }
+-- b/b.go --
+package b
+
+import (
+	_ "unsafe"
+
+	_ "mod.com/a"
+)
+
+//go:linkname hidden mod.com/a.hidden
+func hidden() int
+
+//go:linkname m mod.com/a.(*T).m
+func m() int
+`
+	Run(t, files, func(t *testing.T, env *Env) {
+		env.OpenFile("b/b.go")
+		for _, test := range []struct {
+			re       string // regexp locating the directive argument
+			wantFile string
+			wantRe   string // regexp locating the declaration
+		}{
+			{`mod.com/a.(hidden)`, "a/a.go", `func (hidden)`},
+			{`a.\(\*T\).(m)`, "a/a.go", `\) (m)\(`},
+			{`linkname (hidden)`, "b/b.go", `func (hidden)`},
+		} {
+			name, pos := env.GoToDefinition("b/b.go", env.RegexpSearch("b/b.go", test.re))
+			if name != test.wantFile {
+				t.Errorf("GoToDefinition(%s): got file %q, want %q", test.re, name, test.wantFile)
+				continue
+			}
+			if want := env.RegexpSearch(test.wantFile, test.wantRe); pos != want {
+				t.Errorf("GoToDefinition(%s): got position %v, want %v", test.re, pos, want)
+			}
+		}
+	})
+}
+
+const asmDefinition = `
+-- go.mod --
+module mod.com
+
+go 1.12
+-- add/add.go --
+package add
+
+// Add is implemented in assembly.
+func Add(x, y int64) int64
+
+func addGeneric(x, y int64) int64 { return x + y }
+-- add/add.s --
+#include "textflag.h"
+
+// func Add(x, y int64) int64
+TEXT ·Add(SB), NOSPLIT, $0-24
+	JMP ·addGeneric(SB)
+-- main.go --
+package main
+
+import "mod.com/add"
+
+func main() {
+	println(add.Add(1, 2))
+}
+`
+
+func TestGoToAsmDefinition(t *testing.T) {
+	Run(t, asmDefinition, func(t *testing.T, env *Env) {
+		env.OpenFile("main.go")
+		name, pos := env.GoToDefinition("main.go", env.RegexpSearch("main.go", `add.(Add)`))
+		if want := "add/add.s"; name != want {
+			t.Fatalf("GoToDefinition: got file %q, want %q", name, want)
+		}
+		if want := env.RegexpSearch("add/add.s", `·Add`); pos != want {
+			t.Errorf("GoToDefinition: got position %v, want %v", pos, want)
+		}
+
+		name, pos = env.GoToDefinition("add/add.s", env.RegexpSearch("add/add.s", `·addGeneric`))
+		if want := "add/add.go"; name != want {
+			t.Fatalf("GoToDefinition: got file %q, want %q", name, want)
+		}
+		if want := env.RegexpSearch("add/add.go", `func (addGeneric)`); pos != want {
+			t.Errorf("GoToDefinition: got position %v, want %v", pos, want)
+		}
+	})
+}
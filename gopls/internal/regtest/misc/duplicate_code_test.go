@@ -0,0 +1,91 @@
+// Copyright 2022 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package misc
+
+import (
+	"testing"
+
+	"github.com/iansmith/golang-x-tools/internal/lsp/command"
+	"github.com/iansmith/golang-x-tools/internal/lsp/protocol"
+	. "github.com/iansmith/golang-x-tools/internal/lsp/regtest"
+)
+
+func TestDuplicateCode(t *testing.T) {
+	const files = `
+-- go.mod --
+module mod.com
+
+go 1.14
+-- a/a.go --
+package a
+
+func Sum(xs []int) int {
+	total := 0
+	for _, x := range xs {
+		if x > 0 {
+			total += x
+		}
+	}
+	return total
+}
+-- b/b.go --
+package b
+
+func Total(xs []int) int {
+	total := 0
+	for _, x := range xs {
+		if x > 0 {
+			total += x
+		}
+	}
+	return total
+}
+`
+	WithOptions(
+		EditorConfig{
+			Settings: map[string]interface{}{
+				"duplicateCodeTokens": 20,
+			},
+		},
+	).Run(t, files, func(t *testing.T, env *Env) {
+		env.OpenFile("a/a.go")
+		var d protocol.PublishDiagnosticsParams
+		env.Await(
+			OnceMet(
+				env.DiagnosticAtRegexpWithMessage("a/a.go", `\(xs`, "duplicated code: 32 tokens also found at 1 other location"),
+				ReadDiagnostics("a/a.go", &d),
+			),
+		)
+		diags := diagnosticsWithMessage(&d, "duplicated code: 32 tokens also found at 1 other location")
+		if len(diags) != 1 {
+			t.Fatalf("got %d duplicated code diagnostics, want 1", len(diags))
+		}
+		if got := diags[0].Severity; got != protocol.SeverityHint {
+			t.Errorf("severity = %v, want %v", got, protocol.SeverityHint)
+		}
+
+		cmd, err := command.NewShowDuplicatesCommand("Show duplicates", command.ShowDuplicatesArgs{
+			URI:   env.Sandbox.Workdir.URI("a/a.go"),
+			Range: diags[0].Range,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		var result command.ShowDuplicatesResult
+		env.ExecuteCommand(&protocol.ExecuteCommandParams{
+			Command:   cmd.Command,
+			Arguments: cmd.Arguments,
+		}, &result)
+		if len(result.Locations) != 1 {
+			t.Fatalf("got %d duplicates, want 1", len(result.Locations))
+		}
+		if got := env.Sandbox.Workdir.URIToPath(result.Locations[0].URI); got != "b/b.go" {
+			t.Errorf("duplicate in %s, want b/b.go", got)
+		}
+		if got := result.Locations[0].Range.Start; got.Line != 2 || got.Character != 10 {
+			t.Errorf("duplicate at %d:%d, want 3:11", got.Line+1, got.Character+1)
+		}
+	})
+}
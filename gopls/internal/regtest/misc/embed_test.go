@@ -4,6 +4,8 @@
 package misc
 
 import (
+	"sort"
+	"strings"
 	"testing"
 
 	. "github.com/iansmith/golang-x-tools/internal/lsp/regtest"
@@ -35,3 +37,114 @@ var foo string
 		env.Await(EmptyDiagnostics("x.go"))
 	})
 }
+
+func TestEmbedDiagnosticsFollowFiles(t *testing.T) {
+	testenv.NeedsGo1Point(t, 16)
+	const files = `
+-- go.mod --
+module example.com
+-- x.go --
+package x
+
+import (
+	"embed"
+)
+
+//go:embed data.txt
+var data string
+
+//go:embed static
+var static embed.FS
+-- static/.hidden --
+not embedded
+`
+	Run(t, files, func(t *testing.T, env *Env) {
+		env.OpenFile("x.go")
+		env.Await(
+			env.DiagnosticAtRegexpWithMessage("x.go", `data.txt`, "pattern data.txt: no matching files found"),
+			env.DiagnosticAtRegexpWithMessage("x.go", `static\n`, "contains no embeddable files"),
+		)
+		env.WriteWorkspaceFiles(map[string]string{
+			"data.txt":          "data",
+			"static/index.html": "<html></html>",
+		})
+		env.Await(EmptyDiagnostics("x.go"))
+		env.RemoveWorkspaceFile("data.txt")
+		env.Await(env.DiagnosticAtRegexpWithMessage("x.go", `data.txt`, "no matching files found"))
+	})
+}
+
+func TestEmbedDefinition(t *testing.T) {
+	testenv.NeedsGo1Point(t, 16)
+	const files = `
+-- go.mod --
+module example.com
+-- x.go --
+package x
+
+import (
+	_ "embed"
+)
+
+//go:embed "data/hello.txt"
+var hello string
+-- data/hello.txt --
+hello
+`
+	Run(t, files, func(t *testing.T, env *Env) {
+		env.OpenFile("x.go")
+		name, _ := env.GoToDefinition("x.go", env.RegexpSearch("x.go", `hello.txt`))
+		if want := "data/hello.txt"; name != want {
+			t.Errorf("GoToDefinition: got file %q, want %q", name, want)
+		}
+	})
+}
+
+func TestEmbedCompletion(t *testing.T) {
+	testenv.NeedsGo1Point(t, 16)
+	const files = `
+-- go.mod --
+module example.com
+-- x.go --
+package x
+
+import (
+	"embed"
+)
+
+//go:embed data/
+var data embed.FS
+-- data/hello.txt --
+hello
+-- data/help.txt --
+help
+-- data/img/logo.png --
+logo
+-- data/.hidden --
+hidden
+`
+	Run(t, files, func(t *testing.T, env *Env) {
+		env.OpenFile("x.go")
+
+		pos := env.RegexpSearch("x.go", `data/()`)
+		var labels []string
+		for _, item := range env.Completion("x.go", pos).Items {
+			labels = append(labels, item.Label)
+		}
+		sort.Strings(labels)
+		if got, want := strings.Join(labels, " "), "hello.txt help.txt img/"; got != want {
+			t.Errorf("completion at data/: got labels %q, want %q", got, want)
+		}
+
+		env.RegexpReplace("x.go", `data/`, "data/hell")
+		pos = env.RegexpSearch("x.go", `data/hell()`)
+		completions := env.Completion("x.go", pos)
+		if len(completions.Items) != 1 || completions.Items[0].Label != "hello.txt" {
+			t.Fatalf("completion at data/hell: got %v, want hello.txt", completions.Items)
+		}
+		env.AcceptCompletion("x.go", pos, completions.Items[0])
+		if got := env.Editor.BufferText("x.go"); !strings.Contains(got, "//go:embed data/hello.txt\n") {
+			t.Errorf("after accepting completion, got:\n%s", got)
+		}
+	})
+}
@@ -0,0 +1,145 @@
+// Copyright 2022 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package misc
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/iansmith/golang-x-tools/internal/lsp/command"
+	"github.com/iansmith/golang-x-tools/internal/lsp/protocol"
+	. "github.com/iansmith/golang-x-tools/internal/lsp/regtest"
+	"github.com/iansmith/golang-x-tools/internal/lsp/tests"
+)
+
+const colorFiles = `
+-- go.mod --
+module mod.com
+
+go 1.12
+-- a/a.go --
+package a
+
+type Color uint8
+
+const (
+	Red Color = iota
+	Green
+	Blue
+	Crimson = Red
+)
+`
+
+// generateEnumMethods executes the gopls.generate_enum_methods command for
+// the first match of name in path, saves the generated file genPath, and
+// returns the error of the command.
+func generateEnumMethods(t *testing.T, env *Env, path, name, genPath string) error {
+	t.Helper()
+	pos := env.RegexpSearch(path, name)
+	cmd, err := command.NewGenerateEnumMethodsCommand("Generate enum methods", command.GenerateEnumMethodsArgs{
+		URI:   env.Sandbox.Workdir.URI(path),
+		Range: protocol.Range{Start: pos.ToProtocolPosition(), End: pos.ToProtocolPosition()},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := env.Editor.ExecuteCommand(env.Ctx, &protocol.ExecuteCommandParams{
+		Command:   cmd.Command,
+		Arguments: cmd.Arguments,
+	}); err != nil {
+		return err
+	}
+	if env.Editor.HasBuffer(genPath) {
+		env.SaveBufferWithoutActions(genPath)
+	}
+	env.CheckForFileChanges()
+	env.Await(env.DoneWithChangeWatchedFiles())
+	return nil
+}
+
+func TestGenerateEnumMethods(t *testing.T) {
+	Run(t, colorFiles, func(t *testing.T, env *Env) {
+		env.OpenFile("a/a.go")
+		if err := generateEnumMethods(t, env, "a/a.go", "Green", "a/color_string.go"); err != nil {
+			t.Fatal(err)
+		}
+		const want = `// Code generated by gopls; DO NOT EDIT.
+
+package a
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// String returns the name of c.
+func (c Color) String() string {
+	switch c {
+	case Red:
+		return "Red"
+	case Green:
+		return "Green"
+	case Blue:
+		return "Blue"
+	}
+	return "Color(" + strconv.FormatUint(uint64(c), 10) + ")"
+}
+
+// MarshalText implements encoding.TextMarshaler, returning the name of c.
+func (c Color) MarshalText() ([]byte, error) {
+	switch c {
+	case Red:
+		return []byte("Red"), nil
+	case Green:
+		return []byte("Green"), nil
+	case Blue:
+		return []byte("Blue"), nil
+	}
+	return nil, fmt.Errorf("invalid Color %d", c)
+}
+
+// ParseColor returns the Color named s.
+func ParseColor(s string) (Color, error) {
+	switch s {
+	case "Red":
+		return Red, nil
+	case "Green":
+		return Green, nil
+	case "Blue":
+		return Blue, nil
+	case "Crimson":
+		return Crimson, nil
+	}
+	return 0, fmt.Errorf("invalid Color %q", s)
+}
+`
+		if got := env.ReadWorkspaceFile("a/color_string.go"); got != want {
+			t.Errorf("a/color_string.go after gopls.generate_enum_methods:\n%s", tests.Diff(t, want, got))
+		}
+	})
+}
+
+func TestGenerateEnumMethodsExisting(t *testing.T) {
+	Run(t, colorFiles, func(t *testing.T, env *Env) {
+		env.OpenFile("a/a.go")
+		env.RegexpReplace("a/a.go", `type Color uint8`, "type Color uint8\n\nfunc (c Color) String() string { return \"color\" }")
+		env.SaveBuffer("a/a.go")
+		if err := generateEnumMethods(t, env, "a/a.go", "Color uint8", "a/color_string.go"); err != nil {
+			t.Fatal(err)
+		}
+		got := env.ReadWorkspaceFile("a/color_string.go")
+		if strings.Contains(got, "String()") || !strings.Contains(got, "func ParseColor(") {
+			t.Errorf("a/color_string.go does not have only MarshalText and ParseColor:\n%s", got)
+		}
+
+		// A file that is not generated is not overwritten.
+		env.WriteWorkspaceFile("a/color_string.go", "package a\n")
+		env.Await(env.DoneWithChangeWatchedFiles())
+		err := generateEnumMethods(t, env, "a/a.go", "Color uint8", "a/color_string.go")
+		if err == nil || !strings.Contains(err.Error(), "color_string.go exists and is not generated") {
+			t.Errorf("got error %v, want that color_string.go is not generated", err)
+		}
+	})
+}
@@ -0,0 +1,93 @@
+// Copyright 2022 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package misc
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/iansmith/golang-x-tools/internal/lsp/protocol"
+	. "github.com/iansmith/golang-x-tools/internal/lsp/regtest"
+)
+
+func TestLargeFileThreshold(t *testing.T) {
+	const files = `
+-- go.mod --
+module mod.com
+
+go 1.12
+-- a/a.go --
+package a
+
+func F(x int) {
+	x = x
+}
+`
+	WithOptions(
+		EditorConfig{
+			Settings: map[string]interface{}{
+				"largeFileThreshold": 20,
+				"semanticTokens":     true,
+			},
+		},
+	).Run(t, files, func(t *testing.T, env *Env) {
+		env.OpenFile("a/a.go")
+		// The self-assignment is not reported, as a/a.go is not analyzed.
+		env.Await(OnceMet(
+			env.DoneWithOpen(),
+			NoDiagnostics("a/a.go"),
+		))
+		tokens, err := env.Editor.Server.SemanticTokensFull(env.Ctx, &protocol.SemanticTokensParams{
+			TextDocument: protocol.TextDocumentIdentifier{URI: env.Sandbox.Workdir.URI("a/a.go")},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(tokens.Data) != 0 {
+			t.Errorf("semantic tokens of a large file = %v, want none", tokens.Data)
+		}
+		// The file is still formatted.
+		env.FormatBuffer("a/a.go")
+	})
+}
+
+func TestRefactorGeneratedFiles(t *testing.T) {
+	const files = `
+-- go.mod --
+module mod.com
+
+go 1.12
+-- a/a.go --
+package a
+
+func F() {}
+-- a/gen.go --
+// Code generated by gen. DO NOT EDIT.
+
+package a
+
+var _ = F
+`
+	Run(t, files, func(t *testing.T, env *Env) {
+		env.OpenFile("a/a.go")
+		err := env.Editor.Rename(env.Ctx, "a/a.go", env.RegexpSearch("a/a.go", "F"), "G")
+		if err == nil || !strings.Contains(err.Error(), "refactorGeneratedFiles") {
+			t.Errorf("renaming a symbol used in a generated file returned %v, want an error", err)
+		}
+	})
+	WithOptions(
+		EditorConfig{
+			Settings: map[string]interface{}{
+				"refactorGeneratedFiles": true,
+			},
+		},
+	).Run(t, files, func(t *testing.T, env *Env) {
+		env.OpenFile("a/a.go")
+		env.Rename("a/a.go", env.RegexpSearch("a/a.go", "F"), "G")
+		if got := env.Editor.BufferText("a/gen.go"); !strings.Contains(got, "var _ = G") {
+			t.Errorf("a/gen.go after the rename:\n%s", got)
+		}
+	})
+}
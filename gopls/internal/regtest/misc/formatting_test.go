@@ -8,6 +8,8 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/iansmith/golang-x-tools/internal/lsp/fake"
+	"github.com/iansmith/golang-x-tools/internal/lsp/protocol"
 	. "github.com/iansmith/golang-x-tools/internal/lsp/regtest"
 
 	"github.com/iansmith/golang-x-tools/internal/lsp/tests"
@@ -155,6 +157,58 @@ func TestOrganizeImports(t *testing.T) {
 	})
 }
 
+func TestOrganizeImportsGroups(t *testing.T) {
+	const files = `
+-- go.mod --
+module mod.com
+
+go 1.12
+-- main.go --
+package main
+
+import (
+	"errors"
+	"fmt"
+	"mod.com/lib"
+)
+
+func main() {
+	fmt.Println(errors.New(lib.Msg))
+}
+-- lib/lib.go --
+package lib
+
+const Msg = "bad"
+`
+	const want = `package main
+
+import (
+	"mod.com/lib"
+
+	"errors"
+	"fmt"
+)
+
+func main() {
+	fmt.Println(errors.New(lib.Msg))
+}
+`
+	WithOptions(
+		EditorConfig{
+			Settings: map[string]interface{}{
+				"importGroups": []interface{}{"module", "std"},
+			},
+		},
+	).Run(t, files, func(t *testing.T, env *Env) {
+		env.OpenFile("main.go")
+		env.OrganizeImports("main.go")
+		got := env.Editor.BufferText("main.go")
+		if got != want {
+			t.Errorf("unexpected formatting result:\n%s", tests.Diff(t, want, got))
+		}
+	})
+}
+
 func TestFormattingOnSave(t *testing.T) {
 	Run(t, disorganizedProgram, func(t *testing.T, env *Env) {
 		env.OpenFile("main.go")
@@ -367,3 +421,186 @@ const Bar = 42
 		}
 	})
 }
+
+func TestFormatters(t *testing.T) {
+	const files = `
+-- go.mod --
+module mod.com
+
+go 1.12
+-- main.go --
+package main
+
+import (
+	"mod.com/lib"
+
+	"errors"
+	"fmt"
+)
+
+func main() {
+	fmt.Println(errors.New(lib.Msg))
+}
+-- lib/lib.go --
+package lib
+
+const Msg = "bad"
+`
+	const want = `package main
+
+import (
+	"errors"
+	"fmt"
+
+	"mod.com/lib"
+)
+
+func main() {
+	fmt.Println(errors.New(lib.Msg))
+}
+`
+	WithOptions(
+		EditorConfig{
+			Settings: map[string]interface{}{
+				"importGroups": []interface{}{"std", "module"},
+				"formatters":   []interface{}{"imports"},
+			},
+		},
+	).Run(t, files, func(t *testing.T, env *Env) {
+		env.OpenFile("main.go")
+		env.FormatBuffer("main.go")
+		got := env.Editor.BufferText("main.go")
+		if got != want {
+			t.Errorf("unexpected formatting result:\n%s", tests.Diff(t, want, got))
+		}
+	})
+}
+
+func TestFormatterInvalidOutput(t *testing.T) {
+	WithOptions(
+		EditorConfig{
+			Settings: map[string]interface{}{
+				// The output of go version isn't Go code.
+				"formatters": []interface{}{"exec:go version"},
+			},
+		},
+	).Run(t, unformattedProgram, func(t *testing.T, env *Env) {
+		env.OpenFile("main.go")
+		before := env.Editor.BufferText("main.go")
+		err := env.Editor.FormatBuffer(env.Ctx, "main.go")
+		if err == nil || !strings.Contains(err.Error(), "produced invalid Go code") {
+			t.Errorf("formatting with an invalid formatter returned %v, want an invalid code error", err)
+		}
+		if got := env.Editor.BufferText("main.go"); got != before {
+			t.Errorf("formatting with an invalid formatter changed main.go:\n%s", tests.Diff(t, before, got))
+		}
+	})
+}
+
+func TestOnTypeFormatting(t *testing.T) {
+	const files = `
+-- go.mod --
+module mod.com
+
+go 1.12
+-- lit.go --
+package main
+
+type point struct{ X, Label int }
+
+var pts = []point{
+{X: 1, Label: 2},
+    {
+  X: 3,
+        Label: 4,
+  },
+}//closed
+
+var p = point{
+  X: 1,
+	Label: 2,
+	//newline
+}
+-- lit.go.golden --
+package main
+
+type point struct{ X, Label int }
+
+var pts = []point{
+	{X: 1, Label: 2},
+	{
+		X:     3,
+		Label: 4,
+	},
+}//closed
+
+var p = point{
+	X:     1,
+	Label: 2,
+	//newline
+}
+-- block.go --
+package main
+
+func main() {
+	if true {
+}
+-- block.go.golden --
+package main
+
+func main() {
+	if true {
+	}
+}
+-- open.go --
+package main
+
+var s = []int{
+`
+	Run(t, files, func(t *testing.T, env *Env) {
+		// typed returns the edits of the server after ch was typed at
+		// the text of name matching re, or its subgroup.
+		typed := func(name, re, ch string) []protocol.TextEdit {
+			t.Helper()
+			edits, err := env.Editor.Server.OnTypeFormatting(env.Ctx, &protocol.DocumentOnTypeFormattingParams{
+				TextDocument: protocol.TextDocumentIdentifier{URI: env.Sandbox.Workdir.URI(name)},
+				Position:     env.RegexpSearch(name, re).ToProtocolPosition(),
+				Ch:           ch,
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			return edits
+		}
+		apply := func(name string, edits []protocol.TextEdit) {
+			t.Helper()
+			var fakeEdits []fake.Edit
+			for _, e := range edits {
+				fakeEdits = append(fakeEdits, fake.NewEdit(int(e.Range.Start.Line), int(e.Range.Start.Character), int(e.Range.End.Line), int(e.Range.End.Character), e.NewText))
+			}
+			env.EditBuffer(name, fakeEdits...)
+		}
+		check := func(name string) {
+			t.Helper()
+			got := env.Editor.BufferText(name)
+			want := env.ReadWorkspaceFile(name + ".golden")
+			if got != want {
+				t.Errorf("unexpected on-type formatting result of %s:\n%s", name, tests.Diff(t, want, got))
+			}
+		}
+
+		env.OpenFile("lit.go")
+		apply("lit.go", typed("lit.go", "//closed", "}"))
+		apply("lit.go", typed("lit.go", "\t//newline", "\n"))
+		check("lit.go")
+
+		env.OpenFile("block.go")
+		apply("block.go", typed("block.go", `true \{()`, "{"))
+		check("block.go")
+
+		env.OpenFile("open.go")
+		if edits := typed("open.go", `int\{()`, "{"); len(edits) > 0 {
+			t.Errorf("typing { to open a composite literal returned edits %v, want none", edits)
+		}
+	})
+}
@@ -10,8 +10,10 @@
 package misc
 
 import (
+	"strings"
 	"testing"
 
+	"github.com/iansmith/golang-x-tools/internal/lsp/command"
 	. "github.com/iansmith/golang-x-tools/internal/lsp/regtest"
 )
 
@@ -73,3 +75,50 @@ func main() {
 		)
 	})
 }
+
+func TestRunGeneratorCodeLens(t *testing.T) {
+	const files = `
+-- go.mod --
+module fake.test
+
+go 1.14
+-- generate.go --
+// +build ignore
+
+package main
+
+import (
+	"io/ioutil"
+	"os"
+)
+
+func main() {
+	ioutil.WriteFile(os.Args[1]+".txt", []byte(os.Args[1]), 0644)
+}
+-- lib.go --
+package lib
+
+//go:generate go run generate.go one
+//go:generate go run generate.go two
+`
+	Run(t, files, func(t *testing.T, env *Env) {
+		env.OpenFile("lib.go")
+		var titles []string
+		for _, lens := range env.CodeLens("lib.go") {
+			titles = append(titles, lens.Command.Title)
+		}
+		if got, want := strings.Join(titles, ", "), "run go generate ./..., run go generate, run generator, run generator"; got != want {
+			t.Errorf("got code lenses %q, want %q", got, want)
+		}
+
+		// Run the last generator only.
+		env.ExecuteCodeLensCommand("lib.go", command.Generate)
+		env.Await(NoOutstandingWork())
+		if _, err := env.Sandbox.Workdir.ReadFile("two.txt"); err != nil {
+			t.Errorf("the generator did not run: %v", err)
+		}
+		if _, err := env.Sandbox.Workdir.ReadFile("one.txt"); err == nil {
+			t.Errorf("an unrelated generator ran")
+		}
+	})
+}
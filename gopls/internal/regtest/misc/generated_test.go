@@ -0,0 +1,119 @@
+// Copyright 2022 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package misc
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/iansmith/golang-x-tools/internal/lsp/command"
+	"github.com/iansmith/golang-x-tools/internal/lsp/protocol"
+	. "github.com/iansmith/golang-x-tools/internal/lsp/regtest"
+)
+
+const generatedFiles = `
+-- go.mod --
+module mod.com
+
+go 1.12
+-- a/a.go --
+package a
+
+//go:generate stringer -type=Pill
+//go:generate go run ./gen -output=tables.go
+
+type Pill int
+-- a/pill_string.go --
+// Code generated by "stringer -type=Pill"; DO NOT EDIT.
+
+package a
+-- a/tables.go --
+// Code generated by gen from tables.txt. DO NOT EDIT.
+
+package a
+-- a/tables.txt --
+tables
+`
+
+func TestShowGenerator(t *testing.T) {
+	Run(t, generatedFiles, func(t *testing.T, env *Env) {
+		for _, test := range []struct {
+			file string
+			want []string
+		}{
+			{"a/pill_string.go", []string{"a/a.go:3"}},
+			{"a/tables.go", []string{"a/a.go:4", "a/tables.txt:1"}},
+		} {
+			env.OpenFile(test.file)
+			var action *protocol.CodeAction
+			for _, a := range env.CodeAction(test.file, nil) {
+				if a.Title == "Show generator" {
+					a := a
+					action = &a
+				}
+			}
+			if action == nil {
+				t.Fatalf("no \"Show generator\" code action in %s", test.file)
+			}
+			var result command.ShowGeneratorResult
+			env.ExecuteCommand(&protocol.ExecuteCommandParams{
+				Command:   action.Command.Command,
+				Arguments: action.Command.Arguments,
+			}, &result)
+			if got := locationLines(env, result.Locations); fmt.Sprint(got) != fmt.Sprint(test.want) {
+				t.Errorf("generator of %s = %v, want %v", test.file, got, test.want)
+			}
+		}
+	})
+}
+
+func TestShowGenerated(t *testing.T) {
+	Run(t, generatedFiles, func(t *testing.T, env *Env) {
+		env.OpenFile("a/a.go")
+		for _, test := range []struct {
+			line uint32
+			want []string
+		}{
+			{2, []string{"a/pill_string.go:1"}},
+			{3, []string{"a/tables.go:1"}},
+		} {
+			rng := protocol.Range{
+				Start: protocol.Position{Line: test.line},
+				End:   protocol.Position{Line: test.line},
+			}
+			actions, err := env.Editor.CodeAction(env.Ctx, "a/a.go", &rng, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var action *protocol.CodeAction
+			for _, a := range actions {
+				if a.Title == "Show generated files" {
+					a := a
+					action = &a
+				}
+			}
+			if action == nil {
+				t.Fatalf("no \"Show generated files\" code action at line %d", test.line+1)
+			}
+			var result command.ShowGeneratedResult
+			env.ExecuteCommand(&protocol.ExecuteCommandParams{
+				Command:   action.Command.Command,
+				Arguments: action.Command.Arguments,
+			}, &result)
+			if got := locationLines(env, result.Locations); fmt.Sprint(got) != fmt.Sprint(test.want) {
+				t.Errorf("files generated at line %d = %v, want %v", test.line+1, got, test.want)
+			}
+		}
+	})
+}
+
+// locationLines returns the workspace paths and lines of locs.
+func locationLines(env *Env, locs []protocol.Location) []string {
+	var lines []string
+	for _, loc := range locs {
+		lines = append(lines, fmt.Sprintf("%s:%d", env.Sandbox.Workdir.URIToPath(loc.URI), loc.Range.Start.Line+1))
+	}
+	return lines
+}
@@ -5,7 +5,9 @@
 package misc
 
 import (
+	"reflect"
 	"sort"
+	"strings"
 	"testing"
 
 	"github.com/iansmith/golang-x-tools/internal/lsp/fake"
@@ -149,3 +151,96 @@ func checkHighlights(env *Env, file string, pos fake.Pos, highlightCount int) {
 		}
 	}
 }
+
+func TestFlowHighlights(t *testing.T) {
+	const mod = `
+-- go.mod --
+module mod.com
+
+go 1.12
+-- main.go --
+package main
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"os"
+)
+
+func fail() {
+	os.Exit(1)
+}
+
+func run(x int) {
+	if x < 0 {
+		fail()
+	}
+	for i := 0; i < x; i++ {
+		if i == 3 {
+			break
+		}
+		if i == 4 {
+			log.Fatal("four")
+		}
+		if i == 5 {
+			return
+		}
+	}
+	if x > 9 {
+		panic("large")
+	}
+}
+
+func check(x int) (err error) {
+	err = validate(x)
+	if err != nil {
+		return err
+	}
+	if err := validate(x + 1); err != nil {
+		return fmt.Errorf("next: %w", err)
+	}
+	err = errors.New("done")
+	return
+}
+
+func validate(int) error { return nil }
+
+func main() {}
+`
+	WithOptions(
+		EditorConfig{
+			Settings: map[string]interface{}{
+				"controlFlowHighlights": true,
+				"errorFlowHighlights":   true,
+			},
+		},
+	).Run(t, mod, func(t *testing.T, env *Env) {
+		env.OpenFile("main.go")
+		lines := strings.Split(env.Editor.BufferText("main.go"), "\n")
+		for _, test := range []struct {
+			re   string
+			want []string
+		}{
+			{`(return)\n\t\t}\n\t}`, []string{"func", "fail()", `log.Fatal("four")`, "return", `panic("large")`, "}"}},
+			{`break`, []string{"for", "break", `log.Fatal("four")`, "return"}},
+			{`(err) = validate`, []string{"err", "err", "validate(x)", "err", "return err", "err", "err", `errors.New("done")`, "return"}},
+		} {
+			highlights := env.DocumentHighlight("main.go", env.RegexpSearch("main.go", test.re))
+			sort.Slice(highlights, func(i, j int) bool {
+				return protocol.CompareRange(highlights[i].Range, highlights[j].Range) < 0
+			})
+			var got []string
+			for _, h := range highlights {
+				start, end := h.Range.Start, h.Range.End
+				if start.Line != end.Line {
+					t.Fatalf("highlight %v spans several lines", h.Range)
+				}
+				got = append(got, lines[start.Line][start.Character:end.Character])
+			}
+			if !reflect.DeepEqual(got, test.want) {
+				t.Errorf("highlights at %q = %q, want %q", test.re, got, test.want)
+			}
+		}
+	})
+}
@@ -221,3 +221,105 @@ func main() {
 		}
 	})
 }
+
+func TestHoverStructLayout(t *testing.T) {
+	const source = `
+-- go.mod --
+module mod.com
+
+go 1.12
+-- main.go --
+package main
+
+type T struct {
+	A bool
+	B int32
+	C bool
+}
+
+type U struct {
+	T
+}
+
+func main() {
+	var u U
+	_ = u.C
+}
+`
+	WithOptions(
+		EditorConfig{
+			Settings: map[string]interface{}{
+				"verboseHover": true,
+			},
+		},
+	).Run(t, source, func(t *testing.T, env *Env) {
+		env.OpenFile("main.go")
+		tests := []struct {
+			re   string
+			want []string
+		}{
+			{"type (T)", []string{"size 12, align 4, 6 bytes of padding", "8       1     1      C bool", "9       3            (padding)"}},
+			{"(A) bool", []string{"offset 0, size 1, align 1, followed by 3 bytes of padding"}},
+			{"u\\.(C)", []string{"offset 8, size 1, align 1, followed by 3 bytes of padding"}},
+		}
+		for _, test := range tests {
+			got, _ := env.Hover("main.go", env.RegexpSearch("main.go", test.re))
+			for _, want := range test.want {
+				if !strings.Contains(got.Value, want) {
+					t.Errorf("Hover(%q): got:\n%q\nwant:\n%q", test.re, got.Value, want)
+				}
+			}
+		}
+	})
+
+	Run(t, source, func(t *testing.T, env *Env) {
+		env.OpenFile("main.go")
+		got, _ := env.Hover("main.go", env.RegexpSearch("main.go", "type (T)"))
+		if strings.Contains(got.Value, "padding") {
+			t.Errorf("Hover: got layout without verbose hover:\n%q", got.Value)
+		}
+	})
+}
+
+func TestHoverDocLinks(t *testing.T) {
+	const source = `
+-- go.mod --
+module mod.com
+
+go 1.12
+-- lib/lib.go --
+package lib
+
+import "strings"
+
+// T is a value.
+type T struct{}
+
+// F returns a [T] and calls [strings.ToUpper], but not [Missing].
+func F() T {
+	_ = strings.ToUpper
+	return T{}
+}
+-- main.go --
+package main
+
+import "mod.com/lib"
+
+func main() {
+	_ = lib.F()
+}
+`
+	Run(t, source, func(t *testing.T, env *Env) {
+		env.OpenFile("main.go")
+		got, _ := env.Hover("main.go", env.RegexpSearch("main.go", "lib.(F)"))
+		for _, want := range []string{
+			"[T](" + string(env.Sandbox.Workdir.URI("lib/lib.go")) + "#L6,6)",
+			"[strings\\.ToUpper](https://pkg.go.dev/strings?utm_source=gopls#ToUpper)",
+			"\\[Missing\\]",
+		} {
+			if !strings.Contains(got.Value, want) {
+				t.Errorf("Hover: got:\n%q\nwant:\n%q", got.Value, want)
+			}
+		}
+	})
+}
@@ -0,0 +1,119 @@
+// Copyright 2022 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package misc
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/iansmith/golang-x-tools/internal/lsp/command"
+	"github.com/iansmith/golang-x-tools/internal/lsp/protocol"
+	. "github.com/iansmith/golang-x-tools/internal/lsp/regtest"
+)
+
+const importCycleFiles = `
+-- go.mod --
+module mod.com
+
+go 1.14
+-- a/a.go --
+package a
+
+import "mod.com/b"
+
+// A is a constant.
+const A = 1
+
+var _ = b.B
+-- b/b.go --
+package b
+
+import "mod.com/c"
+
+var B = c.C + c.D
+-- c/c.go --
+package c
+
+import "mod.com/a"
+
+const C, D = a.A, a.A + 1
+`
+
+func TestImportCycleEdges(t *testing.T) {
+	Run(t, importCycleFiles, func(t *testing.T, env *Env) {
+		var diags protocol.PublishDiagnosticsParams
+		env.OpenFile("c/c.go")
+		env.Await(
+			OnceMet(
+				env.DiagnosticAtRegexpWithMessage("c/c.go", `"mod.com/a"`, "import cycle not allowed: mod.com/c -> mod.com/a -> mod.com/b -> mod.com/c"),
+				ReadDiagnostics("c/c.go", &diags),
+			),
+			env.DiagnosticAtRegexpWithMessage("a/a.go", `"mod.com/b"`, "import cycle not allowed: mod.com/a -> mod.com/b -> mod.com/c -> mod.com/a"),
+			env.DiagnosticAtRegexpWithMessage("b/b.go", `"mod.com/c"`, "import cycle not allowed: mod.com/b -> mod.com/c -> mod.com/a -> mod.com/b"),
+		)
+
+		cmd, err := command.NewListImportReferencesCommand("List references", command.ImportCycleArgs{
+			URI:        env.Sandbox.Workdir.URI("b/b.go"),
+			ImportPath: "mod.com/c",
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		var refs command.ListImportReferencesResult
+		env.ExecuteCommand(&protocol.ExecuteCommandParams{
+			Command:   cmd.Command,
+			Arguments: cmd.Arguments,
+		}, &refs)
+		var names []string
+		for _, ref := range refs.References {
+			names = append(names, ref.Name)
+		}
+		if got, want := strings.Join(names, " "), "c.C c.D"; got != want {
+			t.Errorf("references to mod.com/c = %q, want %q", got, want)
+		}
+
+		var extract *protocol.CodeAction
+		for _, action := range env.GetQuickFixes("c/c.go", diags.Diagnostics) {
+			if action.Title == "Extract a.A into a new package" {
+				action := action
+				extract = &action
+			}
+		}
+		if extract == nil {
+			t.Fatalf("no quick fix extracting a.A in c/c.go")
+		}
+		env.ApplyCodeAction(*extract)
+		env.SaveBufferWithoutActions("a/a.go")
+		env.SaveBufferWithoutActions("c/c.go")
+
+		want := `package a
+
+import (
+	"mod.com/a/a"
+	"mod.com/b"
+)
+
+// A is a constant.
+const A = a.A
+
+var _ = b.B
+`
+		if got := env.ReadWorkspaceFile("a/a.go"); got != want {
+			t.Errorf("a/a.go:\n%s\nwant:\n%s", got, want)
+		}
+		want = `package a
+
+// A is a constant.
+const A = 1
+`
+		if got := env.ReadWorkspaceFile("a/a/a.go"); got != want {
+			t.Errorf("a/a/a.go:\n%s\nwant:\n%s", got, want)
+		}
+		env.Await(
+			EmptyDiagnostics("a/a.go"),
+			EmptyDiagnostics("c/c.go"),
+		)
+	})
+}
@@ -0,0 +1,93 @@
+// Copyright 2022 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package misc
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/iansmith/golang-x-tools/internal/lsp/protocol"
+	. "github.com/iansmith/golang-x-tools/internal/lsp/regtest"
+)
+
+func TestInlineValues(t *testing.T) {
+	const files = `
+-- go.mod --
+module mod.com
+
+go 1.12
+-- main.go --
+package main
+
+import "os"
+
+type point struct{ X, Y int }
+
+var count int
+
+func main() {
+	p := point{X: 1}
+	for i := 0; i < 2; i++ {
+		count += p.X
+	}
+	args := os.Args
+	later := len(args)
+	_ = later
+}
+`
+	Run(t, files, func(t *testing.T, env *Env) {
+		env.OpenFile("main.go")
+		stopped := env.RegexpSearch("main.go", "later :=").ToProtocolPosition()
+		stopped.Character = 0
+		values, err := env.Editor.Server.InlineValue(env.Ctx, &protocol.InlineValueParams{
+			TextDocument: protocol.TextDocumentIdentifier{URI: env.Sandbox.Workdir.URI("main.go")},
+			ViewPort: protocol.Range{
+				End: protocol.Position{Line: 17},
+			},
+			Context: protocol.InlineValueContext{
+				StoppedLocation: &protocol.Range{Start: stopped, End: stopped},
+			},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		// The values arrive as generic JSON values; decode them again.
+		data, err := json.Marshal(values)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var decoded []struct {
+			Range        protocol.Range
+			VariableName string
+			Expression   string
+		}
+		if err := json.Unmarshal(data, &decoded); err != nil {
+			t.Fatal(err)
+		}
+		var got []string
+		for _, v := range decoded {
+			if v.VariableName != "" {
+				got = append(got, fmt.Sprintf("%d: lookup %s", v.Range.Start.Line, v.VariableName))
+			} else {
+				got = append(got, fmt.Sprintf("%d: evaluate %s", v.Range.Start.Line, v.Expression))
+			}
+		}
+		// i is out of scope at the stop location, later is not yet
+		// assigned, and os is not a variable.
+		want := []string{
+			"9: lookup p",
+			"11: evaluate main.count",
+			"11: evaluate p.X",
+			"11: lookup p",
+			"13: lookup args",
+			"13: evaluate os.Args",
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("inline values:\ngot  %q\nwant %q", got, want)
+		}
+	})
+}
@@ -0,0 +1,144 @@
+// Copyright 2022 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package misc
+
+import (
+	"testing"
+
+	"github.com/iansmith/golang-x-tools/internal/lsp/command"
+	"github.com/iansmith/golang-x-tools/internal/lsp/protocol"
+	. "github.com/iansmith/golang-x-tools/internal/lsp/regtest"
+	"github.com/iansmith/golang-x-tools/internal/lsp/tests"
+)
+
+func TestGenerateStructFromJSON(t *testing.T) {
+	const files = `
+-- go.mod --
+module mod.com
+
+go 1.12
+-- a.go --
+package a
+
+// Users
+
+func F() {}
+`
+	Run(t, files, func(t *testing.T, env *Env) {
+		env.OpenFile("a.go")
+		pos := env.RegexpSearch("a.go", `func F`)
+		cmd, err := command.NewGenerateStructFromJSONCommand("Generate a struct from JSON", command.GenerateStructFromJSONArgs{
+			URI:      env.Sandbox.Workdir.URI("a.go"),
+			Position: pos.ToProtocolPosition(),
+			Name:     "User",
+			JSON:     `[{"id": 1, "full_name": "Ada"}, {"id": 2, "email": "a@b.c"}]`,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		env.ExecuteCommand(&protocol.ExecuteCommandParams{
+			Command:   cmd.Command,
+			Arguments: cmd.Arguments,
+		}, nil)
+
+		const want = "package a\n\n// Users\n\n" +
+			"type User struct {\n" +
+			"\tID       int64  `json:\"id\"`\n" +
+			"\tFullName string `json:\"full_name,omitempty\"`\n" +
+			"\tEmail    string `json:\"email,omitempty\"`\n" +
+			"}\n" +
+			"func F() {}\n"
+		if got := env.Editor.BufferText("a.go"); got != want {
+			t.Errorf("a.go after gopls.generate_struct_from_json:\n%s", tests.Diff(t, want, got))
+		}
+	})
+}
+
+func TestAddUnknownField(t *testing.T) {
+	const files = `
+-- go.mod --
+module mod.com
+
+go 1.12
+-- a.go --
+package a
+
+type Config struct {
+	ServerName string ` + "`json:\"server_name\"`" + `
+}
+-- b.go --
+package a
+
+var _ = Config{ServerName: "a", MaxConns: 10}
+`
+	Run(t, files, func(t *testing.T, env *Env) {
+		// The fix edits the declaration of Config, in a.go.
+		env.OpenFile("a.go")
+		env.OpenFile("b.go")
+		var d protocol.PublishDiagnosticsParams
+		env.Await(
+			OnceMet(
+				env.DiagnosticAtRegexpWithMessage("b.go", "MaxConns", "unknown field MaxConns"),
+				ReadDiagnostics("b.go", &d),
+			),
+		)
+		env.ApplyQuickFixes("b.go", d.Diagnostics)
+
+		const want = `package a
+
+type Config struct {
+	ServerName string ` + "`json:\"server_name\"`" + `
+	MaxConns int ` + "`json:\"max_conns\"`" + `
+}
+`
+		if got := env.Editor.BufferText("a.go"); got != want {
+			t.Errorf("a.go after the quick fix:\n%s", tests.Diff(t, want, got))
+		}
+	})
+}
+
+func TestDeclareMissingMethod(t *testing.T) {
+	const files = `
+-- go.mod --
+module mod.com
+
+go 1.12
+-- a.go --
+package a
+
+type Server struct{}
+-- b.go --
+package a
+
+func Run(s *Server, port int) error {
+	return s.Listen(port)
+}
+`
+	Run(t, files, func(t *testing.T, env *Env) {
+		// The fix edits the declaration of Server, in a.go.
+		env.OpenFile("a.go")
+		env.OpenFile("b.go")
+		var d protocol.PublishDiagnosticsParams
+		env.Await(
+			OnceMet(
+				env.DiagnosticAtRegexpWithMessage("b.go", "Listen", "has no field or method Listen"),
+				ReadDiagnostics("b.go", &d),
+			),
+		)
+		env.ApplyQuickFixes("b.go", d.Diagnostics)
+
+		const want = `package a
+
+type Server struct{}
+
+func (s *Server) Listen(port int) error {
+	panic("unimplemented")
+}
+`
+		if got := env.Editor.BufferText("a.go"); got != want {
+			t.Errorf("a.go after the quick fix:\n%s", tests.Diff(t, want, got))
+		}
+	})
+}
@@ -0,0 +1,45 @@
+// Copyright 2022 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package misc
+
+import (
+	"testing"
+
+	. "github.com/iansmith/golang-x-tools/internal/lsp/regtest"
+)
+
+func TestLazyTypeCheck(t *testing.T) {
+	const files = `
+-- go.mod --
+module mod.com
+
+go 1.12
+-- a/a.go --
+package a
+
+var X int = "a"
+-- b/b.go --
+package b
+
+var Y int = "b"
+`
+	WithOptions(
+		EditorConfig{
+			Settings: map[string]interface{}{
+				"lazyTypeCheck": true,
+			},
+		},
+	).Run(t, files, func(t *testing.T, env *Env) {
+		env.OpenFile("a/a.go")
+		// Only the package of the open file is type-checked.
+		env.Await(OnceMet(
+			env.DoneWithOpen(),
+			env.DiagnosticAtRegexp("a/a.go", `"a"`),
+			NoDiagnostics("b/b.go"),
+		))
+		env.OpenFile("b/b.go")
+		env.Await(env.DiagnosticAtRegexp("b/b.go", `"b"`))
+	})
+}
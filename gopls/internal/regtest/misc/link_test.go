@@ -5,9 +5,11 @@
 package misc
 
 import (
+	"regexp"
 	"strings"
 	"testing"
 
+	"github.com/iansmith/golang-x-tools/internal/lsp/protocol"
 	. "github.com/iansmith/golang-x-tools/internal/lsp/regtest"
 
 	"github.com/iansmith/golang-x-tools/internal/testenv"
@@ -96,3 +98,118 @@ const Hello = "Hello"
 		}
 	})
 }
+
+func TestPrivateLinkTarget(t *testing.T) {
+	testenv.NeedsGo1Point(t, 13)
+
+	const program = `
+-- go.mod --
+module mod.test
+
+go 1.12
+
+require import.test v1.2.3
+-- go.sum --
+import.test v1.2.3 h1:Mu4N9BICLJFxwwn8YNg6T3frkFWW1O7evXvo0HiRjBc=
+import.test v1.2.3/go.mod h1:KooCN1g237upRg7irU7F+3oADn5tVClU8YYW4I1xhMk=
+-- main.go --
+package main
+
+import "import.test/pkg"
+
+func main() {
+	println(pkg.Hello)
+}`
+
+	const proxy = `
+-- import.test@v1.2.3/go.mod --
+module import.test
+
+go 1.12
+-- import.test@v1.2.3/pkg/const.go --
+package pkg
+
+const Hello = "Hello"
+`
+	WithOptions(
+		ProxyFiles(proxy),
+		EditorConfig{
+			Settings: map[string]interface{}{
+				"privateLinkTarget": "godoc.internal.test",
+			},
+		},
+	).Run(t, program, func(t *testing.T, env *Env) {
+		env.OpenFile("main.go")
+		env.OpenFile("go.mod")
+		env.Await(env.DoneWithOpen())
+
+		// Make the links private once the module has been downloaded.
+		env.ChangeEnv(map[string]string{"GOPRIVATE": "import.test"})
+
+		modLink := "https://godoc.internal.test/mod/import.test@v1.2.3"
+		pkgLink := "https://godoc.internal.test/import.test/pkg"
+
+		content, _ := env.Hover("main.go", env.RegexpSearch("main.go", "pkg.Hello"))
+		if content == nil || !strings.Contains(content.Value, pkgLink) {
+			t.Errorf("hover: got %v in main.go, want contains %q", content, pkgLink)
+		}
+		content, _ = env.Hover("go.mod", env.RegexpSearch("go.mod", "import.test"))
+		if content == nil || !strings.Contains(content.Value, pkgLink) {
+			t.Errorf("hover: got %v in go.mod, want contains %q", content, pkgLink)
+		}
+		links := env.DocumentLink("main.go")
+		if len(links) != 1 || links[0].Target != pkgLink {
+			t.Errorf("documentLink: got %v for main.go, want link to %q", links, pkgLink)
+		}
+		links = env.DocumentLink("go.mod")
+		if len(links) != 1 || links[0].Target != modLink {
+			t.Errorf("documentLink: got %v for go.mod, want link to %q", links, modLink)
+		}
+	})
+}
+
+func TestGenerateDocumentLinks(t *testing.T) {
+	const files = `
+-- go.mod --
+module mod.test
+
+go 1.12
+-- gen/gen.go --
+// +build ignore
+
+package main
+
+func main() {}
+-- gen/lib.go --
+package gen
+
+//go:generate go run -mod=mod golang.org/x/tools/cmd/stringer@v0.1.10 -type=Kind
+//go:generate go run gen.go
+//go:generate go run ./missing.go
+//go:generate stringer -type=Kind
+
+type Kind int
+`
+	Run(t, files, func(t *testing.T, env *Env) {
+		env.OpenFile("gen/lib.go")
+		want := map[string]string{
+			"https://pkg.go.dev/golang.org/x/tools/cmd/stringer@v0.1.10?utm_source=gopls": "golang.org/x/tools/cmd/stringer@v0.1.10",
+			string(env.Sandbox.Workdir.URI("gen/gen.go")):                                 "gen.go",
+		}
+		links := env.DocumentLink("gen/lib.go")
+		if len(links) != len(want) {
+			t.Fatalf("documentLink: got %v, want %d links", links, len(want))
+		}
+		for _, link := range links {
+			text, ok := want[link.Target]
+			if !ok {
+				t.Errorf("documentLink: unexpected link to %q", link.Target)
+				continue
+			}
+			start, end := env.RegexpRange("gen/lib.go", regexp.QuoteMeta(text))
+			if rng := (protocol.Range{Start: start.ToProtocolPosition(), End: end.ToProtocolPosition()}); rng != link.Range {
+				t.Errorf("documentLink: got range %v for %q, want %v", link.Range, link.Target, rng)
+			}
+		}
+	})
+}
@@ -0,0 +1,92 @@
+// Copyright 2022 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package misc
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/iansmith/golang-x-tools/internal/lsp/protocol"
+	. "github.com/iansmith/golang-x-tools/internal/lsp/regtest"
+)
+
+func TestLinkedEditingRange(t *testing.T) {
+	const files = `
+-- go.mod --
+module mod.com
+
+go 1.12
+-- main.go --
+package main
+
+import "fmt"
+
+type User struct {
+	UserName string ` + "`json:\"user_name\" db:\"user_name,omitempty\"`" + `
+}
+
+var global = 1
+
+func main() {
+	total := global
+	for i := range []int{1, 2} {
+		total += i
+	}
+	f := func() { fmt.Println(total) }
+	f()
+	global = total
+}
+
+func param(n int) int {
+	return n
+}
+`
+	Run(t, files, func(t *testing.T, env *Env) {
+		env.OpenFile("main.go")
+		ranges := func(re string) []string {
+			t.Helper()
+			result, err := env.Editor.Server.LinkedEditingRange(env.Ctx, &protocol.LinkedEditingRangeParams{
+				TextDocumentPositionParams: protocol.TextDocumentPositionParams{
+					TextDocument: protocol.TextDocumentIdentifier{URI: env.Sandbox.Workdir.URI("main.go")},
+					Position:     env.RegexpSearch("main.go", re).ToProtocolPosition(),
+				},
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if result == nil {
+				return nil
+			}
+			var got []string
+			for _, r := range result.Ranges {
+				got = append(got, fmt.Sprintf("%d:%d-%d", r.Start.Line+1, r.Start.Character+1, r.End.Character+1))
+			}
+			return got
+		}
+		for _, test := range []struct {
+			re   string
+			want []string
+		}{
+			// The variable of a short variable declaration, from its
+			// declaration or a use, including in a function literal.
+			{`total :=`, []string{"12:2-7", "14:3-8", "16:28-33", "18:11-16"}},
+			{`total\)`, []string{"12:2-7", "14:3-8", "16:28-33", "18:11-16"}},
+			// A variable of a range clause.
+			{`i :=`, []string{"13:6-7", "14:12-13"}},
+			// A repeated word of a struct tag.
+			{`user_name" db`, []string{"6:25-34", "6:40-49"}},
+			// Package-level variables, parameters and words of struct tags
+			// that are not repeated are not linked.
+			{`global =`, nil},
+			{`n int`, nil},
+			{`omitempty`, nil},
+		} {
+			if got := ranges(test.re); !reflect.DeepEqual(got, test.want) {
+				t.Errorf("linked editing ranges at %q = %q, want %q", test.re, got, test.want)
+			}
+		}
+	})
+}
@@ -0,0 +1,104 @@
+// Copyright 2022 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package misc
+
+import (
+	"testing"
+
+	"github.com/iansmith/golang-x-tools/internal/lsp/command"
+	"github.com/iansmith/golang-x-tools/internal/lsp/protocol"
+	. "github.com/iansmith/golang-x-tools/internal/lsp/regtest"
+	"github.com/iansmith/golang-x-tools/internal/lsp/tests"
+)
+
+func TestMovePackage(t *testing.T) {
+	const files = `
+-- go.mod --
+module mod.com
+
+go 1.12
+-- a/a.go --
+package a
+
+func F() {}
+-- a/sub/sub.go --
+package sub
+
+const C = 1
+-- b/b.go --
+package b
+
+import (
+	"mod.com/a"
+	"mod.com/a/sub"
+)
+
+func G() int {
+	a.F()
+	return sub.C
+}
+-- c/c.go --
+package c
+
+import "mod.com/a"
+
+func H(d int) int {
+	a.F()
+	return d
+}
+`
+	Run(t, files, func(t *testing.T, env *Env) {
+		env.OpenFile("a/a.go")
+		cmd, err := command.NewMovePackageCommand("Move package", command.MovePackageArgs{
+			URI:    env.Sandbox.Workdir.URI("a/a.go"),
+			NewDir: env.Sandbox.Workdir.URI("d"),
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		env.ExecuteCommand(&protocol.ExecuteCommandParams{
+			Command:   cmd.Command,
+			Arguments: cmd.Arguments,
+		}, nil)
+
+		want := map[string]string{
+			"d/a.go": `package d
+
+func F() {}
+`,
+			"d/sub/sub.go": `package sub
+
+const C = 1
+`,
+			"b/b.go": `package b
+
+import (
+	"mod.com/d"
+	"mod.com/d/sub"
+)
+
+func G() int {
+	d.F()
+	return sub.C
+}
+`,
+			// c.go already uses the name d, so it keeps the old name.
+			"c/c.go": `package c
+
+import a "mod.com/d"
+
+func H(d int) int {
+	a.F()
+	return d
+}
+`,
+		}
+		for name, want := range want {
+			if got := env.ReadWorkspaceFile(name); got != want {
+				t.Errorf("%s after gopls.move_package:\n%s", name, tests.Diff(t, want, got))
+			}
+		}
+	})
+}
@@ -0,0 +1,91 @@
+// Copyright 2022 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package misc
+
+import (
+	"testing"
+
+	"github.com/iansmith/golang-x-tools/internal/lsp/protocol"
+	. "github.com/iansmith/golang-x-tools/internal/lsp/regtest"
+	"github.com/iansmith/golang-x-tools/internal/lsp/tests"
+)
+
+const newFileFiles = `
+-- go.mod --
+module mod.com
+
+go 1.12
+-- a/a.go --
+package a
+
+func F() {}
+-- a/a_test.go --
+package a_test
+`
+
+func TestNewFileCodeAction(t *testing.T) {
+	WithOptions(
+		EditorConfig{
+			Settings: map[string]interface{}{
+				"newFileTemplate": "// {{.File}} is part of package {{.Package}}.",
+			},
+		},
+	).Run(t, newFileFiles, func(t *testing.T, env *Env) {
+		env.CreateBuffer("a/b.go", "")
+		var found bool
+		for _, action := range env.CodeAction("a/b.go", nil) {
+			if action.Title == "Add package clause" {
+				env.ApplyCodeAction(action)
+				found = true
+			}
+		}
+		if !found {
+			t.Fatal("no \"Add package clause\" code action for an empty file")
+		}
+		const want = `// b.go is part of package a.
+
+package a
+`
+		if got := env.Editor.BufferText("a/b.go"); got != want {
+			t.Errorf("a/b.go after the code action:\n%s", tests.Diff(t, want, got))
+		}
+	})
+}
+
+func TestWillCreateFiles(t *testing.T) {
+	Run(t, newFileFiles, func(t *testing.T, env *Env) {
+		env.OpenFile("a/a.go")
+		edit, err := env.Editor.Server.WillCreateFiles(env.Ctx, &protocol.CreateFilesParams{
+			Files: []protocol.FileCreate{
+				{URI: string(env.Sandbox.Workdir.URI("a/foo_bar_test.go"))},
+				{URI: string(env.Sandbox.Workdir.URI("c/c.go"))},
+			},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		want := map[protocol.DocumentURI]string{
+			// The test package follows that of the other test files.
+			env.Sandbox.Workdir.URI("a/foo_bar_test.go"): `package a_test
+
+import "testing"
+
+func TestFooBar(t *testing.T) {
+}
+`,
+			// A new directory is named after its package.
+			env.Sandbox.Workdir.URI("c/c.go"): "package c\n",
+		}
+		if edit == nil || len(edit.DocumentChanges) != len(want) {
+			t.Fatalf("willCreateFiles returned %v, want edits of %d files", edit, len(want))
+		}
+		for _, change := range edit.DocumentChanges {
+			uri := change.TextDocumentEdit.TextDocument.URI
+			if got := change.TextDocumentEdit.Edits[0].NewText; got != want[uri] {
+				t.Errorf("new content of %s:\n%s", uri, tests.Diff(t, want[uri], got))
+			}
+		}
+	})
+}
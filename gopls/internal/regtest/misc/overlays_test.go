@@ -0,0 +1,86 @@
+// Copyright 2022 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package misc
+
+import (
+	"testing"
+
+	"github.com/iansmith/golang-x-tools/internal/lsp/command"
+	"github.com/iansmith/golang-x-tools/internal/lsp/protocol"
+	. "github.com/iansmith/golang-x-tools/internal/lsp/regtest"
+)
+
+func TestToolOverlays(t *testing.T) {
+	const files = `
+-- go.mod --
+module mod.com
+
+go 1.12
+-- a/a.go --
+package a
+
+func F() int { return 0 }
+-- b/b.go --
+package b
+
+import "mod.com/a"
+
+var X int = a.F()
+`
+	Run(t, files, func(t *testing.T, env *Env) {
+		env.OpenFile("b/b.go")
+		env.Await(OnceMet(
+			env.DoneWithOpen(),
+			NoDiagnostics("b/b.go"),
+		))
+
+		// The proposed change of a/a.go breaks b/b.go.
+		cmd, err := command.NewSetOverlaysCommand("", command.SetOverlaysArgs{
+			Overlays: []command.FileOverlay{{
+				URI:     env.Sandbox.Workdir.URI("a/a.go"),
+				Content: "package a\n\nfunc F() string { return \"\" }\n",
+			}},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		env.ExecuteCommand(&protocol.ExecuteCommandParams{
+			Command:   cmd.Command,
+			Arguments: cmd.Arguments,
+		}, nil)
+		env.Await(env.DiagnosticAtRegexp("b/b.go", `a.F\(\)`))
+		if got := env.ReadWorkspaceFile("a/a.go"); got != "package a\n\nfunc F() int { return 0 }\n" {
+			t.Errorf("set_overlays modified a/a.go on disk:\n%s", got)
+		}
+
+		// Files open in the editor can't be overlaid.
+		cmd, err = command.NewSetOverlaysCommand("", command.SetOverlaysArgs{
+			Overlays: []command.FileOverlay{{
+				URI:     env.Sandbox.Workdir.URI("b/b.go"),
+				Content: "package b\n",
+			}},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := env.Editor.ExecuteCommand(env.Ctx, &protocol.ExecuteCommandParams{
+			Command:   cmd.Command,
+			Arguments: cmd.Arguments,
+		}); err == nil {
+			t.Error("set_overlays of a file open in the editor succeeded")
+		}
+
+		// Clearing the overlays restores the content on disk.
+		cmd, err = command.NewClearOverlaysCommand("", command.URIArgs{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		env.ExecuteCommand(&protocol.ExecuteCommandParams{
+			Command:   cmd.Command,
+			Arguments: cmd.Arguments,
+		}, nil)
+		env.Await(EmptyDiagnostics("b/b.go"))
+	})
+}
@@ -0,0 +1,104 @@
+// Copyright 2022 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package misc
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/iansmith/golang-x-tools/internal/lsp/command"
+	"github.com/iansmith/golang-x-tools/internal/lsp/protocol"
+	. "github.com/iansmith/golang-x-tools/internal/lsp/regtest"
+)
+
+const packageGraphFiles = `
+-- go.mod --
+module mod.com
+
+go 1.14
+-- a/a.go --
+package a
+
+import "mod.com/b"
+
+var _ = b.B
+-- a/a_test.go --
+package a
+
+import "testing"
+
+func TestA(t *testing.T) {}
+-- b/b.go --
+package b
+
+import "fmt"
+
+var B = fmt.Sprint()
+`
+
+// packageGraph executes the gopls.package_graph command and returns the
+// document.
+func packageGraph(t *testing.T, env *Env, args command.PackageGraphArgs) string {
+	t.Helper()
+	cmd, err := command.NewPackageGraphCommand("Package graph", args)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var result command.PackageGraphResult
+	env.ExecuteCommand(&protocol.ExecuteCommandParams{
+		Command:   cmd.Command,
+		Arguments: cmd.Arguments,
+	}, &result)
+	return result.Document
+}
+
+func TestPackageGraph(t *testing.T) {
+	Run(t, packageGraphFiles, func(t *testing.T, env *Env) {
+		env.OpenFile("a/a.go")
+		uri := env.Sandbox.Workdir.URI("a/a.go")
+
+		dot := packageGraph(t, env, command.PackageGraphArgs{URI: uri})
+		want := `digraph packages {
+	"mod.com/a";
+	"mod.com/b";
+	"mod.com/a" -> "mod.com/b";
+}
+`
+		if dot != want {
+			t.Errorf("DOT graph:\n%s\nwant:\n%s", dot, want)
+		}
+
+		doc := packageGraph(t, env, command.PackageGraphArgs{URI: uri, Format: "json", Tests: true, External: true})
+		var graph struct {
+			Packages []struct {
+				ID        string
+				Workspace bool
+				Test      bool
+				Imports   []string
+			}
+		}
+		if err := json.Unmarshal([]byte(doc), &graph); err != nil {
+			t.Fatalf("invalid document: %v\n%s", err, doc)
+		}
+		imports := make(map[string][]string)
+		for _, p := range graph.Packages {
+			imports[p.ID] = p.Imports
+			if wantTest := strings.Contains(p.ID, ".test"); p.Test != wantTest {
+				t.Errorf("%s: Test = %t, want %t", p.ID, p.Test, wantTest)
+			}
+			if wantWorkspace := strings.HasPrefix(p.ID, "mod.com/"); p.Workspace != wantWorkspace {
+				t.Errorf("%s: Workspace = %t, want %t", p.ID, p.Workspace, wantWorkspace)
+			}
+		}
+		if got, want := imports["mod.com/b"], []string{"fmt"}; !reflect.DeepEqual(got, want) {
+			t.Errorf("imports of mod.com/b = %q, want %q", got, want)
+		}
+		if _, ok := imports["mod.com/a [mod.com/a.test]"]; !ok {
+			t.Errorf("no test variant of mod.com/a in %q", doc)
+		}
+	})
+}
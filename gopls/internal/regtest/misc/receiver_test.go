@@ -0,0 +1,204 @@
+// Copyright 2022 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package misc
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/iansmith/golang-x-tools/internal/lsp/command"
+	"github.com/iansmith/golang-x-tools/internal/lsp/protocol"
+	. "github.com/iansmith/golang-x-tools/internal/lsp/regtest"
+	"github.com/iansmith/golang-x-tools/internal/lsp/source"
+	"github.com/iansmith/golang-x-tools/internal/lsp/tests"
+)
+
+// applyFix runs the gopls.apply_fix command for fix at the first match of
+// re in file, and returns its error.
+func applyFix(env *Env, fix, file, re string) error {
+	env.T.Helper()
+	pos := env.RegexpSearch(file, re)
+	cmd, err := command.NewApplyFixCommand(fix, command.ApplyFixArgs{
+		URI:   protocol.URIFromSpanURI(env.Sandbox.Workdir.URI(file).SpanURI()),
+		Fix:   fix,
+		Range: protocol.Range{Start: pos.ToProtocolPosition(), End: pos.ToProtocolPosition()},
+	})
+	if err != nil {
+		env.T.Fatal(err)
+	}
+	_, err = env.Editor.ExecuteCommand(env.Ctx, &protocol.ExecuteCommandParams{
+		Command:   cmd.Command,
+		Arguments: cmd.Arguments,
+	})
+	return err
+}
+
+func TestToggleReceiverCallSites(t *testing.T) {
+	const files = `
+-- go.mod --
+module mod.com
+
+go 1.12
+-- a/a.go --
+package a
+
+type Point struct{ X, Y int }
+
+func (p Point) Sum() int { return p.X + p.Y }
+-- b/b.go --
+package b
+
+import "mod.com/a"
+
+func Sum(p a.Point) int {
+	return a.Point.Sum(p)
+}
+`
+	Run(t, files, func(t *testing.T, env *Env) {
+		// The fix edits both files.
+		env.OpenFile("a/a.go")
+		env.OpenFile("b/b.go")
+		if err := applyFix(env, source.ToggleReceiver, "a/a.go", "Sum"); err != nil {
+			t.Fatal(err)
+		}
+		const wantA = `package a
+
+type Point struct{ X, Y int }
+
+func (p *Point) Sum() int { return p.X + p.Y }
+`
+		if got := env.Editor.BufferText("a/a.go"); got != wantA {
+			t.Errorf("a/a.go after the conversion:\n%s", tests.Diff(t, wantA, got))
+		}
+		const wantB = `package b
+
+import "mod.com/a"
+
+func Sum(p a.Point) int {
+	return (*a.Point).Sum(&p)
+}
+`
+		if got := env.Editor.BufferText("b/b.go"); got != wantB {
+			t.Errorf("b/b.go after the conversion:\n%s", tests.Diff(t, wantB, got))
+		}
+	})
+}
+
+func TestToggleReceiverBlockers(t *testing.T) {
+	const files = `
+-- go.mod --
+module mod.com
+
+go 1.12
+-- a/a.go --
+package a
+
+type Counter struct{ n int }
+
+func (c Counter) String() string { return "counter" }
+
+func (c *Counter) Inc() { c.n++ }
+
+func (c Counter) Reset() { c.n = 0 }
+-- b/b.go --
+package b
+
+import (
+	"fmt"
+
+	"mod.com/a"
+)
+
+var _ fmt.Stringer = a.Counter{}
+
+func New() a.Counter { return a.Counter{} }
+
+var _ = New().String()
+`
+	Run(t, files, func(t *testing.T, env *Env) {
+		env.OpenFile("a/a.go")
+		for _, test := range []struct {
+			re   string
+			want []string
+		}{
+			{"String", []string{"b.go:9:22", "b.go:13:15"}},
+			{"Inc", []string{"a.go:7:27: Inc modifies its receiver c"}},
+			{"Reset", []string{"a.go:9:28: Reset modifies its copy of the receiver c"}},
+		} {
+			err := applyFix(env, source.ToggleReceiver, "a/a.go", test.re)
+			if err == nil {
+				t.Errorf("converting the receiver of %s succeeded, want an error", test.re)
+				continue
+			}
+			for _, want := range test.want {
+				if !strings.Contains(err.Error(), want) {
+					t.Errorf("converting the receiver of %s: got error %q, want it to contain %q", test.re, err, want)
+				}
+			}
+		}
+	})
+}
+
+func TestNameStruct(t *testing.T) {
+	const files = `
+-- go.mod --
+module mod.com
+
+go 1.12
+-- a/a.go --
+package a
+
+var Options struct {
+	Verbose bool
+}
+
+func Set(o struct{ Verbose bool }) { Options = o }
+-- b/b.go --
+package b
+
+import "mod.com/a"
+
+var _ = a.Options
+
+func isOptions(x interface{}) bool {
+	_, ok := x.(struct{ Verbose bool })
+	return ok
+}
+-- c/c.go --
+package c
+
+var Config struct {
+	Name string
+}
+
+func Set(c struct{ Name string }) { Config = c }
+`
+	Run(t, files, func(t *testing.T, env *Env) {
+		env.OpenFile("a/a.go")
+		env.OpenFile("c/c.go")
+		// b asserts that values have the struct type of a.Options, which
+		// would no longer hold for those of a.
+		err := applyFix(env, source.NameStruct, "a/a.go", "struct")
+		if want := "b.go:8:14: type assertion to the struct type"; err == nil || !strings.Contains(err.Error(), want) {
+			t.Errorf("naming the struct of a.Options: got error %v, want it to contain %q", err, want)
+		}
+		if err := applyFix(env, source.NameStruct, "c/c.go", "struct"); err != nil {
+			t.Fatal(err)
+		}
+		const want = `package c
+
+type ConfigType struct {
+	Name string
+}
+
+var Config ConfigType
+
+func Set(c ConfigType) { Config = c }
+`
+		if got := env.Editor.BufferText("c/c.go"); got != want {
+			t.Errorf("c/c.go after naming the struct:\n%s", tests.Diff(t, want, got))
+		}
+	})
+}
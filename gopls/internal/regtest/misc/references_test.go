@@ -5,6 +5,10 @@
 package misc
 
 import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
 	"testing"
 
 	. "github.com/iansmith/golang-x-tools/internal/lsp/regtest"
@@ -81,3 +85,100 @@ func _() {
 		}
 	})
 }
+
+func TestAsmReferences(t *testing.T) {
+	Run(t, asmDefinition, func(t *testing.T, env *Env) {
+		env.OpenFile("add/add.go")
+		env.OpenFile("add/add.s")
+		for _, test := range []struct {
+			file, re string
+			want     []string // files containing the references, in order
+		}{
+			{"add/add.go", `func (addGeneric)`, []string{"add/add.go", "add/add.s"}},
+			{"add/add.s", `·Add`, []string{"add/add.go", "main.go", "add/add.s"}},
+		} {
+			var got []string
+			for _, ref := range env.References(test.file, env.RegexpSearch(test.file, test.re)) {
+				got = append(got, env.Sandbox.Workdir.URIToPath(ref.URI))
+			}
+			if strings.Join(got, " ") != strings.Join(test.want, " ") {
+				t.Errorf("references of %s in %s: got %v, want %v", test.re, test.file, got, test.want)
+			}
+		}
+	})
+}
+
+func TestReferencesInTestVariants(t *testing.T) {
+	const files = `
+-- go.mod --
+module mod.com
+
+go 1.12
+-- a/a.go --
+package a
+
+func Foo() {}
+
+func _() {
+	Foo()
+}
+-- a/a_test.go --
+package a
+
+func _() {
+	Foo()
+}
+-- a/x_test.go --
+package a_test
+
+import "mod.com/a"
+
+func _() {
+	a.Foo()
+}
+`
+	// references returns the sorted locations of the references to Foo
+	// found from the file with the given name.
+	references := func(t *testing.T, env *Env, name string) []string {
+		t.Helper()
+		env.OpenFile(name)
+		refs, err := env.Editor.References(env.Ctx, name, env.RegexpSearch(name, `Foo\(`))
+		if err != nil {
+			t.Fatal(err)
+		}
+		var got []string
+		for _, ref := range refs {
+			got = append(got, fmt.Sprintf("%s:%d", env.Sandbox.Workdir.URIToPath(ref.URI), ref.Range.Start.Line))
+		}
+		sort.Strings(got)
+		return got
+	}
+
+	t.Run("all", func(t *testing.T) {
+		Run(t, files, func(t *testing.T, env *Env) {
+			want := []string{"a/a.go:2", "a/a.go:5", "a/a_test.go:3", "a/x_test.go:5"}
+			for _, name := range []string{"a/a.go", "a/a_test.go", "a/x_test.go"} {
+				if got := references(t, env, name); !reflect.DeepEqual(got, want) {
+					t.Errorf("references from %s = %q, want %q", name, got, want)
+				}
+			}
+		})
+	})
+
+	t.Run("excluding tests", func(t *testing.T) {
+		WithOptions(
+			EditorConfig{
+				Settings: map[string]interface{}{
+					"includeTestReferences": false,
+				},
+			},
+		).Run(t, files, func(t *testing.T, env *Env) {
+			want := []string{"a/a.go:2", "a/a.go:5"}
+			for _, name := range []string{"a/a.go", "a/x_test.go"} {
+				if got := references(t, env, name); !reflect.DeepEqual(got, want) {
+					t.Errorf("references from %s = %q, want %q", name, got, want)
+				}
+			}
+		})
+	})
+}
@@ -5,10 +5,14 @@
 package misc
 
 import (
+	"reflect"
 	"strings"
 	"testing"
 
+	"github.com/iansmith/golang-x-tools/internal/lsp/command"
+	"github.com/iansmith/golang-x-tools/internal/lsp/protocol"
 	. "github.com/iansmith/golang-x-tools/internal/lsp/regtest"
+	"github.com/iansmith/golang-x-tools/internal/lsp/tests"
 )
 
 // Test for golang/go#47564.
@@ -56,3 +60,297 @@ func main() {
 		}
 	})
 }
+
+func TestRenamePackage(t *testing.T) {
+	const files = `
+-- go.mod --
+module mod.com
+
+go 1.12
+-- lib/lib.go --
+package lib
+
+func F() {}
+-- lib/lib_test.go --
+package lib_test
+
+import "mod.com/lib"
+
+var _ = lib.F
+-- lib/sub/sub.go --
+package sub
+
+const C = 1
+-- main.go --
+package main
+
+import (
+	"mod.com/lib"
+	"mod.com/lib/sub"
+)
+
+func main() {
+	lib.F()
+	_ = sub.C
+}
+-- a/a.go --
+package a
+
+import "mod.com/lib"
+
+func G(util int) int {
+	lib.F()
+	return util
+}
+-- b/b.go --
+package b
+
+import util "mod.com/lib"
+
+var _ = util.F
+`
+	Run(t, files, func(t *testing.T, env *Env) {
+		env.OpenFile("lib/lib.go")
+		pos := env.RegexpSearch("lib/lib.go", "lib")
+		env.Rename("lib/lib.go", pos, "util")
+
+		want := map[string]string{
+			"util/lib.go": `package util
+
+func F() {}
+`,
+			"util/lib_test.go": `package util_test
+
+import "mod.com/util"
+
+var _ = util.F
+`,
+			"main.go": `package main
+
+import (
+	"mod.com/util"
+	"mod.com/util/sub"
+)
+
+func main() {
+	util.F()
+	_ = sub.C
+}
+`,
+			// util is a parameter of G, so a.go keeps the old name.
+			"a/a.go": `package a
+
+import lib "mod.com/util"
+
+func G(util int) int {
+	lib.F()
+	return util
+}
+`,
+			"b/b.go": `package b
+
+import "mod.com/util"
+
+var _ = util.F
+`,
+		}
+		for name, want := range want {
+			if got := env.Editor.BufferText(name); got != want {
+				t.Errorf("%s after renaming package:\n%s", name, tests.Diff(t, want, got))
+			}
+		}
+		if got := env.ReadWorkspaceFile("util/sub/sub.go"); !strings.Contains(got, "package sub") {
+			t.Errorf("util/sub/sub.go after renaming package:\n%s", got)
+		}
+	})
+}
+
+func TestRenamePackageNotNamedAfterDirectory(t *testing.T) {
+	const files = `
+-- go.mod --
+module mod.com
+
+go 1.12
+-- go-lib/lib.go --
+package lib
+
+func F() {}
+-- main.go --
+package main
+
+import "mod.com/go-lib"
+
+func main() {
+	lib.F()
+}
+`
+	Run(t, files, func(t *testing.T, env *Env) {
+		env.OpenFile("go-lib/lib.go")
+		pos := env.RegexpSearch("go-lib/lib.go", "lib\n")
+		env.Rename("go-lib/lib.go", pos, "util")
+
+		want := map[string]string{
+			"go-lib/lib.go": `package util
+
+func F() {}
+`,
+			"main.go": `package main
+
+import "mod.com/go-lib"
+
+func main() {
+	util.F()
+}
+`,
+		}
+		for name, want := range want {
+			if got := env.Editor.BufferText(name); got != want {
+				t.Errorf("%s after renaming package:\n%s", name, tests.Diff(t, want, got))
+			}
+		}
+	})
+}
+
+func TestRenameConfirmLargeEdits(t *testing.T) {
+	const files = `
+-- go.mod --
+module mod.com
+
+go 1.12
+-- a/a.go --
+package a
+
+func Hello() {}
+
+func local() {}
+-- main.go --
+package main
+
+import "mod.com/a"
+
+func main() {
+	a.Hello()
+}
+`
+	WithOptions(
+		EditorConfig{
+			Settings: map[string]interface{}{"confirmLargeEdits": true},
+		},
+	).Run(t, files, func(t *testing.T, env *Env) {
+		env.OpenFile("a/a.go")
+		rename := func(name, newName string) *protocol.WorkspaceEdit {
+			t.Helper()
+			pos := env.RegexpSearch("a/a.go", name)
+			edit, err := env.Editor.Server.Rename(env.Ctx, &protocol.RenameParams{
+				TextDocument: protocol.TextDocumentIdentifier{URI: env.Sandbox.Workdir.URI("a/a.go")},
+				Position:     pos.ToProtocolPosition(),
+				NewName:      newName,
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			return edit
+		}
+
+		// Renaming an exported function edits both files.
+		edit := rename("Hello", "Greet")
+		if got, want := edit.ChangeAnnotations, map[string]protocol.ChangeAnnotation{
+			"gopls.confirm": {Label: "Rename to Greet", NeedsConfirmation: true},
+		}; !reflect.DeepEqual(got, want) {
+			t.Errorf("change annotations = %+v, want %+v", got, want)
+		}
+		for _, change := range edit.DocumentChanges {
+			for _, e := range change.TextDocumentEdit.Edits {
+				if e.AnnotationID != "gopls.confirm" {
+					t.Errorf("edit %+v of %s has annotation %q, want gopls.confirm", e.TextEdit, change.TextDocumentEdit.TextDocument.URI, e.AnnotationID)
+				}
+			}
+		}
+
+		// Renaming an unexported function edits only a/a.go.
+		edit = rename("local", "other")
+		if edit.ChangeAnnotations != nil {
+			t.Errorf("change annotations = %+v, want none", edit.ChangeAnnotations)
+		}
+	})
+}
+
+func TestRenameConflicts(t *testing.T) {
+	const files = `
+-- go.mod --
+module mod.com
+
+go 1.12
+-- a/a.go --
+package a
+
+func Hello() {}
+
+func _() {
+	Hello()
+}
+-- main.go --
+package main
+
+import "mod.com/a"
+
+func main() {
+	a.Hello()
+}
+`
+	Run(t, files, func(t *testing.T, env *Env) {
+		env.OpenFile("a/a.go")
+		env.OpenFile("main.go")
+		rename := func(apply bool) command.RenameResult {
+			t.Helper()
+			pos := env.RegexpSearch("a/a.go", "Hello")
+			cmd, err := command.NewRenameCommand("Rename", command.RenameArgs{
+				URI:                 env.Sandbox.Workdir.URI("a/a.go"),
+				Position:            pos.ToProtocolPosition(),
+				NewName:             "hello",
+				ApplyNonConflicting: apply,
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			var result command.RenameResult
+			env.ExecuteCommand(&protocol.ExecuteCommandParams{
+				Command:   cmd.Command,
+				Arguments: cmd.Arguments,
+			}, &result)
+			return result
+		}
+
+		// Making Hello unexported breaks the reference of main.go, so
+		// nothing is renamed.
+		result := rename(false)
+		if len(result.Conflicts) != 1 {
+			t.Fatalf("got conflicts %+v, want 1", result.Conflicts)
+		}
+		c := result.Conflicts[0]
+		const wantMessage = "renaming \"Hello\" to \"hello\" would make it unexported\n\tbreaking references from packages such as \"mod.com\""
+		if c.Kind != "Export" || c.Message != wantMessage || len(c.Locations) != 2 {
+			t.Errorf("got conflict %+v, want an Export conflict %q at 2 locations", c, wantMessage)
+		}
+		if got := env.Editor.BufferText("a/a.go"); strings.Contains(got, "hello") {
+			t.Errorf("a/a.go was renamed despite the conflict:\n%s", got)
+		}
+
+		// The edits of a/a.go do not conflict.
+		rename(true)
+		const want = `package a
+
+func hello() {}
+
+func _() {
+	hello()
+}
+`
+		if got := env.Editor.BufferText("a/a.go"); got != want {
+			t.Errorf("a/a.go after the partial renaming:\n%s", tests.Diff(t, want, got))
+		}
+		if got := env.Editor.BufferText("main.go"); !strings.Contains(got, "a.Hello()") {
+			t.Errorf("the conflicting reference of main.go was renamed:\n%s", got)
+		}
+	})
+}
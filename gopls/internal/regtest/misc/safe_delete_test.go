@@ -0,0 +1,156 @@
+// Copyright 2022 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package misc
+
+import (
+	"strings"
+	"testing"
+
+	. "github.com/iansmith/golang-x-tools/internal/lsp/regtest"
+	"github.com/iansmith/golang-x-tools/internal/lsp/source"
+	"github.com/iansmith/golang-x-tools/internal/lsp/tests"
+)
+
+func TestSafeDeleteParameter(t *testing.T) {
+	const files = `
+-- go.mod --
+module mod.com
+
+go 1.12
+-- a/a.go --
+package a
+
+func Join(sep string, verbose bool, parts ...string) string {
+	s := ""
+	for i, p := range parts {
+		if i > 0 {
+			s += sep
+		}
+		s += p
+	}
+	return s
+}
+-- b/b.go --
+package b
+
+import (
+	"os"
+
+	"mod.com/a"
+)
+
+func Path() string {
+	return a.Join("/", os.Getenv("DEBUG") != "", "usr", "lib")
+}
+`
+	Run(t, files, func(t *testing.T, env *Env) {
+		env.OpenFile("a/a.go")
+		env.OpenFile("b/b.go")
+		err := applyFix(env, source.SafeDelete, "a/a.go", "verbose")
+		if err == nil || !strings.Contains(err.Error(), "b.go:10:21: the argument for verbose may have side effects") {
+			t.Fatalf("deleting verbose: got error %v, want the argument of b.go", err)
+		}
+
+		env.RegexpReplace("b/b.go", `os.Getenv\("DEBUG"\) != ""`, "false")
+		env.RegexpReplace("b/b.go", `\t"os"\n\n`, "")
+		env.SaveBuffer("b/b.go")
+		if err := applyFix(env, source.SafeDelete, "a/a.go", "verbose"); err != nil {
+			t.Fatal(err)
+		}
+		const wantA = `package a
+
+func Join(sep string, parts ...string) string {
+`
+		if got := env.Editor.BufferText("a/a.go"); !strings.HasPrefix(got, wantA) {
+			t.Errorf("a/a.go after the deletion:\n%s", tests.Diff(t, wantA, got))
+		}
+		const wantB = `package b
+
+import (
+	"mod.com/a"
+)
+
+func Path() string {
+	return a.Join("/", "usr", "lib")
+}
+`
+		if got := env.Editor.BufferText("b/b.go"); got != wantB {
+			t.Errorf("b/b.go after the deletion:\n%s", tests.Diff(t, wantB, got))
+		}
+
+		// The parts are used.
+		err = applyFix(env, source.SafeDelete, "a/a.go", "parts")
+		if err == nil || !strings.Contains(err.Error(), "a.go:5:20: parameter parts is used") {
+			t.Fatalf("deleting parts: got error %v, want its use", err)
+		}
+	})
+}
+
+func TestSafeDeleteBlockers(t *testing.T) {
+	const files = `
+-- go.mod --
+module mod.com
+
+go 1.12
+-- a/a.go --
+package a
+
+import "fmt"
+
+type Name string
+
+func (n Name) String() string { return string(n) }
+
+func Greet(n Name) string { return fmt.Sprint(n) }
+
+func helper() {}
+
+var callback = helper
+-- b/b.go --
+package b
+
+import "mod.com/a"
+
+var _ = a.Greet("b")
+`
+	Run(t, files, func(t *testing.T, env *Env) {
+		env.OpenFile("a/a.go")
+		env.OpenFile("b/b.go")
+		for _, test := range []struct {
+			re, want string
+		}{
+			{"Greet", "b.go:5:11: Greet is used"},
+			{"String", "String may implement a method of an interface"},
+			{"helper", "a.go:13:16: helper is used"},
+			{"Name string", "a.go:9:14: Name is used"},
+		} {
+			err := applyFix(env, source.SafeDelete, "a/a.go", test.re)
+			if err == nil || !strings.Contains(err.Error(), test.want) {
+				t.Errorf("deleting %s: got error %v, want %q", test.re, err, test.want)
+			}
+		}
+
+		// callback is not used, and neither is helper once it is deleted.
+		if err := applyFix(env, source.SafeDelete, "a/a.go", "callback"); err != nil {
+			t.Fatal(err)
+		}
+		if err := applyFix(env, source.SafeDelete, "a/a.go", "helper"); err != nil {
+			t.Fatal(err)
+		}
+		const want = `package a
+
+import "fmt"
+
+type Name string
+
+func (n Name) String() string { return string(n) }
+
+func Greet(n Name) string { return fmt.Sprint(n) }
+`
+		if got := env.Editor.BufferText("a/a.go"); got != want {
+			t.Errorf("a/a.go after the deletions:\n%s", tests.Diff(t, want, got))
+		}
+	})
+}
@@ -0,0 +1,172 @@
+// Copyright 2022 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package misc
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/iansmith/golang-x-tools/internal/lsp/command"
+	"github.com/iansmith/golang-x-tools/internal/lsp/protocol"
+	. "github.com/iansmith/golang-x-tools/internal/lsp/regtest"
+	"github.com/iansmith/golang-x-tools/internal/testenv"
+)
+
+const sbomFiles = `
+-- go.mod --
+module mod.com
+
+go 1.14
+
+require golang.org/amod v1.0.0
+-- go.sum --
+golang.org/amod v1.0.0 h1:fR6iVtwagOceyV2dlqwrd4VYHjmuFYCFoV8+G6B0b7U=
+golang.org/amod v1.0.0/go.mod h1:yvny5/2OtYFomKt8ax+WJGvN6pfN1pqjGnn7DQLUi6E=
+-- main.go --
+package main
+
+import "golang.org/amod/avuln"
+
+func main() {
+	avuln.Vuln()
+}
+`
+
+// generateSBOM executes the gopls.generate_sbom command and decodes the
+// returned document into doc.
+func generateSBOM(t *testing.T, env *Env, args command.GenerateSBOMArgs, doc interface{}) {
+	t.Helper()
+	cmd, err := command.NewGenerateSBOMCommand("Generate SBOM", args)
+	if err != nil {
+		t.Fatal(err)
+	}
+	res, err := env.Editor.ExecuteCommand(env.Ctx, &protocol.ExecuteCommandParams{
+		Command:   cmd.Command,
+		Arguments: cmd.Arguments,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := json.Marshal(res)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var result command.GenerateSBOMResult
+	if err := json.Unmarshal(data, &result); err != nil {
+		t.Fatal(err)
+	}
+	if err := json.Unmarshal([]byte(result.Document), doc); err != nil {
+		t.Fatalf("invalid document: %v\n%s", err, result.Document)
+	}
+}
+
+func TestGenerateSBOMCycloneDX(t *testing.T) {
+	testenv.NeedsGo1Point(t, 18) // the vulnerability database needs go1.18
+
+	db := t.TempDir()
+	for name, content := range vulnDB {
+		path := filepath.Join(db, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := ioutil.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	WithOptions(
+		ProxyFiles(vulnProxy),
+		EditorConfig{
+			Env: map[string]string{"GOVULNDB": "file://" + filepath.ToSlash(db)},
+		},
+	).Run(t, sbomFiles, func(t *testing.T, env *Env) {
+		env.OpenFile("main.go")
+		var doc struct {
+			BOMFormat  string
+			Components []struct {
+				Type, Name, Version, PURL string
+			}
+			Dependencies []struct {
+				Ref       string
+				DependsOn []string
+			}
+			Vulnerabilities []struct {
+				ID      string
+				Affects []struct{ Ref string }
+			}
+		}
+		generateSBOM(t, env, command.GenerateSBOMArgs{
+			URI:   env.Sandbox.Workdir.URI("main.go"),
+			Vulns: true,
+		}, &doc)
+
+		if doc.BOMFormat != "CycloneDX" {
+			t.Errorf("bomFormat = %q, want CycloneDX", doc.BOMFormat)
+		}
+		var purls []string
+		for _, c := range doc.Components {
+			purls = append(purls, c.Type+" "+c.PURL)
+		}
+		if want := []string{"library pkg:golang/golang.org/amod@v1.0.0", "application pkg:golang/mod.com"}; !reflect.DeepEqual(purls, want) {
+			t.Errorf("components = %q, want %q", purls, want)
+		}
+		var deps []string
+		for _, d := range doc.Dependencies {
+			for _, on := range d.DependsOn {
+				deps = append(deps, d.Ref+" -> "+on)
+			}
+		}
+		if want := []string{"pkg:golang/mod.com -> pkg:golang/golang.org/amod@v1.0.0"}; !reflect.DeepEqual(deps, want) {
+			t.Errorf("dependencies = %q, want %q", deps, want)
+		}
+		if len(doc.Vulnerabilities) != 1 || doc.Vulnerabilities[0].ID != "GO-2022-01" ||
+			len(doc.Vulnerabilities[0].Affects) != 1 || doc.Vulnerabilities[0].Affects[0].Ref != "pkg:golang/golang.org/amod@v1.0.0" {
+			t.Errorf("vulnerabilities = %+v, want GO-2022-01 affecting golang.org/amod@v1.0.0", doc.Vulnerabilities)
+		}
+	})
+}
+
+func TestGenerateSBOMSPDX(t *testing.T) {
+	WithOptions(
+		ProxyFiles(vulnProxy),
+	).Run(t, sbomFiles, func(t *testing.T, env *Env) {
+		env.OpenFile("main.go")
+		var doc struct {
+			SPDXVersion string
+			Name        string
+			Packages    []struct {
+				SPDXID, Name, VersionInfo string
+			}
+			Relationships []struct {
+				SPDXElementID, RelationshipType, RelatedSPDXElement string
+			}
+		}
+		generateSBOM(t, env, command.GenerateSBOMArgs{
+			URI:    env.Sandbox.Workdir.URI("main.go"),
+			Format: "SPDX",
+		}, &doc)
+
+		if doc.SPDXVersion != "SPDX-2.2" || doc.Name != "mod.com" {
+			t.Errorf("spdxVersion, name = %q, %q, want SPDX-2.2, mod.com", doc.SPDXVersion, doc.Name)
+		}
+		var pkgs []string
+		for _, p := range doc.Packages {
+			pkgs = append(pkgs, p.SPDXID+" "+p.Name+" "+p.VersionInfo)
+		}
+		if want := []string{"SPDXRef-Package-1 golang.org/amod v1.0.0", "SPDXRef-Package-2 mod.com "}; !reflect.DeepEqual(pkgs, want) {
+			t.Errorf("packages = %q, want %q", pkgs, want)
+		}
+		var rels []string
+		for _, r := range doc.Relationships {
+			rels = append(rels, r.SPDXElementID+" "+r.RelationshipType+" "+r.RelatedSPDXElement)
+		}
+		if want := []string{"SPDXRef-DOCUMENT DESCRIBES SPDXRef-Package-2", "SPDXRef-Package-2 DEPENDS_ON SPDXRef-Package-1"}; !reflect.DeepEqual(rels, want) {
+			t.Errorf("relationships = %q, want %q", rels, want)
+		}
+	})
+}
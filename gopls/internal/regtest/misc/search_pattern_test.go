@@ -0,0 +1,65 @@
+// Copyright 2022 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package misc
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/iansmith/golang-x-tools/internal/lsp/command"
+	"github.com/iansmith/golang-x-tools/internal/lsp/protocol"
+	. "github.com/iansmith/golang-x-tools/internal/lsp/regtest"
+)
+
+func TestSearchPattern(t *testing.T) {
+	const files = `
+-- go.mod --
+module mod.com
+
+go 1.14
+-- a/a.go --
+package a
+
+import "fmt"
+
+func A(err error) error {
+	if err != nil {
+		return fmt.Errorf("a: %v", err)
+	}
+	return nil
+}
+-- b/b.go --
+package b
+
+import "fmt"
+
+func B(err error) error {
+	return fmt.Errorf("b: %w", err)
+}
+`
+	Run(t, files, func(t *testing.T, env *Env) {
+		env.OpenFile("a/a.go")
+		cmd, err := command.NewSearchPatternCommand("Search", command.SearchPatternArgs{
+			URI:     env.Sandbox.Workdir.URI("a/a.go"),
+			Pattern: "fmt.Errorf(f, err)",
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		var result command.SearchPatternResult
+		env.ExecuteCommand(&protocol.ExecuteCommandParams{
+			Command:   cmd.Command,
+			Arguments: cmd.Arguments,
+		}, &result)
+		var got []string
+		for _, loc := range result.Locations {
+			got = append(got, fmt.Sprintf("%s:%d:%d", env.Sandbox.Workdir.URIToPath(loc.URI), loc.Range.Start.Line+1, loc.Range.Start.Character+1))
+		}
+		if want := []string{"a/a.go:7:10", "b/b.go:6:9"}; !reflect.DeepEqual(got, want) {
+			t.Errorf("matches = %q, want %q", got, want)
+		}
+	})
+}
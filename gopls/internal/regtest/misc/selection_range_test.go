@@ -0,0 +1,99 @@
+// Copyright 2022 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package misc
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/iansmith/golang-x-tools/internal/lsp/protocol"
+	. "github.com/iansmith/golang-x-tools/internal/lsp/regtest"
+)
+
+func TestSelectionRange(t *testing.T) {
+	const files = `
+-- go.mod --
+module mod.com
+
+go 1.12
+-- main.go --
+// Package main is a command.
+package main
+
+import "fmt"
+
+func main() {
+	if true {
+		fmt.Println("hello", 1+2)
+	}
+}
+`
+	Run(t, files, func(t *testing.T, env *Env) {
+		env.OpenFile("main.go")
+		content := env.Editor.BufferText("main.go")
+		m := protocol.NewColumnMapper(env.Sandbox.Workdir.URI("main.go").SpanURI(), []byte(content))
+		text := func(rng protocol.Range) string {
+			start, err := m.Offset(rng.Start)
+			if err != nil {
+				t.Fatal(err)
+			}
+			end, err := m.Offset(rng.End)
+			if err != nil {
+				t.Fatal(err)
+			}
+			return content[start:end]
+		}
+		ranges, err := env.Editor.Server.SelectionRange(env.Ctx, &protocol.SelectionRangeParams{
+			TextDocument: protocol.TextDocumentIdentifier{URI: env.Sandbox.Workdir.URI("main.go")},
+			Positions: []protocol.Position{
+				env.RegexpSearch("main.go", `2\)`).ToProtocolPosition(),
+				env.RegexpSearch("main.go", `is a command`).ToProtocolPosition(),
+			},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(ranges) != 2 {
+			t.Fatalf("got %d selection ranges, want 2", len(ranges))
+		}
+		var got []string
+		for r := &ranges[0]; r != nil; r = r.Parent {
+			got = append(got, text(r.Range))
+		}
+		want := []string{
+			"2",
+			"1+2",
+			`fmt.Println("hello", 1+2)`,
+			`{
+		fmt.Println("hello", 1+2)
+	}`,
+			`if true {
+		fmt.Println("hello", 1+2)
+	}`,
+			`{
+	if true {
+		fmt.Println("hello", 1+2)
+	}
+}`,
+			`func main() {
+	if true {
+		fmt.Println("hello", 1+2)
+	}
+}`,
+			content[len("// Package main is a command.\n") : len(content)-1],
+			content,
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("selection ranges at 2:\ngot  %q\nwant %q", got, want)
+		}
+		got = nil
+		for r := &ranges[1]; r != nil; r = r.Parent {
+			got = append(got, text(r.Range))
+		}
+		if want := []string{"// Package main is a command.", content}; !reflect.DeepEqual(got, want) {
+			t.Errorf("selection ranges in comment:\ngot  %q\nwant %q", got, want)
+		}
+	})
+}
@@ -7,8 +7,10 @@ package misc
 import (
 	"testing"
 
+	"github.com/iansmith/golang-x-tools/internal/lsp"
 	"github.com/iansmith/golang-x-tools/internal/lsp/protocol"
 	. "github.com/iansmith/golang-x-tools/internal/lsp/regtest"
+	"github.com/iansmith/golang-x-tools/internal/testenv"
 )
 
 func TestBadURICrash_VSCodeIssue1498(t *testing.T) {
@@ -42,3 +44,60 @@ func main() {}
 		}
 	})
 }
+
+func TestSemanticTokensTypeParams(t *testing.T) {
+	testenv.NeedsGo1Point(t, 18)
+	const src = `
+-- go.mod --
+module example.com
+
+go 1.18
+-- main.go --
+package main
+
+func F[T any](x T) T { return *new(T) }
+
+type L[E any] []E
+
+func (l L[E]) First() E { return l[0] }
+`
+	WithOptions(
+		EditorConfig{
+			Settings: map[string]interface{}{
+				"semanticTokens": true,
+			},
+		},
+	).Run(t, src, func(t *testing.T, env *Env) {
+		env.OpenFile("main.go")
+		params := &protocol.SemanticTokensParams{}
+		params.TextDocument.URI = env.Sandbox.Workdir.URI("main.go")
+		toks, err := env.Editor.Server.SemanticTokensFull(env.Ctx, params)
+		if err != nil {
+			t.Fatal(err)
+		}
+		// Decode the relative positions of the type parameter tokens.
+		type pos struct{ line, col uint32 }
+		var got []pos
+		var line, col uint32
+		for i := 0; i+4 < len(toks.Data); i += 5 {
+			if toks.Data[i] > 0 {
+				line += toks.Data[i]
+				col = 0
+			}
+			col += toks.Data[i+1]
+			if lsp.SemType(int(toks.Data[i+3])) == "typeParameter" {
+				got = append(got, pos{line, col})
+			}
+		}
+		want := []pos{{2, 7}, {2, 16}, {2, 19}, {2, 35}, {4, 7}, {4, 16}, {6, 10}, {6, 22}}
+		if len(got) != len(want) {
+			t.Fatalf("got type parameter tokens at %v, want %v", got, want)
+		}
+		for i := range want {
+			if got[i] != want[i] {
+				t.Errorf("got type parameter tokens at %v, want %v", got, want)
+				break
+			}
+		}
+	})
+}
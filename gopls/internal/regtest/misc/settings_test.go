@@ -34,3 +34,43 @@ func main() {
 		// directory filter above.
 	})
 }
+
+func TestMemoryBudget(t *testing.T) {
+	const src = `
+-- go.mod --
+module mod.com
+
+go 1.12
+-- a/a.go --
+package a
+
+type inner struct{ Deep int }
+
+var outer struct{ In inner }
+
+func _() int {
+	return outer.
+}
+`
+
+	// Any gopls uses more than one byte, so it immediately degrades.
+	WithOptions(
+		EditorConfig{
+			Settings: map[string]interface{}{
+				"memoryBudget": "1B",
+			},
+		},
+	).Run(t, src, func(t *testing.T, env *Env) {
+		env.Await(ShownMessage("memoryBudget"))
+		env.OpenFile("a/a.go")
+		completions := env.Completion("a/a.go", env.RegexpSearch("a/a.go", `outer\.()`))
+		for _, item := range completions.Items {
+			if item.Label == "In.Deep" {
+				t.Errorf("got deep completion %q in degraded mode", item.Label)
+			}
+		}
+		if len(completions.Items) == 0 {
+			t.Errorf("got no completions")
+		}
+	})
+}
@@ -0,0 +1,148 @@
+// Copyright 2022 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package misc
+
+import (
+	"strings"
+	"testing"
+
+	. "github.com/iansmith/golang-x-tools/internal/lsp/regtest"
+	"github.com/iansmith/golang-x-tools/internal/lsp/source"
+	"github.com/iansmith/golang-x-tools/internal/lsp/tests"
+)
+
+func TestConvertStringFormatting(t *testing.T) {
+	for _, test := range []struct {
+		name, fix, src, re, want string
+	}{
+		{
+			name: "to Sprintf",
+			fix:  source.ConcatToSprintf,
+			src: `package a
+
+import "strconv"
+
+func f(s string, n int, ok bool) string {
+	return "a" + strconv.Itoa(n) + "b" + (s + "100%") + strconv.FormatBool(ok) + strconv.Quote(s)
+}
+`,
+			re: `"b"`,
+			want: `package a
+
+import "fmt"
+
+func f(s string, n int, ok bool) string {
+	return fmt.Sprintf("a%db%s100%%%t%q", n, s, ok, s)
+}
+`,
+		},
+		{
+			name: "to Sprintf keeping strconv",
+			fix:  source.ConcatToSprintf,
+			src: `package a
+
+import (
+	"fmt"
+	"strconv"
+)
+
+var _ = fmt.Println
+
+func f(n int) (string, error) {
+	_, err := strconv.Atoi("1")
+	return "n=" + strconv.Itoa(n), err
+}
+`,
+			re: `"n="`,
+			want: `package a
+
+import (
+	"fmt"
+	"strconv"
+)
+
+var _ = fmt.Println
+
+func f(n int) (string, error) {
+	_, err := strconv.Atoi("1")
+	return fmt.Sprintf("n=%d", n), err
+}
+`,
+		},
+		{
+			name: "to concatenation",
+			fix:  source.SprintfToConcat,
+			src: `package a
+
+import "fmt"
+
+type ID uint8
+
+func f(s string, n int, id ID, ok bool) string {
+	return fmt.Sprintf("a%db%s100%%%v%q-%d", n, s, ok, s, id)[1:]
+}
+`,
+			re: `Sprintf`,
+			want: `package a
+
+import "strconv"
+
+type ID uint8
+
+func f(s string, n int, id ID, ok bool) string {
+	return ("a" + strconv.Itoa(n) + "b" + s + "100%" + strconv.FormatBool(ok) + strconv.Quote(s) + "-" + strconv.FormatUint(uint64(id), 10))[1:]
+}
+`,
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			files := "-- go.mod --\nmodule mod.com\n\ngo 1.12\n-- a.go --\n" + test.src
+			Run(t, files, func(t *testing.T, env *Env) {
+				env.OpenFile("a.go")
+				if err := applyFix(env, test.fix, "a.go", test.re); err != nil {
+					t.Fatal(err)
+				}
+				if got := env.Editor.BufferText("a.go"); got != test.want {
+					t.Errorf("a.go after the conversion:\n%s", tests.Diff(t, test.want, got))
+				}
+			})
+		})
+	}
+}
+
+func TestConvertToConcatBlockers(t *testing.T) {
+	const files = `
+-- go.mod --
+module mod.com
+
+go 1.12
+-- a.go --
+package a
+
+import "fmt"
+
+type Name string
+
+func (Name) String() string { return "name" }
+
+var (
+	_ = fmt.Sprintf("%s", Name("x"))
+	_ = fmt.Sprintf("%5d", 1)
+	_ = fmt.Sprintf("%f", 1.5)
+)
+`
+	Run(t, files, func(t *testing.T, env *Env) {
+		env.OpenFile("a.go")
+		for re, want := range map[string]string{
+			`Sprintf\("%s"`:  "String",
+			`Sprintf\("%5d"`: "verb",
+			`Sprintf\("%f"`:  "verb",
+		} {
+			if err := applyFix(env, source.SprintfToConcat, "a.go", re); err == nil || !strings.Contains(err.Error(), want) {
+				t.Errorf("converting %s returned %v, want an error mentioning %q", re, err, want)
+			}
+		}
+	})
+}
@@ -0,0 +1,225 @@
+// Copyright 2022 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package misc
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/iansmith/golang-x-tools/internal/lsp/protocol"
+	. "github.com/iansmith/golang-x-tools/internal/lsp/regtest"
+	"github.com/iansmith/golang-x-tools/internal/lsp/tests"
+)
+
+// applyStructTagAction applies the rewrite code action titled title at the
+// first match of re in file, and returns the titles of the struct tag
+// actions offered there.
+func applyStructTagAction(env *Env, file, re, title string) []string {
+	env.T.Helper()
+	start, end := env.RegexpRange(file, re)
+	actions, err := env.Editor.CodeAction(env.Ctx, file, &protocol.Range{Start: start.ToProtocolPosition(), End: end.ToProtocolPosition()}, nil)
+	if err != nil {
+		env.T.Fatal(err)
+	}
+	var titles []string
+	var found *protocol.CodeAction
+	for i, a := range actions {
+		if a.Kind != protocol.RefactorRewrite || !strings.Contains(a.Title, " tags") {
+			continue
+		}
+		titles = append(titles, a.Title)
+		if a.Title == title {
+			found = &actions[i]
+		}
+	}
+	if title != "" {
+		if found == nil {
+			env.T.Fatalf("no code action %q at %q, got %q", title, re, titles)
+		}
+		env.ApplyCodeAction(*found)
+	}
+	return titles
+}
+
+func TestModifyStructTags(t *testing.T) {
+	for _, test := range []struct {
+		name     string
+		settings map[string]interface{}
+		src      string
+		re       string
+		title    string
+		want     string
+	}{
+		{
+			name: "add snake_case",
+			src: `package a
+
+type User struct {
+	ID       int // the identifier
+	UserName string ` + "`yaml:\"user\"`" + `
+	HTTPAddr string
+	internal bool
+	Embedded
+	A, B     int
+}
+
+type Embedded struct{}
+`,
+			re:    `User struct`,
+			title: "Add json tags (snake_case)",
+			want: `package a
+
+type User struct {
+	ID       int    ` + "`json:\"id\"`" + ` // the identifier
+	UserName string ` + "`yaml:\"user\" json:\"user_name\"`" + `
+	HTTPAddr string ` + "`json:\"http_addr\"`" + `
+	internal bool
+	Embedded
+	A, B int
+}
+
+type Embedded struct{}
+`,
+		},
+		{
+			name: "add camelCase to selected fields",
+			src: `package a
+
+type T struct {
+	FirstName string
+	LastName  string
+	Age       int
+}
+`,
+			re:    `(?s)FirstName.*LastName`,
+			title: "Add json tags (camelCase)",
+			want: `package a
+
+type T struct {
+	FirstName string ` + "`json:\"firstName\"`" + `
+	LastName  string ` + "`json:\"lastName\"`" + `
+	Age       int
+}
+`,
+		},
+		{
+			name:     "add configured convention",
+			settings: map[string]interface{}{"structTags": map[string]interface{}{"yaml": "camelCase"}},
+			src: `package a
+
+func f() interface{} {
+	return struct {
+		MaxRetries int
+	}{}
+}
+`,
+			re:    `MaxRetries`,
+			title: "Add yaml tags (camelCase)",
+			want: `package a
+
+func f() interface{} {
+	return struct {
+		MaxRetries int ` + "`yaml:\"maxRetries\"`" + `
+	}{}
+}
+`,
+		},
+		{
+			name: "update",
+			src: `package a
+
+type T struct {
+	NewName  string ` + "`json:\"old_name,omitempty\" xml:\"n\"`" + `
+	Skipped  int    ` + "`json:\"-\"`" + `
+	Default  int    ` + "`json:\",string\"`" + `
+	Untagged int
+}
+`,
+			re:    `T struct`,
+			title: "Update json tags (snake_case)",
+			want: `package a
+
+type T struct {
+	NewName  string ` + "`json:\"new_name,omitempty\" xml:\"n\"`" + `
+	Skipped  int    ` + "`json:\"-\"`" + `
+	Default  int    ` + "`json:\",string\"`" + `
+	Untagged int
+}
+`,
+		},
+		{
+			name: "remove",
+			src: `package a
+
+type T struct {
+	Name  string ` + "`json:\"name\" yaml:\"name\"`" + `
+	Value int    ` + "`json:\"value\"`" + ` // the value
+}
+`,
+			re:    `T struct`,
+			title: "Remove json tags",
+			want: `package a
+
+type T struct {
+	Name  string ` + "`yaml:\"name\"`" + `
+	Value int    // the value
+}
+`,
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			files := "-- go.mod --\nmodule mod.com\n\ngo 1.12\n-- a.go --\n" + test.src
+			WithOptions(EditorConfig{Settings: test.settings}).Run(t, files, func(t *testing.T, env *Env) {
+				env.OpenFile("a.go")
+				applyStructTagAction(env, "a.go", test.re, test.title)
+				if got := env.Editor.BufferText("a.go"); got != test.want {
+					t.Errorf("%q modified the struct type to:\n%s\nwant:\n%s\ndiff:\n%s", test.title, got, test.want, tests.Diff(t, test.want, got))
+				}
+			})
+		})
+	}
+}
+
+func TestStructTagActions(t *testing.T) {
+	const files = `
+-- go.mod --
+module mod.com
+
+go 1.12
+-- a.go --
+package a
+
+type T struct {
+	Name  string ` + "`json:\"name\"`" + `
+	Value int
+}
+
+type unexported struct {
+	value int
+}
+`
+	WithOptions(EditorConfig{
+		Settings: map[string]interface{}{
+			"structTags": map[string]interface{}{"json": "camelCase", "yaml": "snake_case"},
+		},
+	}).Run(t, files, func(t *testing.T, env *Env) {
+		env.OpenFile("a.go")
+		got := applyStructTagAction(env, "a.go", `T struct`, "")
+		want := []string{
+			"Add json tags (camelCase)",
+			"Add json tags (snake_case)",
+			"Add yaml tags (snake_case)",
+			"Add yaml tags (camelCase)",
+			"Update json tags (camelCase)",
+			"Remove json tags",
+		}
+		if strings.Join(got, "\n") != strings.Join(want, "\n") {
+			t.Errorf("struct tag actions = %q, want %q", got, want)
+		}
+		if got := applyStructTagAction(env, "a.go", `unexported struct`, ""); len(got) > 0 {
+			t.Errorf("struct tag actions of a struct type without exported fields = %q, want none", got)
+		}
+	})
+}
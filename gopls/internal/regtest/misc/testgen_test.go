@@ -0,0 +1,171 @@
+// Copyright 2022 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package misc
+
+import (
+	"testing"
+
+	"github.com/iansmith/golang-x-tools/internal/lsp/command"
+	"github.com/iansmith/golang-x-tools/internal/lsp/protocol"
+	. "github.com/iansmith/golang-x-tools/internal/lsp/regtest"
+	"github.com/iansmith/golang-x-tools/internal/lsp/tests"
+)
+
+func TestGenerateTest(t *testing.T) {
+	const files = `
+-- go.mod --
+module mod.com
+
+go 1.12
+-- a/a.go --
+package a
+
+import "io"
+
+func Add(x, y int) int {
+	return x + y
+}
+
+func (r *reader) read(p []byte) (int, error) {
+	return 0, io.EOF
+}
+
+type reader struct{}
+`
+	Run(t, files, func(t *testing.T, env *Env) {
+		env.OpenFile("a/a.go")
+		generateTest(t, env, "a/a.go", "Add")
+		generateTest(t, env, "a/a.go", "read")
+
+		const want = `package a
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestAdd(t *testing.T) {
+	tests := []struct {
+		name string
+		x    int
+		y    int
+		want int
+	}{
+		// TODO: Add test cases.
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Add(tt.x, tt.y)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Add() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_reader_read(t *testing.T) {
+	tests := []struct {
+		name    string
+		recv    *reader
+		p       []byte
+		want    int
+		wantErr bool
+	}{
+		// TODO: Add test cases.
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.recv.read(tt.p)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("read() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("read() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+`
+		if got := env.ReadWorkspaceFile("a/a_test.go"); got != want {
+			t.Errorf("a/a_test.go after gopls.generate_test:\n%s", tests.Diff(t, want, got))
+		}
+	})
+}
+
+func TestGenerateTestExternalPackage(t *testing.T) {
+	const files = `
+-- go.mod --
+module mod.com
+
+go 1.12
+-- a/a.go --
+package a
+
+func Check(s string) error {
+	return nil
+}
+-- a/example_test.go --
+package a_test
+`
+	Run(t, files, func(t *testing.T, env *Env) {
+		env.OpenFile("a/a.go")
+		generateTest(t, env, "a/a.go", "Check")
+
+		const want = `package a_test
+
+import (
+	"testing"
+
+	"mod.com/a"
+)
+
+func TestCheck(t *testing.T) {
+	tests := []struct {
+		name    string
+		s       string
+		wantErr bool
+	}{
+		// TODO: Add test cases.
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := a.Check(tt.s)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Check() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+`
+		if got := env.ReadWorkspaceFile("a/a_test.go"); got != want {
+			t.Errorf("a/a_test.go after gopls.generate_test:\n%s", tests.Diff(t, want, got))
+		}
+	})
+}
+
+// generateTest runs gopls.generate_test for the function or method
+// named name in the file at path, and saves the test file if the
+// command edited it in the editor rather than on disk.
+func generateTest(t *testing.T, env *Env, path, name string) {
+	t.Helper()
+	pos := env.RegexpSearch(path, name+`\(`)
+	cmd, err := command.NewGenerateTestCommand("Generate a test", command.GenerateTestArgs{
+		URI:   env.Sandbox.Workdir.URI(path),
+		Range: protocol.Range{Start: pos.ToProtocolPosition(), End: pos.ToProtocolPosition()},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	env.ExecuteCommand(&protocol.ExecuteCommandParams{
+		Command:   cmd.Command,
+		Arguments: cmd.Arguments,
+	}, nil)
+	testPath := path[:len(path)-len(".go")] + "_test.go"
+	if env.Editor.HasBuffer(testPath) {
+		env.SaveBufferWithoutActions(testPath)
+	}
+	env.CheckForFileChanges()
+	env.Await(env.DoneWithChangeWatchedFiles())
+}
@@ -0,0 +1,153 @@
+// Copyright 2022 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package misc
+
+import (
+	"testing"
+
+	"github.com/iansmith/golang-x-tools/internal/lsp/protocol"
+	. "github.com/iansmith/golang-x-tools/internal/lsp/regtest"
+	"github.com/iansmith/golang-x-tools/internal/lsp/tests"
+)
+
+func TestUnusedSymbols(t *testing.T) {
+	const files = `
+-- go.mod --
+module mod.com
+
+go 1.12
+-- a/a.go --
+package a
+
+import (
+	"fmt"
+	"os"
+	"strings"
+)
+
+// Used is used by package b.
+func Used() string {
+	return strings.ToUpper(helper())
+}
+
+func helper() string { return "x" }
+
+// exit is not used.
+func exit() {
+	os.Exit(1)
+}
+
+type T struct {
+	used   int
+	unused int
+}
+
+var _ = T{}
+
+func (t T) String() string { return fmt.Sprint(t.used) }
+
+func (t T) internal() {}
+
+type tagged struct {
+	X int ` + "`json:\"x\"`" + `
+}
+
+type list struct {
+	next *list
+}
+
+func (l *list) last() *list {
+	if l.next == nil {
+		return l
+	}
+	return l.next.last()
+}
+-- b/b.go --
+package b
+
+import "mod.com/a"
+
+var _ = a.Used()
+`
+	WithOptions(
+		EditorConfig{
+			Settings: map[string]interface{}{
+				"unusedSymbols": true,
+			},
+		},
+	).Run(t, files, func(t *testing.T, env *Env) {
+		env.OpenFile("a/a.go")
+		d := &protocol.PublishDiagnosticsParams{}
+		env.Await(
+			OnceMet(
+				env.DiagnosticAtRegexpWithMessage("a/a.go", `exit\(`, "func exit is unused"),
+				env.DiagnosticAtRegexpWithMessage("a/a.go", `unused int`, "field T.unused is unused"),
+				env.DiagnosticAtRegexpWithMessage("a/a.go", `internal\(`, "method T.internal is unused"),
+				env.DiagnosticAtRegexpWithMessage("a/a.go", `tagged struct`, "type tagged is unused"),
+				env.DiagnosticAtRegexpWithMessage("a/a.go", `list struct`, "type list is unused"),
+				ReadDiagnostics("a/a.go", d),
+			),
+		)
+		if got := len(d.Diagnostics); got != 5 {
+			t.Fatalf("got %d diagnostics, want 5: %v", got, d.Diagnostics)
+		}
+
+		// Delete the unused function, along with the import that only it
+		// uses, and the unused type, along with its method.
+		env.ApplyQuickFixes("a/a.go", diagnosticsWithMessage(d, "func exit is unused"))
+		env.Await(
+			OnceMet(
+				env.DoneWithChange(),
+				env.DiagnosticAtRegexpWithMessage("a/a.go", `list struct`, "type list is unused"),
+				ReadDiagnostics("a/a.go", d),
+			),
+		)
+		env.ApplyQuickFixes("a/a.go", diagnosticsWithMessage(d, "type list is unused"))
+		const want = `package a
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Used is used by package b.
+func Used() string {
+	return strings.ToUpper(helper())
+}
+
+func helper() string { return "x" }
+
+type T struct {
+	used   int
+	unused int
+}
+
+var _ = T{}
+
+func (t T) String() string { return fmt.Sprint(t.used) }
+
+func (t T) internal() {}
+
+type tagged struct {
+	X int ` + "`json:\"x\"`" + `
+}
+`
+		if got := env.Editor.BufferText("a/a.go"); got != want {
+			t.Errorf("a/a.go after deleting unused symbols:\n%s", tests.Diff(t, want, got))
+		}
+	})
+}
+
+// diagnosticsWithMessage returns the diagnostics of d with the given
+// message.
+func diagnosticsWithMessage(d *protocol.PublishDiagnosticsParams, msg string) []protocol.Diagnostic {
+	var diags []protocol.Diagnostic
+	for _, diag := range d.Diagnostics {
+		if diag.Message == msg {
+			diags = append(diags, diag)
+		}
+	}
+	return diags
+}
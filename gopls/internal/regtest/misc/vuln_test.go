@@ -5,11 +5,16 @@
 package misc
 
 import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/iansmith/golang-x-tools/internal/lsp/command"
 	"github.com/iansmith/golang-x-tools/internal/lsp/protocol"
 	. "github.com/iansmith/golang-x-tools/internal/lsp/regtest"
+	"github.com/iansmith/golang-x-tools/internal/lsp/tests"
+	"github.com/iansmith/golang-x-tools/internal/testenv"
 )
 
 func TestRunVulncheckExpError(t *testing.T) {
@@ -41,3 +46,105 @@ package foo
 		}
 	})
 }
+
+const vulnProxy = `
+-- golang.org/amod@v1.0.0/go.mod --
+module golang.org/amod
+
+go 1.14
+-- golang.org/amod@v1.0.0/avuln/avuln.go --
+package avuln
+
+func Vuln() {}
+-- golang.org/amod@v1.0.4/go.mod --
+module golang.org/amod
+
+go 1.14
+-- golang.org/amod@v1.0.4/avuln/avuln.go --
+package avuln
+
+func Vuln() {}
+`
+
+// vulnDB is a vulnerability database, in the layout that GOVULNDB
+// accepts for file URLs, with a vulnerability of golang.org/amod/avuln.
+var vulnDB = map[string]string{
+	"index.json": `{"golang.org/amod": "2022-01-01T00:00:00Z"}`,
+	"golang.org/amod.json": `[{
+		"id": "GO-2022-01",
+		"details": "Vuln is vulnerable.",
+		"affected": [{
+			"package": {"name": "golang.org/amod/avuln"},
+			"ranges": [{"type": "SEMVER", "events": [{"introduced": "1.0.0"}, {"fixed": "1.0.4"}]}],
+			"ecosystem_specific": {"symbols": ["Vuln"]}
+		}]
+	}]`,
+}
+
+func TestVulnerabilityDiagnostics(t *testing.T) {
+	testenv.NeedsGo1Point(t, 18) // the vulnerability database needs go1.18
+
+	const files = `
+-- go.mod --
+module mod.com
+
+go 1.14
+
+require golang.org/amod v1.0.0
+-- go.sum --
+golang.org/amod v1.0.0 h1:fR6iVtwagOceyV2dlqwrd4VYHjmuFYCFoV8+G6B0b7U=
+golang.org/amod v1.0.0/go.mod h1:yvny5/2OtYFomKt8ax+WJGvN6pfN1pqjGnn7DQLUi6E=
+-- main.go --
+package main
+
+import "golang.org/amod/avuln"
+
+func main() {
+	avuln.Vuln()
+}
+`
+	db := t.TempDir()
+	for name, content := range vulnDB {
+		path := filepath.Join(db, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := ioutil.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	WithOptions(
+		ProxyFiles(vulnProxy),
+		EditorConfig{
+			Env:      map[string]string{"GOVULNDB": "file://" + filepath.ToSlash(db)},
+			Settings: map[string]interface{}{"vulncheck": "Imports"},
+		},
+	).Run(t, files, func(t *testing.T, env *Env) {
+		env.OpenFile("main.go")
+		env.OpenFile("go.mod")
+		var d protocol.PublishDiagnosticsParams
+		env.Await(
+			OnceMet(
+				env.DiagnosticAtRegexpWithMessage("main.go", `"golang.org/amod/avuln"`, "golang.org/amod/avuln has a known vulnerability: GO-2022-01 (fixed in v1.0.4)"),
+			),
+			OnceMet(
+				env.DiagnosticAtRegexpWithMessage("go.mod", `require golang.org/amod`, "golang.org/amod has a known vulnerability in golang.org/amod/avuln: GO-2022-01 (fixed in v1.0.4)"),
+				ReadDiagnostics("go.mod", &d),
+			),
+		)
+		env.ApplyQuickFixes("go.mod", d.Diagnostics)
+		const want = `module mod.com
+
+go 1.14
+
+require golang.org/amod v1.0.4
+`
+		if got := env.Editor.BufferText("go.mod"); got != want {
+			t.Fatalf("go.mod after the upgrade:\n%s", tests.Diff(t, want, got))
+		}
+		env.SaveBuffer("go.mod")
+		env.Await(
+			EmptyOrNoDiagnostics("main.go"),
+		)
+	})
+}
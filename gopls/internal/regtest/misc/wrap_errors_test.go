@@ -0,0 +1,95 @@
+// Copyright 2022 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package misc
+
+import (
+	"testing"
+
+	"github.com/iansmith/golang-x-tools/internal/lsp/command"
+	"github.com/iansmith/golang-x-tools/internal/lsp/protocol"
+	. "github.com/iansmith/golang-x-tools/internal/lsp/regtest"
+	"github.com/iansmith/golang-x-tools/internal/lsp/tests"
+)
+
+func TestWrapErrors(t *testing.T) {
+	const files = `
+-- go.mod --
+module mod.com
+
+go 1.12
+-- a.go --
+package a
+
+import "fmt"
+
+func Open(name string) (int, error) {
+	n, err := fmt.Sscan(name)
+	if err != nil {
+		return 0, err
+	}
+	return n, nil
+}
+-- b.go --
+package a
+
+import "os"
+
+func Close(f *os.File) error {
+	err := f.Close()
+	return err
+}
+`
+	WithOptions(
+		EditorConfig{
+			Settings: map[string]interface{}{
+				"errorWrapTemplate": "{{.Package}}.{{.Func}}: %w",
+			},
+		},
+	).Run(t, files, func(t *testing.T, env *Env) {
+		env.OpenFile("a.go")
+		env.OpenFile("b.go")
+		cmd, err := command.NewWrapErrorsCommand("Wrap errors", command.URIArg{
+			URI: env.Sandbox.Workdir.URI("a.go"),
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		env.ExecuteCommand(&protocol.ExecuteCommandParams{
+			Command:   cmd.Command,
+			Arguments: cmd.Arguments,
+		}, nil)
+
+		const wantA = `package a
+
+import "fmt"
+
+func Open(name string) (int, error) {
+	n, err := fmt.Sscan(name)
+	if err != nil {
+		return 0, fmt.Errorf("a.Open: %w", err)
+	}
+	return n, nil
+}
+`
+		if got := env.Editor.BufferText("a.go"); got != wantA {
+			t.Errorf("a.go after gopls.wrap_errors:\n%s", tests.Diff(t, wantA, got))
+		}
+		const wantB = `package a
+
+import (
+	"fmt"
+	"os"
+)
+
+func Close(f *os.File) error {
+	err := f.Close()
+	return fmt.Errorf("a.Close: %w", err)
+}
+`
+		if got := env.Editor.BufferText("b.go"); got != wantB {
+			t.Errorf("b.go after gopls.wrap_errors:\n%s", tests.Diff(t, wantB, got))
+		}
+	})
+}
@@ -0,0 +1,59 @@
+// Copyright 2022 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package modfile
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/iansmith/golang-x-tools/internal/lsp/protocol"
+	. "github.com/iansmith/golang-x-tools/internal/lsp/regtest"
+)
+
+func TestEditGoDirectiveQuickFix(t *testing.T) {
+	const files = `
+-- go.mod --
+module mod.com
+
+go 1.12
+-- a/a.go --
+package a
+
+var X = 0b101
+`
+	Run(t, files, func(t *testing.T, env *Env) {
+		env.OpenFile("a/a.go")
+		var d protocol.PublishDiagnosticsParams
+		env.Await(OnceMet(
+			env.DiagnosticAtRegexpWithMessage("a/a.go", `0b101`, "requires go1.13 or later"),
+			ReadDiagnostics("a/a.go", &d),
+		))
+		env.ApplyQuickFixes("a/a.go", d.Diagnostics)
+		if got := env.ReadWorkspaceFile("go.mod"); !strings.Contains(got, "go 1.13") {
+			t.Errorf("go.mod after the quick fix:\n%s\nwant go 1.13", got)
+		}
+		env.Await(EmptyDiagnostics("a/a.go"))
+	})
+}
+
+func TestGoDirectiveNewerThanGoCommand(t *testing.T) {
+	const files = `
+-- go.mod --
+module mod.com
+
+go 1.99
+-- a/a.go --
+package a
+`
+	Run(t, files, func(t *testing.T, env *Env) {
+		env.OpenFile("go.mod")
+		env.Await(env.DiagnosticAtRegexpWithMessage("go.mod", `go 1.99`, "but the go command is"))
+
+		content, _ := env.Hover("go.mod", env.RegexpSearch("go.mod", `go 1.99`))
+		if content == nil || !strings.Contains(content.Value, "language of the module to go1.99") || !strings.Contains(content.Value, "older than go1.99") {
+			t.Errorf("hover of the go directive = %v, want an explanation of go1.99 and the go command", content)
+		}
+	})
+}
@@ -1116,6 +1116,114 @@ example.com v1.2.3/go.mod h1:Y2Rc5rVWjWur0h3pd9aEvK5Pof8YKDANh9gHA2Maujo=
 	})
 }
 
+func TestAddGoSumEntriesQuickFix(t *testing.T) {
+	testenv.NeedsGo1Point(t, 16)
+	const mod = `
+-- go.mod --
+module mod.com
+
+go 1.12
+
+require (
+	example.com v1.2.3
+	random.org v1.2.3
+)
+-- go.sum --
+-- main.go --
+package main
+
+import (
+	"example.com/blah"
+	"random.org/bye"
+)
+
+func main() {
+	blah.SaySomething()
+	bye.Goodbye()
+}
+`
+	WithOptions(
+		ProxyFiles(workspaceProxy),
+		Modes(Singleton),
+	).Run(t, mod, func(t *testing.T, env *Env) {
+		env.OpenFile("go.mod")
+		params := &protocol.PublishDiagnosticsParams{}
+		env.Await(
+			OnceMet(
+				env.GoSumDiagnostic("go.mod", "example.com"),
+				ReadDiagnostics("go.mod", params),
+			),
+		)
+		var found bool
+		for _, action := range env.GetQuickFixes("go.mod", params.Diagnostics) {
+			if action.Title == "Add missing go.sum entries" {
+				env.ApplyCodeAction(action)
+				found = true
+				break
+			}
+		}
+		if !found {
+			t.Fatalf("no %q quick fix", "Add missing go.sum entries")
+		}
+		const want = `example.com v1.2.3 h1:Yryq11hF02fEf2JlOS2eph+ICE2/ceevGV3C9dl5V/c=
+example.com v1.2.3/go.mod h1:Y2Rc5rVWjWur0h3pd9aEvK5Pof8YKDANh9gHA2Maujo=
+random.org v1.2.3 h1:+JE2Fkp7gS0zsHXGEQJ7hraom3pNTlkxC4b2qPfA+/Q=
+random.org v1.2.3/go.mod h1:E9KM6+bBX2g5ykHZ9H27w16sWo3QwgonyjM44Dnej3I=
+`
+		if got := env.ReadWorkspaceFile("go.sum"); got != want {
+			t.Fatalf("unexpected go.sum contents:\n%s", tests.Diff(t, want, got))
+		}
+	})
+}
+
+func TestSumHover(t *testing.T) {
+	testenv.NeedsGo1Point(t, 16)
+	const mod = `
+-- go.mod --
+module mod.com
+
+go 1.12
+
+require example.com v1.2.3
+-- go.sum --
+example.com v1.2.3 h1:Yryq11hF02fEf2JlOS2eph+ICE2/ceevGV3C9dl5V/c=
+example.com v1.2.3/go.mod h1:Y2Rc5rVWjWur0h3pd9aEvK5Pof8YKDANh9gHA2Maujo=
+random.org v1.2.3 h1:+JE2Fkp7gS0zsHXGEQJ7hraom3pNTlkxC4b2qPfA+/Q=
+random.org v1.2.3/go.mod h1:E9KM6+bBX2g5ykHZ9H27w16sWo3QwgonyjM44Dnej3I=
+-- main.go --
+package main
+
+import "example.com/blah"
+
+func main() {
+	blah.SaySomething()
+}
+`
+	WithOptions(
+		ProxyFiles(workspaceProxy),
+	).Run(t, mod, func(t *testing.T, env *Env) {
+		env.OpenFile("go.sum")
+		for _, test := range []struct {
+			re   string
+			want []string
+		}{
+			{`example.com v1.2.3 h1`, []string{"example.com", "hash of the module's contents", "module proxy", "needed: the module version is selected"}},
+			{`example.com v1.2.3/go.mod`, []string{"hash of the module's go.mod file", "needed: the module version is selected"}},
+			{`random.org v1.2.3 h1`, []string{"not needed", "go mod tidy"}},
+		} {
+			got, _ := env.Hover("go.sum", env.RegexpSearch("go.sum", test.re))
+			if got == nil {
+				t.Fatalf("no hover for %s", test.re)
+			}
+			for _, want := range test.want {
+				if !strings.Contains(got.Value, want) {
+					t.Errorf("hover for %s = %q, want it to contain %q", test.re, got.Value, want)
+				}
+			}
+		}
+	})
+}
+
 func TestDownloadDeps(t *testing.T) {
 	testenv.NeedsGo1Point(t, 14)
 
@@ -766,3 +766,35 @@ func TestSomething(t *testing.T) {}
 		)
 	})
 }
+
+// Test that gopls watches files itself for clients that cannot watch them.
+func TestNativeFileWatching(t *testing.T) {
+	const files = `
+-- go.mod --
+module mod.com
+
+go 1.14
+-- a/a.go --
+package a
+
+import "mod.com/b"
+
+func _() {
+	b.Hello()
+}
+-- b/b.go --
+package b
+`
+	WithOptions(
+		EditorConfig{NoFileWatching: true},
+	).Run(t, files, func(t *testing.T, env *Env) {
+		env.OpenFile("a/a.go")
+		env.Await(env.DiagnosticAtRegexp("a/a.go", "Hello"))
+		env.WriteWorkspaceFile("b/b.go", "package b\n\nfunc Hello() {}\n")
+		env.Await(EmptyDiagnostics("a/a.go"))
+
+		// Files of new directories are watched too.
+		env.WriteWorkspaceFile("a/c/c.go", "package c\n\nvar _ int = \"\"\n")
+		env.Await(env.DiagnosticAtRegexp("a/c/c.go", `""`))
+	})
+}
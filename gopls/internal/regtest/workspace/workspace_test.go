@@ -13,6 +13,7 @@ import (
 
 	"github.com/iansmith/golang-x-tools/gopls/internal/hooks"
 	"github.com/iansmith/golang-x-tools/internal/lsp/bug"
+	"github.com/iansmith/golang-x-tools/internal/lsp/command"
 	"github.com/iansmith/golang-x-tools/internal/lsp/fake"
 	"github.com/iansmith/golang-x-tools/internal/lsp/protocol"
 	"github.com/iansmith/golang-x-tools/internal/lsp/source"
@@ -895,6 +896,186 @@ module example.com/bar/baz
 	})
 }
 
+func TestUseGoWorkReplaceHover(t *testing.T) {
+	const files = `
+-- go.work --
+go 1.18
+
+use ./foo
+
+replace example.com/bar => ./bar
+replace example.com/baz v1.0.0 => example.com/qux v1.1.0
+-- foo/go.mod --
+module example.com/foo
+-- bar/go.mod --
+module example.com/bar
+`
+	Run(t, files, func(t *testing.T, env *Env) {
+		env.OpenFile("go.work")
+
+		tcs := map[string][]string{
+			`example.com/bar =>`: {"`example.com/bar` is replaced by the directory `./bar`", "contains module `example.com/bar`"},
+			`example.com/baz`:    {"`example.com/baz@v1.0.0` is replaced by `example.com/qux@v1.1.0`"},
+		}
+		for hoverRE, wants := range tcs {
+			pos := env.RegexpSearch("go.work", hoverRE)
+			got, _ := env.Hover("go.work", pos)
+			for _, want := range wants {
+				if !strings.Contains(got.Value, want) {
+					t.Errorf(`hover on %q: got %q, want it to contain %q`, hoverRE, got.Value, want)
+				}
+			}
+		}
+	})
+}
+
+func TestUseGoWorkRemoveMissingModule(t *testing.T) {
+	const files = `
+-- go.work --
+go 1.18
+
+use (
+	./bar
+	./foo
+)
+-- bar/go.mod --
+module example.com/bar
+`
+	Run(t, files, func(t *testing.T, env *Env) {
+		env.OpenFile("go.work")
+		d := &protocol.PublishDiagnosticsParams{}
+		env.Await(
+			OnceMet(
+				env.DiagnosticAtRegexpWithMessage("go.work", `\./foo`, "directory ./foo does not contain a module"),
+				ReadDiagnostics("go.work", d),
+			),
+		)
+		env.ApplyQuickFixes("go.work", d.Diagnostics)
+		want := `go 1.18
+
+use ./bar
+`
+		if got := env.Editor.BufferText("go.work"); got != want {
+			t.Errorf("go.work after quick fix: got %q, want %q", got, want)
+		}
+	})
+}
+
+func TestAddModuleToGoWork(t *testing.T) {
+	testenv.NeedsGo1Point(t, 18)
+	const files = `
+-- go.work --
+go 1.18
+
+use ./a
+-- a/go.mod --
+module example.com/a
+
+go 1.18
+-- a/a.go --
+package a
+-- b/go.mod --
+module example.com/b
+
+go 1.18
+-- b/b.go --
+package b
+`
+	Run(t, files, func(t *testing.T, env *Env) {
+		// The quick fix edits go.work, which the fake editor can only do
+		// for open files.
+		env.OpenFile("go.work")
+		env.OpenFile("b/b.go")
+		d := &protocol.PublishDiagnosticsParams{}
+		env.Await(
+			OnceMet(
+				env.DiagnosticAtRegexpWithMessage("b/b.go", "package (b)", "not included in your go.work file"),
+				ReadDiagnostics("b/b.go", d),
+			),
+		)
+		env.ApplyQuickFixes("b/b.go", d.Diagnostics)
+		want := `go 1.18
+
+use (
+	./a
+	./b
+)
+`
+		if got := env.Editor.BufferText("go.work"); got != want {
+			t.Fatalf("go.work after quick fix: got %q, want %q", got, want)
+		}
+		env.SaveBuffer("go.work")
+		env.Await(
+			EmptyDiagnostics("b/b.go"),
+		)
+	})
+}
+
+func TestWorkSyncCodeLens(t *testing.T) {
+	testenv.NeedsGo1Point(t, 18)
+	const proxy = `
+-- example.com@v1.0.0/go.mod --
+module example.com
+
+go 1.12
+-- example.com@v1.0.0/blah/blah.go --
+package blah
+
+const Name = "Blah"
+-- example.com@v1.1.0/go.mod --
+module example.com
+
+go 1.12
+-- example.com@v1.1.0/blah/blah.go --
+package blah
+
+const Name = "Blah"
+`
+	const files = `
+-- go.work --
+go 1.18
+
+use (
+	./a
+	./b
+)
+-- a/go.mod --
+module example.com/a
+
+go 1.18
+
+require example.com v1.0.0
+-- a/a.go --
+package a
+
+import "example.com/blah"
+
+const Name = blah.Name
+-- b/go.mod --
+module example.com/b
+
+go 1.18
+
+require example.com v1.1.0
+-- b/b.go --
+package b
+
+import "example.com/blah"
+
+const Name = blah.Name
+`
+	WithOptions(
+		ProxyFiles(proxy),
+	).Run(t, files, func(t *testing.T, env *Env) {
+		env.OpenFile("go.work")
+		env.ExecuteCodeLensCommand("go.work", command.WorkSync)
+		env.Await(env.DoneWithChangeWatchedFiles())
+		if got := env.ReadWorkspaceFile("a/go.mod"); !strings.Contains(got, "require example.com v1.1.0") {
+			t.Errorf("a/go.mod after go work sync:\n%s\nwant it to require example.com v1.1.0", got)
+		}
+	})
+}
+
 func TestExpandToGoWork(t *testing.T) {
 	testenv.NeedsGo1Point(t, 18)
 	const workspace = `
@@ -1122,6 +1303,126 @@ package exclude
 	})
 }
 
+func TestDirectoryFiltersGlobs(t *testing.T) {
+	// Both node_modules directories, and their errors, should be excluded
+	// from the workspace, at any depth.
+	const files = `
+-- go.mod --
+module example.com
+
+go 1.12
+-- node_modules/x/x.go --
+package x
+
+const _ = Nonexistant
+-- web/node_modules/y/y.go --
+package y
+
+const _ = Nonexistant
+-- web/web.go --
+package web
+
+const _ = Nonexistant
+`
+	cfg := EditorConfig{
+		DirectoryFilters: []string{"-**/node_modules"},
+	}
+	WithOptions(cfg).Run(t, files, func(t *testing.T, env *Env) {
+		env.Await(
+			OnceMet(
+				InitialWorkspaceLoad,
+				env.DiagnosticAtRegexp("web/web.go", "Nonexistant"),
+			),
+			NoDiagnostics("node_modules/x/x.go"),
+			NoDiagnostics("web/node_modules/y/y.go"),
+		)
+	})
+}
+
+func TestFolderDirectoryFilters(t *testing.T) {
+	// The filters of the web folder override directoryFilters.
+	const files = `
+-- web/go.mod --
+module example.com
+
+go 1.12
+-- web/include/include.go --
+package include
+
+const _ = Nonexistant
+-- web/exclude/exclude.go --
+package exclude
+
+const _ = Nonexistant
+`
+	cfg := EditorConfig{
+		DirectoryFilters: []string{"-include"},
+		Settings: map[string]interface{}{
+			"folderDirectoryFilters": map[string]interface{}{
+				"web": []interface{}{"-exclude"},
+			},
+		},
+	}
+	WithOptions(cfg, WorkspaceFolders("web")).Run(t, files, func(t *testing.T, env *Env) {
+		env.Await(
+			OnceMet(
+				InitialWorkspaceLoad,
+				env.DiagnosticAtRegexp("web/include/include.go", "Nonexistant"),
+			),
+			NoDiagnostics("web/exclude/exclude.go"),
+		)
+	})
+}
+
+func TestFolderEnv(t *testing.T) {
+	// Each folder is built for its own platform, so X is declared in both.
+	const files = `
+-- web/go.mod --
+module example.com/web
+
+go 1.12
+-- web/x_js.go --
+package web
+
+const X = 1
+-- web/web.go --
+package web
+
+const _ = X
+-- server/go.mod --
+module example.com/server
+
+go 1.12
+-- server/x_linux.go --
+package server
+
+const X = 1
+-- server/server.go --
+package server
+
+const _ = X
+`
+	cfg := EditorConfig{
+		Settings: map[string]interface{}{
+			"folderEnv": map[string]interface{}{
+				"web":    map[string]interface{}{"GOOS": "js", "GOARCH": "wasm"},
+				"server": map[string]interface{}{"GOOS": "linux", "GOARCH": "amd64"},
+			},
+		},
+	}
+	WithOptions(cfg, WorkspaceFolders("web", "server")).Run(t, files, func(t *testing.T, env *Env) {
+		env.OpenFile("web/web.go")
+		env.OpenFile("server/server.go")
+		env.Await(
+			OnceMet(
+				env.DoneWithOpen(),
+				NoDiagnostics("web/web.go"),
+				NoDiagnostics("server/server.go"),
+			),
+		)
+	})
+}
+
 // Confirm that a fix for a tidy module will correct all modules in the
 // workspace.
 func TestMultiModule_OneBrokenModule(t *testing.T) {
@@ -1169,8 +1470,8 @@ func main() {
 				continue
 			}
 			actions := env.GetQuickFixes("b/go.mod", []protocol.Diagnostic{d})
-			if len(actions) != 2 {
-				t.Fatalf("expected 2 code actions, got %v", len(actions))
+			if len(actions) != 3 {
+				t.Fatalf("expected 3 code actions, got %v", len(actions))
 			}
 			env.ApplyQuickFixes("b/go.mod", []protocol.Diagnostic{d})
 		}
@@ -10,7 +10,6 @@ package vulncheck
 import (
 	"context"
 	"log"
-	"os"
 	"strings"
 
 	"github.com/iansmith/golang-x-tools/go/packages"
@@ -21,6 +20,7 @@ import (
 
 func init() {
 	Govulncheck = govulncheck
+	ModuleVulns = moduleVulns
 }
 
 func govulncheck(ctx context.Context, cfg *packages.Config, args command.VulncheckArgs) (res command.VulncheckResult, _ error) {
@@ -28,7 +28,11 @@ func govulncheck(ctx context.Context, cfg *packages.Config, args command.Vulnche
 		args.Pattern = "."
 	}
 
-	dbClient, err := client.NewClient(findGOVULNDB(cfg), client.Options{HTTPCache: gvc.DefaultCache()})
+	dbCfg, err := dbConfigFromEnv(cfg.Env)
+	if err != nil {
+		return res, err
+	}
+	dbClient, err := dbCfg.newClient()
 	if err != nil {
 		return res, err
 	}
@@ -43,16 +47,46 @@ func govulncheck(ctx context.Context, cfg *packages.Config, args command.Vulnche
 	return res, err
 }
 
-func findGOVULNDB(cfg *packages.Config) []string {
-	for _, kv := range cfg.Env {
-		if strings.HasPrefix(kv, "GOVULNDB=") {
-			return strings.Split(kv[len("GOVULNDB="):], ",")
-		}
+// moduleVulns returns the vulnerabilities of the packages of modPath that
+// affect version, without analyzing whether their symbols are used.
+func moduleVulns(ctx context.Context, env []string, modPath, version string) ([]Vuln, error) {
+	dbCfg, err := dbConfigFromEnv(env)
+	if err != nil {
+		return nil, err
 	}
-	if GOVULNDB := os.Getenv("GOVULNDB"); GOVULNDB != "" {
-		return strings.Split(GOVULNDB, ",")
+	dbClient, err := dbCfg.newClient()
+	if err != nil {
+		return nil, err
 	}
-	return []string{"https://vuln.go.dev"}
+	entries, err := dbClient.GetByModule(ctx, modPath)
+	if err != nil {
+		return nil, err
+	}
+	var vulns []Vuln
+	for _, e := range entries {
+		lf := gvc.LatestFixed(e.Affected)
+		if lf != "" && lf[0] != 'v' {
+			lf = "v" + lf
+		}
+		for _, a := range e.Affected {
+			if !a.Ranges.AffectsSemver(version) {
+				continue
+			}
+			vulns = append(vulns, Vuln{
+				ID:             e.ID,
+				PkgPath:        a.Package.Name,
+				CurrentVersion: version,
+				FixedVersion:   lf,
+				Details:        e.Details,
+
+				Aliases: e.Aliases,
+				Symbol:  strings.Join(a.EcosystemSpecific.Symbols, ", "),
+				ModPath: modPath,
+				URL:     href(e),
+			})
+		}
+	}
+	return vulns, nil
 }
 
 type Vuln = command.Vuln
@@ -0,0 +1,104 @@
+// Copyright 2022 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build go1.18
+// +build go1.18
+
+package vulncheck
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	gvc "github.com/iansmith/golang-x-tools/gopls/internal/govulncheck"
+	"golang.org/x/vuln/client"
+)
+
+// A dbConfig configures the vulnerability databases that are consulted.
+// It is read from these environment variables:
+//
+//	GOVULNDB           comma-separated list of the URLs of the databases,
+//	                   or of the directories containing them; by default
+//	                   https://vuln.go.dev
+//	GOPLSVULNCACHETTL  how long the cached index of a remote database is
+//	                   used before the database is asked whether it changed,
+//	                   as a duration such as "24h"; by default 2h
+//	GOPLSVULNOFFLINE   if true, remote databases are never contacted, and
+//	                   are read from the cache only
+type dbConfig struct {
+	urls    []string
+	ttl     time.Duration // 0 for the client's default
+	offline bool
+}
+
+// dbConfigFromEnv returns the configuration of env, or of the process
+// environment for the variables that env does not set.
+func dbConfigFromEnv(env []string) (dbConfig, error) {
+	cfg := dbConfig{urls: []string{"https://vuln.go.dev"}}
+	if v := getenv(env, "GOVULNDB"); v != "" {
+		cfg.urls = nil
+		for _, u := range strings.Split(v, ",") {
+			if !strings.Contains(u, "://") {
+				// A directory, such as a mirror of the database.
+				dir, err := filepath.Abs(u)
+				if err != nil {
+					return dbConfig{}, err
+				}
+				u = "file://" + filepath.ToSlash(dir)
+			}
+			cfg.urls = append(cfg.urls, u)
+		}
+	}
+	if v := getenv(env, "GOPLSVULNCACHETTL"); v != "" {
+		ttl, err := time.ParseDuration(v)
+		if err != nil || ttl <= 0 {
+			return dbConfig{}, fmt.Errorf("invalid GOPLSVULNCACHETTL %q: want a positive duration", v)
+		}
+		cfg.ttl = ttl
+	}
+	if v := getenv(env, "GOPLSVULNOFFLINE"); v != "" {
+		offline, err := strconv.ParseBool(v)
+		if err != nil {
+			return dbConfig{}, fmt.Errorf("invalid GOPLSVULNOFFLINE %q: %v", v, err)
+		}
+		cfg.offline = offline
+	}
+	return cfg, nil
+}
+
+// newClient returns a client of the databases of cfg that caches the
+// entries of remote databases in the module cache.
+func (cfg dbConfig) newClient() (client.Client, error) {
+	cache := gvc.DefaultCache()
+	opts := client.Options{HTTPCache: cache.WithTTL(cfg.ttl)}
+	if cfg.offline {
+		opts.HTTPCache = cache.WithTTL(-1)
+		opts.HTTPClient = &http.Client{Transport: offlineTransport{}}
+	}
+	return client.NewClient(cfg.urls, opts)
+}
+
+// offlineTransport is the http.RoundTripper of the client of an offline
+// configuration, which fails all requests.
+type offlineTransport struct{}
+
+func (offlineTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	return nil, fmt.Errorf("offline: not fetching %s; run once online, or use a local database in GOVULNDB, to populate the cache", req.URL)
+}
+
+// getenv returns the value of the variable key in env, or in the process
+// environment if env does not set it.
+func getenv(env []string, key string) string {
+	for i := len(env) - 1; i >= 0; i-- {
+		if strings.HasPrefix(env[i], key+"=") {
+			return env[i][len(key)+1:]
+		}
+	}
+	return os.Getenv(key)
+}
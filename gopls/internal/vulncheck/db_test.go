@@ -0,0 +1,57 @@
+// Copyright 2022 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build go1.18
+// +build go1.18
+
+package vulncheck
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestDBConfigFromEnv(t *testing.T) {
+	dir, err := filepath.Abs("testdata")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, test := range []struct {
+		env     []string
+		want    dbConfig
+		wantErr bool
+	}{
+		{
+			env:  []string{"GOVULNDB=https://vuln.example.com"},
+			want: dbConfig{urls: []string{"https://vuln.example.com"}},
+		},
+		{
+			env:  []string{"GOVULNDB=https://vuln.example.com,testdata", "GOPLSVULNCACHETTL=24h", "GOPLSVULNOFFLINE=true"},
+			want: dbConfig{urls: []string{"https://vuln.example.com", "file://" + filepath.ToSlash(dir)}, ttl: 24 * time.Hour, offline: true},
+		},
+		{
+			env:  []string{"GOVULNDB=https://vuln.example.com", "GOVULNDB=file:///vulndb"},
+			want: dbConfig{urls: []string{"file:///vulndb"}},
+		},
+		{
+			env:     []string{"GOPLSVULNCACHETTL=-1h"},
+			wantErr: true,
+		},
+		{
+			env:     []string{"GOPLSVULNOFFLINE=sometimes"},
+			wantErr: true,
+		},
+	} {
+		got, err := dbConfigFromEnv(test.env)
+		if (err != nil) != test.wantErr {
+			t.Errorf("dbConfigFromEnv(%q) returned error %v, want error: %v", test.env, err, test.wantErr)
+			continue
+		}
+		if !test.wantErr && !reflect.DeepEqual(got, test.want) {
+			t.Errorf("dbConfigFromEnv(%q) = %+v, want %+v", test.env, got, test.want)
+		}
+	}
+}
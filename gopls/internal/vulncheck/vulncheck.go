@@ -21,3 +21,10 @@ import (
 var Govulncheck = func(ctx context.Context, cfg *packages.Config, args command.VulncheckArgs) (res command.VulncheckResult, _ error) {
 	return res, errors.New("not implemented")
 }
+
+// ModuleVulns returns the known vulnerabilities of the packages of a
+// module version. With go1.18+, this is swapped with the real
+// implementation.
+var ModuleVulns = func(ctx context.Context, env []string, modPath, version string) ([]command.Vuln, error) {
+	return nil, errors.New("not implemented")
+}
@@ -33,15 +33,16 @@ type tdata struct {
 }
 
 var templates = map[string]tdata{
-	"MainTmpl":    {debug.MainTmpl, &debug.Instance{}},
-	"DebugTmpl":   {debug.DebugTmpl, nil},
-	"RPCTmpl":     {debug.RPCTmpl, &debug.Rpcs{}},
-	"TraceTmpl":   {debug.TraceTmpl, debug.TraceResults{}},
-	"CacheTmpl":   {debug.CacheTmpl, &cache.Cache{}},
-	"SessionTmpl": {debug.SessionTmpl, &cache.Session{}},
-	"ViewTmpl":    {debug.ViewTmpl, &cache.View{}},
-	"ClientTmpl":  {debug.ClientTmpl, &debug.Client{}},
-	"ServerTmpl":  {debug.ServerTmpl, &debug.Server{}},
+	"MainTmpl":     {debug.MainTmpl, &debug.Instance{}},
+	"DebugTmpl":    {debug.DebugTmpl, nil},
+	"RPCTmpl":      {debug.RPCTmpl, &debug.Rpcs{}},
+	"TraceTmpl":    {debug.TraceTmpl, debug.TraceResults{}},
+	"CacheTmpl":    {debug.CacheTmpl, &cache.Cache{}},
+	"SessionTmpl":  {debug.SessionTmpl, &cache.Session{}},
+	"ViewTmpl":     {debug.ViewTmpl, &cache.View{}},
+	"SnapshotTmpl": {debug.SnapshotTmpl, &cache.View{}},
+	"ClientTmpl":   {debug.ClientTmpl, &debug.Client{}},
+	"ServerTmpl":   {debug.ServerTmpl, &debug.Server{}},
 	//"FileTmpl":    {FileTmpl, source.Overlay{}}, // need to construct a source.Overlay in init
 	"InfoTmpl":   {debug.InfoTmpl, "something"},
 	"MemoryTmpl": {debug.MemoryTmpl, runtime.MemStats{}},
@@ -0,0 +1,10 @@
+// Copyright 2022 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package aliases provides access to materialized alias types
+// (go/types.Alias) across Go versions.
+//
+// Before Go 1.22 the type checker never produces alias types, so
+// Unalias is the identity function at those versions.
+package aliases
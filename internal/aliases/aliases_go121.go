@@ -0,0 +1,13 @@
+// Copyright 2022 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build !go1.22
+// +build !go1.22
+
+package aliases
+
+import "go/types"
+
+// Unalias returns t, as alias types do not exist at this Go version.
+func Unalias(t types.Type) types.Type { return t }
@@ -0,0 +1,14 @@
+// Copyright 2022 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build go1.22
+// +build go1.22
+
+package aliases
+
+import "go/types"
+
+// Unalias returns t if it is not an alias type; otherwise it follows
+// t's alias chain until it reaches a non-alias type.
+func Unalias(t types.Type) types.Type { return types.Unalias(t) }
@@ -216,6 +216,7 @@ const (
 	NoNewVars      TypeErrorPass = "nonewvars"
 	NoResultValues TypeErrorPass = "noresultvalues"
 	UndeclaredName TypeErrorPass = "undeclaredname"
+	UnknownField   TypeErrorPass = "unknownfield"
 )
 
 // StmtToInsertVarBefore returns the ast.Stmt before which we can safely insert a new variable.
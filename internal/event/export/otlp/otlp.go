@@ -0,0 +1,335 @@
+// Copyright 2022 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package otlp exports spans to an OpenTelemetry collector, in the JSON
+// encoding of the OpenTelemetry protocol over HTTP. Like the ocagent package,
+// it has no dependencies outside of the standard library.
+package otlp
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/iansmith/golang-x-tools/internal/event"
+	"github.com/iansmith/golang-x-tools/internal/event/core"
+	"github.com/iansmith/golang-x-tools/internal/event/export"
+	"github.com/iansmith/golang-x-tools/internal/event/keys"
+	"github.com/iansmith/golang-x-tools/internal/event/label"
+)
+
+type Config struct {
+	// Endpoint is the URL to which the spans are posted, such as
+	// http://localhost:4318/v1/traces.
+	Endpoint string
+	// Headers are added to each request, for instance to authenticate it.
+	Headers map[string]string
+	Service string
+	Client  *http.Client
+	Rate    time.Duration
+}
+
+// Discover returns the configuration given by the standard OpenTelemetry
+// environment variables OTEL_EXPORTER_OTLP_TRACES_ENDPOINT, or else
+// OTEL_EXPORTER_OTLP_ENDPOINT, OTEL_EXPORTER_OTLP_HEADERS and
+// OTEL_SERVICE_NAME. It returns nil if no endpoint is set.
+func Discover() *Config {
+	config := &Config{
+		Endpoint: os.Getenv("OTEL_EXPORTER_OTLP_TRACES_ENDPOINT"),
+		Service:  os.Getenv("OTEL_SERVICE_NAME"),
+	}
+	if config.Endpoint == "" {
+		base := os.Getenv("OTEL_EXPORTER_OTLP_ENDPOINT")
+		if base == "" {
+			return nil
+		}
+		config.Endpoint = strings.TrimSuffix(base, "/") + "/v1/traces"
+	}
+	for _, header := range strings.Split(os.Getenv("OTEL_EXPORTER_OTLP_HEADERS"), ",") {
+		eq := strings.IndexByte(header, '=')
+		if eq < 0 {
+			continue
+		}
+		if config.Headers == nil {
+			config.Headers = make(map[string]string)
+		}
+		config.Headers[strings.TrimSpace(header[:eq])] = strings.TrimSpace(header[eq+1:])
+	}
+	return config
+}
+
+type Exporter struct {
+	mu     sync.Mutex
+	config Config
+	spans  []*export.Span
+}
+
+// Connect creates an exporter that posts the spans that end to the endpoint
+// of config, every config.Rate. It returns nil if config is nil.
+func Connect(config *Config) *Exporter {
+	if config == nil || config.Endpoint == "" {
+		return nil
+	}
+	resolved := *config
+	if resolved.Client == nil {
+		resolved.Client = http.DefaultClient
+	}
+	if resolved.Service == "" {
+		resolved.Service = filepath.Base(os.Args[0])
+	}
+	if resolved.Rate == 0 {
+		resolved.Rate = 2 * time.Second
+	}
+	exporter := &Exporter{config: resolved}
+	go func() {
+		for range time.Tick(exporter.config.Rate) {
+			exporter.Flush()
+		}
+	}()
+	return exporter
+}
+
+func (e *Exporter) ProcessEvent(ctx context.Context, ev core.Event, lm label.Map) context.Context {
+	if !event.IsEnd(ev) {
+		return ctx
+	}
+	if span := export.GetSpan(ctx); span != nil {
+		e.mu.Lock()
+		e.spans = append(e.spans, span)
+		e.mu.Unlock()
+	}
+	return ctx
+}
+
+// Flush posts the spans that ended since the last call.
+func (e *Exporter) Flush() {
+	e.mu.Lock()
+	spans := e.spans
+	e.spans = nil
+	e.mu.Unlock()
+	if len(spans) == 0 {
+		return
+	}
+	converted := make([]span, len(spans))
+	for i, s := range spans {
+		converted[i] = convertSpan(s)
+	}
+	e.send(&exportTraceServiceRequest{
+		ResourceSpans: []resourceSpans{{
+			Resource: resource{Attributes: []keyValue{
+				{Key: "service.name", Value: anyValue{StringValue: &e.config.Service}},
+				{Key: "process.pid", Value: intValue(int64(os.Getpid()))},
+			}},
+			ScopeSpans: []scopeSpans{{
+				Scope: scope{Name: "golang.org/x/tools/internal/event"},
+				Spans: converted,
+			}},
+		}},
+	})
+}
+
+func (e *Exporter) send(message interface{}) {
+	blob, err := json.Marshal(message)
+	if err != nil {
+		errorInExport("otlp failed to marshal message: %v", err)
+		return
+	}
+	req, err := http.NewRequest("POST", e.config.Endpoint, bytes.NewReader(blob))
+	if err != nil {
+		errorInExport("otlp failed to build request for %v: %v", e.config.Endpoint, err)
+		return
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for k, v := range e.config.Headers {
+		req.Header.Set(k, v)
+	}
+	res, err := e.config.Client.Do(req)
+	if err != nil {
+		errorInExport("otlp failed to send message: %v", err)
+		return
+	}
+	if res.Body != nil {
+		res.Body.Close()
+	}
+}
+
+func errorInExport(message string, args ...interface{}) {
+	// As in the ocagent package, failures to export are dropped.
+}
+
+// The types below are the subset of the JSON encoding of the OTLP trace
+// service request that the exporter uses.
+
+type exportTraceServiceRequest struct {
+	ResourceSpans []resourceSpans `json:"resourceSpans"`
+}
+
+type resourceSpans struct {
+	Resource   resource     `json:"resource"`
+	ScopeSpans []scopeSpans `json:"scopeSpans"`
+}
+
+type resource struct {
+	Attributes []keyValue `json:"attributes,omitempty"`
+}
+
+type scopeSpans struct {
+	Scope scope  `json:"scope"`
+	Spans []span `json:"spans"`
+}
+
+type scope struct {
+	Name string `json:"name"`
+}
+
+type span struct {
+	TraceID           string      `json:"traceId"`
+	SpanID            string      `json:"spanId"`
+	ParentSpanID      string      `json:"parentSpanId,omitempty"`
+	Name              string      `json:"name"`
+	Kind              int         `json:"kind"`
+	StartTimeUnixNano string      `json:"startTimeUnixNano"`
+	EndTimeUnixNano   string      `json:"endTimeUnixNano"`
+	Attributes        []keyValue  `json:"attributes,omitempty"`
+	Events            []spanEvent `json:"events,omitempty"`
+}
+
+type spanEvent struct {
+	TimeUnixNano string     `json:"timeUnixNano"`
+	Name         string     `json:"name"`
+	Attributes   []keyValue `json:"attributes,omitempty"`
+}
+
+type keyValue struct {
+	Key   string   `json:"key"`
+	Value anyValue `json:"value"`
+}
+
+// anyValue holds one of its fields. Integers are strings in the JSON
+// encoding of OTLP.
+type anyValue struct {
+	StringValue *string  `json:"stringValue,omitempty"`
+	IntValue    *string  `json:"intValue,omitempty"`
+	DoubleValue *float64 `json:"doubleValue,omitempty"`
+	BoolValue   *bool    `json:"boolValue,omitempty"`
+}
+
+// spanKindInternal is the kind of the spans of operations internal to the
+// process.
+const spanKindInternal = 1
+
+func convertSpan(s *export.Span) span {
+	result := span{
+		TraceID:           s.ID.TraceID.String(),
+		SpanID:            s.ID.SpanID.String(),
+		Name:              s.Name,
+		Kind:              spanKindInternal,
+		StartTimeUnixNano: convertTimestamp(s.Start().At()),
+		EndTimeUnixNano:   convertTimestamp(s.Finish().At()),
+		// The first label of the start event is the name of the span.
+		Attributes: convertAttributes(s.Start(), 1),
+	}
+	if s.ParentID.IsValid() {
+		result.ParentSpanID = s.ParentID.String()
+	}
+	for _, ev := range s.Events() {
+		result.Events = append(result.Events, convertEvent(ev))
+	}
+	return result
+}
+
+func convertTimestamp(t time.Time) string {
+	return strconv.FormatInt(t.UnixNano(), 10)
+}
+
+func convertEvent(ev core.Event) spanEvent {
+	result := spanEvent{TimeUnixNano: convertTimestamp(ev.At())}
+	index := 0
+	if l := ev.Label(0); l.Key() == keys.Msg {
+		result.Name = keys.Msg.From(l)
+		index = 1
+		if l := ev.Label(1); l.Key() == keys.Err {
+			if err := keys.Err.From(l); err != nil {
+				result.Attributes = append(result.Attributes, keyValue{Key: "error", Value: stringValue(err.Error())})
+			}
+			index = 2
+		}
+	}
+	if result.Name == "" {
+		result.Name = "label"
+	}
+	result.Attributes = append(result.Attributes, convertAttributes(ev, index)...)
+	return result
+}
+
+func convertAttributes(list label.List, index int) []keyValue {
+	var result []keyValue
+	for ; list.Valid(index); index++ {
+		l := list.Label(index)
+		if !l.Valid() || l.Key() == keys.Label {
+			continue
+		}
+		result = append(result, keyValue{Key: l.Key().Name(), Value: convertValue(l)})
+	}
+	return result
+}
+
+func convertValue(l label.Label) anyValue {
+	switch key := l.Key().(type) {
+	case *keys.Int:
+		return intValue(int64(key.From(l)))
+	case *keys.Int8:
+		return intValue(int64(key.From(l)))
+	case *keys.Int16:
+		return intValue(int64(key.From(l)))
+	case *keys.Int32:
+		return intValue(int64(key.From(l)))
+	case *keys.Int64:
+		return intValue(key.From(l))
+	case *keys.UInt:
+		return intValue(int64(key.From(l)))
+	case *keys.UInt8:
+		return intValue(int64(key.From(l)))
+	case *keys.UInt16:
+		return intValue(int64(key.From(l)))
+	case *keys.UInt32:
+		return intValue(int64(key.From(l)))
+	case *keys.UInt64:
+		return intValue(int64(key.From(l)))
+	case *keys.Float32:
+		v := float64(key.From(l))
+		return anyValue{DoubleValue: &v}
+	case *keys.Float64:
+		v := key.From(l)
+		return anyValue{DoubleValue: &v}
+	case *keys.Boolean:
+		v := key.From(l)
+		return anyValue{BoolValue: &v}
+	case *keys.String:
+		return stringValue(key.From(l))
+	case *keys.Error:
+		return stringValue(key.From(l).Error())
+	case *keys.Value:
+		return stringValue(fmt.Sprint(key.From(l)))
+	default:
+		return stringValue(fmt.Sprintf("%T", key))
+	}
+}
+
+func intValue(v int64) anyValue {
+	s := strconv.FormatInt(v, 10)
+	return anyValue{IntValue: &s}
+}
+
+func stringValue(s string) anyValue {
+	return anyValue{StringValue: &s}
+}
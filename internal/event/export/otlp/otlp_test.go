@@ -0,0 +1,159 @@
+// Copyright 2022 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package otlp_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/iansmith/golang-x-tools/internal/event"
+	"github.com/iansmith/golang-x-tools/internal/event/core"
+	"github.com/iansmith/golang-x-tools/internal/event/export"
+	"github.com/iansmith/golang-x-tools/internal/event/export/otlp"
+	"github.com/iansmith/golang-x-tools/internal/event/keys"
+	"github.com/iansmith/golang-x-tools/internal/event/label"
+)
+
+var (
+	keyDB    = keys.NewString("db", "the database name")
+	keyCount = keys.NewInt64("count", "a count")
+)
+
+func TestTrace(t *testing.T) {
+	bodies := make(chan []byte, 1)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/traces" {
+			t.Errorf("got a request to %s, want /v1/traces", r.URL.Path)
+		}
+		if got := r.Header.Get("Authorization"); got != "Bearer secret" {
+			t.Errorf("got Authorization %q, want %q", got, "Bearer secret")
+		}
+		data, err := ioutil.ReadAll(r.Body)
+		if err != nil {
+			t.Error(err)
+		}
+		bodies <- data
+	}))
+	defer srv.Close()
+
+	exporter := otlp.Connect(&otlp.Config{
+		Endpoint: srv.URL + "/v1/traces",
+		Headers:  map[string]string{"Authorization": "Bearer secret"},
+		Service:  "otlp-tests",
+		Rate:     time.Hour,
+	})
+	e := exporter.ProcessEvent
+	e = spanFixer(e)
+	e = export.Spans(e)
+	e = export.Labels(e)
+	e = timeFixer(e)
+	event.SetExporter(e)
+	defer event.SetExporter(nil)
+
+	ctx, done := event.Start(context.Background(), "event span", keyDB.Of("godb"))
+	event.Log(ctx, "cache miss", keyCount.Of(3))
+	done()
+	exporter.Flush()
+
+	var got map[string]interface{}
+	if err := json.Unmarshal(<-bodies, &got); err != nil {
+		t.Fatal(err)
+	}
+	var want map[string]interface{}
+	if err := json.Unmarshal([]byte(`{"resourceSpans":[{
+		"resource":{"attributes":[
+			{"key":"service.name","value":{"stringValue":"otlp-tests"}},
+			{"key":"process.pid","value":{"intValue":"PID"}}
+		]},
+		"scopeSpans":[{
+			"scope":{"name":"golang.org/x/tools/internal/event"},
+			"spans":[{
+				"traceId":"0102030405060708090a0b0c0d0e0f10",
+				"spanId":"0102030405060708",
+				"name":"event span",
+				"kind":1,
+				"startTimeUnixNano":"30000000000",
+				"endTimeUnixNano":"50000000000",
+				"attributes":[{"key":"db","value":{"stringValue":"godb"}}],
+				"events":[{
+					"timeUnixNano":"40000000000",
+					"name":"cache miss",
+					"attributes":[{"key":"count","value":{"intValue":"3"}}]
+				}]
+			}]
+		}]
+	}]}`), &want); err != nil {
+		t.Fatal(err)
+	}
+	// The pid is not known in advance.
+	resource := got["resourceSpans"].([]interface{})[0].(map[string]interface{})["resource"].(map[string]interface{})
+	pid := resource["attributes"].([]interface{})[1].(map[string]interface{})["value"].(map[string]interface{})
+	pid["intValue"] = "PID"
+
+	g, _ := json.Marshal(got)
+	w, _ := json.Marshal(want)
+	if !bytes.Equal(g, w) {
+		t.Errorf("got:\n%s\nwant:\n%s", g, w)
+	}
+}
+
+func TestDiscover(t *testing.T) {
+	for _, test := range []struct {
+		traces, base, headers string
+		want                  *otlp.Config
+	}{
+		{"", "", "", nil},
+		{"", "http://collector:4318/", "", &otlp.Config{Endpoint: "http://collector:4318/v1/traces"}},
+		{"http://collector:4318/traces", "http://other:4318", "", &otlp.Config{Endpoint: "http://collector:4318/traces"}},
+		{"", "http://collector:4318", "a=1, b = 2", &otlp.Config{
+			Endpoint: "http://collector:4318/v1/traces",
+			Headers:  map[string]string{"a": "1", "b": "2"},
+		}},
+	} {
+		t.Setenv("OTEL_EXPORTER_OTLP_TRACES_ENDPOINT", test.traces)
+		t.Setenv("OTEL_EXPORTER_OTLP_ENDPOINT", test.base)
+		t.Setenv("OTEL_EXPORTER_OTLP_HEADERS", test.headers)
+		t.Setenv("OTEL_SERVICE_NAME", "")
+		got := otlp.Discover()
+		g, _ := json.Marshal(got)
+		w, _ := json.Marshal(test.want)
+		if !bytes.Equal(g, w) {
+			t.Errorf("Discover() with endpoints %q, %q and headers %q = %s, want %s", test.traces, test.base, test.headers, g, w)
+		}
+	}
+}
+
+func timeFixer(output event.Exporter) event.Exporter {
+	return func(ctx context.Context, ev core.Event, lm label.Map) context.Context {
+		switch {
+		case event.IsStart(ev):
+			ev = core.CloneEvent(ev, time.Unix(30, 0))
+		case event.IsEnd(ev):
+			ev = core.CloneEvent(ev, time.Unix(50, 0))
+		default:
+			ev = core.CloneEvent(ev, time.Unix(40, 0))
+		}
+		return output(ctx, ev, lm)
+	}
+}
+
+func spanFixer(output event.Exporter) event.Exporter {
+	return func(ctx context.Context, ev core.Event, lm label.Map) context.Context {
+		if event.IsStart(ev) {
+			span := export.GetSpan(ctx)
+			span.ID = export.SpanContext{
+				TraceID: export.TraceID{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16},
+				SpanID:  export.SpanID{1, 2, 3, 4, 5, 6, 7, 8},
+			}
+		}
+		return output(ctx, ev, lm)
+	}
+}
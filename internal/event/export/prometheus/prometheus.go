@@ -10,6 +10,7 @@ import (
 	"fmt"
 	"net/http"
 	"sort"
+	"strconv"
 	"sync"
 
 	"github.com/iansmith/golang-x-tools/internal/event"
@@ -69,7 +70,12 @@ func (e *Exporter) header(w http.ResponseWriter, name, description string, isGau
 func (e *Exporter) row(w http.ResponseWriter, name string, group []label.Label, extra string, value interface{}) {
 	fmt.Fprint(w, name)
 	buf := &bytes.Buffer{}
-	fmt.Fprint(buf, group)
+	for _, l := range group {
+		if buf.Len() > 0 {
+			fmt.Fprint(buf, ",")
+		}
+		writeLabel(buf, l)
+	}
 	if extra != "" {
 		if buf.Len() > 0 {
 			fmt.Fprint(buf, ",")
@@ -84,6 +90,28 @@ func (e *Exporter) row(w http.ResponseWriter, name string, group []label.Label,
 	fmt.Fprintf(w, " %v\n", value)
 }
 
+// writeLabel writes l in the text exposition format: its name, restricted to
+// the characters allowed by Prometheus, and its quoted value.
+func writeLabel(buf *bytes.Buffer, l label.Label) {
+	for _, r := range l.Key().Name() {
+		if r == '_' || r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' {
+			buf.WriteRune(r)
+		} else {
+			buf.WriteByte('_')
+		}
+	}
+	buf.WriteByte('=')
+	value := &bytes.Buffer{}
+	var scratch [128]byte
+	l.Key().Format(value, scratch[:0], l)
+	if bytes.HasPrefix(value.Bytes(), []byte(`"`)) {
+		// String values are already quoted.
+		value.WriteTo(buf)
+	} else {
+		buf.WriteString(strconv.Quote(value.String()))
+	}
+}
+
 func (e *Exporter) Serve(w http.ResponseWriter, r *http.Request) {
 	e.mu.Lock()
 	defer e.mu.Unlock()
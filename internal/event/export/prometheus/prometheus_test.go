@@ -0,0 +1,50 @@
+// Copyright 2022 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package prometheus_test
+
+import (
+	"context"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/iansmith/golang-x-tools/internal/event"
+	"github.com/iansmith/golang-x-tools/internal/event/export"
+	"github.com/iansmith/golang-x-tools/internal/event/export/metric"
+	"github.com/iansmith/golang-x-tools/internal/event/export/prometheus"
+	"github.com/iansmith/golang-x-tools/internal/event/keys"
+	"github.com/iansmith/golang-x-tools/internal/event/label"
+)
+
+func TestServe(t *testing.T) {
+	var (
+		method  = keys.NewString("method", "")
+		status  = keys.NewString("status.code", "")
+		attempt = keys.NewInt("attempt", "")
+		calls   = keys.NewInt64("calls", "")
+	)
+	completed := metric.Scalar{
+		Name:        "completed",
+		Description: "Count of calls.",
+		Keys:        []label.Key{method, status, attempt},
+	}
+	exporter := prometheus.New()
+	metrics := metric.Config{}
+	completed.Count(&metrics, calls)
+	event.SetExporter(export.Labels(metrics.Exporter(exporter.ProcessEvent)))
+	defer event.SetExporter(nil)
+
+	ctx := context.Background()
+	event.Metric(ctx, calls.Of(1), method.Of("initialize"), status.Of("OK"), attempt.Of(2))
+
+	w := httptest.NewRecorder()
+	exporter.Serve(w, httptest.NewRequest("GET", "/metrics/", nil))
+	const want = `# HELP completed Count of calls.
+# TYPE completed counter
+completed{method="initialize",status_code="OK",attempt="2"} 1
+`
+	if got := w.Body.String(); got != want {
+		t.Errorf("Serve wrote:\n%s\nwant:\n%s", got, want)
+	}
+}
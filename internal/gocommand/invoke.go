@@ -198,6 +198,9 @@ func (i *Invocation) run(ctx context.Context, stdout, stderr io.Writer) error {
 		goArgs = append(goArgs, i.Args[0])
 		appendModFile()
 		goArgs = append(goArgs, i.Args[1:]...)
+	case "work":
+		// work subcommands accept neither build flags nor -mod.
+		goArgs = append(goArgs, i.Args...)
 	case "get":
 		goArgs = append(goArgs, i.BuildFlags...)
 		appendModFile()
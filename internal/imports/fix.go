@@ -59,15 +59,47 @@ var importToGroup = []func(localPrefix, importPath string) (num int, ok bool){
 	},
 }
 
-func importGroup(localPrefix, importPath string) int {
+func importGroup(opt *Options, importPath string) int {
+	if len(opt.Groups) > 0 {
+		return configuredImportGroup(opt.Groups, importPath)
+	}
 	for _, fn := range importToGroup {
-		if n, ok := fn(localPrefix, importPath); ok {
+		if n, ok := fn(opt.LocalPrefix, importPath); ok {
 			return n
 		}
 	}
 	return 0
 }
 
+// configuredImportGroup returns the index in groups, an Options.Groups
+// list, of the group of importPath. Prefix groups take precedence over
+// "std" and "third-party"; import paths in no group sort after all the
+// groups.
+func configuredImportGroup(groups []string, importPath string) int {
+	std, thirdParty := -1, -1
+	for i, g := range groups {
+		switch g {
+		case "std":
+			std = i
+		case "third-party":
+			thirdParty = i
+		default:
+			for _, p := range strings.Split(g, ",") {
+				if p != "" && (strings.HasPrefix(importPath, p) || strings.TrimSuffix(p, "/") == importPath) {
+					return i
+				}
+			}
+		}
+	}
+	if std >= 0 && !strings.Contains(strings.Split(importPath, "/")[0], ".") {
+		return std
+	}
+	if thirdParty >= 0 {
+		return thirdParty
+	}
+	return len(groups)
+}
+
 type ImportFixType int
 
 const (
@@ -796,6 +828,51 @@ func GetPackageExports(ctx context.Context, wrapped func(PackageExport), searchP
 	return getCandidatePkgs(ctx, callback, filename, filePkg, env)
 }
 
+// GetExportsWithPrefix calls wrapped for each package that can be imported
+// from filename with the package name filePkg, with its exports whose names
+// start with searchPrefix, ignoring case. Packages without such exports are
+// skipped.
+func GetExportsWithPrefix(ctx context.Context, wrapped func(PackageExport), searchPrefix, filename, filePkg string, env *ProcessEnv) error {
+	searchPrefix = strings.ToLower(searchPrefix)
+	callback := &scanCallback{
+		rootFound: func(gopathwalk.Root) bool {
+			return true
+		},
+		dirFound: func(pkg *pkg) bool {
+			return canUse(filename, pkg.dir)
+		},
+		packageNameLoaded: func(pkg *pkg) bool {
+			// Packages named main, and test packages, can't be imported.
+			return pkg.packageName != "main" && !strings.HasSuffix(pkg.packageName, "_test")
+		},
+		exportsLoaded: func(pkg *pkg, exports []string) {
+			var matches []string
+			for _, export := range exports {
+				if strings.HasPrefix(strings.ToLower(export), searchPrefix) {
+					matches = append(matches, export)
+				}
+			}
+			if len(matches) == 0 {
+				return
+			}
+			sort.Strings(matches)
+			wrapped(PackageExport{
+				Fix: &ImportFix{
+					StmtInfo: ImportInfo{
+						ImportPath: pkg.importPathShort,
+						Name:       candidateImportName(pkg),
+					},
+					IdentName: pkg.packageName,
+					FixType:   AddImport,
+					Relevance: pkg.relevance,
+				},
+				Exports: matches,
+			})
+		},
+	}
+	return getCandidatePkgs(ctx, callback, filename, filePkg, env)
+}
+
 var RequiredGoEnvVars = []string{"GO111MODULE", "GOFLAGS", "GOINSECURE", "GOMOD", "GOMODCACHE", "GONOPROXY", "GONOSUMDB", "GOPATH", "GOPROXY", "GOROOT", "GOSUMDB"}
 
 // ProcessEnv contains environment variables and settings that affect the use of
@@ -2006,6 +2006,105 @@ const _ = runtime.GOOS
 	}
 }
 
+// Tests that the Groups option overrides the default grouping.
+func TestGroups(t *testing.T) {
+	const src = `package main
+
+import (
+	"example.org/pkg"
+	"fmt"
+	"foo.com/bar"
+	"rsc.io/quote"
+	"test.com/sub"
+)
+
+var _, _, _, _, _ = pkg.A, fmt.Print, bar.B, quote.Hello, sub.C
+`
+	tests := []struct {
+		name   string
+		groups []string
+		want   string
+	}{
+		{
+			name:   "local_first",
+			groups: []string{"test.com", "std", "third-party"},
+			want: `package main
+
+import (
+	"test.com/sub"
+
+	"fmt"
+
+	"example.org/pkg"
+	"foo.com/bar"
+	"rsc.io/quote"
+)
+
+var _, _, _, _, _ = pkg.A, fmt.Print, bar.B, quote.Hello, sub.C
+`,
+		},
+		{
+			name:   "named_groups",
+			groups: []string{"std", "third-party", "example.org,foo.com/", "test.com"},
+			want: `package main
+
+import (
+	"fmt"
+
+	"rsc.io/quote"
+
+	"example.org/pkg"
+	"foo.com/bar"
+
+	"test.com/sub"
+)
+
+var _, _, _, _, _ = pkg.A, fmt.Print, bar.B, quote.Hello, sub.C
+`,
+		},
+		{
+			name:   "no_third_party",
+			groups: []string{"std", "test.com"},
+			want: `package main
+
+import (
+	"fmt"
+
+	"test.com/sub"
+
+	"example.org/pkg"
+	"foo.com/bar"
+	"rsc.io/quote"
+)
+
+var _, _, _, _, _ = pkg.A, fmt.Print, bar.B, quote.Hello, sub.C
+`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			testConfig{
+				module: packagestest.Module{
+					Name:  "test.com",
+					Files: fm{"t.go": src},
+				},
+			}.test(t, func(t *goimportTest) {
+				options := &Options{
+					LocalPrefix: "test.com",
+					Groups:      tt.groups,
+					TabWidth:    8,
+					TabIndent:   true,
+					Comments:    true,
+					Fragment:    true,
+					FormatOnly:  true,
+				}
+				t.assertProcessEquals("test.com", "t.go", nil, options, tt.want)
+			})
+		})
+	}
+}
+
 // Tests that "package documentation" files are ignored.
 func TestIgnoreDocumentationPackage(t *testing.T) {
 	const input = `package x
@@ -2831,6 +2930,58 @@ func TestGetPackageCompletions(t *testing.T) {
 	})
 }
 
+func TestGetExportsWithPrefix(t *testing.T) {
+	type res struct {
+		relevance          float64
+		name, path, symbol string
+	}
+	want := []res{
+		{0, "rand", "math/rand", "Seed"},
+		{0, "rand", "bar.com/rand", "SeedBar"},
+	}
+
+	testConfig{
+		modules: []packagestest.Module{
+			{
+				Name:  "bar.com",
+				Files: fm{"rand/bar.go": "package rand\nvar SeedBar int\nvar Bar int\n"},
+			},
+		},
+	}.test(t, func(t *goimportTest) {
+		var mu sync.Mutex
+		var got []res
+		add := func(c PackageExport) {
+			mu.Lock()
+			defer mu.Unlock()
+			for _, csym := range c.Exports {
+				for _, w := range want {
+					if c.Fix.StmtInfo.ImportPath == w.path {
+						got = append(got, res{c.Fix.Relevance, c.Fix.IdentName, c.Fix.StmtInfo.ImportPath, csym})
+						break
+					}
+				}
+			}
+		}
+		if err := GetExportsWithPrefix(context.Background(), add, "seed", "x.go", "x", t.env); err != nil {
+			t.Fatalf("GetExportsWithPrefix() = %v", err)
+		}
+		// Sort, then clear out relevance so it doesn't mess up the DeepEqual.
+		sort.Slice(got, func(i, j int) bool {
+			ri, rj := got[i], got[j]
+			if ri.relevance != rj.relevance {
+				return ri.relevance > rj.relevance // Highest first.
+			}
+			return ri.name < rj.name
+		})
+		for i := range got {
+			got[i].relevance = 0
+		}
+		if !reflect.DeepEqual(want, got) {
+			t.Errorf("wanted results in order %v, got %v", want, got)
+		}
+	})
+}
+
 // Tests #34895: process should not panic on concurrent calls.
 func TestConcurrentProcess(t *testing.T) {
 	testConfig{
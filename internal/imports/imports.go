@@ -34,6 +34,13 @@ type Options struct {
 	// into another group after 3rd-party packages.
 	LocalPrefix string
 
+	// Groups, if set, is the ordered list of the groups into which Process
+	// sorts import paths, overriding the default grouping and LocalPrefix.
+	// Each group is "std", for the packages of the standard library,
+	// "third-party", for the packages in no other group, or a
+	// comma-separated string of import path prefixes.
+	Groups []string
+
 	Fragment  bool // Accept fragment of a source file (no package statement)
 	AllErrors bool // Report all errors (not just the first 10 on different lines)
 
@@ -105,7 +112,7 @@ func ApplyFixes(fixes []*ImportFix, filename string, src []byte, opt *Options, e
 
 func formatFile(fileSet *token.FileSet, file *ast.File, src []byte, adjust func(orig []byte, src []byte) []byte, opt *Options) ([]byte, error) {
 	mergeImports(fileSet, file)
-	sortImports(opt.LocalPrefix, fileSet, file)
+	sortImports(opt, fileSet, file)
 	imps := astutil.Imports(fileSet, file)
 	var spacesBefore []string // import paths we need spaces before
 	for _, impSection := range imps {
@@ -116,7 +123,7 @@ func formatFile(fileSet *token.FileSet, file *ast.File, src []byte, adjust func(
 		lastGroup := -1
 		for _, importSpec := range impSection {
 			importPath, _ := strconv.Unquote(importSpec.Path.Value)
-			groupNum := importGroup(opt.LocalPrefix, importPath)
+			groupNum := importGroup(opt, importPath)
 			if groupNum != lastGroup && lastGroup != -1 {
 				spacesBefore = append(spacesBefore, importPath)
 			}
@@ -10,6 +10,7 @@ import (
 	"fmt"
 	"sync"
 	"sync/atomic"
+	"time"
 
 	"github.com/iansmith/golang-x-tools/internal/event"
 	"github.com/iansmith/golang-x-tools/internal/event/label"
@@ -109,8 +110,10 @@ func (c *conn) Call(ctx context.Context, method string, params, result interface
 		tag.RPCDirection.Of(tag.Outbound),
 		tag.RPCID.Of(fmt.Sprintf("%q", id)),
 	)
+	start := time.Now()
 	defer func() {
 		recordStatus(ctx, err)
+		recordLatency(ctx, start, err)
 		done()
 	}()
 	event.Metric(ctx, tag.Started.Of(1))
@@ -154,9 +157,11 @@ func (c *conn) Call(ctx context.Context, method string, params, result interface
 }
 
 func (c *conn) replier(req Request, spanDone func()) Replier {
+	start := time.Now()
 	return func(ctx context.Context, result interface{}, err error) error {
 		defer func() {
 			recordStatus(ctx, err)
+			recordLatency(ctx, start, err)
 			spanDone()
 		}()
 		call, ok := req.(*Call)
@@ -260,3 +265,15 @@ func recordStatus(ctx context.Context, err error) {
 		event.Label(ctx, tag.StatusCode.Of("OK"))
 	}
 }
+
+// recordLatency records the time elapsed since start, in milliseconds, for
+// an RPC that completed with err.
+func recordLatency(ctx context.Context, start time.Time, err error) {
+	status := "OK"
+	if err != nil {
+		status = "ERROR"
+	}
+	event.Metric(ctx,
+		tag.Latency.Of(float64(time.Since(start))/float64(time.Millisecond)),
+		tag.StatusCode.Of(status))
+}
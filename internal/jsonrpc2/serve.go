@@ -64,6 +64,12 @@ func ListenAndServe(ctx context.Context, network, addr string, server StreamServ
 // the provided server. If idleTimeout is non-zero, ListenAndServe exits after
 // there are no clients for this duration, otherwise it exits only on error.
 func Serve(ctx context.Context, ln net.Listener, server StreamServer, idleTimeout time.Duration) error {
+	return ServeFramed(ctx, ln, server, idleTimeout, NewHeaderStream)
+}
+
+// ServeFramed is like Serve, but frames the messages of the connections with
+// newStream, for example NewRawStream, instead of NewHeaderStream.
+func ServeFramed(ctx context.Context, ln net.Listener, server StreamServer, idleTimeout time.Duration, newStream func(net.Conn) Stream) error {
 	newConns := make(chan net.Conn)
 	closedConns := make(chan error)
 	activeConns := 0
@@ -119,7 +125,7 @@ func Serve(ctx context.Context, ln net.Listener, server StreamServer, idleTimeou
 				<-connTimer.C
 			}
 			activeConns++
-			stream := NewHeaderStream(netConn)
+			stream := newStream(netConn)
 			go func() {
 				conn := NewConn(stream)
 				err := server.ServeStream(ctx, conn)
@@ -0,0 +1,332 @@
+// Copyright 2022 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package commentstyle defines an Analyzer that checks the conventions of doc
+// comments and the spelling of comments.
+package commentstyle
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/iansmith/golang-x-tools/go/analysis"
+	"github.com/iansmith/golang-x-tools/internal/typeparams"
+)
+
+const Doc = `check the conventions and spelling of comments
+
+The commentstyle analyzer reports, with suggested fixes:
+
+- doc comments of exported declarations that do not begin with the name
+  of the declared identifier, such as "Returns the sum." for func Sum;
+
+- malformed deprecation notices, which should be a paragraph beginning
+  with "Deprecated: ", such as "DEPRECATED - use Sum instead.";
+
+- common misspellings in comments, such as "recieve". The -dictionary
+  flag names a file of additional misspellings, one "misspelling
+  correction" pair per line.`
+
+var Analyzer = NewAnalyzer(DefaultDictionary)
+
+// NewAnalyzer returns a commentstyle analyzer that reports the misspellings
+// of dict, and of the files given to its -dictionary flag.
+func NewAnalyzer(dict Dictionary) *analysis.Analyzer {
+	c := &checker{dict: make(Dictionary, len(dict))}
+	for misspelling, correction := range dict {
+		c.dict[strings.ToLower(misspelling)] = correction
+	}
+	a := &analysis.Analyzer{
+		Name: "commentstyle",
+		Doc:  Doc,
+		Run:  c.run,
+	}
+	a.Flags.Var((*dictionaryFlag)(&c.dict), "dictionary",
+		`file of additional misspellings, one "misspelling correction" pair per line`)
+	return a
+}
+
+type checker struct {
+	dict Dictionary
+}
+
+func (c *checker) run(pass *analysis.Pass) (interface{}, error) {
+	for _, file := range pass.Files {
+		// Test functions need not be documented by their name.
+		checkNames := !strings.HasSuffix(pass.Fset.Position(file.Package).Filename, "_test.go")
+		docs := map[*ast.CommentGroup]bool{}
+		if file.Doc != nil {
+			docs[file.Doc] = true
+		}
+		ast.Inspect(file, func(n ast.Node) bool {
+			switch n := n.(type) {
+			case *ast.FuncDecl:
+				if n.Doc != nil {
+					docs[n.Doc] = true
+					if checkNames {
+						checkFuncDoc(pass, n)
+					}
+				}
+				return false
+			case *ast.GenDecl:
+				if n.Doc != nil {
+					docs[n.Doc] = true
+					if checkNames && !n.Lparen.IsValid() && len(n.Specs) == 1 {
+						checkSpecDoc(pass, n.Tok, n.Specs[0], n.Doc)
+					}
+				}
+				if checkNames && n.Lparen.IsValid() && n.Tok == token.TYPE {
+					for _, spec := range n.Specs {
+						if doc := spec.(*ast.TypeSpec).Doc; doc != nil {
+							checkSpecDoc(pass, n.Tok, spec, doc)
+						}
+					}
+				}
+			case *ast.TypeSpec:
+				if n.Doc != nil {
+					docs[n.Doc] = true
+				}
+			case *ast.ValueSpec:
+				if n.Doc != nil {
+					docs[n.Doc] = true
+				}
+			case *ast.Field:
+				if n.Doc != nil {
+					docs[n.Doc] = true
+				}
+			}
+			return true
+		})
+		for _, group := range file.Comments {
+			if docs[group] {
+				checkDeprecation(pass, group)
+			}
+			for _, comment := range group.List {
+				if !isDirective(comment.Text) {
+					c.checkSpelling(pass, comment)
+				}
+			}
+		}
+	}
+	return nil, nil
+}
+
+// checkFuncDoc checks that the doc comment of an exported function, or of an
+// exported method of an exported type, begins with its name.
+func checkFuncDoc(pass *analysis.Pass, decl *ast.FuncDecl) {
+	if !decl.Name.IsExported() {
+		return
+	}
+	what := "function " + decl.Name.Name
+	if decl.Recv != nil && len(decl.Recv.List) > 0 {
+		recv := receiverName(decl.Recv.List[0].Type)
+		if recv == "" || !ast.IsExported(recv) {
+			return
+		}
+		what = "method " + recv + "." + decl.Name.Name
+	}
+	checkDocName(pass, what, decl.Name.Name, decl.Doc, false)
+}
+
+// checkSpecDoc checks that the doc comment of the declaration of an exported
+// type, or of a single exported constant or variable, begins with its name.
+func checkSpecDoc(pass *analysis.Pass, tok token.Token, spec ast.Spec, doc *ast.CommentGroup) {
+	switch spec := spec.(type) {
+	case *ast.TypeSpec:
+		if spec.Name.IsExported() {
+			checkDocName(pass, "type "+spec.Name.Name, spec.Name.Name, doc, true)
+		}
+	case *ast.ValueSpec:
+		if len(spec.Names) == 1 && spec.Names[0].IsExported() {
+			checkDocName(pass, tok.String()+" "+spec.Names[0].Name, spec.Names[0].Name, doc, false)
+		}
+	}
+}
+
+// receiverName returns the name of the type of a method receiver.
+func receiverName(expr ast.Expr) string {
+	for {
+		switch x := expr.(type) {
+		case *ast.StarExpr:
+			expr = x.X
+		case *ast.ParenExpr:
+			expr = x.X
+		case *ast.Ident:
+			return x.Name
+		default:
+			if x, _, _, _ := typeparams.UnpackIndexExpr(expr); x != nil {
+				expr = x
+				continue
+			}
+			return ""
+		}
+	}
+}
+
+// checkDocName reports the doc comment of the identifier name, described by
+// what, if it does not begin with name or, for types, with an article
+// followed by name.
+func checkDocName(pass *analysis.Pass, what, name string, doc *ast.CommentGroup, article bool) {
+	comment, start := firstLine(doc)
+	if comment == nil {
+		return
+	}
+	text := comment.Text[start:]
+	word := firstWord(text)
+	if word == "" || word == name {
+		return
+	}
+	if article && (word == "A" || word == "An" || word == "The") {
+		if next := firstWord(strings.TrimLeft(text[len(word):], " \t")); next == name {
+			return
+		}
+	}
+	pos := comment.Pos() + token.Pos(start)
+	var edit analysis.TextEdit
+	if strings.EqualFold(word, name) || isCamelCase(word) {
+		// The comment begins with the name misspelled, or with another
+		// identifier, such as a former name.
+		edit = analysis.TextEdit{Pos: pos, End: pos + token.Pos(len(word)), NewText: []byte(name)}
+	} else {
+		// Keep the first word, in lower case unless it is an initialism.
+		r, size := utf8.DecodeRuneInString(word)
+		next, _ := utf8.DecodeRuneInString(word[size:])
+		if unicode.IsUpper(r) && unicode.IsLower(next) {
+			edit = analysis.TextEdit{Pos: pos, End: pos + token.Pos(size), NewText: []byte(name + " " + string(unicode.ToLower(r)))}
+		} else {
+			edit = analysis.TextEdit{Pos: pos, End: pos, NewText: []byte(name + " ")}
+		}
+	}
+	pass.Report(analysis.Diagnostic{
+		Pos:     pos,
+		End:     pos + token.Pos(len(word)),
+		Message: fmt.Sprintf("comment on exported %s should be of the form %q", what, name+" ..."),
+		SuggestedFixes: []analysis.SuggestedFix{{
+			Message:   fmt.Sprintf("Begin the comment with %s", name),
+			TextEdits: []analysis.TextEdit{edit},
+		}},
+	})
+}
+
+// firstLine returns the first line comment of doc that is not a directive,
+// with the offset of its text, or nil if doc does not begin with one.
+func firstLine(doc *ast.CommentGroup) (*ast.Comment, int) {
+	for _, c := range doc.List {
+		if isDirective(c.Text) {
+			continue
+		}
+		if !strings.HasPrefix(c.Text, "//") {
+			return nil, 0
+		}
+		text := strings.TrimLeft(c.Text[2:], " \t")
+		if text == "" {
+			continue
+		}
+		return c, len(c.Text) - len(text)
+	}
+	return nil, 0
+}
+
+// firstWord returns the leading identifier of text.
+func firstWord(text string) string {
+	for i, r := range text {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '_' {
+			return text[:i]
+		}
+	}
+	return text
+}
+
+// isCamelCase reports whether word has an upper case letter after a lower
+// case one, as in PkgPath.
+func isCamelCase(word string) bool {
+	lower := false
+	for _, r := range word {
+		if lower && unicode.IsUpper(r) {
+			return true
+		}
+		lower = unicode.IsLower(r)
+	}
+	return false
+}
+
+// checkDeprecation reports the lines of doc that look like deprecation
+// notices but do not follow the convention: a paragraph beginning with
+// "Deprecated: ".
+func checkDeprecation(pass *analysis.Pass, doc *ast.CommentGroup) {
+	blank := true // whether the previous line is blank
+	for _, c := range doc.List {
+		if !strings.HasPrefix(c.Text, "//") {
+			blank = false
+			continue
+		}
+		text := strings.TrimLeft(c.Text[2:], " \t")
+		start := len(c.Text) - len(text)
+		word := firstWord(text)
+		if strings.EqualFold(word, "deprecated") {
+			rest := strings.TrimLeft(text[len(word):], " \t")
+			pos := c.Pos() + token.Pos(start)
+			switch {
+			case word == "Deprecated" && strings.HasPrefix(rest, ": ") && len(rest) == len(text)-len(word):
+				if !blank {
+					indent := strings.Repeat("\t", pass.Fset.Position(c.Pos()).Column-1)
+					pass.Report(analysis.Diagnostic{
+						Pos:     pos,
+						End:     pos + token.Pos(len(word)),
+						Message: "deprecation notice should begin a paragraph",
+						SuggestedFixes: []analysis.SuggestedFix{{
+							Message:   "Begin a paragraph",
+							TextEdits: []analysis.TextEdit{{Pos: c.Pos(), End: c.Pos(), NewText: []byte("//\n" + indent)}},
+						}},
+					})
+				}
+			case rest != "" && strings.ContainsRune(":-.,;", rune(rest[0])):
+				notice := strings.TrimLeft(rest[1:], " \t")
+				if notice == "" {
+					break
+				}
+				end := c.End() - token.Pos(len(notice))
+				pass.Report(analysis.Diagnostic{
+					Pos:     pos,
+					End:     end,
+					Message: `deprecation notice should begin with "Deprecated: "`,
+					SuggestedFixes: []analysis.SuggestedFix{{
+						Message:   `Begin the notice with "Deprecated: "`,
+						TextEdits: []analysis.TextEdit{{Pos: pos, End: end, NewText: []byte("Deprecated: ")}},
+					}},
+				})
+			}
+		}
+		blank = text == ""
+	}
+}
+
+// isDirective reports whether comment is a directive, such as //go:generate
+// or //line, rather than text for people.
+func isDirective(comment string) bool {
+	if !strings.HasPrefix(comment, "//") {
+		return false
+	}
+	text := comment[2:]
+	for _, prefix := range []string{"line ", "extern ", "export "} {
+		if strings.HasPrefix(text, prefix) {
+			return true
+		}
+	}
+	colon := strings.Index(text, ":")
+	if colon <= 0 || colon+1 >= len(text) {
+		return false
+	}
+	for _, r := range text[:colon] {
+		if !('a' <= r && r <= 'z' || '0' <= r && r <= '9') {
+			return false
+		}
+	}
+	r := text[colon+1]
+	return 'a' <= r && r <= 'z' || '0' <= r && r <= '9'
+}
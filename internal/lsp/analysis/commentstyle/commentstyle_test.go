@@ -0,0 +1,27 @@
+// Copyright 2022 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package commentstyle_test
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/iansmith/golang-x-tools/go/analysis/analysistest"
+	"github.com/iansmith/golang-x-tools/internal/lsp/analysis/commentstyle"
+)
+
+func Test(t *testing.T) {
+	testdata := analysistest.TestData()
+	analysistest.RunWithSuggestedFixes(t, testdata, commentstyle.Analyzer, "a")
+}
+
+func TestDictionary(t *testing.T) {
+	testdata := analysistest.TestData()
+	a := commentstyle.NewAnalyzer(commentstyle.Dictionary{"Wrold": "world"})
+	if err := a.Flags.Set("dictionary", filepath.Join(testdata, "dictionary")); err != nil {
+		t.Fatal(err)
+	}
+	analysistest.RunWithSuggestedFixes(t, testdata, a, "b")
+}
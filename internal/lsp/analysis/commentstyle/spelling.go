@@ -0,0 +1,191 @@
+// Copyright 2022 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package commentstyle
+
+import (
+	"bufio"
+	"fmt"
+	"go/ast"
+	"go/token"
+	"os"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/iansmith/golang-x-tools/go/analysis"
+)
+
+// A Dictionary maps misspellings, in lower case, to their corrections.
+type Dictionary map[string]string
+
+// DefaultDictionary holds common misspellings of English words.
+var DefaultDictionary = Dictionary{
+	"accomodate":     "accommodate",
+	"accross":        "across",
+	"acheive":        "achieve",
+	"adress":         "address",
+	"allready":       "already",
+	"alot":           "a lot",
+	"arguement":      "argument",
+	"arguements":     "arguments",
+	"begining":       "beginning",
+	"beleive":        "believe",
+	"calender":       "calendar",
+	"cancelation":    "cancellation",
+	"commited":       "committed",
+	"comparision":    "comparison",
+	"compatability":  "compatibility",
+	"concurent":      "concurrent",
+	"correspondance": "correspondence",
+	"definately":     "definitely",
+	"dependancy":     "dependency",
+	"dependant":      "dependent",
+	"existance":      "existence",
+	"existant":       "existent",
+	"explicitely":    "explicitly",
+	"familar":        "familiar",
+	"garantee":       "guarantee",
+	"guarentee":      "guarantee",
+	"independant":    "independent",
+	"initialise":     "initialize",
+	"intial":         "initial",
+	"lenght":         "length",
+	"neccessary":     "necessary",
+	"necessery":      "necessary",
+	"occured":        "occurred",
+	"occurence":      "occurrence",
+	"paramter":       "parameter",
+	"paramters":      "parameters",
+	"persistant":     "persistent",
+	"posible":        "possible",
+	"preceeding":     "preceding",
+	"propogate":      "propagate",
+	"recieve":        "receive",
+	"recieved":       "received",
+	"recieves":       "receives",
+	"refered":        "referred",
+	"reponse":        "response",
+	"retreive":       "retrieve",
+	"seperate":       "separate",
+	"seperately":     "separately",
+	"seperator":      "separator",
+	"succesful":      "successful",
+	"sucess":         "success",
+	"sucessful":      "successful",
+	"suport":         "support",
+	"supress":        "suppress",
+	"teh":            "the",
+	"thier":          "their",
+	"threshhold":     "threshold",
+	"transfered":     "transferred",
+	"unecessary":     "unnecessary",
+	"untill":         "until",
+	"usefull":        "useful",
+	"wich":           "which",
+	"writting":       "writing",
+}
+
+// dictionaryFlag adds the misspellings of files to a dictionary.
+type dictionaryFlag Dictionary
+
+func (f *dictionaryFlag) String() string { return "" }
+
+// Set reads the misspellings of the file name, one "misspelling correction"
+// pair per line. Blank lines and lines beginning with # are ignored.
+func (f *dictionaryFlag) Set(name string) error {
+	file, err := os.Open(name)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	scanner := bufio.NewScanner(file)
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+		fields := strings.Fields(text)
+		if len(fields) < 2 {
+			return fmt.Errorf("%s:%d: want \"misspelling correction\", got %q", name, line, text)
+		}
+		(*f)[strings.ToLower(fields[0])] = strings.Join(fields[1:], " ")
+	}
+	return scanner.Err()
+}
+
+// checkSpelling reports the words of comment that are misspellings of the
+// dictionary. Words that are part of code, such as pkg.Name, snake_case or
+// `quoted`, and URLs are skipped.
+func (c *checker) checkSpelling(pass *analysis.Pass, comment *ast.Comment) {
+	text := comment.Text
+	for i := 0; i < len(text); {
+		// Find the next field, separated by spaces.
+		for i < len(text) && isSpace(text[i]) {
+			i++
+		}
+		start := i
+		for i < len(text) && !isSpace(text[i]) {
+			i++
+		}
+		field := text[start:i]
+		if strings.Contains(field, "://") || strings.ContainsAny(field, "`_/") {
+			continue
+		}
+		word := strings.TrimLeft(field, `("'*`)
+		offset := start + len(field) - len(word)
+		word = strings.TrimRight(word, `.,;:!?)"'*`)
+		if !isWord(word) {
+			continue
+		}
+		correction, ok := c.dict[strings.ToLower(word)]
+		if !ok {
+			continue
+		}
+		correction = matchCase(word, correction)
+		pos := comment.Pos() + token.Pos(offset)
+		end := pos + token.Pos(len(word))
+		pass.Report(analysis.Diagnostic{
+			Pos:     pos,
+			End:     end,
+			Message: fmt.Sprintf("%q is a misspelling of %q", word, correction),
+			SuggestedFixes: []analysis.SuggestedFix{{
+				Message:   fmt.Sprintf("Replace with %q", correction),
+				TextEdits: []analysis.TextEdit{{Pos: pos, End: end, NewText: []byte(correction)}},
+			}},
+		})
+	}
+}
+
+func isSpace(b byte) bool {
+	return b == ' ' || b == '\t' || b == '\n' || b == '\r'
+}
+
+// isWord reports whether s is made of letters, in lower case, title case or
+// upper case.
+func isWord(s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, r := range s {
+		if !unicode.IsLetter(r) {
+			return false
+		}
+	}
+	_, size := utf8.DecodeRuneInString(s)
+	rest := s[size:]
+	return rest == strings.ToLower(rest) || s == strings.ToUpper(s)
+}
+
+// matchCase returns correction in the case of word.
+func matchCase(word, correction string) string {
+	switch {
+	case len(word) > 1 && word == strings.ToUpper(word):
+		return strings.ToUpper(correction)
+	case word != strings.ToLower(word):
+		r, size := utf8.DecodeRuneInString(correction)
+		return string(unicode.ToUpper(r)) + correction[size:]
+	}
+	return correction
+}
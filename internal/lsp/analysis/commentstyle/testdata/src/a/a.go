@@ -0,0 +1,74 @@
+// Copyright 2022 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package a is a test package for the commentstyle analyzer.
+package a
+
+// Sum returns the sum of x and y.
+func Sum(x, y int) int { return x + y }
+
+// Returns the difference of x and y. // want `comment on exported function Diff should be of the form "Diff \.\.\."`
+func Diff(x, y int) int { return x - y }
+
+// product returns the product of x and y. // want `comment on exported function Product should be of the form "Product \.\.\."`
+func Product(x, y int) int { return x * y }
+
+// PkgPath returns the path of x. // want `comment on exported function PackagePath should be of the form "PackagePath \.\.\."`
+func PackagePath(x int) string { return "" }
+
+// HTTP is not served here. // want `comment on exported function Serve should be of the form "Serve \.\.\."`
+func Serve() {}
+
+// A Point is a point.
+type Point struct {
+	// X is the abscissa.
+	X int
+	// DEPRECATED - use X. // want `deprecation notice should begin with "Deprecated: "`
+	Abscissa int
+}
+
+// Norm is the norm of p.
+//
+// Deprecated: use Len.
+func (p Point) Norm() int { return 0 }
+
+// Len is the length of p.
+// Deprecated: compute it. // want `deprecation notice should begin a paragraph`
+func (p Point) Len() int { return 0 }
+
+// The origin of points. // want `comment on exported method Point.Origin should be of the form "Origin \.\.\."`
+func (p *Point) Origin() Point { return Point{} }
+
+// this comment is on an unexported type.
+type point struct{}
+
+// method of point.
+func (point) Exported() {}
+
+type (
+	// Pair is a pair.
+	Pair [2]int
+	// triple is a triple. // want `comment on exported type Triple should be of the form "Triple \.\.\."`
+	Triple [3]int
+)
+
+// Value holds a value.
+var Value int
+
+// the limit. // want `comment on exported const Limit should be of the form "Limit \.\.\."`
+const Limit = 10
+
+// Grouped constants.
+const (
+	One = 1
+	Two = 2
+)
+
+//go:generate echo recieve
+
+func f() {
+	// We recieve nothing, untill the end. // want `"recieve" is a misspelling of "receive"` `"untill" is a misspelling of "until"`
+	// Teh https://example.com/recieve, snake_recieve and `recieve` are skipped. // want `"Teh" is a misspelling of "The"`
+	/* SEPERATE block. */ // want `"SEPERATE" is a misspelling of "SEPARATE"`
+}
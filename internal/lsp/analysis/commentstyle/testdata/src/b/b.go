@@ -0,0 +1,11 @@
+// Copyright 2022 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package b
+
+// Hello, wrold. // want `"wrold" is a misspelling of "world"`
+var hello = "hello"
+
+// A gohper, but not a recieve, which only the default dictionary holds. // want `"gohper" is a misspelling of "gopher"`
+var gopher = "gopher"
@@ -13,6 +13,7 @@ import (
 	"go/format"
 	"go/token"
 	"go/types"
+	"sort"
 	"strings"
 	"unicode"
 
@@ -21,6 +22,7 @@ import (
 	"github.com/iansmith/golang-x-tools/go/ast/astutil"
 	"github.com/iansmith/golang-x-tools/go/ast/inspector"
 	"github.com/iansmith/golang-x-tools/internal/analysisinternal"
+	"github.com/iansmith/golang-x-tools/internal/lsp/safetoken"
 	"github.com/iansmith/golang-x-tools/internal/span"
 	"github.com/iansmith/golang-x-tools/internal/typeparams"
 )
@@ -31,6 +33,10 @@ This analyzer provides diagnostics for any struct literals that do not have
 any fields initialized. Because the suggested fix for this analysis is
 expensive to compute, callers should compute it separately, using the
 SuggestedFix function below.
+
+It also notes calls that lack some of their arguments, and map literals
+whose key type is an enumeration lacking some of its constants, so that the
+SuggestedFix function can fill in the missing arguments or keys.
 `
 
 var Analyzer = &analysis.Analyzer{
@@ -43,17 +49,16 @@ var Analyzer = &analysis.Analyzer{
 
 func run(pass *analysis.Pass) (interface{}, error) {
 	inspect := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
-	nodeFilter := []ast.Node{(*ast.CompositeLit)(nil)}
+	nodeFilter := []ast.Node{(*ast.CompositeLit)(nil), (*ast.CallExpr)(nil)}
 	inspect.Preorder(nodeFilter, func(n ast.Node) {
 		info := pass.TypesInfo
 		if info == nil {
 			return
 		}
-		expr := n.(*ast.CompositeLit)
 
 		var file *ast.File
 		for _, f := range pass.Files {
-			if f.Pos() <= expr.Pos() && expr.Pos() <= f.End() {
+			if f.Pos() <= n.Pos() && n.Pos() <= f.End() {
 				file = f
 				break
 			}
@@ -62,6 +67,18 @@ func run(pass *analysis.Pass) (interface{}, error) {
 			return
 		}
 
+		if call, ok := n.(*ast.CallExpr); ok {
+			if missingParams(info, call) != nil {
+				pass.Report(analysis.Diagnostic{
+					Message: fmt.Sprintf("Fill arguments of %s", types.ExprString(call.Fun)),
+					Pos:     call.Pos(),
+					End:     call.End(),
+				})
+			}
+			return
+		}
+		expr := n.(*ast.CompositeLit)
+
 		typ := info.TypeOf(expr)
 		if typ == nil {
 			return
@@ -85,6 +102,21 @@ func run(pass *analysis.Pass) (interface{}, error) {
 		}
 		typ = typ.Underlying()
 
+		if m, ok := typ.(*types.Map); ok {
+			if len(missingKeys(info, pass.Pkg, expr, m)) > 0 {
+				name := "map literal"
+				if expr.Type != nil {
+					name = types.ExprString(expr.Type)
+				}
+				pass.Report(analysis.Diagnostic{
+					Message: fmt.Sprintf("Fill %s with known keys", name),
+					Pos:     expr.Pos(),
+					End:     expr.End(),
+				})
+			}
+			return
+		}
+
 		obj, ok := typ.(*types.Struct)
 		if !ok {
 			return
@@ -161,16 +193,21 @@ func SuggestedFix(fset *token.FileSet, rng span.Range, content []byte, file *ast
 	if len(path) == 0 {
 		return nil, fmt.Errorf("no enclosing ast.Node")
 	}
+	if info == nil {
+		return nil, fmt.Errorf("nil types.Info")
+	}
 	var expr *ast.CompositeLit
 	for _, n := range path {
-		if node, ok := n.(*ast.CompositeLit); ok {
+		// A call whose range is that of the diagnostic is the one to fill.
+		if call, ok := n.(*ast.CallExpr); ok && call.Pos() == rng.Start && call.End() == rng.End {
+			return fillCall(fset, file, pkg, info, call)
+		}
+		if node, ok := n.(*ast.CompositeLit); ok && expr == nil {
 			expr = node
-			break
 		}
 	}
-
-	if info == nil {
-		return nil, fmt.Errorf("nil types.Info")
+	if expr == nil {
+		return nil, fmt.Errorf("no composite literal")
 	}
 	typ := info.TypeOf(expr)
 	if typ == nil {
@@ -187,6 +224,10 @@ func SuggestedFix(fset *token.FileSet, rng span.Range, content []byte, file *ast
 	}
 	typ = typ.Underlying()
 
+	if m, ok := typ.(*types.Map); ok {
+		return fillMap(fset, content, file, pkg, info, expr, m)
+	}
+
 	obj, ok := typ.(*types.Struct)
 	if !ok {
 		return nil, fmt.Errorf("unexpected type %v (%T), expected *types.Struct", typ, typ)
@@ -288,16 +329,7 @@ func SuggestedFix(fset *token.FileSet, rng span.Range, content []byte, file *ast
 		Rbrace: tok.LineStart(line),
 	}
 
-	// Find the line on which the composite literal is declared.
-	split := bytes.Split(content, []byte("\n"))
-	lineNumber := fset.Position(expr.Lbrace).Line
-	firstLine := split[lineNumber-1] // lines are 1-indexed
-
-	// Trim the whitespace from the left of the line, and use the index
-	// to get the amount of whitespace on the left.
-	trimmed := bytes.TrimLeftFunc(firstLine, unicode.IsSpace)
-	index := bytes.Index(firstLine, trimmed)
-	whitespace := firstLine[:index]
+	whitespace := lineIndentation(fset, content, expr.Lbrace)
 
 	// First pass through the formatter: turn the expr into a string.
 	var formatBuf bytes.Buffer
@@ -325,6 +357,20 @@ func SuggestedFix(fset *token.FileSet, rng span.Range, content []byte, file *ast
 	}, nil
 }
 
+// lineIndentation returns the whitespace at the start of the line of pos in
+// content.
+func lineIndentation(fset *token.FileSet, content []byte, pos token.Pos) []byte {
+	split := bytes.Split(content, []byte("\n"))
+	lineNumber := fset.Position(pos).Line
+	line := split[lineNumber-1] // lines are 1-indexed
+
+	// Trim the whitespace from the left of the line, and use the index
+	// to get the amount of whitespace on the left.
+	trimmed := bytes.TrimLeftFunc(line, unicode.IsSpace)
+	index := bytes.Index(line, trimmed)
+	return line[:index]
+}
+
 // indent works line by line through str, indenting (prefixing) each line with
 // ind.
 func indent(str, ind []byte) []byte {
@@ -495,3 +541,257 @@ func populateValue(fset *token.FileSet, f *ast.File, pkg *types.Package, typ typ
 	}
 	return nil
 }
+
+// missingParams returns the parameters of the function called by call for
+// which it lacks arguments, the optional variadic parameter excepted, or nil
+// if it has all of them or if they cannot be filled in.
+func missingParams(info *types.Info, call *ast.CallExpr) []*types.Var {
+	if call.Ellipsis.IsValid() {
+		return nil
+	}
+	// Skip conversions and calls to builtins.
+	tv, ok := info.Types[call.Fun]
+	if !ok || !tv.IsValue() {
+		return nil
+	}
+	sig, ok := tv.Type.Underlying().(*types.Signature)
+	if !ok {
+		return nil
+	}
+	// Ignore generic functions for now.
+	// TODO: support type params.
+	if tparams := typeparams.ForSignature(sig); tparams != nil && tparams.Len() > 0 {
+		return nil
+	}
+	// f(g()) passes all the results of g.
+	if len(call.Args) == 1 {
+		if _, ok := info.TypeOf(call.Args[0]).(*types.Tuple); ok {
+			return nil
+		}
+	}
+	required := sig.Params().Len()
+	if sig.Variadic() {
+		required--
+	}
+	var params []*types.Var
+	for i := len(call.Args); i < required; i++ {
+		param := sig.Params().At(i)
+		if _, ok := param.Type().(*typeparams.TypeParam); ok {
+			return nil
+		}
+		if param.Type() == types.Typ[types.Invalid] {
+			return nil
+		}
+		params = append(params, param)
+	}
+	return params
+}
+
+// fillCall returns the suggested fix that appends the missing arguments to
+// call: the identifiers in scope whose types and names best match those of
+// the parameters, or zero values.
+func fillCall(fset *token.FileSet, file *ast.File, pkg *types.Package, info *types.Info, call *ast.CallExpr) (*analysis.SuggestedFix, error) {
+	params := missingParams(info, call)
+	if len(params) == 0 {
+		return nil, fmt.Errorf("no arguments to fill")
+	}
+	var typs []types.Type
+	for _, param := range params {
+		typs = append(typs, param.Type())
+	}
+	matches := analysisinternal.FindMatchingIdents(typs, file, call.Pos(), info, pkg)
+
+	var args []string
+	for _, param := range params {
+		var value ast.Expr
+		if name := param.Name(); name != "" && name != "_" {
+			value = analysisinternal.FindBestMatch(name, matches[param.Type()])
+		}
+		if value == nil {
+			value = populateValue(fset, file, pkg, param.Type())
+		}
+		if value == nil {
+			return nil, nil
+		}
+		var buf bytes.Buffer
+		if err := format.Node(&buf, token.NewFileSet(), value); err != nil {
+			return nil, err
+		}
+		args = append(args, buf.String())
+	}
+
+	// Append the arguments to the last one, which may be followed by a comma.
+	pos, text := call.Rparen, strings.Join(args, ", ")
+	if n := len(call.Args); n > 0 {
+		pos, text = call.Args[n-1].End(), ", "+text
+	}
+	return &analysis.SuggestedFix{
+		TextEdits: []analysis.TextEdit{
+			{
+				Pos:     pos,
+				End:     pos,
+				NewText: []byte(text),
+			},
+		},
+	}, nil
+}
+
+// missingKeys returns the constants of the key type of the map literal expr
+// that are not keys of its elements. The key type is an enumeration: a named
+// basic type, whose constants are declared in its package.
+func missingKeys(info *types.Info, pkg *types.Package, expr *ast.CompositeLit, m *types.Map) []*types.Const {
+	key, ok := m.Key().(*types.Named)
+	if !ok {
+		return nil
+	}
+	if _, ok := key.Underlying().(*types.Basic); !ok {
+		return nil
+	}
+	present := make(map[string]bool)
+	for _, e := range expr.Elts {
+		if kv, ok := e.(*ast.KeyValueExpr); ok {
+			if tv, ok := info.Types[kv.Key]; ok && tv.Value != nil {
+				present[tv.Value.ExactString()] = true
+			}
+		}
+	}
+	var missing []*types.Const
+	for _, c := range enumConstants(pkg, key) {
+		if !present[c.Val().ExactString()] {
+			missing = append(missing, c)
+		}
+	}
+	return missing
+}
+
+// enumConstants returns the constants of type typ declared in its package
+// that are accessible from pkg, in the order of their declarations. Of
+// constants with the same value, only the first one is returned.
+func enumConstants(pkg *types.Package, typ *types.Named) []*types.Const {
+	if typ.Obj().Pkg() == nil {
+		return nil
+	}
+	scope := typ.Obj().Pkg().Scope()
+	var consts []*types.Const
+	for _, name := range scope.Names() {
+		c, ok := scope.Lookup(name).(*types.Const)
+		if !ok || name == "_" || !types.Identical(c.Type(), typ) {
+			continue
+		}
+		if c.Pkg() != pkg && !c.Exported() {
+			continue
+		}
+		consts = append(consts, c)
+	}
+	sort.Slice(consts, func(i, j int) bool {
+		return consts[i].Pos() < consts[j].Pos()
+	})
+	seen := make(map[string]bool)
+	var unique []*types.Const
+	for _, c := range consts {
+		if v := c.Val().ExactString(); !seen[v] {
+			seen[v] = true
+			unique = append(unique, c)
+		}
+	}
+	return unique
+}
+
+// constExpr returns the expression referring to the constant c in the file
+// f of pkg.
+func constExpr(fset *token.FileSet, f *ast.File, pkg *types.Package, c *types.Const) ast.Expr {
+	if c.Pkg() == pkg {
+		return ast.NewIdent(c.Name())
+	}
+	pkgName := c.Pkg().Name()
+	// If the file already imports the package under another name, use that.
+	for _, group := range astutil.Imports(fset, f) {
+		for _, cand := range group {
+			if strings.Trim(cand.Path.Value, `"`) == c.Pkg().Path() {
+				if cand.Name != nil && cand.Name.Name != "" {
+					pkgName = cand.Name.Name
+				}
+			}
+		}
+	}
+	if pkgName == "." {
+		return ast.NewIdent(c.Name())
+	}
+	return &ast.SelectorExpr{
+		X:   ast.NewIdent(pkgName),
+		Sel: ast.NewIdent(c.Name()),
+	}
+}
+
+// fillMap returns the suggested fix that adds the missing keys of the
+// enumeration to the map literal expr, with zero values.
+func fillMap(fset *token.FileSet, content []byte, file *ast.File, pkg *types.Package, info *types.Info, expr *ast.CompositeLit, m *types.Map) (*analysis.SuggestedFix, error) {
+	missing := missingKeys(info, pkg, expr, m)
+	if len(missing) == 0 {
+		return nil, fmt.Errorf("no keys to fill")
+	}
+	tok := fset.File(expr.Lbrace)
+	if tok == nil {
+		return nil, fmt.Errorf("no file for the map literal")
+	}
+
+	// Keep the elements of the literal, and their comments, as they are.
+	var body bytes.Buffer
+	body.WriteString("{\n")
+	if n := len(expr.Elts); n > 0 {
+		lbrace, err := safetoken.Offset(tok, expr.Lbrace)
+		if err != nil {
+			return nil, err
+		}
+		last, err := safetoken.Offset(tok, expr.Elts[n-1].End())
+		if err != nil {
+			return nil, err
+		}
+		rbrace, err := safetoken.Offset(tok, expr.Rbrace)
+		if err != nil {
+			return nil, err
+		}
+		if rbrace > len(content) {
+			return nil, fmt.Errorf("map literal is outside the file content")
+		}
+		body.Write(bytes.TrimSpace(content[lbrace+1 : last]))
+		body.WriteString(",")
+		// Keep the comments following the last element.
+		rest := bytes.TrimSpace(content[last:rbrace])
+		rest = bytes.TrimSpace(bytes.TrimPrefix(rest, []byte(",")))
+		if len(rest) > 0 {
+			body.WriteString(" ")
+			body.Write(rest)
+		}
+		body.WriteString("\n")
+	}
+	for _, c := range missing {
+		value := populateValue(fset, file, pkg, m.Elem())
+		if value == nil {
+			return nil, nil
+		}
+		elt := &ast.KeyValueExpr{Key: constExpr(fset, file, pkg, c), Value: value}
+		body.WriteString("\t")
+		if err := format.Node(&body, token.NewFileSet(), elt); err != nil {
+			return nil, err
+		}
+		body.WriteString(",\n")
+	}
+	body.WriteString("}")
+
+	// Format the elements, as those of a literal of a placeholder type.
+	sug := body.Bytes()
+	const prefix = "_ = x"
+	if formatted, err := format.Source(append([]byte(prefix), sug...)); err == nil {
+		sug = bytes.TrimPrefix(formatted, []byte(prefix))
+	}
+	return &analysis.SuggestedFix{
+		TextEdits: []analysis.TextEdit{
+			{
+				Pos:     expr.Lbrace,
+				End:     expr.Rbrace + 1,
+				NewText: indent(sug, lineIndentation(fset, content, expr.Lbrace)),
+			},
+		},
+	}, nil
+}
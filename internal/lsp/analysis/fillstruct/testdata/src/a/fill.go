@@ -0,0 +1,45 @@
+// Copyright 2022 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package fillstruct
+
+import "go/token"
+
+func twoArgs(a int, s string) {}
+
+func variadic(a int, rest ...string) {}
+
+func pair() (int, string) { return 0, "" }
+
+var _ = func() {
+	twoArgs()  // want "Fill arguments of twoArgs"
+	twoArgs(1) // want "Fill arguments of twoArgs"
+	twoArgs(1, "s")
+	twoArgs(pair())
+	variadic() // want "Fill arguments of variadic"
+	variadic(1)
+	_ = len("")
+	_ = int64(1)
+}
+
+type color int
+
+const (
+	red color = iota
+	green
+	blue
+	primary = red
+)
+
+var _ = map[color]string{} // want `Fill map\[color\]string with known keys`
+
+var _ = map[color]string{ // want `Fill map\[color\]string with known keys`
+	red: "red",
+}
+
+var _ = map[color]string{red: "r", green: "g", blue: "b"}
+
+var _ = map[token.Token]bool{} // want `Fill map\[token.Token\]bool with known keys`
+
+var _ = map[int]string{}
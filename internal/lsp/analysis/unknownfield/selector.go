@@ -0,0 +1,425 @@
+// Copyright 2022 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package unknownfield
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"go/types"
+	"regexp"
+	"strings"
+	"unicode"
+
+	"github.com/iansmith/golang-x-tools/go/analysis"
+	"github.com/iansmith/golang-x-tools/go/ast/astutil"
+	"github.com/iansmith/golang-x-tools/internal/typeparams"
+)
+
+var missingMemberRe = regexp.MustCompile(`^\S+ undefined \(type \S+ has no field or method (\w+)`)
+
+// runForSelector suggests a fix for an error reporting that the selector
+// x.f refers to no field or method of the type of x: it declares the method
+// f if x.f is called, and adds the field f to the struct type of x
+// otherwise, with the types inferred from its use.
+func runForSelector(pass *analysis.Pass, err types.Error) {
+	matches := missingMemberRe.FindStringSubmatch(err.Msg)
+	if matches == nil {
+		return
+	}
+	name := matches[1]
+	file := fileOf(pass, err.Pos)
+	if file == nil {
+		return
+	}
+	path, _ := astutil.PathEnclosingInterval(file, err.Pos, err.Pos)
+	if len(path) < 3 {
+		return
+	}
+	sel, ok := path[1].(*ast.SelectorExpr)
+	if !ok || sel.Sel != path[0] || sel.Sel.Name != name {
+		return
+	}
+	// Skip method expressions, such as T.f.
+	if tv, ok := pass.TypesInfo.Types[sel.X]; !ok || !tv.IsValue() {
+		return
+	}
+
+	// The type of x must be declared at package level in this package.
+	typ := pass.TypesInfo.TypeOf(sel.X)
+	if ptr, ok := typ.(*types.Pointer); ok {
+		typ = ptr.Elem()
+	}
+	named, ok := typ.(*types.Named)
+	if !ok || named.Obj().Pkg() != pass.Pkg {
+		return
+	}
+	if tparams := typeparams.ForNamed(named); tparams != nil && tparams.Len() > 0 {
+		return
+	}
+	spec, declFile := typeSpec(pass, named.Obj())
+	if spec == nil {
+		return
+	}
+
+	var fix *analysis.SuggestedFix
+	if call, ok := path[2].(*ast.CallExpr); ok && call.Fun == sel {
+		fix = methodFix(pass, declFile, named, call, path[2:])
+	} else {
+		fix = fieldFix(pass, declFile, spec, named, sel, path[1:])
+	}
+	if fix == nil {
+		return
+	}
+	pass.Report(analysis.Diagnostic{
+		Pos:            sel.Sel.Pos(),
+		End:            sel.Sel.End(),
+		Message:        err.Msg,
+		SuggestedFixes: []analysis.SuggestedFix{*fix},
+	})
+}
+
+// fieldFix returns the fix adding the field of the selector sel, whose
+// enclosing nodes are path, to the struct type named declared by spec in
+// file, or nil if its type cannot be inferred.
+func fieldFix(pass *analysis.Pass, file *ast.File, spec *ast.TypeSpec, named *types.Named, sel *ast.SelectorExpr, path []ast.Node) *analysis.SuggestedFix {
+	st, ok := spec.Type.(*ast.StructType)
+	if !ok || st.Fields == nil || !st.Fields.Closing.IsValid() {
+		return nil
+	}
+	typ := assignedType(pass.TypesInfo, sel, path)
+	if typ == nil {
+		typ = expectedType(pass.TypesInfo, path)
+	}
+	if typ == nil {
+		return nil
+	}
+	typeString, ok := typeString(pass, file, typ)
+	if !ok {
+		return nil
+	}
+	field := sel.Sel.Name + " " + typeString
+	if tag := jsonTag(st, sel.Sel.Name); tag != "" && ast.IsExported(sel.Sel.Name) {
+		field += " `" + tag + "`"
+	}
+	return &analysis.SuggestedFix{
+		Message:   fmt.Sprintf("Add field %s to %s", sel.Sel.Name, named.Obj().Name()),
+		TextEdits: addFieldEdits(pass, st, field, indentation(pass, spec, file)),
+	}
+}
+
+// methodFix returns the fix declaring the method called by call, whose
+// enclosing nodes are path, on the type named, after its declaration in
+// file, or nil if the types of its parameters or results cannot be
+// inferred.
+func methodFix(pass *analysis.Pass, file *ast.File, named *types.Named, call *ast.CallExpr, path []ast.Node) *analysis.SuggestedFix {
+	if _, ok := named.Underlying().(*types.Interface); ok {
+		return nil
+	}
+	// Methods are declared after the declaration of the type.
+	var decl *ast.GenDecl
+	for _, d := range file.Decls {
+		if d, ok := d.(*ast.GenDecl); ok && d.Pos() <= named.Obj().Pos() && named.Obj().Pos() < d.End() {
+			decl = d
+			break
+		}
+	}
+	if decl == nil {
+		return nil // a local type
+	}
+	if call.Ellipsis.IsValid() {
+		return nil
+	}
+
+	// The parameters are named after the arguments, if they are variables.
+	var params []string
+	seen := make(map[string]bool)
+	for i, arg := range call.Args {
+		typ := pass.TypesInfo.TypeOf(arg)
+		if !isValueType(typ) {
+			return nil
+		}
+		s, ok := typeString(pass, file, types.Default(typ))
+		if !ok {
+			return nil
+		}
+		name := fmt.Sprintf("p%d", i)
+		if id, ok := arg.(*ast.Ident); ok && id.Name != "_" && !seen[id.Name] {
+			if _, ok := pass.TypesInfo.Uses[id].(*types.Var); ok {
+				name = id.Name
+			}
+		}
+		seen[name] = true
+		params = append(params, name+" "+s)
+	}
+
+	// The results are the values expected in the context of the call.
+	var results []string
+	if _, ok := path[1].(*ast.ExprStmt); !ok {
+		typs := expectedTypes(pass.TypesInfo, path)
+		if len(typs) == 0 {
+			return nil
+		}
+		for _, typ := range typs {
+			s, ok := typeString(pass, file, typ)
+			if !ok {
+				return nil
+			}
+			results = append(results, s)
+		}
+	}
+	var result string
+	switch len(results) {
+	case 0:
+	case 1:
+		result = " " + results[0]
+	default:
+		result = " (" + strings.Join(results, ", ") + ")"
+	}
+
+	recvName, pointer := receiver(pass, named)
+	recv := named.Obj().Name()
+	if pointer {
+		recv = "*" + recv
+	}
+	name := call.Fun.(*ast.SelectorExpr).Sel.Name
+	source := fmt.Sprintf("\n\nfunc (%s %s) %s(%s)%s {\n\tpanic(\"unimplemented\")\n}",
+		recvName, recv, name, strings.Join(params, ", "), result)
+	return &analysis.SuggestedFix{
+		Message: fmt.Sprintf("Declare method %s.%s", named.Obj().Name(), name),
+		TextEdits: []analysis.TextEdit{{
+			Pos:     decl.End(),
+			End:     decl.End(),
+			NewText: []byte(source),
+		}},
+	}
+}
+
+// receiver returns the name of the receiver of the methods of named, and
+// whether it is a pointer, following its declared methods.
+func receiver(pass *analysis.Pass, named *types.Named) (string, bool) {
+	for i := 0; i < named.NumMethods(); i++ {
+		sig, ok := named.Method(i).Type().(*types.Signature)
+		if !ok || sig.Recv() == nil {
+			continue
+		}
+		_, pointer := sig.Recv().Type().(*types.Pointer)
+		if name := sig.Recv().Name(); name != "" && name != "_" {
+			return name, pointer
+		}
+		return receiverName(named), pointer
+	}
+	_, isStruct := named.Underlying().(*types.Struct)
+	return receiverName(named), isStruct
+}
+
+// receiverName returns the default name of the receiver of the methods of
+// named: the lower case first letter of its name.
+func receiverName(named *types.Named) string {
+	r := []rune(named.Obj().Name())[0]
+	return string(unicode.ToLower(r))
+}
+
+// assignedType returns the type of the value assigned to the selector sel,
+// whose enclosing nodes are path, or nil if it is not assigned.
+func assignedType(info *types.Info, sel *ast.SelectorExpr, path []ast.Node) types.Type {
+	switch parent := path[1].(type) {
+	case *ast.AssignStmt:
+		for i, lhs := range parent.Lhs {
+			if lhs != sel {
+				continue
+			}
+			var typ types.Type
+			if len(parent.Lhs) == len(parent.Rhs) {
+				typ = info.TypeOf(parent.Rhs[i])
+			} else if len(parent.Rhs) == 1 {
+				if tuple, ok := info.TypeOf(parent.Rhs[0]).(*types.Tuple); ok && tuple.Len() == len(parent.Lhs) {
+					typ = tuple.At(i).Type()
+				}
+			}
+			if !isValueType(typ) {
+				return nil
+			}
+			return types.Default(typ)
+		}
+	case *ast.IncDecStmt:
+		return types.Typ[types.Int]
+	}
+	return nil
+}
+
+// expectedType returns the type of the value expected in the context of the
+// expression path[0], whose enclosing nodes are path[1:], or nil if it is
+// unknown.
+func expectedType(info *types.Info, path []ast.Node) types.Type {
+	if typs := expectedTypes(info, path); len(typs) == 1 {
+		return typs[0]
+	}
+	return nil
+}
+
+// expectedTypes returns the types of the values expected in the context of
+// the expression path[0], whose enclosing nodes are path[1:]: those of
+// several results if it is a call.
+func expectedTypes(info *types.Info, path []ast.Node) []types.Type {
+	if len(path) < 2 {
+		return nil
+	}
+	expr, ok := path[0].(ast.Expr)
+	if !ok {
+		return nil
+	}
+	var typs []types.Type
+	switch parent := path[1].(type) {
+	case *ast.ParenExpr:
+		return expectedTypes(info, path[1:])
+	case *ast.AssignStmt:
+		if parent.Tok != token.ASSIGN {
+			break
+		}
+		if len(parent.Rhs) == 1 && len(parent.Lhs) > 1 && parent.Rhs[0] == expr {
+			for _, lhs := range parent.Lhs {
+				typs = append(typs, info.TypeOf(lhs))
+			}
+			break
+		}
+		for i, rhs := range parent.Rhs {
+			if rhs == expr && i < len(parent.Lhs) {
+				typs = append(typs, info.TypeOf(parent.Lhs[i]))
+			}
+		}
+	case *ast.ValueSpec:
+		if parent.Type == nil {
+			break
+		}
+		t := info.TypeOf(parent.Type)
+		if len(parent.Values) == 1 && len(parent.Names) > 1 && parent.Values[0] == expr {
+			for range parent.Names {
+				typs = append(typs, t)
+			}
+			break
+		}
+		for _, v := range parent.Values {
+			if v == expr {
+				typs = append(typs, t)
+			}
+		}
+	case *ast.ReturnStmt:
+		sig := enclosingSignature(info, path[2:])
+		if sig == nil {
+			break
+		}
+		if len(parent.Results) == 1 && sig.Results().Len() > 1 {
+			for i := 0; i < sig.Results().Len(); i++ {
+				typs = append(typs, sig.Results().At(i).Type())
+			}
+			break
+		}
+		for i, result := range parent.Results {
+			if result == expr && i < sig.Results().Len() {
+				typs = append(typs, sig.Results().At(i).Type())
+			}
+		}
+	case *ast.CallExpr:
+		sig, ok := info.TypeOf(parent.Fun).(*types.Signature)
+		if !ok || parent.Fun == expr {
+			break
+		}
+		for i, arg := range parent.Args {
+			if arg != expr {
+				continue
+			}
+			switch {
+			case sig.Variadic() && i >= sig.Params().Len()-1:
+				if parent.Ellipsis.IsValid() {
+					typs = append(typs, sig.Params().At(sig.Params().Len()-1).Type())
+				} else if s, ok := sig.Params().At(sig.Params().Len() - 1).Type().(*types.Slice); ok {
+					typs = append(typs, s.Elem())
+				}
+			case i < sig.Params().Len():
+				typs = append(typs, sig.Params().At(i).Type())
+			}
+		}
+	case *ast.KeyValueExpr:
+		if parent.Value == expr {
+			if f, ok := info.ObjectOf(keyIdent(parent)).(*types.Var); ok && f.IsField() {
+				typs = append(typs, f.Type())
+			}
+		}
+	case *ast.IfStmt:
+		if parent.Cond == expr {
+			typs = append(typs, types.Typ[types.Bool])
+		}
+	case *ast.ForStmt:
+		if parent.Cond == expr {
+			typs = append(typs, types.Typ[types.Bool])
+		}
+	case *ast.UnaryExpr:
+		if parent.Op == token.NOT {
+			typs = append(typs, types.Typ[types.Bool])
+		}
+	case *ast.BinaryExpr:
+		switch parent.Op {
+		case token.LAND, token.LOR:
+			typs = append(typs, types.Typ[types.Bool])
+		case token.SHL, token.SHR:
+		default:
+			other := parent.X
+			if other == expr {
+				other = parent.Y
+			}
+			if t := info.TypeOf(other); isValueType(t) {
+				typs = append(typs, types.Default(t))
+			}
+		}
+	case *ast.SendStmt:
+		if ch, ok := info.TypeOf(parent.Chan).Underlying().(*types.Chan); ok && parent.Value == expr {
+			typs = append(typs, ch.Elem())
+		}
+	}
+	for _, t := range typs {
+		if !isValueType(t) {
+			return nil
+		}
+	}
+	return typs
+}
+
+// keyIdent returns the key of kv if it is an identifier, or nil.
+func keyIdent(kv *ast.KeyValueExpr) *ast.Ident {
+	id, _ := kv.Key.(*ast.Ident)
+	return id
+}
+
+// enclosingSignature returns the signature of the innermost function of
+// path.
+func enclosingSignature(info *types.Info, path []ast.Node) *types.Signature {
+	for _, n := range path {
+		switch n := n.(type) {
+		case *ast.FuncLit:
+			sig, _ := info.TypeOf(n).(*types.Signature)
+			return sig
+		case *ast.FuncDecl:
+			if fn, ok := info.Defs[n.Name].(*types.Func); ok {
+				sig, _ := fn.Type().(*types.Signature)
+				return sig
+			}
+			return nil
+		}
+	}
+	return nil
+}
+
+// isValueType reports whether typ is the type of a single value, which
+// may be declared.
+func isValueType(typ types.Type) bool {
+	switch typ := typ.(type) {
+	case nil, *types.Tuple:
+		return false
+	case *types.Basic:
+		return typ.Kind() != types.Invalid && typ.Kind() != types.UntypedNil
+	}
+	return true
+}
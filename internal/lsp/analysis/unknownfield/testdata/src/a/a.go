@@ -0,0 +1,65 @@
+// Copyright 2022 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package unknownfield
+
+import (
+	"net/url"
+	"time"
+)
+
+type Basic struct {
+	A int
+}
+
+var _ = Basic{A: 1, B: "b"} // want "unknown field B in struct literal"
+
+type Pointer struct {
+	A int
+}
+
+var _ = &Pointer{C: 1.5} // want "unknown field C in struct literal"
+
+type OneLine struct{ A, B int }
+
+var _ = OneLine{D: true} // want "unknown field D in struct literal"
+
+type Empty struct{}
+
+var _ = Empty{E: []string{"e"}} // want "unknown field E in struct literal"
+
+type (
+	Grouped struct {
+		A int
+	}
+)
+
+var _ = Grouped{F: time.Second} // want "unknown field F in struct literal"
+
+type SnakeJSON struct {
+	ServerName string `json:"server_name"`
+	Port       int    `json:"port"`
+}
+
+var _ = SnakeJSON{ServerID: "x"} // want "unknown field ServerID in struct literal"
+
+type CamelJSON struct {
+	ServerName string `json:"serverName,omitempty"`
+	Port       int    `json:"port,omitempty"`
+}
+
+var _ = CamelJSON{ServerID: 1} // want "unknown field ServerID in struct literal"
+
+func _() {
+	type Local struct {
+		A int
+	}
+	_ = Local{G: Basic{}} // want "unknown field G in struct literal"
+}
+
+// The type of the value is not imported in the file of the struct.
+var _ = Imported{U: &url.URL{}}
+
+// The type of the value cannot be inferred.
+var _ = Basic{N: nil}
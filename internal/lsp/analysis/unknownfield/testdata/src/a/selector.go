@@ -0,0 +1,73 @@
+// Copyright 2022 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package unknownfield
+
+import "strconv"
+
+type Assigned struct {
+	A int
+}
+
+func _(a *Assigned) {
+	a.name = "a" // want "has no field or method name"
+}
+
+type Counter struct {
+	A int
+}
+
+func _(c Counter) {
+	c.hits++ // want "has no field or method hits"
+}
+
+type Config struct {
+	ServerName string `json:"server_name"`
+}
+
+func _(c *Config) bool {
+	return c.Verbose // want "has no field or method Verbose"
+}
+
+type Compared struct{}
+
+func _(c Compared) bool {
+	return c.size > 1.5 // want "has no field or method size"
+}
+
+type Server struct{}
+
+func (s *Server) Start() {}
+
+func _(s Server, port int) {
+	s.Listen(port, "tcp") // want "has no field or method Listen"
+}
+
+type Value int
+
+func (v Value) String() string { return strconv.Itoa(int(v)) }
+
+func _(v Value) (int, error) {
+	return v.Parse(v.String()) // want "has no field or method Parse"
+}
+
+type Setter struct{}
+
+func _(s Setter) {
+	var n int
+	n = s.Get() // want "has no field or method Get"
+	_ = n
+}
+
+// The type of the result cannot be inferred.
+func _(s Setter) {
+	_ = s.Unknown()
+}
+
+type Iface interface{}
+
+// Methods cannot be declared on interfaces.
+func _(i Iface) {
+	i.Missing()
+}
@@ -0,0 +1,368 @@
+// Copyright 2022 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package unknownfield defines an Analyzer that applies suggested fixes
+// to errors of the type "unknown field %s in struct literal" and
+// "x.f undefined (type T has no field or method f)".
+package unknownfield
+
+import (
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/token"
+	"go/types"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/iansmith/golang-x-tools/go/analysis"
+	"github.com/iansmith/golang-x-tools/go/ast/astutil"
+	"github.com/iansmith/golang-x-tools/internal/analysisinternal"
+)
+
+const Doc = `suggested fixes for "unknown field <> in struct literal"
+
+This checker provides suggested fixes for type errors of the
+type "unknown field <> in struct literal". It adds the field to the
+declaration of the struct type, with the type of its value. For example:
+	type T struct {
+		A int
+	}
+
+	var _ = T{A: 1, B: "b"}
+will turn into
+	type T struct {
+		A int
+		B string
+	}
+
+If the fields of the struct have JSON tags, the new field gets one that
+follows their naming convention.
+
+It also fixes the errors reporting that a selector x.f refers to no field
+or method of a type declared in the package. If x.f is called, it declares
+the method f, with parameters of the types of the arguments and results of
+the types expected by the context of the call. Otherwise it adds the field
+f to the struct type of x, with the type of the value assigned to x.f or
+expected by its context.
+`
+
+var Analyzer = &analysis.Analyzer{
+	Name:             string(analysisinternal.UnknownField),
+	Doc:              Doc,
+	Requires:         []*analysis.Analyzer{},
+	Run:              run,
+	RunDespiteErrors: true,
+}
+
+// The message is followed by " of type T" since Go 1.20.
+var unknownFieldRe = regexp.MustCompile(`^unknown field (\w+) in struct literal`)
+
+func run(pass *analysis.Pass) (interface{}, error) {
+	for _, err := range analysisinternal.GetTypeErrors(pass) {
+		runForError(pass, err)
+		runForSelector(pass, err)
+	}
+	return nil, nil
+}
+
+func runForError(pass *analysis.Pass, err types.Error) {
+	matches := unknownFieldRe.FindStringSubmatch(err.Msg)
+	if matches == nil {
+		return
+	}
+	name := matches[1]
+	file := fileOf(pass, err.Pos)
+	if file == nil {
+		return
+	}
+
+	// Find the key of the composite literal.
+	path, _ := astutil.PathEnclosingInterval(file, err.Pos, err.Pos)
+	var kv *ast.KeyValueExpr
+	var lit *ast.CompositeLit
+	for i, n := range path {
+		if x, ok := n.(*ast.KeyValueExpr); ok && i+1 < len(path) {
+			kv = x
+			lit, _ = path[i+1].(*ast.CompositeLit)
+			break
+		}
+	}
+	if kv == nil || lit == nil {
+		return
+	}
+	if key, ok := kv.Key.(*ast.Ident); !ok || key.Name != name {
+		return
+	}
+
+	// The struct type must be declared in this package.
+	named, ok := pass.TypesInfo.TypeOf(lit).(*types.Named)
+	if !ok || named.Obj().Pkg() != pass.Pkg {
+		return
+	}
+	spec, structFile := typeSpec(pass, named.Obj())
+	if spec == nil {
+		return
+	}
+	st, ok := spec.Type.(*ast.StructType)
+	if !ok || st.Fields == nil || !st.Fields.Closing.IsValid() {
+		return
+	}
+
+	typ := pass.TypesInfo.TypeOf(kv.Value)
+	if typ == nil {
+		return
+	}
+	if b, ok := typ.(*types.Basic); ok {
+		if b.Kind() == types.UntypedNil || b.Kind() == types.Invalid {
+			return
+		}
+		typ = types.Default(typ)
+	}
+	typeString, ok := typeString(pass, structFile, typ)
+	if !ok {
+		return
+	}
+	field := name + " " + typeString
+	if tag := jsonTag(st, name); tag != "" {
+		field += " `" + tag + "`"
+	}
+
+	pass.Report(analysis.Diagnostic{
+		Pos:     kv.Pos(),
+		End:     kv.End(),
+		Message: err.Msg,
+		SuggestedFixes: []analysis.SuggestedFix{{
+			Message:   fmt.Sprintf("Add field %s to %s", name, named.Obj().Name()),
+			TextEdits: addFieldEdits(pass, st, field, indentation(pass, spec, structFile)),
+		}},
+	})
+}
+
+// fileOf returns the file of pass containing pos.
+func fileOf(pass *analysis.Pass, pos token.Pos) *ast.File {
+	for _, f := range pass.Files {
+		if f.Pos() <= pos && pos < f.End() {
+			return f
+		}
+	}
+	return nil
+}
+
+// typeSpec returns the declaration of the type obj and its file.
+func typeSpec(pass *analysis.Pass, obj *types.TypeName) (*ast.TypeSpec, *ast.File) {
+	file := fileOf(pass, obj.Pos())
+	if file == nil {
+		return nil, nil
+	}
+	path, _ := astutil.PathEnclosingInterval(file, obj.Pos(), obj.Pos())
+	for _, n := range path {
+		if spec, ok := n.(*ast.TypeSpec); ok && spec.Name.Pos() == obj.Pos() {
+			return spec, file
+		}
+	}
+	return nil, nil
+}
+
+// typeString returns the type expression of typ in file, or false if it
+// refers to a package that file does not import.
+func typeString(pass *analysis.Pass, file *ast.File, typ types.Type) (string, bool) {
+	ok := true
+	qf := func(p *types.Package) string {
+		if p == pass.Pkg {
+			return ""
+		}
+		for _, imp := range file.Imports {
+			if path, _ := strconv.Unquote(imp.Path.Value); path == p.Path() {
+				if imp.Name == nil {
+					return p.Name()
+				}
+				if imp.Name.Name == "." {
+					return ""
+				}
+				return imp.Name.Name
+			}
+		}
+		ok = false
+		return p.Name()
+	}
+	s := types.TypeString(typ, qf)
+	return s, ok
+}
+
+// indentation returns the indentation of the line of the declaration of
+// spec, assuming that file is formatted.
+func indentation(pass *analysis.Pass, spec *ast.TypeSpec, file *ast.File) string {
+	start := spec.Pos()
+	path, _ := astutil.PathEnclosingInterval(file, spec.Pos(), spec.End())
+	for _, n := range path {
+		// An ungrouped declaration starts with the type keyword.
+		if gd, ok := n.(*ast.GenDecl); ok && !gd.Lparen.IsValid() {
+			start = gd.Pos()
+			break
+		}
+	}
+	col := pass.Fset.Position(start).Column
+	if col < 1 {
+		col = 1
+	}
+	return strings.Repeat("\t", col-1)
+}
+
+// addFieldEdits returns the edits that add field, the source of a field
+// declaration, as the last field of st, whose declaration is indented by
+// indent.
+func addFieldEdits(pass *analysis.Pass, st *ast.StructType, field, indent string) []analysis.TextEdit {
+	fields := st.Fields
+	if pass.Fset.Position(fields.Opening).Line != pass.Fset.Position(fields.Closing).Line {
+		// Insert the field on its own line, before the closing brace.
+		closing := pass.Fset.File(fields.Closing)
+		lineStart := closing.LineStart(closing.Line(fields.Closing))
+		return []analysis.TextEdit{{
+			Pos:     lineStart,
+			End:     lineStart,
+			NewText: []byte(indent + "\t" + field + "\n"),
+		}}
+	}
+
+	// Split the struct on several lines.
+	var b strings.Builder
+	b.WriteString("{\n")
+	for _, f := range fields.List {
+		b.WriteString(indent + "\t" + fieldString(pass.Fset, f) + "\n")
+	}
+	b.WriteString(indent + "\t" + field + "\n")
+	b.WriteString(indent + "}")
+	return []analysis.TextEdit{{
+		Pos:     fields.Opening,
+		End:     fields.Closing + 1,
+		NewText: []byte(b.String()),
+	}}
+}
+
+// fieldString returns the source of the field declaration f.
+func fieldString(fset *token.FileSet, f *ast.Field) string {
+	var names []string
+	for _, name := range f.Names {
+		names = append(names, name.Name)
+	}
+	var typ strings.Builder
+	if err := format.Node(&typ, fset, f.Type); err != nil {
+		return ""
+	}
+	s := typ.String()
+	if len(names) > 0 {
+		s = strings.Join(names, ", ") + " " + s
+	}
+	if f.Tag != nil {
+		s += " " + f.Tag.Value
+	}
+	return s
+}
+
+// jsonTag returns the JSON tag of the field name added to st, which
+// follows the naming convention of the JSON tags of its fields, or "" if
+// they have none.
+func jsonTag(st *ast.StructType, name string) string {
+	var (
+		tagged, omitempty int
+		convention        func(string) string
+	)
+	for _, f := range st.Fields.List {
+		if f.Tag == nil || len(f.Names) == 0 {
+			continue
+		}
+		tag, err := strconv.Unquote(f.Tag.Value)
+		if err != nil {
+			continue
+		}
+		value, ok := reflect.StructTag(tag).Lookup("json")
+		if !ok {
+			continue
+		}
+		tagged++
+		parts := strings.Split(value, ",")
+		for _, opt := range parts[1:] {
+			if opt == "omitempty" {
+				omitempty++
+			}
+		}
+		if convention != nil || parts[0] == "" || parts[0] == "-" {
+			continue
+		}
+		// A field named after a single word, such as Name tagged "name",
+		// does not tell the conventions apart.
+		field := f.Names[0].Name
+		if len(words(field)) < 2 && parts[0] == strings.ToLower(field) {
+			continue
+		}
+		for _, c := range []func(string) string{identity, snakeCase, lowerCamelCase, strings.ToLower} {
+			if c(field) == parts[0] {
+				convention = c
+				break
+			}
+		}
+	}
+	if tagged == 0 {
+		return ""
+	}
+	if convention == nil {
+		convention = lowerCamelCase
+	}
+	value := convention(name)
+	if omitempty == tagged {
+		value += ",omitempty"
+	}
+	return fmt.Sprintf("json:%q", value)
+}
+
+func identity(s string) string { return s }
+
+// words splits the Go identifier s into its words: "HTTPServerID" is made of
+// "HTTP", "Server", and "ID".
+func words(s string) []string {
+	var words []string
+	runes := []rune(s)
+	start := 0
+	for i := 1; i <= len(runes); i++ {
+		if i < len(runes) && runes[i] != '_' {
+			prev, cur := runes[i-1], runes[i]
+			next := unicode.IsLower(cur)
+			if i+1 < len(runes) {
+				next = unicode.IsLower(runes[i+1])
+			}
+			boundary := prev == '_' ||
+				(unicode.IsLower(prev) || unicode.IsDigit(prev)) && unicode.IsUpper(cur) ||
+				unicode.IsUpper(prev) && unicode.IsUpper(cur) && next
+			if !boundary {
+				continue
+			}
+		}
+		if w := strings.Trim(string(runes[start:i]), "_"); w != "" {
+			words = append(words, w)
+		}
+		start = i
+	}
+	return words
+}
+
+// snakeCase returns the snake case form of the Go identifier s, such as
+// "server_id" for "ServerID".
+func snakeCase(s string) string {
+	return strings.ToLower(strings.Join(words(s), "_"))
+}
+
+// lowerCamelCase returns the lower camel case form of the Go identifier s,
+// such as "serverID" for "ServerID".
+func lowerCamelCase(s string) string {
+	ws := words(s)
+	if len(ws) == 0 {
+		return s
+	}
+	ws[0] = strings.ToLower(ws[0])
+	return strings.Join(ws, "")
+}
@@ -0,0 +1,54 @@
+// Copyright 2022 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package lsp
+
+import (
+	"github.com/iansmith/golang-x-tools/internal/lsp/protocol"
+	"github.com/iansmith/golang-x-tools/internal/lsp/source"
+)
+
+// confirmAnnotation is the identifier of the change annotation of the
+// edits that the user is asked to confirm.
+const confirmAnnotation = "gopls.confirm"
+
+// annotateLargeEdit marks all the changes of edit, if it edits more than
+// one file or renames one, as needing the confirmation of the user under
+// label, so that the client shows a preview of them before they are
+// applied. Edits are only marked if the confirmLargeEdits setting is on
+// and the client supports change annotations.
+func annotateLargeEdit(options *source.Options, edit *protocol.WorkspaceEdit, label string) {
+	if !options.ConfirmLargeEdits || !options.ChangeAnnotationsSupported {
+		return
+	}
+	files := make(map[protocol.DocumentURI]bool)
+	renames := false
+	for _, change := range edit.DocumentChanges {
+		switch {
+		case change.TextDocumentEdit != nil:
+			files[change.TextDocumentEdit.TextDocument.URI] = true
+		case change.RenameFile != nil:
+			renames = true
+		}
+	}
+	if len(files) < 2 && !renames {
+		return
+	}
+	for _, change := range edit.DocumentChanges {
+		switch {
+		case change.TextDocumentEdit != nil:
+			for i := range change.TextDocumentEdit.Edits {
+				change.TextDocumentEdit.Edits[i].AnnotationID = confirmAnnotation
+			}
+		case change.RenameFile != nil:
+			change.RenameFile.AnnotationID = confirmAnnotation
+		}
+	}
+	edit.ChangeAnnotations = map[string]protocol.ChangeAnnotation{
+		confirmAnnotation: {
+			Label:             label,
+			NeedsConfirmation: true,
+		},
+	}
+}
@@ -10,6 +10,7 @@ import (
 	"go/ast"
 	"go/types"
 	"reflect"
+	"runtime/debug"
 	"sort"
 	"sync"
 
@@ -25,6 +26,7 @@ import (
 
 func (s *snapshot) Analyze(ctx context.Context, id string, analyzers []*source.Analyzer) ([]*source.Diagnostic, error) {
 	var roots []*actionHandle
+	var rootAnalyzers []*source.Analyzer
 	for _, a := range analyzers {
 		if !a.IsEnabled(s.view) {
 			continue
@@ -34,6 +36,7 @@ func (s *snapshot) Analyze(ctx context.Context, id string, analyzers []*source.A
 			return nil, err
 		}
 		roots = append(roots, ah)
+		rootAnalyzers = append(rootAnalyzers, a)
 	}
 
 	// Check if the context has been canceled before running the analyses.
@@ -42,8 +45,11 @@ func (s *snapshot) Analyze(ctx context.Context, id string, analyzers []*source.A
 	}
 
 	var results []*source.Diagnostic
-	for _, ah := range roots {
-		diagnostics, _, err := ah.analyze(ctx, s)
+	for i, ah := range roots {
+		if err := source.Yield(ctx); err != nil {
+			return nil, err
+		}
+		diagnostics, err := persistedAnalyze(ctx, s, ah, rootAnalyzers[i])
 		if err != nil {
 			// Keep going if a single analyzer failed.
 			event.Error(ctx, fmt.Sprintf("analyzer %q failed", ah.analyzer.Name), err)
@@ -54,6 +60,15 @@ func (s *snapshot) Analyze(ctx context.Context, id string, analyzers []*source.A
 	return results, nil
 }
 
+func (s *snapshot) AnalysisResult(ctx context.Context, id string, a *analysis.Analyzer) (interface{}, error) {
+	ah, err := s.actionHandle(ctx, PackageID(id), a)
+	if err != nil {
+		return nil, err
+	}
+	_, result, err := ah.analyze(ctx, s)
+	return result, err
+}
+
 type actionHandleKey string
 
 // An action represents one unit of analysis work: the application of
@@ -65,6 +80,7 @@ type actionHandle struct {
 
 	analyzer *analysis.Analyzer
 	pkg      *pkg
+	pkgKey   packageHandleKey // the key of the package handle of pkg
 }
 
 type actionData struct {
@@ -104,6 +120,7 @@ func (s *snapshot) actionHandle(ctx context.Context, id PackageID, a *analysis.A
 	act = &actionHandle{
 		analyzer: a,
 		pkg:      pkg,
+		pkgKey:   ph.key,
 	}
 	var deps []*actionHandle
 	// Add a dependency on each required analyzers.
@@ -212,6 +229,7 @@ func runAnalysis(ctx context.Context, snapshot *snapshot, analyzer *analysis.Ana
 	defer func() {
 		if r := recover(); r != nil {
 			data.err = fmt.Errorf("analysis %s for package %s panicked: %v", analyzer.Name, pkg.PkgPath(), r)
+			snapshot.view.recoverFromPanic(ctx, fmt.Sprintf("analysis %s for package %s", analyzer.Name, pkg.PkgPath()), r, debug.Stack())
 		}
 	}()
 
@@ -329,10 +347,17 @@ func runAnalysis(ctx context.Context, snapshot *snapshot, analyzer *analysis.Ana
 		data.err = fmt.Errorf("analysis skipped due to errors in package")
 		return data
 	}
+	if ctx.Err() != nil {
+		data.err = ctx.Err()
+		return data
+	}
 	data.result, data.err = pass.Analyzer.Run(pass)
 	if data.err != nil {
 		return data
 	}
+	if options := snapshot.View().Options(); analyzer == options.StaticcheckConfigAnalyzer && options.StaticcheckConfigure != nil && len(options.StaticcheckConfig) > 0 {
+		data.result = options.StaticcheckConfigure(data.result, options.StaticcheckConfig)
+	}
 
 	if got, want := reflect.TypeOf(data.result), pass.Analyzer.ResultType; got != want {
 		data.err = fmt.Errorf(
@@ -192,6 +192,60 @@ var cacheIndex, sessionIndex, viewIndex int64
 func (c *Cache) ID() string                     { return c.id }
 func (c *Cache) MemStats() map[reflect.Type]int { return c.store.Stats() }
 
+// A CacheStat summarizes the values of one kind held by a cache, which are
+// shared by all of its sessions.
+type CacheStat struct {
+	Kind   string // the kind of values, such as "packages"
+	Count  int    // the number of values
+	Shared int    // the number of values used by more than one session
+	Hits   int64  // the number of requests for values already computed or being computed
+	Misses int64  // the number of requests that started the computation of a value
+}
+
+// Stats returns statistics about the values held by c, by kind. Values used
+// by several sessions, such as the type-checked packages of a module open in
+// several editor windows connected to the same daemon, are held only once.
+func (c *Cache) Stats() []CacheStat {
+	var stats []CacheStat
+	gets := c.store.GetStats()
+	for typ, s := range c.store.SharedStats(sessionOfGeneration) {
+		stats = append(stats, CacheStat{
+			Kind:   cacheKind(typ),
+			Count:  s.Handles,
+			Shared: s.Shared,
+			Hits:   gets[typ].Hits,
+			Misses: gets[typ].Misses,
+		})
+	}
+	sort.Slice(stats, func(i, j int) bool {
+		return stats[i].Kind < stats[j].Kind
+	})
+	return stats
+}
+
+// cacheKind returns the kind of the values keyed by keys of type typ.
+func cacheKind(typ reflect.Type) string {
+	switch typ {
+	case reflect.TypeOf(packageHandleKey("")):
+		return "packages"
+	case reflect.TypeOf(actionHandleKey("")):
+		return "analyses"
+	case reflect.TypeOf(parseKey{}):
+		return "parsed files"
+	case reflect.TypeOf(astCacheKey{}):
+		return "ast indexes"
+	case reflect.TypeOf(symbolHandleKey("")):
+		return "symbols"
+	case reflect.TypeOf(source.FileIdentity{}):
+		return "parsed mod files"
+	case reflect.TypeOf(modTidyKey{}):
+		return "mod tidy results"
+	case reflect.TypeOf(modKey{}):
+		return "mod why results"
+	}
+	return typ.String()
+}
+
 type packageStat struct {
 	id        PackageID
 	mode      source.ParseMode
@@ -211,25 +265,7 @@ func (c *Cache) PackageStats(withNames bool) template.HTML {
 			if v.pkg == nil {
 				break
 			}
-			var typsCost, typInfoCost int64
-			if v.pkg.types != nil {
-				typsCost = typesCost(v.pkg.types.Scope())
-			}
-			if v.pkg.typesInfo != nil {
-				typInfoCost = typesInfoCost(v.pkg.typesInfo)
-			}
-			stat := packageStat{
-				id:        v.pkg.m.ID,
-				mode:      v.pkg.mode,
-				types:     typsCost,
-				typesInfo: typInfoCost,
-			}
-			for _, f := range v.pkg.compiledGoFiles {
-				stat.file += int64(len(f.Src))
-				stat.ast += astCost(f.File)
-			}
-			stat.total = stat.file + stat.ast + stat.types + stat.typesInfo
-			packageStats = append(packageStats, stat)
+			packageStats = append(packageStats, v.pkg.stat())
 		}
 	})
 	var totalCost int64
@@ -260,6 +296,29 @@ func (c *Cache) PackageStats(withNames bool) template.HTML {
 	return template.HTML(html)
 }
 
+// stat estimates the memory used by p.
+func (p *pkg) stat() packageStat {
+	var typsCost, typInfoCost int64
+	if p.types != nil {
+		typsCost = typesCost(p.types.Scope())
+	}
+	if p.typesInfo != nil {
+		typInfoCost = typesInfoCost(p.typesInfo)
+	}
+	stat := packageStat{
+		id:        p.m.ID,
+		mode:      p.mode,
+		types:     typsCost,
+		typesInfo: typInfoCost,
+	}
+	for _, f := range p.compiledGoFiles {
+		stat.file += int64(len(f.Src))
+		stat.ast += astCost(f.File)
+	}
+	stat.total = stat.file + stat.ast + stat.types + stat.typesInfo
+	return stat
+}
+
 func astCost(f *ast.File) int64 {
 	if f == nil {
 		return 0
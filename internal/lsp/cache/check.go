@@ -113,6 +113,9 @@ func (s *snapshot) buildPackageHandle(ctx context.Context, id PackageID, mode so
 
 		// Begin loading the direct dependencies, in parallel.
 		var wg sync.WaitGroup
+		// Make sure that the workers below have finished before we return,
+		// especially in case of cancellation or panic.
+		defer wg.Wait()
 		for _, dep := range deps {
 			wg.Add(1)
 			go func(dep *packageHandle) {
@@ -123,10 +126,6 @@ func (s *snapshot) buildPackageHandle(ctx context.Context, id PackageID, mode so
 
 		data := &packageData{}
 		data.pkg, data.err = typeCheck(ctx, snapshot, m.Metadata, mode, deps)
-		// Make sure that the workers above have finished before we return,
-		// especially in case of cancellation.
-		wg.Wait()
-
 		return data
 	}, nil)
 	ph.handle = h
@@ -274,6 +273,10 @@ func (ph *packageHandle) Check(ctx context.Context, s source.Snapshot) (source.P
 func (ph *packageHandle) check(ctx context.Context, s *snapshot) (*pkg, error) {
 	v, err := ph.handle.Get(ctx, s.generation, s)
 	if err != nil {
+		var perr *memoize.PanicError
+		if errors.As(err, &perr) {
+			s.view.recoverFromPanic(ctx, fmt.Sprintf("type-checking %s", ph.m.PkgPath), perr.Value, perr.Stack)
+		}
 		return nil, err
 	}
 	data := v.(*packageData)
@@ -359,6 +362,12 @@ func typeCheck(ctx context.Context, snapshot *snapshot, m *Metadata, mode source
 	}
 
 	for _, e := range m.Errors {
+		// //go:embed patterns that match no files are reported by
+		// source.EmbedDiagnostics, which, unlike the go list error, is kept up
+		// to date as files are created and deleted.
+		if source.IsEmbedMatchError(e.Msg) {
+			continue
+		}
 		diags, err := goPackagesErrorDiagnostics(snapshot, pkg, e)
 		if err != nil {
 			event.Error(ctx, "unable to compute positions for list errors", err, tag.Package.Of(pkg.ID()))
@@ -421,6 +430,12 @@ func typeCheck(ctx context.Context, snapshot *snapshot, m *Metadata, mode source
 	}
 	pkg.diagnostics = append(pkg.diagnostics, depsErrors...)
 
+	cycleErrors, err := importCycleDiagnostics(snapshot, pkg)
+	if err != nil {
+		return nil, err
+	}
+	pkg.diagnostics = append(pkg.diagnostics, cycleErrors...)
+
 	return pkg, nil
 }
 
@@ -515,6 +530,12 @@ func doTypeCheck(ctx context.Context, snapshot *snapshot, m *Metadata, mode sour
 			}
 			depPkg, err := dep.check(ctx, snapshot)
 			if err != nil {
+				// Do not keep a package checked against a dependency whose
+				// type-checking panicked: fail its computation as well.
+				var perr *memoize.PanicError
+				if errors.As(err, &perr) {
+					panic(perr)
+				}
 				return nil, err
 			}
 			pkg.imports[depPkg.m.PkgPath] = depPkg
@@ -547,6 +568,12 @@ func doTypeCheck(ctx context.Context, snapshot *snapshot, m *Metadata, mode sour
 		files = append(files, cgf.File)
 	}
 
+	// Don't start type checking if the context was cancelled while the
+	// files were parsed.
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
 	// Type checking errors are handled via the config, so ignore them here.
 	_ = check.Files(files)
 
@@ -560,6 +587,9 @@ func doTypeCheck(ctx context.Context, snapshot *snapshot, m *Metadata, mode sour
 
 func parseCompiledGoFiles(ctx context.Context, snapshot *snapshot, mode source.ParseMode, pkg *pkg, astFilter *unexportedFilter) error {
 	for _, cgf := range pkg.m.CompiledGoFiles {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
 		fh, err := snapshot.GetFile(ctx, cgf)
 		if err != nil {
 			return err
@@ -0,0 +1,155 @@
+// Copyright 2022 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cache
+
+import (
+	"context"
+	"errors"
+	"sort"
+
+	"github.com/iansmith/golang-x-tools/internal/lsp/source"
+)
+
+// A SnapshotInfo describes the values held by a snapshot, for the debug
+// server. The sizes are estimates, for guidance only.
+type SnapshotInfo struct {
+	ID       uint64
+	Packages []PackageInfo
+	Analyses []AnalysisInfo
+	Overlays []source.Overlay // the overlays of the files of the view
+}
+
+// A PackageInfo describes a package handle of a snapshot.
+type PackageInfo struct {
+	ID      string
+	Mode    string
+	Checked bool  // whether the package has been type-checked
+	Files   int   // the number of compiled Go files, if checked
+	Size    int64 // the estimated memory of the package, if checked
+}
+
+// An AnalysisInfo describes the result of an analyzer for a package of a
+// snapshot.
+type AnalysisInfo struct {
+	Analyzer    string
+	Package     string
+	Done        bool // whether the analysis has completed
+	Diagnostics int
+	Facts       int
+	Size        int64 // the estimated memory of the diagnostics and facts, if done
+}
+
+// SnapshotInfo describes the current snapshot of v, or returns nil if v is
+// shut down. Packages and analyses are sorted by decreasing size.
+func (v *View) SnapshotInfo() *SnapshotInfo {
+	v.snapshotMu.Lock()
+	s := v.snapshot
+	if s == nil {
+		v.snapshotMu.Unlock()
+		return nil
+	}
+	release := s.generation.Acquire()
+	v.snapshotMu.Unlock()
+	defer release()
+
+	// Copy the handles, so as not to block the snapshot while computing the
+	// sizes.
+	s.mu.Lock()
+	packages := make(map[packageKey]*packageHandle, len(s.packages))
+	for k, ph := range s.packages {
+		packages[k] = ph
+	}
+	actions := make(map[actionKey]*actionHandle, len(s.actions))
+	for k, ah := range s.actions {
+		actions[k] = ah
+	}
+	s.mu.Unlock()
+
+	info := &SnapshotInfo{ID: s.id}
+	for k, ph := range packages {
+		p := PackageInfo{ID: string(k.id), Mode: parseModeName(k.mode)}
+		if pkg, err := ph.cached(s.generation); err == nil && pkg != nil {
+			p.Checked = true
+			p.Files = len(pkg.compiledGoFiles)
+			p.Size = pkg.stat().total
+		}
+		info.Packages = append(info.Packages, p)
+	}
+	for k, ah := range actions {
+		a := AnalysisInfo{Analyzer: k.analyzer.Name, Package: string(k.pkg.id)}
+		if data, ok := ah.handle.Cached(s.generation).(*actionData); ok {
+			a.Done = true
+			a.Diagnostics = len(data.diagnostics)
+			a.Facts = len(data.objectFacts) + len(data.packageFacts)
+			a.Size = data.cost()
+		}
+		info.Analyses = append(info.Analyses, a)
+	}
+	for _, o := range v.session.Overlays() {
+		if v.contains(o.URI()) {
+			info.Overlays = append(info.Overlays, o)
+		}
+	}
+	sort.Slice(info.Packages, func(i, j int) bool {
+		if pi, pj := info.Packages[i], info.Packages[j]; pi.Size != pj.Size {
+			return pi.Size > pj.Size
+		}
+		return info.Packages[i].ID < info.Packages[j].ID
+	})
+	sort.Slice(info.Analyses, func(i, j int) bool {
+		if ai, aj := info.Analyses[i], info.Analyses[j]; ai.Size != aj.Size {
+			return ai.Size > aj.Size
+		}
+		if ai, aj := info.Analyses[i], info.Analyses[j]; ai.Package != aj.Package {
+			return ai.Package < aj.Package
+		}
+		return info.Analyses[i].Analyzer < info.Analyses[j].Analyzer
+	})
+	sort.Slice(info.Overlays, func(i, j int) bool {
+		return info.Overlays[i].URI() < info.Overlays[j].URI()
+	})
+	return info
+}
+
+// PackageGraph returns the import graph of the current snapshot of v, as
+// source.PackageGraph does, for the debug server.
+func (v *View) PackageGraph(ctx context.Context, tests, external bool) ([]*source.ImportGraphNode, error) {
+	v.snapshotMu.Lock()
+	s := v.snapshot
+	if s == nil {
+		v.snapshotMu.Unlock()
+		return nil, errors.New("the view is shut down")
+	}
+	release := s.generation.Acquire()
+	v.snapshotMu.Unlock()
+	defer release()
+	return source.PackageGraph(ctx, s, tests, external)
+}
+
+// cost estimates the memory used by the diagnostics and facts of d. The
+// result of the analyzer is not counted, as its type is not known.
+func (d *actionData) cost() int64 {
+	var cost int64
+	for _, diag := range d.diagnostics {
+		cost += 256 + int64(len(diag.Message)) // the fixed fields of a diagnostic
+		for _, fix := range diag.SuggestedFixes {
+			cost += 64 + int64(len(fix.Title))
+		}
+	}
+	cost += 64 * int64(len(d.objectFacts)+len(d.packageFacts))
+	return cost
+}
+
+func parseModeName(mode source.ParseMode) string {
+	switch mode {
+	case source.ParseHeader:
+		return "header"
+	case source.ParseExported:
+		return "exported"
+	case source.ParseFull:
+		return "full"
+	}
+	return "unknown"
+}
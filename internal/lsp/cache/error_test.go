@@ -5,6 +5,7 @@
 package cache
 
 import (
+	"reflect"
 	"strings"
 	"testing"
 )
@@ -50,3 +51,26 @@ func TestParseErrorMessage(t *testing.T) {
 		})
 	}
 }
+
+func TestImportCycle(t *testing.T) {
+	stack, ok := parseImportCycleStack("import cycle not allowed: import stack: [m/a m/b m/c m/d m/b]")
+	if !ok {
+		t.Fatal("parseImportCycleStack failed")
+	}
+	tests := []struct {
+		pkgPath string
+		want    []string
+	}{
+		{"m/a", nil},
+		{"m/b", []string{"m/b", "m/c", "m/d"}},
+		{"m/d", []string{"m/d", "m/b", "m/c"}},
+	}
+	for _, tt := range tests {
+		if got := importCycle(stack, tt.pkgPath); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("importCycle(%q, %q) = %q, want %q", stack, tt.pkgPath, got, tt.want)
+		}
+	}
+	if _, ok := parseImportCycleStack("use of internal package not allowed: import stack: [m/a m/b]"); ok {
+		t.Errorf("parseImportCycleStack succeeded on an error that is not an import cycle")
+	}
+}
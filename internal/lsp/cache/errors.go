@@ -12,18 +12,24 @@ import (
 	"regexp"
 	"strconv"
 	"strings"
+	"unicode/utf8"
 
 	"github.com/iansmith/golang-x-tools/go/analysis"
 	"github.com/iansmith/golang-x-tools/go/packages"
 	"github.com/iansmith/golang-x-tools/internal/analysisinternal"
 	"github.com/iansmith/golang-x-tools/internal/lsp/command"
 	"github.com/iansmith/golang-x-tools/internal/lsp/protocol"
+	"github.com/iansmith/golang-x-tools/internal/lsp/safetoken"
 	"github.com/iansmith/golang-x-tools/internal/lsp/source"
 	"github.com/iansmith/golang-x-tools/internal/span"
 	"github.com/iansmith/golang-x-tools/internal/typesinternal"
 )
 
 func goPackagesErrorDiagnostics(snapshot *snapshot, pkg *pkg, e packages.Error) ([]*source.Diagnostic, error) {
+	if stack, ok := parseImportCycleStack(e.Msg); ok && importCycle(stack, string(pkg.m.PkgPath)) != nil {
+		// Reported by importCycleDiagnostics.
+		return nil, nil
+	}
 	if msg, spn, ok := parseGoListImportCycleError(snapshot, e, pkg); ok {
 		rng, err := spanToRange(pkg, spn)
 		if err != nil {
@@ -40,11 +46,19 @@ func goPackagesErrorDiagnostics(snapshot *snapshot, pkg *pkg, e packages.Error)
 
 	var spn span.Span
 	if e.Pos == "" {
+		if diags := cgoErrorDiagnostics(pkg, e.Msg); len(diags) > 0 {
+			return diags, nil
+		}
 		spn = parseGoListError(e.Msg, pkg.m.Config.Dir)
 		// We may not have been able to parse a valid span. Apply the errors to all files.
 		if _, err := spanToRange(pkg, spn); err != nil {
+			files := pkg.compiledGoFiles
+			if len(files) == 0 {
+				// The compiled files are missing if cgo processing failed.
+				files = pkg.goFiles
+			}
 			var diags []*source.Diagnostic
-			for _, cgf := range pkg.compiledGoFiles {
+			for _, cgf := range files {
 				diags = append(diags, &source.Diagnostic{
 					URI:      cgf.URI,
 					Severity: protocol.SeverityError,
@@ -71,6 +85,104 @@ func goPackagesErrorDiagnostics(snapshot *snapshot, pkg *pkg, e packages.Error)
 	}}, nil
 }
 
+// cgoDiagnosticRe matches the diagnostics of the C compiler, such as
+//
+//	./a.go:4:25: error: 'x' undeclared (first use in this function)
+var cgoDiagnosticRe = regexp.MustCompile(`^(.+\.go):(\d+):(?:(\d+):)? (?:(error|warning|note): )?(.*)$`)
+
+// gccExcerptRe matches the source excerpts of the diagnostics of GCC, such
+// as "    4 |   return x;".
+var gccExcerptRe = regexp.MustCompile(`(?m)^ *\d+ \| `)
+
+// cgoErrorDiagnostics returns the diagnostics of the errors of the C
+// compiler in msg, the output of a failed cgo processing of pkg, at their
+// positions in the cgo preambles of its files. Notes, source excerpts, and
+// positions outside of the files of pkg are ignored.
+func cgoErrorDiagnostics(pkg *pkg, msg string) []*source.Diagnostic {
+	if !usesCgo(pkg) {
+		return nil
+	}
+	// GCC counts columns in display width, with tab stops every 8 columns,
+	// whereas clang counts bytes.
+	displayColumns := gccExcerptRe.MatchString(msg)
+	var diags []*source.Diagnostic
+	for _, line := range strings.Split(msg, "\n") {
+		matches := cgoDiagnosticRe.FindStringSubmatch(strings.TrimSpace(line))
+		if matches == nil || matches[4] == "note" {
+			continue
+		}
+		pos := matches[1] + ":" + matches[2]
+		pgf, err := pkg.File(span.ParseInDir(pos, pkg.m.Config.Dir).URI())
+		if err != nil {
+			continue
+		}
+		if matches[3] != "" {
+			col, _ := strconv.Atoi(matches[3])
+			if displayColumns {
+				lineNum, _ := strconv.Atoi(matches[2])
+				col = byteColumn(pgf, lineNum, col)
+			}
+			pos += ":" + strconv.Itoa(col)
+		}
+		spn := span.ParseInDir(pos, pkg.m.Config.Dir)
+		rng, err := pgf.Mapper.Range(spn)
+		if err != nil {
+			continue
+		}
+		severity := protocol.SeverityError
+		if matches[4] == "warning" {
+			severity = protocol.SeverityWarning
+		}
+		diags = append(diags, &source.Diagnostic{
+			URI:      spn.URI(),
+			Range:    rng,
+			Severity: severity,
+			Source:   source.ListError,
+			Message:  matches[5],
+		})
+	}
+	return diags
+}
+
+// byteColumn returns the 1-based byte column of the 1-based display column
+// col of the line of pgf, in which tabs expand to the next multiple of 8
+// columns and each character is one column wide.
+func byteColumn(pgf *source.ParsedGoFile, line, col int) int {
+	if line < 1 || line > pgf.Tok.LineCount() {
+		return col
+	}
+	start, err := safetoken.Offset(pgf.Tok, pgf.Tok.LineStart(line))
+	if err != nil {
+		return col
+	}
+	display := 1
+	for i := start; i < len(pgf.Src) && pgf.Src[i] != '\n'; {
+		if display >= col {
+			return i - start + 1
+		}
+		r, size := utf8.DecodeRune(pgf.Src[i:])
+		if r == '\t' {
+			display = (display-1)/8*8 + 9
+		} else {
+			display++
+		}
+		i += size
+	}
+	return col
+}
+
+// usesCgo reports whether a Go file of pkg imports "C".
+func usesCgo(pkg *pkg) bool {
+	for _, pgf := range pkg.goFiles {
+		for _, imp := range pgf.File.Imports {
+			if imp.Path.Value == `"C"` {
+				return true
+			}
+		}
+	}
+	return false
+}
+
 func parseErrorDiagnostics(snapshot *snapshot, pkg *pkg, errList scanner.ErrorList) ([]*source.Diagnostic, error) {
 	// The first parser error is likely the root cause of the problem.
 	if errList.Len() <= 0 {
@@ -176,6 +288,10 @@ func editGoDirectiveQuickFix(snapshot *snapshot, uri span.URI, version string) (
 	if snapshot.workspaceMode()&moduleMode == 0 {
 		return nil, nil
 	}
+	// Don't require a version that the go command can't build.
+	if minor, ok := source.GoMinorVersion(version); !ok || minor > snapshot.view.goversion {
+		return nil, nil
+	}
 	title := fmt.Sprintf("go mod edit -go=%s", version)
 	cmd, err := command.NewEditGoDirectiveCommand(title, command.EditGoDirectiveArgs{
 		URI:     protocol.URIFromSpanURI(uri),
@@ -245,6 +361,10 @@ func analysisDiagnosticDiagnostics(snapshot *snapshot, pkg *pkg, a *analysis.Ana
 		SuggestedFixes: fixes,
 		Analyzer:       srcAnalyzer,
 	}
+	if _, ok := snapshot.View().Options().StaticcheckAnalyzers[a.Name]; ok {
+		diag.Code = a.Name
+		diag.CodeHref = staticcheckCodeHref(a.Name)
+	}
 	// If the fixes only delete code, assume that the diagnostic is reporting dead code.
 	if onlyDeletions(fixes) {
 		diag.Tags = []protocol.DiagnosticTag{protocol.Unnecessary}
@@ -277,6 +397,12 @@ func typesCodeHref(snapshot *snapshot, code typesinternal.ErrorCode) string {
 	return source.BuildLink(target, "github.com/iansmith/golang-x-tools/internal/typesinternal", code.String())
 }
 
+// staticcheckCodeHref returns the URL of the documentation of the staticcheck
+// check name.
+func staticcheckCodeHref(name string) string {
+	return "https://staticcheck.io/docs/checks#" + name
+}
+
 func suggestedAnalysisFixes(snapshot *snapshot, pkg *pkg, diag *analysis.Diagnostic, kinds []protocol.CodeActionKind) ([]source.SuggestedFix, error) {
 	var fixes []source.SuggestedFix
 	for _, fix := range diag.SuggestedFixes {
@@ -378,9 +504,10 @@ func parseGoListError(input, wd string) span.Span {
 	return span.ParseInDir(input[:msgIndex], wd)
 }
 
+var importStackRx = regexp.MustCompile(`(.*): import stack: \[(.+)\]`)
+
 func parseGoListImportCycleError(snapshot *snapshot, e packages.Error, pkg *pkg) (string, span.Span, bool) {
-	re := regexp.MustCompile(`(.*): import stack: \[(.+)\]`)
-	matches := re.FindStringSubmatch(strings.TrimSpace(e.Msg))
+	matches := importStackRx.FindStringSubmatch(strings.TrimSpace(e.Msg))
 	if len(matches) < 3 {
 		return e.Msg, span.Span{}, false
 	}
@@ -407,3 +534,132 @@ func parseGoListImportCycleError(snapshot *snapshot, e packages.Error, pkg *pkg)
 	}
 	return msg, span.Span{}, false
 }
+
+// parseImportCycleStack returns the import stack of the go list error
+// message of an import cycle.
+func parseImportCycleStack(msg string) ([]string, bool) {
+	matches := importStackRx.FindStringSubmatch(strings.TrimSpace(msg))
+	if len(matches) < 3 || matches[1] != "import cycle not allowed" {
+		return nil, false
+	}
+	return strings.Split(matches[2], " "), true
+}
+
+// importCycle returns the packages of the import cycle at the end of the
+// import stack, starting with pkgPath, or nil if pkgPath is not in the
+// cycle. For example, the cycle of the stack [a b c d b] from c is [c d b].
+func importCycle(stack []string, pkgPath string) []string {
+	if len(stack) < 2 {
+		return nil
+	}
+	last := stack[len(stack)-1]
+	for i, p := range stack[:len(stack)-1] {
+		if p != last {
+			continue
+		}
+		cycle := stack[i : len(stack)-1]
+		for j, p := range cycle {
+			if p == pkgPath {
+				return append(append([]string{}, cycle[j:]...), cycle[:j]...)
+			}
+		}
+		return nil
+	}
+	return nil
+}
+
+// importCycleDiagnostics returns a diagnostic for each import of pkg that
+// is an edge of an import cycle reported by go list, either for pkg or for
+// a package that it depends on, naming the packages of the cycle. Their
+// related information locates the references of pkg to the imported
+// package, and their quick fixes list the references, or, when pkg refers
+// to a single symbol of the imported package, extract the symbol into a new
+// package to break the cycle.
+func importCycleDiagnostics(snapshot *snapshot, pkg *pkg) ([]*source.Diagnostic, error) {
+	var stacks [][]string
+	for _, e := range pkg.m.Errors {
+		if stack, ok := parseImportCycleStack(e.Msg); ok {
+			stacks = append(stacks, stack)
+		}
+	}
+	for _, depsError := range pkg.m.depsErrors {
+		if strings.TrimSpace(depsError.Err) == "import cycle not allowed" {
+			stacks = append(stacks, depsError.ImportStack)
+		}
+	}
+
+	var diags []*source.Diagnostic
+	seen := make(map[string]bool) // by imported package
+	for _, stack := range stacks {
+		cycle := importCycle(stack, string(pkg.m.PkgPath))
+		if cycle == nil || seen[cycle[1%len(cycle)]] {
+			continue
+		}
+		next := cycle[1%len(cycle)]
+		seen[next] = true
+		msg := fmt.Sprintf("import cycle not allowed: %s -> %s", strings.Join(cycle, " -> "), cycle[0])
+
+		refs, err := source.ImportReferences(snapshot.FileSet(), pkg, next)
+		if err != nil {
+			return nil, err
+		}
+		var related []source.RelatedInformation
+		symbols := make(map[string]bool)
+		for _, ref := range refs {
+			related = append(related, source.RelatedInformation{
+				URI:     ref.URI,
+				Range:   ref.Range,
+				Message: "reference to " + ref.Name,
+			})
+			symbols[ref.Name] = true
+		}
+		for _, cgf := range pkg.compiledGoFiles {
+			for _, imp := range cgf.File.Imports {
+				if source.ImportPath(imp) != next {
+					continue
+				}
+				rng, err := source.NewMappedRange(snapshot.FileSet(), cgf.Mapper, imp.Pos(), imp.End()).Range()
+				if err != nil {
+					return nil, err
+				}
+				fixes, err := importCycleQuickFixes(cgf.URI, next, symbols)
+				if err != nil {
+					return nil, err
+				}
+				diags = append(diags, &source.Diagnostic{
+					URI:            cgf.URI,
+					Range:          rng,
+					Severity:       protocol.SeverityError,
+					Source:         source.ListError,
+					Message:        msg,
+					Related:        related,
+					SuggestedFixes: fixes,
+				})
+			}
+		}
+	}
+	return diags, nil
+}
+
+func importCycleQuickFixes(uri span.URI, importPath string, symbols map[string]bool) ([]source.SuggestedFix, error) {
+	args := command.ImportCycleArgs{
+		URI:        protocol.URIFromSpanURI(uri),
+		ImportPath: importPath,
+	}
+	list, err := command.NewListImportReferencesCommand(fmt.Sprintf("List the references to %s", importPath), args)
+	if err != nil {
+		return nil, err
+	}
+	fixes := []source.SuggestedFix{source.SuggestedFixFromCommand(list, protocol.QuickFix)}
+	if len(symbols) == 1 {
+		var symbol string
+		for symbol = range symbols {
+		}
+		extract, err := command.NewExtractImportedSymbolCommand(fmt.Sprintf("Extract %s into a new package", symbol), args)
+		if err != nil {
+			return nil, err
+		}
+		fixes = append(fixes, source.SuggestedFixFromCommand(extract, protocol.QuickFix))
+	}
+	return fixes, nil
+}
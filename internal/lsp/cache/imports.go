@@ -109,6 +109,7 @@ func (s *importsState) runProcessEnvFunc(ctx context.Context, snapshot *snapshot
 		TabWidth:    8,
 		Env:         s.processEnv,
 		LocalPrefix: localPrefix,
+		Groups:      source.ImportGroups(ctx, snapshot),
 	}
 
 	if err := fn(opts); err != nil {
@@ -66,13 +66,19 @@ func (s *snapshot) load(ctx context.Context, allowNetwork bool, scopes ...interf
 			case "std", "cmd":
 				query = append(query, string(scope))
 			default:
-				query = append(query, fmt.Sprintf("%s/...", scope))
+				if patterns := s.filteredLoadPatterns(); patterns != nil {
+					query = append(query, patterns...)
+				} else {
+					query = append(query, fmt.Sprintf("%s/...", scope))
+				}
 			}
 		case viewLoadScope:
 			// If we are outside of GOPATH, a module, or some other known
 			// build system, don't load subdirectories.
 			if !s.ValidBuildConfiguration() {
 				query = append(query, "./")
+			} else if patterns := s.filteredLoadPatterns(); patterns != nil {
+				query = append(query, patterns...)
 			} else {
 				query = append(query, "./...")
 			}
@@ -117,8 +123,10 @@ func (s *snapshot) load(ctx context.Context, allowNetwork bool, scopes ...interf
 	defer cancel()
 
 	cfg := s.config(ctx, inv)
+	start := time.Now()
 	pkgs, err := packages.Load(cfg, query...)
 	cleanup()
+	event.Metric(ctx, tag.LoadLatency.Of(float64(time.Since(start))/float64(time.Millisecond)))
 
 	// If the context was canceled, return early. Otherwise, we might be
 	// type-checking an incomplete result. Check the context directly,
@@ -185,6 +193,98 @@ func (s *snapshot) load(ctx context.Context, allowNetwork bool, scopes ...interf
 
 // workspaceLayoutErrors returns a diagnostic for every open file, as well as
 // an error message if there are no open files.
+// maxFilteredLoadPatterns is the maximum number of patterns that
+// filteredLoadPatterns may return.
+const maxFilteredLoadPatterns = 200
+
+// filteredLoadPatterns returns the patterns, relative to the view root, that
+// match the packages of the workspace not excluded by the directory filters,
+// so that the go command doesn't even walk the excluded directories. It
+// returns nil if no directory is excluded, if the patterns would be too
+// many, or if the workspace isn't a single module or GOPATH directory, in
+// which case the workspace is loaded whole.
+func (s *snapshot) filteredLoadPatterns() []string {
+	if s.workspace.moduleSource != legacyWorkspace {
+		return nil
+	}
+	root := s.view.rootURI.Filename()
+	folder := s.view.folder.Filename()
+	if !source.InDir(folder, root) {
+		return nil // filters apply relative to the folder
+	}
+	moduleMode := len(s.workspace.getActiveModFiles()) > 0
+	filterer := s.view.filterer
+
+	var patterns []string
+	pruned := false
+	errTooMany := errors.New("too many patterns")
+	err := filepath.Walk(root, func(path string, fi os.FileInfo, err error) error {
+		if err != nil || !fi.IsDir() {
+			return nil
+		}
+		if path != root {
+			// Skip the directories that "./..." doesn't match.
+			name := fi.Name()
+			if strings.HasPrefix(name, ".") || strings.HasPrefix(name, "_") || name == "testdata" || name == "vendor" {
+				return filepath.SkipDir
+			}
+			if moduleMode {
+				if _, err := os.Stat(filepath.Join(path, "go.mod")); err == nil {
+					return filepath.SkipDir // a nested module
+				}
+			}
+		}
+		rel, err := filepath.Rel(root, path)
+		if err != nil {
+			return err
+		}
+		pattern := "."
+		if rel != "." {
+			pattern = "./" + filepath.ToSlash(rel)
+		}
+		relToFolder := strings.TrimPrefix(path, folder)
+		excluded := filterer.Disallow(relToFolder)
+		if filterer.Uniform(relToFolder) {
+			if excluded {
+				pruned = true
+			} else {
+				patterns = append(patterns, pattern+"/...")
+			}
+			if len(patterns) > maxFilteredLoadPatterns {
+				return errTooMany
+			}
+			return filepath.SkipDir
+		}
+		// Some of the subdirectories may be excluded: match the package of
+		// this directory alone, if any, and walk the subdirectories.
+		if !excluded && hasGoFiles(path) {
+			patterns = append(patterns, pattern)
+			if len(patterns) > maxFilteredLoadPatterns {
+				return errTooMany
+			}
+		}
+		return nil
+	})
+	if err != nil || !pruned || len(patterns) == 0 {
+		return nil
+	}
+	return patterns
+}
+
+// hasGoFiles reports whether the directory dir contains Go files.
+func hasGoFiles(dir string) bool {
+	entries, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return false
+	}
+	for _, fi := range entries {
+		if !fi.IsDir() && strings.HasSuffix(fi.Name(), ".go") {
+			return true
+		}
+	}
+	return false
+}
+
 func (s *snapshot) workspaceLayoutError(ctx context.Context) *source.CriticalError {
 	if len(s.workspace.getKnownModFiles()) == 0 {
 		return nil
@@ -391,8 +491,9 @@ func (s *snapshot) setMetadataLocked(ctx context.Context, pkgPath PackagePath, p
 	for _, err := range pkg.Errors {
 		// Filter out parse errors from go list. We'll get them when we
 		// actually parse, and buggy overlay support may generate spurious
-		// errors. (See TestNewModule_Issue38207.)
-		if strings.Contains(err.Msg, "expected '") {
+		// errors. (See TestNewModule_Issue38207.) The errors of the C
+		// compiler, which say "error: expected ';'", are kept.
+		if strings.Contains(err.Msg, "expected '") && !strings.Contains(err.Msg, ": error: ") {
 			continue
 		}
 		m.Errors = append(m.Errors, err)
@@ -410,6 +511,11 @@ func (s *snapshot) setMetadataLocked(ctx context.Context, pkgPath PackagePath, p
 		uris[uri] = struct{}{}
 	}
 	s.updateIDForURIsLocked(id, uris)
+	// Other files, such as assembly files, are not associated with the
+	// package ID, as they are not type checked.
+	for _, filename := range pkg.OtherFiles {
+		m.OtherFiles = append(m.OtherFiles, span.URIFromPath(filename))
+	}
 
 	// TODO(rstambler): is this still necessary?
 	copied := map[PackageID]struct{}{
@@ -29,6 +29,7 @@ type Metadata struct {
 	Name            PackageName
 	GoFiles         []span.URI
 	CompiledGoFiles []span.URI
+	OtherFiles      []span.URI
 	ForTest         PackagePath
 	TypesSizes      types.Sizes
 	Errors          []packages.Error
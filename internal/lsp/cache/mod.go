@@ -337,7 +337,7 @@ func (s *snapshot) extractGoCommandErrors(ctx context.Context, goCmdError string
 
 	var srcErrs []*source.Diagnostic
 	for pm, spn := range diagLocations {
-		diag, err := s.goCommandDiagnostic(pm, spn, goCmdError)
+		diag, err := s.goCommandDiagnostic(ctx, pm, spn, goCmdError)
 		if err != nil {
 			return nil, err
 		}
@@ -393,7 +393,7 @@ func (s *snapshot) matchErrorToModule(ctx context.Context, pm *source.ParsedModu
 }
 
 // goCommandDiagnostic creates a diagnostic for a given go command error.
-func (s *snapshot) goCommandDiagnostic(pm *source.ParsedModule, spn span.Span, goCmdError string) (*source.Diagnostic, error) {
+func (s *snapshot) goCommandDiagnostic(ctx context.Context, pm *source.ParsedModule, spn span.Span, goCmdError string) (*source.Diagnostic, error) {
 	rng, err := pm.Mapper.Range(spn)
 	if err != nil {
 		return nil, err
@@ -448,16 +448,34 @@ See https://github.com/golang/go/issues/39164 for more detail on this issue.`,
 		if innermost != nil {
 			msg = fmt.Sprintf("go.sum is out of sync with go.mod: entry for %v is missing. Please updating it by applying the quick fix.", innermost)
 		}
+		var fixes []source.SuggestedFix
+		// Downloading just the modules with missing entries is much cheaper
+		// than tidying or loading the whole module graph, so offer it first.
+		if missing := s.missingGoSumEntries(ctx, pm, innermost); len(missing) > 0 {
+			title := "Add missing go.sum entries"
+			if len(missing) == 1 {
+				title = fmt.Sprintf("Add go.sum entries for %s", missing[0])
+			}
+			addCmd, err := command.NewAddGoSumEntriesCommand(title, command.GoSumEntriesArgs{
+				URI:     protocol.URIFromSpanURI(pm.URI),
+				Modules: missing,
+			})
+			if err != nil {
+				return nil, err
+			}
+			fixes = append(fixes, source.SuggestedFixFromCommand(addCmd, protocol.QuickFix))
+		}
+		fixes = append(fixes,
+			source.SuggestedFixFromCommand(tidyCmd, protocol.QuickFix),
+			source.SuggestedFixFromCommand(updateCmd, protocol.QuickFix),
+		)
 		return &source.Diagnostic{
-			URI:      pm.URI,
-			Range:    rng,
-			Severity: protocol.SeverityError,
-			Source:   source.ListError,
-			Message:  msg,
-			SuggestedFixes: []source.SuggestedFix{
-				source.SuggestedFixFromCommand(tidyCmd, protocol.QuickFix),
-				source.SuggestedFixFromCommand(updateCmd, protocol.QuickFix),
-			},
+			URI:            pm.URI,
+			Range:          rng,
+			Severity:       protocol.SeverityError,
+			Source:         source.ListError,
+			Message:        msg,
+			SuggestedFixes: fixes,
 		}, nil
 	case strings.Contains(goCmdError, "disabled by GOPROXY=off") && innermost != nil:
 		title := fmt.Sprintf("Download %v@%v", innermost.Path, innermost.Version)
@@ -488,6 +506,67 @@ See https://github.com/golang/go/issues/39164 for more detail on this issue.`,
 	}
 }
 
+// missingGoSumEntries returns the module versions, formatted as
+// path@version, whose go.sum entries are missing for the requirements of
+// pm. The module version named in the go command error, if any, is always
+// included.
+func (s *snapshot) missingGoSumEntries(ctx context.Context, pm *source.ParsedModule, innermost *module.Version) []string {
+	sums := make(map[module.Version][]string)
+	if err := readGoSum(sums, sumFilename(pm.URI), s.goSum(ctx, pm.URI)); err != nil {
+		// A malformed go.sum file needs more than a few new entries.
+		return nil
+	}
+	var missing []string
+	seen := make(map[module.Version]bool)
+	add := func(mod module.Version) {
+		if !seen[mod] {
+			seen[mod] = true
+			missing = append(missing, mod.String())
+		}
+	}
+	if innermost != nil {
+		add(*innermost)
+	}
+	for _, req := range pm.File.Require {
+		mod, ok := replacedModule(pm.File, req.Mod)
+		if !ok || source.IsWorkspaceModuleVersion(mod.Version) {
+			continue
+		}
+		// Every module in the graph needs its go.mod hash, but only direct
+		// dependencies are certain to need the hash of their contents.
+		goMod := module.Version{Path: mod.Path, Version: mod.Version + "/go.mod"}
+		if len(sums[goMod]) == 0 || (!req.Indirect && len(sums[mod]) == 0) {
+			add(mod)
+		}
+	}
+	return missing
+}
+
+// replacedModule returns the module version that provides mod after
+// applying the replace directives of f. It reports false if mod is
+// replaced by a directory, which has no go.sum entries.
+func replacedModule(f *modfile.File, mod module.Version) (module.Version, bool) {
+	var found *modfile.Replace
+	for _, rep := range f.Replace {
+		if rep.Old.Path != mod.Path {
+			continue
+		}
+		// A replacement of a specific version takes precedence over one
+		// of all versions.
+		if rep.Old.Version == mod.Version || (rep.Old.Version == "" && found == nil) {
+			found = rep
+		}
+	}
+	switch {
+	case found == nil:
+		return mod, true
+	case found.New.Version == "":
+		return module.Version{}, false
+	default:
+		return found.New, true
+	}
+}
+
 func findModuleReference(mf *modfile.File, ver module.Version) *modfile.Line {
 	for _, req := range mf.Require {
 		if req.Mod == ver {
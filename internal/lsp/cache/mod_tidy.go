@@ -328,6 +328,7 @@ func unusedDiagnostic(m *protocol.ColumnMapper, req *modfile.Require, onlyDiagno
 		Severity:       protocol.SeverityWarning,
 		Source:         source.ModTidyError,
 		Message:        fmt.Sprintf("%s is not used in this module", req.Mod.Path),
+		Tags:           []protocol.DiagnosticTag{protocol.Unnecessary},
 		SuggestedFixes: []source.SuggestedFix{source.SuggestedFixFromCommand(cmd, protocol.QuickFix)},
 	}, nil
 }
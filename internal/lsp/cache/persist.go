@@ -0,0 +1,386 @@
+// Copyright 2022 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cache
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/gob"
+	"fmt"
+	"os"
+	"sort"
+	"sync"
+
+	"github.com/iansmith/golang-x-tools/go/packages"
+	"github.com/iansmith/golang-x-tools/internal/event"
+	"github.com/iansmith/golang-x-tools/internal/lsp/filecache"
+	"github.com/iansmith/golang-x-tools/internal/lsp/protocol"
+	"github.com/iansmith/golang-x-tools/internal/lsp/source"
+	"github.com/iansmith/golang-x-tools/internal/span"
+)
+
+// The kinds of the values persisted in the file cache.
+const (
+	symbolsKind     = "symbols-v1"     // the symbols of a file, by content
+	symbolIndexKind = "symbolindex-v1" // the symbolIndex of a view, by configuration
+	analysisKind    = "analysis-v1"    // the diagnostics of an analyzer, by package key
+	recentDirsKind  = "recentdirs-v1"  // the recently opened directories of a view, by folder
+)
+
+// persistedSymbolize is like symbolize, but reuses the symbols persisted
+// for the same file content, by this or a previous gopls session.
+func persistedSymbolize(snapshot *snapshot, fh source.FileHandle) ([]source.Symbol, error) {
+	key := sha256.Sum256([]byte(fh.FileIdentity().Hash))
+	if data, err := filecache.Get(symbolsKind, key); err == nil {
+		var symbols []source.Symbol
+		if err := gob.NewDecoder(bytes.NewReader(data)).Decode(&symbols); err == nil {
+			return symbols, nil
+		}
+	}
+	symbols, err := symbolize(snapshot, fh)
+	if err != nil {
+		return symbols, err
+	}
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(symbols); err == nil {
+		// Failing to persist the symbols only makes the next session slower.
+		_ = filecache.Set(symbolsKind, key, buf.Bytes())
+	}
+	return symbols, nil
+}
+
+// A symbolIndex records the metadata graph of the workspace packages of a
+// view, so that a later gopls session can answer workspace symbol queries
+// for the same view before its initial workspace load completes.
+type symbolIndex struct {
+	Packages []indexedPackage
+}
+
+// An indexedPackage is the persisted subset of the Metadata of a workspace
+// package.
+type indexedPackage struct {
+	ID            PackageID
+	PkgPath       PackagePath
+	Name          PackageName
+	Files         []span.URI // the compiled Go files
+	Deps          []PackageID
+	ModulePath    string
+	ModuleVersion string
+}
+
+// symbolIndexKey returns the key of the symbolIndex of v, which depends on
+// everything that determines its workspace packages but the contents of
+// its files.
+func (v *View) symbolIndexKey() [32]byte {
+	h := sha256.New()
+	fmt.Fprintf(h, "folder: %s\n", v.folder)
+	fmt.Fprintf(h, "build flags: %q\n", v.Options().BuildFlags)
+	var keys []string
+	for k := range v.goEnv {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		fmt.Fprintf(h, "env: %s=%s\n", k, v.goEnv[k])
+	}
+	var key [32]byte
+	h.Sum(key[:0])
+	return key
+}
+
+// persistSymbolIndex persists the symbolIndex of the workspace packages of
+// s, once its workspace has been successfully loaded.
+func (s *snapshot) persistSymbolIndex(ctx context.Context) {
+	var index symbolIndex
+	s.mu.Lock()
+	for id := range s.workspacePackages {
+		m, ok := s.metadata[id]
+		if !ok || !m.Valid {
+			continue
+		}
+		pkg := indexedPackage{
+			ID:      m.ID,
+			PkgPath: m.PkgPath,
+			Name:    m.Name,
+			Files:   m.CompiledGoFiles,
+			Deps:    m.Deps,
+		}
+		if m.Module != nil {
+			pkg.ModulePath, pkg.ModuleVersion = m.Module.Path, m.Module.Version
+		}
+		index.Packages = append(index.Packages, pkg)
+	}
+	s.mu.Unlock()
+	if len(index.Packages) == 0 {
+		return
+	}
+	sort.Slice(index.Packages, func(i, j int) bool {
+		return index.Packages[i].ID < index.Packages[j].ID
+	})
+
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(&index); err != nil {
+		event.Error(ctx, "encoding the symbol index", err)
+		return
+	}
+	if err := filecache.Set(symbolIndexKind, s.view.symbolIndexKey(), buf.Bytes()); err != nil {
+		event.Error(ctx, "persisting the symbol index", err)
+	}
+}
+
+// persistedSymbolIndex returns the symbolIndex persisted for v by a previous
+// session, or nil if there is none.
+func (v *View) persistedSymbolIndex() *symbolIndex {
+	v.symbolIndexOnce.Do(func() {
+		data, err := filecache.Get(symbolIndexKind, v.symbolIndexKey())
+		if err != nil {
+			return
+		}
+		var index symbolIndex
+		if err := gob.NewDecoder(bytes.NewReader(data)).Decode(&index); err != nil {
+			return
+		}
+		v.symbolIndex = &index
+	})
+	return v.symbolIndex
+}
+
+// maxRecentDirs is the number of recently opened directories of a view
+// that the lazyTypeCheck setting type-checks.
+const maxRecentDirs = 10
+
+// recentDirsKey returns the key of the recently opened directories of v.
+func (v *View) recentDirsKey() [32]byte {
+	return sha256.Sum256([]byte(v.folder))
+}
+
+// loadRecentDirsLocked loads the directories persisted for v by a previous
+// session, the first time it is called. v.recentDirsMu must be held.
+func (v *View) loadRecentDirsLocked() {
+	if v.recentDirsLoaded {
+		return
+	}
+	v.recentDirsLoaded = true
+	data, err := filecache.Get(recentDirsKind, v.recentDirsKey())
+	if err != nil {
+		return
+	}
+	var dirs []string
+	if err := gob.NewDecoder(bytes.NewReader(data)).Decode(&dirs); err != nil {
+		return
+	}
+	v.recentDirs = dirs
+}
+
+// noteOpenedDir records that a Go file of dir was opened, making it the
+// most recently opened directory of v, and persists the directories.
+func (v *View) noteOpenedDir(dir string) {
+	v.recentDirsMu.Lock()
+	defer v.recentDirsMu.Unlock()
+	v.loadRecentDirsLocked()
+	if len(v.recentDirs) > 0 && v.recentDirs[0] == dir {
+		return
+	}
+	dirs := []string{dir}
+	for _, d := range v.recentDirs {
+		if d != dir && len(dirs) < maxRecentDirs {
+			dirs = append(dirs, d)
+		}
+	}
+	v.recentDirs = dirs
+
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(dirs); err != nil {
+		event.Error(v.baseCtx, "encoding the recent directories", err)
+		return
+	}
+	if err := filecache.Set(recentDirsKind, v.recentDirsKey(), buf.Bytes()); err != nil {
+		event.Error(v.baseCtx, "persisting the recent directories", err)
+	}
+}
+
+// recentDirSet returns the set of the recently opened directories of v.
+func (v *View) recentDirSet() map[string]bool {
+	v.recentDirsMu.Lock()
+	defer v.recentDirsMu.Unlock()
+	v.loadRecentDirsLocked()
+	set := make(map[string]bool, len(v.recentDirs))
+	for _, dir := range v.recentDirs {
+		set[dir] = true
+	}
+	return set
+}
+
+func (s *snapshot) PersistedSymbols(ctx context.Context) (map[span.URI][]source.Symbol, map[span.URI]source.Metadata, bool) {
+	select {
+	case <-s.view.initialWorkspaceLoad:
+		return nil, nil, false
+	default:
+	}
+	index := s.view.persistedSymbolIndex()
+	if index == nil {
+		return nil, nil, false
+	}
+
+	symbols := make(map[span.URI][]source.Symbol)
+	metadata := make(map[span.URI]source.Metadata)
+	for _, pkg := range index.Packages {
+		m := &Metadata{
+			ID:              pkg.ID,
+			PkgPath:         pkg.PkgPath,
+			Name:            pkg.Name,
+			CompiledGoFiles: pkg.Files,
+			Deps:            pkg.Deps,
+		}
+		if pkg.ModulePath != "" {
+			m.Module = &packages.Module{Path: pkg.ModulePath, Version: pkg.ModuleVersion}
+		}
+		for _, uri := range pkg.Files {
+			if _, ok := metadata[uri]; ok {
+				continue
+			}
+			// The symbols of the current contents of the file are usually
+			// persisted too, since they are keyed by content.
+			fh, err := s.GetFile(ctx, uri)
+			if err != nil {
+				continue
+			}
+			v, err := s.buildSymbolHandle(ctx, fh).handle.Get(ctx, s.generation, s)
+			if err != nil {
+				continue
+			}
+			data := v.(*symbolData)
+			if data.err != nil {
+				continue // the file may have been deleted
+			}
+			symbols[uri] = data.symbols
+			metadata[uri] = m
+		}
+	}
+	return symbols, metadata, true
+}
+
+// persistedAnalyze is like act.analyze, but returns only the diagnostics,
+// and reuses those persisted for the same package and analyzer by a
+// previous gopls session, unless act has already run in this one.
+func persistedAnalyze(ctx context.Context, snapshot *snapshot, act *actionHandle, a *source.Analyzer) ([]*source.Diagnostic, error) {
+	ran := act.handle.Cached(snapshot.generation) != nil
+	if executableID() == "" {
+		// The diagnostics of another build may be stale.
+		diagnostics, _, err := act.analyze(ctx, snapshot)
+		return diagnostics, err
+	}
+	key := analysisKey(snapshot, act, a)
+	if !ran {
+		if data, err := filecache.Get(analysisKind, key); err == nil {
+			var persisted []persistedDiagnostic
+			if err := gob.NewDecoder(bytes.NewReader(data)).Decode(&persisted); err == nil {
+				diagnostics := make([]*source.Diagnostic, len(persisted))
+				for i, d := range persisted {
+					diagnostics[i] = d.diagnostic(a)
+				}
+				return diagnostics, nil
+			}
+		}
+	}
+	diagnostics, _, err := act.analyze(ctx, snapshot)
+	if err != nil || ran {
+		return diagnostics, err
+	}
+	persisted := make([]persistedDiagnostic, len(diagnostics))
+	for i, d := range diagnostics {
+		persisted[i] = persistedDiagnostic{
+			URI:            d.URI,
+			Range:          d.Range,
+			Severity:       d.Severity,
+			Code:           d.Code,
+			CodeHref:       d.CodeHref,
+			Source:         d.Source,
+			Message:        d.Message,
+			Tags:           d.Tags,
+			Related:        d.Related,
+			SuggestedFixes: d.SuggestedFixes,
+		}
+	}
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(persisted); err == nil {
+		// Failing to persist the diagnostics only makes the next session slower.
+		_ = filecache.Set(analysisKind, key, buf.Bytes())
+	}
+	return diagnostics, nil
+}
+
+// A persistedDiagnostic is a source.Diagnostic of an analyzer, without the
+// analyzer, which cannot be encoded.
+type persistedDiagnostic struct {
+	URI            span.URI
+	Range          protocol.Range
+	Severity       protocol.DiagnosticSeverity
+	Code           string
+	CodeHref       string
+	Source         source.DiagnosticSource
+	Message        string
+	Tags           []protocol.DiagnosticTag
+	Related        []source.RelatedInformation
+	SuggestedFixes []source.SuggestedFix
+}
+
+// diagnostic returns the diagnostic of d, reported by a.
+func (d persistedDiagnostic) diagnostic(a *source.Analyzer) *source.Diagnostic {
+	return &source.Diagnostic{
+		URI:            d.URI,
+		Range:          d.Range,
+		Severity:       d.Severity,
+		Code:           d.Code,
+		CodeHref:       d.CodeHref,
+		Source:         d.Source,
+		Message:        d.Message,
+		Tags:           d.Tags,
+		Related:        d.Related,
+		SuggestedFixes: d.SuggestedFixes,
+		Analyzer:       a,
+	}
+}
+
+// analysisKey returns the key of the diagnostics of the analyzer a, of
+// act, which depends on the contents of the package and of its
+// dependencies, on the options that affect the analyzer, and on the gopls
+// executable that implements it.
+func analysisKey(snapshot *snapshot, act *actionHandle, a *source.Analyzer) [32]byte {
+	options := snapshot.View().Options()
+	h := sha256.New()
+	fmt.Fprintf(h, "executable: %s\n", executableID())
+	fmt.Fprintf(h, "analyzer: %s\n", a.Analyzer.Name)
+	fmt.Fprintf(h, "severity: %d\n", a.Severity)
+	_, staticcheck := options.StaticcheckAnalyzers[a.Analyzer.Name]
+	fmt.Fprintf(h, "staticcheck: %t %v\n", staticcheck, options.StaticcheckConfig)
+	fmt.Fprintf(h, "package: %s\n", act.pkgKey)
+	var key [32]byte
+	h.Sum(key[:0])
+	return key
+}
+
+var (
+	executableOnce sync.Once
+	executable     string
+)
+
+// executableID identifies the gopls executable, whose analyzers may differ
+// from those of another build: its path, size and modification time.
+func executableID() string {
+	executableOnce.Do(func() {
+		name, err := os.Executable()
+		if err != nil {
+			return
+		}
+		info, err := os.Stat(name)
+		if err != nil {
+			return
+		}
+		executable = fmt.Sprintf("%s %d %d", name, info.Size(), info.ModTime().UnixNano())
+	})
+	return executable
+}
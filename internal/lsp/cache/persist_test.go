@@ -0,0 +1,53 @@
+// Copyright 2022 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cache
+
+import (
+	"bytes"
+	"encoding/gob"
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/iansmith/golang-x-tools/internal/lsp/protocol"
+	"github.com/iansmith/golang-x-tools/internal/lsp/source"
+	"github.com/iansmith/golang-x-tools/internal/span"
+)
+
+// TestPersistedDiagnostic checks that analysis diagnostics survive their
+// encoding, with their fixes, and get back their analyzer.
+func TestPersistedDiagnostic(t *testing.T) {
+	uri := span.URIFromPath("/a/a.go")
+	rng := protocol.Range{Start: protocol.Position{Line: 3, Character: 1}, End: protocol.Position{Line: 3, Character: 6}}
+	want := persistedDiagnostic{
+		URI:      uri,
+		Range:    rng,
+		Severity: protocol.SeverityWarning,
+		Source:   "assign",
+		Message:  "self-assignment of x to x",
+		Tags:     []protocol.DiagnosticTag{protocol.Unnecessary},
+		Related:  []source.RelatedInformation{{URI: uri, Range: rng, Message: "x"}},
+		SuggestedFixes: []source.SuggestedFix{{
+			Title:   "Remove",
+			Edits:   map[span.URI][]protocol.TextEdit{uri: {{Range: rng}}},
+			Command: &protocol.Command{Command: "gopls.fix", Arguments: []json.RawMessage{json.RawMessage(`"x"`)}},
+		}},
+	}
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode([]persistedDiagnostic{want}); err != nil {
+		t.Fatal(err)
+	}
+	var got []persistedDiagnostic
+	if err := gob.NewDecoder(&buf).Decode(&got); err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 1 || !reflect.DeepEqual(got[0], want) {
+		t.Fatalf("decoded diagnostics = %+v, want %+v", got, want)
+	}
+	a := &source.Analyzer{Enabled: true}
+	if d := got[0].diagnostic(a); d.Analyzer != a || d.Message != want.Message {
+		t.Errorf("diagnostic() = %+v, want the diagnostic of %v", d, a)
+	}
+}
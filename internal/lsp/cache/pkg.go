@@ -97,6 +97,10 @@ func (p *pkg) IsIllTyped() bool {
 	return p.types == nil || p.typesInfo == nil || p.typesSizes == nil
 }
 
+func (p *pkg) OtherFiles() []span.URI {
+	return p.m.OtherFiles
+}
+
 func (p *pkg) ForTest() string {
 	return string(p.m.ForTest)
 }
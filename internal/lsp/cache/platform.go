@@ -0,0 +1,181 @@
+// Copyright 2022 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cache
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"go/token"
+	"io/ioutil"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/iansmith/golang-x-tools/go/packages"
+	"github.com/iansmith/golang-x-tools/internal/event"
+	"github.com/iansmith/golang-x-tools/internal/gocommand"
+	"github.com/iansmith/golang-x-tools/internal/lsp/debug/tag"
+	"github.com/iansmith/golang-x-tools/internal/lsp/protocol"
+	"github.com/iansmith/golang-x-tools/internal/lsp/source"
+	"github.com/iansmith/golang-x-tools/internal/span"
+)
+
+// A platformKey identifies the package of a directory, type-checked for a
+// platform.
+type platformKey struct {
+	platform string
+	dir      span.URI
+}
+
+// platformResult holds the diagnostics of a package type-checked for a
+// platform, and the hash of the files they were computed from.
+type platformResult struct {
+	hash  string
+	diags map[span.URI][]*source.Diagnostic
+}
+
+// PlatformDiagnostics type-checks the package of the directory dir as it is
+// built for platform, a GOOS/GOARCH pair, and returns its errors grouped by
+// file. The results are reused until the files of the package, the unsaved
+// files, or the go.mod files of the workspace change.
+func (s *snapshot) PlatformDiagnostics(ctx context.Context, dir span.URI, platform string) (map[span.URI][]*source.Diagnostic, error) {
+	goos, goarch, err := source.ParsePlatform(platform)
+	if err != nil {
+		return nil, err
+	}
+	overlay := s.buildOverlay()
+	hash, err := s.platformHash(ctx, dir, overlay)
+	if err != nil {
+		return nil, err
+	}
+	key := platformKey{platform, dir}
+	s.view.platformMu.Lock()
+	result, ok := s.view.platformResults[key]
+	s.view.platformMu.Unlock()
+	if ok && result.hash == hash {
+		return result.diags, nil
+	}
+
+	ctx, done := event.Start(ctx, "cache.snapshot.PlatformDiagnostics", tag.Directory.Of(dir.Filename()))
+	defer done()
+
+	_, inv, cleanup, err := s.goCommandInvocation(ctx, source.LoadWorkspace, &gocommand.Invocation{
+		WorkingDir: s.view.rootURI.Filename(),
+		Env:        []string{"GOOS=" + goos, "GOARCH=" + goarch},
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer cleanup()
+	cfg := s.config(ctx, inv)
+	// The dependencies come from export data, which the go command builds
+	// for the platform, and only the package itself is type-checked.
+	cfg.Mode = packages.NeedName | packages.NeedFiles | packages.NeedCompiledGoFiles |
+		packages.NeedImports | packages.NeedTypes | packages.NeedTypesSizes |
+		packages.NeedSyntax | packages.NeedTypesInfo
+	cfg.Fset = token.NewFileSet()
+	cfg.Overlay = overlay
+	cfg.ParseFile = nil
+	cfg.Tests = false
+	pkgs, err := packages.Load(cfg, dir.Filename())
+	if err != nil {
+		return nil, err
+	}
+
+	diags := make(map[span.URI][]*source.Diagnostic)
+	for _, pkg := range pkgs {
+		for _, e := range pkg.Errors {
+			// Directories without files for the platform are not errors, and
+			// list errors are reported for the configured platform already.
+			if e.Kind != packages.TypeError && e.Kind != packages.ParseError {
+				continue
+			}
+			diag, err := s.platformDiagnostic(ctx, e)
+			if err != nil {
+				event.Error(ctx, "converting platform error", err, tag.Directory.Of(dir.Filename()))
+				continue
+			}
+			diags[diag.URI] = append(diags[diag.URI], diag)
+		}
+	}
+
+	s.view.platformMu.Lock()
+	if s.view.platformResults == nil {
+		s.view.platformResults = make(map[platformKey]platformResult)
+	}
+	s.view.platformResults[key] = platformResult{hash, diags}
+	s.view.platformMu.Unlock()
+	return diags, nil
+}
+
+// platformHash returns a hash of the files that PlatformDiagnostics depends
+// on: the Go files of dir, the unsaved files of overlay, and the go.mod
+// files of the workspace.
+func (s *snapshot) platformHash(ctx context.Context, dir span.URI, overlay map[string][]byte) (string, error) {
+	var uris []span.URI
+	entries, err := ioutil.ReadDir(dir.Filename())
+	if err != nil {
+		return "", err
+	}
+	for _, fi := range entries {
+		if !fi.IsDir() && strings.HasSuffix(fi.Name(), ".go") {
+			uris = append(uris, span.URIFromPath(filepath.Join(dir.Filename(), fi.Name())))
+		}
+	}
+	for uri := range s.workspace.getActiveModFiles() {
+		uris = append(uris, uri)
+	}
+	var paths []string
+	for path := range overlay {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	h := sha256.New()
+	for _, uri := range uris {
+		fh, err := s.GetFile(ctx, uri)
+		if err != nil {
+			return "", err
+		}
+		fmt.Fprintf(h, "%s %s\n", uri, fh.FileIdentity().Hash)
+	}
+	for _, path := range paths {
+		fmt.Fprintf(h, "%s %s\n", path, hashContents(overlay[path]))
+	}
+	return fmt.Sprintf("%x", h.Sum(nil)), nil
+}
+
+// platformDiagnostic returns the diagnostic of the error e of a package
+// type-checked for another platform.
+func (s *snapshot) platformDiagnostic(ctx context.Context, e packages.Error) (*source.Diagnostic, error) {
+	if e.Pos == "" || e.Pos == "-" {
+		return nil, fmt.Errorf("no position for %q", e.Msg)
+	}
+	spn := span.ParseInDir(e.Pos, s.view.rootURI.Filename())
+	fh, err := s.GetFile(ctx, spn.URI())
+	if err != nil {
+		return nil, err
+	}
+	content, err := fh.Read()
+	if err != nil {
+		return nil, err
+	}
+	rng, err := protocol.NewColumnMapper(spn.URI(), content).Range(spn)
+	if err != nil {
+		return nil, err
+	}
+	dsource := source.TypeError
+	if e.Kind == packages.ParseError {
+		dsource = source.ParseError
+	}
+	return &source.Diagnostic{
+		URI:      spn.URI(),
+		Range:    rng,
+		Severity: protocol.SeverityError,
+		Source:   dsource,
+		Message:  e.Msg,
+	}, nil
+}
@@ -7,13 +7,18 @@ package cache
 import (
 	"context"
 	"fmt"
+	"path/filepath"
+	"sort"
 	"strconv"
+	"strings"
 	"sync"
 	"sync/atomic"
 
+	"golang.org/x/mod/module"
 	"github.com/iansmith/golang-x-tools/internal/event"
 	"github.com/iansmith/golang-x-tools/internal/gocommand"
 	"github.com/iansmith/golang-x-tools/internal/imports"
+	"github.com/iansmith/golang-x-tools/internal/lsp/command"
 	"github.com/iansmith/golang-x-tools/internal/lsp/progress"
 	"github.com/iansmith/golang-x-tools/internal/lsp/source"
 	"github.com/iansmith/golang-x-tools/internal/span"
@@ -38,6 +43,10 @@ type Session struct {
 	gocmdRunner *gocommand.Runner
 
 	progress *progress.Tracker
+
+	// panicHandler, if set, is told of the panics that the views recover
+	// from.
+	panicHandler func(context.Context, error)
 }
 
 type overlay struct {
@@ -137,6 +146,12 @@ func (s *Session) SetProgressTracker(tracker *progress.Tracker) {
 	s.progress = tracker
 }
 
+func (s *Session) SetPanicHandler(handler func(context.Context, error)) {
+	// Like the progress tracker, the handler should be set before any view
+	// is initialized.
+	s.panicHandler = handler
+}
+
 func (s *Session) Shutdown(ctx context.Context) {
 	var views []*View
 	s.viewMu.Lock()
@@ -178,6 +193,7 @@ func (s *Session) createView(ctx context.Context, name string, folder span.URI,
 	if s.cache.options != nil {
 		s.cache.options(options)
 	}
+	options.ApplyFolderOverrides(name, folder.Filename())
 
 	// Set the module-specific information.
 	ws, err := s.getWorkspaceInformation(ctx, folder, options)
@@ -213,11 +229,13 @@ func (s *Session) createView(ctx context.Context, name string, folder span.URI,
 		name:                 name,
 		folder:               folder,
 		moduleUpgrades:       map[string]string{},
+		moduleVulns:          map[module.Version][]command.Vuln{},
 		filesByURI:           map[span.URI]*fileBase{},
 		filesByBase:          map[string][]*fileBase{},
 		rootURI:              root,
 		workspaceInformation: *ws,
 	}
+	v.filterer = newFilterer(root.Filename(), ws.gomodcache, options)
 	v.importsState = &importsState{
 		ctx: backgroundCtx,
 		processEnv: &imports.ProcessEnv{
@@ -245,6 +263,7 @@ func (s *Session) createView(ctx context.Context, name string, folder span.URI,
 		parseWorkHandles:  make(map[span.URI]*parseWorkHandle),
 		modTidyHandles:    make(map[span.URI]*modTidyHandle),
 		modWhyHandles:     make(map[span.URI]*modWhyHandle),
+		vulns:             make(map[PackageID][]command.Vuln),
 		workspace:         workspace,
 	}
 
@@ -469,6 +488,9 @@ func (s *Session) DidModifyFiles(ctx context.Context, changes []source.FileModif
 
 		// Apply the changes to all affected views.
 		for _, view := range changedViews {
+			if c.Action == source.Open && view.Options().LazyTypeCheck && strings.HasSuffix(c.URI.Filename(), ".go") {
+				view.noteOpenedDir(filepath.Dir(c.URI.Filename()))
+			}
 			// Make sure that the file is added to the view.
 			_ = view.getFile(c.URI)
 			if _, ok := views[view]; !ok {
@@ -738,3 +760,55 @@ func (s *Session) FileWatchingGlobPatterns(ctx context.Context) map[string]struc
 	}
 	return patterns
 }
+
+func (s *Session) FileWatchingDirs(ctx context.Context) []span.URI {
+	s.viewMu.RLock()
+	defer s.viewMu.RUnlock()
+	var dirs []string
+	for _, view := range s.views {
+		snapshot, release := view.getSnapshot()
+		dirs = append(dirs, view.folder.Filename())
+		for _, dir := range snapshot.workspace.dirs(ctx, snapshot) {
+			dirs = append(dirs, dir.Filename())
+		}
+		release()
+	}
+	// Omit the directories of the trees of other directories.
+	sort.Strings(dirs)
+	var roots []span.URI
+	for _, dir := range dirs {
+		if len(roots) > 0 && source.InDir(roots[len(roots)-1].Filename(), dir) {
+			continue
+		}
+		roots = append(roots, span.URIFromPath(dir))
+	}
+	return roots
+}
+
+func (s *Session) ShouldWatch(path string, isDir bool) bool {
+	s.viewMu.RLock()
+	defer s.viewMu.RUnlock()
+	if isDir && strings.HasPrefix(filepath.Base(path), ".") {
+		return false // ignored by the go command, and often busy, as .git
+	}
+	ext := strings.TrimPrefix(filepath.Ext(path), ".")
+	for _, view := range s.views {
+		if !isDir {
+			if strings.Contains(","+fileExtensions+",", ","+ext+",") {
+				return true
+			}
+			for _, tmplExt := range view.Options().TemplateExtensions {
+				if ext == strings.TrimPrefix(tmplExt, ".") {
+					return true
+				}
+			}
+			continue
+		}
+		// Filters are applied relative to the workspace folder.
+		folder := view.folder.Filename()
+		if !source.InDir(folder, path) || !view.filterer.Disallow(strings.TrimPrefix(path, folder)) {
+			return true
+		}
+	}
+	return false
+}
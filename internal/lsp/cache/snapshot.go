@@ -30,6 +30,7 @@ import (
 	"github.com/iansmith/golang-x-tools/internal/event"
 	"github.com/iansmith/golang-x-tools/internal/gocommand"
 	"github.com/iansmith/golang-x-tools/internal/lsp/bug"
+	"github.com/iansmith/golang-x-tools/internal/lsp/command"
 	"github.com/iansmith/golang-x-tools/internal/lsp/debug/log"
 	"github.com/iansmith/golang-x-tools/internal/lsp/debug/tag"
 	"github.com/iansmith/golang-x-tools/internal/lsp/source"
@@ -117,6 +118,10 @@ type snapshot struct {
 	modTidyHandles map[span.URI]*modTidyHandle
 	modWhyHandles  map[span.URI]*modWhyHandle
 
+	// vulns maps package IDs to the known vulnerabilities of the packages
+	// they import. It may be invalidated when their metadata changes.
+	vulns map[PackageID][]command.Vuln
+
 	workspace          *workspace
 	workspaceDirHandle *memoize.Handle
 
@@ -763,22 +768,43 @@ func (s *snapshot) workspacePackageIDs() (ids []PackageID) {
 }
 
 func (s *snapshot) activePackageIDs() (ids []PackageID) {
-	if s.view.Options().MemoryMode == source.ModeNormal {
+	options := s.view.Options()
+	if options.MemoryMode == source.ModeNormal && !options.LazyTypeCheck {
 		return s.workspacePackageIDs()
 	}
+	// With lazyTypeCheck, the packages of the recently opened directories
+	// are type-checked too, ahead of their files.
+	var recentDirs map[string]bool
+	if options.LazyTypeCheck {
+		recentDirs = s.view.recentDirSet()
+	}
 
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
 	seen := make(map[PackageID]bool)
 	for id := range s.workspacePackages {
-		if s.isActiveLocked(id, seen) {
+		if s.isActiveLocked(id, seen) || s.inDirsLocked(id, recentDirs) {
 			ids = append(ids, id)
 		}
 	}
 	return ids
 }
 
+// inDirsLocked reports whether the package id has a file in one of dirs.
+func (s *snapshot) inDirsLocked(id PackageID, dirs map[string]bool) bool {
+	m, ok := s.metadata[id]
+	if !ok || len(dirs) == 0 {
+		return false
+	}
+	for _, cgf := range m.CompiledGoFiles {
+		if dirs[filepath.Dir(cgf.Filename())] {
+			return true
+		}
+	}
+	return false
+}
+
 func (s *snapshot) isActiveLocked(id PackageID, seen map[PackageID]bool) (active bool) {
 	if seen == nil {
 		seen = make(map[PackageID]bool)
@@ -971,6 +997,11 @@ func (s *snapshot) ActivePackages(ctx context.Context) ([]source.Package, error)
 	}
 	var pkgs []source.Package
 	for _, ph := range phs {
+		// Let interactive requests preempt the type-checking of the
+		// workspace by the diagnostics.
+		if err := source.Yield(ctx); err != nil {
+			return nil, err
+		}
 		pkg, err := ph.check(ctx, s)
 		if err != nil {
 			return nil, err
@@ -1036,6 +1067,50 @@ func (s *snapshot) MetadataForFile(ctx context.Context, uri span.URI) ([]source.
 	return mds, nil
 }
 
+func (s *snapshot) ModuleGraph(ctx context.Context) (map[string]*packages.Module, map[string][]string, error) {
+	if err := s.awaitLoaded(ctx); err != nil {
+		return nil, nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	mods := make(map[string]*packages.Module)
+	deps := make(map[string]map[string]bool)
+	for id, m := range s.metadata {
+		// Standard library packages have no module.
+		if m.Module == nil {
+			continue
+		}
+		if _, ok := s.workspacePackages[id]; ok {
+			// In the experimental workspace module mode, the modules of the
+			// workspace are loaded as replaced requirements of a synthetic
+			// version.
+			mods[m.Module.Path] = &packages.Module{Path: m.Module.Path, Main: true, Dir: m.Module.Dir, GoMod: m.Module.GoMod, GoVersion: m.Module.GoVersion}
+		} else if _, ok := mods[m.Module.Path]; !ok {
+			mods[m.Module.Path] = m.Module
+		}
+		for _, id := range m.Deps {
+			dep, ok := s.metadata[id]
+			if !ok || dep.Module == nil || dep.Module.Path == m.Module.Path {
+				continue
+			}
+			if deps[m.Module.Path] == nil {
+				deps[m.Module.Path] = make(map[string]bool)
+			}
+			deps[m.Module.Path][dep.Module.Path] = true
+		}
+	}
+	graph := make(map[string][]string, len(deps))
+	for path, paths := range deps {
+		for dep := range paths {
+			graph[path] = append(graph[path], dep)
+		}
+		sort.Strings(graph[path])
+	}
+	return mods, graph, nil
+}
+
 func (s *snapshot) KnownPackages(ctx context.Context) ([]source.Package, error) {
 	if err := s.awaitLoaded(ctx); err != nil {
 		return nil, err
@@ -1064,6 +1139,40 @@ func (s *snapshot) KnownPackages(ctx context.Context) ([]source.Package, error)
 	return pkgs, nil
 }
 
+func (s *snapshot) ImportGraph(ctx context.Context) ([]*source.ImportGraphNode, error) {
+	if err := s.awaitLoaded(ctx); err != nil {
+		return nil, err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var nodes []*source.ImportGraphNode
+	for id, m := range s.metadata {
+		if m.IsIntermediateTestVariant {
+			continue
+		}
+		_, workspace := s.workspacePackages[id]
+		node := &source.ImportGraphNode{
+			ID:        string(id),
+			PkgPath:   string(m.PkgPath),
+			Workspace: workspace,
+			Test:      m.ForTest != "" || strings.HasSuffix(string(m.PkgPath), ".test"),
+		}
+		if m.Module != nil {
+			node.Module = m.Module.Path
+		}
+		for _, dep := range m.Deps {
+			node.Imports = append(node.Imports, string(dep))
+		}
+		sort.Strings(node.Imports)
+		nodes = append(nodes, node)
+	}
+	sort.Slice(nodes, func(i, j int) bool {
+		return nodes[i].ID < nodes[j].ID
+	})
+	return nodes, nil
+}
+
 func (s *snapshot) CachedImportPaths(ctx context.Context) (map[string]source.Package, error) {
 	// Don't reload workspace package metadata.
 	// This function is meant to only return currently cached information.
@@ -1660,8 +1769,20 @@ func inVendor(uri span.URI) bool {
 	return strings.Contains(split[1], "/")
 }
 
+// generationName returns the name of the generation of a snapshot of v. It
+// starts with the ID of the session of v, which sessionOfGeneration extracts.
 func generationName(v *View, snapshotID uint64) string {
-	return fmt.Sprintf("v%v/%v", v.id, snapshotID)
+	return fmt.Sprintf("s%v/v%v/%v", v.session.id, v.id, snapshotID)
+}
+
+// sessionOfGeneration returns the ID of the session whose snapshot g belongs
+// to, prefixed with "s".
+func sessionOfGeneration(g *memoize.Generation) string {
+	name := g.Name()
+	if i := strings.IndexByte(name, '/'); i >= 0 {
+		return name[:i]
+	}
+	return name
 }
 
 // checkSnapshotLocked verifies that some invariants are preserved on the
@@ -1700,7 +1821,10 @@ func (ac *unappliedChanges) GetFile(ctx context.Context, uri span.URI) (source.F
 	return ac.originalSnapshot.GetFile(ctx, uri)
 }
 
-func (s *snapshot) clone(ctx, bgCtx context.Context, changes map[span.URI]*fileChange, forceReloadMetadata bool) *snapshot {
+// clone returns a snapshot of the state of s after the changes. If
+// dropPackages is set, the clone does not inherit the type-checked packages
+// and analysis results of s, which are computed again on demand.
+func (s *snapshot) clone(ctx, bgCtx context.Context, changes map[span.URI]*fileChange, forceReloadMetadata, dropPackages bool) *snapshot {
 	var vendorChanged bool
 	newWorkspace, workspaceChanged, workspaceReload := s.workspace.invalidate(ctx, changes, &unappliedChanges{
 		originalSnapshot: s,
@@ -1737,6 +1861,7 @@ func (s *snapshot) clone(ctx, bgCtx context.Context, changes map[span.URI]*fileC
 		parseWorkHandles:  make(map[span.URI]*parseWorkHandle, len(s.parseWorkHandles)),
 		modTidyHandles:    make(map[span.URI]*modTidyHandle, len(s.modTidyHandles)),
 		modWhyHandles:     make(map[span.URI]*modWhyHandle, len(s.modWhyHandles)),
+		vulns:             make(map[PackageID][]command.Vuln, len(s.vulns)),
 		knownSubdirs:      make(map[span.URI]struct{}, len(s.knownSubdirs)),
 		workspace:         newWorkspace,
 	}
@@ -1929,12 +2054,24 @@ func (s *snapshot) clone(ctx, bgCtx context.Context, changes map[span.URI]*fileC
 		if _, ok := idsToInvalidate[k.id]; ok {
 			continue
 		}
+		// The metadata of packages without compiled files, such as unsafe, is
+		// not kept by the clone, so their handles could not be built again.
+		if dropPackages && len(v.m.CompiledGoFiles) > 0 {
+			continue
+		}
 		newGen.Inherit(v.handle)
 		result.packages[k] = v
 	}
+	// Copy the vulnerabilities of packages whose dependencies are unchanged.
+	for k, v := range s.vulns {
+		if invalidateMetadata, ok := idsToInvalidate[k]; ok && invalidateMetadata {
+			continue
+		}
+		result.vulns[k] = v
+	}
 	// Copy the package analysis information.
 	for k, v := range s.actions {
-		if _, ok := idsToInvalidate[k.pkg.id]; ok {
+		if _, ok := idsToInvalidate[k.pkg.id]; ok || dropPackages {
 			continue
 		}
 		newGen.Inherit(v.handle)
@@ -2248,7 +2385,8 @@ func metadataChanges(ctx context.Context, lockedSnapshot *snapshot, oldFH, newFH
 		origFull, oldErr := peekOrParse(ctx, lockedSnapshot, oldFH, source.ParseFull)
 		currFull, newErr := peekOrParse(ctx, lockedSnapshot, newFH, source.ParseFull)
 		if oldErr == nil && newErr == nil {
-			invalidate = magicCommentsChanged(origFull.File, currFull.File)
+			invalidate = magicCommentsChanged(origFull.File, currFull.File) ||
+				cgoPreamblesChanged(origFull.File, currFull.File)
 		} else {
 			// At this point, we shouldn't ever fail to produce a ParsedGoFile, as
 			// we're already past header parsing.
@@ -2297,6 +2435,33 @@ func magicCommentsChanged(original *ast.File, current *ast.File) bool {
 	return false
 }
 
+// cgoPreamblesChanged reports whether the cgo preambles of original and
+// current differ, which changes the declarations that cgo generates.
+func cgoPreamblesChanged(original *ast.File, current *ast.File) bool {
+	oldPreambles := source.CgoPreambles(original)
+	newPreambles := source.CgoPreambles(current)
+	if len(oldPreambles) != len(newPreambles) {
+		return true
+	}
+	for i := range oldPreambles {
+		if commentsText(oldPreambles[i]) != commentsText(newPreambles[i]) {
+			return true
+		}
+	}
+	return false
+}
+
+// commentsText returns the text of the comments of g, including their
+// markers.
+func commentsText(g *ast.CommentGroup) string {
+	var b strings.Builder
+	for _, c := range g.List {
+		b.WriteString(c.Text)
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
 // validImports extracts the set of valid import paths from imports.
 func validImports(imports []*ast.ImportSpec) map[string]struct{} {
 	m := make(map[string]struct{})
@@ -43,7 +43,7 @@ func (s *snapshot) buildSymbolHandle(ctx context.Context, fh source.FileHandle)
 	h := s.generation.Bind(key, func(_ context.Context, arg memoize.Arg) interface{} {
 		snapshot := arg.(*snapshot)
 		data := &symbolData{}
-		data.symbols, data.err = symbolize(snapshot, fh)
+		data.symbols, data.err = persistedSymbolize(snapshot, fh)
 		return data
 	}, nil)
 
@@ -21,14 +21,18 @@ import (
 	"sync"
 
 	"golang.org/x/mod/modfile"
+	"golang.org/x/mod/module"
 	"golang.org/x/mod/semver"
 	exec "golang.org/x/sys/execabs"
 	"github.com/iansmith/golang-x-tools/go/packages"
 	"github.com/iansmith/golang-x-tools/internal/event"
 	"github.com/iansmith/golang-x-tools/internal/gocommand"
 	"github.com/iansmith/golang-x-tools/internal/imports"
+	"github.com/iansmith/golang-x-tools/internal/lsp/command"
+	"github.com/iansmith/golang-x-tools/internal/lsp/debug/tag"
 	"github.com/iansmith/golang-x-tools/internal/lsp/protocol"
 	"github.com/iansmith/golang-x-tools/internal/lsp/source"
+	"github.com/iansmith/golang-x-tools/internal/lsp/telemetry"
 	"github.com/iansmith/golang-x-tools/internal/span"
 	"github.com/iansmith/golang-x-tools/internal/xcontext"
 )
@@ -40,6 +44,20 @@ type View struct {
 	optionsMu sync.Mutex
 	options   *source.Options
 
+	// filterer applies the directory filters of options, which are never
+	// changed without creating a new view.
+	filterer *source.Filterer
+
+	// platformMu guards platformResults, the diagnostics of the packages
+	// type-checked for the platforms of the diagnosticPlatforms option.
+	platformMu      sync.Mutex
+	platformResults map[platformKey]platformResult
+
+	// panicMu guards panics, the set of the values and top frames of the
+	// panics that the view recovered from.
+	panicMu sync.Mutex
+	panics  map[string]bool
+
 	// mu protects most mutable state of the view.
 	mu sync.Mutex
 
@@ -62,6 +80,10 @@ type View struct {
 	// moduleUpgrades tracks known upgrades for module paths.
 	moduleUpgrades map[string]string
 
+	// moduleVulns caches the known vulnerabilities of module versions,
+	// which do not change while the view exists.
+	moduleVulns map[module.Version][]command.Vuln
+
 	// keep track of files by uri and by basename, a single file may be mapped
 	// to multiple uris, and the same basename may map to multiple files
 	filesByURI  map[span.URI]*fileBase
@@ -84,6 +106,19 @@ type View struct {
 	// context is canceled.
 	initializationSema chan struct{}
 
+	// symbolIndexOnce guards symbolIndex, the symbol index persisted by a
+	// previous session for this view, if any.
+	symbolIndexOnce sync.Once
+	symbolIndex     *symbolIndex
+
+	// recentDirsMu guards recentDirs, the directories of the Go files most
+	// recently opened in this view, most recent first, which the
+	// lazyTypeCheck setting type-checks ahead of their files. They are
+	// persisted across sessions.
+	recentDirsMu     sync.Mutex
+	recentDirs       []string
+	recentDirsLoaded bool
+
 	// rootURI is the rootURI directory of this view. If we are in GOPATH mode, this
 	// is just the folder. If we are in module mode, this is the module rootURI.
 	rootURI span.URI
@@ -239,6 +274,8 @@ func (v *View) FileKind(fh source.FileHandle) source.FileKind {
 		return source.Sum
 	case ".work":
 		return source.Work
+	case ".s":
+		return source.Asm
 	}
 	exts := v.Options().TemplateExtensions
 	for _, ext := range exts {
@@ -258,7 +295,12 @@ func minorOptionsChange(a, b *source.Options) bool {
 	if !reflect.DeepEqual(a.DirectoryFilters, b.DirectoryFilters) {
 		return false
 	}
-	if a.MemoryMode != b.MemoryMode {
+	if a.MemoryMode != b.MemoryMode || a.LazyTypeCheck != b.LazyTypeCheck {
+		return false
+	}
+	// The staticcheck configuration is a result of analysis, which is cached
+	// by the view.
+	if !reflect.DeepEqual(a.StaticcheckConfig, b.StaticcheckConfig) {
 		return false
 	}
 	aBuildFlags := make([]string, len(a.BuildFlags))
@@ -272,6 +314,7 @@ func minorOptionsChange(a, b *source.Options) bool {
 }
 
 func (v *View) SetOptions(ctx context.Context, options *source.Options) (source.View, error) {
+	options.ApplyFolderOverrides(v.name, v.folder.Filename())
 	// no need to rebuild the view if the options were not materially changed
 	v.optionsMu.Lock()
 	if minorOptionsChange(v.options, options) {
@@ -377,7 +420,10 @@ func (s *snapshot) locateTemplateFiles(ctx context.Context) {
 			return err
 		}
 		relpath := strings.TrimPrefix(path, relativeTo)
-		excluded := pathExcludedByFilter(relpath, dir, s.view.gomodcache, s.view.options)
+		excluded := s.view.filterer.Disallow(relpath)
+		if fi.IsDir() && excluded && s.view.filterer.Uniform(relpath) {
+			return filepath.SkipDir
+		}
 		if fileHasExtension(path, suffixes) && !excluded && !fi.IsDir() {
 			k := span.URIFromPath(path)
 			_, err := s.GetVersionedFile(ctx, k)
@@ -404,7 +450,7 @@ func (v *View) contains(uri span.URI) bool {
 	}
 	// Filters are applied relative to the workspace folder.
 	if inFolder {
-		return !pathExcludedByFilter(strings.TrimPrefix(uri.Filename(), v.folder.Filename()), v.rootURI.Filename(), v.gomodcache, v.Options())
+		return !v.filterer.Disallow(strings.TrimPrefix(uri.Filename(), v.folder.Filename()))
 	}
 	return true
 }
@@ -586,6 +632,36 @@ func (v *View) getSnapshot() (*snapshot, func()) {
 	return v.snapshot, v.snapshot.generation.Acquire()
 }
 
+// EstimatedMemory estimates the memory used by the type-checked packages of
+// the current snapshot of v, in bytes. Packages shared with other views are
+// counted in each of them.
+func (v *View) EstimatedMemory() int64 {
+	v.snapshotMu.Lock()
+	s := v.snapshot
+	if s == nil {
+		v.snapshotMu.Unlock()
+		return 0
+	}
+	release := s.generation.Acquire()
+	v.snapshotMu.Unlock()
+	defer release()
+
+	s.mu.Lock()
+	phs := make([]*packageHandle, 0, len(s.packages))
+	for _, ph := range s.packages {
+		phs = append(phs, ph)
+	}
+	s.mu.Unlock()
+
+	var total int64
+	for _, ph := range phs {
+		if p, err := ph.cached(s.generation); err == nil && p != nil {
+			total += p.stat().total
+		}
+	}
+	return total
+}
+
 func (s *snapshot) initialize(ctx context.Context, firstAttempt bool) {
 	select {
 	case <-ctx.Done():
@@ -654,7 +730,12 @@ func (s *snapshot) loadWorkspace(ctx context.Context, firstAttempt bool) {
 	if len(scopes) > 0 {
 		scopes = append(scopes, PackagePath("builtin"))
 	}
-	err := s.load(ctx, firstAttempt, scopes...)
+	var err error
+	if s.view.Options().LazyTypeCheck {
+		err = s.loadInParallel(ctx, firstAttempt, scopes)
+	} else {
+		err = s.load(ctx, firstAttempt, scopes...)
+	}
 
 	// If the context is canceled on the first attempt, loading has failed
 	// because the go command has timed out--that should be a critical error.
@@ -688,12 +769,47 @@ func (s *snapshot) loadWorkspace(ctx context.Context, firstAttempt bool) {
 		}
 	}
 
+	// Persist the loaded workspace packages for the next session.
+	if err == nil && criticalErr == nil {
+		s.persistSymbolIndex(ctx)
+	}
+
 	// Lock the snapshot when setting the initialized error.
 	s.mu.Lock()
 	defer s.mu.Unlock()
 	s.initializedErr = criticalErr
 }
 
+// loadInParallel loads each of scopes with its own go command, in
+// parallel, so that the metadata of a workspace of several modules is
+// loaded sooner. It returns the first error of the loads.
+func (s *snapshot) loadInParallel(ctx context.Context, firstAttempt bool, scopes []interface{}) error {
+	if len(scopes) <= 2 { // a single scope, and builtin
+		return s.load(ctx, firstAttempt, scopes...)
+	}
+	var (
+		wg       sync.WaitGroup
+		errMu    sync.Mutex
+		firstErr error
+	)
+	for _, scope := range scopes {
+		scope := scope
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := s.load(ctx, firstAttempt, scope); err != nil {
+				errMu.Lock()
+				if firstErr == nil {
+					firstErr = err
+				}
+				errMu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+	return firstErr
+}
+
 // invalidateContent invalidates the content of a Go file,
 // including any position and type information that depends on it.
 //
@@ -720,12 +836,75 @@ func (v *View) invalidateContent(ctx context.Context, changes map[span.URI]*file
 
 	oldSnapshot := v.snapshot
 
-	v.snapshot = oldSnapshot.clone(ctx, v.baseCtx, changes, forceReloadMetadata)
+	v.snapshot = oldSnapshot.clone(ctx, v.baseCtx, changes, forceReloadMetadata, false)
 	go oldSnapshot.generation.Destroy("View.invalidateContent")
 
 	return v.snapshot, v.snapshot.generation.Acquire()
 }
 
+// recoverFromPanic handles the panic of a computation for a snapshot of v,
+// described by what, whose results are discarded. The first time the view
+// recovers from a panic of a given value at a given place, it drops the
+// results of its snapshot, which may depend on the state that the panic left
+// behind, and the session warns its client.
+func (v *View) recoverFromPanic(ctx context.Context, what string, value interface{}, stack []byte) {
+	ctx = xcontext.Detach(ctx)
+	err := fmt.Errorf("%s panicked: %v", what, value)
+	event.Error(ctx, "recovered from panic:\n"+string(stack), err, tag.Directory.Of(v.folder.Filename()))
+	if v.Options().Telemetry {
+		telemetry.Crash(value, stack)
+	}
+
+	v.panicMu.Lock()
+	// The same message may come from different bugs, so tell the panics
+	// apart by the top frames of their stacks too.
+	key := fmt.Sprint(value) + "\n" + telemetry.Signature(value, stack)
+	seen := v.panics[key]
+	if v.panics == nil {
+		v.panics = make(map[string]bool)
+	}
+	v.panics[key] = true
+	v.panicMu.Unlock()
+	if seen {
+		return
+	}
+	// The requests in flight on the snapshot are not canceled: they
+	// complete, or fail as the one that panicked did.
+	go v.dropPackages(ctx, false)
+	if handler := v.session.panicHandler; handler != nil {
+		handler(ctx, err)
+	}
+}
+
+// DropPackages replaces the snapshot of v by one without the type-checked
+// packages and analysis results of the current one, so that the memory they
+// hold can be reclaimed unless another view shares them. They are computed
+// again when they are needed.
+func (v *View) DropPackages(ctx context.Context) {
+	v.dropPackages(ctx, true)
+}
+
+// dropPackages implements DropPackages. It cancels the requests in flight on
+// the current snapshot if cancel is set.
+func (v *View) dropPackages(ctx context.Context, cancel bool) {
+	ctx = xcontext.Detach(ctx)
+
+	v.snapshotMu.Lock()
+	defer v.snapshotMu.Unlock()
+
+	if v.snapshot == nil {
+		return
+	}
+	if cancel {
+		v.snapshot.cancel()
+	}
+	v.snapshot.AwaitInitialized(ctx)
+
+	oldSnapshot := v.snapshot
+	v.snapshot = oldSnapshot.clone(ctx, v.baseCtx, nil, false, true)
+	go oldSnapshot.generation.Destroy("View.DropPackages")
+}
+
 func (s *Session) getWorkspaceInformation(ctx context.Context, folder span.URI, options *source.Options) (*workspaceInformation, error) {
 	if err := checkPathCase(folder.Filename()); err != nil {
 		return nil, fmt.Errorf("invalid workspace folder path: %w; check that the casing of the configured workspace folder path agrees with the casing reported by the operating system", err)
@@ -945,6 +1124,10 @@ func (v *View) IsGoPrivatePath(target string) bool {
 	return globsMatchPath(v.goprivate, target)
 }
 
+func (v *View) GoVersion() int {
+	return v.goversion
+}
+
 func (v *View) ModuleUpgrades() map[string]string {
 	v.mu.Lock()
 	defer v.mu.Unlock()
@@ -1041,14 +1224,13 @@ func (s *snapshot) vendorEnabled(ctx context.Context, modURI span.URI, modConten
 }
 
 func (v *View) allFilesExcluded(pkg *packages.Package) bool {
-	opts := v.Options()
 	folder := filepath.ToSlash(v.folder.Filename())
 	for _, f := range pkg.GoFiles {
 		f = filepath.ToSlash(f)
 		if !strings.HasPrefix(f, folder) {
 			return false
 		}
-		if !pathExcludedByFilter(strings.TrimPrefix(f, folder), v.rootURI.Filename(), v.gomodcache, opts) {
+		if !v.filterer.Disallow(strings.TrimPrefix(f, folder)) {
 			return false
 		}
 	}
@@ -1056,8 +1238,9 @@ func (v *View) allFilesExcluded(pkg *packages.Package) bool {
 }
 
 func pathExcludedByFilterFunc(root, gomodcache string, opts *source.Options) func(string) bool {
+	filterer := newFilterer(root, gomodcache, opts)
 	return func(path string) bool {
-		return pathExcludedByFilter(path, root, gomodcache, opts)
+		return filterer.Disallow(path)
 	}
 }
 
@@ -1068,11 +1251,16 @@ func pathExcludedByFilterFunc(root, gomodcache string, opts *source.Options) fun
 // path should be absolute or relative, and has already caused at least one
 // bug.
 func pathExcludedByFilter(path, root, gomodcache string, opts *source.Options) bool {
-	path = strings.TrimPrefix(filepath.ToSlash(path), "/")
+	return newFilterer(root, gomodcache, opts).Disallow(path)
+}
+
+// newFilterer returns the Filterer of the directory filters of opts, which
+// also excludes the module cache.
+func newFilterer(root, gomodcache string, opts *source.Options) *source.Filterer {
 	gomodcache = strings.TrimPrefix(filepath.ToSlash(strings.TrimPrefix(gomodcache, root)), "/")
 	filters := opts.DirectoryFilters
 	if gomodcache != "" {
-		filters = append(filters, "-"+gomodcache)
+		filters = append(filters[:len(filters):len(filters)], "-"+gomodcache)
 	}
-	return source.FiltersDisallow(path, filters)
+	return source.NewFilterer(filters)
 }
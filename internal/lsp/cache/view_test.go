@@ -9,6 +9,7 @@ import (
 	"os"
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/iansmith/golang-x-tools/internal/lsp/fake"
 	"github.com/iansmith/golang-x-tools/internal/lsp/source"
@@ -158,6 +159,21 @@ func TestFilters(t *testing.T) {
 			included: []string{"foobar", "foobar/a"},
 			excluded: []string{"foo", "foo/a"},
 		},
+		{
+			filters:  []string{"-**/node_modules"},
+			included: []string{"a", "a/node_modulesx", "node_modulesx"},
+			excluded: []string{"node_modules", "node_modules/a", "a/node_modules", "a/b/node_modules/c"},
+		},
+		{
+			filters:  []string{"-a/**/gen", "+a/b/gen"},
+			included: []string{"gen", "a", "a/b/gen", "a/b/gen/x"},
+			excluded: []string{"a/gen", "a/c/gen", "a/c/d/gen/x"},
+		},
+		{
+			filters:  []string{"-", "+**/api"},
+			included: []string{"api", "x/api", "x/y/api/z"},
+			excluded: []string{"x", "x/apix", "gopath/pkg/mod/x/api"},
+		},
 	}
 
 	for _, tt := range tests {
@@ -216,3 +232,60 @@ func TestSuffixes(t *testing.T) {
 		}
 	}
 }
+
+func TestRecoverFromPanic(t *testing.T) {
+	dir, err := ioutil.TempDir("", t.Name())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	for name, content := range map[string]string{
+		"go.mod": "module a\n\ngo 1.12\n",
+		"a.go":   "package a\n\nfunc A() {}\n",
+	} {
+		if err := ioutil.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	ctx := context.Background()
+	session := New(nil).NewSession(ctx)
+	var warnings []error
+	session.SetPanicHandler(func(_ context.Context, err error) {
+		warnings = append(warnings, err)
+	})
+	options := source.DefaultOptions().Clone()
+	options.Env = map[string]string{"GOPACKAGESDRIVER": "off"}
+	view, snapshot, release, err := session.NewView(ctx, "a", span.URIFromPath(dir), options)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer session.Shutdown(ctx)
+	if _, err := snapshot.KnownPackages(ctx); err != nil {
+		t.Fatal(err)
+	}
+	release()
+	v := view.(*View)
+	if info := v.SnapshotInfo(); len(info.Packages) == 0 {
+		t.Fatal("no packages before the panic")
+	}
+
+	// Only the first panic of a value at a place is warned of.
+	stack := func(fn string) []byte {
+		return []byte("goroutine 1 [running]:\npanic({0x0, 0x0})\n\t/go/src/runtime/panic.go:838 +0x207\n" +
+			"example.com/a." + fn + "()\n\t/a/a.go:10 +0x25\n")
+	}
+	v.recoverFromPanic(ctx, "checking a", "boom", stack("f"))
+	v.recoverFromPanic(ctx, "checking a again", "boom", stack("f"))
+	v.recoverFromPanic(ctx, "checking b", "boom", stack("g"))
+	if len(warnings) != 2 || warnings[0].Error() != "checking a panicked: boom" || warnings[1].Error() != "checking b panicked: boom" {
+		t.Errorf("got warnings %v, want one for checking a and one for checking b", warnings)
+	}
+	// The packages of the snapshot are dropped in the background.
+	deadline := time.Now().Add(10 * time.Second)
+	for len(v.SnapshotInfo().Packages) > 0 {
+		if time.Now().After(deadline) {
+			t.Fatal("the packages of the snapshot were not dropped")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
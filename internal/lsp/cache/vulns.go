@@ -0,0 +1,107 @@
+// Copyright 2022 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cache
+
+import (
+	"context"
+	"sort"
+
+	"golang.org/x/mod/module"
+	"github.com/iansmith/golang-x-tools/internal/lsp/command"
+)
+
+// Vulnerabilities returns the known vulnerabilities of the packages that
+// the package with the given ID imports, directly or indirectly, sorted by
+// package path and ID. The vulnerabilities of each module version are
+// looked up once per view, and the result for the package is reused until
+// its metadata, or that of one of its dependencies, changes.
+func (s *snapshot) Vulnerabilities(ctx context.Context, id string) ([]command.Vuln, error) {
+	s.mu.Lock()
+	vulns, ok := s.vulns[PackageID(id)]
+	s.mu.Unlock()
+	if ok {
+		return vulns, nil
+	}
+	lookup := s.view.Options().ModuleVulns
+	if lookup == nil {
+		return nil, nil
+	}
+
+	// Collect the packages of the dependencies, and the versions of their
+	// modules.
+	imported := make(map[string]bool)
+	mods := make(map[module.Version]bool)
+	seen := make(map[PackageID]bool)
+	var visit func(PackageID)
+	visit = func(id PackageID) {
+		if seen[id] {
+			return
+		}
+		seen[id] = true
+		m := s.getMetadata(id)
+		if m == nil {
+			return
+		}
+		if mod := m.Module; mod != nil && !mod.Main {
+			v := module.Version{Path: mod.Path, Version: mod.Version}
+			if mod.Replace != nil {
+				v.Version = mod.Replace.Version
+			}
+			// Modules replaced by directories have no version.
+			if v.Version != "" {
+				imported[string(m.PkgPath)] = true
+				mods[v] = true
+			}
+		}
+		for _, dep := range m.Deps {
+			visit(dep)
+		}
+	}
+	visit(PackageID(id))
+
+	vulns = []command.Vuln{}
+	for mod := range mods {
+		modVulns, err := s.view.moduleVulnerabilities(ctx, lookup, mod)
+		if err != nil {
+			return nil, err
+		}
+		for _, v := range modVulns {
+			if imported[v.PkgPath] {
+				vulns = append(vulns, v)
+			}
+		}
+	}
+	sort.Slice(vulns, func(i, j int) bool {
+		if vulns[i].PkgPath != vulns[j].PkgPath {
+			return vulns[i].PkgPath < vulns[j].PkgPath
+		}
+		return vulns[i].ID < vulns[j].ID
+	})
+
+	s.mu.Lock()
+	s.vulns[PackageID(id)] = vulns
+	s.mu.Unlock()
+	return vulns, nil
+}
+
+// moduleVulnerabilities returns the known vulnerabilities of the packages
+// of mod, looking them up with lookup the first time. Failed lookups are
+// retried.
+func (v *View) moduleVulnerabilities(ctx context.Context, lookup func(context.Context, []string, string, string) ([]command.Vuln, error), mod module.Version) ([]command.Vuln, error) {
+	v.mu.Lock()
+	vulns, ok := v.moduleVulns[mod]
+	v.mu.Unlock()
+	if ok {
+		return vulns, nil
+	}
+	vulns, err := lookup(ctx, v.Options().EnvSlice(), mod.Path, mod.Version)
+	if err != nil {
+		return nil, err
+	}
+	v.mu.Lock()
+	v.moduleVulns[mod] = vulns
+	v.mu.Unlock()
+	return vulns, nil
+}
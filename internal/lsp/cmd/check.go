@@ -6,30 +6,71 @@ package cmd
 
 import (
 	"context"
+	"encoding/json"
 	"flag"
 	"fmt"
+	"os"
+	"strings"
 
+	"github.com/iansmith/golang-x-tools/internal/lsp/protocol"
+	"github.com/iansmith/golang-x-tools/internal/lsp/source"
 	"github.com/iansmith/golang-x-tools/internal/span"
+	"github.com/iansmith/golang-x-tools/internal/tool"
 )
 
 // check implements the check verb for gopls.
 type check struct {
+	JSON     bool   `flag:"json" help:"print the diagnostics and their suggested fixes as JSON"`
+	Severity string `flag:"severity" help:"fail if a diagnostic is at least as severe as this one of error, warning, information, or hint"`
+	Enable   string `flag:"enable" help:"comma-separated list of analyzers to enable, as with the analyses setting"`
+	Disable  string `flag:"disable" help:"comma-separated list of analyzers to disable, as with the analyses setting"`
+
 	app *Application
 }
 
 func (c *check) Name() string      { return "check" }
 func (c *check) Parent() string    { return c.app.Name() }
-func (c *check) Usage() string     { return "<filename>" }
+func (c *check) Usage() string     { return "[check-flags] <filename>" }
 func (c *check) ShortHelp() string { return "show diagnostic results for the specified file" }
 func (c *check) DetailedHelp(f *flag.FlagSet) {
 	fmt.Fprint(f.Output(), `
 Example: show the diagnostic results of this file:
 
 	$ gopls check internal/lsp/cmd/check.go
+
+Example: in CI, print the diagnostics as JSON, without those of the
+unusedparams analyzer but with those of the shadow analyzer, and fail if
+any of them is a warning or an error:
+
+	$ gopls check -json -severity=warning -disable=unusedparams -enable=shadow main.go
+
+check-flags:
 `)
 	printFlagDefaults(f)
 }
 
+// A checkDiagnostic is a diagnostic printed by check -json.
+type checkDiagnostic struct {
+	Span           string     `json:"span"`
+	Severity       string     `json:"severity"`
+	Source         string     `json:"source"` // the analyzer, for analysis diagnostics
+	Code           string     `json:"code,omitempty"`
+	Message        string     `json:"message"`
+	SuggestedFixes []checkFix `json:"suggestedFixes,omitempty"`
+}
+
+// A checkFix is a suggested fix of a diagnostic printed by check -json:
+// the edits of the file of the diagnostic that fix it.
+type checkFix struct {
+	Title string      `json:"title"`
+	Edits []checkEdit `json:"edits"`
+}
+
+type checkEdit struct {
+	Span    string `json:"span"`
+	NewText string `json:"newText"`
+}
+
 // Run performs the check on the files specified by args and prints the
 // results to stdout.
 func (c *check) Run(ctx context.Context, args ...string) error {
@@ -37,6 +78,23 @@ func (c *check) Run(ctx context.Context, args ...string) error {
 		// no files, so no results
 		return nil
 	}
+	var threshold protocol.DiagnosticSeverity
+	if c.Severity != "" {
+		for _, s := range []protocol.DiagnosticSeverity{protocol.SeverityError, protocol.SeverityWarning, protocol.SeverityInformation, protocol.SeverityHint} {
+			if strings.EqualFold(fmt.Sprint(s), c.Severity) {
+				threshold = s
+			}
+		}
+		if threshold == 0 {
+			return tool.CommandLineErrorf("unknown severity %q: want error, warning, information, or hint", c.Severity)
+		}
+	}
+	analyses, err := c.analyses()
+	if err != nil {
+		return err
+	}
+	c.app.analyses = analyses
+
 	checking := map[span.URI]*cmdFile{}
 	var uris []span.URI
 	// now we ready to kick things off
@@ -47,6 +105,9 @@ func (c *check) Run(ctx context.Context, args ...string) error {
 	defer conn.terminate(ctx)
 	for _, arg := range args {
 		uri := span.URIFromPath(arg)
+		if checking[uri] != nil {
+			continue
+		}
 		uris = append(uris, uri)
 		file := conn.AddFile(ctx, uri)
 		if file.err != nil {
@@ -57,17 +118,138 @@ func (c *check) Run(ctx context.Context, args ...string) error {
 	if err := conn.diagnoseFiles(ctx, uris); err != nil {
 		return err
 	}
+	// Copy the diagnostics, so that the lock is not held while the
+	// suggested fixes are requested.
 	conn.Client.filesMu.Lock()
-	defer conn.Client.filesMu.Unlock()
+	diagnostics := make(map[span.URI][]protocol.Diagnostic)
+	for uri, file := range checking {
+		diagnostics[uri] = append([]protocol.Diagnostic(nil), file.diagnostics...)
+	}
+	conn.Client.filesMu.Unlock()
 
-	for _, file := range checking {
-		for _, d := range file.diagnostics {
+	failed := 0
+	results := []checkDiagnostic{}
+	for _, uri := range uris {
+		file := checking[uri]
+		var fixes map[checkKey][]checkFix
+		if c.JSON {
+			if fixes, err = c.suggestedFixes(ctx, conn, file, diagnostics[uri]); err != nil {
+				return err
+			}
+		}
+		for _, d := range diagnostics[uri] {
 			spn, err := file.mapper.RangeSpan(d.Range)
 			if err != nil {
 				return fmt.Errorf("Could not convert position %v for %q", d.Range, d.Message)
 			}
-			fmt.Printf("%v: %v\n", spn, d.Message)
+			if threshold != 0 && d.Severity <= threshold {
+				failed++
+			}
+			if !c.JSON {
+				fmt.Printf("%v: %v\n", spn, d.Message)
+				continue
+			}
+			code, _ := d.Code.(string)
+			results = append(results, checkDiagnostic{
+				Span:           fmt.Sprint(spn),
+				Severity:       strings.ToLower(fmt.Sprint(d.Severity)),
+				Source:         d.Source,
+				Code:           code,
+				Message:        d.Message,
+				SuggestedFixes: fixes[checkKey{d.Range, d.Message}],
+			})
+		}
+	}
+	if c.JSON {
+		data, err := json.MarshalIndent(results, "", "\t")
+		if err != nil {
+			return err
 		}
+		fmt.Fprintf(os.Stdout, "%s\n", data)
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d diagnostics are at least as severe as %s", failed, strings.ToLower(c.Severity))
 	}
 	return nil
 }
+
+// analyses returns the value of the analyses setting that enables the
+// analyzers of the -enable flag and disables those of the -disable flag.
+func (c *check) analyses() (map[string]bool, error) {
+	opts := source.DefaultOptions().Clone()
+	if c.app.options != nil {
+		c.app.options(opts)
+	}
+	known := func(name string) bool {
+		for _, analyzers := range []map[string]*source.Analyzer{opts.DefaultAnalyzers, opts.TypeErrorAnalyzers, opts.ConvenienceAnalyzers, opts.StaticcheckAnalyzers} {
+			if _, ok := analyzers[name]; ok {
+				return true
+			}
+		}
+		return false
+	}
+	analyses := make(map[string]bool)
+	for _, flag := range []struct {
+		names   string
+		enabled bool
+	}{{c.Enable, true}, {c.Disable, false}} {
+		for _, name := range strings.Split(flag.names, ",") {
+			if name = strings.TrimSpace(name); name == "" {
+				continue
+			}
+			if !known(name) {
+				return nil, tool.CommandLineErrorf("unknown analyzer %q", name)
+			}
+			analyses[name] = flag.enabled
+		}
+	}
+	return analyses, nil
+}
+
+// A checkKey identifies a diagnostic of a file.
+type checkKey struct {
+	rng     protocol.Range
+	message string
+}
+
+// suggestedFixes returns the quick fixes of the diagnostics of file, by
+// the diagnostics that they fix.
+func (c *check) suggestedFixes(ctx context.Context, conn *connection, file *cmdFile, diagnostics []protocol.Diagnostic) (map[checkKey][]checkFix, error) {
+	if len(diagnostics) == 0 {
+		return nil, nil
+	}
+	actions, err := conn.CodeAction(ctx, &protocol.CodeActionParams{
+		TextDocument: protocol.TextDocumentIdentifier{URI: protocol.URIFromSpanURI(file.uri)},
+		Context: protocol.CodeActionContext{
+			Only:        []protocol.CodeActionKind{protocol.QuickFix},
+			Diagnostics: diagnostics,
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%v: %v", file.uri.Filename(), err)
+	}
+	fixes := make(map[checkKey][]checkFix)
+	for _, a := range actions {
+		fix := checkFix{Title: a.Title, Edits: []checkEdit{}}
+		for _, change := range a.Edit.DocumentChanges {
+			if change.TextDocumentEdit == nil || fileURI(change.TextDocumentEdit.TextDocument.URI) != file.uri {
+				continue
+			}
+			for _, edit := range protocol.AsTextEdits(change.TextDocumentEdit.Edits) {
+				spn, err := file.mapper.RangeSpan(edit.Range)
+				if err != nil {
+					return nil, err
+				}
+				fix.Edits = append(fix.Edits, checkEdit{Span: fmt.Sprint(spn), NewText: edit.NewText})
+			}
+		}
+		if len(fix.Edits) == 0 {
+			continue
+		}
+		for _, d := range a.Diagnostics {
+			key := checkKey{d.Range, d.Message}
+			fixes[key] = append(fixes[key], fix)
+		}
+	}
+	return fixes, nil
+}
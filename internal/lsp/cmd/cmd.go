@@ -50,6 +50,10 @@ type Application struct {
 	// the options configuring function to invoke when building a server
 	options func(*source.Options)
 
+	// analyses overrides the analyses setting of the server, for the
+	// analyzer flags of the check verb.
+	analyses map[string]bool
+
 	// The name of the binary, used in help and telemetry.
 	name string
 
@@ -255,11 +259,14 @@ func (app *Application) featureCommands() []tool.Application {
 	return []tool.Application{
 		&callHierarchy{app: app},
 		&check{app: app},
+		newDaemon(app),
 		&definition{app: app},
+		&fixAll{app: app},
 		&foldingRanges{app: app},
 		&format{app: app},
 		&highlight{app: app},
 		&implementation{app: app},
+		&implementStubs{app: app},
 		&imports{app: app},
 		newRemote(app, ""),
 		newRemote(app, "inspect"),
@@ -267,10 +274,13 @@ func (app *Application) featureCommands() []tool.Application {
 		&prepareRename{app: app},
 		&references{app: app},
 		&rename{app: app},
+		&sbom{app: app},
 		&semtok{app: app},
 		&signature{app: app},
+		&stats{app: app},
 		&suggestedFix{app: app},
 		&symbols{app: app},
+		newTelemetry(app),
 		newWorkspace(app),
 		&workspaceSymbol{app: app},
 		&vulncheck{app: app},
@@ -296,7 +306,7 @@ func (app *Application) connect(ctx context.Context) (*connection, error) {
 		if app.options != nil {
 			app.options(opts)
 		}
-		key := fmt.Sprintf("%s %v %v %v", app.wd, opts.PreferredContentFormat, opts.HierarchicalDocumentSymbolSupport, opts.SymbolMatcher)
+		key := fmt.Sprintf("%s %v %v %v %v %v", app.wd, opts.PreferredContentFormat, opts.HierarchicalDocumentSymbolSupport, opts.SymbolMatcher, opts.SymbolStyle, app.analyses)
 		if c := internalConnections[key]; c != nil {
 			return c, nil
 		}
@@ -366,8 +376,13 @@ func (c *connection) initialize(ctx context.Context, options func(*source.Option
 	params.Capabilities.TextDocument.SemanticTokens.Requests.Full = true
 	params.Capabilities.TextDocument.SemanticTokens.TokenTypes = lsp.SemanticTypes()
 	params.Capabilities.TextDocument.SemanticTokens.TokenModifiers = lsp.SemanticModifiers()
+	// The command line tool doesn't watch files, but its sessions are too
+	// short-lived for gopls to watch them on its behalf: claim to support the
+	// registration of watched files, and ignore it.
+	params.Capabilities.Workspace.DidChangeWatchedFiles.DynamicRegistration = true
 	params.InitializationOptions = map[string]interface{}{
 		"symbolMatcher": matcherString[opts.SymbolMatcher],
+		"symbolStyle":   string(opts.SymbolStyle),
 	}
 	if _, err := c.Server.Initialize(ctx, params); err != nil {
 		return err
@@ -478,14 +493,18 @@ func (c *cmdClient) Configuration(ctx context.Context, p *protocol.ParamConfigur
 			}
 			env[l[0]] = l[1]
 		}
+		analyses := map[string]bool{
+			"fillreturns":    true,
+			"nonewvars":      true,
+			"noresultvalues": true,
+			"undeclaredname": true,
+		}
+		for name, enabled := range c.app.analyses {
+			analyses[name] = enabled
+		}
 		m := map[string]interface{}{
-			"env": env,
-			"analyses": map[string]bool{
-				"fillreturns":    true,
-				"nonewvars":      true,
-				"noresultvalues": true,
-				"undeclaredname": true,
-			},
+			"env":      env,
+			"analyses": analyses,
 		}
 		if c.app.VeryVerbose {
 			m["verboseOutput"] = true
@@ -584,6 +603,49 @@ func (c *connection) AddFile(ctx context.Context, uri span.URI) *cmdFile {
 	return file
 }
 
+// openContent opens the file with the given URI on the server with
+// content instead of its content on disk, if any, until the returned
+// function closes it.
+func (c *connection) openContent(ctx context.Context, uri span.URI, content []byte) (*cmdFile, func(), error) {
+	c.Client.filesMu.Lock()
+	defer c.Client.filesMu.Unlock()
+
+	id := protocol.TextDocumentIdentifier{URI: protocol.URIFromSpanURI(uri)}
+	if file := c.Client.files[uri]; file != nil && file.added {
+		if err := c.Server.DidClose(ctx, &protocol.DidCloseTextDocumentParams{TextDocument: id}); err != nil {
+			return nil, nil, fmt.Errorf("%v: %v", uri, err)
+		}
+	}
+	f := c.Client.fset.AddFile(uri.Filename(), -1, len(content))
+	f.SetLinesForContent(content)
+	file := &cmdFile{
+		uri: uri,
+		mapper: &protocol.ColumnMapper{
+			URI:     uri,
+			TokFile: f,
+			Content: content,
+		},
+		added: true,
+	}
+	// The file is not recorded, for it to be read again from disk by
+	// AddFile once closed.
+	delete(c.Client.files, uri)
+	p := &protocol.DidOpenTextDocumentParams{
+		TextDocument: protocol.TextDocumentItem{
+			URI:        id.URI,
+			LanguageID: "go",
+			Version:    1,
+			Text:       string(content),
+		},
+	}
+	if err := c.Server.DidOpen(ctx, p); err != nil {
+		return nil, nil, fmt.Errorf("%v: %v", uri, err)
+	}
+	return file, func() {
+		c.Server.DidClose(ctx, &protocol.DidCloseTextDocumentParams{TextDocument: id})
+	}, nil
+}
+
 func (c *connection) semanticTokens(ctx context.Context, p *protocol.SemanticTokensRangeParams) (*protocol.SemanticTokens, error) {
 	// use range to avoid limits on full
 	resp, err := c.Server.SemanticTokensRange(ctx, p)
@@ -612,6 +674,63 @@ func (c *connection) diagnoseFiles(ctx context.Context, files []span.URI) error
 	return nil
 }
 
+// qualifySymbols makes the workspace symbols of the connections of app
+// fully qualified and matched case-sensitively, for locate to resolve
+// symbol paths.
+func (app *Application) qualifySymbols() {
+	options := app.options
+	app.options = func(o *source.Options) {
+		if options != nil {
+			options(o)
+		}
+		o.SymbolStyle = source.FullyQualifiedSymbols
+		o.SymbolMatcher = source.SymbolCaseSensitive
+	}
+}
+
+// locate returns the file and the location of arg: a position, or the
+// path of a symbol of the workspace such as example.com/pkg.Type.Method,
+// from which leading path elements of the package may be omitted. The
+// connection must be one of an application whose symbols are qualified.
+func (c *connection) locate(ctx context.Context, arg string) (*cmdFile, protocol.Location, error) {
+	if from := span.Parse(arg); from.HasPosition() {
+		if _, err := os.Stat(from.URI().Filename()); err == nil {
+			file := c.AddFile(ctx, from.URI())
+			if file.err != nil {
+				return nil, protocol.Location{}, file.err
+			}
+			loc, err := file.mapper.Location(from)
+			return file, loc, err
+		}
+	}
+	symbols, err := c.Symbol(ctx, &protocol.WorkspaceSymbolParams{Query: arg})
+	if err != nil {
+		return nil, protocol.Location{}, err
+	}
+	var found []protocol.SymbolInformation
+	for _, s := range symbols {
+		if s.Name == arg || strings.HasSuffix(s.Name, "/"+arg) {
+			found = append(found, s)
+		}
+	}
+	switch len(found) {
+	case 0:
+		return nil, protocol.Location{}, fmt.Errorf("no symbol %s in the workspace", arg)
+	case 1:
+	default:
+		var names []string
+		for _, s := range found {
+			names = append(names, s.Name)
+		}
+		return nil, protocol.Location{}, fmt.Errorf("ambiguous symbol %s: %s", arg, strings.Join(names, ", "))
+	}
+	file := c.AddFile(ctx, fileURI(found[0].Location.URI))
+	if file.err != nil {
+		return nil, protocol.Location{}, file.err
+	}
+	return file, found[0].Location, nil
+}
+
 func (c *connection) terminate(ctx context.Context) {
 	if strings.HasPrefix(c.Client.app.Remote, "internal@") {
 		// internal connections need to be left alive for the next test
@@ -0,0 +1,216 @@
+// Copyright 2022 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/iansmith/golang-x-tools/internal/lsp/lsprpc"
+	"github.com/iansmith/golang-x-tools/internal/tool"
+)
+
+// daemon is a top-level command for managing the lifecycle of the gopls
+// daemon shared by the editors started with -remote.
+type daemon struct {
+	app *Application
+	subcommands
+}
+
+func newDaemon(app *Application) *daemon {
+	return &daemon{
+		app: app,
+		subcommands: subcommands{
+			&daemonStatus{app: app},
+			&daemonStop{app: app},
+			&daemonStop{app: app, restart: true},
+		},
+	}
+}
+
+func (d *daemon) Name() string   { return "daemon" }
+func (d *daemon) Parent() string { return d.app.Name() }
+func (d *daemon) ShortHelp() string {
+	return "print the status of the gopls daemon, or stop or restart it"
+}
+
+// remoteAddr returns the address of the daemon: that of -remote, or else
+// that of the default daemon.
+func (app *Application) remoteAddr() string {
+	if app.Remote == "" {
+		return "auto"
+	}
+	return app.Remote
+}
+
+// daemonStatus prints the state of the daemon and of its sessions.
+type daemonStatus struct {
+	app *Application
+
+	JSON bool `flag:"json" help:"print the status in JSON format"`
+}
+
+func (c *daemonStatus) Name() string  { return "status" }
+func (c *daemonStatus) Usage() string { return "[status-flags]" }
+func (c *daemonStatus) ShortHelp() string {
+	return "print the sessions, workspaces and memory use of the daemon"
+}
+
+func (c *daemonStatus) DetailedHelp(f *flag.FlagSet) {
+	fmt.Fprint(f.Output(), `
+Prints the process, command line and memory use of the gopls daemon, and for
+each of its sessions the workspace folders and the number of calls that it
+has not answered yet. The memory is that of the whole daemon, as the sessions
+share its cache.
+
+Examples:
+
+$ gopls daemon status
+$ gopls -remote=localhost:8082 daemon status -json
+
+status-flags:
+`)
+	printFlagDefaults(f)
+}
+
+func (c *daemonStatus) Run(ctx context.Context, args ...string) error {
+	if len(args) > 0 {
+		return tool.CommandLineErrorf("status does not take arguments, got %v", args)
+	}
+	state, err := lsprpc.QueryServerState(ctx, c.app.remoteAddr())
+	if err != nil {
+		return err
+	}
+	// The session of this query is not that of an editor.
+	var clients []lsprpc.ClientSession
+	for _, client := range state.Clients {
+		if client.SessionID != state.CurrentClientID {
+			clients = append(clients, client)
+		}
+	}
+	state.Clients = clients
+	if c.JSON {
+		v, err := json.MarshalIndent(state, "", "\t")
+		if err != nil {
+			return err
+		}
+		os.Stdout.Write(v)
+		return nil
+	}
+	const mb = 1 << 20
+	fmt.Printf("pid: %d\n", state.PID)
+	fmt.Printf("command: %s\n", strings.Join(state.Args, " "))
+	fmt.Printf("started: %s (up %v)\n", state.Started.Format(time.RFC3339), time.Since(state.Started).Round(time.Second))
+	fmt.Printf("memory: %.1f MB of heap, %.1f MB obtained from the system\n", float64(state.HeapAlloc)/mb, float64(state.Sys)/mb)
+	if state.Logfile != "" {
+		fmt.Printf("logfile: %s\n", state.Logfile)
+	}
+	if state.DebugAddr != "" {
+		fmt.Printf("debug: %s\n", state.DebugAddr)
+	}
+	fmt.Printf("sessions: %d\n", len(state.Clients))
+	if len(state.Clients) == 0 {
+		return nil
+	}
+	w := tabwriter.NewWriter(os.Stdout, 0, 8, 2, ' ', 0)
+	fmt.Fprintln(w, "session\tpending\tfolders")
+	for _, client := range state.Clients {
+		fmt.Fprintf(w, "%s\t%d\t%s\n", client.SessionID, client.Pending, strings.Join(client.Folders, " "))
+	}
+	return w.Flush()
+}
+
+// daemonStop stops the daemon, or restarts it.
+type daemonStop struct {
+	app     *Application
+	restart bool
+
+	Timeout time.Duration `flag:"timeout" help:"how long to wait for the daemon to answer the pending calls of its sessions"`
+}
+
+func (c *daemonStop) Name() string {
+	if c.restart {
+		return "restart"
+	}
+	return "stop"
+}
+
+func (c *daemonStop) Usage() string { return fmt.Sprintf("[%s-flags]", c.Name()) }
+
+func (c *daemonStop) ShortHelp() string {
+	if c.restart {
+		return "restart the daemon with the same command line"
+	}
+	return "stop the daemon"
+}
+
+func (c *daemonStop) DetailedHelp(f *flag.FlagSet) {
+	fmt.Fprintf(f.Output(), `
+Asks the gopls daemon to stop once it has answered the calls pending in its
+sessions, or the timeout elapses. The editors then lose their connection to the
+daemon, and those that restart gopls with -remote=auto connect to a new one.
+Restart starts the new daemon, with the same command line, as soon as the old
+one stops listening.
+
+Examples:
+
+$ gopls daemon %[1]s
+$ gopls -remote=localhost:8082 daemon %[1]s -timeout=30s
+
+%[1]s-flags:
+`, c.Name())
+	printFlagDefaults(f)
+}
+
+func (c *daemonStop) Run(ctx context.Context, args ...string) error {
+	if len(args) > 0 {
+		return tool.CommandLineErrorf("%s does not take arguments, got %v", c.Name(), args)
+	}
+	if c.Timeout == 0 {
+		c.Timeout = 10 * time.Second
+	}
+	addr := c.app.remoteAddr()
+	var oldPID int
+	if c.restart {
+		state, err := lsprpc.QueryServerState(ctx, addr)
+		if err != nil {
+			return err
+		}
+		oldPID = state.PID
+	}
+	pending, err := lsprpc.ShutdownServer(ctx, addr, c.Timeout, c.restart)
+	if err != nil {
+		return err
+	}
+	if pending > 0 {
+		fmt.Fprintf(os.Stderr, "gopls: %d calls were still pending after %v\n", pending, c.Timeout)
+	}
+	if !c.restart {
+		return nil
+	}
+	// Wait for the new daemon to answer at the same address.
+	const (
+		startTimeout = 10 * time.Second
+		poll         = 100 * time.Millisecond
+	)
+	deadline := time.Now().Add(startTimeout)
+	for {
+		state, err := lsprpc.QueryServerState(ctx, addr)
+		if err == nil && state.PID != oldPID {
+			fmt.Printf("restarted gopls daemon: pid %d\n", state.PID)
+			return nil
+		}
+		if time.Now().After(deadline) {
+			return fmt.Errorf("the new gopls daemon did not start within %v", startTimeout)
+		}
+		time.Sleep(poll)
+	}
+}
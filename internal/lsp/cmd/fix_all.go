@@ -0,0 +1,237 @@
+// Copyright 2022 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cmd
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/iansmith/golang-x-tools/internal/lsp/diff"
+	"github.com/iansmith/golang-x-tools/internal/lsp/protocol"
+	"github.com/iansmith/golang-x-tools/internal/lsp/source"
+	"github.com/iansmith/golang-x-tools/internal/span"
+	"github.com/iansmith/golang-x-tools/internal/tool"
+)
+
+// fixAll implements the fix-all verb for gopls.
+type fixAll struct {
+	Diff bool `flag:"d,diff" help:"display diffs instead of rewriting files"`
+
+	app *Application
+}
+
+func (f *fixAll) Name() string   { return "fix-all" }
+func (f *fixAll) Parent() string { return f.app.Name() }
+func (f *fixAll) Usage() string  { return "[fix-all-flags] [<dir>]" }
+func (f *fixAll) ShortHelp() string {
+	return "apply the suggested fixes of all the diagnostics of the workspace"
+}
+func (f *fixAll) DetailedHelp(fs *flag.FlagSet) {
+	fmt.Fprint(fs.Output(), `
+Applies to the Go files of the workspace, by default that of the current
+directory, the quick fix of each of their diagnostics: the preferred one,
+or else the first one. Fixes that conflict with one already applied, or
+that need an editor to compute their edits, are skipped.
+
+Example: print the diffs of the fixes of this module:
+
+	$ gopls fix-all -d
+
+fix-all-flags:
+`)
+	printFlagDefaults(fs)
+}
+
+func (f *fixAll) Run(ctx context.Context, args ...string) error {
+	if len(args) > 1 {
+		return tool.CommandLineErrorf("fix-all accepts at most one directory")
+	}
+	dir := f.app.wd
+	if len(args) == 1 {
+		var err error
+		if dir, err = filepath.Abs(args[0]); err != nil {
+			return err
+		}
+		f.app.wd = dir
+	}
+	uris, err := goFiles(dir)
+	if err != nil {
+		return err
+	}
+
+	conn, err := f.app.connect(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.terminate(ctx)
+	files := make(map[span.URI]*cmdFile)
+	for _, uri := range uris {
+		file := conn.AddFile(ctx, uri)
+		if file.err != nil {
+			return file.err
+		}
+		files[uri] = file
+	}
+	if err := conn.diagnoseFiles(ctx, uris); err != nil {
+		return err
+	}
+	conn.Client.filesMu.Lock()
+	diagnostics := make(map[span.URI][]protocol.Diagnostic)
+	for uri, file := range files {
+		diagnostics[uri] = append([]protocol.Diagnostic(nil), file.diagnostics...)
+	}
+	conn.Client.filesMu.Unlock()
+
+	// The accepted edits, by file.
+	edits := make(map[span.URI][]protocol.TextEdit)
+	for _, uri := range uris {
+		if len(diagnostics[uri]) == 0 {
+			continue
+		}
+		actions, err := conn.CodeAction(ctx, &protocol.CodeActionParams{
+			TextDocument: protocol.TextDocumentIdentifier{URI: protocol.URIFromSpanURI(uri)},
+			Context: protocol.CodeActionContext{
+				Only:        []protocol.CodeActionKind{protocol.QuickFix},
+				Diagnostics: diagnostics[uri],
+			},
+		})
+		if err != nil {
+			return fmt.Errorf("%v: %v", uri.Filename(), err)
+		}
+		for _, a := range chooseFixes(actions) {
+			// Fixes computed by a command edit the files through the
+			// client, which the command line does not support.
+			if a.Command != nil {
+				continue
+			}
+			changes := make(map[span.URI][]protocol.TextEdit)
+			for _, c := range a.Edit.DocumentChanges {
+				if c.TextDocumentEdit != nil {
+					uri := fileURI(c.TextDocumentEdit.TextDocument.URI)
+					changes[uri] = append(changes[uri], protocol.AsTextEdits(c.TextDocumentEdit.Edits)...)
+				}
+			}
+			if conflicts(edits, changes) {
+				continue
+			}
+			for uri, change := range changes {
+				edits[uri] = append(edits[uri], change...)
+			}
+		}
+	}
+
+	var fixed []span.URI
+	for uri := range edits {
+		fixed = append(fixed, uri)
+	}
+	sort.Slice(fixed, func(i, j int) bool { return fixed[i] < fixed[j] })
+	var writes []source.FileWrite
+	for _, uri := range fixed {
+		file := files[uri]
+		if file == nil {
+			// A fix may edit a file outside of the directory.
+			if file = conn.AddFile(ctx, uri); file.err != nil {
+				return file.err
+			}
+		}
+		sedits, newContent, err := applyFileEdits(file, edits[uri])
+		if err != nil {
+			return fmt.Errorf("%v: %v", edits[uri], err)
+		}
+		filename := uri.Filename()
+		if f.Diff {
+			fmt.Print(diff.ToUnified(filename+".orig", filename, string(file.mapper.Content), sedits))
+			continue
+		}
+		writes = append(writes, source.FileWrite{URI: uri, Content: []byte(newContent)})
+	}
+	// No file is modified if one of them can't be.
+	return source.WriteFiles(writes, "")
+}
+
+// chooseFixes returns, in order, the preferred quick fix of each of the
+// diagnostics of actions, or else its first one.
+func chooseFixes(actions []protocol.CodeAction) []*protocol.CodeAction {
+	type diagKey struct {
+		rng     protocol.Range
+		message string
+	}
+	chosen := make(map[diagKey]int)
+	for i, a := range actions {
+		for _, d := range a.Diagnostics {
+			key := diagKey{d.Range, d.Message}
+			if j, ok := chosen[key]; !ok || a.IsPreferred && !actions[j].IsPreferred {
+				chosen[key] = i
+			}
+		}
+	}
+	var indexes []int
+	seen := make(map[int]bool)
+	for _, i := range chosen {
+		if !seen[i] {
+			seen[i] = true
+			indexes = append(indexes, i)
+		}
+	}
+	sort.Ints(indexes)
+	var result []*protocol.CodeAction
+	for _, i := range indexes {
+		result = append(result, &actions[i])
+	}
+	return result
+}
+
+// conflicts reports whether any of the edits of changes overlaps one of
+// edits for the same file.
+func conflicts(edits, changes map[span.URI][]protocol.TextEdit) bool {
+	for uri, change := range changes {
+		for _, c := range change {
+			for _, e := range edits[uri] {
+				// Insertions at the same position conflict too, as their
+				// order is undefined.
+				if protocol.ComparePosition(c.Range.Start, e.Range.End) < 0 && protocol.ComparePosition(e.Range.Start, c.Range.End) < 0 ||
+					c.Range.Start == e.Range.Start {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+// goFiles returns the Go files of the packages in and below dir, skipping,
+// as the go command does for dir/..., the directories of other modules
+// and those whose name is testdata or vendor or starts with . or _.
+func goFiles(dir string) ([]span.URI, error) {
+	var uris []span.URI
+	err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() {
+			if path == dir {
+				return nil
+			}
+			name := info.Name()
+			if name == "testdata" || name == "vendor" || strings.HasPrefix(name, ".") || strings.HasPrefix(name, "_") {
+				return filepath.SkipDir
+			}
+			if _, err := os.Stat(filepath.Join(path, "go.mod")); err == nil {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if strings.HasSuffix(path, ".go") {
+			uris = append(uris, span.URIFromPath(path))
+		}
+		return nil
+	})
+	return uris, err
+}
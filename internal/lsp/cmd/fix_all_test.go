@@ -0,0 +1,70 @@
+// Copyright 2022 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cmd
+
+import (
+	"context"
+	"flag"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/iansmith/golang-x-tools/internal/tool"
+)
+
+// TestFixAll checks that fix-all applies the fixes of the diagnostics of
+// all the packages of a module, but not those of testdata.
+func TestFixAll(t *testing.T) {
+	const (
+		lit   = "package a\n\ntype T struct{ X int }\n\nvar _ = []T{T{X: 1}, T{X: 2}}\n"
+		fixed = "package a\n\ntype T struct{ X int }\n\nvar _ = []T{{X: 1}, {X: 2}}\n"
+	)
+	tmpDir := writeModule(t, map[string]string{
+		"go.mod":          "module fixall\n\ngo 1.12\n",
+		"a.go":            lit,
+		"b/b.go":          "package b\n\nfunc F(x int) {\n\tx = x\n}\n",
+		"testdata/c/c.go": lit,
+	})
+	defer os.RemoveAll(tmpDir)
+
+	app := New("gopls-test", tmpDir, os.Environ(), nil)
+	if err := tool.Run(context.Background(), flag.NewFlagSet("gopls", flag.ContinueOnError), app, []string{"fix-all", tmpDir}); err != nil {
+		t.Fatal(err)
+	}
+	for name, want := range map[string]string{
+		"a.go":            fixed,
+		"b/b.go":          "package b\n\nfunc F(x int) {\n\t\n}\n",
+		"testdata/c/c.go": lit,
+	} {
+		got, err := ioutil.ReadFile(filepath.Join(tmpDir, filepath.FromSlash(name)))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(got) != want {
+			t.Errorf("%s after fix-all:\n%s\nwant:\n%s", name, got, want)
+		}
+	}
+}
+
+// writeModule writes files, by slash-separated name, to a new temporary
+// directory, and returns it.
+func writeModule(t *testing.T, files map[string]string) string {
+	t.Helper()
+	tmpDir, err := ioutil.TempDir("", "gopls-cmd")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for name, content := range files {
+		filename := filepath.Join(tmpDir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(filename), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := ioutil.WriteFile(filename, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return tmpDir
+}
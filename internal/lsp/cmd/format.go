@@ -8,7 +8,6 @@ import (
 	"context"
 	"flag"
 	"fmt"
-	"io/ioutil"
 
 	"github.com/iansmith/golang-x-tools/internal/lsp/diff"
 	"github.com/iansmith/golang-x-tools/internal/lsp/protocol"
@@ -55,6 +54,7 @@ func (c *format) Run(ctx context.Context, args ...string) error {
 		return err
 	}
 	defer conn.terminate(ctx)
+	var writes []source.FileWrite
 	for _, arg := range args {
 		spn := span.Parse(arg)
 		file := conn.AddFile(ctx, spn.URI())
@@ -76,11 +76,10 @@ func (c *format) Run(ctx context.Context, args ...string) error {
 		if err != nil {
 			return fmt.Errorf("%v: %v", spn, err)
 		}
-		sedits, err := source.FromProtocolEdits(file.mapper, edits)
+		sedits, formatted, err := applyFileEdits(file, edits)
 		if err != nil {
 			return fmt.Errorf("%v: %v", spn, err)
 		}
-		formatted := diff.ApplyEdits(string(file.mapper.Content), sedits)
 		printIt := true
 		if c.List {
 			printIt = false
@@ -91,7 +90,7 @@ func (c *format) Run(ctx context.Context, args ...string) error {
 		if c.Write {
 			printIt = false
 			if len(edits) > 0 {
-				ioutil.WriteFile(filename, []byte(formatted), 0644)
+				writes = append(writes, source.FileWrite{URI: spn.URI(), Content: []byte(formatted)})
 			}
 		}
 		if c.Diff {
@@ -103,5 +102,5 @@ func (c *format) Run(ctx context.Context, args ...string) error {
 			fmt.Print(formatted)
 		}
 	}
-	return nil
+	return source.WriteFiles(writes, "")
 }
@@ -0,0 +1,62 @@
+// Copyright 2022 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cmd
+
+import (
+	"context"
+	"flag"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/iansmith/golang-x-tools/internal/tool"
+)
+
+// TestFormatWrite checks that format -w keeps the line endings, byte order
+// mark and permissions of the files, and modifies none of them if one is
+// read-only.
+func TestFormatWrite(t *testing.T) {
+	tmpDir := writeModule(t, map[string]string{
+		"go.mod": "module format\n\ngo 1.12\n",
+		"a.go":   "\uFEFFpackage a\r\n\r\nvar  x = 1\r\n",
+		"b.go":   "package a\n\nvar  y = 1\n",
+	})
+	defer os.RemoveAll(tmpDir)
+	a, b := filepath.Join(tmpDir, "a.go"), filepath.Join(tmpDir, "b.go")
+	if err := os.Chmod(a, 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chmod(b, 0444); err != nil {
+		t.Fatal(err)
+	}
+
+	app := New("gopls-test", tmpDir, os.Environ(), nil)
+	if err := tool.Run(context.Background(), flag.NewFlagSet("gopls", flag.ContinueOnError), app, []string{"format", "-w", a, b}); err == nil {
+		t.Fatal("format -w of a read-only file succeeded")
+	}
+	if got, _ := ioutil.ReadFile(a); string(got) != "\uFEFFpackage a\r\n\r\nvar  x = 1\r\n" {
+		t.Errorf("format -w modified a.go despite the read-only b.go:\n%q", got)
+	}
+
+	app = New("gopls-test", tmpDir, os.Environ(), nil)
+	if err := tool.Run(context.Background(), flag.NewFlagSet("gopls", flag.ContinueOnError), app, []string{"format", "-w", a}); err != nil {
+		t.Fatal(err)
+	}
+	got, err := ioutil.ReadFile(a)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "\uFEFFpackage a\r\n\r\nvar x = 1\r\n"; string(got) != want {
+		t.Errorf("a.go after format -w = %q, want %q", got, want)
+	}
+	info, err := os.Stat(a)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Mode().Perm() != 0600 {
+		t.Errorf("mode of a.go after format -w = %v, want %v", info.Mode().Perm(), os.FileMode(0600))
+	}
+}
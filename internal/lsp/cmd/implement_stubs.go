@@ -0,0 +1,96 @@
+// Copyright 2022 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+
+	"github.com/iansmith/golang-x-tools/internal/lsp/command"
+	"github.com/iansmith/golang-x-tools/internal/lsp/protocol"
+	"github.com/iansmith/golang-x-tools/internal/span"
+	"github.com/iansmith/golang-x-tools/internal/tool"
+)
+
+// implementStubs implements the implement-stubs verb for gopls.
+type implementStubs struct {
+	Diff  bool `flag:"d,diff" help:"display diffs instead of rewriting files"`
+	Write bool `flag:"w,write" help:"write result to (source) file instead of stdout"`
+
+	app *Application
+}
+
+func (i *implementStubs) Name() string   { return "implement-stubs" }
+func (i *implementStubs) Parent() string { return i.app.Name() }
+func (i *implementStubs) Usage() string {
+	return "[implement-stubs-flags] <type> <interface>"
+}
+func (i *implementStubs) ShortHelp() string {
+	return "declare the methods of an interface that a type lacks"
+}
+func (i *implementStubs) DetailedHelp(f *flag.FlagSet) {
+	fmt.Fprint(f.Output(), `
+Declares, after the type, stubs of the methods of the interface that the
+type lacks. The type and the interface are given by the position of their
+names, or by their symbol paths.
+
+Example:
+
+	$ gopls implement-stubs -w example.com/store.fileStore example.com/store.Store
+	$ gopls implement-stubs -d store/file.go:12:6 store/store.go:8:6
+
+implement-stubs-flags:
+`)
+	printFlagDefaults(f)
+}
+
+func (i *implementStubs) Run(ctx context.Context, args ...string) error {
+	if len(args) != 2 {
+		return tool.CommandLineErrorf("implement-stubs expects 2 arguments (type, interface)")
+	}
+	i.app.qualifySymbols()
+	conn, err := i.app.connect(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.terminate(ctx)
+
+	_, concrete, err := conn.locate(ctx, args[0])
+	if err != nil {
+		return err
+	}
+	_, iface, err := conn.locate(ctx, args[1])
+	if err != nil {
+		return err
+	}
+	cmd, err := command.NewImplementStubsCommand("", command.ImplementStubsArgs{
+		Concrete:  concrete,
+		Interface: iface,
+	})
+	if err != nil {
+		return err
+	}
+	res, err := conn.ExecuteCommand(ctx, &protocol.ExecuteCommandParams{Command: cmd.Command, Arguments: cmd.Arguments})
+	if err != nil {
+		return fmt.Errorf("executing server command: %v", err)
+	}
+	// The result arrives as generic JSON values; decode it again.
+	data, err := json.Marshal(res)
+	if err != nil {
+		return err
+	}
+	var result command.ImplementStubsResult
+	if err := json.Unmarshal(data, &result); err != nil {
+		return fmt.Errorf("decoding result: %v", err)
+	}
+	edits := map[span.URI][]protocol.TextEdit{}
+	for _, e := range result.Edits {
+		uri := fileURI(e.TextDocument.URI)
+		edits[uri] = append(edits[uri], protocol.AsTextEdits(e.Edits)...)
+	}
+	return applyEdits(ctx, conn, edits, i.Write, i.Diff, false)
+}
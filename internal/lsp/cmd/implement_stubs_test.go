@@ -0,0 +1,52 @@
+// Copyright 2022 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cmd
+
+import (
+	"context"
+	"flag"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/iansmith/golang-x-tools/internal/tool"
+)
+
+// TestImplementStubs checks that implement-stubs declares the missing
+// methods of an interface of another package, given by its position, on
+// a type given by its symbol path.
+func TestImplementStubs(t *testing.T) {
+	tmpDir := writeModule(t, map[string]string{
+		"go.mod":         "module stubs\n\ngo 1.12\n",
+		"store/iface.go": "package store\n\ntype Store interface {\n\tGet(key string) ([]byte, error)\n\tPut(key string, value []byte) error\n}\n",
+		"file/file.go":   "package file\n\ntype fileStore struct{ dir string }\n\nfunc (s *fileStore) Get(key string) ([]byte, error) { return nil, nil }\n",
+	})
+	defer os.RemoveAll(tmpDir)
+
+	app := New("gopls-test", tmpDir, os.Environ(), nil)
+	args := []string{"implement-stubs", "-w", "file.fileStore", filepath.Join(tmpDir, "store", "iface.go") + ":3:6"}
+	if err := tool.Run(context.Background(), flag.NewFlagSet("gopls", flag.ContinueOnError), app, args); err != nil {
+		t.Fatal(err)
+	}
+	got, err := ioutil.ReadFile(filepath.Join(tmpDir, "file", "file.go"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	const want = `package file
+
+type fileStore struct{ dir string }
+
+func (s *fileStore) Get(key string) ([]byte, error) { return nil, nil }
+
+// Put implements store.Store
+func (s *fileStore) Put(key string, value []byte) error {
+	panic("unimplemented")
+}
+`
+	if string(got) != want {
+		t.Errorf("file.go after implement-stubs:\n%s\nwant:\n%s", got, want)
+	}
+}
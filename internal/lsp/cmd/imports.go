@@ -8,9 +8,13 @@ import (
 	"context"
 	"flag"
 	"fmt"
+	goformat "go/format"
 	"io/ioutil"
+	"os"
+	"path/filepath"
 
 	"github.com/iansmith/golang-x-tools/internal/lsp/diff"
+	"github.com/iansmith/golang-x-tools/internal/lsp/diff/myers"
 	"github.com/iansmith/golang-x-tools/internal/lsp/protocol"
 	"github.com/iansmith/golang-x-tools/internal/lsp/source"
 	"github.com/iansmith/golang-x-tools/internal/span"
@@ -19,15 +23,16 @@ import (
 
 // imports implements the import verb for gopls.
 type imports struct {
-	Diff  bool `flag:"d,diff" help:"display diffs instead of rewriting files"`
-	Write bool `flag:"w,write" help:"write result to (source) file instead of stdout"`
+	Diff   bool   `flag:"d,diff" help:"display diffs instead of rewriting files"`
+	Write  bool   `flag:"w,write" help:"write result to (source) file instead of stdout"`
+	SrcDir string `flag:"srcdir" help:"choose imports for the standard input as if it were in this directory, or this file"`
 
 	app *Application
 }
 
 func (t *imports) Name() string      { return "imports" }
 func (t *imports) Parent() string    { return t.app.Name() }
-func (t *imports) Usage() string     { return "[imports-flags] <filename>" }
+func (t *imports) Usage() string     { return "[imports-flags] [<filename>]" }
 func (t *imports) ShortHelp() string { return "updates import statements" }
 func (t *imports) DetailedHelp(f *flag.FlagSet) {
 	fmt.Fprintf(f.Output(), `
@@ -35,18 +40,32 @@ Example: update imports statements in a file:
 
 	$ gopls imports -w internal/lsp/cmd/check.go
 
+Without a file, like goimports, the command updates the imports of the Go
+source read from the standard input, formats it, and prints it. Forward
+it to a shared gopls daemon, which keeps its cache warm between runs, to
+make it faster:
+
+	$ gopls -remote=auto imports -srcdir=internal/lsp/cmd/check.go < internal/lsp/cmd/check.go
+
 imports-flags:
 `)
 	printFlagDefaults(f)
 }
 
+// stdinFile is the name of the file, in the directory of the -srcdir flag,
+// of the source read from the standard input.
+const stdinFile = "gopls-stdin.go"
+
 // Run performs diagnostic checks on the file specified and either;
 // - if -w is specified, updates the file in place;
 // - if -d is specified, prints out unified diffs of the changes; or
 // - otherwise, prints the new versions to stdout.
 func (t *imports) Run(ctx context.Context, args ...string) error {
-	if len(args) != 1 {
-		return tool.CommandLineErrorf("imports expects 1 argument")
+	if len(args) > 1 {
+		return tool.CommandLineErrorf("imports expects at most 1 argument")
+	}
+	if len(args) == 0 && t.Write {
+		return tool.CommandLineErrorf("cannot use -w with standard input")
 	}
 	conn, err := t.app.connect(ctx)
 	if err != nil {
@@ -54,19 +73,36 @@ func (t *imports) Run(ctx context.Context, args ...string) error {
 	}
 	defer conn.terminate(ctx)
 
-	from := span.Parse(args[0])
-	uri := from.URI()
-	file := conn.AddFile(ctx, uri)
-	if file.err != nil {
-		return file.err
+	var file *cmdFile
+	if len(args) == 0 {
+		content, err := ioutil.ReadAll(os.Stdin)
+		if err != nil {
+			return err
+		}
+		filename, err := t.stdinFilename()
+		if err != nil {
+			return err
+		}
+		var close func()
+		file, close, err = conn.openContent(ctx, span.URIFromPath(filename), content)
+		if err != nil {
+			return err
+		}
+		defer close()
+	} else {
+		file = conn.AddFile(ctx, span.Parse(args[0]).URI())
+		if file.err != nil {
+			return file.err
+		}
 	}
+	uri := file.uri
 	actions, err := conn.CodeAction(ctx, &protocol.CodeActionParams{
 		TextDocument: protocol.TextDocumentIdentifier{
 			URI: protocol.URIFromSpanURI(uri),
 		},
 	})
 	if err != nil {
-		return fmt.Errorf("%v: %v", from, err)
+		return fmt.Errorf("%v: %v", uri.Filename(), err)
 	}
 	var edits []protocol.TextEdit
 	for _, a := range actions {
@@ -74,22 +110,38 @@ func (t *imports) Run(ctx context.Context, args ...string) error {
 			continue
 		}
 		for _, c := range a.Edit.DocumentChanges {
-			if fileURI(c.TextDocument.URI) == uri {
-				edits = append(edits, c.Edits...)
+			if c.TextDocumentEdit != nil && fileURI(c.TextDocumentEdit.TextDocument.URI) == uri {
+				edits = append(edits, protocol.AsTextEdits(c.TextDocumentEdit.Edits)...)
 			}
 		}
 	}
-	sedits, err := source.FromProtocolEdits(file.mapper, edits)
+	sedits, newContent, err := applyFileEdits(file, edits)
 	if err != nil {
 		return fmt.Errorf("%v: %v", edits, err)
 	}
-	newContent := diff.ApplyEdits(string(file.mapper.Content), sedits)
 
 	filename := file.uri.Filename()
+	if len(args) == 0 {
+		// As goimports, format the standard input.
+		formatted, err := goformat.Source([]byte(newContent))
+		if err != nil {
+			return err
+		}
+		if !t.Diff {
+			fmt.Print(string(formatted))
+			return nil
+		}
+		d, err := myers.ComputeEdits(uri, string(file.mapper.Content), string(formatted))
+		if err != nil {
+			return err
+		}
+		fmt.Print(diff.ToUnified("<standard input>.orig", "<standard input>", string(file.mapper.Content), d))
+		return nil
+	}
 	switch {
 	case t.Write:
 		if len(edits) > 0 {
-			ioutil.WriteFile(filename, []byte(newContent), 0644)
+			return source.WriteFiles([]source.FileWrite{{URI: file.uri, Content: []byte(newContent)}}, "")
 		}
 	case t.Diff:
 		diffs := diff.ToUnified(filename+".orig", filename, string(file.mapper.Content), sedits)
@@ -99,3 +151,25 @@ func (t *imports) Run(ctx context.Context, args ...string) error {
 	}
 	return nil
 }
+
+// stdinFilename returns the name of the file of the source read from the
+// standard input: that of the -srcdir flag, if it names a file, or else a
+// file of its directory, by default the working directory.
+func (t *imports) stdinFilename() (string, error) {
+	dir := t.app.wd
+	if t.SrcDir != "" {
+		path, err := filepath.Abs(t.SrcDir)
+		if err != nil {
+			return "", err
+		}
+		info, err := os.Stat(path)
+		if err != nil {
+			return "", err
+		}
+		if !info.IsDir() {
+			return path, nil
+		}
+		dir = path
+	}
+	return filepath.Join(dir, stdinFile), nil
+}
@@ -18,6 +18,7 @@ import (
 // references implements the references verb for gopls
 type references struct {
 	IncludeDeclaration bool `flag:"d,declaration" help:"include the declaration of the specified identifier in the results"`
+	JSON               bool `flag:"json" help:"emit output in JSON format"`
 
 	app *Application
 }
@@ -33,6 +34,8 @@ Example:
 	$ # 1-indexed location (:line:column or :#offset) of the target identifier
 	$ gopls references helper/helper.go:8:6
 	$ gopls references helper/helper.go:#53
+	$ # the references and the names of the symbols containing them, as JSON
+	$ gopls references -json helper/helper.go:8:6
 
 references-flags:
 `)
@@ -72,6 +75,9 @@ func (r *references) Run(ctx context.Context, args ...string) error {
 	if err != nil {
 		return err
 	}
+	if r.JSON {
+		return r.printJSON(ctx, conn, locations)
+	}
 	var spans []string
 	for _, l := range locations {
 		f := conn.AddFile(ctx, fileURI(l.URI))
@@ -90,3 +96,62 @@ func (r *references) Run(ctx context.Context, args ...string) error {
 	}
 	return nil
 }
+
+// A jsonReference is a reference printed by references -json.
+type jsonReference struct {
+	Span          span.Span `json:"span"`
+	ContainerName string    `json:"containerName,omitempty"` // the innermost symbol of the file containing the reference
+}
+
+// printJSON prints locations, sorted, as JSON references.
+func (r *references) printJSON(ctx context.Context, conn *connection, locations []protocol.Location) error {
+	// The symbols of the files of the references, by file.
+	symbols := make(map[span.URI][]jsonSymbol)
+	result := []jsonReference{}
+	for _, l := range locations {
+		uri := fileURI(l.URI)
+		f := conn.AddFile(ctx, uri)
+		if f.err != nil {
+			return f.err
+		}
+		spn, err := f.mapper.Span(l)
+		if err != nil {
+			return err
+		}
+		syms, ok := symbols[uri]
+		if !ok {
+			res, err := conn.DocumentSymbol(ctx, &protocol.DocumentSymbolParams{
+				TextDocument: protocol.TextDocumentIdentifier{URI: l.URI},
+			})
+			if err != nil {
+				return err
+			}
+			if syms, err = fileSymbols(f, res); err != nil {
+				return err
+			}
+			symbols[uri] = syms
+		}
+		ref := jsonReference{Span: spn}
+		var container *span.Span
+		for _, s := range syms {
+			if s.Extent == nil || !contains(*s.Extent, spn) {
+				continue
+			}
+			if container == nil || contains(*container, *s.Extent) {
+				ref.ContainerName, container = s.Name, s.Extent
+			}
+		}
+		result = append(result, ref)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return span.Compare(result[i].Span, result[j].Span) < 0
+	})
+	return printJSON(result)
+}
+
+// contains reports whether outer contains inner.
+func contains(outer, inner span.Span) bool {
+	return outer.URI() == inner.URI() &&
+		span.ComparePoint(outer.Start(), inner.Start()) <= 0 &&
+		span.ComparePoint(inner.End(), outer.End()) <= 0
+}
@@ -8,7 +8,6 @@ import (
 	"context"
 	"flag"
 	"fmt"
-	"io/ioutil"
 	"os"
 	"path/filepath"
 	"sort"
@@ -31,7 +30,7 @@ type rename struct {
 
 func (r *rename) Name() string      { return "rename" }
 func (r *rename) Parent() string    { return r.app.Name() }
-func (r *rename) Usage() string     { return "[rename-flags] <position> <name>" }
+func (r *rename) Usage() string     { return "[rename-flags] <position|symbol> <name>" }
 func (r *rename) ShortHelp() string { return "rename selected identifier" }
 func (r *rename) DetailedHelp(f *flag.FlagSet) {
 	fmt.Fprint(f.Output(), `
@@ -40,6 +39,8 @@ Example:
 	$ # 1-based location (:line:column or :#position) of the thing to change
 	$ gopls rename helper/helper.go:8:6 Foo
 	$ gopls rename helper/helper.go:#53 Foo
+	$ # or path of a symbol of the workspace
+	$ gopls rename -w example.com/helper.Helper.Bar Foo
 
 rename-flags:
 `)
@@ -54,18 +55,14 @@ func (r *rename) Run(ctx context.Context, args ...string) error {
 	if len(args) != 2 {
 		return tool.CommandLineErrorf("definition expects 2 arguments (position, new name)")
 	}
+	r.app.qualifySymbols()
 	conn, err := r.app.connect(ctx)
 	if err != nil {
 		return err
 	}
 	defer conn.terminate(ctx)
 
-	from := span.Parse(args[0])
-	file := conn.AddFile(ctx, from.URI())
-	if file.err != nil {
-		return file.err
-	}
-	loc, err := file.mapper.Location(from)
+	_, loc, err := conn.locate(ctx, args[0])
 	if err != nil {
 		return err
 	}
@@ -78,39 +75,52 @@ func (r *rename) Run(ctx context.Context, args ...string) error {
 	if err != nil {
 		return err
 	}
-	var orderedURIs []string
 	edits := map[span.URI][]protocol.TextEdit{}
 	for _, c := range edit.DocumentChanges {
-		uri := fileURI(c.TextDocument.URI)
-		edits[uri] = append(edits[uri], c.Edits...)
+		// The command line client does not claim support for
+		// renaming files, so all changes are text edits.
+		if c.TextDocumentEdit == nil {
+			continue
+		}
+		uri := fileURI(c.TextDocumentEdit.TextDocument.URI)
+		edits[uri] = append(edits[uri], protocol.AsTextEdits(c.TextDocumentEdit.Edits)...)
+	}
+	return applyEdits(ctx, conn, edits, r.Write, r.Diff, r.Preserve)
+}
+
+// applyEdits applies edits to their files and either:
+// - if write is set, updates the files in place, renaming the originals
+// with a .orig suffix if preserve is set, and prints their names;
+// - if diffs is set, prints out unified diffs of the changes; or
+// - otherwise, prints the new versions to stdout.
+func applyEdits(ctx context.Context, conn *connection, edits map[span.URI][]protocol.TextEdit, write, diffs, preserve bool) error {
+	var orderedURIs []string
+	for uri := range edits {
 		orderedURIs = append(orderedURIs, string(uri))
 	}
 	sort.Strings(orderedURIs)
 	changeCount := len(orderedURIs)
 
+	var writes []source.FileWrite
 	for _, u := range orderedURIs {
 		uri := span.URIFromURI(u)
 		cmdFile := conn.AddFile(ctx, uri)
+		if cmdFile.err != nil {
+			return cmdFile.err
+		}
 		filename := cmdFile.uri.Filename()
 
 		// convert LSP-style edits to []diff.TextEdit cuz Spans are handy
-		renameEdits, err := source.FromProtocolEdits(cmdFile.mapper, edits[uri])
+		fileEdits, newContent, err := applyFileEdits(cmdFile, edits[uri])
 		if err != nil {
 			return fmt.Errorf("%v: %v", edits, err)
 		}
-		newContent := diff.ApplyEdits(string(cmdFile.mapper.Content), renameEdits)
 
 		switch {
-		case r.Write:
-			fmt.Fprintln(os.Stderr, filename)
-			if r.Preserve {
-				if err := os.Rename(filename, filename+".orig"); err != nil {
-					return fmt.Errorf("%v: %v", edits, err)
-				}
-			}
-			ioutil.WriteFile(filename, []byte(newContent), 0644)
-		case r.Diff:
-			diffs := diff.ToUnified(filename+".orig", filename, string(cmdFile.mapper.Content), renameEdits)
+		case write:
+			writes = append(writes, source.FileWrite{URI: cmdFile.uri, Content: []byte(newContent)})
+		case diffs:
+			diffs := diff.ToUnified(filename+".orig", filename, string(cmdFile.mapper.Content), fileEdits)
 			fmt.Print(diffs)
 		default:
 			if len(orderedURIs) > 1 {
@@ -123,5 +133,30 @@ func (r *rename) Run(ctx context.Context, args ...string) error {
 			changeCount -= 1
 		}
 	}
+	if len(writes) == 0 {
+		return nil
+	}
+	suffix := ""
+	if preserve {
+		suffix = ".orig"
+	}
+	// No file is modified if one of them can't be.
+	if err := source.WriteFiles(writes, suffix); err != nil {
+		return err
+	}
+	for _, w := range writes {
+		fmt.Fprintln(os.Stderr, w.URI.Filename())
+	}
 	return nil
 }
+
+// applyFileEdits converts the LSP edits of file, adjusted to keep its line
+// endings and byte order mark, and returns them with the new content of
+// the file.
+func applyFileEdits(file *cmdFile, edits []protocol.TextEdit) ([]diff.TextEdit, string, error) {
+	sedits, err := source.FromProtocolEdits(file.mapper, source.PreserveFileFormat(file.mapper.Content, edits))
+	if err != nil {
+		return nil, "", err
+	}
+	return sedits, diff.ApplyEdits(string(file.mapper.Content), sedits), nil
+}
@@ -0,0 +1,44 @@
+// Copyright 2022 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cmd
+
+import (
+	"context"
+	"flag"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/iansmith/golang-x-tools/internal/tool"
+)
+
+// TestRenameSymbol checks that rename renames the method given by its
+// symbol path in all the files that use it.
+func TestRenameSymbol(t *testing.T) {
+	tmpDir := writeModule(t, map[string]string{
+		"go.mod": "module rename\n\ngo 1.12\n",
+		"a/a.go": "package a\n\ntype T struct{}\n\nfunc (T) M() {}\n\nfunc M() {}\n",
+		"b/b.go": "package b\n\nimport \"rename/a\"\n\nvar _ = a.T.M\n",
+	})
+	defer os.RemoveAll(tmpDir)
+
+	app := New("gopls-test", tmpDir, os.Environ(), nil)
+	if err := tool.Run(context.Background(), flag.NewFlagSet("gopls", flag.ContinueOnError), app, []string{"rename", "-w", "rename/a.T.M", "N"}); err != nil {
+		t.Fatal(err)
+	}
+	for name, want := range map[string]string{
+		"a/a.go": "package a\n\ntype T struct{}\n\nfunc (T) N() {}\n\nfunc M() {}\n",
+		"b/b.go": "package b\n\nimport \"rename/a\"\n\nvar _ = a.T.N\n",
+	} {
+		got, err := ioutil.ReadFile(filepath.Join(tmpDir, filepath.FromSlash(name)))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(got) != want {
+			t.Errorf("%s after rename:\n%s\nwant:\n%s", name, got, want)
+		}
+	}
+}
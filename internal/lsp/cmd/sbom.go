@@ -0,0 +1,96 @@
+// Copyright 2022 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/iansmith/golang-x-tools/internal/lsp/command"
+	"github.com/iansmith/golang-x-tools/internal/lsp/protocol"
+	"github.com/iansmith/golang-x-tools/internal/lsp/source"
+	"github.com/iansmith/golang-x-tools/internal/tool"
+)
+
+// sbom implements the sbom verb for gopls.
+type sbom struct {
+	Format string `flag:"format" help:"the format of the document: CycloneDX or SPDX"`
+	Vulns  bool   `flag:"vulns" help:"list the known vulnerabilities of the imported packages"`
+
+	app *Application
+}
+
+func (s *sbom) Name() string   { return "sbom" }
+func (s *sbom) Parent() string { return s.app.Name() }
+func (s *sbom) Usage() string  { return "[sbom-flags] [<dir>]" }
+func (s *sbom) ShortHelp() string {
+	return "print a software bill of materials of the workspace modules"
+}
+func (s *sbom) DetailedHelp(f *flag.FlagSet) {
+	fmt.Fprint(f.Output(), `
+Prints a JSON CycloneDX 1.4 or SPDX 2.2 document listing the modules of the
+packages of the workspace, by default that of the current directory, and
+the dependencies between them.
+
+Example: print an SPDX document with the known vulnerabilities:
+
+	$ gopls sbom -format=SPDX -vulns
+
+sbom-flags:
+`)
+	printFlagDefaults(f)
+}
+
+func (s *sbom) Run(ctx context.Context, args ...string) error {
+	if len(args) > 1 {
+		return tool.CommandLineErrorf("sbom accepts at most one directory")
+	}
+	dir := s.app.wd
+	if len(args) == 1 {
+		var err error
+		if dir, err = filepath.Abs(args[0]); err != nil {
+			return err
+		}
+		s.app.wd = dir
+	}
+	format := s.Format
+	if format == "" {
+		format = source.SBOMCycloneDX
+	}
+
+	conn, err := s.app.connect(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.terminate(ctx)
+	cmd, err := command.NewGenerateSBOMCommand("", command.GenerateSBOMArgs{
+		URI:    protocol.URIFromPath(dir),
+		Format: format,
+		Vulns:  s.Vulns,
+	})
+	if err != nil {
+		return err
+	}
+	params := &protocol.ExecuteCommandParams{Command: cmd.Command, Arguments: cmd.Arguments}
+	res, err := conn.ExecuteCommand(ctx, params)
+	if err != nil {
+		return fmt.Errorf("executing server command: %v", err)
+	}
+	// The result arrives as generic JSON values; decode it again.
+	data, err := json.Marshal(res)
+	if err != nil {
+		return err
+	}
+	var result command.GenerateSBOMResult
+	if err := json.Unmarshal(data, &result); err != nil {
+		return fmt.Errorf("decoding result: %v", err)
+	}
+	fmt.Fprint(os.Stdout, result.Document)
+	return nil
+}
@@ -12,6 +12,7 @@ import (
 	"io"
 	"log"
 	"os"
+	"strings"
 	"time"
 
 	"github.com/iansmith/golang-x-tools/internal/fakenet"
@@ -29,8 +30,10 @@ type Serve struct {
 	Logfile     string        `flag:"logfile" help:"filename to log to. if value is \"auto\", then logging to a default output file is enabled"`
 	Mode        string        `flag:"mode" help:"no effect"`
 	Port        int           `flag:"port" help:"port on which to run gopls for debugging purposes"`
-	Address     string        `flag:"listen" help:"address on which to listen for remote connections. If prefixed by 'unix;', the subsequent address is assumed to be a unix domain socket. Otherwise, TCP is used."`
+	Address     string        `flag:"listen" help:"address on which to listen for remote connections. If prefixed by 'unix;', the subsequent address is assumed to be a unix domain socket. A ws://host:port/path URL serves WebSocket connections, carrying a message per text frame, and a \\\\.\\pipe\\name address is a Windows named pipe. Otherwise, TCP is used."`
 	IdleTimeout time.Duration `flag:"listen.timeout" help:"when used with -listen, shut down the server when there are no connected clients for this duration"`
+	Origins     string        `flag:"listen.origins" help:"when used with -listen=ws://..., comma-separated origins of the web pages allowed to connect, or * for all of them; by default only those of localhost"`
+	Token       string        `flag:"listen.token" help:"when used with -listen=ws://..., a token that clients must send as the token query parameter or as a bearer token"`
 	Trace       bool          `flag:"rpc.trace" help:"print the full rpc trace in lsp inspector format"`
 	Debug       string        `flag:"debug" help:"serve debug information on the supplied address"`
 
@@ -93,7 +96,10 @@ func (s *Serve) Run(ctx context.Context, args ...string) error {
 		di.MonitorMemory(ctx)
 		di.Serve(ctx, s.Debug)
 	}
-	var ss jsonrpc2.StreamServer
+	var (
+		ss     jsonrpc2.StreamServer
+		daemon *lsprpc.StreamServer
+	)
 	if s.app.Remote != "" {
 		var err error
 		ss, err = lsprpc.NewForwarder(s.app.Remote, s.remoteArgs)
@@ -101,7 +107,8 @@ func (s *Serve) Run(ctx context.Context, args ...string) error {
 			return fmt.Errorf("creating forwarder: %w", err)
 		}
 	} else {
-		ss = lsprpc.NewStreamServer(cache.New(s.app.options), isDaemon)
+		daemon = lsprpc.NewStreamServer(cache.New(s.app.options), isDaemon)
+		ss = daemon
 	}
 
 	var network, addr string
@@ -115,7 +122,38 @@ func (s *Serve) Run(ctx context.Context, args ...string) error {
 	if addr != "" {
 		log.Printf("Gopls daemon: listening on %s network, address %s...", network, addr)
 		defer log.Printf("Gopls daemon: exiting")
-		return jsonrpc2.ListenAndServe(ctx, network, addr, ss, s.IdleTimeout)
+		opts := lsprpc.ListenOptions{Token: s.Token}
+		for _, origin := range strings.Split(s.Origins, ",") {
+			if origin = strings.TrimSpace(origin); origin != "" {
+				opts.Origins = append(opts.Origins, origin)
+			}
+		}
+		if daemon == nil {
+			return lsprpc.ListenAndServe(ctx, network, addr, ss, s.IdleTimeout, opts)
+		}
+		// Stop serving when a client asks the daemon to shut down.
+		ctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+		go func() {
+			select {
+			case <-daemon.Stopped():
+				cancel()
+			case <-ctx.Done():
+			}
+		}()
+		if err := lsprpc.ListenAndServe(ctx, network, addr, ss, s.IdleTimeout, opts); err != nil {
+			return err
+		}
+		if daemon.Restarting() {
+			// The address is now free for the new daemon.
+			executable, err := os.Executable()
+			if err != nil {
+				return err
+			}
+			log.Printf("Gopls daemon: restarting")
+			return lsprpc.StartDaemon(executable, os.Args[1:])
+		}
+		return nil
 	}
 	stream := jsonrpc2.NewHeaderStream(fakenet.NewConn("stdio", os.Stdin, os.Stdout))
 	if s.Trace && di != nil {
@@ -0,0 +1,71 @@
+// Copyright 2022 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/iansmith/golang-x-tools/internal/lsp/lsprpc"
+)
+
+// stats implements the stats command, which prints statistics about the
+// cache of the gopls daemon.
+type stats struct {
+	app *Application
+
+	JSON bool `flag:"json" help:"print the statistics in JSON format"`
+}
+
+func (s *stats) Name() string      { return "stats" }
+func (s *stats) Parent() string    { return s.app.Name() }
+func (s *stats) Usage() string     { return "[stats-flags]" }
+func (s *stats) ShortHelp() string { return "print statistics about the gopls daemon cache" }
+func (s *stats) DetailedHelp(f *flag.FlagSet) {
+	fmt.Fprint(f.Output(), `
+The gopls daemon holds a single cache for all of its sessions, so that
+editor windows open on the same module share type-checked packages and
+analyses. For each kind of cached value, stats prints the number of values,
+and how many of them are shared by more than one session.
+
+Examples:
+
+$ gopls stats
+$ gopls -remote=localhost:8082 stats -json
+
+stats-flags:
+`)
+	printFlagDefaults(f)
+}
+
+func (s *stats) Run(ctx context.Context, args ...string) error {
+	remote := s.app.Remote
+	if remote == "" {
+		remote = "auto"
+	}
+	state, err := lsprpc.QueryServerState(ctx, remote)
+	if err != nil {
+		return err
+	}
+	if s.JSON {
+		v, err := json.MarshalIndent(state.Cache, "", "\t")
+		if err != nil {
+			return err
+		}
+		os.Stdout.Write(v)
+		return nil
+	}
+	fmt.Printf("sessions: %d\n", len(state.Clients))
+	w := tabwriter.NewWriter(os.Stdout, 0, 8, 2, ' ', 0)
+	fmt.Fprintln(w, "kind\tcount\tshared")
+	for _, stat := range state.Cache {
+		fmt.Fprintf(w, "%s\t%d\t%d\n", stat.Kind, stat.Count, stat.Shared)
+	}
+	return w.Flush()
+}
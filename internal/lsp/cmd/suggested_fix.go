@@ -8,7 +8,6 @@ import (
 	"context"
 	"flag"
 	"fmt"
-	"io/ioutil"
 
 	"github.com/iansmith/golang-x-tools/internal/lsp/diff"
 	"github.com/iansmith/golang-x-tools/internal/lsp/protocol"
@@ -103,8 +102,8 @@ func (s *suggestedFix) Run(ctx context.Context, args ...string) error {
 		}
 		if !from.HasPosition() {
 			for _, c := range a.Edit.DocumentChanges {
-				if fileURI(c.TextDocument.URI) == uri {
-					edits = append(edits, c.Edits...)
+				if c.TextDocumentEdit != nil && fileURI(c.TextDocumentEdit.TextDocument.URI) == uri {
+					edits = append(edits, protocol.AsTextEdits(c.TextDocumentEdit.Edits)...)
 				}
 			}
 			continue
@@ -118,8 +117,8 @@ func (s *suggestedFix) Run(ctx context.Context, args ...string) error {
 			}
 			if span.ComparePoint(from.Start(), spn.Start()) == 0 {
 				for _, c := range a.Edit.DocumentChanges {
-					if fileURI(c.TextDocument.URI) == uri {
-						edits = append(edits, c.Edits...)
+					if c.TextDocumentEdit != nil && fileURI(c.TextDocumentEdit.TextDocument.URI) == uri {
+						edits = append(edits, protocol.AsTextEdits(c.TextDocumentEdit.Edits)...)
 					}
 				}
 				break
@@ -129,24 +128,23 @@ func (s *suggestedFix) Run(ctx context.Context, args ...string) error {
 		// If suggested fix is not a diagnostic, still must collect edits.
 		if len(a.Diagnostics) == 0 {
 			for _, c := range a.Edit.DocumentChanges {
-				if fileURI(c.TextDocument.URI) == uri {
-					edits = append(edits, c.Edits...)
+				if c.TextDocumentEdit != nil && fileURI(c.TextDocumentEdit.TextDocument.URI) == uri {
+					edits = append(edits, protocol.AsTextEdits(c.TextDocumentEdit.Edits)...)
 				}
 			}
 		}
 	}
 
-	sedits, err := source.FromProtocolEdits(file.mapper, edits)
+	sedits, newContent, err := applyFileEdits(file, edits)
 	if err != nil {
 		return fmt.Errorf("%v: %v", edits, err)
 	}
-	newContent := diff.ApplyEdits(string(file.mapper.Content), sedits)
 
 	filename := file.uri.Filename()
 	switch {
 	case s.Write:
 		if len(edits) > 0 {
-			ioutil.WriteFile(filename, []byte(newContent), 0644)
+			return source.WriteFiles([]source.FileWrite{{URI: file.uri, Content: []byte(newContent)}}, "")
 		}
 	case s.Diff:
 		diffs := diff.ToUnified(filename+".orig", filename, string(file.mapper.Content), sedits)
@@ -9,6 +9,7 @@ import (
 	"encoding/json"
 	"flag"
 	"fmt"
+	"os"
 	"sort"
 
 	"github.com/iansmith/golang-x-tools/internal/lsp/protocol"
@@ -18,20 +19,38 @@ import (
 
 // symbols implements the symbols verb for gopls
 type symbols struct {
+	JSON      bool `flag:"json" help:"emit output in JSON format"`
+	Workspace bool `flag:"workspace" help:"display the symbols of the workspace matching the argument, instead of those of a file"`
+
 	app *Application
 }
 
 func (r *symbols) Name() string      { return "symbols" }
 func (r *symbols) Parent() string    { return r.app.Name() }
-func (r *symbols) Usage() string     { return "<file>" }
+func (r *symbols) Usage() string     { return "[symbols-flags] <file> | -workspace <query>" }
 func (r *symbols) ShortHelp() string { return "display selected file's symbols" }
 func (r *symbols) DetailedHelp(f *flag.FlagSet) {
 	fmt.Fprint(f.Output(), `
 Example:
 	$ gopls symbols helper/helper.go
+	$ # the symbols of the workspace matching a query, as JSON
+	$ gopls symbols -workspace -json Helper
+
+symbols-flags:
 `)
 	printFlagDefaults(f)
 }
+
+// A jsonSymbol is a symbol printed by symbols -json.
+type jsonSymbol struct {
+	Name          string     `json:"name"`
+	Detail        string     `json:"detail,omitempty"`
+	Kind          string     `json:"kind"`
+	ContainerName string     `json:"containerName,omitempty"` // the enclosing symbol or package
+	Span          span.Span  `json:"span"`                    // span of the name of the symbol
+	Extent        *span.Span `json:"extent,omitempty"`        // span of its declaration, for those of a file
+}
+
 func (r *symbols) Run(ctx context.Context, args ...string) error {
 	if len(args) != 1 {
 		return tool.CommandLineErrorf("symbols expects 1 argument (position)")
@@ -43,6 +62,9 @@ func (r *symbols) Run(ctx context.Context, args ...string) error {
 	}
 	defer conn.terminate(ctx)
 
+	if r.Workspace {
+		return r.workspaceSymbols(ctx, conn, args[0])
+	}
 	from := span.Parse(args[0])
 	p := protocol.DocumentSymbolParams{
 		TextDocument: protocol.TextDocumentIdentifier{
@@ -53,6 +75,17 @@ func (r *symbols) Run(ctx context.Context, args ...string) error {
 	if err != nil {
 		return err
 	}
+	if r.JSON {
+		file := conn.AddFile(ctx, from.URI())
+		if file.err != nil {
+			return file.err
+		}
+		result, err := fileSymbols(file, symbols)
+		if err != nil {
+			return err
+		}
+		return printJSON(result)
+	}
 	for _, s := range symbols {
 		if m, ok := s.(map[string]interface{}); ok {
 			s, err = mapToSymbol(m)
@@ -62,7 +95,7 @@ func (r *symbols) Run(ctx context.Context, args ...string) error {
 		}
 		switch t := s.(type) {
 		case protocol.DocumentSymbol:
-			printDocumentSymbol(t)
+			printDocumentSymbol(t, "")
 		case protocol.SymbolInformation:
 			printSymbolInformation(t)
 		}
@@ -70,6 +103,104 @@ func (r *symbols) Run(ctx context.Context, args ...string) error {
 	return nil
 }
 
+// workspaceSymbols prints the symbols of the workspace matching query.
+func (r *symbols) workspaceSymbols(ctx context.Context, conn *connection, query string) error {
+	symbols, err := conn.Symbol(ctx, &protocol.WorkspaceSymbolParams{Query: query})
+	if err != nil {
+		return err
+	}
+	result := []jsonSymbol{}
+	for _, s := range symbols {
+		f := conn.AddFile(ctx, fileURI(s.Location.URI))
+		if f.err != nil {
+			return f.err
+		}
+		spn, err := f.mapper.Span(s.Location)
+		if err != nil {
+			return err
+		}
+		if !r.JSON {
+			fmt.Printf("%s %s %s\n", spn, s.Name, s.Kind)
+			continue
+		}
+		result = append(result, jsonSymbol{
+			Name:          s.Name,
+			Kind:          fmt.Sprint(s.Kind),
+			ContainerName: s.ContainerName,
+			Span:          spn,
+		})
+	}
+	if r.JSON {
+		return printJSON(result)
+	}
+	return nil
+}
+
+// fileSymbols returns the symbols of file, in the order of symbols, the
+// result of a document symbol request, with those of the hierarchy of
+// document symbols flattened.
+func fileSymbols(file *cmdFile, symbols []interface{}) ([]jsonSymbol, error) {
+	result := []jsonSymbol{}
+	var add func(s protocol.DocumentSymbol, container string) error
+	add = func(s protocol.DocumentSymbol, container string) error {
+		spn, err := file.mapper.RangeSpan(s.SelectionRange)
+		if err != nil {
+			return err
+		}
+		extent, err := file.mapper.RangeSpan(s.Range)
+		if err != nil {
+			return err
+		}
+		result = append(result, jsonSymbol{
+			Name:          s.Name,
+			Detail:        s.Detail,
+			Kind:          fmt.Sprint(s.Kind),
+			ContainerName: container,
+			Span:          spn,
+			Extent:        &extent,
+		})
+		for _, c := range s.Children {
+			if err := add(c, s.Name); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	for _, s := range symbols {
+		if m, ok := s.(map[string]interface{}); ok {
+			var err error
+			if s, err = mapToSymbol(m); err != nil {
+				return nil, err
+			}
+		}
+		switch t := s.(type) {
+		case protocol.DocumentSymbol:
+			if err := add(t, ""); err != nil {
+				return nil, err
+			}
+		case protocol.SymbolInformation:
+			spn, err := file.mapper.RangeSpan(t.Location.Range)
+			if err != nil {
+				return nil, err
+			}
+			result = append(result, jsonSymbol{
+				Name:          t.Name,
+				Kind:          fmt.Sprint(t.Kind),
+				ContainerName: t.ContainerName,
+				Span:          spn,
+			})
+		}
+	}
+	return result, nil
+}
+
+// printJSON prints v to stdout as indented JSON.
+func printJSON(v interface{}) error {
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "\t")
+	return enc.Encode(v)
+}
+
 func mapToSymbol(m map[string]interface{}) (interface{}, error) {
 	b, err := json.Marshal(m)
 	if err != nil {
@@ -91,14 +222,16 @@ func mapToSymbol(m map[string]interface{}) (interface{}, error) {
 	return s, nil
 }
 
-func printDocumentSymbol(s protocol.DocumentSymbol) {
-	fmt.Printf("%s %s %s\n", s.Name, s.Kind, positionToString(s.SelectionRange))
+// printDocumentSymbol prints s and, indented by one more tab, its
+// children.
+func printDocumentSymbol(s protocol.DocumentSymbol, indent string) {
+	fmt.Printf("%s%s %s %s\n", indent, s.Name, s.Kind, positionToString(s.SelectionRange))
 	// Sort children for consistency
 	sort.Slice(s.Children, func(i, j int) bool {
 		return s.Children[i].Name < s.Children[j].Name
 	})
 	for _, c := range s.Children {
-		fmt.Printf("\t%s %s %s\n", c.Name, c.Kind, positionToString(c.SelectionRange))
+		printDocumentSymbol(c, indent+"\t")
 	}
 }
 
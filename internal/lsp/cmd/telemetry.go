@@ -0,0 +1,156 @@
+// Copyright 2022 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/iansmith/golang-x-tools/internal/lsp/telemetry"
+	"github.com/iansmith/golang-x-tools/internal/tool"
+)
+
+// telemetryCommand is a top-level command for inspecting the telemetry that
+// gopls records when the telemetry setting is enabled.
+type telemetryCommand struct {
+	app *Application
+	subcommands
+}
+
+func newTelemetry(app *Application) *telemetryCommand {
+	return &telemetryCommand{
+		app: app,
+		subcommands: subcommands{
+			&telemetryView{app: app},
+			&telemetryClear{app: app},
+		},
+	}
+}
+
+func (t *telemetryCommand) Name() string   { return "telemetry" }
+func (t *telemetryCommand) Parent() string { return t.app.Name() }
+func (t *telemetryCommand) ShortHelp() string {
+	return "print or clear the telemetry recorded by gopls"
+}
+
+// telemetryView prints the consent and the reports that are not uploaded
+// yet.
+type telemetryView struct {
+	app *Application
+}
+
+func (c *telemetryView) Name() string  { return "view" }
+func (c *telemetryView) Usage() string { return "" }
+func (c *telemetryView) ShortHelp() string {
+	return "print the telemetry reports that would be uploaded"
+}
+
+func (c *telemetryView) DetailedHelp(f *flag.FlagSet) {
+	fmt.Fprint(f.Output(), `
+Prints the directory of the telemetry of gopls, when the user consented to
+it, and each report that is not uploaded yet, in the JSON format in which it
+is sent. The report of the current week is uploaded once the week has ended,
+if $GOPLS_TELEMETRY_URL is set; there is no default upload destination. A
+running gopls adds its counts to the report every minute.
+
+Telemetry is only recorded while the "telemetry" setting is enabled. The
+directory is that of $GOPLS_TELEMETRY_DIR, or else gopls/telemetry in the
+configuration directory of the user.
+
+Example:
+
+$ gopls telemetry view
+`)
+	printFlagDefaults(f)
+}
+
+func (c *telemetryView) Run(ctx context.Context, args ...string) error {
+	if len(args) > 0 {
+		return tool.CommandLineErrorf("view does not take arguments, got %v", args)
+	}
+	store, err := telemetryStore()
+	if err != nil {
+		return err
+	}
+	consent, err := store.Consent()
+	if err != nil {
+		return err
+	}
+	fmt.Printf("directory: %s\n", store.Dir)
+	if consent.IsZero() {
+		fmt.Printf("consent: none, nothing is uploaded\n")
+	} else {
+		fmt.Printf("consent: %s\n", consent.Local().Format(time.RFC3339))
+	}
+	reports, err := store.Reports()
+	if err != nil {
+		return err
+	}
+	current := telemetry.Week(time.Now())
+	for _, report := range reports {
+		data, err := json.MarshalIndent(report, "", "\t")
+		if err != nil {
+			return err
+		}
+		state := "to be uploaded"
+		if report.Week >= current {
+			state = "in progress"
+		}
+		fmt.Printf("\nweek of %s (%s):\n%s\n", report.Week, state, data)
+	}
+	return nil
+}
+
+// telemetryClear removes the reports and the consent.
+type telemetryClear struct {
+	app *Application
+}
+
+func (c *telemetryClear) Name() string  { return "clear" }
+func (c *telemetryClear) Usage() string { return "" }
+func (c *telemetryClear) ShortHelp() string {
+	return "remove the telemetry reports and the consent"
+}
+
+func (c *telemetryClear) DetailedHelp(f *flag.FlagSet) {
+	fmt.Fprint(f.Output(), `
+Removes the telemetry reports that are not uploaded yet, and the file that
+records the consent of the user. A gopls that runs with the "telemetry" setting
+enabled records it again, from then on; disable the setting to stop the
+recording.
+
+Example:
+
+$ gopls telemetry clear
+`)
+	printFlagDefaults(f)
+}
+
+func (c *telemetryClear) Run(ctx context.Context, args ...string) error {
+	if len(args) > 0 {
+		return tool.CommandLineErrorf("clear does not take arguments, got %v", args)
+	}
+	store, err := telemetryStore()
+	if err != nil {
+		return err
+	}
+	if err := store.Clear(); err != nil {
+		return err
+	}
+	fmt.Fprintf(os.Stderr, "removed the telemetry of %s\n", store.Dir)
+	return nil
+}
+
+func telemetryStore() (*telemetry.Store, error) {
+	dir, err := telemetry.DefaultDir()
+	if err != nil {
+		return nil, err
+	}
+	return &telemetry.Store{Dir: dir}, nil
+}
@@ -5,8 +5,10 @@
 package cmdtest
 
 import (
+	"encoding/json"
 	"fmt"
 	"io/ioutil"
+	"reflect"
 	"strings"
 	"testing"
 
@@ -20,7 +22,47 @@ func (r *runner) Diagnostics(t *testing.T, uri span.URI, want []*source.Diagnost
 	}
 	fname := uri.Filename()
 	out, _ := r.runGoplsCmd(t, "check", fname)
-	// parse got into a collection of reports
+	got := r.parseCheckOutput(t, fname, out)
+	textGot := r.parseCheckOutput(t, fname, out)
+	for _, diag := range want {
+		expect := fmt.Sprintf("%v:%v:%v: %v", uri.Filename(), diag.Range.Start.Line+1, diag.Range.Start.Character+1, diag.Message)
+		if diag.Range.Start.Character == 0 {
+			expect = fmt.Sprintf("%v:%v: %v", uri.Filename(), diag.Range.Start.Line+1, diag.Message)
+		}
+		expect = r.NormalizePrefix(expect)
+		_, found := got[expect]
+		if !found {
+			t.Errorf("missing diagnostic %q, %v", expect, got)
+		} else {
+			delete(got, expect)
+		}
+	}
+	for extra := range got {
+		t.Errorf("extra diagnostic %q", extra)
+	}
+
+	// The JSON output reports the same diagnostics, and any of them fails
+	// the check with the lowest threshold.
+	out, stderr := r.runGoplsCmd(t, "check", "-json", "-severity=hint", fname)
+	var diags []struct{ Span, Message string }
+	if err := json.Unmarshal([]byte(out), &diags); err != nil {
+		t.Fatalf("invalid JSON output %q: %v", out, err)
+	}
+	var text strings.Builder
+	for _, d := range diags {
+		fmt.Fprintf(&text, "%s: %s\n", d.Span, d.Message)
+	}
+	if jsonGot := r.parseCheckOutput(t, fname, text.String()); !reflect.DeepEqual(jsonGot, textGot) {
+		t.Errorf("check -json reported %v, want %v", jsonGot, textGot)
+	}
+	if failed := strings.Contains(stderr, "at least as severe as hint"); failed != (len(diags) > 0) {
+		t.Errorf("check -severity=hint with %d diagnostics failed: %v, stderr %q", len(diags), failed, stderr)
+	}
+}
+
+// parseCheckOutput parses the output of the check command for fname into
+// a collection of reports.
+func (r *runner) parseCheckOutput(t *testing.T, fname, out string) map[string]struct{} {
 	got := map[string]struct{}{}
 	for _, l := range strings.Split(out, "\n") {
 		if len(l) == 0 {
@@ -44,20 +86,5 @@ func (r *runner) Diagnostics(t *testing.T, uri span.URI, want []*source.Diagnost
 		}
 		got[r.NormalizePrefix(l)] = struct{}{}
 	}
-	for _, diag := range want {
-		expect := fmt.Sprintf("%v:%v:%v: %v", uri.Filename(), diag.Range.Start.Line+1, diag.Range.Start.Character+1, diag.Message)
-		if diag.Range.Start.Character == 0 {
-			expect = fmt.Sprintf("%v:%v: %v", uri.Filename(), diag.Range.Start.Line+1, diag.Message)
-		}
-		expect = r.NormalizePrefix(expect)
-		_, found := got[expect]
-		if !found {
-			t.Errorf("missing diagnostic %q, %v", expect, got)
-		} else {
-			delete(got, expect)
-		}
-	}
-	for extra := range got {
-		t.Errorf("extra diagnostic %q", extra)
-	}
+	return got
 }
@@ -5,6 +5,8 @@
 package cmdtest
 
 import (
+	"go/format"
+	"os"
 	"testing"
 
 	"github.com/iansmith/golang-x-tools/internal/lsp/diff"
@@ -26,4 +28,27 @@ func (r *runner) Import(t *testing.T, spn span.Span) {
 		}
 		t.Errorf("imports failed for %s, expected:\n%s", filename, diff.ToUnified("want", "got", want, d))
 	}
+
+	// The same imports are chosen for the file on the standard input,
+	// which is formatted too.
+	formatted, err := format.Source([]byte(want))
+	if err != nil {
+		return
+	}
+	stdin, err := os.Open(filename)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer stdin.Close()
+	oldStdin := os.Stdin
+	os.Stdin = stdin
+	got, _ = r.NormalizeGoplsCmd(t, "imports", "-srcdir="+filename)
+	os.Stdin = oldStdin
+	if got != string(formatted) {
+		d, err := myers.ComputeEdits(uri, string(formatted), got)
+		if err != nil {
+			t.Fatal(err)
+		}
+		t.Errorf("imports of the standard input failed for %s, expected:\n%s", filename, diff.ToUnified("want", "got", string(formatted), d))
+	}
 }
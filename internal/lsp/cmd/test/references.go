@@ -5,8 +5,10 @@
 package cmdtest
 
 import (
+	"encoding/json"
 	"fmt"
 	"sort"
+	"strings"
 	"testing"
 
 	"github.com/iansmith/golang-x-tools/internal/span"
@@ -44,6 +46,21 @@ func (r *runner) References(t *testing.T, spn span.Span, itemList []span.Span) {
 			} else if expect != got {
 				t.Errorf("references failed for %s expected:\n%s\ngot:\n%s", target, expect, got)
 			}
+
+			// The JSON output has the same spans.
+			out, _ := r.runGoplsCmd(t, append([]string{"references", "-json"}, args[1:]...)...)
+			var refs []struct{ Span span.Span }
+			if err := json.Unmarshal([]byte(out), &refs); err != nil {
+				t.Fatalf("invalid JSON output %q: %v", out, err)
+			}
+			var spans []string
+			for _, ref := range refs {
+				spans = append(spans, r.Normalize(fmt.Sprint(ref.Span)))
+			}
+			sort.Strings(spans)
+			if got := strings.Join(spans, "\n") + "\n"; len(spans) > 0 && got != expect || len(spans) == 0 && expect != "" {
+				t.Errorf("references -json failed for %s expected:\n%s\ngot:\n%s", target, expect, got)
+			}
 		})
 	}
 }
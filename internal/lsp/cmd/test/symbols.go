@@ -5,6 +5,10 @@
 package cmdtest
 
 import (
+	"encoding/json"
+	"reflect"
+	"sort"
+	"strings"
 	"testing"
 
 	"github.com/iansmith/golang-x-tools/internal/lsp/protocol"
@@ -20,4 +24,28 @@ func (r *runner) Symbols(t *testing.T, uri span.URI, expectedSymbols []protocol.
 	if expect != got {
 		t.Errorf("symbols failed for %s expected:\n%s\ngot:\n%s", filename, expect, got)
 	}
+
+	// The JSON output lists the same symbols, with the file's hierarchy
+	// flattened.
+	out, _ := r.runGoplsCmd(t, "symbols", "-json", filename)
+	var symbols []struct{ Name, Kind, ContainerName string }
+	if err := json.Unmarshal([]byte(out), &symbols); err != nil {
+		t.Fatalf("invalid JSON output %q: %v", out, err)
+	}
+	var want, have []string
+	for _, l := range strings.Split(strings.TrimSpace(got), "\n") {
+		// The names of the symbols of groups, such as "const (A, B)", have
+		// spaces.
+		if fields := strings.Fields(l); len(fields) >= 3 {
+			want = append(want, strings.Join(fields[:len(fields)-1], " "))
+		}
+	}
+	for _, s := range symbols {
+		have = append(have, s.Name+" "+s.Kind)
+	}
+	sort.Strings(want)
+	sort.Strings(have)
+	if !reflect.DeepEqual(want, have) {
+		t.Errorf("symbols -json failed for %s: got %v, want %v", filename, have, want)
+	}
 }
@@ -20,8 +20,9 @@ import (
 
 // vulncheck implements the vulncheck command.
 type vulncheck struct {
-	Config bool `flag:"config" help:"If true, the command reads a JSON-encoded package load configuration from stdin"`
-	app    *Application
+	Config  bool `flag:"config" help:"If true, the command reads a JSON-encoded package load configuration from stdin"`
+	Offline bool `flag:"offline" help:"If true, the command never uses the network, and reads remote vulnerability databases from the cache only"`
+	app     *Application
 }
 
 type pkgLoadConfig struct {
@@ -55,6 +56,13 @@ func (v *vulncheck) DetailedHelp(f *flag.FlagSet) {
 	Example:
 	$ gopls vulncheck <packages>
 
+	The vulnerability databases are those of GOVULNDB, a comma-separated
+	list of URLs, or of directories such as a local mirror, defaulting to
+	https://vuln.go.dev. The index of a remote database is cached for
+	GOPLSVULNCACHETTL, 2h by default, before the database is asked
+	whether it changed. With -offline, or GOPLSVULNOFFLINE=true, remote
+	databases are never contacted.
+
 `)
 	printFlagDefaults(f)
 }
@@ -85,6 +93,12 @@ func (v *vulncheck) Run(ctx context.Context, args ...string) error {
 		return tool.CommandLineErrorf("vulncheck feature is not available")
 	}
 
+	if v.Offline {
+		if cfg.Env == nil {
+			cfg.Env = os.Environ()
+		}
+		cfg.Env = append(cfg.Env, "GOPLSVULNOFFLINE=true")
+	}
 	loadCfg := &packages.Config{
 		Context:    ctx,
 		Tests:      cfg.Tests,
@@ -7,6 +7,7 @@ package lsp
 import (
 	"context"
 	"fmt"
+	"go/ast"
 	"sort"
 	"strings"
 
@@ -17,6 +18,7 @@ import (
 	"github.com/iansmith/golang-x-tools/internal/lsp/mod"
 	"github.com/iansmith/golang-x-tools/internal/lsp/protocol"
 	"github.com/iansmith/golang-x-tools/internal/lsp/source"
+	"github.com/iansmith/golang-x-tools/internal/lsp/work"
 	"github.com/iansmith/golang-x-tools/internal/span"
 )
 
@@ -77,6 +79,25 @@ func (s *Server) codeAction(ctx context.Context, params *protocol.CodeActionPara
 			if err != nil {
 				return nil, err
 			}
+			if hasDiagnosticFrom(diagnostics, source.Vulnerability) {
+				vulns, err := source.VulnerabilityDiagnostics(ctx, snapshot)
+				if err != nil {
+					return nil, err
+				}
+				diags = append(diags, vulns[uri]...)
+			}
+			quickFixes, err := codeActionsMatchingDiagnostics(ctx, snapshot, diagnostics, diags)
+			if err != nil {
+				return nil, err
+			}
+			codeActions = append(codeActions, quickFixes...)
+		}
+	case source.Work:
+		if diagnostics := params.Context.Diagnostics; len(diagnostics) > 0 {
+			diags, err := work.DiagnosticsForWork(ctx, snapshot, fh)
+			if err != nil {
+				return nil, err
+			}
 			quickFixes, err := codeActionsMatchingDiagnostics(ctx, snapshot, diagnostics, diags)
 			if err != nil {
 				return nil, err
@@ -86,8 +107,12 @@ func (s *Server) codeAction(ctx context.Context, params *protocol.CodeActionPara
 	case source.Go:
 		// Don't suggest fixes for generated files, since they are generally
 		// not useful and some editors may apply them automatically on save.
+		// Only offer to navigate to their generator.
 		if source.IsGenerated(ctx, snapshot, uri) {
-			return nil, nil
+			if !wanted[protocol.GoGenerated] {
+				return nil, nil
+			}
+			return generatorActions(ctx, snapshot, uri, params.Range)
 		}
 		diagnostics := params.Context.Diagnostics
 
@@ -110,7 +135,7 @@ func (s *Server) codeAction(ctx context.Context, params *protocol.CodeActionPara
 						Title: importFixTitle(importFix.Fix),
 						Kind:  protocol.QuickFix,
 						Edit: protocol.WorkspaceEdit{
-							DocumentChanges: documentChanges(fh, importFix.Edits),
+							DocumentChanges: protocol.TextDocumentEdits(documentChanges(fh, importFix.Edits)),
 						},
 						Diagnostics: fixes,
 					})
@@ -124,7 +149,7 @@ func (s *Server) codeAction(ctx context.Context, params *protocol.CodeActionPara
 					Title: "Organize Imports",
 					Kind:  protocol.SourceOrganizeImports,
 					Edit: protocol.WorkspaceEdit{
-						DocumentChanges: documentChanges(fh, importEdits),
+						DocumentChanges: protocol.TextDocumentEdits(documentChanges(fh, importEdits)),
 					},
 				})
 			}
@@ -132,6 +157,44 @@ func (s *Server) codeAction(ctx context.Context, params *protocol.CodeActionPara
 		if ctx.Err() != nil {
 			return nil, ctx.Err()
 		}
+
+		// A file whose module is missing from go.work has no package, but can
+		// be fixed by adding the module.
+		if wanted[protocol.QuickFix] {
+			for _, pd := range diagnostics {
+				if pd.Source != string(source.WorkFileError) {
+					continue
+				}
+				d, err := work.OrphanedFileDiagnostic(ctx, snapshot, fh, pd.Range)
+				if err != nil || d == nil {
+					return codeActions, err
+				}
+				quickFixes, err := codeActionsMatchingDiagnostics(ctx, snapshot, diagnostics, []*source.Diagnostic{d})
+				if err != nil {
+					return nil, err
+				}
+				return append(codeActions, quickFixes...), nil
+			}
+		}
+
+		// An empty file has no package yet, but can be filled with a
+		// package clause.
+		if wanted[protocol.QuickFix] {
+			edits, err := source.NewFileEdits(ctx, snapshot, uri)
+			if err != nil {
+				return nil, err
+			}
+			if len(edits) > 0 {
+				return append(codeActions, protocol.CodeAction{
+					Title: "Add package clause",
+					Kind:  protocol.QuickFix,
+					Edit: protocol.WorkspaceEdit{
+						DocumentChanges: protocol.TextDocumentEdits(documentChanges(fh, edits)),
+					},
+				}), nil
+			}
+		}
+
 		pkg, err := snapshot.PackageForFile(ctx, fh.URI(), source.TypecheckFull, source.WidestPackage)
 		if err != nil {
 			return nil, err
@@ -146,6 +209,30 @@ func (s *Server) codeAction(ctx context.Context, params *protocol.CodeActionPara
 			return nil, err
 		}
 		fileDiags := append(pkgDiagnostics[uri], analysisDiags[uri]...)
+		if hasDiagnosticFrom(diagnostics, source.UnusedSymbol) {
+			unused, err := source.UnusedSymbols(ctx, snapshot)
+			if err != nil {
+				return nil, err
+			}
+			fileDiags = append(fileDiags, unused[uri]...)
+		}
+		if hasDiagnosticFrom(diagnostics, source.DuplicateCodeHint) {
+			dups, err := source.DuplicateCode(ctx, snapshot)
+			if err != nil {
+				return nil, err
+			}
+			fileDiags = append(fileDiags, dups[uri]...)
+		}
+		if hasDiagnosticFrom(diagnostics, source.Vulnerability) {
+			vulns, err := source.VulnerabilityDiagnostics(ctx, snapshot)
+			if err != nil {
+				return nil, err
+			}
+			fileDiags = append(fileDiags, vulns[uri]...)
+		}
+		// The external analyzers are not rerun: their diagnostics are those
+		// last published, as they are for the files on disk.
+		fileDiags = append(fileDiags, s.externalDiagnostics(uri)...)
 
 		// Split diagnostics into fixes, which must match incoming diagnostics,
 		// and non-fixes, which must match the requested range. Build actions
@@ -196,6 +283,51 @@ func (s *Server) codeAction(ctx context.Context, params *protocol.CodeActionPara
 			codeActions = append(codeActions, fixes...)
 		}
 
+		if wanted[protocol.RefactorInline] {
+			fixes, err := inlineFixes(ctx, snapshot, pkg, uri, params.Range)
+			if err != nil {
+				return nil, err
+			}
+			codeActions = append(codeActions, fixes...)
+		}
+
+		if wanted[protocol.RefactorRewrite] {
+			fixes, err := rewriteFixes(ctx, snapshot, pkg, uri, params.Range)
+			if err != nil {
+				return nil, err
+			}
+			codeActions = append(codeActions, fixes...)
+		}
+
+		if wanted[protocol.QuickFix] {
+			fixes, err := wrapErrorFixes(ctx, snapshot, pkg, uri, params.Range)
+			if err != nil {
+				return nil, err
+			}
+			codeActions = append(codeActions, fixes...)
+		}
+
+		if wanted[protocol.SourceGenerate] {
+			fixes, err := generateStructFixes(ctx, snapshot, pkg, uri, params.Range)
+			if err != nil {
+				return nil, err
+			}
+			codeActions = append(codeActions, fixes...)
+			fixes, err = generateEnumFixes(pkg, uri, params.Range)
+			if err != nil {
+				return nil, err
+			}
+			codeActions = append(codeActions, fixes...)
+		}
+
+		if wanted[protocol.SourceGenerateTest] {
+			fixes, err := generateTestFixes(ctx, snapshot, uri, params.Range)
+			if err != nil {
+				return nil, err
+			}
+			codeActions = append(codeActions, fixes...)
+		}
+
 		if wanted[protocol.GoTest] {
 			fixes, err := goTest(ctx, snapshot, uri, params.Range)
 			if err != nil {
@@ -204,6 +336,22 @@ func (s *Server) codeAction(ctx context.Context, params *protocol.CodeActionPara
 			codeActions = append(codeActions, fixes...)
 		}
 
+		if wanted[protocol.GoGenerated] {
+			actions, err := generatorActions(ctx, snapshot, uri, params.Range)
+			if err != nil {
+				return nil, err
+			}
+			codeActions = append(codeActions, actions...)
+		}
+
+		if wanted[protocol.Explain] {
+			actions, err := s.explainDiagnostics(uri, diagnostics)
+			if err != nil {
+				return nil, err
+			}
+			codeActions = append(codeActions, actions...)
+		}
+
 	default:
 		// Unsupported file kind for a code action.
 		return nil, nil
@@ -277,9 +425,6 @@ func importDiagnostics(fix *imports.ImportFix, diagnostics []protocol.Diagnostic
 }
 
 func extractionFixes(ctx context.Context, snapshot source.Snapshot, pkg source.Package, uri span.URI, rng protocol.Range) ([]protocol.CodeAction, error) {
-	if rng.Start == rng.End {
-		return nil, nil
-	}
 	fh, err := snapshot.GetFile(ctx, uri)
 	if err != nil {
 		return nil, err
@@ -294,6 +439,20 @@ func extractionFixes(ctx context.Context, snapshot source.Snapshot, pkg source.P
 	}
 	puri := protocol.URIFromSpanURI(uri)
 	var commands []protocol.Command
+	if source.CanExtractInterface(pkg, pgf.File, srng) {
+		cmd, err := command.NewApplyFixCommand("Extract interface", command.ApplyFixArgs{
+			URI:   puri,
+			Fix:   source.ExtractInterface,
+			Range: rng,
+		})
+		if err != nil {
+			return nil, err
+		}
+		commands = append(commands, cmd)
+	}
+	if rng.Start == rng.End {
+		return actionsForCommands(commands), nil
+	}
 	if _, ok, methodOk, _ := source.CanExtractFunction(snapshot.FileSet(), srng, pgf.Src, pgf.File); ok {
 		cmd, err := command.NewApplyFixCommand("Extract function", command.ApplyFixArgs{
 			URI:   puri,
@@ -327,6 +486,12 @@ func extractionFixes(ctx context.Context, snapshot source.Snapshot, pkg source.P
 		}
 		commands = append(commands, cmd)
 	}
+	return actionsForCommands(commands), nil
+}
+
+// actionsForCommands returns the refactor.extract code actions that
+// run commands.
+func actionsForCommands(commands []protocol.Command) []protocol.CodeAction {
 	var actions []protocol.CodeAction
 	for i := range commands {
 		actions = append(actions, protocol.CodeAction{
@@ -335,10 +500,228 @@ func extractionFixes(ctx context.Context, snapshot source.Snapshot, pkg source.P
 			Command: &commands[i],
 		})
 	}
+	return actions
+}
+
+func inlineFixes(ctx context.Context, snapshot source.Snapshot, pkg source.Package, uri span.URI, rng protocol.Range) ([]protocol.CodeAction, error) {
+	fh, err := snapshot.GetFile(ctx, uri)
+	if err != nil {
+		return nil, err
+	}
+	_, pgf, err := source.GetParsedFile(ctx, snapshot, fh, source.NarrowestPackage)
+	if err != nil {
+		return nil, fmt.Errorf("getting file for Identifier: %w", err)
+	}
+	srng, err := pgf.Mapper.RangeToSpanRange(rng)
+	if err != nil {
+		return nil, err
+	}
+	if !source.CanInlineCall(pkg, pgf.File, srng) {
+		return nil, nil
+	}
+	cmd, err := command.NewApplyFixCommand("Inline call", command.ApplyFixArgs{
+		URI:   protocol.URIFromSpanURI(uri),
+		Fix:   source.InlineCall,
+		Range: rng,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return []protocol.CodeAction{{
+		Title:   cmd.Title,
+		Kind:    protocol.RefactorInline,
+		Command: &cmd,
+	}}, nil
+}
+
+func wrapErrorFixes(ctx context.Context, snapshot source.Snapshot, pkg source.Package, uri span.URI, rng protocol.Range) ([]protocol.CodeAction, error) {
+	pgf, err := pkg.File(uri)
+	if err != nil {
+		return nil, err
+	}
+	srng, err := pgf.Mapper.RangeToSpanRange(rng)
+	if err != nil {
+		return nil, err
+	}
+	if !source.CanWrapError(pkg, pgf.File, srng) {
+		return nil, nil
+	}
+	cmd, err := command.NewApplyFixCommand("Wrap error with fmt.Errorf", command.ApplyFixArgs{
+		URI:   protocol.URIFromSpanURI(uri),
+		Fix:   source.WrapError,
+		Range: rng,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return []protocol.CodeAction{{
+		Title:   cmd.Title,
+		Kind:    protocol.QuickFix,
+		Command: &cmd,
+	}}, nil
+}
+
+func rewriteFixes(ctx context.Context, snapshot source.Snapshot, pkg source.Package, uri span.URI, rng protocol.Range) ([]protocol.CodeAction, error) {
+	pgf, err := pkg.File(uri)
+	if err != nil {
+		return nil, err
+	}
+	srng, err := pgf.Mapper.RangeToSpanRange(rng)
+	if err != nil {
+		return nil, err
+	}
+	puri := protocol.URIFromSpanURI(uri)
+	var commands []protocol.Command
+	if source.CanNameStruct(pkg, pgf.File, srng) {
+		cmd, err := command.NewApplyFixCommand("Convert anonymous struct to named type", command.ApplyFixArgs{
+			URI:   puri,
+			Fix:   source.NameStruct,
+			Range: rng,
+		})
+		if err != nil {
+			return nil, err
+		}
+		commands = append(commands, cmd)
+	}
+	if pointer, ok := source.CanToggleReceiver(pkg, pgf.File, srng); ok {
+		title := "Convert to pointer receiver"
+		if pointer {
+			title = "Convert to value receiver"
+		}
+		cmd, err := command.NewApplyFixCommand(title, command.ApplyFixArgs{
+			URI:   puri,
+			Fix:   source.ToggleReceiver,
+			Range: rng,
+		})
+		if err != nil {
+			return nil, err
+		}
+		commands = append(commands, cmd)
+	}
+	if kind, name, ok := source.CanSafeDelete(pkg, pgf, srng); ok {
+		cmd, err := command.NewApplyFixCommand(fmt.Sprintf("Safe delete %s %s", kind, name), command.ApplyFixArgs{
+			URI:   puri,
+			Fix:   source.SafeDelete,
+			Range: rng,
+		})
+		if err != nil {
+			return nil, err
+		}
+		commands = append(commands, cmd)
+	}
+	for _, rewrite := range []struct {
+		title string
+		fix   string
+		can   func(source.Package, *ast.File, span.Range) bool
+	}{
+		{"Convert to switch statement", source.IfToSwitch, source.CanConvertIfToSwitch},
+		{"Convert to if statements", source.SwitchToIf, source.CanConvertSwitchToIf},
+		{"Invert if condition", source.InvertIf, source.CanInvertIf},
+		{"Merge nested if statements", source.MergeIfs, source.CanMergeIfs},
+	} {
+		if !rewrite.can(pkg, pgf.File, srng) {
+			continue
+		}
+		cmd, err := command.NewApplyFixCommand(rewrite.title, command.ApplyFixArgs{
+			URI:   puri,
+			Fix:   rewrite.fix,
+			Range: rng,
+		})
+		if err != nil {
+			return nil, err
+		}
+		commands = append(commands, cmd)
+	}
+	for _, rewrite := range []struct {
+		title string
+		fix   string
+		can   func(source.Package, *source.ParsedGoFile, span.Range) bool
+	}{
+		{"Convert to fmt.Sprintf", source.ConcatToSprintf, source.CanConvertToSprintf},
+		{"Convert to string concatenation", source.SprintfToConcat, source.CanConvertToConcat},
+	} {
+		if !rewrite.can(pkg, pgf, srng) {
+			continue
+		}
+		cmd, err := command.NewApplyFixCommand(rewrite.title, command.ApplyFixArgs{
+			URI:   puri,
+			Fix:   rewrite.fix,
+			Range: rng,
+		})
+		if err != nil {
+			return nil, err
+		}
+		commands = append(commands, cmd)
+	}
+	tagCommands, err := structTagCommands(snapshot.View().Options().StructTags, pgf.File, puri, rng, srng)
+	if err != nil {
+		return nil, err
+	}
+	commands = append(commands, tagCommands...)
+	var actions []protocol.CodeAction
+	for i := range commands {
+		actions = append(actions, protocol.CodeAction{
+			Title:   commands[i].Title,
+			Kind:    protocol.RefactorRewrite,
+			Command: &commands[i],
+		})
+	}
 	return actions, nil
 }
 
+// structTagCommands returns the commands that add, update and remove the
+// tags, of the encodings of the structTags setting, of the fields of the
+// struct type at rng. The tags are added in the configured naming
+// convention of their encoding, or in the other one.
+func structTagCommands(conventions map[string]string, file *ast.File, uri protocol.DocumentURI, rng protocol.Range, srng span.Range) ([]protocol.Command, error) {
+	var encodings []string
+	for encoding := range conventions {
+		encodings = append(encodings, encoding)
+	}
+	sort.Strings(encodings)
+	missing, tagged := source.StructTagActions(file, srng, encodings)
+	var commands []protocol.Command
+	add := func(title, action, encoding, convention string) error {
+		cmd, err := command.NewModifyTagsCommand(title, command.ModifyTagsArgs{
+			URI:        uri,
+			Range:      rng,
+			Action:     action,
+			Encoding:   encoding,
+			Convention: convention,
+		})
+		if err != nil {
+			return err
+		}
+		commands = append(commands, cmd)
+		return nil
+	}
+	for _, encoding := range missing {
+		convention, other := conventions[encoding], source.CamelCase
+		if convention == source.CamelCase {
+			other = source.SnakeCase
+		}
+		for _, c := range []string{convention, other} {
+			if err := add(fmt.Sprintf("Add %s tags (%s)", encoding, c), source.AddTags, encoding, c); err != nil {
+				return nil, err
+			}
+		}
+	}
+	for _, encoding := range tagged {
+		if err := add(fmt.Sprintf("Update %s tags (%s)", encoding, conventions[encoding]), source.UpdateTags, encoding, conventions[encoding]); err != nil {
+			return nil, err
+		}
+		if err := add(fmt.Sprintf("Remove %s tags", encoding), source.RemoveTags, encoding, ""); err != nil {
+			return nil, err
+		}
+	}
+	return commands, nil
+}
+
 func documentChanges(fh source.VersionedFileHandle, edits []protocol.TextEdit) []protocol.TextDocumentEdit {
+	// A file that is about to be created has no content.
+	if content, err := fh.Read(); err == nil {
+		edits = source.PreserveFileFormat(content, edits)
+	}
 	return []protocol.TextDocumentEdit{
 		{
 			TextDocument: protocol.OptionalVersionedTextDocumentIdentifier{
@@ -347,7 +730,7 @@ func documentChanges(fh source.VersionedFileHandle, edits []protocol.TextEdit) [
 					URI: protocol.URIFromSpanURI(fh.URI()),
 				},
 			},
-			Edits: edits,
+			Edits: protocol.AsAnnotatedTextEdits(edits),
 		},
 	}
 }
@@ -391,17 +774,18 @@ func codeActionsForDiagnostic(ctx context.Context, snapshot source.Snapshot, sd
 						URI: protocol.URIFromSpanURI(uri),
 					},
 				},
-				Edits: edits,
+				Edits: protocol.AsAnnotatedTextEdits(edits),
 			})
 		}
 		action := protocol.CodeAction{
 			Title: fix.Title,
 			Kind:  fix.ActionKind,
 			Edit: protocol.WorkspaceEdit{
-				DocumentChanges: changes,
+				DocumentChanges: protocol.TextDocumentEdits(changes),
 			},
 			Command: fix.Command,
 		}
+		annotateLargeEdit(snapshot.View().Options(), &action.Edit, fix.Title)
 		if pd != nil {
 			action.Diagnostics = []protocol.Diagnostic{*pd}
 		}
@@ -410,10 +794,166 @@ func codeActionsForDiagnostic(ctx context.Context, snapshot source.Snapshot, sd
 	return actions, nil
 }
 
+// hasDiagnosticFrom reports whether any of diags is from the given source.
+func hasDiagnosticFrom(diags []protocol.Diagnostic, src source.DiagnosticSource) bool {
+	for _, d := range diags {
+		if d.Source == string(src) {
+			return true
+		}
+	}
+	return false
+}
+
+// explainDiagnostics returns a code action that explains the origin of each
+// of the diagnostics that gopls published for uri.
+func (s *Server) explainDiagnostics(uri span.URI, diagnostics []protocol.Diagnostic) ([]protocol.CodeAction, error) {
+	var actions []protocol.CodeAction
+	for _, pd := range diagnostics {
+		if s.publishedDiagnostic(uri, pd) == nil {
+			continue
+		}
+		cmd, err := command.NewExplainDiagnosticCommand("Explain this diagnostic", command.ExplainDiagnosticArgs{
+			URI:     protocol.URIFromSpanURI(uri),
+			Range:   pd.Range,
+			Source:  pd.Source,
+			Message: pd.Message,
+		})
+		if err != nil {
+			return nil, err
+		}
+		actions = append(actions, protocol.CodeAction{
+			Title:       cmd.Title,
+			Kind:        protocol.Explain,
+			Command:     &cmd,
+			Diagnostics: []protocol.Diagnostic{pd},
+		})
+	}
+	return actions, nil
+}
+
 func sameDiagnostic(pd protocol.Diagnostic, sd *source.Diagnostic) bool {
 	return pd.Message == sd.Message && protocol.CompareRange(pd.Range, sd.Range) == 0 && pd.Source == string(sd.Source)
 }
 
+func generateStructFixes(ctx context.Context, snapshot source.Snapshot, pkg source.Package, uri span.URI, rng protocol.Range) ([]protocol.CodeAction, error) {
+	pgf, err := pkg.File(uri)
+	if err != nil {
+		return nil, err
+	}
+	srng, err := pgf.Mapper.RangeToSpanRange(rng)
+	if err != nil {
+		return nil, err
+	}
+	name, fixes := source.StructGenerators(snapshot.FileSet(), pgf.Src, pgf.File, pkg.GetTypes(), pkg.GetTypesInfo(), srng)
+	var actions []protocol.CodeAction
+	for _, fix := range fixes {
+		var title string
+		switch fix {
+		case source.GenerateConstructor:
+			title = fmt.Sprintf("Generate a constructor for %s", name)
+		case source.GenerateOptions:
+			title = fmt.Sprintf("Generate a constructor with options for %s", name)
+		case source.GenerateGetters:
+			title = fmt.Sprintf("Generate getters for %s", name)
+		case source.GenerateSetters:
+			title = fmt.Sprintf("Generate setters for %s", name)
+		}
+		cmd, err := command.NewApplyFixCommand(title, command.ApplyFixArgs{
+			URI:   protocol.URIFromSpanURI(uri),
+			Fix:   fix,
+			Range: rng,
+		})
+		if err != nil {
+			return nil, err
+		}
+		actions = append(actions, protocol.CodeAction{
+			Title:   cmd.Title,
+			Kind:    protocol.SourceGenerate,
+			Command: &cmd,
+		})
+	}
+	return actions, nil
+}
+
+func generateEnumFixes(pkg source.Package, uri span.URI, rng protocol.Range) ([]protocol.CodeAction, error) {
+	pgf, err := pkg.File(uri)
+	if err != nil {
+		return nil, err
+	}
+	srng, err := pgf.Mapper.RangeToSpanRange(rng)
+	if err != nil {
+		return nil, err
+	}
+	name, ok := source.CanGenerateEnumMethods(pkg, pgf.File, srng)
+	if !ok {
+		return nil, nil
+	}
+	cmd, err := command.NewGenerateEnumMethodsCommand(fmt.Sprintf("Generate String, MarshalText, and Parse for %s", name), command.GenerateEnumMethodsArgs{
+		URI:   protocol.URIFromSpanURI(uri),
+		Range: rng,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return []protocol.CodeAction{{
+		Title:   cmd.Title,
+		Kind:    protocol.SourceGenerate,
+		Command: &cmd,
+	}}, nil
+}
+
+func generateTestFixes(ctx context.Context, snapshot source.Snapshot, uri span.URI, rng protocol.Range) ([]protocol.CodeAction, error) {
+	if strings.HasSuffix(uri.Filename(), "_test.go") {
+		return nil, nil
+	}
+	fh, err := snapshot.GetFile(ctx, uri)
+	if err != nil {
+		return nil, err
+	}
+	_, pgf, err := source.GetParsedFile(ctx, snapshot, fh, source.NarrowestPackage)
+	if err != nil {
+		return nil, fmt.Errorf("getting file for Identifier: %w", err)
+	}
+	srng, err := pgf.Mapper.RangeToSpanRange(rng)
+	if err != nil {
+		return nil, err
+	}
+	if !source.CanGenerateTest(pgf.File, srng) {
+		return nil, nil
+	}
+	cmd, err := command.NewGenerateTestCommand("Generate a test", command.GenerateTestArgs{
+		URI:   protocol.URIFromSpanURI(uri),
+		Range: rng,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return []protocol.CodeAction{{
+		Title:   cmd.Title,
+		Kind:    protocol.SourceGenerateTest,
+		Command: &cmd,
+	}}, nil
+}
+
+// generatorActions returns the code actions that navigate from rng in
+// the file uri to the generator of the file, or to the files generated by
+// a //go:generate directive.
+func generatorActions(ctx context.Context, snapshot source.Snapshot, uri span.URI, rng protocol.Range) ([]protocol.CodeAction, error) {
+	commands, err := source.GeneratorCommands(ctx, snapshot, uri, rng)
+	if err != nil {
+		return nil, err
+	}
+	var actions []protocol.CodeAction
+	for i := range commands {
+		actions = append(actions, protocol.CodeAction{
+			Title:   commands[i].Title,
+			Kind:    protocol.GoGenerated,
+			Command: &commands[i],
+		})
+	}
+	return actions, nil
+}
+
 func goTest(ctx context.Context, snapshot source.Snapshot, uri span.URI, rng protocol.Range) ([]protocol.CodeAction, error) {
 	fh, err := snapshot.GetFile(ctx, uri)
 	if err != nil {
@@ -424,7 +964,7 @@ func goTest(ctx context.Context, snapshot source.Snapshot, uri span.URI, rng pro
 		return nil, err
 	}
 
-	var tests, benchmarks []string
+	var tests, benchmarks, fuzz []string
 	for _, fn := range fns.Tests {
 		if !protocol.Intersect(fn.Rng, rng) {
 			continue
@@ -437,12 +977,23 @@ func goTest(ctx context.Context, snapshot source.Snapshot, uri span.URI, rng pro
 		}
 		benchmarks = append(benchmarks, fn.Name)
 	}
+	for _, fn := range fns.Fuzz {
+		if !protocol.Intersect(fn.Rng, rng) {
+			continue
+		}
+		fuzz = append(fuzz, fn.Name)
+	}
 
-	if len(tests) == 0 && len(benchmarks) == 0 {
+	if len(tests) == 0 && len(benchmarks) == 0 && len(fuzz) == 0 {
 		return nil, nil
 	}
 
-	cmd, err := command.NewTestCommand("Run tests and benchmarks", protocol.URIFromSpanURI(uri), tests, benchmarks)
+	cmd, err := command.NewRunTestsCommand("Run tests and benchmarks", command.RunTestsArgs{
+		URI:        protocol.URIFromSpanURI(uri),
+		Tests:      tests,
+		Benchmarks: benchmarks,
+		Fuzz:       fuzz,
+	})
 	if err != nil {
 		return nil, err
 	}
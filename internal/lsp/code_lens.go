@@ -14,6 +14,7 @@ import (
 	"github.com/iansmith/golang-x-tools/internal/lsp/mod"
 	"github.com/iansmith/golang-x-tools/internal/lsp/protocol"
 	"github.com/iansmith/golang-x-tools/internal/lsp/source"
+	"github.com/iansmith/golang-x-tools/internal/lsp/work"
 )
 
 func (s *Server) codeLens(ctx context.Context, params *protocol.CodeLensParams) ([]protocol.CodeLens, error) {
@@ -28,6 +29,8 @@ func (s *Server) codeLens(ctx context.Context, params *protocol.CodeLensParams)
 		lenses = mod.LensFuncs()
 	case source.Go:
 		lenses = source.LensFuncs()
+	case source.Work:
+		lenses = work.LensFuncs()
 	default:
 		// Unsupported file kind for a code lens.
 		return nil, nil
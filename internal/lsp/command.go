@@ -18,15 +18,18 @@ import (
 	"strings"
 
 	"golang.org/x/mod/modfile"
+	"github.com/iansmith/golang-x-tools/cover"
 	"github.com/iansmith/golang-x-tools/go/ast/astutil"
 	"github.com/iansmith/golang-x-tools/go/packages"
 	"github.com/iansmith/golang-x-tools/internal/event"
 	"github.com/iansmith/golang-x-tools/internal/gocommand"
 	"github.com/iansmith/golang-x-tools/internal/lsp/command"
 	"github.com/iansmith/golang-x-tools/internal/lsp/debug"
+	"github.com/iansmith/golang-x-tools/internal/lsp/diff"
 	"github.com/iansmith/golang-x-tools/internal/lsp/progress"
 	"github.com/iansmith/golang-x-tools/internal/lsp/protocol"
 	"github.com/iansmith/golang-x-tools/internal/lsp/source"
+	"github.com/iansmith/golang-x-tools/internal/lsp/source/completion"
 	"github.com/iansmith/golang-x-tools/internal/span"
 	"github.com/iansmith/golang-x-tools/internal/xcontext"
 )
@@ -144,10 +147,12 @@ func (c *commandHandler) ApplyFix(ctx context.Context, args command.ApplyFixArgs
 		if err != nil {
 			return err
 		}
+		edit := protocol.WorkspaceEdit{
+			DocumentChanges: protocol.TextDocumentEdits(edits),
+		}
+		annotateLargeEdit(deps.snapshot.View().Options(), &edit, "Apply fix "+args.Fix)
 		r, err := c.s.client.ApplyEdit(ctx, &protocol.ApplyWorkspaceEditParams{
-			Edit: protocol.WorkspaceEdit{
-				DocumentChanges: edits,
-			},
+			Edit: edit,
 		})
 		if err != nil {
 			return err
@@ -182,7 +187,7 @@ func (c *commandHandler) CheckUpgrades(ctx context.Context, args command.CheckUp
 		}
 		deps.snapshot.View().RegisterModuleUpgrades(upgrades)
 		// Re-diagnose the snapshot to publish the new module diagnostics.
-		c.s.diagnoseSnapshot(deps.snapshot, nil, false)
+		c.s.diagnoseSnapshot(deps.snapshot, nil, FromDidChange)
 		return nil
 	})
 }
@@ -228,6 +233,21 @@ func (c *commandHandler) UpdateGoSum(ctx context.Context, args command.URIArgs)
 	})
 }
 
+func (c *commandHandler) AddGoSumEntries(ctx context.Context, args command.GoSumEntriesArgs) error {
+	return c.run(ctx, commandConfig{
+		progress: "Adding go.sum entries",
+		forURI:   args.URI,
+	}, func(ctx context.Context, deps commandDeps) error {
+		if len(args.Modules) == 0 {
+			return nil
+		}
+		return c.s.runGoModUpdateCommands(ctx, deps.snapshot, args.URI.SpanURI(), func(invoke func(...string) (*bytes.Buffer, error)) error {
+			_, err := invoke(append([]string{"mod", "download"}, args.Modules...)...)
+			return err
+		})
+	})
+}
+
 func (c *commandHandler) Tidy(ctx context.Context, args command.URIArgs) error {
 	return c.run(ctx, commandConfig{
 		requireSave: true,
@@ -273,6 +293,23 @@ func (c *commandHandler) Vendor(ctx context.Context, args command.URIArg) error
 	})
 }
 
+func (c *commandHandler) WorkSync(ctx context.Context, args command.URIArg) error {
+	return c.run(ctx, commandConfig{
+		requireSave: true,
+		progress:    "Running go work sync",
+		forURI:      args.URI,
+	}, func(ctx context.Context, deps commandDeps) error {
+		// go work sync rewrites the go.mod files of the workspace modules on
+		// disk; the resulting file changes are picked up by the file watcher.
+		_, err := deps.snapshot.RunGoCommandDirect(ctx, source.Normal|source.AllowNetwork, &gocommand.Invocation{
+			Verb:       "work",
+			Args:       []string{"sync"},
+			WorkingDir: filepath.Dir(args.URI.SpanURI().Filename()),
+		})
+		return err
+	})
+}
+
 func (c *commandHandler) EditGoDirective(ctx context.Context, args command.EditGoDirectiveArgs) error {
 	return c.run(ctx, commandConfig{
 		requireSave: true, // if go.mod isn't saved it could cause a problem
@@ -322,15 +359,15 @@ func (c *commandHandler) RemoveDependency(ctx context.Context, args command.Remo
 		}
 		response, err := c.s.client.ApplyEdit(ctx, &protocol.ApplyWorkspaceEditParams{
 			Edit: protocol.WorkspaceEdit{
-				DocumentChanges: []protocol.TextDocumentEdit{{
+				DocumentChanges: protocol.TextDocumentEdits([]protocol.TextDocumentEdit{{
 					TextDocument: protocol.OptionalVersionedTextDocumentIdentifier{
 						Version: deps.fh.Version(),
 						TextDocumentIdentifier: protocol.TextDocumentIdentifier{
 							URI: protocol.URIFromSpanURI(deps.fh.URI()),
 						},
 					},
-					Edits: edits,
-				}},
+					Edits: protocol.AsAnnotatedTextEdits(edits),
+				}}),
 			},
 		})
 		if err != nil {
@@ -383,14 +420,14 @@ func (c *commandHandler) RunTests(ctx context.Context, args command.RunTestsArgs
 		requireSave: true,
 		forURI:      args.URI,
 	}, func(ctx context.Context, deps commandDeps) error {
-		if err := c.runTests(ctx, deps.snapshot, deps.work, args.URI, args.Tests, args.Benchmarks); err != nil {
+		if err := c.runTests(ctx, deps.snapshot, deps.work, args.URI, args.Tests, args.Benchmarks, args.Fuzz); err != nil {
 			return fmt.Errorf("running tests failed: %w", err)
 		}
 		return nil
 	})
 }
 
-func (c *commandHandler) runTests(ctx context.Context, snapshot source.Snapshot, work *progress.WorkDone, uri protocol.DocumentURI, tests, benchmarks []string) error {
+func (c *commandHandler) runTests(ctx context.Context, snapshot source.Snapshot, work *progress.WorkDone, uri protocol.DocumentURI, tests, benchmarks, fuzz []string) error {
 	// TODO: fix the error reporting when this runs async.
 	pkgs, err := snapshot.PackagesForFile(ctx, uri.SpanURI(), source.TypecheckWorkspace, false)
 	if err != nil {
@@ -404,20 +441,45 @@ func (c *commandHandler) runTests(ctx context.Context, snapshot source.Snapshot,
 	// create output
 	buf := &bytes.Buffer{}
 	ew := progress.NewEventWriter(ctx, "test")
-	out := io.MultiWriter(ew, progress.NewWorkDoneWriter(work), buf)
+	out := io.MultiWriter(ew, progress.NewWorkDoneWriter(ctx, work), &logWriter{ctx: ctx, client: c.s.client}, buf)
 
-	// Run `go test -run Func` on each test.
-	var failedTests int
-	for _, funcName := range tests {
+	// run runs go test with the given arguments for the function named
+	// funcName, and reports whether it failed.
+	results := make(map[string]*testResult)
+	run := func(funcName string, args ...string) (bool, error) {
 		inv := &gocommand.Invocation{
 			Verb:       "test",
-			Args:       []string{pkgPath, "-v", "-count=1", "-run", fmt.Sprintf("^%s$", funcName)},
+			Args:       append([]string{pkgPath, "-json"}, args...),
 			WorkingDir: filepath.Dir(uri.SpanURI().Filename()),
 		}
-		if err := snapshot.RunGoCommandPiped(ctx, source.Normal, inv, out, out); err != nil {
-			if errors.Is(err, context.Canceled) {
-				return err
-			}
+		runOut := &bytes.Buffer{}
+		stdout := &testEventWriter{out: io.MultiWriter(out, runOut), results: results}
+		err := snapshot.RunGoCommandPiped(ctx, source.Normal, inv, stdout, io.MultiWriter(out, runOut))
+		stdout.flush()
+		if errors.Is(err, context.Canceled) {
+			return false, err
+		}
+		// Benchmarks, and tests that did not build, have no result event.
+		r := results[funcName]
+		if r == nil {
+			r = &testResult{}
+			r.output.Write(runOut.Bytes())
+			results[funcName] = r
+		}
+		if err != nil {
+			r.failed = true
+		}
+		return r.failed, nil
+	}
+
+	// Run `go test -run Func` on each test and fuzz target.
+	var failedTests int
+	for _, funcName := range append(append([]string(nil), tests...), fuzz...) {
+		failed, err := run(funcName, "-count=1", "-run", fmt.Sprintf("^%s$", funcName))
+		if err != nil {
+			return err
+		}
+		if failed {
 			failedTests++
 		}
 	}
@@ -425,19 +487,20 @@ func (c *commandHandler) runTests(ctx context.Context, snapshot source.Snapshot,
 	// Run `go test -run=^$ -bench Func` on each test.
 	var failedBenchmarks int
 	for _, funcName := range benchmarks {
-		inv := &gocommand.Invocation{
-			Verb:       "test",
-			Args:       []string{pkgPath, "-v", "-run=^$", "-bench", fmt.Sprintf("^%s$", funcName)},
-			WorkingDir: filepath.Dir(uri.SpanURI().Filename()),
+		failed, err := run(funcName, "-run=^$", "-bench", fmt.Sprintf("^%s$", funcName))
+		if err != nil {
+			return err
 		}
-		if err := snapshot.RunGoCommandPiped(ctx, source.Normal, inv, out, out); err != nil {
-			if errors.Is(err, context.Canceled) {
-				return err
-			}
+		if failed {
 			failedBenchmarks++
 		}
 	}
 
+	if err := c.s.publishTestResults(ctx, snapshot, uri.SpanURI(), results); err != nil {
+		event.Error(ctx, "publishing test results", err)
+	}
+
+	tests = append(tests, fuzz...)
 	var title string
 	if len(tests) > 0 && len(benchmarks) > 0 {
 		title = "tests and benchmarks"
@@ -466,11 +529,100 @@ func (c *commandHandler) runTests(ctx context.Context, snapshot source.Snapshot,
 	})
 }
 
+func (c *commandHandler) RunTestsWithCoverage(ctx context.Context, args command.RunTestsArgs) error {
+	return c.run(ctx, commandConfig{
+		async:       true,
+		progress:    "Running go test with coverage",
+		requireSave: true,
+		forURI:      args.URI,
+	}, func(ctx context.Context, deps commandDeps) error {
+		if err := c.runTestsWithCoverage(ctx, deps.snapshot, deps.work, args); err != nil {
+			return fmt.Errorf("running tests with coverage failed: %w", err)
+		}
+		return nil
+	})
+}
+
+func (c *commandHandler) runTestsWithCoverage(ctx context.Context, snapshot source.Snapshot, work *progress.WorkDone, args command.RunTestsArgs) error {
+	uri := args.URI.SpanURI()
+	pkgs, err := snapshot.PackagesForFile(ctx, uri, source.TypecheckWorkspace, false)
+	if err != nil {
+		return err
+	}
+	if len(pkgs) == 0 {
+		return fmt.Errorf("package could not be found for file: %s", uri.Filename())
+	}
+	pkgPath := pkgs[0].ForTest()
+	if pkgPath == "" {
+		pkgPath = pkgs[0].PkgPath()
+	}
+
+	profile, err := ioutil.TempFile("", "gopls-coverage")
+	if err != nil {
+		return err
+	}
+	profile.Close()
+	defer os.Remove(profile.Name())
+
+	invArgs := []string{pkgPath, "-v", "-count=1", "-coverprofile", profile.Name()}
+	tests := append(append([]string(nil), args.Tests...), args.Fuzz...)
+	if len(tests) > 0 {
+		invArgs = append(invArgs, "-run", fmt.Sprintf("^(%s)$", strings.Join(tests, "|")))
+	} else if len(args.Benchmarks) > 0 {
+		invArgs = append(invArgs, "-run=^$")
+	}
+	if len(args.Benchmarks) > 0 {
+		invArgs = append(invArgs, "-bench", fmt.Sprintf("^(%s)$", strings.Join(args.Benchmarks, "|")))
+	}
+	inv := &gocommand.Invocation{
+		Verb:       "test",
+		Args:       invArgs,
+		WorkingDir: filepath.Dir(uri.Filename()),
+	}
+	buf := &bytes.Buffer{}
+	ew := progress.NewEventWriter(ctx, "test")
+	out := io.MultiWriter(ew, progress.NewWorkDoneWriter(ctx, work), &logWriter{ctx: ctx, client: c.s.client}, buf)
+	runErr := snapshot.RunGoCommandPiped(ctx, source.Normal, inv, out, out)
+	if errors.Is(runErr, context.Canceled) {
+		return runErr
+	}
+
+	// The tests write a profile even if some of them fail.
+	profiles, err := cover.ParseProfiles(profile.Name())
+	if err != nil || len(profiles) == 0 {
+		if runErr != nil {
+			return fmt.Errorf("%v\n%s", runErr, buf.String())
+		}
+		return fmt.Errorf("reading coverage profile: %v", err)
+	}
+	reports, err := source.CoverageDiagnostics(ctx, snapshot, filepath.Dir(uri.Filename()), pkgPath, profiles)
+	if err != nil {
+		return err
+	}
+	c.s.clearDiagnosticSource(coverageSource)
+	for uri, diags := range reports {
+		c.s.storeDiagnostics(snapshot, uri, coverageSource, diags)
+	}
+	c.s.publishDiagnostics(ctx, true, snapshot)
+
+	message := fmt.Sprintf("coverage: %.1f%% of statements", source.CoveragePercent(profiles))
+	if runErr != nil {
+		message = "tests failed, " + message + "\n" + buf.String()
+	}
+	return c.s.client.ShowMessage(ctx, &protocol.ShowMessageParams{
+		Type:    protocol.Info,
+		Message: message,
+	})
+}
+
 func (c *commandHandler) Generate(ctx context.Context, args command.GenerateArgs) error {
 	title := "Running go generate ."
 	if args.Recursive {
 		title = "Running go generate ./..."
 	}
+	if args.Run != "" {
+		title = "Running generator"
+	}
 	return c.run(ctx, commandConfig{
 		requireSave: true,
 		progress:    title,
@@ -482,12 +634,16 @@ func (c *commandHandler) Generate(ctx context.Context, args command.GenerateArgs
 		if args.Recursive {
 			pattern = "./..."
 		}
+		cmdArgs := []string{"-x"}
+		if args.Run != "" {
+			cmdArgs = append(cmdArgs, "-run", args.Run)
+		}
 		inv := &gocommand.Invocation{
 			Verb:       "generate",
-			Args:       []string{"-x", pattern},
+			Args:       append(cmdArgs, pattern),
 			WorkingDir: args.Dir.SpanURI().Filename(),
 		}
-		stderr := io.MultiWriter(er, progress.NewWorkDoneWriter(deps.work))
+		stderr := io.MultiWriter(er, progress.NewWorkDoneWriter(ctx, deps.work))
 		if err := deps.snapshot.RunGoCommandPiped(ctx, source.Normal, inv, er, stderr); err != nil {
 			return err
 		}
@@ -546,7 +702,7 @@ func (s *Server) runGoModUpdateCommands(ctx context.Context, snapshot source.Sna
 	}
 	response, err := s.client.ApplyEdit(ctx, &protocol.ApplyWorkspaceEditParams{
 		Edit: protocol.WorkspaceEdit{
-			DocumentChanges: changes,
+			DocumentChanges: protocol.TextDocumentEdits(changes),
 		},
 	})
 	if err != nil {
@@ -595,7 +751,7 @@ func applyFileEdits(ctx context.Context, snapshot source.Snapshot, uri span.URI,
 				URI: protocol.URIFromSpanURI(uri),
 			},
 		},
-		Edits: edits,
+		Edits: protocol.AsAnnotatedTextEdits(edits),
 	}}, nil
 }
 
@@ -665,7 +821,7 @@ func (c *commandHandler) ToggleGCDetails(ctx context.Context, args command.URIAr
 			c.s.gcOptimizationDetails[pkg.ID()] = struct{}{}
 		}
 		c.s.gcOptimizationDetailsMu.Unlock()
-		c.s.diagnoseSnapshot(deps.snapshot, nil, false)
+		c.s.diagnoseSnapshot(deps.snapshot, nil, FromDidChange)
 		return nil
 	})
 }
@@ -764,7 +920,7 @@ func (c *commandHandler) AddImport(ctx context.Context, args command.AddImportAr
 		}
 		if _, err := c.s.client.ApplyEdit(ctx, &protocol.ApplyWorkspaceEditParams{
 			Edit: protocol.WorkspaceEdit{
-				DocumentChanges: documentChanges(deps.fh, edits),
+				DocumentChanges: protocol.TextDocumentEdits(documentChanges(deps.fh, edits)),
 			},
 		}); err != nil {
 			return fmt.Errorf("could not apply import edits: %v", err)
@@ -773,6 +929,489 @@ func (c *commandHandler) AddImport(ctx context.Context, args command.AddImportAr
 	})
 }
 
+func (c *commandHandler) MovePackage(ctx context.Context, args command.MovePackageArgs) error {
+	return c.run(ctx, commandConfig{
+		progress:    "Moving package",
+		requireSave: true,
+		forURI:      args.URI,
+	}, func(ctx context.Context, deps commandDeps) error {
+		oldDir := filepath.Dir(deps.fh.URI().Filename())
+		newDir := args.NewDir.SpanURI().Filename()
+		if _, err := os.Stat(newDir); err == nil {
+			return fmt.Errorf("%s already exists", newDir)
+		}
+		edits, err := source.MovePackage(ctx, deps.snapshot, deps.fh.URI(), args.NewDir.SpanURI())
+		if err != nil {
+			return fmt.Errorf("could not move package: %v", err)
+		}
+		if err := source.CheckGeneratedEdits(ctx, deps.snapshot, edits); err != nil {
+			return fmt.Errorf("could not move package: %v", err)
+		}
+		// The files are saved, so edit them on disk before moving the
+		// directory, rather than asking the client to edit buffers
+		// whose files are about to move.
+		var writes []source.FileWrite
+		for uri, e := range edits {
+			content, err := editedContent(ctx, deps.snapshot, uri, e)
+			if err != nil {
+				return err
+			}
+			writes = append(writes, source.FileWrite{URI: uri, Content: content})
+		}
+		if err := source.WriteFiles(writes, ""); err != nil {
+			return err
+		}
+		if err := os.MkdirAll(filepath.Dir(newDir), 0777); err != nil {
+			return err
+		}
+		return os.Rename(oldDir, newDir)
+	})
+}
+
+func (c *commandHandler) Rename(ctx context.Context, args command.RenameArgs) (command.RenameResult, error) {
+	var result command.RenameResult
+	err := c.run(ctx, commandConfig{
+		forURI: args.URI,
+	}, func(ctx context.Context, deps commandDeps) error {
+		edits, renameDir, err := source.Rename(ctx, deps.snapshot, deps.fh, args.Position, args.NewName)
+		var cerr *source.RenameConflictError
+		if errors.As(err, &cerr) {
+			for _, conflict := range cerr.Conflicts {
+				result.Conflicts = append(result.Conflicts, command.RenameConflict{
+					Kind:      string(conflict.Kind),
+					Message:   conflict.Message,
+					Locations: conflict.Locations,
+				})
+			}
+			if !args.ApplyNonConflicting || len(cerr.Edits) == 0 {
+				return nil
+			}
+			edits = cerr.Edits
+		} else if err != nil {
+			return err
+		}
+		edit, err := renameEdit(ctx, deps.snapshot, edits, renameDir, args.NewName)
+		if err != nil {
+			return err
+		}
+		r, err := c.s.client.ApplyEdit(ctx, &protocol.ApplyWorkspaceEditParams{
+			Edit: *edit,
+		})
+		if err != nil {
+			return err
+		}
+		if !r.Applied {
+			return errors.New(r.FailureReason)
+		}
+		return nil
+	})
+	return result, err
+}
+
+func (c *commandHandler) GenerateTest(ctx context.Context, args command.GenerateTestArgs) error {
+	return c.run(ctx, commandConfig{
+		forURI: args.URI,
+	}, func(ctx context.Context, deps commandDeps) error {
+		testURI, edits, err := source.GenerateTest(ctx, deps.snapshot, deps.fh, args.Range)
+		if err != nil {
+			return fmt.Errorf("could not generate test: %v", err)
+		}
+		return c.editOrCreateFile(ctx, deps.snapshot, testURI, edits)
+	})
+}
+
+func (c *commandHandler) GenerateEnumMethods(ctx context.Context, args command.GenerateEnumMethodsArgs) error {
+	return c.run(ctx, commandConfig{
+		forURI: args.URI,
+	}, func(ctx context.Context, deps commandDeps) error {
+		uri, edits, err := source.GenerateEnumMethods(ctx, deps.snapshot, deps.fh, args.Range)
+		if err != nil {
+			return fmt.Errorf("could not generate enum methods: %v", err)
+		}
+		return c.editOrCreateFile(ctx, deps.snapshot, uri, edits)
+	})
+}
+
+func (c *commandHandler) GenerateSBOM(ctx context.Context, args command.GenerateSBOMArgs) (command.GenerateSBOMResult, error) {
+	var result command.GenerateSBOMResult
+	err := c.run(ctx, commandConfig{
+		progress: "Generating SBOM",
+		forURI:   args.URI,
+	}, func(ctx context.Context, deps commandDeps) error {
+		doc, err := source.GenerateSBOM(ctx, deps.snapshot, args.Format, debug.Version, args.Vulns)
+		if err != nil {
+			return fmt.Errorf("could not generate SBOM: %v", err)
+		}
+		result.Document = string(doc)
+		return nil
+	})
+	return result, err
+}
+
+func (c *commandHandler) PackageGraph(ctx context.Context, args command.PackageGraphArgs) (command.PackageGraphResult, error) {
+	var result command.PackageGraphResult
+	err := c.run(ctx, commandConfig{
+		forURI: args.URI,
+	}, func(ctx context.Context, deps commandDeps) error {
+		graph, err := source.PackageGraph(ctx, deps.snapshot, args.Tests, args.External)
+		if err != nil {
+			return err
+		}
+		doc, err := source.FormatPackageGraph(graph, args.Format)
+		if err != nil {
+			return err
+		}
+		result.Document = string(doc)
+		return nil
+	})
+	return result, err
+}
+
+func (c *commandHandler) ListImportReferences(ctx context.Context, args command.ImportCycleArgs) (command.ListImportReferencesResult, error) {
+	var result command.ListImportReferencesResult
+	err := c.run(ctx, commandConfig{
+		forURI: args.URI,
+	}, func(ctx context.Context, deps commandDeps) error {
+		pkg, err := deps.snapshot.PackageForFile(ctx, args.URI.SpanURI(), source.TypecheckWorkspace, source.NarrowestPackage)
+		if err != nil {
+			return err
+		}
+		refs, err := source.ImportReferences(deps.snapshot.FileSet(), pkg, args.ImportPath)
+		if err != nil {
+			return err
+		}
+		var msg strings.Builder
+		fmt.Fprintf(&msg, "%s refers to %s at:", pkg.PkgPath(), args.ImportPath)
+		for _, ref := range refs {
+			result.References = append(result.References, command.ImportReference{
+				Location: protocol.Location{URI: protocol.URIFromSpanURI(ref.URI), Range: ref.Range},
+				Name:     ref.Name,
+			})
+			fmt.Fprintf(&msg, "\n%s:%d:%d: %s", ref.URI.Filename(), ref.Range.Start.Line+1, ref.Range.Start.Character+1, ref.Name)
+		}
+		if len(refs) == 0 {
+			fmt.Fprintf(&msg, " no references")
+		}
+		return c.s.client.ShowMessage(ctx, &protocol.ShowMessageParams{
+			Type:    protocol.Info,
+			Message: msg.String(),
+		})
+	})
+	return result, err
+}
+
+func (c *commandHandler) ExtractImportedSymbol(ctx context.Context, args command.ImportCycleArgs) error {
+	return c.run(ctx, commandConfig{
+		progress:    "Extracting the imported symbol",
+		requireSave: true,
+		forURI:      args.URI,
+	}, func(ctx context.Context, deps commandDeps) error {
+		newURI, content, edits, err := source.ExtractImportedSymbol(ctx, deps.snapshot, args.URI.SpanURI(), args.ImportPath)
+		if err != nil {
+			return fmt.Errorf("could not extract the imported symbol: %v", err)
+		}
+		if err := source.CheckGeneratedEdits(ctx, deps.snapshot, edits); err != nil {
+			return fmt.Errorf("could not extract the imported symbol: %v", err)
+		}
+		var changes []protocol.TextDocumentEdit
+		for uri, e := range edits {
+			fh, err := deps.snapshot.GetVersionedFile(ctx, uri)
+			if err != nil {
+				return err
+			}
+			changes = append(changes, documentChanges(fh, e)...)
+		}
+		sort.Slice(changes, func(i, j int) bool {
+			return changes[i].TextDocument.URI < changes[j].TextDocument.URI
+		})
+		if err := os.MkdirAll(filepath.Dir(newURI.Filename()), 0777); err != nil {
+			return err
+		}
+		if err := ioutil.WriteFile(newURI.Filename(), content, 0666); err != nil {
+			return err
+		}
+		r, err := c.s.client.ApplyEdit(ctx, &protocol.ApplyWorkspaceEditParams{
+			Edit: protocol.WorkspaceEdit{
+				DocumentChanges: protocol.TextDocumentEdits(changes),
+			},
+		})
+		if err != nil {
+			return err
+		}
+		if !r.Applied {
+			return errors.New(r.FailureReason)
+		}
+		return nil
+	})
+}
+
+func (c *commandHandler) SearchPattern(ctx context.Context, args command.SearchPatternArgs) (command.SearchPatternResult, error) {
+	var result command.SearchPatternResult
+	err := c.run(ctx, commandConfig{
+		forURI: args.URI,
+	}, func(ctx context.Context, deps commandDeps) error {
+		locs, err := source.SearchPattern(ctx, deps.snapshot, args.Pattern)
+		if err != nil {
+			return err
+		}
+		result.Locations = locs
+		return nil
+	})
+	return result, err
+}
+
+func (c *commandHandler) ShowDuplicates(ctx context.Context, args command.ShowDuplicatesArgs) (command.ShowDuplicatesResult, error) {
+	var result command.ShowDuplicatesResult
+	err := c.run(ctx, commandConfig{
+		forURI: args.URI,
+	}, func(ctx context.Context, deps commandDeps) error {
+		locs, err := source.Duplicates(ctx, deps.snapshot, args.URI.SpanURI(), args.Range)
+		if err != nil {
+			return err
+		}
+		result.Locations = locs
+		return c.s.client.ShowMessage(ctx, &protocol.ShowMessageParams{
+			Type:    protocol.Info,
+			Message: locationsMessage("The code is duplicated at:", locs),
+		})
+	})
+	return result, err
+}
+
+func (c *commandHandler) ShowGenerator(ctx context.Context, args command.URIArg) (command.ShowGeneratorResult, error) {
+	var result command.ShowGeneratorResult
+	err := c.run(ctx, commandConfig{
+		forURI: args.URI,
+	}, func(ctx context.Context, deps commandDeps) error {
+		locs, err := source.Generator(ctx, deps.snapshot, args.URI.SpanURI())
+		if err != nil {
+			return err
+		}
+		result.Locations = locs
+		return c.showLocations(ctx, deps.snapshot, "The file is generated by:", locs)
+	})
+	return result, err
+}
+
+func (c *commandHandler) ShowGenerated(ctx context.Context, args command.ShowGeneratedArgs) (command.ShowGeneratedResult, error) {
+	var result command.ShowGeneratedResult
+	err := c.run(ctx, commandConfig{
+		forURI: args.URI,
+	}, func(ctx context.Context, deps commandDeps) error {
+		locs, err := source.GeneratedFiles(ctx, deps.snapshot, args.URI.SpanURI(), args.Range)
+		if err != nil {
+			return err
+		}
+		result.Locations = locs
+		return c.showLocations(ctx, deps.snapshot, "The directive generates:", locs)
+	})
+	return result, err
+}
+
+// showLocations shows locs to the user: it opens the location if there is
+// only one and the client can show documents, and otherwise lists them in
+// a message starting with intro.
+func (c *commandHandler) showLocations(ctx context.Context, snapshot source.Snapshot, intro string, locs []protocol.Location) error {
+	if len(locs) == 1 && snapshot.View().Options().ShowDocumentSupported {
+		_, err := c.s.client.ShowDocument(ctx, &protocol.ShowDocumentParams{
+			URI:       protocol.URI(locs[0].URI),
+			TakeFocus: true,
+			Selection: locs[0].Range,
+		})
+		return err
+	}
+	return c.s.client.ShowMessage(ctx, &protocol.ShowMessageParams{
+		Type:    protocol.Info,
+		Message: locationsMessage(intro, locs),
+	})
+}
+
+// locationsMessage returns a message listing locs after intro, one per
+// line.
+func locationsMessage(intro string, locs []protocol.Location) string {
+	var msg strings.Builder
+	msg.WriteString(intro)
+	for _, loc := range locs {
+		fmt.Fprintf(&msg, "\n%s:%d:%d", loc.URI.SpanURI().Filename(), loc.Range.Start.Line+1, loc.Range.Start.Character+1)
+	}
+	return msg.String()
+}
+
+func (c *commandHandler) AcceptCompletion(ctx context.Context, args command.AcceptCompletionArgs) error {
+	return completion.Accept(ctx, args.Symbol)
+}
+
+func (c *commandHandler) ImplementStubs(ctx context.Context, args command.ImplementStubsArgs) (command.ImplementStubsResult, error) {
+	var result command.ImplementStubsResult
+	err := c.run(ctx, commandConfig{
+		forURI: args.Concrete.URI,
+	}, func(ctx context.Context, deps commandDeps) error {
+		ifaceFH, err := deps.snapshot.GetFile(ctx, args.Interface.URI.SpanURI())
+		if err != nil {
+			return err
+		}
+		edits, err := source.ImplementStubs(ctx, deps.snapshot, deps.fh, args.Concrete.Range, ifaceFH, args.Interface.Range)
+		if err != nil {
+			return fmt.Errorf("could not implement stubs: %v", err)
+		}
+		result.Edits = edits
+		return nil
+	})
+	return result, err
+}
+
+// editOrCreateFile applies edits to the file with the given URI in the
+// client, or creates the file on disk with the edits applied to an empty
+// file if it does not exist.
+func (c *commandHandler) editOrCreateFile(ctx context.Context, snapshot source.Snapshot, uri span.URI, edits []protocol.TextEdit) error {
+	fh, err := snapshot.GetVersionedFile(ctx, uri)
+	if err != nil {
+		return err
+	}
+	if _, err := fh.Read(); err != nil {
+		// The client has no document to edit, so create the file on disk.
+		diffEdits, err := source.FromProtocolEdits(protocol.NewColumnMapper(uri, nil), edits)
+		if err != nil {
+			return err
+		}
+		return ioutil.WriteFile(uri.Filename(), []byte(diff.ApplyEdits("", diffEdits)), 0666)
+	}
+	r, err := c.s.client.ApplyEdit(ctx, &protocol.ApplyWorkspaceEditParams{
+		Edit: protocol.WorkspaceEdit{
+			DocumentChanges: protocol.TextDocumentEdits(documentChanges(fh, edits)),
+		},
+	})
+	if err != nil {
+		return err
+	}
+	if !r.Applied {
+		return errors.New(r.FailureReason)
+	}
+	return nil
+}
+
+func (c *commandHandler) GenerateStructFromJSON(ctx context.Context, args command.GenerateStructFromJSONArgs) error {
+	return c.run(ctx, commandConfig{
+		forURI: args.URI,
+	}, func(ctx context.Context, deps commandDeps) error {
+		name := args.Name
+		if name == "" {
+			name = "T"
+		}
+		edits, err := source.StructFromJSONEdits(ctx, deps.snapshot, deps.fh, args.Position, name, []byte(args.JSON))
+		if err != nil {
+			return fmt.Errorf("could not generate struct: %v", err)
+		}
+		r, err := c.s.client.ApplyEdit(ctx, &protocol.ApplyWorkspaceEditParams{
+			Edit: protocol.WorkspaceEdit{
+				DocumentChanges: protocol.TextDocumentEdits(documentChanges(deps.fh, edits)),
+			},
+		})
+		if err != nil {
+			return err
+		}
+		if !r.Applied {
+			return errors.New(r.FailureReason)
+		}
+		return nil
+	})
+}
+
+func (c *commandHandler) ModifyTags(ctx context.Context, args command.ModifyTagsArgs) error {
+	return c.run(ctx, commandConfig{
+		forURI: args.URI,
+	}, func(ctx context.Context, deps commandDeps) error {
+		edits, err := source.ModifyTags(ctx, deps.snapshot, deps.fh, args.Range, args.Action, args.Encoding, args.Convention)
+		if err != nil {
+			return fmt.Errorf("could not modify tags: %v", err)
+		}
+		if len(edits) == 0 {
+			return nil
+		}
+		r, err := c.s.client.ApplyEdit(ctx, &protocol.ApplyWorkspaceEditParams{
+			Edit: protocol.WorkspaceEdit{
+				DocumentChanges: protocol.TextDocumentEdits(documentChanges(deps.fh, edits)),
+			},
+		})
+		if err != nil {
+			return err
+		}
+		if !r.Applied {
+			return errors.New(r.FailureReason)
+		}
+		return nil
+	})
+}
+
+func (c *commandHandler) WrapErrors(ctx context.Context, args command.URIArg) error {
+	return c.run(ctx, commandConfig{
+		progress: "Wrapping errors",
+		forURI:   args.URI,
+	}, func(ctx context.Context, deps commandDeps) error {
+		edits, err := source.WrapErrors(ctx, deps.snapshot, deps.fh)
+		if err != nil {
+			return fmt.Errorf("could not wrap errors: %v", err)
+		}
+		if len(edits) == 0 {
+			return nil
+		}
+		edit := protocol.WorkspaceEdit{
+			DocumentChanges: protocol.TextDocumentEdits(edits),
+		}
+		annotateLargeEdit(deps.snapshot.View().Options(), &edit, "Wrap errors")
+		r, err := c.s.client.ApplyEdit(ctx, &protocol.ApplyWorkspaceEditParams{
+			Edit: edit,
+		})
+		if err != nil {
+			return err
+		}
+		if !r.Applied {
+			return errors.New(r.FailureReason)
+		}
+		return nil
+	})
+}
+
+func (c *commandHandler) ExplainDiagnostic(ctx context.Context, args command.ExplainDiagnosticArgs) (command.DiagnosticExplanation, error) {
+	var result command.DiagnosticExplanation
+	err := c.run(ctx, commandConfig{
+		forURI: args.URI,
+	}, func(ctx context.Context, deps commandDeps) error {
+		d := c.s.publishedDiagnostic(args.URI.SpanURI(), protocol.Diagnostic{
+			Range:   args.Range,
+			Source:  args.Source,
+			Message: args.Message,
+		})
+		if d == nil {
+			return fmt.Errorf("no diagnostic %q at %v in %s", args.Message, args.Range, args.URI.SpanURI().Filename())
+		}
+		result = source.ExplainDiagnostic(deps.snapshot.View().Options(), d)
+		return c.s.client.ShowMessage(ctx, &protocol.ShowMessageParams{
+			Type:    protocol.Info,
+			Message: source.FormatDiagnosticExplanation(result),
+		})
+	})
+	return result, err
+}
+
+// editedContent returns the content of the file with the given URI with
+// edits applied, keeping its line endings and byte order mark.
+func editedContent(ctx context.Context, snapshot source.Snapshot, uri span.URI, edits []protocol.TextEdit) ([]byte, error) {
+	fh, err := snapshot.GetFile(ctx, uri)
+	if err != nil {
+		return nil, err
+	}
+	content, err := fh.Read()
+	if err != nil {
+		return nil, err
+	}
+	diffEdits, err := source.FromProtocolEdits(protocol.NewColumnMapper(uri, content), source.PreserveFileFormat(content, edits))
+	if err != nil {
+		return nil, err
+	}
+	return []byte(diff.ApplyEdits(string(content), diffEdits)), nil
+}
+
 func (c *commandHandler) StartDebugging(ctx context.Context, args command.DebuggingArgs) (result command.DebuggingResult, _ error) {
 	addr := args.Addr
 	if addr == "" {
@@ -821,3 +1460,19 @@ func (c *commandHandler) RunVulncheckExp(ctx context.Context, args command.Vulnc
 	})
 	return result, err
 }
+
+func (c *commandHandler) SetOverlays(ctx context.Context, args command.SetOverlaysArgs) error {
+	return c.run(ctx, commandConfig{}, func(ctx context.Context, _ commandDeps) error {
+		return c.s.setToolOverlays(ctx, args.Overlays)
+	})
+}
+
+func (c *commandHandler) ClearOverlays(ctx context.Context, args command.URIArgs) error {
+	return c.run(ctx, commandConfig{}, func(ctx context.Context, _ commandDeps) error {
+		var uris []span.URI
+		for _, uri := range args.URIs {
+			uris = append(uris, uri.SpanURI())
+		}
+		return c.s.clearToolOverlays(ctx, uris)
+	})
+}
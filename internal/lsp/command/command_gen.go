@@ -19,46 +19,90 @@ import (
 )
 
 const (
-	AddDependency     Command = "add_dependency"
-	AddImport         Command = "add_import"
-	ApplyFix          Command = "apply_fix"
-	CheckUpgrades     Command = "check_upgrades"
-	EditGoDirective   Command = "edit_go_directive"
-	GCDetails         Command = "gc_details"
-	Generate          Command = "generate"
-	GenerateGoplsMod  Command = "generate_gopls_mod"
-	GoGetPackage      Command = "go_get_package"
-	ListImports       Command = "list_imports"
-	ListKnownPackages Command = "list_known_packages"
-	RegenerateCgo     Command = "regenerate_cgo"
-	RemoveDependency  Command = "remove_dependency"
-	RunTests          Command = "run_tests"
-	RunVulncheckExp   Command = "run_vulncheck_exp"
-	StartDebugging    Command = "start_debugging"
-	Test              Command = "test"
-	Tidy              Command = "tidy"
-	ToggleGCDetails   Command = "toggle_gc_details"
-	UpdateGoSum       Command = "update_go_sum"
-	UpgradeDependency Command = "upgrade_dependency"
-	Vendor            Command = "vendor"
+	AcceptCompletion       Command = "accept_completion"
+	AddDependency          Command = "add_dependency"
+	AddGoSumEntries        Command = "add_go_sum_entries"
+	AddImport              Command = "add_import"
+	ApplyFix               Command = "apply_fix"
+	CheckUpgrades          Command = "check_upgrades"
+	ClearOverlays          Command = "clear_overlays"
+	EditGoDirective        Command = "edit_go_directive"
+	ExplainDiagnostic      Command = "explain_diagnostic"
+	ExtractImportedSymbol  Command = "extract_imported_symbol"
+	GCDetails              Command = "gc_details"
+	Generate               Command = "generate"
+	GenerateEnumMethods    Command = "generate_enum_methods"
+	GenerateGoplsMod       Command = "generate_gopls_mod"
+	GenerateSBOM           Command = "generate_sbom"
+	GenerateStructFromJSON Command = "generate_struct_from_json"
+	GenerateTest           Command = "generate_test"
+	GoGetPackage           Command = "go_get_package"
+	ImplementStubs         Command = "implement_stubs"
+	ListImportReferences   Command = "list_import_references"
+	ListImports            Command = "list_imports"
+	ListKnownPackages      Command = "list_known_packages"
+	ModifyTags             Command = "modify_tags"
+	MovePackage            Command = "move_package"
+	PackageGraph           Command = "package_graph"
+	RegenerateCgo          Command = "regenerate_cgo"
+	RemoveDependency       Command = "remove_dependency"
+	Rename                 Command = "rename"
+	RunTests               Command = "run_tests"
+	RunTestsWithCoverage   Command = "run_tests_with_coverage"
+	RunVulncheckExp        Command = "run_vulncheck_exp"
+	SearchPattern          Command = "search_pattern"
+	SetOverlays            Command = "set_overlays"
+	ShowDuplicates         Command = "show_duplicates"
+	ShowGenerated          Command = "show_generated"
+	ShowGenerator          Command = "show_generator"
+	StartDebugging         Command = "start_debugging"
+	Test                   Command = "test"
+	Tidy                   Command = "tidy"
+	ToggleGCDetails        Command = "toggle_gc_details"
+	UpdateGoSum            Command = "update_go_sum"
+	UpgradeDependency      Command = "upgrade_dependency"
+	Vendor                 Command = "vendor"
+	WorkSync               Command = "work_sync"
+	WrapErrors             Command = "wrap_errors"
 )
 
 var Commands = []Command{
+	AcceptCompletion,
 	AddDependency,
+	AddGoSumEntries,
 	AddImport,
 	ApplyFix,
 	CheckUpgrades,
+	ClearOverlays,
 	EditGoDirective,
+	ExplainDiagnostic,
+	ExtractImportedSymbol,
 	GCDetails,
 	Generate,
+	GenerateEnumMethods,
 	GenerateGoplsMod,
+	GenerateSBOM,
+	GenerateStructFromJSON,
+	GenerateTest,
 	GoGetPackage,
+	ImplementStubs,
+	ListImportReferences,
 	ListImports,
 	ListKnownPackages,
+	ModifyTags,
+	MovePackage,
+	PackageGraph,
 	RegenerateCgo,
 	RemoveDependency,
+	Rename,
 	RunTests,
+	RunTestsWithCoverage,
 	RunVulncheckExp,
+	SearchPattern,
+	SetOverlays,
+	ShowDuplicates,
+	ShowGenerated,
+	ShowGenerator,
 	StartDebugging,
 	Test,
 	Tidy,
@@ -66,16 +110,30 @@ var Commands = []Command{
 	UpdateGoSum,
 	UpgradeDependency,
 	Vendor,
+	WorkSync,
+	WrapErrors,
 }
 
 func Dispatch(ctx context.Context, params *protocol.ExecuteCommandParams, s Interface) (interface{}, error) {
 	switch params.Command {
+	case "gopls.accept_completion":
+		var a0 AcceptCompletionArgs
+		if err := UnmarshalArgs(params.Arguments, &a0); err != nil {
+			return nil, err
+		}
+		return nil, s.AcceptCompletion(ctx, a0)
 	case "gopls.add_dependency":
 		var a0 DependencyArgs
 		if err := UnmarshalArgs(params.Arguments, &a0); err != nil {
 			return nil, err
 		}
 		return nil, s.AddDependency(ctx, a0)
+	case "gopls.add_go_sum_entries":
+		var a0 GoSumEntriesArgs
+		if err := UnmarshalArgs(params.Arguments, &a0); err != nil {
+			return nil, err
+		}
+		return nil, s.AddGoSumEntries(ctx, a0)
 	case "gopls.add_import":
 		var a0 AddImportArgs
 		if err := UnmarshalArgs(params.Arguments, &a0); err != nil {
@@ -94,12 +152,30 @@ func Dispatch(ctx context.Context, params *protocol.ExecuteCommandParams, s Inte
 			return nil, err
 		}
 		return nil, s.CheckUpgrades(ctx, a0)
+	case "gopls.clear_overlays":
+		var a0 URIArgs
+		if err := UnmarshalArgs(params.Arguments, &a0); err != nil {
+			return nil, err
+		}
+		return nil, s.ClearOverlays(ctx, a0)
 	case "gopls.edit_go_directive":
 		var a0 EditGoDirectiveArgs
 		if err := UnmarshalArgs(params.Arguments, &a0); err != nil {
 			return nil, err
 		}
 		return nil, s.EditGoDirective(ctx, a0)
+	case "gopls.explain_diagnostic":
+		var a0 ExplainDiagnosticArgs
+		if err := UnmarshalArgs(params.Arguments, &a0); err != nil {
+			return nil, err
+		}
+		return s.ExplainDiagnostic(ctx, a0)
+	case "gopls.extract_imported_symbol":
+		var a0 ImportCycleArgs
+		if err := UnmarshalArgs(params.Arguments, &a0); err != nil {
+			return nil, err
+		}
+		return nil, s.ExtractImportedSymbol(ctx, a0)
 	case "gopls.gc_details":
 		var a0 protocol.DocumentURI
 		if err := UnmarshalArgs(params.Arguments, &a0); err != nil {
@@ -112,18 +188,54 @@ func Dispatch(ctx context.Context, params *protocol.ExecuteCommandParams, s Inte
 			return nil, err
 		}
 		return nil, s.Generate(ctx, a0)
+	case "gopls.generate_enum_methods":
+		var a0 GenerateEnumMethodsArgs
+		if err := UnmarshalArgs(params.Arguments, &a0); err != nil {
+			return nil, err
+		}
+		return nil, s.GenerateEnumMethods(ctx, a0)
 	case "gopls.generate_gopls_mod":
 		var a0 URIArg
 		if err := UnmarshalArgs(params.Arguments, &a0); err != nil {
 			return nil, err
 		}
 		return nil, s.GenerateGoplsMod(ctx, a0)
+	case "gopls.generate_sbom":
+		var a0 GenerateSBOMArgs
+		if err := UnmarshalArgs(params.Arguments, &a0); err != nil {
+			return nil, err
+		}
+		return s.GenerateSBOM(ctx, a0)
+	case "gopls.generate_struct_from_json":
+		var a0 GenerateStructFromJSONArgs
+		if err := UnmarshalArgs(params.Arguments, &a0); err != nil {
+			return nil, err
+		}
+		return nil, s.GenerateStructFromJSON(ctx, a0)
+	case "gopls.generate_test":
+		var a0 GenerateTestArgs
+		if err := UnmarshalArgs(params.Arguments, &a0); err != nil {
+			return nil, err
+		}
+		return nil, s.GenerateTest(ctx, a0)
 	case "gopls.go_get_package":
 		var a0 GoGetPackageArgs
 		if err := UnmarshalArgs(params.Arguments, &a0); err != nil {
 			return nil, err
 		}
 		return nil, s.GoGetPackage(ctx, a0)
+	case "gopls.implement_stubs":
+		var a0 ImplementStubsArgs
+		if err := UnmarshalArgs(params.Arguments, &a0); err != nil {
+			return nil, err
+		}
+		return s.ImplementStubs(ctx, a0)
+	case "gopls.list_import_references":
+		var a0 ImportCycleArgs
+		if err := UnmarshalArgs(params.Arguments, &a0); err != nil {
+			return nil, err
+		}
+		return s.ListImportReferences(ctx, a0)
 	case "gopls.list_imports":
 		var a0 URIArg
 		if err := UnmarshalArgs(params.Arguments, &a0); err != nil {
@@ -136,6 +248,24 @@ func Dispatch(ctx context.Context, params *protocol.ExecuteCommandParams, s Inte
 			return nil, err
 		}
 		return s.ListKnownPackages(ctx, a0)
+	case "gopls.modify_tags":
+		var a0 ModifyTagsArgs
+		if err := UnmarshalArgs(params.Arguments, &a0); err != nil {
+			return nil, err
+		}
+		return nil, s.ModifyTags(ctx, a0)
+	case "gopls.move_package":
+		var a0 MovePackageArgs
+		if err := UnmarshalArgs(params.Arguments, &a0); err != nil {
+			return nil, err
+		}
+		return nil, s.MovePackage(ctx, a0)
+	case "gopls.package_graph":
+		var a0 PackageGraphArgs
+		if err := UnmarshalArgs(params.Arguments, &a0); err != nil {
+			return nil, err
+		}
+		return s.PackageGraph(ctx, a0)
 	case "gopls.regenerate_cgo":
 		var a0 URIArg
 		if err := UnmarshalArgs(params.Arguments, &a0); err != nil {
@@ -148,18 +278,60 @@ func Dispatch(ctx context.Context, params *protocol.ExecuteCommandParams, s Inte
 			return nil, err
 		}
 		return nil, s.RemoveDependency(ctx, a0)
+	case "gopls.rename":
+		var a0 RenameArgs
+		if err := UnmarshalArgs(params.Arguments, &a0); err != nil {
+			return nil, err
+		}
+		return s.Rename(ctx, a0)
 	case "gopls.run_tests":
 		var a0 RunTestsArgs
 		if err := UnmarshalArgs(params.Arguments, &a0); err != nil {
 			return nil, err
 		}
 		return nil, s.RunTests(ctx, a0)
+	case "gopls.run_tests_with_coverage":
+		var a0 RunTestsArgs
+		if err := UnmarshalArgs(params.Arguments, &a0); err != nil {
+			return nil, err
+		}
+		return nil, s.RunTestsWithCoverage(ctx, a0)
 	case "gopls.run_vulncheck_exp":
 		var a0 VulncheckArgs
 		if err := UnmarshalArgs(params.Arguments, &a0); err != nil {
 			return nil, err
 		}
 		return s.RunVulncheckExp(ctx, a0)
+	case "gopls.search_pattern":
+		var a0 SearchPatternArgs
+		if err := UnmarshalArgs(params.Arguments, &a0); err != nil {
+			return nil, err
+		}
+		return s.SearchPattern(ctx, a0)
+	case "gopls.set_overlays":
+		var a0 SetOverlaysArgs
+		if err := UnmarshalArgs(params.Arguments, &a0); err != nil {
+			return nil, err
+		}
+		return nil, s.SetOverlays(ctx, a0)
+	case "gopls.show_duplicates":
+		var a0 ShowDuplicatesArgs
+		if err := UnmarshalArgs(params.Arguments, &a0); err != nil {
+			return nil, err
+		}
+		return s.ShowDuplicates(ctx, a0)
+	case "gopls.show_generated":
+		var a0 ShowGeneratedArgs
+		if err := UnmarshalArgs(params.Arguments, &a0); err != nil {
+			return nil, err
+		}
+		return s.ShowGenerated(ctx, a0)
+	case "gopls.show_generator":
+		var a0 URIArg
+		if err := UnmarshalArgs(params.Arguments, &a0); err != nil {
+			return nil, err
+		}
+		return s.ShowGenerator(ctx, a0)
 	case "gopls.start_debugging":
 		var a0 DebuggingArgs
 		if err := UnmarshalArgs(params.Arguments, &a0); err != nil {
@@ -204,10 +376,34 @@ func Dispatch(ctx context.Context, params *protocol.ExecuteCommandParams, s Inte
 			return nil, err
 		}
 		return nil, s.Vendor(ctx, a0)
+	case "gopls.work_sync":
+		var a0 URIArg
+		if err := UnmarshalArgs(params.Arguments, &a0); err != nil {
+			return nil, err
+		}
+		return nil, s.WorkSync(ctx, a0)
+	case "gopls.wrap_errors":
+		var a0 URIArg
+		if err := UnmarshalArgs(params.Arguments, &a0); err != nil {
+			return nil, err
+		}
+		return nil, s.WrapErrors(ctx, a0)
 	}
 	return nil, fmt.Errorf("unsupported command %q", params.Command)
 }
 
+func NewAcceptCompletionCommand(title string, a0 AcceptCompletionArgs) (protocol.Command, error) {
+	args, err := MarshalArgs(a0)
+	if err != nil {
+		return protocol.Command{}, err
+	}
+	return protocol.Command{
+		Title:     title,
+		Command:   "gopls.accept_completion",
+		Arguments: args,
+	}, nil
+}
+
 func NewAddDependencyCommand(title string, a0 DependencyArgs) (protocol.Command, error) {
 	args, err := MarshalArgs(a0)
 	if err != nil {
@@ -220,6 +416,18 @@ func NewAddDependencyCommand(title string, a0 DependencyArgs) (protocol.Command,
 	}, nil
 }
 
+func NewAddGoSumEntriesCommand(title string, a0 GoSumEntriesArgs) (protocol.Command, error) {
+	args, err := MarshalArgs(a0)
+	if err != nil {
+		return protocol.Command{}, err
+	}
+	return protocol.Command{
+		Title:     title,
+		Command:   "gopls.add_go_sum_entries",
+		Arguments: args,
+	}, nil
+}
+
 func NewAddImportCommand(title string, a0 AddImportArgs) (protocol.Command, error) {
 	args, err := MarshalArgs(a0)
 	if err != nil {
@@ -256,6 +464,18 @@ func NewCheckUpgradesCommand(title string, a0 CheckUpgradesArgs) (protocol.Comma
 	}, nil
 }
 
+func NewClearOverlaysCommand(title string, a0 URIArgs) (protocol.Command, error) {
+	args, err := MarshalArgs(a0)
+	if err != nil {
+		return protocol.Command{}, err
+	}
+	return protocol.Command{
+		Title:     title,
+		Command:   "gopls.clear_overlays",
+		Arguments: args,
+	}, nil
+}
+
 func NewEditGoDirectiveCommand(title string, a0 EditGoDirectiveArgs) (protocol.Command, error) {
 	args, err := MarshalArgs(a0)
 	if err != nil {
@@ -268,6 +488,30 @@ func NewEditGoDirectiveCommand(title string, a0 EditGoDirectiveArgs) (protocol.C
 	}, nil
 }
 
+func NewExplainDiagnosticCommand(title string, a0 ExplainDiagnosticArgs) (protocol.Command, error) {
+	args, err := MarshalArgs(a0)
+	if err != nil {
+		return protocol.Command{}, err
+	}
+	return protocol.Command{
+		Title:     title,
+		Command:   "gopls.explain_diagnostic",
+		Arguments: args,
+	}, nil
+}
+
+func NewExtractImportedSymbolCommand(title string, a0 ImportCycleArgs) (protocol.Command, error) {
+	args, err := MarshalArgs(a0)
+	if err != nil {
+		return protocol.Command{}, err
+	}
+	return protocol.Command{
+		Title:     title,
+		Command:   "gopls.extract_imported_symbol",
+		Arguments: args,
+	}, nil
+}
+
 func NewGCDetailsCommand(title string, a0 protocol.DocumentURI) (protocol.Command, error) {
 	args, err := MarshalArgs(a0)
 	if err != nil {
@@ -292,6 +536,18 @@ func NewGenerateCommand(title string, a0 GenerateArgs) (protocol.Command, error)
 	}, nil
 }
 
+func NewGenerateEnumMethodsCommand(title string, a0 GenerateEnumMethodsArgs) (protocol.Command, error) {
+	args, err := MarshalArgs(a0)
+	if err != nil {
+		return protocol.Command{}, err
+	}
+	return protocol.Command{
+		Title:     title,
+		Command:   "gopls.generate_enum_methods",
+		Arguments: args,
+	}, nil
+}
+
 func NewGenerateGoplsModCommand(title string, a0 URIArg) (protocol.Command, error) {
 	args, err := MarshalArgs(a0)
 	if err != nil {
@@ -304,6 +560,42 @@ func NewGenerateGoplsModCommand(title string, a0 URIArg) (protocol.Command, erro
 	}, nil
 }
 
+func NewGenerateSBOMCommand(title string, a0 GenerateSBOMArgs) (protocol.Command, error) {
+	args, err := MarshalArgs(a0)
+	if err != nil {
+		return protocol.Command{}, err
+	}
+	return protocol.Command{
+		Title:     title,
+		Command:   "gopls.generate_sbom",
+		Arguments: args,
+	}, nil
+}
+
+func NewGenerateStructFromJSONCommand(title string, a0 GenerateStructFromJSONArgs) (protocol.Command, error) {
+	args, err := MarshalArgs(a0)
+	if err != nil {
+		return protocol.Command{}, err
+	}
+	return protocol.Command{
+		Title:     title,
+		Command:   "gopls.generate_struct_from_json",
+		Arguments: args,
+	}, nil
+}
+
+func NewGenerateTestCommand(title string, a0 GenerateTestArgs) (protocol.Command, error) {
+	args, err := MarshalArgs(a0)
+	if err != nil {
+		return protocol.Command{}, err
+	}
+	return protocol.Command{
+		Title:     title,
+		Command:   "gopls.generate_test",
+		Arguments: args,
+	}, nil
+}
+
 func NewGoGetPackageCommand(title string, a0 GoGetPackageArgs) (protocol.Command, error) {
 	args, err := MarshalArgs(a0)
 	if err != nil {
@@ -316,6 +608,30 @@ func NewGoGetPackageCommand(title string, a0 GoGetPackageArgs) (protocol.Command
 	}, nil
 }
 
+func NewImplementStubsCommand(title string, a0 ImplementStubsArgs) (protocol.Command, error) {
+	args, err := MarshalArgs(a0)
+	if err != nil {
+		return protocol.Command{}, err
+	}
+	return protocol.Command{
+		Title:     title,
+		Command:   "gopls.implement_stubs",
+		Arguments: args,
+	}, nil
+}
+
+func NewListImportReferencesCommand(title string, a0 ImportCycleArgs) (protocol.Command, error) {
+	args, err := MarshalArgs(a0)
+	if err != nil {
+		return protocol.Command{}, err
+	}
+	return protocol.Command{
+		Title:     title,
+		Command:   "gopls.list_import_references",
+		Arguments: args,
+	}, nil
+}
+
 func NewListImportsCommand(title string, a0 URIArg) (protocol.Command, error) {
 	args, err := MarshalArgs(a0)
 	if err != nil {
@@ -340,6 +656,42 @@ func NewListKnownPackagesCommand(title string, a0 URIArg) (protocol.Command, err
 	}, nil
 }
 
+func NewModifyTagsCommand(title string, a0 ModifyTagsArgs) (protocol.Command, error) {
+	args, err := MarshalArgs(a0)
+	if err != nil {
+		return protocol.Command{}, err
+	}
+	return protocol.Command{
+		Title:     title,
+		Command:   "gopls.modify_tags",
+		Arguments: args,
+	}, nil
+}
+
+func NewMovePackageCommand(title string, a0 MovePackageArgs) (protocol.Command, error) {
+	args, err := MarshalArgs(a0)
+	if err != nil {
+		return protocol.Command{}, err
+	}
+	return protocol.Command{
+		Title:     title,
+		Command:   "gopls.move_package",
+		Arguments: args,
+	}, nil
+}
+
+func NewPackageGraphCommand(title string, a0 PackageGraphArgs) (protocol.Command, error) {
+	args, err := MarshalArgs(a0)
+	if err != nil {
+		return protocol.Command{}, err
+	}
+	return protocol.Command{
+		Title:     title,
+		Command:   "gopls.package_graph",
+		Arguments: args,
+	}, nil
+}
+
 func NewRegenerateCgoCommand(title string, a0 URIArg) (protocol.Command, error) {
 	args, err := MarshalArgs(a0)
 	if err != nil {
@@ -364,6 +716,18 @@ func NewRemoveDependencyCommand(title string, a0 RemoveDependencyArgs) (protocol
 	}, nil
 }
 
+func NewRenameCommand(title string, a0 RenameArgs) (protocol.Command, error) {
+	args, err := MarshalArgs(a0)
+	if err != nil {
+		return protocol.Command{}, err
+	}
+	return protocol.Command{
+		Title:     title,
+		Command:   "gopls.rename",
+		Arguments: args,
+	}, nil
+}
+
 func NewRunTestsCommand(title string, a0 RunTestsArgs) (protocol.Command, error) {
 	args, err := MarshalArgs(a0)
 	if err != nil {
@@ -376,6 +740,18 @@ func NewRunTestsCommand(title string, a0 RunTestsArgs) (protocol.Command, error)
 	}, nil
 }
 
+func NewRunTestsWithCoverageCommand(title string, a0 RunTestsArgs) (protocol.Command, error) {
+	args, err := MarshalArgs(a0)
+	if err != nil {
+		return protocol.Command{}, err
+	}
+	return protocol.Command{
+		Title:     title,
+		Command:   "gopls.run_tests_with_coverage",
+		Arguments: args,
+	}, nil
+}
+
 func NewRunVulncheckExpCommand(title string, a0 VulncheckArgs) (protocol.Command, error) {
 	args, err := MarshalArgs(a0)
 	if err != nil {
@@ -388,6 +764,66 @@ func NewRunVulncheckExpCommand(title string, a0 VulncheckArgs) (protocol.Command
 	}, nil
 }
 
+func NewSearchPatternCommand(title string, a0 SearchPatternArgs) (protocol.Command, error) {
+	args, err := MarshalArgs(a0)
+	if err != nil {
+		return protocol.Command{}, err
+	}
+	return protocol.Command{
+		Title:     title,
+		Command:   "gopls.search_pattern",
+		Arguments: args,
+	}, nil
+}
+
+func NewSetOverlaysCommand(title string, a0 SetOverlaysArgs) (protocol.Command, error) {
+	args, err := MarshalArgs(a0)
+	if err != nil {
+		return protocol.Command{}, err
+	}
+	return protocol.Command{
+		Title:     title,
+		Command:   "gopls.set_overlays",
+		Arguments: args,
+	}, nil
+}
+
+func NewShowDuplicatesCommand(title string, a0 ShowDuplicatesArgs) (protocol.Command, error) {
+	args, err := MarshalArgs(a0)
+	if err != nil {
+		return protocol.Command{}, err
+	}
+	return protocol.Command{
+		Title:     title,
+		Command:   "gopls.show_duplicates",
+		Arguments: args,
+	}, nil
+}
+
+func NewShowGeneratedCommand(title string, a0 ShowGeneratedArgs) (protocol.Command, error) {
+	args, err := MarshalArgs(a0)
+	if err != nil {
+		return protocol.Command{}, err
+	}
+	return protocol.Command{
+		Title:     title,
+		Command:   "gopls.show_generated",
+		Arguments: args,
+	}, nil
+}
+
+func NewShowGeneratorCommand(title string, a0 URIArg) (protocol.Command, error) {
+	args, err := MarshalArgs(a0)
+	if err != nil {
+		return protocol.Command{}, err
+	}
+	return protocol.Command{
+		Title:     title,
+		Command:   "gopls.show_generator",
+		Arguments: args,
+	}, nil
+}
+
 func NewStartDebuggingCommand(title string, a0 DebuggingArgs) (protocol.Command, error) {
 	args, err := MarshalArgs(a0)
 	if err != nil {
@@ -471,3 +907,27 @@ func NewVendorCommand(title string, a0 URIArg) (protocol.Command, error) {
 		Arguments: args,
 	}, nil
 }
+
+func NewWorkSyncCommand(title string, a0 URIArg) (protocol.Command, error) {
+	args, err := MarshalArgs(a0)
+	if err != nil {
+		return protocol.Command{}, err
+	}
+	return protocol.Command{
+		Title:     title,
+		Command:   "gopls.work_sync",
+		Arguments: args,
+	}, nil
+}
+
+func NewWrapErrorsCommand(title string, a0 URIArg) (protocol.Command, error) {
+	args, err := MarshalArgs(a0)
+	if err != nil {
+		return protocol.Command{}, err
+	}
+	return protocol.Command{
+		Title:     title,
+		Command:   "gopls.wrap_errors",
+		Arguments: args,
+	}, nil
+}
@@ -43,11 +43,21 @@ type Interface interface {
 
 	// TODO: deprecate Test in favor of RunTests below.
 
-	// Test: Run test(s)
+	// RunTests: Run test(s)
 	//
-	// Runs `go test` for a specific set of test or benchmark functions.
+	// Runs `go test` for a specific set of test, benchmark, or fuzz
+	// functions, and reports whether each passed or failed as a
+	// diagnostic on the function.
 	RunTests(context.Context, RunTestsArgs) error
 
+	// RunTestsWithCoverage: Run test(s) with coverage
+	//
+	// Runs `go test -coverprofile` for a specific set of test, benchmark,
+	// or fuzz functions, or for all the tests of the package if none are
+	// given, and reports the statements of the package that they did not
+	// cover as diagnostics.
+	RunTestsWithCoverage(context.Context, RunTestsArgs) error
+
 	// Generate: Run go generate
 	//
 	// Runs `go generate` for a given directory.
@@ -68,6 +78,12 @@ type Interface interface {
 	// Runs `go mod vendor` for a module.
 	Vendor(context.Context, URIArg) error
 
+	// WorkSync: Run go work sync
+	//
+	// Runs `go work sync` for a workspace, updating the requirements of
+	// its modules to match the workspace's build list.
+	WorkSync(context.Context, URIArg) error
+
 	// EditGoDirective: Run go mod edit -go=version
 	//
 	// Runs `go mod edit -go=version` for a module.
@@ -78,6 +94,13 @@ type Interface interface {
 	// Updates the go.sum file for a module.
 	UpdateGoSum(context.Context, URIArgs) error
 
+	// AddGoSumEntries: Add missing go.sum entries
+	//
+	// Runs `go mod download` for the given module versions, adding only
+	// their missing go.sum entries. This is much faster than running
+	// `go mod tidy` on large modules.
+	AddGoSumEntries(context.Context, GoSumEntriesArgs) error
+
 	// CheckUpgrades: Check for upgrades
 	//
 	// Checks for module upgrades.
@@ -138,6 +161,166 @@ type Interface interface {
 	// themselves.
 	AddImport(context.Context, AddImportArgs) error
 
+	// MovePackage: Move a package
+	//
+	// Moves the directory of the package containing the given file to a new
+	// directory in the same module, and updates the import paths of the
+	// package and its subpackages throughout the module. A package named
+	// after its old directory is renamed after the new one. All files must
+	// be saved first.
+	MovePackage(context.Context, MovePackageArgs) error
+
+	// Rename: Rename an identifier, reporting conflicts
+	//
+	// Renames the identifier at the given position like textDocument/rename,
+	// but reports the conflicts of the renaming, classified, in the result
+	// rather than as an error. A renaming with conflicts is not applied,
+	// unless ApplyNonConflicting is set: then its edits are applied, except
+	// those at the references at which conflicts are reported.
+	Rename(context.Context, RenameArgs) (RenameResult, error)
+
+	// GenerateTest: Generate a test
+	//
+	// Adds a table-driven test skeleton for the function or method at the
+	// given range to the _test.go file of its file, creating the test file
+	// if necessary.
+	GenerateTest(context.Context, GenerateTestArgs) error
+
+	// GenerateStructFromJSON: Generate a struct from JSON
+	//
+	// Inserts at the given position of a Go file the declaration of a
+	// struct type, with JSON tags, whose fields are those of the given JSON
+	// document: an object, or an array of objects.
+	GenerateStructFromJSON(context.Context, GenerateStructFromJSONArgs) error
+
+	// ModifyTags: Add, update, or remove struct tags
+	//
+	// Adds the tags of the given encoding that the fields of the struct
+	// type at the given range lack, renames the keys of its existing tags
+	// after their fields, or removes them. Only the fields intersecting a
+	// non-empty range are modified, or all of them if there is none.
+	ModifyTags(context.Context, ModifyTagsArgs) error
+
+	// GenerateEnumMethods: Generate the methods of an enum type
+	//
+	// Writes to the file <type>_string.go, in the directory of the integer
+	// type whose name, or the name of one of whose constants, is at the
+	// given range, a String and a MarshalText method returning the names of
+	// its constants, and a Parse<type> function, without go:generate or
+	// stringer. A generated file of the same name is replaced.
+	GenerateEnumMethods(context.Context, GenerateEnumMethodsArgs) error
+
+	// GenerateSBOM: Generate a software bill of materials
+	//
+	// Returns a CycloneDX or SPDX document listing the modules of the
+	// packages loaded in the view containing the given URI and the
+	// dependencies between them, and optionally the known vulnerabilities
+	// of the packages they import.
+	GenerateSBOM(context.Context, GenerateSBOMArgs) (GenerateSBOMResult, error)
+
+	// PackageGraph: Show the package dependency graph
+	//
+	// Returns the import graph of the packages loaded in the view containing
+	// the given URI, as a Graphviz DOT or JSON document. Test variants and
+	// the packages outside the workspace, such as those of the standard
+	// library and of the dependencies, are left out unless requested.
+	PackageGraph(context.Context, PackageGraphArgs) (PackageGraphResult, error)
+
+	// ListImportReferences: List the references to an imported package
+	//
+	// Returns, and shows to the user, the references in the package of the
+	// given file to the symbols of the package with the given import path,
+	// such as the references that make an import cycle.
+	ListImportReferences(context.Context, ImportCycleArgs) (ListImportReferencesResult, error)
+
+	// ExtractImportedSymbol: Extract the imported symbol into a new package
+	//
+	// Breaks the import of the package with the given import path by the
+	// package of the given file, which refers to a single symbol of it: the
+	// declaration of the symbol moves to a new package in a subdirectory of
+	// the imported package, which the importing package imports instead,
+	// and the imported package keeps the symbol as an alias or a wrapper of
+	// the moved declaration. All files must be saved first.
+	ExtractImportedSymbol(context.Context, ImportCycleArgs) error
+
+	// SearchPattern: Search for code matching a pattern
+	//
+	// Returns the locations of the code of the workspace packages of the
+	// view containing the given URI that matches a Go expression or list of
+	// statements in which, as with gofmt -r, the identifiers of a single
+	// lowercase letter are wildcards matching any expression.
+	SearchPattern(context.Context, SearchPatternArgs) (SearchPatternResult, error)
+
+	// ShowDuplicates: Show the duplicates of duplicated code
+	//
+	// Returns, and shows to the user, the other locations of the duplicated
+	// code at the given range, which the duplicateCodeTokens setting
+	// reports.
+	ShowDuplicates(context.Context, ShowDuplicatesArgs) (ShowDuplicatesResult, error)
+
+	// ShowGenerator: Show the generator of a generated file
+	//
+	// Returns, and shows to the user, the //go:generate directives that
+	// produced the given generated file, following its "Code generated"
+	// header, and the generator inputs that the header names.
+	ShowGenerator(context.Context, URIArg) (ShowGeneratorResult, error)
+
+	// ShowGenerated: Show the files generated by a directive
+	//
+	// Returns, and shows to the user, the generated files of the package
+	// directory that the //go:generate directive at the given range
+	// produces.
+	ShowGenerated(context.Context, ShowGeneratedArgs) (ShowGeneratedResult, error)
+
+	// AcceptCompletion: Record an accepted completion
+	//
+	// Records that the user accepted a completion of the given symbol, so
+	// that the experimentalCompletionRanking setting ranks it higher in
+	// later completions. It is the command of the completion items.
+	AcceptCompletion(context.Context, AcceptCompletionArgs) error
+
+	// ImplementStubs: Implement the methods of an interface
+	//
+	// Returns the edits declaring, after the type whose name is at the
+	// given location, the methods of the interface whose name is at the
+	// other given location that the type lacks, as stubs that panic.
+	ImplementStubs(context.Context, ImplementStubsArgs) (ImplementStubsResult, error)
+
+	// ExplainDiagnostic: Explain this diagnostic
+	//
+	// Describes the origin of a diagnostic published by gopls: the analyzer
+	// that reported it, with its documentation, the analyzers it builds upon
+	// and the facts they derive from the dependencies of the package, or the
+	// type error or other kind of error it comes from. The explanation is
+	// also shown to the user.
+	ExplainDiagnostic(context.Context, ExplainDiagnosticArgs) (DiagnosticExplanation, error)
+
+	// WrapErrors: Wrap the errors returned by a package
+	//
+	// Wraps with fmt.Errorf the error variables returned by the functions of
+	// the package containing the given file, following the
+	// errorWrapTemplate setting.
+	WrapErrors(context.Context, URIArg) error
+
+	// SetOverlays: Set the content of files not open in the editor
+	//
+	// Sets the content of the given files, which must not be open in the
+	// editor, without modifying them on disk, as if the editor had opened
+	// them with this content, so that a tool can get the diagnostics of a
+	// proposed change set: the command returns once the diagnostics of the
+	// affected packages are published. The overlays are kept until they are
+	// cleared with the clear_overlays command, or the editor opens their
+	// files.
+	SetOverlays(context.Context, SetOverlaysArgs) error
+
+	// ClearOverlays: Clear the overlays of files not open in the editor
+	//
+	// Removes the overlays of the given files set by the set_overlays
+	// command, or all of them if no file is given, restoring the content of
+	// the files on disk. The command returns once the diagnostics of the
+	// affected packages are published.
+	ClearOverlays(context.Context, URIArgs) error
+
 	// StartDebugging: Start the gopls debug server
 	//
 	// Start the gopls debug server if it isn't running, and return the debug
@@ -159,6 +342,9 @@ type RunTestsArgs struct {
 
 	// Specific benchmarks to run, e.g. BenchmarkFoo.
 	Benchmarks []string
+
+	// Specific fuzz targets to run against their seed corpus, e.g. FuzzFoo.
+	Fuzz []string
 }
 
 type GenerateArgs struct {
@@ -167,6 +353,10 @@ type GenerateArgs struct {
 
 	// Whether to generate recursively (go generate ./...)
 	Recursive bool
+
+	// If set, only run the //go:generate directives whose text matches this
+	// regular expression (go generate -run).
+	Run string
 }
 
 // TODO(rFindley): document the rest of these once the docgen is fleshed out.
@@ -190,6 +380,18 @@ type URIArgs struct {
 	URIs []protocol.DocumentURI
 }
 
+type SetOverlaysArgs struct {
+	// The files to overlay, with their content.
+	Overlays []FileOverlay
+}
+
+type FileOverlay struct {
+	// The file URI.
+	URI protocol.DocumentURI
+	// The content of the file.
+	Content string
+}
+
 type CheckUpgradesArgs struct {
 	// The go.mod file URI.
 	URI protocol.DocumentURI
@@ -197,6 +399,14 @@ type CheckUpgradesArgs struct {
 	Modules []string
 }
 
+type GoSumEntriesArgs struct {
+	// The go.mod file URI.
+	URI protocol.DocumentURI
+	// The module versions whose go.sum entries are missing, e.g.
+	// example.com@v1.2.3.
+	Modules []string
+}
+
 type DependencyArgs struct {
 	// The go.mod file URI.
 	URI protocol.DocumentURI
@@ -238,6 +448,216 @@ type AddImportArgs struct {
 	URI protocol.DocumentURI
 }
 
+type MovePackageArgs struct {
+	// A file of the package to move.
+	URI protocol.DocumentURI
+	// The new directory of the package, which must not exist.
+	NewDir protocol.DocumentURI
+}
+
+type GenerateTestArgs struct {
+	// The file URI of the function to test.
+	URI protocol.DocumentURI
+	// The range of the function's name or declaration.
+	Range protocol.Range
+}
+
+type GenerateStructFromJSONArgs struct {
+	// The URI of the Go file in which to declare the struct type.
+	URI protocol.DocumentURI
+	// The position at which to insert the declaration.
+	Position protocol.Position
+	// The name of the struct type, "T" if empty.
+	Name string
+	// The JSON document, such as the content of the clipboard.
+	JSON string
+}
+
+type ModifyTagsArgs struct {
+	// The file URI of the struct type.
+	URI protocol.DocumentURI
+	// The range of the struct type or of the fields to modify.
+	Range protocol.Range
+	// The action: "add", "update", or "remove".
+	Action string
+	// The encoding of the tags, such as "json" or "yaml".
+	Encoding string
+	// The naming convention of the keys of the added or updated tags:
+	// "snake_case" or "camelCase".
+	Convention string
+}
+
+type RenameArgs struct {
+	// The file URI of the identifier.
+	URI protocol.DocumentURI
+	// The position of the identifier.
+	Position protocol.Position
+	// The new name of the identifier.
+	NewName string
+	// Whether to apply the edits that do not conflict, if there are
+	// conflicts.
+	ApplyNonConflicting bool
+}
+
+type RenameResult struct {
+	// The conflicts of the renaming, if any.
+	Conflicts []RenameConflict
+}
+
+type RenameConflict struct {
+	// The kind of conflict: "Shadowing", "MethodSet", "Export", or "Other".
+	Kind string
+	// The description of the conflict. Its first line refers to the
+	// renamed declaration, and the following lines, indented by a tab, to
+	// the conflicting declarations and references.
+	Message string
+	// The locations of the lines of Message that have one.
+	Locations []protocol.Location
+}
+
+type GenerateEnumMethodsArgs struct {
+	// The file URI of the type or constant.
+	URI protocol.DocumentURI
+	// The range of the name of the type or constant.
+	Range protocol.Range
+}
+
+type GenerateSBOMArgs struct {
+	// A URI in the view whose modules are listed.
+	URI protocol.DocumentURI
+	// The format of the document: "CycloneDX", the default, or "SPDX".
+	Format string
+	// Whether to list the known vulnerabilities of the imported packages.
+	Vulns bool
+}
+
+type GenerateSBOMResult struct {
+	// The JSON document.
+	Document string
+}
+
+type PackageGraphArgs struct {
+	// A URI in the view whose packages are listed.
+	URI protocol.DocumentURI
+	// The format of the document: "dot", the default, or "json".
+	Format string
+	// Whether to include the test variants of the packages.
+	Tests bool
+	// Whether to include the packages outside the workspace.
+	External bool
+}
+
+type PackageGraphResult struct {
+	// The DOT or JSON document.
+	Document string
+}
+
+type ImportCycleArgs struct {
+	// A file of the importing package.
+	URI protocol.DocumentURI
+	// The import path of the imported package.
+	ImportPath string
+}
+
+type ListImportReferencesResult struct {
+	// The references, sorted by position.
+	References []ImportReference
+}
+
+type ImportReference struct {
+	// The location of the qualified identifier.
+	Location protocol.Location
+	// The qualified identifier, such as "fmt.Println".
+	Name string
+}
+
+type SearchPatternArgs struct {
+	// A URI in the view whose packages are searched.
+	URI protocol.DocumentURI
+	// The pattern, such as "fmt.Errorf(f, err)" or "if e != nil { return e }".
+	Pattern string
+}
+
+type SearchPatternResult struct {
+	// The locations of the matches, sorted.
+	Locations []protocol.Location
+}
+
+type ShowDuplicatesArgs struct {
+	// The file of the duplicated code.
+	URI protocol.DocumentURI
+	// The range of the duplicated code.
+	Range protocol.Range
+}
+
+type ShowDuplicatesResult struct {
+	// The locations of the other occurrences of the code.
+	Locations []protocol.Location
+}
+
+type ShowGeneratorResult struct {
+	// The locations of the directives and of the inputs.
+	Locations []protocol.Location
+}
+
+type ShowGeneratedArgs struct {
+	// The file of the directive.
+	URI protocol.DocumentURI
+	// The range of the directive.
+	Range protocol.Range
+}
+
+type ShowGeneratedResult struct {
+	// The locations of the generated files.
+	Locations []protocol.Location
+}
+
+type AcceptCompletionArgs struct {
+	// The key of the symbol of the completion, such as "fmt.Println".
+	Symbol string
+}
+
+type ImplementStubsArgs struct {
+	// The location of the name of the type.
+	Concrete protocol.Location
+	// The location of the name of the interface.
+	Interface protocol.Location
+}
+
+type ImplementStubsResult struct {
+	// The edits declaring the methods.
+	Edits []protocol.TextDocumentEdit
+}
+
+type ExplainDiagnosticArgs struct {
+	// The file of the diagnostic.
+	URI protocol.DocumentURI
+	// The range, source and message of the diagnostic, as published.
+	Range   protocol.Range
+	Source  string
+	Message string
+}
+
+type DiagnosticExplanation struct {
+	// Origin describes in a sentence what reports the diagnostic.
+	Origin string
+	// Analyzer is the name of the analyzer that reports the diagnostic, or
+	// that provides the fixes of a type error.
+	Analyzer string `json:",omitempty"`
+	// Doc is the summary of the documentation of the analyzer.
+	Doc string `json:",omitempty"`
+	// Requires lists the analyzers whose results the analyzer uses,
+	// directly or not.
+	Requires []string `json:",omitempty"`
+	// Facts lists the facts that the analyzer, or one of those it requires,
+	// derives from the dependencies of the package, as "analyzer: type".
+	Facts []string `json:",omitempty"`
+	// Code is the code of the type error, if any.
+	Code string `json:",omitempty"`
+	// Href links to the documentation of the analyzer or of the error code.
+	Href string `json:",omitempty"`
+}
+
 type ListKnownPackagesResult struct {
 	// Packages is a list of packages relative
 	// to the URIArg passed by the command request.
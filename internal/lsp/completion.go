@@ -10,6 +10,7 @@ import (
 	"strings"
 
 	"github.com/iansmith/golang-x-tools/internal/event"
+	"github.com/iansmith/golang-x-tools/internal/lsp/command"
 	"github.com/iansmith/golang-x-tools/internal/lsp/debug/tag"
 	"github.com/iansmith/golang-x-tools/internal/lsp/lsppos"
 	"github.com/iansmith/golang-x-tools/internal/lsp/protocol"
@@ -20,6 +21,7 @@ import (
 )
 
 func (s *Server) completion(ctx context.Context, params *protocol.CompletionParams) (*protocol.CompletionList, error) {
+	defer s.scheduler.beginInteractive()()
 	snapshot, fh, ok, release, err := s.beginFileRequest(ctx, params.TextDocument.URI, source.UnknownKind)
 	defer release()
 	if !ok {
@@ -136,6 +138,15 @@ func toProtocolCompletionItems(candidates []completion.CompletionItem, rng proto
 			Tags:          candidate.Tags,
 			Deprecated:    candidate.Deprecated,
 		}
+		// Let the client report the accept of the item, to rank it.
+		if options.ExperimentalCompletionRanking && candidate.Symbol != "" {
+			cmd, err := command.NewAcceptCompletionCommand("Record completion", command.AcceptCompletionArgs{
+				Symbol: candidate.Symbol,
+			})
+			if err == nil {
+				item.Command = &cmd
+			}
+		}
 		items = append(items, item)
 	}
 	return items
@@ -36,6 +36,7 @@ func (r *runner) CompletionSnippet(t *testing.T, src span.Span, expected tests.C
 		opts.DeepCompletion = true
 		opts.Matcher = source.Fuzzy
 		opts.CompleteUnimported = false
+		opts.ExperimentalResultAssignments = strings.Contains(string(src.URI()), "results")
 	})
 	got := tests.FindItem(list, *items[expected.CompletionItem])
 	want := expected.PlainSnippet
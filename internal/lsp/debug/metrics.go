@@ -5,8 +5,12 @@
 package debug
 
 import (
+	"fmt"
+	"net/http"
+
 	"github.com/iansmith/golang-x-tools/internal/event/export/metric"
 	"github.com/iansmith/golang-x-tools/internal/event/label"
+	"github.com/iansmith/golang-x-tools/internal/lsp/cache"
 	"github.com/iansmith/golang-x-tools/internal/lsp/debug/tag"
 )
 
@@ -36,6 +40,12 @@ var (
 		Buckets:     millisecondsDistribution,
 	}
 
+	loadLatency = metric.HistogramFloat64{
+		Name:        "load_latency",
+		Description: "Distribution of the latency of go/packages.Load in milliseconds.",
+		Buckets:     millisecondsDistribution,
+	}
+
 	started = metric.Scalar{
 		Name:        "started",
 		Description: "Count of RPCs started by method.",
@@ -53,6 +63,39 @@ func registerMetrics(m *metric.Config) {
 	receivedBytes.Record(m, tag.ReceivedBytes)
 	sentBytes.Record(m, tag.SentBytes)
 	latency.Record(m, tag.Latency)
+	loadLatency.Record(m, tag.LoadLatency)
 	started.Count(m, tag.Started)
 	completed.Count(m, tag.Latency)
 }
+
+// serveMetrics serves the metrics recorded by the prometheus exporter,
+// followed by those that gopls computes on demand: the requests for values of
+// each kind of the caches, and the estimated memory of the views.
+func (i *Instance) serveMetrics(w http.ResponseWriter, r *http.Request) {
+	i.prometheus.Serve(w, r)
+
+	caches := i.State.Caches()
+	stats := make([][]cache.CacheStat, len(caches))
+	for j, c := range caches {
+		stats[j] = c.Stats()
+	}
+	fmt.Fprintf(w, "# HELP cache_hits Count of requests for cached values, by cache and kind.\n")
+	fmt.Fprintf(w, "# TYPE cache_hits counter\n")
+	for j, c := range caches {
+		for _, stat := range stats[j] {
+			fmt.Fprintf(w, "cache_hits{cache=%q,kind=%q} %d\n", c.ID(), stat.Kind, stat.Hits)
+		}
+	}
+	fmt.Fprintf(w, "# HELP cache_misses Count of requests that computed a value, by cache and kind.\n")
+	fmt.Fprintf(w, "# TYPE cache_misses counter\n")
+	for j, c := range caches {
+		for _, stat := range stats[j] {
+			fmt.Fprintf(w, "cache_misses{cache=%q,kind=%q} %d\n", c.ID(), stat.Kind, stat.Misses)
+		}
+	}
+	fmt.Fprintf(w, "# HELP view_memory_bytes Estimated memory of the type-checked packages, by view.\n")
+	fmt.Fprintf(w, "# TYPE view_memory_bytes gauge\n")
+	for _, v := range i.State.Views() {
+		fmt.Fprintf(w, "view_memory_bytes{view=%q,folder=%q} %d\n", v.ID(), v.Folder().Filename(), v.EstimatedMemory())
+	}
+}
@@ -20,6 +20,7 @@ import (
 	"path"
 	"path/filepath"
 	"runtime"
+	rdebug "runtime/debug"
 	rpprof "runtime/pprof"
 	"strconv"
 	"strings"
@@ -31,6 +32,7 @@ import (
 	"github.com/iansmith/golang-x-tools/internal/event/export"
 	"github.com/iansmith/golang-x-tools/internal/event/export/metric"
 	"github.com/iansmith/golang-x-tools/internal/event/export/ocagent"
+	"github.com/iansmith/golang-x-tools/internal/event/export/otlp"
 	"github.com/iansmith/golang-x-tools/internal/event/export/prometheus"
 	"github.com/iansmith/golang-x-tools/internal/event/keys"
 	"github.com/iansmith/golang-x-tools/internal/event/label"
@@ -62,6 +64,7 @@ type Instance struct {
 	exporter event.Exporter
 
 	ocagent    *ocagent.Exporter
+	otlp       *otlp.Exporter
 	prometheus *prometheus.Exporter
 	rpcs       *Rpcs
 	traces     *traces
@@ -343,6 +346,92 @@ func (i *Instance) AddService(s protocol.Server, session *cache.Session) {
 	stdlog.Printf("unable to find a Client to add the protocol.Server to")
 }
 
+// freeMemory runs the garbage collector and returns as much memory as
+// possible to the operating system, then shows the memory page.
+func freeMemory(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "POST" {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	rdebug.FreeOSMemory()
+	http.Redirect(w, r, "/memory", http.StatusSeeOther)
+}
+
+// dropPackages drops the type-checked packages and analysis results of a
+// view, then shows its snapshot page.
+func (i *Instance) dropPackages(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "POST" {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	id := path.Base(r.URL.Path)
+	v := i.State.View(id)
+	if v == nil {
+		http.NotFound(w, r)
+		return
+	}
+	v.DropPackages(r.Context())
+	http.Redirect(w, r, "/snapshot/"+id, http.StatusSeeOther)
+}
+
+// A packageGraph is the import graph of the packages of a view, for the
+// package graph page.
+type packageGraph struct {
+	ID       string // of the view
+	Tests    bool   // whether test variants are included
+	External bool   // whether the packages outside the workspace are included
+	Packages []graphPackage
+}
+
+type graphPackage struct {
+	*source.ImportGraphNode
+	ImportedBy []string
+}
+
+// servePackageGraph serves the import graph of the packages of a view, as
+// a page or, with the format parameter, as a DOT or JSON document. The tests
+// and external parameters include the test variants and the packages outside
+// the workspace.
+func (i *Instance) servePackageGraph(w http.ResponseWriter, r *http.Request) {
+	id := path.Base(r.URL.Path)
+	v := i.State.View(id)
+	if v == nil {
+		http.NotFound(w, r)
+		return
+	}
+	query := r.URL.Query()
+	data := packageGraph{
+		ID:       id,
+		Tests:    query.Get("tests") != "",
+		External: query.Get("external") != "",
+	}
+	nodes, err := v.PackageGraph(r.Context(), data.Tests, data.External)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if format := query.Get("format"); format != "" {
+		doc, err := source.FormatPackageGraph(nodes, format)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+		w.Write(doc)
+		return
+	}
+	importedBy := make(map[string][]string)
+	for _, node := range nodes {
+		for _, imp := range node.Imports {
+			importedBy[imp] = append(importedBy[imp], node.ID)
+		}
+	}
+	for _, node := range nodes {
+		data.Packages = append(data.Packages, graphPackage{node, importedBy[node.ID]})
+	}
+	render(PackageGraphTmpl, func(*http.Request) interface{} { return data })(w, r)
+}
+
 func getMemory(_ *http.Request) interface{} {
 	var m runtime.MemStats
 	runtime.ReadMemStats(&m)
@@ -377,6 +466,7 @@ func WithInstance(ctx context.Context, workdir, agent string) context.Context {
 	//TODO: we should not need to adjust the discovered configuration
 	ocConfig.Address = i.OCAgentConfig
 	i.ocagent = ocagent.Connect(ocConfig)
+	i.otlp = otlp.Connect(otlp.Discover())
 	i.prometheus = prometheus.New()
 	i.rpcs = &Rpcs{}
 	i.traces = &traces{}
@@ -451,7 +541,7 @@ func (i *Instance) Serve(ctx context.Context, addr string) (string, error) {
 		mux.HandleFunc("/debug/pprof/symbol", pprof.Symbol)
 		mux.HandleFunc("/debug/pprof/trace", pprof.Trace)
 		if i.prometheus != nil {
-			mux.HandleFunc("/metrics/", i.prometheus.Serve)
+			mux.HandleFunc("/metrics/", i.serveMetrics)
 		}
 		if i.rpcs != nil {
 			mux.HandleFunc("/rpc/", render(RPCTmpl, i.rpcs.getData))
@@ -462,11 +552,15 @@ func (i *Instance) Serve(ctx context.Context, addr string) (string, error) {
 		mux.HandleFunc("/cache/", render(CacheTmpl, i.getCache))
 		mux.HandleFunc("/session/", render(SessionTmpl, i.getSession))
 		mux.HandleFunc("/view/", render(ViewTmpl, i.getView))
+		mux.HandleFunc("/snapshot/", render(SnapshotTmpl, i.getView))
+		mux.HandleFunc("/drop/", i.dropPackages)
+		mux.HandleFunc("/graph/", i.servePackageGraph)
 		mux.HandleFunc("/client/", render(ClientTmpl, i.getClient))
 		mux.HandleFunc("/server/", render(ServerTmpl, i.getServer))
 		mux.HandleFunc("/file/", render(FileTmpl, i.getFile))
 		mux.HandleFunc("/info", render(InfoTmpl, i.getInfo))
 		mux.HandleFunc("/memory", render(MemoryTmpl, getMemory))
+		mux.HandleFunc("/gc", freeMemory)
 
 		mux.HandleFunc("/_makeabug", func(w http.ResponseWriter, r *http.Request) {
 			bug.Report("bug here", nil)
@@ -610,6 +704,9 @@ func makeInstanceExporter(i *Instance) event.Exporter {
 		if i.ocagent != nil {
 			ctx = i.ocagent.ProcessEvent(ctx, ev, lm)
 		}
+		if i.otlp != nil {
+			ctx = i.otlp.ProcessEvent(ctx, ev, lm)
+		}
 		if i.prometheus != nil {
 			ctx = i.prometheus.ProcessEvent(ctx, ev, lm)
 		}
@@ -691,6 +788,10 @@ func fuint32(v uint32) string {
 	return commas(strconv.FormatUint(uint64(v), 10))
 }
 
+func fint64(v int64) string {
+	return commas(strconv.FormatInt(v, 10))
+}
+
 func fcontent(v []byte) string {
 	return string(v)
 }
@@ -734,10 +835,12 @@ Unknown page
 {{define "serverlink"}}<a href="/server/{{.}}">Server {{.}}</a>{{end}}
 {{define "sessionlink"}}<a href="/session/{{.}}">Session {{.}}</a>{{end}}
 {{define "viewlink"}}<a href="/view/{{.}}">View {{.}}</a>{{end}}
+{{define "snapshotlink"}}<a href="/snapshot/{{.}}">current snapshot</a>{{end}}
 {{define "filelink"}}<a href="/file/{{.Session}}/{{.FileIdentity.Hash}}">{{.FileIdentity.URI}}</a>{{end}}
 `)).Funcs(template.FuncMap{
 	"fuint64":  fuint64,
 	"fuint32":  fuint32,
+	"fint64":   fint64,
 	"fcontent": fcontent,
 	"localAddress": func(s string) string {
 		// Try to translate loopback addresses to localhost, both for cosmetics and
@@ -793,6 +896,7 @@ var MemoryTmpl = template.Must(template.Must(BaseTemplate.Clone()).Parse(`
 {{define "title"}}GoPls memory usage{{end}}
 {{define "head"}}<meta http-equiv="refresh" content="5">{{end}}
 {{define "body"}}
+<form action="/gc" method="post"><button type="submit">Collect garbage and free OS memory</button></form>
 <h2>Stats</h2>
 <table>
 <tr><td class="label">Allocated bytes</td><td class="value">{{fuint64 .HeapAlloc}}</td></tr>
@@ -831,6 +935,11 @@ var CacheTmpl = template.Must(template.Must(BaseTemplate.Clone()).Parse(`
 {{define "body"}}
 <h2>memoize.Store entries</h2>
 <ul>{{range $k,$v := .MemStats}}<li>{{$k}} - {{$v}}</li>{{end}}</ul>
+<h2>Requests by kind</h2>
+<table>
+<tr><th>Kind</th><th>Values</th><th>Shared</th><th>Hits</th><th>Misses</th></tr>
+{{range .Stats}}<tr><td>{{.Kind}}</td><td class="value">{{.Count}}</td><td class="value">{{.Shared}}</td><td class="value">{{.Hits}}</td><td class="value">{{.Misses}}</td></tr>{{end}}
+</table>
 <h2>Per-package usage - not accurate, for guidance only</h2>
 {{.PackageStats true}}
 {{end}}
@@ -901,11 +1010,63 @@ var ViewTmpl = template.Must(template.Must(BaseTemplate.Clone()).Parse(`
 Name: <b>{{.Name}}</b><br>
 Folder: <b>{{.Folder}}</b><br>
 From: <b>{{template "sessionlink" .Session.ID}}</b><br>
+Snapshot: <b>{{template "snapshotlink" .ID}}</b><br>
 <h2>Environment</h2>
 <ul>{{range .Options.Env}}<li>{{.}}</li>{{end}}</ul>
 {{end}}
 `))
 
+var SnapshotTmpl = template.Must(template.Must(BaseTemplate.Clone()).Parse(`
+{{define "title"}}Snapshot of view {{.ID}}{{end}}
+{{define "body"}}
+View: <b>{{template "viewlink" .ID}}</b> in {{.Folder}}<br>
+{{with .SnapshotInfo}}
+Snapshot: <b>{{.ID}}</b><br>
+<form action="/drop/{{$.ID}}" method="post"><button type="submit">Drop type-checked packages and analysis results</button></form>
+<a href="/graph/{{$.ID}}">Package graph</a><br>
+<p>Sizes are estimates, for guidance only.</p>
+<h2>Overlays</h2>
+<ul>{{range .Overlays}}<li>{{template "filelink" .}}</li>{{end}}</ul>
+<h2>Packages</h2>
+<table>
+<tr><th>Package</th><th>Mode</th><th>Files</th><th>Bytes</th></tr>
+{{range .Packages}}<tr><td>{{.ID}}</td><td>{{.Mode}}</td>{{if .Checked}}<td class="value">{{.Files}}</td><td class="value">{{fint64 .Size}}</td>{{else}}<td colspan="2">not type-checked</td>{{end}}</tr>{{end}}
+</table>
+<h2>Analyses</h2>
+<table>
+<tr><th>Analyzer</th><th>Package</th><th>Diagnostics</th><th>Facts</th><th>Bytes</th></tr>
+{{range .Analyses}}<tr><td>{{.Analyzer}}</td><td>{{.Package}}</td>{{if .Done}}<td class="value">{{.Diagnostics}}</td><td class="value">{{.Facts}}</td><td class="value">{{fint64 .Size}}</td>{{else}}<td colspan="3">not done</td>{{end}}</tr>{{end}}
+</table>
+{{else}}
+The view is shut down.
+{{end}}
+{{end}}
+`))
+
+var PackageGraphTmpl = template.Must(template.Must(BaseTemplate.Clone()).Parse(`
+{{define "title"}}Package graph of view {{.ID}}{{end}}
+{{define "body"}}
+View: <b>{{template "viewlink" .ID}}</b><br>
+<form action="/graph/{{.ID}}">
+<label><input type="checkbox" name="tests" value="1"{{if .Tests}} checked{{end}}> Test variants</label>
+<label><input type="checkbox" name="external" value="1"{{if .External}} checked{{end}}> Packages outside the workspace</label>
+<button type="submit">Show</button>
+</form>
+Download as <a href="/graph/{{.ID}}?format=dot{{if .Tests}}&tests=1{{end}}{{if .External}}&external=1{{end}}">DOT</a>
+or <a href="/graph/{{.ID}}?format=json{{if .Tests}}&tests=1{{end}}{{if .External}}&external=1{{end}}">JSON</a>.
+<h2>Packages</h2>
+<table>
+<tr><th>Package</th><th>Module</th><th>Imports</th><th>Imported by</th></tr>
+{{range .Packages}}<tr id="{{.ID}}">
+<td>{{if .Workspace}}<b>{{.ID}}</b>{{else}}{{.ID}}{{end}}</td>
+<td>{{.Module}}</td>
+<td>{{range .Imports}}<a href="#{{.}}">{{.}}</a><br>{{end}}</td>
+<td>{{range .ImportedBy}}<a href="#{{.}}">{{.}}</a><br>{{end}}</td>
+</tr>{{end}}
+</table>
+{{end}}
+`))
+
 var FileTmpl = template.Must(template.Must(BaseTemplate.Clone()).Parse(`
 {{define "title"}}Overlay {{.FileIdentity.Hash}}{{end}}
 {{define "body"}}
@@ -51,6 +51,7 @@ var (
 	ReceivedBytes = keys.NewInt64("received_bytes", "Bytes received.")            //, unit.Bytes)
 	SentBytes     = keys.NewInt64("sent_bytes", "Bytes sent.")                    //, unit.Bytes)
 	Latency       = keys.NewFloat64("latency_ms", "Elapsed time in milliseconds") //, unit.Milliseconds)
+	LoadLatency   = keys.NewFloat64("load_latency_ms", "Elapsed time of go/packages.Load in milliseconds")
 )
 
 const (
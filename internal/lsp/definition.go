@@ -22,6 +22,15 @@ func (s *Server) definition(ctx context.Context, params *protocol.DefinitionPara
 	if snapshot.View().FileKind(fh) == source.Tmpl {
 		return template.Definition(snapshot, fh, params.Position)
 	}
+	if snapshot.View().FileKind(fh) == source.Asm {
+		return source.AsmDefinition(ctx, snapshot, fh, params.Position)
+	}
+	if locations, ok, err := source.EmbedDefinition(ctx, snapshot, fh, params.Position); ok || err != nil {
+		return locations, err
+	}
+	if locations, ok, err := source.LinknameDefinition(ctx, snapshot, fh, params.Position); ok || err != nil {
+		return locations, err
+	}
 	ident, err := source.Identifier(ctx, snapshot, fh, params.Position)
 	if err != nil {
 		return nil, err
@@ -29,7 +38,12 @@ func (s *Server) definition(ctx context.Context, params *protocol.DefinitionPara
 	if ident.IsImport() && !snapshot.View().Options().ImportShortcut.ShowDefinition() {
 		return nil, nil
 	}
-	var locations []protocol.Location
+	// Functions implemented in assembly are defined by their TEXT symbols,
+	// which take precedence over the Go declaration.
+	locations, err := source.AsmFuncDefinitions(ctx, snapshot, ident)
+	if err != nil {
+		return nil, err
+	}
 	for _, ref := range ident.Declaration.MappedRange {
 		decRange, err := ref.Range()
 		if err != nil {
@@ -37,6 +37,14 @@ const (
 	typeCheckSource
 	orphanedSource
 	workSource
+	testSource
+	coverageSource
+	unusedSource
+	embedSource
+	platformSource
+	vulnSource
+	externalSource
+	duplicatesSource
 )
 
 // A diagnosticReport holds results for a single diagnostic source.
@@ -66,6 +74,22 @@ func (d diagnosticSource) String() string {
 		return "FromTypeChecking"
 	case orphanedSource:
 		return "FromOrphans"
+	case testSource:
+		return "FromTests"
+	case coverageSource:
+		return "FromCoverage"
+	case unusedSource:
+		return "FromUnusedSymbols"
+	case embedSource:
+		return "FromEmbed"
+	case platformSource:
+		return "FromPlatforms"
+	case vulnSource:
+		return "FromVulncheck"
+	case externalSource:
+		return "FromExternalAnalyzers"
+	case duplicatesSource:
+		return "FromDuplicateCode"
 	default:
 		return fmt.Sprintf("From?%d?", d)
 	}
@@ -94,24 +118,30 @@ func (s *Server) diagnoseDetached(snapshot source.Snapshot) {
 	s.publishDiagnostics(ctx, true, snapshot)
 }
 
-func (s *Server) diagnoseSnapshots(snapshots map[source.Snapshot][]span.URI, onDisk bool) {
+func (s *Server) diagnoseSnapshots(snapshots map[source.Snapshot][]span.URI, cause ModificationSource) {
 	var diagnosticWG sync.WaitGroup
 	for snapshot, uris := range snapshots {
 		diagnosticWG.Add(1)
 		go func(snapshot source.Snapshot, uris []span.URI) {
 			defer diagnosticWG.Done()
-			s.diagnoseSnapshot(snapshot, uris, onDisk)
+			s.diagnoseSnapshot(snapshot, uris, cause)
 		}(snapshot, uris)
 	}
 	diagnosticWG.Wait()
 }
 
-func (s *Server) diagnoseSnapshot(snapshot source.Snapshot, changedURIs []span.URI, onDisk bool) {
+// diagnoseSnapshot diagnoses snapshot after the modification of
+// changedURIs for the given cause.
+func (s *Server) diagnoseSnapshot(snapshot source.Snapshot, changedURIs []span.URI, cause ModificationSource) {
 	ctx := snapshot.BackgroundContext()
 	ctx, done := event.Start(ctx, "Server.diagnoseSnapshot", tag.Snapshot.Of(snapshot.ID()))
 	defer done()
 
-	delay := snapshot.View().Options().DiagnosticsDelay
+	options := snapshot.View().Options()
+	delay := options.DiagnosticsDelay
+	if cause == FromDidSave && options.DiagnosticsDelayOnSave > 0 {
+		delay = options.DiagnosticsDelayOnSave
+	}
 	if delay > 0 {
 		// 2-phase diagnostics.
 		//
@@ -120,7 +150,7 @@ func (s *Server) diagnoseSnapshot(snapshot source.Snapshot, changedURIs []span.U
 		//
 		// The second phase does everything, and is debounced by the configured
 		// delay.
-		s.diagnoseChangedFiles(ctx, snapshot, changedURIs, onDisk)
+		s.diagnoseChangedFiles(ctx, snapshot, changedURIs, cause == FromDidChangeWatchedFiles)
 		s.publishDiagnostics(ctx, false, snapshot)
 		if ok := <-s.diagDebouncer.debounce(snapshot.View().Name(), snapshot.ID(), time.After(delay)); ok {
 			s.diagnose(ctx, snapshot, false)
@@ -184,6 +214,7 @@ func (s *Server) diagnoseChangedFiles(ctx context.Context, snapshot source.Snaps
 func (s *Server) diagnose(ctx context.Context, snapshot source.Snapshot, forceAnalysis bool) {
 	ctx, done := event.Start(ctx, "Server.diagnose", tag.Snapshot.Of(snapshot.ID()))
 	defer done()
+	ctx = source.WithYield(ctx, s.scheduler.yield)
 
 	// Wait for a free diagnostics slot.
 	select {
@@ -268,15 +299,59 @@ func (s *Server) diagnose(ctx context.Context, snapshot source.Snapshot, forceAn
 		go func(pkg source.Package) {
 			defer wg.Done()
 
+			if source.Yield(ctx) != nil {
+				return
+			}
 			s.diagnosePkg(ctx, snapshot, pkg, forceAnalysis)
 		}(pkg)
 	}
 	wg.Wait()
 
+	// The errors that occur only on other platforms are reported for the
+	// packages of open files.
+	s.diagnosePlatforms(ctx, snapshot)
+
+	// Finding unused symbols requires the references of all the packages.
+	if opts := snapshot.View().Options(); opts.UnusedSymbols && opts.MemoryMode == source.ModeNormal && !opts.LazyTypeCheck {
+		reports, err := source.UnusedSymbols(ctx, snapshot)
+		if err != nil {
+			event.Error(ctx, "warning: finding unused symbols", err, tag.Snapshot.Of(snapshot.ID()))
+		}
+		for uri, diags := range reports {
+			s.storeDiagnostics(snapshot, uri, unusedSource, diags)
+		}
+	}
+
+	// Duplicated code is found across the files of the workspace.
+	if snapshot.View().Options().DuplicateCodeTokens > 0 {
+		reports, err := source.DuplicateCode(ctx, snapshot)
+		if err != nil {
+			event.Error(ctx, "warning: finding duplicate code", err, tag.Snapshot.Of(snapshot.ID()))
+		}
+		for uri, diags := range reports {
+			s.storeDiagnostics(snapshot, uri, duplicatesSource, diags)
+		}
+	}
+
+	// The imports of vulnerable packages are reported in Go files, and the
+	// modules that provide them in go.mod files.
+	if snapshot.View().Options().Vulncheck == source.ModeVulncheckImports {
+		reports, err := source.VulnerabilityDiagnostics(ctx, snapshot)
+		if err != nil {
+			event.Error(ctx, "warning: checking vulnerabilities", err, tag.Snapshot.Of(snapshot.ID()))
+		}
+		for uri, diags := range reports {
+			s.storeDiagnostics(snapshot, uri, vulnSource, diags)
+		}
+	}
+
 	// Confirm that every opened file belongs to a package (if any exist in
 	// the workspace). Otherwise, add a diagnostic to the file.
 	for _, o := range s.session.Overlays() {
-		if _, ok := seen[o.URI()]; ok {
+		// Files outside the modules of a go.work file are loaded as
+		// command-line-arguments workspace packages, so check all open files
+		// when there is a go.work file.
+		if _, ok := seen[o.URI()]; ok && snapshot.WorkFile() == "" {
 			continue
 		}
 		diagnostic := s.checkForOrphanedFile(ctx, snapshot, o)
@@ -292,9 +367,19 @@ func (s *Server) diagnosePkg(ctx context.Context, snapshot source.Snapshot, pkg
 	defer done()
 	enableDiagnostics := false
 	includeAnalysis := alwaysAnalyze // only run analyses for packages with open files
+	largeFile := false
 	for _, pgf := range pkg.CompiledGoFiles() {
 		enableDiagnostics = enableDiagnostics || !snapshot.IgnoredFile(pgf.URI)
 		includeAnalysis = includeAnalysis || snapshot.IsOpen(pgf.URI)
+		largeFile = largeFile || source.IsLargeFile(snapshot, pgf.Src)
+	}
+	// Analyzing a package with a large file would keep the editor waiting,
+	// so clear its analysis diagnostics instead.
+	if includeAnalysis && largeFile {
+		includeAnalysis = false
+		for _, cgf := range pkg.CompiledGoFiles() {
+			s.storeDiagnostics(snapshot, cgf.URI, analysisSource, nil)
+		}
 	}
 	// Don't show any diagnostics on ignored files.
 	if !enableDiagnostics {
@@ -313,6 +398,14 @@ func (s *Server) diagnosePkg(ctx context.Context, snapshot source.Snapshot, pkg
 			s.storeDiagnostics(snapshot, cgf.URI, typeCheckSource, pkgDiagnostics[cgf.URI])
 		}
 	}
+	embedDiagnostics, err := source.EmbedDiagnostics(ctx, snapshot, pkg)
+	if err != nil {
+		event.Error(ctx, "warning: diagnosing embed directives", err, tag.Snapshot.Of(snapshot.ID()), tag.Package.Of(pkg.ID()))
+	} else {
+		for _, cgf := range pkg.CompiledGoFiles() {
+			s.storeDiagnostics(snapshot, cgf.URI, embedSource, embedDiagnostics[cgf.URI])
+		}
+	}
 	if includeAnalysis && !pkg.HasListOrParseErrors() {
 		reports, err := source.Analyze(ctx, snapshot, pkg, false)
 		if err != nil {
@@ -322,6 +415,15 @@ func (s *Server) diagnosePkg(ctx context.Context, snapshot source.Snapshot, pkg
 		for _, cgf := range pkg.CompiledGoFiles() {
 			s.storeDiagnostics(snapshot, cgf.URI, analysisSource, reports[cgf.URI])
 		}
+		if len(snapshot.View().Options().ExternalAnalyzers) > 0 {
+			reports, err := s.externalAnalysis.ExternalAnalyze(ctx, snapshot, pkg)
+			if err != nil {
+				event.Error(ctx, "warning: running external analyzers", err, tag.Snapshot.Of(snapshot.ID()), tag.Package.Of(pkg.ID()))
+			}
+			for _, cgf := range pkg.CompiledGoFiles() {
+				s.storeDiagnostics(snapshot, cgf.URI, externalSource, reports[cgf.URI])
+			}
+		}
 	}
 
 	// If gc optimization details are requested, add them to the
@@ -388,6 +490,41 @@ func (s *Server) storeDiagnostics(snapshot source.Snapshot, uri span.URI, dsourc
 	s.diagnostics[uri].reports[dsource] = report
 }
 
+// externalDiagnostics returns the diagnostics of the external analyzers
+// stored for uri.
+func (s *Server) externalDiagnostics(uri span.URI) []*source.Diagnostic {
+	s.diagnosticsMu.Lock()
+	defer s.diagnosticsMu.Unlock()
+	reports := s.diagnostics[uri]
+	if reports == nil {
+		return nil
+	}
+	var diags []*source.Diagnostic
+	for _, d := range reports.reports[externalSource].diags {
+		diags = append(diags, d)
+	}
+	return diags
+}
+
+// publishedDiagnostic returns the diagnostic of uri, stored by any source,
+// that pd describes, or nil if there is none.
+func (s *Server) publishedDiagnostic(uri span.URI, pd protocol.Diagnostic) *source.Diagnostic {
+	s.diagnosticsMu.Lock()
+	defer s.diagnosticsMu.Unlock()
+	reports := s.diagnostics[uri]
+	if reports == nil {
+		return nil
+	}
+	for _, report := range reports.reports {
+		for _, d := range report.diags {
+			if sameDiagnostic(pd, d) {
+				return d
+			}
+		}
+	}
+	return nil
+}
+
 // clearDiagnosticSource clears all diagnostics for a given source type. It is
 // necessary for cases where diagnostics have been invalidated by something
 // other than a snapshot change, for example when gc_details is toggled.
@@ -454,8 +591,11 @@ func (s *Server) checkForOrphanedFile(ctx context.Context, snapshot source.Snaps
 	if snapshot.IsBuiltin(ctx, fh.URI()) {
 		return nil
 	}
-	pkgs, err := snapshot.PackagesForFile(ctx, fh.URI(), source.TypecheckWorkspace, false)
-	if len(pkgs) > 0 || err == nil {
+	// A file outside the modules of the go.work file still gets a
+	// command-line-arguments package, so check for that before requiring
+	// that the file have no package.
+	pkgs, loadErr := snapshot.PackagesForFile(ctx, fh.URI(), source.TypecheckWorkspace, false)
+	if (len(pkgs) > 0 || loadErr == nil) && snapshot.WorkFile() == "" {
 		return nil
 	}
 	pgf, err := snapshot.ParseGo(ctx, fh, source.ParseHeader)
@@ -477,6 +617,15 @@ func (s *Server) checkForOrphanedFile(ctx context.Context, snapshot source.Snaps
 	if filepath.Ext(fh.URI().Filename()) != ".go" {
 		return nil
 	}
+	// The file may be orphaned because its module is missing from go.work.
+	if d, err := work.OrphanedFileDiagnostic(ctx, snapshot, fh, rng); err != nil {
+		event.Error(ctx, "checking go.work for orphaned file", err, tag.URI.Of(fh.URI()))
+	} else if d != nil {
+		return d
+	}
+	if len(pkgs) > 0 || loadErr == nil {
+		return nil
+	}
 	// TODO(rstambler): We should be able to parse the build tags in the
 	// file and show a more specific error message. For now, put the diagnostic
 	// on the package declaration.
@@ -488,7 +637,7 @@ func (s *Server) checkForOrphanedFile(ctx context.Context, snapshot source.Snaps
 		Message: fmt.Sprintf(`No packages found for open file %s: %v.
 If this file contains build tags, try adding "-tags=<build tag>" to your gopls "buildFlags" configuration (see (https://github.com/golang/tools/blob/master/gopls/doc/settings.md#buildflags-string).
 Otherwise, see the troubleshooting guidelines for help investigating (https://github.com/golang/tools/blob/master/gopls/doc/troubleshooting.md).
-`, fh.URI().Filename(), err),
+`, fh.URI().Filename(), loadErr),
 	}
 }
 
@@ -538,6 +687,7 @@ func (s *Server) publishDiagnostics(ctx context.Context, final bool, snapshot so
 			continue
 		}
 		source.SortDiagnostics(diags)
+		diags = adjustDiagnostics(snapshot.View().Options(), diags)
 		hash := hashDiagnostics(diags...)
 		if hash == r.publishedHash {
 			// Update snapshotID to be the latest snapshot for which this diagnostic
@@ -573,6 +723,51 @@ func (s *Server) publishDiagnostics(ctx context.Context, final bool, snapshot so
 	}
 }
 
+// diagnosticSeverities maps the values of the diagnosticSeverities option
+// to severities.
+var diagnosticSeverities = map[string]protocol.DiagnosticSeverity{
+	"error":       protocol.SeverityError,
+	"warning":     protocol.SeverityWarning,
+	"information": protocol.SeverityInformation,
+	"hint":        protocol.SeverityHint,
+}
+
+// adjustDiagnostics returns the diagnostics of a file to publish, diags
+// with the severities set by the diagnosticSeverities option and, if the
+// batchListErrors option is set, without the go list errors that have
+// the message of an earlier one: their locations are added to the
+// related information of that one. Adjusted diagnostics are copies.
+func adjustDiagnostics(options *source.Options, diags []*source.Diagnostic) []*source.Diagnostic {
+	if len(options.DiagnosticSeverities) == 0 && !options.BatchListErrors {
+		return diags
+	}
+	var result []*source.Diagnostic
+	batches := make(map[string]*source.Diagnostic)
+	for _, d := range diags {
+		if severity, ok := options.DiagnosticSeverities[string(d.Source)]; ok {
+			c := *d
+			c.Severity = diagnosticSeverities[severity]
+			d = &c
+		}
+		if options.BatchListErrors && d.Source == source.ListError {
+			if first, ok := batches[d.Message]; ok {
+				first.Related = append(first.Related, source.RelatedInformation{
+					URI:     d.URI,
+					Range:   d.Range,
+					Message: d.Message,
+				})
+				continue
+			}
+			c := *d
+			c.Related = append([]source.RelatedInformation(nil), d.Related...)
+			d = &c
+			batches[d.Message] = d
+		}
+		result = append(result, d)
+	}
+	return result
+}
+
 func toProtocolDiagnostics(diagnostics []*source.Diagnostic) []protocol.Diagnostic {
 	reports := []protocol.Diagnostic{}
 	for _, diag := range diagnostics {
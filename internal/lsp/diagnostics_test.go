@@ -0,0 +1,45 @@
+// Copyright 2022 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package lsp
+
+import (
+	"testing"
+
+	"github.com/iansmith/golang-x-tools/internal/lsp/protocol"
+	"github.com/iansmith/golang-x-tools/internal/lsp/source"
+)
+
+func TestAdjustDiagnostics(t *testing.T) {
+	at := func(line uint32) protocol.Range {
+		return protocol.Range{Start: protocol.Position{Line: line}, End: protocol.Position{Line: line, Character: 1}}
+	}
+	diags := []*source.Diagnostic{
+		{Range: at(1), Source: source.ListError, Message: "missing go.sum entry", Severity: protocol.SeverityError},
+		{Range: at(2), Source: "printf", Message: "wrong type", Severity: protocol.SeverityWarning},
+		{Range: at(3), Source: source.ListError, Message: "missing go.sum entry", Severity: protocol.SeverityError},
+		{Range: at(4), Source: source.ListError, Message: "no required module", Severity: protocol.SeverityError},
+	}
+	options := &source.Options{}
+	options.DiagnosticSeverities = map[string]string{"printf": "hint"}
+	options.BatchListErrors = true
+
+	got := adjustDiagnostics(options, diags)
+	if len(got) != 3 {
+		t.Fatalf("adjustDiagnostics returned %d diagnostics, want 3", len(got))
+	}
+	if got[0].Range != at(1) || len(got[0].Related) != 1 || got[0].Related[0].Range != at(3) {
+		t.Errorf("batched go list error = %+v, want the one at line 1 with the one at line 3 as related information", got[0])
+	}
+	if got[1].Severity != protocol.SeverityHint {
+		t.Errorf("severity of the printf diagnostic = %v, want %v", got[1].Severity, protocol.SeverityHint)
+	}
+	if got[2].Message != "no required module" || len(got[2].Related) != 0 {
+		t.Errorf("third diagnostic = %+v, want the unbatched no required module error", got[2])
+	}
+	// The stored diagnostics are not modified.
+	if len(diags[0].Related) != 0 || diags[1].Severity != protocol.SeverityWarning {
+		t.Errorf("adjustDiagnostics modified its argument")
+	}
+}
@@ -120,7 +120,7 @@ func (c *Client) ApplyEdit(ctx context.Context, params *protocol.ApplyWorkspaceE
 		return &protocol.ApplyWorkspaceEditResult{FailureReason: "Edit.Changes is unsupported"}, nil
 	}
 	for _, change := range params.Edit.DocumentChanges {
-		if err := c.editor.applyProtocolEdit(ctx, change); err != nil {
+		if err := c.editor.applyDocumentChange(ctx, change); err != nil {
 			return nil, err
 		}
 	}
@@ -124,6 +124,11 @@ type EditorConfig struct {
 	// TODO(rfindley): replace existing EditorConfig fields with Settings.
 	Settings map[string]interface{}
 
+	// NoFileWatching makes the editor behave as a client that cannot watch
+	// files: it does not support the registration of watched files, and does
+	// not send didChangeWatchedFiles notifications.
+	NoFileWatching bool
+
 	ImportShortcut                 string
 	DirectoryFilters               []string
 	VerboseOutput                  bool
@@ -290,6 +295,13 @@ func (e *Editor) initialize(ctx context.Context, workspaceFolders []string) erro
 	}
 
 	params.Capabilities.Workspace.Configuration = true
+	params.Capabilities.Workspace.WorkspaceEdit = &protocol.WorkspaceEditClientCapabilities{
+		DocumentChanges:    true,
+		ResourceOperations: []protocol.ResourceOperationKind{protocol.Rename},
+		ChangeAnnotationSupport: &struct {
+			GroupsOnLabel bool `json:"groupsOnLabel,omitempty"`
+		}{},
+	}
 	params.Capabilities.Window.WorkDoneProgress = true
 	// TODO: set client capabilities
 	params.Capabilities.TextDocument.Completion.CompletionItem.TagSupport.ValueSet = []protocol.CompletionItemTag{protocol.ComplDeprecated}
@@ -307,12 +319,17 @@ func (e *Editor) initialize(ctx context.Context, workspaceFolders []string) erro
 		"event", "function", "method", "macro", "keyword", "modifier", "comment",
 		"string", "number", "regexp", "operator",
 	}
+	params.Capabilities.TextDocument.SemanticTokens.TokenModifiers = []string{
+		"declaration", "definition", "readonly", "static",
+		"deprecated", "abstract", "async", "modification", "documentation", "defaultLibrary",
+		"format",
+	}
 
 	// This is a bit of a hack, since the fake editor doesn't actually support
 	// watching changed files that match a specific glob pattern. However, the
 	// editor does send didChangeWatchedFiles notifications, so set this to
 	// true.
-	params.Capabilities.Workspace.DidChangeWatchedFiles.DynamicRegistration = true
+	params.Capabilities.Workspace.DidChangeWatchedFiles.DynamicRegistration = !e.Config.NoFileWatching
 
 	params.Trace = "messages"
 	// TODO: support workspace folders.
@@ -336,7 +353,7 @@ func (e *Editor) initialize(ctx context.Context, workspaceFolders []string) erro
 // onFileChanges is registered to be called by the Workdir on any writes that
 // go through the Workdir API. It is called synchronously by the Workdir.
 func (e *Editor) onFileChanges(ctx context.Context, evts []FileEvent) {
-	if e.Server == nil {
+	if e.Server == nil || e.Config.NoFileWatching {
 		return
 	}
 
@@ -864,12 +881,18 @@ func (e *Editor) ApplyQuickFixes(ctx context.Context, path string, rng *protocol
 // ApplyCodeAction applies the given code action.
 func (e *Editor) ApplyCodeAction(ctx context.Context, action protocol.CodeAction) error {
 	for _, change := range action.Edit.DocumentChanges {
-		path := e.sandbox.Workdir.URIToPath(change.TextDocument.URI)
-		if int32(e.buffers[path].version) != change.TextDocument.Version {
+		if change.RenameFile != nil {
+			if err := e.renameFile(ctx, change.RenameFile); err != nil {
+				return err
+			}
+			continue
+		}
+		path := e.sandbox.Workdir.URIToPath(change.TextDocumentEdit.TextDocument.URI)
+		if int32(e.buffers[path].version) != change.TextDocumentEdit.TextDocument.Version {
 			// Skip edits for old versions.
 			continue
 		}
-		edits := convertEdits(change.Edits)
+		edits := convertEdits(protocol.AsTextEdits(change.TextDocumentEdit.Edits))
 		if err := e.EditBuffer(ctx, path, edits); err != nil {
 			return fmt.Errorf("editing buffer %q: %w", path, err)
 		}
@@ -1155,7 +1178,47 @@ func (e *Editor) Rename(ctx context.Context, path string, pos Pos, newName strin
 		return err
 	}
 	for _, change := range wsEdits.DocumentChanges {
-		if err := e.applyProtocolEdit(ctx, change); err != nil {
+		if err := e.applyDocumentChange(ctx, change); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// applyDocumentChange applies a change of the DocumentChanges of a
+// workspace edit.
+func (e *Editor) applyDocumentChange(ctx context.Context, change protocol.DocumentChanges) error {
+	if change.RenameFile != nil {
+		return e.renameFile(ctx, change.RenameFile)
+	}
+	return e.applyProtocolEdit(ctx, *change.TextDocumentEdit)
+}
+
+// renameFile renames a file or directory on disk, reopening the buffers
+// of the files that moved at their new paths with their current content.
+func (e *Editor) renameFile(ctx context.Context, rename *protocol.RenameFile) error {
+	oldPath := e.sandbox.Workdir.URIToPath(rename.OldURI)
+	newPath := e.sandbox.Workdir.URIToPath(rename.NewURI)
+
+	e.mu.Lock()
+	moved := make(map[string]buffer)
+	for path, buf := range e.buffers {
+		if path == oldPath || strings.HasPrefix(path, oldPath+"/") {
+			moved[path] = buf
+		}
+	}
+	e.mu.Unlock()
+
+	for path := range moved {
+		if err := e.CloseBuffer(ctx, path); err != nil {
+			return err
+		}
+	}
+	if err := e.sandbox.Workdir.RenameFile(ctx, oldPath, newPath); err != nil {
+		return err
+	}
+	for path, buf := range moved {
+		if err := e.createBuffer(ctx, newPath+strings.TrimPrefix(path, oldPath), buf.dirty, buf.text()); err != nil {
 			return err
 		}
 	}
@@ -1180,7 +1243,7 @@ func (e *Editor) applyProtocolEdit(ctx context.Context, change protocol.TextDocu
 			return err
 		}
 	}
-	fakeEdits := convertEdits(change.Edits)
+	fakeEdits := convertEdits(protocol.AsTextEdits(change.Edits))
 	return e.EditBuffer(ctx, path, fakeEdits)
 }
 
@@ -258,6 +258,15 @@ func (w *Workdir) RemoveFile(ctx context.Context, path string) error {
 	return nil
 }
 
+// RenameFile renames the workdir-relative file or directory oldPath to
+// newPath.
+func (w *Workdir) RenameFile(ctx context.Context, oldPath, newPath string) error {
+	if err := os.Rename(w.AbsPath(oldPath), w.AbsPath(newPath)); err != nil {
+		return fmt.Errorf("renaming %q: %w", oldPath, err)
+	}
+	return w.CheckForFileChanges(ctx)
+}
+
 func (w *Workdir) sendEvents(ctx context.Context, evts []FileEvent) {
 	if len(evts) == 0 {
 		return
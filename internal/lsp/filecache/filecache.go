@@ -0,0 +1,99 @@
+// Copyright 2022 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package filecache provides a persistent, content-addressed cache of the
+// data computed by gopls, such as the symbols of files, so that it survives
+// restarts and is shared by the gopls processes of a user.
+//
+// The cache lives in the directory named by the GOPLSCACHE environment
+// variable, if set, and in the gopls subdirectory of the user cache
+// directory otherwise. A value of "off" disables the cache.
+package filecache
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// ErrNotFound is returned by Get if there is no value for the given key.
+var ErrNotFound = errors.New("not found in the file cache")
+
+// Get returns the value of the given kind stored with key.
+func Get(kind string, key [32]byte) ([]byte, error) {
+	name, err := filename(kind, key)
+	if err != nil {
+		return nil, err
+	}
+	data, err := ioutil.ReadFile(name)
+	if os.IsNotExist(err) {
+		return nil, ErrNotFound
+	}
+	return data, err
+}
+
+// Set stores value as the value of the given kind with key, replacing any
+// previous value. Concurrent calls to Get never observe partial values.
+func Set(kind string, key [32]byte, value []byte) error {
+	name, err := filename(kind, key)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(name), 0700); err != nil {
+		return err
+	}
+	// Write to a temporary file, and rename it atomically.
+	tmp, err := ioutil.TempFile(filepath.Dir(name), filepath.Base(name)+".tmp")
+	if err != nil {
+		return err
+	}
+	_, err = tmp.Write(value)
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Rename(tmp.Name(), name)
+	}
+	if err != nil {
+		os.Remove(tmp.Name())
+	}
+	return err
+}
+
+// filename returns the name of the file storing the value of the given kind
+// with key. Kinds are used as directory names, and should be versioned, such
+// as "symbols-v1", so that changes of the format of values don't break older
+// gopls versions.
+func filename(kind string, key [32]byte) (string, error) {
+	root, err := getDir()
+	if err != nil {
+		return "", err
+	}
+	hex := fmt.Sprintf("%x", key)
+	return filepath.Join(root, kind, hex[:2], hex), nil
+}
+
+var (
+	dirOnce sync.Once
+	dir     string
+	dirErr  error
+)
+
+func getDir() (string, error) {
+	dirOnce.Do(func() {
+		dir = os.Getenv("GOPLSCACHE")
+		switch dir {
+		case "off":
+			dirErr = errors.New("the file cache is disabled")
+		case "":
+			var cacheDir string
+			cacheDir, dirErr = os.UserCacheDir()
+			dir = filepath.Join(cacheDir, "gopls")
+		}
+	})
+	return dir, dirErr
+}
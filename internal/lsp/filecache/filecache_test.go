@@ -0,0 +1,47 @@
+// Copyright 2022 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package filecache
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"io/ioutil"
+	"os"
+	"testing"
+)
+
+func TestMain(m *testing.M) {
+	tmp, err := ioutil.TempDir("", "filecache-test")
+	if err != nil {
+		panic(err)
+	}
+	os.Setenv("GOPLSCACHE", tmp)
+	code := m.Run()
+	os.RemoveAll(tmp)
+	os.Exit(code)
+}
+
+func TestGetSet(t *testing.T) {
+	key := sha256.Sum256([]byte("key"))
+	if _, err := Get("test-v1", key); err != ErrNotFound {
+		t.Fatalf("Get before Set: got error %v, want %v", err, ErrNotFound)
+	}
+	for _, value := range []string{"value", "", "other value"} {
+		if err := Set("test-v1", key, []byte(value)); err != nil {
+			t.Fatal(err)
+		}
+		got, err := Get("test-v1", key)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(got, []byte(value)) {
+			t.Errorf("Get after Set(%q): got %q", value, got)
+		}
+	}
+	// Kinds are separate namespaces.
+	if _, err := Get("test-v2", key); err != ErrNotFound {
+		t.Errorf("Get of another kind: got error %v, want %v", err, ErrNotFound)
+	}
+}
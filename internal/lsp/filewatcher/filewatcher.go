@@ -0,0 +1,190 @@
+// Copyright 2022 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package filewatcher watches directory trees for changes of their files. It
+// is used by gopls when the client cannot watch files itself, such as
+// command-line clients and thin editors that don't support the dynamic
+// registration of workspace/didChangeWatchedFiles.
+//
+// On Linux, changes are reported by inotify. Elsewhere, directory trees are
+// polled.
+package filewatcher
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/iansmith/golang-x-tools/internal/lsp/protocol"
+	"github.com/iansmith/golang-x-tools/internal/span"
+)
+
+// A Watcher watches a set of directory trees, and reports the changes of
+// their files in batches.
+type Watcher struct {
+	filter  func(path string, isDir bool) bool
+	delay   time.Duration
+	handler func([]protocol.FileEvent)
+
+	mu      sync.Mutex
+	backend backend
+	roots   map[string]bool
+	pending map[string]protocol.FileChangeType
+	timer   *time.Timer
+	closed  bool
+}
+
+// A backend notifies its Watcher of the changes of the directory trees it
+// watches.
+type backend interface {
+	// watch starts watching the directory tree rooted at dir.
+	watch(dir string) error
+	// unwatch stops watching the directory tree rooted at dir.
+	unwatch(dir string)
+	close() error
+}
+
+// newBackend returns the backend of w used on this platform. It may be
+// replaced by a platform-specific implementation.
+var newBackend = func(w *Watcher) (backend, error) {
+	return newPoller(w, pollInterval), nil
+}
+
+// New returns a Watcher that calls handler with the changes of the watched
+// files, coalescing the changes that occur within delay of each other.
+//
+// filter reports whether the file or directory at path is of interest. The
+// directories it rejects are not walked, and no change is reported for the
+// files it rejects.
+func New(delay time.Duration, filter func(path string, isDir bool) bool, handler func([]protocol.FileEvent)) (*Watcher, error) {
+	w := &Watcher{
+		filter:  filter,
+		delay:   delay,
+		handler: handler,
+		roots:   make(map[string]bool),
+		pending: make(map[string]protocol.FileChangeType),
+	}
+	b, err := newBackend(w)
+	if err != nil {
+		return nil, err
+	}
+	w.backend = b
+	return w, nil
+}
+
+// Watch sets the roots of the directory trees watched by w: the trees of
+// roots that were not previously watched start being watched, and the trees
+// of previous roots missing from roots stop being watched.
+func (w *Watcher) Watch(roots []string) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.closed {
+		return nil
+	}
+	want := make(map[string]bool)
+	for _, root := range roots {
+		want[root] = true
+	}
+	for root := range w.roots {
+		if !want[root] {
+			w.backend.unwatch(root)
+			delete(w.roots, root)
+		}
+	}
+	var firstErr error
+	for root := range want {
+		if w.roots[root] {
+			continue
+		}
+		if err := w.backend.watch(root); err != nil {
+			if firstErr == nil {
+				firstErr = err
+			}
+			continue
+		}
+		w.roots[root] = true
+	}
+	return firstErr
+}
+
+// Close stops watching all directory trees. Pending changes are dropped.
+func (w *Watcher) Close() error {
+	w.mu.Lock()
+	if w.closed {
+		w.mu.Unlock()
+		return nil
+	}
+	w.closed = true
+	if w.timer != nil {
+		w.timer.Stop()
+	}
+	w.mu.Unlock()
+	// The backend may be notifying w of a change, so it must be closed
+	// without holding w.mu.
+	return w.backend.close()
+}
+
+// notify records the change of the file or directory at path, to be reported
+// once no other change occurred for delay.
+func (w *Watcher) notify(path string, typ protocol.FileChangeType, isDir bool) {
+	if !w.filter(path, isDir) {
+		return
+	}
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.closed {
+		return
+	}
+	if prev, ok := w.pending[path]; ok {
+		var keep bool
+		typ, keep = coalesce(prev, typ)
+		if !keep {
+			delete(w.pending, path)
+			return
+		}
+	}
+	w.pending[path] = typ
+	if w.timer == nil {
+		w.timer = time.AfterFunc(w.delay, w.flush)
+	} else {
+		w.timer.Reset(w.delay)
+	}
+}
+
+// coalesce returns the change equivalent to the change prev followed by the
+// change next of the same file, and whether there is such a change.
+func coalesce(prev, next protocol.FileChangeType) (protocol.FileChangeType, bool) {
+	switch {
+	case prev == protocol.Created && next == protocol.Deleted:
+		return 0, false // the file never existed
+	case prev == protocol.Created:
+		return protocol.Created, true
+	case prev == protocol.Deleted && next == protocol.Created:
+		return protocol.Changed, true
+	}
+	return next, true
+}
+
+// flush reports the pending changes to the handler.
+func (w *Watcher) flush() {
+	w.mu.Lock()
+	if w.closed || len(w.pending) == 0 {
+		w.mu.Unlock()
+		return
+	}
+	events := make([]protocol.FileEvent, 0, len(w.pending))
+	for path, typ := range w.pending {
+		events = append(events, protocol.FileEvent{
+			URI:  protocol.URIFromSpanURI(span.URIFromPath(path)),
+			Type: typ,
+		})
+	}
+	w.pending = make(map[string]protocol.FileChangeType)
+	w.mu.Unlock()
+
+	sort.Slice(events, func(i, j int) bool {
+		return events[i].URI < events[j].URI
+	})
+	w.handler(events)
+}
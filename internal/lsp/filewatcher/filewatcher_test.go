@@ -0,0 +1,141 @@
+// Copyright 2022 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package filewatcher
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/iansmith/golang-x-tools/internal/lsp/protocol"
+)
+
+func TestCoalesce(t *testing.T) {
+	tests := []struct {
+		prev, next protocol.FileChangeType
+		want       protocol.FileChangeType
+		keep       bool
+	}{
+		{protocol.Created, protocol.Changed, protocol.Created, true},
+		{protocol.Created, protocol.Deleted, 0, false},
+		{protocol.Changed, protocol.Changed, protocol.Changed, true},
+		{protocol.Changed, protocol.Deleted, protocol.Deleted, true},
+		{protocol.Deleted, protocol.Created, protocol.Changed, true},
+	}
+	for _, test := range tests {
+		got, keep := coalesce(test.prev, test.next)
+		if got != test.want || keep != test.keep {
+			t.Errorf("coalesce(%v, %v) = %v, %t, want %v, %t", test.prev, test.next, got, keep, test.want, test.keep)
+		}
+	}
+}
+
+func TestWatcher(t *testing.T) {
+	backends := map[string]func(*Watcher) (backend, error){
+		"default": newBackend,
+		"poller": func(w *Watcher) (backend, error) {
+			return newPoller(w, 10*time.Millisecond), nil
+		},
+	}
+	for name, newB := range backends {
+		t.Run(name, func(t *testing.T) {
+			testWatcher(t, newB)
+		})
+	}
+}
+
+func testWatcher(t *testing.T, newB func(*Watcher) (backend, error)) {
+	dir, err := ioutil.TempDir("", "filewatcher-test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	write := func(name, content string) {
+		t.Helper()
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := ioutil.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	write("a.go", "package a")
+	write("ignored/b.go", "package b")
+
+	events := make(chan []protocol.FileEvent, 10)
+	filter := func(path string, isDir bool) bool {
+		if isDir {
+			return filepath.Base(path) != "ignored"
+		}
+		return strings.HasSuffix(path, ".go")
+	}
+	w := &Watcher{
+		filter:  filter,
+		delay:   50 * time.Millisecond,
+		handler: func(evts []protocol.FileEvent) { events <- evts },
+		roots:   make(map[string]bool),
+		pending: make(map[string]protocol.FileChangeType),
+	}
+	if w.backend, err = newB(w); err != nil {
+		t.Fatal(err)
+	}
+	defer w.Close()
+	if err := w.Watch([]string{dir}); err != nil {
+		t.Fatal(err)
+	}
+
+	// expect waits for the next batch of events, and compares it with want,
+	// a list of changes such as "Created a.go".
+	expect := func(want ...string) {
+		t.Helper()
+		var got []protocol.FileEvent
+		select {
+		case got = <-events:
+		case <-time.After(10 * time.Second):
+			t.Fatalf("timed out waiting for %v", want)
+		}
+		var changes []string
+		for _, evt := range got {
+			rel, err := filepath.Rel(dir, evt.URI.SpanURI().Filename())
+			if err != nil {
+				t.Fatal(err)
+			}
+			changes = append(changes, fmt.Sprintf("%v %s", evt.Type, filepath.ToSlash(rel)))
+		}
+		sort.Strings(changes)
+		if strings.Join(changes, "\n") != strings.Join(want, "\n") {
+			t.Errorf("got changes:\n%s\nwant:\n%s", strings.Join(changes, "\n"), strings.Join(want, "\n"))
+		}
+	}
+
+	// The poller only notices changes of the modification time and size.
+	write("a.go", "package a // changed")
+	write("c.go", "package c")
+	write("c.txt", "not Go")
+	write("ignored/d.go", "package d")
+	expect("Changed a.go", "Created c.go")
+
+	write("sub/e.go", "package e")
+	expect("Created sub", "Created sub/e.go")
+
+	if err := os.Remove(filepath.Join(dir, "c.go")); err != nil {
+		t.Fatal(err)
+	}
+	expect("Deleted c.go")
+
+	// A file created and deleted in the same batch is not reported.
+	write("f.go", "package f")
+	if err := os.Remove(filepath.Join(dir, "f.go")); err != nil {
+		t.Fatal(err)
+	}
+	write("g.go", "package g")
+	expect("Created g.go")
+}
@@ -0,0 +1,193 @@
+// Copyright 2022 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package filewatcher
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"unsafe"
+
+	"golang.org/x/sys/unix"
+
+	"github.com/iansmith/golang-x-tools/internal/lsp/protocol"
+)
+
+func init() {
+	newBackend = func(w *Watcher) (backend, error) {
+		return newInotify(w)
+	}
+}
+
+// inotifyMask is the set of inotify events of the watched directories.
+const inotifyMask = unix.IN_CREATE | unix.IN_DELETE | unix.IN_MODIFY | unix.IN_CLOSE_WRITE |
+	unix.IN_MOVED_FROM | unix.IN_MOVED_TO | unix.IN_ONLYDIR | unix.IN_EXCL_UNLINK
+
+// An inotify is a backend that adds an inotify watch to each directory of its
+// trees.
+type inotify struct {
+	w  *Watcher
+	fd int
+
+	mu   sync.Mutex
+	dirs map[string]int // the watch descriptor of each watched directory
+	wds  map[int]string // the directory of each watch descriptor
+
+	stop chan struct{}
+	done chan struct{}
+}
+
+func newInotify(w *Watcher) (*inotify, error) {
+	fd, err := unix.InotifyInit1(unix.IN_CLOEXEC | unix.IN_NONBLOCK)
+	if err != nil {
+		return nil, os.NewSyscallError("inotify_init1", err)
+	}
+	in := &inotify{
+		w:    w,
+		fd:   fd,
+		dirs: make(map[string]int),
+		wds:  make(map[int]string),
+		stop: make(chan struct{}),
+		done: make(chan struct{}),
+	}
+	go in.run()
+	return in, nil
+}
+
+func (in *inotify) watch(dir string) error {
+	if _, err := os.Stat(dir); err != nil {
+		return err
+	}
+	in.addTree(dir, false)
+	return nil
+}
+
+// addTree adds watches to the directories of the tree rooted at dir. If
+// created is set, the tree was just created, and the watcher is notified of
+// its files, which may predate the watches.
+func (in *inotify) addTree(dir string, created bool) {
+	// The watch must be added before reading the directory, so that no file
+	// created in between is missed.
+	wd, err := unix.InotifyAddWatch(in.fd, dir, inotifyMask)
+	if err != nil {
+		return // the directory may have been deleted, or watches exhausted
+	}
+	in.mu.Lock()
+	in.dirs[dir] = wd
+	in.wds[wd] = dir
+	in.mu.Unlock()
+
+	entries, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return
+	}
+	for _, info := range entries {
+		path := filepath.Join(dir, info.Name())
+		if !in.w.filter(path, info.IsDir()) {
+			continue
+		}
+		if created {
+			in.w.notify(path, protocol.Created, info.IsDir())
+		}
+		if info.IsDir() {
+			in.addTree(path, created)
+		}
+	}
+}
+
+func (in *inotify) unwatch(dir string) {
+	in.removeTree(dir)
+}
+
+// removeTree removes the watches of the directories of the tree rooted at
+// dir.
+func (in *inotify) removeTree(dir string) {
+	in.mu.Lock()
+	defer in.mu.Unlock()
+	prefix := dir + string(filepath.Separator)
+	for path, wd := range in.dirs {
+		if path == dir || strings.HasPrefix(path, prefix) {
+			unix.InotifyRmWatch(in.fd, uint32(wd))
+			delete(in.dirs, path)
+			delete(in.wds, wd)
+		}
+	}
+}
+
+func (in *inotify) close() error {
+	close(in.stop)
+	<-in.done
+	return unix.Close(in.fd)
+}
+
+// run reads the events of in until it is closed.
+func (in *inotify) run() {
+	defer close(in.done)
+	buf := make([]byte, 64*(unix.SizeofInotifyEvent+unix.NAME_MAX+1))
+	for {
+		select {
+		case <-in.stop:
+			return
+		default:
+		}
+		// Wait for events with a timeout, so that stop is noticed.
+		fds := []unix.PollFd{{Fd: int32(in.fd), Events: unix.POLLIN}}
+		if n, err := unix.Poll(fds, 100); err != nil || n == 0 {
+			continue
+		}
+		n, err := unix.Read(in.fd, buf)
+		if err != nil || n <= 0 {
+			continue
+		}
+		for offset := 0; offset+unix.SizeofInotifyEvent <= n; {
+			event := (*unix.InotifyEvent)(unsafe.Pointer(&buf[offset]))
+			nameStart := offset + unix.SizeofInotifyEvent
+			offset = nameStart + int(event.Len)
+			if offset > n {
+				break
+			}
+			name := string(bytes.TrimRight(buf[nameStart:offset], "\x00"))
+			in.handle(int(event.Wd), event.Mask, name)
+		}
+	}
+}
+
+// handle notifies the watcher of an inotify event for the entry name of the
+// directory watched by wd.
+func (in *inotify) handle(wd int, mask uint32, name string) {
+	in.mu.Lock()
+	dir, ok := in.wds[wd]
+	if ok && mask&unix.IN_IGNORED != 0 {
+		// The directory was deleted.
+		delete(in.wds, wd)
+		if in.dirs[dir] == wd {
+			delete(in.dirs, dir)
+		}
+	}
+	in.mu.Unlock()
+	if !ok || name == "" {
+		return
+	}
+
+	path := filepath.Join(dir, name)
+	isDir := mask&unix.IN_ISDIR != 0
+	switch {
+	case mask&(unix.IN_CREATE|unix.IN_MOVED_TO) != 0:
+		in.w.notify(path, protocol.Created, isDir)
+		if isDir && in.w.filter(path, true) {
+			in.addTree(path, true)
+		}
+	case mask&(unix.IN_DELETE|unix.IN_MOVED_FROM) != 0:
+		in.w.notify(path, protocol.Deleted, isDir)
+		if isDir {
+			in.removeTree(path)
+		}
+	case mask&(unix.IN_MODIFY|unix.IN_CLOSE_WRITE) != 0 && !isDir:
+		in.w.notify(path, protocol.Changed, false)
+	}
+}
@@ -0,0 +1,147 @@
+// Copyright 2022 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package filewatcher
+
+import (
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/iansmith/golang-x-tools/internal/lsp/protocol"
+)
+
+// pollInterval is the interval at which the poller scans the directory
+// trees it watches.
+const pollInterval = time.Second
+
+// A poller is a backend that periodically scans its directory trees, and
+// compares the scans.
+type poller struct {
+	w *Watcher
+
+	mu    sync.Mutex
+	trees map[string]map[string]fileState // by root, then by path
+
+	stop chan struct{}
+	done chan struct{}
+}
+
+// fileState is the state of a file or directory, as observed by a scan.
+type fileState struct {
+	isDir   bool
+	size    int64
+	modTime time.Time
+}
+
+func newPoller(w *Watcher, interval time.Duration) *poller {
+	p := &poller{
+		w:     w,
+		trees: make(map[string]map[string]fileState),
+		stop:  make(chan struct{}),
+		done:  make(chan struct{}),
+	}
+	go p.run(interval)
+	return p
+}
+
+func (p *poller) run(interval time.Duration) {
+	defer close(p.done)
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-p.stop:
+			return
+		case <-ticker.C:
+			p.poll()
+		}
+	}
+}
+
+// poll scans all the directory trees, and notifies the watcher of their
+// differences with the previous scans.
+func (p *poller) poll() {
+	p.mu.Lock()
+	roots := make([]string, 0, len(p.trees))
+	for root := range p.trees {
+		roots = append(roots, root)
+	}
+	p.mu.Unlock()
+
+	for _, root := range roots {
+		current := p.scan(root)
+		p.mu.Lock()
+		previous, ok := p.trees[root]
+		if ok {
+			p.trees[root] = current
+		}
+		p.mu.Unlock()
+		if !ok {
+			continue // unwatched during the scan
+		}
+		for path, cur := range current {
+			prev, ok := previous[path]
+			switch {
+			case !ok:
+				p.w.notify(path, protocol.Created, cur.isDir)
+			case !cur.isDir && (cur.size != prev.size || !cur.modTime.Equal(prev.modTime)):
+				p.w.notify(path, protocol.Changed, false)
+			}
+		}
+		for path, prev := range previous {
+			if _, ok := current[path]; !ok {
+				p.w.notify(path, protocol.Deleted, prev.isDir)
+			}
+		}
+	}
+}
+
+// scan returns the state of the files and directories of the tree rooted at
+// root that pass the filter of the watcher.
+func (p *poller) scan(root string) map[string]fileState {
+	tree := make(map[string]fileState)
+	filepath.Walk(root, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return nil // the file may have been deleted during the scan
+		}
+		if path != root && !p.w.filter(path, info.IsDir()) {
+			if info.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		tree[path] = fileState{
+			isDir:   info.IsDir(),
+			size:    info.Size(),
+			modTime: info.ModTime(),
+		}
+		return nil
+	})
+	return tree
+}
+
+func (p *poller) watch(dir string) error {
+	if _, err := os.Stat(dir); err != nil {
+		return err
+	}
+	tree := p.scan(dir)
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.trees[dir] = tree
+	return nil
+}
+
+func (p *poller) unwatch(dir string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	delete(p.trees, dir)
+}
+
+func (p *poller) close() error {
+	close(p.stop)
+	<-p.done
+	return nil
+}
@@ -23,7 +23,15 @@ func (s *Server) formatting(ctx context.Context, params *protocol.DocumentFormat
 	case source.Mod:
 		return mod.Format(ctx, snapshot, fh)
 	case source.Go:
-		return source.Format(ctx, snapshot, fh)
+		edits, err := source.Format(ctx, snapshot, fh)
+		if err != nil {
+			return nil, err
+		}
+		content, err := fh.Read()
+		if err != nil {
+			return nil, err
+		}
+		return source.PreserveFileFormat(content, edits), nil
 	case source.Work:
 		return work.Format(ctx, snapshot, fh)
 	}
@@ -21,7 +21,9 @@ import (
 	"github.com/iansmith/golang-x-tools/internal/lsp/debug"
 	"github.com/iansmith/golang-x-tools/internal/lsp/protocol"
 	"github.com/iansmith/golang-x-tools/internal/lsp/source"
+	"github.com/iansmith/golang-x-tools/internal/lsp/telemetry"
 	"github.com/iansmith/golang-x-tools/internal/span"
+	"github.com/iansmith/golang-x-tools/internal/xcontext"
 )
 
 func (s *Server) initialize(ctx context.Context, params *protocol.ParamInitialize) (*protocol.InitializeResult, error) {
@@ -144,17 +146,25 @@ See https://github.com/golang/go/issues/45732 for more information.`,
 			TypeDefinitionProvider:     true,
 			ImplementationProvider:     true,
 			DocumentFormattingProvider: true,
-			DocumentSymbolProvider:     true,
-			WorkspaceSymbolProvider:    true,
+			DocumentOnTypeFormattingProvider: protocol.DocumentOnTypeFormattingOptions{
+				FirstTriggerCharacter: "}",
+				MoreTriggerCharacter:  []string{"{", "\n"},
+			},
+			DocumentSymbolProvider:  true,
+			WorkspaceSymbolProvider: true,
 			ExecuteCommandProvider: protocol.ExecuteCommandOptions{
 				Commands: options.SupportedCommands,
 			},
-			FoldingRangeProvider:      true,
-			HoverProvider:             true,
-			DocumentHighlightProvider: true,
-			DocumentLinkProvider:      protocol.DocumentLinkOptions{},
-			ReferencesProvider:        true,
-			RenameProvider:            renameOpts,
+			FoldingRangeProvider:       true,
+			HoverProvider:              true,
+			DocumentHighlightProvider:  true,
+			DocumentLinkProvider:       protocol.DocumentLinkOptions{},
+			InlayHintProvider:          protocol.InlayHintOptions{},
+			InlineValueProvider:        true,
+			LinkedEditingRangeProvider: true,
+			ReferencesProvider:         true,
+			RenameProvider:             renameOpts,
+			SelectionRangeProvider:     true,
 			SignatureHelpProvider: protocol.SignatureHelpOptions{
 				TriggerCharacters: []string{"(", ","},
 			},
@@ -214,6 +224,32 @@ func (s *Server) initialized(ctx context.Context, params *protocol.InitializedPa
 	if options.SemanticTokens && options.DynamicRegistrationSemanticTokensSupported {
 		registrations = append(registrations, semanticTokenRegistration(options.SemanticTypes, options.SemanticMods))
 	}
+	if options.DynamicWillRenameFilesSupported {
+		// Renaming a directory moves the package in it.
+		registrations = append(registrations, protocol.Registration{
+			ID:     "workspace/willRenameFiles",
+			Method: "workspace/willRenameFiles",
+			RegisterOptions: protocol.FileOperationRegistrationOptions{
+				Filters: []protocol.FileOperationFilter{{
+					Scheme:  "file",
+					Pattern: protocol.FileOperationPattern{Glob: "**", Matches: protocol.FolderOp},
+				}},
+			},
+		})
+	}
+	if options.DynamicWillCreateFilesSupported {
+		// New Go files are filled with a package clause.
+		registrations = append(registrations, protocol.Registration{
+			ID:     "workspace/willCreateFiles",
+			Method: "workspace/willCreateFiles",
+			RegisterOptions: protocol.FileOperationRegistrationOptions{
+				Filters: []protocol.FileOperationFilter{{
+					Scheme:  "file",
+					Pattern: protocol.FileOperationPattern{Glob: "**/*.go", Matches: protocol.FileOp},
+				}},
+			},
+		})
+	}
 	if len(registrations) > 0 {
 		if err := s.client.RegisterCapability(ctx, &protocol.RegistrationParams{
 			Registrations: registrations,
@@ -221,6 +257,20 @@ func (s *Server) initialized(ctx context.Context, params *protocol.InitializedPa
 			return err
 		}
 	}
+
+	// The budget was validated when the option was set.
+	if budget, _ := source.ParseMemoryBudget(options.MemoryBudget); budget > 0 {
+		go s.monitorMemory(xcontext.Detach(ctx), budget)
+	}
+	if options.Telemetry {
+		if err := telemetry.Start(telemetry.Config{Version: debug.Version}); err != nil {
+			event.Error(ctx, "starting telemetry", err)
+		} else {
+			s.stateMu.Lock()
+			s.telemetryStarted = true
+			s.stateMu.Unlock()
+		}
+	}
 	return nil
 }
 
@@ -310,6 +360,9 @@ func (s *Server) addFolders(ctx context.Context, folders []protocol.WorkspaceFol
 // has changed, we unregister and re-register for file watching notifications.
 // updatedSnapshots is the set of snapshots that have been updated.
 func (s *Server) updateWatchedDirectories(ctx context.Context) error {
+	if !s.session.Options().DynamicWatchedFilesSupported {
+		return s.updateFileWatcher(ctx)
+	}
 	patterns := s.session.FileWatchingGlobPatterns(ctx)
 
 	s.watchedGlobPatternsMu.Lock()
@@ -413,6 +466,10 @@ func (s *Server) fetchConfig(ctx context.Context, name string, folder span.URI,
 			return err
 		}
 	}
+	// The configuration must not undo the degraded mode.
+	if s.isMemoryDegraded() {
+		degradeOptions(o)
+	}
 	return nil
 }
 
@@ -490,8 +547,15 @@ func (s *Server) shutdown(ctx context.Context) error {
 	}
 	if s.state != serverShutDown {
 		// drop all the active views
+		s.closeFileWatcher(ctx)
 		s.session.Shutdown(ctx)
 		s.state = serverShutDown
+		if s.telemetryStarted {
+			if err := telemetry.Stop(); err != nil {
+				event.Error(ctx, "stopping telemetry", err)
+			}
+			s.telemetryStarted = false
+		}
 		if s.tempDir != "" {
 			if err := os.RemoveAll(s.tempDir); err != nil {
 				event.Error(ctx, "removing temp dir", err)
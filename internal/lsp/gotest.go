@@ -0,0 +1,174 @@
+// Copyright 2022 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package lsp
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/iansmith/golang-x-tools/internal/lsp/protocol"
+	"github.com/iansmith/golang-x-tools/internal/lsp/source"
+	"github.com/iansmith/golang-x-tools/internal/span"
+)
+
+// A testEvent is an event of the output of go test -json; see
+// 'go doc test2json'.
+type testEvent struct {
+	Action  string
+	Test    string
+	Elapsed float64 // seconds
+	Output  string
+}
+
+// A testResult is the result of a test function run by go test.
+type testResult struct {
+	failed, skipped bool
+	elapsed         float64
+	output          bytes.Buffer
+}
+
+// testEventWriter is an io.Writer that decodes the output of go test
+// -json. It writes the output of the tests to out, and records the
+// results of the top-level test functions in results.
+type testEventWriter struct {
+	out     io.Writer
+	results map[string]*testResult
+	buf     []byte // incomplete last line
+}
+
+func (w *testEventWriter) Write(p []byte) (int, error) {
+	w.buf = append(w.buf, p...)
+	for {
+		i := bytes.IndexByte(w.buf, '\n')
+		if i < 0 {
+			break
+		}
+		w.event(w.buf[:i+1])
+		w.buf = w.buf[i+1:]
+	}
+	return len(p), nil
+}
+
+// flush handles the last line of the output, if it is incomplete.
+func (w *testEventWriter) flush() {
+	if len(w.buf) > 0 {
+		w.event(w.buf)
+		w.buf = nil
+	}
+}
+
+func (w *testEventWriter) event(line []byte) {
+	var e testEvent
+	if err := json.Unmarshal(line, &e); err != nil {
+		// Not an event: pass it through.
+		w.out.Write(line)
+		return
+	}
+	if e.Action == "output" {
+		w.out.Write([]byte(e.Output))
+	}
+	if e.Test == "" {
+		return // output of the package
+	}
+	name := e.Test
+	if i := strings.IndexByte(name, '/'); i >= 0 {
+		name = name[:i] // output of a subtest
+	}
+	r := w.results[name]
+	if r == nil {
+		r = &testResult{}
+		w.results[name] = r
+	}
+	switch e.Action {
+	case "output":
+		r.output.WriteString(e.Output)
+	case "pass", "fail", "skip":
+		if name == e.Test {
+			r.failed = e.Action == "fail"
+			r.skipped = e.Action == "skip"
+			r.elapsed = e.Elapsed
+		}
+	}
+}
+
+// publishTestResults reports the results of the test functions of the
+// file at uri that go test ran as diagnostics on their names. Like the
+// results of a previous run, they last until the next change to the
+// workspace.
+func (s *Server) publishTestResults(ctx context.Context, snapshot source.Snapshot, uri span.URI, results map[string]*testResult) error {
+	fh, err := snapshot.GetFile(ctx, uri)
+	if err != nil {
+		return err
+	}
+	fns, err := source.TestsAndBenchmarks(ctx, snapshot, fh)
+	if err != nil {
+		return err
+	}
+	var diags []*source.Diagnostic
+	ran := make(map[protocol.Range]bool)
+	for _, fn := range append(append(fns.Tests, fns.Benchmarks...), fns.Fuzz...) {
+		r := results[fn.Name]
+		if r == nil {
+			continue
+		}
+		d := &source.Diagnostic{
+			URI:      uri,
+			Range:    fn.NameRng,
+			Severity: protocol.SeverityInformation,
+			Source:   source.TestResult,
+		}
+		switch {
+		case r.failed:
+			d.Severity = protocol.SeverityError
+			d.Message = fmt.Sprintf("%s failed", fn.Name)
+			if output := strings.TrimSpace(r.output.String()); output != "" {
+				d.Message += "\n" + output
+			}
+		case r.skipped:
+			d.Message = fmt.Sprintf("%s skipped", fn.Name)
+		default:
+			d.Message = fmt.Sprintf("%s passed (%.2fs)", fn.Name, r.elapsed)
+		}
+		diags = append(diags, d)
+		ran[fn.NameRng] = true
+	}
+
+	// Replace the results of previous runs of the same functions.
+	s.diagnosticsMu.Lock()
+	if reports := s.diagnostics[uri]; reports != nil {
+		if report, ok := reports.reports[testSource]; ok && report.snapshotID == snapshot.ID() {
+			for hash, d := range report.diags {
+				if ran[d.Range] {
+					delete(report.diags, hash)
+				}
+			}
+		}
+	}
+	s.diagnosticsMu.Unlock()
+
+	s.storeDiagnostics(snapshot, uri, testSource, diags)
+	s.publishDiagnostics(ctx, false, snapshot)
+	return nil
+}
+
+// logWriter is an io.Writer that sends everything written to it to the
+// client as log messages.
+type logWriter struct {
+	ctx    context.Context
+	client protocol.Client
+}
+
+func (w *logWriter) Write(p []byte) (int, error) {
+	// Don't fail just because of a failure to log.
+	w.client.LogMessage(w.ctx, &protocol.LogMessageParams{
+		Type:    protocol.Log,
+		Message: string(p),
+	})
+	return len(p), nil
+}
@@ -11,6 +11,8 @@ import (
 	"go/ast"
 	"go/token"
 	"net/url"
+	"os"
+	"path/filepath"
 	"regexp"
 	"strconv"
 	"strings"
@@ -54,8 +56,8 @@ func modLinks(ctx context.Context, snapshot source.Snapshot, fh source.FileHandl
 		if req.Syntax == nil {
 			continue
 		}
-		// See golang/go#36998: don't link to modules matching GOPRIVATE.
-		if snapshot.View().IsGoPrivatePath(req.Mod.Path) {
+		linkTarget := source.DocLinkTarget(snapshot.View(), req.Mod.Path)
+		if linkTarget == "" {
 			continue
 		}
 		dep := []byte(req.Mod.Path)
@@ -67,7 +69,7 @@ func modLinks(ctx context.Context, snapshot source.Snapshot, fh source.FileHandl
 		// Shift the start position to the location of the
 		// dependency within the require statement.
 		start, end := token.Pos(s+i), token.Pos(s+i+len(dep))
-		target := source.BuildLink(snapshot.View().Options().LinkTarget, "mod/"+req.Mod.String(), "")
+		target := source.BuildLink(linkTarget, "mod/"+req.Mod.String(), "")
 		l, err := toProtocolLink(snapshot, pm.Mapper, target, start, end, source.Mod)
 		if err != nil {
 			return nil, err
@@ -133,17 +135,17 @@ func goLinks(ctx context.Context, snapshot source.Snapshot, fh source.FileHandle
 			if err != nil {
 				continue
 			}
-			// See golang/go#36998: don't link to modules matching GOPRIVATE.
-			if view.IsGoPrivatePath(target) {
+			linkTarget := source.DocLinkTarget(view, target)
+			if linkTarget == "" {
 				continue
 			}
-			if mod, version, ok := moduleAtVersion(target, pkg); ok && strings.ToLower(view.Options().LinkTarget) == "pkg.go.dev" {
+			if mod, version, ok := moduleAtVersion(target, pkg); ok && strings.ToLower(linkTarget) == "pkg.go.dev" {
 				target = strings.Replace(target, mod, mod+"@"+version, 1)
 			}
 			// Account for the quotation marks in the positions.
 			start := imp.Path.Pos() + 1
 			end := imp.Path.End() - 1
-			target = source.BuildLink(view.Options().LinkTarget, target, "")
+			target = source.BuildLink(linkTarget, target, "")
 			l, err := toProtocolLink(snapshot, pgf.Mapper, target, start, end, source.Go)
 			if err != nil {
 				return nil, err
@@ -165,11 +167,89 @@ func goLinks(ctx context.Context, snapshot source.Snapshot, fh source.FileHandle
 				return nil, err
 			}
 			links = append(links, l...)
+			if strings.HasPrefix(comment.Text, generateDirective+" ") {
+				l, err := generateLinks(snapshot, pgf, comment)
+				if err != nil {
+					return nil, err
+				}
+				links = append(links, l...)
+			}
 		}
 	}
 	return links, nil
 }
 
+const generateDirective = "//go:generate"
+
+// generateLinks returns a link for the program run by a //go:generate
+// directive of the form "go run <program>": the documentation of the
+// program's package, or the program itself if it is a file relative to the
+// package directory.
+func generateLinks(snapshot source.Snapshot, pgf *source.ParsedGoFile, comment *ast.Comment) ([]protocol.DocumentLink, error) {
+	// Split the directive into words, remembering their offsets.
+	var words []string
+	var offsets []int
+	text := comment.Text
+	for i := len(generateDirective); i < len(text); {
+		if text[i] == ' ' || text[i] == '\t' {
+			i++
+			continue
+		}
+		start := i
+		for i < len(text) && text[i] != ' ' && text[i] != '\t' {
+			i++
+		}
+		words = append(words, text[start:i])
+		offsets = append(offsets, start)
+	}
+	if len(words) < 3 || words[0] != "go" || words[1] != "run" {
+		return nil, nil
+	}
+	// Skip the flags of go run to find the program.
+	i := 2
+	for i < len(words) && strings.HasPrefix(words[i], "-") {
+		i++
+	}
+	if i == len(words) {
+		return nil, nil
+	}
+	program := words[i]
+	start := comment.Pos() + token.Pos(offsets[i])
+	end := start + token.Pos(len(program))
+
+	view := snapshot.View()
+	var target string
+	if strings.HasSuffix(program, ".go") || strings.HasPrefix(program, ".") {
+		filename := filepath.Join(filepath.Dir(pgf.URI.Filename()), filepath.FromSlash(program))
+		if info, err := os.Stat(filename); err != nil || info.IsDir() {
+			return nil, nil
+		}
+		target = string(protocol.URIFromPath(filename))
+	} else {
+		if !view.Options().ImportShortcut.ShowLinks() {
+			return nil, nil
+		}
+		path := program
+		at := strings.Index(path, "@")
+		if at >= 0 {
+			path = path[:at]
+		}
+		linkTarget := source.DocLinkTarget(view, path)
+		if linkTarget == "" {
+			return nil, nil
+		}
+		if at >= 0 && strings.ToLower(linkTarget) != "pkg.go.dev" {
+			program = path
+		}
+		target = source.BuildLink(linkTarget, program, "")
+	}
+	l, err := toProtocolLink(snapshot, pgf.Mapper, target, start, end, source.Go)
+	if err != nil {
+		return nil, err
+	}
+	return []protocol.DocumentLink{l}, nil
+}
+
 func moduleAtVersion(target string, pkg source.Package) (string, string, bool) {
 	impPkg, err := pkg.GetImport(target)
 	if err != nil {
@@ -294,7 +294,7 @@ func (r *runner) foldingRanges(t *testing.T, prefix string, uri span.URI, ranges
 	}
 
 	// Filter by kind.
-	kinds := []protocol.FoldingRangeKind{protocol.Imports, protocol.Comment}
+	kinds := []protocol.FoldingRangeKind{protocol.Imports, protocol.Comment, protocol.Region}
 	for _, kind := range kinds {
 		var kindOnly []protocol.FoldingRange
 		for _, fRng := range ranges {
@@ -382,6 +382,58 @@ func foldRanges(m *protocol.ColumnMapper, contents string, ranges []protocol.Fol
 	return res, nil
 }
 
+func (r *runner) InlayHints(t *testing.T, spn span.Span) {
+	uri := spn.URI()
+	filename := uri.Filename()
+
+	hints, err := r.server.InlayHint(r.ctx, &protocol.InlayHintParams{
+		TextDocument: protocol.TextDocumentIdentifier{
+			URI: protocol.URIFromSpanURI(uri),
+		},
+		// TODO: add ViewPort
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Map inlay hints to text edits.
+	edits := make([]protocol.TextEdit, len(hints))
+	for i, hint := range hints {
+		var label string
+		for _, part := range hint.Label {
+			label += part.Value
+		}
+		if hint.PaddingLeft {
+			label = " " + label
+		}
+		if hint.PaddingRight {
+			label += " "
+		}
+		edits[i] = protocol.TextEdit{
+			Range:   protocol.Range{Start: *hint.Position, End: *hint.Position},
+			NewText: fmt.Sprintf("<%s>", label),
+		}
+	}
+
+	m, err := r.data.Mapper(uri)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sedits, err := source.FromProtocolEdits(m, edits)
+	if err != nil {
+		t.Error(err)
+	}
+	got := diff.ApplyEdits(string(m.Content), sedits)
+
+	withinlayHints := string(r.data.Golden("inlayHint", filename, func() ([]byte, error) {
+		return []byte(got), nil
+	}))
+
+	if withinlayHints != got {
+		t.Errorf("inlay hints failed for %s, expected:\n%v\ngot:\n%v", filename, withinlayHints, got)
+	}
+}
+
 func (r *runner) Format(t *testing.T, spn span.Span) {
 	uri := spn.URI()
 	filename := uri.Filename()
@@ -1036,9 +1088,13 @@ func (r *runner) PrepareRename(t *testing.T, src span.Span, want *source.Prepare
 	}
 }
 
-func applyTextDocumentEdits(r *runner, edits []protocol.TextDocumentEdit) (map[span.URI]string, error) {
+func applyTextDocumentEdits(r *runner, changes []protocol.DocumentChanges) (map[span.URI]string, error) {
 	res := map[span.URI]string{}
-	for _, docEdits := range edits {
+	for _, change := range changes {
+		docEdits := change.TextDocumentEdit
+		if docEdits == nil {
+			return nil, fmt.Errorf("unexpected rename of %s", change.RenameFile.OldURI)
+		}
 		uri := docEdits.TextDocument.URI.SpanURI()
 		var m *protocol.ColumnMapper
 		// If we have already edited this file, we use the edited version (rather than the
@@ -1052,7 +1108,7 @@ func applyTextDocumentEdits(r *runner, edits []protocol.TextDocumentEdit) (map[s
 			}
 		}
 		res[uri] = string(m.Content)
-		sedits, err := source.FromProtocolEdits(m, docEdits.Edits)
+		sedits, err := source.FromProtocolEdits(m, protocol.AsTextEdits(docEdits.Edits))
 		if err != nil {
 			return nil, err
 		}
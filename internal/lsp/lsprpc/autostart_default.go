@@ -24,6 +24,12 @@ func runRemote(cmd *exec.Cmd) error {
 	return nil
 }
 
+// StartDaemon starts, detached from the current process, the gopls
+// executable with args, such as a daemon to replace the current one.
+func StartDaemon(goplsPath string, args []string) error {
+	return runRemote(exec.Command(goplsPath, args...))
+}
+
 // autoNetworkAddress returns the default network and address for the
 // automatically-started gopls remote. See autostart_posix.go for more
 // information.
@@ -0,0 +1,196 @@
+// Copyright 2022 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package lsprpc
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/net/websocket"
+
+	"github.com/iansmith/golang-x-tools/internal/jsonrpc2"
+)
+
+// Networks of the addresses of ListenAndServe that the net package does not
+// support.
+const (
+	// WebSocketNetwork is the network of ws:// URLs, for browser-based
+	// editors.
+	WebSocketNetwork = "ws"
+	// PipeNetwork is the network of Windows named pipes.
+	PipeNetwork = "pipe"
+)
+
+// ListenOptions configures the authentication of the clients of a
+// WebSocket listener.
+type ListenOptions struct {
+	// Origins are the origins of the web pages allowed to connect, or "*"
+	// for all of them. If empty, only pages served by the local host may
+	// connect. Clients that send no origin, which are not browsers, are
+	// always allowed.
+	Origins []string
+
+	// Token, if set, must be sent by the clients, as the value of the token
+	// query parameter of the URL or as a bearer token of the Authorization
+	// header.
+	Token string
+}
+
+// ListenAndServe is like jsonrpc2.ListenAndServe, but also supports the
+// WebSocketNetwork, whose connections carry a JSON-RPC message per text
+// frame, and the PipeNetwork on Windows.
+func ListenAndServe(ctx context.Context, network, addr string, server jsonrpc2.StreamServer, idleTimeout time.Duration, opts ListenOptions) error {
+	switch network {
+	case WebSocketNetwork:
+		ln, err := listenWebSocket(addr, opts)
+		if err != nil {
+			return err
+		}
+		defer ln.Close()
+		return jsonrpc2.ServeFramed(ctx, ln, server, idleTimeout, jsonrpc2.NewRawStream)
+	case PipeNetwork:
+		ln, err := listenPipe(addr)
+		if err != nil {
+			return err
+		}
+		defer ln.Close()
+		return jsonrpc2.Serve(ctx, ln, server, idleTimeout)
+	}
+	return jsonrpc2.ListenAndServe(ctx, network, addr, server, idleTimeout)
+}
+
+// A wsListener is a net.Listener of the WebSocket connections to the path
+// of a ws:// URL.
+type wsListener struct {
+	ln    net.Listener
+	srv   *http.Server
+	opts  ListenOptions
+	conns chan net.Conn
+
+	closeOnce sync.Once
+	closed    chan struct{}
+}
+
+func listenWebSocket(addr string, opts ListenOptions) (*wsListener, error) {
+	u, err := url.Parse(addr)
+	if err != nil {
+		return nil, err
+	}
+	if u.Scheme != WebSocketNetwork {
+		return nil, fmt.Errorf("invalid WebSocket URL %q: want ws://host:port/path", addr)
+	}
+	ln, err := net.Listen("tcp", u.Host)
+	if err != nil {
+		return nil, err
+	}
+	l := &wsListener{
+		ln:     ln,
+		opts:   opts,
+		conns:  make(chan net.Conn),
+		closed: make(chan struct{}),
+	}
+	path := u.Path
+	if path == "" {
+		path = "/"
+	}
+	mux := http.NewServeMux()
+	mux.Handle(path, websocket.Server{Handshake: l.handshake, Handler: l.serve})
+	l.srv = &http.Server{Handler: mux}
+	go l.srv.Serve(ln)
+	return l, nil
+}
+
+// handshake rejects the clients that do not send the token, and the web
+// pages of origins that are not allowed.
+func (l *wsListener) handshake(config *websocket.Config, req *http.Request) error {
+	if l.opts.Token != "" {
+		token := req.URL.Query().Get("token")
+		if auth := req.Header.Get("Authorization"); strings.HasPrefix(auth, "Bearer ") {
+			token = strings.TrimPrefix(auth, "Bearer ")
+		}
+		if subtle.ConstantTimeCompare([]byte(token), []byte(l.opts.Token)) != 1 {
+			return errors.New("invalid token")
+		}
+	}
+	origin := req.Header.Get("Origin")
+	if origin == "" {
+		return nil
+	}
+	if len(l.opts.Origins) == 0 {
+		u, err := url.Parse(origin)
+		if err != nil {
+			return err
+		}
+		switch u.Hostname() {
+		case "localhost", "127.0.0.1", "::1":
+			return nil
+		}
+		return fmt.Errorf("origin %q is not allowed", origin)
+	}
+	for _, o := range l.opts.Origins {
+		if o == "*" || o == origin {
+			return nil
+		}
+	}
+	return fmt.Errorf("origin %q is not allowed", origin)
+}
+
+// serve hands the connection to Accept, and keeps it open until it is
+// closed, as the websocket package closes it when serve returns.
+func (l *wsListener) serve(ws *websocket.Conn) {
+	ws.PayloadType = websocket.TextFrame
+	c := &wsConn{Conn: ws, done: make(chan struct{})}
+	select {
+	case l.conns <- c:
+	case <-l.closed:
+		return
+	}
+	select {
+	case <-c.done:
+	case <-l.closed:
+	}
+}
+
+func (l *wsListener) Accept() (net.Conn, error) {
+	select {
+	case c := <-l.conns:
+		return c, nil
+	case <-l.closed:
+		return nil, net.ErrClosed
+	}
+}
+
+func (l *wsListener) Close() error {
+	err := net.ErrClosed
+	l.closeOnce.Do(func() {
+		close(l.closed)
+		err = l.srv.Close()
+	})
+	return err
+}
+
+func (l *wsListener) Addr() net.Addr { return l.ln.Addr() }
+
+// A wsConn is a WebSocket connection that tells the handler of the
+// listener when it is closed.
+type wsConn struct {
+	*websocket.Conn
+
+	closeOnce sync.Once
+	done      chan struct{}
+}
+
+func (c *wsConn) Close() error {
+	c.closeOnce.Do(func() { close(c.done) })
+	return c.Conn.Close()
+}
@@ -0,0 +1,103 @@
+// Copyright 2022 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package lsprpc
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"golang.org/x/net/websocket"
+
+	"github.com/iansmith/golang-x-tools/internal/jsonrpc2"
+)
+
+func TestWebSocketListener(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	ln, err := listenWebSocket("ws://127.0.0.1:0/gopls", ListenOptions{Token: "secret"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ln.Close()
+	echo := jsonrpc2.HandlerServer(func(ctx context.Context, reply jsonrpc2.Replier, req jsonrpc2.Request) error {
+		return reply(ctx, req.Params(), nil)
+	})
+	go jsonrpc2.ServeFramed(ctx, ln, echo, 0, jsonrpc2.NewRawStream)
+
+	base := fmt.Sprintf("ws://%s/gopls", ln.Addr())
+	tests := []struct {
+		url, origin string
+		ok          bool
+	}{
+		{base + "?token=secret", "http://localhost:8080", true},
+		{base + "?token=secret", "http://127.0.0.1", true},
+		{base + "?token=secret", "https://example.com", false},
+		{base + "?token=wrong", "http://localhost", false},
+		{base, "http://localhost", false},
+	}
+	for _, test := range tests {
+		ws, err := websocket.Dial(test.url, "", test.origin)
+		if !test.ok {
+			if err == nil {
+				ws.Close()
+				t.Errorf("Dial(%q) from %s succeeded, want an error", test.url, test.origin)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("Dial(%q) from %s: %v", test.url, test.origin, err)
+			continue
+		}
+		conn := jsonrpc2.NewConn(jsonrpc2.NewRawStream(ws))
+		conn.Go(ctx, jsonrpc2.MethodNotFound)
+		var got string
+		if _, err := conn.Call(ctx, "echo", "hello", &got); err != nil {
+			t.Errorf("echo call: %v", err)
+		} else if got != "hello" {
+			t.Errorf("echo call returned %q, want %q", got, "hello")
+		}
+		conn.Close()
+	}
+}
+
+func TestWebSocketOrigins(t *testing.T) {
+	l := &wsListener{opts: ListenOptions{Origins: []string{"https://editor.example"}}}
+	for origin, ok := range map[string]bool{
+		"":                        true,
+		"https://editor.example":  true,
+		"http://localhost":        false,
+		"https://other.example":   false,
+		"https://editor.example/": false,
+	} {
+		req, err := http.NewRequest("GET", "http://localhost/gopls", nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if origin != "" {
+			req.Header.Set("Origin", origin)
+		}
+		if err := l.handshake(&websocket.Config{}, req); (err == nil) != ok {
+			t.Errorf("handshake from origin %q: got error %v, want ok=%t", origin, err, ok)
+		}
+	}
+}
+
+func TestParseAddr(t *testing.T) {
+	for listen, want := range map[string][2]string{
+		"localhost:37374":         {"tcp", "localhost:37374"},
+		"unix;/tmp/gopls":         {"unix", "/tmp/gopls"},
+		"ws://localhost:8080/lsp": {"ws", "ws://localhost:8080/lsp"},
+		`\\.\pipe\gopls`:          {"pipe", `\\.\pipe\gopls`},
+		"auto":                    {"auto", ""},
+	} {
+		network, addr := ParseAddr(listen)
+		if got := [2]string{network, addr}; got != want {
+			t.Errorf("ParseAddr(%q) = %q, want %q", listen, got, want)
+		}
+	}
+}
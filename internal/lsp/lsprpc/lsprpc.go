@@ -13,6 +13,7 @@ import (
 	"log"
 	"net"
 	"os"
+	"runtime"
 	"strconv"
 	"strings"
 	"sync"
@@ -27,6 +28,7 @@ import (
 	"github.com/iansmith/golang-x-tools/internal/lsp/debug"
 	"github.com/iansmith/golang-x-tools/internal/lsp/debug/tag"
 	"github.com/iansmith/golang-x-tools/internal/lsp/protocol"
+	"github.com/iansmith/golang-x-tools/internal/lsp/telemetry"
 )
 
 // Unique identifiers for client/server.
@@ -41,13 +43,47 @@ type StreamServer struct {
 
 	// serverForTest may be set to a test fake for testing.
 	serverForTest protocol.Server
+
+	started time.Time
+
+	// mu guards pending, the number of unanswered calls by session ID,
+	// which the daemon answers before it shuts down.
+	mu      sync.Mutex
+	pending map[string]int
+
+	stopOnce   sync.Once
+	stopped    chan struct{}
+	restarting bool // set before stopped is closed
 }
 
 // NewStreamServer creates a StreamServer using the shared cache. If
 // withTelemetry is true, each session is instrumented with telemetry that
 // records RPC statistics.
 func NewStreamServer(cache *cache.Cache, daemon bool) *StreamServer {
-	return &StreamServer{cache: cache, daemon: daemon}
+	return &StreamServer{
+		cache:   cache,
+		daemon:  daemon,
+		started: time.Now(),
+		pending: make(map[string]int),
+		stopped: make(chan struct{}),
+	}
+}
+
+// Stopped returns a channel that is closed when a client asks the daemon to
+// shut down, once the pending calls of its sessions are answered.
+func (s *StreamServer) Stopped() <-chan struct{} {
+	return s.stopped
+}
+
+// Restarting reports whether the client that stopped the daemon asked for
+// it to be started again once it exits.
+func (s *StreamServer) Restarting() bool {
+	select {
+	case <-s.stopped:
+		return s.restarting
+	default:
+		return false
+	}
 }
 
 func (s *StreamServer) Binder() *ServerBinder {
@@ -89,17 +125,34 @@ func (s *StreamServer) ServeStream(ctx context.Context, conn jsonrpc2.Conn) erro
 	ctx = protocol.WithClient(ctx, client)
 	conn.Go(ctx,
 		protocol.Handlers(
-			handshaker(session, executable, s.daemon,
-				protocol.ServerHandler(server,
-					jsonrpc2.MethodNotFound))))
+			s.handshaker(session, executable,
+				s.countPending(session,
+					countRequests(session,
+						protocol.ServerHandler(server,
+							jsonrpc2.MethodNotFound))))))
 	if s.daemon {
 		log.Printf("Session %s: connected", session.ID())
 		defer log.Printf("Session %s: exited", session.ID())
 	}
-	<-conn.Done()
+	select {
+	case <-conn.Done():
+	case <-s.stopped:
+		// The pending calls are answered: disconnect the client.
+		conn.Close()
+		<-conn.Done()
+	}
 	return conn.Err()
 }
 
+// ServePipe serves a new session over an in-memory pipe, and returns the
+// client end of the connection, for programs that embed gopls. The session
+// is shut down when the connection is closed.
+func (s *StreamServer) ServePipe(ctx context.Context) jsonrpc2.Conn {
+	serverPipe, clientPipe := net.Pipe()
+	go s.ServeStream(ctx, jsonrpc2.NewConn(jsonrpc2.NewRawStream(serverPipe)))
+	return jsonrpc2.NewConn(jsonrpc2.NewRawStream(clientPipe))
+}
+
 // A Forwarder is a jsonrpc2.StreamServer that handles an LSP stream by
 // forwarding it to a remote. This is used when the gopls process started by
 // the editor is in the `-remote` mode, which means it finds and connects to a
@@ -137,6 +190,7 @@ func QueryServerState(ctx context.Context, addr string) (*ServerState, error) {
 	if err != nil {
 		return nil, err
 	}
+	defer serverConn.Close()
 	var state ServerState
 	if err := protocol.Call(ctx, serverConn, sessionsMethod, nil, &state); err != nil {
 		return nil, fmt.Errorf("querying server state: %w", err)
@@ -144,6 +198,23 @@ func QueryServerState(ctx context.Context, addr string) (*ServerState, error) {
 	return &state, nil
 }
 
+// ShutdownServer asks the gopls daemon at addr to shut down, once the
+// pending calls of its sessions are answered or the timeout elapses, and if
+// restart is set to start a new daemon with the same command line. It
+// returns the number of calls left unanswered.
+func ShutdownServer(ctx context.Context, addr string, timeout time.Duration, restart bool) (int, error) {
+	serverConn, err := dialRemote(ctx, addr)
+	if err != nil {
+		return 0, err
+	}
+	defer serverConn.Close()
+	var resp shutdownResponse
+	if err := protocol.Call(ctx, serverConn, shutdownMethod, shutdownRequest{Timeout: timeout, Restart: restart}, &resp); err != nil {
+		return 0, fmt.Errorf("shutting down server: %w", err)
+	}
+	return resp.Pending, nil
+}
+
 // dialRemote is used for making calls into the gopls daemon. addr should be a
 // URL, possibly on the synthetic 'auto' network (e.g. tcp://..., unix://...,
 // or auto://...).
@@ -434,9 +505,11 @@ type handshakeResponse struct {
 // that it looks similar to handshakeResposne, but in fact 'Logfile' and
 // 'DebugAddr' now refer to the client.
 type ClientSession struct {
-	SessionID string `json:"sessionID"`
-	Logfile   string `json:"logfile"`
-	DebugAddr string `json:"debugAddr"`
+	SessionID string   `json:"sessionID"`
+	Logfile   string   `json:"logfile"`
+	DebugAddr string   `json:"debugAddr"`
+	Folders   []string `json:"folders"` // the workspace folders of the session
+	Pending   int      `json:"pending"` // the number of unanswered calls
 }
 
 // ServerState holds information about the gopls daemon process, including its
@@ -446,16 +519,117 @@ type ServerState struct {
 	Logfile         string          `json:"logfile"`
 	DebugAddr       string          `json:"debugAddr"`
 	GoplsPath       string          `json:"goplsPath"`
+	PID             int             `json:"pid"`
+	Args            []string        `json:"args"` // the command line of the daemon
+	Started         time.Time       `json:"started"`
+	HeapAlloc       uint64          `json:"heapAlloc"` // bytes of allocated heap objects
+	Sys             uint64          `json:"sys"`       // bytes obtained from the system
 	CurrentClientID string          `json:"currentClientID"`
 	Clients         []ClientSession `json:"clients"`
+	Cache           []CacheStat     `json:"cache"`
+}
+
+// CacheStat describes the values of one kind held by the cache of the gopls
+// daemon, which is shared by all of its sessions.
+type CacheStat struct {
+	Kind   string `json:"kind"`
+	Count  int    `json:"count"`
+	Shared int    `json:"shared"` // the number of values used by several sessions
+}
+
+// A shutdownRequest asks the gopls daemon to shut down.
+type shutdownRequest struct {
+	// Timeout is how long the daemon waits for its pending calls to be
+	// answered before it shuts down anyway.
+	Timeout time.Duration `json:"timeout"`
+	// Restart asks the daemon to start a new one with the same command line
+	// once it has stopped listening.
+	Restart bool `json:"restart"`
+}
+
+// A shutdownResponse is returned by the gopls daemon before it shuts down.
+type shutdownResponse struct {
+	// Pending is the number of calls left unanswered at the timeout.
+	Pending int `json:"pending"`
 }
 
 const (
 	handshakeMethod = "gopls/handshake"
 	sessionsMethod  = "gopls/sessions"
+	shutdownMethod  = "gopls/shutdown"
 )
 
-func handshaker(session *cache.Session, goplsPath string, logHandshakes bool, handler jsonrpc2.Handler) jsonrpc2.Handler {
+// countRequests counts the requests of each method of session for
+// telemetry, if it is started and the client of session opted in. In a
+// shared daemon, the sessions of the other clients are never counted.
+func countRequests(session *cache.Session, handler jsonrpc2.Handler) jsonrpc2.Handler {
+	return func(ctx context.Context, reply jsonrpc2.Replier, r jsonrpc2.Request) error {
+		if session.Options().Telemetry {
+			telemetry.Inc("request/" + r.Method())
+		}
+		return handler(ctx, reply, r)
+	}
+}
+
+// countPending counts the calls of session while they are pending.
+func (s *StreamServer) countPending(session *cache.Session, handler jsonrpc2.Handler) jsonrpc2.Handler {
+	return func(ctx context.Context, reply jsonrpc2.Replier, r jsonrpc2.Request) error {
+		if _, ok := r.(*jsonrpc2.Call); !ok {
+			return handler(ctx, reply, r)
+		}
+		id := session.ID()
+		s.mu.Lock()
+		s.pending[id]++
+		s.mu.Unlock()
+		var once sync.Once
+		done := func() {
+			once.Do(func() {
+				s.mu.Lock()
+				if s.pending[id]--; s.pending[id] == 0 {
+					delete(s.pending, id)
+				}
+				s.mu.Unlock()
+			})
+		}
+		err := handler(ctx, func(ctx context.Context, result interface{}, err error) error {
+			defer done()
+			return reply(ctx, result, err)
+		}, r)
+		if err != nil {
+			// The call is not answered if the handler fails.
+			done()
+		}
+		return err
+	}
+}
+
+// pendingCalls returns the number of unanswered calls of all the sessions.
+func (s *StreamServer) pendingCalls() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	n := 0
+	for _, count := range s.pending {
+		n += count
+	}
+	return n
+}
+
+// shutdown waits at most timeout for the pending calls to be answered, and
+// returns the number of those that are not.
+func (s *StreamServer) shutdown(timeout time.Duration) int {
+	const poll = 10 * time.Millisecond
+	deadline := time.Now().Add(timeout)
+	for {
+		n := s.pendingCalls()
+		if n == 0 || !time.Now().Before(deadline) {
+			return n
+		}
+		time.Sleep(poll)
+	}
+}
+
+func (s *StreamServer) handshaker(session *cache.Session, goplsPath string, handler jsonrpc2.Handler) jsonrpc2.Handler {
+	logHandshakes := s.daemon
 	return func(ctx context.Context, reply jsonrpc2.Replier, r jsonrpc2.Request) error {
 		switch r.Method() {
 		case handshakeMethod:
@@ -491,22 +665,63 @@ func handshaker(session *cache.Session, goplsPath string, logHandshakes bool, ha
 			return reply(ctx, resp, nil)
 
 		case sessionsMethod:
+			var mem runtime.MemStats
+			runtime.ReadMemStats(&mem)
 			resp := ServerState{
 				GoplsPath:       goplsPath,
+				PID:             os.Getpid(),
+				Args:            os.Args,
+				Started:         s.started,
+				HeapAlloc:       mem.HeapAlloc,
+				Sys:             mem.Sys,
 				CurrentClientID: session.ID(),
 			}
 			if di := debug.GetInstance(ctx); di != nil {
 				resp.Logfile = di.Logfile
 				resp.DebugAddr = di.ListenedDebugAddress()
+				s.mu.Lock()
 				for _, c := range di.State.Clients() {
-					resp.Clients = append(resp.Clients, ClientSession{
+					cs := ClientSession{
 						SessionID: c.Session.ID(),
 						Logfile:   c.Logfile,
 						DebugAddr: c.DebugAddress,
+						Folders:   []string{},
+						Pending:   s.pending[c.Session.ID()],
+					}
+					for _, v := range c.Session.Views() {
+						cs.Folders = append(cs.Folders, v.Folder().Filename())
+					}
+					resp.Clients = append(resp.Clients, cs)
+				}
+				s.mu.Unlock()
+			}
+			if c, ok := session.Cache().(*cache.Cache); ok {
+				for _, stat := range c.Stats() {
+					resp.Cache = append(resp.Cache, CacheStat{
+						Kind:   stat.Kind,
+						Count:  stat.Count,
+						Shared: stat.Shared,
 					})
 				}
 			}
 			return reply(ctx, resp, nil)
+
+		case shutdownMethod:
+			var req shutdownRequest
+			if err := json.Unmarshal(r.Params(), &req); err != nil {
+				sendError(ctx, reply, err)
+				return nil
+			}
+			if logHandshakes {
+				log.Printf("Session %s: asked the daemon to shut down (restart: %t)", session.ID(), req.Restart)
+			}
+			resp := shutdownResponse{Pending: s.shutdown(req.Timeout)}
+			err := reply(ctx, resp, nil)
+			s.stopOnce.Do(func() {
+				s.restarting = req.Restart
+				close(s.stopped)
+			})
+			return err
 		}
 		return handler(ctx, reply, r)
 	}
@@ -531,5 +746,13 @@ func ParseAddr(listen string) (network string, address string) {
 	if parts := strings.SplitN(listen, ";", 2); len(parts) == 2 {
 		return parts[0], parts[1]
 	}
+	// WebSocket URLs and the names of Windows named pipes need no network
+	// prefix.
+	switch {
+	case strings.HasPrefix(listen, WebSocketNetwork+"://"):
+		return WebSocketNetwork, listen
+	case strings.HasPrefix(listen, `\\.\pipe\`):
+		return PipeNetwork, listen
+	}
 	return "tcp", listen
 }
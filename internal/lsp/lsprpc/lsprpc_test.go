@@ -280,6 +280,58 @@ func TestDebugInfoLifecycle(t *testing.T) {
 	}
 }
 
+func TestSharedCacheStats(t *testing.T) {
+	sb, err := fake.NewSandbox(&fake.SandboxConfig{Files: fake.UnpackTxt(exampleProgram)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() {
+		if err := sb.Close(); err != nil {
+			// See TestDebugInfoLifecycle.
+			t.Logf("closing workspace failed: %v", err)
+		}
+	}()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	ctx = debug.WithInstance(ctx, "", "")
+	ss := NewStreamServer(cache.New(nil), false)
+	ts := servertest.NewTCPServer(ctx, ss, nil)
+
+	// Two sessions working on the same module share its type-checked
+	// packages.
+	for i := 0; i < 2; i++ {
+		ed, err := fake.NewEditor(sb, fake.EditorConfig{}).Connect(ctx, ts.Connect(ctx), fake.ClientHooks{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer ed.Close(ctx)
+		if err := ed.OpenFile(ctx, "main.go"); err != nil {
+			t.Fatal(err)
+		}
+		if _, _, err := ed.Hover(ctx, "main.go", fake.Pos{Line: 5, Column: 6}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	state, err := QueryServerState(ctx, "tcp;"+ts.Addr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var packages *CacheStat
+	for i, stat := range state.Cache {
+		if stat.Kind == "packages" {
+			packages = &state.Cache[i]
+		}
+	}
+	if packages == nil {
+		t.Fatalf("no cache statistics about packages in %+v", state.Cache)
+	}
+	if packages.Shared == 0 {
+		t.Errorf("packages are not shared by the sessions: %+v", packages)
+	}
+}
+
 type initServer struct {
 	fakeServer
 
@@ -347,3 +399,77 @@ func TestListenParsing(t *testing.T) {
 		}
 	}
 }
+
+// blockingServer answers hover calls once released.
+type blockingServer struct {
+	fakeServer
+
+	called, release chan struct{}
+}
+
+func (s blockingServer) Hover(ctx context.Context, params *protocol.HoverParams) (*protocol.Hover, error) {
+	s.called <- struct{}{}
+	<-s.release
+	return &protocol.Hover{}, nil
+}
+
+func TestShutdownServer(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	ctx = debug.WithInstance(ctx, "", "")
+
+	server := blockingServer{called: make(chan struct{}), release: make(chan struct{})}
+	ss := NewStreamServer(cache.New(nil), false)
+	ss.serverForTest = server
+	ts := servertest.NewTCPServer(ctx, ss, nil)
+	// The daemon closes the connections when it stops.
+	defer ts.Close()
+	cc := ts.Connect(ctx)
+	cc.Go(ctx, jsonrpc2.MethodNotFound)
+
+	hovered := make(chan error)
+	go func() {
+		_, err := protocol.ServerDispatcher(cc).Hover(ctx, &protocol.HoverParams{})
+		hovered <- err
+	}()
+	<-server.called
+
+	state, err := QueryServerState(ctx, "tcp;"+ts.Addr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pending := 0
+	for _, c := range state.Clients {
+		pending += c.Pending
+	}
+	if pending != 1 {
+		t.Errorf("got %d pending calls in %+v, want 1", pending, state.Clients)
+	}
+
+	// The daemon waits for the hover call before it stops.
+	shutdown := make(chan int)
+	go func() {
+		n, err := ShutdownServer(ctx, "tcp;"+ts.Addr, 10*time.Second, false)
+		if err != nil {
+			t.Error(err)
+		}
+		shutdown <- n
+	}()
+	select {
+	case <-ss.Stopped():
+		t.Fatal("the daemon stopped before answering the hover call")
+	case <-time.After(50 * time.Millisecond):
+	}
+	close(server.release)
+	if err := <-hovered; err != nil {
+		t.Errorf("Hover: %v", err)
+	}
+	if n := <-shutdown; n != 0 {
+		t.Errorf("ShutdownServer left %d calls pending, want 0", n)
+	}
+	<-ss.Stopped()
+	if ss.Restarting() {
+		t.Error("the daemon is restarting, but no restart was asked for")
+	}
+	<-cc.Done()
+}
@@ -0,0 +1,17 @@
+// Copyright 2022 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build !windows
+// +build !windows
+
+package lsprpc
+
+import (
+	"errors"
+	"net"
+)
+
+func listenPipe(name string) (net.Listener, error) {
+	return nil, errors.New("named pipes are only supported on Windows")
+}
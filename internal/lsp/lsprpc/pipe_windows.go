@@ -0,0 +1,203 @@
+// Copyright 2022 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build windows
+// +build windows
+
+package lsprpc
+
+import (
+	"io"
+	"net"
+	"sync"
+	"time"
+	"unsafe"
+
+	"golang.org/x/sys/windows"
+)
+
+// pipeBufferSize is the size of the buffers of the instances of a pipe.
+const pipeBufferSize = 64 << 10
+
+// A pipeListener is a net.Listener of the connections to a named pipe, such
+// as \\.\pipe\gopls, which only the current user and local clients may
+// open.
+type pipeListener struct {
+	name string
+	path *uint16
+	sa   *windows.SecurityAttributes
+
+	mu        sync.Mutex
+	closed    bool
+	accepting bool
+	next      windows.Handle // the instance that the next client connects to
+}
+
+func listenPipe(name string) (net.Listener, error) {
+	path, err := windows.UTF16PtrFromString(name)
+	if err != nil {
+		return nil, err
+	}
+	user, err := windows.GetCurrentProcessToken().GetTokenUser()
+	if err != nil {
+		return nil, err
+	}
+	sd, err := windows.SecurityDescriptorFromString("D:P(A;;GA;;;" + user.User.Sid.String() + ")")
+	if err != nil {
+		return nil, err
+	}
+	l := &pipeListener{
+		name: name,
+		path: path,
+		sa: &windows.SecurityAttributes{
+			Length:             uint32(unsafe.Sizeof(windows.SecurityAttributes{})),
+			SecurityDescriptor: sd,
+		},
+	}
+	// Create the first instance now, so that clients may connect before
+	// Accept and that a pipe of the same name is reported.
+	if l.next, err = l.create(windows.FILE_FLAG_FIRST_PIPE_INSTANCE); err != nil {
+		return nil, &net.OpError{Op: "listen", Net: PipeNetwork, Addr: l.Addr(), Err: err}
+	}
+	return l, nil
+}
+
+func (l *pipeListener) create(flags uint32) (windows.Handle, error) {
+	return windows.CreateNamedPipe(l.path,
+		windows.PIPE_ACCESS_DUPLEX|windows.FILE_FLAG_OVERLAPPED|flags,
+		windows.PIPE_TYPE_BYTE|windows.PIPE_READMODE_BYTE|windows.PIPE_WAIT|windows.PIPE_REJECT_REMOTE_CLIENTS,
+		windows.PIPE_UNLIMITED_INSTANCES, pipeBufferSize, pipeBufferSize, 0, l.sa)
+}
+
+func (l *pipeListener) Accept() (net.Conn, error) {
+	l.mu.Lock()
+	if l.closed {
+		l.mu.Unlock()
+		return nil, net.ErrClosed
+	}
+	h := l.next
+	if h == windows.InvalidHandle {
+		var err error
+		if h, err = l.create(0); err != nil {
+			l.mu.Unlock()
+			return nil, &net.OpError{Op: "accept", Net: PipeNetwork, Addr: l.Addr(), Err: err}
+		}
+		l.next = h
+	}
+	l.accepting = true
+	l.mu.Unlock()
+
+	_, err := waitIO(h, func(o *windows.Overlapped) error {
+		return windows.ConnectNamedPipe(h, o)
+	})
+	if err == windows.ERROR_PIPE_CONNECTED {
+		// The client connected before ConnectNamedPipe.
+		err = nil
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.accepting = false
+	l.next = windows.InvalidHandle
+	if err != nil || l.closed {
+		windows.CloseHandle(h)
+		if l.closed {
+			return nil, net.ErrClosed
+		}
+		return nil, &net.OpError{Op: "accept", Net: PipeNetwork, Addr: l.Addr(), Err: err}
+	}
+	return &pipeConn{h: h, addr: l.Addr()}, nil
+}
+
+func (l *pipeListener) Close() error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.closed {
+		return net.ErrClosed
+	}
+	l.closed = true
+	if l.next != windows.InvalidHandle {
+		if l.accepting {
+			// Accept closes the instance when ConnectNamedPipe is canceled.
+			windows.CancelIoEx(l.next, nil)
+		} else {
+			windows.CloseHandle(l.next)
+			l.next = windows.InvalidHandle
+		}
+	}
+	return nil
+}
+
+func (l *pipeListener) Addr() net.Addr { return pipeAddr(l.name) }
+
+// A pipeConn is the server end of a connection to a named pipe. Its reads
+// and writes are overlapped, so that one does not wait for the other.
+type pipeConn struct {
+	h    windows.Handle
+	addr net.Addr
+
+	closeOnce sync.Once
+}
+
+func (c *pipeConn) Read(b []byte) (int, error) {
+	n, err := waitIO(c.h, func(o *windows.Overlapped) error {
+		return windows.ReadFile(c.h, b, nil, o)
+	})
+	switch err {
+	case windows.ERROR_BROKEN_PIPE, windows.ERROR_PIPE_NOT_CONNECTED:
+		return int(n), io.EOF
+	case windows.ERROR_OPERATION_ABORTED:
+		return int(n), net.ErrClosed
+	}
+	return int(n), err
+}
+
+func (c *pipeConn) Write(b []byte) (int, error) {
+	n, err := waitIO(c.h, func(o *windows.Overlapped) error {
+		return windows.WriteFile(c.h, b, nil, o)
+	})
+	if err == windows.ERROR_OPERATION_ABORTED {
+		err = net.ErrClosed
+	}
+	return int(n), err
+}
+
+func (c *pipeConn) Close() error {
+	err := net.ErrClosed
+	c.closeOnce.Do(func() {
+		windows.CancelIoEx(c.h, nil)
+		err = windows.CloseHandle(c.h)
+	})
+	return err
+}
+
+func (c *pipeConn) LocalAddr() net.Addr  { return c.addr }
+func (c *pipeConn) RemoteAddr() net.Addr { return c.addr }
+
+// Deadlines are not supported, as jsonrpc2 does not use them.
+func (c *pipeConn) SetDeadline(t time.Time) error      { return nil }
+func (c *pipeConn) SetReadDeadline(t time.Time) error  { return nil }
+func (c *pipeConn) SetWriteDeadline(t time.Time) error { return nil }
+
+// waitIO starts the overlapped operation op on h and waits for its result.
+func waitIO(h windows.Handle, op func(*windows.Overlapped) error) (uint32, error) {
+	event, err := windows.CreateEvent(nil, 1, 0, nil)
+	if err != nil {
+		return 0, err
+	}
+	defer windows.CloseHandle(event)
+	o := windows.Overlapped{HEvent: event}
+	if err := op(&o); err != nil && err != windows.ERROR_IO_PENDING {
+		return 0, err
+	}
+	var n uint32
+	err = windows.GetOverlappedResult(h, &o, &n, true)
+	return n, err
+}
+
+// A pipeAddr is the name of a named pipe.
+type pipeAddr string
+
+func (a pipeAddr) Network() string { return PipeNetwork }
+func (a pipeAddr) String() string  { return string(a) }
@@ -0,0 +1,94 @@
+// Copyright 2022 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package lsp
+
+import (
+	"context"
+	"fmt"
+	"runtime"
+	"time"
+
+	"github.com/iansmith/golang-x-tools/internal/event"
+	"github.com/iansmith/golang-x-tools/internal/lsp/protocol"
+	"github.com/iansmith/golang-x-tools/internal/lsp/source"
+)
+
+// memoryCheckInterval is the interval at which the memory used by gopls is
+// compared to the memoryBudget option.
+const memoryCheckInterval = time.Second
+
+// monitorMemory switches the server to degraded mode once the heap exceeds
+// budget bytes. It returns when the server shuts down.
+func (s *Server) monitorMemory(ctx context.Context, budget uint64) {
+	ticker := time.NewTicker(memoryCheckInterval)
+	defer ticker.Stop()
+	for range ticker.C {
+		s.stateMu.Lock()
+		shutDown := s.state == serverShutDown
+		s.stateMu.Unlock()
+		if shutDown {
+			return
+		}
+		var mem runtime.MemStats
+		runtime.ReadMemStats(&mem)
+		if mem.HeapAlloc > budget {
+			s.degrade(ctx, mem.HeapAlloc, budget)
+			return
+		}
+	}
+}
+
+// degrade switches the server to degraded mode: the options of the session
+// and of all of its views, current and future, are degraded so that gopls
+// uses less memory.
+func (s *Server) degrade(ctx context.Context, heap, budget uint64) {
+	s.memoryDegradedMu.Lock()
+	s.memoryDegraded = true
+	s.memoryDegradedMu.Unlock()
+
+	event.Log(ctx, fmt.Sprintf("heap of %d bytes exceeds the memory budget of %d bytes: degrading", heap, budget))
+	options := s.session.Options().Clone()
+	degradeOptions(options)
+	s.session.SetOptions(options)
+	for _, view := range s.session.Views() {
+		options := view.Options().Clone()
+		degradeOptions(options)
+		view, err := view.SetOptions(ctx, options)
+		if err != nil {
+			event.Error(ctx, "degrading view options", err)
+			continue
+		}
+		go func() {
+			snapshot, release := view.Snapshot(ctx)
+			defer release()
+			s.diagnoseDetached(snapshot)
+		}()
+	}
+	if err := s.client.ShowMessage(ctx, &protocol.ShowMessageParams{
+		Type: protocol.Warning,
+		Message: fmt.Sprintf("gopls is using %d MiB of memory, more than its memoryBudget of %d MiB. "+
+			"To use less memory, it now only fully checks packages with open files, and deep completion is disabled.",
+			heap>>20, budget>>20),
+	}); err != nil {
+		event.Error(ctx, "notifying the client of degraded mode", err)
+	}
+}
+
+// isMemoryDegraded reports whether the server is in degraded mode.
+func (s *Server) isMemoryDegraded() bool {
+	s.memoryDegradedMu.Lock()
+	defer s.memoryDegradedMu.Unlock()
+	return s.memoryDegraded
+}
+
+// degradeOptions changes the options of a view in degraded mode.
+func degradeOptions(o *source.Options) {
+	// Only packages with open files are fully type-checked and analyzed, so
+	// fewer packages are cached.
+	o.MemoryMode = source.ModeDegradeClosed
+	// Finding unused symbols needs the references of the whole workspace.
+	o.UnusedSymbols = false
+	o.DeepCompletion = false
+}
@@ -68,11 +68,25 @@ func upgradeLenses(ctx context.Context, snapshot source.Snapshot, fh source.File
 		return nil, err
 	}
 
-	return []protocol.CodeLens{
+	lenses := []protocol.CodeLens{
 		{Range: rng, Command: checkUpgrade},
 		{Range: rng, Command: upgradeTransitive},
 		{Range: rng, Command: upgradeDirect},
-	}, nil
+	}
+
+	// Once the upgrades have been checked, offer to apply all of them.
+	if args := upgradeArgs(pm, snapshot.View().ModuleUpgrades()); len(args) > 0 {
+		upgradeAll, err := command.NewUpgradeDependencyCommand("Upgrade all dependencies", command.DependencyArgs{
+			URI:        uri,
+			AddRequire: false,
+			GoCmdArgs:  args,
+		})
+		if err != nil {
+			return nil, err
+		}
+		lenses = append(lenses, protocol.CodeLens{Range: rng, Command: upgradeAll})
+	}
+	return lenses, nil
 }
 
 func tidyLens(ctx context.Context, snapshot source.Snapshot, fh source.FileHandle) ([]protocol.CodeLens, error) {
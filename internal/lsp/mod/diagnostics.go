@@ -56,6 +56,19 @@ func DiagnosticsForMod(ctx context.Context, snapshot source.Snapshot, fh source.
 
 	// Add upgrade quick fixes for individual modules if we know about them.
 	upgrades := snapshot.View().ModuleUpgrades()
+	var upgradeAll *source.SuggestedFix
+	if args := upgradeArgs(pm, upgrades); len(args) > 1 {
+		cmd, err := command.NewUpgradeDependencyCommand("Upgrade all dependencies", command.DependencyArgs{
+			URI:        protocol.URIFromSpanURI(fh.URI()),
+			AddRequire: false,
+			GoCmdArgs:  args,
+		})
+		if err != nil {
+			return nil, err
+		}
+		fix := source.SuggestedFixFromCommand(cmd, protocol.QuickFix)
+		upgradeAll = &fix
+	}
 	for _, req := range pm.File.Require {
 		ver, ok := upgrades[req.Mod.Path]
 		if !ok || req.Mod.Version == ver {
@@ -75,16 +88,26 @@ func DiagnosticsForMod(ctx context.Context, snapshot source.Snapshot, fh source.
 		if err != nil {
 			return nil, err
 		}
+		fixes := []source.SuggestedFix{source.SuggestedFixFromCommand(cmd, protocol.QuickFix)}
+		if upgradeAll != nil {
+			fixes = append(fixes, *upgradeAll)
+		}
 		diagnostics = append(diagnostics, &source.Diagnostic{
 			URI:            fh.URI(),
 			Range:          rng,
 			Severity:       protocol.SeverityInformation,
 			Source:         source.UpgradeNotification,
 			Message:        fmt.Sprintf("%v can be upgraded", req.Mod.Path),
-			SuggestedFixes: []source.SuggestedFix{source.SuggestedFixFromCommand(cmd, protocol.QuickFix)},
+			SuggestedFixes: fixes,
 		})
 	}
 
+	if d, err := goVersionDiagnostic(snapshot, pm); err != nil {
+		return nil, err
+	} else if d != nil {
+		diagnostics = append(diagnostics, d)
+	}
+
 	// Packages in the workspace can contribute diagnostics to go.mod files.
 	wspkgs, err := snapshot.ActivePackages(ctx)
 	if err != nil && !source.IsNonFatalGoModError(err) {
@@ -114,3 +137,39 @@ func DiagnosticsForMod(ctx context.Context, snapshot source.Snapshot, fh source.
 	}
 	return diagnostics, nil
 }
+
+// goVersionDiagnostic returns the diagnostic of the go directive of pm if it
+// is newer than the go command, which then fails to build the module.
+func goVersionDiagnostic(snapshot source.Snapshot, pm *source.ParsedModule) (*source.Diagnostic, error) {
+	if pm.File.Go == nil {
+		return nil, nil
+	}
+	goversion := snapshot.View().GoVersion()
+	minor, ok := source.GoMinorVersion(pm.File.Go.Version)
+	if !ok || goversion == 0 || minor <= goversion {
+		return nil, nil
+	}
+	rng, err := source.LineToRange(pm.Mapper, pm.URI, pm.File.Go.Syntax.Start, pm.File.Go.Syntax.End)
+	if err != nil {
+		return nil, err
+	}
+	return &source.Diagnostic{
+		URI:      pm.URI,
+		Range:    rng,
+		Severity: protocol.SeverityWarning,
+		Source:   source.GoVersionError,
+		Message:  fmt.Sprintf("module requires go%s, but the go command is go1.%d", pm.File.Go.Version, goversion),
+	}, nil
+}
+
+// upgradeArgs returns the arguments to go get that upgrade the modules
+// required by pm to the versions in upgrades.
+func upgradeArgs(pm *source.ParsedModule, upgrades map[string]string) []string {
+	var args []string
+	for _, req := range pm.File.Require {
+		if ver, ok := upgrades[req.Mod.Path]; ok && req.Mod.Version != ver {
+			args = append(args, req.Mod.Path+"@"+ver)
+		}
+	}
+	return args
+}
@@ -43,6 +43,11 @@ func Hover(ctx context.Context, snapshot source.Snapshot, fh source.FileHandle,
 		return nil, fmt.Errorf("computing cursor position: %w", err)
 	}
 
+	// The cursor may be on the go directive.
+	if goStmt := pm.File.Go; goStmt != nil && goStmt.Syntax.Start.Byte <= offset && offset <= goStmt.Syntax.End.Byte {
+		return goDirectiveHover(snapshot, fh, pm)
+	}
+
 	// Confirm that the cursor is at the position of a require statement.
 	var req *modfile.Require
 	var startPos, endPos int
@@ -86,8 +91,8 @@ func Hover(ctx context.Context, snapshot source.Snapshot, fh source.FileHandle,
 		return nil, err
 	}
 	options := snapshot.View().Options()
-	isPrivate := snapshot.View().IsGoPrivatePath(req.Mod.Path)
-	explanation = formatExplanation(explanation, req, options, isPrivate)
+	linkTarget := source.DocLinkTarget(snapshot.View(), req.Mod.Path)
+	explanation = formatExplanation(explanation, req, options, linkTarget)
 	return &protocol.Hover{
 		Contents: protocol.MarkupContent{
 			Kind:  options.PreferredContentFormat,
@@ -97,7 +102,36 @@ func Hover(ctx context.Context, snapshot source.Snapshot, fh source.FileHandle,
 	}, nil
 }
 
-func formatExplanation(text string, req *modfile.Require, options *source.Options, isPrivate bool) string {
+// goDirectiveHover explains the go directive of pm: the language version
+// it sets, and how it compares with the version of the go command.
+func goDirectiveHover(snapshot source.Snapshot, fh source.FileHandle, pm *source.ParsedModule) (*protocol.Hover, error) {
+	goStmt := pm.File.Go
+	rng, err := source.LineToRange(pm.Mapper, fh.URI(), goStmt.Syntax.Start, goStmt.Syntax.End)
+	if err != nil {
+		return nil, err
+	}
+	version := "go" + goStmt.Version
+	var b strings.Builder
+	fmt.Fprintf(&b, "The go directive sets the version of the Go language of the module to %s. ", version)
+	b.WriteString("The packages of the module can't use the language features added by later versions, such as type parameters in go1.18, ")
+	b.WriteString("and the go command builds them with the semantics of this version, such as module graph pruning from go1.17.")
+	if goversion := snapshot.View().GoVersion(); goversion > 0 {
+		fmt.Fprintf(&b, "\n\nThe go command in use is go1.%d", goversion)
+		if minor, ok := source.GoMinorVersion(goStmt.Version); ok && minor > goversion {
+			fmt.Fprintf(&b, ", which is older than %s: upgrade it to build the module", version)
+		}
+		b.WriteString(".")
+	}
+	return &protocol.Hover{
+		Contents: protocol.MarkupContent{
+			Kind:  snapshot.View().Options().PreferredContentFormat,
+			Value: b.String(),
+		},
+		Range: rng,
+	}, nil
+}
+
+func formatExplanation(text string, req *modfile.Require, options *source.Options, linkTarget string) string {
 	text = strings.TrimSuffix(text, "\n")
 	splt := strings.Split(text, "\n")
 	length := len(splt)
@@ -121,13 +155,12 @@ func formatExplanation(text string, req *modfile.Require, options *source.Option
 
 	imp := splt[length-1] // import path
 	reference := imp
-	// See golang/go#36998: don't link to modules matching GOPRIVATE.
-	if !isPrivate && options.PreferredContentFormat == protocol.Markdown {
+	if linkTarget != "" && options.PreferredContentFormat == protocol.Markdown {
 		target := imp
-		if strings.ToLower(options.LinkTarget) == "pkg.go.dev" {
+		if strings.ToLower(linkTarget) == "pkg.go.dev" {
 			target = strings.Replace(target, req.Mod.Path, req.Mod.String(), 1)
 		}
-		reference = fmt.Sprintf("[%s](%s)", imp, source.BuildLink(options.LinkTarget, target, ""))
+		reference = fmt.Sprintf("[%s](%s)", imp, source.BuildLink(linkTarget, target, ""))
 	}
 	b.WriteString("This module is necessary because " + reference + " is imported in")
 
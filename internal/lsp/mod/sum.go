@@ -0,0 +1,209 @@
+// Copyright 2022 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mod
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"golang.org/x/mod/modfile"
+	"golang.org/x/mod/module"
+	"golang.org/x/mod/semver"
+	"github.com/iansmith/golang-x-tools/internal/event"
+	"github.com/iansmith/golang-x-tools/internal/gocommand"
+	"github.com/iansmith/golang-x-tools/internal/lsp/protocol"
+	"github.com/iansmith/golang-x-tools/internal/lsp/source"
+	"github.com/iansmith/golang-x-tools/internal/span"
+)
+
+// SumHover describes the go.sum entry at the given position: the module
+// version it authenticates, where that version comes from, and whether
+// the module still needs the entry.
+func SumHover(ctx context.Context, snapshot source.Snapshot, fh source.FileHandle, position protocol.Position) (*protocol.Hover, error) {
+	// We only provide hover information for the go.sum files of the view's
+	// go.mod files.
+	modURI := span.URIFromPath(strings.TrimSuffix(fh.URI().Filename(), ".sum") + ".mod")
+	var found bool
+	for _, uri := range snapshot.ModFiles() {
+		if modURI == uri {
+			found = true
+			break
+		}
+	}
+	if !found {
+		return nil, nil
+	}
+
+	ctx, done := event.Start(ctx, "mod.SumHover")
+	defer done()
+
+	content, err := fh.Read()
+	if err != nil {
+		return nil, err
+	}
+	m := protocol.NewColumnMapper(fh.URI(), content)
+	offset, err := m.Offset(position)
+	if err != nil {
+		return nil, fmt.Errorf("computing cursor position: %w", err)
+	}
+
+	// Find the entry on the cursor's line.
+	start := bytes.LastIndexByte(content[:offset], '\n') + 1
+	end := len(content)
+	if i := bytes.IndexByte(content[offset:], '\n'); i >= 0 {
+		end = offset + i
+	}
+	fields := strings.Fields(string(content[start:end]))
+	if len(fields) != 3 {
+		return nil, nil
+	}
+	mod := module.Version{Path: fields[0], Version: strings.TrimSuffix(fields[1], "/go.mod")}
+	isGoMod := mod.Version != fields[1]
+	if err := module.Check(mod.Path, mod.Version); err != nil {
+		return nil, nil
+	}
+
+	modFH, err := snapshot.GetFile(ctx, modURI)
+	if err != nil {
+		return nil, err
+	}
+	pm, err := snapshot.ParseMod(ctx, modFH)
+	if err != nil {
+		return nil, fmt.Errorf("getting modfile handle: %w", err)
+	}
+
+	// Get the range to highlight for the hover: the module path and version.
+	i := bytes.Index(content[start:end], []byte(fields[1]))
+	rng, err := source.ByteOffsetsToRange(m, fh.URI(), start, start+i+len(fields[1]))
+	if err != nil {
+		return nil, err
+	}
+
+	options := snapshot.View().Options()
+	isPrivate := snapshot.View().IsGoPrivatePath(mod.Path)
+	linkTarget := source.DocLinkTarget(snapshot.View(), mod.Path)
+	var b strings.Builder
+	sep := "\n"
+	if options.PreferredContentFormat == protocol.Markdown {
+		sep = "\n\n"
+	}
+	heading := mod.Path + " " + mod.Version
+	if linkTarget != "" && options.PreferredContentFormat == protocol.Markdown {
+		heading = fmt.Sprintf("[%s](%s) %s", mod.Path, source.BuildLink(linkTarget, mod.String(), ""), mod.Version)
+	}
+	b.WriteString("### " + heading + sep)
+	if isGoMod {
+		b.WriteString("This is the hash of the module's go.mod file." + sep)
+	} else {
+		b.WriteString("This is the hash of the module's contents." + sep)
+	}
+
+	// Explain where the module version comes from.
+	switch old := replacedBy(pm.File, mod); {
+	case old != nil:
+		b.WriteString(fmt.Sprintf("This module replaces `%s`.", old))
+	case isPrivate:
+		b.WriteString("This module matches GOPRIVATE and is fetched directly from its repository.")
+	default:
+		b.WriteString("This module is fetched from the module proxy.")
+	}
+	b.WriteString(sep)
+	b.WriteString(sumEntryNeed(ctx, snapshot, pm, mod, isGoMod))
+
+	return &protocol.Hover{
+		Contents: protocol.MarkupContent{
+			Kind:  options.PreferredContentFormat,
+			Value: b.String(),
+		},
+		Range: rng,
+	}, nil
+}
+
+// replacedBy returns the module version replaced by mod in f, if any.
+func replacedBy(f *modfile.File, mod module.Version) *module.Version {
+	for _, rep := range f.Replace {
+		if rep.New == mod {
+			old := rep.Old
+			return &old
+		}
+	}
+	return nil
+}
+
+// sumEntryNeed explains whether the go.sum entry for mod is still needed,
+// based on the module graph reported by `go mod graph`.
+func sumEntryNeed(ctx context.Context, snapshot source.Snapshot, pm *source.ParsedModule, mod module.Version, isGoMod bool) string {
+	stdout, err := snapshot.RunGoCommandDirect(ctx, source.Normal, &gocommand.Invocation{
+		Verb:       "mod",
+		Args:       []string{"graph"},
+		WorkingDir: filepath.Dir(pm.URI.Filename()),
+	})
+	if err != nil {
+		event.Error(ctx, "computing module graph", err)
+		return "Whether this entry is still needed could not be determined."
+	}
+
+	// Collect the module versions in the graph and the version selected
+	// for each module path.
+	graph := make(map[module.Version]bool)
+	selected := make(map[string]string)
+	for _, line := range strings.Split(stdout.String(), "\n") {
+		for _, node := range strings.Fields(line) {
+			i := strings.LastIndex(node, "@")
+			if i < 0 {
+				continue // the main module
+			}
+			v := module.Version{Path: node[:i], Version: node[i+1:]}
+			graph[v] = true
+			if semver.Compare(v.Version, selected[v.Path]) > 0 {
+				selected[v.Path] = v.Version
+			}
+		}
+	}
+
+	// go.sum entries are recorded for replacements, so compare the module
+	// versions after applying the replace directives.
+	var inGraph, isSelected bool
+	for v := range graph {
+		if replacement(pm.File, v) != mod {
+			continue
+		}
+		inGraph = true
+		if selected[v.Path] == v.Version {
+			isSelected = true
+		}
+	}
+	switch {
+	case isSelected:
+		return "This entry is needed: the module version is selected in the build list."
+	case inGraph && isGoMod:
+		return "This entry is needed: the module version is part of the module graph."
+	case inGraph:
+		return "This entry is not needed: a newer version of the module is selected. Running `go mod tidy` will remove it."
+	default:
+		return "This entry is not needed: the module version is not part of the module graph. Running `go mod tidy` will remove it."
+	}
+}
+
+// replacement returns the module version that provides mod after applying
+// the replace directives of f.
+func replacement(f *modfile.File, mod module.Version) module.Version {
+	var found *modfile.Replace
+	for _, rep := range f.Replace {
+		if rep.Old.Path != mod.Path {
+			continue
+		}
+		if rep.Old.Version == mod.Version || (rep.Old.Version == "" && found == nil) {
+			found = rep
+		}
+	}
+	if found == nil {
+		return mod
+	}
+	return found.New
+}
@@ -0,0 +1,49 @@
+// Copyright 2022 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package lsp
+
+import (
+	"context"
+
+	"github.com/iansmith/golang-x-tools/internal/event"
+	"github.com/iansmith/golang-x-tools/internal/lsp/protocol"
+	"github.com/iansmith/golang-x-tools/internal/lsp/source"
+)
+
+// willCreateFiles returns the edits that fill the Go files about to be
+// created with a package clause, following the newFileTemplate setting.
+// Files outside of the views are ignored.
+func (s *Server) willCreateFiles(ctx context.Context, params *protocol.CreateFilesParams) (*protocol.WorkspaceEdit, error) {
+	var docChanges []protocol.TextDocumentEdit
+	for _, create := range params.Files {
+		changes, err := s.newFileChanges(ctx, protocol.DocumentURI(create.URI))
+		if err != nil {
+			// Don't prevent the creation of the file.
+			event.Error(ctx, "computing new file content", err)
+			continue
+		}
+		docChanges = append(docChanges, changes...)
+	}
+	if len(docChanges) == 0 {
+		return nil, nil
+	}
+	return &protocol.WorkspaceEdit{
+		DocumentChanges: protocol.TextDocumentEdits(docChanges),
+	}, nil
+}
+
+// newFileChanges returns the changes that fill the new Go file uri.
+func (s *Server) newFileChanges(ctx context.Context, uri protocol.DocumentURI) ([]protocol.TextDocumentEdit, error) {
+	snapshot, fh, ok, release, err := s.beginFileRequest(ctx, uri, source.Go)
+	defer release()
+	if !ok {
+		return nil, err
+	}
+	edits, err := source.NewFileEdits(ctx, snapshot, fh.URI())
+	if err != nil || len(edits) == 0 {
+		return nil, err
+	}
+	return documentChanges(fh, edits), nil
+}
@@ -0,0 +1,116 @@
+// Copyright 2022 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package lsp
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/iansmith/golang-x-tools/internal/lsp/command"
+	"github.com/iansmith/golang-x-tools/internal/lsp/source"
+	"github.com/iansmith/golang-x-tools/internal/span"
+)
+
+// setToolOverlays sets the overlays of the set_overlays command: the
+// content of files that are not open in the editor. It returns once the
+// affected packages are diagnosed.
+func (s *Server) setToolOverlays(ctx context.Context, overlays []command.FileOverlay) error {
+	s.toolOverlaysMu.Lock()
+	defer s.toolOverlaysMu.Unlock()
+
+	open := make(map[span.URI]bool)
+	for _, o := range s.session.Overlays() {
+		open[o.URI()] = true
+	}
+	var modifications []source.FileModification
+	for _, o := range overlays {
+		uri := o.URI.SpanURI()
+		if !uri.IsFile() {
+			return fmt.Errorf("%s is not a file", o.URI)
+		}
+		action := source.Open
+		if s.toolOverlays[uri] {
+			action = source.Change
+		} else if open[uri] {
+			return fmt.Errorf("%s is open in the editor", uri.Filename())
+		}
+		s.toolOverlayVersion++
+		modifications = append(modifications, source.FileModification{
+			URI:     uri,
+			Action:  action,
+			Version: s.toolOverlayVersion,
+			Text:    []byte(o.Content),
+		})
+	}
+	if err := s.modifyToolOverlays(ctx, modifications); err != nil {
+		return err
+	}
+	for _, m := range modifications {
+		s.toolOverlays[m.URI] = true
+	}
+	return nil
+}
+
+// clearToolOverlays removes the overlays of the set_overlays command of
+// uris, or all of them if uris is empty. It returns once the affected
+// packages are diagnosed.
+func (s *Server) clearToolOverlays(ctx context.Context, uris []span.URI) error {
+	s.toolOverlaysMu.Lock()
+	defer s.toolOverlaysMu.Unlock()
+
+	if len(uris) == 0 {
+		for uri := range s.toolOverlays {
+			uris = append(uris, uri)
+		}
+	}
+	var modifications []source.FileModification
+	for _, uri := range uris {
+		if !s.toolOverlays[uri] {
+			return fmt.Errorf("%s has no overlay set by the %s command", uri.Filename(), command.SetOverlays)
+		}
+		modifications = append(modifications, source.FileModification{
+			URI:     uri,
+			Action:  source.Close,
+			Version: -1,
+		})
+	}
+	if len(modifications) == 0 {
+		return nil
+	}
+	if err := s.modifyToolOverlays(ctx, modifications); err != nil {
+		return err
+	}
+	for _, uri := range uris {
+		delete(s.toolOverlays, uri)
+	}
+	return nil
+}
+
+// forgetToolOverlay records that the editor opened uri, which replaces its
+// overlay set by the set_overlays command, if any.
+func (s *Server) forgetToolOverlay(uri span.URI) {
+	s.toolOverlaysMu.Lock()
+	defer s.toolOverlaysMu.Unlock()
+	delete(s.toolOverlays, uri)
+}
+
+// modifyToolOverlays applies modifications of the overlays of the
+// set_overlays command, and waits until the affected packages are
+// diagnosed.
+func (s *Server) modifyToolOverlays(ctx context.Context, modifications []source.FileModification) error {
+	diagnoseDone := make(chan struct{})
+	s.fileChangeMu.Lock()
+	err := s.processModifications(ctx, modifications, FromToolOverlays, diagnoseDone)
+	s.fileChangeMu.Unlock()
+	if err != nil {
+		return err
+	}
+	select {
+	case <-diagnoseDone:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,110 @@
+// Copyright 2022 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package lsp
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/iansmith/golang-x-tools/internal/event"
+	"github.com/iansmith/golang-x-tools/internal/lsp/debug/tag"
+	"github.com/iansmith/golang-x-tools/internal/lsp/protocol"
+	"github.com/iansmith/golang-x-tools/internal/lsp/source"
+	"github.com/iansmith/golang-x-tools/internal/span"
+)
+
+// diagnosePlatforms stores the errors of the packages of the open files of
+// snapshot that occur only on the platforms of the diagnosticPlatforms
+// option. An error that occurs on several of them is reported once,
+// annotated with all of them.
+func (s *Server) diagnosePlatforms(ctx context.Context, snapshot source.Snapshot) {
+	platforms := snapshot.View().Options().DiagnosticPlatforms
+	if len(platforms) == 0 {
+		return
+	}
+	dirs := make(map[span.URI]bool)
+	for _, o := range s.session.Overlays() {
+		uri := o.URI()
+		if o.Kind() != source.Go || snapshot.FindFile(uri) == nil || snapshot.IgnoredFile(uri) {
+			continue
+		}
+		if view, err := s.session.ViewOf(uri); err != nil || view != snapshot.View() {
+			continue
+		}
+		dirs[span.URIFromPath(filepath.Dir(uri.Filename()))] = true
+	}
+
+	type key struct {
+		uri span.URI
+		rng protocol.Range
+		msg string
+	}
+	type platformDiagnostic struct {
+		diag      *source.Diagnostic
+		platforms []string
+	}
+	merged := make(map[key]*platformDiagnostic)
+	for dir := range dirs {
+		for _, platform := range platforms {
+			reports, err := snapshot.PlatformDiagnostics(ctx, dir, platform)
+			if ctx.Err() != nil {
+				return
+			}
+			if err != nil {
+				event.Error(ctx, "warning: diagnosing for "+platform, err, tag.Directory.Of(dir.Filename()), tag.Snapshot.Of(snapshot.ID()))
+				continue
+			}
+			for uri, diags := range reports {
+				for _, d := range diags {
+					k := key{uri, d.Range, d.Message}
+					if pd, ok := merged[k]; ok {
+						pd.platforms = append(pd.platforms, platform)
+					} else {
+						merged[k] = &platformDiagnostic{d, []string{platform}}
+					}
+				}
+			}
+		}
+	}
+
+	reports := make(map[span.URI][]*source.Diagnostic)
+	for k, pd := range merged {
+		// Errors that also occur on the configured platform are already
+		// reported.
+		if s.hasTypeCheckDiagnostic(snapshot, k.uri, pd.diag) {
+			continue
+		}
+		d := *pd.diag
+		d.Message = fmt.Sprintf("%s (%s)", d.Message, strings.Join(pd.platforms, ", "))
+		reports[k.uri] = append(reports[k.uri], &d)
+	}
+	for uri, diags := range reports {
+		s.storeDiagnostics(snapshot, uri, platformSource, diags)
+	}
+}
+
+// hasTypeCheckDiagnostic reports whether the type-checking diagnostics of
+// the file uri in snapshot include one with the message of diag, at the same
+// position.
+func (s *Server) hasTypeCheckDiagnostic(snapshot source.Snapshot, uri span.URI, diag *source.Diagnostic) bool {
+	s.diagnosticsMu.Lock()
+	defer s.diagnosticsMu.Unlock()
+	reports, ok := s.diagnostics[uri]
+	if !ok {
+		return false
+	}
+	report := reports.reports[typeCheckSource]
+	if report.snapshotID != snapshot.ID() {
+		return false
+	}
+	for _, d := range report.diags {
+		if d.Range.Start == diag.Range.Start && d.Message == diag.Message {
+			return true
+		}
+	}
+	return false
+}
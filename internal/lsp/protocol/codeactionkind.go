@@ -7,5 +7,15 @@ package protocol
 // Custom code actions that aren't explicitly stated in LSP
 const (
 	GoTest CodeActionKind = "goTest"
+
+	// Explain actions describe the origin of a diagnostic.
+	Explain CodeActionKind = "explain"
+
+	// GoGenerated actions navigate between generated files and the
+	// directives that generate them.
+	GoGenerated CodeActionKind = "goGenerated"
+
+	SourceGenerate     CodeActionKind = "source.generate"
+	SourceGenerateTest CodeActionKind = "source.generate_test"
 	// TODO: Add GoGenerate, RegenerateCgo etc.
 )
@@ -0,0 +1,80 @@
+// Copyright 2022 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package protocol
+
+import (
+	"encoding/json"
+	"errors"
+)
+
+// DocumentChanges is an element of the DocumentChanges of a
+// WorkspaceEdit: either an edit to a text document or the rename of a
+// file or directory. Exactly one of its fields is non-nil.
+type DocumentChanges struct {
+	TextDocumentEdit *TextDocumentEdit
+	RenameFile       *RenameFile
+}
+
+// TextDocumentEdits returns DocumentChanges for each of edits.
+func TextDocumentEdits(edits []TextDocumentEdit) []DocumentChanges {
+	changes := make([]DocumentChanges, len(edits))
+	for i := range edits {
+		changes[i] = DocumentChanges{TextDocumentEdit: &edits[i]}
+	}
+	return changes
+}
+
+func (d DocumentChanges) MarshalJSON() ([]byte, error) {
+	switch {
+	case d.TextDocumentEdit != nil:
+		return json.Marshal(d.TextDocumentEdit)
+	case d.RenameFile != nil:
+		return json.Marshal(d.RenameFile)
+	}
+	return nil, errors.New("empty DocumentChanges")
+}
+
+func (d *DocumentChanges) UnmarshalJSON(data []byte) error {
+	var kind struct {
+		Kind string `json:"kind"`
+	}
+	if err := json.Unmarshal(data, &kind); err != nil {
+		return err
+	}
+	switch kind.Kind {
+	case "":
+		d.TextDocumentEdit = new(TextDocumentEdit)
+		return json.Unmarshal(data, d.TextDocumentEdit)
+	case "rename":
+		d.RenameFile = new(RenameFile)
+		return json.Unmarshal(data, d.RenameFile)
+	}
+	return errors.New("unsupported document change of kind " + kind.Kind)
+}
+
+// AsAnnotatedTextEdits returns edits as the unannotated edits of a
+// TextDocumentEdit.
+func AsAnnotatedTextEdits(edits []TextEdit) []AnnotatedTextEdit {
+	if edits == nil {
+		return nil
+	}
+	result := make([]AnnotatedTextEdit, len(edits))
+	for i, edit := range edits {
+		result[i] = AnnotatedTextEdit{TextEdit: edit}
+	}
+	return result
+}
+
+// AsTextEdits returns the TextEdits of edits, without their annotations.
+func AsTextEdits(edits []AnnotatedTextEdit) []TextEdit {
+	if edits == nil {
+		return nil
+	}
+	result := make([]TextEdit, len(edits))
+	for i, edit := range edits {
+		result[i] = edit.TextEdit
+	}
+	return result
+}
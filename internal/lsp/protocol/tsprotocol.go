@@ -21,7 +21,7 @@ type AnnotatedTextEdit struct {
 	/**
 	 * The actual identifier of the change annotation
 	 */
-	AnnotationID ChangeAnnotationIdentifier `json:"annotationId"`
+	AnnotationID ChangeAnnotationIdentifier `json:"annotationId,omitempty"`
 	TextEdit
 }
 
@@ -4999,7 +4999,7 @@ type TextDocumentEdit struct {
 	 * @since 3.16.0 - support for AnnotatedTextEdit. This is guarded using a
 	 * client capability.
 	 */
-	Edits []TextEdit/*TextEdit | AnnotatedTextEdit*/ `json:"edits"`
+	Edits []AnnotatedTextEdit/*TextEdit | AnnotatedTextEdit*/ `json:"edits"`
 }
 
 /**
@@ -5699,7 +5699,7 @@ type WorkspaceEdit struct {
 	 * If a client neither supports `documentChanges` nor `workspace.workspaceEdit.resourceOperations` then
 	 * only plain `TextEdit`s using the `changes` property are supported.
 	 */
-	DocumentChanges []TextDocumentEdit/*TextDocumentEdit | CreateFile | RenameFile | DeleteFile*/ `json:"documentChanges,omitempty"`
+	DocumentChanges []DocumentChanges/*TextDocumentEdit | CreateFile | RenameFile | DeleteFile*/ `json:"documentChanges,omitempty"`
 	/**
 	 * A map of change annotations that can be referenced in `AnnotatedTextEdit`s or create, rename and
 	 * delete file / folder operations.
@@ -5708,7 +5708,7 @@ type WorkspaceEdit struct {
 	 *
 	 * @since 3.16.0
 	 */
-	ChangeAnnotations map[string]ChangeAnnotationIdentifier/*[id: ChangeAnnotationIdentifier]: ChangeAnnotation;*/ `json:"changeAnnotations,omitempty"`
+	ChangeAnnotations map[string]ChangeAnnotation/*[id: ChangeAnnotationIdentifier]: ChangeAnnotation;*/ `json:"changeAnnotations,omitempty"`
 }
 
 type WorkspaceEditClientCapabilities struct {
@@ -5746,7 +5746,7 @@ type WorkspaceEditClientCapabilities struct {
 	 *
 	 * @since 3.16.0
 	 */
-	ChangeAnnotationSupport struct {
+	ChangeAnnotationSupport *struct {
 		/**
 		 * Whether the client groups edits with equal labels into tree nodes,
 		 * for instance all edits labelled with "Changes in Strings" would
@@ -697,7 +697,8 @@ var starred: [string, string][] = [
   ['TextDocumentContentChangeEvent', 'range'], ['CodeAction', 'command'],
   ['CodeAction', 'disabled'],
   ['DidSaveTextDocumentParams', 'text'], ['CompletionItem', 'command'],
-  ['Diagnostic', 'codeDescription']
+  ['Diagnostic', 'codeDescription'],
+  ['WorkspaceEditClientCapabilities', 'changeAnnotationSupport']
 ];
 
 // generate Go code for an interface
@@ -709,7 +710,11 @@ function goInterface(d: Data, nm: string) {
     if (!ts.isPropertySignature(n))
       throw new Error(`expected PropertySignature got ${strKind(n)} `);
     ans = ans.concat(getComments(n));
-    const json = u.JSON(n);
+    let json = u.JSON(n);
+    // AnnotatedTextEdit also stands for the TextEdits of TextDocumentEdit.edits
+    if (d.name == 'AnnotatedTextEdit' && n.name.getText() == 'annotationId') {
+      json = json.substring(0, json.length - 2) + ',omitempty"`';
+    }
     let gt = goType(n.type, n.name.getText());
     if (gt == d.name) gt = '*' + gt; // avoid recursive types (SelectionRange)
     // there are several cases where a * is needed
@@ -902,7 +907,7 @@ function goUnionType(n: ts.UnionTypeNode, nm: string): string {
         return `*TextEdit ${help}`;
       }
       if (a == 'TypeReference') {
-        if (nm == 'edits') return `${goType(n.types[0], '901')} ${help}`;
+        if (nm == 'edits') return `${goType(n.types[1], '901')} ${help}`;
         if (a == b) return `interface{} ${help}`;
         if (nm == 'code') return `interface{} ${help}`;
         if (nm == 'editRange') return `${goType(n.types[0], '904')} ${help}`;
@@ -949,7 +954,7 @@ function goUnionType(n: ts.UnionTypeNode, nm: string): string {
       break;
     }
     case 4:
-      if (nm == 'documentChanges') return `TextDocumentEdit ${help} `;
+      if (nm == 'documentChanges') return `DocumentChanges ${help} `;
       if (nm == 'textDocument/prepareRename') {
         // these names have to be made unique
         const genName = `${goName("prepareRename")}${extraTypes.size}Gn`;
@@ -1102,7 +1107,7 @@ function goTypeLiteral(n: ts.TypeLiteralNode, nm: string): string {
       if (nx.getText() == '[uri: string]: TextEdit[];') {
         res = 'map[string][]TextEdit';
       } else if (nx.getText().startsWith('[id: ChangeAnnotationIdentifier]')) {
-        res = 'map[string]ChangeAnnotationIdentifier';
+        res = 'map[string]ChangeAnnotation';
       } else if (nx.getText().startsWith('[uri: string')) {
         res = 'map[string]interface{}';
       } else if (nx.getText().startsWith('[uri: DocumentUri')) {
@@ -21,6 +21,9 @@ func (s *Server) references(ctx context.Context, params *protocol.ReferenceParam
 	if snapshot.View().FileKind(fh) == source.Tmpl {
 		return template.References(ctx, snapshot, fh, params)
 	}
+	if snapshot.View().FileKind(fh) == source.Asm {
+		return source.AsmReferences(ctx, snapshot, fh, params.Position, params.Context.IncludeDeclaration)
+	}
 	references, err := source.References(ctx, snapshot, fh, params.Position, params.Context.IncludeDeclaration)
 	if err != nil {
 		return nil, err
@@ -36,5 +39,9 @@ func (s *Server) references(ctx context.Context, params *protocol.ReferenceParam
 			Range: refRange,
 		})
 	}
-	return locations, nil
+	asmLocations, err := source.AsmReferencesTo(ctx, snapshot, fh, params.Position, params.Context.IncludeDeclaration)
+	if err != nil {
+		return nil, err
+	}
+	return append(locations, asmLocations...), nil
 }
@@ -6,9 +6,14 @@ package lsp
 
 import (
 	"context"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
 
+	"github.com/iansmith/golang-x-tools/internal/event"
 	"github.com/iansmith/golang-x-tools/internal/lsp/protocol"
 	"github.com/iansmith/golang-x-tools/internal/lsp/source"
+	"github.com/iansmith/golang-x-tools/internal/span"
 )
 
 func (s *Server) rename(ctx context.Context, params *protocol.RenameParams) (*protocol.WorkspaceEdit, error) {
@@ -17,11 +22,19 @@ func (s *Server) rename(ctx context.Context, params *protocol.RenameParams) (*pr
 	if !ok {
 		return nil, err
 	}
-	edits, err := source.Rename(ctx, snapshot, fh, params.Position, params.NewName)
+	edits, renameDir, err := source.Rename(ctx, snapshot, fh, params.Position, params.NewName)
 	if err != nil {
 		return nil, err
 	}
+	return renameEdit(ctx, snapshot, edits, renameDir, params.NewName)
+}
 
+// renameEdit returns the workspace edit of the edits of a renaming to
+// newName, followed by the rename of renameDir, if not nil.
+func renameEdit(ctx context.Context, snapshot source.Snapshot, edits map[span.URI][]protocol.TextEdit, renameDir *protocol.RenameFile, newName string) (*protocol.WorkspaceEdit, error) {
+	if err := source.CheckGeneratedEdits(ctx, snapshot, edits); err != nil {
+		return nil, err
+	}
 	var docChanges []protocol.TextDocumentEdit
 	for uri, e := range edits {
 		fh, err := snapshot.GetVersionedFile(ctx, uri)
@@ -30,9 +43,15 @@ func (s *Server) rename(ctx context.Context, params *protocol.RenameParams) (*pr
 		}
 		docChanges = append(docChanges, documentChanges(fh, e)...)
 	}
-	return &protocol.WorkspaceEdit{
-		DocumentChanges: docChanges,
-	}, nil
+	changes := protocol.TextDocumentEdits(docChanges)
+	if renameDir != nil {
+		changes = append(changes, protocol.DocumentChanges{RenameFile: renameDir})
+	}
+	edit := &protocol.WorkspaceEdit{
+		DocumentChanges: changes,
+	}
+	annotateLargeEdit(snapshot.View().Options(), edit, "Rename to "+newName)
+	return edit, nil
 }
 
 func (s *Server) prepareRename(ctx context.Context, params *protocol.PrepareRenameParams) (*protocol.PrepareRename2Gn, error) {
@@ -54,3 +73,82 @@ func (s *Server) prepareRename(ctx context.Context, params *protocol.PrepareRena
 		Placeholder: item.Text,
 	}, nil
 }
+
+// willRenameFiles returns the edits that moving the packages in the
+// renamed directories requires, so that renaming the directory of a
+// package in the client moves the package. Renamed files, and
+// directories that contain no Go files, are ignored.
+func (s *Server) willRenameFiles(ctx context.Context, params *protocol.RenameFilesParams) (*protocol.WorkspaceEdit, error) {
+	var docChanges []protocol.TextDocumentEdit
+	for _, rename := range params.Files {
+		changes, err := s.movePackageChanges(ctx, rename)
+		if err != nil {
+			return nil, err
+		}
+		docChanges = append(docChanges, changes...)
+	}
+	if len(docChanges) == 0 {
+		return nil, nil
+	}
+	edit := &protocol.WorkspaceEdit{
+		DocumentChanges: protocol.TextDocumentEdits(docChanges),
+	}
+	annotateLargeEdit(s.session.Options(), edit, "Move package")
+	return edit, nil
+}
+
+// movePackageChanges returns the changes that moving the package in the
+// directory renamed by rename requires.
+func (s *Server) movePackageChanges(ctx context.Context, rename protocol.FileRename) ([]protocol.TextDocumentEdit, error) {
+	file := packageFile(span.URIFromURI(rename.OldURI).Filename())
+	if file == "" {
+		return nil, nil
+	}
+	snapshot, fh, ok, release, err := s.beginFileRequest(ctx, protocol.URIFromPath(file), source.Go)
+	defer release()
+	if !ok {
+		return nil, err
+	}
+	edits, err := source.MovePackage(ctx, snapshot, fh.URI(), span.URIFromURI(rename.NewURI))
+	if err == nil {
+		err = source.CheckGeneratedEdits(ctx, snapshot, edits)
+	}
+	if err != nil {
+		// Don't prevent the rename of directories that gopls cannot
+		// move, such as those outside of a module.
+		event.Error(ctx, "computing package move", err)
+		return nil, nil
+	}
+	var docChanges []protocol.TextDocumentEdit
+	for uri, e := range edits {
+		fh, err := snapshot.GetVersionedFile(ctx, uri)
+		if err != nil {
+			return nil, err
+		}
+		docChanges = append(docChanges, documentChanges(fh, e)...)
+	}
+	return docChanges, nil
+}
+
+// packageFile returns a Go file in dir, preferring one that is not a
+// test, or "" if dir is not a directory containing Go files.
+func packageFile(dir string) string {
+	infos, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return ""
+	}
+	file := ""
+	for _, info := range infos {
+		name := info.Name()
+		if info.IsDir() || !strings.HasSuffix(name, ".go") {
+			continue
+		}
+		if !strings.HasSuffix(name, "_test.go") {
+			return filepath.Join(dir, name)
+		}
+		if file == "" {
+			file = filepath.Join(dir, name)
+		}
+	}
+	return file
+}
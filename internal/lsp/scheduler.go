@@ -0,0 +1,74 @@
+// Copyright 2022 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package lsp
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// maxYield bounds the time background work yields to interactive requests,
+// so that a stream of them cannot starve it.
+const maxYield = 2 * time.Second
+
+// A scheduler gives interactive requests, such as completion and hover,
+// priority over the background work of the server, such as diagnostics:
+// the background work yields between its steps while an interactive
+// request is in flight.
+type scheduler struct {
+	mu          sync.Mutex
+	interactive int           // the number of interactive requests in flight
+	idle        chan struct{} // closed when interactive drops to zero
+}
+
+func newScheduler() *scheduler {
+	idle := make(chan struct{})
+	close(idle)
+	return &scheduler{idle: idle}
+}
+
+// beginInteractive records the start of an interactive request, and
+// returns the function that records its end.
+func (s *scheduler) beginInteractive() func() {
+	s.mu.Lock()
+	if s.interactive == 0 {
+		s.idle = make(chan struct{})
+	}
+	s.interactive++
+	s.mu.Unlock()
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			s.mu.Lock()
+			defer s.mu.Unlock()
+			s.interactive--
+			if s.interactive == 0 {
+				close(s.idle)
+			}
+		})
+	}
+}
+
+// yield blocks while interactive requests are in flight, for at most
+// maxYield, and returns the error of ctx.
+func (s *scheduler) yield(ctx context.Context) error {
+	s.mu.Lock()
+	idle := s.idle
+	s.mu.Unlock()
+	select {
+	case <-idle:
+	default:
+		timer := time.NewTimer(maxYield)
+		defer timer.Stop()
+		select {
+		case <-idle:
+		case <-timer.C:
+		case <-ctx.Done():
+		}
+	}
+	return ctx.Err()
+}
@@ -0,0 +1,43 @@
+// Copyright 2022 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package lsp
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestSchedulerYield(t *testing.T) {
+	s := newScheduler()
+	ctx := context.Background()
+	if err := s.yield(ctx); err != nil {
+		t.Fatalf("yield without interactive requests = %v", err)
+	}
+
+	end := s.beginInteractive()
+	yielded := make(chan error)
+	go func() {
+		yielded <- s.yield(ctx)
+	}()
+	select {
+	case <-yielded:
+		t.Fatal("yield returned while an interactive request was in flight")
+	case <-time.After(50 * time.Millisecond):
+	}
+	end()
+	end() // ending a request twice is harmless
+	if err := <-yielded; err != nil {
+		t.Fatalf("yield after the interactive request = %v", err)
+	}
+
+	// Cancellation interrupts the yield.
+	defer s.beginInteractive()()
+	cctx, cancel := context.WithCancel(ctx)
+	cancel()
+	if err := s.yield(cctx); err != context.Canceled {
+		t.Errorf("yield with a cancelled context = %v, want %v", err, context.Canceled)
+	}
+}
@@ -17,7 +17,10 @@ import (
 	"sort"
 	"strings"
 	"time"
+	"unicode/utf8"
 
+	"github.com/iansmith/golang-x-tools/go/analysis/passes/printf"
+	"github.com/iansmith/golang-x-tools/go/types/typeutil"
 	"github.com/iansmith/golang-x-tools/internal/event"
 	"github.com/iansmith/golang-x-tools/internal/lsp/protocol"
 	"github.com/iansmith/golang-x-tools/internal/lsp/safetoken"
@@ -92,6 +95,11 @@ func (s *Server) computeSemanticTokens(ctx context.Context, td protocol.TextDocu
 	if kind != source.Go {
 		return nil, nil
 	}
+	// Don't keep the editor waiting for the tokens of a large file: it
+	// falls back to its own highlighting.
+	if content, err := fh.Read(); err == nil && source.IsLargeFile(snapshot, content) {
+		return &ans, nil
+	}
 	pkg, err := snapshot.PackageForFile(ctx, fh.URI(), source.TypecheckFull, source.WidestPackage)
 	if err != nil {
 		return nil, err
@@ -112,6 +120,7 @@ func (s *Server) computeSemanticTokens(ctx context.Context, td protocol.TextDocu
 		rng:      rng,
 		ti:       pkg.GetTypesInfo(),
 		pkg:      pkg,
+		snapshot: snapshot,
 		fset:     snapshot.FileSet(),
 		tokTypes: s.session.Options().SemanticTypes,
 		tokMods:  s.session.Options().SemanticMods,
@@ -229,12 +238,20 @@ type encoded struct {
 	rng               *protocol.Range
 	ti                *types.Info
 	pkg               source.Package
+	snapshot          source.Snapshot
 	fset              *token.FileSet
 	// allowed starting and ending token.Pos, set by init
 	// used to avoid looking at declarations not in range
 	start, end token.Pos
 	// path from the root of the parse tree, used for debugging
 	stack []ast.Node
+	// deprecated caches whether the declarations of used objects are deprecated
+	deprecated map[types.Object]bool
+	// printf knows which functions of the package are printf wrappers.
+	// It is computed lazily, by printfKind.
+	printf *printf.Result
+	// formats holds the format strings of calls to printf-like functions
+	formats map[*ast.BasicLit]bool
 }
 
 // convert the stack to a string, for debugging
@@ -295,6 +312,10 @@ func (e *encoded) inspector(n ast.Node) bool {
 			e.multiline(x.Pos(), x.End(), x.Value, tokString)
 			break
 		}
+		if e.formats[x] {
+			e.formatString(x)
+			break
+		}
 		ln := len(x.Value)
 		what := tokNumber
 		if x.Kind == token.STRING {
@@ -316,6 +337,7 @@ func (e *encoded) inspector(n ast.Node) bool {
 		if x.Ellipsis != token.NoPos {
 			e.token(x.Ellipsis, len("..."), tokOperator, nil)
 		}
+		e.printfCall(x)
 	case *ast.CaseClause:
 		iam := "case"
 		if x.List == nil {
@@ -484,7 +506,7 @@ func (e *encoded) ident(x *ast.Ident) {
 	case *types.Builtin:
 		tok(x.NamePos, len(x.Name), tokFunction, []string{"defaultLibrary"})
 	case *types.Const:
-		mods := []string{"readonly"}
+		mods := e.useMods(y, "readonly")
 		tt := y.Type()
 		if _, ok := tt.(*types.Basic); ok {
 			tok(x.Pos(), len(x.String()), tokVariable, mods)
@@ -503,7 +525,7 @@ func (e *encoded) ident(x *ast.Ident) {
 		// can this happen? Don't think so
 		e.unexpected(fmt.Sprintf("%s %T %#v", x.String(), tt, tt))
 	case *types.Func:
-		tok(x.Pos(), len(x.Name), tokFunction, nil)
+		tok(x.Pos(), len(x.Name), tokFunction, e.useMods(y))
 	case *types.Label:
 		// nothing to map it to
 	case *types.Nil:
@@ -518,6 +540,8 @@ func (e *encoded) ident(x *ast.Ident) {
 		} else if _, ok := y.Type().(*typeparams.TypeParam); ok {
 			tok(x.Pos(), len(x.String()), tokTypeParam, mods)
 			break
+		} else {
+			mods = e.useMods(y)
 		}
 		tok(x.Pos(), len(x.String()), tokType, mods)
 	case *types.Var:
@@ -526,7 +550,7 @@ func (e *encoded) ident(x *ast.Ident) {
 		} else if _, ok := y.Type().(*typeparams.TypeParam); ok {
 			tok(x.Pos(), len(x.Name), tokTypeParam, nil)
 		} else {
-			tok(x.Pos(), len(x.Name), tokVariable, nil)
+			tok(x.Pos(), len(x.Name), tokVariable, e.useMods(y))
 		}
 	default:
 		// can't happen
@@ -542,6 +566,26 @@ func (e *encoded) ident(x *ast.Ident) {
 	}
 }
 
+// useMods returns the modifiers for a use of obj: mods, and "deprecated" if
+// the declaration of obj is documented as deprecated.
+func (e *encoded) useMods(obj types.Object, mods ...string) []string {
+	if e.snapshot == nil {
+		return mods
+	}
+	deprecated, ok := e.deprecated[obj]
+	if !ok {
+		deprecated = source.IsDeprecated(e.ctx, e.snapshot, e.pkg, obj)
+		if e.deprecated == nil {
+			e.deprecated = make(map[types.Object]bool)
+		}
+		e.deprecated[obj] = deprecated
+	}
+	if deprecated {
+		mods = append(mods, "deprecated")
+	}
+	return mods
+}
+
 func isSignature(use types.Object) bool {
 	if true {
 		return false //PJW: fix after generics seem ok
@@ -683,22 +727,16 @@ func (e *encoded) unkIdent(x *ast.Ident) (tokenType, []string) {
 	return "", nil
 }
 
-func isDeprecated(n *ast.CommentGroup) bool {
-	if n == nil {
-		return false
-	}
-	for _, c := range n.List {
-		if strings.HasPrefix(c.Text, "// Deprecated") {
-			return true
-		}
-	}
-	return false
-}
-
 func (e *encoded) definitionFor(x *ast.Ident, def types.Object) (tokenType, []string) {
 	// PJW: def == types.Label? probably a nothing
 	// PJW: look into replaceing these syntactic tests with types more generally
 	mods := []string{"definition"}
+	if tname, ok := def.(*types.TypeName); ok {
+		// type parameters of functions and receivers
+		if _, ok := tname.Type().(*typeparams.TypeParam); ok {
+			return tokTypeParam, mods
+		}
+	}
 	for i := len(e.stack) - 1; i >= 0; i-- {
 		s := e.stack[i]
 		switch y := s.(type) {
@@ -708,7 +746,7 @@ func (e *encoded) definitionFor(x *ast.Ident, def types.Object) (tokenType, []st
 			}
 			return tokVariable, mods
 		case *ast.GenDecl:
-			if isDeprecated(y.Doc) {
+			if source.IsDeprecatedDoc(y.Doc) {
 				mods = append(mods, "deprecated")
 			}
 			if y.Tok == token.CONST {
@@ -718,7 +756,7 @@ func (e *encoded) definitionFor(x *ast.Ident, def types.Object) (tokenType, []st
 		case *ast.FuncDecl:
 			// If x is immediately under a FuncDecl, it is a function or method
 			if i == len(e.stack)-2 {
-				if isDeprecated(y.Doc) {
+				if source.IsDeprecatedDoc(y.Doc) {
 					mods = append(mods, "deprecated")
 				}
 				if y.Recv != nil {
@@ -764,6 +802,127 @@ func (e *encoded) definitionFor(x *ast.Ident, def types.Object) (tokenType, []st
 	return "", []string{""}
 }
 
+// printfCall records the format string of call, if it is a literal passed
+// to a printf-like function, so that its formatting directives can be
+// reported.
+func (e *encoded) printfCall(call *ast.CallExpr) {
+	if e.ti == nil || e.snapshot == nil {
+		return
+	}
+	fn, ok := typeutil.Callee(e.ti, call).(*types.Func)
+	if !ok {
+		return
+	}
+	if kind := e.printfKind(fn); kind != printf.KindPrintf && kind != printf.KindErrorf {
+		return
+	}
+	// As in the printf analyzer, the format is the last non-variadic parameter.
+	sig, ok := fn.Type().(*types.Signature)
+	if !ok || !sig.Variadic() {
+		return
+	}
+	idx := sig.Params().Len() - 2
+	if idx < 0 || idx >= len(call.Args) {
+		return
+	}
+	lit, ok := call.Args[idx].(*ast.BasicLit)
+	if !ok || lit.Kind != token.STRING {
+		return
+	}
+	if e.formats == nil {
+		e.formats = make(map[*ast.BasicLit]bool)
+	}
+	e.formats[lit] = true
+}
+
+// printfKind reports whether fn behaves like fmt.Print or fmt.Printf,
+// according to the printf analyzer. If the analysis fails, for instance
+// because the package has errors, only the functions the analyzer knows
+// about, such as fmt.Printf, are recognized.
+func (e *encoded) printfKind(fn *types.Func) printf.Kind {
+	if e.printf == nil {
+		e.printf = new(printf.Result)
+		if res, err := e.snapshot.AnalysisResult(e.ctx, e.pkg.ID(), printf.Analyzer); err == nil {
+			if res, ok := res.(*printf.Result); ok {
+				e.printf = res
+			}
+		}
+	}
+	return e.printf.Kind(fn)
+}
+
+// formatString reports the formatting directives of the printf format
+// string lit as strings with the "format" modifier.
+func (e *encoded) formatString(lit *ast.BasicLit) {
+	val := lit.Value
+	last := 0 // end of the last reported token
+	str := func(end int) {
+		if end > last {
+			e.token(lit.Pos()+token.Pos(last), end-last, tokString, nil)
+		}
+	}
+	// skip the quotes
+	for i := 1; i < len(val)-1; {
+		if val[i] != '%' {
+			i++
+			continue
+		}
+		str(i)
+		n := printfDirectiveLen(val[i : len(val)-1])
+		e.token(lit.Pos()+token.Pos(i), n, tokString, []string{"format"})
+		i += n
+		last = i
+	}
+	str(len(val))
+}
+
+// printfDirectiveLen returns the length of the formatting directive, such as
+// %-8.3[2]f, at the start of format.
+func printfDirectiveLen(format string) int {
+	i := 1 // the %
+	digits := func() {
+		for i < len(format) && '0' <= format[i] && format[i] <= '9' {
+			i++
+		}
+	}
+	argIndex := func() {
+		if i < len(format) && format[i] == '[' {
+			start := i
+			i++
+			digits()
+			if i < len(format) && format[i] == ']' {
+				i++
+			} else {
+				i = start
+			}
+		}
+	}
+	for i < len(format) && strings.IndexByte("+-# 0", format[i]) >= 0 {
+		i++ // flags
+	}
+	argIndex()
+	if i < len(format) && format[i] == '*' {
+		i++
+	} else {
+		digits() // width
+	}
+	if i < len(format) && format[i] == '.' {
+		i++
+		argIndex()
+		if i < len(format) && format[i] == '*' {
+			i++
+		} else {
+			digits() // precision
+		}
+	}
+	argIndex()
+	if i < len(format) && format[i] != '\\' {
+		_, size := utf8.DecodeRuneInString(format[i:])
+		i += size // the verb
+	}
+	return i
+}
+
 func (e *encoded) multiline(start, end token.Pos, val string, tok tokenType) {
 	f := e.fset.File(start)
 	// the hard part is finding the lengths of lines. include the \n
@@ -957,5 +1116,6 @@ var (
 	semanticModifiers = [...]string{
 		"declaration", "definition", "readonly", "static",
 		"deprecated", "abstract", "async", "modification", "documentation", "defaultLibrary",
+		"format",
 	}
 )
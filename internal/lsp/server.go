@@ -10,7 +10,9 @@ import (
 	"fmt"
 	"sync"
 
+	"github.com/iansmith/golang-x-tools/internal/event"
 	"github.com/iansmith/golang-x-tools/internal/jsonrpc2"
+	"github.com/iansmith/golang-x-tools/internal/lsp/filewatcher"
 	"github.com/iansmith/golang-x-tools/internal/lsp/progress"
 	"github.com/iansmith/golang-x-tools/internal/lsp/protocol"
 	"github.com/iansmith/golang-x-tools/internal/lsp/source"
@@ -24,17 +26,33 @@ const concurrentAnalyses = 1
 func NewServer(session source.Session, client protocol.ClientCloser) *Server {
 	tracker := progress.NewTracker(client)
 	session.SetProgressTracker(tracker)
-	return &Server{
+	s := &Server{
 		diagnostics:           map[span.URI]*fileReports{},
 		gcOptimizationDetails: make(map[string]struct{}),
 		watchedGlobPatterns:   make(map[string]struct{}),
 		changedFiles:          make(map[span.URI]struct{}),
+		toolOverlays:          make(map[span.URI]bool),
 		session:               session,
 		client:                client,
 		diagnosticsSema:       make(chan struct{}, concurrentAnalyses),
 		progress:              tracker,
 		diagDebouncer:         newDebouncer(),
 		watchedFileDebouncer:  newDebouncer(),
+		scheduler:             newScheduler(),
+	}
+	session.SetPanicHandler(s.warnPanic)
+	return s
+}
+
+// warnPanic warns the client that gopls recovered from a panic, described by
+// err, rather than crash.
+func (s *Server) warnPanic(ctx context.Context, err error) {
+	msg := &protocol.ShowMessageParams{
+		Type:    protocol.Warning,
+		Message: fmt.Sprintf("gopls recovered from a crash: %v\nIts results are discarded and computed again. Please file an issue with the gopls log.", err),
+	}
+	if err := s.eventuallyShowMessage(ctx, msg); err != nil {
+		event.Error(ctx, "warning of a panic", err)
 	}
 }
 
@@ -67,6 +85,9 @@ type Server struct {
 
 	stateMu sync.Mutex
 	state   serverState
+	// telemetryStarted records that the server started telemetry, which it
+	// stops when it shuts down.
+	telemetryStarted bool
 	// notifications generated before serverInitialized
 	notifications []*protocol.ShowMessageParams
 
@@ -78,6 +99,13 @@ type Server struct {
 	changedFilesMu sync.Mutex
 	changedFiles   map[span.URI]struct{}
 
+	// toolOverlays is the set of files whose overlays were set by the
+	// set_overlays command, rather than by the editor. toolOverlayVersion
+	// is the version of the last of these overlays.
+	toolOverlaysMu     sync.Mutex
+	toolOverlays       map[span.URI]bool
+	toolOverlayVersion int32
+
 	// folders is only valid between initialize and initialized, and holds the
 	// set of folders to build views for when we are ready
 	pendingFolders []protocol.WorkspaceFolder
@@ -89,9 +117,17 @@ type Server struct {
 	watchedGlobPatterns    map[string]struct{}
 	watchRegistrationCount int
 
+	// fileWatcher watches the files of the workspace when the client cannot
+	// watch them itself.
+	fileWatcherMu sync.Mutex
+	fileWatcher   *filewatcher.Watcher
+
 	diagnosticsMu sync.Mutex
 	diagnostics   map[span.URI]*fileReports
 
+	// externalAnalysis caches the results of the external analyzers.
+	externalAnalysis source.ExternalAnalysisCache
+
 	// gcOptimizationDetails describes the packages for which we want
 	// optimization details to be included in the diagnostics. The key is the
 	// ID of the package.
@@ -104,6 +140,10 @@ type Server struct {
 
 	progress *progress.Tracker
 
+	// scheduler gives priority to the interactive requests over the
+	// diagnostics.
+	scheduler *scheduler
+
 	// diagDebouncer is used for debouncing diagnostics.
 	diagDebouncer *debouncer
 
@@ -116,6 +156,11 @@ type Server struct {
 	// report with an error message.
 	criticalErrorStatusMu sync.Mutex
 	criticalErrorStatus   *progress.WorkDone
+
+	// memoryDegraded is set once the memory used by gopls exceeds the
+	// memoryBudget option, degrading the options of all views.
+	memoryDegradedMu sync.Mutex
+	memoryDegraded   bool
 }
 
 type pendingModificationSet struct {
@@ -17,7 +17,7 @@ func AddImport(ctx context.Context, snapshot Snapshot, fh VersionedFileHandle, i
 	if err != nil {
 		return nil, err
 	}
-	return ComputeOneImportFixEdits(snapshot, pgf, &imports.ImportFix{
+	return ComputeOneImportFixEdits(ctx, snapshot, pgf, &imports.ImportFix{
 		StmtInfo: imports.ImportInfo{
 			ImportPath: importPath,
 		},
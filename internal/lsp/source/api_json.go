@@ -19,13 +19,29 @@ var GeneratedAPIJSON = &APIJSON{
 				Default:   "{}",
 				Hierarchy: "build",
 			},
+			{
+				Name:      "folderEnv",
+				Type:      "map[string]map[string]string",
+				Doc:       "folderEnv adds environment variables to those of env for some\nworkspace folders. Its keys are the names or the absolute paths of\nworkspace folders, and its values are the variables to add in these\nfolders. Each folder has its own build configuration, so a front end\nbuilt for WebAssembly and a back end built for Linux can be edited\nside by side.\n\nExample: `{\"web\": {\"GOOS\": \"js\", \"GOARCH\": \"wasm\"}}`\n",
+				Default:   "{}",
+				Status:    "experimental",
+				Hierarchy: "build",
+			},
 			{
 				Name:      "directoryFilters",
 				Type:      "[]string",
-				Doc:       "directoryFilters can be used to exclude unwanted directories from the\nworkspace. By default, all directories are included. Filters are an\noperator, `+` to include and `-` to exclude, followed by a path prefix\nrelative to the workspace folder. They are evaluated in order, and\nthe last filter that applies to a path controls whether it is included.\nThe path prefix can be empty, so an initial `-` excludes everything.\n\nExamples:\n\nExclude node_modules: `-node_modules`\n\nInclude only project_a: `-` (exclude everything), `+project_a`\n\nInclude only project_a, but not node_modules inside it: `-`, `+project_a`, `-project_a/node_modules`\n",
+				Doc:       "directoryFilters can be used to exclude unwanted directories from the\nworkspace. By default, all directories are included. Filters are an\noperator, `+` to include and `-` to exclude, followed by a path prefix\nrelative to the workspace folder. They are evaluated in order, and\nthe last filter that applies to a path controls whether it is included.\nThe path prefix can be empty, so an initial `-` excludes everything.\nA path segment of `**` matches any number of path segments, including\nnone.\n\nExcluded directories are neither loaded nor watched.\n\nExamples:\n\nExclude node_modules: `-node_modules`\n\nExclude node_modules at any depth: `-**/node_modules`\n\nInclude only project_a: `-` (exclude everything), `+project_a`\n\nInclude only project_a, but not node_modules inside it: `-`, `+project_a`, `-project_a/node_modules`\n",
 				Default:   "[\"-node_modules\"]",
 				Hierarchy: "build",
 			},
+			{
+				Name:      "folderDirectoryFilters",
+				Type:      "map[string][]string",
+				Doc:       "folderDirectoryFilters overrides directoryFilters for some workspace\nfolders. Its keys are the names or the absolute paths of workspace\nfolders, and its values are the directory filters to use in these\nfolders instead of directoryFilters.\n\nExample: `{\"/home/me/web\": [\"-**/node_modules\", \"-dist\"]}`\n",
+				Default:   "{}",
+				Status:    "experimental",
+				Hierarchy: "build",
+			},
 			{
 				Name:      "templateExtensions",
 				Type:      "[]string",
@@ -48,6 +64,22 @@ var GeneratedAPIJSON = &APIJSON{
 				Status:    "experimental",
 				Hierarchy: "build",
 			},
+			{
+				Name:      "memoryBudget",
+				Type:      "string",
+				Doc:       "memoryBudget is the amount of memory, such as \"4GiB\", that `gopls` may\nuse before switching to a degraded mode rather than risking being\nkilled for running out of memory. In degraded mode, `gopls` behaves as\nin the `DegradeClosed` memory mode, skips analyses that need the whole\nworkspace, and disables deep completion. The client is notified when\nthis happens.\n\nThe default, \"\", means no budget.\n",
+				Default:   "\"\"",
+				Status:    "experimental",
+				Hierarchy: "build",
+			},
+			{
+				Name:      "lazyTypeCheck",
+				Type:      "bool",
+				Doc:       "lazyTypeCheck makes `gopls` load the metadata of the whole workspace,\nloading its modules in parallel, but type-check and diagnose only the\npackages of the open files and their reverse dependencies, and those of\nthe directories most recently opened in the workspace, including by\nprevious sessions. The other packages are type-checked when a request,\nsuch as Find References, needs them. This cuts the time to the first\ndiagnostics in large workspaces, at the cost of the diagnostics of the\npackages that are not type-checked.\n",
+				Default:   "false",
+				Status:    "experimental",
+				Hierarchy: "build",
+			},
 			{
 				Name:      "expandWorkspaceToModule",
 				Type:      "bool",
@@ -120,6 +152,13 @@ var GeneratedAPIJSON = &APIJSON{
 				Default:   "\"pkg.go.dev\"",
 				Hierarchy: "ui.documentation",
 			},
+			{
+				Name:      "privateLinkTarget",
+				Type:      "string",
+				Doc:       "privateLinkTarget controls where documentation links go for the\npackages and modules matching GOPRIVATE, such as the address of an\ninternal documentation server. If empty, there are no links to them.\n",
+				Default:   "\"\"",
+				Hierarchy: "ui.documentation",
+			},
 			{
 				Name:      "linksInHover",
 				Type:      "bool",
@@ -127,6 +166,13 @@ var GeneratedAPIJSON = &APIJSON{
 				Default:   "true",
 				Hierarchy: "ui.documentation",
 			},
+			{
+				Name:      "verboseHover",
+				Type:      "bool",
+				Doc:       "verboseHover adds the memory layout of struct types and fields to\nhover: the size and alignment of types, and the offsets of fields and\nthe padding between them, as computed for the current GOARCH.\n",
+				Default:   "false",
+				Hierarchy: "ui.documentation",
+			},
 			{
 				Name:      "usePlaceholders",
 				Type:      "bool",
@@ -163,6 +209,30 @@ var GeneratedAPIJSON = &APIJSON{
 				Status:    "experimental",
 				Hierarchy: "ui.completion",
 			},
+			{
+				Name:      "experimentalUnimportedSymbolsBudget",
+				Type:      "time.Duration",
+				Doc:       "experimentalUnimportedSymbolsBudget enables the completion of the\nexported symbols of unimported packages, such as \"rand.Seed\" for \"Seed\",\nadding the import of their package. The packages of the module cache\nand GOPATH are searched for at most this duration; zero disables it.\nIt requires completeUnimported.\n",
+				Default:   "\"0s\"",
+				Status:    "experimental",
+				Hierarchy: "ui.completion",
+			},
+			{
+				Name:      "experimentalCompletionRanking",
+				Type:      "bool",
+				Doc:       "experimentalCompletionRanking ranks completion candidates also by how\noften the workspace uses their symbols, and by the completions the user\naccepted recently, which are remembered in the gopls cache directory.\nAccepts are reported by the command of each completion item.\n",
+				Default:   "false",
+				Status:    "experimental",
+				Hierarchy: "ui.completion",
+			},
+			{
+				Name:      "experimentalResultAssignments",
+				Type:      "bool",
+				Doc:       "experimentalResultAssignments completes the calls of functions with\nresults as statements that assign the results to new variables named\nafter them, such as \"f, err := os.Open(name)\". It requires snippets.\n",
+				Default:   "false",
+				Status:    "experimental",
+				Hierarchy: "ui.completion",
+			},
 			{
 				Name: "importShortcut",
 				Type: "enum",
@@ -211,6 +281,30 @@ var GeneratedAPIJSON = &APIJSON{
 				Status:    "advanced",
 				Hierarchy: "ui.navigation",
 			},
+			{
+				Name:      "includeTestReferences",
+				Type:      "bool",
+				Doc:       "includeTestReferences controls whether the references found in\n_test.go files are included in the results of find-references and\nof incoming calls. Rename always updates them.\n",
+				Default:   "true",
+				Status:    "experimental",
+				Hierarchy: "ui.navigation",
+			},
+			{
+				Name:      "controlFlowHighlights",
+				Type:      "bool",
+				Doc:       "controlFlowHighlights controls whether highlighting a return or the\nfunc keyword also highlights the other exit points of the function,\nfound in its control-flow graph: the calls of functions that never\nreturn, such as panic, and the closing brace if it is reachable.\nHighlighting a break or continue also highlights the exit points\nwithin its loop or switch.\n",
+				Default:   "false",
+				Status:    "experimental",
+				Hierarchy: "ui.navigation",
+			},
+			{
+				Name:      "errorFlowHighlights",
+				Type:      "bool",
+				Doc:       "errorFlowHighlights controls whether highlighting a variable of type\nerror also highlights the expressions that assign it and the return\nstatements that propagate it.\n",
+				Default:   "false",
+				Status:    "experimental",
+				Hierarchy: "ui.navigation",
+			},
 			{
 				Name: "analyses",
 				Type: "map[string]bool",
@@ -253,6 +347,11 @@ var GeneratedAPIJSON = &APIJSON{
 							Doc:     "detect some violations of the cgo pointer passing rules\n\nCheck for invalid cgo pointer passing.\nThis looks for code that uses cgo to call C code passing values\nwhose types are almost always invalid according to the cgo pointer\nsharing rules.\nSpecifically, it warns about attempts to pass a Go chan, map, func,\nor slice to C, either directly, or via a pointer, array, or struct.",
 							Default: "true",
 						},
+						{
+							Name:    "\"commentstyle\"",
+							Doc:     "check the conventions and spelling of comments\n\nThe commentstyle analyzer reports, with suggested fixes:\n\n- doc comments of exported declarations that do not begin with the name\n  of the declared identifier, such as \"Returns the sum.\" for func Sum;\n\n- malformed deprecation notices, which should be a paragraph beginning\n  with \"Deprecated: \", such as \"DEPRECATED - use Sum instead.\";\n\n- common misspellings in comments, such as \"recieve\". The -dictionary\n  flag names a file of additional misspellings, one \"misspelling\n  correction\" pair per line.",
+							Default: "false",
+						},
 						{
 							Name:    "\"composites\"",
 							Doc:     "check for unkeyed composite literals\n\nThis analyzer reports a diagnostic for composite literals of struct\ntypes imported from another package that do not use the field-keyed\nsyntax. Such literals are fragile because the addition of a new field\n(even if unexported) to the struct will cause compilation to fail.\n\nAs an example,\n\n\terr = &net.DNSConfigError{err}\n\nshould be replaced by:\n\n\terr = &net.DNSConfigError{Err: err}\n",
@@ -433,9 +532,14 @@ var GeneratedAPIJSON = &APIJSON{
 							Doc:     "suggested fixes for \"undeclared name: <>\"\n\nThis checker provides suggested fixes for type errors of the\ntype \"undeclared name: <>\". It will either insert a new statement,\nsuch as:\n\n\"<> := \"\n\nor a new function declaration, such as:\n\nfunc <>(inferred parameters) {\n\tpanic(\"implement me!\")\n}\n",
 							Default: "true",
 						},
+						{
+							Name:    "\"unknownfield\"",
+							Doc:     "suggested fixes for \"unknown field <> in struct literal\"\n\nThis checker provides suggested fixes for type errors of the\ntype \"unknown field <> in struct literal\". It adds the field to the\ndeclaration of the struct type, with the type of its value. For example:\n\ttype T struct {\n\t\tA int\n\t}\n\n\tvar _ = T{A: 1, B: \"b\"}\nwill turn into\n\ttype T struct {\n\t\tA int\n\t\tB string\n\t}\n\nIf the fields of the struct have JSON tags, the new field gets one that\nfollows their naming convention.\n\nIt also fixes the errors reporting that a selector x.f refers to no field\nor method of a type declared in the package. If x.f is called, it declares\nthe method f, with parameters of the types of the arguments and results of\nthe types expected by the context of the call. Otherwise it adds the field\nf to the struct type of x, with the type of the value assigned to x.f or\nexpected by its context.\n",
+							Default: "true",
+						},
 						{
 							Name:    "\"fillstruct\"",
-							Doc:     "note incomplete struct initializations\n\nThis analyzer provides diagnostics for any struct literals that do not have\nany fields initialized. Because the suggested fix for this analysis is\nexpensive to compute, callers should compute it separately, using the\nSuggestedFix function below.\n",
+							Doc:     "note incomplete struct initializations\n\nThis analyzer provides diagnostics for any struct literals that do not have\nany fields initialized. Because the suggested fix for this analysis is\nexpensive to compute, callers should compute it separately, using the\nSuggestedFix function below.\n\nIt also notes calls that lack some of their arguments, and map literals\nwhose key type is an enumeration lacking some of its constants, so that the\nSuggestedFix function can fill in the missing arguments or keys.\n",
 							Default: "true",
 						},
 						{
@@ -456,6 +560,80 @@ var GeneratedAPIJSON = &APIJSON{
 				Status:    "experimental",
 				Hierarchy: "ui.diagnostic",
 			},
+			{
+				Name:      "staticcheckConfig",
+				Type:      "map[string][]string",
+				Doc:       "staticcheckConfig configures the staticcheck analyzers as a\nstaticcheck.conf file does for the staticcheck command. Its keys are\n\"checks\", \"initialisms\", \"dot_import_whitelist\" and\n\"http_status_code_whitelist\", and their values are lists that replace\nthose of the staticcheck.conf files, or extend them if they contain\n\"inherit\". The \"checks\" list selects the checks that gopls runs, as in\n`[\"inherit\", \"ST1003\", \"-SA1*\"]`, where \"inherit\" stands for the\nchecks enabled by default; the analyses setting still takes precedence\nfor the checks it names.\n\nExample: `{\"checks\": [\"inherit\", \"ST1003\"], \"initialisms\": [\"inherit\", \"GRPC\"]}`\n",
+				Default:   "{}",
+				Status:    "experimental",
+				Hierarchy: "ui.diagnostic",
+			},
+			{
+				Name:      "externalAnalyzers",
+				Type:      "[]string",
+				Doc:       "externalAnalyzers lists the paths of analyzer executables that\nfollow the protocol of `go vet -vettool`, such as the programs built\nwith the go/analysis/unitchecker package. Each is run by `go vet\n-json` on the packages of the open files, once their changes are\nsaved, and its findings are reported as diagnostics from the\nanalyzers it names. As the output of `go vet -json` holds no\nsuggested fixes, none are offered.\n",
+				Default:   "[]",
+				Status:    "experimental",
+				Hierarchy: "ui.diagnostic",
+			},
+			{
+				Name:      "unusedSymbols",
+				Type:      "bool",
+				Doc:       "unusedSymbols enables hints for the functions, methods, types, and\nstruct fields that are not referenced anywhere in the workspace.\nSymbols that may be used through reflection, assembly, cgo, or a\ngo:linkname directive are not reported.\n",
+				Default:   "false",
+				Status:    "experimental",
+				Hierarchy: "ui.diagnostic",
+			},
+			{
+				Name:      "duplicateCodeTokens",
+				Type:      "int",
+				Doc:       "duplicateCodeTokens enables hints for the duplicated code of the\nworkspace: the sequences of at least that many tokens, ignoring\ncomments and layout, that occur more than once in its Go files, with\na code action listing the other occurrences. 0 disables them.\n",
+				Default:   "0",
+				Status:    "experimental",
+				Hierarchy: "ui.diagnostic",
+			},
+			{
+				Name: "vulncheck",
+				Type: "enum",
+				Doc:  "vulncheck enables vulnerability diagnostics. With \"Imports\", the\nimports of packages that have known vulnerabilities, and the\nrequirements of the modules that provide them, are reported as the\nworkspace changes, with quick fixes that upgrade the modules to a\nfixed version. The vulnerability databases are configured by the\nGOVULNDB, GOPLSVULNCACHETTL, and GOPLSVULNOFFLINE variables of \"env\",\nas for `gopls vulncheck`.\n",
+				EnumValues: []EnumValue{
+					{
+						Value: "\"Imports\"",
+						Doc:   "`\"Imports\"` reports the imports of vulnerable packages, and\nthe requirements of the modules that provide them, whether or not\nthe vulnerable symbols are called.\n",
+					},
+					{
+						Value: "\"Off\"",
+						Doc:   "`\"Off\"` disables vulnerability diagnostics.\n",
+					},
+				},
+				Default:   "\"Off\"",
+				Status:    "experimental",
+				Hierarchy: "ui.diagnostic",
+			},
+			{
+				Name:      "diagnosticPlatforms",
+				Type:      "[]string",
+				Doc:       "diagnosticPlatforms lists other platforms, as GOOS/GOARCH pairs such\nas \"windows/arm64\", for which the packages of open files are also\ntype-checked. Errors that occur only on these platforms are reported\nalong with the others, annotated with the platforms they occur on.\n",
+				Default:   "[]",
+				Status:    "experimental",
+				Hierarchy: "ui.diagnostic",
+			},
+			{
+				Name:      "diagnosticSeverities",
+				Type:      "map[string]string",
+				Doc:       "diagnosticSeverities overrides the severities of the diagnostics of\nsome analyzers or other sources, such as \"compiler\" or \"go list\". Its\nkeys are the names of the analyzers or sources, and its values are\n\"error\", \"warning\", \"information\", or \"hint\".\n\nExample: `{\"unusedparams\": \"hint\", \"printf\": \"error\"}`\n",
+				Default:   "{}",
+				Status:    "experimental",
+				Hierarchy: "ui.diagnostic",
+			},
+			{
+				Name:      "batchListErrors",
+				Type:      "bool",
+				Doc:       "batchListErrors merges the go list errors of a file that have the\nsame message, as reported for all the packages that a broken module\nor import affects, into a single diagnostic that lists the other\nlocations as related information.\n",
+				Default:   "false",
+				Status:    "experimental",
+				Hierarchy: "ui.diagnostic",
+			},
 			{
 				Name: "annotations",
 				Type: "map[string]bool",
@@ -497,6 +675,14 @@ var GeneratedAPIJSON = &APIJSON{
 				Status:    "advanced",
 				Hierarchy: "ui.diagnostic",
 			},
+			{
+				Name:      "diagnosticsDelayOnSave",
+				Type:      "time.Duration",
+				Doc:       "diagnosticsDelayOnSave replaces diagnosticsDelay after a file save,\nfor example to compute deep diagnostics sooner after saves than\nafter edits. Zero, the default, uses diagnosticsDelay.\n\nThis option must be set to a valid duration string, for example `\"50ms\"`.\n",
+				Default:   "\"0s\"",
+				Status:    "experimental",
+				Hierarchy: "ui.diagnostic",
+			},
 			{
 				Name:      "experimentalWatchedFileDelay",
 				Type:      "time.Duration",
@@ -505,6 +691,34 @@ var GeneratedAPIJSON = &APIJSON{
 				Status:    "experimental",
 				Hierarchy: "ui.diagnostic",
 			},
+			{
+				Name: "hints",
+				Type: "map[string]bool",
+				Doc:  "hints specify inlay hints that users want to see.\nA full list of hints that gopls uses can be found\n[here](https://github.com/golang/tools/blob/master/gopls/doc/inlayHints.md).\n",
+				EnumKeys: EnumKeys{
+					ValueType: "bool",
+					Keys: []EnumKey{
+						{
+							Name:    "\"compositeLiteralFields\"",
+							Doc:     "Enable/disable inlay hints for the field names of unkeyed composite literals:\n```go\n\t{/* in: */ \"Hello, world\", /* want: */ \"dlrow ,olleH\"}\n```",
+							Default: "false",
+						},
+						{
+							Name:    "\"implicitConversions\"",
+							Doc:     "Enable/disable inlay hints for implicit conversions of values to interface types\nand of untyped integer constants to types other than int:\n```go\n\tfmt.Fprintln(buf /* as io.Writer */, \"hi\")\n\ttime.Sleep(5 /* as time.Duration */)\n```",
+							Default: "false",
+						},
+						{
+							Name:    "\"parameterNames\"",
+							Doc:     "Enable/disable inlay hints for parameter names:\n```go\n\tparseInt(/* str: */ \"123\", /* radix: */ 8)\n```",
+							Default: "false",
+						},
+					},
+				},
+				Default:   "{}",
+				Status:    "experimental",
+				Hierarchy: "ui.inlayhint",
+			},
 			{
 				Name: "codelenses",
 				Type: "map[string]bool",
@@ -547,9 +761,14 @@ var GeneratedAPIJSON = &APIJSON{
 							Doc:     "Runs `go mod vendor` for a module.",
 							Default: "true",
 						},
+						{
+							Name:    "\"work_sync\"",
+							Doc:     "Runs `go work sync` for a workspace, updating the requirements of\nits modules to match the workspace's build list.",
+							Default: "true",
+						},
 					},
 				},
-				Default:   "{\"gc_details\":false,\"generate\":true,\"regenerate_cgo\":true,\"tidy\":true,\"upgrade_dependency\":true,\"vendor\":true}",
+				Default:   "{\"gc_details\":false,\"generate\":true,\"regenerate_cgo\":true,\"tidy\":true,\"upgrade_dependency\":true,\"vendor\":true,\"work_sync\":true}",
 				Hierarchy: "ui",
 			},
 			{
@@ -560,6 +779,30 @@ var GeneratedAPIJSON = &APIJSON{
 				Status:    "experimental",
 				Hierarchy: "ui",
 			},
+			{
+				Name:      "confirmLargeEdits",
+				Type:      "bool",
+				Doc:       "confirmLargeEdits controls whether renamings and other refactorings\nthat edit more than one file, or rename files, ask the user to\nconfirm their edits, in clients that support change annotations, so\nthat the client can show a preview of them.\n",
+				Default:   "false",
+				Status:    "experimental",
+				Hierarchy: "ui",
+			},
+			{
+				Name:      "largeFileThreshold",
+				Type:      "int",
+				Doc:       "largeFileThreshold is the size, in bytes, beyond which a Go file is\ntoo large for gopls to compute its semantic tokens, or to analyze its\npackage, without keeping the editor waiting. Such files are still\nparsed, type-checked, and formatted. 0 disables the limit.\n",
+				Default:   "0",
+				Status:    "experimental",
+				Hierarchy: "ui",
+			},
+			{
+				Name:      "refactorGeneratedFiles",
+				Type:      "bool",
+				Doc:       "refactorGeneratedFiles allows renamings and other refactorings to edit\nthe generated files of the workspace. By default, they fail rather than\nedit them, since the edits would be lost the next time the files are\ngenerated.\n",
+				Default:   "false",
+				Status:    "experimental",
+				Hierarchy: "ui",
+			},
 			{
 				Name:      "local",
 				Type:      "string",
@@ -567,6 +810,13 @@ var GeneratedAPIJSON = &APIJSON{
 				Default:   "\"\"",
 				Hierarchy: "formatting",
 			},
+			{
+				Name:      "importGroups",
+				Type:      "[]string",
+				Doc:       "importGroups is the ordered list of the groups into which imports are\nsorted, overriding the default grouping and `local`. Each group is\n\"std\", for the standard library, \"third-party\", for the packages in no\nother group, \"module\", for the packages of the workspace modules, or a\ncomma-separated list of import path prefixes. Prefix groups take\nprecedence over the others, so `[\"std\", \"third-party\", \"module\"]` is\nthe equivalent of `goimports -local` with the module path.\n",
+				Default:   "[]",
+				Hierarchy: "formatting",
+			},
 			{
 				Name:      "gofumpt",
 				Type:      "bool",
@@ -574,6 +824,48 @@ var GeneratedAPIJSON = &APIJSON{
 				Default:   "false",
 				Hierarchy: "formatting",
 			},
+			{
+				Name:      "gofumptExtraRules",
+				Type:      "bool",
+				Doc:       "gofumptExtraRules enables the extra rules of gofumpt, such as the\ngrouping of adjacent parameters of the same type, when it formats\nfiles.\n",
+				Default:   "false",
+				Hierarchy: "formatting",
+			},
+			{
+				Name:      "formatters",
+				Type:      "[]string",
+				Doc:       "formatters is the ordered list of the formatters that `gopls` applies\nto Go files after gofmt when it formats them. Each formatter is\n\"gofumpt\", \"imports\", which sorts imports into the groups of\n`importGroups` or `local` after joining their existing groups, as gci\ndoes, or \"exec:\" followed by a command that reads the source on its\nstandard input and writes it formatted on its standard output. The\n`gofumpt` setting runs gofumpt first, unless it is listed. The output\nof each formatter must parse.\n",
+				Default:   "[]",
+				Hierarchy: "formatting",
+			},
+			{
+				Name:      "errorWrapTemplate",
+				Type:      "string",
+				Doc:       "errorWrapTemplate is the template of the message of the errors wrapped\nwith `fmt.Errorf` by the \"Wrap error\" code action and the\n`gopls.wrap_errors` command. It is a Go template, whose `.Func` and\n`.Package` are the names of the function returning the error and of\nits package, and whose output must contain `%w` once.\n",
+				Default:   "\"{{.Func}}: %w\"",
+				Hierarchy: "formatting",
+			},
+			{
+				Name:      "newFileTemplate",
+				Type:      "string",
+				Doc:       "newFileTemplate is the template of the header, such as a license\ncomment, of the new Go files that `gopls` fills with a package clause\nwhen they are created, or with the \"Add package clause\" code action on\nan empty file. It is a Go template, whose `.Year`, `.Package` and\n`.File` are the current year, the name of the package of the file and\nthe base name of the file.\n",
+				Default:   "\"\"",
+				Hierarchy: "formatting",
+			},
+			{
+				Name:      "structTags",
+				Type:      "map[string]string",
+				Doc:       "structTags maps the encodings, such as \"json\" or \"yaml\", whose tags\nthe code actions on struct types offer to add, update and remove, to\nthe naming convention of the keys of their tags, \"snake_case\" or\n\"camelCase\".\n\nExample: `{\"json\": \"camelCase\", \"yaml\": \"snake_case\"}`\n",
+				Default:   "{\"json\":\"snake_case\"}",
+				Hierarchy: "formatting",
+			},
+			{
+				Name:    "telemetry",
+				Type:    "bool",
+				Doc:     "telemetry enables the recording of anonymous counters of the requests\nthat `gopls` serves, and of signatures of the panics it recovers from,\nin weekly reports, which are only uploaded to the URL of\n$GOPLS_TELEMETRY_URL. The reports hold no file names, identifiers or\nsource code; `gopls telemetry view` prints them.\n",
+				Default: "false",
+				Status:  "experimental",
+			},
 			{
 				Name:    "verboseOutput",
 				Type:    "bool",
@@ -584,12 +876,24 @@ var GeneratedAPIJSON = &APIJSON{
 		},
 	},
 	Commands: []*CommandJSON{
+		{
+			Command: "gopls.accept_completion",
+			Title:   "Record an accepted completion",
+			Doc:     "Records that the user accepted a completion of the given symbol, so\nthat the experimentalCompletionRanking setting ranks it higher in\nlater completions. It is the command of the completion items.",
+			ArgDoc:  "{\n\t// The key of the symbol of the completion, such as \"fmt.Println\".\n\t\"Symbol\": string,\n}",
+		},
 		{
 			Command: "gopls.add_dependency",
 			Title:   "Add a dependency",
 			Doc:     "Adds a dependency to the go.mod file for a module.",
 			ArgDoc:  "{\n\t// The go.mod file URI.\n\t\"URI\": string,\n\t// Additional args to pass to the go command.\n\t\"GoCmdArgs\": []string,\n\t// Whether to add a require directive.\n\t\"AddRequire\": bool,\n}",
 		},
+		{
+			Command: "gopls.add_go_sum_entries",
+			Title:   "Add missing go.sum entries",
+			Doc:     "Runs `go mod download` for the given module versions, adding only\ntheir missing go.sum entries. This is much faster than running\n`go mod tidy` on large modules.",
+			ArgDoc:  "{\n\t// The go.mod file URI.\n\t\"URI\": string,\n\t// The module versions whose go.sum entries are missing, e.g.\n\t// example.com@v1.2.3.\n\t\"Modules\": []string,\n}",
+		},
 		{
 			Command: "gopls.add_import",
 			Title:   "Add an import",
@@ -608,12 +912,31 @@ var GeneratedAPIJSON = &APIJSON{
 			Doc:     "Checks for module upgrades.",
 			ArgDoc:  "{\n\t// The go.mod file URI.\n\t\"URI\": string,\n\t// The modules to check.\n\t\"Modules\": []string,\n}",
 		},
+		{
+			Command: "gopls.clear_overlays",
+			Title:   "Clear the overlays of files not open in the editor",
+			Doc:     "Removes the overlays of the given files set by the set_overlays\ncommand, or all of them if no file is given, restoring the content of\nthe files on disk. The command returns once the diagnostics of the\naffected packages are published.",
+			ArgDoc:  "{\n\t// The file URIs.\n\t\"URIs\": []string,\n}",
+		},
 		{
 			Command: "gopls.edit_go_directive",
 			Title:   "Run go mod edit -go=version",
 			Doc:     "Runs `go mod edit -go=version` for a module.",
 			ArgDoc:  "{\n\t// Any document URI within the relevant module.\n\t\"URI\": string,\n\t// The version to pass to `go mod edit -go`.\n\t\"Version\": string,\n}",
 		},
+		{
+			Command:   "gopls.explain_diagnostic",
+			Title:     "Explain this diagnostic",
+			Doc:       "Describes the origin of a diagnostic published by gopls: the analyzer\nthat reported it, with its documentation, the analyzers it builds upon\nand the facts they derive from the dependencies of the package, or the\ntype error or other kind of error it comes from. The explanation is\nalso shown to the user.",
+			ArgDoc:    "{\n\t// The file of the diagnostic.\n\t\"URI\": string,\n\t// The range, source and message of the diagnostic, as published.\n\t\"Range\": {\n\t\t\"start\": {\n\t\t\t\"line\": uint32,\n\t\t\t\"character\": uint32,\n\t\t},\n\t\t\"end\": {\n\t\t\t\"line\": uint32,\n\t\t\t\"character\": uint32,\n\t\t},\n\t},\n\t\"Source\": string,\n\t\"Message\": string,\n}",
+			ResultDoc: "{\n\t// Origin describes in a sentence what reports the diagnostic.\n\t\"Origin\": string,\n\t// Analyzer is the name of the analyzer that reports the diagnostic, or\n\t// that provides the fixes of a type error.\n\t\"Analyzer\": string,\n\t// Doc is the summary of the documentation of the analyzer.\n\t\"Doc\": string,\n\t// Requires lists the analyzers whose results the analyzer uses,\n\t// directly or not.\n\t\"Requires\": []string,\n\t// Facts lists the facts that the analyzer, or one of those it requires,\n\t// derives from the dependencies of the package, as \"analyzer: type\".\n\t\"Facts\": []string,\n\t// Code is the code of the type error, if any.\n\t\"Code\": string,\n\t// Href links to the documentation of the analyzer or of the error code.\n\t\"Href\": string,\n}",
+		},
+		{
+			Command: "gopls.extract_imported_symbol",
+			Title:   "Extract the imported symbol into a new package",
+			Doc:     "Breaks the import of the package with the given import path by the\npackage of the given file, which refers to a single symbol of it: the\ndeclaration of the symbol moves to a new package in a subdirectory of\nthe imported package, which the importing package imports instead,\nand the imported package keeps the symbol as an alias or a wrapper of\nthe moved declaration. All files must be saved first.",
+			ArgDoc:  "{\n\t// A file of the importing package.\n\t\"URI\": string,\n\t// The import path of the imported package.\n\t\"ImportPath\": string,\n}",
+		},
 		{
 			Command: "gopls.gc_details",
 			Title:   "Toggle gc_details",
@@ -624,7 +947,13 @@ var GeneratedAPIJSON = &APIJSON{
 			Command: "gopls.generate",
 			Title:   "Run go generate",
 			Doc:     "Runs `go generate` for a given directory.",
-			ArgDoc:  "{\n\t// URI for the directory to generate.\n\t\"Dir\": string,\n\t// Whether to generate recursively (go generate ./...)\n\t\"Recursive\": bool,\n}",
+			ArgDoc:  "{\n\t// URI for the directory to generate.\n\t\"Dir\": string,\n\t// Whether to generate recursively (go generate ./...)\n\t\"Recursive\": bool,\n\t// If set, only run the //go:generate directives whose text matches this\n\t// regular expression (go generate -run).\n\t\"Run\": string,\n}",
+		},
+		{
+			Command: "gopls.generate_enum_methods",
+			Title:   "Generate the methods of an enum type",
+			Doc:     "Writes to the file <type>_string.go, in the directory of the integer\ntype whose name, or the name of one of whose constants, is at the\ngiven range, a String and a MarshalText method returning the names of\nits constants, and a Parse<type> function, without go:generate or\nstringer. A generated file of the same name is replaced.",
+			ArgDoc:  "{\n\t// The file URI of the type or constant.\n\t\"URI\": string,\n\t// The range of the name of the type or constant.\n\t\"Range\": {\n\t\t\"start\": {\n\t\t\t\"line\": uint32,\n\t\t\t\"character\": uint32,\n\t\t},\n\t\t\"end\": {\n\t\t\t\"line\": uint32,\n\t\t\t\"character\": uint32,\n\t\t},\n\t},\n}",
 		},
 		{
 			Command: "gopls.generate_gopls_mod",
@@ -632,12 +961,45 @@ var GeneratedAPIJSON = &APIJSON{
 			Doc:     "(Re)generate the gopls.mod file for a workspace.",
 			ArgDoc:  "{\n\t// The file URI.\n\t\"URI\": string,\n}",
 		},
+		{
+			Command:   "gopls.generate_sbom",
+			Title:     "Generate a software bill of materials",
+			Doc:       "Returns a CycloneDX or SPDX document listing the modules of the\npackages loaded in the view containing the given URI and the\ndependencies between them, and optionally the known vulnerabilities\nof the packages they import.",
+			ArgDoc:    "{\n\t// A URI in the view whose modules are listed.\n\t\"URI\": string,\n\t// The format of the document: \"CycloneDX\", the default, or \"SPDX\".\n\t\"Format\": string,\n\t// Whether to list the known vulnerabilities of the imported packages.\n\t\"Vulns\": bool,\n}",
+			ResultDoc: "{\n\t// The JSON document.\n\t\"Document\": string,\n}",
+		},
+		{
+			Command: "gopls.generate_struct_from_json",
+			Title:   "Generate a struct from JSON",
+			Doc:     "Inserts at the given position of a Go file the declaration of a\nstruct type, with JSON tags, whose fields are those of the given JSON\ndocument: an object, or an array of objects.",
+			ArgDoc:  "{\n\t// The URI of the Go file in which to declare the struct type.\n\t\"URI\": string,\n\t// The position at which to insert the declaration.\n\t\"Position\": {\n\t\t\"line\": uint32,\n\t\t\"character\": uint32,\n\t},\n\t// The name of the struct type, \"T\" if empty.\n\t\"Name\": string,\n\t// The JSON document, such as the content of the clipboard.\n\t\"JSON\": string,\n}",
+		},
+		{
+			Command: "gopls.generate_test",
+			Title:   "Generate a test",
+			Doc:     "Adds a table-driven test skeleton for the function or method at the\ngiven range to the _test.go file of its file, creating the test file\nif necessary.",
+			ArgDoc:  "{\n\t// The file URI of the function to test.\n\t\"URI\": string,\n\t// The range of the function's name or declaration.\n\t\"Range\": {\n\t\t\"start\": {\n\t\t\t\"line\": uint32,\n\t\t\t\"character\": uint32,\n\t\t},\n\t\t\"end\": {\n\t\t\t\"line\": uint32,\n\t\t\t\"character\": uint32,\n\t\t},\n\t},\n}",
+		},
 		{
 			Command: "gopls.go_get_package",
 			Title:   "go get a package",
 			Doc:     "Runs `go get` to fetch a package.",
 			ArgDoc:  "{\n\t// Any document URI within the relevant module.\n\t\"URI\": string,\n\t// The package to go get.\n\t\"Pkg\": string,\n\t\"AddRequire\": bool,\n}",
 		},
+		{
+			Command:   "gopls.implement_stubs",
+			Title:     "Implement the methods of an interface",
+			Doc:       "Returns the edits declaring, after the type whose name is at the\ngiven location, the methods of the interface whose name is at the\nother given location that the type lacks, as stubs that panic.",
+			ArgDoc:    "{\n\t// The location of the name of the type.\n\t\"Concrete\": {\n\t\t\"uri\": string,\n\t\t\"range\": {\n\t\t\t\"start\": { ... },\n\t\t\t\"end\": { ... },\n\t\t},\n\t},\n\t// The location of the name of the interface.\n\t\"Interface\": {\n\t\t\"uri\": string,\n\t\t\"range\": {\n\t\t\t\"start\": { ... },\n\t\t\t\"end\": { ... },\n\t\t},\n\t},\n}",
+			ResultDoc: "{\n\t// The edits declaring the methods.\n\t\"Edits\": []{\n\t\t\"textDocument\": {\n\t\t\t\"version\": int32,\n\t\t\t\"TextDocumentIdentifier\": { ... },\n\t\t},\n\t\t\"edits\": []{\n\t\t\t\"annotationId\": string,\n\t\t\t\"TextEdit\": { ... },\n\t\t},\n\t},\n}",
+		},
+		{
+			Command:   "gopls.list_import_references",
+			Title:     "List the references to an imported package",
+			Doc:       "Returns, and shows to the user, the references in the package of the\ngiven file to the symbols of the package with the given import path,\nsuch as the references that make an import cycle.",
+			ArgDoc:    "{\n\t// A file of the importing package.\n\t\"URI\": string,\n\t// The import path of the imported package.\n\t\"ImportPath\": string,\n}",
+			ResultDoc: "{\n\t// The references, sorted by position.\n\t\"References\": []{\n\t\t\"Location\": {\n\t\t\t\"uri\": string,\n\t\t\t\"range\": { ... },\n\t\t},\n\t\t\"Name\": string,\n\t},\n}",
+		},
 		{
 			Command:   "gopls.list_imports",
 			Title:     "List imports of a file and its package",
@@ -652,6 +1014,25 @@ var GeneratedAPIJSON = &APIJSON{
 			ArgDoc:    "{\n\t// The file URI.\n\t\"URI\": string,\n}",
 			ResultDoc: "{\n\t// Packages is a list of packages relative\n\t// to the URIArg passed by the command request.\n\t// In other words, it omits paths that are already\n\t// imported or cannot be imported due to compiler\n\t// restrictions.\n\t\"Packages\": []string,\n}",
 		},
+		{
+			Command: "gopls.modify_tags",
+			Title:   "Add, update, or remove struct tags",
+			Doc:     "Adds the tags of the given encoding that the fields of the struct\ntype at the given range lack, renames the keys of its existing tags\nafter their fields, or removes them. Only the fields intersecting a\nnon-empty range are modified, or all of them if there is none.",
+			ArgDoc:  "{\n\t// The file URI of the struct type.\n\t\"URI\": string,\n\t// The range of the struct type or of the fields to modify.\n\t\"Range\": {\n\t\t\"start\": {\n\t\t\t\"line\": uint32,\n\t\t\t\"character\": uint32,\n\t\t},\n\t\t\"end\": {\n\t\t\t\"line\": uint32,\n\t\t\t\"character\": uint32,\n\t\t},\n\t},\n\t// The action: \"add\", \"update\", or \"remove\".\n\t\"Action\": string,\n\t// The encoding of the tags, such as \"json\" or \"yaml\".\n\t\"Encoding\": string,\n\t// The naming convention of the keys of the added or updated tags:\n\t// \"snake_case\" or \"camelCase\".\n\t\"Convention\": string,\n}",
+		},
+		{
+			Command: "gopls.move_package",
+			Title:   "Move a package",
+			Doc:     "Moves the directory of the package containing the given file to a new\ndirectory in the same module, and updates the import paths of the\npackage and its subpackages throughout the module. A package named\nafter its old directory is renamed after the new one. All files must\nbe saved first.",
+			ArgDoc:  "{\n\t// A file of the package to move.\n\t\"URI\": string,\n\t// The new directory of the package, which must not exist.\n\t\"NewDir\": string,\n}",
+		},
+		{
+			Command:   "gopls.package_graph",
+			Title:     "Show the package dependency graph",
+			Doc:       "Returns the import graph of the packages loaded in the view containing\nthe given URI, as a Graphviz DOT or JSON document. Test variants and\nthe packages outside the workspace, such as those of the standard\nlibrary and of the dependencies, are left out unless requested.",
+			ArgDoc:    "{\n\t// A URI in the view whose packages are listed.\n\t\"URI\": string,\n\t// The format of the document: \"dot\", the default, or \"json\".\n\t\"Format\": string,\n\t// Whether to include the test variants of the packages.\n\t\"Tests\": bool,\n\t// Whether to include the packages outside the workspace.\n\t\"External\": bool,\n}",
+			ResultDoc: "{\n\t// The DOT or JSON document.\n\t\"Document\": string,\n}",
+		},
 		{
 			Command: "gopls.regenerate_cgo",
 			Title:   "Regenerate cgo",
@@ -664,11 +1045,24 @@ var GeneratedAPIJSON = &APIJSON{
 			Doc:     "Removes a dependency from the go.mod file of a module.",
 			ArgDoc:  "{\n\t// The go.mod file URI.\n\t\"URI\": string,\n\t// The module path to remove.\n\t\"ModulePath\": string,\n\t\"OnlyDiagnostic\": bool,\n}",
 		},
+		{
+			Command:   "gopls.rename",
+			Title:     "Rename an identifier, reporting conflicts",
+			Doc:       "Renames the identifier at the given position like textDocument/rename,\nbut reports the conflicts of the renaming, classified, in the result\nrather than as an error. A renaming with conflicts is not applied,\nunless ApplyNonConflicting is set: then its edits are applied, except\nthose at the references at which conflicts are reported.",
+			ArgDoc:    "{\n\t// The file URI of the identifier.\n\t\"URI\": string,\n\t// The position of the identifier.\n\t\"Position\": {\n\t\t\"line\": uint32,\n\t\t\"character\": uint32,\n\t},\n\t// The new name of the identifier.\n\t\"NewName\": string,\n\t// Whether to apply the edits that do not conflict, if there are\n\t// conflicts.\n\t\"ApplyNonConflicting\": bool,\n}",
+			ResultDoc: "{\n\t// The conflicts of the renaming, if any.\n\t\"Conflicts\": []{\n\t\t\"Kind\": string,\n\t\t\"Message\": string,\n\t\t\"Locations\": []{\n\t\t\t\"uri\": string,\n\t\t\t\"range\": { ... },\n\t\t},\n\t},\n}",
+		},
 		{
 			Command: "gopls.run_tests",
 			Title:   "Run test(s)",
-			Doc:     "Runs `go test` for a specific set of test or benchmark functions.",
-			ArgDoc:  "{\n\t// The test file containing the tests to run.\n\t\"URI\": string,\n\t// Specific test names to run, e.g. TestFoo.\n\t\"Tests\": []string,\n\t// Specific benchmarks to run, e.g. BenchmarkFoo.\n\t\"Benchmarks\": []string,\n}",
+			Doc:     "Runs `go test` for a specific set of test, benchmark, or fuzz\nfunctions, and reports whether each passed or failed as a\ndiagnostic on the function.",
+			ArgDoc:  "{\n\t// The test file containing the tests to run.\n\t\"URI\": string,\n\t// Specific test names to run, e.g. TestFoo.\n\t\"Tests\": []string,\n\t// Specific benchmarks to run, e.g. BenchmarkFoo.\n\t\"Benchmarks\": []string,\n\t// Specific fuzz targets to run against their seed corpus, e.g. FuzzFoo.\n\t\"Fuzz\": []string,\n}",
+		},
+		{
+			Command: "gopls.run_tests_with_coverage",
+			Title:   "Run test(s) with coverage",
+			Doc:     "Runs `go test -coverprofile` for a specific set of test, benchmark,\nor fuzz functions, or for all the tests of the package if none are\ngiven, and reports the statements of the package that they did not\ncover as diagnostics.",
+			ArgDoc:  "{\n\t// The test file containing the tests to run.\n\t\"URI\": string,\n\t// Specific test names to run, e.g. TestFoo.\n\t\"Tests\": []string,\n\t// Specific benchmarks to run, e.g. BenchmarkFoo.\n\t\"Benchmarks\": []string,\n\t// Specific fuzz targets to run against their seed corpus, e.g. FuzzFoo.\n\t\"Fuzz\": []string,\n}",
 		},
 		{
 			Command:   "gopls.run_vulncheck_exp",
@@ -677,6 +1071,40 @@ var GeneratedAPIJSON = &APIJSON{
 			ArgDoc:    "{\n\t// Dir is the directory from which vulncheck will run from.\n\t\"Dir\": string,\n\t// Package pattern. E.g. \"\", \".\", \"./...\".\n\t\"Pattern\": string,\n}",
 			ResultDoc: "{\n\t\"Vuln\": []{\n\t\t\"ID\": string,\n\t\t\"Details\": string,\n\t\t\"Aliases\": []string,\n\t\t\"Symbol\": string,\n\t\t\"PkgPath\": string,\n\t\t\"ModPath\": string,\n\t\t\"URL\": string,\n\t\t\"CurrentVersion\": string,\n\t\t\"FixedVersion\": string,\n\t\t\"CallStacks\": [][]github.com/iansmith/golang-x-tools/internal/lsp/command.StackEntry,\n\t\t\"CallStackSummaries\": []string,\n\t},\n}",
 		},
+		{
+			Command:   "gopls.search_pattern",
+			Title:     "Search for code matching a pattern",
+			Doc:       "Returns the locations of the code of the workspace packages of the\nview containing the given URI that matches a Go expression or list of\nstatements in which, as with gofmt -r, the identifiers of a single\nlowercase letter are wildcards matching any expression.",
+			ArgDoc:    "{\n\t// A URI in the view whose packages are searched.\n\t\"URI\": string,\n\t// The pattern, such as \"fmt.Errorf(f, err)\" or \"if e != nil { return e }\".\n\t\"Pattern\": string,\n}",
+			ResultDoc: "{\n\t// The locations of the matches, sorted.\n\t\"Locations\": []{\n\t\t\"uri\": string,\n\t\t\"range\": {\n\t\t\t\"start\": { ... },\n\t\t\t\"end\": { ... },\n\t\t},\n\t},\n}",
+		},
+		{
+			Command: "gopls.set_overlays",
+			Title:   "Set the content of files not open in the editor",
+			Doc:     "Sets the content of the given files, which must not be open in the\neditor, without modifying them on disk, as if the editor had opened\nthem with this content, so that a tool can get the diagnostics of a\nproposed change set: the command returns once the diagnostics of the\naffected packages are published. The overlays are kept until they are\ncleared with the clear_overlays command, or the editor opens their\nfiles.",
+			ArgDoc:  "{\n\t// The files to overlay, with their content.\n\t\"Overlays\": []{\n\t\t\"URI\": string,\n\t\t\"Content\": string,\n\t},\n}",
+		},
+		{
+			Command:   "gopls.show_duplicates",
+			Title:     "Show the duplicates of duplicated code",
+			Doc:       "Returns, and shows to the user, the other locations of the duplicated\ncode at the given range, which the duplicateCodeTokens setting\nreports.",
+			ArgDoc:    "{\n\t// The file of the duplicated code.\n\t\"URI\": string,\n\t// The range of the duplicated code.\n\t\"Range\": {\n\t\t\"start\": {\n\t\t\t\"line\": uint32,\n\t\t\t\"character\": uint32,\n\t\t},\n\t\t\"end\": {\n\t\t\t\"line\": uint32,\n\t\t\t\"character\": uint32,\n\t\t},\n\t},\n}",
+			ResultDoc: "{\n\t// The locations of the other occurrences of the code.\n\t\"Locations\": []{\n\t\t\"uri\": string,\n\t\t\"range\": {\n\t\t\t\"start\": { ... },\n\t\t\t\"end\": { ... },\n\t\t},\n\t},\n}",
+		},
+		{
+			Command:   "gopls.show_generated",
+			Title:     "Show the files generated by a directive",
+			Doc:       "Returns, and shows to the user, the generated files of the package\ndirectory that the //go:generate directive at the given range\nproduces.",
+			ArgDoc:    "{\n\t// The file of the directive.\n\t\"URI\": string,\n\t// The range of the directive.\n\t\"Range\": {\n\t\t\"start\": {\n\t\t\t\"line\": uint32,\n\t\t\t\"character\": uint32,\n\t\t},\n\t\t\"end\": {\n\t\t\t\"line\": uint32,\n\t\t\t\"character\": uint32,\n\t\t},\n\t},\n}",
+			ResultDoc: "{\n\t// The locations of the generated files.\n\t\"Locations\": []{\n\t\t\"uri\": string,\n\t\t\"range\": {\n\t\t\t\"start\": { ... },\n\t\t\t\"end\": { ... },\n\t\t},\n\t},\n}",
+		},
+		{
+			Command:   "gopls.show_generator",
+			Title:     "Show the generator of a generated file",
+			Doc:       "Returns, and shows to the user, the //go:generate directives that\nproduced the given generated file, following its \"Code generated\"\nheader, and the generator inputs that the header names.",
+			ArgDoc:    "{\n\t// The file URI.\n\t\"URI\": string,\n}",
+			ResultDoc: "{\n\t// The locations of the directives and of the inputs.\n\t\"Locations\": []{\n\t\t\"uri\": string,\n\t\t\"range\": {\n\t\t\t\"start\": { ... },\n\t\t\t\"end\": { ... },\n\t\t},\n\t},\n}",
+		},
 		{
 			Command:   "gopls.start_debugging",
 			Title:     "Start the gopls debug server",
@@ -720,6 +1148,18 @@ var GeneratedAPIJSON = &APIJSON{
 			Doc:     "Runs `go mod vendor` for a module.",
 			ArgDoc:  "{\n\t// The file URI.\n\t\"URI\": string,\n}",
 		},
+		{
+			Command: "gopls.work_sync",
+			Title:   "Run go work sync",
+			Doc:     "Runs `go work sync` for a workspace, updating the requirements of\nits modules to match the workspace's build list.",
+			ArgDoc:  "{\n\t// The file URI.\n\t\"URI\": string,\n}",
+		},
+		{
+			Command: "gopls.wrap_errors",
+			Title:   "Wrap the errors returned by a package",
+			Doc:     "Wraps with fmt.Errorf the error variables returned by the functions of\nthe package containing the given file, following the\nerrorWrapTemplate setting.",
+			ArgDoc:  "{\n\t// The file URI.\n\t\"URI\": string,\n}",
+		},
 	},
 	Lenses: []*LensJSON{
 		{
@@ -757,6 +1197,11 @@ var GeneratedAPIJSON = &APIJSON{
 			Title: "Run go mod vendor",
 			Doc:   "Runs `go mod vendor` for a module.",
 		},
+		{
+			Lens:  "work_sync",
+			Title: "Run go work sync",
+			Doc:   "Runs `go work sync` for a workspace, updating the requirements of\nits modules to match the workspace's build list.",
+		},
 	},
 	Analyzers: []*AnalyzerJSON{
 		{
@@ -794,6 +1239,10 @@ var GeneratedAPIJSON = &APIJSON{
 			Doc:     "detect some violations of the cgo pointer passing rules\n\nCheck for invalid cgo pointer passing.\nThis looks for code that uses cgo to call C code passing values\nwhose types are almost always invalid according to the cgo pointer\nsharing rules.\nSpecifically, it warns about attempts to pass a Go chan, map, func,\nor slice to C, either directly, or via a pointer, array, or struct.",
 			Default: true,
 		},
+		{
+			Name: "commentstyle",
+			Doc:  "check the conventions and spelling of comments\n\nThe commentstyle analyzer reports, with suggested fixes:\n\n- doc comments of exported declarations that do not begin with the name\n  of the declared identifier, such as \"Returns the sum.\" for func Sum;\n\n- malformed deprecation notices, which should be a paragraph beginning\n  with \"Deprecated: \", such as \"DEPRECATED - use Sum instead.\";\n\n- common misspellings in comments, such as \"recieve\". The -dictionary\n  flag names a file of additional misspellings, one \"misspelling\n  correction\" pair per line.",
+		},
 		{
 			Name:    "composites",
 			Doc:     "check for unkeyed composite literals\n\nThis analyzer reports a diagnostic for composite literals of struct\ntypes imported from another package that do not use the field-keyed\nsyntax. Such literals are fragile because the addition of a new field\n(even if unexported) to the struct will cause compilation to fail.\n\nAs an example,\n\n\terr = &net.DNSConfigError{err}\n\nshould be replaced by:\n\n\terr = &net.DNSConfigError{Err: err}\n",
@@ -968,9 +1417,14 @@ var GeneratedAPIJSON = &APIJSON{
 			Doc:     "suggested fixes for \"undeclared name: <>\"\n\nThis checker provides suggested fixes for type errors of the\ntype \"undeclared name: <>\". It will either insert a new statement,\nsuch as:\n\n\"<> := \"\n\nor a new function declaration, such as:\n\nfunc <>(inferred parameters) {\n\tpanic(\"implement me!\")\n}\n",
 			Default: true,
 		},
+		{
+			Name:    "unknownfield",
+			Doc:     "suggested fixes for \"unknown field <> in struct literal\"\n\nThis checker provides suggested fixes for type errors of the\ntype \"unknown field <> in struct literal\". It adds the field to the\ndeclaration of the struct type, with the type of its value. For example:\n\ttype T struct {\n\t\tA int\n\t}\n\n\tvar _ = T{A: 1, B: \"b\"}\nwill turn into\n\ttype T struct {\n\t\tA int\n\t\tB string\n\t}\n\nIf the fields of the struct have JSON tags, the new field gets one that\nfollows their naming convention.\n\nIt also fixes the errors reporting that a selector x.f refers to no field\nor method of a type declared in the package. If x.f is called, it declares\nthe method f, with parameters of the types of the arguments and results of\nthe types expected by the context of the call. Otherwise it adds the field\nf to the struct type of x, with the type of the value assigned to x.f or\nexpected by its context.\n",
+			Default: true,
+		},
 		{
 			Name:    "fillstruct",
-			Doc:     "note incomplete struct initializations\n\nThis analyzer provides diagnostics for any struct literals that do not have\nany fields initialized. Because the suggested fix for this analysis is\nexpensive to compute, callers should compute it separately, using the\nSuggestedFix function below.\n",
+			Doc:     "note incomplete struct initializations\n\nThis analyzer provides diagnostics for any struct literals that do not have\nany fields initialized. Because the suggested fix for this analysis is\nexpensive to compute, callers should compute it separately, using the\nSuggestedFix function below.\n\nIt also notes calls that lack some of their arguments, and map literals\nwhose key type is an enumeration lacking some of its constants, so that the\nSuggestedFix function can fill in the missing arguments or keys.\n",
 			Default: true,
 		},
 		{
@@ -979,4 +1433,18 @@ var GeneratedAPIJSON = &APIJSON{
 			Default: true,
 		},
 	},
+	Hints: []*HintJSON{
+		{
+			Name: "compositeLiteralFields",
+			Doc:  "Enable/disable inlay hints for the field names of unkeyed composite literals:\n```go\n\t{/* in: */ \"Hello, world\", /* want: */ \"dlrow ,olleH\"}\n```",
+		},
+		{
+			Name: "implicitConversions",
+			Doc:  "Enable/disable inlay hints for implicit conversions of values to interface types\nand of untyped integer constants to types other than int:\n```go\n\tfmt.Fprintln(buf /* as io.Writer */, \"hi\")\n\ttime.Sleep(5 /* as time.Duration */)\n```",
+		},
+		{
+			Name: "parameterNames",
+			Doc:  "Enable/disable inlay hints for parameter names:\n```go\n\tparseInt(/* str: */ \"123\", /* radix: */ 8)\n```",
+		},
+	},
 }
@@ -0,0 +1,295 @@
+// Copyright 2022 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package source
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"go/types"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/iansmith/golang-x-tools/internal/event"
+	"github.com/iansmith/golang-x-tools/internal/lsp/protocol"
+	"github.com/iansmith/golang-x-tools/internal/span"
+)
+
+// An asmSymbol is a reference to a symbol of the current package in a Go
+// assembly file, written with a leading middle dot, as in "CALL ·foo(SB)".
+type asmSymbol struct {
+	name       string
+	def        bool // whether the symbol is defined by the enclosing TEXT directive
+	start, end int  // byte offsets of the symbol, including the middle dot
+}
+
+const asmDot = "·"
+
+// parseAsmSymbols returns the references to symbols of the current package
+// in the given Go assembly source. Symbols qualified by a package, such as
+// runtime·memmove, and file-local symbols, such as ·tab<>, are ignored.
+func parseAsmSymbols(content []byte) []asmSymbol {
+	var symbols []asmSymbol
+	offset := 0
+	for _, line := range bytes.SplitAfter(content, []byte("\n")) {
+		text := line
+		if i := bytes.Index(text, []byte("//")); i >= 0 {
+			text = text[:i]
+		}
+		fields := bytes.Fields(text)
+		isText := len(fields) > 0 && string(fields[0]) == "TEXT"
+		first := true
+		for i := 0; ; {
+			j := bytes.Index(text[i:], []byte(asmDot))
+			if j < 0 {
+				break
+			}
+			j += i
+			k := j + len(asmDot)
+			for k < len(text) && isAsmSymbolByte(text[k]) {
+				k++
+			}
+			i = k
+			qualified := j > 0 && (isAsmSymbolByte(text[j-1]) || text[j-1] == '.')
+			rest := text[k:]
+			static := bytes.HasPrefix(rest, []byte("<>"))
+			if qualified || static || k == j+len(asmDot) || len(rest) == 0 || !strings.ContainsRune("(+-", rune(rest[0])) {
+				first = false
+				continue
+			}
+			symbols = append(symbols, asmSymbol{
+				name:  string(text[j+len(asmDot) : k]),
+				def:   isText && first,
+				start: offset + j,
+				end:   offset + k,
+			})
+			first = false
+		}
+		offset += len(line)
+	}
+	return symbols
+}
+
+// isAsmSymbolByte reports whether b may be part of a symbol name. Bytes of
+// multi-byte characters are accepted, so that non-ASCII identifiers, as well
+// as the division slashes of package paths, are treated as part of a name.
+func isAsmSymbolByte(b byte) bool {
+	return b == '_' || '0' <= b && b <= '9' || 'a' <= b && b <= 'z' || 'A' <= b && b <= 'Z' || b >= 0x80 && b != asmDot[0] && b != asmDot[1]
+}
+
+// asmLocations returns the locations in the assembly files of pkg of the
+// symbol name. If defs is set, only TEXT definitions are returned; otherwise
+// only the other references are.
+func asmLocations(ctx context.Context, snapshot Snapshot, pkg Package, name string, defs bool) ([]protocol.Location, error) {
+	var locations []protocol.Location
+	for _, uri := range pkg.OtherFiles() {
+		if filepath.Ext(uri.Filename()) != ".s" {
+			continue
+		}
+		fh, err := snapshot.GetFile(ctx, uri)
+		if err != nil {
+			return nil, err
+		}
+		content, err := fh.Read()
+		if err != nil {
+			continue // the file may have been deleted
+		}
+		m := protocol.NewColumnMapper(uri, content)
+		for _, sym := range parseAsmSymbols(content) {
+			if sym.name != name || sym.def != defs {
+				continue
+			}
+			rng, err := ByteOffsetsToRange(m, uri, sym.start, sym.end)
+			if err != nil {
+				return nil, err
+			}
+			locations = append(locations, protocol.Location{URI: protocol.URIFromSpanURI(uri), Range: rng})
+		}
+	}
+	return locations, nil
+}
+
+// packageLevelObject reports whether obj is declared at the package level,
+// and so may be referenced from assembly.
+func packageLevelObject(obj types.Object) bool {
+	return obj != nil && obj.Pkg() != nil && obj.Parent() == obj.Pkg().Scope()
+}
+
+// AsmFuncDefinitions returns the TEXT symbols defining the function
+// identified by ident, if it is implemented in assembly.
+func AsmFuncDefinitions(ctx context.Context, snapshot Snapshot, ident *IdentifierInfo) ([]protocol.Location, error) {
+	fn, ok := ident.Declaration.obj.(*types.Func)
+	if !ok || !packageLevelObject(fn) {
+		return nil, nil
+	}
+	pkg, err := packageForPath(ctx, snapshot, ident.pkg, fn.Pkg().Path())
+	if err != nil {
+		return nil, nil
+	}
+	return asmLocations(ctx, snapshot, pkg, fn.Name(), true)
+}
+
+// AsmReferencesTo returns the references from assembly files to the
+// package-level object at the given position of the Go file fh. If
+// includeDeclaration is set, the TEXT symbols defining a function implemented
+// in assembly are included.
+func AsmReferencesTo(ctx context.Context, snapshot Snapshot, fh FileHandle, position protocol.Position, includeDeclaration bool) ([]protocol.Location, error) {
+	qos, err := qualifiedObjsAtProtocolPos(ctx, snapshot, fh.URI(), position)
+	if err != nil || len(qos) == 0 {
+		// Errors are reported by the search for references in Go files.
+		return nil, nil
+	}
+	return asmReferences(ctx, snapshot, qos[0].pkg, qos[0].obj, includeDeclaration)
+}
+
+// asmReferences returns the references to obj, declared in pkg, from the
+// assembly files of pkg.
+func asmReferences(ctx context.Context, snapshot Snapshot, pkg Package, obj types.Object, includeDeclaration bool) ([]protocol.Location, error) {
+	if !packageLevelObject(obj) {
+		return nil, nil
+	}
+	var locations []protocol.Location
+	if includeDeclaration {
+		defs, err := asmLocations(ctx, snapshot, pkg, obj.Name(), true)
+		if err != nil {
+			return nil, err
+		}
+		locations = append(locations, defs...)
+	}
+	uses, err := asmLocations(ctx, snapshot, pkg, obj.Name(), false)
+	if err != nil {
+		return nil, err
+	}
+	return append(locations, uses...), nil
+}
+
+// asmObject returns the package and the object referenced by the symbol at
+// the given position of the assembly file fh.
+func asmObject(ctx context.Context, snapshot Snapshot, fh FileHandle, position protocol.Position) (Package, types.Object, error) {
+	content, err := fh.Read()
+	if err != nil {
+		return nil, nil, err
+	}
+	m := protocol.NewColumnMapper(fh.URI(), content)
+	offset, err := m.Offset(position)
+	if err != nil {
+		return nil, nil, err
+	}
+	var name string
+	for _, sym := range parseAsmSymbols(content) {
+		if sym.start <= offset && offset <= sym.end {
+			name = sym.name
+			break
+		}
+	}
+	if name == "" {
+		return nil, nil, fmt.Errorf("no symbol found")
+	}
+	pkg, err := asmPackage(ctx, snapshot, fh.URI())
+	if err != nil {
+		return nil, nil, err
+	}
+	obj := pkg.GetTypes().Scope().Lookup(name)
+	if obj == nil {
+		return nil, nil, fmt.Errorf("no declaration found for %s", name)
+	}
+	return pkg, obj, nil
+}
+
+// asmPackage returns the package containing the assembly file uri: the
+// package of the Go files in the same directory that lists it.
+func asmPackage(ctx context.Context, snapshot Snapshot, uri span.URI) (Package, error) {
+	dir := filepath.Dir(uri.Filename())
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	for _, entry := range entries {
+		if entry.IsDir() || filepath.Ext(entry.Name()) != ".go" {
+			continue
+		}
+		pkgs, err := snapshot.PackagesForFile(ctx, span.URIFromPath(filepath.Join(dir, entry.Name())), TypecheckWorkspace, false)
+		if err != nil {
+			continue
+		}
+		for _, pkg := range pkgs {
+			for _, other := range pkg.OtherFiles() {
+				if other == uri {
+					return pkg, nil
+				}
+			}
+		}
+	}
+	return nil, fmt.Errorf("no package found for %s", uri)
+}
+
+// AsmDefinition returns the location of the Go declaration of the symbol at
+// the given position of the assembly file fh.
+func AsmDefinition(ctx context.Context, snapshot Snapshot, fh FileHandle, position protocol.Position) ([]protocol.Location, error) {
+	ctx, done := event.Start(ctx, "source.AsmDefinition")
+	defer done()
+
+	pkg, obj, err := asmObject(ctx, snapshot, fh, position)
+	if err != nil {
+		return nil, err
+	}
+	loc, err := objLocation(snapshot, pkg, obj)
+	if err != nil {
+		return nil, err
+	}
+	return []protocol.Location{loc}, nil
+}
+
+// AsmReferences returns the references to the symbol at the given position
+// of the assembly file fh, in both Go and assembly files.
+func AsmReferences(ctx context.Context, snapshot Snapshot, fh FileHandle, position protocol.Position, includeDeclaration bool) ([]protocol.Location, error) {
+	ctx, done := event.Start(ctx, "source.AsmReferences")
+	defer done()
+
+	pkg, obj, err := asmObject(ctx, snapshot, fh, position)
+	if err != nil {
+		return nil, err
+	}
+	// Find the references from the Go declaration.
+	decl, err := objLocation(snapshot, pkg, obj)
+	if err != nil {
+		return nil, err
+	}
+	declFH, err := snapshot.GetFile(ctx, decl.URI.SpanURI())
+	if err != nil {
+		return nil, err
+	}
+	refs, err := References(ctx, snapshot, declFH, decl.Range.Start, includeDeclaration)
+	if err != nil {
+		return nil, err
+	}
+	var locations []protocol.Location
+	for _, ref := range refs {
+		rng, err := ref.Range()
+		if err != nil {
+			return nil, err
+		}
+		locations = append(locations, protocol.Location{URI: protocol.URIFromSpanURI(ref.URI()), Range: rng})
+	}
+	asmRefs, err := asmReferences(ctx, snapshot, pkg, obj, includeDeclaration)
+	if err != nil {
+		return nil, err
+	}
+	return append(locations, asmRefs...), nil
+}
+
+// objLocation returns the location of the declaration of obj.
+func objLocation(snapshot Snapshot, pkg Package, obj types.Object) (protocol.Location, error) {
+	rng, err := objToMappedRange(snapshot, pkg, obj)
+	if err != nil {
+		return protocol.Location{}, err
+	}
+	protocolRange, err := rng.Range()
+	if err != nil {
+		return protocol.Location{}, err
+	}
+	return protocol.Location{URI: protocol.URIFromSpanURI(rng.URI()), Range: protocolRange}, nil
+}
@@ -252,14 +252,40 @@ func collectCallExpressions(fset *token.FileSet, mapper *protocol.ColumnMapper,
 // toProtocolOutgoingCalls returns an array of protocol.CallHierarchyOutgoingCall for ast call expressions.
 // Calls to the same function are assigned to the same declaration.
 func toProtocolOutgoingCalls(ctx context.Context, snapshot Snapshot, fh FileHandle, callRanges []protocol.Range) ([]protocol.CallHierarchyOutgoingCall, error) {
-	// Multiple calls could be made to the same function, defined by "same declaration
-	// AST node & same identifier name" to provide a unique identifier key even when
-	// the func is declared in a struct or interface.
-	type key struct {
-		decl ast.Node
-		name string
+	// Multiple calls could be made to the same function, identified by the
+	// position of its declaration, which is unique even when the func is
+	// declared in a struct or interface.
+	fset := snapshot.FileSet()
+	outgoingCalls := map[token.Position]*protocol.CallHierarchyOutgoingCall{}
+	// addCall records a call at callRange to obj, declared at rng.
+	addCall := func(obj types.Object, rng MappedRange, callRange protocol.Range) error {
+		k := fset.Position(obj.Pos())
+		if outgoingCall, ok := outgoingCalls[k]; ok {
+			// The same method may be found in several variants of its
+			// package, such as p and p [p.test].
+			if n := len(outgoingCall.FromRanges); n == 0 || outgoingCall.FromRanges[n-1] != callRange {
+				outgoingCall.FromRanges = append(outgoingCall.FromRanges, callRange)
+			}
+			return nil
+		}
+		declRange, err := rng.Range()
+		if err != nil {
+			return err
+		}
+		outgoingCalls[k] = &protocol.CallHierarchyOutgoingCall{
+			To: protocol.CallHierarchyItem{
+				Name:           obj.Name(),
+				Kind:           protocol.Function,
+				Tags:           []protocol.SymbolTag{},
+				Detail:         fmt.Sprintf("%s • %s", obj.Pkg().Path(), filepath.Base(rng.URI().Filename())),
+				URI:            protocol.DocumentURI(rng.URI()),
+				Range:          declRange,
+				SelectionRange: declRange,
+			},
+			FromRanges: []protocol.Range{callRange},
+		}
+		return nil
 	}
-	outgoingCalls := map[key]*protocol.CallHierarchyOutgoingCall{}
 	for _, callRange := range callRanges {
 		identifier, err := Identifier(ctx, snapshot, fh, callRange.Start)
 		if err != nil {
@@ -274,31 +300,34 @@ func toProtocolOutgoingCalls(ctx context.Context, snapshot Snapshot, fh FileHand
 			continue
 		}
 
-		if outgoingCall, ok := outgoingCalls[key{identifier.Declaration.node, identifier.Name}]; ok {
-			outgoingCall.FromRanges = append(outgoingCall.FromRanges, callRange)
+		if len(identifier.Declaration.MappedRange) == 0 {
 			continue
 		}
+		obj := identifier.Declaration.obj
+		if err := addCall(obj, identifier.Declaration.MappedRange[0], callRange); err != nil {
+			return nil, err
+		}
 
-		if len(identifier.Declaration.MappedRange) == 0 {
+		// A call of an interface method may call the method of any
+		// type that implements the interface. Approximate the dynamic
+		// callees by the corresponding methods of the workspace types
+		// that implement it.
+		method, ok := obj.(*types.Func)
+		if !ok {
 			continue
 		}
-		declMappedRange := identifier.Declaration.MappedRange[0]
-		rng, err := declMappedRange.Range()
+		impls, err := interfaceMethodImpls(ctx, snapshot, method)
 		if err != nil {
 			return nil, err
 		}
-
-		outgoingCalls[key{identifier.Declaration.node, identifier.Name}] = &protocol.CallHierarchyOutgoingCall{
-			To: protocol.CallHierarchyItem{
-				Name:           identifier.Name,
-				Kind:           protocol.Function,
-				Tags:           []protocol.SymbolTag{},
-				Detail:         fmt.Sprintf("%s • %s", identifier.Declaration.obj.Pkg().Path(), filepath.Base(declMappedRange.URI().Filename())),
-				URI:            protocol.DocumentURI(declMappedRange.URI()),
-				Range:          rng,
-				SelectionRange: rng,
-			},
-			FromRanges: []protocol.Range{callRange},
+		for _, impl := range impls {
+			rng, err := nameToMappedRange(snapshot, impl.pkg, impl.obj.Pos(), impl.obj.Name())
+			if err != nil {
+				return nil, err
+			}
+			if err := addCall(impl.obj, rng, callRange); err != nil {
+				return nil, err
+			}
 		}
 	}
 
@@ -308,3 +337,53 @@ func toProtocolOutgoingCalls(ctx context.Context, snapshot Snapshot, fh FileHand
 	}
 	return outgoingCallItems, nil
 }
+
+// interfaceMethodImpls returns the methods of the named types declared
+// in workspace packages that implement the interface method, or nil if
+// method is not an interface method.
+func interfaceMethodImpls(ctx context.Context, snapshot Snapshot, method *types.Func) ([]qualifiedObject, error) {
+	recv := method.Type().(*types.Signature).Recv()
+	if recv == nil || !types.IsInterface(recv.Type()) {
+		return nil, nil
+	}
+	iface, ok := recv.Type().Underlying().(*types.Interface)
+	if !ok || iface.Empty() {
+		return nil, nil
+	}
+	pkgs, err := snapshot.ActivePackages(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var (
+		impls []qualifiedObject
+		seen  = make(map[token.Position]bool)
+		fset  = snapshot.FileSet()
+	)
+	for _, pkg := range pkgs {
+		for _, obj := range pkg.GetTypesInfo().Defs {
+			tname, ok := obj.(*types.TypeName)
+			if !ok || tname.IsAlias() {
+				continue
+			}
+			named, ok := tname.Type().(*types.Named)
+			if !ok || types.IsInterface(named) {
+				continue
+			}
+			typ := ensurePointer(named)
+			if !types.Implements(typ, iface) {
+				continue
+			}
+			sel := types.NewMethodSet(typ).Lookup(method.Pkg(), method.Name())
+			if sel == nil {
+				continue
+			}
+			posn := fset.Position(sel.Obj().Pos())
+			if seen[posn] {
+				continue
+			}
+			seen[posn] = true
+			impls = append(impls, qualifiedObject{obj: sel.Obj(), pkg: pkg})
+		}
+	}
+	return impls, nil
+}
@@ -0,0 +1,103 @@
+// Copyright 2022 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package source
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+	"regexp"
+	"strings"
+
+	"github.com/iansmith/golang-x-tools/internal/lsp/safetoken"
+)
+
+// cgoPrefixes are the prefixes that cgo adds to the C names used by a
+// package to form the names of their Go declarations, in the generated
+// _cgo_gotypes.go file: C.add is declared as _Cfunc_add.
+var cgoPrefixes = []string{"_Cfunc_", "_Ctype_", "_Cvar_", "_Cmacro_", "_Ciconst_", "_Cfconst_", "_Csconst_"}
+
+// cgoNameRe matches the names that cgo generates in Go code, optionally
+// qualified by their package.
+var cgoNameRe = regexp.MustCompile(`\b(?:\w+\.)?_C(?:func|type|var|macro|iconst|fconst|sconst)_`)
+
+// CgoPreambles returns the cgo preambles of f, the comments immediately
+// preceding its import "C" declarations.
+func CgoPreambles(f *ast.File) []*ast.CommentGroup {
+	var preambles []*ast.CommentGroup
+	for _, decl := range f.Decls {
+		gd, ok := decl.(*ast.GenDecl)
+		if !ok || gd.Tok != token.IMPORT {
+			continue
+		}
+		for _, spec := range gd.Specs {
+			imp, ok := spec.(*ast.ImportSpec)
+			if !ok || imp.Path.Value != `"C"` {
+				continue
+			}
+			doc := imp.Doc
+			if doc == nil && !gd.Lparen.IsValid() {
+				doc = gd.Doc
+			}
+			if doc != nil {
+				preambles = append(preambles, doc)
+			}
+		}
+	}
+	return preambles
+}
+
+// cgoName returns the C name of obj if it is a Go declaration generated by
+// cgo, such as "add" for _Cfunc_add, or "" otherwise.
+func cgoName(obj types.Object) string {
+	for _, prefix := range cgoPrefixes {
+		if name := strings.TrimPrefix(obj.Name(), prefix); name != obj.Name() && name != "" {
+			return name
+		}
+	}
+	return ""
+}
+
+// cgoPreambleRange returns the range of the C declaration of obj, a Go
+// declaration generated by cgo for the package pkg: the first occurrence of
+// its C name in the cgo preambles of the files of pkg. It reports false if
+// obj is not generated by cgo, or if its name is not found.
+func cgoPreambleRange(snapshot Snapshot, pkg Package, obj types.Object) (MappedRange, bool) {
+	name := cgoName(obj)
+	if name == "" {
+		return MappedRange{}, false
+	}
+	// C.struct_foo is declared as "struct foo".
+	for _, kind := range []string{"struct_", "union_", "enum_"} {
+		if strings.HasPrefix(name, kind) && len(name) > len(kind) {
+			name = name[len(kind):]
+			break
+		}
+	}
+	re := regexp.MustCompile(`\b` + regexp.QuoteMeta(name) + `\b`)
+	for _, pgf := range pkg.CompiledGoFiles() {
+		for _, doc := range CgoPreambles(pgf.File) {
+			start, err := safetoken.Offset(pgf.Tok, doc.Pos())
+			if err != nil {
+				continue
+			}
+			end, err := safetoken.Offset(pgf.Tok, doc.End())
+			if err != nil || end > len(pgf.Src) || start > end {
+				continue
+			}
+			if loc := re.FindIndex(pgf.Src[start:end]); loc != nil {
+				return NewMappedRange(snapshot.FileSet(), pgf.Mapper, doc.Pos()+token.Pos(loc[0]), doc.Pos()+token.Pos(loc[1])), true
+			}
+		}
+	}
+	return MappedRange{}, false
+}
+
+// cgoSignature returns signature, in which the names generated by cgo are
+// replaced by the names of the Go code that uses them, such as C.int for
+// _Ctype_int.
+func cgoSignature(signature string) string {
+	return cgoNameRe.ReplaceAllString(signature, "C.")
+}
@@ -0,0 +1,243 @@
+// Copyright 2022 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package source
+
+import (
+	"context"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"sort"
+	"strconv"
+
+	"github.com/iansmith/golang-x-tools/go/analysis"
+	"github.com/iansmith/golang-x-tools/internal/span"
+	"github.com/iansmith/golang-x-tools/internal/typesinternal"
+)
+
+// refactoringPackages returns the packages that a refactoring of the
+// package of uri may break: its variants, including those for tests, and
+// their reverse dependencies.
+func refactoringPackages(ctx context.Context, snapshot Snapshot, uri span.URI) ([]Package, error) {
+	variants, err := snapshot.PackagesForFile(ctx, uri, TypecheckFull, true)
+	if err != nil {
+		return nil, err
+	}
+	seen := make(map[string]bool)
+	var pkgs []Package
+	add := func(pkg Package) {
+		if !seen[pkg.ID()] {
+			seen[pkg.ID()] = true
+			pkgs = append(pkgs, pkg)
+		}
+	}
+	for _, pkg := range variants {
+		add(pkg)
+	}
+	for _, pkg := range variants {
+		rdeps, err := snapshot.GetReverseDependencies(ctx, pkg.ID())
+		if err != nil {
+			return nil, err
+		}
+		for _, rdep := range rdeps {
+			add(rdep)
+		}
+	}
+	return pkgs, nil
+}
+
+// sameObject reports whether a and b are the same object of different
+// variants of a package, or of the same one.
+func sameObject(fset *token.FileSet, a, b types.Object) bool {
+	if a == b {
+		return true
+	}
+	if a == nil || b == nil || a.Name() != b.Name() || a.Pkg() == nil || b.Pkg() == nil || a.Pkg().Path() != b.Pkg().Path() {
+		return false
+	}
+	return fset.Position(a.Pos()) == fset.Position(b.Pos())
+}
+
+// fileEdit is an edit of a file, at byte offsets.
+type fileEdit struct {
+	start, end int
+	text       string
+}
+
+// editsByFile returns edits grouped by the name of the file they apply to,
+// sorted by offset and without duplicates, such as the edits of a file
+// computed for each variant of its package.
+func editsByFile(fset *token.FileSet, edits []analysis.TextEdit) map[string][]fileEdit {
+	files := make(map[string][]fileEdit)
+	seen := make(map[string]map[fileEdit]bool)
+	for _, edit := range edits {
+		start, end := fset.Position(edit.Pos), fset.Position(edit.End)
+		e := fileEdit{start.Offset, end.Offset, string(edit.NewText)}
+		if seen[start.Filename] == nil {
+			seen[start.Filename] = make(map[fileEdit]bool)
+		}
+		if seen[start.Filename][e] {
+			continue
+		}
+		seen[start.Filename][e] = true
+		files[start.Filename] = append(files[start.Filename], e)
+	}
+	for _, edits := range files {
+		sort.SliceStable(edits, func(i, j int) bool {
+			return edits[i].start < edits[j].start
+		})
+	}
+	return files
+}
+
+// uniqueEdits returns edits without the duplicates of edits of the same
+// file, sorted by position within each file.
+func uniqueEdits(fset *token.FileSet, edits []analysis.TextEdit) []analysis.TextEdit {
+	type key struct {
+		filename string
+		edit     fileEdit
+	}
+	seen := make(map[key]bool)
+	var unique []analysis.TextEdit
+	for _, edit := range edits {
+		start, end := fset.Position(edit.Pos), fset.Position(edit.End)
+		k := key{start.Filename, fileEdit{start.Offset, end.Offset, string(edit.NewText)}}
+		if !seen[k] {
+			seen[k] = true
+			unique = append(unique, edit)
+		}
+	}
+	sort.SliceStable(unique, func(i, j int) bool {
+		pi, pj := fset.Position(unique[i].Pos), fset.Position(unique[j].Pos)
+		if pi.Filename != pj.Filename {
+			return pi.Filename < pj.Filename
+		}
+		return pi.Offset < pj.Offset
+	})
+	return unique
+}
+
+// applyFileEdits returns src with edits applied.
+func applyFileEdits(src []byte, edits []fileEdit) []byte {
+	var out []byte
+	last := 0
+	for _, e := range edits {
+		out = append(out, src[last:e.start]...)
+		out = append(out, e.text...)
+		last = e.end
+	}
+	return append(out, src[last:]...)
+}
+
+// checkEdits type checks pkgs again with edits applied to their files,
+// in dependency order so that each of them imports the checked versions
+// of the packages of pkgs it depends on, and returns the new type errors.
+// The packages that have errors before the edits are checked, for the
+// sake of the packages that import them, but their errors are not
+// reported.
+func checkEdits(ctx context.Context, snapshot Snapshot, pkgs []Package, edits []analysis.TextEdit) ([]string, error) {
+	fset := snapshot.FileSet()
+	fileEdits := editsByFile(fset, edits)
+
+	byID := make(map[string]Package)
+	for _, pkg := range pkgs {
+		byID[pkg.ID()] = pkg
+	}
+	parsed := make(map[string]*ast.File)
+	checked := make(map[string]*types.Package)
+	var (
+		errs []string
+		seen = make(map[string]bool)
+	)
+	var check func(pkg Package) (*types.Package, error)
+	check = func(pkg Package) (*types.Package, error) {
+		if tpkg, ok := checked[pkg.ID()]; ok {
+			return tpkg, nil
+		}
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		var files []*ast.File
+		for _, pgf := range pkg.CompiledGoFiles() {
+			filename := pgf.URI.Filename()
+			edits, ok := fileEdits[filename]
+			if !ok {
+				files = append(files, pgf.File)
+				continue
+			}
+			f, ok := parsed[filename]
+			if !ok {
+				var err error
+				f, err = parser.ParseFile(fset, filename, applyFileEdits(pgf.Src, edits), parser.AllErrors|parser.ParseComments)
+				if err != nil {
+					return nil, fmt.Errorf("parsing %s after the edits: %v", filename, err)
+				}
+				parsed[filename] = f
+			}
+			files = append(files, f)
+		}
+
+		// Resolve the imports as the original files do.
+		imports := make(map[string]*types.Package)
+		for _, pgf := range pkg.CompiledGoFiles() {
+			for _, spec := range pgf.File.Imports {
+				path, err := strconv.Unquote(spec.Path.Value)
+				if err != nil {
+					continue
+				}
+				obj := pkg.GetTypesInfo().Implicits[spec]
+				if spec.Name != nil {
+					obj = pkg.GetTypesInfo().Defs[spec.Name]
+				}
+				if pkgName, ok := obj.(*types.PkgName); ok {
+					imports[path] = pkgName.Imported()
+				}
+			}
+		}
+		cfg := &types.Config{
+			Error: func(err error) {
+				if pkg.HasTypeErrors() || pkg.HasListOrParseErrors() {
+					return
+				}
+				if msg := err.Error(); !seen[msg] {
+					seen[msg] = true
+					errs = append(errs, msg)
+				}
+			},
+			Importer: importerFunc(func(path string) (*types.Package, error) {
+				imported, ok := imports[path]
+				if !ok {
+					return nil, fmt.Errorf("no imported package for %s", path)
+				}
+				if dep, err := pkg.GetImport(imported.Path()); err == nil {
+					if dep, ok := byID[dep.ID()]; ok {
+						return check(dep)
+					}
+				}
+				return imported, nil
+			}),
+			Sizes: pkg.GetTypesSizes(),
+		}
+		typesinternal.SetUsesCgo(cfg)
+		tpkg := types.NewPackage(pkg.PkgPath(), pkg.Name())
+		// Type errors are reported through the configuration.
+		_ = types.NewChecker(cfg, fset, tpkg, nil).Files(files)
+		checked[pkg.ID()] = tpkg
+		return tpkg, nil
+	}
+	for _, pkg := range pkgs {
+		if _, err := check(pkg); err != nil {
+			return nil, err
+		}
+	}
+	return errs, nil
+}
+
+// importerFunc implements the types.Importer interface with a function.
+type importerFunc func(path string) (*types.Package, error)
+
+func (f importerFunc) Import(path string) (*types.Package, error) { return f(path) }
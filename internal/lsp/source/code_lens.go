@@ -33,6 +33,7 @@ func LensFuncs() map[command.Command]LensFunc {
 var (
 	testRe      = regexp.MustCompile("^Test[^a-z]")
 	benchmarkRe = regexp.MustCompile("^Benchmark[^a-z]")
+	fuzzRe      = regexp.MustCompile("^Fuzz[^a-z]")
 )
 
 func runTestCodeLens(ctx context.Context, snapshot Snapshot, fh FileHandle) ([]protocol.CodeLens, error) {
@@ -44,7 +45,7 @@ func runTestCodeLens(ctx context.Context, snapshot Snapshot, fh FileHandle) ([]p
 	}
 	puri := protocol.URIFromSpanURI(fh.URI())
 	for _, fn := range fns.Tests {
-		cmd, err := command.NewTestCommand("run test", puri, []string{fn.Name}, nil)
+		cmd, err := command.NewRunTestsCommand("run test", command.RunTestsArgs{URI: puri, Tests: []string{fn.Name}})
 		if err != nil {
 			return nil, err
 		}
@@ -53,7 +54,16 @@ func runTestCodeLens(ctx context.Context, snapshot Snapshot, fh FileHandle) ([]p
 	}
 
 	for _, fn := range fns.Benchmarks {
-		cmd, err := command.NewTestCommand("run benchmark", puri, nil, []string{fn.Name})
+		cmd, err := command.NewRunTestsCommand("run benchmark", command.RunTestsArgs{URI: puri, Benchmarks: []string{fn.Name}})
+		if err != nil {
+			return nil, err
+		}
+		rng := protocol.Range{Start: fn.Rng.Start, End: fn.Rng.Start}
+		codeLens = append(codeLens, protocol.CodeLens{Range: rng, Command: cmd})
+	}
+
+	for _, fn := range fns.Fuzz {
+		cmd, err := command.NewRunTestsCommand("run fuzz target", command.RunTestsArgs{URI: puri, Fuzz: []string{fn.Name}})
 		if err != nil {
 			return nil, err
 		}
@@ -75,7 +85,7 @@ func runTestCodeLens(ctx context.Context, snapshot Snapshot, fh FileHandle) ([]p
 		for _, fn := range fns.Benchmarks {
 			benches = append(benches, fn.Name)
 		}
-		cmd, err := command.NewTestCommand("run file benchmarks", puri, nil, benches)
+		cmd, err := command.NewRunTestsCommand("run file benchmarks", command.RunTestsArgs{URI: puri, Benchmarks: benches})
 		if err != nil {
 			return nil, err
 		}
@@ -85,13 +95,15 @@ func runTestCodeLens(ctx context.Context, snapshot Snapshot, fh FileHandle) ([]p
 }
 
 type testFn struct {
-	Name string
-	Rng  protocol.Range
+	Name    string
+	Rng     protocol.Range
+	NameRng protocol.Range // range of the function name
 }
 
 type testFns struct {
 	Tests      []testFn
 	Benchmarks []testFn
+	Fuzz       []testFn
 }
 
 func TestsAndBenchmarks(ctx context.Context, snapshot Snapshot, fh FileHandle) (testFns, error) {
@@ -115,13 +127,21 @@ func TestsAndBenchmarks(ctx context.Context, snapshot Snapshot, fh FileHandle) (
 		if err != nil {
 			return out, err
 		}
+		nameRng, err := NewMappedRange(snapshot.FileSet(), pgf.Mapper, fn.Name.Pos(), fn.Name.End()).Range()
+		if err != nil {
+			return out, err
+		}
 
 		if matchTestFunc(fn, pkg, testRe, "T") {
-			out.Tests = append(out.Tests, testFn{fn.Name.Name, rng})
+			out.Tests = append(out.Tests, testFn{fn.Name.Name, rng, nameRng})
 		}
 
 		if matchTestFunc(fn, pkg, benchmarkRe, "B") {
-			out.Benchmarks = append(out.Benchmarks, testFn{fn.Name.Name, rng})
+			out.Benchmarks = append(out.Benchmarks, testFn{fn.Name.Name, rng, nameRng})
+		}
+
+		if matchTestFunc(fn, pkg, fuzzRe, "F") {
+			out.Fuzz = append(out.Fuzz, testFn{fn.Name.Name, rng, nameRng})
 		}
 	}
 
@@ -172,6 +192,8 @@ func goGenerateCodeLens(ctx context.Context, snapshot Snapshot, fh FileHandle) (
 		return nil, err
 	}
 	const ggDirective = "//go:generate"
+	dir := protocol.URIFromSpanURI(span.URIFromPath(filepath.Dir(fh.URI().Filename())))
+	var codeLens []protocol.CodeLens
 	for _, c := range pgf.File.Comments {
 		for _, l := range c.List {
 			if !strings.HasPrefix(l.Text, ggDirective) {
@@ -181,23 +203,32 @@ func goGenerateCodeLens(ctx context.Context, snapshot Snapshot, fh FileHandle) (
 			if err != nil {
 				return nil, err
 			}
-			dir := protocol.URIFromSpanURI(span.URIFromPath(filepath.Dir(fh.URI().Filename())))
-			nonRecursiveCmd, err := command.NewGenerateCommand("run go generate", command.GenerateArgs{Dir: dir, Recursive: false})
-			if err != nil {
-				return nil, err
+			// The package-wide lenses are only shown on the first directive.
+			if len(codeLens) == 0 {
+				nonRecursiveCmd, err := command.NewGenerateCommand("run go generate", command.GenerateArgs{Dir: dir, Recursive: false})
+				if err != nil {
+					return nil, err
+				}
+				recursiveCmd, err := command.NewGenerateCommand("run go generate ./...", command.GenerateArgs{Dir: dir, Recursive: true})
+				if err != nil {
+					return nil, err
+				}
+				codeLens = append(codeLens,
+					protocol.CodeLens{Range: rng, Command: recursiveCmd},
+					protocol.CodeLens{Range: rng, Command: nonRecursiveCmd},
+				)
 			}
-			recursiveCmd, err := command.NewGenerateCommand("run go generate ./...", command.GenerateArgs{Dir: dir, Recursive: true})
+			// go generate -run matches the full text of the directive,
+			// excluding trailing spaces.
+			run := "^" + regexp.QuoteMeta(strings.TrimRight(l.Text, " \t")) + "$"
+			generatorCmd, err := command.NewGenerateCommand("run generator", command.GenerateArgs{Dir: dir, Run: run})
 			if err != nil {
 				return nil, err
 			}
-			return []protocol.CodeLens{
-				{Range: rng, Command: recursiveCmd},
-				{Range: rng, Command: nonRecursiveCmd},
-			}, nil
-
+			codeLens = append(codeLens, protocol.CodeLens{Range: rng, Command: generatorCmd})
 		}
 	}
-	return nil, nil
+	return codeLens, nil
 }
 
 func regenerateCgoLens(ctx context.Context, snapshot Snapshot, fh FileHandle) ([]protocol.CodeLens, error) {
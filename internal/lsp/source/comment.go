@@ -27,8 +27,32 @@ import (
 //
 // URLs in the comment text are converted into links.
 func CommentToMarkdown(text string) string {
+	return DocCommentToMarkdown(text, nil)
+}
+
+// A DocLinkResolver returns the URL of the documentation of the package or
+// symbol named by a doc link of a comment, such as "fmt.Printf" for
+// [fmt.Printf], or "" if the link does not name a known package or symbol.
+type DocLinkResolver func(link string) string
+
+// DocCommentToMarkdown is like CommentToMarkdown, but also supports the doc
+// comment syntax introduced in Go 1.19: headings starting with "# ", lists,
+// and links, whether to the URLs of link definitions such as
+// "[Text]: https://example.com", or to the documentation of Go packages and
+// symbols such as [fmt.Printf]. Doc links are resolved by resolve, which may
+// be nil.
+func DocCommentToMarkdown(text string, resolve DocLinkResolver) string {
+	buf := &bytes.Buffer{}
+	commentToMarkdown(buf, text, resolve)
+	return buf.String()
+}
+
+// DocCommentToText converts comment text to plain text, removing the markup
+// of the Go 1.19 doc comment syntax: link definitions are omitted, and the
+// brackets around links and doc links resolved by resolve are removed.
+func DocCommentToText(text string, resolve DocLinkResolver) string {
 	buf := &bytes.Buffer{}
-	commentToMarkdown(buf, text)
+	commentToText(buf, text, resolve)
 	return buf.String()
 }
 
@@ -39,15 +63,17 @@ var (
 	mdLinkStart = []byte("[")
 	mdLinkDiv   = []byte("](")
 	mdLinkEnd   = []byte(")")
+
+	mdListIndent = []byte("  ")
 )
 
-func commentToMarkdown(w io.Writer, text string) {
-	blocks := blocks(text)
+func commentToMarkdown(w io.Writer, text string, resolve DocLinkResolver) {
+	l, blocks := newLinker(blocks(text), resolve)
 	for i, b := range blocks {
 		switch b.op {
 		case opPara:
 			for _, line := range b.lines {
-				emphasize(w, line, true)
+				l.emphasize(w, line)
 			}
 		case opHead:
 			// The header block can consist of only one line.
@@ -71,6 +97,20 @@ func commentToMarkdown(w io.Writer, text string) {
 				w.Write(mdIndent)
 				w.Write([]byte(line))
 			}
+		case opList:
+			items := listItems(b.lines)
+			for j, item := range items.items {
+				if j > 0 && items.loose {
+					w.Write(mdNewline)
+				}
+				w.Write([]byte(item.marker + " "))
+				for k, line := range item.lines {
+					if k > 0 {
+						w.Write(mdListIndent)
+					}
+					l.emphasize(w, line)
+				}
+			}
 		}
 
 		if i < len(blocks)-1 {
@@ -79,6 +119,46 @@ func commentToMarkdown(w io.Writer, text string) {
 	}
 }
 
+func commentToText(w io.Writer, text string, resolve DocLinkResolver) {
+	l, blocks := newLinker(blocks(text), resolve)
+	for i, b := range blocks {
+		if i > 0 {
+			io.WriteString(w, "\n")
+		}
+		switch b.op {
+		case opPara:
+			for _, line := range b.lines {
+				io.WriteString(w, l.unbracket(line))
+			}
+		case opHead:
+			if len(b.lines) > 0 {
+				io.WriteString(w, b.lines[0]+"\n")
+			}
+		case opPre:
+			for _, line := range b.lines {
+				if !isBlank(line) {
+					io.WriteString(w, "\t")
+				}
+				io.WriteString(w, line)
+			}
+		case opList:
+			items := listItems(b.lines)
+			for j, item := range items.items {
+				if j > 0 && items.loose {
+					io.WriteString(w, "\n")
+				}
+				io.WriteString(w, "  "+item.marker+" ")
+				for k, line := range item.lines {
+					if k > 0 {
+						io.WriteString(w, "    ")
+					}
+					io.WriteString(w, l.unbracket(line))
+				}
+			}
+		}
+	}
+}
+
 const (
 	ulquo = "“"
 	urquo = "”"
@@ -108,6 +188,181 @@ func escapeRegex(text string) string {
 	return markdownEscape.ReplaceAllString(text, `\$1`)
 }
 
+// A linker renders the links of the text of a comment: the links to the
+// URLs of its link definitions, and the doc links resolved by resolve.
+type linker struct {
+	defs    map[string]string // the URLs of the link definitions, by link text
+	resolve DocLinkResolver
+}
+
+// newLinker returns a linker for the link definitions of blocks, and the
+// other blocks, since link definitions are not displayed.
+func newLinker(blocks []block, resolve DocLinkResolver) (*linker, []block) {
+	l := &linker{defs: make(map[string]string), resolve: resolve}
+	var rest []block
+	for _, b := range blocks {
+		if b.op != opLinks {
+			rest = append(rest, b)
+			continue
+		}
+		for _, line := range b.lines {
+			// The first definition of a link text wins.
+			if text, url, ok := linkDef(line); ok && l.defs[text] == "" {
+				l.defs[text] = url
+			}
+		}
+	}
+	return l, rest
+}
+
+// linkDef parses a link definition such as "[Text]: https://example.com".
+func linkDef(line string) (text, url string, ok bool) {
+	line = strings.TrimSpace(line)
+	if !strings.HasPrefix(line, "[") {
+		return "", "", false
+	}
+	i := strings.Index(line, "]:")
+	if i < 0 {
+		return "", "", false
+	}
+	text, url = line[1:i], strings.TrimSpace(line[i+len("]:"):])
+	if text == "" || strings.ContainsAny(text, "[]") || url == "" || strings.ContainsAny(url, " \t") {
+		return "", "", false
+	}
+	return text, url, true
+}
+
+// link returns the URL of the link whose text, without brackets, is text.
+func (l *linker) link(text string) string {
+	if url, ok := l.defs[text]; ok {
+		return url
+	}
+	if l.resolve != nil && isDocLink(text) {
+		return l.resolve(text)
+	}
+	return ""
+}
+
+// findLink returns the bounds of the first bracketed link of line whose
+// target is known, and its URL.
+func (l *linker) findLink(line string) (i, j int, url string) {
+	for i = 0; ; {
+		k := strings.IndexByte(line[i:], '[')
+		if k < 0 {
+			return -1, -1, ""
+		}
+		i += k
+		k = strings.IndexAny(line[i+1:], "[]")
+		if k < 0 {
+			return -1, -1, ""
+		}
+		j = i + 1 + k + 1
+		if line[j-1] == ']' && j-i > 2 && linkBoundary(line[:i], true) && linkBoundary(line[j:], false) {
+			if url := l.link(line[i+1 : j-1]); url != "" {
+				return i, j, url
+			}
+		}
+		i++
+	}
+}
+
+// linkBoundary reports whether a link may end the text before it (if
+// before is set), or start the text after it: links must be separated from
+// words by spaces or punctuation.
+func linkBoundary(text string, before bool) bool {
+	var r rune
+	if before {
+		r, _ = utf8.DecodeLastRuneInString(text)
+	} else {
+		r, _ = utf8.DecodeRuneInString(text)
+	}
+	return r == utf8.RuneError || !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '_'
+}
+
+// emphasize writes line as markdown, with its links.
+func (l *linker) emphasize(w io.Writer, line string) {
+	for {
+		i, j, url := l.findLink(line)
+		if i < 0 {
+			break
+		}
+		emphasize(w, line[:i], true)
+		w.Write(mdLinkStart)
+		commentEscape(w, line[i+1:j-1], true)
+		w.Write(mdLinkDiv)
+		w.Write([]byte(urlReplacer.Replace(url)))
+		w.Write(mdLinkEnd)
+		line = line[j:]
+	}
+	emphasize(w, line, true)
+}
+
+// unbracket removes the brackets around the links of line.
+func (l *linker) unbracket(line string) string {
+	var b strings.Builder
+	for {
+		i, j, _ := l.findLink(line)
+		if i < 0 {
+			break
+		}
+		b.WriteString(line[:i])
+		b.WriteString(line[i+1 : j-1])
+		line = line[j:]
+	}
+	b.WriteString(line)
+	return b.String()
+}
+
+// isDocLink reports whether text, the text of a bracketed link, has the
+// syntax of a doc link: a package or a symbol name, qualified by a package
+// name or import path, and possibly by a type, as in "fmt", "Name",
+// "*bytes.Buffer", "io.Reader.Read" or "encoding/json.Marshal".
+func isDocLink(text string) bool {
+	_, _, ok := splitDocLink(text)
+	return ok
+}
+
+// splitDocLink splits the text of a doc link into its import path, if it
+// contains one, and its dot-separated names.
+func splitDocLink(text string) (importPath string, names []string, ok bool) {
+	text = strings.TrimPrefix(text, "*")
+	if slash := strings.LastIndex(text, "/"); slash >= 0 {
+		dot := strings.Index(text[slash:], ".")
+		if dot < 0 {
+			importPath, text = text, ""
+		} else {
+			importPath, text = text[:slash+dot], text[slash+dot+1:]
+		}
+		for _, elem := range strings.Split(importPath, "/") {
+			if elem == "" || strings.Trim(elem, "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789-._~") != "" {
+				return "", nil, false
+			}
+		}
+		if text == "" {
+			return importPath, nil, true
+		}
+	}
+	names = strings.Split(text, ".")
+	if len(names) > 3 || importPath != "" && len(names) > 2 {
+		return "", nil, false
+	}
+	for _, name := range names {
+		if !isIdentifier(name) {
+			return "", nil, false
+		}
+	}
+	return importPath, names, true
+}
+
+func isIdentifier(s string) bool {
+	for i, r := range s {
+		if !unicode.IsLetter(r) && r != '_' && (i == 0 || !unicode.IsDigit(r)) {
+			return false
+		}
+	}
+	return s != ""
+}
+
 func emphasize(w io.Writer, line string, nice bool) {
 	for {
 		m := matchRx.FindStringSubmatchIndex(line)
@@ -297,6 +552,8 @@ const (
 	opPara op = iota
 	opHead
 	opPre
+	opList  // a list, made of indented lines starting with a list marker
+	opLinks // link definitions, such as "[Text]: https://example.com"
 )
 
 type block struct {
@@ -315,7 +572,14 @@ func blocks(text string) []block {
 
 	close := func() {
 		if para != nil {
-			out = append(out, block{opPara, para})
+			op := opLinks
+			for _, line := range para {
+				if _, _, ok := linkDef(line); !ok {
+					op = opPara
+					break
+				}
+			}
+			out = append(out, block{op, para})
 			para = nil
 		}
 	}
@@ -349,12 +613,29 @@ func blocks(text string) []block {
 
 			unindent(pre)
 
-			// put those lines in a pre block
-			out = append(out, block{opPre, pre})
+			// put those lines in a list block if the first one starts
+			// with a list marker, otherwise in a pre block
+			op := opPre
+			if _, ok := listMarker(pre[0]); ok {
+				op = opList
+			}
+			out = append(out, block{op, pre})
 			lastWasHeading = false
 			continue
 		}
 
+		if (i == 0 || lastWasBlank) && (i+1 == len(lines) || isBlank(lines[i+1])) && strings.HasPrefix(line, "# ") {
+			// a line starting with "# " and surrounded by blank lines
+			// is a heading
+			if head := strings.TrimSpace(line[len("# "):]); head != "" {
+				close()
+				out = append(out, block{opHead, []string{head}})
+				i++
+				lastWasHeading = true
+				continue
+			}
+		}
+
 		if lastWasBlank && !lastWasHeading && i+2 < len(lines) &&
 			isBlank(lines[i+1]) && !isBlank(lines[i+2]) && indentLen(lines[i+2]) == 0 {
 			// current line is non-blank, surrounded by blank lines
@@ -379,3 +660,66 @@ func blocks(text string) []block {
 
 	return out
 }
+
+// listMarker returns the marker of the list item starting at line, such
+// as "-" or "1.", if any. Bullets are all returned as "-".
+func listMarker(line string) (string, bool) {
+	line = strings.TrimLeft(line, " \t")
+	if r, size := utf8.DecodeRuneInString(line); strings.ContainsRune("-*+•", r) {
+		if rest := line[size:]; rest == "" || rest[0] == ' ' || rest[0] == '\t' || rest[0] == '\n' {
+			return "-", true
+		}
+		return "", false
+	}
+	i := 0
+	for i < len(line) && '0' <= line[i] && line[i] <= '9' {
+		i++
+	}
+	if i == 0 || i > 9 || i == len(line) || line[i] != '.' && line[i] != ')' {
+		return "", false
+	}
+	if rest := line[i+1:]; rest == "" || rest[0] == ' ' || rest[0] == '\t' || rest[0] == '\n' {
+		return line[:i] + ".", true
+	}
+	return "", false
+}
+
+type listItem struct {
+	marker string
+	lines  []string // the text of the item, without the marker and indentation
+}
+
+type list struct {
+	items []listItem
+	loose bool // whether the items are separated by blank lines
+}
+
+// listItems splits the lines of a list block into items.
+func listItems(lines []string) (items list) {
+	for _, line := range lines {
+		if isBlank(line) {
+			if len(items.items) > 0 {
+				items.loose = true
+			}
+			continue
+		}
+		text := strings.TrimLeft(line, " \t")
+		if marker, ok := listMarker(text); ok {
+			_, size := utf8.DecodeRuneInString(text)
+			if marker != "-" {
+				size = len(marker)
+			}
+			text = strings.TrimLeft(text[size:], " \t")
+			if text == "" {
+				text = "\n"
+			}
+			items.items = append(items.items, listItem{marker: marker, lines: []string{text}})
+			continue
+		}
+		if len(items.items) > 0 {
+			last := &items.items[len(items.items)-1]
+			last.lines = append(last.lines, text)
+		}
+	}
+	return items
+}
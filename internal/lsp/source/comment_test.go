@@ -366,3 +366,133 @@ F declaration\.
 		}
 	}
 }
+
+func testDocLinkResolver(link string) string {
+	switch link {
+	case "fmt.Printf":
+		return "https://pkg.go.dev/fmt#Printf"
+	case "*bytes.Buffer":
+		return "https://pkg.go.dev/bytes#Buffer"
+	}
+	return ""
+}
+
+var docCommentTests = []struct {
+	in, markdown, text string
+}{
+	{
+		in: `
+F calls [fmt.Printf] on a [*bytes.Buffer], not [Unknown] or [x].
+`,
+		markdown: `
+F calls [fmt\.Printf](https://pkg.go.dev/fmt#Printf) on a [\*bytes\.Buffer](https://pkg.go.dev/bytes#Buffer), not \[Unknown\] or \[x\]\.
+`,
+		text: `
+F calls fmt.Printf on a *bytes.Buffer, not [Unknown] or [x].
+`,
+	},
+	{
+		in: `
+F follows the [RFC].
+
+[RFC]: https://example.com/rfc
+`,
+		markdown: `
+F follows the [RFC](https://example.com/rfc)\.
+`,
+		text: `
+F follows the RFC.
+`,
+	},
+	{
+		in: `
+F declaration.
+
+# Usage
+
+F does:
+  - one thing
+  - another thing,
+    described on two lines
+
+F returns:
+ 1. a result
+ 2. an error
+`,
+		markdown: `
+F declaration\.
+
+### Usage
+
+F does\:
+
+- one thing
+- another thing,
+  described on two lines
+
+F returns\:
+
+1. a result
+2. an error
+`,
+		text: `
+F declaration.
+
+Usage
+
+F does:
+
+  - one thing
+  - another thing,
+    described on two lines
+
+F returns:
+
+  1. a result
+  2. an error
+`,
+	},
+	{
+		in: `
+F declaration.
+
+	func foo() {
+		fmt.Println("foo")
+	}
+`,
+		markdown: `
+F declaration\.
+
+    func foo() {
+    	fmt.Println("foo")
+    }
+`,
+		text: `
+F declaration.
+
+	func foo() {
+		fmt.Println("foo")
+	}
+`,
+	},
+}
+
+func TestDocCommentToMarkdown(t *testing.T) {
+	for i, tt := range docCommentTests {
+		in := strings.TrimPrefix(tt.in, "\n")
+		want := strings.TrimPrefix(tt.markdown, "\n")
+		if out := DocCommentToMarkdown(in, testDocLinkResolver); out != want {
+			t.Errorf("#%d: mismatch\nhave: %q\nwant: %q", i, out, want)
+		}
+	}
+}
+
+func TestDocCommentToText(t *testing.T) {
+	for i, tt := range docCommentTests {
+		in := strings.TrimPrefix(tt.in, "\n")
+		want := strings.TrimPrefix(tt.text, "\n")
+		if out := DocCommentToText(in, testDocLinkResolver); out != want {
+			t.Errorf("#%d: mismatch\nhave: %q\nwant: %q", i, out, want)
+		}
+	}
+}
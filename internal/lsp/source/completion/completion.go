@@ -67,6 +67,11 @@ type CompletionItem struct {
 	// A higher score indicates that this completion item is more relevant.
 	Score float64
 
+	// Symbol identifies the symbol of the item in the ranking signals, if
+	// completions are ranked by them, so that its accept can be recorded
+	// with Accept.
+	Symbol string
+
 	// snippet is the LSP snippet for the completion item. The LSP
 	// specification contains details about LSP snippets. For example, a
 	// snippet for a function with the following signature:
@@ -102,6 +107,18 @@ type completionOptions struct {
 	postfix           bool
 	matcher           source.Matcher
 	budget            time.Duration
+
+	// unimportedSymbolsBudget bounds the search for the exported symbols
+	// of unimported packages, which is disabled if it is zero.
+	unimportedSymbolsBudget time.Duration
+
+	// ranking enables the ranking of candidates by the usage of their
+	// symbols in the workspace and the completions accepted by the user.
+	ranking bool
+
+	// resultAssignments enables the assignment of the results of calls
+	// completed as statements to new variables.
+	resultAssignments bool
 }
 
 // Snippet is a convenience returns the snippet if available, otherwise
@@ -211,6 +228,9 @@ type completer struct {
 	// matcher matches the candidates against the surrounding prefix.
 	matcher matcher
 
+	// ranking holds the ranking signals of the candidates, if enabled.
+	ranking *ranking
+
 	// methodSetCache caches the types.NewMethodSet call, which is relatively
 	// expensive and can be called many times for the same type while searching
 	// for deep completions.
@@ -223,6 +243,10 @@ type completer struct {
 	// not include any time the request spent in the queue.
 	startTime time.Time
 
+	// goVersion is the minor version of the go directive of the module of
+	// the file, or 0 if it is unknown.
+	goVersion int
+
 	// scopes contains all scopes defined by nodes in our path,
 	// including nil values for nodes that don't defined a scope. It
 	// also includes our package scope and the universal scope at the
@@ -532,6 +556,10 @@ func Completion(ctx context.Context, snapshot source.Snapshot, fh source.FileHan
 			budget:            opts.CompletionBudget,
 			snippets:          opts.InsertTextFormat == protocol.SnippetTextFormat,
 			postfix:           opts.ExperimentalPostfixCompletions,
+
+			unimportedSymbolsBudget: opts.ExperimentalUnimportedSymbolsBudget,
+			ranking:                 opts.ExperimentalCompletionRanking,
+			resultAssignments:       opts.ExperimentalResultAssignments,
 		},
 		// default to a matcher that always matches
 		matcher:        prefixMatcher(""),
@@ -539,6 +567,7 @@ func Completion(ctx context.Context, snapshot source.Snapshot, fh source.FileHan
 		mapper:         pgf.Mapper,
 		startTime:      startTime,
 		scopes:         scopes,
+		goVersion:      source.ModuleGoVersion(ctx, snapshot, fh.URI()),
 	}
 
 	var cancel context.CancelFunc
@@ -555,6 +584,10 @@ func Completion(ctx context.Context, snapshot source.Snapshot, fh source.FileHan
 	}
 	defer cancel()
 
+	if c.opts.ranking {
+		c.ranking = newRanking(ctx, snapshot)
+	}
+
 	if surrounding := c.containingIdent(pgf.Src); surrounding != nil {
 		c.setSurrounding(surrounding)
 	}
@@ -599,6 +632,12 @@ func (c *completer) collectCompletions(ctx context.Context) error {
 		return c.populateImportCompletions(ctx, importSpec)
 	}
 
+	// Inside //go:embed directives, offer completions for embeddable files.
+	if directive := c.embedDirective(); directive != nil {
+		c.embedCompletions(directive)
+		return nil
+	}
+
 	// Inside comments, offer completions for the name of the relevant symbol.
 	for _, comment := range c.file.Comments {
 		if comment.Pos() < c.pos && c.pos <= comment.End() {
@@ -1077,6 +1116,7 @@ func (c *completer) wantTypeName() bool {
 const (
 	maxUnimportedPackageNames = 5
 	unimportedMemberTarget    = 100
+	unimportedSymbolTarget    = 100
 )
 
 // selector finds completions for the specified selector expression.
@@ -1387,6 +1427,9 @@ func (c *completer) lexical(ctx context.Context) error {
 		if err := c.unimportedPackages(ctx, seen); err != nil {
 			return err
 		}
+		if err := c.unimportedSymbols(ctx, seen); err != nil {
+			return err
+		}
 	}
 
 	if c.inference.typeName.isTypeParam {
@@ -1558,6 +1601,72 @@ func (c *completer) unimportedPackages(ctx context.Context, seen map[string]stru
 	return nil
 }
 
+// unimportedSymbols adds candidates for the exported symbols of unimported
+// packages matching the surrounding prefix, qualified by their package name,
+// as in "rand.Seed" for "Seed". Their package is imported when they are
+// selected.
+func (c *completer) unimportedSymbols(ctx context.Context, seen map[string]struct{}) error {
+	if c.opts.unimportedSymbolsBudget == 0 || c.surrounding == nil {
+		return nil
+	}
+	// The candidates are untyped, as their kind and type are unknown
+	// without type-checking their package, so they can never match a
+	// position that wants a type name or a value of a known type.
+	if c.wantTypeName() {
+		return nil
+	}
+	if t := c.inference.objType; t != nil && !isEmptyInterface(t) {
+		return nil
+	}
+	// As with unimported packages, don't search with nothing to go on.
+	prefix := c.surrounding.Prefix()
+	if prefix == "" {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, c.opts.unimportedSymbolsBudget)
+
+	count := 0
+	var mu sync.Mutex
+	add := func(pkgExport imports.PackageExport) {
+		mu.Lock()
+		defer mu.Unlock()
+		fix := pkgExport.Fix
+		// Skip the packages whose name is already in use, including the
+		// imported ones, whose symbols are found by deep completion.
+		if _, ok := seen[fix.IdentName]; ok || alreadyImports(c.file, fix.StmtInfo.ImportPath) {
+			return
+		}
+
+		pkg := types.NewPackage(fix.StmtInfo.ImportPath, fix.IdentName)
+		path := []types.Object{types.NewPkgName(0, nil, fix.IdentName, pkg)}
+		imp := &importInfo{
+			importPath: fix.StmtInfo.ImportPath,
+			name:       fix.StmtInfo.Name,
+		}
+		for _, export := range pkgExport.Exports {
+			if count >= unimportedSymbolTarget {
+				cancel()
+				return
+			}
+			// Like the members of unimported packages, the candidates are
+			// untyped.
+			c.deepState.enqueue(candidate{
+				obj:   types.NewVar(0, pkg, export, nil),
+				score: unimportedScore(fix.Relevance),
+				imp:   imp,
+				path:  path,
+			})
+			count++
+		}
+	}
+	c.completionCallbacks = append(c.completionCallbacks, func(opts *imports.Options) error {
+		defer cancel()
+		return imports.GetExportsWithPrefix(ctx, add, prefix, c.filename, c.pkg.GetTypes().Name(), opts.Env)
+	})
+	return nil
+}
+
 // alreadyImports reports whether f has an import with the specified path.
 func alreadyImports(f *ast.File, path string) bool {
 	for _, s := range f.Imports {
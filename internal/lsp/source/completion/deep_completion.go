@@ -9,6 +9,8 @@ import (
 	"go/types"
 	"strings"
 	"time"
+
+	"github.com/iansmith/golang-x-tools/internal/lsp/source"
 )
 
 // MaxDeepCompletions limits deep completion results because in most cases
@@ -274,6 +276,17 @@ func (c *completer) addCandidate(ctx context.Context, cand *candidate) {
 		cand.score *= 0.9
 	}
 
+	// Favor the symbols used often in the workspace or accepted recently.
+	if c.ranking != nil {
+		cand.score *= c.ranking.boost(obj)
+	}
+
+	// Demote the symbols of the standard library that the Go version of the
+	// module lacks, as building it with this version fails.
+	if source.NewerStdSymbol(obj, c.goVersion) > 0 {
+		cand.score *= lowScore
+	}
+
 	// Favor shallow matches by lowering score according to depth.
 	cand.score -= cand.score * c.deepState.scorePenalty(cand)
 
@@ -283,6 +296,9 @@ func (c *completer) addCandidate(ctx context.Context, cand *candidate) {
 
 	cand.name = deepCandName(cand)
 	if item, err := c.item(ctx, *cand); err == nil {
+		if c.ranking != nil {
+			item.Symbol = symbolKey(obj)
+		}
 		c.items = append(c.items, item)
 	}
 }
@@ -0,0 +1,124 @@
+// Copyright 2022 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package completion
+
+import (
+	"go/ast"
+	"go/token"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/iansmith/golang-x-tools/internal/lsp/protocol"
+	"github.com/iansmith/golang-x-tools/internal/lsp/source"
+	"github.com/iansmith/golang-x-tools/internal/span"
+)
+
+// embedDirective returns the //go:embed directive enclosing the cursor, if
+// any.
+func (c *completer) embedDirective() *ast.Comment {
+	for _, group := range c.file.Comments {
+		if c.pos <= group.Pos() || c.pos > group.End() {
+			continue
+		}
+		for _, comment := range group.List {
+			if comment.Pos() < c.pos && c.pos <= comment.End() && source.IsEmbedDirective(comment) {
+				return comment
+			}
+		}
+	}
+	return nil
+}
+
+// embedCompletions yields completions for the files and directories that
+// may be embedded by the //go:embed directive around the cursor.
+//
+// As with import paths, completions are suggested one path element at a
+// time: directories are suggested with a trailing slash so that the user can
+// keep triggering completions until they reach the file they want.
+func (c *completer) embedCompletions(directive *ast.Comment) {
+	// Don't complete inside the "//go:embed" prefix itself.
+	if c.pos <= directive.Pos()+token.Pos(len("//go:embed")) {
+		return
+	}
+	offset := func(pos token.Pos) int { return int(pos - directive.Pos()) }
+
+	// Find the part of the pattern before the cursor. If the cursor is not
+	// within a pattern, we are starting a new one.
+	var prefix string
+	for _, p := range source.EmbedPatterns(directive) {
+		if !(p.Pos <= c.pos && c.pos <= p.End) {
+			continue
+		}
+		if p.Quoted {
+			lit := directive.Text[offset(p.Pos):offset(p.End)]
+			if c.pos == p.Pos || (c.pos == p.End && len(lit) > 1 && lit[len(lit)-1] == lit[0]) {
+				// The cursor is outside of the quotes.
+				return
+			}
+			prefix = directive.Text[offset(p.Pos)+1 : offset(c.pos)]
+		} else {
+			prefix = directive.Text[offset(p.Pos):offset(c.pos)]
+		}
+		break
+	}
+	prefix = strings.TrimPrefix(prefix, "all:")
+	if strings.ContainsAny(prefix, `*?[\`) {
+		// Don't try to complete glob patterns.
+		return
+	}
+
+	// Only the last path element of the prefix is replaced by a completion.
+	dir, name := "", prefix
+	if i := strings.LastIndex(prefix, "/"); i >= 0 {
+		dir, name = prefix[:i+1], prefix[i+1:]
+	}
+	start, end := c.pos-token.Pos(len(name)), c.pos
+	for end < directive.End() && !strings.ContainsRune(" \t\"`/", rune(directive.Text[offset(end)])) {
+		end++
+	}
+	c.surrounding = &Selection{
+		content: directive.Text[offset(start):offset(end)],
+		cursor:  c.pos,
+		rng:     span.NewRange(c.snapshot.FileSet(), start, end),
+	}
+	c.setMatcherFromPrefix(name)
+
+	// deepSearch is not valuable for file completions.
+	c.deepState.enabled = false
+
+	pkgDir := filepath.Dir(c.filename)
+	entries, err := os.ReadDir(filepath.Join(pkgDir, filepath.FromSlash(dir)))
+	if err != nil {
+		return
+	}
+	for _, entry := range entries {
+		filename := entry.Name()
+		// Hidden files are only suggested if the user asks for them.
+		if strings.HasPrefix(filename, ".") && !strings.HasPrefix(name, ".") {
+			continue
+		}
+		score := c.matcher.Score(filename)
+		if score <= 0 {
+			continue
+		}
+		item := CompletionItem{
+			Label:      filename,
+			InsertText: filename,
+			Kind:       protocol.FileCompletion,
+			Score:      stdScore * float64(score),
+		}
+		if entry.IsDir() {
+			// Directories containing a module cannot be embedded.
+			if _, err := os.Stat(filepath.Join(pkgDir, filepath.FromSlash(dir), filename, "go.mod")); err == nil {
+				continue
+			}
+			item.Label += "/"
+			item.InsertText += "/"
+			item.Kind = protocol.FolderCompletion
+		}
+		c.items = append(c.items, item)
+	}
+}
@@ -69,6 +69,31 @@ func (c *completer) item(ctx context.Context, cand candidate) (CompletionItem, e
 		}
 	}
 
+	// Assign the results of a call completed as a statement to new
+	// variables, as in "f, err := os.Open(name)". In a selector, the "x."
+	// part is moved after the assignment.
+	if names, sel := c.resultAssignment(cand); names != nil {
+		if sel != nil {
+			edits, err := c.editText(sel.Pos(), sel.Sel.Pos(), "")
+			if err != nil {
+				return CompletionItem{}, err
+			}
+			protocolEdits = append(protocolEdits, edits...)
+		}
+		for i, name := range names {
+			if i > 0 {
+				snip.WriteText(", ")
+			}
+			snip.WritePlaceholder(func(b *snippet.Builder) {
+				b.WriteText(name)
+			})
+		}
+		snip.WriteText(" := ")
+		if sel != nil {
+			snip.WriteText(source.FormatNode(c.snapshot.FileSet(), sel.X) + ".")
+		}
+	}
+
 	snip.WriteText(insert)
 
 	switch obj := obj.(type) {
@@ -158,7 +183,7 @@ Suffixes:
 	// If this candidate needs an additional import statement,
 	// add the additional text edits needed.
 	if cand.imp != nil {
-		addlEdits, err := c.importEdits(cand.imp)
+		addlEdits, err := c.importEdits(ctx, cand.imp)
 
 		if err != nil {
 			return CompletionItem{}, err
@@ -212,6 +237,9 @@ Suffixes:
 	if cand.detail != "" {
 		detail = cand.detail
 	}
+	if v := source.NewerStdSymbol(obj, c.goVersion); v > 0 {
+		detail = strings.TrimSpace(fmt.Sprintf("%s (requires go1.%d)", detail, v))
+	}
 	item := CompletionItem{
 		Label:               label,
 		InsertText:          insert,
@@ -251,10 +279,11 @@ Suffixes:
 		event.Error(ctx, "failed to find Hover", err, tag.URI.Of(uri))
 		return item, nil
 	}
+	text := source.DocCommentToText(hover.Comment.Text(), source.NewDocLinkResolver(ctx, c.snapshot, pkg, obj))
 	if c.opts.fullDocumentation {
-		item.Documentation = hover.Comment.Text()
+		item.Documentation = text
 	} else {
-		item.Documentation = doc.Synopsis(hover.Comment.Text())
+		item.Documentation = doc.Synopsis(text)
 	}
 	// The desired pattern is `^// Deprecated`, but the prefix has been removed
 	if strings.HasPrefix(hover.Comment.Text(), "Deprecated") {
@@ -269,7 +298,7 @@ Suffixes:
 }
 
 // importEdits produces the text edits necessary to add the given import to the current file.
-func (c *completer) importEdits(imp *importInfo) ([]protocol.TextEdit, error) {
+func (c *completer) importEdits(ctx context.Context, imp *importInfo) ([]protocol.TextEdit, error) {
 	if imp == nil {
 		return nil, nil
 	}
@@ -279,7 +308,7 @@ func (c *completer) importEdits(imp *importInfo) ([]protocol.TextEdit, error) {
 		return nil, err
 	}
 
-	return source.ComputeOneImportFixEdits(c.snapshot, pgf, &imports.ImportFix{
+	return source.ComputeOneImportFixEdits(ctx, c.snapshot, pgf, &imports.ImportFix{
 		StmtInfo: imports.ImportInfo{
 			ImportPath: imp.importPath,
 			Name:       imp.name,
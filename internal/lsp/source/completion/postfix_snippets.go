@@ -66,6 +66,7 @@ type postfixTmplArgs struct {
 	// Type is the type of "foo.bar" in "foo.bar.print!".
 	Type types.Type
 
+	sig            *types.Signature // of the enclosing function, if any
 	scope          *types.Scope
 	snip           snippet.Builder
 	importIfNeeded func(pkgPath string, scope *types.Scope) (name string, edits []protocol.TextEdit, err error)
@@ -105,6 +106,14 @@ for {{.VarName nil "i"}}, {{.VarName .ElemType "v"}} := range {{.X}} {
 	{{.Cursor}}
 }
 {{- end}}`,
+}, {
+	label:   "for",
+	details: "loop over slice indices",
+	body: `{{if and (eq .Kind "slice") .StmtOK -}}
+for {{.VarName nil "i"}} := range {{.X}} {
+	{{.Cursor}}
+}
+{{- end}}`,
 }, {
 	label:   "append",
 	details: "append and re-assign slice",
@@ -132,6 +141,14 @@ for {{.VarName .KeyType "k"}}, {{.VarName .ElemType "v"}} := range {{.X}} {
 	{{.Cursor}}
 }
 {{- end}}`,
+}, {
+	label:   "for",
+	details: "loop over map keys",
+	body: `{{if and (eq .Kind "map") .StmtOK -}}
+for {{.VarName .KeyType "k"}} := range {{.X}} {
+	{{.Cursor}}
+}
+{{- end}}`,
 }, {
 	label:   "clear",
 	details: "clear map contents",
@@ -149,6 +166,30 @@ for {{.VarName .KeyType "k"}}, {{.VarName .ElemType "v"}} := range {{.X}} {
 	{{$keysVar}} = append({{$keysVar}}, {{$k}})
 }
 {{end}}`,
+}, {
+	label:   "range",
+	details: "range over channel",
+	body: `{{if and (eq .Kind "chan") .StmtOK -}}
+for {{.VarName .ElemType "e"}} := range {{.X}} {
+	{{.Cursor}}
+}
+{{- end}}`,
+}, {
+	label:   "if",
+	details: "if condition",
+	body: `{{if and (eq (.TypeName .Type) "bool") .StmtOK -}}
+if {{.X}} {
+	{{.Cursor}}
+}
+{{- end}}`,
+}, {
+	label:   "wrap",
+	details: "wrap and return error",
+	body: `{{if and .StmtOK .Obj .IsError .ReturnsError -}}
+if {{.X}} != nil {
+	return {{.ZeroResults}}{{.Import "fmt"}}.Errorf("{{.Cursor}}: %w", {{.X}})
+}
+{{- end}}`,
 }, {
 	label:   "var",
 	details: "assign to variables",
@@ -244,6 +285,41 @@ func (a *postfixTmplArgs) Tuple() []*types.Var {
 	return typs
 }
 
+// IsError reports whether X is an error.
+func (a *postfixTmplArgs) IsError() bool {
+	return types.Implements(a.Type, errorIntf)
+}
+
+// ReturnsError reports whether the final result of the enclosing
+// function is an error.
+func (a *postfixTmplArgs) ReturnsError() bool {
+	if a.sig == nil || a.sig.Results().Len() == 0 {
+		return false
+	}
+	results := a.sig.Results()
+	return types.Identical(results.At(results.Len()-1).Type(), types.Universe.Lookup("error").Type())
+}
+
+// ZeroResults returns the zero values of all but the final result of
+// the enclosing function, each followed by ", ", for use in a return
+// statement that returns an error.
+func (a *postfixTmplArgs) ZeroResults() (string, error) {
+	if !a.ReturnsError() {
+		return "", fmt.Errorf("enclosing function does not return an error")
+	}
+	var b strings.Builder
+	results := a.sig.Results()
+	for i := 0; i < results.Len()-1; i++ {
+		zero := formatZeroValue(results.At(i).Type(), a.qf)
+		if zero == "" {
+			return "", fmt.Errorf("no zero value for %v", results.At(i).Type())
+		}
+		b.WriteString(zero)
+		b.WriteString(", ")
+	}
+	return b.String(), nil
+}
+
 // TypeName returns the textual representation of type t.
 func (a *postfixTmplArgs) TypeName(t types.Type) (string, error) {
 	if t == nil || t == types.Typ[types.Invalid] {
@@ -258,6 +334,15 @@ func (a *postfixTmplArgs) TypeName(t types.Type) (string, error) {
 // abbreviating the type name. If the resultant name is already in
 // scope, an integer is appended to make a unique name.
 func (a *postfixTmplArgs) VarName(t types.Type, nonNamedDefault string) string {
+	name := uniqueVarName(a.scope, a.varNames, typeVarName(t, nonNamedDefault))
+	a.varNames[name] = true
+	return name
+}
+
+// typeVarName returns a name for a variable of type t: "err" for errors,
+// an abbreviation of the name of named types, and nonNamedDefault
+// otherwise.
+func typeVarName(t types.Type, nonNamedDefault string) string {
 	if t == nil {
 		t = types.Typ[types.Invalid]
 	}
@@ -279,17 +364,19 @@ func (a *postfixTmplArgs) VarName(t types.Type, nonNamedDefault string) string {
 	if dot := strings.LastIndex(name, "."); dot > -1 {
 		name = name[dot+1:]
 	}
+	return name
+}
 
+// uniqueVarName returns name, with a numeric suffix if needed so that it
+// is neither declared in scope nor among used.
+func uniqueVarName(scope *types.Scope, used map[string]bool, name string) string {
 	uniqueName := name
 	for i := 2; ; i++ {
-		if s, _ := a.scope.LookupParent(uniqueName, token.NoPos); s == nil && !a.varNames[uniqueName] {
+		if s, _ := scope.LookupParent(uniqueName, token.NoPos); s == nil && !used[uniqueName] {
 			break
 		}
 		uniqueName = fmt.Sprintf("%s%d", name, i)
 	}
-
-	a.varNames[uniqueName] = true
-
 	return uniqueName
 }
 
@@ -342,6 +429,15 @@ func (c *completer) addPostfixSnippetCandidates(ctx context.Context, sel *ast.Se
 		return
 	}
 
+	importIfNeeded := func(pkgPath string, scope *types.Scope) (string, []protocol.TextEdit, error) {
+		return c.importIfNeeded(ctx, pkgPath, scope)
+	}
+
+	var sig *types.Signature
+	if c.enclosingFunc != nil {
+		sig = c.enclosingFunc.sig
+	}
+
 	// afterDot is the position after selector dot, e.g. "|" in
 	// "foo.|print".
 	afterDot := sel.Sel.Pos()
@@ -376,7 +472,8 @@ func (c *completer) addPostfixSnippetCandidates(ctx context.Context, sel *ast.Se
 			Obj:            exprObj(c.pkg.GetTypesInfo(), sel.X),
 			Type:           selType,
 			qf:             c.qf,
-			importIfNeeded: c.importIfNeeded,
+			importIfNeeded: importIfNeeded,
+			sig:            sig,
 			scope:          scope,
 			varNames:       make(map[string]bool),
 		}
@@ -429,7 +526,7 @@ func initPostfixRules() {
 
 // importIfNeeded returns the package identifier and any necessary
 // edits to import package pkgPath.
-func (c *completer) importIfNeeded(pkgPath string, scope *types.Scope) (string, []protocol.TextEdit, error) {
+func (c *completer) importIfNeeded(ctx context.Context, pkgPath string, scope *types.Scope) (string, []protocol.TextEdit, error) {
 	defaultName := imports.ImportPathToAssumedName(pkgPath)
 
 	// Check if file already imports pkgPath.
@@ -449,7 +546,7 @@ func (c *completer) importIfNeeded(pkgPath string, scope *types.Scope) (string,
 		return "", nil, fmt.Errorf("import name %q of %q already in use", defaultName, pkgPath)
 	}
 
-	edits, err := c.importEdits(&importInfo{
+	edits, err := c.importEdits(ctx, &importInfo{
 		importPath: pkgPath,
 	})
 	if err != nil {
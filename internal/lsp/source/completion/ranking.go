@@ -0,0 +1,285 @@
+// Copyright 2022 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package completion
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"go/types"
+	"math"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/iansmith/golang-x-tools/internal/event"
+	"github.com/iansmith/golang-x-tools/internal/lsp/filecache"
+	"github.com/iansmith/golang-x-tools/internal/lsp/source"
+)
+
+// The weights of the ranking signals: a candidate's score is increased by
+// at most usesWeight for the symbols used the most in the workspace, and by
+// at most acceptWeight for the completions accepted the most recently.
+const (
+	usesWeight   = 0.1
+	acceptWeight = 0.2
+)
+
+// A ranking holds the signals, other than the context of the completion,
+// that rank candidates: how often the workspace uses their symbols, and
+// how recently the user accepted them.
+type ranking struct {
+	uses    map[string]int // by symbol key
+	maxUses int
+	now     time.Time
+}
+
+// newRanking returns the ranking of the completions in snapshot. The usage
+// signal is omitted if the workspace packages aren't available in time.
+func newRanking(ctx context.Context, snapshot source.Snapshot) *ranking {
+	r := &ranking{now: time.Now()}
+	uses, err := workspaceUses.get(ctx, snapshot)
+	if err != nil {
+		event.Error(ctx, "computing symbol uses for completion ranking", err)
+	}
+	r.uses = uses
+	for _, n := range uses {
+		if n > r.maxUses {
+			r.maxUses = n
+		}
+	}
+	acceptHistory.load(ctx)
+	return r
+}
+
+// boost returns the factor by which the ranking increases the score of a
+// candidate for obj.
+func (r *ranking) boost(obj types.Object) float64 {
+	key := symbolKey(obj)
+	if key == "" {
+		return 1
+	}
+	factor := 1.0
+	if n := r.uses[key]; n > 0 {
+		factor += usesWeight * math.Log1p(float64(n)) / math.Log1p(float64(r.maxUses))
+	}
+	if n := acceptHistory.count(key, r.now); n > 0 {
+		// 1 - 2^-n tends to 1 as accepts add up.
+		factor += acceptWeight * (1 - math.Exp2(-n))
+	}
+	return factor
+}
+
+// symbolKey returns the key of obj in the ranking signals, which is the
+// same in all packages and sessions, or "" if obj isn't ranked. Package
+// members are keyed by their package path and name, and methods by that of
+// their receiver type too. Fields, whose struct is unknown, are keyed by
+// their package path and name, with a "#" before the name.
+func symbolKey(obj types.Object) string {
+	if obj == nil || obj.Pkg() == nil {
+		return ""
+	}
+	switch obj := obj.(type) {
+	case *types.Func:
+		return obj.FullName()
+	case *types.Var:
+		if obj.IsField() {
+			return obj.Pkg().Path() + ".#" + obj.Name()
+		}
+	case *types.PkgName, *types.Label:
+		return ""
+	}
+	if obj.Parent() != obj.Pkg().Scope() {
+		return "" // a local object
+	}
+	return obj.Pkg().Path() + "." + obj.Name()
+}
+
+// workspaceUses holds the counts of the uses of symbols by the workspace
+// packages.
+var workspaceUses = &usesIndex{}
+
+// A usesIndex counts the uses of symbols by packages, which it updates as
+// packages change.
+type usesIndex struct {
+	mu    sync.Mutex
+	pkgs  map[string]*packageUses // by package ID
+	total map[string]int
+}
+
+type packageUses struct {
+	pkg  source.Package // the package whose uses are counted
+	uses map[string]int
+}
+
+// get returns the counts of the uses of symbols by the workspace packages
+// of snapshot, by symbol key. The result must not be modified.
+func (x *usesIndex) get(ctx context.Context, snapshot source.Snapshot) (map[string]int, error) {
+	pkgs, err := snapshot.ActivePackages(ctx)
+	if err != nil {
+		return nil, err
+	}
+	x.mu.Lock()
+	defer x.mu.Unlock()
+
+	changed := x.total == nil || len(pkgs) != len(x.pkgs)
+	ids := make(map[string]bool, len(pkgs))
+	for _, pkg := range pkgs {
+		ids[pkg.ID()] = true
+		if p := x.pkgs[pkg.ID()]; p != nil && p.pkg == pkg {
+			continue
+		}
+		if x.pkgs == nil {
+			x.pkgs = make(map[string]*packageUses)
+		}
+		x.pkgs[pkg.ID()] = &packageUses{pkg: pkg, uses: countUses(pkg)}
+		changed = true
+	}
+	for id := range x.pkgs {
+		if !ids[id] {
+			delete(x.pkgs, id)
+			changed = true
+		}
+	}
+	if changed {
+		x.total = make(map[string]int)
+		for _, p := range x.pkgs {
+			for key, n := range p.uses {
+				x.total[key] += n
+			}
+		}
+	}
+	return x.total, nil
+}
+
+// countUses returns the counts of the uses of symbols by pkg, by symbol key.
+func countUses(pkg source.Package) map[string]int {
+	uses := make(map[string]int)
+	for _, obj := range pkg.GetTypesInfo().Uses {
+		if key := symbolKey(obj); key != "" {
+			uses[key]++
+		}
+	}
+	return uses
+}
+
+// acceptHistory holds the completions accepted by the user, which persist
+// in the file cache.
+var acceptHistory = &history{}
+
+// maxHistory is the number of symbols whose accepts are remembered.
+const maxHistory = 1000
+
+// acceptHalfLife is the time after which an accept counts half.
+const acceptHalfLife = 7 * 24 * time.Hour
+
+// A history counts the accepts of completions, by symbol key, with counts
+// that decay over time so that recent accepts weigh more.
+type history struct {
+	mu      sync.Mutex
+	loaded  bool
+	accepts map[string]acceptCount
+}
+
+// An acceptCount is the count of the accepts of a symbol at a time.
+type acceptCount struct {
+	N    float64   `json:"n"`
+	Time time.Time `json:"time"`
+}
+
+// decayed returns the value of c at now.
+func (c acceptCount) decayed(now time.Time) float64 {
+	age := now.Sub(c.Time)
+	if age <= 0 {
+		return c.N
+	}
+	return c.N * math.Exp2(-float64(age)/float64(acceptHalfLife))
+}
+
+// count returns the decayed count of the accepts of key at now.
+func (h *history) count(key string, now time.Time) float64 {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.accepts[key].decayed(now)
+}
+
+// add records an accept of key at now, forgetting the symbols with the
+// lowest counts beyond maxHistory.
+func (h *history) add(key string, now time.Time) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.accepts == nil {
+		h.accepts = make(map[string]acceptCount)
+	}
+	h.accepts[key] = acceptCount{N: h.accepts[key].decayed(now) + 1, Time: now}
+	if len(h.accepts) <= maxHistory {
+		return
+	}
+	keys := make([]string, 0, len(h.accepts))
+	for k := range h.accepts {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		return h.accepts[keys[i]].decayed(now) > h.accepts[keys[j]].decayed(now)
+	})
+	for _, k := range keys[maxHistory:] {
+		delete(h.accepts, k)
+	}
+}
+
+// historyKind and historyKey locate the history in the file cache.
+const historyKind = "completion-history-v1"
+
+var historyKey = sha256.Sum256([]byte("accepted completions"))
+
+// load reads the history from the file cache, the first time it is called.
+func (h *history) load(ctx context.Context) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.loaded {
+		return
+	}
+	h.loaded = true
+	data, err := filecache.Get(historyKind, historyKey)
+	if err != nil {
+		if !errors.Is(err, filecache.ErrNotFound) {
+			event.Error(ctx, "reading the completion history", err)
+		}
+		return
+	}
+	var accepts map[string]acceptCount
+	if err := json.Unmarshal(data, &accepts); err != nil {
+		event.Error(ctx, "decoding the completion history", err)
+		return
+	}
+	if accepts == nil {
+		accepts = make(map[string]acceptCount)
+	}
+	for k, c := range h.accepts {
+		accepts[k] = c // accepted before loading
+	}
+	h.accepts = accepts
+}
+
+// save writes the history to the file cache.
+func (h *history) save() error {
+	h.mu.Lock()
+	data, err := json.Marshal(h.accepts)
+	h.mu.Unlock()
+	if err != nil {
+		return err
+	}
+	return filecache.Set(historyKind, historyKey, data)
+}
+
+// Accept records that the user accepted a completion of the symbol with
+// the given key, reported by CompletionItem.Symbol, so that the symbol
+// ranks higher in later completions.
+func Accept(ctx context.Context, symbol string) error {
+	acceptHistory.load(ctx)
+	acceptHistory.add(symbol, time.Now())
+	return acceptHistory.save()
+}
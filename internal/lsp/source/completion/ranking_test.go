@@ -0,0 +1,129 @@
+// Copyright 2022 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package completion
+
+import (
+	"fmt"
+	"go/ast"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"math"
+	"testing"
+	"time"
+)
+
+func TestSymbolKey(t *testing.T) {
+	const src = `package p
+
+type T struct{ F int }
+
+func (T) M()   {}
+func (*T) PM() {}
+
+var V int
+
+func F() {
+	var local int
+	_ = local
+}
+`
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, "p.go", src, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	conf := types.Config{Importer: importer.Default()}
+	pkg, err := conf.Check("example.com/p", fset, []*ast.File{f}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	typ := pkg.Scope().Lookup("T")
+	mset := types.NewMethodSet(types.NewPointer(typ.Type()))
+	local := pkg.Scope().Lookup("F").(*types.Func).Scope().Lookup("local")
+	tests := []struct {
+		obj  types.Object
+		want string
+	}{
+		{typ, "example.com/p.T"},
+		{pkg.Scope().Lookup("V"), "example.com/p.V"},
+		{pkg.Scope().Lookup("F"), "example.com/p.F"},
+		{typ.Type().Underlying().(*types.Struct).Field(0), "example.com/p.#F"},
+		{mset.Lookup(pkg, "M").Obj(), "(example.com/p.T).M"},
+		{mset.Lookup(pkg, "PM").Obj(), "(*example.com/p.T).PM"},
+		{local, ""},
+		{types.Universe.Lookup("len"), ""},
+	}
+	for _, test := range tests {
+		if got := symbolKey(test.obj); got != test.want {
+			t.Errorf("symbolKey(%v) = %q, want %q", test.obj, got, test.want)
+		}
+	}
+}
+
+func TestHistory(t *testing.T) {
+	now := time.Date(2022, 6, 1, 0, 0, 0, 0, time.UTC)
+	var h history
+	h.add("a", now)
+	h.add("a", now)
+	h.add("b", now.Add(-acceptHalfLife))
+
+	if got := h.count("a", now); got != 2 {
+		t.Errorf("count of a = %v, want 2", got)
+	}
+	// The accept of b counts half after a half-life.
+	if got := h.count("b", now); math.Abs(got-0.5) > 1e-9 {
+		t.Errorf("count of b = %v, want 0.5", got)
+	}
+	if got := h.count("a", now.Add(2*acceptHalfLife)); math.Abs(got-0.5) > 1e-9 {
+		t.Errorf("count of a after two half-lives = %v, want 0.5", got)
+	}
+	if got := h.count("c", now); got != 0 {
+		t.Errorf("count of c = %v, want 0", got)
+	}
+
+	// Beyond maxHistory symbols, the least accepted ones are forgotten.
+	for i := 0; i < maxHistory; i++ {
+		h.add(fmt.Sprint("sym", i), now)
+	}
+	if len(h.accepts) != maxHistory {
+		t.Errorf("len(accepts) = %d, want %d", len(h.accepts), maxHistory)
+	}
+	if got := h.count("a", now); got != 2 {
+		t.Errorf("count of a = %v, want 2", got)
+	}
+	if got := h.count("b", now); got != 0 {
+		t.Errorf("count of b = %v, want 0 after it is forgotten", got)
+	}
+}
+
+func TestRankingBoost(t *testing.T) {
+	pkg := types.NewPackage("example.com/p", "p")
+	obj := func(name string) types.Object {
+		obj := types.NewVar(token.NoPos, pkg, name, types.Typ[types.Int])
+		pkg.Scope().Insert(obj)
+		return obj
+	}
+	unused, used, mostUsed := obj("Unused"), obj("Used"), obj("MostUsed")
+	r := &ranking{
+		uses: map[string]int{
+			"example.com/p.Used":     3,
+			"example.com/p.MostUsed": 100,
+		},
+		maxUses: 100,
+		now:     time.Now(),
+	}
+	if got := r.boost(unused); got != 1 {
+		t.Errorf("boost of an unused symbol = %v, want 1", got)
+	}
+	b1, b2 := r.boost(used), r.boost(mostUsed)
+	if !(1 < b1 && b1 < b2) {
+		t.Errorf("boosts of used and most used symbols = %v, %v, want increasing ones above 1", b1, b2)
+	}
+	if b2 != 1+usesWeight {
+		t.Errorf("boost of the most used symbol = %v, want %v", b2, 1+usesWeight)
+	}
+}
@@ -6,6 +6,7 @@ package completion
 
 import (
 	"go/ast"
+	"go/types"
 
 	"github.com/iansmith/golang-x-tools/internal/lsp/snippet"
 )
@@ -113,3 +114,59 @@ func (c *completer) functionCallSnippet(name string, tparams, params []string, s
 
 	snip.WriteText(")")
 }
+
+// resultAssignment returns the names of the variables to which the results
+// of the call of cand are assigned, when it is completed as an expression
+// statement, such as "f, err" in "f, err := os.Open(name)". The names are
+// those of the results, or derived from their types, made unique in the
+// scope. It also returns the enclosing selector, if any, whose "x." part
+// the assignment must precede.
+func (c *completer) resultAssignment(cand candidate) ([]string, *ast.SelectorExpr) {
+	if !c.opts.resultAssignments || !c.opts.snippets || !cand.hasMod(invoke) || cand.convertTo != nil {
+		return nil, nil
+	}
+	for _, mod := range cand.mods {
+		if mod != invoke {
+			return nil, nil // other modifiers such as "&" can't be assigned
+		}
+	}
+	sig, ok := cand.obj.Type().Underlying().(*types.Signature)
+	if !ok || sig.Results().Len() == 0 || len(c.path) < 2 {
+		return nil, nil
+	}
+
+	// Check that the completed expression is a statement.
+	id, ok := c.path[0].(*ast.Ident)
+	if !ok {
+		return nil, nil
+	}
+	var (
+		expr ast.Expr = id
+		sel  *ast.SelectorExpr
+		rest = c.path[1:]
+	)
+	if s, ok := rest[0].(*ast.SelectorExpr); ok && s.Sel == id {
+		if len(rest) < 2 {
+			return nil, nil
+		}
+		expr, sel, rest = s, s, rest[1:]
+	}
+	if stmt, ok := rest[0].(*ast.ExprStmt); !ok || stmt.X != expr {
+		return nil, nil
+	}
+
+	scope := c.pkg.GetTypes().Scope().Innermost(c.pos)
+	used := make(map[string]bool)
+	var names []string
+	for i := 0; i < sig.Results().Len(); i++ {
+		v := sig.Results().At(i)
+		name := v.Name()
+		if name == "" || name == "_" {
+			name = typeVarName(v.Type(), "res")
+		}
+		name = uniqueVarName(scope, used, name)
+		used[name] = true
+		names = append(names, name)
+	}
+	return names, sel
+}
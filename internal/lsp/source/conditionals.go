@@ -0,0 +1,572 @@
+// Copyright 2022 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package source
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/printer"
+	"go/token"
+	"go/types"
+	"strings"
+
+	"github.com/iansmith/golang-x-tools/go/analysis"
+	"github.com/iansmith/golang-x-tools/go/ast/astutil"
+	"github.com/iansmith/golang-x-tools/internal/lsp/bug"
+	"github.com/iansmith/golang-x-tools/internal/lsp/safetoken"
+	"github.com/iansmith/golang-x-tools/internal/span"
+)
+
+// CanConvertIfToSwitch reports whether rng selects the header of an if
+// statement of a chain of if/else if statements that compare a single
+// value, which ifToSwitch can convert to a switch statement.
+func CanConvertIfToSwitch(pkg Package, file *ast.File, rng span.Range) bool {
+	_, err := ifToSwitchText(pkg.GetTypesInfo(), file, rng)
+	return err == nil
+}
+
+// CanConvertSwitchToIf reports whether rng selects the header of a switch
+// statement, which switchToIf can convert to if/else if statements.
+func CanConvertSwitchToIf(pkg Package, file *ast.File, rng span.Range) bool {
+	_, err := switchToIfText(file, rng)
+	return err == nil
+}
+
+// CanInvertIf reports whether rng selects the header of an if statement
+// with an else block, whose condition invertIf can invert.
+func CanInvertIf(pkg Package, file *ast.File, rng span.Range) bool {
+	_, err := invertIfText(pkg.GetTypesInfo(), file, rng)
+	return err == nil
+}
+
+// CanMergeIfs reports whether rng selects the header of an if statement
+// whose body is only another if statement, which mergeIfs can merge with
+// it.
+func CanMergeIfs(pkg Package, file *ast.File, rng span.Range) bool {
+	_, err := mergeIfsText(file, rng)
+	return err == nil
+}
+
+// ifToSwitch converts the chain of if/else if statements selected by rng,
+// whose conditions compare a single value to others, to a switch
+// statement on this value.
+func ifToSwitch(fset *token.FileSet, rng span.Range, src []byte, file *ast.File, _ *types.Package, info *types.Info) (*analysis.SuggestedFix, error) {
+	r, err := ifToSwitchText(info, file, rng)
+	if err != nil {
+		return nil, fmt.Errorf("cannot convert to a switch statement: %v", err)
+	}
+	return r.fix(fset, src)
+}
+
+// switchToIf converts the switch statement selected by rng to if/else if
+// statements.
+func switchToIf(fset *token.FileSet, rng span.Range, src []byte, file *ast.File, _ *types.Package, _ *types.Info) (*analysis.SuggestedFix, error) {
+	r, err := switchToIfText(file, rng)
+	if err != nil {
+		return nil, fmt.Errorf("cannot convert to if statements: %v", err)
+	}
+	return r.fix(fset, src)
+}
+
+// invertIf negates the condition of the if statement selected by rng and
+// swaps its body with its else block.
+func invertIf(fset *token.FileSet, rng span.Range, src []byte, file *ast.File, _ *types.Package, info *types.Info) (*analysis.SuggestedFix, error) {
+	r, err := invertIfText(info, file, rng)
+	if err != nil {
+		return nil, fmt.Errorf("cannot invert the if statement: %v", err)
+	}
+	return r.fix(fset, src)
+}
+
+// mergeIfs merges the if statement selected by rng with the if statement
+// that is its only statement, joining their conditions with &&.
+func mergeIfs(fset *token.FileSet, rng span.Range, src []byte, file *ast.File, _ *types.Package, _ *types.Info) (*analysis.SuggestedFix, error) {
+	r, err := mergeIfsText(file, rng)
+	if err != nil {
+		return nil, fmt.Errorf("cannot merge the if statements: %v", err)
+	}
+	return r.fix(fset, src)
+}
+
+// A stmtRewrite replaces a statement with new code.
+type stmtRewrite struct {
+	stmt ast.Stmt
+	// text builds the unformatted code of the new statement from the
+	// source of the file.
+	text func(s *rewriteSource) string
+}
+
+// rewriteSource gives the source of the nodes of a file.
+type rewriteSource struct {
+	tok *token.File
+	src []byte
+}
+
+// node returns the source of n.
+func (s *rewriteSource) node(n ast.Node) string {
+	return s.between(n.Pos(), n.End())
+}
+
+// between returns the source from pos to end.
+func (s *rewriteSource) between(pos, end token.Pos) string {
+	start, err := safetoken.Offset(s.tok, pos)
+	if err != nil {
+		return ""
+	}
+	stop, err := safetoken.Offset(s.tok, end)
+	if err != nil {
+		return ""
+	}
+	return string(s.src[start:stop])
+}
+
+// block returns the source of the statements and comments of b, without
+// its braces.
+func (s *rewriteSource) block(b *ast.BlockStmt) string {
+	return lines(s.between(b.Lbrace+1, b.Rbrace))
+}
+
+// lines returns the source of statements, ending with a newline but
+// without the indentation of the next line, so that the printer adds no
+// blank line.
+func lines(src string) string {
+	src = strings.TrimRight(src, " \t")
+	if !strings.HasSuffix(src, "\n") {
+		src += "\n"
+	}
+	return src
+}
+
+// fix returns the edit that replaces the statement of r, formatted as by
+// gofmt at the indentation of the statement.
+func (r *stmtRewrite) fix(fset *token.FileSet, src []byte) (*analysis.SuggestedFix, error) {
+	tok := fset.File(r.stmt.Pos())
+	if tok == nil {
+		return nil, bug.Errorf("no file for statement")
+	}
+	start, err := safetoken.Offset(tok, r.stmt.Pos())
+	if err != nil {
+		return nil, err
+	}
+	line := src[bytes.LastIndexByte(src[:start], '\n')+1 : start]
+	depth := len(line) - len(bytes.TrimLeft(line, "\t"))
+	text := r.text(&rewriteSource{tok: tok, src: src})
+
+	// Parse the new statement in a function to format it.
+	const header = "package p\nfunc _() {\n"
+	wfset := token.NewFileSet()
+	f, err := parser.ParseFile(wfset, "", header+text+"\n}\n", parser.ParseComments)
+	if err != nil {
+		return nil, bug.Errorf("invalid rewritten statement: %v", err)
+	}
+	body := f.Decls[0].(*ast.FuncDecl).Body
+	if len(body.List) != 1 {
+		return nil, bug.Errorf("rewritten statement is %d statements", len(body.List))
+	}
+	cfg := printer.Config{Mode: printer.UseSpaces | printer.TabIndent, Tabwidth: 8, Indent: depth}
+	var buf bytes.Buffer
+	if err := cfg.Fprint(&buf, wfset, &printer.CommentedNode{Node: body.List[0], Comments: f.Comments}); err != nil {
+		return nil, err
+	}
+	formatted := strings.TrimPrefix(buf.String(), strings.Repeat("\t", depth))
+	return &analysis.SuggestedFix{
+		TextEdits: []analysis.TextEdit{{Pos: r.stmt.Pos(), End: r.stmt.End(), NewText: []byte(formatted)}},
+	}, nil
+}
+
+// selectedStmt returns the innermost if or switch statement whose header,
+// from its keyword to the opening brace of its body, contains rng, and
+// the path of its enclosing nodes, which starts with it.
+func selectedStmt(file *ast.File, rng span.Range) (ast.Stmt, []ast.Node, error) {
+	path, _ := astutil.PathEnclosingInterval(file, rng.Start, rng.End)
+	for i, n := range path {
+		var lbrace token.Pos
+		switch n := n.(type) {
+		case *ast.IfStmt:
+			// The else keyword of an else if statement selects it.
+			if elseIf, ok := n.Else.(*ast.IfStmt); ok && n.Body.End() <= rng.Start && rng.End <= elseIf.Body.Lbrace+1 {
+				return elseIf, append([]ast.Node{elseIf}, path[i:]...), nil
+			}
+			lbrace = n.Body.Lbrace
+		case *ast.SwitchStmt:
+			lbrace = n.Body.Lbrace
+		default:
+			continue
+		}
+		if n.Pos() <= rng.Start && rng.End <= lbrace+1 {
+			return n.(ast.Stmt), path[i:], nil
+		}
+	}
+	return nil, nil, errors.New("no if or switch statement selected")
+}
+
+// selectedIf returns the if statement selected by rng, and the path of its
+// enclosing nodes.
+func selectedIf(file *ast.File, rng span.Range) (*ast.IfStmt, []ast.Node, error) {
+	stmt, path, err := selectedStmt(file, rng)
+	if err != nil {
+		return nil, nil, err
+	}
+	ifStmt, ok := stmt.(*ast.IfStmt)
+	if !ok {
+		return nil, nil, errors.New("no if statement selected")
+	}
+	return ifStmt, path, nil
+}
+
+func ifToSwitchText(info *types.Info, file *ast.File, rng span.Range) (*stmtRewrite, error) {
+	ifStmt, path, err := selectedIf(file, rng)
+	if err != nil {
+		return nil, err
+	}
+	// Start from the first if statement of the chain.
+	for _, n := range path[1:] {
+		parent, ok := n.(*ast.IfStmt)
+		if !ok || parent.Else != ifStmt {
+			break
+		}
+		ifStmt = parent
+	}
+	var ifs []*ast.IfStmt
+	var elseBlock *ast.BlockStmt
+	for stmt := ast.Stmt(ifStmt); stmt != nil; {
+		x := stmt.(*ast.IfStmt)
+		if len(ifs) > 0 && x.Init != nil {
+			return nil, errors.New("an else if statement has an init statement")
+		}
+		ifs = append(ifs, x)
+		switch e := x.Else.(type) {
+		case *ast.IfStmt:
+			stmt = e
+		case *ast.BlockStmt:
+			elseBlock = e
+			stmt = nil
+		default:
+			stmt = nil
+		}
+	}
+	if len(ifs) < 2 {
+		return nil, errors.New("no else if statement")
+	}
+
+	// Each condition is a disjunction of comparisons of the tag, the same
+	// expression without side effects, to values.
+	var tag ast.Expr
+	values := make([][]ast.Expr, len(ifs))
+	seen := make(map[string]bool)
+	for i, x := range ifs {
+		for _, cond := range disjuncts(x.Cond) {
+			eq, ok := cond.(*ast.BinaryExpr)
+			if !ok || eq.Op != token.EQL {
+				return nil, fmt.Errorf("condition %s is not a comparison", types.ExprString(cond))
+			}
+			if tag == nil {
+				switch {
+				case isTag(info, eq.X):
+					tag = astutil.Unparen(eq.X)
+				case isTag(info, eq.Y):
+					tag = astutil.Unparen(eq.Y)
+				default:
+					return nil, fmt.Errorf("no variable compared in %s", types.ExprString(cond))
+				}
+			}
+			var value ast.Expr
+			switch want := types.ExprString(tag); want {
+			case types.ExprString(astutil.Unparen(eq.X)):
+				value = eq.Y
+			case types.ExprString(astutil.Unparen(eq.Y)):
+				value = eq.X
+			default:
+				return nil, fmt.Errorf("%s does not compare %s", types.ExprString(cond), want)
+			}
+			// A switch statement can't have duplicate constant cases.
+			if tv, ok := info.Types[value]; ok && tv.Value != nil {
+				key := tv.Value.Kind().String() + " " + tv.Value.ExactString()
+				if seen[key] {
+					return nil, fmt.Errorf("duplicate case %s", types.ExprString(value))
+				}
+				seen[key] = true
+			}
+			values[i] = append(values[i], value)
+		}
+	}
+	// A break statement would exit the switch statement.
+	blocks := []*ast.BlockStmt{elseBlock}
+	for _, x := range ifs {
+		blocks = append(blocks, x.Body)
+	}
+	for _, b := range blocks {
+		if b != nil && hasBranch(b, token.BREAK) {
+			return nil, errors.New("a break statement would exit the switch statement")
+		}
+	}
+
+	return &stmtRewrite{stmt: ifStmt, text: func(s *rewriteSource) string {
+		var b strings.Builder
+		b.WriteString("switch ")
+		if ifStmt.Init != nil {
+			fmt.Fprintf(&b, "%s; ", s.node(ifStmt.Init))
+		}
+		fmt.Fprintf(&b, "%s {\n", s.node(tag))
+		for i, x := range ifs {
+			var cases []string
+			for _, v := range values[i] {
+				cases = append(cases, s.node(v))
+			}
+			fmt.Fprintf(&b, "case %s:%s", strings.Join(cases, ", "), s.block(x.Body))
+		}
+		if elseBlock != nil {
+			fmt.Fprintf(&b, "default:%s", s.block(elseBlock))
+		}
+		b.WriteString("}")
+		return b.String()
+	}}, nil
+}
+
+// disjuncts returns the operands of the || operators of e.
+func disjuncts(e ast.Expr) []ast.Expr {
+	e = astutil.Unparen(e)
+	if b, ok := e.(*ast.BinaryExpr); ok && b.Op == token.LOR {
+		return append(disjuncts(b.X), disjuncts(b.Y)...)
+	}
+	return []ast.Expr{e}
+}
+
+// isTag reports whether e is a variable, or a selector of one, which a
+// switch statement can evaluate once instead of at each comparison.
+func isTag(info *types.Info, e ast.Expr) bool {
+	if tv, ok := info.Types[e]; !ok || !tv.IsValue() || tv.Value != nil || tv.IsNil() {
+		return false
+	}
+	for {
+		switch x := astutil.Unparen(e).(type) {
+		case *ast.Ident:
+			return true
+		case *ast.SelectorExpr:
+			e = x.X
+		default:
+			return false
+		}
+	}
+}
+
+// hasBranch reports whether n has a branch statement of kind tok without
+// a label, which applies to the statement enclosing n.
+func hasBranch(n ast.Node, tok token.Token) bool {
+	found := false
+	ast.Inspect(n, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.ForStmt, *ast.RangeStmt, *ast.SwitchStmt, *ast.TypeSwitchStmt, *ast.SelectStmt, *ast.FuncLit:
+			return false
+		case *ast.BranchStmt:
+			found = found || n.Tok == tok && n.Label == nil
+		}
+		return !found
+	})
+	return found
+}
+
+func switchToIfText(file *ast.File, rng span.Range) (*stmtRewrite, error) {
+	stmt, path, err := selectedStmt(file, rng)
+	if err != nil {
+		return nil, err
+	}
+	sw, ok := stmt.(*ast.SwitchStmt)
+	if !ok {
+		return nil, errors.New("no switch statement selected")
+	}
+	if _, ok := path[1].(*ast.LabeledStmt); ok {
+		return nil, errors.New("the switch statement has a label")
+	}
+	if sw.Tag != nil {
+		// The tag is evaluated at each comparison.
+		for e := sw.Tag; ; {
+			if sel, ok := astutil.Unparen(e).(*ast.SelectorExpr); ok {
+				e = sel.X
+				continue
+			}
+			if _, ok := astutil.Unparen(e).(*ast.Ident); !ok {
+				return nil, fmt.Errorf("the tag %s is not a variable", types.ExprString(sw.Tag))
+			}
+			break
+		}
+	}
+	var clauses []*ast.CaseClause
+	var defaultClause *ast.CaseClause
+	for _, s := range sw.Body.List {
+		cc := s.(*ast.CaseClause)
+		if hasBranch(cc, token.BREAK) {
+			return nil, errors.New("a case has a break statement")
+		}
+		if hasBranch(cc, token.FALLTHROUGH) {
+			return nil, errors.New("a case has a fallthrough statement")
+		}
+		if cc.List == nil {
+			defaultClause = cc
+		} else {
+			clauses = append(clauses, cc)
+		}
+	}
+	if len(clauses) == 0 {
+		return nil, errors.New("no case")
+	}
+
+	return &stmtRewrite{stmt: sw, text: func(s *rewriteSource) string {
+		// body returns the statements of a case, up to the next one.
+		body := func(cc *ast.CaseClause) string {
+			for i, c := range sw.Body.List {
+				if c == cc && i+1 < len(sw.Body.List) {
+					return lines(s.between(cc.Colon+1, sw.Body.List[i+1].Pos()))
+				}
+			}
+			return lines(s.between(cc.Colon+1, sw.Body.Rbrace))
+		}
+		var b strings.Builder
+		for i, cc := range clauses {
+			if i > 0 {
+				b.WriteString(" else ")
+			}
+			b.WriteString("if ")
+			if i == 0 && sw.Init != nil {
+				fmt.Fprintf(&b, "%s; ", s.node(sw.Init))
+			}
+			var conds []string
+			for _, e := range cc.List {
+				if sw.Tag == nil {
+					conds = append(conds, s.node(e))
+					continue
+				}
+				value := s.node(e)
+				if bin, ok := e.(*ast.BinaryExpr); ok && bin.Op.Precedence() <= token.EQL.Precedence() {
+					value = "(" + value + ")"
+				}
+				conds = append(conds, fmt.Sprintf("%s == %s", s.node(sw.Tag), value))
+			}
+			fmt.Fprintf(&b, "%s {%s}", strings.Join(conds, " || "), body(cc))
+		}
+		if defaultClause != nil && len(defaultClause.Body) > 0 {
+			fmt.Fprintf(&b, " else {%s}", body(defaultClause))
+		}
+		return b.String()
+	}}, nil
+}
+
+func invertIfText(info *types.Info, file *ast.File, rng span.Range) (*stmtRewrite, error) {
+	ifStmt, _, err := selectedIf(file, rng)
+	if err != nil {
+		return nil, err
+	}
+	elseBlock, ok := ifStmt.Else.(*ast.BlockStmt)
+	if !ok {
+		return nil, errors.New("no else block")
+	}
+	return &stmtRewrite{stmt: ifStmt, text: func(s *rewriteSource) string {
+		var b strings.Builder
+		b.WriteString("if ")
+		if ifStmt.Init != nil {
+			fmt.Fprintf(&b, "%s; ", s.node(ifStmt.Init))
+		}
+		cond, _ := negateCond(info, s, ifStmt.Cond)
+		fmt.Fprintf(&b, "%s {%s} else {%s}", cond, s.block(elseBlock), s.block(ifStmt.Body))
+		return b.String()
+	}}, nil
+}
+
+// negatedOps maps the comparison operators to their negations.
+var negatedOps = map[token.Token]token.Token{
+	token.EQL: token.NEQ,
+	token.NEQ: token.EQL,
+	token.LSS: token.GEQ,
+	token.GEQ: token.LSS,
+	token.GTR: token.LEQ,
+	token.LEQ: token.GTR,
+}
+
+// negateCond returns the code of the negation of the condition e, and the
+// precedence of its operator.
+func negateCond(info *types.Info, s *rewriteSource, e ast.Expr) (string, int) {
+	switch x := astutil.Unparen(e).(type) {
+	case *ast.UnaryExpr:
+		if x.Op == token.NOT {
+			operand := astutil.Unparen(x.X)
+			if b, ok := operand.(*ast.BinaryExpr); ok {
+				return s.node(operand), b.Op.Precedence()
+			}
+			return s.node(operand), token.UnaryPrec
+		}
+	case *ast.BinaryExpr:
+		switch x.Op {
+		case token.LAND, token.LOR:
+			op := token.LOR
+			if x.Op == token.LOR {
+				op = token.LAND
+			}
+			operand := func(e ast.Expr) string {
+				neg, prec := negateCond(info, s, e)
+				if prec < op.Precedence() {
+					neg = "(" + neg + ")"
+				}
+				return neg
+			}
+			return fmt.Sprintf("%s %s %s", operand(x.X), op, operand(x.Y)), op.Precedence()
+		default:
+			// !(x < y) is not x >= y for NaNs.
+			if neg, ok := negatedOps[x.Op]; ok && (x.Op == token.EQL || x.Op == token.NEQ || !isFloat(info.TypeOf(x.X)) && !isFloat(info.TypeOf(x.Y))) {
+				return fmt.Sprintf("%s %s %s", s.node(x.X), neg, s.node(x.Y)), neg.Precedence()
+			}
+		}
+	case *ast.Ident, *ast.SelectorExpr, *ast.CallExpr, *ast.IndexExpr:
+		return "!" + s.node(e), token.UnaryPrec
+	}
+	return "!(" + s.node(astutil.Unparen(e)) + ")", token.UnaryPrec
+}
+
+// isFloat reports whether t is a floating-point or complex type, or is
+// unknown.
+func isFloat(t types.Type) bool {
+	if t == nil {
+		return true
+	}
+	b, ok := t.Underlying().(*types.Basic)
+	return !ok || b.Info()&(types.IsFloat|types.IsComplex) != 0
+}
+
+func mergeIfsText(file *ast.File, rng span.Range) (*stmtRewrite, error) {
+	outer, _, err := selectedIf(file, rng)
+	if err != nil {
+		return nil, err
+	}
+	if outer.Else != nil || len(outer.Body.List) != 1 {
+		return nil, errors.New("the body of the if statement is not only an if statement")
+	}
+	inner, ok := outer.Body.List[0].(*ast.IfStmt)
+	if !ok || inner.Init != nil || inner.Else != nil {
+		return nil, errors.New("the body of the if statement is not only an if statement")
+	}
+	// The comments around the inner statement would be lost.
+	for _, c := range file.Comments {
+		if outer.Body.Lbrace < c.Pos() && c.End() < inner.Pos() || inner.End() < c.Pos() && c.End() < outer.Body.Rbrace {
+			return nil, errors.New("the body of the if statement has comments")
+		}
+	}
+	return &stmtRewrite{stmt: outer, text: func(s *rewriteSource) string {
+		cond := func(e ast.Expr) string {
+			if b, ok := e.(*ast.BinaryExpr); ok && b.Op == token.LOR {
+				return "(" + s.node(e) + ")"
+			}
+			return s.node(e)
+		}
+		var b strings.Builder
+		b.WriteString("if ")
+		if outer.Init != nil {
+			fmt.Fprintf(&b, "%s; ", s.node(outer.Init))
+		}
+		fmt.Fprintf(&b, "%s && %s {%s}", cond(outer.Cond), cond(inner.Cond), s.block(inner.Body))
+		return b.String()
+	}}, nil
+}
@@ -0,0 +1,362 @@
+// Copyright 2022 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package source
+
+import (
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/token"
+	"go/types"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/iansmith/golang-x-tools/go/analysis"
+	"github.com/iansmith/golang-x-tools/internal/span"
+	"github.com/iansmith/golang-x-tools/internal/typeparams"
+)
+
+// A genStruct is the declaration of a struct type for which code is
+// generated.
+type genStruct struct {
+	spec   *ast.TypeSpec
+	decl   *ast.GenDecl
+	named  *types.Named
+	fields []genField
+
+	tparams string // the type parameters of the type, such as "[K comparable, V any]"
+	typ     string // the type, such as "T[K, V]"
+	recv    string // the name of the receiver of the methods of the type
+}
+
+// A genField is a named or embedded field of a struct type.
+type genField struct {
+	name     string // the name of the field, or of the type of an embedded field
+	typ      string // the source of the type of the field
+	embedded bool
+	selected bool
+}
+
+// StructGenerators returns the name of the struct type whose declaration
+// rng selects, and the fixes that can generate code for it after the
+// declaration: GenerateConstructor and GenerateOptions, unless the names
+// they declare are already declared, and GenerateGetters and
+// GenerateSetters, if the struct has unexported fields without them.
+func StructGenerators(fset *token.FileSet, src []byte, file *ast.File, pkg *types.Package, info *types.Info, rng span.Range) (string, []string) {
+	s, err := selectedGenStruct(fset, src, file, info, rng)
+	if err != nil {
+		return "", nil
+	}
+	var fixes []string
+	if _, err := s.constructor(pkg, false); err == nil {
+		fixes = append(fixes, GenerateConstructor)
+	}
+	if _, err := s.constructor(pkg, true); err == nil {
+		fixes = append(fixes, GenerateOptions)
+	}
+	if _, err := s.accessors(pkg, false); err == nil {
+		fixes = append(fixes, GenerateGetters)
+	}
+	if _, err := s.accessors(pkg, true); err == nil {
+		fixes = append(fixes, GenerateSetters)
+	}
+	return s.named.Obj().Name(), fixes
+}
+
+// selectedGenStruct returns the struct type declared at package level by
+// the type specification that rng is within. The fields that rng
+// intersects are selected.
+func selectedGenStruct(fset *token.FileSet, src []byte, file *ast.File, info *types.Info, rng span.Range) (*genStruct, error) {
+	for _, decl := range file.Decls {
+		decl, ok := decl.(*ast.GenDecl)
+		if !ok || decl.Tok != token.TYPE {
+			continue
+		}
+		for _, spec := range decl.Specs {
+			spec := spec.(*ast.TypeSpec)
+			if rng.Start < spec.Pos() || spec.End() < rng.End {
+				continue
+			}
+			st, ok := spec.Type.(*ast.StructType)
+			if !ok || spec.Assign.IsValid() {
+				return nil, errors.New("no struct type selected")
+			}
+			obj, ok := info.Defs[spec.Name].(*types.TypeName)
+			if !ok {
+				return nil, errors.New("no type information")
+			}
+			named, ok := obj.Type().(*types.Named)
+			if !ok || spec.Name.Name == "_" {
+				return nil, errors.New("no named struct type selected")
+			}
+			s := &genStruct{spec: spec, decl: decl, named: named, typ: named.Obj().Name()}
+			text := func(n ast.Node) string {
+				return string(src[fset.Position(n.Pos()).Offset:fset.Position(n.End()).Offset])
+			}
+			if tparams := typeparams.ForTypeSpec(spec); tparams != nil && len(tparams.List) > 0 {
+				var params []string
+				for _, field := range tparams.List {
+					params = append(params, text(field))
+				}
+				s.tparams = "[" + strings.Join(params, ", ") + "]"
+				s.typ += FormatTypeParams(typeparams.ForNamed(named))
+			}
+			for _, field := range st.Fields.List {
+				selected := rng.Start <= field.End() && field.Pos() <= rng.End && rng.Start > spec.Name.End()
+				typ := text(field.Type)
+				if len(field.Names) == 0 {
+					name := recvTypeIdent(field.Type)
+					if name == nil {
+						return nil, errors.New("unsupported embedded field")
+					}
+					s.fields = append(s.fields, genField{name: name.Name, typ: typ, embedded: true, selected: selected})
+					continue
+				}
+				for _, name := range field.Names {
+					if name.Name != "_" {
+						s.fields = append(s.fields, genField{name: name.Name, typ: typ, selected: selected})
+					}
+				}
+			}
+			if len(s.fields) == 0 {
+				return nil, errors.New("the struct type has no fields")
+			}
+			s.recv = receiverVarName(named)
+			return s, nil
+		}
+	}
+	return nil, errors.New("no type declaration selected")
+}
+
+// receiverVarName returns the name of the receiver of the existing
+// methods of named, if any, or else its initial letter in lower case.
+func receiverVarName(named *types.Named) string {
+	for i := 0; i < named.NumMethods(); i++ {
+		recv := named.Method(i).Type().(*types.Signature).Recv()
+		if recv != nil && recv.Name() != "" && recv.Name() != "_" {
+			return recv.Name()
+		}
+	}
+	r, _ := utf8.DecodeRuneInString(named.Obj().Name())
+	return string(unicode.ToLower(r))
+}
+
+// exportedName returns name with its initial letter in upper case if
+// exported is true, or in lower case otherwise.
+func exportedName(name string, exported bool) string {
+	r, size := utf8.DecodeRuneInString(name)
+	if exported {
+		return string(unicode.ToUpper(r)) + name[size:]
+	}
+	return string(unicode.ToLower(r)) + name[size:]
+}
+
+// paramName returns the name of the parameter for the field f, which
+// differs from the names of the identifiers that the code declaring it
+// uses.
+func (s *genStruct) paramName(f genField, used ...string) string {
+	name := exportedName(f.name, false)
+	name, _ = generateIdentifier(0, name, func(name string) bool {
+		if token.IsKeyword(name) || name == s.named.Obj().Name() {
+			return true
+		}
+		for _, u := range used {
+			if name == u {
+				return true
+			}
+		}
+		return false
+	})
+	return name
+}
+
+// generateConstructor generates a constructor after the declaration of
+// the struct type whose declaration rng selects, whose parameters set the
+// selected fields, or all of them if none is selected.
+func generateConstructor(fset *token.FileSet, rng span.Range, src []byte, file *ast.File, pkg *types.Package, info *types.Info) (*analysis.SuggestedFix, error) {
+	return generateStructCode(fset, rng, src, file, info, func(s *genStruct) (string, error) {
+		return s.constructor(pkg, false)
+	})
+}
+
+// generateOptions generates a constructor after the declaration of the
+// struct type whose declaration rng selects, whose parameters set the
+// selected fields, followed by functional options that set the others.
+func generateOptions(fset *token.FileSet, rng span.Range, src []byte, file *ast.File, pkg *types.Package, info *types.Info) (*analysis.SuggestedFix, error) {
+	return generateStructCode(fset, rng, src, file, info, func(s *genStruct) (string, error) {
+		return s.constructor(pkg, true)
+	})
+}
+
+// generateGetters generates getter methods for the unexported fields of
+// the struct type whose declaration rng selects, after its declaration.
+func generateGetters(fset *token.FileSet, rng span.Range, src []byte, file *ast.File, pkg *types.Package, info *types.Info) (*analysis.SuggestedFix, error) {
+	return generateStructCode(fset, rng, src, file, info, func(s *genStruct) (string, error) {
+		return s.accessors(pkg, false)
+	})
+}
+
+// generateSetters generates setter methods for the unexported fields of
+// the struct type whose declaration rng selects, after its declaration.
+func generateSetters(fset *token.FileSet, rng span.Range, src []byte, file *ast.File, pkg *types.Package, info *types.Info) (*analysis.SuggestedFix, error) {
+	return generateStructCode(fset, rng, src, file, info, func(s *genStruct) (string, error) {
+		return s.accessors(pkg, true)
+	})
+}
+
+// generateStructCode inserts the code that gen generates for the struct
+// type whose declaration rng selects, formatted, after the declaration
+// and its line comment, if any.
+func generateStructCode(fset *token.FileSet, rng span.Range, src []byte, file *ast.File, info *types.Info, gen func(*genStruct) (string, error)) (*analysis.SuggestedFix, error) {
+	s, err := selectedGenStruct(fset, src, file, info, rng)
+	if err != nil {
+		return nil, err
+	}
+	code, err := gen(s)
+	if err != nil {
+		return nil, err
+	}
+	formatted, err := format.Source([]byte(code))
+	if err != nil {
+		return nil, err
+	}
+	pos := s.decl.End()
+	if !s.decl.Lparen.IsValid() && s.spec.Comment != nil {
+		pos = s.spec.Comment.End()
+	}
+	return &analysis.SuggestedFix{
+		TextEdits: []analysis.TextEdit{{
+			Pos:     pos,
+			End:     pos,
+			NewText: append([]byte("\n\n"), formatted...),
+		}},
+	}, nil
+}
+
+// constructor returns the declaration of the constructor of the struct
+// type in pkg. Its parameters set the selected fields, or all of them if
+// none is selected, unless options is true, in which case the functional
+// options that set the others follow the constructor.
+func (s *genStruct) constructor(pkg *types.Package, options bool) (string, error) {
+	typeName := s.named.Obj().Name()
+	exported := token.IsExported(typeName)
+	name := exportedName("New"+exportedName(typeName, true), exported)
+	optionType := typeName + "Option"
+	declared := []string{name}
+	if options {
+		declared = append(declared, optionType)
+	}
+
+	var required, optional []genField
+	selected := false
+	for _, f := range s.fields {
+		selected = selected || f.selected
+	}
+	for _, f := range s.fields {
+		if f.selected || !options && !selected {
+			required = append(required, f)
+		} else {
+			optional = append(optional, f)
+			declared = append(declared, exportedName("With"+exportedName(f.name, true), exported))
+		}
+	}
+	if options && len(optional) == 0 {
+		return "", errors.New("no optional fields")
+	}
+	for _, name := range declared {
+		if pkg.Scope().Lookup(name) != nil {
+			return "", fmt.Errorf("%s is already declared", name)
+		}
+	}
+
+	// The name of the variable of the new value, and of the options.
+	used := []string{s.recv, "opts", "opt"}
+	var b strings.Builder
+	fmt.Fprintf(&b, "// %s returns a new %s.\n", name, typeName)
+	fmt.Fprintf(&b, "func %s%s(", name, s.tparams)
+	for i, f := range required {
+		if i > 0 {
+			b.WriteString(", ")
+		}
+		fmt.Fprintf(&b, "%s %s", s.paramName(f, used...), f.typ)
+	}
+	typeArgs := strings.TrimPrefix(s.typ, typeName)
+	if options {
+		if len(required) > 0 {
+			b.WriteString(", ")
+		}
+		fmt.Fprintf(&b, "opts ...%s%s", optionType, typeArgs)
+	}
+	fmt.Fprintf(&b, ") *%s {\n", s.typ)
+	result := "return "
+	if options {
+		result = s.recv + " := "
+	}
+	if len(required) == 0 {
+		fmt.Fprintf(&b, "\t%s&%s{}\n", result, s.typ)
+	} else {
+		fmt.Fprintf(&b, "\t%s&%s{\n", result, s.typ)
+		for _, f := range required {
+			fmt.Fprintf(&b, "\t\t%s: %s,\n", f.name, s.paramName(f, used...))
+		}
+		b.WriteString("\t}\n")
+	}
+	if options {
+		fmt.Fprintf(&b, "\tfor _, opt := range opts {\n\t\topt(%s)\n\t}\n", s.recv)
+		fmt.Fprintf(&b, "\treturn %s\n", s.recv)
+	}
+	b.WriteString("}")
+	if !options {
+		return b.String(), nil
+	}
+
+	fmt.Fprintf(&b, "\n\n// %s sets an optional field of a %s.\n", optionType, typeName)
+	fmt.Fprintf(&b, "type %s%s func(*%s)", optionType, s.tparams, s.typ)
+	for _, f := range optional {
+		with := exportedName("With"+exportedName(f.name, true), exported)
+		param := s.paramName(f, s.recv)
+		fmt.Fprintf(&b, "\n\n// %s sets the %s of a %s.\n", with, f.name, typeName)
+		fmt.Fprintf(&b, "func %s%s(%s %s) %s%s {\n", with, s.tparams, param, f.typ, optionType, typeArgs)
+		fmt.Fprintf(&b, "\treturn func(%s *%s) {\n\t\t%s.%s = %s\n\t}\n}", s.recv, s.typ, s.recv, f.name, param)
+	}
+	return b.String(), nil
+}
+
+// accessors returns the declarations of the getter methods of the
+// unexported fields of the struct type in pkg, or of their setter methods
+// if setters is true, except for those whose names are taken by the
+// fields or methods of the type.
+func (s *genStruct) accessors(pkg *types.Package, setters bool) (string, error) {
+	var b strings.Builder
+	for _, f := range s.fields {
+		if token.IsExported(f.name) || f.embedded {
+			continue
+		}
+		name := exportedName(f.name, true)
+		if setters {
+			name = "Set" + name
+		}
+		if obj, _, _ := types.LookupFieldOrMethod(s.named, true, pkg, name); obj != nil {
+			continue
+		}
+		if b.Len() > 0 {
+			b.WriteString("\n\n")
+		}
+		if setters {
+			param := s.paramName(f, s.recv)
+			fmt.Fprintf(&b, "// %s sets the %s of the %s.\n", name, f.name, s.named.Obj().Name())
+			fmt.Fprintf(&b, "func (%s *%s) %s(%s %s) {\n\t%s.%s = %s\n}", s.recv, s.typ, name, param, f.typ, s.recv, f.name, param)
+		} else {
+			fmt.Fprintf(&b, "// %s returns the %s of the %s.\n", name, f.name, s.named.Obj().Name())
+			fmt.Fprintf(&b, "func (%s *%s) %s() %s {\n\treturn %s.%s\n}", s.recv, s.typ, name, f.typ, s.recv, f.name)
+		}
+	}
+	if b.Len() == 0 {
+		return "", errors.New("no unexported fields without accessors")
+	}
+	return b.String(), nil
+}
@@ -0,0 +1,100 @@
+// Copyright 2022 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package source
+
+import (
+	"context"
+	"go/token"
+	"path"
+	"path/filepath"
+
+	"github.com/iansmith/golang-x-tools/cover"
+	"github.com/iansmith/golang-x-tools/internal/lsp/protocol"
+	"github.com/iansmith/golang-x-tools/internal/lsp/safetoken"
+	"github.com/iansmith/golang-x-tools/internal/span"
+)
+
+// CoverageDiagnostics returns diagnostics for the blocks of statements
+// of the package with the given import path, in directory dir, that the
+// coverage profiles report as not covered. The diagnostics are tagged as
+// unnecessary, so that editors render the code as faded.
+func CoverageDiagnostics(ctx context.Context, snapshot Snapshot, dir, pkgPath string, profiles []*cover.Profile) (map[span.URI][]*Diagnostic, error) {
+	reports := make(map[span.URI][]*Diagnostic)
+	for _, p := range profiles {
+		if path.Dir(p.FileName) != pkgPath {
+			continue
+		}
+		uri := span.URIFromPath(filepath.Join(dir, path.Base(p.FileName)))
+		fh, err := snapshot.GetFile(ctx, uri)
+		if err != nil {
+			return nil, err
+		}
+		pgf, err := snapshot.ParseGo(ctx, fh, ParseFull)
+		if err != nil {
+			return nil, err
+		}
+		for _, b := range p.Blocks {
+			if b.Count > 0 || b.NumStmt == 0 {
+				continue
+			}
+			start, ok := linePos(pgf.Tok, b.StartLine, b.StartCol)
+			if !ok {
+				continue
+			}
+			end, ok := linePos(pgf.Tok, b.EndLine, b.EndCol)
+			if !ok {
+				continue
+			}
+			rng, err := NewMappedRange(snapshot.FileSet(), pgf.Mapper, start, end).Range()
+			if err != nil {
+				return nil, err
+			}
+			reports[uri] = append(reports[uri], &Diagnostic{
+				URI:      uri,
+				Range:    rng,
+				Severity: protocol.SeverityHint,
+				Source:   CoverageInfo,
+				Message:  "not covered by tests",
+				Tags:     []protocol.DiagnosticTag{protocol.Unnecessary},
+			})
+		}
+	}
+	return reports, nil
+}
+
+// CoveragePercent returns the percentage of the statements in the
+// coverage profiles that are covered.
+func CoveragePercent(profiles []*cover.Profile) float64 {
+	var total, covered int
+	for _, p := range profiles {
+		for _, b := range p.Blocks {
+			total += b.NumStmt
+			if b.Count > 0 {
+				covered += b.NumStmt
+			}
+		}
+	}
+	if total == 0 {
+		return 0
+	}
+	return 100 * float64(covered) / float64(total)
+}
+
+// linePos returns the position of the 1-based line and byte column in
+// tok, and reports whether it is within the file.
+func linePos(tok *token.File, line, col int) (token.Pos, bool) {
+	if line < 1 || line > tok.LineCount() {
+		return token.NoPos, false
+	}
+	lineOffset, err := safetoken.Offset(tok, tok.LineStart(line))
+	if err != nil {
+		return token.NoPos, false
+	}
+	offset := lineOffset + col - 1
+	if offset < 0 || offset > tok.Size() {
+		return token.NoPos, false
+	}
+	return tok.Pos(offset), true
+}
@@ -0,0 +1,199 @@
+// Copyright 2022 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package source
+
+import (
+	"context"
+	"fmt"
+	"go/token"
+	"go/types"
+	"strings"
+
+	"github.com/iansmith/golang-x-tools/internal/span"
+)
+
+// NewDocLinkResolver returns a DocLinkResolver for the doc comment of obj,
+// an object visible in pkg. The doc links of the comment are resolved in the
+// scope of the declaration of obj.
+//
+// Links to declarations in the workspace are resolved to their position;
+// the others are resolved to their documentation on the configured link
+// target, such as pkg.go.dev.
+func NewDocLinkResolver(ctx context.Context, snapshot Snapshot, pkg Package, obj types.Object) DocLinkResolver {
+	var r *docLinkResolver
+	return func(link string) string {
+		// Finding the declaring package is only worth it if the comment has
+		// doc links.
+		if r == nil {
+			r = newDocLinkResolver(ctx, snapshot, pkg, obj)
+		}
+		return r.resolve(link)
+	}
+}
+
+type docLinkResolver struct {
+	ctx      context.Context
+	snapshot Snapshot
+	pkg      Package           // the package declaring the documented object
+	imports  map[string]string // the import paths of the packages named in the comment, by name
+}
+
+func newDocLinkResolver(ctx context.Context, snapshot Snapshot, pkg Package, obj types.Object) *docLinkResolver {
+	r := &docLinkResolver{ctx: ctx, snapshot: snapshot, pkg: pkg}
+	pos := token.NoPos
+	switch obj := obj.(type) {
+	case nil:
+	case *types.PkgName:
+		// The comment is the documentation of the imported package.
+		if imp, err := pkg.GetImport(obj.Imported().Path()); err == nil {
+			r.pkg = imp
+		}
+	default:
+		if obj.Pos().IsValid() {
+			if declPkg, err := FindPackageFromPos(ctx, snapshot, obj.Pos()); err == nil {
+				r.pkg, pos = declPkg, obj.Pos()
+			}
+		}
+	}
+	r.imports = docImports(r.pkg, pos)
+	return r
+}
+
+// docImports returns the import paths of the packages imported by the file
+// of pkg containing pos, by name. If pos is not valid, the imports of all the
+// files of pkg are returned.
+func docImports(pkg Package, pos token.Pos) map[string]string {
+	imports := make(map[string]string)
+	for _, pgf := range pkg.CompiledGoFiles() {
+		if pos.IsValid() && (pos < token.Pos(pgf.Tok.Base()) || pos > token.Pos(pgf.Tok.Base()+pgf.Tok.Size())) {
+			continue
+		}
+		for _, spec := range pgf.File.Imports {
+			path := ImportPath(spec)
+			if path == "" {
+				continue
+			}
+			var name string
+			if spec.Name != nil {
+				name = spec.Name.Name
+			} else if imp, err := pkg.GetImport(path); err == nil && imp.GetTypes() != nil {
+				name = imp.GetTypes().Name()
+			}
+			if name != "" && name != "_" && name != "." {
+				imports[name] = path
+			}
+		}
+	}
+	return imports
+}
+
+// resolve returns the URL of the package or symbol named by the doc link,
+// such as "fmt.Printf" for [fmt.Printf].
+func (r *docLinkResolver) resolve(link string) string {
+	importPath, names, ok := splitDocLink(link)
+	if !ok || r.pkg.GetTypes() == nil {
+		return ""
+	}
+	scope := r.pkg.GetTypes().Scope()
+	var target Package
+	switch {
+	case importPath != "":
+		// [encoding/json] or [encoding/json.Marshal]
+		target = r.lookupPackage(importPath)
+	case len(names) == 3:
+		// [pkg.Recv.Name]
+		target = r.lookupPackage(r.imports[names[0]])
+		names = names[1:]
+	case len(names) == 2:
+		// [Recv.Name] in the current package, or [pkg.Name]
+		if lookupDocSymbol(scope, r.pkg.GetTypes(), names) != nil {
+			target = r.pkg
+		} else {
+			target = r.lookupPackage(r.imports[names[0]])
+			names = names[1:]
+		}
+	default:
+		// [Name] in the current package, or [pkg]
+		if scope.Lookup(names[0]) != nil {
+			target = r.pkg
+		} else {
+			target = r.lookupPackage(r.imports[names[0]])
+			names = nil
+		}
+	}
+	if target == nil || target.GetTypes() == nil {
+		return ""
+	}
+	if len(names) == 0 {
+		return r.url(target, nil, "")
+	}
+	obj := lookupDocSymbol(target.GetTypes().Scope(), target.GetTypes(), names)
+	if obj == nil {
+		return ""
+	}
+	recv := ""
+	if len(names) == 2 {
+		recv = names[0]
+	}
+	return r.url(target, obj, recv)
+}
+
+func (r *docLinkResolver) lookupPackage(importPath string) Package {
+	if importPath == "" {
+		return nil
+	}
+	pkg, err := packageForPath(r.ctx, r.snapshot, r.pkg, importPath)
+	if err != nil {
+		return nil
+	}
+	return pkg
+}
+
+// lookupDocSymbol returns the object named by the names of a doc link in
+// scope: a package-level declaration, or a field or method of one of its
+// types.
+func lookupDocSymbol(scope *types.Scope, pkg *types.Package, names []string) types.Object {
+	obj := scope.Lookup(names[0])
+	if len(names) == 1 || obj == nil {
+		return obj
+	}
+	tname, ok := obj.(*types.TypeName)
+	if !ok {
+		return nil
+	}
+	member, _, _ := types.LookupFieldOrMethod(tname.Type(), true, pkg, names[1])
+	return member
+}
+
+// url returns the URL of the documentation of obj, a symbol of target whose
+// receiver type, if any, is recv, or of target itself if obj is nil.
+func (r *docLinkResolver) url(target Package, obj types.Object, recv string) string {
+	view := r.snapshot.View()
+	folder := view.Folder().Filename()
+	if obj != nil {
+		if pos := r.snapshot.FileSet().Position(obj.Pos()); pos.IsValid() && InDir(folder, pos.Filename) {
+			return fmt.Sprintf("%s#L%d,%d", span.URIFromPath(pos.Filename), pos.Line, pos.Column)
+		}
+	} else if files := target.CompiledGoFiles(); len(files) > 0 && InDir(folder, files[0].URI.Filename()) {
+		return string(files[0].URI)
+	}
+
+	linkTarget := DocLinkTarget(view, target.PkgPath())
+	if !view.Options().LinksInHover || linkTarget == "" {
+		return ""
+	}
+	path := target.PkgPath()
+	if v := target.Version(); v != nil && v.Path != "" && v.Version != "" && strings.ToLower(linkTarget) == "pkg.go.dev" {
+		path = strings.Replace(path, v.Path, v.Path+"@"+v.Version, 1)
+	}
+	anchor := ""
+	if obj != nil {
+		anchor = obj.Name()
+		if recv != "" {
+			anchor = recv + "." + anchor
+		}
+	}
+	return BuildLink(linkTarget, path, anchor)
+}
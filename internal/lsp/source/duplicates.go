@@ -0,0 +1,302 @@
+// Copyright 2022 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package source
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"go/scanner"
+	"go/token"
+	"sort"
+
+	"github.com/iansmith/golang-x-tools/internal/event"
+	"github.com/iansmith/golang-x-tools/internal/lsp/command"
+	"github.com/iansmith/golang-x-tools/internal/lsp/protocol"
+	"github.com/iansmith/golang-x-tools/internal/lsp/safetoken"
+	"github.com/iansmith/golang-x-tools/internal/span"
+)
+
+// DuplicateCode returns hints for the duplicated code of the workspace
+// packages: the sequences of at least duplicateCodeTokens tokens of their
+// Go files that occur more than once, ignoring comments and layout. Each
+// hint relates the other occurrences, and has a code action listing them.
+// Generated files are skipped.
+func DuplicateCode(ctx context.Context, snapshot Snapshot) (map[span.URI][]*Diagnostic, error) {
+	ctx, done := event.Start(ctx, "source.DuplicateCode")
+	defer done()
+
+	clones, err := findWorkspaceClones(ctx, snapshot)
+	if err != nil {
+		return nil, err
+	}
+	reports := make(map[span.URI][]*Diagnostic)
+	for _, group := range clones {
+		for i, loc := range group.locations {
+			others := len(group.locations) - 1
+			plural := "s"
+			if others == 1 {
+				plural = ""
+			}
+			d := &Diagnostic{
+				URI:      loc.URI.SpanURI(),
+				Range:    loc.Range,
+				Severity: protocol.SeverityHint,
+				Source:   DuplicateCodeHint,
+				Message:  fmt.Sprintf("duplicated code: %d tokens also found at %d other location%s", group.length, others, plural),
+			}
+			for j, other := range group.locations {
+				if j != i {
+					d.Related = append(d.Related, RelatedInformation{
+						URI:     other.URI.SpanURI(),
+						Range:   other.Range,
+						Message: "duplicate",
+					})
+				}
+			}
+			cmd, err := command.NewShowDuplicatesCommand("Show duplicates", command.ShowDuplicatesArgs{
+				URI:   loc.URI,
+				Range: loc.Range,
+			})
+			if err != nil {
+				return nil, err
+			}
+			d.SuggestedFixes = []SuggestedFix{SuggestedFixFromCommand(cmd, protocol.QuickFix)}
+			reports[d.URI] = append(reports[d.URI], d)
+		}
+	}
+	return reports, nil
+}
+
+// Duplicates returns the other locations of the duplicated code at rng in
+// the file uri, reported by DuplicateCode.
+func Duplicates(ctx context.Context, snapshot Snapshot, uri span.URI, rng protocol.Range) ([]protocol.Location, error) {
+	ctx, done := event.Start(ctx, "source.Duplicates")
+	defer done()
+
+	if snapshot.View().Options().DuplicateCodeTokens <= 0 {
+		return nil, errors.New("duplicate code detection is disabled: set duplicateCodeTokens")
+	}
+	clones, err := findWorkspaceClones(ctx, snapshot)
+	if err != nil {
+		return nil, err
+	}
+	for _, group := range clones {
+		for i, loc := range group.locations {
+			if loc.URI.SpanURI() != uri || protocol.ComparePosition(rng.Start, loc.Range.Start) < 0 || protocol.ComparePosition(loc.Range.End, rng.Start) < 0 {
+				continue
+			}
+			var others []protocol.Location
+			others = append(others, group.locations[:i]...)
+			return append(others, group.locations[i+1:]...), nil
+		}
+	}
+	return nil, fmt.Errorf("no duplicated code at %s:%d:%d", uri.Filename(), rng.Start.Line+1, rng.Start.Character+1)
+}
+
+// A cloneGroup is a sequence of tokens that occurs at several locations.
+type cloneGroup struct {
+	length    int // in tokens
+	locations []protocol.Location
+}
+
+// findWorkspaceClones returns the clone groups of the non-generated Go files
+// of the workspace packages, sorted by location.
+func findWorkspaceClones(ctx context.Context, snapshot Snapshot) ([]*cloneGroup, error) {
+	minTokens := snapshot.View().Options().DuplicateCodeTokens
+	pkgs, err := snapshot.ActivePackages(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var files []*ParsedGoFile
+	seen := make(map[span.URI]bool)
+	for _, pkg := range pkgs {
+		for _, pgf := range pkg.CompiledGoFiles() {
+			if seen[pgf.URI] {
+				continue
+			}
+			seen[pgf.URI] = true
+			if snapshot.FileSet().Position(pgf.File.Package).Filename != pgf.URI.Filename() {
+				continue // generated by cgo from another file
+			}
+			if IsGenerated(ctx, snapshot, pgf.URI) {
+				continue
+			}
+			files = append(files, pgf)
+		}
+	}
+	sort.Slice(files, func(i, j int) bool {
+		return files[i].URI < files[j].URI
+	})
+
+	toks := make([][]cloneToken, len(files))
+	ids := make(map[cloneTokenKey]int)
+	for i, pgf := range files {
+		toks[i] = scanCloneTokens(pgf.Src, ids)
+	}
+	var groups []*cloneGroup
+	for _, clone := range findClones(toks, minTokens) {
+		group := &cloneGroup{length: clone.length}
+		for _, m := range clone.members {
+			pgf, ftoks := files[m.file], toks[m.file]
+			start := pgf.Tok.Pos(ftoks[m.start].offset)
+			end := pgf.Tok.Pos(ftoks[m.start+clone.length-1].end)
+			rng, err := NewMappedRange(snapshot.FileSet(), pgf.Mapper, start, end).Range()
+			if err != nil {
+				return nil, err
+			}
+			group.locations = append(group.locations, protocol.Location{URI: protocol.URIFromSpanURI(pgf.URI), Range: rng})
+		}
+		groups = append(groups, group)
+	}
+	return groups, nil
+}
+
+// A cloneToken is a token of a Go file, identified by its kind and text.
+type cloneToken struct {
+	id          int
+	offset, end int
+}
+
+type cloneTokenKey struct {
+	tok token.Token
+	lit string
+}
+
+// scanCloneTokens returns the tokens of the Go source src, except comments
+// and automatic semicolons, with the IDs of ids, to which it adds the new
+// tokens.
+func scanCloneTokens(src []byte, ids map[cloneTokenKey]int) []cloneToken {
+	var toks []cloneToken
+	var s scanner.Scanner
+	file := token.NewFileSet().AddFile("", -1, len(src))
+	s.Init(file, src, nil, 0)
+	for {
+		pos, tok, lit := s.Scan()
+		if tok == token.EOF {
+			return toks
+		}
+		if tok == token.SEMICOLON && lit != ";" {
+			continue // inserted at a newline or the end of the file
+		}
+		key := cloneTokenKey{tok, lit}
+		if !tok.IsLiteral() {
+			key.lit = ""
+		}
+		id, ok := ids[key]
+		if !ok {
+			id = len(ids)
+			ids[key] = id
+		}
+		text := lit
+		if !tok.IsLiteral() && tok != token.SEMICOLON {
+			text = tok.String()
+		}
+		offset, err := safetoken.Offset(file, pos)
+		if err != nil {
+			return toks
+		}
+		toks = append(toks, cloneToken{id: id, offset: offset, end: offset + len(text)})
+	}
+}
+
+// A clone is a sequence of tokens that occurs at several positions.
+type clone struct {
+	length  int
+	members []cloneMember
+}
+
+type cloneMember struct {
+	file, start int // indexes in the token lists
+}
+
+// findClones returns the maximal sequences of at least minTokens tokens,
+// which don't overlap, that occur more than once in the token lists of
+// files, in the order of their first occurrences.
+func findClones(files [][]cloneToken, minTokens int) []*clone {
+	if minTokens <= 0 {
+		return nil
+	}
+	// Index the windows of minTokens tokens by a polynomial hash.
+	const base = 1000003
+	var power uint64 = 1
+	for i := 1; i < minTokens; i++ {
+		power *= base
+	}
+	hashes := make([][]uint64, len(files))
+	windows := make(map[uint64][]cloneMember)
+	for f, toks := range files {
+		if len(toks) < minTokens {
+			continue
+		}
+		hashes[f] = make([]uint64, len(toks)-minTokens+1)
+		var h uint64
+		for i, tok := range toks {
+			if i >= minTokens {
+				h -= uint64(toks[i-minTokens].id+1) * power
+			}
+			h = h*base + uint64(tok.id+1)
+			if start := i - minTokens + 1; start >= 0 {
+				hashes[f][start] = h
+				windows[h] = append(windows[h], cloneMember{f, start})
+			}
+		}
+	}
+
+	equal := func(a, b cloneMember, n int) bool {
+		for i := 0; i < n; i++ {
+			if files[a.file][a.start+i].id != files[b.file][b.start+i].id {
+				return false
+			}
+		}
+		return true
+	}
+	covered := make([][]bool, len(files))
+	for f, toks := range files {
+		covered[f] = make([]bool, len(toks))
+	}
+	var clones []*clone
+	for f, fhashes := range hashes {
+		for i, h := range fhashes {
+			if covered[f][i] {
+				continue
+			}
+			first := cloneMember{f, i}
+			members := []cloneMember{first}
+			for _, m := range windows[h] {
+				last := members[len(members)-1]
+				if m == first || covered[m.file][m.start] || m.file == last.file && m.start < last.start+minTokens || !equal(first, m, minTokens) {
+					continue
+				}
+				members = append(members, m)
+			}
+			if len(members) < 2 {
+				continue
+			}
+			// Extend the clone as long as all its members do, without
+			// overlapping.
+			length := minTokens
+		extend:
+			for {
+				for j, m := range members {
+					if m.start+length >= len(files[m.file]) || files[m.file][m.start+length].id != files[f][i+length].id {
+						break extend
+					}
+					if j > 0 && members[j-1].file == m.file && members[j-1].start+length >= m.start {
+						break extend
+					}
+				}
+				length++
+			}
+			for _, m := range members {
+				for k := m.start; k < m.start+length; k++ {
+					covered[m.file][k] = true
+				}
+			}
+			clones = append(clones, &clone{length: length, members: members})
+		}
+	}
+	return clones
+}
@@ -0,0 +1,66 @@
+// Copyright 2022 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package source
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestFindClones(t *testing.T) {
+	srcs := []string{
+		`package a
+
+func f(x int) int {
+	if x > 0 {
+		return x * 2 // doubled
+	}
+	return -x
+}
+`,
+		`package b
+
+func g(x int) int {
+	if x > 0 {
+		return x *
+			2
+	}
+	return x
+}
+
+func h(x int) int {
+	if x > 0 { return x * 2 }
+	return 0
+}
+`,
+	}
+	ids := make(map[cloneTokenKey]int)
+	var files [][]cloneToken
+	for _, src := range srcs {
+		files = append(files, scanCloneTokens([]byte(src), ids))
+	}
+	clones := findClones(files, 10)
+	var got [][]string
+	for _, c := range clones {
+		var texts []string
+		for _, m := range c.members {
+			toks := files[m.file]
+			texts = append(texts, srcs[m.file][toks[m.start].offset:toks[m.start+c.length-1].end])
+		}
+		got = append(got, texts)
+	}
+	want := [][]string{{
+		"(x int) int {\n\tif x > 0 {\n\t\treturn x * 2 // doubled\n\t}\n\treturn",
+		"(x int) int {\n\tif x > 0 {\n\t\treturn x *\n\t\t\t2\n\t}\n\treturn",
+		"(x int) int {\n\tif x > 0 { return x * 2 }\n\treturn",
+	}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("findClones() = %q, want %q", got, want)
+	}
+
+	if clones := findClones(files, 100); len(clones) != 0 {
+		t.Errorf("findClones(100) = %d clones, want none", len(clones))
+	}
+}
@@ -0,0 +1,258 @@
+// Copyright 2022 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package source
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/token"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/iansmith/golang-x-tools/internal/event"
+	"github.com/iansmith/golang-x-tools/internal/lsp/protocol"
+	"github.com/iansmith/golang-x-tools/internal/span"
+)
+
+// An EmbedPattern is a single file pattern of a //go:embed directive.
+type EmbedPattern struct {
+	Pattern  string    // the unquoted pattern
+	Pos, End token.Pos // the extent of the pattern in the file, including any quotes
+	Quoted   bool      // whether the pattern is a quoted or raw string literal
+}
+
+const embedPrefix = "//go:embed"
+
+// IsEmbedDirective reports whether c is a //go:embed directive.
+func IsEmbedDirective(c *ast.Comment) bool {
+	if !strings.HasPrefix(c.Text, embedPrefix) {
+		return false
+	}
+	rest := c.Text[len(embedPrefix):]
+	return rest == "" || rest[0] == ' ' || rest[0] == '\t'
+}
+
+// EmbedPatterns returns the file patterns of the //go:embed directive c,
+// following the syntax accepted by the go command. Patterns that are not
+// terminated, such as a quoted pattern that is still being typed, extend to
+// the end of the comment.
+func EmbedPatterns(c *ast.Comment) []EmbedPattern {
+	if !IsEmbedDirective(c) {
+		return nil
+	}
+	var patterns []EmbedPattern
+	text := c.Text
+	for i := len(embedPrefix); i < len(text); {
+		if text[i] == ' ' || text[i] == '\t' {
+			i++
+			continue
+		}
+		start := i
+		var pattern string
+		switch text[i] {
+		case '"', '`':
+			quote := text[i]
+			for i++; i < len(text) && text[i] != quote; i++ {
+				if quote == '"' && text[i] == '\\' {
+					i++
+				}
+			}
+			if i < len(text) {
+				i++ // closing quote
+			}
+			lit := text[start:i]
+			if s, err := strconv.Unquote(lit); err == nil {
+				pattern = s
+			} else {
+				pattern = strings.TrimLeft(lit, string(quote))
+			}
+		default:
+			for i < len(text) && text[i] != ' ' && text[i] != '\t' {
+				i++
+			}
+			pattern = text[start:i]
+		}
+		patterns = append(patterns, EmbedPattern{
+			Pattern: pattern,
+			Pos:     c.Pos() + token.Pos(start),
+			End:     c.Pos() + token.Pos(i),
+			Quoted:  text[start] == '"' || text[start] == '`',
+		})
+	}
+	return patterns
+}
+
+// errNoEmbedMatch is returned by embedFiles when a pattern does not match any
+// embeddable files. Its text matches the error reported by the go command.
+var errNoEmbedMatch = errors.New("no matching files found")
+
+// embedFiles returns the files matched by the //go:embed pattern relative to
+// the package directory dir, using the rules of the go command: directories
+// are embedded recursively, skipping files whose names begin with '.' or '_'
+// unless the pattern has the "all:" prefix, as well as nested modules.
+//
+// The patterns are matched against the files on disk.
+func embedFiles(dir, pattern string) ([]string, error) {
+	all := strings.HasPrefix(pattern, "all:")
+	pattern = strings.TrimPrefix(pattern, "all:")
+	if pattern == "." || !fs.ValidPath(pattern) {
+		return nil, fmt.Errorf("invalid pattern syntax")
+	}
+	matches, err := filepath.Glob(filepath.Join(dir, filepath.FromSlash(pattern)))
+	if err != nil {
+		return nil, err
+	}
+	var files []string
+	for _, match := range matches {
+		info, err := os.Stat(match)
+		if err != nil {
+			continue
+		}
+		if !info.IsDir() {
+			if info.Mode().IsRegular() {
+				files = append(files, match)
+			}
+			continue
+		}
+		var dirFiles []string
+		filepath.Walk(match, func(path string, info os.FileInfo, err error) error {
+			if err != nil {
+				return nil
+			}
+			if path == match {
+				return nil
+			}
+			name := info.Name()
+			if !all && (strings.HasPrefix(name, ".") || strings.HasPrefix(name, "_")) {
+				if info.IsDir() {
+					return filepath.SkipDir
+				}
+				return nil
+			}
+			if info.IsDir() {
+				if _, err := os.Stat(filepath.Join(path, "go.mod")); err == nil {
+					return filepath.SkipDir // a nested module
+				}
+				return nil
+			}
+			if info.Mode().IsRegular() {
+				dirFiles = append(dirFiles, path)
+			}
+			return nil
+		})
+		if len(dirFiles) == 0 {
+			rel, _ := filepath.Rel(dir, match)
+			return nil, fmt.Errorf("cannot embed directory %s: contains no embeddable files", filepath.ToSlash(rel))
+		}
+		files = append(files, dirFiles...)
+	}
+	if len(files) == 0 {
+		return nil, errNoEmbedMatch
+	}
+	return files, nil
+}
+
+// EmbedDiagnostics reports the //go:embed patterns in pkg that do not match
+// any files.
+func EmbedDiagnostics(ctx context.Context, snapshot Snapshot, pkg Package) (map[span.URI][]*Diagnostic, error) {
+	ctx, done := event.Start(ctx, "source.EmbedDiagnostics")
+	defer done()
+
+	reports := make(map[span.URI][]*Diagnostic)
+	for _, pgf := range pkg.CompiledGoFiles() {
+		dir := filepath.Dir(pgf.URI.Filename())
+		for _, group := range pgf.File.Comments {
+			for _, c := range group.List {
+				for _, p := range EmbedPatterns(c) {
+					_, err := embedFiles(dir, p.Pattern)
+					if err == nil {
+						continue
+					}
+					msg := fmt.Sprintf("pattern %s: %v", p.Pattern, err)
+					if !IsEmbedMatchError(msg) {
+						// Leave other errors, such as invalid patterns, to
+						// the go command.
+						continue
+					}
+					rng, err := NewMappedRange(snapshot.FileSet(), pgf.Mapper, p.Pos, p.End).Range()
+					if err != nil {
+						return nil, err
+					}
+					reports[pgf.URI] = append(reports[pgf.URI], &Diagnostic{
+						URI:      pgf.URI,
+						Range:    rng,
+						Severity: protocol.SeverityError,
+						Source:   EmbedError,
+						Message:  msg,
+					})
+				}
+			}
+		}
+	}
+	return reports, nil
+}
+
+// IsEmbedMatchError reports whether msg is an error reported by the go
+// command for a //go:embed pattern that does not match any files. Such errors
+// are reported by EmbedDiagnostics, which, unlike the go command, keeps up
+// with files being added to and removed from the package directory.
+func IsEmbedMatchError(msg string) bool {
+	return strings.HasPrefix(msg, "pattern ") &&
+		(strings.HasSuffix(msg, ": "+errNoEmbedMatch.Error()) || strings.HasSuffix(msg, ": contains no embeddable files"))
+}
+
+// EmbedDefinition returns the locations of the files embedded by the
+// //go:embed pattern at the given position. The boolean result reports
+// whether the position is within a //go:embed pattern.
+func EmbedDefinition(ctx context.Context, snapshot Snapshot, fh FileHandle, position protocol.Position) ([]protocol.Location, bool, error) {
+	ctx, done := event.Start(ctx, "source.EmbedDefinition")
+	defer done()
+
+	pgf, err := snapshot.ParseGo(ctx, fh, ParseFull)
+	if err != nil {
+		return nil, false, err
+	}
+	pos, err := pgf.Mapper.Pos(position)
+	if err != nil {
+		return nil, false, err
+	}
+	p, ok := embedPatternAt(pgf.File, pos)
+	if !ok {
+		return nil, false, nil
+	}
+	files, err := embedFiles(filepath.Dir(fh.URI().Filename()), p.Pattern)
+	if err != nil {
+		return nil, true, fmt.Errorf("pattern %s: %v", p.Pattern, err)
+	}
+	locations := make([]protocol.Location, 0, len(files))
+	for _, file := range files {
+		locations = append(locations, protocol.Location{
+			URI: protocol.URIFromPath(file),
+		})
+	}
+	return locations, true, nil
+}
+
+// embedPatternAt returns the //go:embed pattern of f enclosing pos.
+func embedPatternAt(f *ast.File, pos token.Pos) (EmbedPattern, bool) {
+	for _, group := range f.Comments {
+		if pos < group.Pos() || pos > group.End() {
+			continue
+		}
+		for _, c := range group.List {
+			for _, p := range EmbedPatterns(c) {
+				if p.Pos <= pos && pos <= p.End {
+					return p, true
+				}
+			}
+		}
+	}
+	return EmbedPattern{}, false
+}
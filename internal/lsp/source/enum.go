@@ -0,0 +1,232 @@
+// Copyright 2022 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package source
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/token"
+	"go/types"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/iansmith/golang-x-tools/go/ast/astutil"
+	"github.com/iansmith/golang-x-tools/internal/event"
+	"github.com/iansmith/golang-x-tools/internal/lsp/protocol"
+	"github.com/iansmith/golang-x-tools/internal/span"
+)
+
+// CanGenerateEnumMethods reports whether rng selects the name of a type
+// with constants, or of one of the constants, for which
+// GenerateEnumMethods can generate methods, and returns the name of the
+// type.
+func CanGenerateEnumMethods(pkg Package, file *ast.File, rng span.Range) (string, bool) {
+	named, err := selectedEnum(pkg, file, rng)
+	if err != nil {
+		return "", false
+	}
+	return named.Obj().Name(), true
+}
+
+// selectedEnum returns the type whose name, or the name of one of whose
+// constants, rng selects. It is an integer type declared at package level
+// in pkg.
+func selectedEnum(pkg Package, file *ast.File, rng span.Range) (*types.Named, error) {
+	path, _ := astutil.PathEnclosingInterval(file, rng.Start, rng.End)
+	if len(path) == 0 {
+		return nil, errors.New("no name selected")
+	}
+	id, ok := path[0].(*ast.Ident)
+	if !ok || !selectsIdent(rng, id) {
+		return nil, errors.New("no name selected")
+	}
+	var typ types.Type
+	switch obj := pkg.GetTypesInfo().ObjectOf(id).(type) {
+	case *types.TypeName:
+		typ = obj.Type()
+	case *types.Const:
+		typ = obj.Type()
+	default:
+		return nil, errors.New("no type or constant selected")
+	}
+	named, ok := typ.(*types.Named)
+	if !ok {
+		return nil, errors.New("no named type selected")
+	}
+	obj := named.Obj()
+	if obj.Pkg() != pkg.GetTypes() || obj.Parent() != pkg.GetTypes().Scope() {
+		return nil, fmt.Errorf("%s is not declared at package level", obj.Name())
+	}
+	basic, ok := named.Underlying().(*types.Basic)
+	if !ok || basic.Info()&types.IsInteger == 0 {
+		return nil, fmt.Errorf("%s is not an integer type", obj.Name())
+	}
+	if len(enumConsts(pkg.GetTypes(), named)) == 0 {
+		return nil, fmt.Errorf("%s has no constants", obj.Name())
+	}
+	return named, nil
+}
+
+// enumConsts returns the constants of type named declared at package
+// level in pkg, in the order of their declarations.
+func enumConsts(pkg *types.Package, named *types.Named) []*types.Const {
+	var consts []*types.Const
+	scope := pkg.Scope()
+	for _, name := range scope.Names() {
+		if c, ok := scope.Lookup(name).(*types.Const); ok && types.Identical(c.Type(), named) {
+			consts = append(consts, c)
+		}
+	}
+	sort.Slice(consts, func(i, j int) bool { return consts[i].Pos() < consts[j].Pos() })
+	return consts
+}
+
+// GenerateEnumMethods returns the URI of the file <type>_string.go in the
+// directory of fh, and the edits that write to it the methods of the type
+// whose name, or the name of one of whose constants, pRng selects: a
+// String method and a MarshalText method returning the names of its
+// constants, and a Parse<type> function returning the constant with a
+// name, except for those that are declared elsewhere. If the file does not
+// exist, the edits are to an empty file, which the caller must create;
+// otherwise it must be a generated file, which the edits replace.
+func GenerateEnumMethods(ctx context.Context, snapshot Snapshot, fh VersionedFileHandle, pRng protocol.Range) (span.URI, []protocol.TextEdit, error) {
+	ctx, done := event.Start(ctx, "source.GenerateEnumMethods")
+	defer done()
+
+	pkg, pgf, err := GetParsedFile(ctx, snapshot, fh, NarrowestPackage)
+	if err != nil {
+		return "", nil, fmt.Errorf("GetParsedFile: %w", err)
+	}
+	rng, err := pgf.Mapper.RangeToSpanRange(pRng)
+	if err != nil {
+		return "", nil, err
+	}
+	named, err := selectedEnum(pkg, pgf.File, rng)
+	if err != nil {
+		return "", nil, err
+	}
+	name := named.Obj().Name()
+	fset := snapshot.FileSet()
+	dir := filepath.Dir(fset.Position(named.Obj().Pos()).Filename)
+	uri := span.URIFromPath(filepath.Join(dir, strings.ToLower(name)+"_string.go"))
+
+	// The file is replaced if it exists.
+	genFH, err := snapshot.GetFile(ctx, uri)
+	if err != nil {
+		return "", nil, err
+	}
+	var genPGF *ParsedGoFile
+	if _, err := genFH.Read(); err == nil {
+		if !IsGenerated(ctx, snapshot, uri) {
+			return "", nil, fmt.Errorf("%s exists and is not generated", filepath.Base(uri.Filename()))
+		}
+		genPGF, err = snapshot.ParseGo(ctx, genFH, ParseFull)
+		if err != nil {
+			return "", nil, err
+		}
+	}
+	declaredElsewhere := func(obj types.Object) bool {
+		return obj != nil && fset.Position(obj.Pos()).Filename != uri.Filename()
+	}
+	stringMethod, _, _ := types.LookupFieldOrMethod(named, false, pkg.GetTypes(), "String")
+	marshalMethod, _, _ := types.LookupFieldOrMethod(named, false, pkg.GetTypes(), "MarshalText")
+	parseFunc := pkg.GetTypes().Scope().Lookup(parseFuncName(name))
+	var (
+		genString  = !declaredElsewhere(stringMethod)
+		genMarshal = !declaredElsewhere(marshalMethod)
+		genParse   = !declaredElsewhere(parseFunc)
+	)
+	if !genString && !genMarshal && !genParse {
+		return "", nil, fmt.Errorf("%s already has the methods", name)
+	}
+
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "// Code generated by gopls; DO NOT EDIT.\n\npackage %s\n\n", pkg.GetTypes().Name())
+	switch {
+	case genString && (genMarshal || genParse):
+		b.WriteString("import (\n\t\"fmt\"\n\t\"strconv\"\n)\n")
+	case genString:
+		b.WriteString("import \"strconv\"\n")
+	default:
+		b.WriteString("import \"fmt\"\n")
+	}
+	writeEnumMethods(&b, named, enumConsts(pkg.GetTypes(), named), genString, genMarshal, genParse)
+	src, err := format.Source(b.Bytes())
+	if err != nil {
+		return "", nil, fmt.Errorf("formatting the methods: %v", err)
+	}
+	if genPGF == nil {
+		return uri, []protocol.TextEdit{{NewText: string(src)}}, nil
+	}
+	edits, err := computeTextEdits(ctx, snapshot, genPGF, string(src))
+	if err != nil {
+		return "", nil, err
+	}
+	return uri, edits, nil
+}
+
+// writeEnumMethods writes the String and MarshalText methods of named, and
+// its Parse function, as requested, to b, for its constants consts. The
+// methods use the name of the first constant of each value.
+func writeEnumMethods(b *bytes.Buffer, named *types.Named, consts []*types.Const, genString, genMarshal, genParse bool) {
+	name := named.Obj().Name()
+	recv := receiverVarName(named)
+	var (
+		seen   = make(map[string]bool)
+		unique []*types.Const // the first constant of each value
+	)
+	for _, c := range consts {
+		if c.Name() == "_" {
+			continue
+		}
+		if v := c.Val().ExactString(); !seen[v] {
+			seen[v] = true
+			unique = append(unique, c)
+		}
+	}
+	formatValue := "strconv.FormatInt(int64(%s), 10)"
+	if named.Underlying().(*types.Basic).Info()&types.IsUnsigned != 0 {
+		formatValue = "strconv.FormatUint(uint64(%s), 10)"
+	}
+
+	if genString {
+		fmt.Fprintf(b, "\n// String returns the name of %s.\n", recv)
+		fmt.Fprintf(b, "func (%s %s) String() string {\n\tswitch %s {\n", recv, name, recv)
+		for _, c := range unique {
+			fmt.Fprintf(b, "\tcase %s:\n\t\treturn %q\n", c.Name(), c.Name())
+		}
+		fmt.Fprintf(b, "\t}\n\treturn %q + %s + \")\"\n}\n", name+"(", fmt.Sprintf(formatValue, recv))
+	}
+	if genMarshal {
+		fmt.Fprintf(b, "\n// MarshalText implements encoding.TextMarshaler, returning the name of %s.\n", recv)
+		fmt.Fprintf(b, "func (%s %s) MarshalText() ([]byte, error) {\n\tswitch %s {\n", recv, name, recv)
+		for _, c := range unique {
+			fmt.Fprintf(b, "\tcase %s:\n\t\treturn []byte(%q), nil\n", c.Name(), c.Name())
+		}
+		fmt.Fprintf(b, "\t}\n\treturn nil, fmt.Errorf(\"invalid %s %%d\", %s)\n}\n", name, recv)
+	}
+	if genParse {
+		parse := parseFuncName(name)
+		fmt.Fprintf(b, "\n// %s returns the %s named s.\n", parse, name)
+		fmt.Fprintf(b, "func %s(s string) (%s, error) {\n\tswitch s {\n", parse, name)
+		for _, c := range consts {
+			if c.Name() != "_" {
+				fmt.Fprintf(b, "\tcase %q:\n\t\treturn %s, nil\n", c.Name(), c.Name())
+			}
+		}
+		fmt.Fprintf(b, "\t}\n\treturn 0, fmt.Errorf(\"invalid %s %%q\", s)\n}\n", name)
+	}
+}
+
+// parseFuncName returns the name of the Parse function of the type name,
+// which is exported if the type is.
+func parseFuncName(name string) string {
+	return exportedName("Parse"+exportedName(name, true), token.IsExported(name))
+}
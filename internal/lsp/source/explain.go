@@ -0,0 +1,129 @@
+// Copyright 2022 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package source
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/iansmith/golang-x-tools/go/analysis"
+	"github.com/iansmith/golang-x-tools/internal/lsp/command"
+)
+
+// origins describes the sources of the diagnostics that are not reported by
+// analyzers.
+var origins = map[DiagnosticSource]string{
+	ListError:                "an error reported by go list while loading the package",
+	ParseError:               "a syntax error reported by the Go parser",
+	TypeError:                "a type error reported by the type checker",
+	ModTidyError:             "a difference between the go.mod file and the result of go mod tidy",
+	OptimizationDetailsError: "a decision of the compiler's optimizer, shown while the gc_details lens is toggled on for the package",
+	UpgradeNotification:      "an available upgrade of the module, found by the check_upgrades lens",
+	TemplateError:            "an error in the template file",
+	WorkFileError:            "an error in the go.work file",
+	TestResult:               "the result of a test run by the run_tests command",
+	CoverageInfo:             "a statement that the tests run with coverage did not cover",
+	UnusedSymbol:             "a symbol that no package of the workspace uses, found because of the unusedSymbols setting",
+	EmbedError:               "a //go:embed pattern that matches no file",
+	Vulnerability:            "a vulnerability found by govulncheck in a module that the package requires",
+	GoVersionError:           "a go directive in the go.mod file newer than the go command in use",
+}
+
+// ExplainDiagnostic describes the origin of the diagnostic d: the analyzer
+// that reported it, with the analyzers it requires and the facts they
+// derive, or the kind of error it comes from.
+func ExplainDiagnostic(options *Options, d *Diagnostic) command.DiagnosticExplanation {
+	e := command.DiagnosticExplanation{
+		Origin: origins[d.Source],
+		Code:   d.Code,
+		Href:   d.CodeHref,
+	}
+	if e.Origin == "" {
+		e.Origin = fmt.Sprintf("a diagnostic of unknown source %q", d.Source)
+	}
+	if d.Analyzer == nil {
+		return e
+	}
+	a := d.Analyzer.Analyzer
+	e.Analyzer = a.Name
+	e.Doc = docSummary(a.Doc)
+	if d.Source == TypeError {
+		e.Origin += fmt.Sprintf(", whose fixes the %s analyzer provides", a.Name)
+	} else {
+		e.Origin = fmt.Sprintf("reported by the %s analyzer", a.Name)
+	}
+	if e.Href == "" {
+		if _, ok := options.StaticcheckAnalyzers[a.Name]; ok {
+			e.Href = "https://staticcheck.io/docs/checks/#" + a.Name
+		} else {
+			e.Href = "https://github.com/golang/tools/blob/master/gopls/doc/analyzers.md#" + a.Name
+		}
+	}
+	seen := map[*analysis.Analyzer]bool{}
+	var visit func(a *analysis.Analyzer)
+	visit = func(a *analysis.Analyzer) {
+		if seen[a] {
+			return
+		}
+		seen[a] = true
+		for _, fact := range a.FactTypes {
+			e.Facts = append(e.Facts, fmt.Sprintf("%s: %T", a.Name, fact))
+		}
+		for _, req := range a.Requires {
+			e.Requires = append(e.Requires, req.Name)
+		}
+		for _, req := range a.Requires {
+			visit(req)
+		}
+	}
+	visit(a)
+	e.Requires = dedupe(e.Requires)
+	return e
+}
+
+// docSummary returns the first paragraph of the documentation of an
+// analyzer, on one line.
+func docSummary(doc string) string {
+	if i := strings.Index(doc, "\n\n"); i >= 0 {
+		doc = doc[:i]
+	}
+	return strings.Join(strings.Fields(doc), " ")
+}
+
+// dedupe returns the elements of list without the repetitions, in the order
+// of their first occurrence.
+func dedupe(list []string) []string {
+	var result []string
+	seen := map[string]bool{}
+	for _, s := range list {
+		if !seen[s] {
+			seen[s] = true
+			result = append(result, s)
+		}
+	}
+	return result
+}
+
+// FormatDiagnosticExplanation formats e as plain text, for the user.
+func FormatDiagnosticExplanation(e command.DiagnosticExplanation) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "This diagnostic is %s.", e.Origin)
+	if e.Code != "" {
+		fmt.Fprintf(&b, " Its code is %s.", e.Code)
+	}
+	if e.Doc != "" {
+		fmt.Fprintf(&b, "\n\n%s: %s", e.Analyzer, e.Doc)
+	}
+	if len(e.Requires) > 0 {
+		fmt.Fprintf(&b, "\n\nThe analyzer builds upon %s.", strings.Join(e.Requires, ", "))
+	}
+	if len(e.Facts) > 0 {
+		fmt.Fprintf(&b, " Facts derived from the dependencies of the package: %s.", strings.Join(e.Facts, ", "))
+	}
+	if e.Href != "" {
+		fmt.Fprintf(&b, "\n\nDocumentation: %s", e.Href)
+	}
+	return b.String()
+}
@@ -0,0 +1,65 @@
+// Copyright 2022 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package source
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/iansmith/golang-x-tools/go/analysis"
+	"github.com/iansmith/golang-x-tools/internal/lsp/command"
+)
+
+type testFact struct{}
+
+func (*testFact) AFact() {}
+
+func TestExplainDiagnostic(t *testing.T) {
+	base := &analysis.Analyzer{Name: "base", Doc: "base analyzer"}
+	facts := &analysis.Analyzer{
+		Name:      "facts",
+		Doc:       "facts analyzer",
+		Requires:  []*analysis.Analyzer{base},
+		FactTypes: []analysis.Fact{new(testFact)},
+	}
+	check := &analysis.Analyzer{
+		Name:     "check",
+		Doc:      "check reports\nsomething.\n\nMore details.",
+		Requires: []*analysis.Analyzer{facts, base},
+	}
+	options := &Options{}
+
+	got := ExplainDiagnostic(options, &Diagnostic{
+		Source:   AnalyzerErrorKind("check"),
+		Analyzer: &Analyzer{Analyzer: check},
+	})
+	want := command.DiagnosticExplanation{
+		Origin:   "reported by the check analyzer",
+		Analyzer: "check",
+		Doc:      "check reports something.",
+		Requires: []string{"facts", "base"},
+		Facts:    []string{"facts: *source.testFact"},
+		Href:     "https://github.com/golang/tools/blob/master/gopls/doc/analyzers.md#check",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ExplainDiagnostic(check) = %+v, want %+v", got, want)
+	}
+	text := FormatDiagnosticExplanation(got)
+	for _, s := range []string{"check analyzer", "facts, base", "*source.testFact", want.Href} {
+		if !strings.Contains(text, s) {
+			t.Errorf("FormatDiagnosticExplanation(check) = %q, missing %q", text, s)
+		}
+	}
+
+	got = ExplainDiagnostic(options, &Diagnostic{
+		Source:   TypeError,
+		Code:     "UnusedVar",
+		CodeHref: "https://pkg.go.dev/golang.org/x/tools/internal/typesinternal#UnusedVar",
+	})
+	if got.Origin != origins[TypeError] || got.Analyzer != "" || got.Code != "UnusedVar" || got.Href == "" {
+		t.Errorf("ExplainDiagnostic(type error) = %+v", got)
+	}
+}
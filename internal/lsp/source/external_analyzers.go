@@ -0,0 +1,274 @@
+// Copyright 2022 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package source
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"go/token"
+	"io"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"unicode"
+
+	"github.com/iansmith/golang-x-tools/internal/gocommand"
+	"github.com/iansmith/golang-x-tools/internal/lsp/protocol"
+	"github.com/iansmith/golang-x-tools/internal/lsp/safetoken"
+	"github.com/iansmith/golang-x-tools/internal/span"
+)
+
+// maxExternalResults bounds the number of results that an
+// ExternalAnalysisCache holds.
+const maxExternalResults = 256
+
+// An ExternalAnalysisCache runs the external analyzers of the
+// externalAnalyzers setting, and caches their diagnostics by the hash of the
+// inputs of the go vet invocation: the analyzer executable, the build
+// configuration, and the Go files of the package directory.
+type ExternalAnalysisCache struct {
+	mu      sync.Mutex
+	results map[string][]*vetDiagnostic // by key
+}
+
+// ExternalAnalyze returns the diagnostics that the external analyzers report
+// for the files of pkg, or none while a file of pkg has unsaved changes, as
+// the analyzers only see files on disk.
+func (c *ExternalAnalysisCache) ExternalAnalyze(ctx context.Context, snapshot Snapshot, pkg Package) (map[span.URI][]*Diagnostic, error) {
+	tools := snapshot.View().Options().ExternalAnalyzers
+	if len(tools) == 0 || len(pkg.CompiledGoFiles()) == 0 {
+		return nil, nil
+	}
+	for _, pgf := range pkg.CompiledGoFiles() {
+		if fh := snapshot.FindFile(pgf.URI); fh == nil || !fh.Saved() {
+			return nil, nil
+		}
+	}
+	dir := filepath.Dir(pkg.CompiledGoFiles()[0].URI.Filename())
+	reports := make(map[span.URI][]*Diagnostic)
+	var firstErr error
+	for _, tool := range tools {
+		diags, err := c.run(ctx, snapshot, dir, tool)
+		if err != nil {
+			if firstErr == nil {
+				firstErr = fmt.Errorf("external analyzer %s: %w", tool, err)
+			}
+			continue
+		}
+		for _, vd := range diags {
+			if d := vd.toDiagnostic(snapshot, pkg); d != nil {
+				reports[d.URI] = append(reports[d.URI], d)
+			}
+		}
+	}
+	return reports, firstErr
+}
+
+// run returns the diagnostics of the external analyzer tool for the
+// packages of dir, running it if they are not cached.
+func (c *ExternalAnalysisCache) run(ctx context.Context, snapshot Snapshot, dir, tool string) ([]*vetDiagnostic, error) {
+	path, err := exec.LookPath(tool)
+	if err != nil {
+		return nil, err
+	}
+	if path, err = filepath.Abs(path); err != nil {
+		return nil, err
+	}
+	key, err := externalAnalysisKey(ctx, snapshot, dir, path)
+	if err != nil {
+		return nil, err
+	}
+	c.mu.Lock()
+	diags, ok := c.results[key]
+	c.mu.Unlock()
+	if ok {
+		return diags, nil
+	}
+
+	var stderr bytes.Buffer
+	inv := &gocommand.Invocation{
+		Verb:       "vet",
+		Args:       []string{"-vettool=" + path, "-json", "."},
+		WorkingDir: dir,
+	}
+	if err := snapshot.RunGoCommandPiped(ctx, Normal, inv, ioutil.Discard, &stderr); err != nil {
+		return nil, err
+	}
+	diags, err = parseVetJSON(&stderr)
+	if err != nil {
+		return nil, err
+	}
+	c.mu.Lock()
+	if c.results == nil || len(c.results) >= maxExternalResults {
+		c.results = make(map[string][]*vetDiagnostic)
+	}
+	c.results[key] = diags
+	c.mu.Unlock()
+	return diags, nil
+}
+
+// externalAnalysisKey returns the hash of the inputs of the external analyzer
+// at path for the packages of dir.
+func externalAnalysisKey(ctx context.Context, snapshot Snapshot, dir, path string) (string, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return "", err
+	}
+	options := snapshot.View().Options()
+	h := sha256.New()
+	fmt.Fprintf(h, "tool %s %d %s\n", path, info.Size(), info.ModTime())
+	fmt.Fprintf(h, "dir %s\nflags %q\nenv %q\n", dir, options.BuildFlags, options.EnvSlice())
+	entries, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return "", err
+	}
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".go") {
+			continue
+		}
+		fh, err := snapshot.GetFile(ctx, span.URIFromPath(filepath.Join(dir, entry.Name())))
+		if err != nil {
+			return "", err
+		}
+		fmt.Fprintf(h, "file %s %s\n", entry.Name(), fh.FileIdentity().Hash)
+	}
+	return fmt.Sprintf("%x", h.Sum(nil)), nil
+}
+
+// A vetDiagnostic is a diagnostic in the JSON output of go vet -json, as
+// printed by unitchecker. The output has a position but no range, and no
+// suggested fixes.
+type vetDiagnostic struct {
+	Analyzer string `json:"-"`
+	Category string `json:"category,omitempty"`
+	Posn     string `json:"posn"`
+	Message  string `json:"message"`
+}
+
+// parseVetJSON parses the output of go vet -json: for each package, a
+// "# package" line followed by a JSON object mapping the package ID to the
+// name of each analyzer, to its diagnostics or {"error": message}.
+func parseVetJSON(r io.Reader) ([]*vetDiagnostic, error) {
+	// Drop the package lines to get a stream of JSON objects.
+	var data bytes.Buffer
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(nil, 1<<25)
+	for scanner.Scan() {
+		if line := scanner.Bytes(); !bytes.HasPrefix(line, []byte("#")) {
+			data.Write(line)
+			data.WriteByte('\n')
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	var diags []*vetDiagnostic
+	dec := json.NewDecoder(&data)
+	for dec.More() {
+		var tree map[string]map[string]json.RawMessage
+		if err := dec.Decode(&tree); err != nil {
+			return nil, fmt.Errorf("parsing the output of go vet: %v", err)
+		}
+		for _, results := range tree {
+			for analyzer, result := range results {
+				var vds []*vetDiagnostic
+				if err := json.Unmarshal(result, &vds); err != nil {
+					var e struct {
+						Error string `json:"error"`
+					}
+					if json.Unmarshal(result, &e) == nil && e.Error != "" {
+						return nil, fmt.Errorf("%s: %s", analyzer, e.Error)
+					}
+					return nil, fmt.Errorf("parsing the output of go vet: %v", err)
+				}
+				for _, vd := range vds {
+					vd.Analyzer = analyzer
+				}
+				diags = append(diags, vds...)
+			}
+		}
+	}
+	// The same diagnostics are reported for a package and its test variant.
+	sort.SliceStable(diags, func(i, j int) bool {
+		return diags[i].Posn < diags[j].Posn
+	})
+	var unique []*vetDiagnostic
+	for i, vd := range diags {
+		if i > 0 {
+			prev := diags[i-1]
+			if prev.Posn == vd.Posn && prev.Analyzer == vd.Analyzer && prev.Message == vd.Message {
+				continue
+			}
+		}
+		unique = append(unique, vd)
+	}
+	return unique, nil
+}
+
+// toDiagnostic returns the diagnostic of vd, if it is in a file of pkg.
+func (vd *vetDiagnostic) toDiagnostic(snapshot Snapshot, pkg Package) *Diagnostic {
+	s := span.Parse(vd.Posn)
+	pgf, err := pkg.File(s.URI())
+	if err != nil || !s.HasPosition() {
+		return nil
+	}
+	start, ok := externalPos(pgf, s.Start().Line(), s.Start().Column())
+	if !ok {
+		return nil
+	}
+	// Report the word at the position, as go vet reports no range.
+	offset, err := safetoken.Offset(pgf.Tok, start)
+	if err != nil {
+		return nil
+	}
+	for offset < len(pgf.Src) && isWordByte(pgf.Src[offset]) {
+		offset++
+	}
+	end := pgf.Tok.Pos(offset)
+	rng, err := NewMappedRange(snapshot.FileSet(), pgf.Mapper, start, end).Range()
+	if err != nil {
+		return nil
+	}
+	message := vd.Message
+	if vd.Category != "" {
+		message = vd.Category + ": " + message
+	}
+	return &Diagnostic{
+		URI:      pgf.URI,
+		Range:    rng,
+		Severity: protocol.SeverityWarning,
+		Source:   DiagnosticSource(vd.Analyzer),
+		Message:  message,
+	}
+}
+
+// externalPos returns the position of the 1-based line and byte column in
+// pgf.
+func externalPos(pgf *ParsedGoFile, line, col int) (token.Pos, bool) {
+	if line < 1 || line > pgf.Tok.LineCount() || col < 1 {
+		return token.NoPos, false
+	}
+	lineOffset, err := safetoken.Offset(pgf.Tok, pgf.Tok.LineStart(line))
+	if err != nil {
+		return token.NoPos, false
+	}
+	offset := lineOffset + col - 1
+	if offset > len(pgf.Src) {
+		return token.NoPos, false
+	}
+	return pgf.Tok.Pos(offset), true
+}
+
+func isWordByte(b byte) bool {
+	return b == '_' || b >= 0x80 || unicode.IsLetter(rune(b)) || unicode.IsDigit(rune(b))
+}
@@ -0,0 +1,75 @@
+// Copyright 2022 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package source
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseVetJSON(t *testing.T) {
+	const output = `# a
+{
+	"a": {
+		"findcall": [
+			{
+				"posn": "/tmp/a/a.go:4:11",
+				"message": "call of MyFunc123(...)"
+			}
+		]
+	}
+}
+# a [a.test]
+{
+	"a [a.test]": {
+		"findcall": [
+			{
+				"posn": "/tmp/a/a.go:4:11",
+				"message": "call of MyFunc123(...)"
+			},
+			{
+				"posn": "/tmp/a/a_test.go:6:2",
+				"category": "test",
+				"message": "call of MyFunc123(...)"
+			}
+		]
+	}
+}
+`
+	got, err := parseVetJSON(strings.NewReader(output))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []*vetDiagnostic{
+		{
+			Analyzer: "findcall",
+			Posn:     "/tmp/a/a.go:4:11",
+			Message:  "call of MyFunc123(...)",
+		},
+		{
+			Analyzer: "findcall",
+			Category: "test",
+			Posn:     "/tmp/a/a_test.go:6:2",
+			Message:  "call of MyFunc123(...)",
+		},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("parseVetJSON() = %+v, want %+v", got, want)
+	}
+
+	const failure = `# a
+{
+	"a": {
+		"findcall": {
+			"error": "analysis failed"
+		}
+	}
+}
+`
+	if _, err := parseVetJSON(strings.NewReader(failure)); err == nil || !strings.Contains(err.Error(), "analysis failed") {
+		t.Errorf("parseVetJSON(failure) = %v, want an error of the analyzer", err)
+	}
+}
@@ -0,0 +1,236 @@
+// Copyright 2022 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package source
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/token"
+	"go/types"
+	"sort"
+	"strings"
+
+	"github.com/iansmith/golang-x-tools/go/analysis"
+	"github.com/iansmith/golang-x-tools/go/ast/astutil"
+	"github.com/iansmith/golang-x-tools/internal/lsp/protocol"
+	"github.com/iansmith/golang-x-tools/internal/span"
+	"github.com/iansmith/golang-x-tools/internal/typeparams"
+)
+
+// CanExtractInterface reports whether rng selects the name of a type
+// in its declaration, or the names of one or more methods of a type in
+// their declarations, from which extractInterface can declare an
+// interface.
+func CanExtractInterface(pkg Package, file *ast.File, rng span.Range) bool {
+	_, _, _, _, err := interfaceMethods(pkg, file, rng)
+	return err == nil
+}
+
+// extractInterface declares an interface with the methods selected by
+// pRng, or with the exported methods of the type whose name it selects.
+// The interface is named after the type, and declared after the
+// declaration of the type, or before the first selected method.
+// Existing uses of the type are left unchanged.
+func extractInterface(ctx context.Context, snapshot Snapshot, fh VersionedFileHandle, pRng protocol.Range) (*analysis.SuggestedFix, error) {
+	pkg, pgf, err := GetParsedFile(ctx, snapshot, fh, NarrowestPackage)
+	if err != nil {
+		return nil, fmt.Errorf("GetParsedFile: %w", err)
+	}
+	rng, err := pgf.Mapper.RangeToSpanRange(pRng)
+	if err != nil {
+		return nil, err
+	}
+	obj, methods, pos, before, err := interfaceMethods(pkg, pgf.File, rng)
+	if err != nil {
+		return nil, fmt.Errorf("cannot extract interface: %v", err)
+	}
+
+	// Qualify the types of the methods by the names of the packages
+	// imported by the file, importing those that it does not import.
+	importNames := make(map[*types.Package]string)
+	for _, spec := range pgf.File.Imports {
+		obj := pkg.GetTypesInfo().Implicits[spec]
+		if spec.Name != nil {
+			obj = pkg.GetTypesInfo().Defs[spec.Name]
+		}
+		if pkgName, ok := obj.(*types.PkgName); ok && pkgName.Name() != "_" && pkgName.Name() != "." {
+			importNames[pkgName.Imported()] = pkgName.Name()
+		}
+	}
+	missing := make(map[string]string)
+	qual := func(p *types.Package) string {
+		if p == pkg.GetTypes() {
+			return ""
+		}
+		if name, ok := importNames[p]; ok {
+			return name
+		}
+		missing[p.Path()] = p.Name()
+		return p.Name()
+	}
+
+	scope := pkg.GetTypes().Scope()
+	name, _ := generateIdentifier(0, obj.Name()+"Interface", func(name string) bool {
+		return scope.Lookup(name) != nil
+	})
+	var b strings.Builder
+	fmt.Fprintf(&b, "type %s interface {\n", name)
+	for _, m := range methods {
+		if doc := methodDoc(pkg, m); doc != nil {
+			for _, c := range doc.List {
+				fmt.Fprintf(&b, "\t%s\n", c.Text)
+			}
+		}
+		sig := types.TypeString(m.Type(), qual)
+		fmt.Fprintf(&b, "\t%s%s\n", m.Name(), strings.TrimPrefix(sig, "func"))
+	}
+	b.WriteString("}")
+	text := "\n\n" + b.String()
+	if before {
+		text = b.String() + "\n\n"
+	}
+
+	edits, err := addImportEdits(ctx, snapshot, pgf, missing)
+	if err != nil {
+		return nil, err
+	}
+	edits = append(edits, analysis.TextEdit{Pos: pos, End: pos, NewText: []byte(text)})
+	return &analysis.SuggestedFix{TextEdits: edits}, nil
+}
+
+// interfaceMethods returns the type selected by rng and the methods of
+// the interface to extract from it, and the position at which to
+// declare the interface, which is before that position if before is
+// set and after it otherwise.
+func interfaceMethods(pkg Package, file *ast.File, rng span.Range) (_ *types.TypeName, _ []*types.Func, _ token.Pos, before bool, _ error) {
+	info := pkg.GetTypesInfo()
+	path, _ := astutil.PathEnclosingInterval(file, rng.Start, rng.End)
+	if len(path) > 2 {
+		if id, ok := path[0].(*ast.Ident); ok {
+			if spec, ok := path[1].(*ast.TypeSpec); ok && spec.Name == id {
+				obj, ok := info.Defs[id].(*types.TypeName)
+				if !ok {
+					return nil, nil, 0, false, errors.New("no type information")
+				}
+				named, err := concreteNamed(obj)
+				if err != nil {
+					return nil, nil, 0, false, err
+				}
+				var methods []*types.Func
+				exported := false
+				mset := types.NewMethodSet(types.NewPointer(named))
+				for i := 0; i < mset.Len(); i++ {
+					m := mset.At(i).Obj().(*types.Func)
+					if m.Exported() || m.Pkg() == pkg.GetTypes() {
+						methods = append(methods, m)
+						exported = exported || m.Exported()
+					}
+				}
+				if exported {
+					all := methods
+					methods = nil
+					for _, m := range all {
+						if m.Exported() {
+							methods = append(methods, m)
+						}
+					}
+				}
+				if len(methods) == 0 {
+					return nil, nil, 0, false, fmt.Errorf("%s has no methods", obj.Name())
+				}
+				sort.SliceStable(methods, func(i, j int) bool {
+					return methods[i].Pos() < methods[j].Pos()
+				})
+				return obj, methods, path[2].End(), false, nil
+			}
+		}
+	}
+
+	var recv *types.TypeName
+	var methods []*types.Func
+	var first *ast.FuncDecl
+	for _, decl := range file.Decls {
+		decl, ok := decl.(*ast.FuncDecl)
+		if !ok || decl.Recv == nil || !selectsIdent(rng, decl.Name) {
+			continue
+		}
+		fn, ok := info.Defs[decl.Name].(*types.Func)
+		if !ok {
+			continue
+		}
+		t := fn.Type().(*types.Signature).Recv().Type()
+		if ptr, ok := t.(*types.Pointer); ok {
+			t = ptr.Elem()
+		}
+		named, ok := t.(*types.Named)
+		if !ok {
+			continue
+		}
+		if recv != nil && named.Obj() != recv {
+			return nil, nil, 0, false, errors.New("the selected methods have different receiver types")
+		}
+		if _, err := concreteNamed(named.Obj()); err != nil {
+			return nil, nil, 0, false, err
+		}
+		recv = named.Obj()
+		methods = append(methods, fn)
+		if first == nil {
+			first = decl
+		}
+	}
+	if recv == nil {
+		return nil, nil, 0, false, errors.New("no type or methods selected")
+	}
+	pos := first.Pos()
+	if first.Doc != nil {
+		pos = first.Doc.Pos()
+	}
+	return recv, methods, pos, true, nil
+}
+
+// concreteNamed returns the type named by obj, which must be a
+// non-generic named type that is not an interface.
+func concreteNamed(obj *types.TypeName) (*types.Named, error) {
+	named, ok := obj.Type().(*types.Named)
+	if !ok || obj.IsAlias() {
+		return nil, fmt.Errorf("%s is not a named type", obj.Name())
+	}
+	if types.IsInterface(named) {
+		return nil, fmt.Errorf("%s is an interface", obj.Name())
+	}
+	if typeparams.ForNamed(named).Len() > 0 {
+		return nil, fmt.Errorf("%s is generic", obj.Name())
+	}
+	return named, nil
+}
+
+// selectsIdent reports whether rng selects id: if rng is empty, whether
+// it is within id, and otherwise whether it contains id.
+func selectsIdent(rng span.Range, id *ast.Ident) bool {
+	if rng.Start == rng.End {
+		return NodeContains(id, rng.Start)
+	}
+	return rng.Start <= id.Pos() && id.End() <= rng.End
+}
+
+// methodDoc returns the doc comment of the declaration of m in pkg, or nil.
+func methodDoc(pkg Package, m *types.Func) *ast.CommentGroup {
+	if m.Pkg() != pkg.GetTypes() {
+		return nil
+	}
+	for _, pgf := range pkg.CompiledGoFiles() {
+		if !NodeContains(pgf.File, m.Pos()) {
+			continue
+		}
+		for _, decl := range pgf.File.Decls {
+			if decl, ok := decl.(*ast.FuncDecl); ok && decl.Name.Pos() == m.Pos() {
+				return decl.Doc
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,159 @@
+// Copyright 2022 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package source
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strings"
+
+	"github.com/iansmith/golang-x-tools/internal/lsp/protocol"
+	"github.com/iansmith/golang-x-tools/internal/span"
+)
+
+// utf8BOM is the UTF-8 encoding of the byte order mark.
+const utf8BOM = "\uFEFF"
+
+// PreserveFileFormat adjusts edits of content so that they keep its
+// format: the new text of the edits of a file with CRLF line endings ends
+// its lines with CRLF too, and an edit that would delete the byte order
+// mark of content keeps it.
+func PreserveFileFormat(content []byte, edits []protocol.TextEdit) []protocol.TextEdit {
+	crlf := usesCRLF(content)
+	bom := bytes.HasPrefix(content, []byte(utf8BOM))
+	if !crlf && !bom {
+		return edits
+	}
+	bomStart := protocol.Position{}
+	bomEnd := protocol.Position{Character: 1} // the BOM is a UTF-16 unit
+	var result []protocol.TextEdit
+	for _, edit := range edits {
+		if crlf {
+			edit.NewText = toCRLF(edit.NewText)
+		}
+		if bom && edit.Range.Start == bomStart && edit.Range.End != bomStart && !strings.HasPrefix(edit.NewText, utf8BOM) {
+			edit.NewText = utf8BOM + edit.NewText
+			if edit.Range.End == bomEnd && edit.NewText == utf8BOM {
+				continue // the edit only deleted the BOM
+			}
+		}
+		result = append(result, edit)
+	}
+	return result
+}
+
+// usesCRLF reports whether the first line of content ends with CRLF.
+func usesCRLF(content []byte) bool {
+	i := bytes.IndexByte(content, '\n')
+	return i > 0 && content[i-1] == '\r'
+}
+
+// toCRLF replaces the LF line endings of text with CRLF.
+func toCRLF(text string) string {
+	if !strings.Contains(text, "\n") {
+		return text
+	}
+	var buf strings.Builder
+	for i := 0; i < len(text); i++ {
+		if text[i] == '\n' && (i == 0 || text[i-1] != '\r') {
+			buf.WriteByte('\r')
+		}
+		buf.WriteByte(text[i])
+	}
+	return buf.String()
+}
+
+// A FileWrite is the new content of a file.
+type FileWrite struct {
+	URI     span.URI
+	Content []byte
+}
+
+// An UnwritableFile is a file that could not be modified.
+type UnwritableFile struct {
+	URI span.URI
+	Err error
+}
+
+// A WriteFilesError lists the files that WriteFiles could not modify.
+type WriteFilesError struct {
+	Files []UnwritableFile
+}
+
+func (e *WriteFilesError) Error() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "could not modify %d file(s):", len(e.Files))
+	for _, f := range e.Files {
+		fmt.Fprintf(&b, "\n\t%s: %v", f.URI.Filename(), f.Err)
+	}
+	return b.String()
+}
+
+// WriteFiles writes the new contents of files, keeping the permissions of
+// those that exist. If backupSuffix is not empty, the original of each
+// file is first renamed with this suffix.
+//
+// WriteFiles modifies no file unless all of them can be modified: it
+// returns a *WriteFilesError listing the files that are read-only or
+// otherwise not writable. It also lists the files whose write fails
+// after the others were written.
+func WriteFiles(files []FileWrite, backupSuffix string) error {
+	modes := make([]os.FileMode, len(files))
+	var unwritable []UnwritableFile
+	for i, f := range files {
+		mode, err := writableMode(f.URI.Filename())
+		if err != nil {
+			unwritable = append(unwritable, UnwritableFile{URI: f.URI, Err: err})
+		}
+		modes[i] = mode
+	}
+	if len(unwritable) > 0 {
+		return &WriteFilesError{Files: unwritable}
+	}
+	for i, f := range files {
+		filename := f.URI.Filename()
+		if backupSuffix != "" {
+			if err := os.Rename(filename, filename+backupSuffix); err != nil && !os.IsNotExist(err) {
+				unwritable = append(unwritable, UnwritableFile{URI: f.URI, Err: err})
+				continue
+			}
+		}
+		if err := ioutil.WriteFile(filename, f.Content, modes[i]); err != nil {
+			unwritable = append(unwritable, UnwritableFile{URI: f.URI, Err: err})
+		}
+	}
+	if len(unwritable) > 0 {
+		return &WriteFilesError{Files: unwritable}
+	}
+	return nil
+}
+
+// writableMode returns the permissions of the file filename, or those of
+// a new file if it doesn't exist, or an error if it can't be modified.
+func writableMode(filename string) (os.FileMode, error) {
+	info, err := os.Stat(filename)
+	if os.IsNotExist(err) {
+		return 0644, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	if info.IsDir() {
+		return 0, errors.New("is a directory")
+	}
+	// The permissions are checked first, as they don't apply to root.
+	if info.Mode().Perm()&0222 == 0 {
+		return 0, errors.New("file is read-only")
+	}
+	f, err := os.OpenFile(filename, os.O_WRONLY, 0)
+	if err != nil {
+		return 0, err
+	}
+	f.Close()
+	return info.Mode().Perm(), nil
+}
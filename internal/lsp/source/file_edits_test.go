@@ -0,0 +1,112 @@
+// Copyright 2022 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package source
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/iansmith/golang-x-tools/internal/lsp/diff"
+	"github.com/iansmith/golang-x-tools/internal/lsp/protocol"
+	"github.com/iansmith/golang-x-tools/internal/span"
+)
+
+func TestPreserveFileFormat(t *testing.T) {
+	for _, test := range []struct {
+		name, content string
+		edits         []protocol.TextEdit
+		want          string
+	}{
+		{
+			name:    "LF",
+			content: "package a\n",
+			edits:   []protocol.TextEdit{{Range: linesRange(1, 1), NewText: "\nvar x int\n"}},
+			want:    "package a\n\nvar x int\n",
+		},
+		{
+			name:    "CRLF",
+			content: "package a\r\n",
+			edits:   []protocol.TextEdit{{Range: linesRange(1, 1), NewText: "\nvar x int\r\n"}},
+			want:    "package a\r\n\r\nvar x int\r\n",
+		},
+		{
+			name:    "replaced BOM",
+			content: "\uFEFFpackage  a\n",
+			edits:   []protocol.TextEdit{{Range: linesRange(0, 1), NewText: "package a\n"}},
+			want:    "\uFEFFpackage a\n",
+		},
+		{
+			name:    "deleted BOM",
+			content: "\uFEFFpackage a\n",
+			edits: []protocol.TextEdit{{Range: protocol.Range{
+				End: protocol.Position{Character: 1},
+			}}},
+			want: "\uFEFFpackage a\n",
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			uri := span.URIFromPath("a.go")
+			m := protocol.NewColumnMapper(uri, []byte(test.content))
+			edits, err := FromProtocolEdits(m, PreserveFileFormat([]byte(test.content), test.edits))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := diff.ApplyEdits(test.content, edits); got != test.want {
+				t.Errorf("edited content = %q, want %q", got, test.want)
+			}
+		})
+	}
+}
+
+// linesRange returns the range of the lines from start to end, excluded.
+func linesRange(start, end uint32) protocol.Range {
+	return protocol.Range{
+		Start: protocol.Position{Line: start},
+		End:   protocol.Position{Line: end},
+	}
+}
+
+func TestWriteFiles(t *testing.T) {
+	dir, err := ioutil.TempDir("", "gopls-write")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	writable := filepath.Join(dir, "writable.go")
+	readOnly := filepath.Join(dir, "read_only.go")
+	for filename, mode := range map[string]os.FileMode{writable: 0600, readOnly: 0444} {
+		if err := ioutil.WriteFile(filename, []byte("package a\n"), mode); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// No file is modified if one is read-only.
+	err = WriteFiles([]FileWrite{
+		{URI: span.URIFromPath(writable), Content: []byte("package b\n")},
+		{URI: span.URIFromPath(readOnly), Content: []byte("package b\n")},
+	}, "")
+	var werr *WriteFilesError
+	if !errors.As(err, &werr) || len(werr.Files) != 1 || werr.Files[0].URI.Filename() != readOnly {
+		t.Fatalf("WriteFiles with a read-only file returned %v, want an error listing %s", err, readOnly)
+	}
+	if got, _ := ioutil.ReadFile(writable); string(got) != "package a\n" {
+		t.Errorf("WriteFiles modified %s despite the read-only file", writable)
+	}
+
+	// The permissions of the files are kept.
+	if err := WriteFiles([]FileWrite{{URI: span.URIFromPath(writable), Content: []byte("package b\n")}}, ""); err != nil {
+		t.Fatal(err)
+	}
+	info, err := os.Stat(writable)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Mode().Perm() != 0600 {
+		t.Errorf("mode of %s after WriteFiles = %v, want %v", writable, info.Mode().Perm(), os.FileMode(0600))
+	}
+}
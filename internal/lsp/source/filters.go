@@ -0,0 +1,146 @@
+// Copyright 2022 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package source
+
+import (
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// A Filterer applies the directoryFilters option to paths relative to a
+// workspace folder.
+//
+// Each filter is an operator, '+' to include or '-' to exclude, followed by
+// a pattern. A pattern is a slash-separated path relative to the workspace
+// folder, whose segments may be "**" to match any number of segments,
+// including none. A pattern matches the directories it names and all of
+// their descendants: "-a" excludes a and a/b, and "-**/node_modules"
+// excludes node_modules and x/y/node_modules/z. The empty pattern matches
+// every path.
+//
+// Filters are evaluated in order, and the last one that matches a path
+// decides whether it is included. So later filters override earlier ones:
+// with "-a", "+a/b", a/c is excluded but a/b/c is included, whereas with
+// "+a/b", "-a", both are excluded. Paths that no filter matches are
+// included.
+type Filterer struct {
+	filters []dirFilter
+}
+
+type dirFilter struct {
+	exclude bool
+	re      *regexp.Regexp // matches the path, followed by a slash
+	literal string         // the segments of the pattern before the first "**"
+	glob    bool           // whether the pattern has a "**" segment
+}
+
+// NewFilterer returns the Filterer of the given filters, which must be
+// valid according to ValidateDirectoryFilter.
+func NewFilterer(filters []string) *Filterer {
+	f := &Filterer{}
+	for _, filter := range filters {
+		op, pattern := filter[0], strings.Trim(filepath.ToSlash(filter[1:]), "/")
+		df := dirFilter{exclude: op == '-'}
+		var re strings.Builder
+		re.WriteString("^")
+		literal := true
+		if pattern != "" {
+			for _, seg := range strings.Split(pattern, "/") {
+				if seg == "**" {
+					// Zero or more segments.
+					re.WriteString("(?:[^/]*/)*")
+					literal = false
+					df.glob = true
+					continue
+				}
+				re.WriteString(regexp.QuoteMeta(seg) + "/")
+				if literal {
+					df.literal += seg + "/"
+				}
+			}
+		}
+		df.literal = strings.TrimSuffix(df.literal, "/")
+		df.re = regexp.MustCompile(re.String())
+		f.filters = append(f.filters, df)
+	}
+	return f
+}
+
+// ValidateDirectoryFilter returns an error if filter is not a valid
+// directory filter.
+func ValidateDirectoryFilter(filter string) error {
+	if filter == "" || (filter[0] != '+' && filter[0] != '-') {
+		return fmt.Errorf("invalid filter %q, must start with + or -", filter)
+	}
+	for _, seg := range strings.Split(filter[1:], "/") {
+		if seg != "**" && strings.Contains(seg, "**") {
+			return fmt.Errorf("invalid filter %q, ** must be a whole path segment", filter)
+		}
+	}
+	return nil
+}
+
+// Disallow reports whether path, relative to the workspace folder, is
+// excluded by the filters.
+func (f *Filterer) Disallow(path string) bool {
+	path = strings.Trim(filepath.ToSlash(path), "/")
+	if path != "" {
+		path += "/"
+	}
+	var excluded bool
+	for _, filter := range f.filters {
+		if filter.re.MatchString(path) {
+			excluded = filter.exclude
+		}
+	}
+	return excluded
+}
+
+// Uniform reports whether all the descendants of the directory path,
+// relative to the workspace folder, are certainly included or excluded just
+// like path itself. It may report false for trees that turn out to be
+// uniform, such as when a "**" pattern matches none of their directories.
+func (f *Filterer) Uniform(path string) bool {
+	path = strings.Trim(filepath.ToSlash(path), "/")
+	slashed := path
+	if slashed != "" {
+		slashed += "/"
+	}
+	// The filters that match path match all of its descendants, so only the
+	// filters after the last of them may change the status of descendants.
+	var excluded bool
+	last := -1
+	for i, filter := range f.filters {
+		if filter.re.MatchString(slashed) {
+			excluded = filter.exclude
+			last = i
+		}
+	}
+	for _, filter := range f.filters[last+1:] {
+		if filter.exclude == excluded {
+			continue
+		}
+		// A filter may only match descendants of path if it names a
+		// directory below path, or if it is a glob that may match below path.
+		if inDir(filter.literal, path) {
+			return false
+		}
+		if filter.glob && (filter.literal == path || inDir(path, filter.literal)) {
+			return false
+		}
+	}
+	return true
+}
+
+// inDir reports whether the relative path is strictly inside the relative
+// directory dir.
+func inDir(path, dir string) bool {
+	if dir == "" {
+		return path != ""
+	}
+	return strings.HasPrefix(path, dir+"/")
+}
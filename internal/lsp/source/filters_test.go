@@ -0,0 +1,54 @@
+// Copyright 2022 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package source
+
+import "testing"
+
+func TestFiltererUniform(t *testing.T) {
+	tests := []struct {
+		filters    []string
+		uniform    []string
+		nonUniform []string
+	}{
+		{
+			filters:    []string{"-node_modules"},
+			uniform:    []string{"a", "a/b", "node_modules", "node_modules/a"},
+			nonUniform: []string{""},
+		},
+		{
+			filters:    []string{"-", "+a/b"},
+			uniform:    []string{"c", "a/c", "a/b", "a/b/c"},
+			nonUniform: []string{"", "a"},
+		},
+		{
+			filters:    []string{"-**/node_modules"},
+			uniform:    []string{"node_modules", "a/node_modules", "a/node_modules/b"},
+			nonUniform: []string{"", "a", "a/b"},
+		},
+		{
+			filters:    []string{"-a/**/gen"},
+			uniform:    []string{"b", "b/gen", "a/gen"},
+			nonUniform: []string{"", "a", "a/b"},
+		},
+		{
+			filters:    []string{"-**/gen", "+a"},
+			uniform:    []string{"a", "a/b/gen"},
+			nonUniform: []string{"", "b"},
+		},
+	}
+	for _, test := range tests {
+		f := NewFilterer(test.filters)
+		for _, path := range test.uniform {
+			if !f.Uniform(path) {
+				t.Errorf("filters %q: Uniform(%q) = false, want true", test.filters, path)
+			}
+		}
+		for _, path := range test.nonUniform {
+			if f.Uniform(path) {
+				t.Errorf("filters %q: Uniform(%q) = true, want false", test.filters, path)
+			}
+		}
+	}
+}
@@ -30,22 +30,54 @@ type (
 )
 
 const (
-	FillStruct      = "fill_struct"
-	StubMethods     = "stub_methods"
-	UndeclaredName  = "undeclared_name"
-	ExtractVariable = "extract_variable"
-	ExtractFunction = "extract_function"
-	ExtractMethod   = "extract_method"
+	FillStruct          = "fill_struct"
+	StubMethods         = "stub_methods"
+	UndeclaredName      = "undeclared_name"
+	ExtractVariable     = "extract_variable"
+	ExtractFunction     = "extract_function"
+	ExtractMethod       = "extract_method"
+	ExtractInterface    = "extract_interface"
+	InlineCall          = "inline_call"
+	WrapError           = "wrap_error"
+	NameStruct          = "name_struct"
+	ToggleReceiver      = "toggle_receiver"
+	SafeDelete          = "safe_delete"
+	IfToSwitch          = "if_to_switch"
+	SwitchToIf          = "switch_to_if"
+	InvertIf            = "invert_if"
+	MergeIfs            = "merge_ifs"
+	ConcatToSprintf     = "concat_to_sprintf"
+	SprintfToConcat     = "sprintf_to_concat"
+	GenerateConstructor = "generate_constructor"
+	GenerateOptions     = "generate_options"
+	GenerateGetters     = "generate_getters"
+	GenerateSetters     = "generate_setters"
 )
 
 // suggestedFixes maps a suggested fix command id to its handler.
 var suggestedFixes = map[string]SuggestedFixFunc{
-	FillStruct:      singleFile(fillstruct.SuggestedFix),
-	UndeclaredName:  singleFile(undeclaredname.SuggestedFix),
-	ExtractVariable: singleFile(extractVariable),
-	ExtractFunction: singleFile(extractFunction),
-	ExtractMethod:   singleFile(extractMethod),
-	StubMethods:     stubSuggestedFixFunc,
+	FillStruct:          singleFile(fillstruct.SuggestedFix),
+	UndeclaredName:      singleFile(undeclaredname.SuggestedFix),
+	ExtractVariable:     singleFile(extractVariable),
+	ExtractFunction:     singleFile(extractFunction),
+	ExtractMethod:       singleFile(extractMethod),
+	ExtractInterface:    extractInterface,
+	InlineCall:          inlineCall,
+	StubMethods:         stubSuggestedFixFunc,
+	WrapError:           wrapErrorFix,
+	NameStruct:          nameStruct,
+	ToggleReceiver:      toggleReceiver,
+	SafeDelete:          safeDelete,
+	IfToSwitch:          singleFile(ifToSwitch),
+	SwitchToIf:          singleFile(switchToIf),
+	InvertIf:            singleFile(invertIf),
+	MergeIfs:            singleFile(mergeIfs),
+	ConcatToSprintf:     concatToSprintf,
+	SprintfToConcat:     sprintfToConcat,
+	GenerateConstructor: singleFile(generateConstructor),
+	GenerateOptions:     singleFile(generateOptions),
+	GenerateGetters:     singleFile(generateGetters),
+	GenerateSetters:     singleFile(generateSetters),
 }
 
 // singleFile calls analyzers that expect inputs for a single file
@@ -81,6 +113,11 @@ func ApplyFix(ctx context.Context, fix string, snapshot Snapshot, fh VersionedFi
 	if suggestion == nil {
 		return nil, nil
 	}
+	return suggestedFixEdits(ctx, snapshot, suggestion)
+}
+
+// suggestedFixEdits returns the edits of suggestion, by file.
+func suggestedFixEdits(ctx context.Context, snapshot Snapshot, suggestion *analysis.SuggestedFix) ([]protocol.TextDocumentEdit, error) {
 	fset := snapshot.FileSet()
 	editsPerFile := map[span.URI]*protocol.TextDocumentEdit{}
 	for _, edit := range suggestion.TextEdits {
@@ -112,10 +149,10 @@ func ApplyFix(ctx context.Context, fix string, snapshot Snapshot, fh VersionedFi
 		if err != nil {
 			return nil, err
 		}
-		te.Edits = append(te.Edits, protocol.TextEdit{
+		te.Edits = append(te.Edits, protocol.AnnotatedTextEdit{TextEdit: protocol.TextEdit{
 			Range:   rng,
 			NewText: string(edit.NewText),
-		})
+		}})
 	}
 	var edits []protocol.TextDocumentEdit
 	for _, edit := range editsPerFile {
@@ -45,6 +45,11 @@ func FoldingRange(ctx context.Context, snapshot Snapshot, fh FileHandle, lineFol
 
 	// Get folding ranges for comments separately as they are not walked by ast.Inspect.
 	ranges = append(ranges, commentsFoldingRange(fset, pgf.Mapper, pgf.File)...)
+	if rng := headerFoldingRange(fset, pgf.Mapper, pgf.File); rng != nil {
+		ranges = append(ranges, rng)
+	}
+	ranges = append(ranges, regionsFoldingRange(fset, pgf.Mapper, pgf.File)...)
+	ranges = append(ranges, importSectionsFoldingRange(fset, pgf.Mapper, pgf.File)...)
 
 	visit := func(n ast.Node) bool {
 		rng := foldingRangeFunc(fset, pgf.Mapper, n, lineFoldingOnly)
@@ -167,19 +172,116 @@ func commentsFoldingRange(fset *token.FileSet, m *protocol.ColumnMapper, file *a
 			continue
 		}
 
-		firstComment := commentGrp.List[0]
-		startPos, endLinePos := firstComment.Pos(), firstComment.End()
-		startCmmnt, endCmmnt := fset.Position(startPos), fset.Position(endLinePos)
-		if startCmmnt.Line != endCmmnt.Line {
-			// If the first comment spans multiple lines, then we want to have the
-			// folding range start at the end of the first line.
-			endLinePos = token.Pos(int(startPos) + len(strings.Split(firstComment.Text, "\n")[0]))
-		}
 		comments = append(comments, &FoldingRangeInfo{
 			// Fold from the end of the first line comment to the end of the comment block.
-			MappedRange: NewMappedRange(fset, m, endLinePos, commentGrp.End()),
+			MappedRange: NewMappedRange(fset, m, lineEnd(commentGrp.List[0]), commentGrp.End()),
 			Kind:        protocol.Comment,
 		})
 	}
 	return comments
 }
+
+// headerFoldingRange returns the folding range of the comment groups, such
+// as a copyright notice and build constraints, that precede the package
+// clause and its doc comment, if there are several of them. It starts at
+// the end of the first line and has kind protocol.Comment.
+func headerFoldingRange(fset *token.FileSet, m *protocol.ColumnMapper, file *ast.File) *FoldingRangeInfo {
+	var header []*ast.CommentGroup
+	for _, commentGrp := range file.Comments {
+		if commentGrp.Pos() > file.Package || commentGrp == file.Doc {
+			break
+		}
+		header = append(header, commentGrp)
+	}
+	if len(header) < 2 {
+		return nil
+	}
+	first := header[0].List[0]
+	return &FoldingRangeInfo{
+		MappedRange: NewMappedRange(fset, m, lineEnd(first), header[len(header)-1].End()),
+		Kind:        protocol.Comment,
+	}
+}
+
+// regionsFoldingRange returns the folding ranges of the regions delimited
+// by "//region" and "//endregion" line comments, which may also be written
+// "// #region" and "// #endregion", as some editors do. The folding range
+// starts at the end of the "//region" comment, leaving its description
+// visible, and ends at the end of the matching "//endregion" comment. It
+// has kind protocol.Region.
+func regionsFoldingRange(fset *token.FileSet, m *protocol.ColumnMapper, file *ast.File) (regions []*FoldingRangeInfo) {
+	var open []*ast.Comment // stack of the unclosed regions
+	for _, commentGrp := range file.Comments {
+		for _, c := range commentGrp.List {
+			switch regionMarker(c.Text) {
+			case "region":
+				open = append(open, c)
+			case "endregion":
+				if len(open) == 0 {
+					continue // unmatched
+				}
+				start := open[len(open)-1]
+				open = open[:len(open)-1]
+				regions = append(regions, &FoldingRangeInfo{
+					MappedRange: NewMappedRange(fset, m, start.End(), c.End()),
+					Kind:        protocol.Region,
+				})
+			}
+		}
+	}
+	return regions
+}
+
+// regionMarker returns "region" or "endregion" if text is a line comment
+// opening or closing a region, and "" otherwise.
+func regionMarker(text string) string {
+	if !strings.HasPrefix(text, "//") {
+		return ""
+	}
+	text = strings.TrimPrefix(strings.TrimSpace(text[len("//"):]), "#")
+	for _, marker := range []string{"region", "endregion"} {
+		if rest := strings.TrimPrefix(text, marker); rest != text && (rest == "" || rest[0] == ' ' || rest[0] == '\t') {
+			return marker
+		}
+	}
+	return ""
+}
+
+// importSectionsFoldingRange returns the folding ranges of the sections,
+// separated by blank lines, of the parenthesized import declarations that
+// have several of them. The folding range of a section of several imports
+// starts at the end of its first import and has kind protocol.Imports.
+func importSectionsFoldingRange(fset *token.FileSet, m *protocol.ColumnMapper, file *ast.File) (sections []*FoldingRangeInfo) {
+	for _, decl := range file.Decls {
+		decl, ok := decl.(*ast.GenDecl)
+		if !ok || decl.Tok != token.IMPORT || !decl.Lparen.IsValid() || len(decl.Specs) < 2 {
+			continue
+		}
+		var groups [][]ast.Spec
+		for i, spec := range decl.Specs {
+			if i == 0 || fset.Position(spec.Pos()).Line > fset.Position(decl.Specs[i-1].End()).Line+1 {
+				groups = append(groups, nil)
+			}
+			groups[len(groups)-1] = append(groups[len(groups)-1], spec)
+		}
+		if len(groups) < 2 {
+			continue
+		}
+		for _, group := range groups {
+			if len(group) < 2 {
+				continue
+			}
+			sections = append(sections, &FoldingRangeInfo{
+				MappedRange: NewMappedRange(fset, m, group[0].End(), group[len(group)-1].End()),
+				Kind:        protocol.Imports,
+			})
+		}
+	}
+	return sections
+}
+
+// lineEnd returns the position of the end of the first line of comment c,
+// so that a comment spanning multiple lines folds after its first line.
+func lineEnd(c *ast.Comment) token.Pos {
+	return c.Pos() + token.Pos(len(strings.Split(c.Text, "\n")[0]))
+}
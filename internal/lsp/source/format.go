@@ -13,9 +13,11 @@ import (
 	"go/format"
 	"go/parser"
 	"go/token"
+	"sort"
 	"strings"
 	"text/scanner"
 
+	"github.com/iansmith/golang-x-tools/go/analysis"
 	"github.com/iansmith/golang-x-tools/internal/event"
 	"github.com/iansmith/golang-x-tools/internal/imports"
 	"github.com/iansmith/golang-x-tools/internal/lsp/diff"
@@ -59,33 +61,13 @@ func Format(ctx context.Context, snapshot Snapshot, fh FileHandle) ([]protocol.T
 	if err := format.Node(buf, fset, pgf.File); err != nil {
 		return nil, err
 	}
-	formatted := buf.String()
-
-	// Apply additional formatting, if any is supported. Currently, the only
-	// supported additional formatter is gofumpt.
-	if format := snapshot.View().Options().GofumptFormat; snapshot.View().Options().Gofumpt && format != nil {
-		// gofumpt can customize formatting based on language version and module
-		// path, if available.
-		//
-		// Try to derive this information, but fall-back on the default behavior.
-		//
-		// TODO: under which circumstances can we fail to find module information?
-		// Can this, for example, result in inconsistent formatting across saves,
-		// due to pending calls to packages.Load?
-		var langVersion, modulePath string
-		mds, err := snapshot.MetadataForFile(ctx, fh.URI())
-		if err == nil && len(mds) > 0 {
-			if mi := mds[0].ModuleInfo(); mi != nil {
-				langVersion = mi.GoVersion
-				modulePath = mi.Path
-			}
-		}
-		b, err := format(ctx, langVersion, modulePath, buf.Bytes())
-		if err != nil {
-			return nil, err
-		}
-		formatted = string(b)
+
+	// Apply the additional formatters, such as gofumpt.
+	b, err := applyFormatters(ctx, snapshot, fh, buf.Bytes())
+	if err != nil {
+		return nil, err
 	}
+	formatted := string(b)
 	return computeTextEdits(ctx, snapshot, pgf, formatted)
 }
 
@@ -158,9 +140,15 @@ func computeImportEdits(snapshot Snapshot, pgf *ParsedGoFile, options *imports.O
 }
 
 // ComputeOneImportFixEdits returns text edits for a single import fix.
-func ComputeOneImportFixEdits(snapshot Snapshot, pgf *ParsedGoFile, fix *imports.ImportFix) ([]protocol.TextEdit, error) {
+func ComputeOneImportFixEdits(ctx context.Context, snapshot Snapshot, pgf *ParsedGoFile, fix *imports.ImportFix) ([]protocol.TextEdit, error) {
+	return computeImportFixEdits(ctx, snapshot, pgf, []*imports.ImportFix{fix})
+}
+
+// computeImportFixEdits returns text edits for a set of import fixes.
+func computeImportFixEdits(ctx context.Context, snapshot Snapshot, pgf *ParsedGoFile, fixes []*imports.ImportFix) ([]protocol.TextEdit, error) {
 	options := &imports.Options{
 		LocalPrefix: snapshot.View().Options().Local,
+		Groups:      ImportGroups(ctx, snapshot),
 		// Defaults.
 		AllErrors:  true,
 		Comments:   true,
@@ -169,7 +157,75 @@ func ComputeOneImportFixEdits(snapshot Snapshot, pgf *ParsedGoFile, fix *imports
 		TabIndent:  true,
 		TabWidth:   8,
 	}
-	return computeFixEdits(snapshot, pgf, options, []*imports.ImportFix{fix})
+	return computeFixEdits(snapshot, pgf, options, fixes)
+}
+
+// ImportGroups returns the import groups configured for the view of
+// snapshot, with "module" replaced by the paths of the workspace modules.
+func ImportGroups(ctx context.Context, snapshot Snapshot) []string {
+	var groups []string
+	for _, group := range snapshot.View().Options().ImportGroups {
+		if group != "module" {
+			groups = append(groups, group)
+			continue
+		}
+		var paths []string
+		for _, uri := range snapshot.ModFiles() {
+			fh, err := snapshot.GetFile(ctx, uri)
+			if err != nil {
+				continue
+			}
+			pm, err := snapshot.ParseMod(ctx, fh)
+			if err != nil || pm.File == nil || pm.File.Module == nil {
+				continue
+			}
+			// Match the module path itself, not paths it is a prefix of.
+			paths = append(paths, pm.File.Module.Mod.Path+"/")
+		}
+		groups = append(groups, strings.Join(paths, ","))
+	}
+	return groups
+}
+
+// addImportEdits returns the edits that add to pgf the imports of the
+// packages in paths, a map from import path to package name.
+func addImportEdits(ctx context.Context, snapshot Snapshot, pgf *ParsedGoFile, paths map[string]string) ([]analysis.TextEdit, error) {
+	if len(paths) == 0 {
+		return nil, nil
+	}
+	var fixes []*imports.ImportFix
+	for path, name := range paths {
+		fixes = append(fixes, &imports.ImportFix{
+			StmtInfo:  imports.ImportInfo{ImportPath: path},
+			IdentName: name,
+			FixType:   imports.AddImport,
+		})
+	}
+	sort.Slice(fixes, func(i, j int) bool {
+		return fixes[i].StmtInfo.ImportPath < fixes[j].StmtInfo.ImportPath
+	})
+	edits, err := importFixEdits(ctx, snapshot, pgf, fixes)
+	if err != nil {
+		return nil, fmt.Errorf("adding imports: %v", err)
+	}
+	return edits, nil
+}
+
+// importFixEdits returns the edits that apply fixes to the imports of pgf.
+func importFixEdits(ctx context.Context, snapshot Snapshot, pgf *ParsedGoFile, fixes []*imports.ImportFix) ([]analysis.TextEdit, error) {
+	protocolEdits, err := computeImportFixEdits(ctx, snapshot, pgf, fixes)
+	if err != nil {
+		return nil, err
+	}
+	var edits []analysis.TextEdit
+	for _, e := range protocolEdits {
+		rng, err := pgf.Mapper.RangeToSpanRange(e.Range)
+		if err != nil {
+			return nil, err
+		}
+		edits = append(edits, analysis.TextEdit{Pos: rng.Start, End: rng.End, NewText: []byte(e.NewText)})
+	}
+	return edits, nil
 }
 
 func computeFixEdits(snapshot Snapshot, pgf *ParsedGoFile, options *imports.Options, fixes []*imports.ImportFix) ([]protocol.TextEdit, error) {
@@ -0,0 +1,185 @@
+// Copyright 2022 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package source
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/iansmith/golang-x-tools/internal/event"
+	"github.com/iansmith/golang-x-tools/internal/imports"
+)
+
+// The formatters of the formatters setting. Any other formatter is a
+// command, after execFormatterPrefix.
+const (
+	gofumptFormatter    = "gofumpt"
+	importsFormatter    = "imports"
+	execFormatterPrefix = "exec:"
+)
+
+// GofumptOptions are the options of the GofumptFormat hook.
+type GofumptOptions struct {
+	LangVersion string // the Go version of the module of the file, if known
+	ModulePath  string // the path of the module of the file, if known
+	ExtraRules  bool   // the gofumptExtraRules setting
+}
+
+// validateFormatter checks a formatter of the formatters setting.
+func validateFormatter(formatter string) error {
+	switch {
+	case formatter == gofumptFormatter, formatter == importsFormatter:
+		return nil
+	case strings.HasPrefix(formatter, execFormatterPrefix):
+		if len(strings.Fields(strings.TrimPrefix(formatter, execFormatterPrefix))) == 0 {
+			return errors.New("missing command")
+		}
+		return nil
+	}
+	return fmt.Errorf("expect %q, %q, or %q followed by a command", gofumptFormatter, importsFormatter, execFormatterPrefix)
+}
+
+// applyFormatters applies to src, the gofmt-formatted content of fh, the
+// formatters of the formatters setting in order, preceded by gofumpt if
+// the gofumpt setting is on. The output of each formatter must parse, so
+// that a misbehaving formatter cannot corrupt the file.
+func applyFormatters(ctx context.Context, snapshot Snapshot, fh FileHandle, src []byte) ([]byte, error) {
+	ctx, done := event.Start(ctx, "source.applyFormatters")
+	defer done()
+
+	options := snapshot.View().Options()
+	formatters := options.Formatters
+	if options.Gofumpt && !containsFormatter(formatters, gofumptFormatter) {
+		formatters = append([]string{gofumptFormatter}, formatters...)
+	}
+	filename := fh.URI().Filename()
+	for _, formatter := range formatters {
+		var out []byte
+		var err error
+		switch {
+		case formatter == gofumptFormatter:
+			if options.GofumptFormat == nil {
+				continue // not wired in by this build
+			}
+			out, err = options.GofumptFormat(ctx, gofumptOptions(ctx, snapshot, fh), src)
+		case formatter == importsFormatter:
+			out, err = regroupImports(filename, src, &imports.Options{
+				LocalPrefix: options.Local,
+				Groups:      ImportGroups(ctx, snapshot),
+				AllErrors:   true,
+				Comments:    true,
+				FormatOnly:  true,
+				TabIndent:   true,
+				TabWidth:    8,
+			})
+		case strings.HasPrefix(formatter, execFormatterPrefix):
+			out, err = runFormatterCommand(ctx, filename, strings.TrimPrefix(formatter, execFormatterPrefix), src)
+		default:
+			err = validateFormatter(formatter)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("formatter %q: %w", formatter, err)
+		}
+		if _, err := parser.ParseFile(token.NewFileSet(), filename, out, parser.ParseComments); err != nil {
+			return nil, fmt.Errorf("formatter %q produced invalid Go code: %v", formatter, err)
+		}
+		src = out
+	}
+	return src, nil
+}
+
+func containsFormatter(formatters []string, formatter string) bool {
+	for _, f := range formatters {
+		if f == formatter {
+			return true
+		}
+	}
+	return false
+}
+
+// gofumptOptions returns the options of gofumpt for fh. gofumpt can
+// customize formatting based on the language version and module path, if
+// available.
+//
+// TODO: under which circumstances can we fail to find module information?
+// Can this, for example, result in inconsistent formatting across saves,
+// due to pending calls to packages.Load?
+func gofumptOptions(ctx context.Context, snapshot Snapshot, fh FileHandle) GofumptOptions {
+	opts := GofumptOptions{ExtraRules: snapshot.View().Options().GofumptExtraRules}
+	mds, err := snapshot.MetadataForFile(ctx, fh.URI())
+	if err == nil && len(mds) > 0 {
+		if mi := mds[0].ModuleInfo(); mi != nil {
+			opts.LangVersion = mi.GoVersion
+			opts.ModulePath = mi.Path
+		}
+	}
+	return opts
+}
+
+// regroupImports sorts the imports of src into the groups of opt, as gci
+// does: unlike goimports, which keeps the existing groups, it first joins
+// the groups of each import declaration. Comment lines between imports
+// still separate them.
+func regroupImports(filename string, src []byte, opt *imports.Options) ([]byte, error) {
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, filename, src, parser.ImportsOnly|parser.ParseComments)
+	if err != nil {
+		return nil, err
+	}
+	tok := fset.File(file.Pos())
+	// Remove the blank lines between the specs of the import declarations,
+	// from the last one.
+	joined := src
+	for i := len(file.Decls) - 1; i >= 0; i-- {
+		decl, ok := file.Decls[i].(*ast.GenDecl)
+		if !ok || decl.Tok != token.IMPORT || !decl.Lparen.IsValid() {
+			continue
+		}
+		lparen, end, err := posOffsets(tok, decl.Lparen, decl.Rparen)
+		if err != nil {
+			return nil, err
+		}
+		start := lparen + 1
+		var buf bytes.Buffer
+		buf.Write(joined[:start])
+		for j, line := range bytes.SplitAfter(joined[start:end], []byte("\n")) {
+			// The first line is the end of the line of the parenthesis.
+			if j == 0 || len(bytes.TrimSpace(line)) > 0 || !bytes.HasSuffix(line, []byte("\n")) {
+				buf.Write(line)
+			}
+		}
+		buf.Write(joined[end:])
+		joined = buf.Bytes()
+	}
+	return imports.Process(filename, joined, opt)
+}
+
+// runFormatterCommand runs the formatter command line, which reads the
+// source on its standard input and writes it formatted on its standard
+// output, in the directory of filename.
+func runFormatterCommand(ctx context.Context, filename, command string, src []byte) ([]byte, error) {
+	args := strings.Fields(command)
+	cmd := exec.CommandContext(ctx, args[0], args[1:]...)
+	cmd.Dir = filepath.Dir(filename)
+	cmd.Stdin = bytes.NewReader(src)
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return nil, fmt.Errorf("%v: %s", err, msg)
+		}
+		return nil, err
+	}
+	return stdout.Bytes(), nil
+}
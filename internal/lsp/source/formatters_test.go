@@ -0,0 +1,54 @@
+// Copyright 2022 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package source
+
+import (
+	"testing"
+
+	"github.com/iansmith/golang-x-tools/internal/imports"
+)
+
+func TestRegroupImports(t *testing.T) {
+	const src = `package p
+
+import (
+	"example.com/mod/b"
+
+	"fmt"
+	"golang.org/x/tools/go/ast/astutil"
+
+	"example.com/mod/a"
+	"os"
+)
+
+import "strings"
+`
+	const want = `package p
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"golang.org/x/tools/go/ast/astutil"
+
+	"example.com/mod/a"
+	"example.com/mod/b"
+)
+`
+	got, err := regroupImports("p.go", []byte(src), &imports.Options{
+		Groups:     []string{"std", "third-party", "example.com/mod"},
+		Comments:   true,
+		FormatOnly: true,
+		TabIndent:  true,
+		TabWidth:   8,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(got) != want {
+		t.Errorf("regroupImports() =\n%s\nwant:\n%s", got, want)
+	}
+}
@@ -0,0 +1,345 @@
+// Copyright 2022 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package source
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/iansmith/golang-x-tools/internal/event"
+	"github.com/iansmith/golang-x-tools/internal/lsp/command"
+	"github.com/iansmith/golang-x-tools/internal/lsp/protocol"
+	"github.com/iansmith/golang-x-tools/internal/span"
+)
+
+// Generator returns the locations of what produced the generated Go file
+// uri: the //go:generate directives of its directory that run the
+// generator named by its "Code generated" header, or that name the file,
+// and the generator inputs that the header names. If no directive matches,
+// the only directive of the directory is assumed to produce the file.
+func Generator(ctx context.Context, snapshot Snapshot, uri span.URI) ([]protocol.Location, error) {
+	ctx, done := event.Start(ctx, "source.Generator")
+	defer done()
+
+	header, err := generatedFileHeader(ctx, snapshot, uri)
+	if err != nil {
+		return nil, err
+	}
+	if header == nil {
+		return nil, fmt.Errorf("%s is not a generated file", uri.Filename())
+	}
+	directives, err := generateDirectives(ctx, snapshot, filepath.Dir(uri.Filename()))
+	if err != nil {
+		return nil, err
+	}
+	var locs []protocol.Location
+	for _, d := range header.producers(directives, uri.Filename()) {
+		locs = append(locs, d.location)
+	}
+	for _, input := range header.inputs {
+		locs = append(locs, protocol.Location{URI: protocol.URIFromPath(input)})
+	}
+	if len(locs) == 0 {
+		return nil, fmt.Errorf("no generator found for %s", uri.Filename())
+	}
+	return locs, nil
+}
+
+// GeneratedFiles returns the locations of the generated Go files of the
+// directory of uri that the //go:generate directive at rng in uri
+// produces, according to Generator.
+func GeneratedFiles(ctx context.Context, snapshot Snapshot, uri span.URI, rng protocol.Range) ([]protocol.Location, error) {
+	ctx, done := event.Start(ctx, "source.GeneratedFiles")
+	defer done()
+
+	dir := filepath.Dir(uri.Filename())
+	directives, err := generateDirectives(ctx, snapshot, dir)
+	if err != nil {
+		return nil, err
+	}
+	directive := directiveAt(directives, uri, rng)
+	if directive == nil {
+		return nil, fmt.Errorf("no //go:generate directive at %s:%d", uri.Filename(), rng.Start.Line+1)
+	}
+	var locs []protocol.Location
+	for _, filename := range goFiles(dir) {
+		header, err := generatedFileHeader(ctx, snapshot, span.URIFromPath(filename))
+		if err != nil || header == nil {
+			continue
+		}
+		for _, d := range header.producers(directives, filename) {
+			if d == directive {
+				locs = append(locs, protocol.Location{URI: protocol.URIFromPath(filename)})
+				break
+			}
+		}
+	}
+	if len(locs) == 0 {
+		return nil, fmt.Errorf("no generated files found for %s", directive.text)
+	}
+	return locs, nil
+}
+
+// GeneratorCommands returns the commands that navigate from rng in the Go
+// file uri to the generator of the file, if rng is in its "Code generated"
+// header, and to the files that a //go:generate directive at rng
+// produces.
+func GeneratorCommands(ctx context.Context, snapshot Snapshot, uri span.URI, rng protocol.Range) ([]protocol.Command, error) {
+	var commands []protocol.Command
+	header, err := generatedFileHeader(ctx, snapshot, uri)
+	if err != nil {
+		return nil, err
+	}
+	if header != nil && protocol.Intersect(header.rng, rng) {
+		cmd, err := command.NewShowGeneratorCommand("Show generator", command.URIArg{
+			URI: protocol.URIFromSpanURI(uri),
+		})
+		if err != nil {
+			return nil, err
+		}
+		commands = append(commands, cmd)
+	}
+	directives, err := generateDirectives(ctx, snapshot, filepath.Dir(uri.Filename()))
+	if err != nil {
+		return nil, err
+	}
+	if d := directiveAt(directives, uri, rng); d != nil {
+		cmd, err := command.NewShowGeneratedCommand("Show generated files", command.ShowGeneratedArgs{
+			URI:   d.location.URI,
+			Range: d.location.Range,
+		})
+		if err != nil {
+			return nil, err
+		}
+		commands = append(commands, cmd)
+	}
+	return commands, nil
+}
+
+// A generatedHeader is the "Code generated" header of a generated file.
+type generatedHeader struct {
+	rng       protocol.Range // of the "Code generated" comment
+	generator string         // the lower-cased name of the generator, or ""
+	inputs    []string       // the files named as the source of the file
+}
+
+var (
+	// generatorRx matches the "Code generated" comments that name the
+	// generator, such as `// Code generated by "stringer -type=T"; DO NOT
+	// EDIT.`
+	generatorRx = regexp.MustCompile(`^// Code generated by (.*?)[.;]? DO NOT EDIT\.?$`)
+	// sourceRx matches the comments that name the source of a generated
+	// file, such as "// source: api.proto".
+	sourceRx = regexp.MustCompile(`^//\s*[Ss]ource:\s*(\S+)$`)
+)
+
+// generatedFileHeader returns the header of the Go file uri, or nil if it
+// isn't a generated file.
+func generatedFileHeader(ctx context.Context, snapshot Snapshot, uri span.URI) (*generatedHeader, error) {
+	if !IsGenerated(ctx, snapshot, uri) {
+		return nil, nil
+	}
+	fh, err := snapshot.GetFile(ctx, uri)
+	if err != nil {
+		return nil, err
+	}
+	pgf, err := snapshot.ParseGo(ctx, fh, ParseHeader)
+	if err != nil {
+		return nil, err
+	}
+	dir := filepath.Dir(uri.Filename())
+	var header *generatedHeader
+	var sources []string
+	for _, group := range pgf.File.Comments {
+		for _, c := range group.List {
+			if header == nil && generatedRx.MatchString(c.Text) && pgf.Tok.Position(c.Slash).Column == 1 {
+				rng, err := NewMappedRange(snapshot.FileSet(), pgf.Mapper, c.Pos(), c.End()).Range()
+				if err != nil {
+					return nil, err
+				}
+				header = &generatedHeader{rng: rng}
+				if m := generatorRx.FindStringSubmatch(c.Text); m != nil {
+					words := strings.Fields(strings.Trim(m[1], "\"`"))
+					if len(words) > 0 {
+						header.generator = commandName(words[0])
+						sources = append(sources, words[1:]...)
+					}
+				}
+			}
+			if c.Pos() < pgf.File.Package {
+				if m := sourceRx.FindStringSubmatch(c.Text); m != nil {
+					sources = append(sources, m[1])
+				}
+			}
+		}
+	}
+	if header == nil {
+		return nil, nil
+	}
+	// Only the words of the header that name existing files are inputs.
+	seen := make(map[string]bool)
+	for _, word := range sources {
+		for _, name := range argFiles(word) {
+			if !filepath.IsAbs(name) {
+				name = filepath.Join(dir, name)
+			}
+			if info, err := os.Stat(name); err == nil && !info.IsDir() && name != uri.Filename() && !seen[name] {
+				seen[name] = true
+				header.inputs = append(header.inputs, name)
+			}
+		}
+	}
+	return header, nil
+}
+
+// producers returns the directives that produce the generated file
+// filename with the header h: those that name the file if any, or else
+// those that run its generator, or else the only directive.
+func (h *generatedHeader) producers(directives []*generateDirective, filename string) []*generateDirective {
+	base := filepath.Base(filename)
+	var naming, running []*generateDirective
+	for _, d := range directives {
+		switch {
+		case d.names(base):
+			naming = append(naming, d)
+		case h.generator != "" && d.runs(h.generator):
+			running = append(running, d)
+		}
+	}
+	switch {
+	case len(naming) > 0:
+		return naming
+	case len(running) > 0:
+		return running
+	case len(directives) == 1:
+		return directives
+	}
+	return nil
+}
+
+// A generateDirective is a //go:generate directive.
+type generateDirective struct {
+	location protocol.Location
+	text     string   // of the comment
+	args     []string // the words of the command
+}
+
+// generateDirectives returns the //go:generate directives of the Go files
+// of dir, in file order.
+func generateDirectives(ctx context.Context, snapshot Snapshot, dir string) ([]*generateDirective, error) {
+	const ggDirective = "//go:generate"
+	var directives []*generateDirective
+	for _, filename := range goFiles(dir) {
+		fh, err := snapshot.GetFile(ctx, span.URIFromPath(filename))
+		if err != nil {
+			return nil, err
+		}
+		pgf, err := snapshot.ParseGo(ctx, fh, ParseFull)
+		if err != nil {
+			continue
+		}
+		for _, group := range pgf.File.Comments {
+			for _, c := range group.List {
+				if !strings.HasPrefix(c.Text, ggDirective+" ") || pgf.Tok.Position(c.Slash).Column != 1 {
+					continue
+				}
+				args := strings.Fields(strings.TrimPrefix(c.Text, ggDirective))
+				if len(args) == 0 {
+					continue
+				}
+				rng, err := NewMappedRange(snapshot.FileSet(), pgf.Mapper, c.Pos(), c.End()).Range()
+				if err != nil {
+					return nil, err
+				}
+				directives = append(directives, &generateDirective{
+					location: protocol.Location{URI: protocol.URIFromSpanURI(pgf.URI), Range: rng},
+					text:     c.Text,
+					args:     args,
+				})
+			}
+		}
+	}
+	return directives, nil
+}
+
+// directiveAt returns the directive of directives at rng in the file uri,
+// or nil if there is none.
+func directiveAt(directives []*generateDirective, uri span.URI, rng protocol.Range) *generateDirective {
+	for _, d := range directives {
+		if d.location.URI.SpanURI() == uri && protocol.Intersect(d.location.Range, rng) {
+			return d
+		}
+	}
+	return nil
+}
+
+// names reports whether an argument of d names a file whose base name is
+// base, as in "-output=base".
+func (d *generateDirective) names(base string) bool {
+	for _, arg := range d.args[1:] {
+		for _, name := range argFiles(arg) {
+			if filepath.Base(name) == base {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// runs reports whether d runs the generator of the given lower-cased name,
+// or a command of which it is a plugin, as protoc-gen-go is of protoc.
+func (d *generateDirective) runs(generator string) bool {
+	cmd := commandName(d.args[0])
+	if cmd == "go" && len(d.args) > 2 && d.args[1] == "run" {
+		cmd = commandName(d.args[2])
+	}
+	return cmd == generator || strings.HasPrefix(generator, cmd+"-")
+}
+
+// commandName returns the lower-cased base name of the command or Go
+// program path, without its extension or version.
+func commandName(path string) string {
+	if i := strings.LastIndex(path, "@"); i >= 0 {
+		path = path[:i]
+	}
+	name := strings.ToLower(filepath.Base(path))
+	for _, ext := range []string{".exe", ".go"} {
+		name = strings.TrimSuffix(name, ext)
+	}
+	return name
+}
+
+// argFiles returns the file names that the command argument arg may
+// contain, such as x.go in "-output=x.go", and a.go and b.go in
+// "-files=a.go,b.go".
+func argFiles(arg string) []string {
+	if i := strings.Index(arg, "="); i >= 0 {
+		arg = arg[i+1:]
+	}
+	var names []string
+	for _, name := range strings.Split(strings.Trim(arg, "\"'"), ",") {
+		if strings.Contains(filepath.Base(name), ".") {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// goFiles returns the names of the Go files of dir.
+func goFiles(dir string) []string {
+	infos, _ := ioutil.ReadDir(dir)
+	var files []string
+	for _, info := range infos {
+		if !info.IsDir() && strings.HasSuffix(info.Name(), ".go") {
+			files = append(files, filepath.Join(dir, info.Name()))
+		}
+	}
+	return files
+}
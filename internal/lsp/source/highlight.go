@@ -13,6 +13,8 @@ import (
 	"strings"
 
 	"github.com/iansmith/golang-x-tools/go/ast/astutil"
+	"github.com/iansmith/golang-x-tools/go/cfg"
+	"github.com/iansmith/golang-x-tools/go/types/typeutil"
 	"github.com/iansmith/golang-x-tools/internal/event"
 	"github.com/iansmith/golang-x-tools/internal/lsp/protocol"
 )
@@ -53,7 +55,7 @@ func Highlight(ctx context.Context, snapshot Snapshot, fh FileHandle, position p
 			}
 		}
 	}
-	result, err := highlightPath(pkg, path)
+	result, err := highlightPath(pkg, path, snapshot.View().Options())
 	if err != nil {
 		return nil, err
 	}
@@ -72,7 +74,7 @@ func Highlight(ctx context.Context, snapshot Snapshot, fh FileHandle, position p
 	return ranges, nil
 }
 
-func highlightPath(pkg Package, path []ast.Node) (map[posRange]struct{}, error) {
+func highlightPath(pkg Package, path []ast.Node, opts *Options) (map[posRange]struct{}, error) {
 	result := make(map[posRange]struct{})
 	switch node := path[0].(type) {
 	case *ast.BasicLit:
@@ -85,8 +87,14 @@ func highlightPath(pkg Package, path []ast.Node) (map[posRange]struct{}, error)
 		highlightFuncControlFlow(path, result)
 	case *ast.ReturnStmt, *ast.FuncDecl, *ast.FuncType:
 		highlightFuncControlFlow(path, result)
+		if body := funcBody(path); opts.ControlFlowHighlights && body != nil {
+			highlightExitPoints(pkg, body, body, result)
+		}
 	case *ast.Ident:
 		highlightIdentifiers(pkg, path, result)
+		if opts.ErrorFlowHighlights {
+			highlightErrorFlow(pkg, path, result)
+		}
 	case *ast.ForStmt, *ast.RangeStmt:
 		highlightLoopControlFlow(path, result)
 	case *ast.SwitchStmt:
@@ -106,6 +114,9 @@ func highlightPath(pkg Package, path []ast.Node) (map[posRange]struct{}, error)
 				highlightLoopControlFlow(path, result)
 			}
 		}
+		if body, target := funcBody(path), branchTarget(node, path); opts.ControlFlowHighlights && body != nil && target != nil {
+			highlightExitPoints(pkg, body, target, result)
+		}
 	default:
 		// If the cursor is in an unidentified area, return empty results.
 		return nil, nil
@@ -263,6 +274,268 @@ func highlightLabeledFlow(node *ast.BranchStmt, result map[posRange]struct{}) {
 	}
 }
 
+// funcBody returns the body of the innermost function enclosing path, or
+// nil if there is none.
+func funcBody(path []ast.Node) *ast.BlockStmt {
+	for _, n := range path {
+		switch n := n.(type) {
+		case *ast.FuncDecl:
+			return n.Body
+		case *ast.FuncLit:
+			return n.Body
+		}
+	}
+	return nil
+}
+
+// branchTarget returns the loop, switch, or select statement that the
+// branch statement at path[0] breaks or continues, or nil if it has none.
+func branchTarget(branch *ast.BranchStmt, path []ast.Node) ast.Node {
+	if branch.Label != nil {
+		if obj := branch.Label.Obj; obj != nil {
+			if label, ok := obj.Decl.(*ast.LabeledStmt); ok {
+				return label.Stmt
+			}
+		}
+		return nil
+	}
+	for _, n := range path {
+		switch n.(type) {
+		case *ast.ForStmt, *ast.RangeStmt:
+			return n
+		case *ast.SwitchStmt, *ast.TypeSwitchStmt, *ast.SelectStmt:
+			if branch.Tok == token.BREAK {
+				return n
+			}
+		case *ast.FuncDecl, *ast.FuncLit:
+			return nil
+		}
+	}
+	return nil
+}
+
+// highlightExitPoints highlights the points within node at which control
+// leaves the function with the given body, as found in its control-flow
+// graph: the reachable return statements, including the implicit one at
+// the closing brace, and the reachable calls of functions that never
+// return.
+func highlightExitPoints(pkg Package, body *ast.BlockStmt, node ast.Node, result map[posRange]struct{}) {
+	calls := newCallReturns(pkg)
+	within := func(pos, end token.Pos) bool {
+		return node.Pos() <= pos && end <= node.End()
+	}
+	for _, b := range cfg.New(body, calls.mayReturn).Blocks {
+		if !b.Live {
+			continue
+		}
+		for _, n := range b.Nodes {
+			switch n := n.(type) {
+			case *ast.ReturnStmt:
+				if n.Return == body.Rbrace {
+					// The implicit return at the end of the function.
+					if within(body.Rbrace, body.End()) {
+						result[posRange{start: body.Rbrace, end: body.End()}] = struct{}{}
+					}
+				} else if within(n.Pos(), n.End()) {
+					result[posRange{start: n.Pos(), end: n.End()}] = struct{}{}
+				}
+			case *ast.ExprStmt:
+				if call, ok := n.X.(*ast.CallExpr); ok && within(call.Pos(), call.End()) && !calls.mayReturn(call) {
+					result[posRange{start: call.Pos(), end: call.End()}] = struct{}{}
+				}
+			}
+		}
+	}
+}
+
+// callReturns determines, as the ctrlflow analyzer does, whether calls
+// may return: a function declared in the package does not return if no
+// return is reachable in its control-flow graph. The functions of other
+// packages, whose bodies are not available, are assumed to return, except
+// for the well-known ones of the standard library.
+type callReturns struct {
+	info     *types.Info
+	decls    map[*types.Func]*ast.FuncDecl
+	noReturn map[*types.Func]bool
+}
+
+func newCallReturns(pkg Package) *callReturns {
+	c := &callReturns{
+		info:     pkg.GetTypesInfo(),
+		decls:    make(map[*types.Func]*ast.FuncDecl),
+		noReturn: make(map[*types.Func]bool),
+	}
+	for _, pgf := range pkg.CompiledGoFiles() {
+		for _, decl := range pgf.File.Decls {
+			if decl, ok := decl.(*ast.FuncDecl); ok {
+				if fn, ok := c.info.Defs[decl.Name].(*types.Func); ok {
+					c.decls[fn] = decl
+				}
+			}
+		}
+	}
+	return c
+}
+
+// mayReturn reports whether the called function may return. It is passed
+// to the CFG builder.
+func (c *callReturns) mayReturn(call *ast.CallExpr) bool {
+	if id, ok := call.Fun.(*ast.Ident); ok && c.info.Uses[id] == types.Universe.Lookup("panic") {
+		return false // panic never returns
+	}
+	fn := typeutil.StaticCallee(c.info, call)
+	if fn == nil {
+		return true // callee not statically known; be conservative
+	}
+	noReturn, ok := c.noReturn[fn]
+	if !ok {
+		c.noReturn[fn] = false // break cycles
+		if decl := c.decls[fn]; decl != nil && decl.Body != nil {
+			noReturn = !hasReachableReturn(cfg.New(decl.Body, c.mayReturn))
+		} else {
+			noReturn = isKnownNoReturn(fn)
+		}
+		c.noReturn[fn] = noReturn
+	}
+	return !noReturn
+}
+
+func hasReachableReturn(g *cfg.CFG) bool {
+	for _, b := range g.Blocks {
+		if b.Live && b.Return() != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// isKnownNoReturn reports whether fn is a function or method of the
+// standard library that never returns.
+func isKnownNoReturn(fn *types.Func) bool {
+	if fn.Pkg() == nil {
+		return false
+	}
+	switch name := fn.Name(); fn.Pkg().Path() {
+	case "os":
+		return name == "Exit"
+	case "runtime":
+		return name == "Goexit"
+	case "syscall":
+		return name == "Exit" || name == "ExitProcess" || name == "ExitThread"
+	case "log":
+		switch name {
+		case "Fatal", "Fatalf", "Fatalln", "Panic", "Panicf", "Panicln":
+			return true
+		}
+	case "testing":
+		switch name {
+		case "FailNow", "Fatal", "Fatalf", "SkipNow", "Skip", "Skipf":
+			return true
+		}
+	}
+	return false
+}
+
+// highlightErrorFlow highlights, if the identifier at path[0] is a local
+// variable of type error, the expressions assigned to it and the return
+// statements that propagate it within the function declaring it.
+func highlightErrorFlow(pkg Package, path []ast.Node, result map[posRange]struct{}) {
+	info := pkg.GetTypesInfo()
+	v, ok := info.ObjectOf(path[0].(*ast.Ident)).(*types.Var)
+	if !ok || v.IsField() || !types.Identical(v.Type(), types.Universe.Lookup("error").Type()) {
+		return
+	}
+	var (
+		fn     ast.Node
+		fnType *ast.FuncType
+	)
+	for _, n := range path {
+		switch n := n.(type) {
+		case *ast.FuncDecl:
+			fn, fnType = n, n.Type
+		case *ast.FuncLit:
+			fn, fnType = n, n.Type
+		default:
+			continue
+		}
+		if fn.Pos() <= v.Pos() && v.Pos() < fn.End() {
+			break
+		}
+		fn = nil
+	}
+	if fn == nil {
+		return // not a local variable
+	}
+	isResult := false
+	if fnType.Results != nil {
+		for _, field := range fnType.Results.List {
+			for _, name := range field.Names {
+				isResult = isResult || info.Defs[name] == v
+			}
+		}
+	}
+
+	add := func(n ast.Node) {
+		result[posRange{start: n.Pos(), end: n.End()}] = struct{}{}
+	}
+	assigned := func(lhs []ast.Expr, rhs []ast.Expr) {
+		for i, e := range lhs {
+			if id, ok := e.(*ast.Ident); ok && info.ObjectOf(id) == v {
+				switch len(rhs) {
+				case len(lhs):
+					add(rhs[i])
+				case 1:
+					add(rhs[0])
+				}
+			}
+		}
+	}
+	var inspect func(n ast.Node, inFn bool) bool
+	inspect = func(n ast.Node, inFn bool) bool {
+		switch n := n.(type) {
+		case *ast.FuncLit:
+			if inFn && ast.Node(n) != fn {
+				// A bare return within a function literal does not
+				// return the results of the enclosing function.
+				ast.Inspect(n.Body, func(n ast.Node) bool { return inspect(n, false) })
+				return false
+			}
+		case *ast.AssignStmt:
+			assigned(n.Lhs, n.Rhs)
+		case *ast.ValueSpec:
+			lhs := make([]ast.Expr, len(n.Names))
+			for i, name := range n.Names {
+				lhs[i] = name
+			}
+			assigned(lhs, n.Values)
+		case *ast.ReturnStmt:
+			if len(n.Results) == 0 && isResult && inFn {
+				add(n)
+			}
+			for _, e := range n.Results {
+				if mentions(info, e, v) {
+					add(n)
+					break
+				}
+			}
+		}
+		return true
+	}
+	ast.Inspect(fn, func(n ast.Node) bool { return inspect(n, true) })
+}
+
+// mentions reports whether the expression e uses the variable v.
+func mentions(info *types.Info, e ast.Expr, v *types.Var) bool {
+	found := false
+	ast.Inspect(e, func(n ast.Node) bool {
+		if id, ok := n.(*ast.Ident); ok && info.Uses[id] == v {
+			found = true
+		}
+		return !found
+	})
+	return found
+}
+
 func labelFor(path []ast.Node) *ast.Ident {
 	if len(path) > 1 {
 		if n, ok := path[1].(*ast.LabeledStmt); ok {
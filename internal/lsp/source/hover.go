@@ -17,6 +17,7 @@ import (
 	"go/types"
 	"strconv"
 	"strings"
+	"text/tabwriter"
 	"time"
 	"unicode/utf8"
 
@@ -68,6 +69,22 @@ type HoverJSON struct {
 	// LinkAnchor is the pkg.go.dev link anchor for the given symbol.
 	// For example, the "Node" part of "pkg.go.dev/go/ast#Node".
 	LinkAnchor string `json:"linkAnchor"`
+
+	// Layout is the memory layout of the symbol, if it is a struct type or a
+	// struct field. It is only computed if verbose hover is enabled.
+	Layout string `json:"layout"`
+
+	// AddedIn is the Go version that added the symbol, such as "go1.20", if
+	// it is a symbol of the standard library newer than the go directive of
+	// the module of the file.
+	AddedIn string `json:"addedIn,omitempty"`
+
+	// docLinks resolves the doc links of the symbol's documentation.
+	docLinks DocLinkResolver
+
+	// linkTarget is the documentation link target for LinkPath, if it is
+	// not the LinkTarget option.
+	linkTarget string
 }
 
 func Hover(ctx context.Context, snapshot Snapshot, fh FileHandle, position protocol.Position) (*protocol.Hover, error) {
@@ -255,6 +272,7 @@ func HoverIdentifier(ctx context.Context, i *IdentifierInfo) (*HoverJSON, error)
 	h := &HoverJSON{
 		FullDocumentation: hoverCtx.Comment.Text(),
 		Synopsis:          doc.Synopsis(hoverCtx.Comment.Text()),
+		docLinks:          NewDocLinkResolver(ctx, i.Snapshot, i.pkg, i.Declaration.obj),
 	}
 
 	fset := i.Snapshot.FileSet()
@@ -305,10 +323,19 @@ func HoverIdentifier(ctx context.Context, i *IdentifierInfo) (*HoverJSON, error)
 	if obj := i.Declaration.obj; obj != nil {
 		h.SingleLine = objectString(obj, i.qf, nil)
 	}
+	// Show the names of the Go declarations generated by cgo as they are used.
+	h.Signature = cgoSignature(h.Signature)
+	h.SingleLine = cgoSignature(h.SingleLine)
 	obj := i.Declaration.obj
 	if obj == nil {
 		return h, nil
 	}
+	if i.Snapshot.View().Options().VerboseHover && i.pkg != nil {
+		h.Layout = memoryLayout(i.pkg.GetTypesSizes(), obj, i.fieldOf, i.qf)
+	}
+	if v := NewerStdSymbol(obj, ModuleGoVersion(ctx, i.Snapshot, i.URI())); v > 0 {
+		h.AddedIn = fmt.Sprintf("go1.%d", v)
+	}
 
 	// Check if the identifier is test-only (and is therefore not part of a
 	// package's API). This is true if the request originated in a test package,
@@ -321,12 +348,11 @@ func HoverIdentifier(ctx context.Context, i *IdentifierInfo) (*HoverJSON, error)
 
 	h.SymbolName, h.LinkPath, h.LinkAnchor = linkData(obj, i.enclosing)
 
-	// See golang/go#36998: don't link to modules matching GOPRIVATE.
-	//
 	// The path returned by linkData is an import path.
-	if i.Snapshot.View().IsGoPrivatePath(h.LinkPath) {
+	h.linkTarget = DocLinkTarget(i.Snapshot.View(), h.LinkPath)
+	if h.linkTarget == "" {
 		h.LinkPath = ""
-	} else if mod, version, ok := moduleAtVersion(h.LinkPath, i); ok {
+	} else if mod, version, ok := moduleAtVersion(h.LinkPath, h.linkTarget, i); ok {
 		h.LinkPath = strings.Replace(h.LinkPath, mod, mod+"@"+version, 1)
 	}
 
@@ -423,10 +449,10 @@ func linkData(obj types.Object, enclosing *types.TypeName) (name, importPath, an
 	return name, importPath, anchor
 }
 
-func moduleAtVersion(path string, i *IdentifierInfo) (string, string, bool) {
+func moduleAtVersion(path, target string, i *IdentifierInfo) (string, string, bool) {
 	// TODO(rfindley): moduleAtVersion should not be responsible for deciding
 	// whether or not the link target supports module version links.
-	if strings.ToLower(i.Snapshot.View().Options().LinkTarget) != "pkg.go.dev" {
+	if strings.ToLower(target) != "pkg.go.dev" {
 		return "", "", false
 	}
 	impPkg, err := i.pkg.GetImport(path)
@@ -792,11 +818,13 @@ func FormatHover(h *HoverJSON, options *Options) (string, error) {
 		return string(b), nil
 	}
 
+	layout := formatLayout(h, options)
+	addedIn := formatAddedIn(h)
 	link := formatLink(h, options)
 	doc := formatDoc(h, options)
 
 	var b strings.Builder
-	parts := []string{signature, doc, link}
+	parts := []string{signature, addedIn, layout, doc, link}
 	for i, el := range parts {
 		if el != "" {
 			b.WriteString(el)
@@ -827,14 +855,33 @@ func formatSignature(h *HoverJSON, options *Options) string {
 	return signature
 }
 
+func formatLayout(h *HoverJSON, options *Options) string {
+	layout := h.Layout
+	if layout != "" && options.PreferredContentFormat == protocol.Markdown {
+		layout = fmt.Sprintf("```\n%s\n```", layout)
+	}
+	return layout
+}
+
+func formatAddedIn(h *HoverJSON) string {
+	if h.AddedIn == "" {
+		return ""
+	}
+	return fmt.Sprintf("Added in %s, which is newer than the go directive of the module.", h.AddedIn)
+}
+
 func formatLink(h *HoverJSON, options *Options) string {
-	if !options.LinksInHover || options.LinkTarget == "" || h.LinkPath == "" {
+	target := h.linkTarget
+	if target == "" {
+		target = options.LinkTarget
+	}
+	if !options.LinksInHover || target == "" || h.LinkPath == "" {
 		return ""
 	}
-	plainLink := BuildLink(options.LinkTarget, h.LinkPath, h.LinkAnchor)
+	plainLink := BuildLink(target, h.LinkPath, h.LinkAnchor)
 	switch options.PreferredContentFormat {
 	case protocol.Markdown:
-		return fmt.Sprintf("[`%s` on %s](%s)", h.SymbolName, options.LinkTarget, plainLink)
+		return fmt.Sprintf("[`%s` on %s](%s)", h.SymbolName, target, plainLink)
 	case protocol.PlainText:
 		return ""
 	default:
@@ -842,6 +889,19 @@ func formatLink(h *HoverJSON, options *Options) string {
 	}
 }
 
+// DocLinkTarget returns the target of the documentation links to the import
+// or module path: the PrivateLinkTarget option for the paths matching
+// GOPRIVATE, and the LinkTarget option otherwise. It returns "" if there
+// should be no links to path.
+func DocLinkTarget(view View, path string) string {
+	// See golang/go#36998: don't link to modules matching GOPRIVATE, unless
+	// they have their own documentation server.
+	if view.IsGoPrivatePath(path) {
+		return view.Options().PrivateLinkTarget
+	}
+	return view.Options().LinkTarget
+}
+
 // BuildLink constructs a link with the given target, path, and anchor.
 func BuildLink(target, path, anchor string) string {
 	link := fmt.Sprintf("https://%s/%s", target, path)
@@ -863,9 +923,9 @@ func formatDoc(h *HoverJSON, options *Options) string {
 		doc = h.FullDocumentation
 	}
 	if options.PreferredContentFormat == protocol.Markdown {
-		return CommentToMarkdown(doc)
+		return DocCommentToMarkdown(doc, h.docLinks)
 	}
-	return doc
+	return DocCommentToText(doc, h.docLinks)
 }
 
 func anyNonEmpty(x []string) bool {
@@ -876,3 +936,161 @@ func anyNonEmpty(x []string) bool {
 	}
 	return false
 }
+
+// memoryLayout describes the memory layout of obj, if it is a struct type or a
+// field of the struct type fieldOf, as computed by sizes.
+//
+// For a struct type, it lists the offset, size and alignment of each field,
+// as well as the padding inserted between fields. For a field, it gives the
+// offset, size and alignment of the field, and the padding that follows it.
+func memoryLayout(sizes types.Sizes, obj types.Object, fieldOf *types.Struct, qf types.Qualifier) string {
+	if sizes == nil {
+		return ""
+	}
+	sizes = gcSizes{sizes}
+	switch obj := obj.(type) {
+	case *types.TypeName:
+		s, ok := obj.Type().Underlying().(*types.Struct)
+		if !ok || !sizeKnown(s) {
+			return ""
+		}
+		return structLayout(sizes, s, qf)
+	case *types.Var:
+		if !obj.IsField() || fieldOf == nil || !sizeKnown(fieldOf) {
+			return ""
+		}
+		offsets := sizes.Offsetsof(structFields(fieldOf))
+		for i := 0; i < fieldOf.NumFields(); i++ {
+			if fieldOf.Field(i) != obj {
+				continue
+			}
+			size := sizes.Sizeof(obj.Type())
+			layout := fmt.Sprintf("offset %d, size %d, align %d", offsets[i], size, sizes.Alignof(obj.Type()))
+			if pad := fieldEnd(sizes, fieldOf, offsets, i) - offsets[i] - size; pad > 0 {
+				layout += fmt.Sprintf(", followed by %d bytes of padding", pad)
+			}
+			return layout
+		}
+	}
+	return ""
+}
+
+// structLayout returns a table of the fields of s, with their offsets, sizes
+// and alignments, and the padding between them.
+func structLayout(sizes types.Sizes, s *types.Struct, qf types.Qualifier) string {
+	fields := structFields(s)
+	offsets := sizes.Offsetsof(fields)
+	var rows strings.Builder
+	w := tabwriter.NewWriter(&rows, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "offset\tsize\talign\tfield")
+	var padding int64
+	for i, f := range fields {
+		size := sizes.Sizeof(f.Type())
+		fmt.Fprintf(w, "%d\t%d\t%d\t%s %s\n", offsets[i], size, sizes.Alignof(f.Type()), f.Name(), types.TypeString(f.Type(), qf))
+		if pad := fieldEnd(sizes, s, offsets, i) - offsets[i] - size; pad > 0 {
+			fmt.Fprintf(w, "%d\t%d\t\t(padding)\n", offsets[i]+size, pad)
+			padding += pad
+		}
+	}
+	w.Flush()
+	summary := fmt.Sprintf("size %d, align %d", sizes.Sizeof(s), sizes.Alignof(s))
+	if padding > 0 {
+		summary += fmt.Sprintf(", %d bytes of padding", padding)
+	}
+	if len(fields) == 0 {
+		return summary
+	}
+	return summary + "\n\n" + strings.TrimSuffix(rows.String(), "\n")
+}
+
+// fieldEnd returns the offset at which the storage of the ith field of s,
+// including any padding that follows it, ends: the offset of the next field,
+// or the size of s.
+func fieldEnd(sizes types.Sizes, s *types.Struct, offsets []int64, i int) int64 {
+	if i+1 < len(offsets) {
+		return offsets[i+1]
+	}
+	return sizes.Sizeof(s)
+}
+
+func structFields(s *types.Struct) []*types.Var {
+	fields := make([]*types.Var, s.NumFields())
+	for i := range fields {
+		fields[i] = s.Field(i)
+	}
+	return fields
+}
+
+// gcSizes lays out structs as the gc compiler does. Unlike types.StdSizes, it
+// includes the padding that follows the last field in the size of a struct,
+// and gives a size of one byte to a zero-size last field of a struct.
+type gcSizes struct {
+	types.Sizes
+}
+
+func (s gcSizes) Sizeof(t types.Type) int64 {
+	switch u := t.Underlying().(type) {
+	case *types.Struct:
+		n := u.NumFields()
+		if n == 0 {
+			return 0
+		}
+		offset := s.Offsetsof(structFields(u))[n-1]
+		size := s.Sizeof(u.Field(n - 1).Type())
+		if offset > 0 && size == 0 {
+			size = 1
+		}
+		return alignTo(offset+size, s.Alignof(t))
+	case *types.Array:
+		return s.Sizeof(u.Elem()) * u.Len()
+	}
+	return s.Sizes.Sizeof(t)
+}
+
+func (s gcSizes) Offsetsof(fields []*types.Var) []int64 {
+	offsets := make([]int64, len(fields))
+	var offset int64
+	for i, f := range fields {
+		offset = alignTo(offset, s.Alignof(f.Type()))
+		offsets[i] = offset
+		offset += s.Sizeof(f.Type())
+	}
+	return offsets
+}
+
+// alignTo rounds x up to a multiple of a.
+func alignTo(x, a int64) int64 {
+	return (x + a - 1) / a * a
+}
+
+// sizeKnown reports whether the size of t is known, that is, whether it does
+// not depend on a type parameter or an invalid type.
+func sizeKnown(t types.Type) bool {
+	seen := make(map[*types.Named]bool)
+	var known func(t types.Type) bool
+	known = func(t types.Type) bool {
+		switch t := t.(type) {
+		case *typeparams.TypeParam:
+			return false
+		case *types.Basic:
+			return t.Kind() != types.Invalid
+		case *types.Array:
+			return known(t.Elem())
+		case *types.Named:
+			if seen[t] {
+				return false // an invalid recursive type
+			}
+			seen[t] = true
+			defer delete(seen, t)
+			return known(t.Underlying())
+		case *types.Struct:
+			for i := 0; i < t.NumFields(); i++ {
+				if !known(t.Field(i).Type()) {
+					return false
+				}
+			}
+		}
+		return true
+	}
+	return known(t)
+}
@@ -46,6 +46,10 @@ type IdentifierInfo struct {
 	// documentation links.
 	enclosing *types.TypeName
 
+	// For struct fields, fieldOf is the struct type declaring the field, for
+	// use in describing its memory layout.
+	fieldOf *types.Struct
+
 	pkg Package
 	qf  types.Qualifier
 }
@@ -273,6 +277,10 @@ func findIdentifier(ctx context.Context, snapshot Snapshot, pkg Package, pgf *Pa
 		}
 	}
 
+	if v, ok := result.Declaration.obj.(*types.Var); ok && v.IsField() {
+		result.fieldOf = searchForStruct(pkg.GetTypesInfo(), path, v)
+	}
+
 	// If the original position was an embedded field, we want to jump
 	// to the field's type definition, not the field's definition.
 	if v, ok := result.Declaration.obj.(*types.Var); ok && v.Embedded() {
@@ -286,12 +294,17 @@ func findIdentifier(ctx context.Context, snapshot Snapshot, pkg Package, pgf *Pa
 	if err != nil {
 		return nil, err
 	}
-	result.Declaration.MappedRange = append(result.Declaration.MappedRange, rng)
-
 	declPkg, err := FindPackageFromPos(ctx, snapshot, result.Declaration.obj.Pos())
 	if err != nil {
 		return nil, err
 	}
+	// The Go declarations of the C names are in a file generated by cgo, so
+	// use their C declarations instead.
+	if crng, ok := cgoPreambleRange(snapshot, declPkg, result.Declaration.obj); ok {
+		rng = crng
+	}
+	result.Declaration.MappedRange = append(result.Declaration.MappedRange, rng)
+
 	if result.Declaration.node, err = snapshot.PosToDecl(ctx, declPkg, result.Declaration.obj.Pos()); err != nil {
 		return nil, err
 	}
@@ -316,6 +329,11 @@ func findIdentifier(ctx context.Context, snapshot Snapshot, pkg Package, pgf *Pa
 		if result.Type.MappedRange, err = objToMappedRange(snapshot, pkg, result.Type.Object); err != nil {
 			return nil, err
 		}
+		if typePkg, err := FindPackageFromPos(ctx, snapshot, result.Type.Object.Pos()); err == nil {
+			if crng, ok := cgoPreambleRange(snapshot, typePkg, result.Type.Object); ok {
+				result.Type.MappedRange = crng
+			}
+		}
 	}
 	return result, nil
 }
@@ -377,6 +395,44 @@ func inferredSignature(info *types.Info, id *ast.Ident) *types.Signature {
 	return sig
 }
 
+// searchForStruct returns the struct type declaring field, as found from the
+// innermost selector, composite literal or struct type of path.
+func searchForStruct(info *types.Info, path []ast.Node, field *types.Var) *types.Struct {
+	for _, n := range path {
+		var typ types.Type
+		switch n := n.(type) {
+		case *ast.SelectorExpr:
+			sel, ok := info.Selections[n]
+			if !ok {
+				return nil
+			}
+			// The field may be promoted from an embedded struct.
+			typ = sel.Recv()
+			for _, index := range sel.Index()[:len(sel.Index())-1] {
+				if s, ok := Deref(typ).Underlying().(*types.Struct); ok {
+					typ = s.Field(index).Type()
+				}
+			}
+		case *ast.CompositeLit, *ast.StructType:
+			typ = info.TypeOf(n.(ast.Expr))
+		default:
+			continue
+		}
+		if typ == nil {
+			return nil
+		}
+		if s, ok := Deref(typ).Underlying().(*types.Struct); ok {
+			for i := 0; i < s.NumFields(); i++ {
+				if s.Field(i) == field {
+					return s
+				}
+			}
+		}
+		return nil
+	}
+	return nil
+}
+
 func searchForEnclosing(info *types.Info, path []ast.Node) *types.TypeName {
 	for _, n := range path {
 		switch n := n.(type) {
@@ -12,11 +12,13 @@ import (
 	"go/token"
 	"go/types"
 	"sort"
+	"strings"
 
 	"github.com/iansmith/golang-x-tools/internal/event"
 	"github.com/iansmith/golang-x-tools/internal/lsp/protocol"
 	"github.com/iansmith/golang-x-tools/internal/lsp/safetoken"
 	"github.com/iansmith/golang-x-tools/internal/span"
+	"github.com/iansmith/golang-x-tools/internal/typeparams"
 )
 
 func Implementation(ctx context.Context, snapshot Snapshot, f FileHandle, pp protocol.Position) ([]protocol.Location, error) {
@@ -27,7 +29,10 @@ func Implementation(ctx context.Context, snapshot Snapshot, f FileHandle, pp pro
 	if err != nil {
 		return nil, err
 	}
-	var locations []protocol.Location
+	var (
+		locations []protocol.Location
+		insts     = make(map[protocol.Location]string)
+	)
 	for _, impl := range impls {
 		if impl.pkg == nil || len(impl.pkg.CompiledGoFiles()) == 0 {
 			continue
@@ -40,13 +45,22 @@ func Implementation(ctx context.Context, snapshot Snapshot, f FileHandle, pp pro
 		if err != nil {
 			return nil, err
 		}
-		locations = append(locations, protocol.Location{
+		loc := protocol.Location{
 			URI:   protocol.URIFromSpanURI(rng.URI()),
 			Range: pr,
-		})
+		}
+		if _, ok := insts[loc]; ok {
+			continue
+		}
+		insts[loc] = impl.inst
+		locations = append(locations, loc)
 	}
+	// Group the matches of generic code by instantiation.
 	sort.Slice(locations, func(i, j int) bool {
 		li, lj := locations[i], locations[j]
+		if insts[li] != insts[lj] {
+			return insts[li] < insts[lj]
+		}
 		if li.URI == lj.URI {
 			return protocol.CompareRange(li.Range, lj.Range) < 0
 		}
@@ -59,10 +73,17 @@ var ErrNotAType = errors.New("not a type name or method")
 
 // implementations returns the concrete implementations of the specified
 // interface, or the interfaces implemented by the specified concrete type.
+//
+// For generic code, it also returns the types that satisfy the specified
+// constraint, or the constraint of the specified type parameter, and the
+// constraints, named or declared by a type parameter, that the specified
+// type satisfies. A generic interface is matched through the instantiation
+// inferred from the corresponding method signatures, which is recorded in
+// the inst field of the results.
 func implementations(ctx context.Context, s Snapshot, f FileHandle, pp protocol.Position) ([]qualifiedObject, error) {
 	var (
 		impls []qualifiedObject
-		seen  = make(map[token.Position]bool)
+		seen  = make(map[implKey]bool)
 		fset  = s.FileSet()
 	)
 
@@ -80,25 +101,45 @@ func implementations(ctx context.Context, s Snapshot, f FileHandle, pp protocol.
 		case *types.Func:
 			queryMethod = obj
 			if recv := obj.Type().(*types.Signature).Recv(); recv != nil {
-				queryType = ensurePointer(recv.Type())
+				queryType = recv.Type()
+				if named, ok := queryType.(*types.Named); ok {
+					// The methods of a generic interface have its
+					// instantiation by its own type parameters as receiver.
+					queryType = typeparams.NamedTypeOrigin(named)
+				}
 			}
 		case *types.TypeName:
-			queryType = ensurePointer(obj.Type())
+			queryType = obj.Type()
+			// The types that satisfy the constraint of a type parameter
+			// are its possible type arguments.
+			if tparam, ok := queryType.(*typeparams.TypeParam); ok {
+				queryType = tparam.Constraint()
+			}
 		}
 
 		if queryType == nil {
 			return nil, ErrNotAType
 		}
 
-		if types.NewMethodSet(queryType).Len() == 0 {
+		if IsInterface(queryType) && types.NewMethodSet(queryType).Len() == 0 && !isConstraint(queryType) {
 			return nil, nil
 		}
 
+		// A concrete type is only matched against the constraints declared
+		// where it may be used as a type argument.
+		var scope map[*types.Package]bool
+		if !IsInterface(queryType) {
+			if scope, err = constraintScope(ctx, s, qo.pkg); err != nil {
+				return nil, err
+			}
+		}
+
 		// Find all named types, even local types (which can have methods
-		// due to promotion).
+		// due to promotion), and, for a concrete type, the constraints
+		// declared by type parameters with a type set.
 		var (
-			allNamed []*types.Named
-			pkgs     = make(map[*types.Package]Package)
+			cands []implCandidate
+			pkgs  = make(map[*types.Package]Package)
 		)
 		knownPkgs, err := s.KnownPackages(ctx)
 		if err != nil {
@@ -114,25 +155,33 @@ func implementations(ctx context.Context, s Snapshot, f FileHandle, pp protocol.
 				if !ok || obj.IsAlias() {
 					continue
 				}
-				if named, ok := obj.Type().(*types.Named); ok {
-					allNamed = append(allNamed, named)
+				switch typ := obj.Type().(type) {
+				case *types.Named:
+					if isConstraint(typ) && scope != nil && !scope[obj.Pkg()] {
+						continue
+					}
+					cands = append(cands, implCandidate{obj, typ})
+				case *typeparams.TypeParam:
+					// Named constraints are reported once as such.
+					constraint := typ.Constraint()
+					if _, named := constraint.(*types.Named); !named && scope[obj.Pkg()] && queryMethod == nil && isConstraint(constraint) {
+						cands = append(cands, implCandidate{obj, constraint})
+					}
 				}
 			}
 		}
 
-		// Find all the named types that match our query.
-		for _, named := range allNamed {
-			var (
-				candObj  types.Object = named.Obj()
-				candType              = ensurePointer(named)
-			)
+		// Find all the candidates that match our query.
+		for _, cand := range cands {
+			candObj := cand.obj
 
-			if !concreteImplementsIntf(candType, queryType) {
+			inst, ok := matchImplementation(cand.typ, queryType)
+			if !ok {
 				continue
 			}
 
-			ms := types.NewMethodSet(candType)
-			if ms.Len() == 0 {
+			ms := types.NewMethodSet(ensurePointer(cand.typ))
+			if ms.Len() == 0 && !isConstraint(cand.typ) && !isConstraint(queryType) {
 				// Skip empty interfaces.
 				continue
 			}
@@ -146,16 +195,17 @@ func implementations(ctx context.Context, s Snapshot, f FileHandle, pp protocol.
 				candObj = sel.Obj()
 			}
 
-			pos := fset.Position(candObj.Pos())
-			if candObj == queryMethod || seen[pos] {
+			key := implKey{fset.Position(candObj.Pos()), inst}
+			if candObj == queryMethod || seen[key] {
 				continue
 			}
 
-			seen[pos] = true
+			seen[key] = true
 
 			impls = append(impls, qualifiedObject{
-				obj: candObj,
-				pkg: pkgs[candObj.Pkg()],
+				obj:  candObj,
+				pkg:  pkgs[candObj.Pkg()],
+				inst: inst,
 			})
 		}
 	}
@@ -163,14 +213,55 @@ func implementations(ctx context.Context, s Snapshot, f FileHandle, pp protocol.
 	return impls, nil
 }
 
-// concreteImplementsIntf returns true if a is an interface type implemented by
-// concrete type b, or vice versa.
-func concreteImplementsIntf(a, b types.Type) bool {
+// constraintScope returns the packages whose constraints may have a type
+// declared in pkg as type argument: pkg, its dependencies, and the
+// packages that depend on it.
+func constraintScope(ctx context.Context, s Snapshot, pkg Package) (map[*types.Package]bool, error) {
+	scope := make(map[*types.Package]bool)
+	var addDeps func(Package)
+	addDeps = func(pkg Package) {
+		if scope[pkg.GetTypes()] {
+			return
+		}
+		scope[pkg.GetTypes()] = true
+		for _, imp := range pkg.Imports() {
+			addDeps(imp)
+		}
+	}
+	addDeps(pkg)
+	rdeps, err := s.GetReverseDependencies(ctx, pkg.ID())
+	if err != nil {
+		return nil, err
+	}
+	for _, rdep := range rdeps {
+		scope[rdep.GetTypes()] = true
+	}
+	return scope, nil
+}
+
+// An implCandidate is a type that implementations may report, declared
+// by obj.
+type implCandidate struct {
+	obj types.Object
+	typ types.Type
+}
+
+// An implKey identifies a result of implementations.
+type implKey struct {
+	pos  token.Position
+	inst string
+}
+
+// matchImplementation reports whether a is an interface type implemented,
+// or a constraint satisfied, by concrete type b, or vice versa. If they
+// match through an instantiation of a generic interface, or by binding
+// the type parameters mentioned by a constraint, inst describes it.
+func matchImplementation(a, b types.Type) (inst string, ok bool) {
 	aIsIntf, bIsIntf := IsInterface(a), IsInterface(b)
 
 	// Make sure exactly one is an interface type.
 	if aIsIntf == bIsIntf {
-		return false
+		return "", false
 	}
 
 	// Rearrange if needed so "a" is the concrete type.
@@ -178,7 +269,216 @@ func concreteImplementsIntf(a, b types.Type) bool {
 		a, b = b, a
 	}
 
-	return types.AssignableTo(a, b)
+	if named, ok := b.(*types.Named); ok && isGeneric(named) {
+		if concrete, ok := a.(*types.Named); ok && isGeneric(concrete) {
+			return "", typeparams.GenericAssignableTo(nil, concrete, named)
+		}
+		targs, ok := inferTypeArgs(a, named)
+		if !ok {
+			return "", false
+		}
+		instance, err := typeparams.Instantiate(nil, named, targs, true)
+		if err != nil || !satisfies(a, instance) {
+			return "", false
+		}
+		return types.TypeString(instance, packageQualifier), true
+	}
+	if satisfies(a, b) {
+		return "", true
+	}
+	return matchTerms(a, b)
+}
+
+// satisfies reports whether the concrete type T implements the interface
+// iface or, if iface is a constraint, is in its type set.
+func satisfies(T, iface types.Type) bool {
+	if isConstraint(iface) {
+		return types.Implements(T, iface.Underlying().(*types.Interface))
+	}
+	return types.AssignableTo(ensurePointer(T), iface)
+}
+
+// matchTerms reports whether the concrete type T satisfies the constraint
+// iface through a term that mentions type parameters, such as ~[]E: the
+// core type of the term is unified with the underlying type of T, binding
+// the type parameters. It returns the bindings.
+func matchTerms(T, iface types.Type) (string, bool) {
+	if !isConstraint(iface) {
+		return "", false
+	}
+	itf := iface.Underlying().(*types.Interface)
+	ms := types.NewMethodSet(T)
+	for i := 0; i < itf.NumMethods(); i++ {
+		if m := itf.Method(i); ms.Lookup(m.Pkg(), m.Name()) == nil {
+			return "", false
+		}
+	}
+	terms, err := typeparams.InterfaceTermSet(itf)
+	if err != nil {
+		return "", false
+	}
+	for _, term := range terms {
+		bindings := make(map[*typeparams.TypeParam]types.Type)
+		x, y := term.Type(), T
+		if term.Tilde() {
+			x, y = x.Underlying(), y.Underlying()
+		}
+		if !unify(x, y, bindings) || len(bindings) == 0 || !satisfyConstraints(bindings) {
+			continue
+		}
+		var tparams []*typeparams.TypeParam
+		for tparam := range bindings {
+			tparams = append(tparams, tparam)
+		}
+		sort.Slice(tparams, func(i, j int) bool {
+			return tparams[i].Index() < tparams[j].Index()
+		})
+		var args []string
+		for _, tparam := range tparams {
+			args = append(args, tparam.Obj().Name()+" = "+types.TypeString(bindings[tparam], packageQualifier))
+		}
+		return strings.Join(args, ", "), true
+	}
+	return "", false
+}
+
+// satisfyConstraints reports whether the types bound to type parameters
+// satisfy their constraints.
+func satisfyConstraints(bindings map[*typeparams.TypeParam]types.Type) bool {
+	for tparam, T := range bindings {
+		if iface, ok := tparam.Constraint().Underlying().(*types.Interface); ok && !types.Implements(T, iface) {
+			return false
+		}
+	}
+	return true
+}
+
+// inferTypeArgs infers the type arguments of the generic interface named
+// by which the concrete type T may implement it, unifying the signatures
+// of its methods with those of T, and then its terms with T.
+func inferTypeArgs(T types.Type, named *types.Named) ([]types.Type, bool) {
+	iface := named.Underlying().(*types.Interface)
+	ms := types.NewMethodSet(ensurePointer(T))
+	bindings := make(map[*typeparams.TypeParam]types.Type)
+	for i := 0; i < iface.NumMethods(); i++ {
+		m := iface.Method(i)
+		sel := ms.Lookup(m.Pkg(), m.Name())
+		if sel == nil || !unify(m.Type(), sel.Obj().Type(), bindings) {
+			return nil, false
+		}
+	}
+	tparams := typeparams.ForNamed(named)
+	if len(bindings) < tparams.Len() && isConstraint(iface) {
+		terms, err := typeparams.InterfaceTermSet(iface)
+		if err != nil {
+			return nil, false
+		}
+		for _, term := range terms {
+			b := make(map[*typeparams.TypeParam]types.Type, len(bindings))
+			for tparam, T := range bindings {
+				b[tparam] = T
+			}
+			x, y := term.Type(), T
+			if term.Tilde() {
+				x, y = x.Underlying(), y.Underlying()
+			}
+			if unify(x, y, b) {
+				bindings = b
+				break
+			}
+		}
+	}
+	targs := make([]types.Type, tparams.Len())
+	for i := range targs {
+		if targs[i] = bindings[tparams.At(i)]; targs[i] == nil {
+			return nil, false
+		}
+	}
+	return targs, true
+}
+
+// unify reports whether the type x, which may mention type parameters,
+// matches y once every type parameter is replaced by its type in bindings.
+// Type parameters without a binding are bound to the matching part of y.
+func unify(x, y types.Type, bindings map[*typeparams.TypeParam]types.Type) bool {
+	if tparam, ok := x.(*typeparams.TypeParam); ok {
+		if bound, ok := bindings[tparam]; ok {
+			return types.Identical(bound, y)
+		}
+		bindings[tparam] = y
+		return true
+	}
+	switch x := x.(type) {
+	case *types.Pointer:
+		y, ok := y.(*types.Pointer)
+		return ok && unify(x.Elem(), y.Elem(), bindings)
+	case *types.Slice:
+		y, ok := y.(*types.Slice)
+		return ok && unify(x.Elem(), y.Elem(), bindings)
+	case *types.Array:
+		y, ok := y.(*types.Array)
+		return ok && x.Len() == y.Len() && unify(x.Elem(), y.Elem(), bindings)
+	case *types.Map:
+		y, ok := y.(*types.Map)
+		return ok && unify(x.Key(), y.Key(), bindings) && unify(x.Elem(), y.Elem(), bindings)
+	case *types.Chan:
+		y, ok := y.(*types.Chan)
+		return ok && x.Dir() == y.Dir() && unify(x.Elem(), y.Elem(), bindings)
+	case *types.Signature:
+		y, ok := y.(*types.Signature)
+		return ok && x.Variadic() == y.Variadic() &&
+			unifyTuples(x.Params(), y.Params(), bindings) &&
+			unifyTuples(x.Results(), y.Results(), bindings)
+	case *types.Named:
+		xargs := typeparams.NamedTypeArgs(x)
+		if xargs.Len() == 0 {
+			break
+		}
+		y, ok := y.(*types.Named)
+		if !ok || typeparams.NamedTypeOrigin(x) != typeparams.NamedTypeOrigin(y) {
+			return false
+		}
+		yargs := typeparams.NamedTypeArgs(y)
+		for i := 0; i < xargs.Len(); i++ {
+			if !unify(xargs.At(i), yargs.At(i), bindings) {
+				return false
+			}
+		}
+		return true
+	}
+	return types.Identical(x, y)
+}
+
+// unifyTuples unifies the types of the elements of x and y.
+func unifyTuples(x, y *types.Tuple, bindings map[*typeparams.TypeParam]types.Type) bool {
+	if x.Len() != y.Len() {
+		return false
+	}
+	for i := 0; i < x.Len(); i++ {
+		if !unify(x.At(i).Type(), y.At(i).Type(), bindings) {
+			return false
+		}
+	}
+	return true
+}
+
+// isConstraint reports whether T is an interface that may only be used
+// as a constraint, because it has a type set or embeds comparable.
+func isConstraint(T types.Type) bool {
+	iface, ok := T.Underlying().(*types.Interface)
+	return ok && !typeparams.IsMethodSet(iface)
+}
+
+// isGeneric reports whether named is a generic type that is not
+// instantiated.
+func isGeneric(named *types.Named) bool {
+	return typeparams.ForNamed(named).Len() > 0 && typeparams.NamedTypeArgs(named).Len() == 0
+}
+
+// packageQualifier qualifies the names of types by the name of their
+// package.
+func packageQualifier(pkg *types.Package) string {
+	return pkg.Name()
 }
 
 // ensurePointer wraps T in a *types.Pointer if T is a named, non-interface
@@ -203,6 +503,10 @@ type qualifiedObject struct {
 
 	// sourcePkg is the Package that contains node, if any.
 	sourcePkg Package
+
+	// inst describes the instantiation of a generic interface, or the type
+	// arguments of a constraint, through which implementations matched obj.
+	inst string
 }
 
 var (
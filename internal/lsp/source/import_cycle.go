@@ -0,0 +1,569 @@
+// Copyright 2022 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package source
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/iansmith/golang-x-tools/go/ast/astutil"
+	"github.com/iansmith/golang-x-tools/internal/event"
+	"github.com/iansmith/golang-x-tools/internal/lsp/protocol"
+	"github.com/iansmith/golang-x-tools/internal/span"
+	"github.com/iansmith/golang-x-tools/internal/typeparams"
+)
+
+// An ImportReference is a use, in a file of a package, of a symbol of a
+// package that it imports.
+type ImportReference struct {
+	URI   span.URI
+	Range protocol.Range
+	// Name is the qualified name of the symbol, such as "fmt.Println".
+	Name string
+}
+
+// ImportReferences returns the references in the files of pkg to the
+// symbols of the package with the given import path, sorted by position.
+//
+// The references are found syntactically, as the imported package may be
+// missing from the type information of pkg, for example when the import
+// is part of a cycle.
+func ImportReferences(fset *token.FileSet, pkg Package, importPath string) ([]ImportReference, error) {
+	var refs []ImportReference
+	for _, pgf := range pkg.CompiledGoFiles() {
+		_, sels := importSelectors(pkg, pgf.File, importPath)
+		for _, sel := range sels {
+			rng, err := NewMappedRange(fset, pgf.Mapper, sel.Pos(), sel.End()).Range()
+			if err != nil {
+				return nil, err
+			}
+			refs = append(refs, ImportReference{
+				URI:   pgf.URI,
+				Range: rng,
+				Name:  sel.X.(*ast.Ident).Name + "." + sel.Sel.Name,
+			})
+		}
+	}
+	return refs, nil
+}
+
+// importSelectors returns the specs in file importing the package with the
+// given import path, and the selector expressions of file that refer to a
+// symbol of that package through them.
+func importSelectors(pkg Package, file *ast.File, importPath string) ([]*ast.ImportSpec, []*ast.SelectorExpr) {
+	info := pkg.GetTypesInfo()
+	var specs []*ast.ImportSpec
+	names := make(map[string]types.Object) // the package name of each spec, if type checked
+	for _, spec := range file.Imports {
+		if ImportPath(spec) != importPath {
+			continue
+		}
+		var obj types.Object
+		name := path.Base(importPath)
+		if spec.Name != nil {
+			name = spec.Name.Name
+			obj = info.Defs[spec.Name]
+		} else if obj = info.Implicits[spec]; obj != nil {
+			name = obj.Name()
+		}
+		if name == "_" || name == "." {
+			continue
+		}
+		specs = append(specs, spec)
+		names[name] = obj
+	}
+	if len(specs) == 0 {
+		return nil, nil
+	}
+	var sels []*ast.SelectorExpr
+	ast.Inspect(file, func(n ast.Node) bool {
+		sel, ok := n.(*ast.SelectorExpr)
+		if !ok {
+			return true
+		}
+		id, ok := sel.X.(*ast.Ident)
+		if !ok {
+			return true
+		}
+		obj, ok := names[id.Name]
+		if !ok {
+			return true
+		}
+		// Skip the identifiers that a local declaration shadows.
+		switch use := info.Uses[id].(type) {
+		case nil:
+			if obj != nil {
+				return true
+			}
+		case *types.PkgName:
+			if obj != nil && use != obj {
+				return true
+			}
+		default:
+			return true
+		}
+		sels = append(sels, sel)
+		return false
+	})
+	return specs, sels
+}
+
+// ExtractImportedSymbol breaks the import by the package containing uri of
+// the package with the given import path, when the package refers to a
+// single symbol of it: the declaration of the symbol moves to a new package
+// in a subdirectory of the imported package, which the importing package
+// imports instead, and the imported package keeps the symbol as an alias or
+// a wrapper of the moved declaration.
+//
+// It returns the URI and content of the file of the new package, and the
+// edits to the existing files.
+func ExtractImportedSymbol(ctx context.Context, snapshot Snapshot, uri span.URI, importPath string) (span.URI, []byte, map[span.URI][]protocol.TextEdit, error) {
+	ctx, done := event.Start(ctx, "source.ExtractImportedSymbol")
+	defer done()
+
+	pkg, err := snapshot.PackageForFile(ctx, uri, TypecheckWorkspace, NarrowestPackage)
+	if err != nil {
+		return "", nil, nil, err
+	}
+	uses := make(map[string]bool)
+	for _, pgf := range pkg.CompiledGoFiles() {
+		_, sels := importSelectors(pkg, pgf.File, importPath)
+		for _, sel := range sels {
+			uses[sel.Sel.Name] = true
+		}
+	}
+	if len(uses) != 1 {
+		return "", nil, nil, fmt.Errorf("%s refers to %d symbols of %s, not one", pkg.PkgPath(), len(uses), importPath)
+	}
+	var name string
+	for name = range uses {
+	}
+
+	imported, err := workspacePackage(ctx, snapshot, importPath)
+	if err != nil {
+		return "", nil, nil, err
+	}
+	x, err := findExtractableDecl(ctx, snapshot, imported, name, pkg.PkgPath())
+	if err != nil {
+		return "", nil, nil, err
+	}
+
+	newName := strings.ToLower(name)
+	if token.IsKeyword(newName) {
+		newName += "pkg"
+	}
+	newDir := filepath.Join(filepath.Dir(x.pgf.URI.Filename()), newName)
+	if _, err := os.Stat(newDir); err == nil {
+		return "", nil, nil, fmt.Errorf("%s already exists", newDir)
+	}
+	newPath := importPath + "/" + newName
+	newURI := span.URIFromPath(filepath.Join(newDir, newName+".go"))
+	content, err := x.newFile(newName)
+	if err != nil {
+		return "", nil, nil, err
+	}
+
+	edits := make(map[span.URI][]protocol.TextEdit)
+	qualEdits, err := x.forward(snapshot, newName, newPath)
+	if err != nil {
+		return "", nil, nil, err
+	}
+	edits[x.pgf.URI] = qualEdits
+	for _, pgf := range pkg.CompiledGoFiles() {
+		fileEdits, err := requalify(snapshot, pkg, pgf, importPath, newName, newPath)
+		if err != nil {
+			return "", nil, nil, err
+		}
+		if len(fileEdits) > 0 {
+			edits[pgf.URI] = fileEdits
+		}
+	}
+	return newURI, content, edits, nil
+}
+
+// workspacePackage returns the non-test workspace package with the given
+// import path.
+func workspacePackage(ctx context.Context, snapshot Snapshot, importPath string) (Package, error) {
+	pkgs, err := snapshot.ActivePackages(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, p := range pkgs {
+		if p.PkgPath() == importPath && p.ForTest() == "" {
+			return p, nil
+		}
+	}
+	return nil, fmt.Errorf("no package %s in the workspace", importPath)
+}
+
+// An extractableDecl is a top-level declaration that can move to a new
+// package.
+type extractableDecl struct {
+	pkg  Package
+	pgf  *ParsedGoFile
+	decl ast.Decl
+	spec ast.Spec // of a grouped type declaration, or nil
+	obj  types.Object
+}
+
+// findExtractableDecl returns the declaration of name in pkg, if it can move
+// to a new package without creating an import cycle with importer: it must
+// be a constant, a type without methods or type parameters, or a function
+// without type parameters, and it must not refer to the other package-level
+// symbols of pkg, nor to a package that depends on importer.
+func findExtractableDecl(ctx context.Context, snapshot Snapshot, pkg Package, name, importer string) (*extractableDecl, error) {
+	obj := pkg.GetTypes().Scope().Lookup(name)
+	if obj == nil {
+		return nil, fmt.Errorf("no symbol %s in %s", name, pkg.PkgPath())
+	}
+	x := &extractableDecl{pkg: pkg, obj: obj}
+	for _, pgf := range pkg.CompiledGoFiles() {
+		if pgf.File.Pos() <= obj.Pos() && obj.Pos() < pgf.File.End() {
+			x.pgf = pgf
+		}
+	}
+	if x.pgf == nil {
+		return nil, fmt.Errorf("no declaration of %s in %s", name, pkg.PkgPath())
+	}
+	for _, decl := range x.pgf.File.Decls {
+		if decl.Pos() <= obj.Pos() && obj.Pos() < decl.End() {
+			x.decl = decl
+		}
+	}
+	switch decl := x.decl.(type) {
+	case *ast.FuncDecl:
+		if decl.Recv != nil || typeparams.ForFuncType(decl.Type) != nil {
+			return nil, fmt.Errorf("cannot move the generic function %s", name)
+		}
+		if decl.Body == nil {
+			return nil, fmt.Errorf("cannot move the function %s without a body", name)
+		}
+	case *ast.GenDecl:
+		switch decl.Tok {
+		case token.TYPE:
+			spec := decl.Specs[0].(*ast.TypeSpec)
+			for _, s := range decl.Specs {
+				if s.Pos() <= obj.Pos() && obj.Pos() < s.End() {
+					spec = s.(*ast.TypeSpec)
+				}
+			}
+			if spec.Assign.IsValid() || typeparams.ForTypeSpec(spec) != nil {
+				return nil, fmt.Errorf("cannot move the alias or generic type %s", name)
+			}
+			if named, ok := obj.Type().(*types.Named); !ok || named.NumMethods() > 0 {
+				return nil, fmt.Errorf("cannot move the type %s, which has methods", name)
+			}
+			if decl.Lparen.IsValid() {
+				x.spec = spec
+			}
+		case token.CONST:
+			if decl.Lparen.IsValid() || len(decl.Specs[0].(*ast.ValueSpec).Names) != 1 {
+				return nil, fmt.Errorf("cannot move the constant %s out of its group", name)
+			}
+		default:
+			return nil, fmt.Errorf("cannot move the variable %s", name)
+		}
+	default:
+		return nil, fmt.Errorf("no declaration of %s in %s", name, pkg.PkgPath())
+	}
+
+	graph, err := snapshot.ImportGraph(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, imp := range x.imports() {
+		if dependsOn(graph, imp.Imported().Path(), importer) {
+			return nil, fmt.Errorf("cannot move %s: it refers to %s, which imports %s", name, imp.Imported().Path(), importer)
+		}
+	}
+	info := pkg.GetTypesInfo()
+	var local types.Object
+	ast.Inspect(x.node(), func(n ast.Node) bool {
+		if id, ok := n.(*ast.Ident); ok && local == nil {
+			if use := info.Uses[id]; use != nil && use != obj && use.Parent() == pkg.GetTypes().Scope() {
+				local = use
+			}
+		}
+		return local == nil
+	})
+	if local != nil {
+		return nil, fmt.Errorf("cannot move %s: it refers to %s of %s", name, local.Name(), pkg.PkgPath())
+	}
+	return x, nil
+}
+
+// dependsOn reports whether the package with import path from imports the
+// one with import path to, directly or indirectly, in graph.
+func dependsOn(graph []*ImportGraphNode, from, to string) bool {
+	byID := make(map[string]*ImportGraphNode)
+	for _, node := range graph {
+		byID[node.ID] = node
+	}
+	seen := make(map[string]bool)
+	var visit func(node *ImportGraphNode) bool
+	visit = func(node *ImportGraphNode) bool {
+		if node == nil || seen[node.ID] {
+			return false
+		}
+		seen[node.ID] = true
+		if node.PkgPath == to {
+			return true
+		}
+		for _, id := range node.Imports {
+			if visit(byID[id]) {
+				return true
+			}
+		}
+		return false
+	}
+	for _, node := range graph {
+		if node.PkgPath == from && !node.Test && visit(node) {
+			return true
+		}
+	}
+	return false
+}
+
+// node returns the syntax that moves: the grouped spec, or the declaration.
+func (x *extractableDecl) node() ast.Node {
+	if x.spec != nil {
+		return x.spec
+	}
+	return x.decl
+}
+
+// imports returns the packages that the declaration refers to.
+func (x *extractableDecl) imports() []*types.PkgName {
+	info := x.pkg.GetTypesInfo()
+	seen := make(map[*types.PkgName]bool)
+	var imports []*types.PkgName
+	ast.Inspect(x.node(), func(n ast.Node) bool {
+		if id, ok := n.(*ast.Ident); ok {
+			if pkgName, ok := info.Uses[id].(*types.PkgName); ok && !seen[pkgName] {
+				seen[pkgName] = true
+				imports = append(imports, pkgName)
+			}
+		}
+		return true
+	})
+	sort.Slice(imports, func(i, j int) bool {
+		return imports[i].Imported().Path() < imports[j].Imported().Path()
+	})
+	return imports
+}
+
+// text returns the source of the nodes from start to end.
+func (x *extractableDecl) text(start, end token.Pos) (string, error) {
+	s, e, err := posOffsets(x.pgf.Tok, start, end)
+	if err != nil {
+		return "", err
+	}
+	if e > len(x.pgf.Src) {
+		return "", fmt.Errorf("range [%d:%d) is outside the source of %s", s, e, x.pgf.URI.Filename())
+	}
+	return string(x.pgf.Src[s:e]), nil
+}
+
+// newFile returns the content of the file of the new package name, holding
+// the declaration.
+func (x *extractableDecl) newFile(name string) ([]byte, error) {
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "package %s\n\n", name)
+	if imports := x.imports(); len(imports) > 0 {
+		buf.WriteString("import (\n")
+		for _, imp := range imports {
+			buf.WriteString("\t")
+			if imp.Name() != imp.Imported().Name() {
+				buf.WriteString(imp.Name() + " ")
+			}
+			buf.WriteString(strconv.Quote(imp.Imported().Path()) + "\n")
+		}
+		buf.WriteString(")\n\n")
+	}
+	var doc *ast.CommentGroup
+	switch decl := x.decl.(type) {
+	case *ast.FuncDecl:
+		doc = decl.Doc
+	case *ast.GenDecl:
+		if x.spec != nil {
+			doc = x.spec.(*ast.TypeSpec).Doc
+		} else {
+			doc = decl.Doc
+		}
+	}
+	if doc != nil {
+		text, err := x.text(doc.Pos(), doc.End())
+		if err != nil {
+			return nil, err
+		}
+		buf.WriteString(text + "\n")
+	}
+	if x.spec != nil {
+		text, err := x.text(x.spec.Pos(), x.spec.End())
+		if err != nil {
+			return nil, err
+		}
+		buf.WriteString("type " + text + "\n")
+	} else {
+		text, err := x.text(x.decl.Pos(), x.decl.End())
+		if err != nil {
+			return nil, err
+		}
+		buf.WriteString(text + "\n")
+	}
+	return format.Source(buf.Bytes())
+}
+
+// forward returns the edits to the file of the declaration that replace it
+// with an alias or a wrapper of the moved declaration, in the package with
+// the given name and import path.
+func (x *extractableDecl) forward(snapshot Snapshot, name, importPath string) ([]protocol.TextEdit, error) {
+	if declsUseName(x.pgf.File, name) {
+		return nil, fmt.Errorf("cannot import %s in %s: the name %s is already in use", importPath, x.pgf.URI.Filename(), name)
+	}
+	qualified := name + "." + x.obj.Name()
+	var start, end token.Pos
+	var repl string
+	switch decl := x.decl.(type) {
+	case *ast.FuncDecl:
+		start, end = decl.Pos(), decl.End()
+		var params, args []string
+		for _, field := range decl.Type.Params.List {
+			typ, err := x.text(field.Type.Pos(), field.Type.End())
+			if err != nil {
+				return nil, err
+			}
+			variadic := ""
+			if _, ok := field.Type.(*ast.Ellipsis); ok {
+				variadic = "..."
+			}
+			names := field.Names
+			if len(names) == 0 {
+				names = []*ast.Ident{{Name: "_"}}
+			}
+			var fieldNames []string
+			for _, id := range names {
+				arg := id.Name
+				if arg == "_" {
+					// Name the parameter to pass it on.
+					arg = fmt.Sprintf("p%d", len(args))
+				}
+				fieldNames = append(fieldNames, arg)
+				args = append(args, arg+variadic)
+			}
+			params = append(params, strings.Join(fieldNames, ", ")+" "+typ)
+		}
+		results := ""
+		if decl.Type.Results != nil {
+			text, err := x.text(decl.Type.Results.Pos(), decl.Type.Results.End())
+			if err != nil {
+				return nil, err
+			}
+			results = " " + text
+		}
+		call := fmt.Sprintf("%s(%s)", qualified, strings.Join(args, ", "))
+		if results != "" {
+			call = "return " + call
+		}
+		repl = fmt.Sprintf("func %s(%s)%s {\n\t%s\n}", x.obj.Name(), strings.Join(params, ", "), results, call)
+	case *ast.GenDecl:
+		if x.spec != nil {
+			start, end = x.spec.Pos(), x.spec.End()
+			repl = fmt.Sprintf("%s = %s", x.obj.Name(), qualified)
+		} else {
+			start, end = decl.Pos(), decl.End()
+			repl = fmt.Sprintf("%s %s = %s", decl.Tok, x.obj.Name(), qualified)
+		}
+	}
+	oldImports := x.imports()
+
+	startOffset, endOffset, err := posOffsets(x.pgf.Tok, start, end)
+	if err != nil {
+		return nil, err
+	}
+	src := string(x.pgf.Src)
+	edited := src[:startOffset] + repl + src[endOffset:]
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, x.pgf.URI.Filename(), edited, parser.ParseComments)
+	if err != nil {
+		return nil, err
+	}
+	astutil.AddImport(fset, file, importPath)
+	for _, imp := range oldImports {
+		path := imp.Imported().Path()
+		if !astutil.UsesImport(file, path) {
+			named := ""
+			if imp.Name() != imp.Imported().Name() {
+				named = imp.Name()
+			}
+			astutil.DeleteNamedImport(fset, file, named, path)
+		}
+	}
+	var buf bytes.Buffer
+	if err := format.Node(&buf, fset, file); err != nil {
+		return nil, err
+	}
+	diffs, err := snapshot.View().Options().ComputeEdits(x.pgf.URI, src, buf.String())
+	if err != nil {
+		return nil, err
+	}
+	return ToProtocolEdits(x.pgf.Mapper, diffs)
+}
+
+// requalify returns the edits to pgf, a file of pkg, that replace its
+// import of oldPath by one of newPath, whose name is newName, and the
+// qualifier of the references to the imported package by newName.
+func requalify(snapshot Snapshot, pkg Package, pgf *ParsedGoFile, oldPath, newName, newPath string) ([]protocol.TextEdit, error) {
+	specs, sels := importSelectors(pkg, pgf.File, oldPath)
+	if len(specs) == 0 {
+		return nil, nil
+	}
+	var edits []protocol.TextEdit
+	edit := func(start, end token.Pos, newText string) error {
+		rng, err := NewMappedRange(snapshot.FileSet(), pgf.Mapper, start, end).Range()
+		if err != nil {
+			return err
+		}
+		edits = append(edits, protocol.TextEdit{Range: rng, NewText: newText})
+		return nil
+	}
+	for _, spec := range specs {
+		if err := edit(spec.Pos(), spec.End(), strconv.Quote(newPath)); err != nil {
+			return nil, err
+		}
+	}
+	if len(sels) > 0 && sels[0].X.(*ast.Ident).Name != newName && declsUseName(pgf.File, newName) {
+		return nil, fmt.Errorf("cannot import %s in %s: the name %s is already in use", newPath, pgf.URI.Filename(), newName)
+	}
+	for _, sel := range sels {
+		if err := edit(sel.X.Pos(), sel.X.End(), newName); err != nil {
+			return nil, err
+		}
+	}
+	return edits, nil
+}
+
+// declsUseName reports whether any identifier in the imports and
+// declarations of file is named name.
+func declsUseName(file *ast.File, name string) bool {
+	for _, decl := range file.Decls {
+		if usesName(decl, name) {
+			return true
+		}
+	}
+	return false
+}
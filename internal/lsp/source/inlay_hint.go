@@ -0,0 +1,409 @@
+// Copyright 2022 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package source
+
+import (
+	"context"
+	"fmt"
+	"go/ast"
+	"go/token"
+	"go/types"
+	"sort"
+
+	"github.com/iansmith/golang-x-tools/internal/event"
+	"github.com/iansmith/golang-x-tools/internal/lsp/lsppos"
+	"github.com/iansmith/golang-x-tools/internal/lsp/protocol"
+	"github.com/iansmith/golang-x-tools/internal/typeparams"
+)
+
+// maxLabelLength is the length beyond which an inlay hint label is
+// truncated, so that long type names do not obscure the code.
+const maxLabelLength = 28
+
+// An InlayHintFunc returns the inlay hints for a node of a file.
+type InlayHintFunc func(node ast.Node, tmap *lsppos.TokenMapper, info *types.Info, q *types.Qualifier) []protocol.InlayHint
+
+// A Hint describes a category of inlay hints.
+type Hint struct {
+	Name string
+	Doc  string
+	Run  InlayHintFunc
+}
+
+// The names of the categories of inlay hints, used as the keys of the
+// "hints" setting.
+const (
+	ParameterNames             = "parameterNames"
+	CompositeLiteralFieldNames = "compositeLiteralFields"
+	ImplicitConversions        = "implicitConversions"
+)
+
+// AllInlayHints maps the name of each category of inlay hints to its
+// description. Every category is disabled by default.
+var AllInlayHints = map[string]*Hint{
+	ParameterNames: {
+		Name: ParameterNames,
+		Doc:  "Enable/disable inlay hints for parameter names:\n```go\n\tparseInt(/* str: */ \"123\", /* radix: */ 8)\n```",
+		Run:  parameterNames,
+	},
+	CompositeLiteralFieldNames: {
+		Name: CompositeLiteralFieldNames,
+		Doc:  "Enable/disable inlay hints for the field names of unkeyed composite literals:\n```go\n\t{/* in: */ \"Hello, world\", /* want: */ \"dlrow ,olleH\"}\n```",
+		Run:  compositeLiteralFields,
+	},
+	ImplicitConversions: {
+		Name: ImplicitConversions,
+		Doc:  "Enable/disable inlay hints for implicit conversions of values to interface types\nand of untyped integer constants to types other than int:\n```go\n\tfmt.Fprintln(buf /* as io.Writer */, \"hi\")\n\ttime.Sleep(5 /* as time.Duration */)\n```",
+		Run:  implicitConversions,
+	},
+}
+
+// InlayHint returns the enabled inlay hints for the nodes of the file
+// that lie within pRng, or for the whole file if pRng is empty.
+func InlayHint(ctx context.Context, snapshot Snapshot, fh FileHandle, pRng protocol.Range) ([]protocol.InlayHint, error) {
+	ctx, done := event.Start(ctx, "source.InlayHint")
+	defer done()
+
+	// Collect the enabled categories, in a deterministic order.
+	var enabled []string
+	for name, on := range snapshot.View().Options().Hints {
+		if _, ok := AllInlayHints[name]; ok && on {
+			enabled = append(enabled, name)
+		}
+	}
+	if len(enabled) == 0 {
+		return nil, nil
+	}
+	sort.Strings(enabled)
+
+	pkg, pgf, err := GetParsedFile(ctx, snapshot, fh, NarrowestPackage)
+	if err != nil {
+		return nil, fmt.Errorf("getting file for InlayHint: %w", err)
+	}
+	tmap := lsppos.NewTokenMapper(pgf.Src, pgf.Tok)
+	info := pkg.GetTypesInfo()
+	q := Qualifier(pgf.File, pkg.GetTypes(), info)
+
+	start, end := pgf.File.Pos(), pgf.File.End()
+	if pRng.Start != pRng.End {
+		rng, err := pgf.Mapper.RangeToSpanRange(pRng)
+		if err != nil {
+			return nil, err
+		}
+		start, end = rng.Start, rng.End
+	}
+
+	var hints []protocol.InlayHint
+	ast.Inspect(pgf.File, func(node ast.Node) bool {
+		if node == nil || node.End() < start || node.Pos() > end {
+			return false
+		}
+		for _, name := range enabled {
+			hints = append(hints, AllInlayHints[name].Run(node, tmap, info, &q)...)
+		}
+		return true
+	})
+	return hints, nil
+}
+
+// parameterNames labels the arguments of a call with the names of the
+// corresponding parameters, unless the argument is a variable of the
+// same name.
+func parameterNames(node ast.Node, tmap *lsppos.TokenMapper, info *types.Info, _ *types.Qualifier) []protocol.InlayHint {
+	call, ok := node.(*ast.CallExpr)
+	if !ok {
+		return nil
+	}
+	sig, ok := info.TypeOf(call.Fun).(*types.Signature)
+	if !ok {
+		return nil // a conversion, or a call of a builtin
+	}
+	params := sig.Params()
+	var hints []protocol.InlayHint
+	for i, arg := range call.Args {
+		j := i
+		if j >= params.Len() {
+			if !sig.Variadic() {
+				break
+			}
+			j = params.Len() - 1
+		}
+		param := params.At(j)
+		name := param.Name()
+		if name == "" || name == "_" {
+			continue
+		}
+		if id, ok := arg.(*ast.Ident); ok && id.Name == name {
+			continue
+		}
+		if sig.Variadic() && j == params.Len()-1 {
+			if i > j {
+				continue // label only the first variadic argument
+			}
+			if !call.Ellipsis.IsValid() {
+				name = "..." + name
+			}
+		}
+		pos, ok := tmap.Position(arg.Pos())
+		if !ok {
+			continue
+		}
+		hints = append(hints, protocol.InlayHint{
+			Position:     &pos,
+			Label:        buildLabel(name + ":"),
+			Kind:         protocol.Parameter,
+			PaddingRight: true,
+		})
+	}
+	return hints
+}
+
+// compositeLiteralFields labels the elements of an unkeyed struct
+// literal with the names of the corresponding fields.
+func compositeLiteralFields(node ast.Node, tmap *lsppos.TokenMapper, info *types.Info, _ *types.Qualifier) []protocol.InlayHint {
+	lit, ok := node.(*ast.CompositeLit)
+	if !ok {
+		return nil
+	}
+	typ := info.TypeOf(lit)
+	if typ == nil {
+		return nil
+	}
+	if ptr, ok := typeparams.CoreType(typ).(*types.Pointer); ok {
+		typ = ptr.Elem() // for literals in a slice of pointers, such as []*T{{...}}
+	}
+	str, ok := typeparams.CoreType(typ).(*types.Struct)
+	if !ok {
+		return nil
+	}
+	var hints []protocol.InlayHint
+	for i, elt := range lit.Elts {
+		if _, ok := elt.(*ast.KeyValueExpr); ok {
+			return nil // a keyed literal
+		}
+		if i >= str.NumFields() {
+			break
+		}
+		pos, ok := tmap.Position(elt.Pos())
+		if !ok {
+			continue
+		}
+		hints = append(hints, protocol.InlayHint{
+			Position:     &pos,
+			Label:        buildLabel(str.Field(i).Name() + ":"),
+			Kind:         protocol.Parameter,
+			PaddingRight: true,
+		})
+	}
+	return hints
+}
+
+// implicitConversions labels the values that are implicitly converted
+// to an interface type where they are passed as arguments, returned as
+// results, assigned, or used as the elements of composite literals. In
+// the same places, except for assignments, where the type is usually
+// evident, it labels the untyped integer constants that are implicitly
+// converted to an integer type other than their default type.
+func implicitConversions(node ast.Node, tmap *lsppos.TokenMapper, info *types.Info, q *types.Qualifier) []protocol.InlayHint {
+	var hints []protocol.InlayHint
+	convert := func(e ast.Expr, to types.Type, constants bool) {
+		if e == nil || to == nil {
+			return
+		}
+		tv, ok := info.Types[e]
+		if !ok || tv.IsNil() || tv.IsType() {
+			return
+		}
+		switch {
+		case types.IsInterface(to) && !typeparams.IsTypeParam(to):
+			if tv.Type == nil || types.IsInterface(tv.Type) {
+				return
+			}
+		case constants && tv.Value != nil:
+			kind := untypedKind(info, e)
+			if kind == types.Invalid {
+				return
+			}
+			// The type checker records the type to which
+			// an untyped constant is converted.
+			to = tv.Type
+			if types.Identical(to, types.Default(types.Typ[kind])) {
+				return
+			}
+			if b, ok := to.Underlying().(*types.Basic); !ok || b.Info()&types.IsInteger == 0 {
+				return
+			}
+		default:
+			return
+		}
+		pos, ok := tmap.Position(e.End())
+		if !ok {
+			return
+		}
+		hints = append(hints, protocol.InlayHint{
+			Position:    &pos,
+			Label:       buildLabel("as " + types.TypeString(to, *q)),
+			Kind:        protocol.Type,
+			PaddingLeft: true,
+		})
+	}
+
+	switch n := node.(type) {
+	case *ast.CallExpr:
+		sig, ok := info.TypeOf(n.Fun).(*types.Signature)
+		if !ok {
+			return nil
+		}
+		params := sig.Params()
+		for i, arg := range n.Args {
+			switch {
+			case sig.Variadic() && i >= params.Len()-1:
+				if n.Ellipsis.IsValid() {
+					continue
+				}
+				if s, ok := params.At(params.Len() - 1).Type().(*types.Slice); ok {
+					convert(arg, s.Elem(), true)
+				}
+			case i < params.Len():
+				convert(arg, params.At(i).Type(), true)
+			}
+		}
+
+	case *ast.FuncDecl:
+		if n.Body != nil {
+			results(n.Body, info.TypeOf(n.Name), convert)
+		}
+	case *ast.FuncLit:
+		results(n.Body, info.TypeOf(n), convert)
+
+	case *ast.AssignStmt:
+		if n.Tok == token.ASSIGN && len(n.Lhs) == len(n.Rhs) {
+			for i, lhs := range n.Lhs {
+				convert(n.Rhs[i], info.TypeOf(lhs), false)
+			}
+		}
+	case *ast.ValueSpec:
+		if n.Type != nil && len(n.Names) == len(n.Values) {
+			t := info.TypeOf(n.Type)
+			for _, v := range n.Values {
+				convert(v, t, false)
+			}
+		}
+
+	case *ast.CompositeLit:
+		typ := info.TypeOf(n)
+		if typ == nil {
+			return nil
+		}
+		switch t := typeparams.CoreType(typ).(type) {
+		case *types.Struct:
+			for i, elt := range n.Elts {
+				if kv, ok := elt.(*ast.KeyValueExpr); ok {
+					if id, ok := kv.Key.(*ast.Ident); ok {
+						if v, ok := info.ObjectOf(id).(*types.Var); ok {
+							convert(kv.Value, v.Type(), true)
+						}
+					}
+				} else if i < t.NumFields() {
+					convert(elt, t.Field(i).Type(), true)
+				}
+			}
+		case *types.Slice, *types.Array, *types.Map:
+			var key, elem types.Type
+			switch t := t.(type) {
+			case *types.Slice:
+				elem = t.Elem()
+			case *types.Array:
+				elem = t.Elem()
+			case *types.Map:
+				key, elem = t.Key(), t.Elem()
+			}
+			for _, elt := range n.Elts {
+				if kv, ok := elt.(*ast.KeyValueExpr); ok {
+					if key != nil {
+						convert(kv.Key, key, true)
+					}
+					convert(kv.Value, elem, true)
+				} else {
+					convert(elt, elem, true)
+				}
+			}
+		}
+	}
+	return hints
+}
+
+// results calls convert for each result of each return statement in
+// body, which is the body of a function of type sig, excluding those of
+// nested function literals.
+func results(body *ast.BlockStmt, sig types.Type, convert func(ast.Expr, types.Type, bool)) {
+	s, ok := sig.(*types.Signature)
+	if !ok {
+		return
+	}
+	ast.Inspect(body, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.FuncLit:
+			return false
+		case *ast.ReturnStmt:
+			if len(n.Results) == s.Results().Len() {
+				for i, r := range n.Results {
+					convert(r, s.Results().At(i).Type(), true)
+				}
+			}
+		}
+		return true
+	})
+}
+
+// untypedKind returns the kind of e, types.UntypedInt or
+// types.UntypedRune, if e is an untyped integer or rune constant
+// expression, or types.Invalid otherwise.
+func untypedKind(info *types.Info, e ast.Expr) types.BasicKind {
+	switch e := e.(type) {
+	case *ast.BasicLit:
+		switch e.Kind {
+		case token.INT:
+			return types.UntypedInt
+		case token.CHAR:
+			return types.UntypedRune
+		}
+	case *ast.Ident:
+		if c, ok := info.ObjectOf(e).(*types.Const); ok {
+			if b, ok := c.Type().(*types.Basic); ok && (b.Kind() == types.UntypedInt || b.Kind() == types.UntypedRune) {
+				return b.Kind()
+			}
+		}
+	case *ast.ParenExpr:
+		return untypedKind(info, e.X)
+	case *ast.UnaryExpr:
+		return untypedKind(info, e.X)
+	case *ast.BinaryExpr:
+		x := untypedKind(info, e.X)
+		if e.Op == token.SHL || e.Op == token.SHR {
+			return x
+		}
+		y := untypedKind(info, e.Y)
+		if x == types.Invalid || y == types.Invalid {
+			return types.Invalid
+		}
+		if x == types.UntypedRune || y == types.UntypedRune {
+			return types.UntypedRune
+		}
+		return types.UntypedInt
+	}
+	return types.Invalid
+}
+
+func buildLabel(s string) []protocol.InlayHintLabelPart {
+	label := protocol.InlayHintLabelPart{
+		Value: s,
+	}
+	if len(s) > maxLabelLength+len("...") {
+		label.Value = s[:maxLabelLength] + "..."
+		label.Tooltip = s
+	}
+	return []protocol.InlayHintLabelPart{label}
+}
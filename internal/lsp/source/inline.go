@@ -0,0 +1,1061 @@
+// Copyright 2022 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package source
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/token"
+	"go/types"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/iansmith/golang-x-tools/go/analysis"
+	"github.com/iansmith/golang-x-tools/go/ast/astutil"
+	"github.com/iansmith/golang-x-tools/go/types/typeutil"
+	"github.com/iansmith/golang-x-tools/internal/lsp/protocol"
+	"github.com/iansmith/golang-x-tools/internal/lsp/safetoken"
+	"github.com/iansmith/golang-x-tools/internal/span"
+	"github.com/iansmith/golang-x-tools/internal/typeparams"
+)
+
+// CanInlineCall reports whether rng is within a call that inlineCall
+// may be able to inline: a call to a non-generic function or method
+// declared with a body in pkg. The remaining conditions are checked
+// when the fix is computed.
+func CanInlineCall(pkg Package, file *ast.File, rng span.Range) bool {
+	_, _, _, err := findInlinableCall(pkg, file, rng)
+	return err == nil
+}
+
+// findInlinableCall returns the path from the innermost call enclosing
+// rng to the root of file, and the declaration of the called function
+// along with the file that contains it.
+func findInlinableCall(pkg Package, file *ast.File, rng span.Range) ([]ast.Node, *ast.FuncDecl, *ParsedGoFile, error) {
+	path, _ := astutil.PathEnclosingInterval(file, rng.Start, rng.End)
+	for len(path) > 0 {
+		if _, ok := path[0].(*ast.CallExpr); ok {
+			break
+		}
+		path = path[1:]
+	}
+	if len(path) == 0 {
+		return nil, nil, nil, errors.New("no call expression found")
+	}
+	fn, ok := typeutil.Callee(pkg.GetTypesInfo(), path[0].(*ast.CallExpr)).(*types.Func)
+	if !ok {
+		return nil, nil, nil, errors.New("not a call to a function or method")
+	}
+	if fn.Pkg() != pkg.GetTypes() {
+		return nil, nil, nil, fmt.Errorf("%s is declared in another package", fn.Name())
+	}
+	for _, pgf := range pkg.CompiledGoFiles() {
+		if !NodeContains(pgf.File, fn.Pos()) {
+			continue
+		}
+		for _, decl := range pgf.File.Decls {
+			decl, ok := decl.(*ast.FuncDecl)
+			if !ok || decl.Name.Pos() != fn.Pos() {
+				continue
+			}
+			if decl.Body == nil {
+				return nil, nil, nil, fmt.Errorf("%s has no body", fn.Name())
+			}
+			if isGenericDecl(decl) {
+				return nil, nil, nil, fmt.Errorf("%s is generic", fn.Name())
+			}
+			return path, decl, pgf, nil
+		}
+	}
+	return nil, nil, nil, fmt.Errorf("declaration of %s not found", fn.Name())
+}
+
+// isGenericDecl reports whether decl declares a generic function or a
+// method of a generic type.
+func isGenericDecl(decl *ast.FuncDecl) bool {
+	if tparams := typeparams.ForFuncType(decl.Type); tparams != nil && tparams.NumFields() > 0 {
+		return true
+	}
+	if decl.Recv == nil || len(decl.Recv.List) == 0 {
+		return false
+	}
+	recv := decl.Recv.List[0].Type
+	if star, ok := recv.(*ast.StarExpr); ok {
+		recv = star.X
+	}
+	x, _, _, _ := typeparams.UnpackIndexExpr(recv)
+	return x != nil
+}
+
+// inlineCall replaces the call enclosing pRng by the body of the called
+// function. Parameters are bound to their arguments by an assignment
+// preceding the body, unless an argument can be substituted for its
+// parameter without changing the meaning of the program, and the local
+// declarations of the callee are renamed if they would conflict with
+// names visible at the call site.
+//
+// A function whose body is a single return statement may be inlined
+// wherever the call appears. Otherwise, the call must be the whole of
+// an expression statement, the right-hand side of an assignment or
+// variable declaration, or the operand of a return statement. Unless
+// it is the operand of a return statement, the callee may return only
+// at the end of its body.
+func inlineCall(ctx context.Context, snapshot Snapshot, fh VersionedFileHandle, pRng protocol.Range) (*analysis.SuggestedFix, error) {
+	pkg, pgf, err := GetParsedFile(ctx, snapshot, fh, NarrowestPackage)
+	if err != nil {
+		return nil, fmt.Errorf("GetParsedFile: %w", err)
+	}
+	rng, err := pgf.Mapper.RangeToSpanRange(pRng)
+	if err != nil {
+		return nil, err
+	}
+	path, decl, calleeFile, err := findInlinableCall(pkg, pgf.File, rng)
+	if err != nil {
+		return nil, fmt.Errorf("cannot inline call: %v", err)
+	}
+	info := pkg.GetTypesInfo()
+	in := &inliner{
+		pkg:      pkg.GetTypes(),
+		info:     info,
+		caller:   pgf,
+		callee:   calleeFile,
+		path:     path,
+		call:     path[0].(*ast.CallExpr),
+		decl:     decl,
+		sig:      info.Defs[decl.Name].Type().(*types.Signature),
+		names:    make(map[string]bool),
+		imports:  make(map[string]string),
+		renames:  make(map[types.Object]string),
+		symbolic: make(map[*ast.Ident]string),
+		substs:   make(map[types.Object]*binding),
+	}
+	for _, scope := range CollectScopes(info, path, in.call.Pos()) {
+		if scope != nil {
+			in.scope = scope
+			break
+		}
+	}
+	edit, err := in.inline()
+	if err != nil {
+		return nil, fmt.Errorf("cannot inline call to %s: %v", decl.Name.Name, err)
+	}
+	edits, err := addImportEdits(ctx, snapshot, pgf, in.imports)
+	if err != nil {
+		return nil, err
+	}
+	edits = append(edits, *edit)
+	return &analysis.SuggestedFix{TextEdits: edits}, nil
+}
+
+// An inliner replaces a call by the body of the called function.
+type inliner struct {
+	pkg    *types.Package
+	info   *types.Info
+	caller *ParsedGoFile // file containing the call
+	callee *ParsedGoFile // file containing the called function
+	path   []ast.Node    // path from the call to the root of the caller's file
+	call   *ast.CallExpr // the call to inline
+	decl   *ast.FuncDecl // declaration of the called function
+	sig    *types.Signature
+	scope  *types.Scope // innermost scope enclosing the call
+
+	names    map[string]bool           // names of identifiers in the callee, and new names chosen for its objects
+	imports  map[string]string         // import path → name, for packages the caller's file must import
+	renames  map[types.Object]string   // new names of the objects declared by the callee
+	symbolic map[*ast.Ident]string     // new names of the symbolic variables of type switches
+	substs   map[types.Object]*binding // parameters replaced by their arguments
+	edits    []inlineEdit              // edits to the identifiers of the callee
+	err      error                     // first error reported by qualifier
+}
+
+// A binding is a parameter of the callee and the argument bound to it.
+type binding struct {
+	param   *types.Var
+	text    string // text of the argument, converted to the type of param if necessary
+	primary bool   // text is a primary expression, which needs no parentheses as an operand
+	pure    bool   // text has no effects, and no statement of the callee can change its value
+	operand string // if text takes the address of a variable, the text of the variable
+}
+
+// An inlineEdit replaces the text of the callee between start and end.
+type inlineEdit struct {
+	start, end token.Pos
+	text       string
+}
+
+// The contexts of a call inlined as a sequence of statements.
+const (
+	exprContext   = iota // f(x)
+	assignContext        // y := f(x), or y = f(x)
+	varContext           // var y = f(x)
+	returnContext        // return f(x)
+)
+
+func (in *inliner) inline() (*analysis.TextEdit, error) {
+	if err := in.checkBody(); err != nil {
+		return nil, err
+	}
+	bindings, err := in.bindings()
+	if err != nil {
+		return nil, err
+	}
+	ast.Inspect(in.decl, func(n ast.Node) bool {
+		if id, ok := n.(*ast.Ident); ok {
+			in.names[id.Name] = true
+		}
+		return true
+	})
+
+	// A function whose body is a single return statement is replaced
+	// by its result if every argument can be substituted for its
+	// parameter, unless the result could not stand as a statement.
+	parent, _ := in.parent()
+	if result := in.singleResult(); result != nil {
+		_, isStmt := parent.(*ast.ExprStmt)
+		all := !isStmt || isCallOrReceive(result)
+		for _, b := range bindings {
+			all = all && in.canSubstitute(b)
+		}
+		if all {
+			for _, b := range bindings {
+				in.substs[b.param] = b
+			}
+			return in.inlineExpr(parent, result)
+		}
+	}
+	return in.inlineStmts(bindings)
+}
+
+// checkBody reports an error if the body of the callee has a construct
+// whose meaning would change if it were inlined.
+func (in *inliner) checkBody() error {
+	if len(in.call.Args) == 1 {
+		if tuple, ok := in.info.TypeOf(in.call.Args[0]).(*types.Tuple); ok && tuple.Len() != 1 {
+			return errors.New("its argument is a multi-valued expression")
+		}
+	}
+	var err error
+	ast.Inspect(in.decl.Body, func(n ast.Node) bool {
+		switch n.(type) {
+		case *ast.FuncLit:
+			return false
+		case *ast.DeferStmt:
+			err = errors.New("it contains a defer statement")
+		case *ast.LabeledStmt:
+			err = errors.New("it contains a labeled statement")
+		}
+		return err == nil
+	})
+	if err != nil {
+		return err
+	}
+	for _, pkgName := range in.callee.File.Imports {
+		if pkgName.Name != nil && pkgName.Name.Name == "." {
+			return errors.New("its file has a dot import")
+		}
+	}
+	return nil
+}
+
+// bindings returns the bindings of the receiver and parameters of the
+// callee to the arguments of the call.
+func (in *inliner) bindings() ([]*binding, error) {
+	var bindings []*binding
+	if recv := in.sig.Recv(); recv != nil {
+		sel, ok := astutil.Unparen(in.call.Fun).(*ast.SelectorExpr)
+		if !ok {
+			return nil, errors.New("it is not called as a method")
+		}
+		selection := in.info.Selections[sel]
+		if selection == nil || selection.Kind() != types.MethodVal {
+			return nil, errors.New("it is called through a method expression")
+		}
+		if len(selection.Index()) > 1 {
+			return nil, errors.New("it is a promoted method")
+		}
+		b := &binding{
+			param:   recv,
+			text:    in.callerText(sel.X),
+			primary: isPrimary(sel.X),
+			pure:    in.isPure(sel.X),
+		}
+		_, ptrRecv := recv.Type().(*types.Pointer)
+		_, ptrArg := in.info.TypeOf(sel.X).(*types.Pointer)
+		switch {
+		case ptrRecv && !ptrArg:
+			b.operand = parenthesize(b.text, b.primary)
+			b.text = "&" + b.operand
+			b.primary = false
+		case !ptrRecv && ptrArg:
+			b.text = "*" + parenthesize(b.text, b.primary)
+			b.primary, b.pure = false, false
+		}
+		bindings = append(bindings, b)
+	}
+
+	params := in.sig.Params()
+	args := in.call.Args
+	for i := 0; i < params.Len(); i++ {
+		param := params.At(i)
+		if i == params.Len()-1 && in.sig.Variadic() && !in.call.Ellipsis.IsValid() {
+			// Collect the remaining arguments in a slice.
+			slice := param.Type().(*types.Slice)
+			var elems []string
+			for _, arg := range args[i:] {
+				elems = append(elems, in.convert(arg, in.callerText(arg), slice.Elem(), nil))
+			}
+			b := &binding{param: param, primary: true}
+			if len(elems) == 0 {
+				b.text = in.conversion(slice, "nil")
+				b.pure = true
+			} else {
+				b.text = in.typeString(slice) + "{" + strings.Join(elems, ", ") + "}"
+			}
+			bindings = append(bindings, b)
+			break
+		}
+		arg := args[i]
+		text := in.callerText(arg)
+		b := &binding{
+			param:   param,
+			text:    in.convert(arg, text, param.Type(), nil),
+			primary: isPrimary(arg),
+			pure:    in.isPure(arg),
+		}
+		if b.text != text {
+			b.primary = true
+		}
+		bindings = append(bindings, b)
+	}
+	return bindings, in.err
+}
+
+// canSubstitute reports whether the argument of b may replace each use
+// of its parameter in the body of the callee.
+func (in *inliner) canSubstitute(b *binding) bool {
+	return b.pure && !in.mutated(b.param)
+}
+
+// parent returns the parent of the call, ignoring parentheses, and its
+// index in the path.
+func (in *inliner) parent() (ast.Node, int) {
+	i := 1
+	for ; i < len(in.path)-1; i++ {
+		if _, ok := in.path[i].(*ast.ParenExpr); !ok {
+			break
+		}
+	}
+	return in.path[i], i
+}
+
+// singleResult returns the result of the callee if its body is a single
+// return statement of one unnamed result, or nil.
+func (in *inliner) singleResult() ast.Expr {
+	if len(in.decl.Body.List) != 1 || in.sig.Results().Len() != 1 || in.sig.Results().At(0).Name() != "" {
+		return nil
+	}
+	ret, ok := in.decl.Body.List[0].(*ast.ReturnStmt)
+	if !ok || len(ret.Results) != 1 {
+		return nil
+	}
+	return ret.Results[0]
+}
+
+// inlineExpr replaces the call by result, the single result of the callee.
+func (in *inliner) inlineExpr(parent ast.Node, result ast.Expr) (*analysis.TextEdit, error) {
+	if err := in.prepareEdits(); err != nil {
+		return nil, err
+	}
+	text, err := in.calleeText(result.Pos(), result.End(), nil)
+	if err != nil {
+		return nil, err
+	}
+	converted := in.convert(result, text, in.sig.Results().At(0).Type(), in.target(0))
+	if converted == text && !isPrimary(result) && needsParens(parent, in.path[0]) {
+		converted = "(" + text + ")"
+	}
+	if in.err != nil {
+		return nil, in.err
+	}
+	return &analysis.TextEdit{Pos: in.call.Pos(), End: in.call.End(), NewText: []byte(converted)}, nil
+}
+
+// inlineStmts replaces the statement containing the call by the
+// bindings of the parameters that cannot be substituted, followed by
+// the body of the callee.
+func (in *inliner) inlineStmts(bindings []*binding) (*analysis.TextEdit, error) {
+	parent, i := in.parent()
+	var stmt ast.Stmt
+	var context int
+	switch parent := parent.(type) {
+	case *ast.ExprStmt:
+		stmt, context = parent, exprContext
+	case *ast.AssignStmt:
+		if len(parent.Rhs) == 1 && (parent.Tok == token.DEFINE || parent.Tok == token.ASSIGN) {
+			stmt, context = parent, assignContext
+		}
+	case *ast.ReturnStmt:
+		if len(parent.Results) == 1 {
+			stmt, context = parent, returnContext
+		}
+	case *ast.ValueSpec:
+		if len(parent.Values) == 1 && i+2 < len(in.path) {
+			if decl, ok := in.path[i+1].(*ast.GenDecl); ok && len(decl.Specs) == 1 && !decl.Lparen.IsValid() {
+				if declStmt, ok := in.path[i+2].(*ast.DeclStmt); ok {
+					stmt, context = declStmt, varContext
+					i += 2
+				}
+			}
+		}
+	}
+	if stmt == nil {
+		return nil, errors.New("its body is not a single return statement, and its result is used in an expression")
+	}
+	switch in.path[i+1].(type) {
+	case *ast.BlockStmt, *ast.CaseClause, *ast.CommClause:
+	default:
+		return nil, errors.New("the call is not in a list of statements")
+	}
+
+	body := in.decl.Body
+	var returns []*ast.ReturnStmt
+	ast.Inspect(body, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.FuncLit:
+			return false
+		case *ast.ReturnStmt:
+			returns = append(returns, n)
+		}
+		return true
+	})
+	var last ast.Stmt
+	if len(body.List) > 0 {
+		last = body.List[len(body.List)-1]
+	}
+	if context != returnContext {
+		for _, ret := range returns {
+			if ret != last {
+				return nil, errors.New("it returns before the end of its body")
+			}
+		}
+		if _, ok := last.(*ast.ReturnStmt); !ok && in.sig.Results().Len() > 0 && context != exprContext {
+			return nil, errors.New("its body does not end with a return statement")
+		}
+	}
+
+	for _, b := range bindings {
+		if in.canSubstitute(b) {
+			in.substs[b.param] = b
+		}
+	}
+	if err := in.prepareEdits(); err != nil {
+		return nil, err
+	}
+
+	// Bind the remaining parameters, and declare the named results.
+	var lines []string
+	var lhs, rhs []string
+	define := false
+	for _, b := range bindings {
+		if _, ok := in.substs[b.param]; ok {
+			continue
+		}
+		name := in.name(b.param)
+		if name == "" || name == "_" || !in.used(b.param) {
+			name = "_"
+		} else {
+			define = true
+		}
+		lhs = append(lhs, name)
+		rhs = append(rhs, b.text)
+	}
+	if len(lhs) > 0 {
+		tok := "="
+		if define {
+			tok = ":="
+		}
+		lines = append(lines, strings.Join(lhs, ", ")+" "+tok+" "+strings.Join(rhs, ", "))
+	}
+	results := in.sig.Results()
+	var resultNames []string
+	for i := 0; i < results.Len(); i++ {
+		v := results.At(i)
+		if v.Name() == "" {
+			break
+		}
+		if v.Name() == "_" {
+			resultNames = append(resultNames, in.typeString(v.Type())+"{}")
+			continue
+		}
+		name := in.name(v)
+		resultNames = append(resultNames, name)
+		lines = append(lines, "var "+name+" "+in.typeString(v.Type()))
+	}
+
+	// Replace the return statements.
+	var returnEdits []inlineEdit
+	for _, ret := range returns {
+		var values []string
+		if len(ret.Results) == 0 {
+			values = resultNames
+		}
+		for i, result := range ret.Results {
+			text, err := in.calleeText(result.Pos(), result.End(), nil)
+			if err != nil {
+				return nil, err
+			}
+			if len(ret.Results) == results.Len() {
+				text = in.convert(result, text, results.At(i).Type(), in.target(i))
+			}
+			values = append(values, text)
+		}
+		var text string
+		switch context {
+		case exprContext:
+			pure := true
+			for _, result := range ret.Results {
+				pure = pure && in.isPure(result)
+			}
+			if !pure {
+				text = strings.Repeat("_, ", results.Len()-1) + "_ = " + strings.Join(values, ", ")
+			}
+		case assignContext:
+			assign := stmt.(*ast.AssignStmt)
+			text = in.callerTextRange(assign.Pos(), assign.Rhs[0].Pos()) + strings.Join(values, ", ")
+		case varContext:
+			spec := parent.(*ast.ValueSpec)
+			text = in.callerTextRange(stmt.Pos(), spec.Values[0].Pos()) + strings.Join(values, ", ")
+		case returnContext:
+			text = "return"
+			if len(values) > 0 {
+				text += " " + strings.Join(values, ", ")
+			}
+		}
+		returnEdits = append(returnEdits, inlineEdit{ret.Pos(), ret.End(), text})
+	}
+	if in.err != nil {
+		return nil, in.err
+	}
+
+	text, err := in.calleeText(body.Lbrace+1, body.Rbrace, returnEdits)
+	if err != nil {
+		return nil, err
+	}
+	indent, err := calculateIndentation(in.caller.Src, in.caller.Tok, stmt)
+	if err != nil {
+		return nil, err
+	}
+	calleeIndent := ""
+	if len(body.List) > 0 {
+		calleeIndent, err = calculateIndentation(in.callee.Src, in.callee.Tok, body.List[0])
+		if err != nil {
+			return nil, err
+		}
+	}
+	for _, line := range strings.Split(strings.TrimSpace(text), "\n") {
+		line = strings.TrimRight(strings.TrimPrefix(line, calleeIndent), " \t")
+		if line != "" || len(lines) > 0 && lines[len(lines)-1] != "" {
+			lines = append(lines, line)
+		}
+	}
+	for len(lines) > 0 && lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	for i := 1; i < len(lines); i++ {
+		if lines[i] != "" {
+			lines[i] = indent + lines[i]
+		}
+	}
+
+	start, end := stmt.Pos(), stmt.End()
+	if len(lines) == 0 {
+		// Delete the line of the statement.
+		tok := in.caller.Tok
+		line := tok.Line(stmt.Pos())
+		if lineStart := tok.LineStart(line); int(start-lineStart) == len(indent) && line < tok.LineCount() {
+			start, end = lineStart, tok.LineStart(line+1)
+		}
+	}
+	return &analysis.TextEdit{Pos: start, End: end, NewText: []byte(strings.Join(lines, "\n"))}, nil
+}
+
+// name returns the name of the object declared by the callee at the
+// call site.
+func (in *inliner) name(obj types.Object) string {
+	if name, ok := in.renames[obj]; ok {
+		return name
+	}
+	return obj.Name()
+}
+
+// used reports whether obj is used by the callee.
+func (in *inliner) used(obj types.Object) bool {
+	used := false
+	ast.Inspect(in.decl.Body, func(n ast.Node) bool {
+		if id, ok := n.(*ast.Ident); ok && in.info.Uses[id] == obj {
+			used = true
+		}
+		return !used
+	})
+	return used
+}
+
+// mutated reports whether param may be changed by the callee, or used
+// by a function literal, which may run after the caller changes the
+// argument. A parameter whose fields or elements are assigned or whose
+// address is taken counts as changed.
+func (in *inliner) mutated(param *types.Var) bool {
+	mutated := false
+	ast.Inspect(in.decl.Body, func(n ast.Node) bool {
+		id, ok := n.(*ast.Ident)
+		if !ok || in.info.Uses[id] != param {
+			return !mutated
+		}
+		path, _ := astutil.PathEnclosingInterval(in.callee.File, id.Pos(), id.End())
+		rooted := true // child denotes the parameter or a part of it
+		var child ast.Node = id
+		for _, n := range path[1:] {
+			if n == in.decl.Body {
+				break
+			}
+			switch n := n.(type) {
+			case *ast.FuncLit:
+				mutated = true
+			case *ast.ParenExpr:
+			case *ast.IndexExpr:
+				rooted = rooted && n.X == child
+			case *ast.SliceExpr:
+				rooted = rooted && n.X == child
+			case *ast.SelectorExpr:
+				switch sel := in.info.Selections[n]; {
+				case sel == nil || sel.Indirect():
+					rooted = false
+				case sel.Kind() == types.MethodVal:
+					_, ptrRecv := sel.Obj().Type().(*types.Signature).Recv().Type().(*types.Pointer)
+					_, ptrX := in.info.TypeOf(n.X).(*types.Pointer)
+					mutated = mutated || rooted && ptrRecv && !ptrX
+					rooted = false
+				}
+			case *ast.AssignStmt:
+				for _, lhs := range n.Lhs {
+					mutated = mutated || rooted && lhs == child
+				}
+				rooted = false
+			case *ast.IncDecStmt:
+				mutated = mutated || rooted
+				rooted = false
+			case *ast.UnaryExpr:
+				mutated = mutated || rooted && n.Op == token.AND
+				rooted = false
+			case *ast.RangeStmt:
+				mutated = mutated || rooted && (n.Key == child || n.Value == child)
+				rooted = false
+			default:
+				rooted = false
+			}
+			child = n
+		}
+		return !mutated
+	})
+	return mutated
+}
+
+// isPure reports whether e, an expression of the caller, has no effects
+// and denotes a constant, or a local variable of the caller or a field
+// of one, so that it may be evaluated any number of times with the same
+// result.
+func (in *inliner) isPure(e ast.Expr) bool {
+	if tv, ok := in.info.Types[e]; ok && (tv.Value != nil || tv.IsNil()) {
+		return true
+	}
+	switch e := e.(type) {
+	case *ast.ParenExpr:
+		return in.isPure(e.X)
+	case *ast.Ident:
+		v, ok := in.info.Uses[e].(*types.Var)
+		return ok && v.Pkg() != nil && v.Parent() != v.Pkg().Scope()
+	case *ast.SelectorExpr:
+		sel := in.info.Selections[e]
+		return sel != nil && sel.Kind() == types.FieldVal && !sel.Indirect() && in.isPure(e.X)
+	case *ast.UnaryExpr:
+		return e.Op == token.AND && in.isPure(e.X)
+	}
+	return false
+}
+
+// isPrimary reports whether e is a primary expression, which may be
+// an operand without parentheses.
+func isPrimary(e ast.Expr) bool {
+	switch e.(type) {
+	case *ast.Ident, *ast.BasicLit, *ast.CompositeLit, *ast.FuncLit, *ast.ParenExpr,
+		*ast.SelectorExpr, *ast.IndexExpr, *ast.SliceExpr, *ast.TypeAssertExpr, *ast.CallExpr:
+		return true
+	}
+	return false
+}
+
+func isCallOrReceive(e ast.Expr) bool {
+	switch e := astutil.Unparen(e).(type) {
+	case *ast.CallExpr:
+		return true
+	case *ast.UnaryExpr:
+		return e.Op == token.ARROW
+	}
+	return false
+}
+
+func parenthesize(text string, primary bool) string {
+	if primary {
+		return text
+	}
+	return "(" + text + ")"
+}
+
+// needsParens reports whether an expression that is not a primary
+// expression would need parentheses to replace the child x of parent.
+func needsParens(parent, x ast.Node) bool {
+	switch parent := parent.(type) {
+	case *ast.ParenExpr, *ast.ExprStmt, *ast.AssignStmt, *ast.ValueSpec, *ast.ReturnStmt,
+		*ast.CompositeLit, *ast.KeyValueExpr, *ast.SendStmt, *ast.IfStmt, *ast.SwitchStmt,
+		*ast.ForStmt, *ast.RangeStmt, *ast.CaseClause:
+		return false
+	case *ast.CallExpr:
+		return parent.Fun == x
+	case *ast.IndexExpr:
+		return parent.X == x
+	case *ast.SliceExpr:
+		return parent.X == x
+	}
+	return true
+}
+
+// convert returns text, the text of e, converted to type t unless the
+// value of e already has type t, or the context of the call requires the
+// type target, which is identical to t.
+func (in *inliner) convert(e ast.Expr, text string, t, target types.Type) string {
+	if target != nil && types.Identical(target, t) {
+		return text
+	}
+	// The type recorded for an untyped constant is the type to which
+	// it is implicitly converted, so use the default type instead.
+	if kind := untypedConstKind(in.info, e); kind != types.Invalid {
+		if types.Identical(types.Default(types.Typ[kind]), t) {
+			return text
+		}
+	} else if tv := in.info.Types[e]; tv.Type != nil && !tv.IsNil() && types.Identical(tv.Type, t) {
+		return text
+	}
+	return in.conversion(t, text)
+}
+
+// untypedConstKind returns the kind of e if it is an untyped constant
+// expression, or types.Invalid.
+func untypedConstKind(info *types.Info, e ast.Expr) types.BasicKind {
+	if tv, ok := info.Types[e]; !ok || tv.Value == nil {
+		return types.Invalid
+	}
+	switch e := e.(type) {
+	case *ast.BasicLit:
+		switch e.Kind {
+		case token.INT:
+			return types.UntypedInt
+		case token.FLOAT:
+			return types.UntypedFloat
+		case token.IMAG:
+			return types.UntypedComplex
+		case token.CHAR:
+			return types.UntypedRune
+		case token.STRING:
+			return types.UntypedString
+		}
+	case *ast.Ident, *ast.SelectorExpr:
+		if c, ok := info.Uses[identOf(e)].(*types.Const); ok {
+			if b, ok := c.Type().(*types.Basic); ok && b.Info()&types.IsUntyped != 0 {
+				return b.Kind()
+			}
+		}
+	case *ast.ParenExpr:
+		return untypedConstKind(info, e.X)
+	case *ast.UnaryExpr:
+		return untypedConstKind(info, e.X)
+	case *ast.BinaryExpr:
+		switch e.Op {
+		case token.EQL, token.NEQ, token.LSS, token.LEQ, token.GTR, token.GEQ:
+			return types.UntypedBool
+		case token.SHL, token.SHR:
+			return untypedConstKind(info, e.X)
+		}
+		x, y := untypedConstKind(info, e.X), untypedConstKind(info, e.Y)
+		if x == types.Invalid || y == types.Invalid {
+			return types.Invalid
+		}
+		if y > x {
+			return y // the kinds of untyped numeric constants are ordered
+		}
+		return x
+	}
+	return types.Invalid
+}
+
+func identOf(e ast.Expr) *ast.Ident {
+	if sel, ok := e.(*ast.SelectorExpr); ok {
+		return sel.Sel
+	}
+	return e.(*ast.Ident)
+}
+
+// conversion returns the conversion of text to type t.
+func (in *inliner) conversion(t types.Type, text string) string {
+	typ := in.typeString(t)
+	if strings.HasPrefix(typ, "*") || strings.HasPrefix(typ, "<-") || strings.HasPrefix(typ, "func") {
+		typ = "(" + typ + ")"
+	}
+	return typ + "(" + text + ")"
+}
+
+func (in *inliner) typeString(t types.Type) string {
+	return types.TypeString(t, in.qualifier)
+}
+
+// qualifier qualifies the names of packages by the names given to them
+// by the caller's file, adding an import for each package that it does
+// not import.
+func (in *inliner) qualifier(p *types.Package) string {
+	if p == in.pkg {
+		return ""
+	}
+	name, err := in.importName(p)
+	if err != nil && in.err == nil {
+		in.err = err
+	}
+	return name
+}
+
+// importName returns the name by which the caller's file refers to the
+// package p, recording an import of p if the file does not import it.
+func (in *inliner) importName(p *types.Package) (string, error) {
+	if name, ok := in.imports[p.Path()]; ok {
+		return name, nil
+	}
+	for _, spec := range in.caller.File.Imports {
+		if path, _ := strconv.Unquote(spec.Path.Value); path != p.Path() {
+			continue
+		}
+		obj := in.info.Implicits[spec]
+		if spec.Name != nil {
+			obj = in.info.Defs[spec.Name]
+		}
+		pkgName, ok := obj.(*types.PkgName)
+		if !ok || pkgName.Name() == "_" || pkgName.Name() == "." {
+			continue
+		}
+		if _, found := in.scope.LookupParent(pkgName.Name(), in.call.Pos()); found != pkgName {
+			return "", fmt.Errorf("the name %s of package %s is shadowed at the call site", pkgName.Name(), p.Path())
+		}
+		return pkgName.Name(), nil
+	}
+	name := p.Name()
+	if _, found := in.scope.LookupParent(name, token.NoPos); found != nil {
+		return "", fmt.Errorf("package %s cannot be imported as %s, which is already declared", p.Path(), name)
+	}
+	in.imports[p.Path()] = name
+	return name, nil
+}
+
+// target returns the type required by the context of the call for its
+// i'th result, or nil if the context does not require a type.
+func (in *inliner) target(i int) types.Type {
+	parent, j := in.parent()
+	switch parent := parent.(type) {
+	case *ast.AssignStmt:
+		if parent.Tok == token.ASSIGN && len(parent.Rhs) == 1 && i < len(parent.Lhs) {
+			return in.info.TypeOf(parent.Lhs[i])
+		}
+	case *ast.ValueSpec:
+		if parent.Type != nil {
+			return in.info.TypeOf(parent.Type)
+		}
+	case *ast.ReturnStmt:
+		for _, n := range in.path[j+1:] {
+			var sig *types.Signature
+			switch n := n.(type) {
+			case *ast.FuncDecl:
+				sig, _ = in.info.Defs[n.Name].Type().(*types.Signature)
+			case *ast.FuncLit:
+				sig, _ = in.info.TypeOf(n).(*types.Signature)
+			default:
+				continue
+			}
+			if sig != nil && i < sig.Results().Len() {
+				return sig.Results().At(i).Type()
+			}
+			break
+		}
+	}
+	return nil
+}
+
+// prepareEdits chooses new names for the objects declared by the callee
+// that would conflict with names visible at the call site, checks that
+// the references of the callee to package-level objects denote the same
+// objects at the call site, and computes the edits to the identifiers
+// of the callee.
+func (in *inliner) prepareEdits() error {
+	ast.Inspect(in.decl, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.Ident:
+			obj := in.info.Defs[n]
+			if obj == nil || n == in.decl.Name || obj.Parent() == nil || in.substs[obj] != nil {
+				break
+			}
+			if _, ok := in.renames[obj]; !ok && obj.Name() != "_" && in.visible(obj.Name()) {
+				in.renames[obj] = in.newName(obj.Name())
+			}
+		case *ast.TypeSwitchStmt:
+			assign, ok := n.Assign.(*ast.AssignStmt)
+			if !ok || len(assign.Lhs) != 1 {
+				break
+			}
+			id, ok := assign.Lhs[0].(*ast.Ident)
+			if !ok || id.Name == "_" || !in.visible(id.Name) {
+				break
+			}
+			name := in.newName(id.Name)
+			in.symbolic[id] = name
+			for _, clause := range n.Body.List {
+				if obj := in.info.Implicits[clause]; obj != nil {
+					in.renames[obj] = name
+				}
+			}
+		}
+		return true
+	})
+
+	var err error
+	astutil.Apply(in.decl.Body, func(c *astutil.Cursor) bool {
+		id, ok := c.Node().(*ast.Ident)
+		if !ok || err != nil {
+			return err == nil
+		}
+		if sel, ok := c.Parent().(*ast.SelectorExpr); ok && sel.Sel == id {
+			return true // a field, method, or qualified identifier
+		}
+		if name, ok := in.symbolic[id]; ok {
+			in.edits = append(in.edits, inlineEdit{id.Pos(), id.End(), name})
+			return true
+		}
+		obj := in.info.Uses[id]
+		if obj == nil {
+			obj = in.info.Defs[id]
+		}
+		if obj == nil {
+			return true
+		}
+		if b := in.substs[obj]; b != nil {
+			text := b.text
+			if sel, ok := c.Parent().(*ast.SelectorExpr); ok && sel.X == id && b.operand != "" {
+				text = b.operand // (&x).f is x.f
+			} else if !b.primary && needsParens(c.Parent(), id) {
+				text = "(" + text + ")"
+			}
+			in.edits = append(in.edits, inlineEdit{id.Pos(), id.End(), text})
+			return true
+		}
+		if name, ok := in.renames[obj]; ok {
+			in.edits = append(in.edits, inlineEdit{id.Pos(), id.End(), name})
+			return true
+		}
+		if pkgName, ok := obj.(*types.PkgName); ok {
+			var name string
+			name, err = in.importName(pkgName.Imported())
+			if name != id.Name {
+				in.edits = append(in.edits, inlineEdit{id.Pos(), id.End(), name})
+			}
+			return true
+		}
+		if obj.Pkg() != nil && obj.Parent() != obj.Pkg().Scope() || obj.Parent() == nil {
+			return true // a local object, field, or method
+		}
+		if _, found := in.scope.LookupParent(id.Name, in.call.Pos()); found != obj {
+			err = fmt.Errorf("%s refers to a different object at the call site", id.Name)
+		}
+		return true
+	}, nil)
+	return err
+}
+
+// visible reports whether name is declared in a scope enclosing the call.
+func (in *inliner) visible(name string) bool {
+	_, obj := in.scope.LookupParent(name, token.NoPos)
+	return obj != nil
+}
+
+// newName returns a name derived from name that is not visible at the
+// call site and not used by the callee.
+func (in *inliner) newName(name string) string {
+	name, _ = generateIdentifier(1, name, func(name string) bool {
+		return in.visible(name) || in.names[name]
+	})
+	in.names[name] = true
+	return name
+}
+
+// callerText returns the text of n in the caller's file.
+func (in *inliner) callerText(n ast.Node) string {
+	return in.callerTextRange(n.Pos(), n.End())
+}
+
+func (in *inliner) callerTextRange(start, end token.Pos) string {
+	startOffset, err := safetoken.Offset(in.caller.Tok, start)
+	if err != nil {
+		return ""
+	}
+	endOffset, err := safetoken.Offset(in.caller.Tok, end)
+	if err != nil {
+		return ""
+	}
+	return string(in.caller.Src[startOffset:endOffset])
+}
+
+// calleeText returns the text of the callee between start and end,
+// after applying the edits to its identifiers and extra, whose ranges
+// must not overlap each other.
+func (in *inliner) calleeText(start, end token.Pos, extra []inlineEdit) (string, error) {
+	edits := append([]inlineEdit(nil), extra...)
+	for _, e := range in.edits {
+		if e.start < start || e.end > end {
+			continue
+		}
+		covered := false
+		for _, x := range extra {
+			covered = covered || x.start <= e.start && e.end <= x.end
+		}
+		if !covered {
+			edits = append(edits, e)
+		}
+	}
+	sort.Slice(edits, func(i, j int) bool { return edits[i].start < edits[j].start })
+
+	var buf strings.Builder
+	tok := in.callee.Tok
+	pos := start
+	for _, e := range append(edits, inlineEdit{start: end, end: end}) {
+		from, err := safetoken.Offset(tok, pos)
+		if err != nil {
+			return "", err
+		}
+		to, err := safetoken.Offset(tok, e.start)
+		if err != nil {
+			return "", err
+		}
+		buf.Write(in.callee.Src[from:to])
+		buf.WriteString(e.text)
+		pos = e.end
+	}
+	return buf.String(), nil
+}
@@ -0,0 +1,137 @@
+// Copyright 2022 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package source
+
+import (
+	"context"
+	"fmt"
+	"go/ast"
+	"go/types"
+
+	"github.com/iansmith/golang-x-tools/go/ast/astutil"
+	"github.com/iansmith/golang-x-tools/internal/event"
+	"github.com/iansmith/golang-x-tools/internal/lsp/protocol"
+)
+
+// InlineValues returns the values that a debugger stopped at stopped in
+// fh can show in the lines of viewport, for the code of the function
+// enclosing stopped up to it: the local variables in scope at stopped,
+// looked up by name, and, as expressions to evaluate, the fields that
+// the code selects from them and the package-level variables it uses.
+// Each value is reported once per line.
+func InlineValues(ctx context.Context, snapshot Snapshot, fh FileHandle, viewport, stopped protocol.Range) ([]protocol.InlineValue, error) {
+	ctx, done := event.Start(ctx, "source.InlineValues")
+	defer done()
+
+	pkg, pgf, err := GetParsedFile(ctx, snapshot, fh, NarrowestPackage)
+	if err != nil {
+		return nil, fmt.Errorf("getting file for InlineValues: %w", err)
+	}
+	stop, err := pgf.Mapper.RangeToSpanRange(stopped)
+	if err != nil {
+		return nil, err
+	}
+	view, err := pgf.Mapper.RangeToSpanRange(viewport)
+	if err != nil {
+		return nil, err
+	}
+
+	// Only the innermost function enclosing the stop location runs.
+	path, _ := astutil.PathEnclosingInterval(pgf.File, stop.Start, stop.End)
+	var fn ast.Node
+	for _, n := range path {
+		switch n.(type) {
+		case *ast.FuncDecl, *ast.FuncLit:
+			fn = n
+		}
+		if fn != nil {
+			break
+		}
+	}
+	if fn == nil {
+		return nil, nil
+	}
+	start, end := fn.Pos(), stop.End
+	if view.Start > start {
+		start = view.Start
+	}
+	if view.End < end {
+		end = view.End
+	}
+
+	info := pkg.GetTypesInfo()
+	// local reports whether id denotes a local variable of the code that
+	// is visible and initialized at the stop location.
+	local := func(id *ast.Ident) bool {
+		v, ok := info.ObjectOf(id).(*types.Var)
+		if !ok || v.IsField() || v.Name() == "_" || v.Pkg() == nil || v.Parent() == v.Pkg().Scope() {
+			return false
+		}
+		return v.Parent() != nil && v.Parent().Contains(stop.Start) && v.Pos() < stop.Start
+	}
+	type lineExpr struct {
+		line uint32
+		expr string
+	}
+	seen := make(map[lineExpr]bool)
+	var values []protocol.InlineValue
+	add := func(n ast.Node, expr string, lookup bool) error {
+		if n.Pos() < start || n.End() > end {
+			return nil
+		}
+		rng, err := NewMappedRange(snapshot.FileSet(), pgf.Mapper, n.Pos(), n.End()).Range()
+		if err != nil {
+			return err
+		}
+		key := lineExpr{rng.Start.Line, expr}
+		if seen[key] {
+			return nil
+		}
+		seen[key] = true
+		if lookup {
+			values = append(values, protocol.InlineValueVariableLookup{
+				Range:               &rng,
+				VariableName:        expr,
+				CaseSensitiveLookup: true,
+			})
+		} else {
+			values = append(values, protocol.InlineValueEvaluatableExpression{
+				Range:      &rng,
+				Expression: expr,
+			})
+		}
+		return nil
+	}
+	ast.Inspect(fn, func(n ast.Node) bool {
+		if n == nil || err != nil || n.End() < start || n.Pos() > end {
+			return false
+		}
+		switch n := n.(type) {
+		case *ast.SelectorExpr:
+			if sel, ok := info.Selections[n]; ok {
+				if id, ok := n.X.(*ast.Ident); ok && sel.Kind() == types.FieldVal && local(id) {
+					err = add(n, id.Name+"."+n.Sel.Name, false)
+				}
+				return true
+			}
+			// A qualified identifier.
+			if v, ok := info.Uses[n.Sel].(*types.Var); ok && v.Pkg() != nil && v.Parent() == v.Pkg().Scope() {
+				err = add(n, v.Pkg().Name()+"."+v.Name(), false)
+			}
+			return false
+		case *ast.Ident:
+			if local(n) {
+				err = add(n, n.Name, true)
+			} else if v, ok := info.ObjectOf(n).(*types.Var); ok && v.Pkg() != nil && v.Parent() == v.Pkg().Scope() {
+				err = add(n, v.Pkg().Name()+"."+v.Name(), false)
+			}
+		}
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+	return values, nil
+}
@@ -0,0 +1,320 @@
+// Copyright 2022 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package source
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"go/format"
+	"go/token"
+	"io"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/iansmith/golang-x-tools/internal/lsp/protocol"
+)
+
+// jsonInitialisms are the words of JSON keys that are spelled in upper case
+// in Go identifiers.
+var jsonInitialisms = map[string]bool{
+	"api": true, "ascii": true, "cpu": true, "css": true, "dns": true,
+	"html": true, "http": true, "https": true, "id": true, "ip": true,
+	"json": true, "sql": true, "tcp": true, "tls": true, "ttl": true,
+	"udp": true, "ui": true, "uid": true, "uri": true, "url": true,
+	"utf8": true, "uuid": true, "xml": true,
+}
+
+// A jsonType is the Go type inferred for the JSON values of a key.
+type jsonType struct {
+	kind     jsonKind
+	elem     *jsonType    // the type of the elements of an array
+	fields   []*jsonField // the fields of an object, in order
+	optional bool         // whether the value may be null or missing
+}
+
+type jsonKind int
+
+const (
+	jsonUnknown jsonKind = iota // only null values
+	jsonBool
+	jsonInt
+	jsonFloat
+	jsonString
+	jsonArray
+	jsonObject
+	jsonAny // values of different kinds
+)
+
+// A jsonField is a key of the JSON objects of a jsonType.
+type jsonField struct {
+	key string
+	typ *jsonType
+}
+
+// StructFromJSON returns the declaration of a struct type named name, with
+// JSON tags, whose fields are those of the JSON document data: an object,
+// or an array of objects.
+func StructFromJSON(name string, data []byte) ([]byte, error) {
+	if !token.IsIdentifier(name) {
+		return nil, fmt.Errorf("invalid type name %q", name)
+	}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	typ, err := decodeJSONType(dec)
+	if err != nil {
+		return nil, fmt.Errorf("invalid JSON: %v", err)
+	}
+	if _, err := dec.Token(); err != io.EOF {
+		return nil, fmt.Errorf("invalid JSON: data after the document")
+	}
+	if typ.kind == jsonArray && typ.elem != nil {
+		typ = typ.elem
+	}
+	if typ.kind != jsonObject {
+		return nil, fmt.Errorf("the JSON document is not an object or an array of objects")
+	}
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "type %s ", name)
+	writeJSONType(&buf, typ)
+	return format.Source(buf.Bytes())
+}
+
+// decodeJSONType returns the type of the next JSON value of dec.
+func decodeJSONType(dec *json.Decoder) (*jsonType, error) {
+	tok, err := dec.Token()
+	if err != nil {
+		return nil, err
+	}
+	switch tok := tok.(type) {
+	case nil:
+		return &jsonType{kind: jsonUnknown, optional: true}, nil
+	case bool:
+		return &jsonType{kind: jsonBool}, nil
+	case string:
+		return &jsonType{kind: jsonString}, nil
+	case json.Number:
+		if _, err := strconv.ParseInt(string(tok), 10, 64); err == nil {
+			return &jsonType{kind: jsonInt}, nil
+		}
+		return &jsonType{kind: jsonFloat}, nil
+	case json.Delim:
+		switch tok {
+		case '[':
+			var elem *jsonType
+			for dec.More() {
+				t, err := decodeJSONType(dec)
+				if err != nil {
+					return nil, err
+				}
+				elem = mergeJSONTypes(elem, t)
+			}
+			if _, err := dec.Token(); err != nil {
+				return nil, err
+			}
+			return &jsonType{kind: jsonArray, elem: elem}, nil
+		case '{':
+			typ := &jsonType{kind: jsonObject}
+			for dec.More() {
+				keyTok, err := dec.Token()
+				if err != nil {
+					return nil, err
+				}
+				key, _ := keyTok.(string)
+				t, err := decodeJSONType(dec)
+				if err != nil {
+					return nil, err
+				}
+				if f := typ.field(key); f != nil {
+					f.typ = mergeJSONTypes(f.typ, t)
+				} else {
+					typ.fields = append(typ.fields, &jsonField{key, t})
+				}
+			}
+			if _, err := dec.Token(); err != nil {
+				return nil, err
+			}
+			return typ, nil
+		}
+	}
+	return nil, fmt.Errorf("unexpected token %v", tok)
+}
+
+func (t *jsonType) field(key string) *jsonField {
+	for _, f := range t.fields {
+		if f.key == key {
+			return f
+		}
+	}
+	return nil
+}
+
+// mergeJSONTypes returns the type of the values of both types x and y, the
+// first of which may be nil.
+func mergeJSONTypes(x, y *jsonType) *jsonType {
+	if x == nil {
+		return y
+	}
+	optional := x.optional || y.optional
+	switch {
+	case x.kind == jsonUnknown:
+		x, y = y, x
+		fallthrough
+	case y.kind == jsonUnknown:
+		t := *x
+		t.optional = optional
+		return &t
+	case x.kind == y.kind && x.kind == jsonArray:
+		return &jsonType{kind: jsonArray, elem: mergeJSONTypes(x.elem, y.elem), optional: optional}
+	case x.kind == y.kind && x.kind == jsonObject:
+		t := &jsonType{kind: jsonObject, optional: optional}
+		for _, f := range x.fields {
+			t.fields = append(t.fields, &jsonField{f.key, f.typ})
+		}
+		for _, f := range y.fields {
+			if tf := t.field(f.key); tf != nil {
+				tf.typ = mergeJSONTypes(tf.typ, f.typ)
+			} else {
+				t.fields = append(t.fields, &jsonField{f.key, f.typ})
+			}
+		}
+		// Keys missing from some of the objects are optional.
+		for _, tf := range t.fields {
+			if x.field(tf.key) == nil || y.field(tf.key) == nil {
+				ft := *tf.typ
+				ft.optional = true
+				tf.typ = &ft
+			}
+		}
+		return t
+	case x.kind == y.kind:
+		return &jsonType{kind: x.kind, optional: optional}
+	case (x.kind == jsonInt || x.kind == jsonFloat) && (y.kind == jsonInt || y.kind == jsonFloat):
+		return &jsonType{kind: jsonFloat, optional: optional}
+	default:
+		return &jsonType{kind: jsonAny, optional: optional}
+	}
+}
+
+// writeJSONType writes the Go type expression of t to buf.
+func writeJSONType(buf *bytes.Buffer, t *jsonType) {
+	switch t.kind {
+	case jsonBool:
+		buf.WriteString("bool")
+	case jsonInt:
+		buf.WriteString("int64")
+	case jsonFloat:
+		buf.WriteString("float64")
+	case jsonString:
+		buf.WriteString("string")
+	case jsonArray:
+		buf.WriteString("[]")
+		if t.elem == nil {
+			buf.WriteString("interface{}")
+		} else {
+			writeJSONType(buf, t.elem)
+		}
+	case jsonObject:
+		buf.WriteString("struct {\n")
+		seen := make(map[string]bool)
+		for _, f := range t.fields {
+			if strings.Contains(f.key, "`") {
+				continue // not representable in a struct tag
+			}
+			name := jsonFieldName(f.key)
+			for i := 2; seen[name]; i++ {
+				name = jsonFieldName(f.key) + strconv.Itoa(i)
+			}
+			seen[name] = true
+			tag := f.key
+			if f.typ.optional {
+				tag += ",omitempty"
+			}
+			fmt.Fprintf(buf, "%s ", name)
+			writeJSONType(buf, f.typ)
+			fmt.Fprintf(buf, " `json:%s`\n", strconv.Quote(tag))
+		}
+		buf.WriteString("}")
+	default:
+		buf.WriteString("interface{}")
+	}
+}
+
+// jsonFieldName returns the exported Go field name of the JSON key, such as
+// UserID for "user_id".
+func jsonFieldName(key string) string {
+	var b strings.Builder
+	for _, word := range jsonKeyWords(key) {
+		if jsonInitialisms[strings.ToLower(word)] {
+			b.WriteString(strings.ToUpper(word))
+			continue
+		}
+		runes := []rune(word)
+		b.WriteRune(unicode.ToUpper(runes[0]))
+		b.WriteString(string(runes[1:]))
+	}
+	name := b.String()
+	if name == "" {
+		return "Field"
+	}
+	if r := []rune(name)[0]; !unicode.IsLetter(r) {
+		name = "X" + name
+	}
+	return name
+}
+
+// jsonKeyWords splits a JSON key into words at the characters that are not
+// letters or digits, and at the lower-to-upper case transitions.
+func jsonKeyWords(key string) []string {
+	var (
+		words []string
+		word  []rune
+		prev  rune
+	)
+	for _, r := range key {
+		switch {
+		case !unicode.IsLetter(r) && !unicode.IsDigit(r):
+			if len(word) > 0 {
+				words = append(words, string(word))
+			}
+			word = nil
+		case unicode.IsUpper(r) && (unicode.IsLower(prev) || unicode.IsDigit(prev)) && len(word) > 0:
+			words = append(words, string(word))
+			word = []rune{r}
+		default:
+			word = append(word, r)
+		}
+		prev = r
+	}
+	if len(word) > 0 {
+		words = append(words, string(word))
+	}
+	return words
+}
+
+// StructFromJSONEdits returns the edit inserting the struct type name
+// generated from the JSON document data at the position pos of the Go file
+// fh.
+func StructFromJSONEdits(ctx context.Context, snapshot Snapshot, fh FileHandle, pos protocol.Position, name string, data []byte) ([]protocol.TextEdit, error) {
+	decl, err := StructFromJSON(name, data)
+	if err != nil {
+		return nil, err
+	}
+	pgf, err := snapshot.ParseGo(ctx, fh, ParseHeader)
+	if err != nil {
+		return nil, err
+	}
+	// The position must be valid in the file.
+	if _, err := pgf.Mapper.Point(pos); err != nil {
+		return nil, err
+	}
+	return []protocol.TextEdit{{
+		Range:   protocol.Range{Start: pos, End: pos},
+		NewText: string(decl) + "\n",
+	}}, nil
+}
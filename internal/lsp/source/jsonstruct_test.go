@@ -0,0 +1,68 @@
+// Copyright 2022 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package source
+
+import "testing"
+
+func TestStructFromJSON(t *testing.T) {
+	tests := []struct {
+		name, json, want string
+	}{
+		{
+			name: "User",
+			json: `{"user_id": 1, "name": "a", "score": 1.5, "admin": true, "homePage": "x", "tags": ["a"], "extra": null}`,
+			want: "type User struct {\n" +
+				"\tUserID   int64       `json:\"user_id\"`\n" +
+				"\tName     string      `json:\"name\"`\n" +
+				"\tScore    float64     `json:\"score\"`\n" +
+				"\tAdmin    bool        `json:\"admin\"`\n" +
+				"\tHomePage string      `json:\"homePage\"`\n" +
+				"\tTags     []string    `json:\"tags\"`\n" +
+				"\tExtra    interface{} `json:\"extra,omitempty\"`\n" +
+				"}",
+		},
+		{
+			// The objects of an array are merged.
+			name: "Point",
+			json: `[{"x": 1, "y": 2}, {"x": 1.5, "label": "a"}, {"x": 2, "y": null}]`,
+			want: "type Point struct {\n" +
+				"\tX     float64 `json:\"x\"`\n" +
+				"\tY     int64   `json:\"y,omitempty\"`\n" +
+				"\tLabel string  `json:\"label,omitempty\"`\n" +
+				"}",
+		},
+		{
+			name: "Config",
+			json: `{"server": {"url": "x", "2fa": false}, "values": [1, "a"], "empty": []}`,
+			want: "type Config struct {\n" +
+				"\tServer struct {\n" +
+				"\t\tURL  string `json:\"url\"`\n" +
+				"\t\tX2fa bool   `json:\"2fa\"`\n" +
+				"\t} `json:\"server\"`\n" +
+				"\tValues []interface{} `json:\"values\"`\n" +
+				"\tEmpty  []interface{} `json:\"empty\"`\n" +
+				"}",
+		},
+	}
+	for _, test := range tests {
+		got, err := StructFromJSON(test.name, []byte(test.json))
+		if err != nil {
+			t.Errorf("StructFromJSON(%q): %v", test.json, err)
+			continue
+		}
+		if string(got) != test.want {
+			t.Errorf("StructFromJSON(%q) =\n%s\nwant:\n%s", test.json, got, test.want)
+		}
+	}
+
+	for _, json := range []string{`1`, `["a"]`, `{"a": 1} {}`, `{"a":`} {
+		if _, err := StructFromJSON("T", []byte(json)); err == nil {
+			t.Errorf("StructFromJSON(%q) succeeded, want an error", json)
+		}
+	}
+	if _, err := StructFromJSON("not a name", []byte(`{}`)); err == nil {
+		t.Errorf("StructFromJSON with an invalid name succeeded, want an error")
+	}
+}
@@ -0,0 +1,180 @@
+// Copyright 2022 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package source
+
+import (
+	"context"
+	"go/ast"
+	"go/token"
+	"go/types"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/iansmith/golang-x-tools/go/ast/astutil"
+	"github.com/iansmith/golang-x-tools/internal/event"
+	"github.com/iansmith/golang-x-tools/internal/lsp/protocol"
+)
+
+// tagWordPattern matches the words of struct tags linked by
+// LinkedEditingRanges, which may contain hyphens.
+const tagWordPattern = `[\w-]+`
+
+// LinkedEditingRanges returns the ranges that can be edited together with
+// the one at pos: the occurrences, in its function, of a variable declared
+// by a short variable declaration, or the occurrences of a word repeated
+// in a struct tag. It returns nil if there are none.
+func LinkedEditingRanges(ctx context.Context, snapshot Snapshot, fh FileHandle, position protocol.Position) (*protocol.LinkedEditingRanges, error) {
+	ctx, done := event.Start(ctx, "source.LinkedEditingRanges")
+	defer done()
+
+	pkg, pgf, err := GetParsedFile(ctx, snapshot, fh, NarrowestPackage)
+	if err != nil {
+		return nil, err
+	}
+	pos, err := pgf.Mapper.Pos(position)
+	if err != nil {
+		return nil, err
+	}
+	path, _ := astutil.PathEnclosingInterval(pgf.File, pos, pos)
+	if len(path) == 0 {
+		return nil, nil
+	}
+	var ranges [][2]token.Pos
+	wordPattern := ""
+	switch n := path[0].(type) {
+	case *ast.Ident:
+		ranges = shortVarRanges(pkg.GetTypesInfo(), path, n)
+	case *ast.BasicLit:
+		if len(path) > 1 {
+			if field, ok := path[1].(*ast.Field); ok && field.Tag == n {
+				ranges = tagWordRanges(n, pos)
+				wordPattern = tagWordPattern
+			}
+		}
+	}
+	if len(ranges) < 2 {
+		return nil, nil
+	}
+	result := &protocol.LinkedEditingRanges{WordPattern: wordPattern}
+	for _, r := range ranges {
+		rng, err := NewMappedRange(snapshot.FileSet(), pgf.Mapper, r[0], r[1]).Range()
+		if err != nil {
+			return nil, err
+		}
+		result.Ranges = append(result.Ranges, rng)
+	}
+	return result, nil
+}
+
+// shortVarRanges returns the ranges of the occurrences of the variable
+// of id, in the function enclosing path, if it is declared by a short
+// variable declaration or a range clause with :=.
+func shortVarRanges(info *types.Info, path []ast.Node, id *ast.Ident) [][2]token.Pos {
+	v, ok := info.ObjectOf(id).(*types.Var)
+	if !ok || v.IsField() {
+		return nil
+	}
+	// The outermost function enclosing id contains the occurrences of the
+	// variable, if it is local.
+	var body ast.Node
+	for _, n := range path {
+		switch n.(type) {
+		case *ast.FuncDecl, *ast.FuncLit:
+			body = n
+		}
+	}
+	if body == nil || v.Pos() < body.Pos() || v.Pos() >= body.End() {
+		return nil
+	}
+	declared := false
+	var ranges [][2]token.Pos
+	ast.Inspect(body, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.AssignStmt:
+			if n.Tok == token.DEFINE && declares(info, n.Lhs, v) {
+				declared = true
+			}
+		case *ast.RangeStmt:
+			if n.Tok == token.DEFINE && declares(info, []ast.Expr{n.Key, n.Value}, v) {
+				declared = true
+			}
+		case *ast.Ident:
+			if info.ObjectOf(n) == v {
+				ranges = append(ranges, [2]token.Pos{n.Pos(), n.End()})
+			}
+		}
+		return true
+	})
+	if !declared {
+		return nil
+	}
+	return ranges
+}
+
+// declares reports whether one of the identifiers of lhs defines v.
+func declares(info *types.Info, lhs []ast.Expr, v *types.Var) bool {
+	for _, e := range lhs {
+		if id, ok := e.(*ast.Ident); ok && info.Defs[id] == v {
+			return true
+		}
+	}
+	return false
+}
+
+// tagWordRanges returns the ranges of the occurrences, in the struct tag
+// lit, of the word at pos: a run of letters, digits, underscores and
+// hyphens, such as a key of the tag in its encodings.
+func tagWordRanges(lit *ast.BasicLit, pos token.Pos) [][2]token.Pos {
+	text := lit.Value
+	offset := int(pos - lit.Pos())
+	if offset < 0 || offset > len(text) {
+		return nil
+	}
+	start, end := offset, offset
+	for start > 0 {
+		r, size := utf8.DecodeLastRuneInString(text[:start])
+		if !isTagWordRune(r) {
+			break
+		}
+		start -= size
+	}
+	for end < len(text) {
+		r, size := utf8.DecodeRuneInString(text[end:])
+		if !isTagWordRune(r) {
+			break
+		}
+		end += size
+	}
+	word := text[start:end]
+	if word == "" {
+		return nil
+	}
+	var ranges [][2]token.Pos
+	for i := 0; i+len(word) <= len(text); {
+		if text[i:i+len(word)] != word || !tagWordBoundary(text, i, i+len(word)) {
+			_, size := utf8.DecodeRuneInString(text[i:])
+			i += size
+			continue
+		}
+		ranges = append(ranges, [2]token.Pos{lit.Pos() + token.Pos(i), lit.Pos() + token.Pos(i+len(word))})
+		i += len(word)
+	}
+	return ranges
+}
+
+// tagWordBoundary reports whether text[start:end] is a whole word.
+func tagWordBoundary(text string, start, end int) bool {
+	if r, _ := utf8.DecodeLastRuneInString(text[:start]); start > 0 && isTagWordRune(r) {
+		return false
+	}
+	if r, _ := utf8.DecodeRuneInString(text[end:]); end < len(text) && isTagWordRune(r) {
+		return false
+	}
+	return true
+}
+
+func isTagWordRune(r rune) bool {
+	return r == '_' || r == '-' || unicode.IsLetter(r) || unicode.IsDigit(r)
+}
@@ -0,0 +1,137 @@
+// Copyright 2022 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package source
+
+import (
+	"context"
+	"fmt"
+	"go/token"
+	"go/types"
+	"strings"
+
+	"github.com/iansmith/golang-x-tools/internal/event"
+	"github.com/iansmith/golang-x-tools/internal/lsp/protocol"
+)
+
+const linknamePrefix = "//go:linkname"
+
+// LinknameDefinition returns the location of the declaration named by the
+// //go:linkname directive at the given position: either the local name, or
+// the import path qualified target, such as runtime.nanotime. The boolean
+// result reports whether the position is within a //go:linkname directive.
+func LinknameDefinition(ctx context.Context, snapshot Snapshot, fh FileHandle, position protocol.Position) ([]protocol.Location, bool, error) {
+	ctx, done := event.Start(ctx, "source.LinknameDefinition")
+	defer done()
+
+	pgf, err := snapshot.ParseGo(ctx, fh, ParseFull)
+	if err != nil {
+		return nil, false, err
+	}
+	pos, err := pgf.Mapper.Pos(position)
+	if err != nil {
+		return nil, false, err
+	}
+
+	// Find the directive argument under the cursor.
+	var args []string
+	argIndex := -1
+	for _, group := range pgf.File.Comments {
+		if pos < group.Pos() || pos > group.End() {
+			continue
+		}
+		for _, c := range group.List {
+			if !strings.HasPrefix(c.Text, linknamePrefix+" ") || pos < c.Pos() || pos > c.End() {
+				continue
+			}
+			text := c.Text[len(linknamePrefix):]
+			for i := 0; i < len(text); {
+				if text[i] == ' ' || text[i] == '\t' {
+					i++
+					continue
+				}
+				start := i
+				for i < len(text) && text[i] != ' ' && text[i] != '\t' {
+					i++
+				}
+				offset := c.Pos() + token.Pos(len(linknamePrefix))
+				if offset+token.Pos(start) <= pos && pos <= offset+token.Pos(i) {
+					argIndex = len(args)
+				}
+				args = append(args, text[start:i])
+			}
+		}
+	}
+	if argIndex < 0 || argIndex > 1 {
+		return nil, false, nil
+	}
+
+	pkg, err := snapshot.PackageForFile(ctx, fh.URI(), TypecheckWorkspace, NarrowestPackage)
+	if err != nil {
+		return nil, true, err
+	}
+	var obj types.Object
+	target := pkg
+	if argIndex == 0 {
+		// The local name is declared in the package scope.
+		obj = pkg.GetTypes().Scope().Lookup(args[0])
+	} else {
+		pkgPath, name, ok := splitLinknameTarget(args[1])
+		if !ok {
+			return nil, true, fmt.Errorf("invalid //go:linkname target %q", args[1])
+		}
+		target, err = packageForPath(ctx, snapshot, pkg, pkgPath)
+		if err != nil {
+			return nil, true, err
+		}
+		obj = lookupLinknameTarget(target.GetTypes(), name)
+	}
+	if obj == nil {
+		return nil, true, fmt.Errorf("no declaration found for %q", args[argIndex])
+	}
+	rng, err := objToMappedRange(snapshot, target, obj)
+	if err != nil {
+		return nil, true, err
+	}
+	protocolRange, err := rng.Range()
+	if err != nil {
+		return nil, true, err
+	}
+	return []protocol.Location{{
+		URI:   protocol.URIFromSpanURI(rng.URI()),
+		Range: protocolRange,
+	}}, true, nil
+}
+
+// splitLinknameTarget splits a //go:linkname target such as
+// "example.com/pkg.(*T).m" into its package path and package-relative name.
+func splitLinknameTarget(target string) (pkgPath, name string, ok bool) {
+	slash := strings.LastIndex(target, "/")
+	dot := strings.Index(target[slash+1:], ".")
+	if dot < 0 {
+		return "", "", false
+	}
+	dot += slash + 1
+	return target[:dot], target[dot+1:], dot+1 < len(target)
+}
+
+// lookupLinknameTarget returns the object named by the package-relative name
+// of a //go:linkname target: a package-level declaration, or a method
+// written as T.m or (*T).m.
+func lookupLinknameTarget(pkg *types.Package, name string) types.Object {
+	dot := strings.LastIndex(name, ".")
+	if dot < 0 {
+		return pkg.Scope().Lookup(name)
+	}
+	recv := strings.TrimSuffix(strings.TrimPrefix(name[:dot], "(*"), ")")
+	tname, ok := pkg.Scope().Lookup(recv).(*types.TypeName)
+	if !ok {
+		return nil
+	}
+	obj, _, _ := types.LookupFieldOrMethod(types.NewPointer(tname.Type()), true, pkg, name[dot+1:])
+	if fn, ok := obj.(*types.Func); ok {
+		return fn
+	}
+	return nil
+}
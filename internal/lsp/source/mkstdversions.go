@@ -0,0 +1,107 @@
+// Copyright 2022 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build ignore
+// +build ignore
+
+// mkstdversions generates the zstdversions.go file, containing the minor
+// version of Go that added each symbol of the standard library, from the
+// API files of the Go distribution that runs it.
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"go/format"
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+	"regexp"
+	"runtime"
+	"sort"
+	"strconv"
+)
+
+var (
+	apiFile = regexp.MustCompile(`^go1\.(\d+)\.txt$`)
+	sym     = regexp.MustCompile(`^pkg (\S+?)(?: \([^)]*\))?, (?:(?:var|func|type|const) ([A-Z]\w*)|method \(\*?([A-Z]\w*)(?:\[[^\]]*\])?\) ([A-Z]\w*))`)
+)
+
+func main() {
+	dir := filepath.Join(runtime.GOROOT(), "api")
+	entries, err := ioutil.ReadDir(dir)
+	if err != nil {
+		log.Fatal(err)
+	}
+	// The symbols of go1.txt are in all versions.
+	versions := make(map[string]int)
+	if err := readAPI(filepath.Join(dir, "go1.txt"), 0, versions); err != nil {
+		log.Fatal(err)
+	}
+	for _, e := range entries {
+		m := apiFile.FindStringSubmatch(e.Name())
+		if m == nil {
+			continue
+		}
+		minor, _ := strconv.Atoi(m[1])
+		if err := readAPI(filepath.Join(dir, e.Name()), minor, versions); err != nil {
+			log.Fatal(err)
+		}
+	}
+
+	var keys []string
+	for key, minor := range versions {
+		if minor > 0 {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "// Code generated by mkstdversions.go. DO NOT EDIT.\n\n")
+	fmt.Fprintf(&buf, "package source\n\n")
+	fmt.Fprintf(&buf, "// stdVersions maps the symbols of the standard library added after Go\n")
+	fmt.Fprintf(&buf, "// 1.0, as \"path.Name\" or \"path.Type.Method\", to the minor version of Go\n")
+	fmt.Fprintf(&buf, "// that added them.\n")
+	fmt.Fprintf(&buf, "var stdVersions = map[string]int{\n")
+	for _, key := range keys {
+		fmt.Fprintf(&buf, "\t%q: %d,\n", key, versions[key])
+	}
+	fmt.Fprintf(&buf, "}\n")
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := ioutil.WriteFile("zstdversions.go", src, 0666); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// readAPI records in versions the symbols of the API file filename, of Go
+// 1.minor, that no earlier version has. The syscall package is left out, as
+// its symbols depend on the platform.
+func readAPI(filename string, minor int, versions map[string]int) error {
+	f, err := os.Open(filename)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	sc := bufio.NewScanner(f)
+	for sc.Scan() {
+		m := sym.FindStringSubmatch(sc.Text())
+		if m == nil || m[1] == "syscall" {
+			continue
+		}
+		key := m[1] + "." + m[2]
+		if m[2] == "" {
+			key = m[1] + "." + m[3] + "." + m[4]
+		}
+		if v, ok := versions[key]; !ok || minor < v {
+			versions[key] = minor
+		}
+	}
+	return sc.Err()
+}
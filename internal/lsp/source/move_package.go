@@ -0,0 +1,324 @@
+// Copyright 2022 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package source
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/token"
+	"go/types"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/iansmith/golang-x-tools/internal/event"
+	"github.com/iansmith/golang-x-tools/internal/lsp/protocol"
+	"github.com/iansmith/golang-x-tools/internal/span"
+)
+
+// MovePackage returns the edits to the Go files of the workspace that
+// moving the directory of the package containing uri to newDir
+// requires: the import paths of the package, and of the packages in its
+// subdirectories, are updated in the files that import them. If the
+// package is named after its directory, it is renamed after newDir, and
+// its package clauses and the identifiers qualified by its name are
+// updated too; files in which the new name would be ambiguous import
+// the package under its old name instead.
+//
+// The edits are to the files before the move. The caller is responsible
+// for moving the directory itself.
+func MovePackage(ctx context.Context, snapshot Snapshot, uri span.URI, newDir span.URI) (map[span.URI][]protocol.TextEdit, error) {
+	ctx, done := event.Start(ctx, "source.MovePackage")
+	defer done()
+
+	pkg, err := snapshot.PackageForFile(ctx, uri, TypecheckWorkspace, NarrowestPackage)
+	if err != nil {
+		return nil, err
+	}
+	oldDir := filepath.Dir(uri.Filename())
+	modURI := snapshot.GoModForFile(uri)
+	if modURI == "" {
+		return nil, fmt.Errorf("no go.mod file for %s", uri.Filename())
+	}
+	fh, err := snapshot.GetFile(ctx, modURI)
+	if err != nil {
+		return nil, err
+	}
+	pm, err := snapshot.ParseMod(ctx, fh)
+	if err != nil {
+		return nil, err
+	}
+	if pm.File == nil || pm.File.Module == nil {
+		return nil, fmt.Errorf("no module path in %s", modURI.Filename())
+	}
+	modDir := filepath.Dir(modURI.Filename())
+	modPath := pm.File.Module.Mod.Path
+
+	rel, err := filepath.Rel(modDir, newDir.Filename())
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return nil, fmt.Errorf("%s is not within the module in %s", newDir.Filename(), modDir)
+	}
+	if rel == "." {
+		return nil, errors.New("cannot move a package to the root of its module")
+	}
+	if sub, err := filepath.Rel(oldDir, newDir.Filename()); err == nil && (sub == "." || !strings.HasPrefix(sub, "..")) {
+		return nil, fmt.Errorf("cannot move %s into itself", oldDir)
+	}
+	oldPath, oldName := pkg.PkgPath(), pkg.GetTypes().Name()
+	if strings.HasSuffix(oldName, "_test") {
+		// uri is an external test file.
+		oldPath, oldName = strings.TrimSuffix(oldPath, "_test"), strings.TrimSuffix(oldName, "_test")
+	}
+	m := packageMove{
+		dir:     oldDir,
+		oldPath: oldPath,
+		newPath: path.Join(modPath, filepath.ToSlash(rel)),
+		oldName: oldName,
+		newName: oldName,
+	}
+	if base := filepath.Base(newDir.Filename()); oldName == filepath.Base(oldDir) && oldName != "main" && isValidIdentifier(base) && !token.IsKeyword(base) {
+		m.newName = base
+	}
+	return m.edits(ctx, snapshot)
+}
+
+// renamePackage returns the edits that renaming the package containing
+// uri to newName requires. If the package is named after its directory,
+// and the client can rename files, the directory is renamed to newName
+// too, and the import paths of the package and its subpackages are
+// updated; the returned RenameFile describes the rename of the
+// directory, which follows the edits.
+func renamePackage(ctx context.Context, snapshot Snapshot, uri span.URI, newName string) (map[span.URI][]protocol.TextEdit, *protocol.RenameFile, error) {
+	if !isValidIdentifier(newName) || token.IsKeyword(newName) {
+		return nil, nil, fmt.Errorf("invalid package name: %q", newName)
+	}
+	if strings.HasSuffix(newName, "_test") {
+		return nil, nil, fmt.Errorf("invalid package name: %q ends in _test", newName)
+	}
+	pkg, err := snapshot.PackageForFile(ctx, uri, TypecheckWorkspace, NarrowestPackage)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := checkPackageRenamable(pkg); err != nil {
+		return nil, nil, err
+	}
+	oldName := pkg.GetTypes().Name()
+	if oldName == newName {
+		return nil, nil, fmt.Errorf("old and new names are the same: %s", newName)
+	}
+
+	oldDir := filepath.Dir(uri.Filename())
+	modURI := snapshot.GoModForFile(uri)
+	if filepath.Base(oldDir) == oldName && modURI != "" && filepath.Dir(modURI.Filename()) != oldDir && snapshot.View().Options().RenameFileSupported {
+		newDir := filepath.Join(filepath.Dir(oldDir), newName)
+		if _, err := os.Stat(newDir); err == nil {
+			return nil, nil, fmt.Errorf("cannot rename directory %s: %s already exists", oldDir, newDir)
+		}
+		edits, err := MovePackage(ctx, snapshot, uri, span.URIFromPath(newDir))
+		if err != nil {
+			return nil, nil, err
+		}
+		return edits, &protocol.RenameFile{
+			Kind:   "rename",
+			OldURI: protocol.URIFromPath(oldDir),
+			NewURI: protocol.URIFromPath(newDir),
+		}, nil
+	}
+
+	// Rename the package but leave its directory and import path as is.
+	m := packageMove{
+		dir:     oldDir,
+		oldPath: pkg.PkgPath(),
+		newPath: pkg.PkgPath(),
+		oldName: oldName,
+		newName: newName,
+	}
+	edits, err := m.edits(ctx, snapshot)
+	return edits, nil, err
+}
+
+// checkPackageRenamable verifies that the package pkg may be renamed.
+func checkPackageRenamable(pkg Package) error {
+	switch name := pkg.GetTypes().Name(); {
+	case name == "main":
+		return errors.New("can't rename package main")
+	case strings.HasSuffix(name, "_test"):
+		return errors.New("can't rename an external test package: rename the package it tests")
+	}
+	return nil
+}
+
+// inPackageClause parses the Go file f, and reports whether pp is within
+// the name of its package clause.
+func inPackageClause(ctx context.Context, snapshot Snapshot, f FileHandle, pp protocol.Position) (*ParsedGoFile, bool, error) {
+	pgf, err := snapshot.ParseGo(ctx, f, ParseFull)
+	if err != nil {
+		return nil, false, err
+	}
+	pos, err := pgf.Mapper.Pos(pp)
+	if err != nil {
+		return nil, false, err
+	}
+	return pgf, NodeContains(pgf.File.Name, pos), nil
+}
+
+// A packageMove describes a change to the import path or name of the
+// package in dir, or both.
+type packageMove struct {
+	dir              string
+	oldPath, newPath string
+	oldName, newName string
+}
+
+// moved returns the import path after the move of the package with the
+// given import path, which is either the moved package or one in one of
+// its subdirectories, or "" if the package does not move.
+func (m packageMove) moved(importPath string) string {
+	if m.oldPath == m.newPath {
+		return ""
+	}
+	if importPath == m.oldPath {
+		return m.newPath
+	}
+	if strings.HasPrefix(importPath, m.oldPath+"/") {
+		return m.newPath + strings.TrimPrefix(importPath, m.oldPath)
+	}
+	return ""
+}
+
+// edits returns the edits to the files of the workspace that the move
+// requires.
+func (m packageMove) edits(ctx context.Context, snapshot Snapshot) (map[span.URI][]protocol.TextEdit, error) {
+	pkgs, err := snapshot.ActivePackages(ctx)
+	if err != nil {
+		return nil, err
+	}
+	result := make(map[span.URI][]protocol.TextEdit)
+	seen := make(map[span.URI]bool)
+	for _, p := range pkgs {
+		for _, pgf := range p.CompiledGoFiles() {
+			if seen[pgf.URI] {
+				continue
+			}
+			seen[pgf.URI] = true
+			if snapshot.FileSet().Position(pgf.File.Package).Filename != pgf.URI.Filename() {
+				continue // generated by cgo from another file
+			}
+			edits, err := m.fileEdits(snapshot, p, pgf)
+			if err != nil {
+				return nil, err
+			}
+			if len(edits) > 0 {
+				result[pgf.URI] = edits
+			}
+		}
+	}
+	return result, nil
+}
+
+// fileEdits returns the edits to pgf, a file of pkg, that the move
+// requires.
+func (m packageMove) fileEdits(snapshot Snapshot, pkg Package, pgf *ParsedGoFile) ([]protocol.TextEdit, error) {
+	var edits []protocol.TextEdit
+	edit := func(start, end token.Pos, newText string) error {
+		rng, err := NewMappedRange(snapshot.FileSet(), pgf.Mapper, start, end).Range()
+		if err != nil {
+			return err
+		}
+		edits = append(edits, protocol.TextEdit{Range: rng, NewText: newText})
+		return nil
+	}
+
+	file := pgf.File
+	if m.oldName != m.newName && filepath.Dir(pgf.URI.Filename()) == m.dir {
+		name := file.Name
+		switch name.Name {
+		case m.oldName:
+			if err := edit(name.Pos(), name.End(), m.newName); err != nil {
+				return nil, err
+			}
+		case m.oldName + "_test":
+			if err := edit(name.Pos(), name.End(), m.newName+"_test"); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	info := pkg.GetTypesInfo()
+	for _, spec := range file.Imports {
+		importPath, err := strconv.Unquote(spec.Path.Value)
+		if err != nil {
+			continue
+		}
+		to := m.moved(importPath)
+		if importPath != m.oldPath || m.oldName == m.newName {
+			if to != "" {
+				if err := edit(spec.Path.Pos(), spec.Path.End(), strconv.Quote(to)); err != nil {
+					return nil, err
+				}
+			}
+			continue
+		}
+		if to == "" {
+			to = importPath
+		}
+		if spec.Name != nil {
+			if spec.Name.Name == m.newName {
+				// The alias is now redundant.
+				if err := edit(spec.Name.Pos(), spec.Path.End(), strconv.Quote(to)); err != nil {
+					return nil, err
+				}
+			} else if to != importPath {
+				if err := edit(spec.Path.Pos(), spec.Path.End(), strconv.Quote(to)); err != nil {
+					return nil, err
+				}
+			}
+			continue
+		}
+
+		// The file refers to the renamed package by its name. Rename
+		// the qualified identifiers, unless an identifier in the file
+		// already has the new name, in which case keep the old name.
+		if usesName(file, m.newName) {
+			if err := edit(spec.Path.Pos(), spec.Path.End(), m.oldName+" "+strconv.Quote(to)); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		if to != importPath {
+			if err := edit(spec.Path.Pos(), spec.Path.End(), strconv.Quote(to)); err != nil {
+				return nil, err
+			}
+		}
+		pkgName, ok := info.Implicits[spec].(*types.PkgName)
+		if !ok {
+			continue
+		}
+		for id, obj := range info.Uses {
+			if obj == pkgName {
+				if err := edit(id.Pos(), id.End(), m.newName); err != nil {
+					return nil, err
+				}
+			}
+		}
+	}
+	return edits, nil
+}
+
+// usesName reports whether any identifier in node is named name.
+func usesName(node ast.Node, name string) bool {
+	found := false
+	ast.Inspect(node, func(n ast.Node) bool {
+		if id, ok := n.(*ast.Ident); ok && id.Name == name {
+			found = true
+		}
+		return !found
+	})
+	return found
+}
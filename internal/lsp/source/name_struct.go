@@ -0,0 +1,350 @@
+// Copyright 2022 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package source
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/token"
+	"go/types"
+	"strings"
+
+	"github.com/iansmith/golang-x-tools/go/analysis"
+	"github.com/iansmith/golang-x-tools/go/ast/astutil"
+	"github.com/iansmith/golang-x-tools/internal/lsp/protocol"
+	"github.com/iansmith/golang-x-tools/internal/span"
+	"github.com/iansmith/golang-x-tools/internal/typeparams"
+)
+
+// CanNameStruct reports whether rng selects the struct keyword of an
+// anonymous struct type, which nameStruct can declare as a named type.
+func CanNameStruct(pkg Package, file *ast.File, rng span.Range) bool {
+	_, _, err := selectedStruct(pkg, file, rng)
+	return err == nil
+}
+
+// selectedStruct returns the anonymous struct type whose struct keyword
+// rng selects, and the path of its enclosing nodes, which starts with it.
+func selectedStruct(pkg Package, file *ast.File, rng span.Range) (*ast.StructType, []ast.Node, error) {
+	path, _ := astutil.PathEnclosingInterval(file, rng.Start, rng.End)
+	for i, n := range path {
+		st, ok := n.(*ast.StructType)
+		if !ok {
+			continue
+		}
+		if rng.End < st.Struct || st.Struct+token.Pos(len("struct")) < rng.Start {
+			return nil, nil, errors.New("no struct keyword selected")
+		}
+		if i+1 < len(path) {
+			if spec, ok := path[i+1].(*ast.TypeSpec); ok && spec.Type == st && !spec.Assign.IsValid() {
+				return nil, nil, errors.New("the struct type is already named")
+			}
+		}
+		typ, ok := pkg.GetTypesInfo().TypeOf(st).(*types.Struct)
+		if !ok {
+			return nil, nil, errors.New("no type information")
+		}
+		if typ.NumFields() == 0 {
+			return nil, nil, errors.New("the struct type is empty")
+		}
+		if obj := localType(typ, pkg.GetTypes()); obj != nil {
+			return nil, nil, fmt.Errorf("the struct type refers to %s, which is not declared at package level", obj.Name())
+		}
+		return st, path[i:], nil
+	}
+	return nil, nil, errors.New("no struct type selected")
+}
+
+// localType returns a type name that typ refers to which is not declared
+// at package level, such as a type parameter or a type declared in a
+// function, or nil.
+func localType(typ types.Type, pkg *types.Package) types.Object {
+	switch t := typ.(type) {
+	case *typeparams.TypeParam:
+		return t.Obj()
+	case *types.Named:
+		if obj := t.Obj(); obj.Pkg() == pkg && obj.Parent() != pkg.Scope() {
+			return obj
+		}
+		if targs := typeparams.NamedTypeArgs(t); targs != nil {
+			for i := 0; i < targs.Len(); i++ {
+				if obj := localType(targs.At(i), pkg); obj != nil {
+					return obj
+				}
+			}
+		}
+	case *types.Pointer:
+		return localType(t.Elem(), pkg)
+	case *types.Slice:
+		return localType(t.Elem(), pkg)
+	case *types.Array:
+		return localType(t.Elem(), pkg)
+	case *types.Chan:
+		return localType(t.Elem(), pkg)
+	case *types.Map:
+		if obj := localType(t.Key(), pkg); obj != nil {
+			return obj
+		}
+		return localType(t.Elem(), pkg)
+	case *types.Struct:
+		for i := 0; i < t.NumFields(); i++ {
+			if obj := localType(t.Field(i).Type(), pkg); obj != nil {
+				return obj
+			}
+		}
+	case *types.Tuple:
+		for i := 0; i < t.Len(); i++ {
+			if obj := localType(t.At(i).Type(), pkg); obj != nil {
+				return obj
+			}
+		}
+	case *types.Signature:
+		if obj := localType(t.Params(), pkg); obj != nil {
+			return obj
+		}
+		return localType(t.Results(), pkg)
+	case *types.Interface:
+		for i := 0; i < t.NumExplicitMethods(); i++ {
+			if obj := localType(t.ExplicitMethod(i).Type(), pkg); obj != nil {
+				return obj
+			}
+		}
+		for i := 0; i < t.NumEmbeddeds(); i++ {
+			if obj := localType(t.EmbeddedType(i), pkg); obj != nil {
+				return obj
+			}
+		}
+	}
+	return nil
+}
+
+// nameStruct declares the anonymous struct type whose struct keyword pRng
+// selects as a named type, before the declaration that contains it, and
+// replaces the identical struct types of its package with the new type.
+// The type is named after the variable or field of the struct type, if
+// any. The conversion fails if the package or the packages that import it
+// use the struct type in type assertions, which would no longer hold, or
+// if it would cause type errors, such as when the values of a composite
+// type of the struct type are exchanged with other packages.
+func nameStruct(ctx context.Context, snapshot Snapshot, fh VersionedFileHandle, pRng protocol.Range) (*analysis.SuggestedFix, error) {
+	pkg, pgf, err := GetParsedFile(ctx, snapshot, fh, NarrowestPackage)
+	if err != nil {
+		return nil, fmt.Errorf("GetParsedFile: %w", err)
+	}
+	rng, err := pgf.Mapper.RangeToSpanRange(pRng)
+	if err != nil {
+		return nil, err
+	}
+	st, path, err := selectedStruct(pkg, pgf.File, rng)
+	if err != nil {
+		return nil, fmt.Errorf("cannot name struct: %v", err)
+	}
+	if pkg.HasTypeErrors() || pkg.HasListOrParseErrors() {
+		return nil, fmt.Errorf("cannot name struct: package %s has errors", pkg.PkgPath())
+	}
+	fset := snapshot.FileSet()
+	offset := fset.Position(st.Pos()).Offset
+
+	pkgs, err := refactoringPackages(ctx, snapshot, pgf.URI)
+	if err != nil {
+		return nil, err
+	}
+
+	// Find the identical struct types of the variants of the package, and
+	// the type assertions to them in all the packages.
+	type occurrence struct {
+		pkg Package
+		st  *ast.StructType
+	}
+	var (
+		occurrences []occurrence
+		blockers    []string
+	)
+	for _, p := range pkgs {
+		typ := variantStruct(p, pgf.URI, offset)
+		variant := typ != nil
+		if !variant {
+			// Another package, which refers to the type of the variant
+			// that it imports.
+			typ = pkg.GetTypesInfo().TypeOf(st).(*types.Struct)
+			if imp, err := p.GetImport(pkg.PkgPath()); err == nil {
+				if t := variantStruct(imp, pgf.URI, offset); t != nil {
+					typ = t
+				}
+			}
+		}
+		info := p.GetTypesInfo()
+		for _, f := range p.GetSyntax() {
+			named := make(map[*ast.StructType]bool)
+			ast.Inspect(f, func(n ast.Node) bool {
+				switch n := n.(type) {
+				case *ast.TypeSpec:
+					if st, ok := n.Type.(*ast.StructType); ok && !n.Assign.IsValid() {
+						named[st] = true
+					}
+				case *ast.TypeAssertExpr:
+					if n.Type != nil && identicalStruct(info.TypeOf(n.Type), typ) {
+						blockers = append(blockers, fmt.Sprintf("%s: type assertion to the struct type", fset.Position(n.Type.Pos())))
+					}
+				case *ast.TypeSwitchStmt:
+					for _, clause := range n.Body.List {
+						for _, e := range clause.(*ast.CaseClause).List {
+							if identicalStruct(info.TypeOf(e), typ) {
+								blockers = append(blockers, fmt.Sprintf("%s: type switch case of the struct type", fset.Position(e.Pos())))
+							}
+						}
+					}
+				case *ast.StructType:
+					if variant && !named[n] && identicalStruct(info.TypeOf(n), typ) {
+						occurrences = append(occurrences, occurrence{p, n})
+						return false
+					}
+				}
+				return true
+			})
+		}
+	}
+	if len(blockers) > 0 {
+		return nil, fmt.Errorf("cannot name struct:\n%s", strings.Join(blockers, "\n"))
+	}
+
+	// The name must refer to the new type wherever the struct type is
+	// replaced.
+	scope := pkg.GetTypes().Scope()
+	name, _ := generateIdentifier(0, structName(path), func(name string) bool {
+		if scope.Lookup(name) != nil {
+			return true
+		}
+		for i := 0; i < scope.NumChildren(); i++ {
+			if scope.Child(i).Lookup(name) != nil {
+				return true // an import
+			}
+		}
+		for _, o := range occurrences {
+			s := o.pkg.GetTypes().Scope().Innermost(o.st.Pos())
+			if s == nil {
+				continue
+			}
+			if _, obj := s.LookupParent(name, o.st.Pos()); obj != nil {
+				return true
+			}
+		}
+		return false
+	})
+
+	// Declare the type before the declaration that contains the
+	// selected struct type, with its doc comment.
+	var decl ast.Node = path[len(path)-2]
+	doc := nodeDoc(decl)
+	if doc != nil {
+		decl = doc
+	}
+	src := string(pgf.Src[fset.Position(st.Pos()).Offset:fset.Position(st.End()).Offset])
+	lineStart := pgf.Tok.LineStart(pgf.Tok.Line(st.Pos()))
+	indent := string(pgf.Src[fset.Position(lineStart).Offset:offset])
+	indent = indent[:len(indent)-len(strings.TrimLeft(indent, " \t"))]
+	src = strings.ReplaceAll(src, "\n"+indent, "\n")
+
+	edits := []analysis.TextEdit{{
+		Pos:     decl.Pos(),
+		End:     decl.Pos(),
+		NewText: []byte(fmt.Sprintf("type %s %s\n\n", name, src)),
+	}}
+	for _, o := range occurrences {
+		edits = append(edits, analysis.TextEdit{Pos: o.st.Pos(), End: o.st.End(), NewText: []byte(name)})
+	}
+	edits = uniqueEdits(fset, edits)
+	errs, err := checkEdits(ctx, snapshot, pkgs, edits)
+	if err != nil {
+		return nil, err
+	}
+	if len(errs) > 0 {
+		return nil, fmt.Errorf("cannot name struct, which would cause errors:\n%s", strings.Join(errs, "\n"))
+	}
+	return &analysis.SuggestedFix{TextEdits: edits}, nil
+}
+
+// variantStruct returns the type of the struct type at offset in the file
+// uri of pkg, or nil if pkg does not contain uri.
+func variantStruct(pkg Package, uri span.URI, offset int) *types.Struct {
+	pgf, err := pkg.File(uri)
+	if err != nil {
+		return nil
+	}
+	pos := pgf.Tok.Pos(offset)
+	path, _ := astutil.PathEnclosingInterval(pgf.File, pos, pos)
+	for _, n := range path {
+		if st, ok := n.(*ast.StructType); ok && st.Pos() == pos {
+			typ, _ := pkg.GetTypesInfo().TypeOf(st).(*types.Struct)
+			return typ
+		}
+	}
+	return nil
+}
+
+// identicalStruct reports whether t is the struct type typ.
+func identicalStruct(t types.Type, typ *types.Struct) bool {
+	s, ok := t.(*types.Struct)
+	return ok && types.Identical(s, typ)
+}
+
+// structName returns the prefix of the name of the struct type that path
+// starts with: the name of the variable or field declared with it, or of
+// elements of it, followed by "Type".
+func structName(path []ast.Node) string {
+	child := path[0]
+	for _, n := range path[1:] {
+		var id *ast.Ident
+		switch n := n.(type) {
+		case *ast.ArrayType, *ast.StarExpr, *ast.ChanType, *ast.Ellipsis, *ast.ParenExpr, *ast.UnaryExpr:
+			child = n
+			continue
+		case *ast.MapType:
+			if n.Value == child {
+				child = n
+				continue
+			}
+		case *ast.CompositeLit:
+			if n.Type == child {
+				child = n
+				continue
+			}
+		case *ast.Field:
+			if len(n.Names) == 1 {
+				id = n.Names[0]
+			}
+		case *ast.ValueSpec:
+			if len(n.Names) == 1 {
+				id = n.Names[0]
+			}
+		case *ast.AssignStmt:
+			if len(n.Lhs) == 1 && len(n.Rhs) == 1 && n.Rhs[0] == child {
+				id, _ = n.Lhs[0].(*ast.Ident)
+			}
+		case *ast.KeyValueExpr:
+			if n.Value == child {
+				id, _ = n.Key.(*ast.Ident)
+			}
+		}
+		if id != nil && id.Name != "_" {
+			return id.Name + "Type"
+		}
+		break
+	}
+	return "anonStruct"
+}
+
+// nodeDoc returns the doc comment of the declaration decl, or nil.
+func nodeDoc(decl ast.Node) *ast.CommentGroup {
+	switch decl := decl.(type) {
+	case *ast.FuncDecl:
+		return decl.Doc
+	case *ast.GenDecl:
+		return decl.Doc
+	}
+	return nil
+}
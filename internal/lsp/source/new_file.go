@@ -0,0 +1,187 @@
+// Copyright 2022 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package source
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"go/token"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"text/template"
+	"time"
+	"unicode"
+
+	"github.com/iansmith/golang-x-tools/internal/event"
+	"github.com/iansmith/golang-x-tools/internal/lsp/protocol"
+	"github.com/iansmith/golang-x-tools/internal/span"
+)
+
+// newFileData is the data of the newFileTemplate setting.
+type newFileData struct {
+	Year    int    // the current year
+	Package string // the name of the package of the file
+	File    string // the base name of the file
+}
+
+// parseNewFileTemplate parses the newFileTemplate setting.
+func parseNewFileTemplate(text string) (*template.Template, error) {
+	tmpl, err := template.New("newFileTemplate").Parse(text)
+	if err != nil {
+		return nil, err
+	}
+	if err := tmpl.Execute(ioutil.Discard, newFileData{Year: 2022, Package: "p", File: "p.go"}); err != nil {
+		return nil, err
+	}
+	return tmpl, nil
+}
+
+// NewFileEdits returns the edits that fill the empty Go file uri: the
+// header of the newFileTemplate setting, followed by the package clause of
+// the other files of its directory. A test file also imports "testing" and
+// declares a test named after the file. It returns no edits if the file
+// isn't empty.
+func NewFileEdits(ctx context.Context, snapshot Snapshot, uri span.URI) ([]protocol.TextEdit, error) {
+	ctx, done := event.Start(ctx, "source.NewFileEdits")
+	defer done()
+
+	fh, err := snapshot.GetFile(ctx, uri)
+	if err != nil {
+		return nil, err
+	}
+	// The file doesn't exist yet if it is about to be created.
+	if src, err := fh.Read(); err == nil && len(bytes.TrimSpace(src)) > 0 {
+		return nil, nil
+	}
+	filename := uri.Filename()
+	isTest := strings.HasSuffix(filename, "_test.go")
+	pkgName, testPkgName := dirPackageNames(ctx, snapshot, filename)
+	if isTest {
+		pkgName = testPkgName
+	}
+
+	var buf bytes.Buffer
+	if text := snapshot.View().Options().NewFileTemplate; text != "" {
+		// The template was validated when the option was set.
+		tmpl, err := parseNewFileTemplate(text)
+		if err != nil {
+			return nil, err
+		}
+		if err := tmpl.Execute(&buf, newFileData{
+			Year:    time.Now().Year(),
+			Package: pkgName,
+			File:    filepath.Base(filename),
+		}); err != nil {
+			return nil, err
+		}
+		if buf.Len() > 0 {
+			if !bytes.HasSuffix(buf.Bytes(), []byte("\n")) {
+				buf.WriteByte('\n')
+			}
+			// Keep the header apart from the package documentation.
+			buf.WriteByte('\n')
+		}
+	}
+	fmt.Fprintf(&buf, "package %s\n", pkgName)
+	// TestMain has another signature, which is left to the user.
+	if name := testFuncName(filename); isTest && name != "TestMain" {
+		fmt.Fprintf(&buf, "\nimport \"testing\"\n\nfunc %s(t *testing.T) {\n}\n", name)
+	}
+	return []protocol.TextEdit{{NewText: buf.String()}}, nil
+}
+
+// dirPackageNames returns the names of the packages of the files, and of
+// the test files, in the directory of filename, other than filename. They
+// are the most common names of the package clauses of these files, or are
+// derived from the name of the directory if it has no such files.
+func dirPackageNames(ctx context.Context, snapshot Snapshot, filename string) (pkgName, testPkgName string) {
+	dir := filepath.Dir(filename)
+	counts := make(map[string]int)
+	testCounts := make(map[string]int)
+	for _, path := range goFiles(dir) {
+		if path == filename {
+			continue
+		}
+		fh, err := snapshot.GetFile(ctx, span.URIFromPath(path))
+		if err != nil {
+			continue
+		}
+		pgf, err := snapshot.ParseGo(ctx, fh, ParseHeader)
+		if err != nil || pgf.File.Name == nil || pgf.File.Name.Name == "_" {
+			continue
+		}
+		if strings.HasSuffix(path, "_test.go") {
+			testCounts[pgf.File.Name.Name]++
+		} else {
+			counts[pgf.File.Name.Name]++
+		}
+	}
+	pkgName = mostCommon(counts)
+	testPkgName = mostCommon(testCounts)
+	if pkgName == "" {
+		pkgName = strings.TrimSuffix(testPkgName, "_test")
+	}
+	if pkgName == "" {
+		pkgName = dirPackageName(dir)
+	}
+	if testPkgName == "" {
+		testPkgName = pkgName
+	}
+	return pkgName, testPkgName
+}
+
+// mostCommon returns the key with the largest count, the first in the
+// order of names for equal counts, or "" if counts is empty.
+func mostCommon(counts map[string]int) string {
+	best := ""
+	for name, n := range counts {
+		if best == "" || n > counts[best] || n == counts[best] && name < best {
+			best = name
+		}
+	}
+	return best
+}
+
+// dirPackageName returns the package name that the name of dir suggests:
+// its letters, lower-cased, and digits. It returns "main" if there are
+// none.
+func dirPackageName(dir string) string {
+	var buf strings.Builder
+	for _, r := range filepath.Base(dir) {
+		switch {
+		case 'a' <= r && r <= 'z' || 'A' <= r && r <= 'Z':
+			buf.WriteRune(unicode.ToLower(r))
+		case '0' <= r && r <= '9' && buf.Len() > 0:
+			buf.WriteRune(r)
+		}
+	}
+	if name := buf.String(); name != "" && !token.IsKeyword(name) {
+		return name
+	}
+	return "main"
+}
+
+// testFuncName returns the name of the test of the test file filename,
+// such as TestFooBar for foo_bar_test.go.
+func testFuncName(filename string) string {
+	base := strings.TrimSuffix(filepath.Base(filename), "_test.go")
+	var buf strings.Builder
+	buf.WriteString("Test")
+	upper := true
+	for _, r := range base {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			upper = true
+			continue
+		}
+		if upper {
+			r = unicode.ToUpper(r)
+			upper = false
+		}
+		buf.WriteRune(r)
+	}
+	return buf.String()
+}
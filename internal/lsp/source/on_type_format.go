@@ -0,0 +1,198 @@
+// Copyright 2022 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package source
+
+import (
+	"bytes"
+	"context"
+	"go/ast"
+	"go/parser"
+	"go/printer"
+	"go/scanner"
+	"go/token"
+	"strings"
+
+	"github.com/iansmith/golang-x-tools/go/ast/astutil"
+	"github.com/iansmith/golang-x-tools/internal/event"
+	"github.com/iansmith/golang-x-tools/internal/lsp/protocol"
+	"github.com/iansmith/golang-x-tools/internal/lsp/safetoken"
+)
+
+// OnTypeFormat returns the edits to apply to fh after the user typed ch,
+// the text just before position.
+//
+// Typing the "}" closing a composite literal, or a newline within one,
+// reindents the literal and aligns the values of its keyed elements as
+// gofmt would; the line of the cursor is left alone after a newline.
+// Typing a "{" that ends its line and opens a block whose closing brace
+// is missing inserts that brace on the next line.
+func OnTypeFormat(ctx context.Context, snapshot Snapshot, fh FileHandle, position protocol.Position, ch string) ([]protocol.TextEdit, error) {
+	ctx, done := event.Start(ctx, "source.OnTypeFormat")
+	defer done()
+
+	// Generated files shouldn't be edited.
+	if IsGenerated(ctx, snapshot, fh.URI()) {
+		return nil, nil
+	}
+	pgf, err := snapshot.ParseGo(ctx, fh, ParseFull)
+	if err != nil {
+		return nil, err
+	}
+	pos, err := pgf.Mapper.Pos(position)
+	if err != nil {
+		return nil, err
+	}
+	offset, err := safetoken.Offset(pgf.Tok, pos)
+	if err != nil {
+		return nil, err
+	}
+	switch ch {
+	case "{":
+		return closeBlock(snapshot, pgf, offset)
+	case "}", "\n":
+		return formatCompositeLit(snapshot, pgf, position, offset, ch == "\n")
+	}
+	return nil, nil
+}
+
+// closeBlock returns the edit inserting the "}" that closes the block
+// opened by the "{" before offset, if that brace ends its line and the
+// braces of the file are unbalanced. The "}" goes on a line of its own,
+// indented as the line of the "{".
+func closeBlock(snapshot Snapshot, pgf *ParsedGoFile, offset int) ([]protocol.TextEdit, error) {
+	src := pgf.Src
+	if offset == 0 || src[offset-1] != '{' {
+		return nil, nil
+	}
+	lineEnd := len(src)
+	if i := bytes.IndexByte(src[offset:], '\n'); i >= 0 {
+		lineEnd = offset + i
+	}
+	if len(bytes.TrimSpace(src[offset:lineEnd])) > 0 || braceDepth(src) <= 0 {
+		return nil, nil
+	}
+	line := src[bytes.LastIndexByte(src[:offset], '\n')+1 : offset]
+	insert := "\n" + string(line[:len(line)-len(bytes.TrimLeft(line, " \t"))]) + "}"
+
+	// Only close blocks, not composite literals, types or other
+	// expressions: check that, with the edit, the braces delimit a block.
+	fixed := make([]byte, 0, len(src)+len(insert))
+	fixed = append(fixed, src[:offset]...)
+	fixed = append(fixed, insert...)
+	fixed = append(fixed, src[offset:]...)
+	fset := token.NewFileSet()
+	file, _ := parser.ParseFile(fset, "", fixed, parser.AllErrors)
+	if file == nil {
+		return nil, nil
+	}
+	block := false
+	ast.Inspect(file, func(n ast.Node) bool {
+		if b, ok := n.(*ast.BlockStmt); ok && b.Rbrace.IsValid() &&
+			fset.Position(b.Lbrace).Offset == offset-1 &&
+			fset.Position(b.Rbrace).Offset == offset+len(insert)-1 {
+			block = true
+		}
+		return !block
+	})
+	if !block {
+		return nil, nil
+	}
+	rng, err := NewMappedRange(snapshot.FileSet(), pgf.Mapper, pgf.Tok.Pos(offset), pgf.Tok.Pos(offset)).Range()
+	if err != nil {
+		return nil, err
+	}
+	return []protocol.TextEdit{{Range: rng, NewText: insert}}, nil
+}
+
+// braceDepth returns the number of the "{" of src, outside of comments
+// and literals, that no "}" closes.
+func braceDepth(src []byte) int {
+	var s scanner.Scanner
+	s.Init(token.NewFileSet().AddFile("", -1, len(src)), src, nil, 0)
+	depth := 0
+	for {
+		_, tok, _ := s.Scan()
+		switch tok {
+		case token.EOF:
+			return depth
+		case token.LBRACE:
+			depth++
+		case token.RBRACE:
+			depth--
+		}
+	}
+}
+
+// formatCompositeLit returns the edits formatting the composite literal
+// closed by the "}" before offset or, if newline is set, the innermost one
+// containing offset, without those of the line of position. The literal
+// is indented by the tabs that start its first line.
+func formatCompositeLit(snapshot Snapshot, pgf *ParsedGoFile, position protocol.Position, offset int, newline bool) ([]protocol.TextEdit, error) {
+	// Printing an AST with errors may modify the code.
+	if pgf.ParseErr != nil || offset == 0 {
+		return nil, nil
+	}
+	pos := pgf.Tok.Pos(offset)
+	if !newline {
+		pos--
+	}
+	path, _ := astutil.PathEnclosingInterval(pgf.File, pos, pos)
+	var lit *ast.CompositeLit
+	for _, n := range path {
+		if l, ok := n.(*ast.CompositeLit); ok && (newline && l.Lbrace < pos && pos <= l.Rbrace || !newline && l.Rbrace == pos) {
+			lit = l
+			break
+		}
+	}
+	if lit == nil {
+		return nil, nil
+	}
+	start, err := safetoken.Offset(pgf.Tok, lit.Pos())
+	if err != nil {
+		return nil, err
+	}
+	end, err := safetoken.Offset(pgf.Tok, lit.End())
+	if err != nil {
+		return nil, err
+	}
+	line := pgf.Src[bytes.LastIndexByte(pgf.Src[:start], '\n')+1 : start]
+	depth := len(line) - len(bytes.TrimLeft(line, "\t"))
+
+	var comments []*ast.CommentGroup
+	for _, c := range pgf.File.Comments {
+		if lit.Pos() <= c.Pos() && c.End() <= lit.End() {
+			comments = append(comments, c)
+		}
+	}
+	// Use the configuration of go/format.
+	cfg := printer.Config{Mode: printer.UseSpaces | printer.TabIndent, Tabwidth: 8, Indent: depth}
+	var buf bytes.Buffer
+	if err := cfg.Fprint(&buf, snapshot.FileSet(), &printer.CommentedNode{Node: lit, Comments: comments}); err != nil {
+		return nil, err
+	}
+	formatted := strings.TrimPrefix(buf.String(), strings.Repeat("\t", depth))
+
+	src := string(pgf.Src)
+	diffs, err := snapshot.View().Options().ComputeEdits(pgf.URI, src, src[:start]+formatted+src[end:])
+	if err != nil {
+		return nil, err
+	}
+	edits, err := ToProtocolEdits(pgf.Mapper, diffs)
+	if err != nil {
+		return nil, err
+	}
+	if !newline {
+		return edits, nil
+	}
+	// Leave the new line, and the indentation the client gave it, alone.
+	var result []protocol.TextEdit
+	lineStart := protocol.Position{Line: position.Line}
+	for _, edit := range edits {
+		if protocol.ComparePosition(edit.Range.End, lineStart) <= 0 {
+			result = append(result, edit)
+		}
+	}
+	return result, nil
+}
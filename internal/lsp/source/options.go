@@ -11,6 +11,7 @@ import (
 	"path/filepath"
 	"regexp"
 	"runtime"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
@@ -49,6 +50,7 @@ import (
 	"github.com/iansmith/golang-x-tools/go/analysis/passes/unusedresult"
 	"github.com/iansmith/golang-x-tools/go/analysis/passes/unusedwrite"
 	"github.com/iansmith/golang-x-tools/go/packages"
+	"github.com/iansmith/golang-x-tools/internal/lsp/analysis/commentstyle"
 	"github.com/iansmith/golang-x-tools/internal/lsp/analysis/embeddirective"
 	"github.com/iansmith/golang-x-tools/internal/lsp/analysis/fillreturns"
 	"github.com/iansmith/golang-x-tools/internal/lsp/analysis/fillstruct"
@@ -60,6 +62,7 @@ import (
 	"github.com/iansmith/golang-x-tools/internal/lsp/analysis/simplifyslice"
 	"github.com/iansmith/golang-x-tools/internal/lsp/analysis/stubmethods"
 	"github.com/iansmith/golang-x-tools/internal/lsp/analysis/undeclaredname"
+	"github.com/iansmith/golang-x-tools/internal/lsp/analysis/unknownfield"
 	"github.com/iansmith/golang-x-tools/internal/lsp/analysis/unusedparams"
 	"github.com/iansmith/golang-x-tools/internal/lsp/analysis/useany"
 	"github.com/iansmith/golang-x-tools/internal/lsp/command"
@@ -101,12 +104,19 @@ func DefaultOptions() *Options {
 						protocol.QuickFix:              true,
 						protocol.RefactorRewrite:       true,
 						protocol.RefactorExtract:       true,
+						protocol.RefactorInline:        true,
+						protocol.SourceGenerate:        true,
+						protocol.SourceGenerateTest:    true,
+						protocol.Explain:               true,
+						protocol.GoGenerated:           true,
 					},
 					Mod: {
 						protocol.SourceOrganizeImports: true,
 						protocol.QuickFix:              true,
 					},
-					Work: {},
+					Work: {
+						protocol.QuickFix: true,
+					},
 					Sum:  {},
 					Tmpl: {},
 				},
@@ -120,9 +130,16 @@ func DefaultOptions() *Options {
 					DirectoryFilters:            []string{"-node_modules"},
 					TemplateExtensions:          []string{},
 				},
+				FormattingOptions: FormattingOptions{
+					ErrorWrapTemplate: "{{.Func}}: %w",
+					StructTags: map[string]string{
+						"json": SnakeCase,
+					},
+				},
 				UIOptions: UIOptions{
 					DiagnosticOptions: DiagnosticOptions{
 						DiagnosticsDelay: 250 * time.Millisecond,
+						Vulncheck:        ModeVulncheckOff,
 						Annotations: map[Annotation]bool{
 							Bounds: true,
 							Escape: true,
@@ -136,9 +153,10 @@ func DefaultOptions() *Options {
 						LinksInHover: true,
 					},
 					NavigationOptions: NavigationOptions{
-						ImportShortcut: Both,
-						SymbolMatcher:  SymbolFastFuzzy,
-						SymbolStyle:    DynamicSymbols,
+						ImportShortcut:        Both,
+						SymbolMatcher:         SymbolFastFuzzy,
+						SymbolStyle:           DynamicSymbols,
+						IncludeTestReferences: true,
 					},
 					CompletionOptions: CompletionOptions{
 						Matcher:                        Fuzzy,
@@ -152,6 +170,7 @@ func DefaultOptions() *Options {
 						string(command.GCDetails):         false,
 						string(command.UpgradeDependency): true,
 						string(command.Vendor):            true,
+						string(command.WorkSync):          true,
 					},
 				},
 			},
@@ -194,6 +213,11 @@ type ClientOptions struct {
 	DynamicConfigurationSupported              bool
 	DynamicRegistrationSemanticTokensSupported bool
 	DynamicWatchedFilesSupported               bool
+	DynamicWillRenameFilesSupported            bool
+	DynamicWillCreateFilesSupported            bool
+	ShowDocumentSupported                      bool
+	RenameFileSupported                        bool
+	ChangeAnnotationsSupported                 bool
 	PreferredContentFormat                     protocol.MarkupKind
 	LineFoldingOnly                            bool
 	HierarchicalDocumentSymbolSupport          bool
@@ -220,22 +244,46 @@ type BuildOptions struct {
 	// Env adds environment variables to external commands run by `gopls`, most notably `go list`.
 	Env map[string]string
 
+	// FolderEnv adds environment variables to those of env for some
+	// workspace folders. Its keys are the names or the absolute paths of
+	// workspace folders, and its values are the variables to add in these
+	// folders. Each folder has its own build configuration, so a front end
+	// built for WebAssembly and a back end built for Linux can be edited
+	// side by side.
+	//
+	// Example: `{"web": {"GOOS": "js", "GOARCH": "wasm"}}`
+	FolderEnv map[string]map[string]string `status:"experimental"`
+
 	// DirectoryFilters can be used to exclude unwanted directories from the
 	// workspace. By default, all directories are included. Filters are an
 	// operator, `+` to include and `-` to exclude, followed by a path prefix
 	// relative to the workspace folder. They are evaluated in order, and
 	// the last filter that applies to a path controls whether it is included.
 	// The path prefix can be empty, so an initial `-` excludes everything.
+	// A path segment of `**` matches any number of path segments, including
+	// none.
+	//
+	// Excluded directories are neither loaded nor watched.
 	//
 	// Examples:
 	//
 	// Exclude node_modules: `-node_modules`
 	//
+	// Exclude node_modules at any depth: `-**/node_modules`
+	//
 	// Include only project_a: `-` (exclude everything), `+project_a`
 	//
 	// Include only project_a, but not node_modules inside it: `-`, `+project_a`, `-project_a/node_modules`
 	DirectoryFilters []string
 
+	// FolderDirectoryFilters overrides directoryFilters for some workspace
+	// folders. Its keys are the names or the absolute paths of workspace
+	// folders, and its values are the directory filters to use in these
+	// folders instead of directoryFilters.
+	//
+	// Example: `{"/home/me/web": ["-**/node_modules", "-dist"]}`
+	FolderDirectoryFilters map[string][]string `status:"experimental"`
+
 	// TemplateExtensions gives the extensions of file names that are treateed
 	// as template files. (The extension
 	// is the part of the file name after the final dot.)
@@ -247,6 +295,26 @@ type BuildOptions struct {
 	// Values other than `Normal` are untested and may break in surprising ways.
 	MemoryMode MemoryMode `status:"experimental"`
 
+	// MemoryBudget is the amount of memory, such as "4GiB", that `gopls` may
+	// use before switching to a degraded mode rather than risking being
+	// killed for running out of memory. In degraded mode, `gopls` behaves as
+	// in the `DegradeClosed` memory mode, skips analyses that need the whole
+	// workspace, and disables deep completion. The client is notified when
+	// this happens.
+	//
+	// The default, "", means no budget.
+	MemoryBudget string `status:"experimental"`
+
+	// LazyTypeCheck makes `gopls` load the metadata of the whole workspace,
+	// loading its modules in parallel, but type-check and diagnose only the
+	// packages of the open files and their reverse dependencies, and those of
+	// the directories most recently opened in the workspace, including by
+	// previous sessions. The other packages are type-checked when a request,
+	// such as Find References, needs them. This cuts the time to the first
+	// diagnostics in large workspaces, at the cost of the diagnostics of the
+	// packages that are not type-checked.
+	LazyTypeCheck bool `status:"experimental"`
+
 	// ExpandWorkspaceToModule instructs `gopls` to adjust the scope of the
 	// workspace to find the best available module root. `gopls` first looks for
 	// a go.mod file in any parent directory of the workspace folder, expanding
@@ -289,6 +357,7 @@ type UIOptions struct {
 	CompletionOptions
 	NavigationOptions
 	DiagnosticOptions
+	InlayHintOptions
 
 	// Codelenses overrides the enabled/disabled state of code lenses. See the
 	// "Code Lenses" section of the
@@ -312,6 +381,24 @@ type UIOptions struct {
 	// SemanticTokens controls whether the LSP server will send
 	// semantic tokens to the client.
 	SemanticTokens bool `status:"experimental"`
+
+	// ConfirmLargeEdits controls whether renamings and other refactorings
+	// that edit more than one file, or rename files, ask the user to
+	// confirm their edits, in clients that support change annotations, so
+	// that the client can show a preview of them.
+	ConfirmLargeEdits bool `status:"experimental"`
+
+	// LargeFileThreshold is the size, in bytes, beyond which a Go file is
+	// too large for gopls to compute its semantic tokens, or to analyze its
+	// package, without keeping the editor waiting. Such files are still
+	// parsed, type-checked, and formatted. 0 disables the limit.
+	LargeFileThreshold int `status:"experimental"`
+
+	// RefactorGeneratedFiles allows renamings and other refactorings to edit
+	// the generated files of the workspace. By default, they fail rather than
+	// edit them, since the edits would be lost the next time the files are
+	// generated.
+	RefactorGeneratedFiles bool `status:"experimental"`
 }
 
 type CompletionOptions struct {
@@ -333,6 +420,24 @@ type CompletionOptions struct {
 	// ExperimentalPostfixCompletions enables artificial method snippets
 	// such as "someSlice.sort!".
 	ExperimentalPostfixCompletions bool `status:"experimental"`
+
+	// ExperimentalUnimportedSymbolsBudget enables the completion of the
+	// exported symbols of unimported packages, such as "rand.Seed" for "Seed",
+	// adding the import of their package. The packages of the module cache
+	// and GOPATH are searched for at most this duration; zero disables it.
+	// It requires completeUnimported.
+	ExperimentalUnimportedSymbolsBudget time.Duration `status:"experimental"`
+
+	// ExperimentalCompletionRanking ranks completion candidates also by how
+	// often the workspace uses their symbols, and by the completions the user
+	// accepted recently, which are remembered in the gopls cache directory.
+	// Accepts are reported by the command of each completion item.
+	ExperimentalCompletionRanking bool `status:"experimental"`
+
+	// ExperimentalResultAssignments completes the calls of functions with
+	// results as statements that assign the results to new variables named
+	// after them, such as "f, err := os.Open(name)". It requires snippets.
+	ExperimentalResultAssignments bool `status:"experimental"`
 }
 
 type DocumentationOptions struct {
@@ -349,8 +454,18 @@ type DocumentationOptions struct {
 	// If company chooses to use its own `godoc.org`, its address can be used as well.
 	LinkTarget string
 
+	// PrivateLinkTarget controls where documentation links go for the
+	// packages and modules matching GOPRIVATE, such as the address of an
+	// internal documentation server. If empty, there are no links to them.
+	PrivateLinkTarget string
+
 	// LinksInHover toggles the presence of links to documentation in hover.
 	LinksInHover bool
+
+	// VerboseHover adds the memory layout of struct types and fields to
+	// hover: the size and alignment of types, and the offsets of fields and
+	// the padding between them, as computed for the current GOARCH.
+	VerboseHover bool
 }
 
 type FormattingOptions struct {
@@ -360,8 +475,62 @@ type FormattingOptions struct {
 	// separately.
 	Local string
 
+	// ImportGroups is the ordered list of the groups into which imports are
+	// sorted, overriding the default grouping and `local`. Each group is
+	// "std", for the standard library, "third-party", for the packages in no
+	// other group, "module", for the packages of the workspace modules, or a
+	// comma-separated list of import path prefixes. Prefix groups take
+	// precedence over the others, so `["std", "third-party", "module"]` is
+	// the equivalent of `goimports -local` with the module path.
+	ImportGroups []string
+
 	// Gofumpt indicates if we should run gofumpt formatting.
 	Gofumpt bool
+
+	// GofumptExtraRules enables the extra rules of gofumpt, such as the
+	// grouping of adjacent parameters of the same type, when it formats
+	// files.
+	GofumptExtraRules bool
+
+	// Formatters is the ordered list of the formatters that `gopls` applies
+	// to Go files after gofmt when it formats them. Each formatter is
+	// "gofumpt", "imports", which sorts imports into the groups of
+	// `importGroups` or `local` after joining their existing groups, as gci
+	// does, or "exec:" followed by a command that reads the source on its
+	// standard input and writes it formatted on its standard output. The
+	// `gofumpt` setting runs gofumpt first, unless it is listed. The output
+	// of each formatter must parse.
+	Formatters []string
+
+	// ErrorWrapTemplate is the template of the message of the errors wrapped
+	// with `fmt.Errorf` by the "Wrap error" code action and the
+	// `gopls.wrap_errors` command. It is a Go template, whose `.Func` and
+	// `.Package` are the names of the function returning the error and of
+	// its package, and whose output must contain `%w` once.
+	ErrorWrapTemplate string
+
+	// NewFileTemplate is the template of the header, such as a license
+	// comment, of the new Go files that `gopls` fills with a package clause
+	// when they are created, or with the "Add package clause" code action on
+	// an empty file. It is a Go template, whose `.Year`, `.Package` and
+	// `.File` are the current year, the name of the package of the file and
+	// the base name of the file.
+	NewFileTemplate string
+
+	// StructTags maps the encodings, such as "json" or "yaml", whose tags
+	// the code actions on struct types offer to add, update and remove, to
+	// the naming convention of the keys of their tags, "snake_case" or
+	// "camelCase".
+	//
+	// Example: `{"json": "camelCase", "yaml": "snake_case"}`
+	StructTags map[string]string
+}
+
+type InlayHintOptions struct {
+	// Hints specify inlay hints that users want to see.
+	// A full list of hints that gopls uses can be found
+	// [here](https://github.com/golang/tools/blob/master/gopls/doc/inlayHints.md).
+	Hints map[string]bool `status:"experimental"`
 }
 
 type DiagnosticOptions struct {
@@ -385,6 +554,69 @@ type DiagnosticOptions struct {
 	// Staticcheck enables additional analyses from staticcheck.io.
 	Staticcheck bool `status:"experimental"`
 
+	// StaticcheckConfig configures the staticcheck analyzers as a
+	// staticcheck.conf file does for the staticcheck command. Its keys are
+	// "checks", "initialisms", "dot_import_whitelist" and
+	// "http_status_code_whitelist", and their values are lists that replace
+	// those of the staticcheck.conf files, or extend them if they contain
+	// "inherit". The "checks" list selects the checks that gopls runs, as in
+	// `["inherit", "ST1003", "-SA1*"]`, where "inherit" stands for the
+	// checks enabled by default; the analyses setting still takes precedence
+	// for the checks it names.
+	//
+	// Example: `{"checks": ["inherit", "ST1003"], "initialisms": ["inherit", "GRPC"]}`
+	StaticcheckConfig map[string][]string `status:"experimental"`
+
+	// ExternalAnalyzers lists the paths of analyzer executables that
+	// follow the protocol of `go vet -vettool`, such as the programs built
+	// with the go/analysis/unitchecker package. Each is run by `go vet
+	// -json` on the packages of the open files, once their changes are
+	// saved, and its findings are reported as diagnostics from the
+	// analyzers it names. As the output of `go vet -json` holds no
+	// suggested fixes, none are offered.
+	ExternalAnalyzers []string `status:"experimental"`
+
+	// UnusedSymbols enables hints for the functions, methods, types, and
+	// struct fields that are not referenced anywhere in the workspace.
+	// Symbols that may be used through reflection, assembly, cgo, or a
+	// go:linkname directive are not reported.
+	UnusedSymbols bool `status:"experimental"`
+
+	// DuplicateCodeTokens enables hints for the duplicated code of the
+	// workspace: the sequences of at least that many tokens, ignoring
+	// comments and layout, that occur more than once in its Go files, with
+	// a code action listing the other occurrences. 0 disables them.
+	DuplicateCodeTokens int `status:"experimental"`
+
+	// Vulncheck enables vulnerability diagnostics. With "Imports", the
+	// imports of packages that have known vulnerabilities, and the
+	// requirements of the modules that provide them, are reported as the
+	// workspace changes, with quick fixes that upgrade the modules to a
+	// fixed version. The vulnerability databases are configured by the
+	// GOVULNDB, GOPLSVULNCACHETTL, and GOPLSVULNOFFLINE variables of "env",
+	// as for `gopls vulncheck`.
+	Vulncheck VulncheckMode `status:"experimental"`
+
+	// DiagnosticPlatforms lists other platforms, as GOOS/GOARCH pairs such
+	// as "windows/arm64", for which the packages of open files are also
+	// type-checked. Errors that occur only on these platforms are reported
+	// along with the others, annotated with the platforms they occur on.
+	DiagnosticPlatforms []string `status:"experimental"`
+
+	// DiagnosticSeverities overrides the severities of the diagnostics of
+	// some analyzers or other sources, such as "compiler" or "go list". Its
+	// keys are the names of the analyzers or sources, and its values are
+	// "error", "warning", "information", or "hint".
+	//
+	// Example: `{"unusedparams": "hint", "printf": "error"}`
+	DiagnosticSeverities map[string]string `status:"experimental"`
+
+	// BatchListErrors merges the go list errors of a file that have the
+	// same message, as reported for all the packages that a broken module
+	// or import affects, into a single diagnostic that lists the other
+	// locations as related information.
+	BatchListErrors bool `status:"experimental"`
+
 	// Annotations specifies the various kinds of optimization diagnostics
 	// that should be reported by the gc_details command.
 	Annotations map[Annotation]bool `status:"experimental"`
@@ -397,6 +629,13 @@ type DiagnosticOptions struct {
 	// This option must be set to a valid duration string, for example `"250ms"`.
 	DiagnosticsDelay time.Duration `status:"advanced"`
 
+	// DiagnosticsDelayOnSave replaces diagnosticsDelay after a file save,
+	// for example to compute deep diagnostics sooner after saves than
+	// after edits. Zero, the default, uses diagnosticsDelay.
+	//
+	// This option must be set to a valid duration string, for example `"50ms"`.
+	DiagnosticsDelayOnSave time.Duration `status:"experimental"`
+
 	// ExperimentalWatchedFileDelay controls the amount of time that gopls waits
 	// for additional workspace/didChangeWatchedFiles notifications to arrive,
 	// before processing all such notifications in a single batch. This is
@@ -427,6 +666,24 @@ type NavigationOptions struct {
 	// }
 	// ```
 	SymbolStyle SymbolStyle `status:"advanced"`
+
+	// IncludeTestReferences controls whether the references found in
+	// _test.go files are included in the results of find-references and
+	// of incoming calls. Rename always updates them.
+	IncludeTestReferences bool `status:"experimental"`
+
+	// ControlFlowHighlights controls whether highlighting a return or the
+	// func keyword also highlights the other exit points of the function,
+	// found in its control-flow graph: the calls of functions that never
+	// return, such as panic, and the closing brace if it is reachable.
+	// Highlighting a break or continue also highlights the exit points
+	// within its loop or switch.
+	ControlFlowHighlights bool `status:"experimental"`
+
+	// ErrorFlowHighlights controls whether highlighting a variable of type
+	// error also highlights the expressions that assign it and the return
+	// statements that propagate it.
+	ErrorFlowHighlights bool `status:"experimental"`
 }
 
 // UserOptions holds custom Gopls configuration (not part of the LSP) that is
@@ -436,6 +693,13 @@ type UserOptions struct {
 	UIOptions
 	FormattingOptions
 
+	// Telemetry enables the recording of anonymous counters of the requests
+	// that `gopls` serves, and of signatures of the panics it recovers from,
+	// in weekly reports, which are only uploaded to the URL of
+	// $GOPLS_TELEMETRY_URL. The reports hold no file names, identifiers or
+	// source code; `gopls telemetry view` prints them.
+	Telemetry bool `status:"experimental"`
+
 	// VerboseOutput enables additional debug logging.
 	VerboseOutput bool `status:"debug"`
 }
@@ -483,17 +747,28 @@ type Hooks struct {
 	URLRegexp *regexp.Regexp
 
 	// GofumptFormat allows the gopls module to wire-in a call to
-	// gofumpt/format.Source. langVersion and modulePath are used for some
-	// Gofumpt formatting rules -- see the Gofumpt documentation for details.
-	GofumptFormat func(ctx context.Context, langVersion, modulePath string, src []byte) ([]byte, error)
+	// gofumpt/format.Source. The options are used for some Gofumpt
+	// formatting rules -- see the Gofumpt documentation for details.
+	GofumptFormat func(ctx context.Context, opts GofumptOptions, src []byte) ([]byte, error)
 
 	DefaultAnalyzers     map[string]*Analyzer
 	TypeErrorAnalyzers   map[string]*Analyzer
 	ConvenienceAnalyzers map[string]*Analyzer
 	StaticcheckAnalyzers map[string]*Analyzer
 
+	// StaticcheckConfigAnalyzer is the analyzer whose result is the
+	// configuration of the staticcheck analyzers, and StaticcheckConfigure
+	// returns that result updated with the StaticcheckConfig setting.
+	StaticcheckConfigAnalyzer *analysis.Analyzer
+	StaticcheckConfigure      func(result interface{}, config map[string][]string) interface{}
+
 	// Govulncheck is the implementation of the Govulncheck gopls command.
 	Govulncheck func(context.Context, *packages.Config, command.VulncheckArgs) (command.VulncheckResult, error)
+
+	// ModuleVulns returns the known vulnerabilities of the packages of the
+	// module modPath at version, from the vulnerability database that env
+	// configures. It is the source of the vulnerability diagnostics.
+	ModuleVulns func(ctx context.Context, env []string, modPath, version string) ([]command.Vuln, error)
 }
 
 // InternalOptions contains settings that are not intended for use by the
@@ -629,6 +904,110 @@ const (
 	ModeDegradeClosed MemoryMode = "DegradeClosed"
 )
 
+type VulncheckMode string
+
+const (
+	// ModeVulncheckOff disables vulnerability diagnostics.
+	ModeVulncheckOff VulncheckMode = "Off"
+	// ModeVulncheckImports reports the imports of vulnerable packages, and
+	// the requirements of the modules that provide them, whether or not
+	// the vulnerable symbols are called.
+	ModeVulncheckImports VulncheckMode = "Imports"
+)
+
+// parseDirectoryFilters returns the valid directory filters of the JSON list
+// ifilters.
+func parseDirectoryFilters(ifilters []interface{}) ([]string, error) {
+	var filters []string
+	for _, ifilter := range ifilters {
+		filter := fmt.Sprint(ifilter)
+		if err := ValidateDirectoryFilter(filter); err != nil {
+			return nil, err
+		}
+		filters = append(filters, strings.TrimRight(filepath.FromSlash(filter), "/"))
+	}
+	return filters, nil
+}
+
+// ApplyFolderOverrides applies the per-folder options of the workspace folder
+// with the given name and path: FolderEnv is added to Env, and
+// FolderDirectoryFilters replaces DirectoryFilters. The options of folders
+// are looked up by path first, and then by name.
+func (o *Options) ApplyFolderOverrides(name, folder string) {
+	keys := []string{folder}
+	if name != "" {
+		keys = append(keys, name)
+	}
+	for _, key := range keys {
+		if env, ok := o.FolderEnv[key]; ok {
+			if o.Env == nil {
+				o.Env = make(map[string]string)
+			}
+			for k, v := range env {
+				o.Env[k] = v
+			}
+			break
+		}
+	}
+	for _, key := range keys {
+		if filters, ok := o.FolderDirectoryFilters[key]; ok {
+			o.DirectoryFilters = filters
+			break
+		}
+	}
+}
+
+// folderKey returns the key of the per-folder options of folder, which is
+// either the name or the absolute path of a workspace folder.
+func folderKey(folder string) string {
+	if filepath.IsAbs(folder) {
+		return filepath.Clean(folder)
+	}
+	return folder
+}
+
+// ParsePlatform returns the GOOS and GOARCH of a platform such as
+// "windows/arm64".
+func ParsePlatform(platform string) (goos, goarch string, err error) {
+	parts := strings.Split(platform, "/")
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return "", "", fmt.Errorf("invalid platform %q, must be GOOS/GOARCH", platform)
+	}
+	return parts[0], parts[1], nil
+}
+
+// ParseMemoryBudget returns the number of bytes of a memoryBudget such as
+// "512MB" or "4GiB", or 0 if it is empty.
+func ParseMemoryBudget(budget string) (uint64, error) {
+	if budget == "" {
+		return 0, nil
+	}
+	units := []struct {
+		suffix string
+		size   uint64
+	}{
+		// Longer suffixes first, as "B" is a suffix of the others.
+		{"KiB", 1 << 10}, {"MiB", 1 << 20}, {"GiB", 1 << 30}, {"TiB", 1 << 40},
+		{"KB", 1e3}, {"MB", 1e6}, {"GB", 1e9}, {"TB", 1e12},
+		{"B", 1},
+	}
+	number, size := budget, uint64(1)
+	for _, unit := range units {
+		if strings.HasSuffix(budget, unit.suffix) {
+			number, size = strings.TrimSpace(strings.TrimSuffix(budget, unit.suffix)), unit.size
+			break
+		}
+	}
+	n, err := strconv.ParseUint(number, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("expected a number of bytes with an optional unit such as MB or GiB")
+	}
+	if n == 0 {
+		return 0, fmt.Errorf("the budget must be positive")
+	}
+	return n * size, nil
+}
+
 type OptionResults []OptionResult
 
 type OptionResult struct {
@@ -689,6 +1068,19 @@ func (o *Options) ForClientCapabilities(caps protocol.ClientCapabilities) {
 	o.DynamicConfigurationSupported = caps.Workspace.DidChangeConfiguration.DynamicRegistration
 	o.DynamicRegistrationSemanticTokensSupported = caps.TextDocument.SemanticTokens.DynamicRegistration
 	o.DynamicWatchedFilesSupported = caps.Workspace.DidChangeWatchedFiles.DynamicRegistration
+	o.ShowDocumentSupported = caps.Window.ShowDocument.Support
+	if fo := caps.Workspace.FileOperations; fo != nil {
+		o.DynamicWillRenameFilesSupported = fo.DynamicRegistration && fo.WillRename
+		o.DynamicWillCreateFilesSupported = fo.DynamicRegistration && fo.WillCreate
+	}
+	if we := caps.Workspace.WorkspaceEdit; we != nil && we.DocumentChanges {
+		for _, kind := range we.ResourceOperations {
+			if kind == protocol.Rename {
+				o.RenameFileSupported = true
+			}
+		}
+		o.ChangeAnnotationsSupported = we.ChangeAnnotationSupport != nil
+	}
 
 	// Check which types of content format are supported by this client.
 	if hover := caps.TextDocument.Hover; len(hover.ContentFormat) > 0 {
@@ -726,6 +1118,10 @@ func (o *Options) Clone() *Options {
 			GofumptFormat:        o.GofumptFormat,
 			URLRegexp:            o.URLRegexp,
 			Govulncheck:          o.Govulncheck,
+			ModuleVulns:          o.ModuleVulns,
+
+			StaticcheckConfigAnalyzer: o.StaticcheckConfigAnalyzer,
+			StaticcheckConfigure:      o.StaticcheckConfigure,
 		},
 		ServerOptions: o.ServerOptions,
 		UserOptions:   o.UserOptions,
@@ -741,6 +1137,7 @@ func (o *Options) Clone() *Options {
 	}
 	result.Analyses = copyStringMap(o.Analyses)
 	result.Codelenses = copyStringMap(o.Codelenses)
+	result.Hints = copyStringMap(o.Hints)
 
 	copySlice := func(src []string) []string {
 		dst := make([]string, len(src))
@@ -750,6 +1147,44 @@ func (o *Options) Clone() *Options {
 	result.SetEnvSlice(o.EnvSlice())
 	result.BuildFlags = copySlice(o.BuildFlags)
 	result.DirectoryFilters = copySlice(o.DirectoryFilters)
+	result.DiagnosticPlatforms = copySlice(o.DiagnosticPlatforms)
+	result.ExternalAnalyzers = copySlice(o.ExternalAnalyzers)
+	if o.DiagnosticSeverities != nil {
+		result.DiagnosticSeverities = make(map[string]string)
+		for k, v := range o.DiagnosticSeverities {
+			result.DiagnosticSeverities[k] = v
+		}
+	}
+	if o.FolderEnv != nil {
+		result.FolderEnv = make(map[string]map[string]string)
+		for k, v := range o.FolderEnv {
+			env := make(map[string]string)
+			for k, v := range v {
+				env[k] = v
+			}
+			result.FolderEnv[k] = env
+		}
+	}
+	if o.StaticcheckConfig != nil {
+		result.StaticcheckConfig = make(map[string][]string)
+		for k, v := range o.StaticcheckConfig {
+			result.StaticcheckConfig[k] = copySlice(v)
+		}
+	}
+	if o.FolderDirectoryFilters != nil {
+		result.FolderDirectoryFilters = make(map[string][]string)
+		for k, v := range o.FolderDirectoryFilters {
+			result.FolderDirectoryFilters[k] = copySlice(v)
+		}
+	}
+	result.ImportGroups = copySlice(o.ImportGroups)
+	result.Formatters = copySlice(o.Formatters)
+	if o.StructTags != nil {
+		result.StructTags = make(map[string]string)
+		for k, v := range o.StructTags {
+			result.StructTags[k] = v
+		}
+	}
 
 	copyAnalyzerMap := func(src map[string]*Analyzer) map[string]*Analyzer {
 		dst := make(map[string]*Analyzer)
@@ -779,6 +1214,7 @@ func (o *Options) AddStaticcheckAnalyzer(a *analysis.Analyzer, enabled bool, sev
 func (o *Options) EnableAllExperiments() {
 	o.SemanticTokens = true
 	o.ExperimentalPostfixCompletions = true
+	o.ExperimentalUnimportedSymbolsBudget = 50 * time.Millisecond
 	o.ExperimentalUseInvalidMetadata = true
 	o.ExperimentalWatchedFileDelay = 50 * time.Millisecond
 	o.SymbolMatcher = SymbolFastFuzzy
@@ -818,6 +1254,27 @@ func (o *Options) set(name string, value interface{}, seen map[string]struct{})
 			o.Env[k] = fmt.Sprint(v)
 		}
 
+	case "folderEnv":
+		mfolders, ok := value.(map[string]interface{})
+		if !ok {
+			result.errorf("invalid type %T, expect map", value)
+			break
+		}
+		folderEnv := make(map[string]map[string]string)
+		for folder, v := range mfolders {
+			menv, ok := v.(map[string]interface{})
+			if !ok {
+				result.errorf("invalid type %T for folder %q, expect map", v, folder)
+				return result
+			}
+			env := make(map[string]string)
+			for k, v := range menv {
+				env[k] = fmt.Sprint(v)
+			}
+			folderEnv[folderKey(folder)] = env
+		}
+		o.FolderEnv = folderEnv
+
 	case "buildFlags":
 		iflags, ok := value.([]interface{})
 		if !ok {
@@ -835,16 +1292,33 @@ func (o *Options) set(name string, value interface{}, seen map[string]struct{})
 			result.errorf("invalid type %T, expect list", value)
 			break
 		}
-		var filters []string
-		for _, ifilter := range ifilters {
-			filter := fmt.Sprint(ifilter)
-			if filter == "" || (filter[0] != '+' && filter[0] != '-') {
-				result.errorf("invalid filter %q, must start with + or -", filter)
+		filters, err := parseDirectoryFilters(ifilters)
+		if err != nil {
+			result.errorf("%v", err)
+			return result
+		}
+		o.DirectoryFilters = filters
+	case "folderDirectoryFilters":
+		mfilters, ok := value.(map[string]interface{})
+		if !ok {
+			result.errorf("invalid type %T, expect map", value)
+			break
+		}
+		folderFilters := make(map[string][]string)
+		for folder, v := range mfilters {
+			ifilters, ok := v.([]interface{})
+			if !ok {
+				result.errorf("invalid type %T for folder %q, expect list", v, folder)
 				return result
 			}
-			filters = append(filters, strings.TrimRight(filepath.FromSlash(filter), "/"))
+			filters, err := parseDirectoryFilters(ifilters)
+			if err != nil {
+				result.errorf("%v", err)
+				return result
+			}
+			folderFilters[folderKey(folder)] = filters
 		}
-		o.DirectoryFilters = filters
+		o.FolderDirectoryFilters = folderFilters
 	case "memoryMode":
 		if s, ok := result.asOneOf(
 			string(ModeNormal),
@@ -852,6 +1326,16 @@ func (o *Options) set(name string, value interface{}, seen map[string]struct{})
 		); ok {
 			o.MemoryMode = MemoryMode(s)
 		}
+	case "memoryBudget":
+		if s, ok := result.asString(); ok {
+			if _, err := ParseMemoryBudget(s); err != nil {
+				result.errorf("invalid memoryBudget %q: %v", s, err)
+				break
+			}
+			o.MemoryBudget = s
+		}
+	case "lazyTypeCheck":
+		result.setBool(&o.LazyTypeCheck)
 	case "completionDocumentation":
 		result.setBool(&o.CompletionDocumentation)
 	case "usePlaceholders":
@@ -881,6 +1365,14 @@ func (o *Options) set(name string, value interface{}, seen map[string]struct{})
 			o.SymbolMatcher = SymbolMatcher(s)
 		}
 
+	case "vulncheck":
+		if s, ok := result.asOneOf(
+			string(ModeVulncheckOff),
+			string(ModeVulncheckImports),
+		); ok {
+			o.Vulncheck = VulncheckMode(s)
+		}
+
 	case "symbolStyle":
 		if s, ok := result.asOneOf(
 			string(FullyQualifiedSymbols),
@@ -890,6 +1382,15 @@ func (o *Options) set(name string, value interface{}, seen map[string]struct{})
 			o.SymbolStyle = SymbolStyle(s)
 		}
 
+	case "includeTestReferences":
+		result.setBool(&o.IncludeTestReferences)
+
+	case "controlFlowHighlights":
+		result.setBool(&o.ControlFlowHighlights)
+
+	case "errorFlowHighlights":
+		result.setBool(&o.ErrorFlowHighlights)
+
 	case "hoverKind":
 		if s, ok := result.asOneOf(
 			string(NoDocumentation),
@@ -904,9 +1405,15 @@ func (o *Options) set(name string, value interface{}, seen map[string]struct{})
 	case "linkTarget":
 		result.setString(&o.LinkTarget)
 
+	case "privateLinkTarget":
+		result.setString(&o.PrivateLinkTarget)
+
 	case "linksInHover":
 		result.setBool(&o.LinksInHover)
 
+	case "verboseHover":
+		result.setBool(&o.VerboseHover)
+
 	case "importShortcut":
 		if s, ok := result.asOneOf(string(Both), string(Link), string(Definition)); ok {
 			o.ImportShortcut = ImportShortcut(s)
@@ -947,9 +1454,111 @@ func (o *Options) set(name string, value interface{}, seen map[string]struct{})
 			}
 		}
 
+	case "staticcheckConfig":
+		mkeys, ok := value.(map[string]interface{})
+		if !ok {
+			result.errorf("invalid type %T, expect map", value)
+			break
+		}
+		config := make(map[string][]string)
+		for key, v := range mkeys {
+			switch key {
+			case "checks", "initialisms", "dot_import_whitelist", "http_status_code_whitelist":
+			default:
+				result.errorf("unknown staticcheck configuration key %q", key)
+				return result
+			}
+			ivalues, ok := v.([]interface{})
+			if !ok {
+				result.errorf("invalid type %T for %q, expect list", v, key)
+				return result
+			}
+			values := make([]string, 0, len(ivalues))
+			for _, iv := range ivalues {
+				values = append(values, fmt.Sprint(iv))
+			}
+			config[key] = values
+		}
+		o.StaticcheckConfig = config
+
+	case "unusedSymbols":
+		result.setBool(&o.UnusedSymbols)
+
+	case "duplicateCodeTokens":
+		result.setNonNegativeInt(&o.DuplicateCodeTokens)
+
+	case "diagnosticPlatforms":
+		iplatforms, ok := value.([]interface{})
+		if !ok {
+			result.errorf("invalid type %T, expect list", value)
+			break
+		}
+		var platforms []string
+		for _, iplatform := range iplatforms {
+			platform := fmt.Sprint(iplatform)
+			if _, _, err := ParsePlatform(platform); err != nil {
+				result.errorf("%v", err)
+				return result
+			}
+			platforms = append(platforms, platform)
+		}
+		o.DiagnosticPlatforms = platforms
+
+	case "externalAnalyzers":
+		ipaths, ok := value.([]interface{})
+		if !ok {
+			result.errorf("invalid type %T, expect list", value)
+			break
+		}
+		var paths []string
+		for _, ipath := range ipaths {
+			paths = append(paths, fmt.Sprint(ipath))
+		}
+		o.ExternalAnalyzers = paths
+
+	case "diagnosticSeverities":
+		msources, ok := value.(map[string]interface{})
+		if !ok {
+			result.errorf("invalid type %T, expect map", value)
+			break
+		}
+		severities := make(map[string]string)
+		for dsource, v := range msources {
+			severity, err := asOneOf(fmt.Sprint(v), "error", "warning", "information", "hint")
+			if err != nil {
+				result.errorf("invalid severity %q for %q: expect error, warning, information, or hint", v, dsource)
+				return result
+			}
+			severities[dsource] = severity
+		}
+		o.DiagnosticSeverities = severities
+
+	case "batchListErrors":
+		result.setBool(&o.BatchListErrors)
+
 	case "local":
 		result.setString(&o.Local)
 
+	case "importGroups":
+		igroups, ok := value.([]interface{})
+		if !ok {
+			result.errorf("invalid type %T, expect list", value)
+			break
+		}
+		var groups []string
+		for _, igroup := range igroups {
+			group := fmt.Sprint(igroup)
+			if group == "" {
+				result.errorf("invalid empty import group")
+				return result
+			}
+			groups = append(groups, group)
+		}
+		o.ImportGroups = groups
+
+	case "telemetry":
+		result.setBool(&o.Telemetry)
+
 	case "verboseOutput":
 		result.setBool(&o.VerboseOutput)
 
@@ -965,15 +1574,91 @@ func (o *Options) set(name string, value interface{}, seen map[string]struct{})
 	case "gofumpt":
 		result.setBool(&o.Gofumpt)
 
+	case "gofumptExtraRules":
+		result.setBool(&o.GofumptExtraRules)
+
+	case "formatters":
+		iformatters, ok := value.([]interface{})
+		if !ok {
+			result.errorf("invalid type %T, expect list", value)
+			break
+		}
+		var formatters []string
+		for _, iformatter := range iformatters {
+			formatter := fmt.Sprint(iformatter)
+			if err := validateFormatter(formatter); err != nil {
+				result.errorf("invalid formatter %q: %v", formatter, err)
+				return result
+			}
+			formatters = append(formatters, formatter)
+		}
+		o.Formatters = formatters
+
+	case "errorWrapTemplate":
+		if s, ok := result.asString(); ok {
+			if _, err := parseErrorWrapTemplate(s); err != nil {
+				result.errorf("invalid errorWrapTemplate %q: %v", s, err)
+				break
+			}
+			o.ErrorWrapTemplate = s
+		}
+
+	case "newFileTemplate":
+		if s, ok := result.asString(); ok {
+			if _, err := parseNewFileTemplate(s); err != nil {
+				result.errorf("invalid newFileTemplate %q: %v", s, err)
+				break
+			}
+			o.NewFileTemplate = s
+		}
+
+	case "structTags":
+		mencodings, ok := value.(map[string]interface{})
+		if !ok {
+			result.errorf("invalid type %T, expect map", value)
+			break
+		}
+		conventions := make(map[string]string)
+		for encoding, v := range mencodings {
+			convention, err := asOneOf(fmt.Sprint(v), SnakeCase, CamelCase)
+			if err != nil {
+				result.errorf("invalid naming convention %q for %q: expect snake_case or camelCase", v, encoding)
+				return result
+			}
+			conventions[encoding] = convention
+		}
+		o.StructTags = conventions
+
 	case "semanticTokens":
 		result.setBool(&o.SemanticTokens)
 
+	case "confirmLargeEdits":
+		result.setBool(&o.ConfirmLargeEdits)
+
+	case "largeFileThreshold":
+		result.setNonNegativeInt(&o.LargeFileThreshold)
+
+	case "refactorGeneratedFiles":
+		result.setBool(&o.RefactorGeneratedFiles)
+
+	case "hints":
+		result.setBoolMap(&o.Hints)
+
 	case "expandWorkspaceToModule":
 		result.setBool(&o.ExpandWorkspaceToModule)
 
 	case "experimentalPostfixCompletions":
 		result.setBool(&o.ExperimentalPostfixCompletions)
 
+	case "experimentalUnimportedSymbolsBudget":
+		result.setDuration(&o.ExperimentalUnimportedSymbolsBudget)
+
+	case "experimentalCompletionRanking":
+		result.setBool(&o.ExperimentalCompletionRanking)
+
+	case "experimentalResultAssignments":
+		result.setBool(&o.ExperimentalResultAssignments)
+
 	case "experimentalWorkspaceModule": // TODO(rfindley): suggest go.work on go1.18+
 		result.setBool(&o.ExperimentalWorkspaceModule)
 
@@ -1000,6 +1685,9 @@ func (o *Options) set(name string, value interface{}, seen map[string]struct{})
 		}
 		result.setDuration(&o.DiagnosticsDelay)
 
+	case "diagnosticsDelayOnSave":
+		result.setDuration(&o.DiagnosticsDelayOnSave)
+
 	case "experimentalWatchedFileDelay":
 		result.setDuration(&o.ExperimentalWatchedFileDelay)
 
@@ -1101,6 +1789,23 @@ func (r *OptionResult) setBool(b *bool) {
 	}
 }
 
+func (r *OptionResult) setNonNegativeInt(i *int) {
+	// JSON numbers are decoded as float64.
+	switch v := r.Value.(type) {
+	case float64:
+		if v >= 0 && v == float64(int(v)) {
+			*i = int(v)
+			return
+		}
+	case int:
+		if v >= 0 {
+			*i = v
+			return
+		}
+	}
+	r.errorf("invalid value %v, expect a non-negative integer", r.Value)
+}
+
 func (r *OptionResult) setDuration(d *time.Duration) {
 	if v, ok := r.asString(); ok {
 		parsed, err := time.ParseDuration(v)
@@ -1258,6 +1963,10 @@ func typeErrorAnalyzers() map[string]*Analyzer {
 			Fix:      UndeclaredName,
 			Enabled:  true,
 		},
+		unknownfield.Analyzer.Name: {
+			Analyzer: unknownfield.Analyzer,
+			Enabled:  true,
+		},
 	}
 }
 
@@ -1317,6 +2026,7 @@ func defaultAnalyzers() map[string]*Analyzer {
 		unusedparams.Analyzer.Name:     {Analyzer: unusedparams.Analyzer, Enabled: false},
 		unusedwrite.Analyzer.Name:      {Analyzer: unusedwrite.Analyzer, Enabled: false},
 		useany.Analyzer.Name:           {Analyzer: useany.Analyzer, Enabled: false},
+		commentstyle.Analyzer.Name:     {Analyzer: commentstyle.Analyzer, Enabled: false},
 		infertypeargs.Analyzer.Name:    {Analyzer: infertypeargs.Analyzer, Enabled: true},
 		embeddirective.Analyzer.Name:   {Analyzer: embeddirective.Analyzer, Enabled: true},
 
@@ -1351,6 +2061,7 @@ type APIJSON struct {
 	Commands  []*CommandJSON
 	Lenses    []*LensJSON
 	Analyzers []*AnalyzerJSON
+	Hints     []*HintJSON
 }
 
 type OptionJSON struct {
@@ -1421,7 +2132,7 @@ func shouldShowEnumKeysInSettings(name string) bool {
 }
 
 func hardcodedEnumKeys(name string) bool {
-	return name == "analyses" || name == "codelenses"
+	return name == "analyses" || name == "codelenses" || name == "hints"
 }
 
 type EnumKeys struct {
@@ -1489,3 +2200,17 @@ func (a *AnalyzerJSON) String() string {
 func (a *AnalyzerJSON) Write(w io.Writer) {
 	fmt.Fprintf(w, "%s (%s): %v", a.Name, a.Doc, a.Default)
 }
+
+type HintJSON struct {
+	Name    string
+	Doc     string
+	Default bool
+}
+
+func (h *HintJSON) String() string {
+	return h.Name
+}
+
+func (h *HintJSON) Write(w io.Writer) {
+	fmt.Fprintf(w, "%s (%s): %v", h.Name, h.Doc, h.Default)
+}
@@ -5,6 +5,7 @@
 package source
 
 import (
+	"reflect"
 	"testing"
 	"time"
 )
@@ -43,12 +44,142 @@ func TestSetOption(t *testing.T) {
 			value: "2s",
 			check: func(o Options) bool { return o.CompletionBudget == 2*time.Second },
 		},
+		{
+			name:  "memoryBudget",
+			value: "4GiB",
+			check: func(o Options) bool { return o.MemoryBudget == "4GiB" },
+		},
+		{
+			name:      "memoryBudget",
+			value:     "4 gigabytes",
+			wantError: true,
+			check:     func(o Options) bool { return o.MemoryBudget == "" },
+		},
+		{
+			name:  "lazyTypeCheck",
+			value: true,
+			check: func(o Options) bool { return o.LazyTypeCheck },
+		},
 		{
 			name:      "staticcheck",
 			value:     true,
 			check:     func(o Options) bool { return o.Staticcheck == true },
 			wantError: true, // o.StaticcheckSupported is unset
 		},
+		{
+			name: "staticcheckConfig",
+			value: map[string]interface{}{
+				"checks":      []interface{}{"inherit", "-ST1000"},
+				"initialisms": []interface{}{"GRPC"},
+			},
+			check: func(o Options) bool {
+				return reflect.DeepEqual(o.StaticcheckConfig, map[string][]string{
+					"checks":      {"inherit", "-ST1000"},
+					"initialisms": {"GRPC"},
+				})
+			},
+		},
+		{
+			name:      "staticcheckConfig",
+			value:     map[string]interface{}{"unknown": []interface{}{"x"}},
+			wantError: true,
+			check:     func(o Options) bool { return o.StaticcheckConfig == nil },
+		},
+		{
+			name:  "duplicateCodeTokens",
+			value: float64(60),
+			check: func(o Options) bool { return o.DuplicateCodeTokens == 60 },
+		},
+		{
+			name:      "duplicateCodeTokens",
+			value:     1.5,
+			wantError: true,
+			check:     func(o Options) bool { return o.DuplicateCodeTokens == 0 },
+		},
+		{
+			name:  "largeFileThreshold",
+			value: float64(1 << 20),
+			check: func(o Options) bool { return o.LargeFileThreshold == 1<<20 },
+		},
+		{
+			name:      "largeFileThreshold",
+			value:     float64(-1),
+			wantError: true,
+			check:     func(o Options) bool { return o.LargeFileThreshold == 0 },
+		},
+		{
+			name:  "importGroups",
+			value: []interface{}{"std", "module"},
+			check: func(o Options) bool {
+				return len(o.ImportGroups) == 2 && o.ImportGroups[0] == "std" && o.ImportGroups[1] == "module"
+			},
+		},
+		{
+			name:      "importGroups",
+			value:     []interface{}{"std", ""},
+			wantError: true,
+			check:     func(o Options) bool { return len(o.ImportGroups) == 0 },
+		},
+		{
+			name:  "errorWrapTemplate",
+			value: "{{.Package}}.{{.Func}} failed: %w",
+			check: func(o Options) bool { return o.ErrorWrapTemplate == "{{.Package}}.{{.Func}} failed: %w" },
+		},
+		{
+			name:      "errorWrapTemplate",
+			value:     "{{.Func}} failed",
+			wantError: true,
+			check:     func(o Options) bool { return o.ErrorWrapTemplate == "" },
+		},
+		{
+			name:      "errorWrapTemplate",
+			value:     "{{.Function}}: %w",
+			wantError: true,
+			check:     func(o Options) bool { return o.ErrorWrapTemplate == "" },
+		},
+		{
+			name:  "formatters",
+			value: []interface{}{"imports", "exec:goimports-reviser -format"},
+			check: func(o Options) bool {
+				return len(o.Formatters) == 2 && o.Formatters[0] == "imports" && o.Formatters[1] == "exec:goimports-reviser -format"
+			},
+		},
+		{
+			name:      "formatters",
+			value:     []interface{}{"gci"},
+			wantError: true,
+			check:     func(o Options) bool { return len(o.Formatters) == 0 },
+		},
+		{
+			name:      "formatters",
+			value:     []interface{}{"exec: "},
+			wantError: true,
+			check:     func(o Options) bool { return len(o.Formatters) == 0 },
+		},
+		{
+			name:  "newFileTemplate",
+			value: "// Copyright {{.Year}} The Authors.",
+			check: func(o Options) bool { return o.NewFileTemplate == "// Copyright {{.Year}} The Authors." },
+		},
+		{
+			name:      "newFileTemplate",
+			value:     "// Package {{.Name}}",
+			wantError: true,
+			check:     func(o Options) bool { return o.NewFileTemplate == "" },
+		},
+		{
+			name:  "structTags",
+			value: map[string]interface{}{"json": "CamelCase", "yaml": "snake_case"},
+			check: func(o Options) bool {
+				return o.StructTags["json"] == CamelCase && o.StructTags["yaml"] == SnakeCase
+			},
+		},
+		{
+			name:      "structTags",
+			value:     map[string]interface{}{"json": "kebab-case"},
+			wantError: true,
+			check:     func(o Options) bool { return len(o.StructTags) == 0 },
+		},
 		{
 			name:  "codelenses",
 			value: map[string]interface{}{"generate": true},
@@ -156,6 +287,89 @@ func TestSetOption(t *testing.T) {
 				return len(o.DirectoryFilters) == 0
 			},
 		},
+		{
+			name:  "directoryFilters",
+			value: []interface{}{"-**/node_modules"},
+			check: func(o Options) bool {
+				return len(o.DirectoryFilters) == 1
+			},
+		},
+		{
+			name:      "directoryFilters",
+			value:     []interface{}{"-a/**b"},
+			wantError: true,
+			check: func(o Options) bool {
+				return len(o.DirectoryFilters) == 0
+			},
+		},
+		{
+			name:  "diagnosticPlatforms",
+			value: []interface{}{"windows/arm64", "darwin/amd64"},
+			check: func(o Options) bool {
+				return len(o.DiagnosticPlatforms) == 2
+			},
+		},
+		{
+			name:      "diagnosticPlatforms",
+			value:     []interface{}{"windows"},
+			wantError: true,
+			check: func(o Options) bool {
+				return len(o.DiagnosticPlatforms) == 0
+			},
+		},
+		{
+			name:  "diagnosticSeverities",
+			value: map[string]interface{}{"unusedparams": "Hint", "printf": "error"},
+			check: func(o Options) bool {
+				return o.DiagnosticSeverities["unusedparams"] == "hint" && o.DiagnosticSeverities["printf"] == "error"
+			},
+		},
+		{
+			name:      "diagnosticSeverities",
+			value:     map[string]interface{}{"printf": "fatal"},
+			wantError: true,
+			check: func(o Options) bool {
+				return len(o.DiagnosticSeverities) == 0
+			},
+		},
+		{
+			name: "folderEnv",
+			value: map[string]interface{}{
+				"web": map[string]interface{}{"GOOS": "js", "GOARCH": "wasm"},
+			},
+			check: func(o Options) bool {
+				return o.FolderEnv["web"]["GOOS"] == "js" && o.FolderEnv["web"]["GOARCH"] == "wasm"
+			},
+		},
+		{
+			name: "folderEnv",
+			value: map[string]interface{}{
+				"web": "GOOS=js",
+			},
+			wantError: true,
+			check: func(o Options) bool {
+				return o.FolderEnv == nil
+			},
+		},
+		{
+			name: "folderDirectoryFilters",
+			value: map[string]interface{}{
+				"web": []interface{}{"-**/node_modules", "-dist"},
+			},
+			check: func(o Options) bool {
+				return len(o.FolderDirectoryFilters["web"]) == 2
+			},
+		},
+		{
+			name: "folderDirectoryFilters",
+			value: map[string]interface{}{
+				"web": []interface{}{"dist"},
+			},
+			wantError: true,
+			check: func(o Options) bool {
+				return o.FolderDirectoryFilters == nil
+			},
+		},
 		{
 			name: "annotations",
 			value: map[string]interface{}{
@@ -182,3 +396,93 @@ func TestSetOption(t *testing.T) {
 		}
 	}
 }
+
+func TestCheckEnabled(t *testing.T) {
+	tests := []struct {
+		checks []string
+		name   string
+		def    bool
+		want   bool
+	}{
+		{[]string{"all"}, "ST1000", false, true},
+		{[]string{"*", "-ST1000"}, "ST1000", true, false},
+		{[]string{"SA1*"}, "SA1000", false, true},
+		{[]string{"SA1*"}, "SA2000", true, false},
+		{[]string{"inherit"}, "SA2000", true, true},
+		{[]string{"inherit", "-SA*"}, "SA2000", true, false},
+		{[]string{"inherit", "ST1003"}, "ST1003", false, true},
+		{[]string{"-all", "inherit"}, "S1000", true, true},
+	}
+	for _, test := range tests {
+		if got := checkEnabled(test.checks, test.name, test.def); got != test.want {
+			t.Errorf("checkEnabled(%q, %s, %t) = %t, want %t", test.checks, test.name, test.def, got, test.want)
+		}
+	}
+}
+
+func TestParseMemoryBudget(t *testing.T) {
+	tests := []struct {
+		budget  string
+		want    uint64
+		wantErr bool
+	}{
+		{budget: "", want: 0},
+		{budget: "1024", want: 1024},
+		{budget: "100B", want: 100},
+		{budget: "512MB", want: 512e6},
+		{budget: "512 MiB", want: 512 << 20},
+		{budget: "4GiB", want: 4 << 30},
+		{budget: "2TB", want: 2e12},
+		{budget: "0GB", wantErr: true},
+		{budget: "-1GB", wantErr: true},
+		{budget: "1.5GB", wantErr: true},
+		{budget: "GB", wantErr: true},
+	}
+	for _, test := range tests {
+		got, err := ParseMemoryBudget(test.budget)
+		if (err != nil) != test.wantErr {
+			t.Errorf("ParseMemoryBudget(%q) returned error %v, want error: %t", test.budget, err, test.wantErr)
+			continue
+		}
+		if got != test.want {
+			t.Errorf("ParseMemoryBudget(%q) = %d, want %d", test.budget, got, test.want)
+		}
+	}
+}
+
+func TestApplyFolderOverrides(t *testing.T) {
+	o := &Options{UserOptions: UserOptions{
+		BuildOptions: BuildOptions{
+			Env:              map[string]string{"GOOS": "linux", "GOFLAGS": "-mod=mod"},
+			DirectoryFilters: []string{"-node_modules"},
+			FolderEnv: map[string]map[string]string{
+				"web":          {"GOOS": "js", "GOARCH": "wasm"},
+				"/src/web":     {"GOOS": "windows"},
+				"/src/unnamed": {"GOARCH": "arm64"},
+			},
+			FolderDirectoryFilters: map[string][]string{
+				"web": {"-dist"},
+			},
+		},
+	}}
+	tests := []struct {
+		name, folder string
+		wantEnv      map[string]string
+		wantFilters  []string
+	}{
+		{"web", "/src/web", map[string]string{"GOOS": "windows", "GOFLAGS": "-mod=mod"}, []string{"-dist"}},
+		{"web", "/other/web", map[string]string{"GOOS": "js", "GOARCH": "wasm", "GOFLAGS": "-mod=mod"}, []string{"-dist"}},
+		{"", "/src/unnamed", map[string]string{"GOOS": "linux", "GOARCH": "arm64", "GOFLAGS": "-mod=mod"}, []string{"-node_modules"}},
+		{"server", "/src/server", map[string]string{"GOOS": "linux", "GOFLAGS": "-mod=mod"}, []string{"-node_modules"}},
+	}
+	for _, test := range tests {
+		opts := o.Clone()
+		opts.ApplyFolderOverrides(test.name, test.folder)
+		if !reflect.DeepEqual(opts.Env, test.wantEnv) {
+			t.Errorf("ApplyFolderOverrides(%q, %q): Env = %v, want %v", test.name, test.folder, opts.Env, test.wantEnv)
+		}
+		if !reflect.DeepEqual(opts.DirectoryFilters, test.wantFilters) {
+			t.Errorf("ApplyFolderOverrides(%q, %q): DirectoryFilters = %v, want %v", test.name, test.folder, opts.DirectoryFilters, test.wantFilters)
+		}
+	}
+}
@@ -0,0 +1,100 @@
+// Copyright 2022 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package source
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"github.com/iansmith/golang-x-tools/internal/event"
+)
+
+// The formats of the documents of PackageGraph.
+const (
+	PackageGraphDOT  = "dot"
+	PackageGraphJSON = "json"
+)
+
+// An ImportGraphNode is a package of the import graph of a snapshot.
+type ImportGraphNode struct {
+	ID      string
+	PkgPath string `json:"Path"`
+	// Module is the path of the module of the package, if any.
+	Module string `json:",omitempty"`
+	// Workspace reports whether the package is a workspace package.
+	Workspace bool
+	// Test reports whether the package is a test variant, an external test
+	// package or a test main package.
+	Test bool
+	// Imports holds the IDs of the packages that the package imports.
+	Imports []string
+}
+
+// PackageGraph returns the import graph of the packages loaded in snapshot.
+// The test variants of packages are left out unless tests is set, and the
+// packages outside the workspace unless external is set.
+func PackageGraph(ctx context.Context, snapshot Snapshot, tests, external bool) ([]*ImportGraphNode, error) {
+	ctx, done := event.Start(ctx, "source.PackageGraph")
+	defer done()
+
+	nodes, err := snapshot.ImportGraph(ctx)
+	if err != nil {
+		return nil, err
+	}
+	keep := make(map[string]bool)
+	for _, node := range nodes {
+		keep[node.ID] = (tests || !node.Test) && (external || node.Workspace)
+	}
+	var graph []*ImportGraphNode
+	for _, node := range nodes {
+		if !keep[node.ID] {
+			continue
+		}
+		kept := *node
+		kept.Imports = nil
+		for _, id := range node.Imports {
+			if keep[id] {
+				kept.Imports = append(kept.Imports, id)
+			}
+		}
+		graph = append(graph, &kept)
+	}
+	return graph, nil
+}
+
+// FormatPackageGraph returns a document in the given format, PackageGraphDOT
+// or PackageGraphJSON, describing graph.
+func FormatPackageGraph(graph []*ImportGraphNode, format string) ([]byte, error) {
+	switch format {
+	case "", PackageGraphDOT:
+		var buf bytes.Buffer
+		buf.WriteString("digraph packages {\n")
+		// The packages outside the workspace are dashed.
+		for _, node := range graph {
+			if node.Workspace {
+				fmt.Fprintf(&buf, "\t%s;\n", strconv.Quote(node.ID))
+			} else {
+				fmt.Fprintf(&buf, "\t%s [style=dashed];\n", strconv.Quote(node.ID))
+			}
+		}
+		for _, node := range graph {
+			for _, id := range node.Imports {
+				fmt.Fprintf(&buf, "\t%s -> %s;\n", strconv.Quote(node.ID), strconv.Quote(id))
+			}
+		}
+		buf.WriteString("}\n")
+		return buf.Bytes(), nil
+	case PackageGraphJSON:
+		if graph == nil {
+			graph = []*ImportGraphNode{}
+		}
+		return json.MarshalIndent(struct{ Packages []*ImportGraphNode }{graph}, "", "\t")
+	default:
+		return nil, fmt.Errorf("unknown package graph format %q", format)
+	}
+}
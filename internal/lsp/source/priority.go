@@ -0,0 +1,30 @@
+// Copyright 2022 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package source
+
+import "context"
+
+type yieldKey struct{}
+
+// WithYield returns a context for background work, such as diagnostics,
+// whose loops call yield between their steps, so that more urgent work can
+// run first. yield blocks while there is such work, and returns a non-nil
+// error if the background work must stop.
+func WithYield(ctx context.Context, yield func(context.Context) error) context.Context {
+	return context.WithValue(ctx, yieldKey{}, yield)
+}
+
+// Yield lets more urgent work run if ctx is that of background work, then
+// returns the error of ctx. Computations shared by several requests, such
+// as the type-checking of a package, must not call it: they would delay
+// the urgent requests that wait for them.
+func Yield(ctx context.Context) error {
+	if yield, ok := ctx.Value(yieldKey{}).(func(context.Context) error); ok {
+		if err := yield(ctx); err != nil {
+			return err
+		}
+	}
+	return ctx.Err()
+}
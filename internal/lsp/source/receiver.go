@@ -0,0 +1,349 @@
+// Copyright 2022 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package source
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/token"
+	"go/types"
+	"strings"
+
+	"github.com/iansmith/golang-x-tools/go/analysis"
+	"github.com/iansmith/golang-x-tools/go/ast/astutil"
+	"github.com/iansmith/golang-x-tools/internal/lsp/protocol"
+	"github.com/iansmith/golang-x-tools/internal/span"
+	"github.com/iansmith/golang-x-tools/internal/typeparams"
+)
+
+// CanToggleReceiver reports whether rng selects the receiver or the name
+// of a method in its declaration, whose receiver toggleReceiver can
+// convert, and whether it is a pointer.
+func CanToggleReceiver(pkg Package, file *ast.File, rng span.Range) (pointer, ok bool) {
+	_, _, pointer, err := selectedReceiver(pkg.GetTypesInfo(), file, rng)
+	return pointer, err == nil
+}
+
+// selectedReceiver returns the declaration of the method whose receiver
+// or name rng selects, the method, and whether its receiver is a pointer.
+func selectedReceiver(info *types.Info, file *ast.File, rng span.Range) (*ast.FuncDecl, *types.Func, bool, error) {
+	path, _ := astutil.PathEnclosingInterval(file, rng.Start, rng.End)
+	var decl *ast.FuncDecl
+	for _, n := range path {
+		if d, ok := n.(*ast.FuncDecl); ok {
+			decl = d
+			break
+		}
+	}
+	if decl == nil || decl.Recv == nil || len(decl.Recv.List) != 1 {
+		return nil, nil, false, errors.New("no method selected")
+	}
+	if !selectsIdent(rng, decl.Name) && !(decl.Recv.Pos() <= rng.Start && rng.End <= decl.Recv.End()) {
+		return nil, nil, false, errors.New("neither the receiver nor the name of the method is selected")
+	}
+	fn, ok := info.Defs[decl.Name].(*types.Func)
+	if !ok {
+		return nil, nil, false, errors.New("no type information")
+	}
+	recv := fn.Type().(*types.Signature).Recv()
+	typ := recv.Type()
+	ptr, pointer := typ.(*types.Pointer)
+	if pointer {
+		typ = ptr.Elem()
+	}
+	named, ok := typ.(*types.Named)
+	if !ok {
+		return nil, nil, false, errors.New("no named receiver type")
+	}
+	if typeparams.ForNamed(named).Len() > 0 {
+		return nil, nil, false, fmt.Errorf("%s is generic", named.Obj().Name())
+	}
+	if _, ok := astutil.Unparen(decl.Recv.List[0].Type).(*ast.StarExpr); ok != pointer {
+		return nil, nil, false, errors.New("unsupported receiver type expression")
+	}
+	return decl, fn, pointer, nil
+}
+
+// toggleReceiver converts the receiver of the method selected by pRng from
+// a value to a pointer, or from a pointer to a value. The uses of the
+// receiver in the method and the method expressions that call the method
+// are updated. The conversion fails if it would change the behavior of the
+// method, that is, if it modifies its receiver. It also fails if the
+// method would no longer be callable where it is called, such as on
+// values that are not addressable, or if a type would no longer implement
+// an interface that it is used as.
+func toggleReceiver(ctx context.Context, snapshot Snapshot, fh VersionedFileHandle, pRng protocol.Range) (*analysis.SuggestedFix, error) {
+	pkg, pgf, err := GetParsedFile(ctx, snapshot, fh, NarrowestPackage)
+	if err != nil {
+		return nil, fmt.Errorf("GetParsedFile: %w", err)
+	}
+	rng, err := pgf.Mapper.RangeToSpanRange(pRng)
+	if err != nil {
+		return nil, err
+	}
+	decl, fn, pointer, err := selectedReceiver(pkg.GetTypesInfo(), pgf.File, rng)
+	if err != nil {
+		return nil, fmt.Errorf("cannot convert receiver: %v", err)
+	}
+	if pkg.HasTypeErrors() || pkg.HasListOrParseErrors() {
+		return nil, fmt.Errorf("cannot convert receiver: package %s has errors", pkg.PkgPath())
+	}
+	fset := snapshot.FileSet()
+	recvType := astutil.Unparen(decl.Recv.List[0].Type)
+
+	var edits []analysis.TextEdit
+	if pointer {
+		star := recvType.(*ast.StarExpr)
+		edits = append(edits, analysis.TextEdit{Pos: star.Star, End: star.X.Pos()})
+	} else {
+		edits = append(edits, analysis.TextEdit{Pos: recvType.Pos(), End: recvType.Pos(), NewText: []byte("*")})
+	}
+
+	var blockers []string
+	blocker := func(pos token.Pos, format string, args ...interface{}) {
+		blockers = append(blockers, fmt.Sprintf("%s: %s", fset.Position(pos), fmt.Sprintf(format, args...)))
+	}
+	if recv := fn.Type().(*types.Signature).Recv(); recv.Name() != "" && recv.Name() != "_" && decl.Body != nil {
+		for _, use := range receiverUses(pkg.GetTypesInfo(), pgf.File, decl.Body, recv, pointer) {
+			switch {
+			case use.mutated:
+				if pointer {
+					blocker(use.id.Pos(), "%s modifies its receiver %s", fn.Name(), recv.Name())
+				} else {
+					blocker(use.id.Pos(), "%s modifies its copy of the receiver %s", fn.Name(), recv.Name())
+				}
+			case use.derefParens != nil:
+				// (*r) becomes r.
+				edits = append(edits, analysis.TextEdit{Pos: use.derefParens.Pos(), End: use.derefParens.End(), NewText: []byte(recv.Name())})
+			case use.deref != nil:
+				// *r becomes r.
+				edits = append(edits, analysis.TextEdit{Pos: use.deref.Star, End: use.deref.X.Pos()})
+			case use.bare && pointer:
+				blocker(use.id.Pos(), "%s uses its receiver %s as a pointer", fn.Name(), recv.Name())
+			case use.bare:
+				text := "*" + recv.Name()
+				if use.parens {
+					text = "(" + text + ")"
+				}
+				edits = append(edits, analysis.TextEdit{Pos: use.id.Pos(), End: use.id.End(), NewText: []byte(text)})
+			}
+		}
+	}
+	if len(blockers) > 0 {
+		return nil, fmt.Errorf("cannot convert the receiver of %s:\n%s", fn.Name(), strings.Join(blockers, "\n"))
+	}
+
+	pkgs, err := refactoringPackages(ctx, snapshot, pgf.URI)
+	if err != nil {
+		return nil, err
+	}
+	if !pointer {
+		// T.M(x, ...) becomes (*T).M(&x, ...).
+		for _, pkg := range pkgs {
+			edits = append(edits, methodExprEdits(fset, pkg, fn)...)
+		}
+	}
+	edits = uniqueEdits(fset, edits)
+	errs, err := checkEdits(ctx, snapshot, pkgs, edits)
+	if err != nil {
+		return nil, err
+	}
+	if len(errs) > 0 {
+		return nil, fmt.Errorf("cannot convert the receiver of %s, which would cause errors:\n%s", fn.Name(), strings.Join(errs, "\n"))
+	}
+	return &analysis.SuggestedFix{TextEdits: edits}, nil
+}
+
+// A receiverUse is a use of the receiver of a method in its body.
+type receiverUse struct {
+	id          *ast.Ident
+	mutated     bool           // the use modifies the receiver, or the value it points to
+	deref       *ast.StarExpr  // the dereference of a pointer receiver, if any
+	derefParens *ast.ParenExpr // the parentheses around deref, if any
+	bare        bool           // the receiver is used as a whole, and not through a selector
+	parens      bool           // the use needs parentheses once dereferenced
+}
+
+// receiverUses returns the uses of recv, the receiver of a method, in its
+// body, which is in file. A use that assigns to the receiver or to one of
+// its fields or elements, takes their address, or calls a method with a
+// pointer receiver on them, modifies the receiver. For a pointer receiver,
+// that is the value that it points to.
+func receiverUses(info *types.Info, file *ast.File, body *ast.BlockStmt, recv *types.Var, pointer bool) []receiverUse {
+	var uses []receiverUse
+	ast.Inspect(body, func(n ast.Node) bool {
+		id, ok := n.(*ast.Ident)
+		if !ok || info.Uses[id] != recv {
+			return true
+		}
+		use := receiverUse{id: id, bare: true}
+		path, _ := astutil.PathEnclosingInterval(file, id.Pos(), id.End())
+		rooted := true      // child denotes the receiver value or a part of it
+		indirect := pointer // child is the receiver itself, a pointer
+		var child ast.Node = id
+	loop:
+		for _, n := range path[1:] {
+			if n == body {
+				break
+			}
+			switch n := n.(type) {
+			case *ast.ParenExpr:
+				if use.deref != nil && n.X == use.deref {
+					use.derefParens = n
+				}
+				child = n
+				continue
+			case *ast.StarExpr:
+				if indirect && n.X == child {
+					use.deref = n
+					use.bare = false
+					indirect = false
+					child = n
+					continue
+				}
+				rooted = false
+			case *ast.IndexExpr:
+				if child == id {
+					use.parens = true
+				}
+				_, isArray := info.TypeOf(n.X).Underlying().(*types.Array)
+				rooted = rooted && n.X == child && isArray && !indirect
+			case *ast.SliceExpr:
+				if child == id {
+					use.parens = true
+				}
+				_, isArray := info.TypeOf(n.X).Underlying().(*types.Array)
+				use.mutated = use.mutated || rooted && n.X == child && isArray && !indirect
+				rooted = false
+			case *ast.SelectorExpr:
+				if n.X == child && child == id {
+					use.bare = false
+				}
+				sel := info.Selections[n]
+				switch {
+				case sel == nil || sel.Indirect() && !indirect:
+					rooted = false
+				case sel.Kind() == types.MethodVal:
+					_, ptrRecv := sel.Obj().Type().(*types.Signature).Recv().Type().(*types.Pointer)
+					use.mutated = use.mutated || rooted && ptrRecv
+					rooted = false
+				}
+				indirect = false
+			case *ast.CallExpr:
+				if n.Fun == child && child == id {
+					use.parens = true
+				}
+				rooted = false
+			case *ast.TypeAssertExpr:
+				if child == id {
+					use.parens = true
+				}
+				rooted = false
+			case *ast.AssignStmt:
+				for _, lhs := range n.Lhs {
+					use.mutated = use.mutated || rooted && !indirect && lhs == child
+				}
+				rooted = false
+			case *ast.IncDecStmt:
+				use.mutated = use.mutated || rooted && !indirect
+				rooted = false
+			case *ast.UnaryExpr:
+				use.mutated = use.mutated || rooted && !indirect && n.Op == token.AND
+				rooted = false
+			case *ast.RangeStmt:
+				use.mutated = use.mutated || rooted && !indirect && (n.Key == child || n.Value == child)
+				rooted = false
+			default:
+				rooted = false
+			}
+			if !rooted {
+				break loop
+			}
+			child = n
+		}
+		uses = append(uses, use)
+		return true
+	})
+	return uses
+}
+
+// methodExprEdits returns the edits of the files of pkg that convert the
+// calls of method expressions of fn, a method whose receiver is a value,
+// to calls of method expressions of the pointer type, passing the address
+// of their first argument if it is addressable.
+func methodExprEdits(fset *token.FileSet, pkg Package, fn *types.Func) []analysis.TextEdit {
+	info := pkg.GetTypesInfo()
+	var edits []analysis.TextEdit
+	for _, pgf := range pkg.CompiledGoFiles() {
+		ast.Inspect(pgf.File, func(n ast.Node) bool {
+			call, ok := n.(*ast.CallExpr)
+			if !ok || len(call.Args) == 0 {
+				return true
+			}
+			sel, ok := astutil.Unparen(call.Fun).(*ast.SelectorExpr)
+			if !ok {
+				return true
+			}
+			s := info.Selections[sel]
+			if s == nil || s.Kind() != types.MethodExpr || !sameObject(fset, s.Obj(), fn) {
+				return true
+			}
+			if _, ok := s.Recv().(*types.Pointer); ok {
+				return true
+			}
+			arg := call.Args[0]
+			if _, isLit := astutil.Unparen(arg).(*ast.CompositeLit); !isLit && !addressable(info, arg) {
+				return true // reported by the type checker
+			}
+			edits = append(edits,
+				analysis.TextEdit{Pos: sel.X.Pos(), End: sel.X.Pos(), NewText: []byte("(*")},
+				analysis.TextEdit{Pos: sel.X.End(), End: sel.X.End(), NewText: []byte(")")},
+				analysis.TextEdit{Pos: arg.Pos(), End: arg.Pos(), NewText: []byte("&")},
+			)
+			return true
+		})
+	}
+	return edits
+}
+
+// addressable reports whether e is addressable.
+func addressable(info *types.Info, e ast.Expr) bool {
+	switch e := e.(type) {
+	case *ast.ParenExpr:
+		return addressable(info, e.X)
+	case *ast.Ident:
+		_, ok := info.Uses[e].(*types.Var)
+		return ok
+	case *ast.StarExpr:
+		return true
+	case *ast.SelectorExpr:
+		sel := info.Selections[e]
+		if sel == nil {
+			// A qualified identifier.
+			_, ok := info.Uses[e.Sel].(*types.Var)
+			return ok
+		}
+		if sel.Kind() != types.FieldVal {
+			return false
+		}
+		if _, ok := info.TypeOf(e.X).Underlying().(*types.Pointer); ok || sel.Indirect() {
+			return true
+		}
+		return addressable(info, e.X)
+	case *ast.IndexExpr:
+		switch t := info.TypeOf(e.X).Underlying().(type) {
+		case *types.Slice:
+			return true
+		case *types.Pointer:
+			_, ok := t.Elem().Underlying().(*types.Array)
+			return ok
+		case *types.Array:
+			return addressable(info, e.X)
+		}
+	}
+	return false
+}
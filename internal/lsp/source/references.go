@@ -12,6 +12,7 @@ import (
 	"go/token"
 	"go/types"
 	"sort"
+	"strings"
 
 	"github.com/iansmith/golang-x-tools/internal/event"
 	"github.com/iansmith/golang-x-tools/internal/lsp/protocol"
@@ -47,6 +48,10 @@ func References(ctx context.Context, s Snapshot, f FileHandle, pp protocol.Posit
 	if err != nil {
 		return nil, err
 	}
+	refs, err = uniqueReferences(refs, s.View().Options().IncludeTestReferences)
+	if err != nil {
+		return nil, err
+	}
 
 	toSort := refs
 	if includeDeclaration {
@@ -62,6 +67,31 @@ func References(ctx context.Context, s Snapshot, f FileHandle, pp protocol.Posit
 	return refs, nil
 }
 
+// uniqueReferences returns refs without the references that repeat an
+// earlier one: a file belonging to several packages, such as p and
+// p [p.test], is searched once per package, and interface references may
+// repeat direct ones. Unless includeTests is set, the references in
+// _test.go files, other than declarations, are dropped as well.
+func uniqueReferences(refs []*ReferenceInfo, includeTests bool) ([]*ReferenceInfo, error) {
+	seen := make(map[span.Span]bool)
+	result := refs[:0]
+	for _, ref := range refs {
+		spn, err := ref.Span()
+		if err != nil {
+			return nil, err
+		}
+		if seen[spn] {
+			continue
+		}
+		seen[spn] = true
+		if !includeTests && !ref.isDeclaration && strings.HasSuffix(spn.URI().Filename(), "_test.go") {
+			continue
+		}
+		result = append(result, ref)
+	}
+	return result, nil
+}
+
 // references is a helper function to avoid recomputing qualifiedObjsAtProtocolPos.
 func references(ctx context.Context, snapshot Snapshot, qos []qualifiedObject, includeDeclaration, includeInterfaceRefs, includeEmbeddedRefs bool) ([]*ReferenceInfo, error) {
 	var (
@@ -84,8 +114,14 @@ func references(ctx context.Context, snapshot Snapshot, qos []qualifiedObject, i
 	}
 	// Make sure declaration is the first item in the response.
 	if includeDeclaration {
+		declRange := declIdent.MappedRange
+		// The C names are declared in the cgo preamble, not in the Go file
+		// generated by cgo.
+		if crng, ok := cgoPreambleRange(snapshot, qos[0].pkg, qos[0].obj); ok {
+			declRange = crng
+		}
 		references = append(references, &ReferenceInfo{
-			MappedRange:   declIdent.MappedRange,
+			MappedRange:   declRange,
 			Name:          qos[0].obj.Name(),
 			ident:         declIdent.ident,
 			obj:           qos[0].obj,
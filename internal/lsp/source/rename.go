@@ -31,6 +31,8 @@ type renamer struct {
 	objsToUpdate       map[types.Object]bool
 	hadConflicts       bool
 	errors             string
+	kind               RenameConflictKind // of the conflicts being reported
+	conflicts          []renameConflict
 	from, to           string
 	satisfyConstraints map[satisfy.Constraint]bool
 	packages           map[*types.Package]Package // may include additional packages that are a dep of pkg
@@ -38,6 +40,60 @@ type renamer struct {
 	changeMethods      bool
 }
 
+// A RenameConflictKind classifies the conflicts of a renaming.
+type RenameConflictKind string
+
+const (
+	// ShadowingConflict is the kind of the conflicts of names that would
+	// collide with other declarations in the same block, shadow them, or be
+	// shadowed by them.
+	ShadowingConflict RenameConflictKind = "Shadowing"
+	// MethodSetConflict is the kind of the conflicts of methods whose
+	// renaming would change the method set of a type, so that it no longer
+	// implements an interface, or so that calls select other methods.
+	MethodSetConflict RenameConflictKind = "MethodSet"
+	// ExportConflict is the kind of the conflicts of exported names whose
+	// renaming would break references from other packages, or change the
+	// API of a module used by another module.
+	ExportConflict RenameConflictKind = "Export"
+	// OtherConflict is the kind of the other conflicts, including those of
+	// renamings that cannot be checked.
+	OtherConflict RenameConflictKind = "Other"
+)
+
+// A RenameConflict describes why a renaming would break the program, or
+// change its meaning.
+type RenameConflict struct {
+	Kind RenameConflictKind
+	// Message describes the conflict. Its first line refers to the renamed
+	// declaration, and the following lines, indented by a tab, to the
+	// conflicting declarations and references.
+	Message string
+	// Locations are the locations of the lines of Message that have one.
+	Locations []protocol.Location
+}
+
+// A RenameConflictError is the error of a renaming that has conflicts.
+type RenameConflictError struct {
+	Conflicts []RenameConflict
+	// Edits are the edits of the renaming, except those of the references
+	// at which conflicts are reported.
+	Edits map[span.URI][]protocol.TextEdit
+
+	msg string
+}
+
+func (e *RenameConflictError) Error() string {
+	return e.msg
+}
+
+// A renameConflict is a conflict reported by a renamer.
+type renameConflict struct {
+	kind      RenameConflictKind
+	lines     []string
+	positions []token.Pos // of lines, or token.NoPos
+}
+
 type PrepareItem struct {
 	Range protocol.Range
 	Text  string
@@ -52,6 +108,26 @@ func PrepareRename(ctx context.Context, snapshot Snapshot, f FileHandle, pp prot
 	ctx, done := event.Start(ctx, "source.PrepareRename")
 	defer done()
 
+	if pgf, ok, err := inPackageClause(ctx, snapshot, f, pp); err != nil {
+		return nil, nil, err
+	} else if ok {
+		pkg, err := snapshot.PackageForFile(ctx, f.URI(), TypecheckWorkspace, NarrowestPackage)
+		if err != nil {
+			return nil, nil, err
+		}
+		if err := checkPackageRenamable(pkg); err != nil {
+			return nil, err, err
+		}
+		rng, err := NewMappedRange(snapshot.FileSet(), pgf.Mapper, pgf.File.Name.Pos(), pgf.File.Name.End()).Range()
+		if err != nil {
+			return nil, nil, err
+		}
+		return &PrepareItem{
+			Range: rng,
+			Text:  pgf.File.Name.Name,
+		}, nil, nil
+	}
+
 	qos, err := qualifiedObjsAtProtocolPos(ctx, snapshot, f.URI(), pp)
 	if err != nil {
 		return nil, nil, err
@@ -91,10 +167,26 @@ func checkRenamable(obj types.Object) error {
 
 // Rename returns a map of TextEdits for each file modified when renaming a
 // given identifier within a package.
-func Rename(ctx context.Context, s Snapshot, f FileHandle, pp protocol.Position, newName string) (map[span.URI][]protocol.TextEdit, error) {
+//
+// Renaming the name of a package clause renames the package, which may
+// also rename its directory: if so, the returned RenameFile describes the
+// rename of the directory, which must follow the edits.
+func Rename(ctx context.Context, s Snapshot, f FileHandle, pp protocol.Position, newName string) (map[span.URI][]protocol.TextEdit, *protocol.RenameFile, error) {
 	ctx, done := event.Start(ctx, "source.Rename")
 	defer done()
 
+	if _, ok, err := inPackageClause(ctx, s, f, pp); err != nil {
+		return nil, nil, err
+	} else if ok {
+		return renamePackage(ctx, s, f.URI(), newName)
+	}
+	edits, err := rename(ctx, s, f, pp, newName)
+	return edits, nil, err
+}
+
+// rename returns a map of TextEdits for each file modified when renaming
+// the identifier at pp.
+func rename(ctx context.Context, s Snapshot, f FileHandle, pp protocol.Position, newName string) (map[span.URI][]protocol.TextEdit, error) {
 	qos, err := qualifiedObjsAtProtocolPos(ctx, s, f.URI(), pp)
 	if err != nil {
 		return nil, err
@@ -147,18 +239,108 @@ func Rename(ctx context.Context, s Snapshot, f FileHandle, pp protocol.Position,
 	// Check that the renaming of the identifier is ok.
 	for _, ref := range refs {
 		r.check(ref.obj)
-		if r.hadConflicts { // one error is enough.
-			break
-		}
-	}
-	if r.hadConflicts {
-		return nil, fmt.Errorf(r.errors)
 	}
+	r.checkModules()
 
 	changes, err := r.update()
+	if r.hadConflicts {
+		if err != nil {
+			// Only the conflicts can be reported.
+			changes = nil
+		}
+		return nil, r.conflictError(ctx, s, changes)
+	}
 	if err != nil {
 		return nil, err
 	}
+	return toProtocolRenameEdits(ctx, s, changes)
+}
+
+// conflictError returns the error reporting the conflicts of r, with the
+// given edits of the renaming except those at the conflicting references.
+func (r *renamer) conflictError(ctx context.Context, s Snapshot, changes map[span.URI][]diff.TextEdit) error {
+	mappers := make(map[span.URI]*protocol.ColumnMapper)
+	location := func(pos token.Pos) (span.Span, *protocol.Location, error) {
+		spn, err := span.NewRange(r.fset, pos, pos).Span()
+		if err != nil {
+			return span.Span{}, nil, err
+		}
+		m, ok := mappers[spn.URI()]
+		if !ok {
+			fh, err := s.GetFile(ctx, spn.URI())
+			if err != nil {
+				return span.Span{}, nil, err
+			}
+			data, err := fh.Read()
+			if err != nil {
+				return span.Span{}, nil, err
+			}
+			m = protocol.NewColumnMapper(spn.URI(), data)
+			mappers[spn.URI()] = m
+		}
+		rng, err := m.Range(spn)
+		if err != nil {
+			return span.Span{}, nil, err
+		}
+		return spn, &protocol.Location{URI: protocol.URIFromSpanURI(spn.URI()), Range: rng}, nil
+	}
+
+	cerr := &RenameConflictError{msg: r.errors}
+	var sites []span.Span
+	for _, c := range r.conflicts {
+		conflict := RenameConflict{
+			Kind:    c.kind,
+			Message: strings.Join(c.lines, "\n"),
+		}
+		for i, pos := range c.positions {
+			if !pos.IsValid() {
+				continue
+			}
+			spn, loc, err := location(pos)
+			if err != nil {
+				return err
+			}
+			conflict.Locations = append(conflict.Locations, *loc)
+			// The first line refers to the renamed declaration, whose edit
+			// is kept.
+			if i > 0 {
+				sites = append(sites, spn)
+			}
+		}
+		cerr.Conflicts = append(cerr.Conflicts, conflict)
+	}
+
+	for uri, edits := range changes {
+		var kept []diff.TextEdit
+		for _, edit := range edits {
+			conflicts := false
+			for _, site := range sites {
+				if site.URI() == uri && edit.Span.Start().Offset() <= site.Start().Offset() && site.Start().Offset() < edit.Span.End().Offset() {
+					conflicts = true
+					break
+				}
+			}
+			if !conflicts {
+				kept = append(kept, edit)
+			}
+		}
+		if len(kept) > 0 {
+			changes[uri] = kept
+		} else {
+			delete(changes, uri)
+		}
+	}
+	edits, err := toProtocolRenameEdits(ctx, s, changes)
+	if err != nil {
+		return err
+	}
+	cerr.Edits = edits
+	return cerr
+}
+
+// toProtocolRenameEdits returns the protocol edits of the given edits of a
+// renaming.
+func toProtocolRenameEdits(ctx context.Context, s Snapshot, changes map[span.URI][]diff.TextEdit) (map[span.URI][]protocol.TextEdit, error) {
 	result := make(map[span.URI][]protocol.TextEdit)
 	for uri, edits := range changes {
 		// These edits should really be associated with FileHandles for maximal correctness.
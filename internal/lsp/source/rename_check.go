@@ -21,9 +21,26 @@ import (
 )
 
 // errorf reports an error (e.g. conflict) and prevents file modification.
+// Messages that start with a tab continue the description of the previous
+// conflict.
 func (r *renamer) errorf(pos token.Pos, format string, args ...interface{}) {
 	r.hadConflicts = true
-	r.errors += fmt.Sprintf(format, args...)
+	msg := fmt.Sprintf(format, args...)
+	r.errors += msg
+	if !strings.HasPrefix(msg, "\t") || len(r.conflicts) == 0 {
+		r.conflicts = append(r.conflicts, renameConflict{kind: r.kind})
+	}
+	c := &r.conflicts[len(r.conflicts)-1]
+	c.lines = append(c.lines, strings.TrimSuffix(msg, "\n"))
+	c.positions = append(c.positions, pos)
+}
+
+// conflictKind sets the kind of the conflicts that are reported, until
+// the returned function restores the previous kind.
+func (r *renamer) conflictKind(kind RenameConflictKind) func() {
+	prev := r.kind
+	r.kind = kind
+	return func() { r.kind = prev }
 }
 
 // check performs safety checks of the renaming of the 'from' object to r.to.
@@ -33,6 +50,8 @@ func (r *renamer) check(from types.Object) {
 	}
 	r.objsToUpdate[from] = true
 
+	defer r.conflictKind(ShadowingConflict)()
+
 	// NB: order of conditions is important.
 	if from_, ok := from.(*types.PkgName); ok {
 		r.checkInFileBlock(from_)
@@ -43,10 +62,12 @@ func (r *renamer) check(from types.Object) {
 	} else if v, ok := from.(*types.Var); ok && v.IsField() {
 		r.checkStructField(v)
 	} else if f, ok := from.(*types.Func); ok && recv(f) != nil {
+		r.kind = MethodSetConflict
 		r.checkMethod(f)
 	} else if isLocal(from) {
 		r.checkInLocalScope(from)
 	} else {
+		r.kind = OtherConflict
 		r.errorf(from.Pos(), "unexpected %s object %q (please report a bug)\n",
 			objectKind(from), from)
 	}
@@ -97,6 +118,7 @@ func (r *renamer) checkInPackageBlock(from types.Object) {
 
 	// Check that in the package block, "init" is a function, and never referenced.
 	if r.to == "init" {
+		restore := r.conflictKind(OtherConflict)
 		kind := objectKind(from)
 		if kind == "func" {
 			// Reject if intra-package references to it exist.
@@ -113,6 +135,7 @@ func (r *renamer) checkInPackageBlock(from types.Object) {
 			r.errorf(from.Pos(), "you cannot have a %s at package level named %q",
 				kind, r.to)
 		}
+		restore()
 	}
 
 	// Check for conflicts between package block and all file blocks.
@@ -768,6 +791,7 @@ func (r *renamer) checkExport(id *ast.Ident, pkg *types.Package, from types.Obje
 	// (Such references may be qualified identifiers or field/method
 	// selections.)
 	if !ast.IsExported(r.to) && pkg != from.Pkg() {
+		defer r.conflictKind(ExportConflict)()
 		r.errorf(from.Pos(),
 			"renaming %q to %q would make it unexported",
 			from.Name(), r.to)
@@ -778,6 +802,39 @@ func (r *renamer) checkExport(id *ast.Ident, pkg *types.Package, from types.Obje
 	return true
 }
 
+// checkModules checks that the renaming does not change the API that a
+// module exports to the other modules of the workspace, which may require
+// a version of it that keeps the old name.
+func (r *renamer) checkModules() {
+	if !ast.IsExported(r.from) {
+		return
+	}
+	defer r.conflictKind(ExportConflict)()
+
+	var decl *ReferenceInfo
+	for _, ref := range r.refs {
+		if ref.isDeclaration {
+			decl = ref
+			break
+		}
+	}
+	if decl == nil || decl.ident == nil || decl.pkg.Version() == nil {
+		return
+	}
+	mod := decl.pkg.Version().Path
+	reported := make(map[string]bool)
+	for _, ref := range r.refs {
+		v := ref.pkg.Version()
+		if ref.ident == nil || v == nil || v.Path == mod || reported[v.Path] {
+			continue
+		}
+		reported[v.Path] = true
+		r.errorf(decl.ident.Pos(), "renaming %q to %q would change the API of module %q",
+			r.from, r.to, mod)
+		r.errorf(ref.ident.Pos(), "\tused by module %q", v.Path)
+	}
+}
+
 // satisfy returns the set of interface satisfaction constraints.
 func (r *renamer) satisfy() map[satisfy.Constraint]bool {
 	if r.satisfyConstraints == nil {
@@ -792,6 +849,7 @@ func (r *renamer) satisfy() map[satisfy.Constraint]bool {
 			//
 			// Only proceed if all packages have no errors.
 			if pkg.HasListOrParseErrors() || pkg.HasTypeErrors() {
+				defer r.conflictKind(OtherConflict)()
 				r.errorf(token.NoPos, // we don't have a position for this error.
 					"renaming %q to %q not possible because %q has errors",
 					r.from, r.to, pkg.PkgPath())
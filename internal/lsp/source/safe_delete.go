@@ -0,0 +1,532 @@
+// Copyright 2022 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package source
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/token"
+	"go/types"
+	"strings"
+
+	"github.com/iansmith/golang-x-tools/go/analysis"
+	"github.com/iansmith/golang-x-tools/go/ast/astutil"
+	"github.com/iansmith/golang-x-tools/internal/lsp/protocol"
+	"github.com/iansmith/golang-x-tools/internal/span"
+	"github.com/iansmith/golang-x-tools/internal/typeparams"
+)
+
+// A deletion is a declaration selected for deletion.
+type deletion struct {
+	kind string // "function", "method", "type", "variable", or "parameter"
+	id   *ast.Ident
+	obj  types.Object
+
+	// node is the node to delete to delete the declaration, unless it
+	// declares a parameter.
+	node ast.Node
+
+	// The function of a parameter, and the index of the parameter.
+	fn    *ast.FuncDecl
+	index int
+}
+
+// CanSafeDelete reports whether rng selects the name of a function,
+// method, type, variable, or parameter in its declaration, which
+// safeDelete may delete, and returns its kind.
+func CanSafeDelete(pkg Package, pgf *ParsedGoFile, rng span.Range) (kind, name string, ok bool) {
+	d, err := selectedDeletion(pkg, pgf, rng)
+	if err != nil {
+		return "", "", false
+	}
+	return d.kind, d.id.Name, true
+}
+
+// selectedDeletion returns the declaration whose name rng selects.
+func selectedDeletion(pkg Package, pgf *ParsedGoFile, rng span.Range) (*deletion, error) {
+	path, _ := astutil.PathEnclosingInterval(pgf.File, rng.Start, rng.End)
+	if len(path) < 3 {
+		return nil, errors.New("no declaration selected")
+	}
+	id, ok := path[0].(*ast.Ident)
+	if !ok || !selectsIdent(rng, id) || id.Name == "_" {
+		return nil, errors.New("no name selected")
+	}
+	obj := pkg.GetTypesInfo().Defs[id]
+	if obj == nil {
+		return nil, errors.New("no declaration selected")
+	}
+	d := &deletion{id: id, obj: obj}
+	switch n := path[1].(type) {
+	case *ast.FuncDecl:
+		if n.Name != id {
+			return nil, errors.New("no declaration selected")
+		}
+		d.kind, d.node = "function", n
+		if n.Recv != nil {
+			d.kind = "method"
+		}
+		if err := checkDeletableFunc(pkg, pgf, n); err != nil {
+			return nil, err
+		}
+		if n.Recv == nil && (id.Name == "init" || id.Name == "main" && pkg.Name() == "main" ||
+			strings.HasSuffix(pgf.URI.Filename(), "_test.go") && testEntryRe.MatchString(id.Name)) {
+			return nil, fmt.Errorf("%s is an entry point", id.Name)
+		}
+
+	case *ast.TypeSpec:
+		decl := path[2].(*ast.GenDecl)
+		d.kind, d.node = "type", n
+		if len(decl.Specs) == 1 {
+			d.node = decl
+		}
+
+	case *ast.ValueSpec:
+		decl := path[2].(*ast.GenDecl)
+		if decl.Tok != token.VAR {
+			return nil, errors.New("no variable selected")
+		}
+		if len(n.Names) != 1 {
+			return nil, fmt.Errorf("%s is declared with other variables", id.Name)
+		}
+		d.kind, d.node = "variable", n
+		if len(decl.Specs) == 1 {
+			d.node = decl
+		}
+
+	case *ast.Field:
+		if len(path) < 4 {
+			return nil, errors.New("no parameter selected")
+		}
+		// The path omits the type of a function declaration.
+		fn, ok := path[3].(*ast.FuncDecl)
+		if !ok || path[2] != fn.Type.Params {
+			return nil, errors.New("no parameter selected")
+		}
+		if err := checkDeletableFunc(pkg, pgf, fn); err != nil {
+			return nil, err
+		}
+		d.kind, d.fn = "parameter", fn
+		for _, field := range fn.Type.Params.List {
+			for _, name := range field.Names {
+				if name == id {
+					return d, nil
+				}
+				d.index++
+			}
+		}
+		return nil, errors.New("no parameter selected")
+
+	default:
+		return nil, errors.New("no declaration selected")
+	}
+	return d, nil
+}
+
+// checkDeletableFunc returns an error if the declaration or the signature
+// of the function decl of pgf may be needed without a reference to it.
+func checkDeletableFunc(pkg Package, pgf *ParsedGoFile, decl *ast.FuncDecl) error {
+	if decl.Body == nil {
+		return fmt.Errorf("%s has no body", decl.Name.Name)
+	}
+	if hasExportDirective(decl.Doc) {
+		return fmt.Errorf("%s is exported to C", decl.Name.Name)
+	}
+	for _, cg := range pgf.File.Comments {
+		for _, c := range cg.List {
+			if fields := strings.Fields(c.Text); len(fields) >= 2 && fields[0] == "//go:linkname" && fields[1] == decl.Name.Name {
+				return fmt.Errorf("%s is named by a //go:linkname directive", decl.Name.Name)
+			}
+		}
+	}
+	return nil
+}
+
+// safeDelete deletes the function, method, type, variable, or parameter
+// whose name pRng selects, along with the imports that only its
+// declaration uses. The methods of a type are deleted with it, and the
+// arguments of a parameter are deleted from the calls of its function.
+// The deletion fails if the declaration is used anywhere else, according
+// to a search for its references: by the packages of the workspace that
+// import its package, and through the interfaces that a method
+// implements. It also fails if the name of a method, or of a method whose
+// parameter is deleted, is that of a method of an interface, which the
+// method may implement without a reference, if a deleted initializer or
+// argument may have side effects, or if the deletion would cause type
+// errors.
+func safeDelete(ctx context.Context, snapshot Snapshot, fh VersionedFileHandle, pRng protocol.Range) (*analysis.SuggestedFix, error) {
+	pkg, pgf, err := GetParsedFile(ctx, snapshot, fh, NarrowestPackage)
+	if err != nil {
+		return nil, fmt.Errorf("GetParsedFile: %w", err)
+	}
+	rng, err := pgf.Mapper.RangeToSpanRange(pRng)
+	if err != nil {
+		return nil, err
+	}
+	d, err := selectedDeletion(pkg, pgf, rng)
+	if err != nil {
+		return nil, fmt.Errorf("cannot delete: %v", err)
+	}
+	if pkg.HasTypeErrors() || pkg.HasListOrParseErrors() {
+		return nil, fmt.Errorf("cannot delete %s %s: package %s has errors", d.kind, d.id.Name, pkg.PkgPath())
+	}
+	variants, err := snapshot.PackagesForFile(ctx, pgf.URI, TypecheckFull, true)
+	if err != nil {
+		return nil, err
+	}
+	s := &safeDeleter{
+		snapshot: snapshot,
+		fset:     snapshot.FileSet(),
+		files:    make(map[string]*ParsedGoFile),
+		infos:    make(map[string]*types.Info),
+		deleted:  make(map[string][][2]int),
+	}
+	if d.kind == "parameter" {
+		err = s.deleteParam(ctx, pkg, pgf, d, variants)
+	} else {
+		err = s.deleteDecl(ctx, pkg, pgf, d, variants)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if len(s.blockers) > 0 {
+		return nil, fmt.Errorf("cannot delete %s %s:\n%s", d.kind, d.id.Name, strings.Join(s.blockers, "\n"))
+	}
+
+	var edits []analysis.TextEdit
+	for filename, deleted := range s.deleted {
+		pgf := s.files[filename]
+		for _, r := range deletedRanges(pgf, s.infos[filename], deleted) {
+			edits = append(edits, analysis.TextEdit{Pos: pgf.Tok.Pos(r[0]), End: pgf.Tok.Pos(r[1])})
+		}
+	}
+	edits = uniqueEdits(s.fset, edits)
+	pkgs, err := refactoringPackages(ctx, snapshot, pgf.URI)
+	if err != nil {
+		return nil, err
+	}
+	errs, err := checkEdits(ctx, snapshot, pkgs, edits)
+	if err != nil {
+		return nil, err
+	}
+	if len(errs) > 0 {
+		return nil, fmt.Errorf("cannot delete %s %s, which would cause errors:\n%s", d.kind, d.id.Name, strings.Join(errs, "\n"))
+	}
+	return &analysis.SuggestedFix{TextEdits: edits}, nil
+}
+
+// A safeDeleter collects the ranges of the files to delete, and the
+// reasons not to delete them.
+type safeDeleter struct {
+	snapshot Snapshot
+	fset     *token.FileSet
+	files    map[string]*ParsedGoFile
+	infos    map[string]*types.Info
+	deleted  map[string][][2]int
+	blockers []string
+}
+
+// delete records the range [start, end) of pgf of pkg to delete.
+func (s *safeDeleter) delete(pkg Package, pgf *ParsedGoFile, start, end int) {
+	filename := pgf.URI.Filename()
+	if _, ok := s.files[filename]; !ok {
+		s.files[filename] = pgf
+		s.infos[filename] = pkg.GetTypesInfo()
+	}
+	s.deleted[filename] = append(s.deleted[filename], [2]int{start, end})
+}
+
+// deleteLines records the lines of the node n of pgf of pkg to delete.
+func (s *safeDeleter) deleteLines(pkg Package, pgf *ParsedGoFile, n ast.Node) error {
+	start, end, err := nodeOffsets(pgf, n)
+	if err != nil {
+		return err
+	}
+	start, end, ok := lineRange(pgf.Src, start, end)
+	if !ok {
+		return fmt.Errorf("cannot delete the declaration at %s, which shares its lines with other code", s.fset.Position(n.Pos()))
+	}
+	s.delete(pkg, pgf, start, end)
+	return nil
+}
+
+// isDeleted reports whether pos is within the ranges to delete.
+func (s *safeDeleter) isDeleted(pos token.Pos) bool {
+	p := s.fset.Position(pos)
+	for _, r := range s.deleted[p.Filename] {
+		if r[0] <= p.Offset && p.Offset < r[1] {
+			return true
+		}
+	}
+	return false
+}
+
+// references returns the references to the object declared by id, in pgf,
+// including those through the interfaces it implements.
+func (s *safeDeleter) references(ctx context.Context, pgf *ParsedGoFile, id *ast.Ident, interfaceRefs bool) ([]*ReferenceInfo, error) {
+	rng, err := NewMappedRange(s.fset, pgf.Mapper, id.Pos(), id.End()).Range()
+	if err != nil {
+		return nil, err
+	}
+	qos, err := qualifiedObjsAtProtocolPos(ctx, s.snapshot, pgf.URI, rng.Start)
+	if err != nil {
+		return nil, err
+	}
+	return references(ctx, s.snapshot, qos, false, interfaceRefs, false)
+}
+
+// deleteDecl records the ranges to delete the declaration d of pgf of pkg,
+// along with the methods of a type in the variants of pkg.
+func (s *safeDeleter) deleteDecl(ctx context.Context, pkg Package, pgf *ParsedGoFile, d *deletion, variants []Package) error {
+	if err := s.deleteLines(pkg, pgf, d.node); err != nil {
+		return err
+	}
+	type decl struct {
+		pgf  *ParsedGoFile
+		id   *ast.Ident
+		name string
+	}
+	decls := []decl{{pgf, d.id, d.id.Name}}
+	switch d.kind {
+	case "type":
+		seen := make(map[string]bool)
+		for _, p := range variants {
+			for _, f := range p.CompiledGoFiles() {
+				if seen[f.URI.Filename()] {
+					continue
+				}
+				seen[f.URI.Filename()] = true
+				for _, fd := range f.File.Decls {
+					fd, ok := fd.(*ast.FuncDecl)
+					if !ok || fd.Recv == nil || len(fd.Recv.List) != 1 {
+						continue
+					}
+					recv := receiverTypeName(fd.Recv.List[0].Type)
+					if recv == nil || !sameObject(s.fset, p.GetTypesInfo().Uses[recv], d.obj) {
+						continue
+					}
+					if err := s.deleteLines(p, f, fd); err != nil {
+						return err
+					}
+					decls = append(decls, decl{f, fd.Name, recv.Name + "." + fd.Name.Name})
+				}
+			}
+		}
+	case "method":
+		if s.implementsInterface(variants, d.id.Name) {
+			s.blockers = append(s.blockers, fmt.Sprintf("%s may implement a method of an interface", d.id.Name))
+		}
+	case "variable":
+		spec := d.node
+		if gd, ok := spec.(*ast.GenDecl); ok {
+			spec = gd.Specs[0]
+		}
+		for _, v := range spec.(*ast.ValueSpec).Values {
+			if hasSideEffects(pkg.GetTypesInfo(), v) {
+				s.blockers = append(s.blockers, fmt.Sprintf("%s: the initializer of %s may have side effects", s.fset.Position(v.Pos()), d.id.Name))
+			}
+		}
+	}
+	for _, decl := range decls {
+		refs, err := s.references(ctx, decl.pgf, decl.id, true)
+		if err != nil {
+			return err
+		}
+		for _, ref := range refs {
+			if !s.isDeleted(ref.ident.Pos()) {
+				s.blockers = append(s.blockers, fmt.Sprintf("%s: %s is used", s.fset.Position(ref.ident.Pos()), decl.name))
+			}
+		}
+	}
+	return nil
+}
+
+// implementsInterface reports whether name is the name of a method of an
+// interface of the variants or the packages that they import.
+func (s *safeDeleter) implementsInterface(variants []Package, name string) bool {
+	u := &unusedFinder{ifaceMethods: make(map[string]bool)}
+	seen := make(map[*types.Package]bool)
+	for _, p := range variants {
+		u.collectInterfaces(p, seen)
+	}
+	return u.ifaceMethods[name]
+}
+
+// deleteParam records the ranges to delete the parameter d of pgf of pkg,
+// and its arguments in the calls of its function.
+func (s *safeDeleter) deleteParam(ctx context.Context, pkg Package, pgf *ParsedGoFile, d *deletion, variants []Package) error {
+	info := pkg.GetTypesInfo()
+	for id, obj := range info.Uses {
+		if obj == d.obj {
+			s.blockers = append(s.blockers, fmt.Sprintf("%s: parameter %s is used", s.fset.Position(id.Pos()), d.id.Name))
+		}
+	}
+	if d.fn.Recv != nil && s.implementsInterface(variants, d.fn.Name.Name) {
+		s.blockers = append(s.blockers, fmt.Sprintf("%s may implement a method of an interface", d.fn.Name.Name))
+	}
+	sig, ok := info.Defs[d.fn.Name].Type().(*types.Signature)
+	if !ok {
+		return errors.New("no type information")
+	}
+	variadic := sig.Variadic() && d.index == sig.Params().Len()-1
+
+	// Delete the name of the parameter, or its field if it is the only
+	// one it declares.
+	var (
+		items []ast.Node
+		item  int
+		index int
+	)
+	for i, field := range d.fn.Type.Params.List {
+		for j := range field.Names {
+			if index == d.index {
+				if len(field.Names) == 1 {
+					for _, f := range d.fn.Type.Params.List {
+						items = append(items, f)
+					}
+					item = i
+				} else {
+					for _, name := range field.Names {
+						items = append(items, name)
+					}
+					item = j
+				}
+			}
+			index++
+		}
+	}
+	startPos, endPos := listItemRange(items, item, item+1)
+	start, end, err := posOffsets(pgf.Tok, startPos, endPos)
+	if err != nil {
+		return err
+	}
+	s.delete(pkg, pgf, start, end)
+
+	// Delete the arguments of the calls of the function.
+	refs, err := s.references(ctx, pgf, d.fn.Name, false)
+	if err != nil {
+		return err
+	}
+	for _, ref := range refs {
+		pos := s.fset.Position(ref.ident.Pos())
+		rpgf, err := ref.pkg.File(ref.URI())
+		if err != nil {
+			return err
+		}
+		rinfo := ref.pkg.GetTypesInfo()
+		call, recv := calledIdent(rpgf.File, rinfo, ref.ident)
+		if call == nil {
+			s.blockers = append(s.blockers, fmt.Sprintf("%s: %s is used as a value", pos, d.fn.Name.Name))
+			continue
+		}
+		// A method expression is called with the receiver first.
+		first := d.index
+		if recv {
+			first++
+		}
+		if len(call.Args) == 1 && first+sig.Params().Len() > 1 {
+			s.blockers = append(s.blockers, fmt.Sprintf("%s: the arguments of %s are the results of a call", pos, d.fn.Name.Name))
+			continue
+		}
+		last := first + 1
+		if variadic {
+			last = len(call.Args)
+		}
+		if first >= len(call.Args) {
+			continue // no variadic arguments
+		}
+		args := make([]ast.Node, len(call.Args))
+		for i, arg := range call.Args {
+			args[i] = arg
+			if first <= i && i < last && hasSideEffects(rinfo, arg) {
+				s.blockers = append(s.blockers, fmt.Sprintf("%s: the argument for %s may have side effects", s.fset.Position(arg.Pos()), d.id.Name))
+			}
+		}
+		start, stop := listItemRange(args, first, last)
+		if variadic && call.Ellipsis.IsValid() {
+			stop = call.Ellipsis + token.Pos(len("..."))
+		}
+		startOffset, stopOffset, err := posOffsets(rpgf.Tok, start, stop)
+		if err != nil {
+			return err
+		}
+		s.delete(ref.pkg, rpgf, startOffset, stopOffset)
+	}
+	return nil
+}
+
+// listItemRange returns the range to delete to delete the items of list,
+// a comma separated list, from index i to index j, along with a separating
+// comma.
+func listItemRange(list []ast.Node, i, j int) (token.Pos, token.Pos) {
+	switch {
+	case j < len(list):
+		return list[i].Pos(), list[j].Pos()
+	case i > 0:
+		return list[i-1].End(), list[j-1].End()
+	}
+	return list[0].Pos(), list[j-1].End()
+}
+
+// calledIdent returns the call of file whose function is the identifier
+// id, possibly qualified or instantiated, or nil, and whether it is a
+// method expression, which takes the receiver as its first argument.
+func calledIdent(file *ast.File, info *types.Info, id *ast.Ident) (*ast.CallExpr, bool) {
+	path, _ := astutil.PathEnclosingInterval(file, id.Pos(), id.End())
+	var (
+		child ast.Node = id
+		recv  bool
+	)
+	for _, n := range path[1:] {
+		switch n := n.(type) {
+		case *ast.SelectorExpr:
+			if n.Sel != child {
+				return nil, false
+			}
+			if sel, ok := info.Selections[n]; ok && sel.Kind() == types.MethodExpr {
+				recv = true
+			}
+		case *ast.ParenExpr:
+		case *ast.IndexExpr, *typeparams.IndexListExpr:
+			if x, _, _, _ := typeparams.UnpackIndexExpr(n); x != child {
+				return nil, false
+			}
+		case *ast.CallExpr:
+			if n.Fun != child {
+				return nil, false
+			}
+			return n, recv
+		default:
+			return nil, false
+		}
+		child = n
+	}
+	return nil, false
+}
+
+// hasSideEffects reports whether the evaluation of e may have side
+// effects. The calls of functions, except for conversions, and the
+// receive operations are assumed to have some.
+func hasSideEffects(info *types.Info, e ast.Expr) bool {
+	effects := false
+	ast.Inspect(e, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.FuncLit:
+			return false // its body is not evaluated
+		case *ast.CallExpr:
+			if !info.Types[n.Fun].IsType() {
+				effects = true
+			}
+		case *ast.UnaryExpr:
+			if n.Op == token.ARROW {
+				effects = true
+			}
+		}
+		return !effects
+	})
+	return effects
+}
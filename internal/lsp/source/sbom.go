@@ -0,0 +1,334 @@
+// Copyright 2022 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package source
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/iansmith/golang-x-tools/go/packages"
+	"github.com/iansmith/golang-x-tools/internal/event"
+	"github.com/iansmith/golang-x-tools/internal/lsp/command"
+)
+
+// The formats of the documents of GenerateSBOM.
+const (
+	SBOMCycloneDX = "CycloneDX"
+	SBOMSPDX      = "SPDX"
+)
+
+// GenerateSBOM returns a software bill of materials, in the given format,
+// of the modules of the packages loaded in snapshot and of the
+// dependencies between them. If vulns is set, it also lists the known
+// vulnerabilities of the packages that the active packages import. The
+// tool that generates the document is recorded as gopls at toolVersion.
+func GenerateSBOM(ctx context.Context, snapshot Snapshot, format, toolVersion string, vulns bool) ([]byte, error) {
+	ctx, done := event.Start(ctx, "source.GenerateSBOM")
+	defer done()
+
+	mods, graph, err := snapshot.ModuleGraph(ctx)
+	if err != nil {
+		return nil, err
+	}
+	bom := &sbom{
+		mods:    mods,
+		graph:   graph,
+		created: time.Now().UTC().Format(time.RFC3339),
+		tool:    toolVersion,
+	}
+	for path := range mods {
+		bom.paths = append(bom.paths, path)
+	}
+	sort.Strings(bom.paths)
+	if vulns {
+		if bom.vulns, err = sbomVulnerabilities(ctx, snapshot); err != nil {
+			return nil, err
+		}
+	}
+
+	var doc interface{}
+	switch format {
+	case "", SBOMCycloneDX:
+		doc = bom.cycloneDX()
+	case SBOMSPDX:
+		doc = bom.spdx()
+	default:
+		return nil, fmt.Errorf("unknown SBOM format %q: want %q or %q", format, SBOMCycloneDX, SBOMSPDX)
+	}
+	data, err := json.MarshalIndent(doc, "", "\t")
+	if err != nil {
+		return nil, err
+	}
+	return append(data, '\n'), nil
+}
+
+// sbomVulnerabilities returns the known vulnerabilities of the packages
+// that the active packages of snapshot import, once each, sorted by ID and
+// package path.
+func sbomVulnerabilities(ctx context.Context, snapshot Snapshot) ([]command.Vuln, error) {
+	pkgs, err := snapshot.ActivePackages(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var all []command.Vuln
+	seen := make(map[[2]string]bool)
+	for _, pkg := range pkgs {
+		vulns, err := snapshot.Vulnerabilities(ctx, pkg.ID())
+		if err != nil {
+			return nil, err
+		}
+		for _, v := range vulns {
+			if key := [2]string{v.ID, v.PkgPath}; !seen[key] {
+				seen[key] = true
+				all = append(all, v)
+			}
+		}
+	}
+	sort.Slice(all, func(i, j int) bool {
+		if all[i].ID != all[j].ID {
+			return all[i].ID < all[j].ID
+		}
+		return all[i].PkgPath < all[j].PkgPath
+	})
+	return all, nil
+}
+
+// An sbom holds the contents of a generated SBOM document.
+type sbom struct {
+	mods    map[string]*packages.Module
+	paths   []string // sorted keys of mods
+	graph   map[string][]string
+	vulns   []command.Vuln
+	created string
+	tool    string
+}
+
+// version returns the version of the module with the given path, taking
+// replacements into account.
+func (b *sbom) version(path string) string {
+	mod := b.mods[path]
+	if mod.Replace != nil && mod.Replace.Version != "" {
+		return mod.Replace.Version
+	}
+	return mod.Version
+}
+
+// purl returns the package URL of the module with the given path.
+func (b *sbom) purl(path string) string {
+	purl := "pkg:golang/" + path
+	if v := b.version(path); v != "" {
+		purl += "@" + v
+	}
+	return purl
+}
+
+type cdxDocument struct {
+	BOMFormat       string             `json:"bomFormat"`
+	SpecVersion     string             `json:"specVersion"`
+	Version         int                `json:"version"`
+	Metadata        cdxMetadata        `json:"metadata"`
+	Components      []cdxComponent     `json:"components"`
+	Dependencies    []cdxDependency    `json:"dependencies"`
+	Vulnerabilities []cdxVulnerability `json:"vulnerabilities,omitempty"`
+}
+
+type cdxMetadata struct {
+	Timestamp string    `json:"timestamp"`
+	Tools     []cdxTool `json:"tools"`
+}
+
+type cdxTool struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+}
+
+type cdxComponent struct {
+	Type    string `json:"type"`
+	BOMRef  string `json:"bom-ref"`
+	Name    string `json:"name"`
+	Version string `json:"version,omitempty"`
+	PURL    string `json:"purl"`
+}
+
+type cdxDependency struct {
+	Ref       string   `json:"ref"`
+	DependsOn []string `json:"dependsOn"`
+}
+
+type cdxVulnerability struct {
+	ID          string       `json:"id"`
+	Source      *cdxSource   `json:"source,omitempty"`
+	Description string       `json:"description,omitempty"`
+	Affects     []cdxAffects `json:"affects"`
+}
+
+type cdxSource struct {
+	URL string `json:"url"`
+}
+
+type cdxAffects struct {
+	Ref string `json:"ref"`
+}
+
+// cycloneDX returns the document of b in the CycloneDX 1.4 JSON format.
+func (b *sbom) cycloneDX() *cdxDocument {
+	doc := &cdxDocument{
+		BOMFormat:   "CycloneDX",
+		SpecVersion: "1.4",
+		Version:     1,
+		Metadata: cdxMetadata{
+			Timestamp: b.created,
+			Tools:     []cdxTool{{Name: "gopls", Version: b.tool}},
+		},
+		Components:   []cdxComponent{},
+		Dependencies: []cdxDependency{},
+	}
+	for _, path := range b.paths {
+		typ := "library"
+		if b.mods[path].Main {
+			typ = "application"
+		}
+		doc.Components = append(doc.Components, cdxComponent{
+			Type:    typ,
+			BOMRef:  b.purl(path),
+			Name:    path,
+			Version: b.version(path),
+			PURL:    b.purl(path),
+		})
+		dep := cdxDependency{Ref: b.purl(path), DependsOn: []string{}}
+		for _, p := range b.graph[path] {
+			dep.DependsOn = append(dep.DependsOn, b.purl(p))
+		}
+		doc.Dependencies = append(doc.Dependencies, dep)
+	}
+	for _, v := range b.vulns {
+		if _, ok := b.mods[v.ModPath]; !ok {
+			continue
+		}
+		cv := cdxVulnerability{
+			ID:          v.ID,
+			Description: fmt.Sprintf("%s has a known vulnerability", v.PkgPath),
+			Affects:     []cdxAffects{{Ref: b.purl(v.ModPath)}},
+		}
+		if v.FixedVersion != "" {
+			cv.Description += fmt.Sprintf(", fixed in %s", v.FixedVersion)
+		}
+		if v.URL != "" {
+			cv.Source = &cdxSource{URL: v.URL}
+		}
+		doc.Vulnerabilities = append(doc.Vulnerabilities, cv)
+	}
+	return doc
+}
+
+type spdxDocument struct {
+	SPDXVersion       string             `json:"spdxVersion"`
+	DataLicense       string             `json:"dataLicense"`
+	SPDXID            string             `json:"SPDXID"`
+	Name              string             `json:"name"`
+	DocumentNamespace string             `json:"documentNamespace"`
+	CreationInfo      spdxCreationInfo   `json:"creationInfo"`
+	Packages          []spdxPackage      `json:"packages"`
+	Relationships     []spdxRelationship `json:"relationships"`
+}
+
+type spdxCreationInfo struct {
+	Created  string   `json:"created"`
+	Creators []string `json:"creators"`
+}
+
+type spdxPackage struct {
+	SPDXID           string       `json:"SPDXID"`
+	Name             string       `json:"name"`
+	VersionInfo      string       `json:"versionInfo,omitempty"`
+	DownloadLocation string       `json:"downloadLocation"`
+	FilesAnalyzed    bool         `json:"filesAnalyzed"`
+	ExternalRefs     []spdxExtRef `json:"externalRefs"`
+}
+
+type spdxExtRef struct {
+	ReferenceCategory string `json:"referenceCategory"`
+	ReferenceType     string `json:"referenceType"`
+	ReferenceLocator  string `json:"referenceLocator"`
+	Comment           string `json:"comment,omitempty"`
+}
+
+type spdxRelationship struct {
+	SPDXElementID      string `json:"spdxElementId"`
+	RelationshipType   string `json:"relationshipType"`
+	RelatedSPDXElement string `json:"relatedSpdxElement"`
+}
+
+// spdx returns the document of b in the SPDX 2.2 JSON format.
+func (b *sbom) spdx() *spdxDocument {
+	name := "gopls-sbom"
+	for _, path := range b.paths {
+		if b.mods[path].Main {
+			name = path
+			break
+		}
+	}
+	doc := &spdxDocument{
+		SPDXVersion:       "SPDX-2.2",
+		DataLicense:       "CC0-1.0",
+		SPDXID:            "SPDXRef-DOCUMENT",
+		Name:              name,
+		DocumentNamespace: fmt.Sprintf("https://spdx.org/spdxdocs/%s-%s", strings.ReplaceAll(name, "/", "-"), b.created),
+		CreationInfo: spdxCreationInfo{
+			Created:  b.created,
+			Creators: []string{"Tool: gopls-" + b.tool},
+		},
+		Packages:      []spdxPackage{},
+		Relationships: []spdxRelationship{},
+	}
+	ids := make(map[string]string, len(b.paths))
+	for i, path := range b.paths {
+		ids[path] = fmt.Sprintf("SPDXRef-Package-%d", i+1)
+	}
+	for _, path := range b.paths {
+		pkg := spdxPackage{
+			SPDXID:           ids[path],
+			Name:             path,
+			VersionInfo:      b.version(path),
+			DownloadLocation: "NOASSERTION",
+			ExternalRefs: []spdxExtRef{{
+				ReferenceCategory: "PACKAGE-MANAGER",
+				ReferenceType:     "purl",
+				ReferenceLocator:  b.purl(path),
+			}},
+		}
+		for _, v := range b.vulns {
+			if v.ModPath != path || v.URL == "" {
+				continue
+			}
+			pkg.ExternalRefs = append(pkg.ExternalRefs, spdxExtRef{
+				ReferenceCategory: "SECURITY",
+				ReferenceType:     "advisory",
+				ReferenceLocator:  v.URL,
+				Comment:           fmt.Sprintf("%s: %s has a known vulnerability", v.ID, v.PkgPath),
+			})
+		}
+		doc.Packages = append(doc.Packages, pkg)
+		if b.mods[path].Main {
+			doc.Relationships = append(doc.Relationships, spdxRelationship{
+				SPDXElementID:      doc.SPDXID,
+				RelationshipType:   "DESCRIBES",
+				RelatedSPDXElement: ids[path],
+			})
+		}
+		for _, dep := range b.graph[path] {
+			doc.Relationships = append(doc.Relationships, spdxRelationship{
+				SPDXElementID:      ids[path],
+				RelationshipType:   "DEPENDS_ON",
+				RelatedSPDXElement: ids[dep],
+			})
+		}
+	}
+	return doc
+}
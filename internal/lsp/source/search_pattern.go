@@ -0,0 +1,228 @@
+// Copyright 2022 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package source
+
+import (
+	"context"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"reflect"
+	"sort"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/iansmith/golang-x-tools/go/ast/inspector"
+	"github.com/iansmith/golang-x-tools/internal/event"
+	"github.com/iansmith/golang-x-tools/internal/lsp/protocol"
+	"github.com/iansmith/golang-x-tools/internal/span"
+)
+
+// SearchPattern returns the locations of the code of the workspace packages
+// matching pattern, a Go expression or a list of statements in which, as
+// with gofmt -r, the identifiers of a single lowercase letter are
+// wildcards: a wildcard matches any expression, and the same expression
+// wherever it appears in the pattern. A list of statements matches
+// consecutive statements of a block.
+func SearchPattern(ctx context.Context, snapshot Snapshot, pattern string) ([]protocol.Location, error) {
+	ctx, done := event.Start(ctx, "source.SearchPattern")
+	defer done()
+
+	p, err := parseSearchPattern(pattern)
+	if err != nil {
+		return nil, err
+	}
+	pkgs, err := snapshot.ActivePackages(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var locs []protocol.Location
+	seen := make(map[span.URI]bool)
+	for _, pkg := range pkgs {
+		for _, pgf := range pkg.CompiledGoFiles() {
+			if seen[pgf.URI] {
+				continue
+			}
+			seen[pgf.URI] = true
+			if snapshot.FileSet().Position(pgf.File.Package).Filename != pgf.URI.Filename() {
+				continue // generated by cgo from another file
+			}
+			for _, m := range p.find(pgf.File) {
+				rng, err := NewMappedRange(snapshot.FileSet(), pgf.Mapper, m[0], m[1]).Range()
+				if err != nil {
+					return nil, err
+				}
+				locs = append(locs, protocol.Location{URI: protocol.URIFromSpanURI(pgf.URI), Range: rng})
+			}
+		}
+	}
+	sort.Slice(locs, func(i, j int) bool {
+		if locs[i].URI != locs[j].URI {
+			return locs[i].URI < locs[j].URI
+		}
+		return protocol.CompareRange(locs[i].Range, locs[j].Range) < 0
+	})
+	return locs, nil
+}
+
+// A searchPattern is a parsed pattern of SearchPattern: an expression, or a
+// list of statements.
+type searchPattern struct {
+	expr  ast.Expr
+	stmts []ast.Stmt
+}
+
+// parseSearchPattern parses pattern as an expression, or else as a list of
+// statements.
+func parseSearchPattern(pattern string) (*searchPattern, error) {
+	if expr, err := parser.ParseExpr(pattern); err == nil {
+		return &searchPattern{expr: expr}, nil
+	}
+	src := "package p; func _() {\n" + pattern + "\n}"
+	file, err := parser.ParseFile(token.NewFileSet(), "", src, 0)
+	if err != nil {
+		return nil, fmt.Errorf("invalid pattern %q: not an expression or a list of statements", pattern)
+	}
+	stmts := file.Decls[0].(*ast.FuncDecl).Body.List
+	if len(stmts) == 0 {
+		return nil, fmt.Errorf("empty pattern %q", pattern)
+	}
+	return &searchPattern{stmts: stmts}, nil
+}
+
+// find returns the start and end positions of the matches of p in file, in
+// preorder.
+func (p *searchPattern) find(file *ast.File) [][2]token.Pos {
+	var matches [][2]token.Pos
+	in := inspector.New([]*ast.File{file})
+	switch {
+	case p.expr != nil:
+		var types []ast.Node // of the nodes that may match
+		if !isWildcard(p.expr) {
+			types = []ast.Node{p.expr}
+		}
+		in.Preorder(types, func(n ast.Node) {
+			if expr, ok := n.(ast.Expr); ok && matchNode(p.expr, expr) {
+				matches = append(matches, [2]token.Pos{n.Pos(), n.End()})
+			}
+		})
+	case len(p.stmts) == 1:
+		in.Preorder([]ast.Node{p.stmts[0]}, func(n ast.Node) {
+			if matchNode(p.stmts[0], n) {
+				matches = append(matches, [2]token.Pos{n.Pos(), n.End()})
+			}
+		})
+	default:
+		in.Preorder([]ast.Node{(*ast.BlockStmt)(nil), (*ast.CaseClause)(nil), (*ast.CommClause)(nil)}, func(n ast.Node) {
+			var list []ast.Stmt
+			switch n := n.(type) {
+			case *ast.BlockStmt:
+				list = n.List
+			case *ast.CaseClause:
+				list = n.Body
+			case *ast.CommClause:
+				list = n.Body
+			}
+			for i := 0; i+len(p.stmts) <= len(list); i++ {
+				if matchNode(p.stmts, list[i:i+len(p.stmts)]) {
+					matches = append(matches, [2]token.Pos{list[i].Pos(), list[i+len(p.stmts)-1].End()})
+				}
+			}
+		})
+	}
+	return matches
+}
+
+// isWildcard reports whether n is a wildcard of a pattern: an identifier of
+// a single lowercase letter.
+func isWildcard(n ast.Node) bool {
+	id, ok := n.(*ast.Ident)
+	if !ok {
+		return false
+	}
+	r, size := utf8.DecodeRuneInString(id.Name)
+	return size == len(id.Name) && unicode.IsLower(r)
+}
+
+// matchNode reports whether the syntax val matches the pattern syntax.
+func matchNode(pattern, val interface{}) bool {
+	return match(make(map[string]reflect.Value), reflect.ValueOf(pattern), reflect.ValueOf(val))
+}
+
+var (
+	identType        = reflect.TypeOf((*ast.Ident)(nil))
+	objectPtrType    = reflect.TypeOf((*ast.Object)(nil))
+	commentGroupType = reflect.TypeOf((*ast.CommentGroup)(nil))
+	positionType     = reflect.TypeOf(token.NoPos)
+	callExprType     = reflect.TypeOf((*ast.CallExpr)(nil))
+)
+
+// match reports whether val matches pattern, as in gofmt -r: m maps the
+// names of the wildcards matched so far to the expressions they matched,
+// and positions, objects and comments are ignored.
+func match(m map[string]reflect.Value, pattern, val reflect.Value) bool {
+	if m != nil && pattern.IsValid() && pattern.Type() == identType && isWildcard(pattern.Interface().(*ast.Ident)) && val.IsValid() {
+		// Wildcards only match non-nil expressions.
+		if _, ok := val.Interface().(ast.Expr); ok && !val.IsNil() {
+			name := pattern.Interface().(*ast.Ident).Name
+			if old, ok := m[name]; ok {
+				return match(nil, old, val)
+			}
+			m[name] = val
+			return true
+		}
+	}
+
+	if !pattern.IsValid() || !val.IsValid() {
+		return !pattern.IsValid() && !val.IsValid()
+	}
+	if pattern.Type() != val.Type() {
+		return false
+	}
+	switch pattern.Type() {
+	case identType:
+		p := pattern.Interface().(*ast.Ident)
+		v := val.Interface().(*ast.Ident)
+		return p == nil && v == nil || p != nil && v != nil && p.Name == v.Name
+	case objectPtrType, commentGroupType, positionType:
+		return true
+	case callExprType:
+		// f(x) and f(x...) differ only by the position of the ellipsis.
+		p := pattern.Interface().(*ast.CallExpr)
+		v := val.Interface().(*ast.CallExpr)
+		if p != nil && v != nil && p.Ellipsis.IsValid() != v.Ellipsis.IsValid() {
+			return false
+		}
+	}
+
+	p := reflect.Indirect(pattern)
+	v := reflect.Indirect(val)
+	if !p.IsValid() || !v.IsValid() {
+		return !p.IsValid() && !v.IsValid()
+	}
+	switch p.Kind() {
+	case reflect.Slice:
+		if p.Len() != v.Len() {
+			return false
+		}
+		for i := 0; i < p.Len(); i++ {
+			if !match(m, p.Index(i), v.Index(i)) {
+				return false
+			}
+		}
+		return true
+	case reflect.Struct:
+		for i := 0; i < p.NumField(); i++ {
+			if !match(m, p.Field(i), v.Field(i)) {
+				return false
+			}
+		}
+		return true
+	case reflect.Interface:
+		return match(m, p.Elem(), v.Elem())
+	}
+	return p.Interface() == v.Interface()
+}
@@ -0,0 +1,70 @@
+// Copyright 2022 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package source
+
+import (
+	"go/parser"
+	"go/token"
+	"reflect"
+	"testing"
+)
+
+func TestSearchPattern(t *testing.T) {
+	const src = `package p
+
+import "fmt"
+
+func f(a, b []int, err error) {
+	fmt.Println(a, a)
+	fmt.Println(a, b)
+	fmt.Println(b...)
+	if err != nil {
+		return
+	}
+	x := len(a)
+	fmt.Println(x)
+	switch {
+	case len(b) > 0:
+		y := len(b)
+		fmt.Println(y)
+	}
+}
+`
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "p.go", src, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		pattern string
+		want    []string
+	}{
+		{"fmt.Println(x, x)", []string{"fmt.Println(a, a)"}},
+		{"fmt.Println(x, y)", []string{"fmt.Println(a, a)", "fmt.Println(a, b)"}},
+		{"fmt.Println(x...)", []string{"fmt.Println(b...)"}},
+		{"len(x)", []string{"len(a)", "len(b)", "len(b)"}},
+		{"if e != nil { return }", []string{"if err != nil {\n\t\treturn\n\t}"}},
+		{"v := len(s); fmt.Println(v)", []string{"x := len(a)\n\tfmt.Println(x)", "y := len(b)\n\t\tfmt.Println(y)"}},
+		{"fmt.Printf(x)", nil},
+	}
+	for _, test := range tests {
+		p, err := parseSearchPattern(test.pattern)
+		if err != nil {
+			t.Errorf("parseSearchPattern(%q): %v", test.pattern, err)
+			continue
+		}
+		var got []string
+		for _, m := range p.find(file) {
+			got = append(got, src[fset.Position(m[0]).Offset:fset.Position(m[1]).Offset])
+		}
+		if !reflect.DeepEqual(got, test.want) {
+			t.Errorf("matches of %q = %q, want %q", test.pattern, got, test.want)
+		}
+	}
+
+	if _, err := parseSearchPattern("if {"); err == nil {
+		t.Errorf("parseSearchPattern succeeded on an invalid pattern")
+	}
+}
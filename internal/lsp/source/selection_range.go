@@ -0,0 +1,89 @@
+// Copyright 2022 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package source
+
+import (
+	"context"
+	"go/ast"
+	"go/token"
+
+	"github.com/iansmith/golang-x-tools/go/ast/astutil"
+	"github.com/iansmith/golang-x-tools/internal/event"
+	"github.com/iansmith/golang-x-tools/internal/lsp/protocol"
+)
+
+// SelectionRanges returns, for each of the positions in fh, the ranges of
+// the syntax enclosing it, innermost first, as a list linked through
+// Parent: an identifier, its expression, statement, block, function, and
+// so on up to the whole file. Clients use them to progressively expand
+// the selection. The ranges of a position within a comment start with the
+// comment and its group.
+func SelectionRanges(ctx context.Context, snapshot Snapshot, fh FileHandle, positions []protocol.Position) ([]protocol.SelectionRange, error) {
+	ctx, done := event.Start(ctx, "source.SelectionRanges")
+	defer done()
+
+	pgf, err := snapshot.ParseGo(ctx, fh, ParseFull)
+	if err != nil {
+		return nil, err
+	}
+	fset := snapshot.FileSet()
+
+	result := make([]protocol.SelectionRange, len(positions))
+	for i, position := range positions {
+		pos, err := pgf.Mapper.Pos(position)
+		if err != nil {
+			return nil, err
+		}
+		var nodes []ast.Node
+		for _, group := range pgf.File.Comments {
+			if group.Pos() <= pos && pos <= group.End() {
+				for _, c := range group.List {
+					if c.Pos() <= pos && pos <= c.End() {
+						nodes = append(nodes, c)
+					}
+				}
+				nodes = append(nodes, group)
+			}
+		}
+		path, _ := astutil.PathEnclosingInterval(pgf.File, pos, pos)
+		nodes = append(nodes, path...)
+
+		// The ranges, innermost first, each strictly enclosing the one
+		// before it: this drops the repeated ones of nodes spanning the
+		// same text, such as an expression statement and its call, and
+		// the file node for a comment before the package clause.
+		var ranges []protocol.Range
+		var lastStart, lastEnd token.Pos
+		add := func(start, end token.Pos) error {
+			if len(ranges) > 0 && (start > lastStart || end < lastEnd || start == lastStart && end == lastEnd) {
+				return nil
+			}
+			rng, err := NewMappedRange(fset, pgf.Mapper, start, end).Range()
+			if err != nil {
+				return err
+			}
+			ranges = append(ranges, rng)
+			lastStart, lastEnd = start, end
+			return nil
+		}
+		for _, n := range nodes {
+			if err := add(n.Pos(), n.End()); err != nil {
+				return nil, err
+			}
+		}
+		// The file node does not span the comments before the package
+		// clause, so finish with the whole file.
+		if err := add(pgf.Tok.Pos(0), pgf.Tok.Pos(pgf.Tok.Size())); err != nil {
+			return nil, err
+		}
+
+		var parent *protocol.SelectionRange
+		for j := len(ranges) - 1; j > 0; j-- {
+			parent = &protocol.SelectionRange{Range: ranges[j], Parent: parent}
+		}
+		result[i] = protocol.SelectionRange{Range: ranges[0], Parent: parent}
+	}
+	return result, nil
+}
@@ -8,12 +8,16 @@ import (
 	"context"
 	"fmt"
 	"go/ast"
+	"go/doc"
+	"go/scanner"
 	"go/token"
 	"go/types"
+	"strings"
 
 	"github.com/iansmith/golang-x-tools/go/ast/astutil"
 	"github.com/iansmith/golang-x-tools/internal/event"
 	"github.com/iansmith/golang-x-tools/internal/lsp/protocol"
+	"github.com/iansmith/golang-x-tools/internal/typeparams"
 )
 
 func SignatureHelp(ctx context.Context, snapshot Snapshot, fh FileHandle, position protocol.Position) (*protocol.SignatureInformation, int, error) {
@@ -63,17 +67,32 @@ FindCall:
 	// Get the object representing the function, if available.
 	// There is no object in certain cases such as calling a function returned by
 	// a function (e.g. "foo()()").
-	var obj types.Object
-	switch t := callExpr.Fun.(type) {
+	var (
+		obj   types.Object
+		ident *ast.Ident
+	)
+	fun := callExpr.Fun
+	if x, _, _, _ := typeparams.UnpackIndexExpr(fun); x != nil {
+		fun = x // an explicit instantiation, such as "Map[int]"
+	}
+	switch t := fun.(type) {
 	case *ast.Ident:
-		obj = pkg.GetTypesInfo().ObjectOf(t)
+		ident = t
 	case *ast.SelectorExpr:
-		obj = pkg.GetTypesInfo().ObjectOf(t.Sel)
+		ident = t.Sel
+	}
+	if ident != nil {
+		obj = pkg.GetTypesInfo().ObjectOf(ident)
 	}
 
 	// Handle builtin functions separately.
 	if obj, ok := obj.(*types.Builtin); ok {
-		return builtinSignature(ctx, snapshot, callExpr, obj.Name(), pos)
+		return builtinSignature(ctx, snapshot, pkg, pgf, callExpr, obj.Name(), pos, qf)
+	}
+
+	// Handle type conversions, such as "int64(x)", separately.
+	if tv, ok := pkg.GetTypesInfo().Types[callExpr.Fun]; ok && tv.IsType() {
+		return conversionSignature(ctx, snapshot, pkg, pgf, callExpr, tv.Type, pos, qf)
 	}
 
 	// Get the type information for the function being called.
@@ -87,40 +106,35 @@ FindCall:
 		return nil, 0, fmt.Errorf("cannot find signature for Fun %[1]T (%[1]v)", callExpr.Fun)
 	}
 
-	activeParam := activeParameter(callExpr, sig.Params().Len(), sig.Variadic(), pos)
+	activeParam := activeParameter(pgf, callExpr, sig.Params().Len(), pos)
 
 	var (
 		name    string
 		comment *ast.CommentGroup
 	)
 	if obj != nil {
-		declPkg, err := FindPackageFromPos(ctx, snapshot, obj.Pos())
-		if err != nil {
-			return nil, 0, err
-		}
-		node, err := snapshot.PosToDecl(ctx, declPkg, obj.Pos())
-		if err != nil {
-			return nil, 0, err
-		}
-		rng, err := objToMappedRange(snapshot, pkg, obj)
-		if err != nil {
-			return nil, 0, err
-		}
-		decl := Declaration{
-			obj:  obj,
-			node: node,
-		}
-		decl.MappedRange = append(decl.MappedRange, rng)
-		d, err := FindHoverContext(ctx, snapshot, pkg, decl.obj, decl.node, nil)
+		comment, err = declComment(ctx, snapshot, pkg, obj)
 		if err != nil {
 			return nil, 0, err
 		}
 		name = obj.Name()
-		comment = d.Comment
 	} else {
 		name = "func"
 	}
 	s := NewSignature(ctx, snapshot, pkg, sig, comment, qf)
+
+	// Show the type arguments of a generic function, or its type parameters
+	// if they aren't inferred yet.
+	if inst, ok := typeparams.GetInstances(pkg.GetTypesInfo())[ident]; ident != nil && ok {
+		var targs []string
+		for i := 0; i < inst.TypeArgs.Len(); i++ {
+			targs = append(targs, types.TypeString(inst.TypeArgs.At(i), qf))
+		}
+		name += "[" + strings.Join(targs, ", ") + "]"
+	} else if tparams := s.TypeParams(); len(tparams) > 0 {
+		name += "[" + strings.Join(tparams, ", ") + "]"
+	}
+
 	paramInfo := make([]protocol.ParameterInformation, 0, len(s.params))
 	for _, p := range s.params {
 		paramInfo = append(paramInfo, protocol.ParameterInformation{Label: p})
@@ -132,46 +146,210 @@ FindCall:
 	}, activeParam, nil
 }
 
-func builtinSignature(ctx context.Context, snapshot Snapshot, callExpr *ast.CallExpr, name string, pos token.Pos) (*protocol.SignatureInformation, int, error) {
+// declComment returns the doc comment of the declaration of obj.
+func declComment(ctx context.Context, snapshot Snapshot, pkg Package, obj types.Object) (*ast.CommentGroup, error) {
+	declPkg, err := FindPackageFromPos(ctx, snapshot, obj.Pos())
+	if err != nil {
+		return nil, err
+	}
+	node, err := snapshot.PosToDecl(ctx, declPkg, obj.Pos())
+	if err != nil {
+		return nil, err
+	}
+	rng, err := objToMappedRange(snapshot, pkg, obj)
+	if err != nil {
+		return nil, err
+	}
+	decl := Declaration{
+		obj:  obj,
+		node: node,
+	}
+	decl.MappedRange = append(decl.MappedRange, rng)
+	d, err := FindHoverContext(ctx, snapshot, pkg, decl.obj, decl.node, nil)
+	if err != nil {
+		return nil, err
+	}
+	return d.Comment, nil
+}
+
+func builtinSignature(ctx context.Context, snapshot Snapshot, pkg Package, pgf *ParsedGoFile, callExpr *ast.CallExpr, name string, pos token.Pos, qf types.Qualifier) (*protocol.SignatureInformation, int, error) {
 	sig, err := NewBuiltinSignature(ctx, snapshot, name)
 	if err != nil {
 		return nil, 0, err
 	}
+	label := sig.name + sig.Format()
 	paramInfo := make([]protocol.ParameterInformation, 0, len(sig.params))
-	for _, p := range sig.params {
-		paramInfo = append(paramInfo, protocol.ParameterInformation{Label: p})
+	if params, result, ok := typedBuiltinParams(pkg.GetTypesInfo(), callExpr, name, qf); ok {
+		var labels []string
+		for _, p := range params {
+			typ := p.typ
+			if p.variadic {
+				typ = "..." + typ
+			}
+			labels = append(labels, p.name+" "+typ)
+			paramInfo = append(paramInfo, protocol.ParameterInformation{
+				Label:         p.name + " " + typ,
+				Documentation: p.doc,
+			})
+		}
+		label = name + "(" + strings.Join(labels, ", ") + ") " + result
+	} else {
+		for _, p := range sig.params {
+			paramInfo = append(paramInfo, protocol.ParameterInformation{Label: p})
+		}
 	}
-	activeParam := activeParameter(callExpr, len(sig.params), sig.variadic, pos)
+	activeParam := activeParameter(pgf, callExpr, len(paramInfo), pos)
 	return &protocol.SignatureInformation{
-		Label:         sig.name + sig.Format(),
+		Label:         label,
 		Documentation: sig.doc,
 		Parameters:    paramInfo,
 	}, activeParam, nil
+}
 
+// A builtinParam is a parameter of a builtin function, typed by the
+// arguments of a call.
+type builtinParam struct {
+	name, typ, doc string
+	variadic       bool
 }
 
-func activeParameter(callExpr *ast.CallExpr, numParams int, variadic bool, pos token.Pos) (activeParam int) {
+// typedBuiltinParams returns the parameters and result of the call of the
+// builtin make, append or copy, typed by the type of its first argument.
+// It reports false for other builtins, or if the type isn't known yet.
+func typedBuiltinParams(info *types.Info, callExpr *ast.CallExpr, name string, qf types.Qualifier) ([]builtinParam, string, bool) {
+	if len(callExpr.Args) == 0 {
+		return nil, "", false
+	}
+	t := info.TypeOf(callExpr.Args[0])
+	if t == nil {
+		return nil, "", false
+	}
+	typ := types.TypeString(t, qf)
+	switch name {
+	case "make":
+		switch t.Underlying().(type) {
+		case *types.Slice:
+			return []builtinParam{
+				{name: "t", typ: typ, doc: "The type of the slice."},
+				{name: "len", typ: "int", doc: "The length of the slice."},
+				{name: "cap", typ: "int", doc: "The capacity of the slice, at least len. It is len if omitted."},
+			}, typ, true
+		case *types.Map:
+			return []builtinParam{
+				{name: "t", typ: typ, doc: "The type of the map."},
+				{name: "size", typ: "int", doc: "A hint of the number of elements of the map, which may be omitted."},
+			}, typ, true
+		case *types.Chan:
+			return []builtinParam{
+				{name: "t", typ: typ, doc: "The type of the channel."},
+				{name: "size", typ: "int", doc: "The buffer capacity of the channel. The channel is unbuffered if it is zero or omitted."},
+			}, typ, true
+		}
+	case "append":
+		if slice, ok := t.Underlying().(*types.Slice); ok {
+			return []builtinParam{
+				{name: "slice", typ: typ, doc: "The slice to which the elements are appended. It is returned if it has enough capacity."},
+				{name: "elems", typ: types.TypeString(slice.Elem(), qf), doc: "The elements to append. A string can be appended to a []byte as s...", variadic: true},
+			}, typ, true
+		}
+	case "copy":
+		if _, ok := t.Underlying().(*types.Slice); ok {
+			return []builtinParam{
+				{name: "dst", typ: typ, doc: "The destination slice."},
+				{name: "src", typ: typ, doc: "The source slice, or a string if dst is a []byte. The minimum of len(dst) and len(src) elements are copied."},
+			}, "int", true
+		}
+	}
+	return nil, "", false
+}
+
+// conversionSignature returns the signature of the conversion callExpr to
+// typ, with the doc comment of typ if it is a named type.
+func conversionSignature(ctx context.Context, snapshot Snapshot, pkg Package, pgf *ParsedGoFile, callExpr *ast.CallExpr, typ types.Type, pos token.Pos, qf types.Qualifier) (*protocol.SignatureInformation, int, error) {
+	name := types.TypeString(typ, qf)
+	switch typ.(type) {
+	case *types.Pointer, *types.Signature, *types.Chan:
+		name = "(" + name + ")" // as in "(*T)(x)"
+	}
+	var d string
+	if named, ok := typ.(*types.Named); ok && named.Obj().Pkg() != nil {
+		comment, err := declComment(ctx, snapshot, pkg, named.Obj())
+		if err != nil {
+			return nil, 0, err
+		}
+		if comment != nil {
+			d = comment.Text()
+		}
+		switch snapshot.View().Options().HoverKind {
+		case SynopsisDocumentation:
+			d = doc.Synopsis(d)
+		case NoDocumentation:
+			d = ""
+		}
+	}
+	return &protocol.SignatureInformation{
+		Label:         name + "(x) " + types.TypeString(typ, qf),
+		Documentation: d,
+		Parameters: []protocol.ParameterInformation{{
+			Label:         "x",
+			Documentation: "The value to convert to " + types.TypeString(typ, qf) + ".",
+		}},
+	}, activeParameter(pgf, callExpr, 1, pos), nil
+}
+
+// activeParameter returns the index of the parameter of the argument of
+// callExpr at pos. Arguments end at the comma that follows them, so that
+// the space before a comma, or on the line after the last argument of a
+// multi-line call, belongs to the preceding argument.
+func activeParameter(pgf *ParsedGoFile, callExpr *ast.CallExpr, numParams int, pos token.Pos) (activeParam int) {
 	if len(callExpr.Args) == 0 {
 		return 0
 	}
 	// First, check if the position is even in the range of the arguments.
-	start, end := callExpr.Lparen, callExpr.Rparen
-	if !(start <= pos && pos <= end) {
+	if !(callExpr.Lparen <= pos && pos <= callExpr.Rparen) {
 		return 0
 	}
-	for _, expr := range callExpr.Args {
-		if start == token.NoPos {
-			start = expr.Pos()
-		}
-		end = expr.End()
-		if start <= pos && pos <= end {
+	for i, arg := range callExpr.Args {
+		if pos <= arg.End() {
 			break
 		}
-		// Don't advance the active parameter for the last parameter of a variadic function.
-		if !variadic || activeParam < numParams-1 {
-			activeParam++
+		end := pos
+		if i+1 < len(callExpr.Args) && callExpr.Args[i+1].Pos() < end {
+			end = callExpr.Args[i+1].Pos()
+		}
+		if !commaBetween(pgf, arg.End(), end) {
+			break
 		}
-		start = expr.Pos() + 1 // to account for commas
+		activeParam++
+	}
+	// Arguments beyond the parameters, such as those of a variadic parameter,
+	// or a trailing comma, belong to the last parameter.
+	if activeParam >= numParams && numParams > 0 {
+		activeParam = numParams - 1
 	}
 	return activeParam
 }
+
+// commaBetween reports whether there is a comma between from and to in the
+// file of pgf, ignoring comments.
+func commaBetween(pgf *ParsedGoFile, from, to token.Pos) bool {
+	if from >= to {
+		return false
+	}
+	start, end, err := posOffsets(pgf.Tok, from, to)
+	if err != nil || end > len(pgf.Src) {
+		return false
+	}
+	src := pgf.Src[start:end]
+	var s scanner.Scanner
+	s.Init(token.NewFileSet().AddFile("", -1, len(src)), src, nil, 0)
+	for {
+		_, tok, _ := s.Scan()
+		switch tok {
+		case token.COMMA:
+			return true
+		case token.EOF:
+			return false
+		}
+	}
+}
@@ -192,6 +192,7 @@ func (r *runner) CompletionSnippet(t *testing.T, src span.Span, expected tests.C
 		opts.UsePlaceholders = placeholders
 		opts.DeepCompletion = true
 		opts.CompleteUnimported = false
+		opts.ExperimentalResultAssignments = strings.Contains(string(src.URI()), "results")
 	})
 	got := tests.FindItem(list, *items[expected.CompletionItem])
 	want := expected.PlainSnippet
@@ -383,7 +384,7 @@ func (r *runner) foldingRanges(t *testing.T, prefix string, uri span.URI, data s
 	}
 
 	// Filter by kind.
-	kinds := []protocol.FoldingRangeKind{protocol.Imports, protocol.Comment}
+	kinds := []protocol.FoldingRangeKind{protocol.Imports, protocol.Comment, protocol.Region}
 	for _, kind := range kinds {
 		var kindOnly []*source.FoldingRangeInfo
 		for _, fRng := range ranges {
@@ -504,6 +505,10 @@ func (r *runner) Format(t *testing.T, spn span.Span) {
 	}
 }
 
+func (r *runner) InlayHints(t *testing.T, spn span.Span) {
+	// TODO: inlayHints not supported in source
+}
+
 func (r *runner) SemanticTokens(t *testing.T, spn span.Span) {
 	t.Skip("nothing to test in source")
 }
@@ -772,7 +777,7 @@ func (r *runner) Rename(t *testing.T, spn span.Span, newText string) {
 	if err != nil {
 		t.Fatal(err)
 	}
-	changes, err := source.Rename(r.ctx, r.snapshot, fh, srcRng.Start, newText)
+	changes, _, err := source.Rename(r.ctx, r.snapshot, fh, srcRng.Start, newText)
 	if err != nil {
 		renamed := string(r.data.Golden(tag, spn.URI().Filename(), func() ([]byte, error) {
 			return []byte(err.Error()), nil
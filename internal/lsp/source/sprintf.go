@@ -0,0 +1,434 @@
+// Copyright 2022 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package source
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/constant"
+	"go/token"
+	"go/types"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/iansmith/golang-x-tools/go/analysis"
+	"github.com/iansmith/golang-x-tools/go/ast/astutil"
+	"github.com/iansmith/golang-x-tools/go/types/typeutil"
+	"github.com/iansmith/golang-x-tools/internal/imports"
+	"github.com/iansmith/golang-x-tools/internal/lsp/protocol"
+	"github.com/iansmith/golang-x-tools/internal/span"
+)
+
+// CanConvertToSprintf reports whether rng selects a string concatenation,
+// which concatToSprintf can convert to a call of fmt.Sprintf.
+func CanConvertToSprintf(pkg Package, pgf *ParsedGoFile, rng span.Range) bool {
+	_, err := selectedConcat(pkg, pgf, rng)
+	return err == nil
+}
+
+// CanConvertToConcat reports whether rng selects a call of fmt.Sprintf,
+// which sprintfToConcat can convert to a string concatenation.
+func CanConvertToConcat(pkg Package, pgf *ParsedGoFile, rng span.Range) bool {
+	_, err := selectedSprintf(pkg, pgf, rng)
+	return err == nil
+}
+
+// concatToSprintf converts the string concatenation selected by pRng to a
+// call of fmt.Sprintf. Its string literals become the text of the format,
+// the calls of strconv.Itoa, FormatInt and FormatUint in base 10,
+// FormatBool and Quote become the %d, %t and %q verbs of their argument,
+// and the other operands become %s verbs.
+func concatToSprintf(ctx context.Context, snapshot Snapshot, fh VersionedFileHandle, pRng protocol.Range) (*analysis.SuggestedFix, error) {
+	fix, err := convertString(ctx, snapshot, fh, pRng, selectedConcat)
+	if err != nil {
+		return nil, fmt.Errorf("cannot convert to fmt.Sprintf: %v", err)
+	}
+	return fix, nil
+}
+
+// sprintfToConcat converts the call of fmt.Sprintf selected by pRng to a
+// string concatenation. Its format may only have the %s, %v, %d, %t and
+// %q verbs, without flags, of arguments whose concatenation or strconv
+// conversion is the same.
+func sprintfToConcat(ctx context.Context, snapshot Snapshot, fh VersionedFileHandle, pRng protocol.Range) (*analysis.SuggestedFix, error) {
+	fix, err := convertString(ctx, snapshot, fh, pRng, selectedSprintf)
+	if err != nil {
+		return nil, fmt.Errorf("cannot convert to a string concatenation: %v", err)
+	}
+	return fix, nil
+}
+
+// A stringConversion replaces a string expression with an equivalent one.
+type stringConversion struct {
+	expr    ast.Expr
+	text    string
+	imports []*imports.ImportFix // the imports to add or delete
+}
+
+func convertString(ctx context.Context, snapshot Snapshot, fh VersionedFileHandle, pRng protocol.Range, selected func(Package, *ParsedGoFile, span.Range) (*stringConversion, error)) (*analysis.SuggestedFix, error) {
+	pkg, pgf, err := GetParsedFile(ctx, snapshot, fh, NarrowestPackage)
+	if err != nil {
+		return nil, fmt.Errorf("GetParsedFile: %w", err)
+	}
+	rng, err := pgf.Mapper.RangeToSpanRange(pRng)
+	if err != nil {
+		return nil, err
+	}
+	c, err := selected(pkg, pgf, rng)
+	if err != nil {
+		return nil, err
+	}
+	edits := []analysis.TextEdit{{Pos: c.expr.Pos(), End: c.expr.End(), NewText: []byte(c.text)}}
+	if len(c.imports) > 0 {
+		// Delete the imports first, so that deleting the only import of a
+		// declaration after adding one does not leave it in parentheses.
+		sort.SliceStable(c.imports, func(i, j int) bool {
+			return c.imports[i].FixType == imports.DeleteImport && c.imports[j].FixType != imports.DeleteImport
+		})
+		importEdits, err := importFixEdits(ctx, snapshot, pgf, c.imports)
+		if err != nil {
+			return nil, fmt.Errorf("updating imports: %v", err)
+		}
+		edits = append(edits, importEdits...)
+	}
+	return &analysis.SuggestedFix{TextEdits: edits}, nil
+}
+
+// selectedConcat returns the conversion to fmt.Sprintf of the outermost
+// string concatenation that encloses rng.
+func selectedConcat(pkg Package, pgf *ParsedGoFile, rng span.Range) (*stringConversion, error) {
+	info := pkg.GetTypesInfo()
+	path, _ := astutil.PathEnclosingInterval(pgf.File, rng.Start, rng.End)
+	var expr ast.Expr
+	for _, n := range path {
+		if isConcat(info, n) {
+			expr = n.(ast.Expr)
+		} else if _, ok := n.(*ast.ParenExpr); !ok && expr != nil {
+			break
+		}
+	}
+	if expr == nil {
+		return nil, errors.New("no string concatenation selected")
+	}
+	if t := info.TypeOf(expr); !types.Identical(t, types.Typ[types.String]) {
+		return nil, fmt.Errorf("the concatenation has type %s, not string", t)
+	}
+	if info.Types[expr].Value != nil {
+		return nil, errors.New("the concatenation is constant")
+	}
+
+	s := &rewriteSource{tok: pgf.Tok, src: pgf.Src}
+	var format strings.Builder
+	var args []string
+	strconvCalls := 0
+	for _, op := range concatOperands(info, expr) {
+		if lit, ok := op.(*ast.BasicLit); ok && lit.Kind == token.STRING {
+			format.WriteString(strings.ReplaceAll(constant.StringVal(info.Types[lit].Value), "%", "%%"))
+			continue
+		}
+		if call, ok := op.(*ast.CallExpr); ok {
+			if verb := strconvVerb(info, call); verb != "" {
+				format.WriteString(verb)
+				args = append(args, s.node(call.Args[0]))
+				strconvCalls++
+				continue
+			}
+		}
+		// The operands of a concatenation of type string are strings, or
+		// untyped string constants.
+		format.WriteString("%s")
+		args = append(args, s.node(op))
+	}
+
+	fmtName, fixes, err := packageRef(pkg, pgf.File, expr.Pos(), "fmt")
+	if err != nil {
+		return nil, err
+	}
+	if name, spec := importedPackage(info, pgf.File, "strconv"); name != nil && strconvCalls > 0 && packageUses(info, pgf.File, name) == strconvCalls {
+		fixes = append(fixes, deleteImportFix(spec))
+	}
+	return &stringConversion{
+		expr:    expr,
+		text:    fmt.Sprintf("%s.Sprintf(%s, %s)", fmtName, strconv.Quote(format.String()), strings.Join(args, ", ")),
+		imports: fixes,
+	}, nil
+}
+
+// isConcat reports whether n is a string concatenation.
+func isConcat(info *types.Info, n ast.Node) bool {
+	b, ok := n.(*ast.BinaryExpr)
+	return ok && b.Op == token.ADD && isStringType(info.TypeOf(b))
+}
+
+// concatOperands returns the operands of the string concatenation e, with
+// those of the concatenations in parentheses that it has.
+func concatOperands(info *types.Info, e ast.Expr) []ast.Expr {
+	e = astutil.Unparen(e)
+	if isConcat(info, e) {
+		b := e.(*ast.BinaryExpr)
+		return append(concatOperands(info, b.X), concatOperands(info, b.Y)...)
+	}
+	return []ast.Expr{e}
+}
+
+// strconvVerb returns the fmt verb that formats the argument of call as
+// it does, if it is a call of a strconv function with one.
+func strconvVerb(info *types.Info, call *ast.CallExpr) string {
+	switch strconvFunc(info, call) {
+	case "Itoa":
+		return "%d"
+	case "FormatInt", "FormatUint":
+		if tv := info.Types[call.Args[1]]; tv.Value != nil && tv.Value.ExactString() == "10" {
+			return "%d"
+		}
+	case "FormatBool":
+		return "%t"
+	case "Quote":
+		return "%q"
+	}
+	return ""
+}
+
+// strconvFunc returns the name of the function of the strconv package
+// that call calls, if any.
+func strconvFunc(info *types.Info, call *ast.CallExpr) string {
+	sel, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok || call.Ellipsis.IsValid() {
+		return ""
+	}
+	id, ok := sel.X.(*ast.Ident)
+	if !ok {
+		return ""
+	}
+	if name, ok := info.Uses[id].(*types.PkgName); !ok || name.Imported().Path() != "strconv" {
+		return ""
+	}
+	return sel.Sel.Name
+}
+
+// selectedSprintf returns the conversion to a string concatenation of the
+// innermost call of fmt.Sprintf that encloses rng.
+func selectedSprintf(pkg Package, pgf *ParsedGoFile, rng span.Range) (*stringConversion, error) {
+	info := pkg.GetTypesInfo()
+	path, _ := astutil.PathEnclosingInterval(pgf.File, rng.Start, rng.End)
+	var call *ast.CallExpr
+	var parent ast.Node
+	for i, n := range path {
+		if c, ok := n.(*ast.CallExpr); ok && isSprintf(info, c) {
+			call, parent = c, path[i+1]
+			break
+		}
+	}
+	if call == nil {
+		return nil, errors.New("no call of fmt.Sprintf selected")
+	}
+	if len(call.Args) == 0 || call.Ellipsis.IsValid() {
+		return nil, errors.New("no format")
+	}
+	tv := info.Types[call.Args[0]]
+	if tv.Value == nil || tv.Value.Kind() != constant.String {
+		return nil, errors.New("the format is not a constant")
+	}
+	format := constant.StringVal(tv.Value)
+
+	// A part is either a string literal, or a conversion of an argument by
+	// a strconv function, if fn is set.
+	type part struct {
+		fn, text string
+	}
+	s := &rewriteSource{tok: pgf.Tok, src: pgf.Src}
+	var parts []part
+	var lit strings.Builder
+	args := call.Args[1:]
+	for i := 0; i < len(format); i++ {
+		if format[i] != '%' {
+			lit.WriteByte(format[i])
+			continue
+		}
+		i++
+		if i == len(format) {
+			return nil, errors.New("the format ends with %")
+		}
+		verb := format[i]
+		if verb == '%' {
+			lit.WriteByte('%')
+			continue
+		}
+		if len(args) == 0 {
+			return nil, fmt.Errorf("no argument for %%%c", verb)
+		}
+		arg := args[0]
+		args = args[1:]
+		t := info.TypeOf(arg)
+		if t == nil || hasFormatMethods(t) {
+			return nil, fmt.Errorf("%s has a String, Error or Format method", types.ExprString(arg))
+		}
+		if lit.Len() > 0 {
+			parts = append(parts, part{text: strconv.Quote(lit.String())})
+			lit.Reset()
+		}
+		text := s.node(arg)
+		basic, _ := t.Underlying().(*types.Basic)
+		switch {
+		case basic == nil:
+			return nil, fmt.Errorf("unsupported argument type %s", t)
+		case (verb == 's' || verb == 'v' || verb == 'q') && basic.Info()&types.IsString != 0:
+			if !types.Identical(t, types.Typ[types.String]) && !types.Identical(t, types.Typ[types.UntypedString]) {
+				text = "string(" + text + ")"
+			}
+			if verb == 'q' {
+				parts = append(parts, part{fn: "Quote", text: text})
+			} else {
+				parts = append(parts, part{text: text})
+			}
+		case (verb == 'd' || verb == 'v') && basic.Info()&types.IsInteger != 0:
+			switch {
+			case types.Identical(t, types.Typ[types.Int]) || types.Identical(t, types.Typ[types.UntypedInt]):
+				parts = append(parts, part{fn: "Itoa", text: text})
+			case basic.Info()&types.IsUnsigned != 0:
+				parts = append(parts, part{fn: "FormatUint", text: "uint64(" + text + "), 10"})
+			default:
+				parts = append(parts, part{fn: "FormatInt", text: "int64(" + text + "), 10"})
+			}
+		case (verb == 't' || verb == 'v') && basic.Info()&types.IsBoolean != 0:
+			parts = append(parts, part{fn: "FormatBool", text: text})
+		default:
+			return nil, fmt.Errorf("unsupported verb %%%c for %s", verb, types.ExprString(arg))
+		}
+	}
+	if len(args) > 0 {
+		return nil, errors.New("extra arguments")
+	}
+	if lit.Len() > 0 || len(parts) == 0 {
+		parts = append(parts, part{text: strconv.Quote(lit.String())})
+	}
+
+	var fixes []*imports.ImportFix
+	strconvName := ""
+	for _, p := range parts {
+		if p.fn == "" {
+			continue
+		}
+		var err error
+		strconvName, fixes, err = packageRef(pkg, pgf.File, call.Pos(), "strconv")
+		if err != nil {
+			return nil, err
+		}
+		break
+	}
+	if name, spec := importedPackage(info, pgf.File, "fmt"); name != nil && packageUses(info, pgf.File, name) == 1 {
+		fixes = append(fixes, deleteImportFix(spec))
+	}
+	var texts []string
+	for _, p := range parts {
+		if p.fn != "" {
+			texts = append(texts, fmt.Sprintf("%s.%s(%s)", strconvName, p.fn, p.text))
+		} else {
+			texts = append(texts, p.text)
+		}
+	}
+	text := strings.Join(texts, " + ")
+	if len(texts) > 1 {
+		switch parent := parent.(type) {
+		case *ast.SelectorExpr, *ast.IndexExpr, *ast.SliceExpr, *ast.TypeAssertExpr, *ast.StarExpr, *ast.UnaryExpr:
+			text = "(" + text + ")"
+		case *ast.CallExpr:
+			if parent.Fun == call {
+				text = "(" + text + ")"
+			}
+		}
+	}
+	return &stringConversion{expr: call, text: text, imports: fixes}, nil
+}
+
+// isSprintf reports whether call calls fmt.Sprintf.
+func isSprintf(info *types.Info, call *ast.CallExpr) bool {
+	fn, ok := typeutil.Callee(info, call).(*types.Func)
+	return ok && fn.Pkg() != nil && fn.Pkg().Path() == "fmt" && fn.Name() == "Sprintf"
+}
+
+// isStringType reports whether t is a string type.
+func isStringType(t types.Type) bool {
+	if t == nil {
+		return false
+	}
+	b, ok := t.Underlying().(*types.Basic)
+	return ok && b.Info()&types.IsString != 0
+}
+
+// hasFormatMethods reports whether the values of type t have a String,
+// Error or Format method, which fmt uses to format them.
+func hasFormatMethods(t types.Type) bool {
+	mset := types.NewMethodSet(t)
+	for _, name := range []string{"String", "Error", "Format"} {
+		if mset.Lookup(nil, name) != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// importedPackage returns the name that file imports the package path
+// with, and its import, if it imports it with a name other than . and _.
+func importedPackage(info *types.Info, file *ast.File, path string) (*types.PkgName, *ast.ImportSpec) {
+	for _, spec := range file.Imports {
+		obj := info.Implicits[spec]
+		if spec.Name != nil {
+			obj = info.Defs[spec.Name]
+		}
+		if name, ok := obj.(*types.PkgName); ok && name.Imported().Path() == path && name.Name() != "." && name.Name() != "_" {
+			return name, spec
+		}
+	}
+	return nil, nil
+}
+
+// packageUses returns the number of uses of name in file.
+func packageUses(info *types.Info, file *ast.File, name *types.PkgName) int {
+	n := 0
+	ast.Inspect(file, func(node ast.Node) bool {
+		if id, ok := node.(*ast.Ident); ok && info.Uses[id] == name {
+			n++
+		}
+		return true
+	})
+	return n
+}
+
+// packageRef returns the name with which the code at pos of file can
+// refer to the standard library package path, and the import to add if
+// file doesn't import it.
+func packageRef(pkg Package, file *ast.File, pos token.Pos, path string) (string, []*imports.ImportFix, error) {
+	if name, _ := importedPackage(pkg.GetTypesInfo(), file, path); name != nil {
+		return name.Name(), nil, nil
+	}
+	scope := pkg.GetTypes().Scope().Innermost(pos)
+	if scope != nil {
+		if _, obj := scope.LookupParent(path, pos); obj != nil {
+			return "", nil, fmt.Errorf("%s is shadowed by %s", path, obj)
+		}
+	}
+	return path, []*imports.ImportFix{{
+		StmtInfo:  imports.ImportInfo{ImportPath: path},
+		IdentName: path,
+		FixType:   imports.AddImport,
+	}}, nil
+}
+
+// deleteImportFix returns the fix that deletes the import spec.
+func deleteImportFix(spec *ast.ImportSpec) *imports.ImportFix {
+	path, _ := strconv.Unquote(spec.Path.Value)
+	fix := &imports.ImportFix{
+		StmtInfo: imports.ImportInfo{ImportPath: path},
+		FixType:  imports.DeleteImport,
+	}
+	if spec.Name != nil {
+		fix.StmtInfo.Name = spec.Name.Name
+	}
+	return fix
+}
@@ -0,0 +1,78 @@
+// Copyright 2022 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package source
+
+//go:generate go run mkstdversions.go
+
+import (
+	"context"
+	"go/types"
+	"strings"
+
+	"github.com/iansmith/golang-x-tools/internal/span"
+)
+
+// StdSymbolVersion returns the minor version of Go that added obj, a
+// package-level symbol or a method of the standard library, such as 20
+// for errors.Join. It returns 0 for the symbols of Go 1.0, and for those
+// that are not in the standard library.
+func StdSymbolVersion(obj types.Object) int {
+	if obj == nil || obj.Pkg() == nil {
+		return 0
+	}
+	path := obj.Pkg().Path()
+	// The paths of the standard library have no dot in their first element.
+	first := path
+	if i := strings.IndexByte(path, '/'); i >= 0 {
+		first = path[:i]
+	}
+	if strings.Contains(first, ".") {
+		return 0
+	}
+	if fn, ok := obj.(*types.Func); ok {
+		if recv := fn.Type().(*types.Signature).Recv(); recv != nil {
+			typ := recv.Type()
+			if ptr, ok := typ.(*types.Pointer); ok {
+				typ = ptr.Elem()
+			}
+			named, ok := typ.(*types.Named)
+			if !ok {
+				return 0 // an interface method
+			}
+			return stdVersions[path+"."+named.Obj().Name()+"."+obj.Name()]
+		}
+	}
+	if obj.Parent() != obj.Pkg().Scope() && obj.Parent() != nil {
+		return 0 // a field or a local symbol
+	}
+	return stdVersions[path+"."+obj.Name()]
+}
+
+// ModuleGoVersion returns the minor version of the go directive of the
+// module of the file uri, or 0 if it is unknown.
+func ModuleGoVersion(ctx context.Context, snapshot Snapshot, uri span.URI) int {
+	mds, err := snapshot.MetadataForFile(ctx, uri)
+	if err != nil || len(mds) == 0 {
+		return 0
+	}
+	mi := mds[0].ModuleInfo()
+	if mi == nil {
+		return 0
+	}
+	minor, _ := GoMinorVersion(mi.GoVersion)
+	return minor
+}
+
+// NewerStdSymbol returns the minor version of Go that added obj if it is a
+// symbol of the standard library that Go 1.moduleVersion lacks, or 0.
+func NewerStdSymbol(obj types.Object, moduleVersion int) int {
+	if moduleVersion == 0 {
+		return 0
+	}
+	if v := StdSymbolVersion(obj); v > moduleVersion {
+		return v
+	}
+	return 0
+}
@@ -0,0 +1,57 @@
+// Copyright 2022 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package source
+
+import (
+	"go/token"
+	"go/types"
+	"testing"
+)
+
+func TestStdSymbolVersion(t *testing.T) {
+	// newFunc declares a function, or a method of the named type recv, in
+	// the package path.
+	newFunc := func(path, recv, name string) types.Object {
+		pkg := types.NewPackage(path, "p")
+		var recvVar *types.Var
+		if recv != "" {
+			tname := types.NewTypeName(token.NoPos, pkg, recv, nil)
+			named := types.NewNamed(tname, types.NewStruct(nil, nil), nil)
+			pkg.Scope().Insert(tname)
+			recvVar = types.NewVar(token.NoPos, pkg, "", types.NewPointer(named))
+		}
+		fn := types.NewFunc(token.NoPos, pkg, name, types.NewSignature(recvVar, nil, nil, false))
+		if recv == "" {
+			pkg.Scope().Insert(fn)
+		}
+		return fn
+	}
+	for _, test := range []struct {
+		obj  types.Object
+		want int
+	}{
+		{newFunc("strings", "", "Cut"), 18},
+		{newFunc("strings", "", "Index"), 0},
+		{newFunc("bytes", "Buffer", "AvailableBuffer"), 21},
+		{newFunc("example.com/strings", "", "Cut"), 0},
+	} {
+		if got := StdSymbolVersion(test.obj); got != test.want {
+			t.Errorf("StdSymbolVersion(%s) = %d, want %d", test.obj, got, test.want)
+		}
+	}
+
+	cut := newFunc("strings", "", "Cut")
+	for _, test := range []struct {
+		moduleVersion, want int
+	}{
+		{0, 0},
+		{17, 18},
+		{18, 0},
+	} {
+		if got := NewerStdSymbol(cut, test.moduleVersion); got != test.want {
+			t.Errorf("NewerStdSymbol(strings.Cut, %d) = %d, want %d", test.moduleVersion, got, test.want)
+		}
+	}
+}
@@ -0,0 +1,385 @@
+// Copyright 2022 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package source
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/printer"
+	"go/token"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/iansmith/golang-x-tools/go/ast/astutil"
+	"github.com/iansmith/golang-x-tools/internal/lsp/bug"
+	"github.com/iansmith/golang-x-tools/internal/lsp/protocol"
+	"github.com/iansmith/golang-x-tools/internal/lsp/safetoken"
+	"github.com/iansmith/golang-x-tools/internal/span"
+)
+
+// The naming conventions of the keys of struct tags.
+const (
+	SnakeCase = "snake_case"
+	CamelCase = "camelCase"
+)
+
+// The actions of the ModifyTags command.
+const (
+	AddTags    = "add"
+	UpdateTags = "update"
+	RemoveTags = "remove"
+)
+
+// A tagField is a field of a struct type whose tag may be modified: an
+// exported field declared with a single name.
+type tagField struct {
+	field *ast.Field
+	name  string
+	tag   []tagPair
+}
+
+// A tagPair is a key:"value" pair of a struct tag.
+type tagPair struct {
+	key, value string
+}
+
+// StructTagActions reports, among the encodings, those whose tag some
+// field selected by rng lacks, and those whose tag some of them has. The
+// fields of the innermost struct type enclosing rng that intersect it are
+// selected, or all of them if there is none.
+func StructTagActions(file *ast.File, rng span.Range, encodings []string) (missing, tagged []string) {
+	_, fields, err := selectedTagFields(file, rng)
+	if err != nil {
+		return nil, nil
+	}
+	for _, encoding := range encodings {
+		var lacks, has bool
+		for _, f := range fields {
+			if tagIndex(f.tag, encoding) < 0 {
+				lacks = true
+			} else {
+				has = true
+			}
+		}
+		if lacks {
+			missing = append(missing, encoding)
+		}
+		if has {
+			tagged = append(tagged, encoding)
+		}
+	}
+	return missing, tagged
+}
+
+// ModifyTags returns the edits that apply action to the encoding tags of
+// the fields of the struct type selected by rng: "add" adds the tags that
+// the fields lack, "update" renames the keys of the existing tags after
+// their fields, keeping their options, and "remove" deletes the tags. The
+// keys follow convention, "snake_case" or "camelCase".
+func ModifyTags(ctx context.Context, snapshot Snapshot, fh FileHandle, rng protocol.Range, action, encoding, convention string) ([]protocol.TextEdit, error) {
+	if encoding == "" || strings.ContainsAny(encoding, " :\"`") {
+		return nil, fmt.Errorf("invalid encoding %q", encoding)
+	}
+	switch action {
+	case AddTags, UpdateTags, RemoveTags:
+	default:
+		return nil, fmt.Errorf("invalid action %q: expect add, update, or remove", action)
+	}
+	switch convention {
+	case SnakeCase, CamelCase:
+	default:
+		if action != RemoveTags {
+			return nil, fmt.Errorf("invalid naming convention %q: expect snake_case or camelCase", convention)
+		}
+	}
+	pgf, err := snapshot.ParseGo(ctx, fh, ParseFull)
+	if err != nil {
+		return nil, err
+	}
+	srng, err := pgf.Mapper.RangeToSpanRange(rng)
+	if err != nil {
+		return nil, err
+	}
+	st, fields, err := selectedTagFields(pgf.File, srng)
+	if err != nil {
+		return nil, err
+	}
+	tok := snapshot.FileSet().File(st.Pos())
+	if tok == nil {
+		return nil, bug.Errorf("no file for struct type")
+	}
+	start, err := safetoken.Offset(tok, st.Pos())
+	if err != nil {
+		return nil, err
+	}
+	end, err := safetoken.Offset(tok, st.End())
+	if err != nil {
+		return nil, err
+	}
+
+	// Replace the tags of the fields in the source of the struct type,
+	// from the last one.
+	text := string(pgf.Src[start:end])
+	changed := false
+	for i := len(fields) - 1; i >= 0; i-- {
+		f := fields[i]
+		tag, ok := modifyTag(f.tag, f.name, action, encoding, convention)
+		if !ok {
+			continue
+		}
+		changed = true
+		tagPos, tagEnd := f.field.Type.End(), f.field.Type.End()
+		if f.field.Tag != nil {
+			tagEnd = f.field.Tag.End()
+		}
+		from, err := safetoken.Offset(tok, tagPos)
+		if err != nil {
+			return nil, err
+		}
+		to, err := safetoken.Offset(tok, tagEnd)
+		if err != nil {
+			return nil, err
+		}
+		var lit string
+		if len(tag) > 0 {
+			lit = " " + formatTag(tag)
+		}
+		text = text[:from-start] + lit + text[to-start:]
+	}
+	if !changed {
+		return nil, nil
+	}
+
+	// Parse the new struct type in a declaration to align its tags as gofmt
+	// does, at the indentation of its first line.
+	line := pgf.Src[bytes.LastIndexByte(pgf.Src[:start], '\n')+1 : start]
+	depth := len(line) - len(bytes.TrimLeft(line, "\t"))
+	const header = "package p\ntype _ "
+	wfset := token.NewFileSet()
+	f, err := parser.ParseFile(wfset, "", header+text+"\n", parser.ParseComments)
+	if err != nil {
+		return nil, bug.Errorf("invalid modified struct type: %v", err)
+	}
+	spec := f.Decls[0].(*ast.GenDecl).Specs[0].(*ast.TypeSpec)
+	cfg := printer.Config{Mode: printer.UseSpaces | printer.TabIndent, Tabwidth: 8, Indent: depth}
+	var buf bytes.Buffer
+	if err := cfg.Fprint(&buf, wfset, &printer.CommentedNode{Node: spec.Type, Comments: f.Comments}); err != nil {
+		return nil, err
+	}
+	formatted := strings.TrimPrefix(buf.String(), strings.Repeat("\t", depth))
+
+	spn, err := span.NewRange(snapshot.FileSet(), st.Pos(), st.End()).Span()
+	if err != nil {
+		return nil, err
+	}
+	editRng, err := pgf.Mapper.Range(spn)
+	if err != nil {
+		return nil, err
+	}
+	return []protocol.TextEdit{{Range: editRng, NewText: formatted}}, nil
+}
+
+// selectedTagFields returns the innermost struct type enclosing rng, or
+// named by the type declaration enclosing it, and its fields that
+// intersect rng, or all of them if there is none. It only returns the
+// fields whose tags can be parsed.
+func selectedTagFields(file *ast.File, rng span.Range) (*ast.StructType, []tagField, error) {
+	path, _ := astutil.PathEnclosingInterval(file, rng.Start, rng.End)
+	var st *ast.StructType
+	for _, n := range path {
+		if s, ok := n.(*ast.StructType); ok {
+			st = s
+			break
+		}
+		if spec, ok := n.(*ast.TypeSpec); ok {
+			st, _ = spec.Type.(*ast.StructType)
+			break
+		}
+	}
+	if st == nil || st.Fields == nil {
+		return nil, nil, errors.New("no struct type selected")
+	}
+	var all, selected []tagField
+	for _, field := range st.Fields.List {
+		if len(field.Names) != 1 || !field.Names[0].IsExported() {
+			continue
+		}
+		f := tagField{field: field, name: field.Names[0].Name}
+		if field.Tag != nil {
+			s, err := strconv.Unquote(field.Tag.Value)
+			if err != nil {
+				continue
+			}
+			tag, ok := parseTag(s)
+			if !ok {
+				continue
+			}
+			f.tag = tag
+		}
+		all = append(all, f)
+		if rng.Start != rng.End && rng.Start < field.End() && field.Pos() < rng.End {
+			selected = append(selected, f)
+		}
+	}
+	if len(selected) == 0 {
+		selected = all
+	}
+	if len(selected) == 0 {
+		return nil, nil, errors.New("the struct type has no exported field")
+	}
+	return st, selected, nil
+}
+
+// parseTag parses the key:"value" pairs of a struct tag, separated by
+// spaces, as reflect.StructTag.Lookup does, and reports whether it
+// follows this convention.
+func parseTag(s string) ([]tagPair, bool) {
+	var tag []tagPair
+	for {
+		s = strings.TrimLeft(s, " ")
+		if s == "" {
+			return tag, true
+		}
+		i := 0
+		for i < len(s) && s[i] > ' ' && s[i] != ':' && s[i] != '"' && s[i] != 0x7f {
+			i++
+		}
+		if i == 0 || i+1 >= len(s) || s[i] != ':' || s[i+1] != '"' {
+			return nil, false
+		}
+		key := s[:i]
+		s = s[i+1:]
+		i = 1
+		for i < len(s) && s[i] != '"' {
+			if s[i] == '\\' {
+				i++
+			}
+			i++
+		}
+		if i >= len(s) {
+			return nil, false
+		}
+		value, err := strconv.Unquote(s[:i+1])
+		if err != nil {
+			return nil, false
+		}
+		tag = append(tag, tagPair{key, value})
+		s = s[i+1:]
+	}
+}
+
+// formatTag returns the literal of the struct tag made of the pairs of
+// tag, in back quotes unless its text contains one.
+func formatTag(tag []tagPair) string {
+	var pairs []string
+	for _, p := range tag {
+		pairs = append(pairs, p.key+":"+strconv.Quote(p.value))
+	}
+	s := strings.Join(pairs, " ")
+	if strings.Contains(s, "`") {
+		return strconv.Quote(s)
+	}
+	return "`" + s + "`"
+}
+
+// tagIndex returns the index of the pair of tag with the key, or -1.
+func tagIndex(tag []tagPair, key string) int {
+	for i, p := range tag {
+		if p.key == key {
+			return i
+		}
+	}
+	return -1
+}
+
+// modifyTag returns the tag of the field name after action on its
+// encoding pair, and reports whether it changed.
+func modifyTag(tag []tagPair, name, action, encoding, convention string) ([]tagPair, bool) {
+	i := tagIndex(tag, encoding)
+	switch action {
+	case AddTags:
+		if i >= 0 {
+			return nil, false
+		}
+		return append(append([]tagPair(nil), tag...), tagPair{encoding, tagKey(name, convention)}), true
+	case UpdateTags:
+		if i < 0 {
+			return nil, false
+		}
+		// The options following the name are kept, and the name "-", which
+		// skips the field, or an empty one, which stands for the name of the
+		// field, are not replaced.
+		key, opts := tag[i].value, ""
+		if comma := strings.IndexByte(key, ','); comma >= 0 {
+			key, opts = key[:comma], key[comma:]
+		}
+		if key == "" || key == "-" || key == tagKey(name, convention) {
+			return nil, false
+		}
+		tag = append([]tagPair(nil), tag...)
+		tag[i].value = tagKey(name, convention) + opts
+		return tag, true
+	case RemoveTags:
+		if i < 0 {
+			return nil, false
+		}
+		return append(append([]tagPair(nil), tag[:i]...), tag[i+1:]...), true
+	}
+	return nil, false
+}
+
+// tagKey returns the key of the field name in the naming convention.
+func tagKey(name, convention string) string {
+	words := identifierWords(name)
+	for i, word := range words {
+		word = strings.ToLower(word)
+		if convention == CamelCase && i > 0 {
+			runes := []rune(word)
+			word = string(unicode.ToUpper(runes[0])) + string(runes[1:])
+		}
+		words[i] = word
+	}
+	if convention == CamelCase {
+		return strings.Join(words, "")
+	}
+	return strings.Join(words, "_")
+}
+
+// identifierWords splits a Go identifier into words at its underscores,
+// at the lower-to-upper case transitions, and before the last letter of
+// a run of upper case letters followed by a lower case one, so that
+// "HTTPServer_ID" is split into "HTTP", "Server" and "ID".
+func identifierWords(name string) []string {
+	runes := []rune(name)
+	var words []string
+	var word []rune
+	for i, r := range runes {
+		if r == '_' {
+			if len(word) > 0 {
+				words = append(words, string(word))
+			}
+			word = nil
+			continue
+		}
+		if unicode.IsUpper(r) && len(word) > 0 {
+			prev := word[len(word)-1]
+			acronymEnd := unicode.IsUpper(prev) && i+1 < len(runes) && unicode.IsLower(runes[i+1])
+			if unicode.IsLower(prev) || unicode.IsDigit(prev) || acronymEnd {
+				words = append(words, string(word))
+				word = nil
+			}
+		}
+		word = append(word, r)
+	}
+	if len(word) > 0 {
+		words = append(words, string(word))
+	}
+	return words
+}
@@ -0,0 +1,63 @@
+// Copyright 2022 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package source
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestTagKey(t *testing.T) {
+	for _, test := range []struct {
+		name, snake, camel string
+	}{
+		{"Name", "name", "name"},
+		{"UserID", "user_id", "userId"},
+		{"HTTPServer", "http_server", "httpServer"},
+		{"ID", "id", "id"},
+		{"Base64Data", "base64_data", "base64Data"},
+		{"Max_Value", "max_value", "maxValue"},
+	} {
+		if got := tagKey(test.name, SnakeCase); got != test.snake {
+			t.Errorf("tagKey(%q, snake_case) = %q, want %q", test.name, got, test.snake)
+		}
+		if got := tagKey(test.name, CamelCase); got != test.camel {
+			t.Errorf("tagKey(%q, camelCase) = %q, want %q", test.name, got, test.camel)
+		}
+	}
+}
+
+func TestParseTag(t *testing.T) {
+	for _, test := range []struct {
+		tag  string
+		want []tagPair
+		ok   bool
+	}{
+		{``, nil, true},
+		{`json:"name,omitempty"`, []tagPair{{"json", "name,omitempty"}}, true},
+		{`json:"a"  yaml:"b\"c"`, []tagPair{{"json", "a"}, {"yaml", `b"c`}}, true},
+		{`json:name`, nil, false},
+		{`json:"a`, nil, false},
+	} {
+		got, ok := parseTag(test.tag)
+		if ok != test.ok || !reflect.DeepEqual(got, test.want) {
+			t.Errorf("parseTag(%q) = %v, %t, want %v, %t", test.tag, got, ok, test.want, test.ok)
+		}
+	}
+}
+
+func TestFormatTag(t *testing.T) {
+	for _, test := range []struct {
+		tag  []tagPair
+		want string
+	}{
+		{[]tagPair{{"json", "a,omitempty"}, {"yaml", "b"}}, "`json:\"a,omitempty\" yaml:\"b\"`"},
+		{[]tagPair{{"json", "a`b"}}, `"json:\"a` + "`" + `b\""`},
+	} {
+		if got := formatTag(test.tag); got != test.want {
+			t.Errorf("formatTag(%v) = %s, want %s", test.tag, got, test.want)
+		}
+	}
+}
@@ -13,10 +13,14 @@ import (
 	"go/parser"
 	"go/token"
 	"go/types"
+	"strconv"
 	"strings"
+	"unicode"
+	"unicode/utf8"
 
 	"github.com/iansmith/golang-x-tools/go/analysis"
 	"github.com/iansmith/golang-x-tools/go/ast/astutil"
+	"github.com/iansmith/golang-x-tools/internal/event"
 	"github.com/iansmith/golang-x-tools/internal/lsp/analysis/stubmethods"
 	"github.com/iansmith/golang-x-tools/internal/lsp/protocol"
 	"github.com/iansmith/golang-x-tools/internal/span"
@@ -36,6 +40,90 @@ func stubSuggestedFixFunc(ctx context.Context, snapshot Snapshot, fh VersionedFi
 	if si == nil {
 		return nil, fmt.Errorf("nil interface request")
 	}
+	return stubFix(ctx, snapshot, si)
+}
+
+// ImplementStubs returns the edits declaring, after the type whose name is
+// at concreteRng in concreteFH, the methods of the interface whose name is
+// at ifaceRng in ifaceFH that the type lacks. The methods have pointer
+// receivers unless all the methods of the type have value receivers.
+func ImplementStubs(ctx context.Context, snapshot Snapshot, concreteFH FileHandle, concreteRng protocol.Range, ifaceFH FileHandle, ifaceRng protocol.Range) ([]protocol.TextDocumentEdit, error) {
+	ctx, done := event.Start(ctx, "source.ImplementStubs")
+	defer done()
+
+	pkg, concrete, err := namedTypeAt(ctx, snapshot, concreteFH, concreteRng)
+	if err != nil {
+		return nil, err
+	}
+	if types.IsInterface(concrete) {
+		return nil, fmt.Errorf("%s is an interface", concrete.Obj().Name())
+	}
+	_, iface, err := namedTypeAt(ctx, snapshot, ifaceFH, ifaceRng)
+	if err != nil {
+		return nil, err
+	}
+	if !types.IsInterface(iface) {
+		return nil, fmt.Errorf("%s is not an interface", iface.Obj().Name())
+	}
+	// Use the interface as seen from the package of the concrete type, if
+	// it imports it, for the signatures of their methods to be comparable.
+	ifaceObj := types.Object(iface.Obj())
+	if path := ifaceObj.Pkg().Path(); path == pkg.PkgPath() {
+		if obj := pkg.GetTypes().Scope().Lookup(ifaceObj.Name()); obj != nil {
+			ifaceObj = obj
+		}
+	} else if imp, err := pkg.GetImport(path); err == nil {
+		if obj := imp.GetTypes().Scope().Lookup(ifaceObj.Name()); obj != nil {
+			ifaceObj = obj
+		}
+	}
+	pointer := true
+	if n := concrete.NumMethods(); n > 0 {
+		pointer = false
+		for i := 0; i < n; i++ {
+			if _, ok := concrete.Method(i).Type().(*types.Signature).Recv().Type().(*types.Pointer); ok {
+				pointer = true
+			}
+		}
+	}
+	fix, err := stubFix(ctx, snapshot, &stubmethods.StubInfo{
+		Interface: ifaceObj,
+		Concrete:  concrete,
+		Pointer:   pointer,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return suggestedFixEdits(ctx, snapshot, fix)
+}
+
+// namedTypeAt returns the package of fh, and the named type whose name is
+// at rng in fh.
+func namedTypeAt(ctx context.Context, snapshot Snapshot, fh FileHandle, rng protocol.Range) (Package, *types.Named, error) {
+	pkg, pgf, err := GetParsedFile(ctx, snapshot, fh, NarrowestPackage)
+	if err != nil {
+		return nil, nil, fmt.Errorf("GetParsedFile: %w", err)
+	}
+	spn, err := pgf.Mapper.RangeToSpanRange(rng)
+	if err != nil {
+		return nil, nil, err
+	}
+	path, _ := astutil.PathEnclosingInterval(pgf.File, spn.Start, spn.End)
+	if len(path) > 0 {
+		if id, ok := path[0].(*ast.Ident); ok {
+			if obj, ok := pkg.GetTypesInfo().ObjectOf(id).(*types.TypeName); ok {
+				if named, ok := obj.Type().(*types.Named); ok {
+					return pkg, named, nil
+				}
+			}
+		}
+	}
+	return nil, nil, fmt.Errorf("no named type at %s:%v", fh.URI().Filename(), rng.Start)
+}
+
+// stubFix returns the fix declaring the methods of si.Interface that
+// si.Concrete lacks.
+func stubFix(ctx context.Context, snapshot Snapshot, si *stubmethods.StubInfo) (*analysis.SuggestedFix, error) {
 	parsedConcreteFile, concreteFH, err := getStubFile(ctx, si.Concrete.Obj(), snapshot)
 	if err != nil {
 		return nil, fmt.Errorf("getFile(concrete): %w", err)
@@ -52,12 +140,11 @@ func stubSuggestedFixFunc(ctx context.Context, snapshot Snapshot, fh VersionedFi
 	if err != nil {
 		return nil, fmt.Errorf("stubMethods: %w", err)
 	}
-	nodes, _ = astutil.PathEnclosingInterval(parsedConcreteFile.File, si.Concrete.Obj().Pos(), si.Concrete.Obj().Pos())
 	concreteSrc, err := concreteFH.Read()
 	if err != nil {
 		return nil, fmt.Errorf("error reading concrete file source: %w", err)
 	}
-	insertPos := snapshot.FileSet().Position(nodes[1].End()).Offset
+	insertPos := snapshot.FileSet().Position(stubInsertPos(parsedConcreteFile.File, si.Concrete.Obj())).Offset
 	if insertPos >= len(concreteSrc) {
 		return nil, fmt.Errorf("insertion position is past the end of the file")
 	}
@@ -119,6 +206,7 @@ func stubMethods(ctx context.Context, concreteFile *ast.File, si *stubmethods.St
 	var (
 		stubImports   []*stubImport
 		methodsBuffer bytes.Buffer
+		recv          = getStubReceiverName(concreteFile, si)
 	)
 	for _, mi := range missing {
 		for _, m := range mi.missing {
@@ -132,8 +220,13 @@ func stubMethods(ctx context.Context, concreteFile *ast.File, si *stubmethods.St
 				}
 				stubImports = append(stubImports, &stubImport{name, path})
 			}))
+			name := recv
+			if hasParamNamed(m.Type().(*types.Signature), name) {
+				name = "" // avoid a duplicate argument
+			}
 			_, err = methodsBuffer.Write(printStubMethod(methodData{
 				Method:    m.Name(),
+				Receiver:  name,
 				Concrete:  getStubReceiver(si),
 				Interface: deduceIfaceName(si.Concrete.Obj().Pkg(), si.Interface.Pkg(), si.Interface),
 				Signature: strings.TrimPrefix(sig, "func"),
@@ -153,6 +246,7 @@ func stubMethods(ctx context.Context, concreteFile *ast.File, si *stubmethods.St
 func stubErr(ctx context.Context, concreteFile *ast.File, si *stubmethods.StubInfo, snapshot Snapshot) []byte {
 	return printStubMethod(methodData{
 		Method:    "Error",
+		Receiver:  getStubReceiverName(concreteFile, si),
 		Interface: "error",
 		Concrete:  getStubReceiver(si),
 		Signature: "() string",
@@ -171,8 +265,94 @@ func getStubReceiver(si *stubmethods.StubInfo) string {
 	return concrete
 }
 
+// getStubReceiverName returns the name of the receiver of the stub
+// methods: the name used by the existing methods of the concrete type,
+// if any, or else its initial letter in lower case. It returns "" if
+// the name would conflict with an import of the concrete type's file.
+func getStubReceiverName(concreteFile *ast.File, si *stubmethods.StubInfo) string {
+	var name string
+	for i := 0; i < si.Concrete.NumMethods(); i++ {
+		recv := si.Concrete.Method(i).Type().(*types.Signature).Recv()
+		if recv != nil && recv.Name() != "" && recv.Name() != "_" {
+			name = recv.Name()
+			break
+		}
+	}
+	if name == "" {
+		r, _ := utf8.DecodeRuneInString(si.Concrete.Obj().Name())
+		name = string(unicode.ToLower(r))
+	}
+	for _, imp := range concreteFile.Imports {
+		impName := ""
+		if imp.Name != nil {
+			impName = imp.Name.Name
+		} else if path, err := strconv.Unquote(imp.Path.Value); err == nil {
+			impName = importBase(path)
+		}
+		if impName == name {
+			return ""
+		}
+	}
+	return name
+}
+
+// importBase returns the last element of an import path, which is
+// usually the name of the package.
+func importBase(path string) string {
+	if i := strings.LastIndex(path, "/"); i >= 0 {
+		return path[i+1:]
+	}
+	return path
+}
+
+// hasParamNamed reports whether sig has a parameter or result with the
+// given name.
+func hasParamNamed(sig *types.Signature, name string) bool {
+	for _, tuple := range []*types.Tuple{sig.Params(), sig.Results()} {
+		for i := 0; i < tuple.Len(); i++ {
+			if tuple.At(i).Name() == name {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// stubInsertPos returns the position after which the stub methods of
+// the concrete type are inserted into its file: the end of the last
+// method of the type declared in the file, or else the end of the type
+// declaration.
+func stubInsertPos(file *ast.File, concrete *types.TypeName) token.Pos {
+	nodes, _ := astutil.PathEnclosingInterval(file, concrete.Pos(), concrete.Pos())
+	pos := nodes[1].End()
+	for _, decl := range file.Decls {
+		fn, ok := decl.(*ast.FuncDecl)
+		if !ok || fn.Recv == nil || len(fn.Recv.List) == 0 || fn.End() < pos {
+			continue
+		}
+		if id := recvTypeIdent(fn.Recv.List[0].Type); id != nil && id.Name == concrete.Name() {
+			pos = fn.End()
+		}
+	}
+	return pos
+}
+
+// recvTypeIdent returns the name of the type of a receiver such as
+// T, *T, or *T[P], or nil.
+func recvTypeIdent(expr ast.Expr) *ast.Ident {
+	if star, ok := expr.(*ast.StarExpr); ok {
+		expr = star.X
+	}
+	if x, _, _, _ := typeparams.UnpackIndexExpr(expr); x != nil {
+		expr = x
+	}
+	id, _ := expr.(*ast.Ident)
+	return id
+}
+
 type methodData struct {
 	Method    string
+	Receiver  string
 	Interface string
 	Concrete  string
 	Signature string
@@ -181,13 +361,19 @@ type methodData struct {
 // printStubMethod takes methodData and returns Go code that represents the given method such as:
 //
 //	// {{ .Method }} implements {{ .Interface }}
-//	func ({{ .Concrete }}) {{ .Method }}{{ .Signature }} {
+//	func ({{ .Receiver }} {{ .Concrete }}) {{ .Method }}{{ .Signature }} {
 //		panic("unimplemented")
 //	}
+//
+// The receiver is unnamed if md.Receiver is empty.
 func printStubMethod(md methodData) []byte {
+	recv := md.Concrete
+	if md.Receiver != "" {
+		recv = md.Receiver + " " + recv
+	}
 	var b bytes.Buffer
 	fmt.Fprintf(&b, "// %s implements %s\n", md.Method, md.Interface)
-	fmt.Fprintf(&b, "func (%s) %s%s {\n\t", md.Concrete, md.Method, md.Signature)
+	fmt.Fprintf(&b, "func (%s) %s%s {\n\t", recv, md.Method, md.Signature)
 	fmt.Fprintln(&b, `panic("unimplemented")`)
 	fmt.Fprintln(&b, "}")
 	return b.Bytes()
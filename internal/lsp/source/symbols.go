@@ -8,7 +8,9 @@ import (
 	"context"
 	"fmt"
 	"go/ast"
+	"go/token"
 	"go/types"
+	"strings"
 
 	"github.com/iansmith/golang-x-tools/internal/event"
 	"github.com/iansmith/golang-x-tools/internal/lsp/protocol"
@@ -26,7 +28,20 @@ func DocumentSymbols(ctx context.Context, snapshot Snapshot, fh FileHandle) ([]p
 	info := pkg.GetTypesInfo()
 	q := Qualifier(pgf.File, pkg.GetTypes(), info)
 
-	symbolsToReceiver := make(map[types.Type]int)
+	// The methods of the types declared in the file are nested in the
+	// symbols of the types, after their fields.
+	declaredTypes := make(map[*types.TypeName]bool)
+	for _, decl := range pgf.File.Decls {
+		if decl, ok := decl.(*ast.GenDecl); ok && decl.Tok == token.TYPE {
+			for _, spec := range decl.Specs {
+				if obj, ok := info.Defs[spec.(*ast.TypeSpec).Name].(*types.TypeName); ok {
+					declaredTypes[obj] = true
+				}
+			}
+		}
+	}
+	typeSymbols := make(map[*types.TypeName]int)
+	methods := make(map[*types.TypeName][]protocol.DocumentSymbol)
 	var symbols []protocol.DocumentSymbol
 	for _, decl := range pgf.File.Decls {
 		switch decl := decl.(type) {
@@ -39,14 +54,19 @@ func DocumentSymbols(ctx context.Context, snapshot Snapshot, fh FileHandle) ([]p
 				if err != nil {
 					return nil, err
 				}
-				// If function is a method, prepend the type of the method.
 				if fs.Kind == protocol.Method {
-					rtype := obj.Type().(*types.Signature).Recv().Type()
-					fs.Name = fmt.Sprintf("(%s).%s", types.TypeString(rtype, q), fs.Name)
+					recv := obj.Type().(*types.Signature).Recv().Type()
+					if tname := recvTypeName(recv); declaredTypes[tname] {
+						methods[tname] = append(methods[tname], fs)
+						continue
+					}
+					// Otherwise prepend the type of the method.
+					fs.Name = fmt.Sprintf("(%s).%s", types.TypeString(recv, q), fs.Name)
 				}
 				symbols = append(symbols, fs)
 			}
 		case *ast.GenDecl:
+			var values []protocol.DocumentSymbol
 			for _, spec := range decl.Specs {
 				switch spec := spec.(type) {
 				case *ast.TypeSpec:
@@ -59,28 +79,98 @@ func DocumentSymbols(ctx context.Context, snapshot Snapshot, fh FileHandle) ([]p
 							return nil, err
 						}
 						symbols = append(symbols, ts)
-						symbolsToReceiver[obj.Type()] = len(symbols) - 1
+						if tname, ok := obj.(*types.TypeName); ok {
+							typeSymbols[tname] = len(symbols) - 1
+						}
 					}
 				case *ast.ValueSpec:
+					// The values of a group span their own specs.
+					var node ast.Node = decl
+					if decl.Lparen.IsValid() {
+						node = spec
+					}
 					for _, name := range spec.Names {
 						if name.Name == "_" {
 							continue
 						}
 						if obj := info.ObjectOf(name); obj != nil {
-							vs, err := varSymbol(snapshot, pkg, decl, name, obj, q)
+							vs, err := varSymbol(snapshot, pkg, node, name, obj, q)
 							if err != nil {
 								return nil, err
 							}
-							symbols = append(symbols, vs)
+							values = append(values, vs)
 						}
 					}
 				}
 			}
+			if len(values) < 2 || !decl.Lparen.IsValid() {
+				symbols = append(symbols, values...)
+				continue
+			}
+			gs, err := groupSymbol(snapshot, pkg, decl, values)
+			if err != nil {
+				return nil, err
+			}
+			symbols = append(symbols, gs)
+		}
+	}
+	for tname, ms := range methods {
+		if i, ok := typeSymbols[tname]; ok {
+			symbols[i].Children = append(symbols[i].Children, ms...)
 		}
 	}
 	return symbols, nil
 }
 
+// recvTypeName returns the declaration of the named type of the
+// receiver type recv, or nil.
+func recvTypeName(recv types.Type) *types.TypeName {
+	if ptr, ok := recv.(*types.Pointer); ok {
+		recv = ptr.Elem()
+	}
+	if named, ok := recv.(*types.Named); ok {
+		return named.Obj()
+	}
+	return nil
+}
+
+// groupSymbol returns the symbol of the parenthesized const or var
+// declaration decl, whose children are the symbols of its values. It is
+// named after the first values, as in "const (A, B, C, …)".
+func groupSymbol(snapshot Snapshot, pkg Package, decl *ast.GenDecl, values []protocol.DocumentSymbol) (protocol.DocumentSymbol, error) {
+	const maxNames = 3
+	var names []string
+	for i, v := range values {
+		if i == maxNames {
+			names = append(names, "…")
+			break
+		}
+		names = append(names, v.Name)
+	}
+	s := protocol.DocumentSymbol{
+		Name:     fmt.Sprintf("%s (%s)", decl.Tok, strings.Join(names, ", ")),
+		Kind:     protocol.Variable,
+		Children: values,
+	}
+	if decl.Tok == token.CONST {
+		s.Kind = protocol.Constant
+	}
+	var err error
+	s.Range, err = nodeToProtocolRange(snapshot, pkg, decl)
+	if err != nil {
+		return protocol.DocumentSymbol{}, err
+	}
+	mrng, err := posToMappedRange(snapshot, pkg, decl.TokPos, decl.TokPos+token.Pos(len(decl.Tok.String())))
+	if err != nil {
+		return protocol.DocumentSymbol{}, err
+	}
+	s.SelectionRange, err = mrng.Range()
+	if err != nil {
+		return protocol.DocumentSymbol{}, err
+	}
+	return s, nil
+}
+
 func funcSymbol(snapshot Snapshot, pkg Package, decl *ast.FuncDecl, obj types.Object, q types.Qualifier) (protocol.DocumentSymbol, error) {
 	s := protocol.DocumentSymbol{
 		Name: obj.Name(),
@@ -136,23 +226,7 @@ func typeSymbol(snapshot Snapshot, pkg Package, info *types.Info, spec *ast.Type
 	t, objIsStruct := obj.Type().Underlying().(*types.Struct)
 	st, specIsStruct := spec.Type.(*ast.StructType)
 	if objIsStruct && specIsStruct {
-		for i := 0; i < t.NumFields(); i++ {
-			f := t.Field(i)
-			child := protocol.DocumentSymbol{
-				Name: f.Name(),
-				Kind: protocol.Field,
-			}
-			child.Detail, _ = FormatType(f.Type(), qf)
-
-			spanNode, selectionNode := nodesForStructField(i, st)
-			if span, err := nodeToProtocolRange(snapshot, pkg, spanNode); err == nil {
-				child.Range = span
-			}
-			if span, err := nodeToProtocolRange(snapshot, pkg, selectionNode); err == nil {
-				child.SelectionRange = span
-			}
-			s.Children = append(s.Children, child)
-		}
+		s.Children = fieldSymbols(snapshot, pkg, t, st, qf)
 	}
 
 	ti, objIsInterface := obj.Type().Underlying().(*types.Interface)
@@ -223,6 +297,38 @@ func typeSymbol(snapshot Snapshot, pkg Package, info *types.Info, spec *ast.Type
 	return s, nil
 }
 
+// fieldSymbols returns the symbols of the fields of the struct type t,
+// declared by st. The fields of the anonymous struct types of its fields
+// are nested in their symbols.
+func fieldSymbols(snapshot Snapshot, pkg Package, t *types.Struct, st *ast.StructType, qf types.Qualifier) []protocol.DocumentSymbol {
+	var symbols []protocol.DocumentSymbol
+	for i := 0; i < t.NumFields(); i++ {
+		f := t.Field(i)
+		child := protocol.DocumentSymbol{
+			Name: f.Name(),
+			Kind: protocol.Field,
+		}
+		child.Detail, _ = FormatType(f.Type(), qf)
+
+		spanNode, selectionNode := nodesForStructField(i, st)
+		if span, err := nodeToProtocolRange(snapshot, pkg, spanNode); err == nil {
+			child.Range = span
+		}
+		if span, err := nodeToProtocolRange(snapshot, pkg, selectionNode); err == nil {
+			child.SelectionRange = span
+		}
+		if field, ok := spanNode.(*ast.Field); ok {
+			ft, objIsStruct := f.Type().(*types.Struct)
+			fst, specIsStruct := field.Type.(*ast.StructType)
+			if objIsStruct && specIsStruct {
+				child.Children = fieldSymbols(snapshot, pkg, ft, fst, qf)
+			}
+		}
+		symbols = append(symbols, child)
+	}
+	return symbols
+}
+
 func nodesForStructField(i int, st *ast.StructType) (span, selection ast.Node) {
 	j := 0
 	for _, field := range st.Fields.List {
@@ -0,0 +1,382 @@
+// Copyright 2022 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package source
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/types"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/iansmith/golang-x-tools/internal/event"
+	"github.com/iansmith/golang-x-tools/internal/imports"
+	"github.com/iansmith/golang-x-tools/internal/lsp/protocol"
+	"github.com/iansmith/golang-x-tools/internal/span"
+	"github.com/iansmith/golang-x-tools/internal/typeparams"
+)
+
+// CanGenerateTest reports whether rng is within the declaration of a
+// function or method in file, a non-test file, for which GenerateTest
+// can generate a test.
+func CanGenerateTest(file *ast.File, rng span.Range) bool {
+	return testedFuncDecl(file, rng) != nil
+}
+
+// testedFuncDecl returns the declaration of the function or method in
+// file whose name or signature contains rng, or nil if there is none
+// for which a test can be generated.
+func testedFuncDecl(file *ast.File, rng span.Range) *ast.FuncDecl {
+	for _, decl := range file.Decls {
+		decl, ok := decl.(*ast.FuncDecl)
+		if !ok || decl.Body == nil || rng.Start < decl.Pos() || rng.End > decl.Body.Lbrace {
+			continue
+		}
+		if decl.Name.Name == "_" || decl.Recv == nil && (decl.Name.Name == "init" || decl.Name.Name == "main" && file.Name.Name == "main") {
+			return nil
+		}
+		return decl
+	}
+	return nil
+}
+
+// GenerateTest returns the URI of the _test.go file for the Go file fh,
+// and the edits to it that add a table-driven test for the function or
+// method whose declaration contains pRng. If the test file does not
+// exist, the edits are to an empty file, which the caller must create.
+//
+// A new test file is in the external test package if an existing test
+// file in its directory is, and the function is exported.
+func GenerateTest(ctx context.Context, snapshot Snapshot, fh VersionedFileHandle, pRng protocol.Range) (span.URI, []protocol.TextEdit, error) {
+	ctx, done := event.Start(ctx, "source.GenerateTest")
+	defer done()
+
+	if strings.HasSuffix(fh.URI().Filename(), "_test.go") {
+		return "", nil, errors.New("cannot generate a test for a test file")
+	}
+	pkg, pgf, err := GetParsedFile(ctx, snapshot, fh, NarrowestPackage)
+	if err != nil {
+		return "", nil, fmt.Errorf("GetParsedFile: %w", err)
+	}
+	rng, err := pgf.Mapper.RangeToSpanRange(pRng)
+	if err != nil {
+		return "", nil, err
+	}
+	decl := testedFuncDecl(pgf.File, rng)
+	if decl == nil {
+		return "", nil, errors.New("no function or method declaration selected")
+	}
+	fn, ok := pkg.GetTypesInfo().Defs[decl.Name].(*types.Func)
+	if !ok {
+		return "", nil, fmt.Errorf("no type information for %s", decl.Name.Name)
+	}
+	sig := fn.Type().(*types.Signature)
+	if typeparams.ForSignature(sig).Len() > 0 || typeparams.RecvTypeParams(sig).Len() > 0 {
+		return "", nil, fmt.Errorf("cannot generate a test for generic %s", fn.Name())
+	}
+	var recvName string
+	if sig.Recv() != nil {
+		named, ok := Deref(sig.Recv().Type()).(*types.Named)
+		if !ok {
+			return "", nil, fmt.Errorf("invalid receiver type for %s", fn.Name())
+		}
+		recvName = named.Obj().Name()
+	}
+	exported := fn.Exported() && (recvName == "" || ast.IsExported(recvName))
+
+	testName := recvName + "_" + fn.Name()
+	if recvName == "" {
+		testName = fn.Name()
+	}
+	if r, _ := utf8.DecodeRuneInString(testName); unicode.IsLower(r) {
+		testName = "_" + testName
+	}
+	testName = "Test" + testName
+
+	// Find the package of the test file, and make sure that the test
+	// doesn't already exist.
+	testURI := span.URIFromPath(strings.TrimSuffix(fh.URI().Filename(), ".go") + "_test.go")
+	pkgName := pkg.GetTypes().Name()
+	testPkgName := pkgName
+	var testPGF *ParsedGoFile
+	for _, uri := range testFiles(filepath.Dir(fh.URI().Filename()), testURI) {
+		tfh, err := snapshot.GetFile(ctx, uri)
+		if err != nil {
+			return "", nil, err
+		}
+		if _, err := tfh.Read(); err != nil {
+			continue // the file does not exist
+		}
+		tpgf, err := snapshot.ParseGo(ctx, tfh, ParseFull)
+		if err != nil {
+			return "", nil, err
+		}
+		for _, decl := range tpgf.File.Decls {
+			if decl, ok := decl.(*ast.FuncDecl); ok && decl.Recv == nil && decl.Name.Name == testName {
+				return "", nil, fmt.Errorf("%s already exists in %s", testName, filepath.Base(uri.Filename()))
+			}
+		}
+		if uri == testURI {
+			testPGF = tpgf
+			testPkgName = tpgf.File.Name.Name
+		} else if testPGF == nil && exported && tpgf.File.Name.Name == pkgName+"_test" {
+			testPkgName = tpgf.File.Name.Name
+		}
+	}
+	external := testPkgName != pkgName
+	if external && !exported {
+		return "", nil, fmt.Errorf("cannot test unexported %s in external test package %s", fn.Name(), testPkgName)
+	}
+
+	// Qualify the types of the test by the names under which the test
+	// file imports their packages, recording the imports it needs.
+	importNames := make(map[string]string) // import path -> name
+	if testPGF != nil {
+		for _, spec := range testPGF.File.Imports {
+			if path, err := strconv.Unquote(spec.Path.Value); err == nil && spec.Name != nil && spec.Name.Name != "_" && spec.Name.Name != "." {
+				importNames[path] = spec.Name.Name
+			}
+		}
+	}
+	needed := make(map[string]string) // import path -> name
+	qualify := func(p *types.Package) string {
+		if p == pkg.GetTypes() && !external {
+			return ""
+		}
+		name, ok := importNames[p.Path()]
+		if !ok {
+			name = p.Name()
+		}
+		needed[p.Path()] = name
+		return name
+	}
+
+	var b bytes.Buffer
+	writeTableTest(&b, testName, fn, sig, qualify)
+	src, err := format.Source(b.Bytes())
+	if err != nil {
+		return "", nil, fmt.Errorf("formatting test: %v", err)
+	}
+
+	if testPGF == nil {
+		// Write a new file with an import declaration for the test,
+		// grouped as organizing imports would.
+		var paths []string
+		for path := range needed {
+			paths = append(paths, path)
+		}
+		sort.Strings(paths)
+		var f bytes.Buffer
+		fmt.Fprintf(&f, "package %s\n\nimport (\n", testPkgName)
+		for _, path := range paths {
+			if name := needed[path]; name != imports.ImportPathToAssumedName(path) {
+				fmt.Fprintf(&f, "\t%s %q\n", name, path)
+			} else {
+				fmt.Fprintf(&f, "\t%q\n", path)
+			}
+		}
+		fmt.Fprintf(&f, ")\n\n%s", src)
+		content, err := imports.Process(testURI.Filename(), f.Bytes(), &imports.Options{
+			LocalPrefix: snapshot.View().Options().Local,
+			Groups:      ImportGroups(ctx, snapshot),
+			Comments:    true,
+			TabIndent:   true,
+			TabWidth:    8,
+			FormatOnly:  true,
+		})
+		if err != nil {
+			return "", nil, fmt.Errorf("formatting test file: %v", err)
+		}
+		return testURI, []protocol.TextEdit{{NewText: string(content)}}, nil
+	}
+
+	// Append the test to the existing file, and add the imports it lacks.
+	var fixes []*imports.ImportFix
+	for path, name := range needed {
+		if hasImport(testPGF.File, path) {
+			continue
+		}
+		fix := &imports.ImportFix{
+			StmtInfo:  imports.ImportInfo{ImportPath: path},
+			IdentName: name,
+			FixType:   imports.AddImport,
+		}
+		if name != imports.ImportPathToAssumedName(path) {
+			fix.StmtInfo.Name = name
+		}
+		fixes = append(fixes, fix)
+	}
+	sort.Slice(fixes, func(i, j int) bool {
+		return fixes[i].StmtInfo.ImportPath < fixes[j].StmtInfo.ImportPath
+	})
+	var edits []protocol.TextEdit
+	if len(fixes) > 0 {
+		edits, err = computeImportFixEdits(ctx, snapshot, testPGF, fixes)
+		if err != nil {
+			return "", nil, fmt.Errorf("adding imports: %v", err)
+		}
+	}
+	eof := testPGF.Tok.Pos(testPGF.Tok.Size())
+	end, err := NewMappedRange(snapshot.FileSet(), testPGF.Mapper, eof, eof).Range()
+	if err != nil {
+		return "", nil, err
+	}
+	text := "\n" + string(src)
+	if !bytes.HasSuffix(testPGF.Src, []byte("\n")) {
+		text = "\n" + text
+	}
+	edits = append(edits, protocol.TextEdit{Range: end, NewText: text})
+	return testURI, edits, nil
+}
+
+// testFiles returns the URIs of the _test.go files in dir, including
+// testURI whether or not it exists, with testURI first.
+func testFiles(dir string, testURI span.URI) []span.URI {
+	uris := []span.URI{testURI}
+	matches, _ := filepath.Glob(filepath.Join(dir, "*_test.go"))
+	for _, match := range matches {
+		if uri := span.URIFromPath(match); uri != testURI {
+			uris = append(uris, uri)
+		}
+	}
+	return uris
+}
+
+// hasImport reports whether file imports the package with the given
+// import path under a name that may qualify identifiers.
+func hasImport(file *ast.File, path string) bool {
+	for _, spec := range file.Imports {
+		if p, err := strconv.Unquote(spec.Path.Value); err == nil && p == path {
+			if spec.Name == nil || spec.Name.Name != "_" && spec.Name.Name != "." {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// writeTableTest writes to b a table-driven test named testName for fn,
+// whose signature is sig, with a field in the table for the receiver,
+// each parameter, and each result of fn. A final error result is
+// checked by a wantErr field.
+func writeTableTest(b *bytes.Buffer, testName string, fn *types.Func, sig *types.Signature, qualify types.Qualifier) {
+	typeString := func(t types.Type) string { return types.TypeString(t, qualify) }
+	testing := qualify(types.NewPackage("testing", "testing"))
+
+	// Choose the fields of the table, avoiding duplicate names.
+	fields := map[string]bool{"name": true}
+	field := func(name, fallback string) string {
+		if name == "" || name == "_" {
+			name = fallback
+		}
+		for unique, i := name, 2; ; i++ {
+			if !fields[unique] {
+				fields[unique] = true
+				return unique
+			}
+			unique = fmt.Sprintf("%s%d", name, i)
+		}
+	}
+	type tableField struct{ name, typ string }
+	var recv *tableField
+	if sig.Recv() != nil {
+		recv = &tableField{field("recv", "recv"), typeString(sig.Recv().Type())}
+	}
+	params := sig.Params()
+	var args []tableField
+	for i := 0; i < params.Len(); i++ {
+		p := params.At(i)
+		args = append(args, tableField{field(p.Name(), fmt.Sprintf("arg%d", i+1)), typeString(p.Type())})
+	}
+	results := sig.Results()
+	var wants []tableField
+	wantErr := ""
+	for i := 0; i < results.Len(); i++ {
+		t := results.At(i).Type()
+		if i == results.Len()-1 && types.Identical(t, types.Universe.Lookup("error").Type()) {
+			wantErr = field("wantErr", "wantErr")
+			continue
+		}
+		name := "want"
+		if i > 0 {
+			name = fmt.Sprintf("want%d", i+1)
+		}
+		wants = append(wants, tableField{field(name, name), typeString(t)})
+	}
+	var reflect string
+	if len(wants) > 0 {
+		reflect = qualify(types.NewPackage("reflect", "reflect"))
+	}
+
+	// Build the call of fn.
+	callee := fn.Name()
+	if recv != nil {
+		callee = "tt." + recv.name + "." + callee
+	} else if q := qualify(fn.Pkg()); q != "" {
+		callee = q + "." + callee
+	}
+	var argExprs []string
+	for i, arg := range args {
+		expr := "tt." + arg.name
+		if sig.Variadic() && i == len(args)-1 {
+			expr += "..."
+		}
+		argExprs = append(argExprs, expr)
+	}
+	call := fmt.Sprintf("%s(%s)", callee, strings.Join(argExprs, ", "))
+	var gots []string
+	for i := range wants {
+		if i == 0 {
+			gots = append(gots, "got")
+		} else {
+			gots = append(gots, fmt.Sprintf("got%d", i+1))
+		}
+	}
+	lhs := gots
+	if wantErr != "" {
+		lhs = append(lhs, "err")
+	}
+
+	fmt.Fprintf(b, "func %s(t *%s.T) {\n", testName, testing)
+	fmt.Fprintf(b, "tests := []struct {\nname string\n")
+	if recv != nil {
+		fmt.Fprintf(b, "%s %s\n", recv.name, recv.typ)
+	}
+	for _, f := range args {
+		fmt.Fprintf(b, "%s %s\n", f.name, f.typ)
+	}
+	for _, f := range wants {
+		fmt.Fprintf(b, "%s %s\n", f.name, f.typ)
+	}
+	if wantErr != "" {
+		fmt.Fprintf(b, "%s bool\n", wantErr)
+	}
+	fmt.Fprintf(b, "}{\n// TODO: Add test cases.\n}\n")
+	fmt.Fprintf(b, "for _, tt := range tests {\nt.Run(tt.name, func(t *%s.T) {\n", testing)
+	if len(lhs) > 0 {
+		fmt.Fprintf(b, "%s := %s\n", strings.Join(lhs, ", "), call)
+	} else {
+		fmt.Fprintf(b, "%s\n", call)
+	}
+	if wantErr != "" {
+		fmt.Fprintf(b, "if (err != nil) != tt.%s {\nt.Fatalf(\"%s() error = %%v, %s %%v\", err, tt.%[1]s)\n}\n", wantErr, fn.Name(), wantErr)
+	}
+	for i, f := range wants {
+		what := ""
+		if i > 0 {
+			what = " " + gots[i]
+		}
+		fmt.Fprintf(b, "if !%s.DeepEqual(%s, tt.%s) {\nt.Errorf(\"%s()%s = %%v, want %%v\", %[2]s, tt.%[3]s)\n}\n", reflect, gots[i], f.name, fn.Name(), what)
+	}
+	fmt.Fprintf(b, "})\n}\n}\n")
+}
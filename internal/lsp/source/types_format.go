@@ -264,11 +264,11 @@ func FormatVarType(ctx context.Context, snapshot Snapshot, srcpkg Package, obj *
 		return types.TypeString(obj.Type(), qf)
 	}
 
-	// If the given expr refers to a type parameter, then use the
-	// object's Type instead of the type parameter declaration. This helps
-	// format the instantiated type as opposed to the original undeclared
-	// generic type.
-	if typeparams.IsTypeParam(pkg.GetTypesInfo().Types[expr].Type) {
+	// If the given expr refers to a type parameter, such as in "[]T", then
+	// use the object's Type instead of the type parameter declaration. This
+	// helps format the instantiated type as opposed to the original
+	// undeclared generic type.
+	if refersToTypeParam(pkg.GetTypesInfo(), expr) {
 		return types.TypeString(obj.Type(), qf)
 	}
 
@@ -286,6 +286,21 @@ func FormatVarType(ctx context.Context, snapshot Snapshot, srcpkg Package, obj *
 	return fmted
 }
 
+// refersToTypeParam reports whether the type expression expr refers to a
+// type parameter.
+func refersToTypeParam(info *types.Info, expr ast.Expr) bool {
+	found := false
+	ast.Inspect(expr, func(n ast.Node) bool {
+		if id, ok := n.(*ast.Ident); ok {
+			if obj, ok := info.Uses[id].(*types.TypeName); ok && typeparams.IsTypeParam(obj.Type()) {
+				found = true
+			}
+		}
+		return !found
+	})
+	return found
+}
+
 // varType returns the type expression for a *types.Var.
 func varType(ctx context.Context, snapshot Snapshot, pkg Package, obj *types.Var) (ast.Expr, error) {
 	field, err := snapshot.PosToField(ctx, pkg, obj.Pos())
@@ -0,0 +1,592 @@
+// Copyright 2022 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package source
+
+import (
+	"context"
+	"fmt"
+	"go/ast"
+	"go/token"
+	"go/types"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/iansmith/golang-x-tools/internal/event"
+	"github.com/iansmith/golang-x-tools/internal/lsp/protocol"
+	"github.com/iansmith/golang-x-tools/internal/lsp/safetoken"
+	"github.com/iansmith/golang-x-tools/internal/span"
+	"github.com/iansmith/golang-x-tools/internal/typeparams"
+)
+
+// UnusedSymbols returns hints for the functions, methods, types, and
+// struct fields declared in the workspace packages that none of them
+// refer to, with quick fixes that delete the declarations.
+//
+// Symbols that may be used without a reference that the type checker
+// records are not reported: methods named like the method of an
+// interface, fields of structs that have tags, embedded fields,
+// functions without a body or named by a //go:linkname or //export
+// directive, and the entry points of programs and tests. Nothing is
+// reported if a workspace package has errors, since its references are
+// then incomplete.
+func UnusedSymbols(ctx context.Context, snapshot Snapshot) (map[span.URI][]*Diagnostic, error) {
+	ctx, done := event.Start(ctx, "source.UnusedSymbols")
+	defer done()
+
+	pkgs, err := snapshot.ActivePackages(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, pkg := range pkgs {
+		if pkg.HasListOrParseErrors() || pkg.HasTypeErrors() {
+			return nil, nil
+		}
+	}
+	u := &unusedFinder{
+		fset:         snapshot.FileSet(),
+		decls:        make(map[objKey]*unusedDecl),
+		receivers:    make(map[objKey]bool),
+		ifaceMethods: make(map[string]bool),
+		linknamed:    make(map[string]bool),
+	}
+	seen := make(map[span.URI]bool)
+	seenTypes := make(map[*types.Package]bool)
+	for _, pkg := range pkgs {
+		u.collectInterfaces(pkg, seenTypes)
+		for _, pgf := range pkg.CompiledGoFiles() {
+			if seen[pgf.URI] {
+				continue
+			}
+			seen[pgf.URI] = true
+			if u.fset.Position(pgf.File.Package).Filename != pgf.URI.Filename() {
+				continue // generated by cgo from another file
+			}
+			u.collectDirectives(pkg, pgf)
+			if IsGenerated(ctx, snapshot, pgf.URI) {
+				continue
+			}
+			u.collectDecls(pkg, pgf)
+		}
+	}
+	for _, d := range u.decls {
+		if parent := u.decls[d.parent]; parent != nil && d.kind == "method" {
+			parent.methods = append(parent.methods, d)
+		}
+	}
+	for _, pkg := range pkgs {
+		u.collectUses(pkg)
+	}
+	return u.diagnostics()
+}
+
+// An objKey identifies a declared object by the position of its name,
+// which is the same in all the variants of its package.
+type objKey struct {
+	filename string
+	offset   int
+}
+
+// An unusedDecl is the declaration of a symbol that may be unused.
+type unusedDecl struct {
+	kind string // "func", "method", "type", or "field"
+	name string // qualified by the type for methods and fields
+	pkg  Package
+	pgf  *ParsedGoFile
+	id   *ast.Ident
+
+	// node is the node to delete to delete the declaration, or nil if it
+	// can't be deleted by itself.
+	node ast.Node
+
+	// References within [start, end) are references of the declaration to
+	// itself.
+	start, end int
+
+	parent  objKey        // the type of a method or field
+	methods []*unusedDecl // the methods of a type
+	used    bool
+}
+
+type unusedFinder struct {
+	fset      *token.FileSet
+	decls     map[objKey]*unusedDecl
+	receivers map[objKey]bool // names of types in method receivers
+
+	ifaceMethods map[string]bool // names of all interface methods
+	linknamed    map[string]bool // "path.name" of symbols in go:linkname directives
+}
+
+func (u *unusedFinder) key(pos token.Pos) objKey {
+	p := u.fset.Position(pos)
+	return objKey{p.Filename, p.Offset}
+}
+
+var testEntryRe = regexp.MustCompile("^(Test|Benchmark|Fuzz|Example)")
+
+func (u *unusedFinder) collectDecls(pkg Package, pgf *ParsedGoFile) {
+	isTestFile := strings.HasSuffix(pgf.URI.Filename(), "_test.go")
+	add := func(kind, name string, id *ast.Ident, node, self ast.Node) *unusedDecl {
+		if id.Name == "_" {
+			return nil
+		}
+		d := &unusedDecl{kind: kind, name: name, pkg: pkg, pgf: pgf, id: id, node: node}
+		if self != nil {
+			if start, end, err := posOffsets(pgf.Tok, self.Pos(), self.End()); err == nil {
+				d.start, d.end = start, end
+			}
+		}
+		u.decls[u.key(id.Pos())] = d
+		return d
+	}
+	for _, decl := range pgf.File.Decls {
+		switch decl := decl.(type) {
+		case *ast.FuncDecl:
+			name := decl.Name.Name
+			var recv *ast.Ident
+			if decl.Recv != nil && len(decl.Recv.List) == 1 {
+				recv = receiverTypeName(decl.Recv.List[0].Type)
+				if recv == nil {
+					continue
+				}
+				u.receivers[u.key(recv.Pos())] = true
+			}
+			if decl.Body == nil || hasExportDirective(decl.Doc) {
+				continue
+			}
+			if recv != nil {
+				if d := add("method", recv.Name+"."+name, decl.Name, decl, decl); d != nil {
+					if obj := pkg.GetTypesInfo().Uses[recv]; obj != nil {
+						d.parent = u.key(obj.Pos())
+					}
+				}
+				continue
+			}
+			if name == "init" || name == "main" && pkg.Name() == "main" || isTestFile && testEntryRe.MatchString(name) {
+				continue
+			}
+			add("func", name, decl.Name, decl, decl)
+
+		case *ast.GenDecl:
+			if decl.Tok != token.TYPE {
+				continue
+			}
+			for _, spec := range decl.Specs {
+				spec := spec.(*ast.TypeSpec)
+				var node ast.Node = spec
+				if len(decl.Specs) == 1 {
+					node = decl
+				}
+				add("type", spec.Name.Name, spec.Name, node, spec)
+				st, ok := spec.Type.(*ast.StructType)
+				if !ok || hasTags(st) {
+					continue
+				}
+				for _, field := range st.Fields.List {
+					var node ast.Node
+					if len(field.Names) == 1 {
+						node = field
+					}
+					for _, id := range field.Names {
+						if d := add("field", spec.Name.Name+"."+id.Name, id, node, nil); d != nil {
+							d.parent = u.key(spec.Name.Pos())
+						}
+					}
+				}
+			}
+		}
+	}
+}
+
+// receiverTypeName returns the name of the type in the receiver type
+// expression x.
+func receiverTypeName(x ast.Expr) *ast.Ident {
+	if star, ok := x.(*ast.StarExpr); ok {
+		x = star.X
+	}
+	if base, _, _, _ := typeparams.UnpackIndexExpr(x); base != nil {
+		x = base
+	}
+	id, _ := x.(*ast.Ident)
+	return id
+}
+
+// hasExportDirective reports whether doc contains a cgo //export
+// directive.
+func hasExportDirective(doc *ast.CommentGroup) bool {
+	if doc == nil {
+		return false
+	}
+	for _, c := range doc.List {
+		if strings.HasPrefix(c.Text, "//export ") {
+			return true
+		}
+	}
+	return false
+}
+
+// hasTags reports whether any field of st has a tag, which suggests that
+// the fields are used through reflection.
+func hasTags(st *ast.StructType) bool {
+	for _, field := range st.Fields.List {
+		if field.Tag != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// collectInterfaces records the names of the methods of the interfaces
+// in pkg and in the packages it imports, directly or not.
+func (u *unusedFinder) collectInterfaces(pkg Package, seen map[*types.Package]bool) {
+	addMethods := func(T types.Type) {
+		if iface, ok := T.Underlying().(*types.Interface); ok {
+			for i := 0; i < iface.NumMethods(); i++ {
+				u.ifaceMethods[iface.Method(i).Name()] = true
+			}
+		}
+	}
+	var visit func(*types.Package)
+	visit = func(p *types.Package) {
+		if seen[p] {
+			return
+		}
+		seen[p] = true
+		scope := p.Scope()
+		for _, name := range scope.Names() {
+			if tn, ok := scope.Lookup(name).(*types.TypeName); ok {
+				addMethods(tn.Type())
+			}
+		}
+		for _, imp := range p.Imports() {
+			visit(imp)
+		}
+	}
+	visit(pkg.GetTypes())
+
+	// Interfaces that are not declared at package level.
+	for _, tv := range pkg.GetTypesInfo().Types {
+		if tv.Type != nil {
+			addMethods(tv.Type)
+		}
+	}
+}
+
+// collectDirectives records the symbols that the //go:linkname directives
+// of pgf name.
+func (u *unusedFinder) collectDirectives(pkg Package, pgf *ParsedGoFile) {
+	for _, cg := range pgf.File.Comments {
+		for _, c := range cg.List {
+			if !strings.HasPrefix(c.Text, "//go:linkname ") {
+				continue
+			}
+			fields := strings.Fields(c.Text)
+			if len(fields) >= 2 {
+				u.linknamed[pkg.PkgPath()+"."+fields[1]] = true
+			}
+			if len(fields) >= 3 {
+				u.linknamed[fields[2]] = true
+			}
+		}
+	}
+}
+
+func (u *unusedFinder) collectUses(pkg Package) {
+	info := pkg.GetTypesInfo()
+	for id, obj := range info.Uses {
+		u.use(id.Pos(), obj)
+	}
+	// The fields of unkeyed struct literals are used without a reference.
+	for _, pgf := range pkg.CompiledGoFiles() {
+		ast.Inspect(pgf.File, func(n ast.Node) bool {
+			lit, ok := n.(*ast.CompositeLit)
+			if !ok || len(lit.Elts) == 0 {
+				return true
+			}
+			if _, ok := lit.Elts[0].(*ast.KeyValueExpr); ok {
+				return true
+			}
+			T := info.TypeOf(lit)
+			if T == nil {
+				return true
+			}
+			if st, ok := T.Underlying().(*types.Struct); ok {
+				for i := 0; i < st.NumFields(); i++ {
+					u.use(token.NoPos, st.Field(i))
+				}
+			}
+			return true
+		})
+	}
+}
+
+// use records a reference at pos to obj, unless the reference is within
+// the declaration of obj, or of one of its methods.
+func (u *unusedFinder) use(pos token.Pos, obj types.Object) {
+	if obj == nil || obj.Pkg() == nil {
+		return
+	}
+	d := u.decls[u.key(obj.Pos())]
+	if d == nil || d.used {
+		return
+	}
+	if pos.IsValid() {
+		k := u.key(pos)
+		if u.receivers[k] || d.contains(k) {
+			return
+		}
+		for _, m := range d.methods {
+			if m.contains(k) {
+				return
+			}
+		}
+	}
+	d.used = true
+}
+
+// contains reports whether the reference at k is within the declaration
+// d.
+func (d *unusedDecl) contains(k objKey) bool {
+	return k.filename == d.pgf.URI.Filename() && d.start <= k.offset && k.offset < d.end
+}
+
+func (u *unusedFinder) diagnostics() (map[span.URI][]*Diagnostic, error) {
+	reports := make(map[span.URI][]*Diagnostic)
+	for _, d := range u.decls {
+		if d.used {
+			continue
+		}
+		if parent := u.decls[d.parent]; parent != nil && !parent.used {
+			continue // deleted with its type
+		}
+		if d.kind == "method" && u.ifaceMethods[d.id.Name] || u.linknamed[d.pkg.PkgPath()+"."+d.name] {
+			continue
+		}
+		rng, err := NewMappedRange(u.fset, d.pgf.Mapper, d.id.Pos(), d.id.End()).Range()
+		if err != nil {
+			return nil, err
+		}
+		diag := &Diagnostic{
+			URI:      d.pgf.URI,
+			Range:    rng,
+			Severity: protocol.SeverityHint,
+			Source:   UnusedSymbol,
+			Message:  fmt.Sprintf("%s %s is unused", d.kind, d.name),
+			Tags:     []protocol.DiagnosticTag{protocol.Unnecessary},
+		}
+		if edits, err := u.deleteEdits(d); err != nil {
+			return nil, err
+		} else if edits != nil {
+			diag.SuggestedFixes = []SuggestedFix{{
+				Title:      fmt.Sprintf("Delete %s %s", d.kind, d.name),
+				Edits:      edits,
+				ActionKind: protocol.QuickFix,
+			}}
+		}
+		reports[d.pgf.URI] = append(reports[d.pgf.URI], diag)
+	}
+	return reports, nil
+}
+
+// deleteEdits returns the edits that delete the declaration d, along with
+// its methods and the imports that only they use, or nil if d can't be
+// deleted.
+func (u *unusedFinder) deleteEdits(d *unusedDecl) (map[span.URI][]protocol.TextEdit, error) {
+	decls := append([]*unusedDecl{d}, d.methods...)
+	byFile := make(map[*ParsedGoFile][]*unusedDecl)
+	for _, d := range decls {
+		if d.node == nil {
+			return nil, nil
+		}
+		byFile[d.pgf] = append(byFile[d.pgf], d)
+	}
+	edits := make(map[span.URI][]protocol.TextEdit)
+	for pgf, decls := range byFile {
+		var deleted [][2]int
+		for _, d := range decls {
+			start, end, err := nodeOffsets(pgf, d.node)
+			if err != nil {
+				return nil, err
+			}
+			s, e, ok := lineRange(pgf.Src, start, end)
+			if !ok {
+				return nil, nil
+			}
+			deleted = append(deleted, [2]int{s, e})
+		}
+		for _, r := range deletedRanges(pgf, decls[0].pkg.GetTypesInfo(), deleted) {
+			rng, err := NewMappedRange(u.fset, pgf.Mapper, pgf.Tok.Pos(r[0]), pgf.Tok.Pos(r[1])).Range()
+			if err != nil {
+				return nil, err
+			}
+			edits[pgf.URI] = append(edits[pgf.URI], protocol.TextEdit{Range: rng})
+		}
+	}
+	return edits, nil
+}
+
+// deletedRanges returns the deleted offset ranges of pgf, along with the
+// ranges of the imports that only they use, sorted and merged.
+func deletedRanges(pgf *ParsedGoFile, info *types.Info, deleted [][2]int) [][2]int {
+	deleted = append(deleted, unusedImports(pgf, info, deleted)...)
+
+	// Merge the ranges, which may overlap in the blank lines they include.
+	sort.Slice(deleted, func(i, j int) bool { return deleted[i][0] < deleted[j][0] })
+	var merged [][2]int
+	for _, r := range deleted {
+		if n := len(merged); n > 0 && r[0] <= merged[n-1][1] {
+			if r[1] > merged[n-1][1] {
+				merged[n-1][1] = r[1]
+			}
+			continue
+		}
+		merged = append(merged, r)
+	}
+	// Don't leave a blank line at the end of the file.
+	if n := len(merged); n > 0 && merged[n-1][1] == len(pgf.Src) {
+		if s := merged[n-1][0]; s > 1 && pgf.Src[s-1] == '\n' && pgf.Src[s-2] == '\n' {
+			merged[n-1][0]--
+		}
+	}
+	return merged
+}
+
+// nodeOffsets returns the offsets of the start and end of n in pgf,
+// including its doc comment and line comment.
+func nodeOffsets(pgf *ParsedGoFile, n ast.Node) (int, int, error) {
+	start, end := n.Pos(), n.End()
+	var doc, comment *ast.CommentGroup
+	switch n := n.(type) {
+	case *ast.FuncDecl:
+		doc = n.Doc
+	case *ast.GenDecl:
+		doc = n.Doc
+	case *ast.TypeSpec:
+		doc, comment = n.Doc, n.Comment
+	case *ast.ImportSpec:
+		doc, comment = n.Doc, n.Comment
+	case *ast.ValueSpec:
+		doc, comment = n.Doc, n.Comment
+	case *ast.Field:
+		doc, comment = n.Doc, n.Comment
+	}
+	if doc != nil {
+		start = doc.Pos()
+	}
+	if comment != nil {
+		end = comment.End()
+	}
+	return posOffsets(pgf.Tok, start, end)
+}
+
+// posOffsets returns the offsets of start and end in tok.
+func posOffsets(tok *token.File, start, end token.Pos) (int, int, error) {
+	s, err := safetoken.Offset(tok, start)
+	if err != nil {
+		return 0, 0, err
+	}
+	e, err := safetoken.Offset(tok, end)
+	if err != nil {
+		return 0, 0, err
+	}
+	return s, e, nil
+}
+
+// lineRange returns the offsets of the lines that the text of src from
+// start to end spans, and reports whether the text is alone on them. The
+// range includes a blank line that follows the text.
+func lineRange(src []byte, start, end int) (int, int, bool) {
+	s := start
+	for s > 0 && (src[s-1] == ' ' || src[s-1] == '\t') {
+		s--
+	}
+	if s > 0 && src[s-1] != '\n' {
+		return 0, 0, false
+	}
+	e := end
+	for e < len(src) && (src[e] == ' ' || src[e] == '\t' || src[e] == '\r') {
+		e++
+	}
+	if e < len(src) && src[e] != '\n' {
+		return 0, 0, false
+	}
+	if e < len(src) {
+		e++
+	}
+	if e < len(src) && src[e] == '\n' {
+		e++
+	}
+	return s, e, true
+}
+
+// unusedImports returns the offset ranges of the imports of pgf that are
+// only used within the deleted ranges.
+func unusedImports(pgf *ParsedGoFile, info *types.Info, deleted [][2]int) [][2]int {
+	inDeleted := func(offset int) bool {
+		for _, r := range deleted {
+			if r[0] <= offset && offset < r[1] {
+				return true
+			}
+		}
+		return false
+	}
+	tok := pgf.Tok
+	var ranges [][2]int
+	for _, decl := range pgf.File.Decls {
+		decl, ok := decl.(*ast.GenDecl)
+		if !ok || decl.Tok != token.IMPORT {
+			continue
+		}
+		var unused []ast.Node
+		for _, spec := range decl.Specs {
+			spec := spec.(*ast.ImportSpec)
+			var obj types.Object
+			if spec.Name != nil {
+				obj = info.Defs[spec.Name]
+			} else {
+				obj = info.Implicits[spec]
+			}
+			pkgName, ok := obj.(*types.PkgName)
+			if !ok {
+				continue // blank or dot import
+			}
+			inside, outside := 0, 0
+			for id, obj := range info.Uses {
+				if obj != pkgName {
+					continue
+				}
+				offset, err := safetoken.Offset(tok, id.Pos())
+				if err != nil {
+					continue // use in another file
+				}
+				if inDeleted(offset) {
+					inside++
+				} else {
+					outside++
+				}
+			}
+			if inside > 0 && outside == 0 {
+				unused = append(unused, spec)
+			}
+		}
+		if len(unused) == 0 {
+			continue
+		}
+		nodes := unused
+		if len(unused) == len(decl.Specs) {
+			nodes = []ast.Node{decl}
+		}
+		for _, n := range nodes {
+			start, end, err := nodeOffsets(pgf, n)
+			if err != nil {
+				continue
+			}
+			if s, e, ok := lineRange(pgf.Src, start, end); ok {
+				ranges = append(ranges, [2]int{s, e})
+			}
+		}
+	}
+	return ranges
+}
@@ -130,6 +130,50 @@ func IsGenerated(ctx context.Context, snapshot Snapshot, uri span.URI) bool {
 	return false
 }
 
+// CheckGeneratedEdits returns an error naming the generated files that
+// the edits of a refactoring modify, unless the refactorGeneratedFiles
+// setting allows it.
+func CheckGeneratedEdits(ctx context.Context, snapshot Snapshot, edits map[span.URI][]protocol.TextEdit) error {
+	if snapshot.View().Options().RefactorGeneratedFiles {
+		return nil
+	}
+	var generated []string
+	for uri, e := range edits {
+		if len(e) > 0 && IsGenerated(ctx, snapshot, uri) {
+			generated = append(generated, uri.Filename())
+		}
+	}
+	if len(generated) == 0 {
+		return nil
+	}
+	sort.Strings(generated)
+	return fmt.Errorf("refusing to edit generated files %s (set refactorGeneratedFiles to allow it)", strings.Join(generated, ", "))
+}
+
+// GoMinorVersion returns the minor version of the Go version v of a go
+// directive, such as 17 for "1.17" or "1.17.2".
+func GoMinorVersion(v string) (int, bool) {
+	if !strings.HasPrefix(v, "1.") {
+		return 0, false
+	}
+	v = v[len("1."):]
+	if i := strings.IndexByte(v, '.'); i >= 0 {
+		v = v[:i]
+	}
+	minor, err := strconv.Atoi(v)
+	if err != nil || minor < 0 {
+		return 0, false
+	}
+	return minor, true
+}
+
+// IsLargeFile reports whether content, of a Go file, is larger than the
+// largeFileThreshold setting.
+func IsLargeFile(snapshot Snapshot, content []byte) bool {
+	threshold := snapshot.View().Options().LargeFileThreshold
+	return threshold > 0 && len(content) > threshold
+}
+
 func nodeToProtocolRange(snapshot Snapshot, pkg Package, n ast.Node) (protocol.Range, error) {
 	mrng, err := posToMappedRange(snapshot, pkg, n.Pos(), n.End())
 	if err != nil {
@@ -212,6 +256,8 @@ func (k FileKind) String() string {
 		return "tmpl"
 	case Work:
 		return "go.work"
+	case Asm:
+		return "asm"
 	default:
 		return fmt.Sprintf("unk%d", k)
 	}
@@ -345,6 +391,109 @@ func findFileInDeps(pkg Package, uri span.URI) (*ParsedGoFile, Package, error) {
 	return nil, nil, fmt.Errorf("no file for %s in package %s", uri, pkg.ID())
 }
 
+// packageForPath returns the package with the given path, searching the
+// dependencies of pkg before the other packages known to the snapshot.
+func packageForPath(ctx context.Context, snapshot Snapshot, pkg Package, pkgPath string) (Package, error) {
+	queue := []Package{pkg}
+	seen := make(map[string]bool)
+	for len(queue) > 0 {
+		p := queue[0]
+		queue = queue[1:]
+		if p.PkgPath() == pkgPath {
+			return p, nil
+		}
+		seen[p.ID()] = true
+		for _, dep := range p.Imports() {
+			if !seen[dep.ID()] {
+				queue = append(queue, dep)
+			}
+		}
+	}
+	known, err := snapshot.CachedImportPaths(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if p, ok := known[pkgPath]; ok {
+		return p, nil
+	}
+	return nil, fmt.Errorf("package %q not found", pkgPath)
+}
+
+// IsDeprecatedDoc reports whether the documentation doc marks its
+// declaration as deprecated.
+func IsDeprecatedDoc(doc *ast.CommentGroup) bool {
+	if doc == nil {
+		return false
+	}
+	for _, c := range doc.List {
+		if strings.HasPrefix(c.Text, "// Deprecated") {
+			return true
+		}
+	}
+	return false
+}
+
+// IsDeprecated reports whether the declaration of obj, a package-level
+// object, method or struct field used in pkg, is documented as deprecated.
+func IsDeprecated(ctx context.Context, snapshot Snapshot, pkg Package, obj types.Object) bool {
+	field := false
+	switch obj := obj.(type) {
+	case *types.Func:
+		// Interface methods are declared by fields.
+		if recv := obj.Type().(*types.Signature).Recv(); recv != nil {
+			field = types.IsInterface(recv.Type())
+		}
+	case *types.Var:
+		field = obj.IsField()
+		if !field && !packageLevelObject(obj) {
+			return false
+		}
+	default:
+		if !packageLevelObject(obj) {
+			return false
+		}
+	}
+	if obj.Pkg() == nil || !obj.Pos().IsValid() {
+		return false
+	}
+	declPkg, err := packageForPath(ctx, snapshot, pkg, obj.Pkg().Path())
+	if err != nil {
+		return false
+	}
+	if field {
+		field, err := snapshot.PosToField(ctx, declPkg, obj.Pos())
+		return err == nil && field != nil && IsDeprecatedDoc(field.Doc)
+	}
+	decl, err := snapshot.PosToDecl(ctx, declPkg, obj.Pos())
+	if err != nil {
+		return false
+	}
+	switch decl := decl.(type) {
+	case *ast.FuncDecl:
+		return IsDeprecatedDoc(decl.Doc)
+	case *ast.GenDecl:
+		if IsDeprecatedDoc(decl.Doc) {
+			return true
+		}
+		// A spec of a grouped declaration may be deprecated on its own.
+		for _, spec := range decl.Specs {
+			switch spec := spec.(type) {
+			case *ast.TypeSpec:
+				if spec.Name.Pos() == obj.Pos() {
+					return IsDeprecatedDoc(spec.Doc)
+				}
+			case *ast.ValueSpec:
+				for _, name := range spec.Names {
+					if name.Pos() == obj.Pos() {
+						return IsDeprecatedDoc(spec.Doc)
+					}
+				}
+			}
+		}
+	}
+	return false
+}
+
 // ImportPath returns the unquoted import path of s,
 // or "" if the path is not properly quoted.
 func ImportPath(s *ast.ImportSpec) string {
@@ -81,3 +81,23 @@ func scanFile(fset *token.FileSet, name string, content []byte) *token.File {
 	}
 	return f
 }
+
+func TestGoMinorVersion(t *testing.T) {
+	for _, test := range []struct {
+		version string
+		want    int
+		ok      bool
+	}{
+		{"1.17", 17, true},
+		{"1.17.2", 17, true},
+		{"1.0", 0, true},
+		{"2.0", 0, false},
+		{"1.x", 0, false},
+		{"", 0, false},
+	} {
+		got, ok := GoMinorVersion(test.version)
+		if got != test.want || ok != test.ok {
+			t.Errorf("GoMinorVersion(%q) = %d, %t, want %d, %t", test.version, got, ok, test.want, test.ok)
+		}
+	}
+}
@@ -22,6 +22,7 @@ import (
 	"github.com/iansmith/golang-x-tools/go/packages"
 	"github.com/iansmith/golang-x-tools/internal/gocommand"
 	"github.com/iansmith/golang-x-tools/internal/imports"
+	"github.com/iansmith/golang-x-tools/internal/lsp/command"
 	"github.com/iansmith/golang-x-tools/internal/lsp/progress"
 	"github.com/iansmith/golang-x-tools/internal/lsp/protocol"
 	"github.com/iansmith/golang-x-tools/internal/span"
@@ -93,9 +94,19 @@ type Snapshot interface {
 	// for pkg, grouped by file.
 	DiagnosePackage(ctx context.Context, pkg Package) (map[span.URI][]*Diagnostic, error)
 
+	// PlatformDiagnostics returns the errors of the package of the directory
+	// dir, type-checked for platform, a GOOS/GOARCH pair such as
+	// "windows/arm64", grouped by file.
+	PlatformDiagnostics(ctx context.Context, dir span.URI, platform string) (map[span.URI][]*Diagnostic, error)
+
 	// Analyze runs the analyses for the given package at this snapshot.
 	Analyze(ctx context.Context, pkgID string, analyzers []*Analyzer) ([]*Diagnostic, error)
 
+	// AnalysisResult returns the result of running the given analyzer on the
+	// package with the given ID, such as the printf analyzer's knowledge of
+	// printf wrappers.
+	AnalysisResult(ctx context.Context, pkgID string, a *analysis.Analyzer) (interface{}, error)
+
 	// RunGoCommandPiped runs the given `go` command, writing its output
 	// to stdout and stderr. Verb, Args, and WorkingDir must be specified.
 	//
@@ -131,6 +142,15 @@ type Snapshot interface {
 	// the given go.mod file.
 	ModTidy(ctx context.Context, pm *ParsedModule) (*TidiedModule, error)
 
+	// Vulnerabilities returns the known vulnerabilities of the packages
+	// imported, directly or indirectly, by the package with the given ID.
+	Vulnerabilities(ctx context.Context, id string) ([]command.Vuln, error)
+
+	// ModuleGraph returns the modules of the packages loaded in the snapshot
+	// by path, and for each of them the sorted paths of the other modules
+	// whose packages its packages import.
+	ModuleGraph(ctx context.Context) (map[string]*packages.Module, map[string][]string, error)
+
 	// GoModForFile returns the URI of the go.mod file for the given URI.
 	GoModForFile(uri span.URI) span.URI
 
@@ -167,6 +187,10 @@ type Snapshot interface {
 	// in TypecheckWorkspace mode.
 	KnownPackages(ctx context.Context) ([]Package, error)
 
+	// ImportGraph returns the packages loaded in this snapshot, except the
+	// intermediate test variants, with their imports, sorted by ID.
+	ImportGraph(ctx context.Context) ([]*ImportGraphNode, error)
+
 	// ActivePackages returns the packages considered 'active' in the workspace.
 	//
 	// In normal memory mode, this is all workspace packages. In degraded memory
@@ -176,6 +200,12 @@ type Snapshot interface {
 	// Symbols returns all symbols in the snapshot.
 	Symbols(ctx context.Context) (map[span.URI][]Symbol, error)
 
+	// PersistedSymbols returns the symbols of the workspace files, and the
+	// metadata of their packages, as recorded by a previous session for the
+	// same view. It reports false once the initial workspace load has
+	// completed, or if nothing was recorded.
+	PersistedSymbols(ctx context.Context) (map[span.URI][]Symbol, map[span.URI]Metadata, bool)
+
 	// Metadata returns package metadata associated with the given file URI.
 	MetadataForFile(ctx context.Context, uri span.URI) ([]Metadata, error)
 
@@ -268,6 +298,10 @@ type View interface {
 	// ModuleUpgrades returns known module upgrades.
 	ModuleUpgrades() map[string]string
 
+	// GoVersion returns the minor version of the go command of the view,
+	// such as 18 for go1.18.
+	GoVersion() int
+
 	// RegisterModuleUpgrades registers that upgrades exist for the given modules.
 	RegisterModuleUpgrades(upgrades map[string]string)
 
@@ -387,8 +421,25 @@ type Session interface {
 	// any directory in the module root, and any replace targets.
 	FileWatchingGlobPatterns(ctx context.Context) map[string]struct{}
 
+	// FileWatchingDirs returns the roots of the directory trees to watch for
+	// changes of the files known by the views, for clients that cannot watch
+	// files themselves: the view folders, and the workspace directories
+	// outside of them, such as replace targets.
+	FileWatchingDirs(ctx context.Context) []span.URI
+
+	// ShouldWatch reports whether changes of the file or directory at path,
+	// in one of the trees of FileWatchingDirs, may affect the views. Files
+	// irrelevant to gopls, and directories excluded by the directoryFilters
+	// of all views, need not be watched.
+	ShouldWatch(path string, isDir bool) bool
+
 	// SetProgressTracker sets the progress tracker for the session.
 	SetProgressTracker(tracker *progress.Tracker)
+
+	// SetPanicHandler sets the function called with a description of each
+	// panic, in the computation of the results of a snapshot, that the views
+	// of the session have recovered from.
+	SetPanicHandler(handler func(context.Context, error))
 }
 
 var ErrViewExists = errors.New("view already exists for session")
@@ -541,7 +592,7 @@ func (id FileIdentity) String() string {
 }
 
 // FileKind describes the kind of the file in question.
-// It can be one of Go,mod, Sum, or Tmpl.
+// It can be one of Go, Mod, Sum, Tmpl, Work, or Asm.
 type FileKind int
 
 const (
@@ -558,6 +609,8 @@ const (
 	Tmpl
 	// Work is a go.work file.
 	Work
+	// Asm is a Go assembly file.
+	Asm
 )
 
 // Analyzer represents a go/analysis analyzer with some boolean properties
@@ -595,9 +648,46 @@ func (a Analyzer) IsEnabled(view View) bool {
 	if enabled, ok := view.Options().Analyses[a.Analyzer.Name]; ok {
 		return enabled
 	}
+	if _, ok := view.Options().StaticcheckAnalyzers[a.Analyzer.Name]; ok {
+		if checks := view.Options().StaticcheckConfig["checks"]; len(checks) > 0 {
+			return checkEnabled(checks, a.Analyzer.Name, a.Enabled)
+		}
+	}
 	return a.Enabled
 }
 
+// checkEnabled reports whether the staticcheck check name is enabled by the
+// list of checks, as in a staticcheck.conf file: each entry enables the checks
+// it matches, or disables them if it begins with "-", and matches all checks
+// if it is "all" or "*", the checks of a prefix if it ends with "*", or a
+// single check. "inherit" stands for the default of the check, and checks
+// not listed are disabled.
+func checkEnabled(checks []string, name string, def bool) bool {
+	enabled := false
+	for _, check := range checks {
+		if check == "inherit" {
+			enabled = def
+			continue
+		}
+		enable := true
+		if strings.HasPrefix(check, "-") {
+			enable = false
+			check = check[1:]
+		}
+		switch {
+		case check == "all" || check == "*":
+		case strings.HasSuffix(check, "*"):
+			if !strings.HasPrefix(name, check[:len(check)-1]) {
+				continue
+			}
+		case check != name:
+			continue
+		}
+		enabled = enable
+	}
+	return enabled
+}
+
 // Package represents a Go package that has been type-checked. It maintains
 // only the relevant fields of a *go/packages.Package.
 type Package interface {
@@ -606,6 +696,7 @@ type Package interface {
 	PkgPath() string
 	CompiledGoFiles() []*ParsedGoFile
 	File(uri span.URI) (*ParsedGoFile, error)
+	OtherFiles() []span.URI // non-Go source files, such as assembly files
 	GetSyntax() []*ast.File
 	GetTypes() *types.Package
 	GetTypesInfo() *types.Info
@@ -664,6 +755,13 @@ const (
 	UpgradeNotification      DiagnosticSource = "upgrade available"
 	TemplateError            DiagnosticSource = "template"
 	WorkFileError            DiagnosticSource = "go.work file"
+	TestResult               DiagnosticSource = "go test"
+	CoverageInfo             DiagnosticSource = "coverage"
+	UnusedSymbol             DiagnosticSource = "unused"
+	EmbedError               DiagnosticSource = "go:embed"
+	Vulnerability            DiagnosticSource = "govulncheck"
+	DuplicateCodeHint        DiagnosticSource = "duplicate code"
+	GoVersionError           DiagnosticSource = "go version"
 )
 
 func AnalyzerErrorKind(name string) DiagnosticSource {
@@ -0,0 +1,155 @@
+// Copyright 2022 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package source
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/iansmith/golang-x-tools/internal/event"
+	"github.com/iansmith/golang-x-tools/internal/lsp/command"
+	"github.com/iansmith/golang-x-tools/internal/lsp/protocol"
+	"github.com/iansmith/golang-x-tools/internal/span"
+)
+
+// VulnerabilityDiagnostics returns warnings at the imports of the workspace
+// packages that name packages with known vulnerabilities, and at the
+// requirements of the workspace go.mod files on the modules that provide
+// the vulnerable packages their packages import, directly or indirectly.
+// The warnings of vulnerabilities that are fixed in a later version have a
+// quick fix that upgrades the module to it.
+func VulnerabilityDiagnostics(ctx context.Context, snapshot Snapshot) (map[span.URI][]*Diagnostic, error) {
+	ctx, done := event.Start(ctx, "source.VulnerabilityDiagnostics")
+	defer done()
+
+	pkgs, err := snapshot.ActivePackages(ctx)
+	if err != nil {
+		return nil, err
+	}
+	reports := make(map[span.URI][]*Diagnostic)
+	seen := make(map[span.URI]bool)
+	modVulns := make(map[span.URI][]command.Vuln)
+	seenModVulns := make(map[span.URI]map[[2]string]bool)
+	for _, pkg := range pkgs {
+		vulns, err := snapshot.Vulnerabilities(ctx, pkg.ID())
+		if err != nil {
+			return nil, err
+		}
+		if len(vulns) == 0 {
+			continue
+		}
+		var modURI span.URI
+		for _, pgf := range pkg.CompiledGoFiles() {
+			modURI = snapshot.GoModForFile(pgf.URI)
+			if seen[pgf.URI] {
+				continue
+			}
+			seen[pgf.URI] = true
+			for _, imp := range pgf.File.Imports {
+				path := ImportPath(imp)
+				for _, v := range vulns {
+					if v.PkgPath != path {
+						continue
+					}
+					rng, err := NewMappedRange(snapshot.FileSet(), pgf.Mapper, imp.Path.Pos(), imp.Path.End()).Range()
+					if err != nil {
+						return nil, err
+					}
+					fixes, err := vulnFixes(modURI, v, fmt.Sprintf("Upgrade %s to %s", v.ModPath, v.FixedVersion))
+					if err != nil {
+						return nil, err
+					}
+					reports[pgf.URI] = append(reports[pgf.URI], &Diagnostic{
+						URI:            pgf.URI,
+						Range:          rng,
+						Severity:       protocol.SeverityWarning,
+						Code:           v.ID,
+						CodeHref:       v.URL,
+						Source:         Vulnerability,
+						Message:        fmt.Sprintf("%s has a known vulnerability%s", v.PkgPath, vulnSummary(v)),
+						SuggestedFixes: fixes,
+					})
+				}
+			}
+		}
+		if modURI == "" {
+			continue
+		}
+		if seenModVulns[modURI] == nil {
+			seenModVulns[modURI] = make(map[[2]string]bool)
+		}
+		for _, v := range vulns {
+			if key := [2]string{v.PkgPath, v.ID}; !seenModVulns[modURI][key] {
+				seenModVulns[modURI][key] = true
+				modVulns[modURI] = append(modVulns[modURI], v)
+			}
+		}
+	}
+
+	for modURI, vulns := range modVulns {
+		fh, err := snapshot.GetFile(ctx, modURI)
+		if err != nil {
+			return nil, err
+		}
+		pm, err := snapshot.ParseMod(ctx, fh)
+		if err != nil {
+			// The errors of the go.mod file are reported by its diagnostics.
+			continue
+		}
+		for _, req := range pm.File.Require {
+			for _, v := range vulns {
+				if v.ModPath != req.Mod.Path {
+					continue
+				}
+				rng, err := LineToRange(pm.Mapper, modURI, req.Syntax.Start, req.Syntax.End)
+				if err != nil {
+					return nil, err
+				}
+				fixes, err := vulnFixes(modURI, v, fmt.Sprintf("Upgrade to %s", v.FixedVersion))
+				if err != nil {
+					return nil, err
+				}
+				reports[modURI] = append(reports[modURI], &Diagnostic{
+					URI:            modURI,
+					Range:          rng,
+					Severity:       protocol.SeverityWarning,
+					Code:           v.ID,
+					CodeHref:       v.URL,
+					Source:         Vulnerability,
+					Message:        fmt.Sprintf("%s has a known vulnerability in %s%s", v.ModPath, v.PkgPath, vulnSummary(v)),
+					SuggestedFixes: fixes,
+				})
+			}
+		}
+	}
+	return reports, nil
+}
+
+// vulnSummary returns the ID of v, and the version in which it is fixed if
+// there is one, for the message of a diagnostic.
+func vulnSummary(v command.Vuln) string {
+	if v.FixedVersion == "" {
+		return fmt.Sprintf(": %s", v.ID)
+	}
+	return fmt.Sprintf(": %s (fixed in %s)", v.ID, v.FixedVersion)
+}
+
+// vulnFixes returns the quick fix titled title that upgrades the module of
+// v to the version in which v is fixed, in the go.mod file modURI, if
+// there is such a version.
+func vulnFixes(modURI span.URI, v command.Vuln, title string) ([]SuggestedFix, error) {
+	if modURI == "" || v.FixedVersion == "" {
+		return nil, nil
+	}
+	cmd, err := command.NewUpgradeDependencyCommand(title, command.DependencyArgs{
+		URI:        protocol.URIFromSpanURI(modURI),
+		AddRequire: false,
+		GoCmdArgs:  []string{v.ModPath + "@" + v.FixedVersion},
+	})
+	if err != nil {
+		return nil, err
+	}
+	return []SuggestedFix{SuggestedFixFromCommand(cmd, protocol.QuickFix)}, nil
+}
@@ -317,17 +317,31 @@ func (sc *symbolCollector) walk(ctx context.Context, views []View) ([]protocol.S
 	for _, v := range views {
 		snapshot, release := v.Snapshot(ctx)
 		defer release()
+
+		filterer := NewFilterer(v.Options().DirectoryFilters)
+		folder := filepath.ToSlash(v.Folder().Filename())
+
+		// Until the workspace is loaded, which may take minutes in large
+		// workspaces, use the symbols recorded by a previous session.
+		if psyms, mds, ok := snapshot.PersistedSymbols(ctx); ok {
+			for uri, syms := range psyms {
+				nm := strings.TrimPrefix(filepath.ToSlash(uri.Filename()), folder)
+				if _, ok := files[uri]; ok || filterer.Disallow(nm) {
+					continue
+				}
+				files[uri] = symbolFile{uri, mds[uri], syms}
+			}
+			continue
+		}
+
 		psyms, err := snapshot.Symbols(ctx)
 		if err != nil {
 			return nil, err
 		}
-
-		filters := v.Options().DirectoryFilters
-		folder := filepath.ToSlash(v.Folder().Filename())
 		for uri, syms := range psyms {
 			norm := filepath.ToSlash(uri.Filename())
 			nm := strings.TrimPrefix(norm, folder)
-			if FiltersDisallow(nm, filters) {
+			if filterer.Disallow(nm) {
 				continue
 			}
 			// Only scan each file once.
@@ -378,28 +392,6 @@ func (sc *symbolCollector) walk(ctx context.Context, views []View) ([]protocol.S
 	return sc.results(), nil
 }
 
-// FilterDisallow is code from the body of cache.pathExcludedByFilter in cache/view.go
-// Exporting and using that function would cause an import cycle.
-// Moving it here and exporting it would leave behind view_test.go.
-// (This code is exported and used in the body of cache.pathExcludedByFilter)
-func FiltersDisallow(path string, filters []string) bool {
-	path = strings.TrimPrefix(path, "/")
-	var excluded bool
-	for _, filter := range filters {
-		op, prefix := filter[0], filter[1:]
-		// Non-empty prefixes have to be precise directory matches.
-		if prefix != "" {
-			prefix = prefix + "/"
-			path = path + "/"
-		}
-		if !strings.HasPrefix(path, prefix) {
-			continue
-		}
-		excluded = op == '-'
-	}
-	return excluded
-}
-
 // symbolFile holds symbol information for a single file.
 type symbolFile struct {
 	uri  span.URI
@@ -0,0 +1,283 @@
+// Copyright 2022 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package source
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"go/ast"
+	"go/token"
+	"go/types"
+	"strconv"
+	"strings"
+	"text/template"
+
+	"github.com/iansmith/golang-x-tools/go/analysis"
+	"github.com/iansmith/golang-x-tools/internal/imports"
+	"github.com/iansmith/golang-x-tools/internal/lsp/protocol"
+	"github.com/iansmith/golang-x-tools/internal/span"
+)
+
+// errorWrapData is the data of the errorWrapTemplate setting.
+type errorWrapData struct {
+	Func    string // the name of the function returning the error
+	Package string // the name of its package
+}
+
+// parseErrorWrapTemplate parses the errorWrapTemplate setting, whose output
+// must contain the %w verb.
+func parseErrorWrapTemplate(text string) (*template.Template, error) {
+	tmpl, err := template.New("errorWrapTemplate").Parse(text)
+	if err != nil {
+		return nil, err
+	}
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, errorWrapData{Func: "f", Package: "p"}); err != nil {
+		return nil, err
+	}
+	if strings.Count(buf.String(), "%w") != 1 {
+		return nil, fmt.Errorf("the message must contain %%w once")
+	}
+	return tmpl, nil
+}
+
+// A wrappableReturn is the error variable returned by a return statement,
+// which may be wrapped with fmt.Errorf.
+type wrappableReturn struct {
+	err *ast.Ident
+	fn  string // the name of the enclosing function declaration
+}
+
+// wrappableReturns returns the error variables returned by the return
+// statements of file that intersect [start, end]: the last results of the
+// functions whose last result is an error.
+func wrappableReturns(info *types.Info, file *ast.File, start, end token.Pos) []wrappableReturn {
+	errorType := types.Universe.Lookup("error").Type()
+	var returns []wrappableReturn
+	for _, decl := range file.Decls {
+		fd, ok := decl.(*ast.FuncDecl)
+		if !ok || fd.Body == nil || fd.End() < start || end < fd.Pos() {
+			continue
+		}
+		obj, ok := info.Defs[fd.Name].(*types.Func)
+		if !ok {
+			continue
+		}
+		var inspect func(body *ast.BlockStmt, sig *types.Signature)
+		inspect = func(body *ast.BlockStmt, sig *types.Signature) {
+			ast.Inspect(body, func(n ast.Node) bool {
+				switch n := n.(type) {
+				case *ast.FuncLit:
+					if sig, ok := info.TypeOf(n).(*types.Signature); ok {
+						inspect(n.Body, sig)
+					}
+					return false
+				case *ast.ReturnStmt:
+					if n.End() < start || end < n.Pos() {
+						return false
+					}
+					results := sig.Results()
+					if results.Len() == 0 || len(n.Results) != results.Len() {
+						return false
+					}
+					if !types.Identical(results.At(results.Len()-1).Type(), errorType) {
+						return false
+					}
+					id, ok := n.Results[len(n.Results)-1].(*ast.Ident)
+					if !ok {
+						return false
+					}
+					if v, ok := info.Uses[id].(*types.Var); ok && types.Identical(v.Type(), errorType) {
+						returns = append(returns, wrappableReturn{err: id, fn: fd.Name.Name})
+					}
+					return false
+				}
+				return true
+			})
+		}
+		inspect(fd.Body, obj.Type().(*types.Signature))
+	}
+	return returns
+}
+
+// CanWrapError reports whether rng contains a return statement of an error
+// variable that may be wrapped with fmt.Errorf.
+func CanWrapError(pkg Package, file *ast.File, rng span.Range) bool {
+	return len(wrappableReturns(pkg.GetTypesInfo(), file, rng.Start, rng.End)) > 0
+}
+
+// wrapErrorEdits returns the edits to pgf, a file of pkg, that wrap the
+// returned errors with fmt.Errorf, following the errorWrapTemplate setting,
+// and that import fmt if needed.
+func wrapErrorEdits(ctx context.Context, snapshot Snapshot, pkg Package, pgf *ParsedGoFile, returns []wrappableReturn) ([]analysis.TextEdit, error) {
+	tmpl, err := parseErrorWrapTemplate(snapshot.View().Options().ErrorWrapTemplate)
+	if err != nil {
+		return nil, fmt.Errorf("invalid errorWrapTemplate: %v", err)
+	}
+
+	// Use the name under which the file imports fmt, if it does.
+	fmtName, imported := "fmt", false
+	for _, imp := range pgf.File.Imports {
+		if path, _ := strconv.Unquote(imp.Path.Value); path != "fmt" {
+			continue
+		}
+		if imp.Name != nil && imp.Name.Name == "_" {
+			continue
+		}
+		imported = true
+		if imp.Name != nil {
+			fmtName = imp.Name.Name
+		}
+		break
+	}
+	errorf := fmtName + ".Errorf"
+	if fmtName == "." {
+		errorf = "Errorf"
+	}
+
+	var edits []analysis.TextEdit
+	for _, r := range returns {
+		if !refersToErrorf(pkg.GetTypes(), r.err.Pos(), errorf, imported) {
+			continue // fmt is shadowed
+		}
+		var msg bytes.Buffer
+		if err := tmpl.Execute(&msg, errorWrapData{Func: r.fn, Package: pkg.GetTypes().Name()}); err != nil {
+			return nil, err
+		}
+		edits = append(edits, analysis.TextEdit{
+			Pos:     r.err.Pos(),
+			End:     r.err.End(),
+			NewText: []byte(fmt.Sprintf("%s(%s, %s)", errorf, strconv.Quote(msg.String()), r.err.Name)),
+		})
+	}
+	if imported || len(edits) == 0 {
+		return edits, nil
+	}
+
+	importEdits, err := ComputeOneImportFixEdits(ctx, snapshot, pgf, &imports.ImportFix{
+		StmtInfo: imports.ImportInfo{
+			ImportPath: "fmt",
+		},
+		FixType: imports.AddImport,
+	})
+	if err != nil {
+		return nil, err
+	}
+	// The import edits precede the others in the file.
+	var all []analysis.TextEdit
+	for _, edit := range importEdits {
+		rng, err := pgf.Mapper.RangeToSpanRange(edit.Range)
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, analysis.TextEdit{
+			Pos:     rng.Start,
+			End:     rng.End,
+			NewText: []byte(edit.NewText),
+		})
+	}
+	return append(all, edits...), nil
+}
+
+// refersToErrorf reports whether errorf, "Errorf" or "name.Errorf", refers
+// to fmt.Errorf at pos in pkg, if its file imports fmt, or whether the import
+// of fmt would not be shadowed at pos otherwise.
+func refersToErrorf(pkg *types.Package, pos token.Pos, errorf string, imported bool) bool {
+	scope := pkg.Scope().Innermost(pos)
+	if scope == nil {
+		return false
+	}
+	name := strings.Split(errorf, ".")[0]
+	if !imported {
+		name = "fmt"
+	}
+	_, obj := scope.LookupParent(name, pos)
+	if !imported {
+		return obj == nil
+	}
+	switch obj := obj.(type) {
+	case *types.PkgName:
+		return obj.Imported().Path() == "fmt"
+	case *types.Func:
+		return obj.Pkg() != nil && obj.Pkg().Path() == "fmt"
+	}
+	return false
+}
+
+// wrapErrorFix wraps the errors returned in rng with fmt.Errorf.
+func wrapErrorFix(ctx context.Context, snapshot Snapshot, fh VersionedFileHandle, rng protocol.Range) (*analysis.SuggestedFix, error) {
+	pkg, pgf, err := GetParsedFile(ctx, snapshot, fh, NarrowestPackage)
+	if err != nil {
+		return nil, fmt.Errorf("getting file for wrapping errors: %w", err)
+	}
+	srng, err := pgf.Mapper.RangeToSpanRange(rng)
+	if err != nil {
+		return nil, err
+	}
+	returns := wrappableReturns(pkg.GetTypesInfo(), pgf.File, srng.Start, srng.End)
+	if len(returns) == 0 {
+		return nil, fmt.Errorf("no returned error to wrap")
+	}
+	edits, err := wrapErrorEdits(ctx, snapshot, pkg, pgf, returns)
+	if err != nil {
+		return nil, err
+	}
+	return &analysis.SuggestedFix{TextEdits: edits}, nil
+}
+
+// WrapErrors returns the edits that wrap with fmt.Errorf the errors returned
+// in the files of the package of fh, except the generated ones.
+func WrapErrors(ctx context.Context, snapshot Snapshot, fh VersionedFileHandle) ([]protocol.TextDocumentEdit, error) {
+	pkg, err := snapshot.PackageForFile(ctx, fh.URI(), TypecheckFull, NarrowestPackage)
+	if err != nil {
+		return nil, err
+	}
+	var changes []protocol.TextDocumentEdit
+	for _, pgf := range pkg.CompiledGoFiles() {
+		if IsGenerated(ctx, snapshot, pgf.URI) {
+			continue
+		}
+		returns := wrappableReturns(pkg.GetTypesInfo(), pgf.File, pgf.File.Pos(), pgf.File.End())
+		if len(returns) == 0 {
+			continue
+		}
+		edits, err := wrapErrorEdits(ctx, snapshot, pkg, pgf, returns)
+		if err != nil {
+			return nil, err
+		}
+		if len(edits) == 0 {
+			continue
+		}
+		fh, err := snapshot.GetVersionedFile(ctx, pgf.URI)
+		if err != nil {
+			return nil, err
+		}
+		change := protocol.TextDocumentEdit{
+			TextDocument: protocol.OptionalVersionedTextDocumentIdentifier{
+				Version: fh.Version(),
+				TextDocumentIdentifier: protocol.TextDocumentIdentifier{
+					URI: protocol.URIFromSpanURI(pgf.URI),
+				},
+			},
+		}
+		for _, edit := range edits {
+			spn, err := span.NewRange(snapshot.FileSet(), edit.Pos, edit.End).Span()
+			if err != nil {
+				return nil, err
+			}
+			rng, err := pgf.Mapper.Range(spn)
+			if err != nil {
+				return nil, err
+			}
+			change.Edits = append(change.Edits, protocol.AnnotatedTextEdit{TextEdit: protocol.TextEdit{
+				Range:   rng,
+				NewText: string(edit.NewText),
+			}})
+		}
+		changes = append(changes, change)
+	}
+	return changes, nil
+}
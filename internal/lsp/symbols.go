@@ -35,15 +35,20 @@ func (s *Server) documentSymbol(ctx context.Context, params *protocol.DocumentSy
 	}
 	// Convert the symbols to an interface array.
 	// TODO: Remove this once the lsp deprecates SymbolInformation.
-	symbols := make([]interface{}, len(docSymbols))
-	for i, s := range docSymbols {
-		if snapshot.View().Options().HierarchicalDocumentSymbolSupport {
+	if snapshot.View().Options().HierarchicalDocumentSymbolSupport {
+		symbols := make([]interface{}, len(docSymbols))
+		for i, s := range docSymbols {
 			symbols[i] = s
-			continue
 		}
-		// If the client does not support hierarchical document symbols, then
-		// we need to be backwards compatible for now and return SymbolInformation.
-		symbols[i] = protocol.SymbolInformation{
+		return symbols, nil
+	}
+	// If the client does not support hierarchical document symbols, then
+	// we need to be backwards compatible for now and return SymbolInformation,
+	// with the nested symbols flattened after their containers.
+	symbols := []interface{}{}
+	var add func(s protocol.DocumentSymbol, container string)
+	add = func(s protocol.DocumentSymbol, container string) {
+		symbols = append(symbols, protocol.SymbolInformation{
 			Name:       s.Name,
 			Kind:       s.Kind,
 			Deprecated: s.Deprecated,
@@ -51,7 +56,14 @@ func (s *Server) documentSymbol(ctx context.Context, params *protocol.DocumentSy
 				URI:   params.TextDocument.URI,
 				Range: s.Range,
 			},
+			ContainerName: container,
+		})
+		for _, c := range s.Children {
+			add(c, s.Name)
 		}
 	}
+	for _, s := range docSymbols {
+		add(s, "")
+	}
 	return symbols, nil
 }
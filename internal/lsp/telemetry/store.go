@@ -0,0 +1,328 @@
+// Copyright 2022 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package telemetry
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"time"
+)
+
+// A Report holds the counts of a week. It is the JSON body posted by
+// uploads.
+type Report struct {
+	Week      string           `json:"week"` // the Monday that starts the week, as 2006-01-02, in UTC
+	Program   string           `json:"program"`
+	Version   string           `json:"version"`
+	GOOS      string           `json:"goos"`
+	GOARCH    string           `json:"goarch"`
+	GoVersion string           `json:"goVersion"` // the version of Go that built gopls
+	Counters  map[string]int64 `json:"counters,omitempty"`
+	Crashes   map[string]int64 `json:"crashes,omitempty"` // by signature
+}
+
+// A Store is a directory of telemetry reports, which holds:
+//
+//	consent                   the time at which the user consented, in RFC 3339 format
+//	local/WEEK.PROCESS.json   the report of each week and process that is not uploaded yet
+//	local/WEEK.PROCESS.json.PROCESS.upload
+//	                          a report claimed by the upload of a process
+//
+// Each process only writes its own reports, so that the processes that share
+// the directory, such as a daemon and its forwarders, never lose each other's
+// counts. The reports of a week are merged when they are read.
+type Store struct {
+	Dir string
+}
+
+// processID distinguishes the files of this process from those of the other
+// processes that share a Store.
+var processID = fmt.Sprintf("%d-%d", os.Getpid(), time.Now().UnixNano())
+
+// uploadGrace is how long after the end of a week its reports are left
+// alone, so that the processes that record counts at the end of the week
+// have flushed them.
+const uploadGrace = 24 * time.Hour
+
+// DefaultDir returns the directory of the reports: that of the
+// GOPLS_TELEMETRY_DIR environment variable, or else gopls/telemetry in the
+// configuration directory of the user.
+func DefaultDir() (string, error) {
+	if dir := os.Getenv("GOPLS_TELEMETRY_DIR"); dir != "" {
+		return dir, nil
+	}
+	config, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(config, "gopls", "telemetry"), nil
+}
+
+func (s *Store) consentFile() string { return filepath.Join(s.Dir, "consent") }
+func (s *Store) localDir() string    { return filepath.Join(s.Dir, "local") }
+
+// Consent returns the time at which the user consented to telemetry, or the
+// zero time if there is no consent file.
+func (s *Store) Consent() (time.Time, error) {
+	data, err := ioutil.ReadFile(s.consentFile())
+	if errors.Is(err, os.ErrNotExist) {
+		return time.Time{}, nil
+	}
+	if err != nil {
+		return time.Time{}, err
+	}
+	t, err := time.Parse(time.RFC3339, strings.TrimSpace(string(data)))
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid consent file %s: %v", s.consentFile(), err)
+	}
+	return t, nil
+}
+
+// StampConsent writes the consent file with the time now, unless it exists,
+// and returns the time at which the user consented.
+func (s *Store) StampConsent(now time.Time) (time.Time, error) {
+	if t, err := s.Consent(); err != nil || !t.IsZero() {
+		return t, err
+	}
+	if err := os.MkdirAll(s.Dir, 0755); err != nil {
+		return time.Time{}, err
+	}
+	now = now.UTC().Truncate(time.Second)
+	if err := ioutil.WriteFile(s.consentFile(), []byte(now.Format(time.RFC3339)+"\n"), 0644); err != nil {
+		return time.Time{}, err
+	}
+	return now, nil
+}
+
+// Clear removes the reports and the consent file of s.
+func (s *Store) Clear() error {
+	if err := os.RemoveAll(s.localDir()); err != nil {
+		return err
+	}
+	if err := os.Remove(s.consentFile()); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+// Week returns the week of t, as the date of its Monday in UTC.
+func Week(t time.Time) string {
+	t = t.UTC()
+	days := (int(t.Weekday()) + 6) % 7 // since Monday
+	return t.AddDate(0, 0, -days).Format("2006-01-02")
+}
+
+// Add adds the counts to the report of the week of now of this process.
+func (s *Store) Add(now time.Time, version string, counters, crashes map[string]int64) error {
+	week := Week(now)
+	report, err := readReport(s.reportFile(week))
+	if err != nil {
+		return err
+	}
+	if report == nil {
+		report = &Report{
+			Week:      week,
+			Program:   "gopls",
+			Version:   version,
+			GOOS:      runtime.GOOS,
+			GOARCH:    runtime.GOARCH,
+			GoVersion: runtime.Version(),
+		}
+	}
+	report.Counters = addCounts(report.Counters, counters)
+	report.Crashes = addCounts(report.Crashes, crashes)
+	return s.writeReport(report)
+}
+
+func addCounts(dst, src map[string]int64) map[string]int64 {
+	if len(src) == 0 {
+		return dst
+	}
+	if dst == nil {
+		dst = make(map[string]int64)
+	}
+	for k, n := range src {
+		dst[k] += n
+	}
+	return dst
+}
+
+// reportFile returns the file of the report of week of this process.
+func (s *Store) reportFile(week string) string {
+	return filepath.Join(s.localDir(), week+"."+processID+".json")
+}
+
+// readReport returns the report of file, or nil if there is none.
+func readReport(file string) (*Report, error) {
+	data, err := ioutil.ReadFile(file)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	report := new(Report)
+	if err := json.Unmarshal(data, report); err != nil {
+		return nil, fmt.Errorf("invalid report %s: %v", file, err)
+	}
+	return report, nil
+}
+
+// writeReport writes the file of report, through a rename so that other
+// processes never read a partial one.
+func (s *Store) writeReport(report *Report) error {
+	data, err := json.MarshalIndent(report, "", "\t")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(s.localDir(), 0755); err != nil {
+		return err
+	}
+	tmp, err := ioutil.TempFile(s.localDir(), report.Week+".*.tmp")
+	if err != nil {
+		return err
+	}
+	_, err = tmp.Write(append(data, '\n'))
+	if cerr := tmp.Close(); err == nil {
+		err = cerr
+	}
+	if err == nil {
+		err = os.Rename(tmp.Name(), s.reportFile(report.Week))
+	}
+	if err != nil {
+		os.Remove(tmp.Name())
+	}
+	return err
+}
+
+// Reports returns the reports that are not uploaded yet, by week. The
+// reports of the processes are merged.
+func (s *Store) Reports() ([]*Report, error) {
+	files, err := filepath.Glob(filepath.Join(s.localDir(), "*.json"))
+	if err != nil {
+		return nil, err
+	}
+	reports, _, err := mergeReports(files)
+	return reports, err
+}
+
+// mergeReports returns the reports of files, merged by week and by the
+// program that recorded them, along with the files of each merged report.
+// The files, whose names start with their week, are in lexical order, so
+// the reports are sorted by week.
+func mergeReports(files []string) ([]*Report, [][]string, error) {
+	type key struct {
+		week, program, version, goos, goarch, goVersion string
+	}
+	index := make(map[key]int)
+	var (
+		reports []*Report
+		sources [][]string
+	)
+	for _, file := range files {
+		report, err := readReport(file)
+		if err != nil {
+			return nil, nil, err
+		}
+		if report == nil {
+			continue // uploaded by another process
+		}
+		k := key{report.Week, report.Program, report.Version, report.GOOS, report.GOARCH, report.GoVersion}
+		i, ok := index[k]
+		if !ok {
+			i = len(reports)
+			index[k] = i
+			m := *report
+			m.Counters, m.Crashes = nil, nil
+			reports = append(reports, &m)
+			sources = append(sources, nil)
+		}
+		reports[i].Counters = addCounts(reports[i].Counters, report.Counters)
+		reports[i].Crashes = addCounts(reports[i].Crashes, report.Crashes)
+		sources[i] = append(sources[i], file)
+	}
+	return reports, sources, nil
+}
+
+// Upload posts the reports of the weeks before that of now to url, and
+// removes those it sent. It does nothing if there is no consent file.
+//
+// The files of a week are first claimed by renaming them, so that the
+// processes that upload at the same time never send the same counts twice.
+// The files of a failed upload are restored.
+func (s *Store) Upload(client *http.Client, url string, now time.Time) error {
+	if t, err := s.Consent(); err != nil || t.IsZero() {
+		return err
+	}
+	files, err := filepath.Glob(filepath.Join(s.localDir(), "*.json"))
+	if err != nil {
+		return err
+	}
+	week := Week(now.Add(-uploadGrace))
+	claimed := make(map[string][]string) // by week
+	var weeks []string                   // in order, as files are
+	for _, file := range files {
+		w := strings.SplitN(filepath.Base(file), ".", 2)[0]
+		if w >= week {
+			continue
+		}
+		claim := file + "." + processID + ".upload"
+		if err := os.Rename(file, claim); err != nil {
+			continue // claimed by another process
+		}
+		if claimed[w] == nil {
+			weeks = append(weeks, w)
+		}
+		claimed[w] = append(claimed[w], claim)
+	}
+	for i, w := range weeks {
+		if err := uploadFiles(client, url, claimed[w]); err != nil {
+			for _, w := range weeks[i:] {
+				for _, claim := range claimed[w] {
+					os.Rename(claim, strings.TrimSuffix(claim, "."+processID+".upload"))
+				}
+			}
+			return err
+		}
+	}
+	return nil
+}
+
+// uploadFiles posts the reports of the claimed files to url, and removes the
+// files of each report once it is sent.
+func uploadFiles(client *http.Client, url string, claimed []string) error {
+	reports, sources, err := mergeReports(claimed)
+	if err != nil {
+		return err
+	}
+	for i, report := range reports {
+		data, err := json.Marshal(report)
+		if err != nil {
+			return err
+		}
+		res, err := client.Post(url, "application/json", bytes.NewReader(data))
+		if err != nil {
+			return err
+		}
+		res.Body.Close()
+		if res.StatusCode/100 != 2 {
+			return fmt.Errorf("uploading the report of %s: %s", report.Week, res.Status)
+		}
+		for _, file := range sources[i] {
+			if err := os.Remove(file); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,249 @@
+// Copyright 2022 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package telemetry records anonymous counters of the use of gopls, such as
+// the number of requests of each method, and signatures of the panics it
+// recovers from, for the users who opt in with the telemetry setting.
+//
+// The counters are accumulated in a report per week, in a local directory.
+// If an upload URL is configured, the reports of the weeks that have ended
+// are uploaded to it once a day; there is no default destination. The
+// reports hold no file names, identifiers or source code: a crash signature
+// is the type of the panic value and the functions and lines of gopls in which
+// it occurred. "gopls telemetry view" prints the reports that would be sent.
+//
+// Nothing is recorded until Start is called, which stamps the consent file of
+// the directory if it does not exist yet. Reports are only uploaded from a
+// directory with a consent file.
+//
+// The counters are those of the process: a server shared by several clients
+// must only count the requests of the clients that opted in.
+package telemetry
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Config configures the recording of telemetry.
+type Config struct {
+	// Dir is the directory of the reports. The default is that of
+	// DefaultDir.
+	Dir string
+	// Version is the version of gopls recorded in the reports.
+	Version string
+	// UploadURL is the URL to which the reports are posted. The default is
+	// that of GOPLS_TELEMETRY_URL. If neither is set, the reports are only
+	// kept in Dir.
+	UploadURL string
+	// Client posts the reports. The default is a client that gives up on
+	// an upload after uploadTimeout.
+	Client *http.Client
+}
+
+const (
+	flushInterval  = time.Minute
+	uploadInterval = 24 * time.Hour
+	uploadTimeout  = time.Minute
+)
+
+var (
+	mu      sync.Mutex
+	current *recorder // nil unless telemetry is started
+)
+
+// A recorder holds the counts of the current process that are not yet in the
+// report of the week.
+type recorder struct {
+	store    *Store
+	version  string
+	counters map[string]int64
+	crashes  map[string]int64
+
+	users int           // the number of Start calls not matched by Stop
+	done  chan struct{} // closed by the last Stop
+}
+
+// Start stamps the consent file of the directory of config if needed, then
+// records the counters and crashes of the process from then on. It flushes
+// them to the report of the week every minute, and, if an upload URL is
+// configured, uploads the reports of the weeks that have ended once a day.
+// If telemetry is already started, Start only counts one more user of it;
+// each call of Start must be matched by a call of Stop.
+func Start(config Config) error {
+	mu.Lock()
+	defer mu.Unlock()
+	if current != nil {
+		current.users++
+		return nil
+	}
+	dir := config.Dir
+	if dir == "" {
+		var err error
+		if dir, err = DefaultDir(); err != nil {
+			return err
+		}
+	}
+	store := &Store{Dir: dir}
+	if _, err := store.StampConsent(time.Now()); err != nil {
+		return err
+	}
+	url := config.UploadURL
+	if url == "" {
+		url = os.Getenv("GOPLS_TELEMETRY_URL")
+	}
+	client := config.Client
+	if client == nil {
+		client = &http.Client{Timeout: uploadTimeout}
+	}
+	r := &recorder{
+		store:    store,
+		version:  config.Version,
+		counters: make(map[string]int64),
+		crashes:  make(map[string]int64),
+		users:    1,
+		done:     make(chan struct{}),
+	}
+	current = r
+	go func() {
+		ticker := time.NewTicker(flushInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				Flush()
+			case <-r.done:
+				return
+			}
+		}
+	}()
+	if url != "" {
+		go func() {
+			ticker := time.NewTicker(uploadInterval)
+			defer ticker.Stop()
+			for {
+				// Failures are retried the next day, as the reports are kept.
+				store.Upload(client, url, time.Now())
+				select {
+				case <-ticker.C:
+				case <-r.done:
+					return
+				}
+			}
+		}()
+	}
+	return nil
+}
+
+// Stop matches a call of Start. The last call stops the recording, after
+// flushing the counts of the process to the report of the week, and stops
+// the uploads.
+func Stop() error {
+	mu.Lock()
+	defer mu.Unlock()
+	r := current
+	if r == nil {
+		return nil
+	}
+	if r.users--; r.users > 0 {
+		return nil
+	}
+	current = nil
+	close(r.done)
+	return r.flush()
+}
+
+// Inc increments the counter of the given name, if telemetry is started.
+// Names must not identify the user or their code: they are constants, such
+// as "request/textDocument/hover".
+func Inc(name string) {
+	mu.Lock()
+	defer mu.Unlock()
+	if current != nil {
+		current.counters[name]++
+	}
+}
+
+// Crash records the signature of a panic recovered by gopls, if telemetry
+// is started, and flushes the counts at once, in case the process does not
+// survive it. The stack is that of the recovering goroutine, as returned by
+// runtime/debug.Stack.
+func Crash(value interface{}, stack []byte) {
+	mu.Lock()
+	if current == nil {
+		mu.Unlock()
+		return
+	}
+	current.crashes[Signature(value, stack)]++
+	mu.Unlock()
+	Flush()
+}
+
+// Flush adds the counts of the process to the report of the week, if
+// telemetry is started.
+func Flush() error {
+	mu.Lock()
+	defer mu.Unlock()
+	if current == nil {
+		return nil
+	}
+	return current.flush()
+}
+
+// flush adds the counts of r to the report of the week. It is called with
+// mu held.
+func (r *recorder) flush() error {
+	if len(r.counters) == 0 && len(r.crashes) == 0 {
+		return nil
+	}
+	if err := r.store.Add(time.Now(), r.version, r.counters, r.crashes); err != nil {
+		return err
+	}
+	r.counters = make(map[string]int64)
+	r.crashes = make(map[string]int64)
+	return nil
+}
+
+// maxFrames is the number of frames of gopls in a crash signature.
+const maxFrames = 4
+
+// Signature returns the signature of a panic: the type of its value and the
+// innermost frames of stack below the call of panic, as
+// "function:line", such as "runtime.boundsError: source.format:42
+// source.Format:27". Neither the message of the panic nor the arguments and
+// files of the frames, which may describe the code of the user, are part of
+// it.
+func Signature(value interface{}, stack []byte) string {
+	lines := strings.Split(string(stack), "\n")
+	// The frames of interest follow the last call of panic.
+	start := 0
+	for i, line := range lines {
+		if strings.HasPrefix(line, "panic(") {
+			start = i + 2
+		}
+	}
+	var frames []string
+	for i := start; i+1 < len(lines) && len(frames) < maxFrames; i += 2 {
+		fn, pos := lines[i], strings.TrimSpace(lines[i+1])
+		if fn == "" || strings.HasPrefix(fn, "created by ") {
+			break
+		}
+		// Drop the arguments and the directory of the package.
+		if paren := strings.LastIndex(fn, "("); paren > 0 && strings.HasSuffix(fn, ")") {
+			fn = fn[:paren]
+		}
+		fn = fn[strings.LastIndex(fn, "/")+1:]
+		// Keep the line, but not the file, of the position.
+		if space := strings.IndexByte(pos, ' '); space >= 0 {
+			pos = pos[:space]
+		}
+		line := pos[strings.LastIndex(pos, ":")+1:]
+		frames = append(frames, fn+":"+line)
+	}
+	return fmt.Sprintf("%T: %s", value, strings.Join(frames, " "))
+}
@@ -0,0 +1,201 @@
+// Copyright 2022 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package telemetry
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"reflect"
+	"runtime/debug"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestWeek(t *testing.T) {
+	for _, test := range []struct {
+		date, want string
+	}{
+		{"2022-08-01", "2022-08-01"}, // a Monday
+		{"2022-08-03", "2022-08-01"},
+		{"2022-08-07", "2022-08-01"}, // a Sunday
+		{"2022-08-08", "2022-08-08"},
+		{"2022-01-01", "2021-12-27"},
+	} {
+		date, err := time.Parse("2006-01-02", test.date)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := Week(date); got != test.want {
+			t.Errorf("Week(%s) = %s, want %s", test.date, got, test.want)
+		}
+	}
+}
+
+func TestStore(t *testing.T) {
+	s := &Store{Dir: t.TempDir()}
+	monday := time.Date(2022, 8, 1, 10, 0, 0, 0, time.UTC)
+	if err := s.Add(monday, "v1", map[string]int64{"request/hover": 2}, nil); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Add(monday.Add(48*time.Hour), "v1", map[string]int64{"request/hover": 1, "request/completion": 1}, map[string]int64{"sig": 1}); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Add(monday.AddDate(0, 0, 7), "v1", map[string]int64{"request/hover": 5}, nil); err != nil {
+		t.Fatal(err)
+	}
+	reports, err := s.Reports()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(reports) != 2 {
+		t.Fatalf("got %d reports, want 2", len(reports))
+	}
+	want := map[string]int64{"request/hover": 3, "request/completion": 1}
+	if got := reports[0].Counters; reports[0].Week != "2022-08-01" || !reflect.DeepEqual(got, want) {
+		t.Errorf("report of %s has counters %v, want a report of 2022-08-01 with %v", reports[0].Week, got, want)
+	}
+	if got := reports[0].Crashes; !reflect.DeepEqual(got, map[string]int64{"sig": 1}) {
+		t.Errorf("got crashes %v, want sig: 1", got)
+	}
+
+	// Nothing is uploaded without consent.
+	var posted []Report
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		data, err := ioutil.ReadAll(r.Body)
+		if err != nil {
+			t.Error(err)
+		}
+		var report Report
+		if err := json.Unmarshal(data, &report); err != nil {
+			t.Error(err)
+		}
+		posted = append(posted, report)
+	}))
+	defer srv.Close()
+	thursday := monday.AddDate(0, 0, 10)
+	if err := s.Upload(srv.Client(), srv.URL, thursday); err != nil {
+		t.Fatal(err)
+	}
+	if len(posted) > 0 {
+		t.Fatalf("uploaded %d reports without consent", len(posted))
+	}
+
+	stamp, err := s.StampConsent(monday)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if again, err := s.StampConsent(thursday); err != nil || !again.Equal(stamp) {
+		t.Errorf("StampConsent again = %v, %v, want the first stamp %v", again, err, stamp)
+	}
+	// Only the report of the week that has ended is uploaded.
+	if err := s.Upload(srv.Client(), srv.URL, thursday); err != nil {
+		t.Fatal(err)
+	}
+	if len(posted) != 1 || posted[0].Week != "2022-08-01" {
+		t.Fatalf("uploaded %v, want the report of 2022-08-01", posted)
+	}
+	if reports, err := s.Reports(); err != nil || len(reports) != 1 || reports[0].Week != "2022-08-08" {
+		t.Errorf("after the upload, got reports %v, %v, want that of 2022-08-08", reports, err)
+	}
+
+	if err := s.Clear(); err != nil {
+		t.Fatal(err)
+	}
+	if reports, err := s.Reports(); err != nil || len(reports) > 0 {
+		t.Errorf("after Clear, got reports %v, %v", reports, err)
+	}
+	if consent, err := s.Consent(); err != nil || !consent.IsZero() {
+		t.Errorf("after Clear, got consent %v, %v", consent, err)
+	}
+}
+
+func TestStoreProcesses(t *testing.T) {
+	s := &Store{Dir: t.TempDir()}
+	monday := time.Date(2022, 8, 1, 10, 0, 0, 0, time.UTC)
+	if _, err := s.StampConsent(monday); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Add(monday, "v1", map[string]int64{"request/hover": 2}, nil); err != nil {
+		t.Fatal(err)
+	}
+	// Another process sharing the directory writes its own report.
+	other := &Report{Week: "2022-08-01", Program: "gopls", Version: "v1", Counters: map[string]int64{"request/hover": 1}}
+	mine, err := readReport(s.reportFile("2022-08-01"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	other.GOOS, other.GOARCH, other.GoVersion = mine.GOOS, mine.GOARCH, mine.GoVersion
+	data, err := json.Marshal(other)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(filepath.Join(s.localDir(), "2022-08-01.other.json"), data, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	reports, err := s.Reports()
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]int64{"request/hover": 3}
+	if len(reports) != 1 || !reflect.DeepEqual(reports[0].Counters, want) {
+		t.Fatalf("got reports %v, want one report with %v", reports, want)
+	}
+
+	// The upload sends the merged report once, and leaves nothing behind.
+	var posted []Report
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var report Report
+		if err := json.NewDecoder(r.Body).Decode(&report); err != nil {
+			t.Error(err)
+		}
+		posted = append(posted, report)
+	}))
+	defer srv.Close()
+	thursday := monday.AddDate(0, 0, 10)
+	for i := 0; i < 2; i++ {
+		if err := s.Upload(srv.Client(), srv.URL, thursday); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if len(posted) != 1 || !reflect.DeepEqual(posted[0].Counters, want) {
+		t.Errorf("uploaded %v, want one report with %v", posted, want)
+	}
+	if files, err := filepath.Glob(filepath.Join(s.localDir(), "*")); err != nil || len(files) > 0 {
+		t.Errorf("after the upload, got files %v, %v", files, err)
+	}
+}
+
+type testError struct{}
+
+func (testError) Error() string { return "secret identifier" }
+
+func TestSignature(t *testing.T) {
+	var sig string
+	func() {
+		defer func() {
+			sig = Signature(recover(), debug.Stack())
+		}()
+		panicky()
+	}()
+	const prefix = "telemetry.testError: telemetry.panicky:"
+	if !strings.HasPrefix(sig, prefix) {
+		t.Errorf("Signature = %q, want prefix %q", sig, prefix)
+	}
+	if strings.Contains(sig, "secret") || strings.Contains(sig, ".go") {
+		t.Errorf("Signature = %q, which holds the message or the files", sig)
+	}
+	if n := len(strings.Fields(sig)); n != 1+maxFrames {
+		t.Errorf("Signature = %q, want %d frames", sig, maxFrames)
+	}
+}
+
+func panicky() {
+	panic(testError{})
+}
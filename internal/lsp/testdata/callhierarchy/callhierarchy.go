@@ -26,7 +26,7 @@ var x = func() { //@mark(hierarchyLiteral, "func"),mark(hierarchyLiteralOut, "x"
 }
 
 // D is exported to test incoming/outgoing calls across packages
-func D() { //@mark(hierarchyD, "D"),incomingcalls(hierarchyD, hierarchyA, hierarchyB, hierarchyC, hierarchyLiteral, incomingA),outgoingcalls(hierarchyD, hierarchyE, hierarchyF, hierarchyG, hierarchyLiteralOut, outgoingB, hierarchyFoo, hierarchyH, hierarchyI, hierarchyJ, hierarchyK)
+func D() { //@mark(hierarchyD, "D"),incomingcalls(hierarchyD, hierarchyA, hierarchyB, hierarchyC, hierarchyLiteral, incomingA, hierarchyTestD),outgoingcalls(hierarchyD, hierarchyE, hierarchyF, hierarchyG, hierarchyLiteralOut, outgoingB, hierarchyFoo, hierarchyH, hierarchyI, hierarchyImplH, hierarchyImplI, hierarchyJ, hierarchyK)
 	e()
 	x()
 	F()
@@ -61,8 +61,8 @@ type Interface interface {
 
 type impl struct{}
 
-func (i impl) H() {}
-func (i impl) I() {}
+func (i impl) H() {} //@mark(hierarchyImplH, "H")
+func (i impl) I() {} //@mark(hierarchyImplI, "I")
 
 type Struct struct {
 	J func() //@mark(hierarchyJ, "J")
@@ -0,0 +1,17 @@
+// Copyright 2022 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package callhierarchy_test
+
+import (
+	"testing"
+
+	"github.com/iansmith/golang-x-tools/internal/lsp/callhierarchy"
+)
+
+// TestD is in an external test package to test incoming calls from
+// packages that depend on a test variant of the callee's package.
+func TestD(t *testing.T) { //@mark(hierarchyTestD, "TestD")
+	callhierarchy.D()
+}
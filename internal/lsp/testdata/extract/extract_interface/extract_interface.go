@@ -0,0 +1,15 @@
+package extract
+
+import "bytes"
+
+type buffer struct { //@suggestedfix("buffer", "refactor.extract")
+	b bytes.Buffer
+}
+
+// Read reads from the buffer.
+func (b *buffer) Read(p []byte) (int, error) { return b.b.Read(p) }
+
+// Write writes to the buffer.
+func (b *buffer) Write(p []byte) (n int, err error) { return b.b.Write(p) } //@suggestedfix("Write", "refactor.extract")
+
+func (b *buffer) reset() { b.b.Reset() }
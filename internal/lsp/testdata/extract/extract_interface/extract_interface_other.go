@@ -0,0 +1,5 @@
+package extract
+
+import "io"
+
+func (b *buffer) WriteTo(w io.Writer) (int64, error) { return b.b.WriteTo(w) }
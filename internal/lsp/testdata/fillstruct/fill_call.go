@@ -0,0 +1,12 @@
+package fillstruct
+
+func addUser(name string, age int, admin bool) {}
+
+func fillCall() {
+	name := "gopher"
+	addUser() //@suggestedfix(")", "refactor.rewrite")
+}
+
+func fillCallPartial() {
+	addUser("gopher") //@suggestedfix(")", "refactor.rewrite")
+}
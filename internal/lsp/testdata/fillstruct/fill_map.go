@@ -0,0 +1,19 @@
+package fillstruct
+
+type Weekday int
+
+const (
+	Sunday Weekday = iota
+	Monday
+	Tuesday
+)
+
+func fillMap() {
+	_ = map[Weekday]string{} //@suggestedfix("}", "refactor.rewrite")
+}
+
+func fillMapPartial() {
+	_ = map[Weekday]int{
+		Monday: 1, // the first workday
+	} //@suggestedfix("}", "refactor.rewrite")
+}
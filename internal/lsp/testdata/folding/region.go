@@ -0,0 +1,35 @@
+// Copyright 2022 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build go1.1
+// +build go1.1
+
+package folding //@fold("package")
+
+import (
+	"fmt"
+	"strings"
+
+	"os"
+	"path/filepath"
+)
+
+//region Helpers
+
+func join(elems ...string) string {
+	return filepath.Join(elems...)
+}
+
+// #region Nested
+func upper(s string) string {
+	return strings.ToUpper(s)
+}
+
+// #endregion
+
+//endregion
+
+func show() {
+	fmt.Fprintln(os.Stdout, join("a", upper("b")))
+}
@@ -1,4 +1,4 @@
 package generate
 
-//go:generate echo Hi //@ codelens("//go:generate", "run go generate", "generate"), codelens("//go:generate", "run go generate ./...", "generate")
-//go:generate echo I shall have no CodeLens
+//go:generate echo Hi //@ codelens("//go:generate", "run go generate", "generate"), codelens("//go:generate", "run go generate ./...", "generate"), codelens("//go:generate", "run generator", "generate")
+//go:generate echo Bye //@ codelens("//go:generate", "run generator", "generate")
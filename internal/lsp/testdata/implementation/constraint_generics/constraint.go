@@ -0,0 +1,24 @@
+//go:build go1.18
+// +build go1.18
+
+package constraint
+
+type Label string //@Label,implementations("Label", JoinE)
+
+type Labels []Label //@Labels,implementations("Labels", JoinS)
+
+func Join[E Label, S ~[]E](s S) E { //@mark(JoinE, "E"),mark(JoinS, "S"),implementations("E", Label),implementations("S", Labels)
+	return ""
+}
+
+type Source[T any] interface { //@Source,implementations("Source", IntSource, LabelSource)
+	Take() (T, bool) //@mark(SourceTake, "Take"),implementations("Take", IntTake, LabelTake)
+}
+
+type LabelSource struct{} //@LabelSource,implementations("LabelSource", Source)
+
+func (*LabelSource) Take() (Label, bool) { return "", false } //@mark(LabelTake, "Take"),implementations("Take", SourceTake)
+
+type IntSource struct{} //@IntSource
+
+func (IntSource) Take() (int, bool) { return 0, false } //@mark(IntTake, "Take")
@@ -0,0 +1,43 @@
+package inlayHint //@inlayHint("package")
+
+import (
+	"fmt"
+	"io"
+	"time"
+)
+
+type pair struct {
+	in, want string
+}
+
+type point struct {
+	X, Y int8
+}
+
+func reverse(in string) string {
+	return in
+}
+
+func write(w io.Writer, prefix string, args ...interface{}) {
+	w.Write([]byte(prefix))
+}
+
+func describe() fmt.Stringer {
+	return time.Second
+}
+
+func hints(w io.Writer, in string) {
+	_ = reverse("hello")
+	_ = reverse(in)
+	write(w, "x", 1, 2)
+	write(w, "x", []interface{}{1}...)
+	time.Sleep(5)
+	_ = []pair{
+		{"Hello, world", "dlrow ,olleH"},
+		{in: "a", want: "a"},
+	}
+	_ = []*point{{1, 2}}
+	var s fmt.Stringer = time.Minute
+	_ = s
+	_ = map[time.Duration]interface{}{3: "three"}
+}
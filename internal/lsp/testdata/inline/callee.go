@@ -0,0 +1,55 @@
+package inline
+
+import "strings"
+
+func add(x, y int) int {
+	return x + y
+}
+
+func divmod(x, y int) (int, int) {
+	q := x / y
+	r := x - q*y
+	return q, r
+}
+
+func sign(x int) int {
+	if x < 0 {
+		return -1
+	}
+	if x > 0 {
+		return 1
+	}
+	return 0
+}
+
+func split(s string) (head, tail string) {
+	i := strings.Index(s, "/")
+	if i < 0 {
+		head = s
+	} else {
+		head, tail = s[:i], s[i+1:]
+	}
+	return
+}
+
+func greet(names ...string) {
+	for _, name := range names {
+		println("hello, " + name)
+	}
+}
+
+type counter struct{ n int }
+
+func (c *counter) incr(by int) {
+	c.n += by
+}
+
+func (c counter) value() int {
+	return c.n
+}
+
+type celsius float64
+
+func half(c celsius) celsius {
+	return c / 2
+}
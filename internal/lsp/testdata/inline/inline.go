@@ -0,0 +1,45 @@
+package inline
+
+func next() int { return 3 }
+
+func _() {
+	a := 1
+	b := add(a, 2) //@suggestedfix("add", "refactor.inline")
+	_ = b * add(a, b) //@suggestedfix("add", "refactor.inline")
+}
+
+func _() {
+	q, r := divmod(7, next()) //@suggestedfix("divmod", "refactor.inline")
+	println(q, r)
+}
+
+func _(x int) {
+	q := 0
+	var r int
+	r, _ = divmod(x, 2) //@suggestedfix("divmod", "refactor.inline")
+	println(q, r)
+}
+
+func _(n int) int {
+	return sign(n - 1) //@suggestedfix("sign", "refactor.inline")
+}
+
+func _(path string) string {
+	i := 0
+	head, _ := split(path) //@suggestedfix("split", "refactor.inline")
+	return head[i:]
+}
+
+func _(name string) {
+	greet("world", name) //@suggestedfix("greet", "refactor.inline")
+}
+
+func _(n int) int {
+	var c counter
+	c.incr(n) //@suggestedfix("incr", "refactor.inline")
+	return c.value() //@suggestedfix("value", "refactor.inline")
+}
+
+func _() float64 {
+	return float64(half(10)) //@suggestedfix("half", "refactor.inline")
+}
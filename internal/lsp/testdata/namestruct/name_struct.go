@@ -0,0 +1,28 @@
+package namestruct
+
+// config is used by several functions.
+var config struct { //@suggestedfix("struct", "refactor.rewrite")
+	Name string
+	Port int // the port
+}
+
+func setConfig(c struct {
+	Name string
+	Port int // the port
+}) {
+	config = c
+}
+
+func configs() []struct {
+	Name string
+	Port int
+} {
+	return nil
+}
+
+func _() {
+	points := []struct { //@suggestedfix("struct", "refactor.rewrite")
+		X, Y int
+	}{{1, 2}}
+	_ = points
+}
@@ -0,0 +1,24 @@
+package receiver
+
+type counter struct {
+	n int
+}
+
+func (c counter) value() int { //@suggestedfix("c counter", "refactor.rewrite")
+	return c.n
+}
+
+func (c *counter) String() string { //@suggestedfix("c *counter", "refactor.rewrite")
+	if (*c).n == 0 {
+		return "zero"
+	}
+	return "more"
+}
+
+func (c counter) copy() counter { //@suggestedfix("c counter", "refactor.rewrite")
+	return c
+}
+
+func values(c counter, cs []counter) int {
+	return counter.value(c) + cs[0].value()
+}
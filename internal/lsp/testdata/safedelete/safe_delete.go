@@ -0,0 +1,32 @@
+package safedelete
+
+import (
+	"fmt"
+	"strings"
+)
+
+// shout returns s in upper case.
+func shout(s string) string { //@suggestedfix("shout", "refactor.rewrite")
+	return strings.ToUpper(s)
+}
+
+type point struct { //@suggestedfix("point", "refactor.rewrite")
+	x, y int
+}
+
+func (p point) norm() int {
+	return p.x*p.x + p.y*p.y
+}
+
+var (
+	limit   = 10
+	verbose = false //@suggestedfix("verbose", "refactor.rewrite")
+)
+
+func describe(name string, n int, extra bool) string { //@suggestedfix("extra", "refactor.rewrite")
+	return fmt.Sprintf("%s: %d", name, n+limit)
+}
+
+func Describe() string {
+	return describe("a", 1, true) + describe("b", 2, !true)
+}
@@ -1,5 +1,7 @@
 package semantictokens //@ semantic("")
 
+import "fmt"
+
 func f(x ...interface{}) {
 }
 
@@ -36,3 +38,16 @@ type E func(AA) BB
 var a chan<- chan int
 var b chan<- <-chan int
 var c <-chan <-chan int
+
+// Deprecated: use weirⰀd.
+func old() {}
+
+func logf(format string, args ...interface{}) {
+	fmt.Printf(format, args...)
+}
+
+func formats() {
+	old()
+	fmt.Printf("%d%% of %-8.3[2]q\n", 1, "x")
+	logf("%s", "x")
+}
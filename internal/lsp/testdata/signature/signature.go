@@ -65,7 +65,16 @@ func Qux() {
 	var ms myStruct
 	ms.foo(nil) //@signature("nil", "foo(e *json.Decoder) (*big.Int, error)", 0)
 
-	_ = make([]int, 1, 2) //@signature("2", "make(t Type, size ...int) Type", 1)
+	_ = make([]int, 1, 2)        //@signature("2", "make(t []int, len int, cap int) []int", 2)
+	_ = make(map[string]int, 10) //@signature("10", "make(t map[string]int, size int) map[string]int", 1)
+	_ = make(chan MyType)        //@signature(")", "make(t chan MyType, size int) chan MyType", 0)
+	_ = append([]byte{}, "x"...) //@signature("...", "append(slice []byte, elems ...byte) []byte", 1)
+	_ = copy([]int{}, []int{})   //@signature("[]int{})", "copy(dst []int, src []int) int", 1)
+	_ = new(int)                 //@signature("int", "new(Type) *Type", 0)
+
+	_ = int64(13)          //@signature("13", "int64(x) int64", 0)
+	_ = MyType(struct{}{}) //@signature("struct", "MyType(x) MyType", 0)
+	_ = (*MyType)(nil)     //@signature("nil", "(*MyType)(x) *MyType", 0)
 
 	Foo(myFunc(123), 456) //@signature("myFunc", "Foo(a string, b int) (c bool)", 0)
 	Foo(myFunc(123), 456) //@signature("123", "myFunc(foo int) string", 0)
@@ -73,6 +82,16 @@ func Qux() {
 	panic("oops!")            //@signature(")", "panic(v interface{})", 0)
 	println("hello", "world") //@signature(",", "println(args ...Type)", 0)
 
+	Foo(
+		"foo",
+		123,
+	) //@signature(")", "Foo(a string, b int) (c bool)", 1)
+	Bar(
+		13.37,
+		0x13,
+		0x37,
+	) //@signature(")", "Bar(float64, ...byte)", 1)
+
 	Hello(func() {
 		//@signature("//", "", 0)
 	})
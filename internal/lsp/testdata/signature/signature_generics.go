@@ -0,0 +1,17 @@
+//go:build go1.18
+// +build go1.18
+
+package signature
+
+func Map[T, U any](s []T, f func(T) U) []U { return nil }
+
+func Zero[T any]() (zero T) { return }
+
+func itoa(int) string { return "" }
+
+func _() {
+	Map([]int{}, itoa)           //@signature("itoa", "Map[int, string](s []int, f func(int) string) []string", 1)
+	Map[int, bool]([]int{}, nil) //@signature("nil", "Map[int, bool](s []int, f func(int) bool) []bool", 1)
+	Map()                        //@signature(")", "Map[T any, U any](s []T, f func(T) U) []U", 0)
+	Zero[string]()               //@signature(")", "Zero[string]() (zero string)", 0)
+}
@@ -28,6 +28,7 @@ func _() {
 
 func _() {
 	/* append! */ //@item(postfixAppend, "append!", "append and re-assign slice", "snippet")
+	/* for! */ //@item(postfixFor, "for!", "loop over slice indices", "snippet")
 	/* last! */ //@item(postfixLast, "last!", "s[len(s)-1]", "snippet")
 	/* print! */ //@item(postfixPrint, "print!", "print to stdout", "snippet")
 	/* range! */ //@item(postfixRange, "range!", "range over slice", "snippet")
@@ -36,7 +37,27 @@ func _() {
 	/* var! */ //@item(postfixVar, "var!", "assign to variable", "snippet")
 
 	var foo []int
-	foo. //@complete(" //", postfixAppend, postfixCopy, postfixLast, postfixPrint, postfixRange, postfixReverse, postfixSort, postfixVar)
+	foo. //@complete(" //", postfixAppend, postfixCopy, postfixFor, postfixLast, postfixPrint, postfixRange, postfixReverse, postfixSort, postfixVar)
 
 		foo = nil
 }
+
+func _() error {
+	/* if! */ //@item(postfixIf, "if!", "if condition", "snippet")
+	/* wrap! */ //@item(postfixWrap, "wrap!", "wrap and return error", "snippet")
+
+	var ok bool
+	ok.if //@rank(" //", postfixIf)
+
+	var err error
+	err.wrap //@rank(" //", postfixWrap)
+
+	_ = ok.if //@complete(" //")
+
+	return nil
+}
+
+func _() {
+	var err error
+	err.wrap //@complete(" //")
+}
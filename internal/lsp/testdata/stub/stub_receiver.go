@@ -0,0 +1,17 @@
+package stub
+
+import "io"
+
+// This file tests that the stub methods use the receiver name of the
+// existing methods and follow them.
+
+var _ io.ReadWriteCloser = &receiverName{} //@suggestedfix("&", "refactor.rewrite")
+
+type receiverName struct{}
+
+func (rn *receiverName) Close() error {
+	return nil
+}
+
+// other is declared after the methods of receiverName.
+func other() {}
@@ -32,13 +32,13 @@ type Quux struct { //@symbol("Quux", "Quux", "Struct", "", "main.Quux")
 	X, Y float64 //@mark(qX, "X"), symbol("X", "X", "Field", "Quux", "main.X"), symbol("Y", "Y", "Field", "Quux", "main.Y")
 }
 
-func (f Foo) Baz() string { //@symbol("(Foo).Baz", "Baz", "Method", "", "main.Foo.Baz")
+func (f Foo) Baz() string { //@symbol("Baz", "Baz", "Method", "Foo", "main.Foo.Baz")
 	return f.baz
 }
 
 func _() {}
 
-func (q *Quux) Do() {} //@mark(qDo, "Do"), symbol("(*Quux).Do", "Do", "Method", "", "main.Quux.Do")
+func (q *Quux) Do() {} //@mark(qDo, "Do"), symbol("Do", "Do", "Method", "Quux", "main.Quux.Do")
 
 func main() { //@symbol("main", "main", "Function", "", "main.main")
 
@@ -62,3 +62,25 @@ type WithEmbeddeds interface { //@symbol("WithEmbeddeds", "WithEmbeddeds", "Inte
 func Dunk() int { return 0 } //@symbol("Dunk", "Dunk", "Function", "", "main.Dunk")
 
 func dunk() {} //@symbol("dunk", "dunk", "Function", "", "main.dunk")
+
+type Color int //@symbol("Color", "Color", "Number", "", "main.Color")
+
+func (c Color) String() string { return "" } //@symbol("String", "String", "Method", "Color", "main.Color.String")
+
+const ( //@symbol("const (Red, Blue, Cyan, …)", "const", "Constant", "", "main.const")
+	Red   Color = iota //@symbol("Red", "Red", "Constant", "const (Red, Blue, Cyan, …)", "main.Red")
+	Blue               //@symbol("Blue", "Blue", "Constant", "const (Red, Blue, Cyan, …)", "main.Blue")
+	Cyan               //@symbol("Cyan", "Cyan", "Constant", "const (Red, Blue, Cyan, …)", "main.Cyan")
+	Black              //@symbol("Black", "Black", "Constant", "const (Red, Blue, Cyan, …)", "main.Black")
+)
+
+var ( //@symbol("var (Verbose, Level)", "var", "Variable", "", "main.var")
+	Verbose bool //@symbol("Verbose", "Verbose", "Variable", "var (Verbose, Level)", "main.Verbose")
+	Level   int  //@symbol("Level", "Level", "Variable", "var (Verbose, Level)", "main.Level")
+)
+
+type Options struct { //@symbol("Options", "Options", "Struct", "", "main.Options")
+	Server struct { //@symbol("Server", "Server", "Field", "Options", "main.Options.Server")
+		Host string //@symbol("Host", "Host", "Field", "Options.Server", "main.Options.Server.Host")
+	}
+}
@@ -0,0 +1,11 @@
+package wraperr
+
+import "errors"
+
+func open(name string) (int, error) {
+	err := errors.New(name)
+	if err != nil {
+		return 0, err //@suggestedfix("err", "quickfix")
+	}
+	return 0, nil
+}
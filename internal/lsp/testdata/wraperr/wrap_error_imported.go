@@ -0,0 +1,16 @@
+package wraperr
+
+import (
+	"errors"
+	format "fmt"
+)
+
+var _ = format.Sprint
+
+func parse() error {
+	err := errors.New("parse")
+	f := func() error {
+		return err //@suggestedfix("err", "quickfix")
+	}
+	return f()
+}
@@ -66,6 +66,7 @@ type FuzzyCompletions map[span.Span][]Completion
 type CaseSensitiveCompletions map[span.Span][]Completion
 type RankCompletions map[span.Span][]Completion
 type FoldingRanges []span.Span
+type InlayHints []span.Span
 type Formats []span.Span
 type Imports []span.Span
 type SemanticTokens []span.Span
@@ -102,6 +103,7 @@ type Data struct {
 	CaseSensitiveCompletions CaseSensitiveCompletions
 	RankCompletions          RankCompletions
 	FoldingRanges            FoldingRanges
+	InlayHints               InlayHints
 	Formats                  Formats
 	Imports                  Imports
 	SemanticTokens           SemanticTokens
@@ -147,6 +149,7 @@ type Tests interface {
 	CaseSensitiveCompletion(*testing.T, span.Span, Completion, CompletionItems)
 	RankCompletion(*testing.T, span.Span, Completion, CompletionItems)
 	FoldingRanges(*testing.T, span.Span)
+	InlayHints(*testing.T, span.Span)
 	Format(*testing.T, span.Span)
 	Import(*testing.T, span.Span)
 	SemanticTokens(*testing.T, span.Span)
@@ -246,6 +249,7 @@ func DefaultOptions(o *source.Options) {
 			protocol.QuickFix:              true,
 			protocol.RefactorRewrite:       true,
 			protocol.RefactorExtract:       true,
+			protocol.RefactorInline:        true,
 			protocol.SourceFixAll:          true,
 		},
 		source.Mod: {
@@ -262,6 +266,12 @@ func DefaultOptions(o *source.Options) {
 	o.HierarchicalDocumentSymbolSupport = true
 	o.ExperimentalWorkspaceModule = true
 	o.SemanticTokens = true
+	o.Hints = make(map[string]bool)
+	for name := range source.AllInlayHints {
+		o.Hints[name] = true
+	}
+	// The suggestedfix markers on struct types expect a single code action.
+	o.StructTags = nil
 }
 
 func RunTests(t *testing.T, dataDir string, includeMultiModule bool, f func(*testing.T, *Data)) {
@@ -457,6 +467,7 @@ func load(t testing.TB, mode string, dir string) *Data {
 		"rank":            datum.collectCompletions(CompletionRank),
 		"snippet":         datum.collectCompletionSnippets,
 		"fold":            datum.collectFoldingRanges,
+		"inlayHint":       datum.collectInlayHints,
 		"format":          datum.collectFormats,
 		"import":          datum.collectImports,
 		"semantic":        datum.collectSemanticTokens,
@@ -481,12 +492,20 @@ func load(t testing.TB, mode string, dir string) *Data {
 	}); err != nil {
 		t.Fatal(err)
 	}
-	for _, symbols := range datum.Symbols {
+	// The children of a symbol are those whose parent is the path of its
+	// name, such as "T" for the fields of type T, or "T.F" for the fields of
+	// its field F.
+	var addChildren func(symbols []protocol.DocumentSymbol, path string)
+	addChildren = func(symbols []protocol.DocumentSymbol, path string) {
 		for i := range symbols {
-			children := datum.symbolsChildren[symbols[i].Name]
-			symbols[i].Children = children
+			name := path + symbols[i].Name
+			symbols[i].Children = datum.symbolsChildren[name]
+			addChildren(symbols[i].Children, name+".")
 		}
 	}
+	for _, symbols := range datum.Symbols {
+		addChildren(symbols, "")
+	}
 	// Collect names for the entries that require golden files.
 	if err := datum.Exported.Expect(map[string]interface{}{
 		"godef":                        datum.collectDefinitionNames,
@@ -664,6 +683,16 @@ func Run(t *testing.T, tests Tests, data *Data) {
 		}
 	})
 
+	t.Run("InlayHints", func(t *testing.T) {
+		t.Helper()
+		for _, spn := range data.InlayHints {
+			t.Run(uriName(spn.URI()), func(t *testing.T) {
+				t.Helper()
+				tests.InlayHints(t, spn)
+			})
+		}
+	})
+
 	t.Run("Format", func(t *testing.T) {
 		t.Helper()
 		for _, spn := range data.Formats {
@@ -961,6 +990,7 @@ func checkData(t *testing.T, data *Data) {
 	fmt.Fprintf(buf, "CaseSensitiveCompletionsCount = %v\n", countCompletions(data.CaseSensitiveCompletions))
 	fmt.Fprintf(buf, "DiagnosticsCount = %v\n", diagnosticsCount)
 	fmt.Fprintf(buf, "FoldingRangesCount = %v\n", len(data.FoldingRanges))
+	fmt.Fprintf(buf, "InlayHintsCount = %v\n", len(data.InlayHints))
 	fmt.Fprintf(buf, "FormatCount = %v\n", len(data.Formats))
 	fmt.Fprintf(buf, "ImportCount = %v\n", len(data.Imports))
 	fmt.Fprintf(buf, "SemanticTokenCount = %v\n", len(data.SemanticTokens))
@@ -1166,6 +1196,10 @@ func (data *Data) collectFoldingRanges(spn span.Span) {
 	data.FoldingRanges = append(data.FoldingRanges, spn)
 }
 
+func (data *Data) collectInlayHints(spn span.Span) {
+	data.InlayHints = append(data.InlayHints, spn)
+}
+
 func (data *Data) collectFormats(spn span.Span) {
 	data.Formats = append(data.Formats, spn)
 }
@@ -47,6 +47,10 @@ const (
 	// FromInitialWorkspaceLoad refers to the loading of all packages in the
 	// workspace when the view is first created.
 	FromInitialWorkspaceLoad
+
+	// FromToolOverlays refers to the overlays of files not open in the
+	// editor set or cleared by the set_overlays and clear_overlays commands.
+	FromToolOverlays
 )
 
 func (m ModificationSource) String() string {
@@ -65,6 +69,8 @@ func (m ModificationSource) String() string {
 		return "regenerate cgo"
 	case FromInitialWorkspaceLoad:
 		return "initial workspace load"
+	case FromToolOverlays:
+		return "file overlays"
 	default:
 		return "unknown file modification"
 	}
@@ -91,6 +97,8 @@ func (s *Server) didOpen(ctx context.Context, params *protocol.DidOpenTextDocume
 			return err
 		}
 	}
+	// The content in the editor replaces any overlay of a tool.
+	s.forgetToolOverlay(uri)
 	return s.didModifyFiles(ctx, []source.FileModification{{
 		URI:        uri,
 		Action:     source.Open,
@@ -222,7 +230,7 @@ func (s *Server) didModifyFiles(ctx context.Context, modifications []source.File
 	defer s.fileChangeMu.Unlock()
 	if !onDisk || delay == 0 {
 		// No delay: process the modifications immediately.
-		return s.processModifications(ctx, modifications, onDisk, diagnoseDone)
+		return s.processModifications(ctx, modifications, cause, diagnoseDone)
 	}
 	// Debounce and batch up pending modifications from watched files.
 	pending := &pendingModificationSet{
@@ -259,7 +267,7 @@ func (s *Server) didModifyFiles(ctx context.Context, modifications []source.File
 		}
 
 		allDone := make(chan struct{})
-		if err := s.processModifications(ctx, allChanges, onDisk, allDone); err != nil {
+		if err := s.processModifications(ctx, allChanges, cause, allDone); err != nil {
 			event.Error(ctx, "processing delayed file changes", err)
 		}
 		s.pendingOnDiskChanges = nil
@@ -275,7 +283,7 @@ func (s *Server) didModifyFiles(ctx context.Context, modifications []source.File
 // processModifications update server state to reflect file changes, and
 // triggers diagnostics to run asynchronously. The diagnoseDone channel will be
 // closed once diagnostics complete.
-func (s *Server) processModifications(ctx context.Context, modifications []source.FileModification, onDisk bool, diagnoseDone chan struct{}) error {
+func (s *Server) processModifications(ctx context.Context, modifications []source.FileModification, cause ModificationSource, diagnoseDone chan struct{}) error {
 	s.stateMu.Lock()
 	if s.state >= serverShutDown {
 		// This state check does not prevent races below, and exists only to
@@ -297,7 +305,7 @@ func (s *Server) processModifications(ctx context.Context, modifications []sourc
 	}
 
 	go func() {
-		s.diagnoseSnapshots(snapshots, onDisk)
+		s.diagnoseSnapshots(snapshots, cause)
 		for _, release := range releases {
 			release()
 		}
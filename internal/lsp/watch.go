@@ -0,0 +1,57 @@
+// Copyright 2022 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package lsp
+
+import (
+	"context"
+	"time"
+
+	"github.com/iansmith/golang-x-tools/internal/event"
+	"github.com/iansmith/golang-x-tools/internal/lsp/filewatcher"
+	"github.com/iansmith/golang-x-tools/internal/lsp/protocol"
+	"github.com/iansmith/golang-x-tools/internal/xcontext"
+)
+
+// fileWatcherDelay is the delay within which the changes reported by the
+// native file watcher are coalesced.
+const fileWatcherDelay = 100 * time.Millisecond
+
+// updateFileWatcher watches the directories of the session with a native
+// file watcher, for clients that cannot watch files themselves. The changes
+// are handled as if the client had sent them.
+func (s *Server) updateFileWatcher(ctx context.Context) error {
+	s.fileWatcherMu.Lock()
+	defer s.fileWatcherMu.Unlock()
+	if s.fileWatcher == nil {
+		ctx := xcontext.Detach(ctx)
+		w, err := filewatcher.New(fileWatcherDelay, s.session.ShouldWatch, func(changes []protocol.FileEvent) {
+			if err := s.didChangeWatchedFiles(ctx, &protocol.DidChangeWatchedFilesParams{Changes: changes}); err != nil {
+				event.Error(ctx, "processing watched file changes", err)
+			}
+		})
+		if err != nil {
+			return err
+		}
+		s.fileWatcher = w
+	}
+	var roots []string
+	for _, dir := range s.session.FileWatchingDirs(ctx) {
+		roots = append(roots, dir.Filename())
+	}
+	return s.fileWatcher.Watch(roots)
+}
+
+// closeFileWatcher stops the native file watcher, if any.
+func (s *Server) closeFileWatcher(ctx context.Context) {
+	s.fileWatcherMu.Lock()
+	defer s.fileWatcherMu.Unlock()
+	if s.fileWatcher == nil {
+		return
+	}
+	if err := s.fileWatcher.Close(); err != nil {
+		event.Error(ctx, "closing the file watcher", err)
+	}
+	s.fileWatcher = nil
+}
@@ -0,0 +1,49 @@
+// Copyright 2022 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package work
+
+import (
+	"context"
+
+	"golang.org/x/mod/modfile"
+	"github.com/iansmith/golang-x-tools/internal/lsp/command"
+	"github.com/iansmith/golang-x-tools/internal/lsp/protocol"
+	"github.com/iansmith/golang-x-tools/internal/lsp/source"
+)
+
+// LensFuncs returns the supported lensFuncs for go.work files.
+func LensFuncs() map[command.Command]source.LensFunc {
+	return map[command.Command]source.LensFunc{
+		command.WorkSync: syncLens,
+	}
+}
+
+func syncLens(ctx context.Context, snapshot source.Snapshot, fh source.FileHandle) ([]protocol.CodeLens, error) {
+	pw, err := snapshot.ParseWork(ctx, fh)
+	if err != nil || pw.File == nil {
+		return nil, err
+	}
+	if len(pw.File.Use) == 0 {
+		// Nothing to sync.
+		return nil, nil
+	}
+	// Show the lens on the go directive, or on the first use directive if
+	// there is no go directive.
+	var syntax *modfile.Line
+	if pw.File.Go != nil {
+		syntax = pw.File.Go.Syntax
+	} else {
+		syntax = pw.File.Use[0].Syntax
+	}
+	rng, err := source.LineToRange(pw.Mapper, fh.URI(), syntax.Start, syntax.End)
+	if err != nil {
+		return nil, err
+	}
+	cmd, err := command.NewWorkSyncCommand("Run go work sync", command.URIArg{URI: protocol.URIFromSpanURI(fh.URI())})
+	if err != nil {
+		return nil, err
+	}
+	return []protocol.CodeLens{{Range: rng, Command: cmd}}, nil
+}
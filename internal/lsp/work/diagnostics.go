@@ -9,6 +9,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 
 	"golang.org/x/mod/modfile"
 	"github.com/iansmith/golang-x-tools/internal/event"
@@ -69,18 +70,132 @@ func DiagnosticsForWork(ctx context.Context, snapshot source.Snapshot, fh source
 			return nil, err
 		}
 		if _, err := modfh.Read(); err != nil && os.IsNotExist(err) {
+			path := use.Path
+			edits, err := editWorkFile(snapshot, pw, func(f *modfile.WorkFile) error {
+				return f.DropUse(path)
+			})
+			if err != nil {
+				return nil, err
+			}
 			diagnostics = append(diagnostics, &source.Diagnostic{
 				URI:      fh.URI(),
 				Range:    rng,
 				Severity: protocol.SeverityError,
-				Source:   source.UnknownError, // Do we need a new source for this?
+				Source:   source.WorkFileError,
 				Message:  fmt.Sprintf("directory %v does not contain a module", use.Path),
+				SuggestedFixes: []source.SuggestedFix{{
+					Title:      fmt.Sprintf("Remove use of %s", use.Path),
+					Edits:      edits,
+					ActionKind: protocol.QuickFix,
+				}},
 			})
 		}
 	}
 	return diagnostics, nil
 }
 
+// OrphanedFileDiagnostic returns a diagnostic for a Go file that belongs to
+// no workspace package because its module is missing from the go.work
+// file, along with a fix that adds the module. It returns nil if the file
+// has no enclosing module in the workspace folder or its module is already
+// used.
+func OrphanedFileDiagnostic(ctx context.Context, snapshot source.Snapshot, fh source.FileHandle, rng protocol.Range) (*source.Diagnostic, error) {
+	uri := snapshot.WorkFile()
+	if uri == "" {
+		return nil, nil
+	}
+	// Only consider modules within the workspace folder, so that files in
+	// the module cache are not reported.
+	folder := snapshot.View().Folder().Filename()
+	if !source.InDir(folder, fh.URI().Filename()) {
+		return nil, nil
+	}
+	modDir := enclosingModuleDir(filepath.Dir(fh.URI().Filename()), folder)
+	if modDir == "" {
+		return nil, nil
+	}
+	workFH, err := snapshot.GetFile(ctx, uri)
+	if err != nil {
+		return nil, err
+	}
+	pw, err := snapshot.ParseWork(ctx, workFH)
+	if err != nil {
+		return nil, err
+	}
+	for _, use := range pw.File.Use {
+		if filepath.Dir(modFileURI(pw, use).Filename()) == modDir {
+			return nil, nil
+		}
+	}
+	// Prefer a path relative to the go.work file, as go work use does.
+	dir := modDir
+	if rel, err := filepath.Rel(filepath.Dir(uri.Filename()), modDir); err == nil {
+		dir = filepath.ToSlash(rel)
+		if dir != "." && !strings.HasPrefix(dir, "../") {
+			dir = "./" + dir
+		}
+	}
+	edits, err := editWorkFile(snapshot, pw, func(f *modfile.WorkFile) error {
+		return f.AddUse(dir, "")
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &source.Diagnostic{
+		URI:      fh.URI(),
+		Range:    rng,
+		Severity: protocol.SeverityWarning,
+		Source:   source.WorkFileError,
+		Message:  fmt.Sprintf("This file is within module %s, which is not included in your go.work file.", dir),
+		SuggestedFixes: []source.SuggestedFix{{
+			Title:      fmt.Sprintf("Add %s to go.work", dir),
+			Edits:      edits,
+			ActionKind: protocol.QuickFix,
+		}},
+	}, nil
+}
+
+// enclosingModuleDir returns the directory of the nearest go.mod file
+// enclosing dir and within root, or "" if there is none.
+func enclosingModuleDir(dir, root string) string {
+	for source.InDir(root, dir) {
+		if _, err := os.Stat(filepath.Join(dir, "go.mod")); err == nil {
+			return dir
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			break
+		}
+		dir = parent
+	}
+	return ""
+}
+
+// editWorkFile returns the edits to the go.work file of pw that result from
+// applying edit to a copy of its contents.
+func editWorkFile(snapshot source.Snapshot, pw *source.ParsedWorkFile, edit func(*modfile.WorkFile) error) (map[span.URI][]protocol.TextEdit, error) {
+	// Reparse the file, as the parsed go.work file is shared by the
+	// snapshot and must not be modified.
+	f, err := modfile.ParseWork(pw.URI.Filename(), pw.Mapper.Content, nil)
+	if err != nil {
+		return nil, err
+	}
+	if err := edit(f); err != nil {
+		return nil, err
+	}
+	f.Cleanup()
+	formatted := modfile.Format(f.Syntax)
+	diff, err := snapshot.View().Options().ComputeEdits(pw.URI, string(pw.Mapper.Content), string(formatted))
+	if err != nil {
+		return nil, err
+	}
+	edits, err := source.ToProtocolEdits(pw.Mapper, diff)
+	if err != nil {
+		return nil, err
+	}
+	return map[span.URI][]protocol.TextEdit{pw.URI: edits}, nil
+}
+
 func modFileURI(pw *source.ParsedWorkFile, use *modfile.Use) span.URI {
 	workdir := filepath.Dir(pw.URI.Filename())
 
@@ -9,11 +9,14 @@ import (
 	"context"
 	"fmt"
 	"go/token"
+	"path/filepath"
+	"strings"
 
 	"golang.org/x/mod/modfile"
 	"github.com/iansmith/golang-x-tools/internal/event"
 	"github.com/iansmith/golang-x-tools/internal/lsp/protocol"
 	"github.com/iansmith/golang-x-tools/internal/lsp/source"
+	"github.com/iansmith/golang-x-tools/internal/span"
 )
 
 func Hover(ctx context.Context, snapshot source.Snapshot, fh source.FileHandle, position protocol.Position) (*protocol.Hover, error) {
@@ -39,9 +42,10 @@ func Hover(ctx context.Context, snapshot source.Snapshot, fh source.FileHandle,
 	// the position of the use statement's directory path.
 	use, pathStart, pathEnd := usePath(pw, pos)
 
-	// The cursor position is not on a use statement.
+	// The cursor position is not on a use statement, but may be on a
+	// replace statement.
 	if use == nil {
-		return nil, nil
+		return replaceHover(ctx, snapshot, pw, pos)
 	}
 
 	// Get the mod file denoted by the use.
@@ -88,3 +92,60 @@ func usePath(pw *source.ParsedWorkFile, pos token.Pos) (use *modfile.Use, pathSt
 	}
 	return nil, 0, 0
 }
+
+// replaceHover describes the replace statement of pw at pos, if any.
+func replaceHover(ctx context.Context, snapshot source.Snapshot, pw *source.ParsedWorkFile, pos token.Pos) (*protocol.Hover, error) {
+	var rep *modfile.Replace
+	for _, r := range pw.File.Replace {
+		if token.Pos(r.Syntax.Start.Byte) <= pos && pos <= token.Pos(r.Syntax.End.Byte) {
+			rep = r
+			break
+		}
+	}
+	if rep == nil {
+		return nil, nil
+	}
+	rng, err := source.LineToRange(pw.Mapper, pw.URI, rep.Syntax.Start, rep.Syntax.End)
+	if err != nil {
+		return nil, err
+	}
+
+	options := snapshot.View().Options()
+	sep := "\n"
+	if options.PreferredContentFormat == protocol.Markdown {
+		sep = "\n\n"
+	}
+	old := rep.Old.Path
+	if rep.Old.Version != "" {
+		old = rep.Old.String()
+	}
+	var b strings.Builder
+	if rep.New.Version != "" {
+		fmt.Fprintf(&b, "`%s` is replaced by `%s` in all workspace modules.", old, rep.New)
+	} else {
+		fmt.Fprintf(&b, "`%s` is replaced by the directory `%s` in all workspace modules.", old, rep.New.Path)
+		// Report the module found in the replacement directory.
+		dir := filepath.FromSlash(rep.New.Path)
+		if !filepath.IsAbs(dir) {
+			dir = filepath.Join(filepath.Dir(pw.URI.Filename()), dir)
+		}
+		modfh, err := snapshot.GetFile(ctx, span.URIFromPath(filepath.Join(dir, "go.mod")))
+		if err != nil {
+			return nil, err
+		}
+		if _, err := modfh.Read(); err != nil {
+			fmt.Fprintf(&b, "%sThe directory %s does not contain a module.", sep, rep.New.Path)
+		} else if pm, err := snapshot.ParseMod(ctx, modfh); err == nil && pm.File.Module != nil {
+			fmt.Fprintf(&b, "%sThe directory %s contains module `%s`.", sep, rep.New.Path, pm.File.Module.Mod.Path)
+		}
+	}
+	b.WriteString(sep)
+	b.WriteString("It takes precedence over replace directives in the go.mod files of the workspace modules.")
+	return &protocol.Hover{
+		Contents: protocol.MarkupContent{
+			Kind:  options.PreferredContentFormat,
+			Value: b.String(),
+		},
+		Range: rng,
+	}, nil
+}
@@ -14,6 +14,7 @@ import (
 	"flag"
 	"fmt"
 	"reflect"
+	"runtime/debug"
 	"sync"
 	"sync/atomic"
 
@@ -36,6 +37,11 @@ type Store struct {
 
 	// generations is the set of generations live in this store.
 	generations map[*Generation]struct{}
+
+	// getsMu guards gets, the outcomes of the calls to Get on the handles of
+	// each type of key.
+	getsMu sync.Mutex
+	gets   map[reflect.Type]GetStats
 }
 
 // Generation creates a new Generation associated with s. Destroy must be
@@ -157,6 +163,9 @@ type Handle struct {
 	function Function
 	// value is set in completed state.
 	value interface{}
+	// panicErr is set when the last run of function panicked, until a run
+	// completes.
+	panicErr *PanicError
 	// cleanup, if non-nil, is used to perform any necessary clean-up on values
 	// produced by function.
 	cleanup func(interface{})
@@ -214,6 +223,79 @@ func (s *Store) Stats() map[reflect.Type]int {
 	return result
 }
 
+// HandleStats summarizes the handles of one type of key in a store.
+type HandleStats struct {
+	Handles int // the number of handles
+	Shared  int // the number of handles valid in generations of several groups
+}
+
+// SharedStats returns the HandleStats of each type of key in the store. group
+// reports the group of a generation, such as the session that created it: a
+// handle is shared if it is valid in generations of more than one group.
+func (s *Store) SharedStats(group func(*Generation) string) map[reflect.Type]HandleStats {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	result := map[reflect.Type]HandleStats{}
+	for k, h := range s.handles {
+		stats := result[reflect.TypeOf(k)]
+		stats.Handles++
+		h.mu.Lock()
+		var first *string
+		for g := range h.generations {
+			if name := group(g); first == nil {
+				first = &name
+			} else if name != *first {
+				stats.Shared++
+				break
+			}
+		}
+		h.mu.Unlock()
+		result[reflect.TypeOf(k)] = stats
+	}
+	return result
+}
+
+// GetStats counts the calls to Get on the handles of one type of key.
+type GetStats struct {
+	Hits   int64 // the calls that found the value computed or being computed
+	Misses int64 // the calls that started the computation of the value
+}
+
+// GetStats returns the GetStats of each type of key in the store, since its
+// creation.
+func (s *Store) GetStats() map[reflect.Type]GetStats {
+	s.getsMu.Lock()
+	defer s.getsMu.Unlock()
+
+	result := make(map[reflect.Type]GetStats, len(s.gets))
+	for typ, stats := range s.gets {
+		result[typ] = stats
+	}
+	return result
+}
+
+func (s *Store) recordGet(key interface{}, hit bool) {
+	typ := reflect.TypeOf(key)
+	s.getsMu.Lock()
+	defer s.getsMu.Unlock()
+	if s.gets == nil {
+		s.gets = make(map[reflect.Type]GetStats)
+	}
+	stats := s.gets[typ]
+	if hit {
+		stats.Hits++
+	} else {
+		stats.Misses++
+	}
+	s.gets[typ] = stats
+}
+
+// Name returns the name of g, as passed to Store.Generation.
+func (g *Generation) Name() string {
+	return g.name
+}
+
 // DebugOnlyIterate iterates through all live cache entries and calls f on them.
 // It should only be used for debugging purposes.
 func (s *Store) DebugOnlyIterate(f func(k, v interface{})) {
@@ -288,11 +370,14 @@ func (h *Handle) Get(ctx context.Context, g *Generation, arg Arg) (interface{},
 	}
 	switch h.state {
 	case stateIdle:
+		g.store.recordGet(h.key, false)
 		return h.run(ctx, g, arg)
 	case stateRunning:
+		g.store.recordGet(h.key, true)
 		return h.wait(ctx)
 	case stateCompleted:
 		defer h.mu.Unlock()
+		g.store.recordGet(h.key, true)
 		return h.value, nil
 	case stateDestroyed:
 		h.mu.Unlock()
@@ -323,7 +408,25 @@ func (h *Handle) run(ctx context.Context, g *Generation, arg Arg) (interface{},
 		if childCtx.Err() != nil {
 			return
 		}
-		v := function(childCtx, arg)
+		v, panicErr := runProtected(childCtx, h.key, function, arg)
+		if panicErr != nil {
+			h.mu.Lock()
+			defer h.mu.Unlock()
+			if h.state != stateRunning {
+				return
+			}
+			// Fail the current Gets, and let later ones run the function again.
+			// The failed Gets leave without counting themselves out, so the
+			// next run starts with no waiters.
+			h.panicErr = panicErr
+			h.cancel()
+			close(h.done)
+			h.state = stateIdle
+			h.done = nil
+			h.cancel = nil
+			h.waiters = 0
+			return
+		}
 		if childCtx.Err() != nil {
 			// It's possible that v was computed despite the context cancellation. In
 			// this case we should ensure that it is cleaned up.
@@ -349,6 +452,7 @@ func (h *Handle) run(ctx context.Context, g *Generation, arg Arg) (interface{},
 		// At this point v will be cleaned up whenever h is destroyed.
 		h.value = v
 		h.function = nil
+		h.panicErr = nil
 		h.state = stateCompleted
 		close(h.done)
 	}()
@@ -356,6 +460,34 @@ func (h *Handle) run(ctx context.Context, g *Generation, arg Arg) (interface{},
 	return h.wait(ctx)
 }
 
+// A PanicError is returned by Get when the function of a handle panicked.
+// The handle is left idle, so that a later Get runs the function again.
+type PanicError struct {
+	Key   interface{} // the key of the handle
+	Value interface{} // the value passed to panic
+	Stack []byte      // the stack of the goroutine that panicked
+}
+
+func (e *PanicError) Error() string {
+	return fmt.Sprintf("panic computing a value for a key of type %T: %v", e.Key, e.Value)
+}
+
+// runProtected calls function, and recovers from its panic. A function that
+// panics with the PanicError of another handle, such as one it depends on,
+// fails with that error.
+func runProtected(ctx context.Context, key interface{}, function Function, arg Arg) (v interface{}, err *PanicError) {
+	defer func() {
+		if r := recover(); r != nil {
+			if perr, ok := r.(*PanicError); ok {
+				err = perr
+				return
+			}
+			err = &PanicError{Key: key, Value: r, Stack: debug.Stack()}
+		}
+	}()
+	return function(ctx, arg), nil
+}
+
 // wait waits for the value to be computed, or ctx to be cancelled. h.mu must be locked.
 func (h *Handle) wait(ctx context.Context) (interface{}, error) {
 	h.waiters++
@@ -369,6 +501,9 @@ func (h *Handle) wait(ctx context.Context) (interface{}, error) {
 		if h.state == stateCompleted {
 			return h.value, nil
 		}
+		if h.panicErr != nil {
+			return nil, h.panicErr
+		}
 		return nil, nil
 	case <-ctx.Done():
 		h.mu.Lock()
@@ -6,8 +6,11 @@ package memoize_test
 
 import (
 	"context"
+	"errors"
+	"reflect"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/iansmith/golang-x-tools/internal/memoize"
 )
@@ -70,6 +73,109 @@ func TestGenerations(t *testing.T) {
 	expectGet(t, h3, g3, "new res")
 }
 
+func TestPanic(t *testing.T) {
+	s := &memoize.Store{}
+	g := s.Generation("x")
+	runs := 0
+	h := g.Bind("key", func(context.Context, memoize.Arg) interface{} {
+		runs++
+		if runs == 1 {
+			panic("boom")
+		}
+		return "res"
+	}, nil)
+	_, err := h.Get(context.Background(), g, nil)
+	var perr *memoize.PanicError
+	if !errors.As(err, &perr) || perr.Value != "boom" || perr.Key != "key" {
+		t.Fatalf("Get() of a panicking function returned %v, want a PanicError", err)
+	}
+	// The next Get runs the function again.
+	expectGet(t, h, g, "res")
+	if runs != 2 {
+		t.Errorf("the function ran %d times, want 2", runs)
+	}
+}
+
+func TestPanicThenCancel(t *testing.T) {
+	s := &memoize.Store{}
+	g := s.Generation("x")
+	runs := 0
+	cancelled := make(chan struct{})
+	h := g.Bind("key", func(ctx context.Context, _ memoize.Arg) interface{} {
+		runs++
+		if runs == 1 {
+			panic("boom")
+		}
+		<-ctx.Done()
+		close(cancelled)
+		return nil
+	}, nil)
+	if _, err := h.Get(context.Background(), g, nil); err == nil {
+		t.Fatal("Get() of a panicking function succeeded")
+	}
+	// Once its only caller cancels, the second run is cancelled.
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		cancel()
+	}()
+	if _, err := h.Get(ctx, g, nil); err != context.Canceled {
+		t.Errorf("Get() with a cancelled context returned %v, want %v", err, context.Canceled)
+	}
+	select {
+	case <-cancelled:
+	case <-time.After(5 * time.Second):
+		t.Fatal("the context of the function was not cancelled")
+	}
+}
+
+func TestGetStats(t *testing.T) {
+	s := &memoize.Store{}
+	g := s.Generation("x")
+	f := func(context.Context, memoize.Arg) interface{} { return "res" }
+	h := g.Bind("key", f, nil)
+	expectGet(t, h, g, "res")
+	expectGet(t, h, g, "res")
+	expectGet(t, h, g, "res")
+	got := s.GetStats()[reflect.TypeOf("")]
+	if want := (memoize.GetStats{Hits: 2, Misses: 1}); got != want {
+		t.Errorf("GetStats() = %+v, want %+v", got, want)
+	}
+}
+
+func TestSharedStats(t *testing.T) {
+	s := &memoize.Store{}
+	a1, a2, b1 := s.Generation("a/1"), s.Generation("a/2"), s.Generation("b/1")
+	f := func(context.Context, memoize.Arg) interface{} { return "res" }
+	a1.Bind("a only", f, nil)
+	a2.Bind("a only", f, nil)
+	a1.Bind("shared", f, nil)
+	b1.Bind("shared", f, nil)
+	b1.Bind(1, f, nil)
+
+	group := func(g *memoize.Generation) string {
+		return strings.SplitN(g.Name(), "/", 2)[0]
+	}
+	got := s.SharedStats(group)
+	want := map[reflect.Type]memoize.HandleStats{
+		reflect.TypeOf(""): {Handles: 2, Shared: 1},
+		reflect.TypeOf(0):  {Handles: 1, Shared: 0},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("SharedStats() = %v, want %v", got, want)
+	}
+
+	// Once b1 is destroyed, nothing is shared anymore.
+	b1.Destroy("TestSharedStats")
+	got = s.SharedStats(group)
+	want = map[reflect.Type]memoize.HandleStats{
+		reflect.TypeOf(""): {Handles: 2, Shared: 0},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("after Destroy, SharedStats() = %v, want %v", got, want)
+	}
+}
+
 func TestCleanup(t *testing.T) {
 	s := &memoize.Store{}
 	g1 := s.Generation("g1")
@@ -0,0 +1,233 @@
+// Copyright 2022 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package embedded runs gopls, the Go language server, in the process of a
+// Go program, such as an IDE shell or a batch tool, which issues LSP
+// requests to it programmatically.
+//
+// The requests and their results are the JSON values of the Language Server
+// Protocol: their parameters are of any type that encodes to the expected
+// JSON, and their results decode into any suitable value. For instance:
+//
+//	server := embedded.New(ctx, embedded.Config{})
+//	defer server.Close()
+//	if err := server.Initialize(ctx, dir); err != nil {
+//		...
+//	}
+//	var hover struct {
+//		Contents struct{ Value string }
+//	}
+//	err := server.Call(ctx, "textDocument/hover", map[string]interface{}{
+//		"textDocument": map[string]string{"uri": "file:///path/to/file.go"},
+//		"position":     map[string]int{"line": 10, "character": 6},
+//	}, &hover)
+//
+// The notifications that the server sends, such as
+// textDocument/publishDiagnostics, are delivered on the channel of
+// Notifications.
+package embedded
+
+import (
+	"context"
+	"encoding/json"
+	"path/filepath"
+	"sync"
+
+	"github.com/iansmith/golang-x-tools/internal/jsonrpc2"
+	"github.com/iansmith/golang-x-tools/internal/lsp/cache"
+	"github.com/iansmith/golang-x-tools/internal/lsp/debug"
+	"github.com/iansmith/golang-x-tools/internal/lsp/lsprpc"
+	"github.com/iansmith/golang-x-tools/internal/lsp/protocol"
+	"github.com/iansmith/golang-x-tools/internal/lsp/source"
+)
+
+// Options are the options of the server, before the settings of the client
+// apply to them.
+type Options = source.Options
+
+// Config configures an embedded server.
+type Config struct {
+	// Options, if set, changes the default options of the server, as the
+	// gopls command does to add the staticcheck analyzers or the gofumpt
+	// formatter.
+	Options func(*Options)
+
+	// Settings are the "gopls" settings of the client, such as
+	// {"staticcheck": true}. They are sent with the initialize request of
+	// Initialize and in reply to each workspace/configuration request.
+	Settings map[string]interface{}
+
+	// Handler, if set, replies to the requests that the server sends to the
+	// client, such as workspace/applyEdit, except workspace/configuration.
+	// If it is nil, these requests get a null result.
+	Handler func(ctx context.Context, method string, params json.RawMessage) (interface{}, error)
+}
+
+// A Notification is a notification that the server sends to the client.
+type Notification struct {
+	Method string
+	Params json.RawMessage
+}
+
+// A Server is a gopls server running in this process.
+type Server struct {
+	config Config
+	conn   jsonrpc2.Conn
+	out    chan Notification
+
+	mu       sync.Mutex
+	cond     *sync.Cond
+	pending  []Notification // notifications not yet delivered on out
+	closed   bool
+	shutDown bool
+}
+
+// New starts a server with the given configuration. The server runs until
+// Close is called.
+func New(ctx context.Context, config Config) *Server {
+	if debug.GetInstance(ctx) == nil {
+		ctx = debug.WithInstance(ctx, "", "")
+	}
+	s := &Server{
+		config: config,
+		conn:   lsprpc.NewStreamServer(cache.New(config.Options), false).ServePipe(ctx),
+		out:    make(chan Notification),
+	}
+	s.cond = sync.NewCond(&s.mu)
+	s.conn.Go(ctx, protocol.Handlers(s.handle))
+	go s.deliver()
+	return s
+}
+
+// Initialize sends the initialize request to the server, for a workspace of
+// the given folders, followed by the initialized notification.
+func (s *Server) Initialize(ctx context.Context, folders ...string) error {
+	params := &protocol.ParamInitialize{}
+	params.ClientInfo.Name = "embedded"
+	for _, folder := range folders {
+		folder, err := filepath.Abs(folder)
+		if err != nil {
+			return err
+		}
+		params.WorkspaceFolders = append(params.WorkspaceFolders, protocol.WorkspaceFolder{
+			URI:  string(protocol.URIFromPath(folder)),
+			Name: filepath.Base(folder),
+		})
+	}
+	if len(params.WorkspaceFolders) > 0 {
+		params.RootURI = protocol.DocumentURI(params.WorkspaceFolders[0].URI)
+	}
+	params.Capabilities.Workspace.Configuration = true
+	params.Capabilities.Workspace.WorkspaceEdit = &protocol.WorkspaceEditClientCapabilities{
+		DocumentChanges: true,
+	}
+	params.InitializationOptions = s.config.Settings
+	if err := s.Call(ctx, "initialize", params, nil); err != nil {
+		return err
+	}
+	return s.Notify(ctx, "initialized", &protocol.InitializedParams{})
+}
+
+// Call sends the request method to the server, and decodes its result into
+// result, unless result is nil.
+func (s *Server) Call(ctx context.Context, method string, params, result interface{}) error {
+	_, err := s.conn.Call(ctx, method, params, result)
+	if err == nil && method == "shutdown" {
+		s.mu.Lock()
+		s.shutDown = true
+		s.mu.Unlock()
+	}
+	return err
+}
+
+// Notify sends the notification method to the server. The exit notification
+// is preceded by a shutdown request if there was none, as the server
+// otherwise exits the process.
+func (s *Server) Notify(ctx context.Context, method string, params interface{}) error {
+	if method == "exit" {
+		s.mu.Lock()
+		shutDown := s.shutDown
+		s.mu.Unlock()
+		if !shutDown {
+			if err := s.Call(ctx, "shutdown", nil, nil); err != nil {
+				return err
+			}
+		}
+	}
+	return s.conn.Notify(ctx, method, params)
+}
+
+// Notifications returns the channel of the notifications that the server
+// sends, in order. Notifications are queued until received, and the channel
+// is closed by Close.
+func (s *Server) Notifications() <-chan Notification {
+	return s.out
+}
+
+// Close shuts the server down and closes its connection.
+func (s *Server) Close() error {
+	s.mu.Lock()
+	shutDown := s.shutDown
+	s.closed = true
+	s.cond.Broadcast()
+	s.mu.Unlock()
+	if !shutDown {
+		s.Call(context.Background(), "shutdown", nil, nil)
+	}
+	err := s.conn.Close()
+	<-s.conn.Done()
+	return err
+}
+
+// handle handles the messages of the server to the client.
+func (s *Server) handle(ctx context.Context, reply jsonrpc2.Replier, req jsonrpc2.Request) error {
+	if _, ok := req.(*jsonrpc2.Call); !ok {
+		s.mu.Lock()
+		s.pending = append(s.pending, Notification{Method: req.Method(), Params: req.Params()})
+		s.cond.Signal()
+		s.mu.Unlock()
+		return reply(ctx, nil, nil)
+	}
+	if req.Method() == "workspace/configuration" {
+		var params protocol.ParamConfiguration
+		if err := json.Unmarshal(req.Params(), &params); err != nil {
+			return reply(ctx, nil, err)
+		}
+		results := make([]interface{}, len(params.Items))
+		for i, item := range params.Items {
+			if item.Section == "gopls" {
+				results[i] = s.config.Settings
+			}
+		}
+		return reply(ctx, results, nil)
+	}
+	if s.config.Handler != nil {
+		result, err := s.config.Handler(ctx, req.Method(), req.Params())
+		return reply(ctx, result, err)
+	}
+	return reply(ctx, nil, nil)
+}
+
+// deliver sends the pending notifications on the out channel, until Close.
+func (s *Server) deliver() {
+	defer close(s.out)
+	for {
+		s.mu.Lock()
+		for len(s.pending) == 0 && !s.closed {
+			s.cond.Wait()
+		}
+		if s.closed {
+			s.mu.Unlock()
+			return
+		}
+		n := s.pending[0]
+		s.pending = s.pending[1:]
+		s.mu.Unlock()
+		select {
+		case s.out <- n:
+		case <-s.conn.Done():
+			return
+		}
+	}
+}
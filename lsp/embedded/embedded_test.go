@@ -0,0 +1,92 @@
+// Copyright 2022 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package embedded_test
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/iansmith/golang-x-tools/internal/span"
+	"github.com/iansmith/golang-x-tools/internal/testenv"
+	"github.com/iansmith/golang-x-tools/lsp/embedded"
+)
+
+func TestServer(t *testing.T) {
+	testenv.NeedsGo1Point(t, 13)
+	dir := t.TempDir()
+	for name, content := range map[string]string{
+		"go.mod":  "module example.com/a\n\ngo 1.12\n",
+		"a.go":    "package a\n\n// F does nothing.\nfunc F() {}\n",
+		"main.go": "package a\n\nfunc g() { F(1) }\n",
+	} {
+		if err := ioutil.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	ctx := context.Background()
+	configured := false
+	server := embedded.New(ctx, embedded.Config{
+		Options: func(o *embedded.Options) {
+			configured = true
+		},
+		Settings: map[string]interface{}{"hoverKind": "SynopsisDocumentation"},
+	})
+	defer server.Close()
+	if err := server.Initialize(ctx, dir); err != nil {
+		t.Fatal(err)
+	}
+	if !configured {
+		t.Error("the Options of the configuration were not applied")
+	}
+
+	uri := string(span.URIFromPath(filepath.Join(dir, "a.go")))
+	var hover struct {
+		Contents struct{ Value string }
+	}
+	if err := server.Call(ctx, "textDocument/hover", map[string]interface{}{
+		"textDocument": map[string]string{"uri": uri},
+		"position":     map[string]int{"line": 3, "character": 5},
+	}, &hover); err != nil {
+		t.Fatal(err)
+	}
+	if want := "func F()"; !strings.Contains(hover.Contents.Value, want) {
+		t.Errorf("hover = %q, want it to contain %q", hover.Contents.Value, want)
+	}
+
+	// The call of F in main.go has too many arguments.
+	for n := range server.Notifications() {
+		if n.Method != "textDocument/publishDiagnostics" {
+			continue
+		}
+		var params struct {
+			URI         string
+			Diagnostics []struct{ Message string }
+		}
+		if err := json.Unmarshal(n.Params, &params); err != nil {
+			t.Fatal(err)
+		}
+		if strings.HasSuffix(params.URI, "/main.go") && len(params.Diagnostics) > 0 {
+			if msg := params.Diagnostics[0].Message; !strings.Contains(msg, "too many arguments") {
+				t.Errorf("diagnostic of main.go = %q, want too many arguments", msg)
+			}
+			break
+		}
+	}
+
+	if err := server.Notify(ctx, "exit", nil); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestMain(m *testing.M) {
+	testenv.ExitIfSmallMachine()
+	os.Exit(m.Run())
+}